@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -26,17 +27,20 @@ import (
 	cliagent "github.com/langoai/lango/internal/cli/agent"
 	clialerts "github.com/langoai/lango/internal/cli/alerts"
 	cliapproval "github.com/langoai/lango/internal/cli/approval"
+	cliaudit "github.com/langoai/lango/internal/cli/audit"
 	clibg "github.com/langoai/lango/internal/cli/bg"
 	"github.com/langoai/lango/internal/cli/chat"
 	"github.com/langoai/lango/internal/cli/prompt"
 	"github.com/langoai/lango/internal/cli/cliboot"
 	"github.com/langoai/lango/internal/cli/cockpit"
 	"github.com/langoai/lango/internal/cli/cockpit/pages"
+	"github.com/langoai/lango/internal/cli/cockpit/remote"
 	cliconfigcmd "github.com/langoai/lango/internal/cli/configcmd"
 	clicontract "github.com/langoai/lango/internal/cli/contract"
 	clicron "github.com/langoai/lango/internal/cli/cron"
 	"github.com/langoai/lango/internal/cli/doctor"
 	clieconomy "github.com/langoai/lango/internal/cli/economy"
+	clievents "github.com/langoai/lango/internal/cli/events"
 	cligraph "github.com/langoai/lango/internal/cli/graph"
 	clilearning "github.com/langoai/lango/internal/cli/learning"
 	clilibrarian "github.com/langoai/lango/internal/cli/librarian"
@@ -50,12 +54,15 @@ import (
 	cliprovenance "github.com/langoai/lango/internal/cli/provenance"
 	clirun "github.com/langoai/lango/internal/cli/run"
 	clisecurity "github.com/langoai/lango/internal/cli/security"
+	clisession "github.com/langoai/lango/internal/cli/session"
+	cliskill "github.com/langoai/lango/internal/cli/skill"
 	"github.com/langoai/lango/internal/cli/settings"
 	cliaccount "github.com/langoai/lango/internal/cli/smartaccount"
 	clistatus "github.com/langoai/lango/internal/cli/status"
 	"github.com/langoai/lango/internal/cli/tui"
 	cliworkflow "github.com/langoai/lango/internal/cli/workflow"
 	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/economy/simchain"
 	"github.com/langoai/lango/internal/logging"
 	"github.com/langoai/lango/internal/sandbox"
 	"github.com/langoai/lango/internal/session"
@@ -158,6 +165,10 @@ func main() {
 	librarianCmd.GroupID = "ai"
 	rootCmd.AddCommand(librarianCmd)
 
+	skillCmd := cliskill.NewSkillCmd(cliboot.Config)
+	skillCmd.GroupID = "ai"
+	rootCmd.AddCommand(skillCmd)
+
 	metricsCmd := climetrics.NewMetricsCmd()
 	metricsCmd.GroupID = "ai"
 	rootCmd.AddCommand(metricsCmd)
@@ -179,6 +190,10 @@ func main() {
 	provenanceCmd.GroupID = "auto"
 	rootCmd.AddCommand(provenanceCmd)
 
+	sessionCmd := clisession.NewSessionCmd(cliboot.BootResult)
+	sessionCmd.GroupID = "auto"
+	rootCmd.AddCommand(sessionCmd)
+
 	bgCmd := clibg.NewBgCmd(func() (*background.Manager, error) {
 		return nil, fmt.Errorf("bg commands require a running server (use 'lango serve' first)")
 	})
@@ -214,10 +229,18 @@ func main() {
 	sandboxCmd.GroupID = "sys"
 	rootCmd.AddCommand(sandboxCmd)
 
+	auditCmd := cliaudit.NewAuditCmd(cliboot.BootResult)
+	auditCmd.GroupID = "sys"
+	rootCmd.AddCommand(auditCmd)
+
 	alertsCmd := clialerts.NewAlertsCmd()
 	alertsCmd.GroupID = "sys"
 	rootCmd.AddCommand(alertsCmd)
 
+	eventsCmd := clievents.NewEventsCmd(cliboot.Config)
+	eventsCmd.GroupID = "sys"
+	rootCmd.AddCommand(eventsCmd)
+
 	// --- Security & System (continued) ---
 	approvalCmd := cliapproval.NewApprovalCmd(cliboot.Config)
 	approvalCmd.GroupID = "sys"
@@ -289,6 +312,7 @@ func runChat() error {
 		TurnRunner: application.TurnRunner,
 		Config:     cfg,
 		SessionKey: sessionKey,
+		MCPManager: application.MCPManager,
 	})
 
 	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
@@ -309,7 +333,13 @@ func runChat() error {
 }
 
 func serveCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		chainMode    string
+		chainRPC     string
+		contractsOut string
+	)
+
+	cmd := &cobra.Command{
 		Use:     "serve",
 		Short:   "Start the gateway server",
 		GroupID: "start",
@@ -337,13 +367,37 @@ func serveCmd() *cobra.Command {
 
 			log.Infow("starting lango", "version", Version, "profile", boot.ProfileName)
 
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var sim *simulatedChain
+			switch chainMode {
+			case "":
+			case "simulated":
+				sim, err = startSimulatedChain(ctx, cfg, chainRPC, contractsOut)
+				if err != nil {
+					return fmt.Errorf("simulated chain: %w", err)
+				}
+				defer sim.Close()
+				log.Infow("simulated chain ready",
+					"rpcUrl", cfg.Payment.Network.RPCURL,
+					"hub", cfg.Economy.Escrow.OnChain.HubAddress,
+					"usdc", cfg.Payment.Network.USDCContract,
+					"attached", sim.chain == nil)
+			default:
+				return fmt.Errorf("unknown --chain %q (supported: simulated)", chainMode)
+			}
+
 			application, err := app.New(boot)
 			if err != nil {
 				return fmt.Errorf("create application: %w", err)
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
+			if sim != nil {
+				if err := sim.FundWallet(ctx, application.WalletProvider); err != nil {
+					log.Warnw("simulated chain: fund wallet", "error", err)
+				}
+			}
 
 			sigChan := make(chan os.Signal, 2)
 			signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -362,6 +416,14 @@ func serveCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&chainMode, "chain", "",
+		`run against a local chain instead of the configured network ("simulated")`)
+	cmd.Flags().StringVar(&chainRPC, "chain-rpc", simchain.DefaultListenAddr,
+		"JSON-RPC address of the simulated chain; attaches if a chain is already serving there")
+	cmd.Flags().StringVar(&contractsOut, "contracts-out", "",
+		"Foundry build output to deploy the simulated chain contracts from (default: built-in artifacts)")
+	return cmd
 }
 
 func watchServeSignals(
@@ -490,6 +552,9 @@ func startupSummary(cfg *config.Config) string {
 	if cfg.Channels.Slack.Enabled {
 		channels = append(channels, "slack")
 	}
+	if cfg.Channels.Email.Enabled {
+		channels = append(channels, "email")
+	}
 
 	channelDetail := "none"
 	if len(channels) > 0 {
@@ -530,6 +595,8 @@ func mcpServerCount(cfg *config.Config) string {
 var withChannels bool
 
 func cockpitCmd() *cobra.Command {
+	var remoteURL, remoteToken, remoteSession string
+
 	cmd := &cobra.Command{
 		Use:     "cockpit",
 		Short:   "Launch multi-panel TUI (same as bare lango)",
@@ -538,15 +605,88 @@ func cockpitCmd() *cobra.Command {
 			if !prompt.IsInteractive() {
 				return fmt.Errorf("cockpit requires an interactive terminal")
 			}
+			if remoteURL != "" {
+				if withChannels {
+					return fmt.Errorf("--with-channels cannot be combined with --remote")
+				}
+				return runRemoteCockpit(remoteURL, remoteToken, remoteSession)
+			}
 			return runCockpit()
 		},
 	}
 	cmd.Flags().BoolVar(&withChannels, "with-channels", false,
 		"Start live channel adapters (Telegram/Discord/Slack). "+
 			"Only use when no lango serve is running with the same credentials.")
+	cmd.Flags().StringVar(&remoteURL, "remote", "",
+		"Attach to a running lango serve gateway (e.g. wss://host:18789) instead of starting a local app")
+	cmd.Flags().StringVar(&remoteToken, "token", "",
+		"Cockpit bearer token for --remote (default: $LANGO_COCKPIT_TOKEN)")
+	cmd.Flags().StringVar(&remoteSession, "session", "",
+		"Session to attach to with --remote (default: the gateway's cockpit session)")
 	return cmd
 }
 
+// runRemoteCockpit attaches the cockpit to a running gateway. Chat, tool,
+// approval, task and metrics traffic flows over the gateway WebSocket; no
+// local application or database is started.
+func runRemoteCockpit(remoteURL, token, sessionKey string) error {
+	if token == "" {
+		token = os.Getenv("LANGO_COCKPIT_TOKEN")
+	}
+	if token == "" {
+		return fmt.Errorf("remote cockpit requires --token or LANGO_COCKPIT_TOKEN")
+	}
+
+	fmt.Fprint(os.Stderr, tui.Banner())
+	fmt.Fprintf(os.Stderr, "\n  Connecting to %s...\n", remoteURL)
+
+	dialCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	client, err := remote.Dial(dialCtx, remoteURL, token, sessionKey)
+	cancel()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	hello := client.Hello()
+	cfg := config.DefaultConfig()
+	cfg.Agent.Provider = "remote"
+	cfg.Agent.Model = hello.Name
+	if u, err := url.Parse(remoteURL); err == nil && u.Host != "" {
+		cfg.Agent.Model = hello.Name + "@" + u.Host
+	}
+
+	model := cockpit.New(cockpit.Deps{
+		TurnRunner: client,
+		Config:     cfg,
+		SessionKey: hello.SessionKey,
+	})
+
+	statusPage := pages.NewStatusPage(nil, nil, cfg)
+	statusPage.SetMetricsSource(client)
+	model.RegisterPage(cockpit.PageStatus, statusPage)
+	if hello.Tasks {
+		var actioner pages.TaskActioner
+		if client.IsOperator() {
+			actioner = client
+		}
+		model.RegisterPage(cockpit.PageTasks, pages.NewTasksPage(client, actioner))
+	} else {
+		model.RegisterPage(cockpit.PageTasks, pages.NewTasksPage(nil, nil))
+	}
+	model.RegisterPage(cockpit.PageKnowledge, pages.NewKnowledgePage(nil, hello.SessionKey))
+
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	model.SetProgram(p)
+	client.SetSender(p.Send)
+	go p.Send(chat.SystemMsg{Text: client.Greeting()})
+
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("TUI: %w", err)
+	}
+	return nil
+}
+
 func chatCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:     "chat",
@@ -650,6 +790,7 @@ func runCockpit() error {
 		EventBus:          application.EventBus,
 		ApprovalHistory:   application.ApprovalHistory,
 		GrantStore:        application.GrantStore,
+		MCPManager:        application.MCPManager,
 	})
 
 	// Register pages.
@@ -682,6 +823,11 @@ func runCockpit() error {
 	}
 	model.RegisterPage(cockpit.PageApprovals,
 		pages.NewApprovalsPage(application.ApprovalHistory, application.GrantStore))
+	model.RegisterPage(cockpit.PageKnowledge,
+		pages.NewKnowledgePage(cockpit.NewKnowledgeBridge(
+			application.KnowledgeStore, application.MemoryStore, application.GraphStore,
+			cfg.Retrieval.AutoAdjust.MinScore, cfg.Retrieval.AutoAdjust.MaxScore,
+		), sessionKey))
 
 	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	model.SetProgram(p)
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/economy/simchain"
+	"github.com/langoai/lango/internal/wallet"
+)
+
+// simulatedWalletUSDC is the mock USDC minted to a node wallet (1000 USDC).
+var simulatedWalletUSDC = big.NewInt(1_000_000_000)
+
+// simulatedChain is the chain a `serve --chain=simulated` node runs against:
+// one it started itself, or one another local node is already serving.
+type simulatedChain struct {
+	chain *simchain.Chain // nil when attached to another node's chain
+	dep   simchain.Deployment
+}
+
+// startSimulatedChain attaches to a simulated chain at listenAddr, or starts
+// one there, and points cfg at it.
+func startSimulatedChain(ctx context.Context, cfg *config.Config, listenAddr, artifactsDir string) (*simulatedChain, error) {
+	attachCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	dep, err := simchain.Attach(attachCtx, "http://"+listenAddr)
+	cancel()
+	switch {
+	case err == nil:
+		dep.ApplyConfig(cfg)
+		return &simulatedChain{dep: dep}, nil
+	case errors.Is(err, simchain.ErrNotSimulated):
+		return nil, fmt.Errorf("%s is serving a different chain: %w", listenAddr, err)
+	}
+
+	chain, err := simchain.Start(ctx, simchain.Options{
+		ArtifactsDir: artifactsDir,
+		ListenAddr:   listenAddr,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dep = chain.Deployment()
+	dep.ApplyConfig(cfg)
+	return &simulatedChain{chain: chain, dep: dep}, nil
+}
+
+// FundWallet sends gas money and mock USDC to the node's payment wallet.
+func (s *simulatedChain) FundWallet(ctx context.Context, wp wallet.WalletProvider) error {
+	if wp == nil {
+		return errors.New("payment wallet not initialized")
+	}
+	addr, err := wp.Address(ctx)
+	if err != nil {
+		return fmt.Errorf("wallet address: %w", err)
+	}
+
+	client := s.client()
+	if client == nil {
+		client, err = ethclient.DialContext(ctx, s.dep.RPCURL)
+		if err != nil {
+			return fmt.Errorf("dial %s: %w", s.dep.RPCURL, err)
+		}
+		defer client.Close()
+	}
+	return simchain.Fund(ctx, client, s.dep, common.HexToAddress(addr), simulatedWalletUSDC)
+}
+
+func (s *simulatedChain) client() *ethclient.Client {
+	if s.chain == nil {
+		return nil
+	}
+	return s.chain.Client()
+}
+
+// Close stops the chain if this node started it.
+func (s *simulatedChain) Close() {
+	if s.chain != nil {
+		_ = s.chain.Close()
+	}
+}
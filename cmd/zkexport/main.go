@@ -47,9 +47,11 @@ func main() {
 	logger, _ := zap.NewDevelopment()
 	sugar := logger.Sugar()
 
+	// Exported verifiers use an unsafe local setup and are for R&D only.
 	svc, err := zkp.NewProverService(zkp.Config{
-		Scheme: zkp.SchemeGroth16,
-		Logger: sugar,
+		Scheme:     zkp.SchemeGroth16,
+		Logger:     sugar,
+		DevNetwork: true,
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "create prover service: %v\n", err)
@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.20;
+
+/// @notice ERC-4337 v0.7 packed user operation.
+struct PackedUserOperation {
+    address sender;
+    uint256 nonce;
+    bytes initCode;
+    bytes callData;
+    bytes32 accountGasLimits; // verificationGasLimit (hi) | callGasLimit (lo)
+    uint256 preVerificationGas;
+    bytes32 gasFees; // maxPriorityFeePerGas (hi) | maxFeePerGas (lo)
+    bytes paymasterAndData; // paymaster | verificationGasLimit | postOpGasLimit | data
+    bytes signature;
+}
+
+interface IMockAccount {
+    function validateUserOp(PackedUserOperation calldata userOp, bytes32 userOpHash, uint256 missingAccountFunds)
+        external
+        returns (uint256 validationData);
+}
+
+interface IMockPaymaster {
+    function validatePaymasterUserOp(PackedUserOperation calldata userOp, bytes32 userOpHash, uint256 maxCost)
+        external
+        returns (bytes memory context, uint256 validationData);
+
+    function postOp(uint8 mode, bytes calldata context, uint256 actualGasCost, uint256 actualUserOpFeePerGas)
+        external;
+}
+
+/// @title MockEntryPoint — minimal ERC-4337 v0.7 EntryPoint for integration tests.
+/// @dev Computes the v0.7 userOpHash, keeps deposits and sequential nonces
+///      (key 0 only), validates the account and paymaster, executes the call,
+///      runs postOp and charges the payer. It does not deploy accounts from
+///      initCode, check validity time ranges, support aggregators or offer the
+///      simulation methods; a bundler calls handleOps directly.
+contract MockEntryPoint {
+    /// @dev Decoded gas and payment fields of one operation.
+    struct OpInfo {
+        bytes32 userOpHash;
+        address paymaster;
+        uint256 verificationGasLimit;
+        uint256 callGasLimit;
+        uint256 paymasterVerificationGasLimit;
+        uint256 paymasterPostOpGasLimit;
+        uint256 maxFeePerGas;
+        uint256 maxPriorityFeePerGas;
+        uint256 maxCost;
+    }
+
+    mapping(address => uint256) public balanceOf;
+    mapping(address => uint256) internal nonces;
+
+    event Deposited(address indexed account, uint256 totalDeposit);
+    event UserOperationEvent(
+        bytes32 indexed userOpHash,
+        address indexed sender,
+        address indexed paymaster,
+        uint256 nonce,
+        bool success,
+        uint256 actualGasCost,
+        uint256 actualGasUsed
+    );
+
+    error FailedOp(uint256 opIndex, string reason);
+
+    receive() external payable {
+        depositTo(msg.sender);
+    }
+
+    function depositTo(address account) public payable {
+        balanceOf[account] += msg.value;
+        emit Deposited(account, balanceOf[account]);
+    }
+
+    function getNonce(address sender, uint192 key) external view returns (uint256) {
+        require(key == 0, "only nonce key 0");
+        return nonces[sender];
+    }
+
+    function getUserOpHash(PackedUserOperation calldata op) public view returns (bytes32) {
+        bytes32 inner = keccak256(
+            abi.encode(
+                op.sender,
+                op.nonce,
+                keccak256(op.initCode),
+                keccak256(op.callData),
+                op.accountGasLimits,
+                op.preVerificationGas,
+                op.gasFees,
+                keccak256(op.paymasterAndData)
+            )
+        );
+        return keccak256(abi.encode(inner, address(this), block.chainid));
+    }
+
+    function handleOps(PackedUserOperation[] calldata ops, address payable beneficiary) external {
+        uint256 collected;
+        for (uint256 i = 0; i < ops.length; i++) {
+            collected += _handleOp(i, ops[i]);
+        }
+        (bool ok,) = beneficiary.call{value: collected}("");
+        require(ok, "AA91 failed send to beneficiary");
+    }
+
+    function _handleOp(uint256 opIndex, PackedUserOperation calldata op) internal returns (uint256 actualGasCost) {
+        uint256 preGas = gasleft();
+        OpInfo memory info = _decode(opIndex, op);
+        address payer = info.paymaster == address(0) ? op.sender : info.paymaster;
+
+        bytes memory context = _validate(opIndex, op, info, payer);
+
+        (bool success,) = op.sender.call{gas: info.callGasLimit}(op.callData);
+
+        uint256 gasPrice = _gasPrice(info);
+        if (context.length > 0) {
+            uint256 costSoFar = (preGas - gasleft() + op.preVerificationGas) * gasPrice;
+            try IMockPaymaster(info.paymaster).postOp{gas: info.paymasterPostOpGasLimit}(
+                success ? 0 : 1, context, costSoFar, gasPrice
+            ) {}
+            catch {
+                revert FailedOp(opIndex, "AA50 postOp reverted");
+            }
+        }
+
+        uint256 actualGasUsed = preGas - gasleft() + op.preVerificationGas;
+        actualGasCost = actualGasUsed * gasPrice;
+        if (actualGasCost > info.maxCost) {
+            actualGasCost = info.maxCost;
+        }
+        balanceOf[payer] += info.maxCost - actualGasCost;
+        emit UserOperationEvent(
+            info.userOpHash, op.sender, info.paymaster, op.nonce, success, actualGasCost, actualGasUsed
+        );
+    }
+
+    function _decode(uint256 opIndex, PackedUserOperation calldata op) internal returns (OpInfo memory info) {
+        if (op.initCode.length != 0) revert FailedOp(opIndex, "AA99 initCode not supported");
+        if (op.nonce != nonces[op.sender]++) revert FailedOp(opIndex, "AA25 invalid account nonce");
+
+        info.userOpHash = getUserOpHash(op);
+        info.verificationGasLimit = uint128(bytes16(op.accountGasLimits));
+        info.callGasLimit = uint128(uint256(op.accountGasLimits));
+        info.maxPriorityFeePerGas = uint128(bytes16(op.gasFees));
+        info.maxFeePerGas = uint128(uint256(op.gasFees));
+
+        bytes calldata pmd = op.paymasterAndData;
+        if (pmd.length > 0) {
+            if (pmd.length < 52) revert FailedOp(opIndex, "AA93 invalid paymasterAndData");
+            info.paymaster = address(bytes20(pmd[:20]));
+            info.paymasterVerificationGasLimit = uint128(bytes16(pmd[20:36]));
+            info.paymasterPostOpGasLimit = uint128(bytes16(pmd[36:52]));
+        }
+
+        uint256 requiredGas = info.verificationGasLimit + info.callGasLimit + info.paymasterVerificationGasLimit
+            + info.paymasterPostOpGasLimit + op.preVerificationGas;
+        info.maxCost = requiredGas * info.maxFeePerGas;
+    }
+
+    /// @dev Validates the account, takes the prefund from the payer's deposit
+    ///      and, with a paymaster, validates it and returns its postOp context.
+    function _validate(uint256 opIndex, PackedUserOperation calldata op, OpInfo memory info, address payer)
+        internal
+        returns (bytes memory context)
+    {
+        uint256 missing;
+        if (info.paymaster == address(0) && balanceOf[op.sender] < info.maxCost) {
+            missing = info.maxCost - balanceOf[op.sender];
+        }
+        try IMockAccount(op.sender).validateUserOp{gas: info.verificationGasLimit}(op, info.userOpHash, missing)
+        returns (uint256 validationData) {
+            if (validationData != 0) revert FailedOp(opIndex, "AA24 signature error");
+        } catch {
+            revert FailedOp(opIndex, "AA23 reverted");
+        }
+
+        if (balanceOf[payer] < info.maxCost) {
+            revert FailedOp(
+                opIndex, info.paymaster == address(0) ? "AA21 didn't pay prefund" : "AA31 paymaster deposit too low"
+            );
+        }
+        balanceOf[payer] -= info.maxCost;
+
+        if (info.paymaster == address(0)) {
+            return "";
+        }
+        try IMockPaymaster(info.paymaster).validatePaymasterUserOp{gas: info.paymasterVerificationGasLimit}(
+            op, info.userOpHash, info.maxCost
+        ) returns (bytes memory ctx, uint256 validationData) {
+            if (validationData != 0) revert FailedOp(opIndex, "AA34 signature error");
+            context = ctx;
+        } catch {
+            revert FailedOp(opIndex, "AA33 reverted");
+        }
+    }
+
+    function _gasPrice(OpInfo memory info) internal view returns (uint256) {
+        if (info.maxFeePerGas == info.maxPriorityFeePerGas) {
+            return info.maxFeePerGas;
+        }
+        uint256 price = block.basefee + info.maxPriorityFeePerGas;
+        return price < info.maxFeePerGas ? price : info.maxFeePerGas;
+    }
+}
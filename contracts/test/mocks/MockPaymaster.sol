@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.20;
+
+import "./MockEntryPoint.sol";
+
+/// @title MockPaymaster — sponsors every UserOperation from its EntryPoint deposit.
+/// @dev Only the EntryPoint may call the paymaster hooks. The deposit is paid
+///      in through deposit(); there is no withdrawal, as on any test chain.
+contract MockPaymaster {
+    address payable public immutable entryPoint;
+    uint256 public sponsoredOps;
+
+    event Sponsored(address indexed sender, bytes32 indexed userOpHash, uint256 actualGasCost);
+
+    constructor(address payable _entryPoint) {
+        entryPoint = _entryPoint;
+    }
+
+    modifier onlyEntryPoint() {
+        require(msg.sender == entryPoint, "not from EntryPoint");
+        _;
+    }
+
+    function deposit() external payable {
+        MockEntryPoint(entryPoint).depositTo{value: msg.value}(address(this));
+    }
+
+    function getDeposit() external view returns (uint256) {
+        return MockEntryPoint(entryPoint).balanceOf(address(this));
+    }
+
+    function validatePaymasterUserOp(PackedUserOperation calldata userOp, bytes32 userOpHash, uint256)
+        external
+        view
+        onlyEntryPoint
+        returns (bytes memory context, uint256 validationData)
+    {
+        return (abi.encode(userOp.sender, userOpHash), 0);
+    }
+
+    function postOp(uint8, bytes calldata context, uint256 actualGasCost, uint256) external onlyEntryPoint {
+        (address sender, bytes32 userOpHash) = abi.decode(context, (address, bytes32));
+        sponsoredOps++;
+        emit Sponsored(sender, userOpHash, actualGasCost);
+    }
+}
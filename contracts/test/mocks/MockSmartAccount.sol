@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.20;
+
+import "./MockEntryPoint.sol";
+
+/// @title MockSmartAccount — single-owner ERC-4337 account for integration tests.
+/// @dev The owner signs the raw userOpHash (no EIP-191 prefix), as the smart
+///      account manager does; v may be 0/1 or 27/28.
+contract MockSmartAccount {
+    address payable public immutable entryPoint;
+    address public immutable owner;
+
+    constructor(address payable _entryPoint, address _owner) {
+        entryPoint = _entryPoint;
+        owner = _owner;
+    }
+
+    receive() external payable {}
+
+    function validateUserOp(PackedUserOperation calldata userOp, bytes32 userOpHash, uint256 missingAccountFunds)
+        external
+        returns (uint256 validationData)
+    {
+        require(msg.sender == entryPoint, "not from EntryPoint");
+        validationData = _recover(userOpHash, userOp.signature) == owner ? 0 : 1;
+        if (missingAccountFunds > 0) {
+            (bool ok,) = entryPoint.call{value: missingAccountFunds}("");
+            ok; // the EntryPoint checks the deposit
+        }
+    }
+
+    function execute(address target, uint256 value, bytes calldata data) external {
+        require(msg.sender == entryPoint || msg.sender == owner, "not authorized");
+        (bool ok, bytes memory ret) = target.call{value: value}(data);
+        if (!ok) {
+            assembly {
+                revert(add(ret, 32), mload(ret))
+            }
+        }
+    }
+
+    function _recover(bytes32 hash, bytes calldata sig) internal pure returns (address) {
+        if (sig.length != 65) {
+            return address(0);
+        }
+        bytes32 r = bytes32(sig[0:32]);
+        bytes32 s = bytes32(sig[32:64]);
+        uint8 v = uint8(sig[64]);
+        if (v < 27) {
+            v += 27;
+        }
+        return ecrecover(hash, v, r, s);
+    }
+}
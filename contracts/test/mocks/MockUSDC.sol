@@ -1,20 +1,28 @@
 // SPDX-License-Identifier: MIT
 pragma solidity ^0.8.20;
 
-/// @title MockUSDC — minimal ERC-20 for integration tests.
-/// @dev Anyone can mint; 6 decimals like real USDC.
+/// @title MockUSDC — minimal ERC-20 with EIP-3009 for integration tests.
+/// @dev Anyone can mint; 6 decimals like real USDC. transferWithAuthorization
+///      uses the USDC v2 EIP-712 domain ("USD Coin", "2"), so authorizations
+///      built for real USDC verify against the mock.
 contract MockUSDC {
     string public constant name     = "Mock USDC";
     string public constant symbol   = "USDC";
     uint8  public constant decimals = 6;
 
+    bytes32 public constant TRANSFER_WITH_AUTHORIZATION_TYPEHASH = keccak256(
+        "TransferWithAuthorization(address from,address to,uint256 value,uint256 validAfter,uint256 validBefore,bytes32 nonce)"
+    );
+
     uint256 public totalSupply;
 
     mapping(address => uint256)                      public balanceOf;
     mapping(address => mapping(address => uint256))  public allowance;
+    mapping(address => mapping(bytes32 => bool))     public authorizationState;
 
     event Transfer(address indexed from, address indexed to, uint256 value);
     event Approval(address indexed owner, address indexed spender, uint256 value);
+    event AuthorizationUsed(address indexed authorizer, bytes32 indexed nonce);
 
     function mint(address to, uint256 amount) external {
         totalSupply        += amount;
@@ -39,6 +47,45 @@ contract MockUSDC {
         return _transfer(from, to, amount);
     }
 
+    /// @notice EIP-712 domain separator of the USDC v2 domain on this chain.
+    function DOMAIN_SEPARATOR() public view returns (bytes32) {
+        return keccak256(abi.encode(
+            keccak256("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"),
+            keccak256("USD Coin"),
+            keccak256("2"),
+            block.chainid,
+            address(this)
+        ));
+    }
+
+    /// @notice EIP-3009: execute a transfer signed by `from`.
+    function transferWithAuthorization(
+        address from,
+        address to,
+        uint256 value,
+        uint256 validAfter,
+        uint256 validBefore,
+        bytes32 nonce,
+        uint8 v,
+        bytes32 r,
+        bytes32 s
+    ) external {
+        require(block.timestamp > validAfter, "EIP3009: authorization is not yet valid");
+        require(block.timestamp < validBefore, "EIP3009: authorization is expired");
+        require(!authorizationState[from][nonce], "EIP3009: authorization is used");
+
+        bytes32 structHash = keccak256(abi.encode(
+            TRANSFER_WITH_AUTHORIZATION_TYPEHASH, from, to, value, validAfter, validBefore, nonce
+        ));
+        bytes32 digest = keccak256(abi.encodePacked("\x19\x01", DOMAIN_SEPARATOR(), structHash));
+        address signer = ecrecover(digest, v, r, s);
+        require(signer != address(0) && signer == from, "EIP3009: invalid signature");
+
+        authorizationState[from][nonce] = true;
+        emit AuthorizationUsed(from, nonce);
+        _transfer(from, to, value);
+    }
+
     function _transfer(address from, address to, uint256 amount) internal returns (bool) {
         require(balanceOf[from] >= amount, "ERC20: insufficient balance");
         balanceOf[from] -= amount;
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	adk_agent "google.golang.org/adk/agent"
 	"google.golang.org/adk/agent/llmagent"
@@ -21,6 +22,7 @@ import (
 	"google.golang.org/genai"
 
 	"github.com/langoai/lango/internal/logging"
+	otelgenai "github.com/langoai/lango/internal/observability/genai"
 	"github.com/langoai/lango/internal/runledger"
 	internal "github.com/langoai/lango/internal/session"
 )
@@ -52,6 +54,7 @@ type agentOptions struct {
 	childLifecycleHook  func(internal.SessionLifecycleEvent)
 	isolatedAgents      []string
 	plugins             []*plugin.Plugin
+	tracer              trace.Tracer
 }
 
 // WithAgentTokenBudget sets the session history token budget.
@@ -93,6 +96,13 @@ func WithPlugins(plugins ...*plugin.Plugin) AgentOption {
 	return func(o *agentOptions) { o.plugins = append(o.plugins, plugins...) }
 }
 
+// WithAgentTracer records each run as an OpenTelemetry "invoke_agent" span.
+// Sub-agent delegations become linked child spans, and model and tool spans
+// of the run are parented to the agent that issued them.
+func WithAgentTracer(tracer trace.Tracer) AgentOption {
+	return func(o *agentOptions) { o.tracer = tracer }
+}
+
 // Agent wraps the ADK runner for integration with Lango.
 type Agent struct {
 	runner           *runner.Runner
@@ -101,6 +111,7 @@ type Agent struct {
 	errorFixProvider ErrorFixProvider // optional: for self-correction on errors
 	sessionService   *SessionServiceAdapter
 	isolatedAgents   map[string]struct{}
+	tracer           trace.Tracer // optional: GenAI agent spans
 }
 
 // RunDiagnostics captures high-level runtime facts about a single agent turn.
@@ -179,6 +190,7 @@ func NewAgent(ctx context.Context, tools []tool.Tool, mod model.LLM, systemPromp
 		errorFixProvider: o.errorFixProvider,
 		sessionService:   sessService,
 		isolatedAgents:   makeIsolatedAgentSet(o.isolatedAgents),
+		tracer:           o.tracer,
 	}, nil
 }
 
@@ -227,6 +239,7 @@ func NewAgentFromADK(adkAgent adk_agent.Agent, store internal.Store, opts ...Age
 		errorFixProvider: o.errorFixProvider,
 		sessionService:   sessService,
 		isolatedAgents:   makeIsolatedAgentSet(o.isolatedAgents),
+		tracer:           o.tracer,
 	}, nil
 }
 
@@ -270,10 +283,18 @@ func (a *Agent) Run(ctx context.Context, sessionID string, input string) iter.Se
 		maxTurns = defaultMaxTurns
 	}
 
-	// Execute via Runner with turn limit enforcement.
-	inner := a.runner.Run(ctx, "user", sessionID, userMsg, runCfg)
-
 	return func(yield func(*session.Event, error) bool) {
+		runCtx := ctx
+		var scope *otelgenai.AgentScope
+		if a.tracer != nil {
+			runCtx, scope = otelgenai.StartAgent(ctx, a.tracer, a.adkAgent.Name())
+			defer scope.End()
+			yield = recordRunError(scope, yield)
+		}
+
+		// Execute via Runner with turn limit enforcement.
+		inner := a.runner.Run(runCtx, "user", sessionID, userMsg, runCfg)
+
 		turnCount := 0
 		warnedAtThreshold := false
 
@@ -304,6 +325,9 @@ func (a *Agent) Run(ctx context.Context, sessionID string, input string) iter.Se
 			if isDelegationEvent(event) {
 				target := event.Actions.TransferToAgent
 				delegationCount++
+				if scope != nil {
+					scope.Delegate(event.Author, target)
+				}
 				if target != "" && target != "lango-orchestrator" {
 					uniqueAgents[target] = struct{}{}
 					if target == "planner" {
@@ -419,6 +443,17 @@ func (a *Agent) Run(ctx context.Context, sessionID string, input string) iter.Se
 	}
 }
 
+// recordRunError wraps yield so that run errors are recorded on the agent
+// span before they are passed on.
+func recordRunError(scope *otelgenai.AgentScope, yield func(*session.Event, error) bool) func(*session.Event, error) bool {
+	return func(event *session.Event, err error) bool {
+		if err != nil {
+			scope.RecordError(err)
+		}
+		return yield(event, err)
+	}
+}
+
 // hasFunctionCalls reports whether the event contains any FunctionCall parts.
 func hasFunctionCalls(e *session.Event) bool {
 	if e.Content == nil {
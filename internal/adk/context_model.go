@@ -36,6 +36,13 @@ type RunSummaryProvider interface {
 	MaxJournalSeqForSession(ctx context.Context, sessionKey string) (int64, error)
 }
 
+// PinnedResourceProvider supplies external content pinned into every turn
+// (e.g. MCP resources). Items are injected into the external knowledge layer
+// ahead of searched references, so they are kept first under budget pressure.
+type PinnedResourceProvider interface {
+	PinnedResources(ctx context.Context) ([]knowledge.ContextItem, error)
+}
+
 // RunSummaryContext is the compact command-context view injected from RunLedger.
 type RunSummaryContext struct {
 	RunID          string
@@ -59,6 +66,7 @@ type ContextAwareModelAdapter struct {
 	runtimeAdapter     *RuntimeContextAdapter
 	runSummaryProvider RunSummaryProvider
 	runSummaryCache    *runSummaryCache
+	pinnedProvider     PinnedResourceProvider
 	basePrompt         string
 	maxReflections     int
 	maxObservations    int
@@ -108,6 +116,12 @@ func (m *ContextAwareModelAdapter) WithRunSummaryProvider(provider RunSummaryPro
 	return m
 }
 
+// WithPinnedResources adds pinned external resource injection.
+func (m *ContextAwareModelAdapter) WithPinnedResources(provider PinnedResourceProvider) *ContextAwareModelAdapter {
+	m.pinnedProvider = provider
+	return m
+}
+
 // WithRAG adds RAG (retrieval-augmented generation) support.
 func (m *ContextAwareModelAdapter) WithRAG(svc *embedding.RAGService, opts embedding.RetrieveOptions) *ContextAwareModelAdapter {
 	m.ragService = svc
@@ -192,6 +206,7 @@ func (m *ContextAwareModelAdapter) GenerateContent(ctx context.Context, req *mod
 	var reflections []memory.Reflection
 	var observations []memory.Observation
 	var runSummaries []RunSummaryContext
+	var pinned []knowledge.ContextItem
 
 	g, gCtx := errgroup.WithContext(ctx)
 
@@ -256,10 +271,23 @@ func (m *ContextAwareModelAdapter) GenerateContent(ctx context.Context, req *mod
 		})
 	}
 
+	if m.pinnedProvider != nil {
+		g.Go(func() error {
+			items, err := m.pinnedProvider.PinnedResources(gCtx)
+			if err != nil {
+				m.logger.Warnw("pinned resource retrieval error", "error", err)
+				return nil
+			}
+			pinned = items
+			return nil
+		})
+	}
+
 	_ = g.Wait()
 
 	// Merge retriever (non-factual) and coordinator (factual) results.
 	knowledgeResult = mergeRetrievalResults(knowledgeResult, coordinatorResult)
+	knowledgeResult = prependPinned(knowledgeResult, pinned)
 
 	// ──────────────────────────────────────────────────────────
 	// Phase 2: Measure actual content → Reallocate budgets.
@@ -428,6 +456,23 @@ func estimateRunSummaryTokens(summaries []RunSummaryContext) int {
 	return total
 }
 
+// prependPinned places pinned items at the front of the external knowledge
+// layer so budget truncation drops searched references before them.
+func prependPinned(result *knowledge.RetrievalResult, pinned []knowledge.ContextItem) *knowledge.RetrievalResult {
+	if len(pinned) == 0 {
+		return result
+	}
+	if result == nil {
+		result = &knowledge.RetrievalResult{Items: make(map[knowledge.ContextLayer][]knowledge.ContextItem)}
+	}
+	layer := knowledge.LayerExternalKnowledge
+	items := make([]knowledge.ContextItem, 0, len(pinned)+len(result.Items[layer]))
+	items = append(items, pinned...)
+	result.Items[layer] = append(items, result.Items[layer]...)
+	result.TotalItems += len(pinned)
+	return result
+}
+
 // mergeRetrievalResults combines two RetrievalResults by merging their Items maps.
 // The two results should cover disjoint layer sets (no key conflict expected).
 func mergeRetrievalResults(a, b *knowledge.RetrievalResult) *knowledge.RetrievalResult {
@@ -11,6 +11,7 @@ import (
 	"google.golang.org/adk/model"
 	"google.golang.org/genai"
 
+	"github.com/langoai/lango/internal/knowledge"
 	"github.com/langoai/lango/internal/memory"
 	"github.com/langoai/lango/internal/prompt"
 	"github.com/langoai/lango/internal/provider"
@@ -308,3 +309,29 @@ func TestRetrieveRunSummaryData_CacheInvalidatesOnSeqChange(t *testing.T) {
 	assert.NotEqual(t, got1[0].RunID, got2[0].RunID)
 	assert.Equal(t, 2, prov.listCalls, "list should be called twice (cache invalidated)")
 }
+
+func TestPrependPinned(t *testing.T) {
+	t.Parallel()
+
+	pinned := []knowledge.ContextItem{{Layer: knowledge.LayerExternalKnowledge, Key: "pinned"}}
+
+	got := prependPinned(nil, pinned)
+	require.NotNil(t, got)
+	assert.Equal(t, 1, got.TotalItems)
+	assert.Equal(t, "pinned", got.Items[knowledge.LayerExternalKnowledge][0].Key)
+
+	result := &knowledge.RetrievalResult{
+		Items: map[knowledge.ContextLayer][]knowledge.ContextItem{
+			knowledge.LayerExternalKnowledge: {{Layer: knowledge.LayerExternalKnowledge, Key: "searched"}},
+		},
+		TotalItems: 1,
+	}
+	got = prependPinned(result, pinned)
+	items := got.Items[knowledge.LayerExternalKnowledge]
+	require.Len(t, items, 2)
+	assert.Equal(t, "pinned", items[0].Key)
+	assert.Equal(t, "searched", items[1].Key)
+	assert.Equal(t, 2, got.TotalItems)
+
+	assert.Same(t, result, prependPinned(result, nil))
+}
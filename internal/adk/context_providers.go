@@ -142,7 +142,7 @@ func deriveChannelType(sessionKey string) string {
 		return "direct"
 	}
 	switch prefix {
-	case "telegram", "discord", "slack":
+	case "telegram", "discord", "slack", "email":
 		return prefix
 	default:
 		return "direct"
@@ -112,6 +112,7 @@ func TestDeriveChannelType(t *testing.T) {
 		{give: "telegram:123:456", want: "telegram"},
 		{give: "discord:guild:channel", want: "discord"},
 		{give: "slack:team:channel", want: "slack"},
+		{give: "email:root@example.com:alice@example.com", want: "email"},
 		{give: "unknown:123:456", want: "direct"},
 		{give: "http:something", want: "direct"},
 	}
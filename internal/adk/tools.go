@@ -37,6 +37,12 @@ func AdaptToolForAgentWithTimeout(t *agent.Tool, agentName string, timeout time.
 	return adaptToolWithOptions(t, agentName, timeout)
 }
 
+// InputSchema returns the JSON Schema describing an agent.Tool's parameters,
+// as presented to the model. Used to publish tools to other protocols (MCP).
+func InputSchema(t *agent.Tool) *jsonschema.Schema {
+	return buildInputSchema(t)
+}
+
 // buildInputSchema builds a JSON Schema from an agent.Tool's parameter definitions.
 // It supports three formats:
 //  1. Full JSON Schema from SchemaBuilder.Build(): {"type":"object","properties":{...},"required":[...]}
@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/langoai/lango/internal/config"
@@ -73,6 +76,77 @@ func (w *WebhookDelivery) Send(ctx context.Context, evt eventbus.AlertEvent) err
 	return nil
 }
 
+// MessageSender sends a text message to a channel delivery target such as
+// "email:ops@example.com". It is satisfied by the app's channel sender.
+type MessageSender interface {
+	SendMessage(ctx context.Context, channel, message string) error
+}
+
+// EmailDelivery delivers alerts as plain-text mail through the email channel.
+// The sender is bound after channels start; alerts raised before that fail.
+type EmailDelivery struct {
+	to string
+
+	mu     sync.RWMutex
+	sender MessageSender
+}
+
+// NewEmailDelivery creates an email delivery channel for the given recipient.
+// An empty recipient uses the email channel's first allowlisted address.
+func NewEmailDelivery(to string) *EmailDelivery {
+	return &EmailDelivery{to: to}
+}
+
+func (e *EmailDelivery) Type() string { return "email" }
+
+// SetSender binds the sender used to deliver mail.
+func (e *EmailDelivery) SetSender(s MessageSender) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sender = s
+}
+
+// Send mails the alert to the recipient.
+func (e *EmailDelivery) Send(ctx context.Context, evt eventbus.AlertEvent) error {
+	e.mu.RLock()
+	sender := e.sender
+	e.mu.RUnlock()
+	if sender == nil {
+		return fmt.Errorf("email channel not available")
+	}
+
+	target := "email"
+	if e.to != "" {
+		target += ":" + e.to
+	}
+	return sender.SendMessage(ctx, target, formatAlertText(evt))
+}
+
+// formatAlertText renders an alert as plain text. The first line doubles
+// as the mail subject.
+func formatAlertText(evt eventbus.AlertEvent) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] Lango alert: %s\n\n%s\n", strings.ToUpper(evt.Severity), evt.Type, evt.Message)
+	if len(evt.Details) > 0 {
+		keys := make([]string, 0, len(evt.Details))
+		for k := range evt.Details {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteString("\nDetails:\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "  %s: %v\n", k, evt.Details[k])
+		}
+	}
+	if evt.SessionKey != "" {
+		fmt.Fprintf(&b, "\nSession: %s\n", evt.SessionKey)
+	}
+	if !evt.Timestamp.IsZero() {
+		fmt.Fprintf(&b, "Time: %s\n", evt.Timestamp.Format(time.RFC3339))
+	}
+	return b.String()
+}
+
 // severityRank returns a numeric rank for severity comparison.
 // Higher rank = more severe.
 func severityRank(s string) int {
@@ -110,6 +184,8 @@ func NewDeliveryRouter(bus *eventbus.Bus, channels []config.AlertDeliveryConfig)
 				continue
 			}
 			ch = NewWebhookDelivery(cfg.WebhookURL)
+		case "email":
+			ch = NewEmailDelivery(cfg.To)
 		default:
 			deliveryLogger.Warnw("unknown delivery channel type, skipping", "type", cfg.Type)
 			continue
@@ -129,6 +205,17 @@ func NewDeliveryRouter(bus *eventbus.Bus, channels []config.AlertDeliveryConfig)
 	return r
 }
 
+// SetMessageSender binds the channel sender to deliveries that send through
+// a channel, such as email. Channels start after the router is created, so
+// the sender is bound once they are available.
+func (r *DeliveryRouter) SetMessageSender(s MessageSender) {
+	for _, entry := range r.channels {
+		if em, ok := entry.channel.(*EmailDelivery); ok {
+			em.SetSender(s)
+		}
+	}
+}
+
 func (r *DeliveryRouter) handle(evt eventbus.AlertEvent) {
 	rank := severityRank(evt.Severity)
 
@@ -1,9 +1,11 @@
 package alerting
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -151,3 +153,67 @@ func TestDeliveryRouter_UnknownType_Skipped(t *testing.T) {
 
 	assert.Empty(t, r.channels, "unknown channel type should be skipped")
 }
+
+type fakeMessageSender struct {
+	mu      sync.Mutex
+	targets []string
+	texts   []string
+}
+
+func (f *fakeMessageSender) SendMessage(_ context.Context, channel, message string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.targets = append(f.targets, channel)
+	f.texts = append(f.texts, message)
+	return nil
+}
+
+func TestEmailDelivery_Send(t *testing.T) {
+	t.Parallel()
+
+	em := NewEmailDelivery("ops@example.com")
+	evt := eventbus.AlertEvent{
+		Type:     "circuit_breaker",
+		Severity: "critical",
+		Message:  "provider circuit open",
+		Details:  map[string]interface{}{"provider": "openai", "failures": 5},
+	}
+
+	err := em.Send(t.Context(), evt)
+	require.Error(t, err, "send before a sender is bound should fail")
+
+	sender := &fakeMessageSender{}
+	em.SetSender(sender)
+	require.NoError(t, em.Send(t.Context(), evt))
+
+	require.Len(t, sender.targets, 1)
+	assert.Equal(t, "email:ops@example.com", sender.targets[0])
+	assert.True(t, strings.HasPrefix(sender.texts[0], "[CRITICAL] Lango alert: circuit_breaker\n"))
+	assert.Contains(t, sender.texts[0], "provider circuit open")
+	assert.Contains(t, sender.texts[0], "  failures: 5\n  provider: openai\n")
+}
+
+func TestDeliveryRouter_EmailUsesMessageSender(t *testing.T) {
+	t.Parallel()
+
+	bus := eventbus.New()
+	r := NewDeliveryRouter(bus, []config.AlertDeliveryConfig{
+		{Type: "email"},
+	})
+	require.Len(t, r.channels, 1)
+
+	sender := &fakeMessageSender{}
+	r.SetMessageSender(sender)
+
+	bus.Publish(eventbus.AlertEvent{
+		Type:      "test",
+		Severity:  "warning",
+		Timestamp: time.Now(),
+	})
+
+	time.Sleep(100 * time.Millisecond)
+
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+	assert.Equal(t, []string{"email"}, sender.targets, "empty recipient should use the channel default")
+}
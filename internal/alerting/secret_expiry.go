@@ -0,0 +1,136 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/langoai/lango/internal/eventbus"
+	"github.com/langoai/lango/internal/security"
+)
+
+// SecretExpiryLister lists stored secrets whose expiry is at or before a cutoff.
+// *security.SecretsStore satisfies this interface.
+type SecretExpiryLister interface {
+	ListExpiring(ctx context.Context, before time.Time) ([]*security.SecretInfo, error)
+}
+
+// SecretExpiryMonitor periodically checks stored secrets and publishes
+// AlertEvent when a secret is about to expire or has expired. Each secret
+// version is alerted at most once per stage, so rotating a secret re-arms it.
+type SecretExpiryMonitor struct {
+	bus      *eventbus.Bus
+	lister   SecretExpiryLister
+	warning  time.Duration
+	interval time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	alerted map[string]string // "name@version" -> last alerted stage
+	cancel  context.CancelFunc
+}
+
+// NewSecretExpiryMonitor creates a monitor that warns warning ahead of expiry
+// and checks every interval.
+func NewSecretExpiryMonitor(bus *eventbus.Bus, lister SecretExpiryLister, warning, interval time.Duration) *SecretExpiryMonitor {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &SecretExpiryMonitor{
+		bus:      bus,
+		lister:   lister,
+		warning:  warning,
+		interval: interval,
+		now:      time.Now,
+		alerted:  make(map[string]string),
+	}
+}
+
+// Name implements lifecycle.Component.
+func (m *SecretExpiryMonitor) Name() string { return "secret-expiry-monitor" }
+
+// Start runs an immediate check and then one per interval until Stop.
+func (m *SecretExpiryMonitor) Start(ctx context.Context, wg *sync.WaitGroup) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	m.mu.Lock()
+	m.cancel = cancel
+	m.mu.Unlock()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		m.Check(runCtx)
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				m.Check(runCtx)
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop halts periodic checks.
+func (m *SecretExpiryMonitor) Stop(_ context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+	return nil
+}
+
+// Check publishes alerts for secrets entering the warning window or past
+// expiry and returns the number of alerts published.
+func (m *SecretExpiryMonitor) Check(ctx context.Context) int {
+	now := m.now()
+	secrets, err := m.lister.ListExpiring(ctx, now.Add(m.warning))
+	if err != nil {
+		deliveryLogger.Warnw("secret expiry check failed", "error", err)
+		return 0
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	published := 0
+	for _, s := range secrets {
+		if s.ExpiresAt == nil {
+			continue
+		}
+		stage, severity := "expiring", "warning"
+		message := fmt.Sprintf("secret %q expires at %s", s.Name, s.ExpiresAt.Format(time.RFC3339))
+		if s.Expired(now) {
+			stage, severity = "expired", "critical"
+			message = fmt.Sprintf("secret %q expired at %s", s.Name, s.ExpiresAt.Format(time.RFC3339))
+		}
+
+		key := fmt.Sprintf("%s@%d", s.Name, s.Version)
+		if m.alerted[key] == stage {
+			continue
+		}
+		m.alerted[key] = stage
+
+		m.bus.Publish(eventbus.AlertEvent{
+			Type:     "secret_expiry",
+			Severity: severity,
+			Message:  message,
+			Details: map[string]interface{}{
+				"secret":    s.Name,
+				"version":   s.Version,
+				"stage":     stage,
+				"expiresAt": s.ExpiresAt.Format(time.RFC3339),
+			},
+			Timestamp: now,
+		})
+		published++
+	}
+	return published
+}
@@ -0,0 +1,66 @@
+package alerting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/langoai/lango/internal/eventbus"
+	"github.com/langoai/lango/internal/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubExpiryLister struct {
+	secrets []*security.SecretInfo
+}
+
+func (s *stubExpiryLister) ListExpiring(_ context.Context, before time.Time) ([]*security.SecretInfo, error) {
+	var out []*security.SecretInfo
+	for _, info := range s.secrets {
+		if info.ExpiresAt != nil && !info.ExpiresAt.After(before) {
+			out = append(out, info)
+		}
+	}
+	return out, nil
+}
+
+func TestSecretExpiryMonitor_Check(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	soon := now.Add(2 * 24 * time.Hour)
+	later := now.Add(30 * 24 * time.Hour)
+	lister := &stubExpiryLister{secrets: []*security.SecretInfo{
+		{Name: "soon", Version: 1, ExpiresAt: &soon},
+		{Name: "later", Version: 1, ExpiresAt: &later},
+	}}
+
+	bus := eventbus.New()
+	var received []eventbus.AlertEvent
+	eventbus.SubscribeTyped(bus, func(evt eventbus.AlertEvent) {
+		received = append(received, evt)
+	})
+
+	m := NewSecretExpiryMonitor(bus, lister, 7*24*time.Hour, time.Hour)
+	m.now = func() time.Time { return now }
+
+	assert.Equal(t, 1, m.Check(context.Background()))
+	require.Len(t, received, 1)
+	assert.Equal(t, "secret_expiry", received[0].Type)
+	assert.Equal(t, "warning", received[0].Severity)
+	assert.Equal(t, "soon", received[0].Details["secret"])
+
+	// Same stage is not re-alerted.
+	assert.Equal(t, 0, m.Check(context.Background()))
+
+	// Once expired, a critical alert follows.
+	now = soon.Add(time.Minute)
+	assert.Equal(t, 1, m.Check(context.Background()))
+	require.Len(t, received, 2)
+	assert.Equal(t, "critical", received[1].Severity)
+
+	// Rotation (new version) re-arms the alert.
+	lister.secrets[0] = &security.SecretInfo{Name: "soon", Version: 2, ExpiresAt: &soon}
+	assert.Equal(t, 1, m.Check(context.Background()))
+}
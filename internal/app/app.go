@@ -14,12 +14,14 @@ import (
 	"github.com/langoai/lango/internal/adk"
 	"github.com/langoai/lango/internal/agent"
 	"github.com/langoai/lango/internal/agentrt"
+	"github.com/langoai/lango/internal/alerting"
 	"github.com/langoai/lango/internal/appinit"
 	"github.com/langoai/lango/internal/approval"
 	"github.com/langoai/lango/internal/background"
 	"github.com/langoai/lango/internal/bootstrap"
 	"github.com/langoai/lango/internal/config"
 	cronpkg "github.com/langoai/lango/internal/cron"
+	"github.com/langoai/lango/internal/economy/pricing"
 	"github.com/langoai/lango/internal/eventbus"
 	"github.com/langoai/lango/internal/gateway"
 	"github.com/langoai/lango/internal/learning"
@@ -27,6 +29,7 @@ import (
 	"github.com/langoai/lango/internal/logging"
 	"github.com/langoai/lango/internal/observability"
 	"github.com/langoai/lango/internal/observability/audit"
+	"github.com/langoai/lango/internal/provenance"
 	"github.com/langoai/lango/internal/runledger"
 	"github.com/langoai/lango/internal/sandbox"
 	"github.com/langoai/lango/internal/session"
@@ -87,11 +90,17 @@ func New(boot *bootstrap.Result, opts ...AppOption) (*App, error) {
 	bus := eventbus.New()
 	ctx, cancel := context.WithCancel(context.Background())
 	app := &App{
-		Config:   cfg,
-		EventBus: bus,
-		registry: lifecycle.NewRegistry(),
-		ctx:      ctx,
-		cancel:   cancel,
+		Config:       cfg,
+		EventBus:     bus,
+		EventJournal: initEventJournal(cfg, bus),
+		registry:     lifecycle.NewRegistry(),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+
+	// Hash-chain every audit log row written through the shared client.
+	if boot.DBClient != nil {
+		audit.InstallChain(boot.DBClient)
 	}
 
 	// LocalChat/Cockpit mode: skip Network and Automation lifecycle components.
@@ -133,6 +142,25 @@ func New(boot *bootstrap.Result, opts ...AppOption) (*App, error) {
 	// SandboxDecisionEvent records flow into the audit recorder.
 	if fv, ok := resolver.Resolve(appinit.ProvidesSupervisor).(*foundationValues); ok && fv.Supervisor != nil {
 		fv.Supervisor.SetEventBus(bus)
+		if cfg.Observability.Tracing.Enabled {
+			fv.Supervisor.SetTracing(observability.Tracer(), genAIContentCapture(cfg))
+		}
+	}
+
+	// B1a2. Secret access audit events, expiry alerts and backend lease renewal.
+	if fv, ok := resolver.Resolve(appinit.ProvidesSupervisor).(*foundationValues); ok && fv.Secrets != nil {
+		fv.Secrets.SetEventBus(bus)
+		if c, ok := fv.SecretsBk.(lifecycle.Component); ok {
+			app.registry.Register(c, lifecycle.PriorityInfra)
+		}
+		if cfg.Alerting.Enabled {
+			monitor := alerting.NewSecretExpiryMonitor(bus, fv.Secrets,
+				cfg.Security.Secrets.ExpiryWarning, cfg.Security.Secrets.ExpiryCheckInterval)
+			app.registry.Register(monitor, lifecycle.PriorityAutomation)
+			logger().Infow("secret expiry monitor wired",
+				"warning", cfg.Security.Secrets.ExpiryWarning,
+				"interval", cfg.Security.Secrets.ExpiryCheckInterval)
+		}
 	}
 
 	// B1b. Provenance runtime capture + transport wiring.
@@ -191,6 +219,9 @@ func New(boot *bootstrap.Result, opts ...AppOption) (*App, error) {
 	if app.RunLedgerStore != nil {
 		app.Gateway.SetRunLedgerStore(app.RunLedgerStore)
 	}
+	if app.ProvenanceRewind != nil {
+		app.Gateway.SetSessionRewinder(app.ProvenanceRewind)
+	}
 	cleanups.push("gateway", func() {
 		_ = app.Gateway.Shutdown(context.Background())
 	})
@@ -245,16 +276,25 @@ func New(boot *bootstrap.Result, opts ...AppOption) (*App, error) {
 
 	// B4f. Tracing middleware — outermost, so blocked calls are also traced.
 	if cfg.Observability.Tracing.Enabled {
-		tools = toolchain.ChainAll(tools, toolchain.WithTracing(observability.Tracer()))
+		tools = toolchain.ChainAll(tools, toolchain.WithTracing(observability.Tracer(), genAIContentCapture(cfg)))
 		logger().Info("tracing middleware enabled (outermost)")
 	}
 
 	// Log tool registration summary for diagnostics.
 	logToolRegistrationSummary(catalog)
+	app.Tools = tools
+
+	// Composite skills run their steps through the wrapped tools so
+	// approval, hooks and policy apply to every step.
+	if sr := resolveSR(iv); sr != nil {
+		sr.SetToolLookup(toolLookup(tools))
+		sr.SetSandboxApprover(skillSandboxApprover(composite))
+	}
 
 	// B6. Agent creation.
 	scanner := fv.Scanner
 	p2pc, _ := resolver.Resolve(appinit.ProvidesP2P).(*p2pComponents)
+	mcpc, _ := resolver.Resolve(appinit.ProvidesMCP).(*mcpComponents)
 	adkAgent, err := initAgent(context.Background(), &agentDeps{
 		sv:       fv.Supervisor,
 		cfg:      cfg,
@@ -269,6 +309,7 @@ func New(boot *bootstrap.Result, opts ...AppOption) (*App, error) {
 		lc:       resolveLC(iv),
 		catalog:  catalog,
 		p2pc:     p2pc,
+		mcpc:     mcpc,
 		eventBus: bus,
 		rls:      app.RunLedgerStore,
 		prov: func() *provenanceValues {
@@ -398,6 +439,9 @@ func populateAppFields(app *App, r appinit.Resolver) {
 	if av, ok := r.Resolve(appinit.ProvidesAutomation).(*automationValues); ok {
 		if cs, ok := av.CronScheduler.(*cronpkg.Scheduler); ok {
 			app.CronScheduler = cs
+			if app.Gateway != nil {
+				cs.RegisterWebhookRoutes(app.Gateway.Router())
+			}
 		}
 		if bm, ok := av.BackgroundManager.(*background.Manager); ok {
 			app.BackgroundManager = bm
@@ -439,6 +483,21 @@ func populateAppFields(app *App, r appinit.Resolver) {
 		app.HealthRegistry = obsc.healthRegistry
 		app.TokenStore = obsc.tokenStore
 		app.TracerShutdown = obsc.tracerShutdown
+		if obsc.alertRouter != nil {
+			// Channel-backed alert deliveries (email) send through the
+			// app's channels, which resolve at send time.
+			obsc.alertRouter.SetMessageSender(newChannelSender(app))
+		}
+	}
+
+	// Remote cockpit: expose background tasks and metrics over the gateway.
+	if app.Gateway != nil {
+		if app.BackgroundManager != nil {
+			app.Gateway.SetTaskManager(app.BackgroundManager)
+		}
+		if app.MetricsCollector != nil {
+			app.Gateway.SetMetricsSource(app.MetricsCollector)
+		}
 	}
 
 	// RunLedger.
@@ -453,6 +512,9 @@ func populateAppFields(app *App, r appinit.Resolver) {
 		app.ProvenanceSessionTree = pv.sessionTree
 		app.ProvenanceAttribution = pv.attribution
 		app.ProvenanceBundle = pv.bundle
+		if history, ok := app.Store.(provenance.SessionHistory); ok {
+			app.ProvenanceRewind = provenance.NewRewindService(pv.checkpointStore, app.RunLedgerStore, history)
+		}
 	}
 }
 
@@ -513,6 +575,9 @@ func buildHookRegistry(cfg *config.Config, bus *eventbus.Bus) *toolchain.HookReg
 // buildApprovalProvider constructs the composite approval provider and grant store.
 func buildApprovalProvider(cfg *config.Config, gw *gateway.Server) (*approval.CompositeProvider, *approval.GrantStore) {
 	composite := approval.NewCompositeProvider()
+	if cfg.Server.Cockpit.Enabled {
+		composite.Register(approval.NewCockpitProvider(gw))
+	}
 	composite.Register(approval.NewGatewayProvider(gw))
 	if cfg.Security.Interceptor.HeadlessAutoApprove {
 		composite.SetTTYFallback(&approval.HeadlessProvider{})
@@ -547,6 +612,16 @@ func wirePostAgent(app *App, r appinit.Resolver, tools []*agent.Tool, bus *event
 	// P2P executor + REST API routes.
 	p2pc, _ := r.Resolve(appinit.ProvidesP2P).(*p2pComponents)
 	pc, _ := r.Resolve(appinit.ProvidesPayment).(*paymentComponents)
+
+	// X402 seller: charge for configured gateway and A2A routes.
+	var pricingEngine *pricing.Engine
+	if econc, ok := r.Resolve(appinit.ProvidesEconomy).(*economyComponents); ok && econc != nil {
+		pricingEngine = econc.pricingEngine
+	}
+	if seller := initX402Seller(cfg, pc, pricingEngine, boot.DBClient); seller != nil {
+		app.Gateway.SetPaywall(seller.Middleware)
+	}
+
 	if p2pc != nil {
 		if p2pc.handler != nil {
 			toolIndex := make(map[string]*agent.Tool, len(tools))
@@ -693,6 +768,7 @@ func wirePostAgent(app *App, r appinit.Resolver, tools []*agent.Tool, bus *event
 		auditRec := audit.NewRecorder(boot.DBClient)
 		auditRec.Subscribe(bus)
 		logger().Info("audit recorder wired to event bus")
+		wireAuditCheckpoints(app, cfg, boot.DBClient)
 	}
 }
 
@@ -876,6 +952,13 @@ func (a *App) Stop(ctx context.Context) error {
 		}
 	}
 
+	if a.EventJournal != nil {
+		a.EventBus.SetJournal(nil, nil)
+		if err := a.EventJournal.Close(); err != nil {
+			logger().Warnw("event journal close error", "error", err)
+		}
+	}
+
 	return errors.Join(stopErr, waitErr)
 }
 
@@ -918,6 +1001,7 @@ func registerConfigSecrets(scanner *agent.SecretScanner, cfg *config.Config) {
 	register("slack.botToken", cfg.Channels.Slack.BotToken)
 	register("slack.appToken", cfg.Channels.Slack.AppToken)
 	register("slack.signingSecret", cfg.Channels.Slack.SigningSecret)
+	register("email.password", cfg.Channels.Email.Password)
 
 	// Auth provider secrets
 	for id, a := range cfg.Auth.Providers {
@@ -932,5 +1016,8 @@ func registerConfigSecrets(scanner *agent.SecretScanner, cfg *config.Config) {
 		for ek, ev := range srv.Env {
 			register("mcp."+name+".env."+ek, ev)
 		}
+		if srv.OAuth != nil {
+			register("mcp."+name+".oauth.clientSecret", srv.OAuth.ClientSecret)
+		}
 	}
 }
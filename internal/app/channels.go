@@ -7,6 +7,7 @@ import (
 
 	"github.com/langoai/lango/internal/approval"
 	"github.com/langoai/lango/internal/channels/discord"
+	"github.com/langoai/lango/internal/channels/email"
 	"github.com/langoai/lango/internal/channels/slack"
 	"github.com/langoai/lango/internal/channels/telegram"
 	"github.com/langoai/lango/internal/deadline"
@@ -86,6 +87,36 @@ func (a *App) initChannels() error {
 		}
 	}
 
+	// Email
+	if a.Config.Channels.Email.Enabled {
+		emConfig := email.Config{
+			IMAPAddress:        a.Config.Channels.Email.IMAPAddress,
+			SMTPAddress:        a.Config.Channels.Email.SMTPAddress,
+			Username:           a.Config.Channels.Email.Username,
+			Password:           a.Config.Channels.Email.Password,
+			Address:            a.Config.Channels.Email.Address,
+			Mailbox:            a.Config.Channels.Email.Mailbox,
+			PollInterval:       a.Config.Channels.Email.PollInterval,
+			Allowlist:          a.Config.Channels.Email.Allowlist,
+			TrustedAuthServID:  a.Config.Channels.Email.TrustedAuthServID,
+			Insecure:           a.Config.Channels.Email.Insecure,
+			ApprovalTimeoutSec: a.Config.Security.Interceptor.ApprovalTimeoutSec,
+		}
+		emChannel, err := email.New(emConfig)
+		if err != nil {
+			logger().Errorw("create email channel", "error", err)
+		} else {
+			emChannel.SetHandler(func(ctx context.Context, msg *email.IncomingMessage) (*email.OutgoingMessage, error) {
+				return a.handleEmailMessage(ctx, msg)
+			})
+			a.Channels = append(a.Channels, emChannel)
+			if composite, ok := a.ApprovalProvider.(*approval.CompositeProvider); ok {
+				composite.Register(emChannel.GetApprovalProvider())
+			}
+			logger().Info("email channel initialized")
+		}
+	}
+
 	return nil
 }
 
@@ -200,6 +231,42 @@ func (a *App) handleSlackMessage(ctx context.Context, msg *slack.IncomingMessage
 	return &slack.OutgoingMessage{Text: response}, nil
 }
 
+func (a *App) handleEmailMessage(ctx context.Context, msg *email.IncomingMessage) (*email.OutgoingMessage, error) {
+	sessionKey := fmt.Sprintf("%s:%s:%s", types.ChannelEmail, msg.ThreadID, msg.From)
+
+	if a.EventBus != nil {
+		senderName := msg.FromName
+		if senderName == "" {
+			senderName = msg.From
+		}
+		a.EventBus.Publish(eventbus.ChannelMessageReceivedEvent{
+			Channel:    string(types.ChannelEmail),
+			SessionKey: sessionKey,
+			SenderName: senderName,
+			SenderID:   msg.From,
+			Text:       msg.Text,
+			Timestamp:  time.Now(),
+			Metadata:   map[string]string{"messageID": msg.MessageID, "subject": msg.Subject},
+		})
+	}
+
+	response, err := a.runAgent(ctx, sessionKey, msg.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.EventBus != nil {
+		a.EventBus.Publish(eventbus.ChannelMessageSentEvent{
+			Channel:      string(types.ChannelEmail),
+			SessionKey:   sessionKey,
+			ResponseText: response,
+			Timestamp:    time.Now(),
+		})
+	}
+
+	return &email.OutgoingMessage{Text: response}, nil
+}
+
 // runAgent executes the agent and aggregates the response.
 func (a *App) runAgent(ctx context.Context, sessionKey, input string) (string, error) {
 	idleTimeout, hardCeiling := a.resolveTimeouts()
@@ -54,6 +54,7 @@ type foundationValues struct {
 	Crypto     security.CryptoProvider
 	Keys       *security.KeyRegistry
 	Secrets    *security.SecretsStore
+	SecretsBk  security.SecretsBackend
 	BrowserSM  *browser.SessionManager
 	Refs       *security.RefStore
 	Scanner    *agent.SecretScanner
@@ -124,6 +125,20 @@ func (m *foundationModule) Init(ctx context.Context, r appinit.Resolver) (*appin
 		return nil, fmt.Errorf("security init: %w", err)
 	}
 
+	// External secrets backend for {{secret:path#field}} references.
+	var secretsBackend security.SecretsBackend
+	if secrets != nil {
+		secretsBackend, err = security.NewSecretsBackend(cfg.Security.Secrets)
+		if err != nil {
+			return nil, fmt.Errorf("secrets backend %q: %w", cfg.Security.Secrets.Backend, err)
+		}
+		if secretsBackend != nil {
+			secrets.SetBackend(secretsBackend)
+			secrets.SetRemotePaths(security.RemoteSecretPathsFromConfig(cfg.Security.Secrets.RemotePaths))
+			logger().Infow("external secrets backend enabled", "backend", cfg.Security.Secrets.Backend)
+		}
+	}
+
 	// Base tools: exec, filesystem, browser.
 	var blockedPaths []string
 	if home, err := os.UserHomeDir(); err == nil {
@@ -165,6 +180,14 @@ func (m *foundationModule) Init(ctx context.Context, r appinit.Resolver) (*appin
 	scanner := agent.NewSecretScanner()
 	registerConfigSecrets(scanner, cfg)
 
+	// Secret references resolve through each secret's access policy.
+	if secrets != nil {
+		refs.SetAuthorizer(secrets)
+		sv.SetSecrets(refs, secrets)
+	} else {
+		sv.SetSecrets(refs, nil)
+	}
+
 	// Crypto tools.
 	var cryptoTools []*agent.Tool
 	if crypto != nil && keys != nil {
@@ -193,6 +216,7 @@ func (m *foundationModule) Init(ctx context.Context, r appinit.Resolver) (*appin
 				Crypto:     crypto,
 				Keys:       keys,
 				Secrets:    secrets,
+				SecretsBk:  secretsBackend,
 				BrowserSM:  browserSM,
 				Refs:       refs,
 				Scanner:    scanner,
@@ -520,7 +544,7 @@ func (m *automationModule) Init(ctx context.Context, r appinit.Resolver) (*appin
 	var entries []appinit.CatalogEntry
 	var components []lifecycle.ComponentEntry
 
-	cron := initCron(cfg, store, m.app)
+	cron := initCron(cfg, store, fv.Secrets, m.app)
 	if cron != nil {
 		cronTools := cronpkg.BuildTools(cron, cfg.Cron.DefaultDeliverTo)
 		tools = append(tools, cronTools...)
@@ -671,7 +695,11 @@ func (m *networkModule) Init(ctx context.Context, r appinit.Resolver) (*appinit.
 		entries = append(entries, appinit.CatalogEntry{Category: "payment", Description: "Blockchain payments (USDC on Base)", ConfigKey: "payment.enabled", Enabled: true, Tools: pt})
 
 		// P2P.
-		p2pc = initP2P(cfg, pc.wallet, pc, m.boot.DBClient, fv.Secrets, m.bus, m.boot.IdentityKey, m.boot.PQSigningKeySeed, m.boot.LangoDir)
+		var err error
+		p2pc, err = initP2P(cfg, pc.wallet, pc, m.boot.DBClient, fv.Secrets, m.bus, m.boot.IdentityKey, m.boot.PQSigningKeySeed, m.boot.LangoDir)
+		if err != nil {
+			return nil, err
+		}
 		if p2pc != nil {
 			// P2P Node lifecycle.
 			if p2pc.node != nil {
@@ -728,7 +756,7 @@ func (m *networkModule) Init(ctx context.Context, r appinit.Resolver) (*appinit.
 				}
 			}
 
-			wsc = initWorkspace(cfg, p2pc.node, localDID, sessionValidator)
+			wsc = initWorkspace(cfg, p2pc, localDID, sessionValidator, m.bus)
 			if wsc != nil {
 				wsTools := buildWorkspaceTools(wsc)
 				tools = append(tools, wsTools...)
@@ -800,6 +828,11 @@ func (m *networkModule) Init(ctx context.Context, r appinit.Resolver) (*appinit.
 				tools = append(tools, escrowTools...)
 				entries = append(entries, appinit.CatalogEntry{Category: "escrow", Description: "On-chain escrow management", ConfigKey: "economy.escrow.enabled", Enabled: true, Tools: escrowTools})
 			}
+			if econc.arbitration != nil {
+				arbTools := buildArbitrationTools(econc.arbitration)
+				tools = append(tools, arbTools...)
+				entries = append(entries, appinit.CatalogEntry{Category: "escrow_arbitration", Description: "Arbitrated escrow dispute resolution", ConfigKey: "economy.escrow.arbitration.enabled", Enabled: true, Tools: arbTools})
+			}
 			if econc.sentinelEngine != nil {
 				sentTools := sentinel.BuildTools(econc.sentinelEngine)
 				tools = append(tools, sentTools...)
@@ -913,8 +946,10 @@ func (m *extensionModule) Name() string { return "extension" }
 func (m *extensionModule) Provides() []appinit.Provides {
 	return []appinit.Provides{appinit.ProvidesMCP, appinit.ProvidesObservability}
 }
-func (m *extensionModule) DependsOn() []appinit.Provides { return nil }
-func (m *extensionModule) Enabled() bool                 { return true }
+func (m *extensionModule) DependsOn() []appinit.Provides {
+	return []appinit.Provides{appinit.ProvidesSupervisor}
+}
+func (m *extensionModule) Enabled() bool { return true }
 
 func (m *extensionModule) Init(ctx context.Context, r appinit.Resolver) (*appinit.ModuleResult, error) {
 	cfg := m.cfg
@@ -924,11 +959,15 @@ func (m *extensionModule) Init(ctx context.Context, r appinit.Resolver) (*appini
 	var components []lifecycle.ComponentEntry
 
 	// MCP.
-	mcpc := initMCP(cfg, m.bus)
+	var secrets *security.SecretsStore
+	if fv, ok := r.Resolve(appinit.ProvidesSupervisor).(*foundationValues); ok && fv != nil {
+		secrets = fv.Secrets
+	}
+	mcpc := initMCP(cfg, m.bus, secrets)
 	if mcpc != nil {
 		tools = append(tools, mcpc.tools...)
 		entries = append(entries, appinit.CatalogEntry{Category: "mcp", Description: "MCP plugin tools (external servers)", ConfigKey: "mcp.enabled", Enabled: true, Tools: mcpc.tools})
-		mgmtTools := buildMCPManagementTools(mcpc.manager)
+		mgmtTools := buildMCPManagementTools(mcpc.manager, mcpc.resources)
 		tools = append(tools, mgmtTools...)
 		entries = append(entries, appinit.CatalogEntry{Category: "mcp", Description: "MCP management tools", ConfigKey: "mcp.enabled", Enabled: true, Tools: mgmtTools})
 		// MCP Manager lifecycle.
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/langoai/lango/internal/channels/discord"
+	"github.com/langoai/lango/internal/channels/email"
 	"github.com/langoai/lango/internal/channels/slack"
 	"github.com/langoai/lango/internal/channels/telegram"
 	"github.com/langoai/lango/internal/types"
@@ -35,6 +36,7 @@ func parseDeliveryTarget(target string) (types.ChannelType, string) {
 // Target format: "channel" (bare name) or "channel:id" (with routing ID).
 // For telegram: uses chatID from target, falls back to first allowlisted chat ID.
 // For discord/slack: uses channelID from target, returns error if not provided.
+// For email: uses the address from target, falls back to first allowlisted address.
 func (s *channelSender) SendMessage(_ context.Context, channel, message string) error {
 	chName, targetID := parseDeliveryTarget(channel)
 
@@ -71,6 +73,16 @@ func (s *channelSender) SendMessage(_ context.Context, channel, message string)
 				}
 				return sl.Send(targetID, &slack.OutgoingMessage{Text: message})
 			}
+		case types.ChannelEmail:
+			if em, ok := c.(*email.Channel); ok {
+				if targetID == "" {
+					targetID = s.firstEmailAddress()
+					if targetID == "" {
+						return fmt.Errorf("email delivery requires an address (use email:ADDRESS) or at least one allowlisted address")
+					}
+				}
+				return em.Send(targetID, &email.OutgoingMessage{Text: message})
+			}
 		}
 	}
 
@@ -129,3 +141,14 @@ func (s *channelSender) firstTelegramChatID() int64 {
 	}
 	return 0
 }
+
+// firstEmailAddress returns the first allowlist entry that is a full
+// address rather than an "@domain" pattern.
+func (s *channelSender) firstEmailAddress() string {
+	for _, a := range s.app.Config.Channels.Email.Allowlist {
+		if a = strings.TrimSpace(a); a != "" && !strings.HasPrefix(a, "@") {
+			return a
+		}
+	}
+	return ""
+}
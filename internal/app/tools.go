@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/langoai/lango/internal/agent"
+	"github.com/langoai/lango/internal/approval"
 	"github.com/langoai/lango/internal/automation"
 	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/session"
+	"github.com/langoai/lango/internal/skill"
 	"github.com/langoai/lango/internal/supervisor"
 	"github.com/langoai/lango/internal/toolchain"
 	"github.com/langoai/lango/internal/tools/browser"
@@ -69,7 +73,7 @@ func classifyLangoExec(cmd string, automationAvailable map[string]bool) (string,
 		{"lango p2p", "", "p2p_status, p2p_connect, p2p_disconnect, p2p_peers, p2p_query, p2p_discover, p2p_firewall_rules, p2p_firewall_add, p2p_firewall_remove, p2p_reputation, p2p_pay, p2p_price_query"},
 		{"lango security", "", "crypto_encrypt, crypto_decrypt, crypto_sign, crypto_hash, crypto_keys, secrets_store, secrets_get, secrets_list, secrets_delete"},
 		{"lango payment", "", "payment_send, payment_create_wallet, payment_x402_fetch"},
-		{"lango mcp", "", "mcp_status, mcp_tools"},
+		{"lango mcp", "", "mcp_status, mcp_tools, mcp_resources, mcp_read_resource, mcp_prompts, mcp_get_prompt"},
 		{"lango contract", "", "contract_read, contract_call, contract_abi_load"},
 		{"lango account", "", "smart_account_deploy, smart_account_info, session_key_create, session_key_list, session_key_revoke, session_execute, policy_check, module_install, module_uninstall, spending_status, paymaster_status, paymaster_approve"},
 	}
@@ -139,6 +143,44 @@ func blockProtectedPaths(cmd string, guard *execpkg.CommandGuard) string {
 	return ""
 }
 
+// toolLookup indexes tools by name for composite skill execution.
+func toolLookup(tools []*agent.Tool) skill.ToolLookup {
+	index := make(map[string]*agent.Tool, len(tools))
+	for _, t := range tools {
+		index[t.Name] = t
+	}
+	return func(name string) (*agent.Tool, bool) {
+		t, ok := index[name]
+		return t, ok
+	}
+}
+
+// skillSandboxApprover asks the user, through the approval providers, to
+// grant the extra sandbox permissions a script skill requests.
+func skillSandboxApprover(provider approval.Provider) skill.SandboxApprover {
+	return func(ctx context.Context, sk skill.SkillEntry) (bool, error) {
+		resp, err := provider.RequestApproval(ctx, approval.ApprovalRequest{
+			ID:         fmt.Sprintf("skill-sandbox-%d", time.Now().UnixNano()),
+			ToolName:   "skill_" + sk.Name,
+			SessionKey: session.SessionKeyFromContext(ctx),
+			Params: map[string]interface{}{
+				"writePaths":          sk.Sandbox.WritePaths,
+				"network":             sk.Sandbox.Network,
+				"unrestrictedNetwork": sk.Sandbox.UnrestrictedNetwork(),
+			},
+			Summary:     fmt.Sprintf("Skill '%s' requests sandbox access: %s", sk.Name, sk.Sandbox.Summary()),
+			CreatedAt:   time.Now(),
+			SafetyLevel: agent.SafetyLevelDangerous.String(),
+			Category:    "skill",
+			Activity:    string(agent.ActivityExecute),
+		})
+		if err != nil {
+			return false, err
+		}
+		return resp.Approved, nil
+	}
+}
+
 // wrapBrowserHandler wraps a browser tool handler with panic recovery and auto-reconnect.
 // Delegates to toolchain.WithBrowserRecovery.
 func wrapBrowserHandler(t *agent.Tool, sm *browser.SessionManager) *agent.Tool {
@@ -0,0 +1,309 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/langoai/lango/internal/agent"
+	"github.com/langoai/lango/internal/economy/escrow/arbitration"
+	"github.com/langoai/lango/internal/toolparam"
+)
+
+// buildArbitrationTools creates tools for arbitrated escrow dispute resolution.
+func buildArbitrationTools(svc *arbitration.Service) []*agent.Tool {
+	return []*agent.Tool{
+		arbitrationOpenTool(svc),
+		arbitrationEvidenceTool(svc),
+		arbitrationNominateTool(svc),
+		arbitrationRuleTool(svc),
+		arbitrationSettleTool(svc),
+		arbitrationStatusTool(svc),
+	}
+}
+
+func arbitrationOpenTool(svc *arbitration.Service) *agent.Tool {
+	return &agent.Tool{
+		Name:        "escrow_arbitration_open",
+		Description: "Open an arbitration case for a disputed escrow. Milestone evidence is attached automatically.",
+		SafetyLevel: agent.SafetyLevelModerate,
+		Capability: agent.ToolCapability{
+			Category:             "escrow",
+			Exposure:             agent.ExposureDeferred,
+			Activity:             agent.ActivityWrite,
+			RequiredCapabilities: []string{"payment"},
+		},
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"escrowId": map[string]interface{}{"type": "string", "description": "Disputed escrow ID"},
+			},
+			"required": []string{"escrowId"},
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			escrowID, err := toolparam.RequireString(params, "escrowId")
+			if err != nil {
+				return nil, err
+			}
+			c, err := svc.OpenCase(ctx, escrowID)
+			if err != nil {
+				return nil, err
+			}
+			return arbitrationCaseResult(c), nil
+		},
+	}
+}
+
+func arbitrationEvidenceTool(svc *arbitration.Service) *agent.Tool {
+	return &agent.Tool{
+		Name:        "escrow_dispute_evidence",
+		Description: "Submit evidence for an arbitration case, optionally linked to a milestone and a signed provenance bundle",
+		SafetyLevel: agent.SafetyLevelModerate,
+		Capability: agent.ToolCapability{
+			Category:             "escrow",
+			Exposure:             agent.ExposureDeferred,
+			Activity:             agent.ActivityWrite,
+			RequiredCapabilities: []string{"payment"},
+		},
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"escrowId":         map[string]interface{}{"type": "string", "description": "Escrow ID of the case"},
+				"statement":        map[string]interface{}{"type": "string", "description": "Evidence statement"},
+				"milestoneId":      map[string]interface{}{"type": "string", "description": "Milestone the evidence refers to"},
+				"provenanceBundle": map[string]interface{}{"type": "string", "description": "Signed provenance bundle JSON"},
+			},
+			"required": []string{"escrowId"},
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			escrowID, err := toolparam.RequireString(params, "escrowId")
+			if err != nil {
+				return nil, err
+			}
+			req := arbitration.EvidenceRequest{
+				EscrowID:    escrowID,
+				Statement:   toolparam.OptionalString(params, "statement", ""),
+				MilestoneID: toolparam.OptionalString(params, "milestoneId", ""),
+			}
+			if bundle := toolparam.OptionalString(params, "provenanceBundle", ""); bundle != "" {
+				req.ProvenanceBundle = []byte(bundle)
+			}
+
+			ev, err := svc.SubmitEvidence(ctx, req)
+			if ev == nil {
+				return nil, err
+			}
+			result := map[string]interface{}{
+				"escrowId":   ev.EscrowID,
+				"evidenceId": ev.ID,
+				"role":       string(ev.Role),
+				"forwarded":  err == nil,
+			}
+			if err != nil {
+				result["error"] = err.Error()
+			}
+			return result, nil
+		},
+	}
+}
+
+func arbitrationNominateTool(svc *arbitration.Service) *agent.Tool {
+	return &agent.Tool{
+		Name:        "escrow_arbiter_select",
+		Description: "Select a mutually trusted arbiter for an arbitration case. Candidates are ranked by reputation unless arbiterDid is given. An assigned arbiter is only replaced with replace=true once both parties agreed.",
+		SafetyLevel: agent.SafetyLevelModerate,
+		Capability: agent.ToolCapability{
+			Category:             "escrow",
+			Exposure:             agent.ExposureDeferred,
+			Activity:             agent.ActivityExecute,
+			RequiredCapabilities: []string{"payment"},
+		},
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"escrowId":   map[string]interface{}{"type": "string", "description": "Escrow ID of the case"},
+				"arbiterDid": map[string]interface{}{"type": "string", "description": "Preferred arbiter DID"},
+				"replace":    map[string]interface{}{"type": "boolean", "description": "Agree to replace the assigned arbiter; the counterparty must agree too"},
+			},
+			"required": []string{"escrowId"},
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			escrowID, err := toolparam.RequireString(params, "escrowId")
+			if err != nil {
+				return nil, err
+			}
+			if toolparam.OptionalBool(params, "replace", false) {
+				if _, err := svc.AgreeArbiterReplacement(escrowID); err != nil {
+					return nil, err
+				}
+			}
+			c, err := svc.NominateArbiter(ctx, escrowID, toolparam.OptionalString(params, "arbiterDid", ""))
+			if err != nil {
+				return nil, err
+			}
+			return arbitrationCaseResult(c), nil
+		},
+	}
+}
+
+func arbitrationRuleTool(svc *arbitration.Service) *agent.Tool {
+	return &agent.Tool{
+		Name:        "escrow_rule",
+		Description: "Issue a signed ruling on a case assigned to this agent as arbiter, splitting funds between seller and buyer",
+		SafetyLevel: agent.SafetyLevelDangerous,
+		Capability: agent.ToolCapability{
+			Category:             "escrow",
+			Exposure:             agent.ExposureDeferred,
+			Activity:             agent.ActivityExecute,
+			RequiredCapabilities: []string{"payment"},
+		},
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"escrowId":      map[string]interface{}{"type": "string", "description": "Escrow ID of the case"},
+				"sellerPercent": map[string]interface{}{"type": "number", "description": "Percentage of funds to seller (0-100); the rest goes to the buyer"},
+				"rationale":     map[string]interface{}{"type": "string", "description": "Reasoning for the ruling"},
+			},
+			"required": []string{"escrowId", "sellerPercent", "rationale"},
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			escrowID, err := toolparam.RequireString(params, "escrowId")
+			if err != nil {
+				return nil, err
+			}
+			pct, err := toolparam.RequireFloat64(params, "sellerPercent")
+			if err != nil {
+				return nil, err
+			}
+			if pct < 0 || pct > 100 {
+				return nil, fmt.Errorf("sellerPercent must be between 0 and 100")
+			}
+			rationale, err := toolparam.RequireString(params, "rationale")
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := svc.IssueRuling(ctx, escrowID, int(pct), rationale)
+			if r == nil {
+				return nil, err
+			}
+			result := map[string]interface{}{
+				"escrowId":      r.EscrowID,
+				"sellerPercent": r.SellerPercent,
+				"issuedAt":      r.IssuedAt,
+				"delivered":     err == nil,
+			}
+			if err != nil {
+				result["error"] = err.Error()
+			}
+			return result, nil
+		},
+	}
+}
+
+func arbitrationSettleTool(svc *arbitration.Service) *agent.Tool {
+	return &agent.Tool{
+		Name:        "escrow_arbitration_settle",
+		Description: "Settle a received arbitration ruling on the local escrow (retries a failed settlement)",
+		SafetyLevel: agent.SafetyLevelDangerous,
+		Capability: agent.ToolCapability{
+			Category:             "escrow",
+			Exposure:             agent.ExposureDeferred,
+			Activity:             agent.ActivityExecute,
+			RequiredCapabilities: []string{"payment"},
+		},
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"escrowId": map[string]interface{}{"type": "string", "description": "Escrow ID of the case"},
+			},
+			"required": []string{"escrowId"},
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			escrowID, err := toolparam.RequireString(params, "escrowId")
+			if err != nil {
+				return nil, err
+			}
+			c, err := svc.ApplyRuling(ctx, escrowID)
+			if err != nil {
+				return nil, err
+			}
+			return arbitrationCaseResult(c), nil
+		},
+	}
+}
+
+func arbitrationStatusTool(svc *arbitration.Service) *agent.Tool {
+	return &agent.Tool{
+		Name:        "escrow_arbitration_status",
+		Description: "Get an arbitration case with its evidence and ruling, or list all cases when escrowId is omitted",
+		SafetyLevel: agent.SafetyLevelSafe,
+		Capability: agent.ToolCapability{
+			Category:             "escrow",
+			Exposure:             agent.ExposureDeferred,
+			Activity:             agent.ActivityQuery,
+			ReadOnly:             true,
+			RequiredCapabilities: []string{"payment"},
+		},
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"escrowId": map[string]interface{}{"type": "string", "description": "Escrow ID of the case"},
+			},
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			if escrowID := toolparam.OptionalString(params, "escrowId", ""); escrowID != "" {
+				c, err := svc.Get(escrowID)
+				if err != nil {
+					return nil, err
+				}
+				result := arbitrationCaseResult(c)
+				evidence := make([]map[string]interface{}, len(c.Evidence))
+				for i, ev := range c.Evidence {
+					evidence[i] = map[string]interface{}{
+						"id":                ev.ID,
+						"submitterDid":      ev.SubmitterDID,
+						"role":              string(ev.Role),
+						"milestoneId":       ev.MilestoneID,
+						"milestoneEvidence": ev.MilestoneEvidence,
+						"statement":         ev.Statement,
+						"hasBundle":         len(ev.ProvenanceBundle) > 0,
+					}
+				}
+				result["evidence"] = evidence
+				if c.Ruling != nil {
+					result["rationale"] = c.Ruling.Rationale
+				}
+				return result, nil
+			}
+
+			cases := svc.List()
+			items := make([]map[string]interface{}, len(cases))
+			for i, c := range cases {
+				items[i] = arbitrationCaseResult(c)
+			}
+			return toolparam.ListResponse("cases", items, len(items)), nil
+		},
+	}
+}
+
+// arbitrationCaseResult summarizes a case for tool responses.
+func arbitrationCaseResult(c *arbitration.Case) map[string]interface{} {
+	result := map[string]interface{}{
+		"escrowId":      c.EscrowID,
+		"status":        string(c.Status),
+		"buyerDid":      c.BuyerDID,
+		"sellerDid":     c.SellerDID,
+		"arbiterDid":    c.ArbiterDID,
+		"evidenceCount": len(c.Evidence),
+	}
+	if len(c.Rejected) > 0 {
+		result["rejectedArbiters"] = c.Rejected
+	}
+	if c.Ruling != nil {
+		result["sellerPercent"] = c.Ruling.SellerPercent
+	}
+	if c.SettledTx != "" {
+		result["settledTx"] = c.SettledTx
+	}
+	return result
+}
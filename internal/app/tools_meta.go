@@ -279,8 +279,9 @@ func buildMetaTools(store *knowledge.Store, engine *learning.Engine, registry *s
 					"name":        map[string]interface{}{"type": "string", "description": "Unique name for the skill"},
 					"description": map[string]interface{}{"type": "string", "description": "Description of what the skill does"},
 					"type":        map[string]interface{}{"type": "string", "description": "Skill type: composite, script, or template", "enum": []string{"composite", "script", "template"}},
-					"definition":  map[string]interface{}{"type": "string", "description": "JSON string of the skill definition"},
+					"definition":  map[string]interface{}{"type": "string", "description": "JSON string of the skill definition. Composite: {\"steps\":[{\"id\":\"...\",\"tool\":\"...\",\"params\":{...},\"if\":\"...\"}]} where params may reference {{.inputs.<param>}} and {{.steps.<id>.output}}. Script: {\"script\":\"...\",\"runtime\":\"sh|bash|python3|node\"}; the script reads its parameters as JSON on stdin or from LANGO_PARAM_<NAME> env vars"},
 					"parameters":  map[string]interface{}{"type": "string", "description": "Optional JSON string of parameter schema"},
+					"output_schema": map[string]interface{}{"type": "string", "description": "Optional JSON schema a script skill's JSON stdout must satisfy"},
 				},
 				"required": []string{"name", "description", "type", "definition"},
 			},
@@ -314,12 +315,20 @@ func buildMetaTools(store *knowledge.Store, engine *learning.Engine, registry *s
 					}
 				}
 
+				var outputSchema map[string]interface{}
+				if schemaStr, ok := params["output_schema"].(string); ok && schemaStr != "" {
+					if err := json.Unmarshal([]byte(schemaStr), &outputSchema); err != nil {
+						return nil, fmt.Errorf("parse output_schema JSON: %w", err)
+					}
+				}
+
 				entry := skill.SkillEntry{
 					Name:             name,
 					Description:      description,
 					Type:             skill.SkillType(skillType),
 					Definition:       definition,
 					Parameters:       parameters,
+					OutputSchema:     outputSchema,
 					Status:           skill.SkillStatusActive,
 					CreatedBy:        "agent",
 					RequiresApproval: false,
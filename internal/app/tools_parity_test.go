@@ -13,6 +13,7 @@ import (
 	"github.com/langoai/lango/internal/economy"
 	"github.com/langoai/lango/internal/economy/budget"
 	"github.com/langoai/lango/internal/economy/escrow"
+	"github.com/langoai/lango/internal/economy/escrow/arbitration"
 	"github.com/langoai/lango/internal/economy/escrow/sentinel"
 	"github.com/langoai/lango/internal/economy/negotiation"
 	"github.com/langoai/lango/internal/economy/pricing"
@@ -525,6 +526,29 @@ func TestBuildOnChainEscrowTools_Parity(t *testing.T) {
 	assertNoDuplicateNames(t, tools)
 }
 
+// ─── Escrow Arbitration Tools ───
+
+func TestBuildArbitrationTools_Parity(t *testing.T) {
+	t.Parallel()
+
+	svc := arbitration.NewService(arbitration.Config{LocalDID: "did:lango:test"})
+	tools := buildArbitrationTools(svc)
+
+	wantNames := []string{
+		"escrow_arbitration_open",
+		"escrow_dispute_evidence",
+		"escrow_arbiter_select",
+		"escrow_rule",
+		"escrow_arbitration_settle",
+		"escrow_arbitration_status",
+	}
+
+	assert.Len(t, tools, len(wantNames))
+	assert.Equal(t, wantNames, toolNamesUnsorted(tools))
+	assertAllHandlersNonNil(t, tools)
+	assertNoDuplicateNames(t, tools)
+}
+
 // ─── Cross-cutting: No Lost Tools After Extraction ───
 // This test ensures the combined tool count across all domain builders
 // matches expectations, catching any accidental omissions during extraction.
@@ -556,6 +580,7 @@ func TestToolBuilders_TotalCount(t *testing.T) {
 		"escrow_in":      5, // economy_escrow_* (internal escrow)
 		"pricing":        1,
 		"escrow_onchain": 10,
+		"arbitration":    6,
 	}
 
 	total := 0
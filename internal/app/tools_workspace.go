@@ -46,10 +46,15 @@ func buildWorkspaceTools(wc *wsComponents) []*agent.Tool {
 				return nil, fmt.Errorf("create workspace: %w", err)
 			}
 
-			// Subscribe to gossip topic.
+			// Subscribe to gossip topic and generate the first group key.
 			if wc.gossip != nil {
 				_ = wc.gossip.Subscribe(ws.ID)
 			}
+			if wc.keys != nil {
+				if err := wc.keys.Ensure(ctx, ws.ID); err != nil {
+					return nil, fmt.Errorf("generate workspace key: %w", err)
+				}
+			}
 
 			return map[string]interface{}{
 				"id":        ws.ID,
@@ -63,7 +68,7 @@ func buildWorkspaceTools(wc *wsComponents) []*agent.Tool {
 
 	tools = append(tools, &agent.Tool{
 		Name:        "p2p_workspace_join",
-		Description: "Join an existing P2P workspace",
+		Description: "Join an existing P2P workspace (requires an invite from a current member)",
 		SafetyLevel: agent.SafetyLevelDangerous,
 		Capability: agent.ToolCapability{
 			Category: "workspace",
@@ -90,7 +95,59 @@ func buildWorkspaceTools(wc *wsComponents) []*agent.Tool {
 				_ = wc.gossip.Subscribe(wsID)
 			}
 
-			return map[string]interface{}{"joined": wsID}, nil
+			// Fetch the group key and announce the join to other members.
+			result := map[string]interface{}{"joined": wsID}
+			if _, err := broadcastWorkspaceMessage(ctx, wc, wsID, workspace.Message{
+				Type:    workspace.MessageTypeMemberJoined,
+				Content: "joined",
+			}); err != nil {
+				result["announceError"] = err.Error()
+			}
+
+			return result, nil
+		},
+	})
+
+	tools = append(tools, &agent.Tool{
+		Name:        "p2p_workspace_invite",
+		Description: "Invite a peer DID to a P2P workspace you are a member of; only invited peers can join",
+		SafetyLevel: agent.SafetyLevelDangerous,
+		Capability: agent.ToolCapability{
+			Category: "workspace",
+			Activity: agent.ActivityWrite,
+		},
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"workspaceId": map[string]interface{}{"type": "string", "description": "Workspace ID"},
+				"did":         map[string]interface{}{"type": "string", "description": "DID of the peer to invite"},
+			},
+			"required": []string{"workspaceId", "did"},
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			wsID, err := toolparam.RequireString(params, "workspaceId")
+			if err != nil {
+				return nil, err
+			}
+			did, err := toolparam.RequireString(params, "did")
+			if err != nil {
+				return nil, err
+			}
+
+			if err := wc.manager.Invite(ctx, wsID, did, wc.localDID); err != nil {
+				return nil, err
+			}
+
+			// The signed invite lets other members admit the peer too.
+			result := map[string]interface{}{"invited": did, "workspaceId": wsID}
+			if _, err := broadcastWorkspaceMessage(ctx, wc, wsID, workspace.Message{
+				Type:     workspace.MessageTypeMemberInvited,
+				Content:  "invited " + did,
+				Metadata: map[string]string{workspace.MetaInvitee: did},
+			}); err != nil {
+				result["announceError"] = err.Error()
+			}
+			return result, nil
 		},
 	})
 
@@ -115,6 +172,15 @@ func buildWorkspaceTools(wc *wsComponents) []*agent.Tool {
 				return nil, err
 			}
 
+			// Announce departure while still a member so the key leader
+			// rotates the group key.
+			if _, err := broadcastWorkspaceMessage(ctx, wc, wsID, workspace.Message{
+				Type:    workspace.MessageTypeMemberLeft,
+				Content: "left",
+			}); err != nil {
+				logger().Warnw("announce workspace leave", "workspace", wsID, "error", err)
+			}
+
 			if err := wc.manager.Leave(ctx, wsID); err != nil {
 				return nil, err
 			}
@@ -122,6 +188,9 @@ func buildWorkspaceTools(wc *wsComponents) []*agent.Tool {
 			if wc.gossip != nil {
 				wc.gossip.Unsubscribe(wsID)
 			}
+			if wc.keyring != nil {
+				wc.keyring.Forget(wsID)
+			}
 
 			return map[string]interface{}{"left": wsID}, nil
 		},
@@ -256,29 +325,25 @@ func buildWorkspaceTools(wc *wsComponents) []*agent.Tool {
 			msgType := toolparam.OptionalString(params, "type", string(workspace.MessageTypeKnowledgeShare))
 			parentID := toolparam.OptionalString(params, "parentId", "")
 
-			msg := workspace.Message{
+			msg, err := broadcastWorkspaceMessage(ctx, wc, wsID, workspace.Message{
 				Type:      workspace.MessageType(msgType),
 				Content:   content,
 				ParentID:  parentID,
 				Timestamp: time.Now(),
-			}
-
-			// Persist locally.
-			if err := wc.manager.Post(ctx, wsID, msg); err != nil {
+			})
+			if err != nil && msg.ID == "" {
 				return nil, err
 			}
 
-			// Broadcast via GossipSub.
-			if wc.gossip != nil {
-				msg.WorkspaceID = wsID
-				_ = wc.gossip.Publish(ctx, wsID, msg)
-			}
-
-			return map[string]interface{}{
+			result := map[string]interface{}{
 				"posted":      true,
 				"messageId":   msg.ID,
 				"workspaceId": wsID,
-			}, nil
+			}
+			if err != nil {
+				result["broadcastError"] = err.Error()
+			}
+			return result, nil
 		},
 	})
 
@@ -410,16 +475,17 @@ func buildGitTools(wc *wsComponents) []*agent.Tool {
 				// Broadcast commit signal via workspace gossip.
 				if wc.gossip != nil {
 					msg := workspace.Message{
-						Type:      workspace.MessageTypeCommitSignal,
-						SenderDID: wc.localDID,
-						Content:   fmt.Sprintf("pushed bundle (head: %s): %s", hash, message),
+						Type:    workspace.MessageTypeCommitSignal,
+						Content: fmt.Sprintf("pushed bundle (head: %s): %s", hash, message),
 						Metadata: map[string]string{
 							"headCommit": hash,
 							"bundleSize": fmt.Sprintf("%d", len(bundle)),
 						},
 						Timestamp: time.Now(),
 					}
-					_ = wc.gossip.Publish(ctx, wsID, msg)
+					if _, err := broadcastWorkspaceMessage(ctx, wc, wsID, msg); err != nil {
+						result["signalError"] = err.Error()
+					}
 				}
 
 				return result, nil
@@ -532,3 +598,30 @@ func buildGitTools(wc *wsComponents) []*agent.Tool {
 		},
 	}
 }
+
+// broadcastWorkspaceMessage signs a locally authored message, stores it, and
+// publishes it encrypted under the workspace group key. A missing group key
+// is generated or fetched from the key leader first. Publication failures
+// are returned after the message has been stored locally.
+func broadcastWorkspaceMessage(ctx context.Context, wc *wsComponents, wsID string, msg workspace.Message) (workspace.Message, error) {
+	msg, err := wc.manager.Compose(ctx, wsID, msg)
+	if err != nil {
+		return workspace.Message{}, err
+	}
+	if err := wc.manager.Post(ctx, wsID, msg); err != nil {
+		return workspace.Message{}, err
+	}
+
+	if wc.gossip == nil {
+		return msg, nil
+	}
+	if wc.keys != nil {
+		if err := wc.keys.Ensure(ctx, wsID); err != nil {
+			return msg, fmt.Errorf("workspace key: %w", err)
+		}
+	}
+	if err := wc.gossip.Publish(ctx, wsID, msg); err != nil {
+		return msg, err
+	}
+	return msg, nil
+}
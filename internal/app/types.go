@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/langoai/lango/internal/adk"
+	"github.com/langoai/lango/internal/agent"
 	"github.com/langoai/lango/internal/agentmemory"
 	"github.com/langoai/lango/internal/agentregistry"
 	"github.com/langoai/lango/internal/approval"
@@ -144,6 +145,7 @@ type App struct {
 	ProvenanceSessionTree *provenance.SessionTree
 	ProvenanceAttribution *provenance.AttributionService
 	ProvenanceBundle      *provenance.BundleService
+	ProvenanceRewind      *provenance.RewindService
 
 	// MCP Components (optional, external MCP server integration)
 	MCPManager *mcp.ServerManager
@@ -153,10 +155,15 @@ type App struct {
 	HealthRegistry   *health.Registry
 	TokenStore       *token.EntTokenStore
 	TracerShutdown   func(context.Context) error
+	EventJournal     *eventbus.Journal
 
 	// Tool Catalog (built-in tool discovery + dynamic dispatch)
 	ToolCatalog *toolcatalog.Catalog
 
+	// Tools holds every agent tool wrapped with the full middleware chain
+	// (approval, hooks, output management, exec policy, tracing).
+	Tools []*agent.Tool
+
 	// P2P Components (optional)
 	P2PNode            *p2p.Node
 	P2PAgentPool       *agentpool.Pool
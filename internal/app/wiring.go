@@ -18,6 +18,7 @@ import (
 	"github.com/langoai/lango/internal/eventbus"
 	"github.com/langoai/lango/internal/gateway"
 	"github.com/langoai/lango/internal/knowledge"
+	"github.com/langoai/lango/internal/observability"
 	"github.com/langoai/lango/internal/orchestration"
 	"github.com/langoai/lango/internal/prompt"
 	"github.com/langoai/lango/internal/provenance"
@@ -262,6 +263,7 @@ type agentDeps struct {
 	lc           *librarianComponents
 	catalog      *toolcatalog.Catalog
 	p2pc         *p2pComponents
+	mcpc         *mcpComponents
 	eventBus     *eventbus.Bus
 	rls          runledger.RunLedgerStore
 	prov         *provenanceValues
@@ -366,6 +368,14 @@ func initAgent(ctx context.Context, deps *agentDeps) (*adk.Agent, error) {
 			ctxAdapter.WithRunSummaryProvider(&runSummaryProviderAdapter{store: deps.rls})
 		}
 
+		// Wire pinned MCP resources into the external knowledge layer.
+		if deps.mcpc != nil && deps.mcpc.resources != nil {
+			ctxAdapter.WithPinnedResources(&mcpPinnedResourceProvider{
+				cache:  deps.mcpc.resources,
+				budget: cfg.MCP.PinnedResourceTokens,
+			})
+		}
+
 		// Wire in context budget manager.
 		wireBudgetManager(cfg, builder, ctxAdapter)
 
@@ -475,18 +485,7 @@ func initAgent(ctx context.Context, deps *agentDeps) (*adk.Agent, error) {
 
 	// If PII redaction is enabled, wrap with PII-redacting adapter
 	if cfg.Security.Interceptor.Enabled && cfg.Security.Interceptor.RedactPII {
-		redactor := agent.NewPIIRedactor(agent.PIIConfig{
-			RedactEmail:       true,
-			RedactPhone:       true,
-			CustomRegex:       cfg.Security.Interceptor.PIIRegexPatterns,
-			DisabledBuiltins:  cfg.Security.Interceptor.PIIDisabledPatterns,
-			CustomPatterns:    cfg.Security.Interceptor.PIICustomPatterns,
-			PresidioEnabled:   cfg.Security.Interceptor.Presidio.Enabled,
-			PresidioURL:       cfg.Security.Interceptor.Presidio.URL,
-			PresidioThreshold: cfg.Security.Interceptor.Presidio.ScoreThreshold,
-			PresidioLanguage:  cfg.Security.Interceptor.Presidio.Language,
-		})
-		llm = adk.NewPIIRedactingModelAdapter(llm, redactor, scanner)
+		llm = adk.NewPIIRedactingModelAdapter(llm, newPIIRedactor(cfg), scanner)
 		logger().Info("PII redaction interceptor enabled")
 	}
 
@@ -586,6 +585,21 @@ func isolatedAgentNames(specs []orchestration.AgentSpec) []string {
 	return out
 }
 
+// newPIIRedactor creates the PII redactor configured by the security interceptor.
+func newPIIRedactor(cfg *config.Config) *agent.PIIRedactor {
+	return agent.NewPIIRedactor(agent.PIIConfig{
+		RedactEmail:       true,
+		RedactPhone:       true,
+		CustomRegex:       cfg.Security.Interceptor.PIIRegexPatterns,
+		DisabledBuiltins:  cfg.Security.Interceptor.PIIDisabledPatterns,
+		CustomPatterns:    cfg.Security.Interceptor.PIICustomPatterns,
+		PresidioEnabled:   cfg.Security.Interceptor.Presidio.Enabled,
+		PresidioURL:       cfg.Security.Interceptor.Presidio.URL,
+		PresidioThreshold: cfg.Security.Interceptor.Presidio.ScoreThreshold,
+		PresidioLanguage:  cfg.Security.Interceptor.Presidio.Language,
+	})
+}
+
 // buildAgentOptions constructs AgentOption slice from config and knowledge components.
 func buildAgentOptions(cfg *config.Config, kc *knowledgeComponents) []adk.AgentOption {
 	var opts []adk.AgentOption
@@ -610,6 +624,11 @@ func buildAgentOptions(cfg *config.Config, kc *knowledgeComponents) []adk.AgentO
 		opts = append(opts, adk.WithAgentErrorFixProvider(kc.engine))
 	}
 
+	// GenAI agent spans with sub-agent delegation links.
+	if cfg.Observability.Tracing.Enabled {
+		opts = append(opts, adk.WithAgentTracer(observability.Tracer()))
+	}
+
 	return opts
 }
 
@@ -701,6 +720,7 @@ func initGateway(cfg *config.Config, adkAgent *adk.Agent, store session.Store, a
 		IdleTimeout:      idle,
 		MaxTimeout:       ceiling,
 		RunLedger:        cfg.RunLedger,
+		Cockpit:          cfg.Server.Cockpit,
 	}, adkAgent, nil, store, auth)
 }
 
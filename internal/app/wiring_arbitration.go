@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/economy/escrow"
+	"github.com/langoai/lango/internal/economy/escrow/arbitration"
+	"github.com/langoai/lango/internal/eventbus"
+	"github.com/langoai/lango/internal/p2p/arbitrationproto"
+	"github.com/langoai/lango/internal/p2p/identity"
+	"github.com/langoai/lango/internal/provenance"
+)
+
+// initArbitration creates the escrow arbitration service and registers its
+// P2P protocol handler. Returns nil when arbitration is disabled or the P2P
+// runtime is unavailable.
+func initArbitration(cfg *config.Config, p2pc *p2pComponents, ee *escrow.Engine, bus *eventbus.Bus) *arbitration.Service {
+	ac := cfg.Economy.Escrow.Arbitration
+	if !ac.Enabled {
+		return nil
+	}
+	if p2pc == nil || p2pc.node == nil || p2pc.sessions == nil || p2pc.identity == nil || p2pc.signer == nil {
+		logger().Warn("economy: arbitration requires P2P identity and sessions, skipping")
+		return nil
+	}
+
+	did, err := p2pc.identity.DID(context.Background())
+	if err != nil || did == nil {
+		logger().Warnw("economy: arbitration local DID unavailable, skipping", "error", err)
+		return nil
+	}
+
+	var reputation arbitration.ReputationFunc
+	if p2pc.reputation != nil {
+		rep := p2pc.reputation
+		reputation = func(ctx context.Context, peerDID string) (float64, error) {
+			return rep.GetScore(ctx, peerDID)
+		}
+	}
+
+	verifiers := arbitrationVerifiers(p2pc.bundles)
+	svc := arbitration.NewService(arbitration.Config{
+		LocalDID: did.ID,
+		Escrow:   ee,
+		Selector: arbitration.NewSelector(arbitration.SelectorConfig{
+			Reputation: reputation,
+			Conflict:   arbitration.EscrowConflicts(ee),
+			MinScore:   ac.MinArbiterScore,
+		}),
+		Candidates: func() []string {
+			if p2pc.agentPool == nil {
+				return nil
+			}
+			agents := p2pc.agentPool.List()
+			dids := make([]string, 0, len(agents))
+			for _, a := range agents {
+				dids = append(dids, a.DID)
+			}
+			return dids
+		},
+		Transport:      arbitrationTransport(p2pc),
+		Signer:         p2pc.signer,
+		Verifiers:      verifiers,
+		VerifyBundle:   arbitrationBundleVerifier(p2pc.bundles),
+		MaxNominations: ac.MaxNominations,
+		Bus:            bus,
+		Logger:         logger(),
+	})
+
+	handler := arbitrationproto.NewHandler(arbitrationproto.HandlerConfig{
+		Validator: func(token string) (string, bool) {
+			return p2pc.sessions.GetByToken(token)
+		},
+		Handle: svc.HandleMessage,
+		Logger: logger().Desugar(),
+	})
+	p2pc.node.SetStreamHandler(arbitrationproto.ProtocolID, handler.StreamHandler())
+	logger().Info("economy: arbitration service initialized")
+	return svc
+}
+
+// arbitrationVerifiers reuses the workspace signature verifiers, which cover
+// both v1 (secp256k1) and v2 (Ed25519 bundle) DIDs.
+func arbitrationVerifiers(bundles identity.BundleResolver) map[string]arbitration.SignatureVerifyFunc {
+	out := make(map[string]arbitration.SignatureVerifyFunc)
+	for alg, fn := range workspaceVerifiers(bundles) {
+		out[alg] = arbitration.SignatureVerifyFunc(fn)
+	}
+	return out
+}
+
+// arbitrationBundleVerifier checks the signature of provenance bundles
+// attached to dispute evidence without importing them.
+func arbitrationBundleVerifier(bundles identity.BundleResolver) arbitration.BundleVerifier {
+	verifiers := make(map[string]provenance.SignatureVerifyFunc)
+	for alg, fn := range workspaceVerifiers(bundles) {
+		verifiers[alg] = provenance.SignatureVerifyFunc(fn)
+	}
+	bs := provenance.NewBundleService(nil, nil, nil, nil, verifiers)
+
+	return func(_ context.Context, _ string, data []byte) error {
+		var bundle provenance.ProvenanceBundle
+		if err := json.Unmarshal(data, &bundle); err != nil {
+			return fmt.Errorf("unmarshal bundle: %w", err)
+		}
+		return bs.Verify(&bundle)
+	}
+}
+
+// arbitrationTransport sends arbitration messages over the dedicated P2P
+// protocol using the handshake session with the target peer.
+func arbitrationTransport(p2pc *p2pComponents) arbitration.Transport {
+	return func(ctx context.Context, peerDID string, msg arbitration.Message) (*arbitration.Reply, error) {
+		sess := p2pc.sessions.Get(peerDID)
+		if sess == nil {
+			return nil, fmt.Errorf("no active session with %s", peerDID)
+		}
+		target, err := identity.ParseDID(peerDID)
+		if err != nil {
+			return nil, fmt.Errorf("parse peer DID: %w", err)
+		}
+		return arbitrationproto.Send(ctx, p2pc.node.Host(), target.PeerID, sess.Token, msg)
+	}
+}
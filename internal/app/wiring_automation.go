@@ -10,6 +10,7 @@ import (
 	"github.com/langoai/lango/internal/config"
 	cronpkg "github.com/langoai/lango/internal/cron"
 	"github.com/langoai/lango/internal/runledger"
+	"github.com/langoai/lango/internal/security"
 	"github.com/langoai/lango/internal/session"
 	"github.com/langoai/lango/internal/turnrunner"
 	"github.com/langoai/lango/internal/turntrace"
@@ -40,7 +41,7 @@ func (r *agentRunnerAdapter) Run(ctx context.Context, sessionKey, promptText str
 }
 
 // initCron creates the cron scheduling system if enabled.
-func initCron(cfg *config.Config, store session.Store, app *App) *cronpkg.Scheduler {
+func initCron(cfg *config.Config, store session.Store, secrets *security.SecretsStore, app *App) *cronpkg.Scheduler {
 	if !cfg.Cron.Enabled {
 		logger().Info("cron scheduling disabled")
 		return nil
@@ -74,12 +75,17 @@ func initCron(cfg *config.Config, store session.Store, app *App) *cronpkg.Schedu
 		defaultJobTimeout = 30 * time.Minute
 	}
 
-	scheduler := cronpkg.New(cronStore, executor, cronpkg.SchedulerConfig{
+	schedCfg := cronpkg.SchedulerConfig{
 		Timezone:       tz,
 		MaxJobs:        maxJobs,
 		DefaultTimeout: defaultJobTimeout,
 		Logger:         logger(),
-	})
+		Bus:            app.EventBus,
+	}
+	if secrets != nil {
+		schedCfg.Secrets = secrets
+	}
+	scheduler := cronpkg.New(cronStore, executor, schedCfg)
 
 	logger().Infow("cron scheduling initialized",
 		"timezone", tz,
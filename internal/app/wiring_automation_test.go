@@ -36,7 +36,7 @@ func TestInitCron_DisabledReturnsNil(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Cron.Enabled = false
 
-	result := initCron(cfg, &stubSessionStore{}, &App{Config: cfg})
+	result := initCron(cfg, &stubSessionStore{}, nil, &App{Config: cfg})
 
 	assert.Nil(t, result, "expected nil scheduler when cron is disabled")
 }
@@ -45,7 +45,7 @@ func TestInitCron_NonEntStoreReturnsNil(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Cron.Enabled = true
 
-	result := initCron(cfg, &stubSessionStore{}, &App{Config: cfg})
+	result := initCron(cfg, &stubSessionStore{}, nil, &App{Config: cfg})
 
 	assert.Nil(t, result, "expected nil scheduler when store is not EntStore")
 }
@@ -76,7 +76,7 @@ func TestInitCron_DisabledBranch_TableDriven(t *testing.T) {
 			cfg := config.DefaultConfig()
 			cfg.Cron.Enabled = tt.giveCronOn
 
-			result := initCron(cfg, tt.giveStore, &App{Config: cfg})
+			result := initCron(cfg, tt.giveStore, nil, &App{Config: cfg})
 
 			if tt.wantNil {
 				assert.Nil(t, result)
@@ -10,6 +10,7 @@ import (
 	"github.com/langoai/lango/internal/contract"
 	"github.com/langoai/lango/internal/economy/budget"
 	"github.com/langoai/lango/internal/economy/escrow"
+	"github.com/langoai/lango/internal/economy/escrow/arbitration"
 	"github.com/langoai/lango/internal/economy/escrow/hub"
 	"github.com/langoai/lango/internal/economy/escrow/sentinel"
 	"github.com/langoai/lango/internal/economy/negotiation"
@@ -30,6 +31,7 @@ type economyComponents struct {
 	escrowEngine      *escrow.Engine
 	escrowSettler     escrow.SettlementExecutor
 	sentinelEngine    *sentinel.Engine
+	arbitration       *arbitration.Service
 	eventMonitor      *hub.EventMonitor
 	danglingDetector  *hub.DanglingDetector
 }
@@ -228,7 +230,10 @@ func initEconomy(cfg *config.Config, p2pc *p2pComponents, pc *paymentComponents,
 			logger().Info("economy: sentinel engine initialized")
 		}
 
-		// 5b. On-chain event reconciliation (requires on-chain mode + RPC).
+		// 5b. Arbitrated dispute resolution over P2P.
+		ec.arbitration = initArbitration(cfg, p2pc, escrowEngine, bus)
+
+		// 5c. On-chain event reconciliation (requires on-chain mode + RPC).
 		oc := cfg.Economy.Escrow.OnChain
 		if oc.Enabled && pc != nil && pc.rpcClient != nil {
 			hubAddr := common.HexToAddress(oc.HubAddress)
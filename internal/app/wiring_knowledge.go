@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/langoai/lango/internal/adk"
@@ -195,16 +194,7 @@ func initSkills(cfg *config.Config, baseTools []*agent.Tool, bus *eventbus.Bus)
 		return nil
 	}
 
-	dir := cfg.Skill.SkillsDir
-	if dir == "" {
-		dir = "~/.lango/skills"
-	}
-	// Expand ~ to home directory.
-	if len(dir) > 1 && dir[:2] == "~/" {
-		if home, err := os.UserHomeDir(); err == nil {
-			dir = filepath.Join(home, dir[2:])
-		}
-	}
+	dir := skill.ResolveSkillsDir(cfg.Skill.SkillsDir)
 
 	sLogger := logger()
 	store := skill.NewFileSkillStore(dir, sLogger)
@@ -298,17 +288,30 @@ func initConversationAnalysis(cfg *config.Config, sv *supervisor.Supervisor, sto
 	return buf
 }
 
-// providerTextGenerator adapts a supervisor.ProviderProxy to the llm.TextGenerator interface.
+// providerTextGenerator adapts a supervisor.ProviderProxy to the llm.TextGenerator
+// and llm.SchemaGenerator interfaces.
 type providerTextGenerator struct {
 	proxy *supervisor.ProviderProxy
 }
 
 func (g *providerTextGenerator) GenerateText(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	return g.generate(ctx, systemPrompt, userPrompt, nil)
+}
+
+// GenerateWithSchema constrains the reply to format using the provider's
+// native structured output mode. Endpoints that reject the format return an
+// error, and llm.GenerateStructured falls back to the schema in the prompt.
+func (g *providerTextGenerator) GenerateWithSchema(ctx context.Context, systemPrompt, userPrompt string, format provider.ResponseFormat) (string, error) {
+	return g.generate(ctx, systemPrompt, userPrompt, &format)
+}
+
+func (g *providerTextGenerator) generate(ctx context.Context, systemPrompt, userPrompt string, format *provider.ResponseFormat) (string, error) {
 	params := provider.GenerateParams{
 		Messages: []provider.Message{
 			{Role: "system", Content: systemPrompt},
 			{Role: "user", Content: userPrompt},
 		},
+		ResponseFormat: format,
 	}
 
 	stream, err := g.proxy.Generate(ctx, params)
@@ -9,17 +9,23 @@ import (
 	"github.com/langoai/lango/internal/agent"
 	"github.com/langoai/lango/internal/config"
 	"github.com/langoai/lango/internal/eventbus"
+	"github.com/langoai/lango/internal/knowledge"
 	"github.com/langoai/lango/internal/mcp"
+	"github.com/langoai/lango/internal/security"
+	"github.com/langoai/lango/internal/toolparam"
 )
 
 // mcpComponents holds the results of MCP initialization.
 type mcpComponents struct {
-	manager *mcp.ServerManager
-	tools   []*agent.Tool
+	manager   *mcp.ServerManager
+	resources *mcp.ResourceCache
+	tools     []*agent.Tool
 }
 
 // initMCP creates the MCP server manager and connects to configured servers.
-func initMCP(cfg *config.Config, bus *eventbus.Bus) *mcpComponents {
+// secrets may be nil; {{secret:...}} references in server env or headers then
+// fail to resolve and the affected server is not started.
+func initMCP(cfg *config.Config, bus *eventbus.Bus, secrets *security.SecretsStore) *mcpComponents {
 	if !cfg.MCP.Enabled {
 		logger().Info("MCP integration disabled")
 		return nil
@@ -56,6 +62,14 @@ func initMCP(cfg *config.Config, bus *eventbus.Bus) *mcpComponents {
 	if bus != nil {
 		mgr.SetEventBus(bus)
 	}
+	if secrets != nil {
+		mgr.SetSecretResolver(secrets)
+		mgr.SetTokenStore(secrets)
+	}
+
+	// The resource cache registers for update notifications, so it must
+	// exist before connections are created.
+	resources := mcp.NewResourceCache(mgr)
 
 	// Connect to all servers (best-effort, failures are logged)
 	errs := mgr.ConnectAll(context.Background())
@@ -77,14 +91,37 @@ func initMCP(cfg *config.Config, bus *eventbus.Bus) *mcpComponents {
 	)
 
 	return &mcpComponents{
-		manager: mgr,
-		tools:   tools,
+		manager:   mgr,
+		resources: resources,
+		tools:     tools,
+	}
+}
+
+// mcpPinnedResourceProvider adapts the MCP resource cache to the context
+// adapter's pinned resource provider.
+type mcpPinnedResourceProvider struct {
+	cache  *mcp.ResourceCache
+	budget int
+}
+
+func (p *mcpPinnedResourceProvider) PinnedResources(ctx context.Context) ([]knowledge.ContextItem, error) {
+	pinned := p.cache.Pinned(ctx, p.budget)
+	items := make([]knowledge.ContextItem, 0, len(pinned))
+	for _, r := range pinned {
+		items = append(items, knowledge.ContextItem{
+			Layer:    knowledge.LayerExternalKnowledge,
+			Key:      r.Name,
+			Content:  r.Content,
+			Category: "mcp_resource",
+			Source:   "mcp://" + r.ServerName + "/" + r.URI,
+		})
 	}
+	return items, nil
 }
 
 // buildMCPManagementTools creates meta-tools for managing MCP servers at runtime.
-func buildMCPManagementTools(mgr *mcp.ServerManager) []*agent.Tool {
-	return []*agent.Tool{
+func buildMCPManagementTools(mgr *mcp.ServerManager, resources *mcp.ResourceCache) []*agent.Tool {
+	tools := []*agent.Tool{
 		{
 			Name:        "mcp_status",
 			Description: "Show connection status of all MCP servers.",
@@ -142,4 +179,164 @@ func buildMCPManagementTools(mgr *mcp.ServerManager) []*agent.Tool {
 			},
 		},
 	}
+	tools = append(tools, buildMCPResourceTools(mgr, resources)...)
+	return append(tools, buildMCPPromptTools(mgr)...)
+}
+
+// buildMCPResourceTools creates tools for listing and reading MCP resources.
+func buildMCPResourceTools(mgr *mcp.ServerManager, cache *mcp.ResourceCache) []*agent.Tool {
+	return []*agent.Tool{
+		{
+			Name:        "mcp_resources",
+			Description: "List resources (documents, data) exposed by MCP servers. Optional: pass 'server' to filter by server name.",
+			Parameters: map[string]interface{}{
+				"server": map[string]interface{}{
+					"type":        "string",
+					"description": "Filter resources by server name (optional)",
+				},
+			},
+			SafetyLevel: agent.SafetyLevelSafe,
+			Capability: agent.ToolCapability{
+				Category: "mcp",
+				Activity: agent.ActivityQuery,
+				ReadOnly: true,
+			},
+			Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				serverFilter, _ := params["server"].(string)
+				var lines []string
+				for _, dr := range mgr.AllResources() {
+					if serverFilter != "" && dr.ServerName != serverFilter {
+						continue
+					}
+					line := fmt.Sprintf("%s: %s (%s)", dr.ServerName, dr.Resource.URI, dr.Resource.Name)
+					if dr.Resource.Description != "" {
+						line += " - " + dr.Resource.Description
+					}
+					lines = append(lines, line)
+				}
+				if len(lines) == 0 {
+					return "No MCP resources available.", nil
+				}
+				return strings.Join(lines, "\n"), nil
+			},
+		},
+		{
+			Name:        "mcp_read_resource",
+			Description: "Read the content of an MCP resource. Set 'subscribe' to keep it cached and refreshed on server change notifications.",
+			Parameters: map[string]interface{}{
+				"server": map[string]interface{}{
+					"type":        "string",
+					"description": "MCP server name",
+					"required":    true,
+				},
+				"uri": map[string]interface{}{
+					"type":        "string",
+					"description": "Resource URI",
+					"required":    true,
+				},
+				"subscribe": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Subscribe to change notifications and cache the content",
+				},
+			},
+			SafetyLevel: agent.SafetyLevelSafe,
+			Capability: agent.ToolCapability{
+				Category: "mcp",
+				Activity: agent.ActivityRead,
+				ReadOnly: true,
+			},
+			Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				server, err := toolparam.RequireString(params, "server")
+				if err != nil {
+					return nil, err
+				}
+				uri, err := toolparam.RequireString(params, "uri")
+				if err != nil {
+					return nil, err
+				}
+				subscribe, _ := params["subscribe"].(bool)
+				return cache.Read(ctx, server, uri, subscribe)
+			},
+		},
+	}
+}
+
+// buildMCPPromptTools creates tools for listing and rendering MCP prompts,
+// so the agent can invoke server prompt templates like skills.
+func buildMCPPromptTools(mgr *mcp.ServerManager) []*agent.Tool {
+	return []*agent.Tool{
+		{
+			Name:        "mcp_prompts",
+			Description: "List prompt templates exposed by MCP servers with their arguments.",
+			Parameters:  nil,
+			SafetyLevel: agent.SafetyLevelSafe,
+			Capability: agent.ToolCapability{
+				Category: "mcp",
+				Activity: agent.ActivityQuery,
+				ReadOnly: true,
+			},
+			Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				var lines []string
+				for _, dp := range mgr.AllPrompts() {
+					var args []string
+					for _, a := range dp.Prompt.Arguments {
+						if a.Required {
+							args = append(args, a.Name+"*")
+						} else {
+							args = append(args, a.Name)
+						}
+					}
+					lines = append(lines, fmt.Sprintf("%s/%s(%s): %s",
+						dp.ServerName, dp.Prompt.Name, strings.Join(args, ", "), dp.Prompt.Description))
+				}
+				if len(lines) == 0 {
+					return "No MCP prompts available.", nil
+				}
+				return strings.Join(lines, "\n"), nil
+			},
+		},
+		{
+			Name:        "mcp_get_prompt",
+			Description: "Render an MCP prompt template with arguments and return its instructions to follow.",
+			Parameters: map[string]interface{}{
+				"server": map[string]interface{}{
+					"type":        "string",
+					"description": "MCP server name",
+					"required":    true,
+				},
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Prompt name",
+					"required":    true,
+				},
+				"arguments": map[string]interface{}{
+					"type":        "object",
+					"description": "Prompt arguments as string key/value pairs",
+				},
+			},
+			SafetyLevel: agent.SafetyLevelSafe,
+			Capability: agent.ToolCapability{
+				Category: "mcp",
+				Activity: agent.ActivityRead,
+				ReadOnly: true,
+			},
+			Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				server, err := toolparam.RequireString(params, "server")
+				if err != nil {
+					return nil, err
+				}
+				name, err := toolparam.RequireString(params, "name")
+				if err != nil {
+					return nil, err
+				}
+				args := make(map[string]string)
+				if raw, ok := params["arguments"].(map[string]interface{}); ok {
+					for k, v := range raw {
+						args[k] = fmt.Sprint(v)
+					}
+				}
+				return mgr.GetPrompt(ctx, server, name, args)
+			},
+		},
+	}
 }
@@ -8,9 +8,13 @@ import (
 	"github.com/langoai/lango/internal/config"
 	"github.com/langoai/lango/internal/ent"
 	"github.com/langoai/lango/internal/eventbus"
+	"github.com/langoai/lango/internal/lifecycle"
 	"github.com/langoai/lango/internal/observability"
+	"github.com/langoai/lango/internal/observability/audit"
+	"github.com/langoai/lango/internal/observability/genai"
 	"github.com/langoai/lango/internal/observability/health"
 	"github.com/langoai/lango/internal/observability/token"
+	"github.com/langoai/lango/internal/p2p/identity"
 	"github.com/langoai/lango/internal/toolchain"
 )
 
@@ -22,6 +26,42 @@ type observabilityComponents struct {
 	tokenStore     *token.EntTokenStore
 	promExporter   *observability.PrometheusExporter
 	tracerShutdown func(context.Context) error
+	alertRouter    *alerting.DeliveryRouter
+}
+
+// genAIContentCapture returns the content capture settings for GenAI spans.
+// Captured content is passed through the same PII redactor as the security
+// interceptor.
+func genAIContentCapture(cfg *config.Config) genai.ContentCapture {
+	if !cfg.Observability.Tracing.CaptureContent {
+		return genai.ContentCapture{}
+	}
+	return genai.ContentCapture{Enabled: true, Redact: newPIIRedactor(cfg).RedactInput}
+}
+
+// initEventJournal opens the event bus journal and attaches it to the bus.
+// It runs before any module subscribes or publishes so the journal sees every
+// event. A journal that cannot be opened is logged and skipped.
+func initEventJournal(cfg *config.Config, bus *eventbus.Bus) *eventbus.Journal {
+	jc := cfg.Observability.EventJournal
+	if !jc.Enabled {
+		return nil
+	}
+
+	journal, err := eventbus.OpenJournal(jc.Dir, eventbus.JournalOptions{
+		SegmentSize: int64(jc.SegmentSizeMB) << 20,
+		MaxSegments: jc.MaxSegments,
+		Sync:        jc.Sync,
+	})
+	if err != nil {
+		logger().Warnw("event journal disabled", "dir", jc.Dir, "error", err)
+		return nil
+	}
+	bus.SetJournal(journal, func(err error) {
+		logger().Warnw("event journal append", "error", err)
+	})
+	logger().Infow("event journal enabled", "dir", jc.Dir, "last_seq", journal.LastSeq())
+	return journal
 }
 
 // initObservability creates observability components if enabled.
@@ -122,7 +162,7 @@ func initObservability(cfg *config.Config, dbClient *ent.Client, bus *eventbus.B
 
 		// 7b. External alert delivery channels (webhook, etc.)
 		if len(cfg.Alerting.Delivery) > 0 {
-			alerting.NewDeliveryRouter(bus, cfg.Alerting.Delivery)
+			oc.alertRouter = alerting.NewDeliveryRouter(bus, cfg.Alerting.Delivery)
 			logger().Infow("observability: alert delivery router wired", "channels", len(cfg.Alerting.Delivery))
 		}
 	}
@@ -148,3 +188,30 @@ func wireModelAdapterTokenUsage(adapter *adk.ModelAdapter, bus *eventbus.Bus) {
 	}
 }
 
+// wireAuditCheckpoints registers a lifecycle component that periodically
+// signs the audit hash chain head with the wallet key. Without a wallet the
+// chain is still written, but only row-level edits are detectable.
+func wireAuditCheckpoints(app *App, cfg *config.Config, client *ent.Client) {
+	if app.WalletProvider == nil {
+		logger().Info("audit checkpoints disabled (no wallet configured)")
+		return
+	}
+	ctx := context.Background()
+	pub, err := app.WalletProvider.PublicKey(ctx)
+	if err != nil {
+		logger().Warnw("audit checkpoints disabled", "error", err)
+		return
+	}
+	did, err := identity.DIDFromPublicKey(pub)
+	if err != nil {
+		logger().Warnw("audit checkpoints disabled", "error", err)
+		return
+	}
+	if pinned := cfg.Observability.Audit.CheckpointSigner; pinned != "" && pinned != did.ID {
+		logger().Warnw("audit checkpoints are signed by the wallet DID, not the configured checkpoint signer; verify will reject them",
+			"signer", did.ID, "checkpointSigner", pinned)
+	}
+	cp := audit.NewCheckpointer(client, did.ID, &walletBundleSigner{wp: app.WalletProvider}, cfg.Observability.Audit.CheckpointInterval)
+	app.registry.Register(cp, lifecycle.PriorityCore)
+	logger().Infow("audit checkpoints enabled", "signer", did.ID)
+}
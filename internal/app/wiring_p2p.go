@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ed25519"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -150,6 +151,8 @@ type p2pComponents struct {
 	fw             *firewall.Firewall
 	gossip         *discovery.GossipService
 	identity       didProvider // DID() — WalletDIDProvider or BundleProvider
+	signer         handshake.Signer            // signs as identity's DID
+	bundles        *identity.MemoryBundleCache // peer bundles learned during handshake
 	handler        *p2pproto.Handler
 	payGate        *paygate.Gate
 	reputation     *reputation.Store
@@ -163,16 +166,18 @@ type p2pComponents struct {
 	kemEnabled     bool // PQ KEM handshake enabled
 }
 
-// initP2P creates the P2P networking components if enabled.
-func initP2P(cfg *config.Config, wp wallet.WalletProvider, pc *paymentComponents, dbClient *ent.Client, secrets *security.SecretsStore, bus *eventbus.Bus, identityKey ed25519.PrivateKey, pqSigningKeySeed []byte, langoDir string) *p2pComponents {
+// initP2P creates the P2P networking components if enabled. Optional parts
+// that fail are skipped with a warning; an error means the configured
+// security guarantees cannot be met and startup must stop.
+func initP2P(cfg *config.Config, wp wallet.WalletProvider, pc *paymentComponents, dbClient *ent.Client, secrets *security.SecretsStore, bus *eventbus.Bus, identityKey ed25519.PrivateKey, pqSigningKeySeed []byte, langoDir string) (*p2pComponents, error) {
 	if !cfg.P2P.Enabled {
 		logger().Info("P2P networking disabled")
-		return nil
+		return nil, nil
 	}
 
 	if wp == nil {
 		logger().Warn("P2P networking requires wallet provider, skipping")
-		return nil
+		return nil, nil
 	}
 
 	pLogger := logger()
@@ -181,7 +186,7 @@ func initP2P(cfg *config.Config, wp wallet.WalletProvider, pc *paymentComponents
 	node, err := p2p.NewNode(cfg.P2P, pLogger, secrets)
 	if err != nil {
 		pLogger.Warnw("P2P node creation failed, skipping", "error", err)
-		return nil
+		return nil, nil
 	}
 
 	// Create identity provider.
@@ -221,11 +226,14 @@ func initP2P(cfg *config.Config, wp wallet.WalletProvider, pc *paymentComponents
 	sessions, err := handshake.NewSessionStore(sessionTTL)
 	if err != nil {
 		pLogger.Warnw("P2P session store creation failed, skipping", "error", err)
-		return nil
+		return nil, nil
 	}
 
 	// Initialize ZKP prover (optional).
-	zkProver := initZKP(cfg)
+	zkProver, err := initZKP(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create nonce cache for replay protection (TTL = 2 * handshake timeout).
 	nonceTTL := 2 * cfg.P2P.HandshakeTimeout
@@ -652,9 +660,10 @@ func initP2P(cfg *config.Config, wp wallet.WalletProvider, pc *paymentComponents
 
 		// Find a valid session token for this peer by scanning the agent pool
 		// to resolve PeerID → DID, then looking up the session.
-		var token string
+		var token, did string
 		for _, a := range pool.List() {
 			if a.PeerID == peerID {
+				did = a.DID
 				if sess := sessions.Get(a.DID); sess != nil {
 					token = sess.Token
 				}
@@ -665,29 +674,17 @@ func initP2P(cfg *config.Config, wp wallet.WalletProvider, pc *paymentComponents
 			return nil, fmt.Errorf("no active session for peer %s", peerID)
 		}
 
-		// Open a stream to the remote peer and send a tool invocation request.
-		s, err := node.Host().NewStream(ctx, pid, libp2pproto.ID(p2pproto.ProtocolID))
-		if err != nil {
-			return nil, fmt.Errorf("open stream to %s: %w", peerID, err)
-		}
-		defer s.Close()
-
-		payload := map[string]interface{}{
-			"toolName": toolName,
-			"params":   params,
-		}
-		resp, err := p2pproto.SendRequest(ctx, s, p2pproto.RequestToolInvoke, token, payload)
-		if err != nil {
-			return nil, fmt.Errorf("invoke %s on peer %s: %w", toolName, peerID, err)
-		}
-		if resp.Status != p2pproto.ResponseStatusOK {
-			errMsg := resp.Error
-			if errMsg == "" {
-				errMsg = "unknown remote error"
-			}
-			return nil, fmt.Errorf("remote tool %s: %s", toolName, errMsg)
-		}
-		return resp.Result, nil
+		// Invoke through the remote agent client, which runs long tasks as
+		// streamed jobs and falls back to a plain tool_invoke for old peers.
+		remote := p2pproto.NewRemoteAgent(p2pproto.RemoteAgentConfig{
+			Name:         peerID,
+			DID:          did,
+			PeerID:       pid,
+			SessionToken: token,
+			Host:         node.Host(),
+			Logger:       pLogger,
+		})
+		return remote.InvokeTool(ctx, toolName, params)
 	}
 	coord = team.NewCoordinator(team.CoordinatorConfig{
 		Pool:     pool,
@@ -788,6 +785,8 @@ func initP2P(cfg *config.Config, wp wallet.WalletProvider, pc *paymentComponents
 		fw:          fw,
 		gossip:      gossip,
 		identity:    localID,
+		signer:      primarySigner,
+		bundles:     bundleCache,
 		handler:     handler,
 		payGate:     pg,
 		reputation:  repStore,
@@ -799,7 +798,7 @@ func initP2P(cfg *config.Config, wp wallet.WalletProvider, pc *paymentComponents
 		provider:      provider,
 		healthMonitor: healthMon,
 		kemEnabled:    cfg.P2P.EnablePQHandshake,
-	}
+	}, nil
 }
 
 // payGateAdapter adapts paygate.Gate to protocol.PayGateChecker.
@@ -836,10 +835,17 @@ func (a *payGateAdapter) Check(peerDID, toolName string, payload map[string]inte
 	return pgr, nil
 }
 
-// initZKP creates ZKP components if enabled.
-func initZKP(cfg *config.Config) *zkp.ProverService {
+// Release drops the deferred post-pay entry of an invocation that did not complete.
+func (a *payGateAdapter) Release(settlementID string) {
+	a.gate.Ledger().Remove(settlementID)
+}
+
+// initZKP creates ZKP components if enabled. Once zkHandshake or
+// zkAttestation is on, a prover that cannot start (e.g. an unsafe SRS
+// outside a local chain) is an error rather than a silent downgrade.
+func initZKP(cfg *config.Config) (*zkp.ProverService, error) {
 	if !cfg.P2P.ZKHandshake && !cfg.P2P.ZKAttestation {
-		return nil
+		return nil, nil
 	}
 
 	prover, err := zkp.NewProverService(zkp.Config{
@@ -848,10 +854,15 @@ func initZKP(cfg *config.Config) *zkp.ProverService {
 		SRSMode:  zkp.SRSMode(cfg.P2P.ZKP.SRSMode),
 		SRSPath:  cfg.P2P.ZKP.SRSPath,
 		Logger:   logger(),
+
+		DevNetwork: wallet.IsDevNetwork(cfg.Payment.Network.ChainID),
 	})
+	if errors.Is(err, zkp.ErrUnsafeSRS) {
+		return nil, fmt.Errorf("init ZKP on chain %d: %w (run 'lango p2p zkp ceremony' and set p2p.zkp.srsMode to file, or disable p2p.zkHandshake and p2p.zkAttestation)",
+			cfg.Payment.Network.ChainID, err)
+	}
 	if err != nil {
-		logger().Warnw("ZKP prover init error, skipping", "error", err)
-		return nil
+		return nil, fmt.Errorf("init ZKP prover: %w", err)
 	}
 
 	// Compile all 4 circuits.
@@ -874,5 +885,5 @@ func initZKP(cfg *config.Config) *zkp.ProverService {
 		"scheme", prover.Scheme(),
 		"circuits", len(circuitDefs),
 	)
-	return prover
+	return prover, nil
 }
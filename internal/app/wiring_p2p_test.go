@@ -6,7 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/langoai/lango/internal/config"
 	"github.com/langoai/lango/internal/p2p/handshake"
+	"github.com/langoai/lango/internal/p2p/zkp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -185,3 +187,25 @@ func TestApprovalFnApproveAboveMinScore(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, approved, "peer above min trust score should be approved")
 }
+
+func TestInitZKP_RefusesUnsafeSRSOnPublicChain(t *testing.T) {
+	t.Parallel()
+
+	// ZK handshake and attestation on with the default unsafe SRS on Base
+	// Sepolia; config validation rejects this before startup.
+	cfg := config.DefaultConfig()
+	cfg.P2P.ZKHandshake = true
+	cfg.P2P.ZKAttestation = true
+	cfg.P2P.ZKP.ProofCacheDir = t.TempDir()
+
+	prover, err := initZKP(cfg)
+	assert.ErrorIs(t, err, zkp.ErrUnsafeSRS)
+	assert.Nil(t, prover)
+
+	// With both ZK features off there is nothing to refuse.
+	cfg.P2P.ZKHandshake = false
+	cfg.P2P.ZKAttestation = false
+	prover, err = initZKP(cfg)
+	assert.NoError(t, err)
+	assert.Nil(t, prover)
+}
@@ -1,10 +1,18 @@
 package app
 
 import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 
 	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/economy/pricing"
+	"github.com/langoai/lango/internal/ent"
+	"github.com/langoai/lango/internal/p2p/settlement"
 	"github.com/langoai/lango/internal/payment"
+	"github.com/langoai/lango/internal/payment/contracts"
 	"github.com/langoai/lango/internal/security"
 	"github.com/langoai/lango/internal/session"
 	"github.com/langoai/lango/internal/wallet"
@@ -149,3 +157,82 @@ func initX402(cfg *config.Config, secrets *security.SecretsStore, limiter wallet
 		interceptor: interceptor,
 	}
 }
+
+// initX402Seller creates the X402 seller middleware for the configured paid
+// routes. Prices come from the economy pricing engine when it is enabled;
+// otherwise a standalone engine holds the route base prices.
+func initX402Seller(cfg *config.Config, pc *paymentComponents, engine *pricing.Engine, db *ent.Client) *x402pkg.Seller {
+	sellerCfg := cfg.Payment.X402.Seller
+	if !sellerCfg.Enabled || len(sellerCfg.Routes) == 0 {
+		return nil
+	}
+	if pc == nil || pc.rpcClient == nil || db == nil {
+		logger().Warn("X402 seller requires payment RPC and database, skipping")
+		return nil
+	}
+
+	if engine == nil {
+		var err error
+		engine, err = pricing.New(config.DynamicPricingConfig{})
+		if err != nil {
+			logger().Warnw("X402 seller pricing init", "error", err)
+			return nil
+		}
+	}
+
+	routes := make([]x402pkg.Route, 0, len(sellerCfg.Routes))
+	for _, rt := range sellerCfg.Routes {
+		if err := engine.SetBasePriceFromString(rt.Path, rt.Price); err != nil {
+			logger().Warnw("X402 seller route price", "path", rt.Path, "error", err)
+			return nil
+		}
+		routes = append(routes, x402pkg.Route{Method: rt.Method, Path: rt.Path, Description: rt.Description})
+	}
+
+	usdcAddr := common.HexToAddress(cfg.Payment.Network.USDCContract)
+	if cfg.Payment.Network.USDCContract == "" {
+		addr, err := contracts.LookupUSDC(pc.chainID)
+		if err != nil {
+			logger().Warnw("X402 seller USDC lookup", "error", err)
+			return nil
+		}
+		usdcAddr = addr
+	}
+
+	payTo := sellerCfg.PayTo
+	if payTo == "" {
+		addr, err := pc.wallet.Address(context.Background())
+		if err != nil {
+			logger().Warnw("X402 seller wallet address", "error", err)
+			return nil
+		}
+		payTo = addr
+	}
+
+	settler := settlement.New(settlement.Config{
+		Wallet:    pc.wallet,
+		RPCClient: pc.rpcClient,
+		DBClient:  db,
+		ChainID:   pc.chainID,
+		USDCAddr:  usdcAddr,
+		Logger:    logger(),
+	})
+
+	seller := x402pkg.NewSeller(x402pkg.SellerConfig{
+		ChainID:    pc.chainID,
+		Asset:      usdcAddr,
+		PayTo:      common.HexToAddress(payTo),
+		MaxTimeout: time.Duration(sellerCfg.MaxTimeoutSeconds) * time.Second,
+		Routes:     routes,
+		Quoter:     engine,
+		Settler:    settler,
+		Logger:     logger(),
+	})
+
+	logger().Infow("X402 seller configured",
+		"routes", len(routes),
+		"payTo", payTo,
+		"chainId", pc.chainID,
+	)
+	return seller
+}
@@ -1,15 +1,22 @@
 package app
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"go.uber.org/zap"
 
 	"github.com/langoai/lango/internal/config"
-	"github.com/langoai/lango/internal/p2p"
+	"github.com/langoai/lango/internal/eventbus"
 	"github.com/langoai/lango/internal/p2p/gitbundle"
+	"github.com/langoai/lango/internal/p2p/identity"
 	"github.com/langoai/lango/internal/p2p/workspace"
+	"github.com/langoai/lango/internal/security"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -19,6 +26,8 @@ type wsComponents struct {
 	gitService *gitbundle.Service
 	gitHandler *gitbundle.Handler
 	gossip     *workspace.WorkspaceGossip
+	keyring    *workspace.GroupKeyring
+	keys       *workspace.KeyExchange
 	chronicler *workspace.Chronicler
 	tracker    *workspace.ContributionTracker
 	db         *bolt.DB
@@ -26,7 +35,10 @@ type wsComponents struct {
 }
 
 // initWorkspace creates workspace and git bundle components if enabled.
-func initWorkspace(cfg *config.Config, node *p2p.Node, localDID string, sessionValidator gitbundle.SessionValidator) *wsComponents {
+// Workspace messages are signed with the P2P identity key and end-to-end
+// encrypted under a per-workspace group key distributed over handshake
+// sessions; rejected messages are published as security events on bus.
+func initWorkspace(cfg *config.Config, p2pc *p2pComponents, localDID string, sessionValidator gitbundle.SessionValidator, bus *eventbus.Bus) *wsComponents {
 	wsCfg := cfg.P2P.Workspace
 	if !wsCfg.Enabled {
 		logger().Info("P2P workspace disabled")
@@ -34,6 +46,7 @@ func initWorkspace(cfg *config.Config, node *p2p.Node, localDID string, sessionV
 	}
 
 	log := logger()
+	node := p2pc.node
 
 	// Resolve data directory.
 	dataDir := wsCfg.DataDir
@@ -58,12 +71,32 @@ func initWorkspace(cfg *config.Config, node *p2p.Node, localDID string, sessionV
 		return nil
 	}
 
+	onRejected := func(r workspace.Rejection) {
+		log.Warnw("workspace message rejected",
+			"workspace", r.WorkspaceID, "message", r.MessageID, "sender", r.SenderDID,
+			"peer", r.PeerID, "stage", r.Stage, "error", r.Err)
+		if bus != nil {
+			bus.Publish(eventbus.WorkspaceMessageRejectedEvent{
+				WorkspaceID: r.WorkspaceID,
+				MessageID:   r.MessageID,
+				SenderDID:   r.SenderDID,
+				PeerID:      r.PeerID,
+				Stage:       r.Stage,
+				Reason:      r.Err.Error(),
+				RejectedAt:  time.Now(),
+			})
+		}
+	}
+
 	// Create workspace manager (Manager defaults MaxWorkspaces to 10 if <= 0).
 	mgr, err := workspace.NewManager(workspace.ManagerConfig{
 		DB:            db,
 		LocalDID:      localDID,
 		MaxWorkspaces: wsCfg.MaxWorkspaces,
 		Logger:        log,
+		Signer:        p2pc.signer,
+		Verifiers:     workspaceVerifiers(p2pc.bundles),
+		OnRejected:    onRejected,
 	})
 	if err != nil {
 		log.Warnw("create workspace manager", "error", err)
@@ -108,6 +141,48 @@ func initWorkspace(cfg *config.Config, node *p2p.Node, localDID string, sessionV
 		log.Info("workspace chronicler enabled (triple adder pending)")
 	}
 
+	// Group keys are distributed to members over the handshake sessions.
+	keyring := workspace.NewGroupKeyring(workspace.DefaultKeyGrace)
+	keys := workspace.NewKeyExchange(workspace.KeyExchangeConfig{
+		Host:       node.Host(),
+		LocalDID:   localDID,
+		Keyring:    keyring,
+		SessionKey: workspaceSessionKey(p2pc),
+		ResolvePeer: func(did string) (peer.ID, error) {
+			d, err := identity.ResolveDID(did, p2pc.bundles)
+			if err != nil {
+				return "", err
+			}
+			return d.PeerID, nil
+		},
+		Members: mgr.Members,
+		Admit: func(workspaceID, did string) error {
+			// A key fetch from an invited non-member of a live workspace is
+			// treated as a join; anyone else is refused.
+			if mgr.IsMember(workspaceID, did) {
+				return nil
+			}
+			if !mgr.IsInvited(workspaceID, did) {
+				return fmt.Errorf("%s: %w", did, workspace.ErrNotInvited)
+			}
+			ws, err := mgr.Get(context.Background(), workspaceID)
+			if err != nil {
+				return err
+			}
+			if ws.Status == workspace.StatusArchived {
+				return fmt.Errorf("workspace %s is archived", workspaceID)
+			}
+			return mgr.AddMember(context.Background(), workspaceID, &workspace.Member{
+				DID:      did,
+				Role:     workspace.RoleMember,
+				JoinedAt: time.Now(),
+			})
+		},
+		Logger: log,
+	})
+	node.SetStreamHandler(workspace.KeyProtocolID, keys.StreamHandler())
+	log.Infow("registered workspace key protocol handler", "protocol", workspace.KeyProtocolID)
+
 	// Create workspace gossip with message handler already wired.
 	var wsGossip *workspace.WorkspaceGossip
 	ps, err := node.PubSub()
@@ -118,6 +193,17 @@ func initWorkspace(cfg *config.Config, node *p2p.Node, localDID string, sessionV
 			PubSub:  ps,
 			LocalID: node.PeerID(),
 			Handler: func(msg workspace.Message) {
+				// Post verifies the signature and membership; rejected
+				// messages are reported through onRejected.
+				ctx := context.Background()
+				if err := mgr.Post(ctx, msg.WorkspaceID, msg); err != nil {
+					if !errors.Is(err, workspace.ErrInvalidSignature) && !errors.Is(err, workspace.ErrUnsignedMessage) && !errors.Is(err, workspace.ErrNotMember) {
+						log.Debugw("store workspace message", "workspace", msg.WorkspaceID, "error", err)
+					}
+					return
+				}
+				handleWorkspaceMembership(ctx, mgr, keys, msg, log)
+
 				if chronicler != nil {
 					chronicler.HandleMessage(msg)
 				}
@@ -125,7 +211,9 @@ func initWorkspace(cfg *config.Config, node *p2p.Node, localDID string, sessionV
 					tracker.RecordMessage(msg.WorkspaceID, msg.SenderDID)
 				}
 			},
-			Logger: log,
+			Keyring:    keyring,
+			OnRejected: onRejected,
+			Logger:     log,
 		})
 	}
 
@@ -141,9 +229,92 @@ func initWorkspace(cfg *config.Config, node *p2p.Node, localDID string, sessionV
 		gitService: gitSvc,
 		gitHandler: gitHdl,
 		gossip:     wsGossip,
+		keyring:    keyring,
+		keys:       keys,
 		chronicler: chronicler,
 		tracker:    tracker,
 		db:         db,
 		localDID:   localDID,
 	}
 }
+
+// handleWorkspaceMembership applies verified MEMBER_INVITED, MEMBER_JOINED
+// and MEMBER_LEFT announcements. Invites are signed by an existing member;
+// joins and departures are signed by the member themselves, so a peer can
+// only add or remove its own DID, and only join once invited. When a member
+// leaves, the key leader rotates the group key so the departed member cannot
+// read later messages.
+func handleWorkspaceMembership(ctx context.Context, mgr *workspace.Manager, keys *workspace.KeyExchange, msg workspace.Message, log *zap.SugaredLogger) {
+	switch msg.Type {
+	case workspace.MessageTypeMemberInvited:
+		invitee := msg.Metadata[workspace.MetaInvitee]
+		if invitee == "" {
+			return
+		}
+		if err := mgr.Invite(ctx, msg.WorkspaceID, invitee, msg.SenderDID); err != nil {
+			log.Warnw("record workspace invite", "workspace", msg.WorkspaceID, "invitee", invitee, "error", err)
+		}
+	case workspace.MessageTypeMemberJoined:
+		if !mgr.IsMember(msg.WorkspaceID, msg.SenderDID) && !mgr.IsInvited(msg.WorkspaceID, msg.SenderDID) {
+			log.Warnw("ignore uninvited workspace join", "workspace", msg.WorkspaceID, "member", msg.SenderDID)
+			return
+		}
+		if err := mgr.AddMember(ctx, msg.WorkspaceID, &workspace.Member{
+			DID:      msg.SenderDID,
+			Role:     workspace.RoleMember,
+			JoinedAt: msg.Timestamp,
+		}); err != nil {
+			log.Warnw("add workspace member", "workspace", msg.WorkspaceID, "member", msg.SenderDID, "error", err)
+		}
+	case workspace.MessageTypeMemberLeft:
+		if err := mgr.RemoveMember(ctx, msg.WorkspaceID, msg.SenderDID); err != nil {
+			log.Warnw("remove workspace member", "workspace", msg.WorkspaceID, "member", msg.SenderDID, "error", err)
+			return
+		}
+		if keys.IsLeader(msg.WorkspaceID) {
+			go func() {
+				if err := keys.Rotate(context.Background(), msg.WorkspaceID); err != nil {
+					log.Warnw("rotate workspace key", "workspace", msg.WorkspaceID, "error", err)
+				}
+			}()
+		}
+	}
+}
+
+// workspaceVerifiers builds the signature verifier map for workspace
+// messages. V1 DIDs embed their key; v2 DIDs resolve the Ed25519 signing
+// key from bundles learned during the handshake.
+func workspaceVerifiers(bundles identity.BundleResolver) map[string]workspace.SignatureVerifyFunc {
+	return map[string]workspace.SignatureVerifyFunc{
+		security.AlgorithmSecp256k1Keccak256: identity.VerifyMessageSignature,
+		security.AlgorithmEd25519: func(did string, payload, sig []byte) error {
+			d, err := identity.ResolveDID(did, bundles)
+			if err != nil {
+				return err
+			}
+			return security.VerifyEd25519(d.PublicKey, payload, sig)
+		},
+	}
+}
+
+// workspaceSessionKey returns the handshake session encryption key shared
+// with a peer. Sessions may be keyed by a v2 DID or its legacy v1 alias.
+func workspaceSessionKey(p2pc *p2pComponents) workspace.SessionKeyFunc {
+	return func(did string) []byte {
+		if p2pc.sessions == nil {
+			return nil
+		}
+		if sess := p2pc.sessions.Get(did); sess != nil {
+			return append([]byte(nil), sess.EncryptionKey...)
+		}
+		if p2pc.bundles == nil {
+			return nil
+		}
+		if b, err := p2pc.bundles.ResolveBundle(did); err == nil && b.LegacyDID != "" {
+			if sess := p2pc.sessions.Get(b.LegacyDID); sess != nil {
+				return append([]byte(nil), sess.EncryptionKey...)
+			}
+		}
+		return nil
+	}
+}
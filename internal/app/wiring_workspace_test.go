@@ -0,0 +1,52 @@
+package app
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/p2p/identity"
+	"github.com/langoai/lango/internal/security"
+)
+
+func TestWorkspaceVerifiers(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte("workspace message")
+	bundles := identity.NewMemoryBundleCache()
+	verifiers := workspaceVerifiers(bundles)
+
+	// v1 DID: secp256k1-keccak256 with the key embedded in the DID.
+	walletKey, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	v1, err := identity.DIDFromPublicKey(ethcrypto.CompressPubkey(&walletKey.PublicKey))
+	require.NoError(t, err)
+	sig, err := ethcrypto.Sign(ethcrypto.Keccak256(payload), walletKey)
+	require.NoError(t, err)
+
+	verifySecp := verifiers[security.AlgorithmSecp256k1Keccak256]
+	assert.NoError(t, verifySecp(v1.ID, payload, sig))
+	assert.Error(t, verifySecp(v1.ID, []byte("tampered"), sig))
+
+	// v2 DID: Ed25519 key resolved from the handshake bundle cache.
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	bundle := &identity.IdentityBundle{
+		Version:    1,
+		SigningKey: identity.PublicKeyEntry{Algorithm: security.AlgorithmEd25519, PublicKey: pub},
+	}
+	v2, err := identity.ComputeDIDv2(bundle)
+	require.NoError(t, err)
+	edSig := ed25519.Sign(priv, payload)
+
+	verifyEd := verifiers[security.AlgorithmEd25519]
+	assert.Error(t, verifyEd(v2, payload, edSig), "unknown bundle")
+
+	bundles.Store(v2, bundle)
+	assert.NoError(t, verifyEd(v2, payload, edSig))
+	assert.Error(t, verifyEd(v2, []byte("tampered"), edSig))
+}
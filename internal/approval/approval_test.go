@@ -2,6 +2,7 @@ package approval
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -399,3 +400,44 @@ func (m *mockGatewayApprover) HasCompanions() bool {
 func (m *mockGatewayApprover) RequestApproval(_ context.Context, _ string) (ApprovalResponse, error) {
 	return ApprovalResponse{Approved: m.result}, m.err
 }
+
+func TestCockpitProvider(t *testing.T) {
+	gw := &mockOperatorApprover{operators: map[string]bool{"sess-1": true}}
+	p := NewCockpitProvider(gw)
+
+	if !p.CanHandle("sess-1") {
+		t.Error("CanHandle(sess-1) = false, want true")
+	}
+	if p.CanHandle("sess-2") {
+		t.Error("CanHandle(sess-2) = true, want false")
+	}
+
+	gw.result = true
+	resp, err := p.RequestApproval(context.Background(), ApprovalRequest{ID: "r1", SessionKey: "sess-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Approved || resp.Provider != "cockpit" {
+		t.Errorf("resp = %+v, want approved by cockpit", resp)
+	}
+
+	gw.err = fmt.Errorf("approval timeout")
+	_, err = p.RequestApproval(context.Background(), ApprovalRequest{ID: "r2", SessionKey: "sess-1"})
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("err = %v, want ErrTimeout", err)
+	}
+}
+
+type mockOperatorApprover struct {
+	operators map[string]bool
+	result    bool
+	err       error
+}
+
+func (m *mockOperatorApprover) HasOperators(sessionKey string) bool {
+	return m.operators[sessionKey]
+}
+
+func (m *mockOperatorApprover) RequestOperatorApproval(_ context.Context, _ ApprovalRequest) (ApprovalResponse, error) {
+	return ApprovalResponse{Approved: m.result}, m.err
+}
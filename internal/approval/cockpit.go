@@ -0,0 +1,52 @@
+package approval
+
+import (
+	"context"
+	"strings"
+)
+
+// OperatorApprover abstracts the gateway.Server methods needed to route
+// approvals to remote cockpit operators.
+type OperatorApprover interface {
+	HasOperators(sessionKey string) bool
+	RequestOperatorApproval(ctx context.Context, req ApprovalRequest) (ApprovalResponse, error)
+}
+
+// CockpitProvider routes approval requests to remote cockpit operators
+// attached to the requesting session.
+type CockpitProvider struct {
+	gw OperatorApprover
+}
+
+var _ Provider = (*CockpitProvider)(nil)
+
+// NewCockpitProvider creates a CockpitProvider backed by the given gateway.
+func NewCockpitProvider(gw OperatorApprover) *CockpitProvider {
+	return &CockpitProvider{gw: gw}
+}
+
+// RequestApproval sends the request to the session's cockpit operators.
+func (c *CockpitProvider) RequestApproval(ctx context.Context, req ApprovalRequest) (ApprovalResponse, error) {
+	resp, err := c.gw.RequestOperatorApproval(ctx, req)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "approval timeout"):
+			return ApprovalResponse{}, WrapError(ErrTimeout, "cockpit", req.ID, err.Error())
+		case strings.Contains(err.Error(), "no cockpit operator attached"):
+			return ApprovalResponse{}, WrapError(ErrUnavailable, "cockpit", req.ID, err.Error())
+		default:
+			return ApprovalResponse{}, err
+		}
+	}
+	resp.Provider = "cockpit"
+	return resp, nil
+}
+
+// CanHandle returns true when an operator is attached to the session.
+func (c *CockpitProvider) CanHandle(sessionKey string) bool {
+	return c.gw.HasOperators(sessionKey)
+}
+
+func (c *CockpitProvider) Name() string {
+	return "cockpit"
+}
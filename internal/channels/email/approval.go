@@ -0,0 +1,171 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/langoai/lango/internal/approval"
+)
+
+// replier sends a reply to a sender's conversation in an email thread.
+type replier interface {
+	Reply(threadID, to, text string) error
+}
+
+// approvalPending is a request waiting for a reply in its thread.
+type approvalPending struct {
+	id string
+	ch chan approval.ApprovalResponse
+}
+
+// ApprovalProvider implements approval.Provider for email. The request is
+// sent as a reply to the session's sender, and that sender answers by
+// replying "approve", "deny" or "always". Replies from any other address are
+// not decisions. When several requests wait in one session, a reply answers
+// the oldest.
+type ApprovalProvider struct {
+	replier replier
+	timeout time.Duration
+
+	mu      sync.Mutex
+	pending map[threadKey][]*approvalPending // FIFO per thread and sender
+}
+
+var _ approval.Provider = (*ApprovalProvider)(nil)
+
+// NewApprovalProvider creates an email approval provider.
+func NewApprovalProvider(r replier, timeout time.Duration) *ApprovalProvider {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &ApprovalProvider{
+		replier: r,
+		timeout: timeout,
+		pending: make(map[threadKey][]*approvalPending),
+	}
+}
+
+// RequestApproval replies to the session's sender and waits for the answer.
+func (p *ApprovalProvider) RequestApproval(ctx context.Context, req approval.ApprovalRequest) (approval.ApprovalResponse, error) {
+	key, err := parseSessionKey(req.SessionKey)
+	if err != nil {
+		return approval.ApprovalResponse{}, fmt.Errorf("parse session key: %w", err)
+	}
+
+	pending := &approvalPending{id: req.ID, ch: make(chan approval.ApprovalResponse, 1)}
+	p.mu.Lock()
+	p.pending[key] = append(p.pending[key], pending)
+	p.mu.Unlock()
+	defer p.remove(key, pending)
+
+	if err := p.replier.Reply(key.id, key.sender, approvalText(req, p.timeout)); err != nil {
+		return approval.ApprovalResponse{}, fmt.Errorf("send approval message: %w", err)
+	}
+
+	select {
+	case resp := <-pending.ch:
+		return resp, nil
+	case <-ctx.Done():
+		return approval.ApprovalResponse{}, ctx.Err()
+	case <-time.After(p.timeout):
+		return approval.ApprovalResponse{}, fmt.Errorf("approval timeout")
+	}
+}
+
+// HandleReply answers the oldest request pending for sender in threadID when
+// body is an approval decision. It reports whether the reply was consumed.
+func (p *ApprovalProvider) HandleReply(threadID, sender, body string) bool {
+	resp, ok := parseDecision(body)
+	if !ok {
+		return false
+	}
+
+	key := threadKey{id: threadID, sender: strings.ToLower(sender)}
+	p.mu.Lock()
+	queue := p.pending[key]
+	if len(queue) == 0 {
+		p.mu.Unlock()
+		return false
+	}
+	pending := queue[0]
+	p.dropLocked(key, pending)
+	p.mu.Unlock()
+
+	select {
+	case pending.ch <- resp:
+	default:
+	}
+	return true
+}
+
+// CanHandle returns true for session keys starting with "email:".
+func (p *ApprovalProvider) CanHandle(sessionKey string) bool {
+	return strings.HasPrefix(sessionKey, "email:")
+}
+
+func (p *ApprovalProvider) remove(key threadKey, pending *approvalPending) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dropLocked(key, pending)
+}
+
+func (p *ApprovalProvider) dropLocked(key threadKey, pending *approvalPending) {
+	queue := p.pending[key]
+	for i, q := range queue {
+		if q == pending {
+			queue = append(queue[:i:i], queue[i+1:]...)
+			break
+		}
+	}
+	if len(queue) == 0 {
+		delete(p.pending, key)
+		return
+	}
+	p.pending[key] = queue
+}
+
+func approvalText(req approval.ApprovalRequest, timeout time.Duration) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Tool %q requires approval.\n", req.ToolName)
+	if req.Summary != "" {
+		fmt.Fprintf(&b, "\n%s\n", req.Summary)
+	}
+	fmt.Fprintf(&b, "\nReply \"approve\" to run it, \"deny\" to refuse, or \"always\" to always allow this tool. "+
+		"The request expires in %s.\n", timeout.Round(time.Second))
+	return b.String()
+}
+
+// parseDecision reads an approval decision from the first line of a reply.
+// Only short answers count, so an ordinary message starting with "no" is
+// not taken as a denial.
+func parseDecision(body string) (approval.ApprovalResponse, bool) {
+	line, _, _ := strings.Cut(strings.TrimSpace(body), "\n")
+	words := strings.Fields(strings.ToLower(strings.Trim(strings.TrimSpace(line), ".!")))
+	if len(words) == 0 || len(words) > 2 {
+		return approval.ApprovalResponse{}, false
+	}
+	switch strings.Join(words, " ") {
+	case "approve", "approved", "yes":
+		return approval.ApprovalResponse{Approved: true}, true
+	case "deny", "denied", "no", "reject":
+		return approval.ApprovalResponse{}, true
+	case "always", "always allow", "always approve":
+		return approval.ApprovalResponse{Approved: true, AlwaysAllow: true}, true
+	}
+	return approval.ApprovalResponse{}, false
+}
+
+// parseSessionKey extracts the thread ID and sender from a session key like
+// "email:<threadID>:<sender>". Sender addresses contain no colon, so the
+// thread ID is everything between the first and last colon.
+func parseSessionKey(sessionKey string) (threadKey, error) {
+	rest, ok := strings.CutPrefix(sessionKey, "email:")
+	i := strings.LastIndexByte(rest, ':')
+	if !ok || i <= 0 || i == len(rest)-1 {
+		return threadKey{}, fmt.Errorf("invalid email session key: %s", sessionKey)
+	}
+	return threadKey{id: rest[:i], sender: strings.ToLower(rest[i+1:])}, nil
+}
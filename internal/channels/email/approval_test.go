@@ -0,0 +1,161 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/langoai/lango/internal/approval"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReplier struct {
+	mu      sync.Mutex
+	replies []string
+	sent    chan struct{}
+	err     error
+}
+
+func newFakeReplier() *fakeReplier {
+	return &fakeReplier{sent: make(chan struct{}, 10)}
+}
+
+func (f *fakeReplier) Reply(threadID, to, text string) error {
+	f.mu.Lock()
+	f.replies = append(f.replies, threadID+"|"+to+"|"+text)
+	f.mu.Unlock()
+	f.sent <- struct{}{}
+	return f.err
+}
+
+func TestApprovalProvider_ReplyDecisions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give string
+		want approval.ApprovalResponse
+	}{
+		{give: "approve", want: approval.ApprovalResponse{Approved: true}},
+		{give: "Yes!\n\nthanks", want: approval.ApprovalResponse{Approved: true}},
+		{give: "deny", want: approval.ApprovalResponse{}},
+		{give: "No.", want: approval.ApprovalResponse{}},
+		{give: "Always allow", want: approval.ApprovalResponse{Approved: true, AlwaysAllow: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			r := newFakeReplier()
+			p := NewApprovalProvider(r, 5*time.Second)
+
+			done := make(chan approval.ApprovalResponse, 1)
+			go func() {
+				resp, err := p.RequestApproval(context.Background(), approval.ApprovalRequest{
+					ID:         "req-1",
+					ToolName:   "exec",
+					SessionKey: "email:root@x:bob@example.com",
+				})
+				assert.NoError(t, err)
+				done <- resp
+			}()
+			<-r.sent
+
+			assert.True(t, p.HandleReply("root@x", "bob@example.com", tt.give))
+			assert.Equal(t, tt.want, <-done)
+			assert.Contains(t, r.replies[0], "root@x|")
+			assert.Contains(t, r.replies[0], `"exec"`)
+		})
+	}
+}
+
+func TestApprovalProvider_IgnoresOtherReplies(t *testing.T) {
+	t.Parallel()
+
+	r := newFakeReplier()
+	p := NewApprovalProvider(r, 5*time.Second)
+
+	// No pending request: a decision word is an ordinary message.
+	assert.False(t, p.HandleReply("root@x", "bob@example.com", "yes"))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.RequestApproval(context.Background(), approval.ApprovalRequest{
+			ID: "req-1", ToolName: "exec", SessionKey: "email:root@x:bob@example.com",
+		})
+		done <- err
+	}()
+	<-r.sent
+
+	assert.False(t, p.HandleReply("root@x", "bob@example.com", "no idea what this is about, please explain"))
+	assert.False(t, p.HandleReply("other@x", "bob@example.com", "approve"))
+	// Another allowlisted sender replying in Bob's thread cannot decide.
+	assert.False(t, p.HandleReply("root@x", "eve@example.com", "approve"))
+	assert.True(t, p.HandleReply("root@x", "Bob@Example.com", "approve"))
+	require.NoError(t, <-done)
+}
+
+func TestApprovalProvider_Timeout(t *testing.T) {
+	t.Parallel()
+
+	p := NewApprovalProvider(newFakeReplier(), 50*time.Millisecond)
+	_, err := p.RequestApproval(context.Background(), approval.ApprovalRequest{
+		ID: "req-1", SessionKey: "email:root@x:bob@example.com",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "approval timeout")
+	assert.Empty(t, p.pending)
+}
+
+func TestApprovalProvider_SendError(t *testing.T) {
+	t.Parallel()
+
+	r := newFakeReplier()
+	r.err = fmt.Errorf("smtp down")
+	p := NewApprovalProvider(r, time.Second)
+	_, err := p.RequestApproval(context.Background(), approval.ApprovalRequest{
+		ID: "req-1", SessionKey: "email:root@x:bob@example.com",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "smtp down")
+	assert.Empty(t, p.pending)
+}
+
+func TestApprovalProvider_CanHandle(t *testing.T) {
+	t.Parallel()
+
+	p := NewApprovalProvider(newFakeReplier(), 0)
+	assert.True(t, p.CanHandle("email:root@x:bob@example.com"))
+	assert.False(t, p.CanHandle("slack:C1:U1"))
+}
+
+func TestParseSessionKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give    string
+		want    threadKey
+		wantErr bool
+	}{
+		{give: "email:abc@host:bob@example.com", want: threadKey{id: "abc@host", sender: "bob@example.com"}},
+		{give: "email:a:b@host:Bob@example.com", want: threadKey{id: "a:b@host", sender: "bob@example.com"}},
+		{give: "email:bob@example.com", wantErr: true},
+		{give: "email:abc@host:", wantErr: true},
+		{give: "slack:C1:U1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSessionKey(tt.give)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
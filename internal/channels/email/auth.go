@@ -0,0 +1,88 @@
+package email
+
+import "strings"
+
+// authenticated reports whether the Authentication-Results added by the
+// trusted server authServID vouch for the From domain of addr: dmarc=pass,
+// or dkim=pass for a signing domain aligned with it.
+//
+// Receiving servers prepend their header, so only the first one carrying
+// authServID is read; a header with the same ID further down was either
+// written by an earlier hop or forged by the sender.
+func authenticated(results []string, authServID, addr string) bool {
+	at := strings.LastIndexByte(addr, '@')
+	if at < 0 || authServID == "" {
+		return false
+	}
+	fromDomain := strings.ToLower(addr[at+1:])
+
+	for _, header := range results {
+		parts := strings.Split(stripComments(header), ";")
+		id := strings.Fields(parts[0])
+		if len(id) == 0 || !strings.EqualFold(id[0], authServID) {
+			continue
+		}
+		for _, res := range parts[1:] {
+			method, result, props := parseResInfo(res)
+			if result != "pass" {
+				continue
+			}
+			switch method {
+			case "dmarc":
+				if d := props["header.from"]; d == "" || d == fromDomain {
+					return true
+				}
+			case "dkim":
+				if aligned(fromDomain, props["header.d"]) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// parseResInfo splits one "method=result prop=value ..." result into its
+// method, result and lowercased properties.
+func parseResInfo(res string) (method, result string, props map[string]string) {
+	fields := strings.Fields(strings.ToLower(res))
+	if len(fields) == 0 {
+		return "", "", nil
+	}
+	method, result, _ = strings.Cut(fields[0], "=")
+	props = make(map[string]string, len(fields)-1)
+	for _, f := range fields[1:] {
+		if k, v, ok := strings.Cut(f, "="); ok {
+			props[k] = strings.Trim(v, `"`)
+		}
+	}
+	return method, result, props
+}
+
+// aligned reports whether the DKIM signing domain d is the From domain or
+// one of its parents (relaxed alignment). Single-label domains never align.
+func aligned(fromDomain, d string) bool {
+	if !strings.Contains(d, ".") {
+		return false
+	}
+	return fromDomain == d || strings.HasSuffix(fromDomain, "."+d)
+}
+
+// stripComments removes RFC 5322 parenthesized comments, which may contain
+// ";" and "=" that would confuse the result parser.
+func stripComments(s string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range s {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,435 @@
+// Package email implements an email channel: mail is read from an IMAP
+// mailbox and answered over SMTP, with each thread mapped to a session.
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/langoai/lango/internal/logging"
+)
+
+var logger = logging.SubsystemSugar("channel.email")
+
+// sendTimeout bounds a single SMTP delivery.
+const sendTimeout = time.Minute
+
+// maxReferences caps the References header of replies: the thread root is
+// always kept, followed by the most recent messages.
+const maxReferences = 20
+
+// Config holds email channel configuration
+type Config struct {
+	IMAPAddress        string // host:port
+	SMTPAddress        string // host:port
+	Username           string
+	Password           string
+	Address            string        // From address (default: Username)
+	Mailbox            string        // default: INBOX
+	PollInterval       time.Duration // 0 = IDLE when supported
+	Allowlist          []string      // sender addresses or "@domain"
+	TrustedAuthServID  string        // authserv-id whose Authentication-Results are trusted
+	Insecure           bool          // plain-text IMAP/SMTP, for local servers
+	ApprovalTimeoutSec int           // 0 = default 30s
+}
+
+// MessageHandler handles incoming messages
+type MessageHandler func(ctx context.Context, msg *IncomingMessage) (*OutgoingMessage, error)
+
+// IncomingMessage represents an email from an allowlisted sender. Text is
+// the body without quoted reply text, followed by the extracted content of
+// any attachments.
+type IncomingMessage struct {
+	MessageID   string
+	ThreadID    string // Message-ID of the first message in the thread
+	From        string
+	FromName    string
+	Subject     string
+	Text        string
+	Attachments []Attachment
+}
+
+// OutgoingMessage represents an email to send. Replies reuse the thread's
+// subject; Subject is used for new threads only.
+type OutgoingMessage struct {
+	Subject string
+	Text    string
+}
+
+// threadKey identifies one sender's conversation in a thread. The thread ID
+// comes from the sender-controlled References header, so state is never
+// shared between senders: each one only ever affects their own session.
+type threadKey struct {
+	id     string
+	sender string
+}
+
+// thread is the reply state of a conversation.
+type thread struct {
+	subject    string
+	lastID     string
+	references []string
+}
+
+// Channel implements the email channel
+type Channel struct {
+	config   Config
+	from     string
+	smtp     *smtpSender
+	handler  MessageHandler
+	approval *ApprovalProvider
+
+	mu      sync.Mutex
+	threads map[threadKey]*thread
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// New creates a new email channel
+func New(cfg Config) (*Channel, error) {
+	if cfg.IMAPAddress == "" {
+		return nil, fmt.Errorf("imap address is required")
+	}
+	if cfg.SMTPAddress == "" {
+		return nil, fmt.Errorf("smtp address is required")
+	}
+	if cfg.Username == "" {
+		return nil, fmt.Errorf("username is required")
+	}
+	if len(cfg.Allowlist) == 0 {
+		return nil, fmt.Errorf("allowlist is required: list the sender addresses or @domains to accept")
+	}
+	if cfg.TrustedAuthServID == "" {
+		return nil, fmt.Errorf("trusted authserv-id is required: set it to the receiving server's Authentication-Results ID")
+	}
+	if cfg.Address == "" {
+		cfg.Address = cfg.Username
+	}
+	from, err := mail.ParseAddress(cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", cfg.Address, err)
+	}
+	if cfg.Mailbox == "" {
+		cfg.Mailbox = "INBOX"
+	}
+	allow := make([]string, len(cfg.Allowlist))
+	for i, a := range cfg.Allowlist {
+		allow[i] = strings.ToLower(strings.TrimSpace(a))
+	}
+	cfg.Allowlist = allow
+
+	ch := &Channel{
+		config: cfg,
+		from:   strings.ToLower(from.Address),
+		smtp: &smtpSender{
+			addr:     cfg.SMTPAddress,
+			username: cfg.Username,
+			password: cfg.Password,
+			insecure: cfg.Insecure,
+		},
+		threads:  make(map[threadKey]*thread),
+		stopChan: make(chan struct{}),
+	}
+	ch.approval = NewApprovalProvider(ch, time.Duration(cfg.ApprovalTimeoutSec)*time.Second)
+	return ch, nil
+}
+
+// SetHandler sets the message handler
+func (c *Channel) SetHandler(handler MessageHandler) {
+	c.handler = handler
+}
+
+// GetApprovalProvider returns the channel's approval provider for composite registration.
+func (c *Channel) GetApprovalProvider() *ApprovalProvider {
+	return c.approval
+}
+
+// Name returns the channel identifier.
+func (c *Channel) Name() string { return "email" }
+
+// Start logs in to the IMAP server and starts ingesting mail.
+func (c *Channel) Start(ctx context.Context) error {
+	if c.handler == nil {
+		return fmt.Errorf("message handler not set")
+	}
+
+	cl, err := c.connectIMAP()
+	if err != nil {
+		return err
+	}
+	logger.Infow("email channel connected", "address", c.from, "mailbox", c.config.Mailbox)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.watch(ctx, cl)
+	}()
+	return nil
+}
+
+// allowed reports whether mail from addr is accepted.
+func (c *Channel) allowed(addr string) bool {
+	for _, a := range c.config.Allowlist {
+		if a == addr || strings.HasPrefix(a, "@") && strings.HasSuffix(addr, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// dispatch handles one raw message fetched from the mailbox.
+func (c *Channel) dispatch(ctx context.Context, raw []byte) {
+	pm, err := parseMessage(raw)
+	if err != nil {
+		logger.Warnw("skip unparsable message", "error", err)
+		return
+	}
+	if pm.From == c.from {
+		return
+	}
+	if !c.allowed(pm.From) {
+		logger.Infow("ignore mail from sender not in allowlist", "from", pm.From)
+		return
+	}
+	// From is sender-controlled; neither a turn nor an approval reply is
+	// accepted unless the receiving server authenticated its domain.
+	if !authenticated(pm.AuthResults, c.config.TrustedAuthServID, pm.From) {
+		logger.Warnw("ignore mail without passing authentication results", "from", pm.From, "authservId", c.config.TrustedAuthServID)
+		return
+	}
+	if pm.MessageID == "" {
+		pm.MessageID = newMessageID(pm.From)
+	}
+
+	threadID := pm.threadRoot()
+	c.recordIncoming(threadKey{id: threadID, sender: pm.From}, pm)
+
+	if c.approval.HandleReply(threadID, pm.From, pm.Body) {
+		return
+	}
+
+	incoming := &IncomingMessage{
+		MessageID:   pm.MessageID,
+		ThreadID:    threadID,
+		From:        pm.From,
+		FromName:    pm.FromName,
+		Subject:     pm.Subject,
+		Text:        composeText(pm),
+		Attachments: pm.Attachments,
+	}
+	if incoming.Text == "" {
+		logger.Infow("ignore empty message", "from", pm.From, "messageId", pm.MessageID)
+		return
+	}
+
+	logger.Infow("received message", "from", pm.From, "thread", threadID)
+
+	// Handle in a separate goroutine so a turn waiting for approval does not
+	// block ingestion of the approval reply.
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		response, err := c.handler(ctx, incoming)
+		if err != nil {
+			logger.Errorw("handler error", "error", err)
+			if err := c.Reply(threadID, pm.From, formatChannelError(err)); err != nil {
+				logger.Errorw("send error reply", "error", err)
+			}
+			return
+		}
+		if response != nil && response.Text != "" {
+			if err := c.Reply(threadID, pm.From, response.Text); err != nil {
+				logger.Errorw("send reply", "error", err)
+			}
+		}
+	}()
+}
+
+// recordIncoming updates the sender's reply state from an incoming message.
+func (c *Channel) recordIncoming(key threadKey, pm *parsedMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, ok := c.threads[key]
+	if !ok {
+		t = &thread{subject: pm.Subject}
+		c.threads[key] = t
+	}
+	t.lastID = pm.MessageID
+	refs := pm.References
+	if len(refs) == 0 && pm.InReplyTo != "" {
+		refs = []string{pm.InReplyTo}
+	}
+	t.references = trimReferences(append(append([]string(nil), refs...), pm.MessageID))
+}
+
+// Reply sends text to a sender's conversation in a thread, threaded with
+// In-Reply-To and References.
+func (c *Channel) Reply(threadID, to, text string) error {
+	key := threadKey{id: threadID, sender: strings.ToLower(to)}
+	c.mu.Lock()
+	t, ok := c.threads[key]
+	if !ok {
+		c.mu.Unlock()
+		return fmt.Errorf("unknown email thread %q with %s", threadID, to)
+	}
+	m := &outgoingMail{
+		From:       c.config.Address,
+		To:         key.sender,
+		Subject:    replySubject(t.subject),
+		Body:       text,
+		MessageID:  newMessageID(c.from),
+		InReplyTo:  t.lastID,
+		References: append([]string(nil), t.references...),
+		Date:       time.Now(),
+	}
+	c.mu.Unlock()
+
+	if err := c.sendMail(m); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	t.lastID = m.MessageID
+	t.references = trimReferences(append(t.references, m.MessageID))
+	c.mu.Unlock()
+	return nil
+}
+
+// Send starts a new thread with to. Replies to it continue as a session.
+func (c *Channel) Send(to string, msg *OutgoingMessage) error {
+	addr, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", to, err)
+	}
+	subject := msg.Subject
+	if subject == "" {
+		subject = subjectFromText(msg.Text)
+	}
+	m := &outgoingMail{
+		From:      c.config.Address,
+		To:        addr.Address,
+		Subject:   subject,
+		Body:      msg.Text,
+		MessageID: newMessageID(c.from),
+		Date:      time.Now(),
+	}
+	if err := c.sendMail(m); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.threads[threadKey{id: m.MessageID, sender: strings.ToLower(addr.Address)}] = &thread{
+		subject:    subject,
+		lastID:     m.MessageID,
+		references: []string{m.MessageID},
+	}
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Channel) sendMail(m *outgoingMail) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	if err := c.smtp.send(ctx, c.from, m.To, m.render()); err != nil {
+		return fmt.Errorf("send email to %s: %w", m.To, err)
+	}
+	return nil
+}
+
+// Stop stops the email channel.
+func (c *Channel) Stop(ctx context.Context) error {
+	c.stopOnce.Do(func() {
+		close(c.stopChan)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	logger.Info("email channel stopped")
+	return nil
+}
+
+// composeText appends the attachments of pm to its body.
+func composeText(pm *parsedMessage) string {
+	var b strings.Builder
+	b.WriteString(pm.Body)
+	for _, a := range pm.Attachments {
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		name := a.Filename
+		if name == "" {
+			name = "unnamed"
+		}
+		fmt.Fprintf(&b, "[Attachment: %s (%s, %d bytes)]", name, a.ContentType, a.Size)
+		if a.Text == "" {
+			b.WriteString(" content not extracted")
+			continue
+		}
+		b.WriteString("\n")
+		b.WriteString(a.Text)
+		if a.Truncated {
+			b.WriteString("\n[truncated]")
+		}
+	}
+	return b.String()
+}
+
+// replySubject prefixes subject with "Re: " unless it already has it.
+func replySubject(subject string) string {
+	if strings.HasPrefix(strings.ToLower(subject), "re:") {
+		return subject
+	}
+	return "Re: " + subject
+}
+
+// subjectFromText derives a subject from the first line of text.
+func subjectFromText(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	line = strings.Trim(strings.TrimSpace(line), "#*_ ")
+	if line == "" {
+		return "Message from Lango"
+	}
+	if r := []rune(line); len(r) > 78 {
+		line = string(r[:75]) + "..."
+	}
+	return line
+}
+
+func trimReferences(refs []string) []string {
+	if len(refs) <= maxReferences {
+		return refs
+	}
+	return append(refs[:1:1], refs[len(refs)-maxReferences+1:]...)
+}
+
+// formatChannelError returns a user-friendly error message.
+// If the error implements UserMessage(), that is used; otherwise falls back to Error().
+func formatChannelError(err error) string {
+	type userMessager interface {
+		UserMessage() string
+	}
+	var um userMessager
+	if errors.As(err, &um) {
+		return um.UserMessage()
+	}
+	return fmt.Sprintf("Error: %s", err.Error())
+}
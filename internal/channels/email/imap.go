@@ -0,0 +1,212 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+)
+
+const (
+	// defaultPollInterval applies when IDLE is unavailable and no interval
+	// is configured.
+	defaultPollInterval = time.Minute
+	// idleRefresh re-checks the mailbox even if IDLE reports nothing, in
+	// case an update was missed.
+	idleRefresh = 10 * time.Minute
+	// maxReconnectBackoff caps the delay between IMAP reconnect attempts.
+	maxReconnectBackoff = time.Minute
+)
+
+// connectIMAP dials, logs in and selects the configured mailbox.
+func (c *Channel) connectIMAP() (*client.Client, error) {
+	var (
+		cl  *client.Client
+		err error
+	)
+	if c.config.Insecure {
+		cl, err = client.Dial(c.config.IMAPAddress)
+	} else {
+		cl, err = client.DialTLS(c.config.IMAPAddress, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("dial imap: %w", err)
+	}
+	if err := cl.Login(c.config.Username, c.config.Password); err != nil {
+		_ = cl.Logout()
+		return nil, fmt.Errorf("imap login: %w", err)
+	}
+	if _, err := cl.Select(c.config.Mailbox, false); err != nil {
+		_ = cl.Logout()
+		return nil, fmt.Errorf("select mailbox %s: %w", c.config.Mailbox, err)
+	}
+	return cl, nil
+}
+
+// watch ingests new mail until the channel stops, reconnecting with
+// backoff when the connection fails. cl is the connection opened by Start.
+func (c *Channel) watch(ctx context.Context, cl *client.Client) {
+	backoff := time.Second
+	for {
+		if cl != nil {
+			err := c.watchConn(ctx, cl)
+			_ = cl.Logout()
+			if err == nil {
+				return
+			}
+			logger.Warnw("imap connection lost", "error", err, "retryIn", backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.stopChan:
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxReconnectBackoff)
+
+		var err error
+		if cl, err = c.connectIMAP(); err != nil {
+			logger.Warnw("imap reconnect failed", "error", err)
+			cl = nil
+			continue
+		}
+		backoff = time.Second
+	}
+}
+
+// watchConn fetches unseen mail, then waits for more with IDLE or by
+// polling. It returns nil when the channel stops.
+func (c *Channel) watchConn(ctx context.Context, cl *client.Client) error {
+	updates := make(chan client.Update, 64)
+	wake := make(chan struct{}, 1)
+	connDone := make(chan struct{})
+	defer close(connDone)
+	cl.Updates = updates
+	go func() {
+		for {
+			select {
+			case <-connDone:
+				return
+			case u := <-updates:
+				if _, ok := u.(*client.MailboxUpdate); ok {
+					select {
+					case wake <- struct{}{}:
+					default:
+					}
+				}
+			}
+		}
+	}()
+
+	useIdle := false
+	if c.config.PollInterval == 0 {
+		ok, err := cl.Support("IDLE")
+		if err != nil {
+			return fmt.Errorf("imap capability: %w", err)
+		}
+		useIdle = ok
+	}
+	interval := c.config.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	for {
+		if err := c.fetchUnseen(ctx, cl); err != nil {
+			return err
+		}
+
+		if !useIdle {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-c.stopChan:
+				return nil
+			case <-time.After(interval):
+			}
+			continue
+		}
+
+		stopped, err := c.idle(ctx, cl, wake)
+		if err != nil {
+			return fmt.Errorf("imap idle: %w", err)
+		}
+		if stopped {
+			return nil
+		}
+	}
+}
+
+// idle waits in IMAP IDLE until the mailbox changes, idleRefresh passes or
+// the channel stops. It reports whether the channel stopped.
+func (c *Channel) idle(ctx context.Context, cl *client.Client, wake <-chan struct{}) (bool, error) {
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() { done <- cl.Idle(stop, nil) }()
+
+	stopped := false
+	select {
+	case err := <-done:
+		return false, err
+	case <-wake:
+	case <-time.After(idleRefresh):
+	case <-ctx.Done():
+		stopped = true
+	case <-c.stopChan:
+		stopped = true
+	}
+	close(stop)
+	return stopped, <-done
+}
+
+// fetchUnseen fetches all unseen messages, marks them seen and dispatches
+// them. Messages are marked seen before handling so a crash mid-turn does
+// not replay them.
+func (c *Channel) fetchUnseen(ctx context.Context, cl *client.Client) error {
+	criteria := imap.NewSearchCriteria()
+	criteria.WithoutFlags = []string{imap.SeenFlag}
+	uids, err := cl.UidSearch(criteria)
+	if err != nil {
+		return fmt.Errorf("search unseen: %w", err)
+	}
+	if len(uids) == 0 {
+		return nil
+	}
+
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(uids...)
+	section := &imap.BodySectionName{Peek: true}
+
+	msgs := make(chan *imap.Message, len(uids))
+	if err := cl.UidFetch(seqset, []imap.FetchItem{section.FetchItem(), imap.FetchUid}, msgs); err != nil {
+		return fmt.Errorf("fetch unseen: %w", err)
+	}
+
+	var raws [][]byte
+	for m := range msgs {
+		body := m.GetBody(section)
+		if body == nil {
+			continue
+		}
+		raw, err := io.ReadAll(body)
+		if err != nil {
+			logger.Warnw("read message body", "uid", m.Uid, "error", err)
+			continue
+		}
+		raws = append(raws, raw)
+	}
+
+	if err := cl.UidStore(seqset, imap.FormatFlagsOp(imap.AddFlags, true), []interface{}{imap.SeenFlag}, nil); err != nil {
+		return fmt.Errorf("mark seen: %w", err)
+	}
+
+	for _, raw := range raws {
+		c.dispatch(ctx, raw)
+	}
+	return nil
+}
@@ -0,0 +1,326 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxAttachmentText caps the text extracted from one attachment.
+	maxAttachmentText = 32 * 1024
+	// maxBodyBytes caps how much of a single MIME part is read.
+	maxBodyBytes = 4 * 1024 * 1024
+)
+
+// Attachment describes a file attached to an incoming message. Text holds
+// the extracted content of text-like attachments and is empty otherwise.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Size        int
+	Text        string
+	Truncated   bool
+}
+
+// parsedMessage is an incoming message decoded from its RFC 5322 form.
+type parsedMessage struct {
+	MessageID   string
+	InReplyTo   string
+	References  []string
+	From        string
+	FromName    string
+	Subject     string
+	Body        string
+	Attachments []Attachment
+	// AuthResults holds the Authentication-Results headers, newest first.
+	AuthResults []string
+}
+
+// threadRoot returns the Message-ID of the first message of the thread:
+// the first References entry, else In-Reply-To, else the message itself.
+func (m *parsedMessage) threadRoot() string {
+	if len(m.References) > 0 {
+		return m.References[0]
+	}
+	if m.InReplyTo != "" {
+		return m.InReplyTo
+	}
+	return m.MessageID
+}
+
+var wordDecoder = mime.WordDecoder{CharsetReader: charsetReader}
+
+// parseMessage decodes a raw message, extracting the plain-text body and
+// attachments.
+func parseMessage(raw []byte) (*parsedMessage, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("read message: %w", err)
+	}
+
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("parse From: %w", err)
+	}
+	subject, err := wordDecoder.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+
+	pm := &parsedMessage{
+		MessageID:   firstMessageID(msg.Header.Get("Message-ID")),
+		InReplyTo:   firstMessageID(msg.Header.Get("In-Reply-To")),
+		References:  messageIDs(msg.Header.Get("References")),
+		From:        strings.ToLower(from.Address),
+		FromName:    from.Name,
+		Subject:     subject,
+		AuthResults: msg.Header["Authentication-Results"],
+	}
+
+	var html string
+	err = walkPart(msg.Header, msg.Body, func(h partHeader, body []byte) {
+		switch {
+		case h.attachment:
+			pm.Attachments = append(pm.Attachments, extractAttachment(h, body))
+		case h.mediaType == "text/plain" && pm.Body == "":
+			pm.Body = string(body)
+		case h.mediaType == "text/html" && html == "":
+			html = string(body)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pm.Body == "" && html != "" {
+		pm.Body = htmlToText(html)
+	}
+	pm.Body = strings.TrimSpace(stripQuoted(normalizeNewlines(pm.Body)))
+	return pm, nil
+}
+
+// partHeader is the subset of MIME headers walkPart needs.
+type partHeader struct {
+	mediaType  string
+	params     map[string]string
+	encoding   string
+	filename   string
+	attachment bool
+}
+
+type headerGetter interface {
+	Get(key string) string
+}
+
+func readPartHeader(h headerGetter) partHeader {
+	ph := partHeader{mediaType: "text/plain", encoding: strings.ToLower(strings.TrimSpace(h.Get("Content-Transfer-Encoding")))}
+	if ct := h.Get("Content-Type"); ct != "" {
+		if mt, params, err := mime.ParseMediaType(ct); err == nil {
+			ph.mediaType = strings.ToLower(mt)
+			ph.params = params
+		}
+	}
+	var disposition string
+	if cd := h.Get("Content-Disposition"); cd != "" {
+		if disp, params, err := mime.ParseMediaType(cd); err == nil {
+			disposition = strings.ToLower(disp)
+			ph.filename = params["filename"]
+		}
+	}
+	if ph.filename == "" {
+		ph.filename = ph.params["name"]
+	}
+	if dec, err := wordDecoder.DecodeHeader(ph.filename); err == nil {
+		ph.filename = dec
+	}
+	// Named parts and non-text leaves are attachments; the rest is body.
+	ph.attachment = disposition == "attachment" || ph.filename != "" || !strings.HasPrefix(ph.mediaType, "text/")
+	return ph
+}
+
+// walkPart decodes a MIME entity and calls fn for every leaf part.
+func walkPart(h headerGetter, r io.Reader, fn func(partHeader, []byte)) error {
+	ph := readPartHeader(h)
+
+	if strings.HasPrefix(ph.mediaType, "multipart/") {
+		boundary := ph.params["boundary"]
+		if boundary == "" {
+			return fmt.Errorf("multipart message without boundary")
+		}
+		mr := multipart.NewReader(r, boundary)
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("read MIME part: %w", err)
+			}
+			if err := walkPart(part.Header, part, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(decodeTransfer(r, ph.encoding), maxBodyBytes))
+	if err != nil {
+		return fmt.Errorf("decode %s part: %w", ph.mediaType, err)
+	}
+	if strings.HasPrefix(ph.mediaType, "text/") && !ph.attachment {
+		body = toUTF8(body, ph.params["charset"])
+	}
+	fn(ph, body)
+	return nil
+}
+
+func decodeTransfer(r io.Reader, encoding string) io.Reader {
+	switch encoding {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+// textLikeTypes are non-text/* media types whose content is extracted.
+var textLikeTypes = map[string]bool{
+	"application/json":       true,
+	"application/xml":        true,
+	"application/yaml":       true,
+	"application/x-yaml":     true,
+	"application/javascript": true,
+	"application/x-sh":       true,
+	"application/sql":        true,
+}
+
+func extractAttachment(h partHeader, body []byte) Attachment {
+	a := Attachment{Filename: h.filename, ContentType: h.mediaType, Size: len(body)}
+	if !strings.HasPrefix(h.mediaType, "text/") && !textLikeTypes[h.mediaType] {
+		return a
+	}
+	body = toUTF8(body, h.params["charset"])
+	if !utf8.Valid(body) {
+		return a
+	}
+	if len(body) > maxAttachmentText {
+		body = body[:maxAttachmentText]
+		for !utf8.Valid(body) {
+			body = body[:len(body)-1]
+		}
+		a.Truncated = true
+	}
+	a.Text = string(body)
+	return a
+}
+
+// toUTF8 converts ISO-8859-1 text to UTF-8. Other charsets are passed
+// through unchanged.
+func toUTF8(body []byte, charset string) []byte {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1":
+		out := make([]rune, len(body))
+		for i, b := range body {
+			out[i] = rune(b)
+		}
+		return []byte(string(out))
+	default:
+		return body
+	}
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "utf-8", "us-ascii":
+		return input, nil
+	case "iso-8859-1", "latin1":
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(toUTF8(data, charset)), nil
+	default:
+		return nil, fmt.Errorf("unsupported charset %q", charset)
+	}
+}
+
+var messageIDPattern = regexp.MustCompile(`<([^<>\s]+)>`)
+
+// messageIDs returns the Message-IDs in a References-style header, without
+// angle brackets.
+func messageIDs(header string) []string {
+	var ids []string
+	for _, m := range messageIDPattern.FindAllStringSubmatch(header, -1) {
+		ids = append(ids, m[1])
+	}
+	return ids
+}
+
+func firstMessageID(header string) string {
+	if ids := messageIDs(header); len(ids) > 0 {
+		return ids[0]
+	}
+	return strings.Trim(strings.TrimSpace(header), "<>")
+}
+
+func normalizeNewlines(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
+
+var attributionPattern = regexp.MustCompile(`(?i)^on .+wrote:$`)
+
+// stripQuoted removes quoted reply text: ">"-prefixed lines and the
+// "On ... wrote:" attribution line that introduces them. Everything from
+// an "-----Original Message-----" separator on is dropped too.
+func stripQuoted(body string) string {
+	lines := strings.Split(body, "\n")
+	out := make([]string, 0, len(lines))
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "-----Original Message-----") {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		if attributionPattern.MatchString(trimmed) && nextIsQuote(lines[i+1:]) {
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+func nextIsQuote(lines []string) bool {
+	for _, l := range lines {
+		if t := strings.TrimSpace(l); t != "" {
+			return strings.HasPrefix(t, ">")
+		}
+	}
+	return false
+}
+
+var (
+	htmlBreakPattern = regexp.MustCompile(`(?i)<\s*(br|/p|/div|/li|/tr|/h[1-6])\s*/?>`)
+	htmlTagPattern   = regexp.MustCompile(`<[^>]*>`)
+	htmlQuotePattern = regexp.MustCompile(`(?is)<blockquote.*?</blockquote>`)
+	blankRunPattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText is a minimal HTML to text conversion for HTML-only mail.
+func htmlToText(html string) string {
+	s := htmlQuotePattern.ReplaceAllString(html, "")
+	s = htmlBreakPattern.ReplaceAllString(s, "\n")
+	s = htmlTagPattern.ReplaceAllString(s, "")
+	s = strings.NewReplacer("&nbsp;", " ", "&lt;", "<", "&gt;", ">", "&quot;", `"`, "&#39;", "'", "&amp;", "&").Replace(s)
+	return blankRunPattern.ReplaceAllString(s, "\n\n")
+}
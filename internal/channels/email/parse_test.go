@@ -0,0 +1,223 @@
+package email
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func crlf(s string) []byte {
+	return []byte(strings.ReplaceAll(s, "\n", "\r\n"))
+}
+
+func TestParseMessage_ThreadRoot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give string
+		want string
+	}{
+		{
+			give: "Message-ID: <c@x>\nReferences: <a@x> <b@x>\nIn-Reply-To: <b@x>\n",
+			want: "a@x",
+		},
+		{
+			give: "Message-ID: <c@x>\nIn-Reply-To: <b@x>\n",
+			want: "b@x",
+		},
+		{
+			give: "Message-ID: <c@x>\n",
+			want: "c@x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+			raw := crlf("From: Alice <Alice@Example.com>\nSubject: hi\n" + tt.give + "\nhello\n")
+			pm, err := parseMessage(raw)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, pm.threadRoot())
+			assert.Equal(t, "alice@example.com", pm.From)
+			assert.Equal(t, "Alice", pm.FromName)
+			assert.Equal(t, "hello", pm.Body)
+		})
+	}
+}
+
+func TestParseMessage_StripsQuotedText(t *testing.T) {
+	t.Parallel()
+
+	raw := crlf(`From: bob@example.com
+Subject: Re: plan
+Message-ID: <2@example.com>
+
+Sounds good, go ahead.
+
+On Mon, 1 Jan 2026 at 10:00, Lango <bot@example.com> wrote:
+> Shall I proceed?
+> More quoted text
+`)
+	pm, err := parseMessage(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "Sounds good, go ahead.", pm.Body)
+
+	raw = crlf(`From: bob@example.com
+Subject: Re: plan
+
+approve
+
+-----Original Message-----
+From: bot
+Tool requires approval.
+`)
+	pm, err = parseMessage(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "approve", pm.Body)
+}
+
+func TestParseMessage_MultipartAttachments(t *testing.T) {
+	t.Parallel()
+
+	raw := crlf(`From: bob@example.com
+Subject: =?utf-8?q?R=C3=A9sum=C3=A9?=
+Message-ID: <m@example.com>
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain; charset=utf-8
+Content-Transfer-Encoding: quoted-printable
+
+Caf=C3=A9 notes attached.
+--inner
+Content-Type: text/html; charset=utf-8
+
+<p>Caf&eacute; notes attached.</p>
+--inner--
+--outer
+Content-Type: text/csv; name="data.csv"
+Content-Disposition: attachment; filename="data.csv"
+Content-Transfer-Encoding: base64
+
+YSxiCjEsMgo=
+--outer
+Content-Type: image/png
+Content-Disposition: attachment; filename="pic.png"
+Content-Transfer-Encoding: base64
+
+iVBORw0KGgo=
+--outer--
+`)
+	pm, err := parseMessage(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "Résumé", pm.Subject)
+	assert.Equal(t, "Café notes attached.", pm.Body)
+	require.Len(t, pm.Attachments, 2)
+
+	assert.Equal(t, "data.csv", pm.Attachments[0].Filename)
+	assert.Equal(t, "text/csv", pm.Attachments[0].ContentType)
+	assert.Equal(t, "a,b\n1,2\n", pm.Attachments[0].Text)
+
+	assert.Equal(t, "pic.png", pm.Attachments[1].Filename)
+	assert.Equal(t, 8, pm.Attachments[1].Size)
+	assert.Empty(t, pm.Attachments[1].Text)
+
+	text := composeText(pm)
+	assert.Contains(t, text, "[Attachment: data.csv (text/csv, 8 bytes)]\na,b\n1,2")
+	assert.Contains(t, text, "[Attachment: pic.png (image/png, 8 bytes)] content not extracted")
+}
+
+func TestParseMessage_HTMLOnly(t *testing.T) {
+	t.Parallel()
+
+	raw := crlf(`From: bob@example.com
+Subject: html
+Content-Type: text/html; charset=iso-8859-1
+
+<div>Line one</div><div>Caf` + "\xe9" + ` &amp; more</div><blockquote>old</blockquote>
+`)
+	pm, err := parseMessage(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "Line one\nCafé & more", pm.Body)
+}
+
+func TestParseMessage_TruncatesLargeAttachment(t *testing.T) {
+	t.Parallel()
+
+	big := strings.Repeat("x", maxAttachmentText+10)
+	raw := crlf(`From: bob@example.com
+Subject: log
+Content-Type: multipart/mixed; boundary="b"
+
+--b
+Content-Type: text/plain
+
+see log
+--b
+Content-Type: text/plain; name="app.log"
+
+` + big + `
+--b--
+`)
+	pm, err := parseMessage(raw)
+	require.NoError(t, err)
+	require.Len(t, pm.Attachments, 1)
+	assert.True(t, pm.Attachments[0].Truncated)
+	assert.Len(t, pm.Attachments[0].Text, maxAttachmentText)
+}
+
+func TestAuthenticated(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give        string
+		giveFrom    string
+		giveResults []string
+		want        bool
+	}{
+		{give: "dmarc pass", giveFrom: "alice@example.org", giveResults: []string{"mx.example.com; dmarc=pass header.from=example.org"}, want: true},
+		{give: "aligned dkim", giveFrom: "alice@example.org", giveResults: []string{"mx.example.com 1; dkim=pass (good signature) header.d=example.org; dmarc=none"}, want: true},
+		{give: "parent dkim domain", giveFrom: "alice@mail.example.org", giveResults: []string{"MX.example.com; dkim=pass header.d=Example.org"}, want: true},
+		{give: "unaligned dkim", giveFrom: "alice@example.org", giveResults: []string{"mx.example.com; dkim=pass header.d=example.net"}},
+		{give: "single label dkim", giveFrom: "alice@example.org", giveResults: []string{"mx.example.com; dkim=pass header.d=org"}},
+		{give: "dmarc for other domain", giveFrom: "alice@example.org", giveResults: []string{"mx.example.com; dmarc=pass header.from=example.net"}},
+		{give: "dmarc fail", giveFrom: "alice@example.org", giveResults: []string{"mx.example.com; dmarc=fail header.from=example.org"}},
+		{give: "untrusted server", giveFrom: "alice@example.org", giveResults: []string{"mx.example.net; dmarc=pass header.from=example.org"}},
+		{give: "forged lower header", giveFrom: "alice@example.org", giveResults: []string{"mx.example.com; dmarc=fail header.from=example.org", "mx.example.com; dmarc=pass header.from=example.org"}},
+		{give: "comment hides result", giveFrom: "alice@example.org", giveResults: []string{"mx.example.com; dmarc=fail (; dmarc=pass) header.from=example.org"}},
+		{give: "no header", giveFrom: "alice@example.org"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			assert.Equal(t, tt.want, authenticated(tt.giveResults, "mx.example.com", tt.giveFrom))
+		})
+	}
+}
+
+func TestOutgoingMail_Render(t *testing.T) {
+	t.Parallel()
+
+	m := &outgoingMail{
+		From:       "bot@example.com",
+		To:         "bob@example.com",
+		Subject:    "Re: Café",
+		Body:       "line 1\nline 2",
+		MessageID:  "3@example.com",
+		InReplyTo:  "2@example.com",
+		References: []string{"1@example.com", "2@example.com"},
+	}
+	pm, err := parseMessage(m.render())
+	require.NoError(t, err)
+	assert.Equal(t, "3@example.com", pm.MessageID)
+	assert.Equal(t, "2@example.com", pm.InReplyTo)
+	assert.Equal(t, []string{"1@example.com", "2@example.com"}, pm.References)
+	assert.Equal(t, "Re: Café", pm.Subject)
+	assert.Equal(t, "line 1\nline 2", pm.Body)
+}
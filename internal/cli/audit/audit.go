@@ -0,0 +1,266 @@
+// Package audit provides CLI commands for verifying and exporting the
+// hash-chained audit log.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/bootstrap"
+	auditpkg "github.com/langoai/lango/internal/observability/audit"
+	"github.com/langoai/lango/internal/p2p/identity"
+	"github.com/langoai/lango/internal/security"
+	"github.com/langoai/lango/internal/wallet"
+)
+
+// NewAuditCmd creates the audit command group with lazy bootstrap loading.
+func NewAuditCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Verify and export the tamper-evident audit log",
+		Long: `Verify and export the audit log.
+
+Every audit record is chained to its predecessor by hash, and the chain head
+is periodically signed with the wallet identity key. Editing, deleting or
+inserting rows directly in the database breaks the chain.`,
+	}
+
+	cmd.AddCommand(newVerifyCmd(bootLoader))
+	cmd.AddCommand(newExportCmd(bootLoader))
+
+	return cmd
+}
+
+// verifiers returns the checkpoint signature verifiers for each supported
+// algorithm, keyed by algorithm name.
+func verifiers() map[string]auditpkg.SignatureVerifyFunc {
+	return map[string]auditpkg.SignatureVerifyFunc{
+		security.AlgorithmSecp256k1Keccak256: identity.VerifyMessageSignature,
+		security.AlgorithmEd25519: func(did string, payload, signature []byte) error {
+			pubkey, err := identity.ParseDIDPublicKey(did)
+			if err != nil {
+				return err
+			}
+			return security.VerifyEd25519(pubkey, payload, signature)
+		},
+	}
+}
+
+// trustedSigner returns the DID checkpoints must be signed by: the --signer
+// flag, then observability.audit.checkpointSigner, then the local wallet
+// DID. It returns "" when none is available.
+func trustedSigner(ctx context.Context, boot *bootstrap.Result, flag string) string {
+	if flag != "" {
+		return flag
+	}
+	if s := boot.Config.Observability.Audit.CheckpointSigner; s != "" {
+		return s
+	}
+	did, err := localWalletDID(ctx, boot)
+	if err != nil {
+		return ""
+	}
+	return did
+}
+
+// localWalletDID derives the DID of the local wallet key, which signs
+// checkpoints on this node.
+func localWalletDID(ctx context.Context, boot *bootstrap.Result) (string, error) {
+	if boot.Crypto == nil || !boot.Config.Payment.Enabled {
+		return "", fmt.Errorf("no local wallet")
+	}
+	switch boot.Config.Payment.WalletProvider {
+	case "", "local", "composite":
+	default:
+		return "", fmt.Errorf("wallet provider %q has no local key", boot.Config.Payment.WalletProvider)
+	}
+
+	keys := security.NewKeyRegistry(boot.DBClient)
+	secrets := security.NewSecretsStore(boot.DBClient, keys, boot.Crypto)
+	wp := wallet.NewLocalWallet(secrets, boot.Config.Payment.Network.RPCURL, boot.Config.Payment.Network.ChainID)
+	pub, err := wp.PublicKey(ctx)
+	if err != nil {
+		return "", fmt.Errorf("load wallet public key: %w", err)
+	}
+	did, err := identity.DIDFromPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("derive wallet DID: %w", err)
+	}
+	return did.ID, nil
+}
+
+func newVerifyCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
+	var (
+		jsonOutput bool
+		signer     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Detect modified, deleted or inserted audit records",
+		Long: `Recompute the audit hash chain and check every signed checkpoint.
+
+Checkpoints must be signed by a trusted DID: --signer, else
+observability.audit.checkpointSigner, else the local wallet DID. A
+checkpoint signed by any other key is reported as a problem, because a
+rewritten chain can be re-signed with a fresh key.
+
+Each problem names the record's sequence number and ID. The command exits
+with an error when any problem is found.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			boot, err := bootLoader()
+			if err != nil {
+				return err
+			}
+			defer boot.DBClient.Close()
+
+			pinned := trustedSigner(cmd.Context(), boot, signer)
+			report, err := auditpkg.Verify(cmd.Context(), boot.DBClient, pinned, verifiers())
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(report); err != nil {
+					return fmt.Errorf("encode report: %w", err)
+				}
+			} else {
+				printReport(cmd.OutOrStdout(), report)
+			}
+			if !report.OK() {
+				return fmt.Errorf("audit chain verification failed: %d problem(s)", len(report.Problems))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the report as JSON")
+	cmd.Flags().StringVar(&signer, "signer", "", "DID trusted to sign checkpoints (default: config, then local wallet)")
+	return cmd
+}
+
+func printReport(w io.Writer, r *auditpkg.Report) {
+	fmt.Fprintf(w, "Records:     %d chained", r.Records)
+	if r.Legacy > 0 {
+		fmt.Fprintf(w, ", %d written before chaining (unverifiable)", r.Legacy)
+	}
+	fmt.Fprintln(w)
+	if r.HeadSeq > 0 {
+		fmt.Fprintf(w, "Head:        seq %d  %s\n", r.HeadSeq, r.HeadHash)
+	}
+	fmt.Fprintf(w, "Checkpoints: %d\n", r.Checkpoints)
+	if r.Signer != "" {
+		fmt.Fprintf(w, "Signer:      %s\n", r.Signer)
+	}
+
+	if r.OK() {
+		fmt.Fprintln(w, "\nAudit chain intact.")
+		return
+	}
+	fmt.Fprintf(w, "\n%d problem(s):\n", len(r.Problems))
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SEQ\tKIND\tID\tDETAIL")
+	for _, p := range r.Problems {
+		seq := "-"
+		if p.Seq > 0 {
+			seq = fmt.Sprint(p.Seq)
+		}
+		id := p.ID
+		if id == "" {
+			id = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", seq, p.Kind, id, p.Message)
+	}
+	tw.Flush()
+}
+
+func newExportCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
+	var (
+		since  string
+		format string
+		out    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export audit records with their chain proofs",
+		Long: `Export audit records in chain order. Every record carries its prev_hash
+and hash, and records covered by a signed checkpoint carry the signer,
+algorithm and signature.
+
+--since accepts an RFC 3339 time, a date (2006-01-02) or a duration back
+from now (e.g. 24h).
+
+Examples:
+  lango audit export > audit.jsonl
+  lango audit export --since 2026-01-01 --format csv --out audit.csv
+  lango audit export --since 168h`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := auditpkg.ParseExportFormat(format)
+			if err != nil {
+				return err
+			}
+			from, err := parseSince(since, time.Now())
+			if err != nil {
+				return err
+			}
+
+			boot, err := bootLoader()
+			if err != nil {
+				return err
+			}
+			defer boot.DBClient.Close()
+
+			w := cmd.OutOrStdout()
+			if out != "" {
+				file, err := os.OpenFile(out, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+				if err != nil {
+					return fmt.Errorf("create export file: %w", err)
+				}
+				defer file.Close()
+				w = file
+			}
+			if err := auditpkg.Export(cmd.Context(), boot.DBClient, w, f, from); err != nil {
+				return fmt.Errorf("export audit log: %w", err)
+			}
+			if out != "" {
+				cmd.PrintErrf("Exported audit log to %s\n", out)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only export records at or after this time")
+	cmd.Flags().StringVar(&format, "format", string(auditpkg.ExportJSONL), "Output format: jsonl or csv")
+	cmd.Flags().StringVar(&out, "out", "", "Write to a file instead of stdout")
+	return cmd
+}
+
+// parseSince parses an RFC 3339 time, a date, or a duration before now.
+// An empty string means no lower bound.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q (want RFC 3339 time, YYYY-MM-DD or duration)", s)
+}
@@ -0,0 +1,162 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/bootstrap"
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/ent/auditlog"
+	"github.com/langoai/lango/internal/ent/enttest"
+	auditpkg "github.com/langoai/lango/internal/observability/audit"
+	"github.com/langoai/lango/internal/p2p/identity"
+	"github.com/langoai/lango/internal/security"
+)
+
+func seededBootLoader(t *testing.T, unchained bool) func() (*bootstrap.Result, error) {
+	t.Helper()
+	ctx := context.Background()
+	dsn := "file:" + filepath.Join(t.TempDir(), "audit.db") + "?_fk=1"
+	client := enttest.Open(t, "sqlite3", dsn)
+	chained := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { chained.Close() })
+	auditpkg.InstallChain(chained)
+	for _, target := range []string{"a", "b", "c"} {
+		_, err := chained.AuditLog.Create().SetAction(auditlog.ActionToolCall).SetActor("agent").SetTarget(target).Save(ctx)
+		require.NoError(t, err)
+	}
+	if unchained {
+		_, err := client.AuditLog.Create().SetAction(auditlog.ActionToolCall).SetActor("intruder").
+			SetTimestamp(time.Now().Add(time.Minute)).Save(ctx)
+		require.NoError(t, err)
+	}
+	return func() (*bootstrap.Result, error) {
+		return &bootstrap.Result{Config: config.DefaultConfig(), DBClient: client}, nil
+	}
+}
+
+func execute(t *testing.T, bootLoader func() (*bootstrap.Result, error), args ...string) (string, error) {
+	t.Helper()
+	cmd := NewAuditCmd(bootLoader)
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestVerifyCmd(t *testing.T) {
+	out, err := execute(t, seededBootLoader(t, false), "verify")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Records:     3 chained")
+	assert.Contains(t, out, "Audit chain intact.")
+
+	out, err = execute(t, seededBootLoader(t, true), "verify")
+	require.ErrorContains(t, err, "1 problem(s)")
+	assert.Contains(t, out, "unchained")
+}
+
+type ethSigner struct{ key *ecdsa.PrivateKey }
+
+func (s ethSigner) Sign(_ context.Context, payload []byte) ([]byte, error) {
+	return ethcrypto.Sign(ethcrypto.Keccak256(payload), s.key)
+}
+
+func (s ethSigner) Algorithm() string { return security.AlgorithmSecp256k1Keccak256 }
+
+func newEthSigner(t *testing.T) (ethSigner, string) {
+	t.Helper()
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	did, err := identity.DIDFromPublicKey(ethcrypto.CompressPubkey(&key.PublicKey))
+	require.NoError(t, err)
+	return ethSigner{key: key}, did.ID
+}
+
+func TestVerifyCmd_PinnedSigner(t *testing.T) {
+	ctx := context.Background()
+	dsn := "file:" + filepath.Join(t.TempDir(), "audit.db") + "?_fk=1"
+	chained := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { chained.Close() })
+	auditpkg.InstallChain(chained)
+	_, err := chained.AuditLog.Create().SetAction(auditlog.ActionToolCall).SetActor("agent").Save(ctx)
+	require.NoError(t, err)
+
+	// A checkpoint signed by a key nobody pinned, as after a chain rewrite.
+	signer, did := newEthSigner(t)
+	_, err = auditpkg.NewCheckpointer(chained, did, signer, 0).Checkpoint(ctx)
+	require.NoError(t, err)
+
+	cfg := config.DefaultConfig()
+	bootLoader := func() (*bootstrap.Result, error) {
+		return &bootstrap.Result{Config: cfg, DBClient: enttest.Open(t, "sqlite3", dsn)}, nil
+	}
+
+	out, err := execute(t, bootLoader, "verify")
+	require.ErrorContains(t, err, "1 problem(s)")
+	assert.Contains(t, out, "no trusted signer")
+
+	_, other := newEthSigner(t)
+	out, err = execute(t, bootLoader, "verify", "--signer", other)
+	require.ErrorContains(t, err, "1 problem(s)")
+	assert.Contains(t, out, "expected "+other)
+
+	cfg.Observability.Audit.CheckpointSigner = did
+	out, err = execute(t, bootLoader, "verify")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Signer:      "+did)
+}
+
+func TestExportCmd(t *testing.T) {
+	out, err := execute(t, seededBootLoader(t, false), "export", "--format", "csv")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "seq,id,timestamp"))
+	assert.True(t, strings.HasPrefix(lines[3], "3,"))
+
+	_, err = execute(t, seededBootLoader(t, false), "export", "--format", "xml")
+	assert.ErrorContains(t, err, "must be jsonl or csv")
+}
+
+func TestParseSince(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		give    string
+		want    time.Time
+		wantErr bool
+	}{
+		{give: "", want: time.Time{}},
+		{give: "2026-03-01T08:00:00Z", want: time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)},
+		{give: "2026-03-01", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)},
+		{give: "24h", want: now.Add(-24 * time.Hour)},
+		{give: "yesterday", wantErr: true},
+		{give: "-1h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSince(tt.give, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v", got)
+		})
+	}
+}
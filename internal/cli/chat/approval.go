@@ -76,6 +76,10 @@ func formatChannelOrigin(sessionKey string) string {
 		channelName = "Discord"
 	case "slack":
 		channelName = "Slack"
+	case "email":
+		// Email keys are "email:<thread>:<sender>"; the sender is more
+		// recognizable than the thread's Message-ID.
+		return fmt.Sprintf("[Email] %s", sessionKey[strings.LastIndexByte(sessionKey, ':')+1:])
 	default:
 		return ""
 	}
@@ -98,6 +102,8 @@ func formatChannelBadge(sessionKey string) string {
 		return "[DC]"
 	case "slack":
 		return "[SL]"
+	case "email":
+		return "[EM]"
 	default:
 		return ""
 	}
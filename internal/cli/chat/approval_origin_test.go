@@ -25,6 +25,11 @@ func TestFormatChannelOrigin_Slack(t *testing.T) {
 	assert.Equal(t, "[Slack] C123", got)
 }
 
+func TestFormatChannelOrigin_Email(t *testing.T) {
+	got := formatChannelOrigin("email:abc@mail.example.com:alice@example.com")
+	assert.Equal(t, "[Email] alice@example.com", got)
+}
+
 func TestFormatChannelOrigin_NonChannel(t *testing.T) {
 	got := formatChannelOrigin("tui-12345")
 	assert.Equal(t, "", got)
@@ -57,6 +62,11 @@ func TestFormatChannelBadge_Slack(t *testing.T) {
 	assert.Equal(t, "[SL]", got)
 }
 
+func TestFormatChannelBadge_Email(t *testing.T) {
+	got := formatChannelBadge("email:thread@host:user@example.com")
+	assert.Equal(t, "[EM]", got)
+}
+
 func TestFormatChannelBadge_NonChannel(t *testing.T) {
 	got := formatChannelBadge("tui-12345")
 	assert.Equal(t, "", got)
@@ -18,15 +18,23 @@ import (
 	"github.com/langoai/lango/internal/approval"
 	"github.com/langoai/lango/internal/background"
 	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/mcp"
 	"github.com/langoai/lango/internal/turnrunner"
 )
 
+// TurnRunner runs a single chat turn. *turnrunner.Runner runs it in-process;
+// a remote cockpit runs it over the gateway.
+type TurnRunner interface {
+	Run(ctx context.Context, req turnrunner.Request) (turnrunner.Result, error)
+}
+
 // Deps holds the dependencies injected into the chat model.
 type Deps struct {
-	TurnRunner        *turnrunner.Runner
+	TurnRunner        TurnRunner
 	Config            *config.Config
 	SessionKey        string
 	BackgroundManager *background.Manager // optional, nil when background tasks unavailable
+	MCPManager        *mcp.ServerManager  // optional, enables MCP prompt slash commands
 }
 
 // cursorBlinkInterval is the period between cursor blink toggles.
@@ -45,7 +53,7 @@ type ChatParts struct {
 
 // ChatModel is the root bubbletea model for the interactive TUI chat.
 type ChatModel struct {
-	turnRunner *turnrunner.Runner
+	turnRunner TurnRunner
 	cfg        *config.Config
 	sessionKey string
 
@@ -65,6 +73,7 @@ type ChatModel struct {
 	program *tea.Program
 
 	bgManager *background.Manager
+	mcp       *mcp.ServerManager
 	taskStrip taskStripModel
 	pending   pendingIndicator
 
@@ -80,6 +89,7 @@ func New(deps Deps) *ChatModel {
 		cfg:        deps.Config,
 		sessionKey: deps.SessionKey,
 		bgManager:  deps.BackgroundManager,
+		mcp:        deps.MCPManager,
 		taskStrip:  newTaskStripModel(deps.BackgroundManager),
 		input:      newInputModel(),
 		chatView:   newChatViewModel(80, 20),
@@ -291,6 +301,13 @@ func (m *ChatModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case SystemMsg:
 		m.chatView.appendSystem(msg.Text)
 		return m, nil
+
+	case PromptSubmitMsg:
+		if m.state != stateIdle {
+			m.chatView.appendSystem("A turn is already running; prompt not submitted.")
+			return m, nil
+		}
+		return m, m.submitInput(msg.Text)
 	}
 
 	if m.inputAcceptsText() {
@@ -444,19 +461,24 @@ func (m *ChatModel) handleIdleKey(msg tea.KeyMsg) tea.Cmd {
 			return cmd
 		}
 
-		m.chatView.appendUser(input)
-		// Set pending state before transition so recalcLayout accounts for the strip.
-		m.pending.Activate()
-		return tea.Batch(
-			m.transitionTo(stateStreaming),
-			m.submitCmd(input),
-			m.pending.TickCmd(),
-		)
+		return m.submitInput(input)
 	}
 
 	return nil
 }
 
+// submitInput shows input as the user's message and starts a turn.
+func (m *ChatModel) submitInput(input string) tea.Cmd {
+	m.chatView.appendUser(input)
+	// Set pending state before transition so recalcLayout accounts for the strip.
+	m.pending.Activate()
+	return tea.Batch(
+		m.transitionTo(stateStreaming),
+		m.submitCmd(input),
+		m.pending.TickCmd(),
+	)
+}
+
 func (m *ChatModel) handleStreamingKey(msg tea.KeyMsg) tea.Cmd {
 	if key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+c"))) {
 		if m.cancelFn != nil {
@@ -488,3 +488,30 @@ func TestCPRTimeoutFlushesEsc(t *testing.T) {
 		t.Fatalf("want cprIdle after timeout, got %v", m.cpr.state)
 	}
 }
+
+func TestParsePromptArgs(t *testing.T) {
+	tests := []struct {
+		give     string
+		argNames []string
+		want     map[string]string
+	}{
+		{give: "", argNames: []string{"file"}, want: map[string]string{}},
+		{give: "main.go", argNames: []string{"file"}, want: map[string]string{"file": "main.go"}},
+		{give: "fix the tests", argNames: []string{"task"}, want: map[string]string{"task": "fix the tests"}},
+		{
+			give:     `file=main.go focus="error handling"`,
+			argNames: []string{"file", "focus"},
+			want:     map[string]string{"file": "main.go", "focus": "error handling"},
+		},
+		{give: `focus="unterminated`, argNames: []string{"focus", "x"}, want: map[string]string{"focus": "unterminated"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got := parsePromptArgs(tt.give, tt.argNames)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("parsePromptArgs(%q) = %v, want %v", tt.give, got, tt.want)
+			}
+		})
+	}
+}
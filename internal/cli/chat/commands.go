@@ -1,8 +1,10 @@
 package chat
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -82,6 +84,13 @@ func dispatchSlash(m *ChatModel, input string) (bool, tea.Cmd) {
 		}
 	}
 
+	// MCP prompts: /<server>:<prompt> [args]. Prompt names keep their case.
+	if server, name, ok := strings.Cut(strings.TrimPrefix(parts[0], "/"), ":"); ok && m.mcp != nil {
+		if _, found := m.mcp.FindPrompt(server, name); found {
+			return true, cmdMCPPrompt(m, server, name, args)
+		}
+	}
+
 	// Unknown command.
 	return true, func() tea.Msg {
 		return SystemMsg{Text: fmt.Sprintf("Unknown command: %s. Type /help for available commands.", cmd)}
@@ -100,6 +109,22 @@ func cmdHelp(m *ChatModel, _ string) tea.Cmd {
 		fmt.Fprintf(&b, "  %s  %s\n", name, sc.desc)
 	}
 
+	if m.mcp != nil {
+		if prompts := m.mcp.AllPrompts(); len(prompts) > 0 {
+			b.WriteString("\n")
+			hdr := lipgloss.NewStyle().Bold(true).Foreground(tui.Primary).Render("MCP Prompts")
+			b.WriteString(hdr + "\n")
+			for _, dp := range prompts {
+				usage := "/" + dp.ServerName + ":" + dp.Prompt.Name
+				for _, a := range dp.Prompt.Arguments {
+					usage += " " + a.Name + "="
+				}
+				name := lipgloss.NewStyle().Bold(true).Foreground(tui.Highlight).Render(usage)
+				fmt.Fprintf(&b, "  %s  %s\n", name, dp.Prompt.Description)
+			}
+		}
+	}
+
 	b.WriteString("\n")
 	keys := lipgloss.NewStyle().Bold(true).Foreground(tui.Primary).Render("Key Bindings")
 	b.WriteString(keys + "\n")
@@ -120,6 +145,73 @@ func cmdHelp(m *ChatModel, _ string) tea.Cmd {
 	}
 }
 
+// mcpPromptTimeout bounds rendering an MCP prompt before submission.
+const mcpPromptTimeout = 30 * time.Second
+
+// cmdMCPPrompt renders an MCP prompt and submits the result as the user's turn.
+func cmdMCPPrompt(m *ChatModel, server, name, rawArgs string) tea.Cmd {
+	mgr := m.mcp
+	var argNames []string
+	if dp, ok := mgr.FindPrompt(server, name); ok {
+		for _, a := range dp.Prompt.Arguments {
+			argNames = append(argNames, a.Name)
+		}
+	}
+	args := parsePromptArgs(rawArgs, argNames)
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), mcpPromptTimeout)
+		defer cancel()
+		text, err := mgr.GetPrompt(ctx, server, name, args)
+		if err != nil {
+			return SystemMsg{Text: fmt.Sprintf("MCP prompt failed: %v", err)}
+		}
+		if strings.TrimSpace(text) == "" {
+			return SystemMsg{Text: fmt.Sprintf("MCP prompt %s:%s rendered no content.", server, name)}
+		}
+		return PromptSubmitMsg{Text: text}
+	}
+}
+
+// parsePromptArgs parses "key=value" pairs (values may be double-quoted).
+// When the prompt takes a single argument and no pair is given, the whole
+// input is used as that argument's value.
+func parsePromptArgs(raw string, argNames []string) map[string]string {
+	args := make(map[string]string)
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return args
+	}
+	if len(argNames) == 1 && !strings.Contains(raw, "=") {
+		args[argNames[0]] = raw
+		return args
+	}
+
+	for raw != "" {
+		key, rest, ok := strings.Cut(raw, "=")
+		if !ok {
+			break
+		}
+		key = strings.TrimSpace(key)
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			value, rest, _ = strings.Cut(rest, " ")
+		}
+		if key != "" {
+			args[key] = value
+		}
+		raw = strings.TrimSpace(rest)
+	}
+	return args
+}
+
 func cmdClear(m *ChatModel, _ string) tea.Cmd {
 	m.chatView.clear()
 	m.sessionKey = generateSessionKey()
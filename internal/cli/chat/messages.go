@@ -40,6 +40,12 @@ type SystemMsg struct {
 	Text string
 }
 
+// PromptSubmitMsg submits a rendered prompt (e.g. an MCP prompt slash
+// command) as the user's turn.
+type PromptSubmitMsg struct {
+	Text string
+}
+
 // CursorTickMsg triggers cursor blink toggle during streaming.
 type CursorTickMsg time.Time
 
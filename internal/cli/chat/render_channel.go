@@ -19,6 +19,8 @@ func channelColor(channel string) lipgloss.Color {
 		return lipgloss.Color("#5865F2") // Discord blurple
 	case "slack":
 		return lipgloss.Color("#4A154B") // Slack aubergine
+	case "email":
+		return lipgloss.Color("#D97706") // Email amber
 	default:
 		return tui.Muted
 	}
@@ -17,6 +17,7 @@ func TestChannelColor_KnownChannels(t *testing.T) {
 		{give: "telegram", wantNot: tui.Muted},
 		{give: "discord", wantNot: tui.Muted},
 		{give: "slack", wantNot: tui.Muted},
+		{give: "email", wantNot: tui.Muted},
 	}
 
 	for _, tt := range tests {
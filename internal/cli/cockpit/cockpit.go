@@ -45,6 +45,7 @@ func New(deps Deps) *Model {
 		Config:            deps.Config,
 		SessionKey:        deps.SessionKey,
 		BackgroundManager: deps.BackgroundManager,
+		MCPManager:        deps.MCPManager,
 	})
 
 	return &Model{
@@ -347,6 +348,8 @@ func (m *Model) handleKey(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		return m, m.switchPage(PageTasks)
 	case key.Matches(msg, m.keymap.Page6):
 		return m, m.switchPage(PageApprovals)
+	case key.Matches(msg, m.keymap.Page7):
+		return m, m.switchPage(PageKnowledge)
 	}
 
 	// Focus-dependent routing.
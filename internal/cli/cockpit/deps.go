@@ -4,19 +4,20 @@ import (
 	"github.com/langoai/lango/internal/app"
 	"github.com/langoai/lango/internal/approval"
 	"github.com/langoai/lango/internal/background"
+	"github.com/langoai/lango/internal/cli/chat"
 	"github.com/langoai/lango/internal/config"
 	"github.com/langoai/lango/internal/configstore"
 	"github.com/langoai/lango/internal/eventbus"
+	"github.com/langoai/lango/internal/mcp"
 	"github.com/langoai/lango/internal/observability"
 	"github.com/langoai/lango/internal/toolcatalog"
-	"github.com/langoai/lango/internal/turnrunner"
 )
 
 // Deps holds the dependencies for the cockpit TUI.
 // ApprovalProvider is NOT included — type assertion for SetTTYFallback
 // is handled in cmd/lango/main.go's runCockpit().
 type Deps struct {
-	TurnRunner        *turnrunner.Runner
+	TurnRunner        chat.TurnRunner
 	Config            *config.Config
 	SessionKey        string
 	ToolCatalog       *toolcatalog.Catalog
@@ -28,4 +29,5 @@ type Deps struct {
 	EventBus          *eventbus.Bus          // optional, enables channel event subscription
 	ApprovalHistory   *approval.HistoryStore // optional, approval decision history
 	GrantStore        *approval.GrantStore   // optional, persistent session grants
+	MCPManager        *mcp.ServerManager     // optional, enables MCP prompt slash commands
 }
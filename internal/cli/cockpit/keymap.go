@@ -13,6 +13,7 @@ type keyMap struct {
 	Page4         key.Binding
 	Page5         key.Binding
 	Page6         key.Binding
+	Page7         key.Binding
 }
 
 func defaultKeyMap() keyMap {
@@ -57,5 +58,9 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("ctrl+6"),
 			key.WithHelp("ctrl+6", "approvals"),
 		),
+		Page7: key.NewBinding(
+			key.WithKeys("ctrl+7"),
+			key.WithHelp("ctrl+7", "knowledge"),
+		),
 	}
 }
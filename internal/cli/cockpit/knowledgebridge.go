@@ -0,0 +1,278 @@
+package cockpit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/langoai/lango/internal/cli/cockpit/pages"
+	"github.com/langoai/lango/internal/ent"
+	"github.com/langoai/lango/internal/graph"
+	"github.com/langoai/lango/internal/knowledge"
+	"github.com/langoai/lango/internal/memory"
+)
+
+const (
+	knowledgeListLimit = 50
+	relevanceStep      = 0.5
+	confidenceStep     = 0.1
+
+	defaultMinRelevance = 0.1
+	defaultMaxRelevance = 5.0
+)
+
+// KnowledgeBridge adapts the knowledge, memory and graph stores to
+// pages.KnowledgeBrowser. Any store may be nil; its section then reports
+// pages.ErrSectionUnavailable.
+type KnowledgeBridge struct {
+	knowledge *knowledge.Store
+	memory    *memory.Store
+	graph     graph.Store
+
+	minRelevance float64
+	maxRelevance float64
+}
+
+var _ pages.KnowledgeBrowser = (*KnowledgeBridge)(nil)
+
+// NewKnowledgeBridge creates a KnowledgeBridge. minRelevance and
+// maxRelevance bound manual boost/demote; zero values use the
+// retrieval.autoAdjust defaults.
+func NewKnowledgeBridge(ks *knowledge.Store, ms *memory.Store, gs graph.Store, minRelevance, maxRelevance float64) *KnowledgeBridge {
+	if minRelevance <= 0 {
+		minRelevance = defaultMinRelevance
+	}
+	if maxRelevance <= minRelevance {
+		maxRelevance = defaultMaxRelevance
+	}
+	return &KnowledgeBridge{
+		knowledge:    ks,
+		memory:       ms,
+		graph:        gs,
+		minRelevance: minRelevance,
+		maxRelevance: maxRelevance,
+	}
+}
+
+// SearchKnowledge lists the latest knowledge entries matching query, or the
+// most relevant entries when query is empty.
+func (b *KnowledgeBridge) SearchKnowledge(ctx context.Context, query string) ([]pages.KnowledgeItem, error) {
+	if b.knowledge == nil {
+		return nil, pages.ErrSectionUnavailable
+	}
+	entries, err := b.knowledge.SearchKnowledge(ctx, query, "", knowledgeListLimit)
+	if err != nil {
+		return nil, err
+	}
+	return toKnowledgeItems(entries), nil
+}
+
+// KnowledgeHistory returns every version of a knowledge entry, newest first.
+func (b *KnowledgeBridge) KnowledgeHistory(ctx context.Context, key string) ([]pages.KnowledgeItem, error) {
+	if b.knowledge == nil {
+		return nil, pages.ErrSectionUnavailable
+	}
+	entries, err := b.knowledge.GetKnowledgeHistory(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return toKnowledgeItems(entries), nil
+}
+
+// UpdateKnowledge saves content as a new version of an entry.
+func (b *KnowledgeBridge) UpdateKnowledge(ctx context.Context, key, content string) error {
+	if b.knowledge == nil {
+		return pages.ErrSectionUnavailable
+	}
+	entry, err := b.knowledge.GetKnowledge(ctx, key)
+	if err != nil {
+		return err
+	}
+	entry.Content = content
+	return b.knowledge.SaveKnowledge(ctx, "", *entry)
+}
+
+// DeleteKnowledge deletes every version of an entry.
+func (b *KnowledgeBridge) DeleteKnowledge(ctx context.Context, key string) error {
+	if b.knowledge == nil {
+		return pages.ErrSectionUnavailable
+	}
+	return b.knowledge.DeleteKnowledge(ctx, key)
+}
+
+// AdjustRelevance boosts or demotes an entry's relevance score within the
+// configured bounds.
+func (b *KnowledgeBridge) AdjustRelevance(ctx context.Context, key string, up bool) error {
+	if b.knowledge == nil {
+		return pages.ErrSectionUnavailable
+	}
+	if up {
+		return b.knowledge.BoostRelevanceScore(ctx, key, relevanceStep, b.maxRelevance)
+	}
+	return b.knowledge.DemoteRelevanceScore(ctx, key, relevanceStep, b.minRelevance)
+}
+
+// SearchLearnings lists learnings matching query, or the most recent
+// learnings when query is empty.
+func (b *KnowledgeBridge) SearchLearnings(ctx context.Context, query string) ([]pages.LearningItem, error) {
+	if b.knowledge == nil {
+		return nil, pages.ErrSectionUnavailable
+	}
+	var (
+		entities []*ent.Learning
+		err      error
+	)
+	if query != "" {
+		entities, err = b.knowledge.SearchLearningEntities(ctx, query, knowledgeListLimit)
+	} else {
+		entities, _, err = b.knowledge.ListLearnings(ctx, "", 0, time.Time{}, knowledgeListLimit, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	items := make([]pages.LearningItem, 0, len(entities))
+	for _, l := range entities {
+		items = append(items, pages.LearningItem{
+			ID:           l.ID.String(),
+			Trigger:      l.Trigger,
+			ErrorPattern: l.ErrorPattern,
+			Diagnosis:    l.Diagnosis,
+			Fix:          l.Fix,
+			Category:     string(l.Category),
+			Confidence:   l.Confidence,
+			CreatedAt:    l.CreatedAt,
+		})
+	}
+	return items, nil
+}
+
+// DeleteLearning deletes a learning by ID.
+func (b *KnowledgeBridge) DeleteLearning(ctx context.Context, id string) error {
+	if b.knowledge == nil {
+		return pages.ErrSectionUnavailable
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("parse learning id: %w", err)
+	}
+	return b.knowledge.DeleteLearning(ctx, uid)
+}
+
+// AdjustConfidence raises or lowers a learning's confidence.
+func (b *KnowledgeBridge) AdjustConfidence(ctx context.Context, id string, up bool) error {
+	if b.knowledge == nil {
+		return pages.ErrSectionUnavailable
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("parse learning id: %w", err)
+	}
+	delta := confidenceStep
+	if !up {
+		delta = -delta
+	}
+	return b.knowledge.AdjustLearningConfidence(ctx, uid, delta)
+}
+
+// ListMemory returns a session's observations and reflections, newest first.
+func (b *KnowledgeBridge) ListMemory(ctx context.Context, sessionKey string) ([]pages.MemoryNote, error) {
+	if b.memory == nil {
+		return nil, pages.ErrSectionUnavailable
+	}
+	observations, err := b.memory.ListObservations(ctx, sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	reflections, err := b.memory.ListReflections(ctx, sessionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := make([]pages.MemoryNote, 0, len(observations)+len(reflections))
+	for _, r := range reflections {
+		notes = append(notes, pages.MemoryNote{
+			ID: r.ID.String(), SessionKey: r.SessionKey, Kind: "reflection",
+			Content: r.Content, CreatedAt: r.CreatedAt,
+		})
+	}
+	for _, o := range observations {
+		notes = append(notes, pages.MemoryNote{
+			ID: o.ID.String(), SessionKey: o.SessionKey, Kind: "observation",
+			Content: o.Content, CreatedAt: o.CreatedAt,
+		})
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].CreatedAt.After(notes[j].CreatedAt)
+	})
+	return notes, nil
+}
+
+// DeleteMemory deletes one observation or reflection.
+func (b *KnowledgeBridge) DeleteMemory(ctx context.Context, note pages.MemoryNote) error {
+	if b.memory == nil {
+		return pages.ErrSectionUnavailable
+	}
+	uid, err := uuid.Parse(note.ID)
+	if err != nil {
+		return fmt.Errorf("parse %s id: %w", note.Kind, err)
+	}
+	if note.Kind == "reflection" {
+		return b.memory.DeleteReflections(ctx, []uuid.UUID{uid})
+	}
+	return b.memory.DeleteObservations(ctx, []uuid.UUID{uid})
+}
+
+// Neighborhood returns the edges touching node, optionally filtered by
+// predicate.
+func (b *KnowledgeBridge) Neighborhood(ctx context.Context, node string, predicates []string) ([]pages.GraphEdge, error) {
+	if b.graph == nil {
+		return nil, pages.ErrSectionUnavailable
+	}
+	triples, err := b.graph.Traverse(ctx, node, 1, predicates)
+	if err != nil {
+		return nil, err
+	}
+	edges := make([]pages.GraphEdge, 0, len(triples))
+	for _, t := range triples {
+		edges = append(edges, pages.GraphEdge{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object})
+	}
+	return edges, nil
+}
+
+// Predicates returns the predicate types offered as graph filters.
+func (b *KnowledgeBridge) Predicates() []string {
+	values := graph.Predicate("").Values()
+	preds := make([]string, len(values))
+	for i, p := range values {
+		preds[i] = string(p)
+	}
+	return preds
+}
+
+// RemoveEdge removes a triple from the graph.
+func (b *KnowledgeBridge) RemoveEdge(ctx context.Context, e pages.GraphEdge) error {
+	if b.graph == nil {
+		return pages.ErrSectionUnavailable
+	}
+	return b.graph.RemoveTriple(ctx, graph.Triple{Subject: e.Subject, Predicate: e.Predicate, Object: e.Object})
+}
+
+func toKnowledgeItems(entries []knowledge.KnowledgeEntry) []pages.KnowledgeItem {
+	items := make([]pages.KnowledgeItem, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, pages.KnowledgeItem{
+			Key:       e.Key,
+			Category:  string(e.Category),
+			Content:   e.Content,
+			Source:    e.Source,
+			Tags:      e.Tags,
+			Version:   e.Version,
+			Relevance: e.RelevanceScore,
+			UpdatedAt: e.UpdatedAt,
+		})
+	}
+	return items
+}
@@ -0,0 +1,147 @@
+package cockpit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/langoai/lango/internal/cli/cockpit/pages"
+	"github.com/langoai/lango/internal/ent/enttest"
+	"github.com/langoai/lango/internal/graph"
+	"github.com/langoai/lango/internal/knowledge"
+	"github.com/langoai/lango/internal/memory"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestKnowledgeBridge(t *testing.T) (*KnowledgeBridge, *knowledge.Store, *memory.Store, graph.Store) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	logger := zap.NewNop().Sugar()
+
+	gs, err := graph.NewBoltStore(filepath.Join(t.TempDir(), "graph.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { gs.Close() })
+
+	ks := knowledge.NewStore(client, logger)
+	ms := memory.NewStore(client, logger)
+	return NewKnowledgeBridge(ks, ms, gs, 0, 0), ks, ms, gs
+}
+
+func TestKnowledgeBridge_KnowledgeCuration(t *testing.T) {
+	b, ks, _, _ := newTestKnowledgeBridge(t)
+	ctx := context.Background()
+
+	require.NoError(t, ks.SaveKnowledge(ctx, "s1", knowledge.KnowledgeEntry{
+		Key: "go-style", Category: "rule", Content: "Use tabs",
+	}))
+	require.NoError(t, b.UpdateKnowledge(ctx, "go-style", "Use gofmt"))
+
+	items, err := b.SearchKnowledge(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 2, items[0].Version)
+	assert.Equal(t, "rule", items[0].Category)
+
+	history, err := b.KnowledgeHistory(ctx, "go-style")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "Use tabs", history[1].Content)
+
+	require.NoError(t, b.AdjustRelevance(ctx, "go-style", true))
+	got, err := ks.GetKnowledge(ctx, "go-style")
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, got.RelevanceScore)
+
+	for range 5 {
+		require.NoError(t, b.AdjustRelevance(ctx, "go-style", false))
+	}
+	got, err = ks.GetKnowledge(ctx, "go-style")
+	require.NoError(t, err)
+	assert.Equal(t, defaultMinRelevance, got.RelevanceScore, "demote stops at the floor")
+
+	require.NoError(t, b.DeleteKnowledge(ctx, "go-style"))
+	items, err = b.SearchKnowledge(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestKnowledgeBridge_Learnings(t *testing.T) {
+	b, ks, _, _ := newTestKnowledgeBridge(t)
+	ctx := context.Background()
+
+	require.NoError(t, ks.SaveLearning(ctx, "s1", knowledge.LearningEntry{
+		Trigger: "go build", ErrorPattern: "missing go.sum entry", Fix: "go mod tidy", Category: "tool_error",
+	}))
+
+	items, err := b.SearchLearnings(ctx, "go.sum")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	before := items[0].Confidence
+
+	require.NoError(t, b.AdjustConfidence(ctx, items[0].ID, true))
+	items, err = b.SearchLearnings(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.InDelta(t, min(before+confidenceStep, 1.0), items[0].Confidence, 1e-9)
+
+	require.NoError(t, b.DeleteLearning(ctx, items[0].ID))
+	items, err = b.SearchLearnings(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestKnowledgeBridge_MemoryAndGraph(t *testing.T) {
+	b, _, ms, gs := newTestKnowledgeBridge(t)
+	ctx := context.Background()
+
+	require.NoError(t, ms.SaveObservation(ctx, memory.Observation{SessionKey: "s1", Content: "prefers Go"}))
+	require.NoError(t, ms.SaveReflection(ctx, memory.Reflection{SessionKey: "s1", Content: "Go developer"}))
+
+	notes, err := b.ListMemory(ctx, "s1")
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	kinds := []string{notes[0].Kind, notes[1].Kind}
+	assert.ElementsMatch(t, []string{"observation", "reflection"}, kinds)
+
+	for _, n := range notes {
+		require.NoError(t, b.DeleteMemory(ctx, n))
+	}
+	notes, err = b.ListMemory(ctx, "s1")
+	require.NoError(t, err)
+	assert.Empty(t, notes)
+
+	require.NoError(t, gs.AddTriples(ctx, []graph.Triple{
+		{Subject: "error:timeout", Predicate: graph.ResolvedBy, Object: "fix:retry"},
+		{Subject: "error:timeout", Predicate: graph.RelatedTo, Object: "service:api"},
+	}))
+	edges, err := b.Neighborhood(ctx, "error:timeout", nil)
+	require.NoError(t, err)
+	assert.Len(t, edges, 2)
+
+	edges, err = b.Neighborhood(ctx, "error:timeout", []string{graph.ResolvedBy})
+	require.NoError(t, err)
+	require.Len(t, edges, 1)
+	assert.Equal(t, "fix:retry", edges[0].Object)
+
+	require.NoError(t, b.RemoveEdge(ctx, edges[0]))
+	edges, err = b.Neighborhood(ctx, "fix:retry", nil)
+	require.NoError(t, err)
+	assert.Empty(t, edges)
+}
+
+func TestKnowledgeBridge_Unavailable(t *testing.T) {
+	b := NewKnowledgeBridge(nil, nil, nil, 0, 0)
+	ctx := context.Background()
+
+	_, err := b.SearchKnowledge(ctx, "")
+	assert.ErrorIs(t, err, pages.ErrSectionUnavailable)
+	_, err = b.ListMemory(ctx, "s1")
+	assert.ErrorIs(t, err, pages.ErrSectionUnavailable)
+	_, err = b.Neighborhood(ctx, "n", nil)
+	assert.ErrorIs(t, err, pages.ErrSectionUnavailable)
+}
@@ -0,0 +1,785 @@
+package pages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/langoai/lango/internal/cli/cockpit/theme"
+	"github.com/langoai/lango/internal/cli/tui"
+)
+
+// ErrSectionUnavailable is returned by a KnowledgeBrowser when the backing
+// store for a section (knowledge, memory, graph) is not enabled.
+var ErrSectionUnavailable = errors.New("not enabled")
+
+// KnowledgeSection identifies a tab on the knowledge page.
+type KnowledgeSection int
+
+const (
+	SectionKnowledge KnowledgeSection = iota
+	SectionLearnings
+	SectionMemory
+	SectionGraph
+)
+
+var knowledgeSectionNames = []string{"Knowledge", "Learnings", "Memory", "Graph"}
+
+// String returns the section tab label.
+func (s KnowledgeSection) String() string {
+	if int(s) < len(knowledgeSectionNames) {
+		return knowledgeSectionNames[s]
+	}
+	return "unknown"
+}
+
+// KnowledgeItem is a knowledge entry (one version) for display.
+type KnowledgeItem struct {
+	Key       string
+	Category  string
+	Content   string
+	Source    string
+	Tags      []string
+	Version   int
+	Relevance float64
+	UpdatedAt time.Time
+}
+
+// LearningItem is a learning entry for display.
+type LearningItem struct {
+	ID           string
+	Trigger      string
+	ErrorPattern string
+	Diagnosis    string
+	Fix          string
+	Category     string
+	Confidence   float64
+	CreatedAt    time.Time
+}
+
+// MemoryNote is an observation or reflection for display.
+type MemoryNote struct {
+	ID         string
+	SessionKey string
+	Kind       string // "observation" or "reflection"
+	Content    string
+	CreatedAt  time.Time
+}
+
+// GraphEdge is a graph triple for display.
+type GraphEdge struct {
+	Subject   string
+	Predicate string
+	Object    string
+}
+
+// KnowledgeBrowser provides the data and curation actions behind the
+// knowledge page. Methods return ErrSectionUnavailable when the backing
+// store is disabled.
+type KnowledgeBrowser interface {
+	SearchKnowledge(ctx context.Context, query string) ([]KnowledgeItem, error)
+	KnowledgeHistory(ctx context.Context, key string) ([]KnowledgeItem, error)
+	UpdateKnowledge(ctx context.Context, key, content string) error
+	DeleteKnowledge(ctx context.Context, key string) error
+	AdjustRelevance(ctx context.Context, key string, up bool) error
+
+	SearchLearnings(ctx context.Context, query string) ([]LearningItem, error)
+	DeleteLearning(ctx context.Context, id string) error
+	AdjustConfidence(ctx context.Context, id string, up bool) error
+
+	ListMemory(ctx context.Context, sessionKey string) ([]MemoryNote, error)
+	DeleteMemory(ctx context.Context, note MemoryNote) error
+
+	Neighborhood(ctx context.Context, node string, predicates []string) ([]GraphEdge, error)
+	Predicates() []string
+	RemoveEdge(ctx context.Context, edge GraphEdge) error
+}
+
+// knowledgeLoadedMsg carries the result of an async section load.
+type knowledgeLoadedMsg struct {
+	section   KnowledgeSection
+	knowledge []KnowledgeItem
+	learnings []LearningItem
+	notes     []MemoryNote
+	edges     []GraphEdge
+	err       error
+}
+
+// knowledgeHistoryMsg carries the version history of a knowledge entry.
+type knowledgeHistoryMsg struct {
+	key     string
+	history []KnowledgeItem
+	err     error
+}
+
+// knowledgeActionMsg carries the outcome of an edit/delete/boost action.
+type knowledgeActionMsg struct {
+	msg string
+	err error
+}
+
+// KnowledgePage browses and curates what the agent has learned: knowledge
+// entries with version history, learnings, observations/reflections and
+// the knowledge graph neighborhood of a node.
+type KnowledgePage struct {
+	browser KnowledgeBrowser
+
+	section KnowledgeSection
+	cursor  [4]int
+	query   [4]string // per-section filter: search query, session key or graph node
+	loadErr error
+
+	knowledge []KnowledgeItem
+	learnings []LearningItem
+	notes     []MemoryNote
+	edges     []GraphEdge
+
+	graphTrail []string // visited graph nodes; the last is the current node
+	predicate  int      // 0 = all predicates, otherwise index+1 into Predicates()
+
+	detailMode bool
+	history    []KnowledgeItem
+
+	searching bool
+	input     textinput.Model
+	editing   bool
+	editKey   string
+	editor    textarea.Model
+
+	pendingDelete string // target awaiting a second "d" press
+	statusMsg     string
+	statusTime    time.Time
+
+	width, height int
+}
+
+// NewKnowledgePage creates a KnowledgePage. sessionKey seeds the memory
+// section filter. browser may be nil.
+func NewKnowledgePage(browser KnowledgeBrowser, sessionKey string) *KnowledgePage {
+	in := textinput.New()
+	in.Prompt = "/ "
+	ed := textarea.New()
+	ed.ShowLineNumbers = false
+
+	p := &KnowledgePage{browser: browser, input: in, editor: ed}
+	p.query[SectionMemory] = sessionKey
+	return p
+}
+
+// Title returns the page tab label.
+func (p *KnowledgePage) Title() string { return "Knowledge" }
+
+// ShortHelp returns context-sensitive key bindings for the help bar.
+func (p *KnowledgePage) ShortHelp() []key.Binding {
+	if p.editing {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "save")),
+			key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		}
+	}
+	if p.searching {
+		return []key.Binding{
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "apply")),
+			key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		}
+	}
+
+	bindings := []key.Binding{
+		key.NewBinding(key.WithKeys("left", "right"), key.WithHelp("←/→", "section")),
+		key.NewBinding(key.WithKeys("/"), key.WithHelp("/", p.filterLabel())),
+		key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+		key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+	}
+	switch p.section {
+	case SectionKnowledge:
+		bindings = append(bindings,
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "history")),
+			key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit")),
+			key.NewBinding(key.WithKeys("+", "-"), key.WithHelp("+/-", "boost/demote")),
+		)
+	case SectionLearnings:
+		bindings = append(bindings,
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "details")),
+			key.NewBinding(key.WithKeys("+", "-"), key.WithHelp("+/-", "confidence")),
+		)
+	case SectionMemory:
+		bindings = append(bindings,
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "details")))
+	case SectionGraph:
+		bindings = append(bindings,
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "follow")),
+			key.NewBinding(key.WithKeys("backspace"), key.WithHelp("bksp", "back")),
+			key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "predicate")),
+		)
+	}
+	return append(bindings, key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")))
+}
+
+// Init satisfies tea.Model.
+func (p *KnowledgePage) Init() tea.Cmd { return nil }
+
+// Activate reloads the current section.
+func (p *KnowledgePage) Activate() tea.Cmd { return p.load() }
+
+// Deactivate is called when the page loses focus.
+func (p *KnowledgePage) Deactivate() {
+	p.searching = false
+	p.input.Blur()
+}
+
+// Update handles messages.
+func (p *KnowledgePage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+		p.editor.SetWidth(max(msg.Width-8, 20))
+		p.editor.SetHeight(max(msg.Height/2, 5))
+
+	case knowledgeLoadedMsg:
+		if msg.section != p.section {
+			return p, nil
+		}
+		p.loadErr = msg.err
+		p.knowledge, p.learnings, p.notes, p.edges = msg.knowledge, msg.learnings, msg.notes, msg.edges
+		if n := p.rowCount(); p.cursor[p.section] >= n {
+			p.cursor[p.section] = max(n-1, 0)
+		}
+
+	case knowledgeHistoryMsg:
+		if msg.err != nil {
+			p.setStatus("Error: " + msg.err.Error())
+		} else if item, ok := p.selectedKnowledge(); ok && item.Key == msg.key {
+			p.history = msg.history
+		}
+
+	case knowledgeActionMsg:
+		if msg.err != nil {
+			p.setStatus("Error: " + msg.err.Error())
+			return p, nil
+		}
+		p.setStatus(msg.msg)
+		return p, p.load()
+
+	case tea.KeyMsg:
+		return p.handleKey(msg)
+	}
+	return p, nil
+}
+
+func (p *KnowledgePage) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if p.editing {
+		return p.handleEditKey(msg)
+	}
+	if p.searching {
+		return p.handleSearchKey(msg)
+	}
+
+	// Any key other than a second "d" cancels a pending delete.
+	if msg.String() != "d" {
+		p.pendingDelete = ""
+	}
+
+	switch msg.String() {
+	case "left", "h":
+		p.switchSection(p.section - 1)
+		return p, p.load()
+	case "right", "l":
+		p.switchSection(p.section + 1)
+		return p, p.load()
+	case "/":
+		p.searching = true
+		p.input.SetValue(p.query[p.section])
+		p.input.CursorEnd()
+		return p, p.input.Focus()
+	case "up", "k":
+		if p.cursor[p.section] > 0 {
+			p.cursor[p.section]--
+			p.history = nil
+		}
+		return p, p.loadDetail()
+	case "down", "j":
+		if p.cursor[p.section] < p.rowCount()-1 {
+			p.cursor[p.section]++
+			p.history = nil
+		}
+		return p, p.loadDetail()
+	case "enter":
+		if p.section == SectionGraph {
+			return p, p.followEdge()
+		}
+		if p.rowCount() == 0 {
+			return p, nil
+		}
+		p.detailMode = !p.detailMode
+		return p, p.loadDetail()
+	case "esc":
+		p.detailMode = false
+	case "backspace":
+		if p.section == SectionGraph && len(p.graphTrail) > 1 {
+			p.graphTrail = p.graphTrail[:len(p.graphTrail)-1]
+			p.query[SectionGraph] = p.graphTrail[len(p.graphTrail)-1]
+			p.cursor[SectionGraph] = 0
+			return p, p.load()
+		}
+	case "p":
+		if p.section == SectionGraph && p.browser != nil {
+			p.predicate = (p.predicate + 1) % (len(p.browser.Predicates()) + 1)
+			p.cursor[SectionGraph] = 0
+			return p, p.load()
+		}
+	case "e":
+		if item, ok := p.selectedKnowledge(); ok {
+			p.editing = true
+			p.editKey = item.Key
+			p.editor.SetValue(item.Content)
+			return p, p.editor.Focus()
+		}
+	case "+", "=":
+		return p, p.adjustSelected(true)
+	case "-", "_":
+		return p, p.adjustSelected(false)
+	case "d":
+		return p, p.deleteSelected()
+	}
+	return p, nil
+}
+
+func (p *KnowledgePage) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		p.searching = false
+		p.input.Blur()
+		q := strings.TrimSpace(p.input.Value())
+		p.query[p.section] = q
+		p.cursor[p.section] = 0
+		p.detailMode = false
+		if p.section == SectionGraph {
+			p.graphTrail = nil
+			if q != "" {
+				p.graphTrail = []string{q}
+			}
+		}
+		return p, p.load()
+	case "esc":
+		p.searching = false
+		p.input.Blur()
+		return p, nil
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd
+}
+
+func (p *KnowledgePage) handleEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+s":
+		p.editing = false
+		p.editor.Blur()
+		browser, k, content := p.browser, p.editKey, p.editor.Value()
+		return p, func() tea.Msg {
+			if err := browser.UpdateKnowledge(context.Background(), k, content); err != nil {
+				return knowledgeActionMsg{err: err}
+			}
+			return knowledgeActionMsg{msg: "Saved new version of " + k}
+		}
+	case "esc":
+		p.editing = false
+		p.editor.Blur()
+		return p, nil
+	}
+	var cmd tea.Cmd
+	p.editor, cmd = p.editor.Update(msg)
+	return p, cmd
+}
+
+func (p *KnowledgePage) switchSection(s KnowledgeSection) {
+	n := KnowledgeSection(len(knowledgeSectionNames))
+	p.section = (s + n) % n
+	p.detailMode = false
+	p.history = nil
+	p.loadErr = nil
+	p.knowledge, p.learnings, p.notes, p.edges = nil, nil, nil, nil
+}
+
+func (p *KnowledgePage) setStatus(msg string) {
+	p.statusMsg = msg
+	p.statusTime = time.Now()
+}
+
+// filterLabel names what "/" filters in the current section.
+func (p *KnowledgePage) filterLabel() string {
+	switch p.section {
+	case SectionMemory:
+		return "session"
+	case SectionGraph:
+		return "node"
+	default:
+		return "search"
+	}
+}
+
+// activePredicates returns the predicate filter for graph traversal.
+func (p *KnowledgePage) activePredicates() []string {
+	if p.predicate == 0 || p.browser == nil {
+		return nil
+	}
+	preds := p.browser.Predicates()
+	if p.predicate > len(preds) {
+		return nil
+	}
+	return []string{preds[p.predicate-1]}
+}
+
+func (p *KnowledgePage) rowCount() int {
+	switch p.section {
+	case SectionKnowledge:
+		return len(p.knowledge)
+	case SectionLearnings:
+		return len(p.learnings)
+	case SectionMemory:
+		return len(p.notes)
+	case SectionGraph:
+		return len(p.edges)
+	}
+	return 0
+}
+
+func (p *KnowledgePage) selectedKnowledge() (KnowledgeItem, bool) {
+	c := p.cursor[SectionKnowledge]
+	if p.section != SectionKnowledge || c >= len(p.knowledge) {
+		return KnowledgeItem{}, false
+	}
+	return p.knowledge[c], true
+}
+
+// load returns a command that fetches the current section's rows.
+func (p *KnowledgePage) load() tea.Cmd {
+	browser, section, query := p.browser, p.section, p.query[p.section]
+	preds := p.activePredicates()
+	return func() tea.Msg {
+		if browser == nil {
+			return knowledgeLoadedMsg{section: section, err: ErrSectionUnavailable}
+		}
+		ctx := context.Background()
+		msg := knowledgeLoadedMsg{section: section}
+		switch section {
+		case SectionKnowledge:
+			msg.knowledge, msg.err = browser.SearchKnowledge(ctx, query)
+		case SectionLearnings:
+			msg.learnings, msg.err = browser.SearchLearnings(ctx, query)
+		case SectionMemory:
+			if query != "" {
+				msg.notes, msg.err = browser.ListMemory(ctx, query)
+			}
+		case SectionGraph:
+			if query != "" {
+				msg.edges, msg.err = browser.Neighborhood(ctx, query, preds)
+			}
+		}
+		return msg
+	}
+}
+
+// loadDetail fetches version history for the selected knowledge entry
+// when the detail panel is open.
+func (p *KnowledgePage) loadDetail() tea.Cmd {
+	item, ok := p.selectedKnowledge()
+	if !ok || !p.detailMode || p.browser == nil {
+		return nil
+	}
+	browser := p.browser
+	return func() tea.Msg {
+		history, err := browser.KnowledgeHistory(context.Background(), item.Key)
+		return knowledgeHistoryMsg{key: item.Key, history: history, err: err}
+	}
+}
+
+// followEdge moves the graph view to the far end of the selected edge.
+func (p *KnowledgePage) followEdge() tea.Cmd {
+	c := p.cursor[SectionGraph]
+	if c >= len(p.edges) {
+		return nil
+	}
+	e := p.edges[c]
+	current := p.query[SectionGraph]
+	next := e.Object
+	if e.Object == current {
+		next = e.Subject
+	}
+	p.graphTrail = append(p.graphTrail, next)
+	p.query[SectionGraph] = next
+	p.cursor[SectionGraph] = 0
+	return p.load()
+}
+
+// adjustSelected boosts or demotes the selected knowledge entry's relevance
+// or learning's confidence.
+func (p *KnowledgePage) adjustSelected(up bool) tea.Cmd {
+	if p.browser == nil {
+		return nil
+	}
+	browser, c := p.browser, p.cursor[p.section]
+	verb := "Demoted"
+	if up {
+		verb = "Boosted"
+	}
+	switch {
+	case p.section == SectionKnowledge && c < len(p.knowledge):
+		k := p.knowledge[c].Key
+		return func() tea.Msg {
+			if err := browser.AdjustRelevance(context.Background(), k, up); err != nil {
+				return knowledgeActionMsg{err: err}
+			}
+			return knowledgeActionMsg{msg: verb + " " + k}
+		}
+	case p.section == SectionLearnings && c < len(p.learnings):
+		l := p.learnings[c]
+		return func() tea.Msg {
+			if err := browser.AdjustConfidence(context.Background(), l.ID, up); err != nil {
+				return knowledgeActionMsg{err: err}
+			}
+			return knowledgeActionMsg{msg: verb + " learning " + l.Trigger}
+		}
+	}
+	return nil
+}
+
+// deleteSelected deletes the selected row on the second consecutive "d".
+func (p *KnowledgePage) deleteSelected() tea.Cmd {
+	if p.browser == nil || p.rowCount() == 0 {
+		return nil
+	}
+	browser, c := p.browser, p.cursor[p.section]
+
+	var target string
+	var del func(ctx context.Context) error
+	switch p.section {
+	case SectionKnowledge:
+		k := p.knowledge[c].Key
+		target = "knowledge " + k
+		del = func(ctx context.Context) error { return browser.DeleteKnowledge(ctx, k) }
+	case SectionLearnings:
+		l := p.learnings[c]
+		target = "learning " + l.Trigger
+		del = func(ctx context.Context) error { return browser.DeleteLearning(ctx, l.ID) }
+	case SectionMemory:
+		n := p.notes[c]
+		target = n.Kind + " " + n.ID
+		del = func(ctx context.Context) error { return browser.DeleteMemory(ctx, n) }
+	case SectionGraph:
+		e := p.edges[c]
+		target = fmt.Sprintf("edge %s -[%s]-> %s", e.Subject, e.Predicate, e.Object)
+		del = func(ctx context.Context) error { return browser.RemoveEdge(ctx, e) }
+	}
+
+	if p.pendingDelete != target {
+		p.pendingDelete = target
+		p.setStatus("Press d again to delete " + target)
+		return nil
+	}
+	p.pendingDelete = ""
+	p.detailMode = false
+	return func() tea.Msg {
+		if err := del(context.Background()); err != nil {
+			return knowledgeActionMsg{err: err}
+		}
+		return knowledgeActionMsg{msg: "Deleted " + target}
+	}
+}
+
+// View renders the knowledge page.
+func (p *KnowledgePage) View() string {
+	lines := []string{p.viewTabs(), p.viewFilter(), ""}
+
+	switch {
+	case p.editing:
+		lines = append(lines,
+			lipgloss.NewStyle().Bold(true).Foreground(theme.Primary).PaddingLeft(2).
+				Render("Edit "+p.editKey+" (ctrl+s to save a new version)"),
+			lipgloss.NewStyle().PaddingLeft(2).Render(p.editor.View()))
+		return strings.Join(lines, "\n")
+	case errors.Is(p.loadErr, ErrSectionUnavailable):
+		lines = append(lines, p.mutedLine(p.section.String()+" is not enabled."))
+	case p.loadErr != nil:
+		lines = append(lines, lipgloss.NewStyle().Foreground(theme.Error).PaddingLeft(2).
+			Render(fmt.Sprintf("Error: %v", p.loadErr)))
+	case p.rowCount() == 0:
+		lines = append(lines, p.mutedLine(p.emptyText()))
+	default:
+		lines = append(lines, p.viewRows()...)
+		if p.detailMode {
+			lines = append(lines, "", p.viewDetail())
+		}
+	}
+
+	if p.statusMsg != "" && time.Since(p.statusTime) < statusMsgTTL {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(theme.Warning).PaddingLeft(4).Render(p.statusMsg))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (p *KnowledgePage) viewTabs() string {
+	tabs := make([]string, 0, len(knowledgeSectionNames))
+	for i, name := range knowledgeSectionNames {
+		style := lipgloss.NewStyle().Padding(0, 1).Foreground(theme.TextTertiary)
+		if KnowledgeSection(i) == p.section {
+			style = style.Bold(true).Foreground(theme.Primary).Underline(true)
+		}
+		tabs = append(tabs, style.Render(name))
+	}
+	return lipgloss.NewStyle().PaddingLeft(1).PaddingTop(1).Render(strings.Join(tabs, " "))
+}
+
+func (p *KnowledgePage) viewFilter() string {
+	style := lipgloss.NewStyle().Foreground(theme.TextSecondary).PaddingLeft(2)
+	if p.searching {
+		return style.Render(p.input.View())
+	}
+	label := strings.ToUpper(p.filterLabel()[:1]) + p.filterLabel()[1:]
+	q := p.query[p.section]
+	if q == "" {
+		q = "(none)"
+	}
+	text := label + ": " + q
+	if p.section == SectionGraph {
+		pred := "all"
+		if preds := p.activePredicates(); len(preds) > 0 {
+			pred = preds[0]
+		}
+		text += "   Predicate: " + pred
+		if len(p.graphTrail) > 1 {
+			text += "   Path: " + strings.Join(p.graphTrail, " → ")
+		}
+	}
+	return style.Render(ansi.Truncate(text, max(p.width-4, 20), "…"))
+}
+
+func (p *KnowledgePage) mutedLine(s string) string {
+	return lipgloss.NewStyle().Foreground(theme.TextSecondary).PaddingLeft(4).Render(s)
+}
+
+func (p *KnowledgePage) emptyText() string {
+	switch p.section {
+	case SectionMemory:
+		if p.query[SectionMemory] == "" {
+			return "Press / to choose a session."
+		}
+		return "No observations or reflections for this session."
+	case SectionGraph:
+		if p.query[SectionGraph] == "" {
+			return "Press / to enter a start node."
+		}
+		return "No edges for this node."
+	case SectionLearnings:
+		return "No learnings found."
+	default:
+		return "No knowledge entries found."
+	}
+}
+
+// viewRows renders the cursor-navigable rows of the current section.
+func (p *KnowledgePage) viewRows() []string {
+	textW := max(p.width-40, 16)
+	now := time.Now()
+
+	rows := make([]string, 0, p.rowCount())
+	for i := 0; i < p.rowCount(); i++ {
+		var row string
+		switch p.section {
+		case SectionKnowledge:
+			k := p.knowledge[i]
+			row = fmt.Sprintf("%-24s %-11s v%-3d %4.2f  %s",
+				tui.Truncate(k.Key, 24), tui.Truncate(k.Category, 11), k.Version, k.Relevance,
+				ansi.Truncate(oneLine(k.Content), textW, "…"))
+		case SectionLearnings:
+			l := p.learnings[i]
+			row = fmt.Sprintf("%3.0f%%  %-12s %s",
+				l.Confidence*100, tui.Truncate(l.Category, 12),
+				ansi.Truncate(oneLine(l.Trigger+" — "+l.Fix), textW+12, "…"))
+		case SectionMemory:
+			n := p.notes[i]
+			row = fmt.Sprintf("%-11s %-10s %s",
+				n.Kind, tui.RelativeTimeHuman(now, n.CreatedAt),
+				ansi.Truncate(oneLine(n.Content), textW+14, "…"))
+		case SectionGraph:
+			e := p.edges[i]
+			row = ansi.Truncate(fmt.Sprintf("%s  -[%s]->  %s", e.Subject, e.Predicate, e.Object), max(p.width-8, 20), "…")
+		}
+
+		style := lipgloss.NewStyle().PaddingLeft(2)
+		if i == p.cursor[p.section] {
+			style = style.Foreground(theme.Accent).Bold(true)
+			row = "> " + row
+		} else {
+			style = style.Foreground(theme.TextPrimary)
+			row = "  " + row
+		}
+		rows = append(rows, style.Render(row))
+	}
+	return rows
+}
+
+// viewDetail renders the detail panel for the selected row.
+func (p *KnowledgePage) viewDetail() string {
+	valW := max(p.width-10, 20)
+	c := p.cursor[p.section]
+	var lines []string
+	add := func(label, val string) {
+		if val == "" {
+			return
+		}
+		lines = append(lines, "  "+label+":")
+		for _, l := range strings.Split(tui.WordWrap(val, valW), "\n") {
+			lines = append(lines, "    "+l)
+		}
+	}
+
+	switch p.section {
+	case SectionKnowledge:
+		k := p.knowledge[c]
+		lines = append(lines, fmt.Sprintf("  %s  (%s, v%d, relevance %.2f)", k.Key, k.Category, k.Version, k.Relevance))
+		if len(k.Tags) > 0 {
+			lines = append(lines, "  Tags: "+strings.Join(k.Tags, ", "))
+		}
+		add("Source", k.Source)
+		add("Content", k.Content)
+		if len(p.history) > 1 {
+			lines = append(lines, "", "  History:")
+			for _, h := range p.history {
+				lines = append(lines, fmt.Sprintf("    v%-3d %-10s %s", h.Version,
+					tui.RelativeTimeHuman(time.Now(), h.UpdatedAt),
+					ansi.Truncate(oneLine(h.Content), max(valW-20, 10), "…")))
+			}
+		}
+	case SectionLearnings:
+		l := p.learnings[c]
+		lines = append(lines, fmt.Sprintf("  %s  (confidence %.0f%%)", l.Category, l.Confidence*100))
+		add("Trigger", l.Trigger)
+		add("Error pattern", l.ErrorPattern)
+		add("Diagnosis", l.Diagnosis)
+		add("Fix", l.Fix)
+	case SectionMemory:
+		n := p.notes[c]
+		lines = append(lines, fmt.Sprintf("  %s %s  (%s)", n.Kind, n.ID, n.CreatedAt.Format(time.DateTime)))
+		add("Content", n.Content)
+	}
+
+	sep := lipgloss.NewStyle().Foreground(theme.BorderSubtle).PaddingLeft(2).
+		Render("─── Detail " + strings.Repeat("─", max(valW-10, 4)))
+	return sep + "\n" + lipgloss.NewStyle().Foreground(theme.TextSecondary).Render(strings.Join(lines, "\n"))
+}
+
+// oneLine collapses whitespace so multi-line content fits a table row.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
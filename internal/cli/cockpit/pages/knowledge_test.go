@@ -0,0 +1,289 @@
+package pages
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockKnowledgeBrowser implements KnowledgeBrowser for testing.
+type mockKnowledgeBrowser struct {
+	knowledge []KnowledgeItem
+	learnings []LearningItem
+	notes     map[string][]MemoryNote
+	edges     map[string][]GraphEdge
+
+	lastQuery      string
+	lastPredicates []string
+	updated        map[string]string
+	deleted        []string
+	adjusted       []string
+}
+
+func newMockKnowledgeBrowser() *mockKnowledgeBrowser {
+	return &mockKnowledgeBrowser{
+		knowledge: []KnowledgeItem{
+			{Key: "go-style", Category: "rule", Content: "Use gofmt", Version: 2, Relevance: 1.5},
+			{Key: "deploy", Category: "fact", Content: "Deploy on Fridays is banned", Version: 1, Relevance: 1},
+		},
+		learnings: []LearningItem{
+			{ID: "l1", Trigger: "go build", Fix: "run go mod tidy", Category: "tool_error", Confidence: 0.6},
+		},
+		notes: map[string][]MemoryNote{
+			"sess-1": {{ID: "o1", Kind: "observation", Content: "User prefers short answers", CreatedAt: time.Now()}},
+		},
+		edges: map[string][]GraphEdge{
+			"error:timeout": {{Subject: "error:timeout", Predicate: "resolved_by", Object: "fix:retry"}},
+			"fix:retry":     {{Subject: "error:timeout", Predicate: "resolved_by", Object: "fix:retry"}},
+		},
+		updated: make(map[string]string),
+	}
+}
+
+func (m *mockKnowledgeBrowser) SearchKnowledge(_ context.Context, query string) ([]KnowledgeItem, error) {
+	m.lastQuery = query
+	return m.knowledge, nil
+}
+
+func (m *mockKnowledgeBrowser) KnowledgeHistory(_ context.Context, key string) ([]KnowledgeItem, error) {
+	return []KnowledgeItem{
+		{Key: key, Version: 2, Content: "Use gofmt"},
+		{Key: key, Version: 1, Content: "Use tabs"},
+	}, nil
+}
+
+func (m *mockKnowledgeBrowser) UpdateKnowledge(_ context.Context, key, content string) error {
+	m.updated[key] = content
+	return nil
+}
+
+func (m *mockKnowledgeBrowser) DeleteKnowledge(_ context.Context, key string) error {
+	m.deleted = append(m.deleted, "knowledge:"+key)
+	return nil
+}
+
+func (m *mockKnowledgeBrowser) AdjustRelevance(_ context.Context, key string, up bool) error {
+	m.adjusted = append(m.adjusted, adjustLabel(key, up))
+	return nil
+}
+
+func (m *mockKnowledgeBrowser) SearchLearnings(_ context.Context, query string) ([]LearningItem, error) {
+	m.lastQuery = query
+	return m.learnings, nil
+}
+
+func (m *mockKnowledgeBrowser) DeleteLearning(_ context.Context, id string) error {
+	m.deleted = append(m.deleted, "learning:"+id)
+	return nil
+}
+
+func (m *mockKnowledgeBrowser) AdjustConfidence(_ context.Context, id string, up bool) error {
+	m.adjusted = append(m.adjusted, adjustLabel(id, up))
+	return nil
+}
+
+func (m *mockKnowledgeBrowser) ListMemory(_ context.Context, sessionKey string) ([]MemoryNote, error) {
+	return m.notes[sessionKey], nil
+}
+
+func (m *mockKnowledgeBrowser) DeleteMemory(_ context.Context, note MemoryNote) error {
+	m.deleted = append(m.deleted, note.Kind+":"+note.ID)
+	return nil
+}
+
+func (m *mockKnowledgeBrowser) Neighborhood(_ context.Context, node string, predicates []string) ([]GraphEdge, error) {
+	m.lastPredicates = predicates
+	return m.edges[node], nil
+}
+
+func (m *mockKnowledgeBrowser) Predicates() []string { return []string{"related_to", "resolved_by"} }
+
+func (m *mockKnowledgeBrowser) RemoveEdge(_ context.Context, e GraphEdge) error {
+	m.deleted = append(m.deleted, "edge:"+e.Predicate)
+	return nil
+}
+
+func adjustLabel(id string, up bool) string {
+	if up {
+		return "+" + id
+	}
+	return "-" + id
+}
+
+// runCmd executes cmd and feeds the resulting message back into the page.
+func runCmd(t *testing.T, p *KnowledgePage, cmd tea.Cmd) {
+	t.Helper()
+	if cmd == nil {
+		return
+	}
+	msg := cmd()
+	if msg == nil {
+		return
+	}
+	_, next := p.Update(msg)
+	if _, ok := msg.(knowledgeActionMsg); ok {
+		runCmd(t, p, next) // reload after an action
+	}
+}
+
+func pressKey(t *testing.T, p *KnowledgePage, k string) {
+	t.Helper()
+	var msg tea.KeyMsg
+	switch k {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case "right":
+		msg = tea.KeyMsg{Type: tea.KeyRight}
+	case "left":
+		msg = tea.KeyMsg{Type: tea.KeyLeft}
+	case "down":
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	case "backspace":
+		msg = tea.KeyMsg{Type: tea.KeyBackspace}
+	case "ctrl+s":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlS}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	}
+	_, cmd := p.Update(msg)
+	if p.searching || p.editing {
+		return // input commands only drive cursor blinking
+	}
+	runCmd(t, p, cmd)
+}
+
+func typeText(t *testing.T, p *KnowledgePage, s string) {
+	t.Helper()
+	for _, r := range s {
+		pressKey(t, p, string(r))
+	}
+}
+
+func newTestKnowledgePage(t *testing.T) (*KnowledgePage, *mockKnowledgeBrowser) {
+	t.Helper()
+	b := newMockKnowledgeBrowser()
+	p := NewKnowledgePage(b, "sess-1")
+	p.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	runCmd(t, p, p.Activate())
+	return p, b
+}
+
+func TestKnowledgePage_ListAndHistory(t *testing.T) {
+	p, _ := newTestKnowledgePage(t)
+
+	view := p.View()
+	assert.Contains(t, view, "go-style")
+	assert.Contains(t, view, "deploy")
+	assert.Contains(t, view, "1.50")
+
+	pressKey(t, p, "enter")
+	require.True(t, p.detailMode)
+	require.Len(t, p.history, 2)
+	assert.Contains(t, p.View(), "Use tabs", "history shows older versions")
+
+	pressKey(t, p, "esc")
+	assert.False(t, p.detailMode)
+}
+
+func TestKnowledgePage_Search(t *testing.T) {
+	p, b := newTestKnowledgePage(t)
+
+	pressKey(t, p, "/")
+	require.True(t, p.searching)
+	typeText(t, p, "gofmt")
+	pressKey(t, p, "enter")
+
+	assert.False(t, p.searching)
+	assert.Equal(t, "gofmt", b.lastQuery)
+	assert.Contains(t, p.View(), "Search: gofmt")
+}
+
+func TestKnowledgePage_EditBoostDelete(t *testing.T) {
+	p, b := newTestKnowledgePage(t)
+
+	pressKey(t, p, "e")
+	require.True(t, p.editing)
+	assert.Equal(t, "Use gofmt", p.editor.Value())
+	typeText(t, p, " always")
+	pressKey(t, p, "ctrl+s")
+	assert.False(t, p.editing)
+	assert.Equal(t, "Use gofmt always", b.updated["go-style"])
+
+	pressKey(t, p, "+")
+	pressKey(t, p, "-")
+	assert.Equal(t, []string{"+go-style", "-go-style"}, b.adjusted)
+
+	// Delete requires a second press.
+	pressKey(t, p, "down")
+	pressKey(t, p, "d")
+	assert.Empty(t, b.deleted)
+	assert.Contains(t, p.View(), "Press d again")
+	pressKey(t, p, "d")
+	assert.Equal(t, []string{"knowledge:deploy"}, b.deleted)
+}
+
+func TestKnowledgePage_DeleteCancelledByOtherKey(t *testing.T) {
+	p, b := newTestKnowledgePage(t)
+
+	pressKey(t, p, "d")
+	pressKey(t, p, "down")
+	pressKey(t, p, "d")
+	assert.Empty(t, b.deleted, "moving the cursor resets the confirmation")
+}
+
+func TestKnowledgePage_LearningsAndMemory(t *testing.T) {
+	p, b := newTestKnowledgePage(t)
+
+	pressKey(t, p, "right")
+	assert.Equal(t, SectionLearnings, p.section)
+	assert.Contains(t, p.View(), "60%")
+	pressKey(t, p, "+")
+	assert.Equal(t, []string{"+l1"}, b.adjusted)
+
+	pressKey(t, p, "right")
+	assert.Equal(t, SectionMemory, p.section)
+	assert.Contains(t, p.View(), "Session: sess-1")
+	assert.Contains(t, p.View(), "User prefers short answers")
+	pressKey(t, p, "d")
+	pressKey(t, p, "d")
+	assert.Equal(t, []string{"observation:o1"}, b.deleted)
+}
+
+func TestKnowledgePage_GraphNavigation(t *testing.T) {
+	p, b := newTestKnowledgePage(t)
+
+	pressKey(t, p, "left")
+	require.Equal(t, SectionGraph, p.section)
+	assert.Contains(t, p.View(), "Press / to enter a start node")
+
+	pressKey(t, p, "/")
+	typeText(t, p, "error:timeout")
+	pressKey(t, p, "enter")
+	assert.Contains(t, p.View(), "-[resolved_by]->")
+
+	// Following the edge moves to the other endpoint.
+	pressKey(t, p, "enter")
+	assert.Equal(t, "fix:retry", p.query[SectionGraph])
+	assert.Contains(t, p.View(), "error:timeout → fix:retry")
+
+	pressKey(t, p, "backspace")
+	assert.Equal(t, "error:timeout", p.query[SectionGraph])
+
+	pressKey(t, p, "p")
+	assert.Equal(t, []string{"related_to"}, b.lastPredicates)
+	pressKey(t, p, "p")
+	pressKey(t, p, "p")
+	assert.Nil(t, b.lastPredicates, "cycles back to all predicates")
+}
+
+func TestKnowledgePage_Unavailable(t *testing.T) {
+	p := NewKnowledgePage(nil, "")
+	runCmd(t, p, p.Activate())
+	assert.Contains(t, p.View(), "Knowledge is not enabled.")
+}
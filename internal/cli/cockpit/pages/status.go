@@ -20,11 +20,17 @@ import (
 // tickMsg triggers a periodic metrics refresh.
 type tickMsg time.Time
 
+// MetricsSource provides system metrics snapshots.
+// *observability.MetricsCollector satisfies it.
+type MetricsSource interface {
+	Snapshot() observability.SystemSnapshot
+}
+
 // StatusPage displays feature status and system metrics.
 type StatusPage struct {
 	featureStatuses  []types.FeatureStatus
 	statusProvider   func() []types.FeatureStatus
-	metricsCollector *observability.MetricsCollector
+	metricsCollector MetricsSource
 	snapshot         observability.SystemSnapshot
 	cfg              *config.Config
 	tickActive       bool
@@ -40,11 +46,20 @@ func NewStatusPage(
 	collector *observability.MetricsCollector,
 	cfg *config.Config,
 ) *StatusPage {
-	return &StatusPage{
-		statusProvider:   statusProvider,
-		metricsCollector: collector,
-		cfg:              cfg,
+	m := &StatusPage{
+		statusProvider: statusProvider,
+		cfg:            cfg,
+	}
+	if collector != nil {
+		m.metricsCollector = collector
 	}
+	return m
+}
+
+// SetMetricsSource replaces the metrics source, e.g. with snapshots streamed
+// from a remote gateway.
+func (m *StatusPage) SetMetricsSource(src MetricsSource) {
+	m.metricsCollector = src
 }
 
 // Title returns the page tab label.
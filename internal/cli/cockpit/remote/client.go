@@ -0,0 +1,389 @@
+// Package remote attaches the cockpit TUI to a running `lango serve` over the
+// gateway's /cockpit WebSocket endpoint.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gorilla/websocket"
+
+	"github.com/langoai/lango/internal/cli/chat"
+	"github.com/langoai/lango/internal/gateway"
+	"github.com/langoai/lango/internal/observability"
+	"github.com/langoai/lango/internal/turnrunner"
+	"github.com/langoai/lango/internal/turntrace"
+)
+
+const (
+	callTimeout  = 10 * time.Second
+	pingInterval = 30 * time.Second
+)
+
+var (
+	// ErrReadOnly is returned when a viewer attempts an operator action.
+	ErrReadOnly = errors.New("read-only: this token has the viewer role")
+	// ErrClosed is returned for calls made after the connection dropped.
+	ErrClosed = errors.New("remote cockpit connection closed")
+	// ErrTurnRunning is returned when a turn is submitted while another of
+	// this cockpit's turns is still running.
+	ErrTurnRunning = errors.New("a turn is already running")
+)
+
+// Operator describes a cockpit attached to the same session.
+type Operator = gateway.CockpitOperator
+
+// Hello is the gateway's answer to cockpit.hello.
+type Hello struct {
+	ClientID   string                        `json:"clientId"`
+	Name       string                        `json:"name"`
+	Role       string                        `json:"role"`
+	SessionKey string                        `json:"sessionKey"`
+	Operators  []Operator                    `json:"operators"`
+	Tasks      bool                          `json:"tasks"`
+	Metrics    *observability.SystemSnapshot `json:"metrics,omitempty"`
+}
+
+// message is a frame received from the gateway: either an RPC response
+// (ID set) or an event.
+type message struct {
+	ID      string            `json:"id"`
+	Type    string            `json:"type"`
+	Event   string            `json:"event"`
+	Payload json.RawMessage   `json:"payload"`
+	Result  json.RawMessage   `json:"result"`
+	Error   *gateway.RPCError `json:"error"`
+}
+
+// turnState tracks this cockpit's own running turn so streamed events are
+// delivered to its request callbacks.
+type turnState struct {
+	req     turnrunner.Request
+	traceID string
+	failure *turnFailure
+}
+
+type turnFailure struct {
+	Error      string `json:"error"`
+	Type       string `json:"type"`
+	Code       string `json:"code"`
+	CauseClass string `json:"causeClass"`
+	Summary    string `json:"summary"`
+	TraceID    string `json:"traceId"`
+}
+
+// Client is a remote cockpit connection. It implements chat.TurnRunner,
+// pages.TaskLister, pages.TaskActioner and pages.MetricsSource so the
+// regular cockpit pages can run against a remote gateway.
+type Client struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+	nextID  atomic.Uint64
+	hello   Hello
+
+	pendingMu sync.Mutex
+	pending   map[string]chan message
+
+	mu        sync.Mutex
+	sender    func(tea.Msg)
+	turn      *turnState
+	approvals map[string]context.CancelFunc
+	operators []Operator
+	metrics   observability.SystemSnapshot
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Dial connects to the gateway at rawURL, authenticates with token and
+// attaches to sessionKey (the gateway default when empty).
+func Dial(ctx context.Context, rawURL, token, sessionKey string) (*Client, error) {
+	endpoint, err := EndpointURL(rawURL, sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, endpoint, header)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			return nil, fmt.Errorf("connect %s: invalid cockpit token", endpoint)
+		}
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("connect %s: remote cockpit is not enabled on this server (server.cockpit.enabled)", endpoint)
+		}
+		return nil, fmt.Errorf("connect %s: %w", endpoint, err)
+	}
+
+	c := &Client{
+		conn:      conn,
+		pending:   make(map[string]chan message),
+		approvals: make(map[string]context.CancelFunc),
+		done:      make(chan struct{}),
+	}
+	go c.readLoop()
+	go c.pingLoop()
+
+	raw, err := c.call(ctx, "cockpit.hello", nil)
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("cockpit hello: %w", err)
+	}
+	if err := json.Unmarshal(raw, &c.hello); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("decode cockpit hello: %w", err)
+	}
+	c.mu.Lock()
+	c.operators = c.hello.Operators
+	if c.hello.Metrics != nil {
+		c.metrics = *c.hello.Metrics
+	}
+	c.mu.Unlock()
+	return c, nil
+}
+
+// EndpointURL turns a user-supplied gateway address into the /cockpit
+// WebSocket URL. http(s) schemes map to ws(s); a bare host defaults to wss.
+func EndpointURL(rawURL, sessionKey string) (string, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "wss://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("parse remote url: %w", err)
+	}
+	switch u.Scheme {
+	case "ws", "wss":
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("unsupported remote url scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("remote url %q has no host", rawURL)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/cockpit"
+	}
+	if sessionKey != "" {
+		q := u.Query()
+		q.Set("session", sessionKey)
+		u.RawQuery = q.Encode()
+	}
+	return u.String(), nil
+}
+
+// Hello returns the identity the gateway assigned to this cockpit.
+func (c *Client) Hello() Hello { return c.hello }
+
+// IsOperator reports whether this cockpit may chat, approve and manage tasks.
+func (c *Client) IsOperator() bool { return c.hello.Role == gateway.RoleOperator }
+
+// Operators returns the cockpits currently attached to the session.
+func (c *Client) Operators() []Operator {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Operator(nil), c.operators...)
+}
+
+// SetSender sets the function used to deliver events to the TUI,
+// typically tea.Program.Send.
+func (c *Client) SetSender(send func(tea.Msg)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sender = send
+}
+
+// Done is closed when the connection drops.
+func (c *Client) Done() <-chan struct{} { return c.done }
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	c.writeMu.Lock()
+	_ = c.conn.WriteMessage(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	c.writeMu.Unlock()
+	return c.conn.Close()
+}
+
+// Run sends input as a chat message to the attached session and streams the
+// turn's events to req's callbacks.
+func (c *Client) Run(ctx context.Context, req turnrunner.Request) (turnrunner.Result, error) {
+	if !c.IsOperator() {
+		return turnrunner.Result{}, ErrReadOnly
+	}
+	start := time.Now()
+
+	c.mu.Lock()
+	if c.turn != nil {
+		c.mu.Unlock()
+		return turnrunner.Result{}, ErrTurnRunning
+	}
+	turn := &turnState{req: req}
+	c.turn = turn
+	c.mu.Unlock()
+
+	raw, err := c.call(ctx, "chat.message", map[string]string{"message": req.Input})
+
+	c.mu.Lock()
+	c.turn = nil
+	c.mu.Unlock()
+
+	result := turnrunner.Result{Elapsed: time.Since(start), TraceID: turn.traceID}
+	if f := turn.failure; f != nil {
+		result.Outcome = turnrunner.TurnOutcome(f.Type)
+		result.UserMessage = f.Error
+		result.ErrorCode = f.Code
+		result.CauseClass = f.CauseClass
+		result.Summary = f.Summary
+		result.TraceID = f.TraceID
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+
+	var resp struct {
+		Response string `json:"response"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return result, fmt.Errorf("decode chat response: %w", err)
+	}
+	result.ResponseText = resp.Response
+	result.Outcome = turntrace.OutcomeSuccess
+	return result, nil
+}
+
+// Snapshot returns the most recent metrics snapshot streamed by the gateway.
+func (c *Client) Snapshot() observability.SystemSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.metrics
+}
+
+// call sends an RPC request and waits for its response.
+func (c *Client) call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	var raw json.RawMessage
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("encode %s params: %w", method, err)
+		}
+		raw = data
+	}
+
+	id := strconv.FormatUint(c.nextID.Add(1), 10)
+	ch := make(chan message, 1)
+	c.pendingMu.Lock()
+	c.pending[id] = ch
+	c.pendingMu.Unlock()
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+	}()
+
+	c.writeMu.Lock()
+	err := c.conn.WriteJSON(gateway.RPCRequest{ID: id, Method: method, Params: raw})
+	c.writeMu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("send %s: %w", method, err)
+	}
+
+	select {
+	case msg := <-ch:
+		if msg.Error != nil {
+			return nil, errors.New(msg.Error.Message)
+		}
+		return msg.Result, nil
+	case <-c.done:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// callWithTimeout is call bounded by callTimeout.
+func (c *Client) callWithTimeout(method string, params interface{}) (json.RawMessage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+	return c.call(ctx, method, params)
+}
+
+func (c *Client) readLoop() {
+	defer c.closeOnce.Do(func() {
+		close(c.done)
+		c.mu.Lock()
+		for _, cancel := range c.approvals {
+			cancel()
+		}
+		c.mu.Unlock()
+		c.send(chat.SystemMsg{Text: "Disconnected from the remote gateway."})
+	})
+
+	for {
+		var msg message
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		if msg.Type == "event" {
+			c.handleEvent(msg.Event, msg.Payload)
+			continue
+		}
+		c.pendingMu.Lock()
+		ch, ok := c.pending[msg.ID]
+		c.pendingMu.Unlock()
+		if ok {
+			ch <- msg
+		}
+	}
+}
+
+func (c *Client) pingLoop() {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.writeMu.Lock()
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(callTimeout))
+			c.writeMu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// send delivers msg to the TUI when a sender is set.
+func (c *Client) send(msg tea.Msg) {
+	c.mu.Lock()
+	send := c.sender
+	c.mu.Unlock()
+	if send != nil {
+		send(msg)
+	}
+}
+
+// ownTurn returns this cockpit's running turn, or nil when the session's
+// events belong to another client.
+func (c *Client) ownTurn() *turnState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.turn
+}
@@ -0,0 +1,233 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/approval"
+	"github.com/langoai/lango/internal/background"
+	"github.com/langoai/lango/internal/cli/chat"
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/gateway"
+	"github.com/langoai/lango/internal/observability"
+	"github.com/langoai/lango/internal/turnrunner"
+)
+
+func TestEndpointURL(t *testing.T) {
+	tests := []struct {
+		give    string
+		session string
+		want    string
+		wantErr bool
+	}{
+		{give: "wss://lango.example.com", want: "wss://lango.example.com/cockpit"},
+		{give: "lango.example.com:18789", session: "ops", want: "wss://lango.example.com:18789/cockpit?session=ops"},
+		{give: "http://localhost:18789/", want: "ws://localhost:18789/cockpit"},
+		{give: "https://example.com/lango/cockpit", want: "wss://example.com/lango/cockpit"},
+		{give: "ftp://example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			got, err := EndpointURL(tt.give, tt.session)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type fakeTasks struct{ cancelled []string }
+
+func (f *fakeTasks) List() []background.TaskSnapshot {
+	now := time.Now()
+	return []background.TaskSnapshot{
+		{ID: "old", Prompt: "first", StatusText: "done", OriginSession: "sess-1", StartedAt: now.Add(-time.Hour), CompletedAt: now.Add(-59 * time.Minute)},
+		{ID: "new", Prompt: "second", StatusText: "running", OriginSession: "sess-1", StartedAt: now.Add(-time.Minute)},
+		{ID: "other", Prompt: "third", StatusText: "running", OriginSession: "sess-2", StartedAt: now},
+	}
+}
+
+func (f *fakeTasks) Status(id string) (*background.TaskSnapshot, error) {
+	for _, snap := range f.List() {
+		if snap.ID == id {
+			return &snap, nil
+		}
+	}
+	return nil, fmt.Errorf("task %q not found", id)
+}
+
+func (f *fakeTasks) Cancel(id string) error {
+	f.cancelled = append(f.cancelled, id)
+	return nil
+}
+
+func (f *fakeTasks) Submit(context.Context, string, background.Origin) (string, error) {
+	return "", nil
+}
+
+type fakeMetrics struct{}
+
+func (fakeMetrics) Snapshot() observability.SystemSnapshot {
+	return observability.SystemSnapshot{ToolExecutions: 7}
+}
+
+func newTestGateway(t *testing.T) (*gateway.Server, string) {
+	t.Helper()
+	server := gateway.New(gateway.Config{
+		WebSocketEnabled: true,
+		ApprovalTimeout:  5 * time.Second,
+		Cockpit: config.RemoteCockpitConfig{
+			Enabled: true,
+			Tokens: []config.CockpitTokenConfig{
+				{Name: "alice", Token: "op-token", Role: gateway.RoleOperator},
+				{Name: "bob", Token: "view-token", Role: gateway.RoleViewer},
+			},
+		},
+	}, nil, nil, nil, nil)
+	server.SetTaskManager(&fakeTasks{})
+	server.SetMetricsSource(fakeMetrics{})
+	ts := httptest.NewServer(server.Router())
+	t.Cleanup(ts.Close)
+	return server, "ws" + strings.TrimPrefix(ts.URL, "http")
+}
+
+// msgRecorder collects TUI messages and auto-answers approval prompts.
+type msgRecorder struct {
+	msgs    chan tea.Msg
+	approve bool
+}
+
+func newMsgRecorder(approve bool) *msgRecorder {
+	return &msgRecorder{msgs: make(chan tea.Msg, 32), approve: approve}
+}
+
+func (r *msgRecorder) send(msg tea.Msg) {
+	if req, ok := msg.(chat.ApprovalRequestMsg); ok && r.approve {
+		req.Response <- approval.ApprovalResponse{Approved: true}
+	}
+	r.msgs <- msg
+}
+
+func (r *msgRecorder) waitSystem(t *testing.T, substr string) {
+	t.Helper()
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case msg := <-r.msgs:
+			if sys, ok := msg.(chat.SystemMsg); ok && strings.Contains(sys.Text, substr) {
+				return
+			}
+		case <-deadline:
+			t.Fatalf("no system message containing %q", substr)
+		}
+	}
+}
+
+func TestClient_DialRejectsBadToken(t *testing.T) {
+	_, url := newTestGateway(t)
+	_, err := Dial(context.Background(), url, "nope", "sess-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid cockpit token")
+}
+
+func TestClient_OperatorAndViewer(t *testing.T) {
+	server, url := newTestGateway(t)
+	ctx := context.Background()
+
+	op, err := Dial(ctx, url, "op-token", "sess-1")
+	require.NoError(t, err)
+	defer op.Close()
+	opMsgs := newMsgRecorder(true)
+	op.SetSender(opMsgs.send)
+
+	viewer, err := Dial(ctx, url, "view-token", "sess-1")
+	require.NoError(t, err)
+	defer viewer.Close()
+	viewerMsgs := newMsgRecorder(false)
+	viewer.SetSender(viewerMsgs.send)
+
+	assert.True(t, op.IsOperator())
+	assert.False(t, viewer.IsOperator())
+	assert.Equal(t, int64(7), op.Snapshot().ToolExecutions, "hello carries a metrics snapshot")
+	assert.Contains(t, viewer.Greeting(), "Also attached: alice (operator)")
+	assert.Contains(t, viewer.Greeting(), "Read-only")
+	opMsgs.waitSystem(t, "bob (viewer) attached")
+
+	// Viewers cannot act.
+	_, err = viewer.Run(ctx, turnrunner.Request{Input: "hi"})
+	assert.ErrorIs(t, err, ErrReadOnly)
+	assert.ErrorIs(t, viewer.CancelTask("new"), ErrReadOnly)
+
+	// Both roles see the session's tasks, newest first; operators can
+	// cancel them.
+	tasks := viewer.ListTasks()
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "new", tasks[0].ID)
+	assert.Equal(t, time.Minute, tasks[1].Elapsed)
+	require.NoError(t, op.CancelTask("new"))
+	assert.Error(t, op.CancelTask("other"))
+
+	// Approvals are answered by the operator and announced to the viewer.
+	resp, err := server.RequestOperatorApproval(ctx, approval.ApprovalRequest{
+		ID: "req-1", SessionKey: "sess-1", ToolName: "exec",
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.Approved)
+	viewerMsgs.waitSystem(t, "Approval requested for exec")
+	viewerMsgs.waitSystem(t, "Approval approved by alice")
+}
+
+func TestClient_RoutesTurnEvents(t *testing.T) {
+	rec := newMsgRecorder(false)
+	c := &Client{hello: Hello{ClientID: "me"}, approvals: map[string]context.CancelFunc{}}
+	c.SetSender(rec.send)
+
+	chunk := func(s string) json.RawMessage {
+		data, _ := json.Marshal(map[string]string{"chunk": s})
+		return data
+	}
+
+	// Another client's turn: events go straight to the TUI.
+	c.handleEvent("chat.user", json.RawMessage(`{"clientId":"other","sender":"carol","message":"status?"}`))
+	c.handleEvent("agent.chunk", chunk("all good"))
+	c.handleEvent("agent.done", json.RawMessage(`{"traceId":"t1"}`))
+	require.Len(t, rec.msgs, 3)
+	assert.Equal(t, "carol", (<-rec.msgs).(chat.ChannelMessageMsg).SenderName)
+	assert.Equal(t, chat.ChunkMsg{Chunk: "all good"}, <-rec.msgs)
+	assert.Equal(t, "t1", (<-rec.msgs).(chat.DoneMsg).Result.TraceID)
+
+	// Own turn: events go to the request callbacks.
+	var got []string
+	c.turn = &turnState{req: turnrunner.Request{
+		OnChunk:    func(s string) { got = append(got, s) },
+		OnToolCall: func(_, tool string, _ map[string]any) { got = append(got, "tool:"+tool) },
+	}}
+	c.handleEvent("chat.user", json.RawMessage(`{"clientId":"me","message":"hi"}`))
+	c.handleEvent("agent.tool_call", json.RawMessage(`{"callId":"1","toolName":"exec"}`))
+	c.handleEvent("agent.chunk", chunk("done"))
+	c.handleEvent("agent.error", json.RawMessage(`{"error":"boom","type":"tool_error"}`))
+	assert.Empty(t, rec.msgs)
+	assert.Equal(t, []string{"tool:exec", "done"}, got)
+	require.NotNil(t, c.turn.failure)
+	assert.Equal(t, "tool_error", c.turn.failure.Type)
+}
+
+func TestDiffOperators(t *testing.T) {
+	before := []Operator{{Name: "alice", Role: "operator"}, {Name: "bob", Role: "viewer"}}
+	after := []Operator{{Name: "alice", Role: "operator"}, {Name: "carol", Role: "viewer"}}
+	joined, left := diffOperators(before, after)
+	assert.Equal(t, []Operator{{Name: "carol", Role: "viewer"}}, joined)
+	assert.Equal(t, []Operator{{Name: "bob", Role: "viewer"}}, left)
+}
@@ -0,0 +1,390 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/langoai/lango/internal/approval"
+	"github.com/langoai/lango/internal/cli/chat"
+	"github.com/langoai/lango/internal/observability"
+	"github.com/langoai/lango/internal/turnrunner"
+	"github.com/langoai/lango/internal/turntrace"
+)
+
+// handleEvent routes a gateway event. Agent events during this cockpit's
+// own turn go to the turn's callbacks; otherwise they belong to another
+// client's turn and are sent to the TUI directly.
+func (c *Client) handleEvent(event string, payload json.RawMessage) {
+	switch event {
+	case "chat.user":
+		c.handleUserMessage(payload)
+	case "agent.chunk":
+		c.handleChunk(payload)
+	case "agent.tool_call":
+		c.handleToolCall(payload)
+	case "agent.tool_result":
+		c.handleToolResult(payload)
+	case "agent.delegation":
+		c.handleDelegation(payload)
+	case "agent.budget_warning":
+		c.handleBudgetWarning(payload)
+	case "agent.warning":
+		var p struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(payload, &p) == nil && p.Message != "" {
+			c.send(chat.SystemMsg{Text: p.Message})
+		}
+	case "agent.done":
+		c.handleDone(payload)
+	case "agent.error":
+		c.handleError(payload)
+	case "approval.request":
+		c.handleApprovalRequest(payload)
+	case "approval.resolved":
+		c.handleApprovalResolved(payload)
+	case "cockpit.presence":
+		c.handlePresence(payload)
+	case "cockpit.metrics":
+		var snap observability.SystemSnapshot
+		if json.Unmarshal(payload, &snap) == nil {
+			c.mu.Lock()
+			c.metrics = snap
+			c.mu.Unlock()
+		}
+	}
+}
+
+func (c *Client) handleUserMessage(payload json.RawMessage) {
+	var p struct {
+		SessionKey string `json:"sessionKey"`
+		ClientID   string `json:"clientId"`
+		Sender     string `json:"sender"`
+		Message    string `json:"message"`
+	}
+	if json.Unmarshal(payload, &p) != nil || p.ClientID == c.hello.ClientID {
+		return
+	}
+	c.send(chat.ChannelMessageMsg{
+		Channel:    "cockpit",
+		SessionKey: p.SessionKey,
+		SenderName: p.Sender,
+		Text:       p.Message,
+		Timestamp:  time.Now(),
+	})
+}
+
+func (c *Client) handleChunk(payload json.RawMessage) {
+	var p struct {
+		Chunk string `json:"chunk"`
+	}
+	if json.Unmarshal(payload, &p) != nil {
+		return
+	}
+	if t := c.ownTurn(); t != nil {
+		if t.req.OnChunk != nil {
+			t.req.OnChunk(p.Chunk)
+		}
+		return
+	}
+	c.send(chat.ChunkMsg{Chunk: p.Chunk})
+}
+
+func (c *Client) handleToolCall(payload json.RawMessage) {
+	var p struct {
+		CallID   string         `json:"callId"`
+		ToolName string         `json:"toolName"`
+		Params   map[string]any `json:"params"`
+	}
+	if json.Unmarshal(payload, &p) != nil {
+		return
+	}
+	if t := c.ownTurn(); t != nil {
+		if t.req.OnToolCall != nil {
+			t.req.OnToolCall(p.CallID, p.ToolName, p.Params)
+		}
+		return
+	}
+	c.send(chat.ToolStartedMsg{CallID: p.CallID, ToolName: p.ToolName, Params: p.Params})
+}
+
+func (c *Client) handleToolResult(payload json.RawMessage) {
+	var p struct {
+		CallID     string `json:"callId"`
+		ToolName   string `json:"toolName"`
+		Success    bool   `json:"success"`
+		DurationMs int64  `json:"durationMs"`
+		Preview    string `json:"preview"`
+	}
+	if json.Unmarshal(payload, &p) != nil {
+		return
+	}
+	duration := time.Duration(p.DurationMs) * time.Millisecond
+	if t := c.ownTurn(); t != nil {
+		if t.req.OnToolResult != nil {
+			t.req.OnToolResult(p.CallID, p.ToolName, p.Success, duration, p.Preview)
+		}
+		return
+	}
+	c.send(chat.ToolFinishedMsg{
+		CallID: p.CallID, ToolName: p.ToolName, Success: p.Success, Duration: duration, Output: p.Preview,
+	})
+}
+
+func (c *Client) handleDelegation(payload json.RawMessage) {
+	var p struct {
+		From   string `json:"from"`
+		To     string `json:"to"`
+		Reason string `json:"reason"`
+	}
+	if json.Unmarshal(payload, &p) != nil {
+		return
+	}
+	if t := c.ownTurn(); t != nil {
+		if t.req.OnDelegation != nil {
+			t.req.OnDelegation(p.From, p.To, p.Reason)
+		}
+		return
+	}
+	c.send(chat.DelegationMsg{From: p.From, To: p.To, Reason: p.Reason})
+}
+
+func (c *Client) handleBudgetWarning(payload json.RawMessage) {
+	var p struct {
+		Used string `json:"used"`
+		Max  string `json:"max"`
+	}
+	if json.Unmarshal(payload, &p) != nil {
+		return
+	}
+	used, _ := strconv.Atoi(p.Used)
+	limit, _ := strconv.Atoi(p.Max)
+	if limit <= 0 {
+		return
+	}
+	if t := c.ownTurn(); t != nil {
+		if t.req.OnBudgetWarning != nil {
+			t.req.OnBudgetWarning(used, limit)
+		}
+		return
+	}
+	c.send(chat.BudgetWarningMsg{Used: used, Max: limit})
+}
+
+func (c *Client) handleDone(payload json.RawMessage) {
+	var p struct {
+		TraceID string `json:"traceId"`
+	}
+	_ = json.Unmarshal(payload, &p)
+
+	c.mu.Lock()
+	t := c.turn
+	if t != nil {
+		t.traceID = p.TraceID
+	}
+	c.mu.Unlock()
+	if t == nil {
+		c.send(chat.DoneMsg{Result: turnrunner.Result{Outcome: turntrace.OutcomeSuccess, TraceID: p.TraceID}})
+	}
+}
+
+func (c *Client) handleError(payload json.RawMessage) {
+	var f turnFailure
+	if json.Unmarshal(payload, &f) != nil {
+		return
+	}
+
+	c.mu.Lock()
+	t := c.turn
+	if t != nil {
+		t.failure = &f
+	}
+	c.mu.Unlock()
+	if t == nil {
+		c.send(chat.DoneMsg{Result: turnrunner.Result{
+			Outcome:     turnrunner.TurnOutcome(f.Type),
+			UserMessage: f.Error,
+			TraceID:     f.TraceID,
+		}})
+	}
+}
+
+// handleApprovalRequest prompts operators through the chat approval UI and
+// relays the answer. Viewers only see a notice.
+func (c *Client) handleApprovalRequest(payload json.RawMessage) {
+	var p struct {
+		ID          string         `json:"id"`
+		SessionKey  string         `json:"sessionKey"`
+		ToolName    string         `json:"toolName"`
+		Summary     string         `json:"summary"`
+		Params      map[string]any `json:"params"`
+		SafetyLevel string         `json:"safetyLevel"`
+		Category    string         `json:"category"`
+		Activity    string         `json:"activity"`
+	}
+	if json.Unmarshal(payload, &p) != nil || p.ID == "" {
+		return
+	}
+
+	if !c.IsOperator() {
+		c.send(chat.SystemMsg{Text: fmt.Sprintf("Approval requested for %s (waiting for an operator).", p.ToolName)})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.mu.Lock()
+	c.approvals[p.ID] = cancel
+	c.mu.Unlock()
+
+	req := approval.ApprovalRequest{
+		ID:          p.ID,
+		ToolName:    p.ToolName,
+		SessionKey:  p.SessionKey,
+		Params:      p.Params,
+		Summary:     p.Summary,
+		CreatedAt:   time.Now(),
+		SafetyLevel: p.SafetyLevel,
+		Category:    p.Category,
+		Activity:    p.Activity,
+	}
+	go func() {
+		defer c.dropApproval(p.ID)
+		prompt := chat.NewTUIApprovalProvider(func(msg interface{}) { c.send(msg) })
+		resp, err := prompt.RequestApproval(ctx, req)
+		if err != nil {
+			return // resolved elsewhere or connection closed
+		}
+		_, err = c.callWithTimeout("approval.response", map[string]interface{}{
+			"requestId":   p.ID,
+			"approved":    resp.Approved,
+			"alwaysAllow": resp.AlwaysAllow,
+		})
+		if err != nil {
+			c.send(chat.SystemMsg{Text: fmt.Sprintf("Approval response for %s failed: %v", p.ToolName, err)})
+		}
+	}()
+}
+
+func (c *Client) handleApprovalResolved(payload json.RawMessage) {
+	var p struct {
+		RequestID string `json:"requestId"`
+		Approved  bool   `json:"approved"`
+		Expired   bool   `json:"expired"`
+		By        string `json:"by"`
+	}
+	if json.Unmarshal(payload, &p) != nil {
+		return
+	}
+
+	c.mu.Lock()
+	cancel, pending := c.approvals[p.RequestID]
+	c.mu.Unlock()
+	if pending {
+		cancel()
+	}
+
+	switch {
+	case p.Expired:
+		c.send(chat.SystemMsg{Text: "Approval request expired."})
+	case p.By != "" && p.By != c.hello.Name:
+		verdict := "denied"
+		if p.Approved {
+			verdict = "approved"
+		}
+		c.send(chat.SystemMsg{Text: fmt.Sprintf("Approval %s by %s.", verdict, p.By)})
+	}
+}
+
+func (c *Client) dropApproval(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cancel, ok := c.approvals[id]; ok {
+		cancel()
+		delete(c.approvals, id)
+	}
+}
+
+func (c *Client) handlePresence(payload json.RawMessage) {
+	var p struct {
+		Operators []Operator `json:"operators"`
+	}
+	if json.Unmarshal(payload, &p) != nil {
+		return
+	}
+
+	c.mu.Lock()
+	before := c.operators
+	c.operators = p.Operators
+	c.mu.Unlock()
+
+	joined, left := diffOperators(before, p.Operators)
+	for _, op := range joined {
+		if op.Name != c.hello.Name {
+			c.send(chat.SystemMsg{Text: fmt.Sprintf("%s (%s) attached.", op.Name, op.Role)})
+		}
+	}
+	for _, op := range left {
+		c.send(chat.SystemMsg{Text: fmt.Sprintf("%s (%s) detached.", op.Name, op.Role)})
+	}
+}
+
+// diffOperators returns the operators present only in after (joined) and
+// only in before (left).
+func diffOperators(before, after []Operator) (joined, left []Operator) {
+	count := func(ops []Operator) map[string]int {
+		m := make(map[string]int, len(ops))
+		for _, op := range ops {
+			m[op.Name+"\x00"+op.Role]++
+		}
+		return m
+	}
+	was, now := count(before), count(after)
+	for _, op := range after {
+		k := op.Name + "\x00" + op.Role
+		if was[k] > 0 {
+			was[k]--
+			continue
+		}
+		joined = append(joined, op)
+	}
+	for _, op := range before {
+		k := op.Name + "\x00" + op.Role
+		if now[k] > 0 {
+			now[k]--
+			continue
+		}
+		left = append(left, op)
+	}
+	return joined, left
+}
+
+// Greeting describes the attachment for the chat transcript.
+func (c *Client) Greeting() string {
+	text := fmt.Sprintf("Attached to session %s as %s (%s).", c.hello.SessionKey, c.hello.Name, c.hello.Role)
+	var others []Operator
+	for _, op := range c.Operators() {
+		if op.Name != c.hello.Name {
+			others = append(others, op)
+		}
+	}
+	if len(others) > 0 {
+		text += " Also attached: " + formatOperators(others) + "."
+	}
+	if !c.IsOperator() {
+		text += " Read-only: chat, approvals and task actions are disabled."
+	}
+	return text
+}
+
+// formatOperators renders operators as "alice (operator), bob (viewer)".
+func formatOperators(ops []Operator) string {
+	parts := make([]string, len(ops))
+	for i, op := range ops {
+		parts[i] = fmt.Sprintf("%s (%s)", op.Name, op.Role)
+	}
+	return strings.Join(parts, ", ")
+}
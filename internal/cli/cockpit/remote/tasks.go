@@ -0,0 +1,72 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/langoai/lango/internal/background"
+	"github.com/langoai/lango/internal/cli/cockpit/pages"
+)
+
+// ListTasks fetches the gateway's background tasks, newest first. Errors
+// yield an empty list so the Tasks page keeps polling.
+func (c *Client) ListTasks() []pages.TaskInfo {
+	raw, err := c.callWithTimeout("cockpit.tasks.list", nil)
+	if err != nil {
+		return nil
+	}
+	var snapshots []background.TaskSnapshot
+	if err := json.Unmarshal(raw, &snapshots); err != nil {
+		return nil
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].StartedAt.After(snapshots[j].StartedAt)
+	})
+
+	tasks := make([]pages.TaskInfo, len(snapshots))
+	for i, s := range snapshots {
+		tasks[i] = pages.TaskInfo{
+			ID:            s.ID,
+			Prompt:        s.Prompt,
+			Status:        s.StatusText,
+			Elapsed:       taskElapsed(s),
+			Result:        s.Result,
+			Error:         s.Error,
+			OriginChannel: s.OriginChannel,
+			TokensUsed:    s.TokensUsed,
+		}
+	}
+	return tasks
+}
+
+// CancelTask cancels a background task on the gateway.
+func (c *Client) CancelTask(id string) error {
+	if !c.IsOperator() {
+		return ErrReadOnly
+	}
+	_, err := c.callWithTimeout("cockpit.tasks.cancel", map[string]string{"id": id})
+	return err
+}
+
+// RetryTask resubmits a background task's prompt on the gateway.
+func (c *Client) RetryTask(ctx context.Context, id string) error {
+	if !c.IsOperator() {
+		return ErrReadOnly
+	}
+	_, err := c.call(ctx, "cockpit.tasks.retry", map[string]string{"id": id})
+	return err
+}
+
+// taskElapsed computes the elapsed duration for a task snapshot.
+func taskElapsed(s background.TaskSnapshot) time.Duration {
+	if s.StartedAt.IsZero() {
+		return 0
+	}
+	if !s.CompletedAt.IsZero() {
+		return s.CompletedAt.Sub(s.StartedAt)
+	}
+	return time.Since(s.StartedAt)
+}
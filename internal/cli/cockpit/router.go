@@ -19,6 +19,7 @@ const (
 	PageSessions
 	PageTasks
 	PageApprovals
+	PageKnowledge
 )
 
 // String returns the page name for sidebar matching.
@@ -38,6 +39,8 @@ func (p PageID) String() string {
 		return "tasks"
 	case PageApprovals:
 		return "approvals"
+	case PageKnowledge:
+		return "knowledge"
 	default:
 		return "unknown"
 	}
@@ -74,6 +77,7 @@ func AllPageMetas() []sidebar.MenuItem {
 		{ID: PageSessions.String(), Icon: theme.IconSessions, Label: "Sessions"},
 		{ID: PageTasks.String(), Icon: theme.IconStatus, Label: "Tasks"},
 		{ID: PageApprovals.String(), Icon: theme.IconApprovals, Label: "Approvals"},
+		{ID: PageKnowledge.String(), Icon: theme.IconKnowledge, Label: "Knowledge"},
 	}
 }
 
@@ -95,6 +99,8 @@ func PageIDFromString(id string) PageID {
 		return PageTasks
 	case "approvals":
 		return PageApprovals
+	case "knowledge":
+		return PageKnowledge
 	default:
 		return PageChat
 	}
@@ -8,6 +8,7 @@ const (
 	IconStatus   = "◍"
 	IconSessions  = "◈"
 	IconApprovals = "◎"
+	IconKnowledge = "◇"
 )
 
 // Status indicators.
@@ -3,8 +3,13 @@ package cron
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -14,6 +19,7 @@ import (
 	"github.com/langoai/lango/internal/bootstrap"
 	"github.com/langoai/lango/internal/cron"
 	"github.com/langoai/lango/internal/ent"
+	"github.com/langoai/lango/internal/security"
 	"github.com/langoai/lango/internal/toolchain"
 )
 
@@ -39,6 +45,18 @@ func initStore(boot *bootstrap.Result) cron.Store {
 	return cron.NewEntStore(boot.DBClient)
 }
 
+// initSecrets opens the secrets store webhook trigger secrets are kept in.
+func initSecrets(ctx context.Context, boot *bootstrap.Result) (*security.SecretsStore, error) {
+	if boot.Crypto == nil {
+		return nil, fmt.Errorf("secrets store unavailable: crypto provider not initialized")
+	}
+	keys := security.NewKeyRegistry(boot.DBClient)
+	if _, err := keys.RegisterKey(ctx, "default", "local", security.KeyTypeEncryption); err != nil {
+		return nil, fmt.Errorf("register default key: %w", err)
+	}
+	return security.NewSecretsStore(boot.DBClient, keys, boot.Crypto), nil
+}
+
 func newAddCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
 	var (
 		name      string
@@ -49,6 +67,15 @@ func newAddCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
 		deliverTo []string
 		isolated  bool
 		timezone  string
+
+		onFile        string
+		debounce      time.Duration
+		onWebhook     bool
+		webhookSecret string
+		onEvent       []string
+		match         map[string]string
+		rateLimit     int
+		dedupWindow   time.Duration
 	)
 
 	cmd := &cobra.Command{
@@ -59,7 +86,16 @@ func newAddCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
 Examples:
   lango cron add --name "news" --schedule "0 9 * * *" --prompt "Summarize today's news" --deliver slack
   lango cron add --name "check" --every 1h --prompt "Check server status" --isolated
-  lango cron add --name "meeting" --at "2026-02-20T15:00:00" --prompt "Prepare meeting notes"`,
+  lango cron add --name "meeting" --at "2026-02-20T15:00:00" --prompt "Prepare meeting notes"
+
+Triggers run the job when something happens instead of (or as well as) on a
+schedule. The trigger payload is interpolated into the prompt: {{payload}}
+is the whole payload as JSON, {{payload.field}} a single field. Without a
+placeholder the payload is appended to the prompt.
+
+  lango cron add --name "ingest" --on-file "/data/inbox/*.csv" --prompt "Import {{payload.path}}"
+  lango cron add --name "deploy" --on-webhook --prompt "Review deployment {{payload.ref}}"
+  lango cron add --name "curate" --on-event content.saved --match source=knowledge --prompt "Review {{payload.id}}"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if prompt == "" {
 				return fmt.Errorf("--prompt is required")
@@ -86,13 +122,31 @@ Examples:
 				scheduleVal = at
 				count++
 			}
-			if count == 0 {
-				return fmt.Errorf("one of --schedule, --every, or --at is required")
-			}
 			if count > 1 {
 				return fmt.Errorf("only one of --schedule, --every, or --at may be specified")
 			}
 
+			trigger, err := buildTrigger(onFile, onWebhook, webhookSecret, onEvent, match)
+			if err != nil {
+				return err
+			}
+			if trigger == nil {
+				if count == 0 {
+					return fmt.Errorf("one of --schedule, --every, or --at is required (or a trigger: --on-file, --on-webhook, --on-event)")
+				}
+			} else {
+				trigger.Debounce = debounce
+				trigger.RateLimit = rateLimit
+				trigger.DedupWindow = dedupWindow
+				if err := trigger.Validate(); err != nil {
+					return fmt.Errorf("invalid trigger: %w", err)
+				}
+				if count == 0 {
+					scheduleType = cron.ScheduleTypeTrigger
+					scheduleVal = trigger.Summary()
+				}
+			}
+
 			sessionMode := "main"
 			if isolated {
 				sessionMode = "isolated"
@@ -109,6 +163,7 @@ Examples:
 			defer boot.DBClient.Close()
 
 			store := initStore(boot)
+			ctx := context.Background()
 
 			job := cron.Job{
 				ID:           uuid.New().String(),
@@ -119,11 +174,32 @@ Examples:
 				SessionMode:  sessionMode,
 				DeliverTo:    deliverTo,
 				Timezone:     timezone,
+				Trigger:      trigger,
 				Enabled:      true,
 				CreatedAt:    time.Now(),
 			}
 
-			if err := store.Create(context.Background(), job); err != nil {
+			// Webhook secrets live in the secrets store; the job row only
+			// keeps a reference.
+			var secretValue string
+			var secrets *security.SecretsStore
+			if trigger != nil && trigger.Kind == cron.TriggerKindWebhook {
+				secrets, err = initSecrets(ctx, boot)
+				if err != nil {
+					return fmt.Errorf("webhook trigger: %w", err)
+				}
+				secretValue = trigger.Secret
+				trigger.SecretRef = cron.WebhookSecretPrefix + job.ID
+				trigger.Secret = ""
+				if err := secrets.Store(ctx, trigger.SecretRef, []byte(secretValue)); err != nil {
+					return fmt.Errorf("store webhook secret: %w", err)
+				}
+			}
+
+			if err := store.Create(ctx, job); err != nil {
+				if secrets != nil {
+					_ = secrets.Delete(ctx, trigger.SecretRef)
+				}
 				return fmt.Errorf("create job: %w", err)
 			}
 
@@ -133,6 +209,17 @@ Examples:
 			if len(deliverTo) > 0 {
 				fmt.Printf("  Deliver to: %v\n", deliverTo)
 			}
+			if trigger != nil {
+				fmt.Printf("  Trigger: %s\n", trigger.Summary())
+			}
+			if trigger != nil && trigger.Kind == cron.TriggerKindWebhook {
+				route := strings.Replace(cron.WebhookRoute, "{name}", url.PathEscape(name), 1)
+				fmt.Printf("  Webhook: POST http://%s:%d%s\n", boot.Config.Server.Host, boot.Config.Server.Port, route)
+				fmt.Printf("  Secret: %s (stored as %s; shown only once)\n", secretValue, trigger.SecretRef)
+				fmt.Printf("  Send %s: <unix seconds> and a unique %s, and sign \"<timestamp>.<delivery>.<body>\"\n",
+					cron.WebhookTimestampHeader, cron.WebhookDeliveryHeader)
+				fmt.Printf("  with HMAC-SHA256 as %s: sha256=<hex>\n", cron.WebhookSignatureHeader)
+			}
 			return nil
 		},
 	}
@@ -145,10 +232,54 @@ Examples:
 	cmd.Flags().StringSliceVar(&deliverTo, "deliver", nil, "channels to deliver results (e.g. slack,telegram)")
 	cmd.Flags().BoolVar(&isolated, "isolated", false, "run in isolated session")
 	cmd.Flags().StringVar(&timezone, "timezone", "", "timezone (default: config or UTC)")
+	cmd.Flags().StringVar(&onFile, "on-file", "", "run when files matching a glob change (e.g. '/data/inbox/*.csv')")
+	cmd.Flags().DurationVar(&debounce, "debounce", 0, "quiet period before a file trigger fires (default 500ms)")
+	cmd.Flags().BoolVar(&onWebhook, "on-webhook", false, "run on a signed POST to the gateway webhook route")
+	cmd.Flags().StringVar(&webhookSecret, "webhook-secret", "", "HMAC secret for --on-webhook (default: generated)")
+	cmd.Flags().StringSliceVar(&onEvent, "on-event", nil, "run on event bus events (e.g. escrow.released)")
+	cmd.Flags().StringToStringVar(&match, "match", nil, "only fire on events whose fields match (e.g. source=knowledge)")
+	cmd.Flags().IntVar(&rateLimit, "rate-limit", 0, "maximum trigger fires per minute (default 6)")
+	cmd.Flags().DurationVar(&dedupWindow, "dedup-window", 0, "drop identical trigger payloads within this window (default 1m)")
 
 	return cmd
 }
 
+// buildTrigger assembles the trigger spec from the --on-* flags, or returns
+// nil when none is set.
+func buildTrigger(onFile string, onWebhook bool, secret string, onEvent []string, match map[string]string) (*cron.Trigger, error) {
+	var triggers []*cron.Trigger
+	if onFile != "" {
+		triggers = append(triggers, &cron.Trigger{Kind: cron.TriggerKindFile, Path: onFile})
+	}
+	if onWebhook {
+		if secret == "" {
+			buf := make([]byte, 32)
+			if _, err := rand.Read(buf); err != nil {
+				return nil, fmt.Errorf("generate webhook secret: %w", err)
+			}
+			secret = hex.EncodeToString(buf)
+		}
+		triggers = append(triggers, &cron.Trigger{Kind: cron.TriggerKindWebhook, Secret: secret})
+	}
+	if len(onEvent) > 0 {
+		triggers = append(triggers, &cron.Trigger{Kind: cron.TriggerKindEvent, Events: onEvent, Match: match})
+	}
+
+	switch {
+	case len(triggers) > 1:
+		return nil, fmt.Errorf("only one of --on-file, --on-webhook, or --on-event may be specified")
+	case len(triggers) == 0:
+		if secret != "" || len(match) > 0 {
+			return nil, fmt.Errorf("--webhook-secret and --match require a trigger flag")
+		}
+		return nil, nil
+	}
+	if len(match) > 0 && triggers[0].Kind != cron.TriggerKindEvent {
+		return nil, fmt.Errorf("--match only applies to --on-event")
+	}
+	return triggers[0], nil
+}
+
 func newListCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
 	return &cobra.Command{
 		Use:   "list",
@@ -208,14 +339,26 @@ func newDeleteCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
 			defer boot.DBClient.Close()
 
 			store := initStore(boot)
-			id, err := resolveJobID(context.Background(), store, args[0])
+			ctx := context.Background()
+			id, err := resolveJobID(ctx, store, args[0])
 			if err != nil {
 				return err
 			}
+			job, err := store.Get(ctx, id)
+			if err != nil {
+				return fmt.Errorf("get job: %w", err)
+			}
 
-			if err := store.Delete(context.Background(), id); err != nil {
+			if err := store.Delete(ctx, id); err != nil {
 				return fmt.Errorf("delete job: %w", err)
 			}
+			if job.Trigger != nil && job.Trigger.SecretRef != "" {
+				keys := security.NewKeyRegistry(boot.DBClient)
+				err := security.NewSecretsStore(boot.DBClient, keys, boot.Crypto).Delete(ctx, job.Trigger.SecretRef)
+				if err != nil && !errors.Is(err, security.ErrSecretNotFound) {
+					return fmt.Errorf("delete webhook secret: %w", err)
+				}
+			}
 
 			fmt.Printf("Cron job %q deleted.\n", args[0])
 			return nil
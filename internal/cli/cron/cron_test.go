@@ -1,12 +1,17 @@
 package cron
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/langoai/lango/internal/bootstrap"
 	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/cron"
+	"github.com/langoai/lango/internal/ent/enttest"
+	"github.com/langoai/lango/internal/security"
 	"github.com/langoai/lango/internal/testutil"
 )
 
@@ -174,3 +179,110 @@ func TestShortID(t *testing.T) {
 		})
 	}
 }
+
+func TestAddCmd_FileTrigger(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cmd := NewCronCmd(testutil.FakeBootLoader(t, cfg))
+
+	result := testutil.ExecCmdOK(t, cmd, "add",
+		"--name", "inbox",
+		"--prompt", "Import {{payload.path}}",
+		"--on-file", t.TempDir()+"/*.csv",
+		"--debounce", "2s",
+	)
+	assert.Contains(t, result.Stdout, `Cron job "inbox" created`)
+	assert.Contains(t, result.Stdout, "Schedule: trigger file:")
+}
+
+func TestAddCmd_WebhookTrigger(t *testing.T) {
+	cfg := config.DefaultConfig()
+	crypto := security.NewLocalCryptoProvider()
+	require.NoError(t, crypto.Initialize("test-passphrase-12345"))
+
+	// Each command closes its client, so the loader opens a fresh one on a
+	// shared in-memory database that the test keeps open.
+	dsn := "file:cron_webhook?mode=memory&cache=shared&_fk=1"
+	client := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { client.Close() })
+	loader := func() (*bootstrap.Result, error) {
+		return &bootstrap.Result{Config: cfg, DBClient: enttest.Open(t, "sqlite3", dsn), Crypto: crypto}, nil
+	}
+
+	result := testutil.ExecCmdOK(t, NewCronCmd(loader), "add",
+		"--name", "deploy",
+		"--prompt", "Review {{payload.ref}}",
+		"--on-webhook",
+		"--webhook-secret", "s3cret",
+	)
+	assert.Contains(t, result.Stdout, "/cron/webhook/deploy")
+	assert.Contains(t, result.Stdout, "Secret: s3cret")
+	assert.Contains(t, result.Stdout, cron.WebhookTimestampHeader)
+
+	// The job row keeps only a reference to the secret.
+	ctx := context.Background()
+	job, err := cron.NewEntStore(client).GetByName(ctx, "deploy")
+	require.NoError(t, err)
+	assert.Empty(t, job.Trigger.Secret)
+	assert.Equal(t, cron.WebhookSecretPrefix+job.ID, job.Trigger.SecretRef)
+	secrets := security.NewSecretsStore(client, security.NewKeyRegistry(client), crypto)
+	got, err := secrets.Get(ctx, job.Trigger.SecretRef)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", string(got))
+
+	// Deleting the job deletes its secret.
+	testutil.ExecCmdOK(t, NewCronCmd(loader), "delete", "deploy")
+	_, err = secrets.Get(ctx, job.Trigger.SecretRef)
+	assert.ErrorIs(t, err, security.ErrSecretNotFound)
+}
+
+func TestAddCmd_WebhookTriggerWithoutSecretsStore(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cmd := NewCronCmd(testutil.FakeBootLoader(t, cfg))
+
+	result := testutil.ExecCmd(t, cmd, "add",
+		"--name", "deploy",
+		"--prompt", "Review",
+		"--on-webhook",
+	)
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "secrets store unavailable")
+}
+
+func TestAddCmd_EventTriggerWithSchedule(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cmd := NewCronCmd(testutil.FakeBootLoader(t, cfg))
+
+	result := testutil.ExecCmdOK(t, cmd, "add",
+		"--name", "deals",
+		"--prompt", "Log {{payload}}",
+		"--every", "1h",
+		"--on-event", "content.saved",
+		"--match", "source=knowledge",
+	)
+	assert.Contains(t, result.Stdout, "every 1h")
+	assert.Contains(t, result.Stdout, "Trigger: event:content.saved[source=knowledge]")
+}
+
+func TestAddCmd_TriggerErrors(t *testing.T) {
+	tests := []struct {
+		give    []string
+		wantErr string
+	}{
+		{give: []string{"--on-file", "/tmp/*.csv", "--on-webhook"}, wantErr: "only one of --on-file, --on-webhook, or --on-event"},
+		{give: []string{"--on-webhook", "--match", "a=b"}, wantErr: "--match only applies to --on-event"},
+		{give: []string{"--schedule", "0 9 * * *", "--match", "a=b"}, wantErr: "require a trigger flag"},
+		{give: []string{"--on-file", "/tmp/*/x.csv"}, wantErr: "invalid trigger"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantErr, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cmd := NewCronCmd(testutil.FakeBootLoader(t, cfg))
+
+			args := append([]string{"add", "--name", "t", "--prompt", "p"}, tt.give...)
+			result := testutil.ExecCmd(t, cmd, args...)
+			require.Error(t, result.Err)
+			assert.Contains(t, result.Err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -61,8 +61,22 @@ func (c *ChannelCheck) Run(ctx context.Context, cfg *config.Config) Result {
 		}
 	}
 
+	// Check Email
+	if cfg.Channels.Email.Enabled {
+		switch {
+		case cfg.Channels.Email.IMAPAddress == "" || cfg.Channels.Email.SMTPAddress == "":
+			issues = append(issues, "Email: IMAP/SMTP address not set")
+		case resolveEnvValue(cfg.Channels.Email.Password) == "":
+			issues = append(issues, "Email: password not set")
+		case len(cfg.Channels.Email.Allowlist) == 0:
+			issues = append(issues, "Email: allowlist is empty")
+		default:
+			configured = append(configured, "Email")
+		}
+	}
+
 	// No channels enabled
-	if !cfg.Channels.Telegram.Enabled && !cfg.Channels.Discord.Enabled && !cfg.Channels.Slack.Enabled {
+	if !cfg.Channels.Telegram.Enabled && !cfg.Channels.Discord.Enabled && !cfg.Channels.Slack.Enabled && !cfg.Channels.Email.Enabled {
 		return Result{
 			Name:    c.Name(),
 			Status:  StatusWarn,
@@ -43,6 +43,7 @@ func newEscrowStatusCmd(cfgLoader func() (*config.Config, error)) *cobra.Command
 			fmt.Printf("  Max Milestones:  %d\n", cfg.Economy.Escrow.MaxMilestones)
 			fmt.Printf("  Auto Release:    %v\n", cfg.Economy.Escrow.AutoRelease)
 			fmt.Printf("  Dispute Window:  %s\n", cfg.Economy.Escrow.DisputeWindow)
+			fmt.Printf("  Arbitration:     %v\n", cfg.Economy.Escrow.Arbitration.Enabled)
 			return nil
 		},
 	}
@@ -0,0 +1,195 @@
+// Package events provides CLI commands for inspecting the event bus journal.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/eventbus"
+)
+
+// NewEventsCmd creates the events command with lazy config loading.
+func NewEventsCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Inspect the event bus journal",
+		Long: `Inspect the persistent event bus journal.
+
+The journal records every event published on the internal event bus when
+observability.eventJournal.enabled is set. It can be read while the server
+is running.`,
+	}
+
+	cmd.AddCommand(newTailCmd(cfgLoader))
+
+	return cmd
+}
+
+func newTailCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
+	var (
+		types      []string
+		follow     bool
+		lines      int
+		from       uint64
+		jsonOutput bool
+		dir        string
+		interval   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Print the most recent journaled events",
+		Long: `Print the most recent journaled events, oldest first.
+
+Examples:
+  lango events tail                          # Last 20 events
+  lango events tail --type content.saved -n 5
+  lango events tail --follow --type escrow.created --type escrow.released
+  lango events tail --from 1200 --json       # Every event from seq 1200 as JSON lines`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if dir == "" {
+				cfg, err := cfgLoader()
+				if err != nil {
+					return fmt.Errorf("load config: %w", err)
+				}
+				if !cfg.Observability.EventJournal.Enabled {
+					fmt.Fprintln(os.Stderr, "Note: observability.eventJournal.enabled is false; new events are not being recorded.")
+				}
+				dir = cfg.Observability.EventJournal.Dir
+			}
+
+			emit := textPrinter(os.Stdout)
+			if jsonOutput {
+				emit = jsonPrinter(os.Stdout)
+			}
+
+			var (
+				next uint64
+				err  error
+			)
+			if cmd.Flags().Changed("from") {
+				next, err = printFrom(dir, from, types, emit)
+			} else {
+				next, err = printLast(dir, lines, types, emit)
+			}
+			if err != nil || !follow {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			return followJournal(ctx, dir, next, types, interval, emit)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&types, "type", nil, "Only show these event types (repeatable)")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep printing new events as they are journaled")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 20, "Number of recent events to print")
+	cmd.Flags().Uint64Var(&from, "from", 0, "Print every event from this sequence number instead of the last --lines")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw journal records as JSON lines")
+	cmd.Flags().StringVar(&dir, "dir", "", "Journal directory (default: observability.eventJournal.dir)")
+	cmd.Flags().DurationVar(&interval, "interval", 500*time.Millisecond, "Poll interval for --follow")
+
+	return cmd
+}
+
+// printFrom prints every record from seq onward and returns the sequence
+// number to continue from.
+func printFrom(dir string, seq uint64, types []string, emit func(eventbus.Record) error) (uint64, error) {
+	next := seq
+	for rec, err := range eventbus.ReadJournal(dir, seq, types...) {
+		if err != nil {
+			return next, fmt.Errorf("read journal: %w", err)
+		}
+		if err := emit(rec); err != nil {
+			return next, err
+		}
+		next = rec.Seq + 1
+	}
+	return next, nil
+}
+
+// printLast prints the last n matching records and returns the sequence
+// number to continue from.
+func printLast(dir string, n int, types []string, emit func(eventbus.Record) error) (uint64, error) {
+	var (
+		ring []eventbus.Record
+		next uint64
+	)
+	for rec, err := range eventbus.ReadJournal(dir, 0) {
+		if err != nil {
+			return 0, fmt.Errorf("read journal: %w", err)
+		}
+		next = rec.Seq + 1
+		if !matchType(rec.Type, types) || n <= 0 {
+			continue
+		}
+		if len(ring) == n {
+			ring = ring[1:]
+		}
+		ring = append(ring, rec)
+	}
+	for _, rec := range ring {
+		if err := emit(rec); err != nil {
+			return next, err
+		}
+	}
+	return next, nil
+}
+
+// followJournal polls the journal for records from seq onward until ctx is
+// cancelled.
+func followJournal(ctx context.Context, dir string, seq uint64, types []string, interval time.Duration, emit func(eventbus.Record) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		next, err := printFrom(dir, seq, types, emit)
+		if err != nil {
+			return err
+		}
+		seq = next
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func matchType(t string, types []string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, want := range types {
+		if t == want {
+			return true
+		}
+	}
+	return false
+}
+
+func textPrinter(w io.Writer) func(eventbus.Record) error {
+	return func(rec eventbus.Record) error {
+		_, err := fmt.Fprintf(w, "#%d  %s  %s  %s\n",
+			rec.Seq, rec.Time.Local().Format("2006-01-02 15:04:05.000"), rec.Type, rec.Payload)
+		return err
+	}
+}
+
+func jsonPrinter(w io.Writer) func(eventbus.Record) error {
+	enc := json.NewEncoder(w)
+	return func(rec eventbus.Record) error {
+		return enc.Encode(rec)
+	}
+}
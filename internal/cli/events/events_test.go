@@ -0,0 +1,132 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/eventbus"
+	"github.com/langoai/lango/internal/testutil"
+)
+
+func writeJournal(t *testing.T, dir string, types ...string) *eventbus.Journal {
+	t.Helper()
+	j, err := eventbus.OpenJournal(dir, eventbus.JournalOptions{})
+	require.NoError(t, err)
+	for i, typ := range types {
+		_, err := j.Append(typ, []byte(`{"n":`+strings.Repeat("1", i+1)+`}`))
+		require.NoError(t, err)
+	}
+	return j
+}
+
+func journalConfig(dir string) *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.Observability.EventJournal.Enabled = true
+	cfg.Observability.EventJournal.Dir = dir
+	return cfg
+}
+
+func TestTailCmd_LastLines(t *testing.T) {
+	dir := t.TempDir()
+	j := writeJournal(t, dir, "a", "b", "a", "a", "c")
+	defer j.Close()
+
+	cmd := NewEventsCmd(testutil.FakeCfgLoader(journalConfig(dir)))
+	result := testutil.ExecCmdOK(t, cmd, "tail", "-n", "2", "--type", "a")
+
+	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "#3  "), lines[0])
+	assert.Contains(t, lines[0], `a  {"n":111}`)
+	assert.True(t, strings.HasPrefix(lines[1], "#4  "), lines[1])
+}
+
+func TestTailCmd_FromJSON(t *testing.T) {
+	dir := t.TempDir()
+	j := writeJournal(t, dir, "a", "b", "c")
+	defer j.Close()
+
+	cmd := NewEventsCmd(testutil.FailCfgLoader(assert.AnError))
+	result := testutil.ExecCmdOK(t, cmd, "tail", "--dir", dir, "--from", "2", "--json")
+
+	var got []eventbus.Record
+	dec := json.NewDecoder(strings.NewReader(result.Stdout))
+	for dec.More() {
+		var rec eventbus.Record
+		require.NoError(t, dec.Decode(&rec))
+		got = append(got, rec)
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, uint64(2), got[0].Seq)
+	assert.Equal(t, "c", got[1].Type)
+}
+
+func TestTailCmd_EmptyJournal(t *testing.T) {
+	cmd := NewEventsCmd(testutil.FakeCfgLoader(journalConfig(t.TempDir())))
+	result := testutil.ExecCmdOK(t, cmd, "tail")
+	assert.Empty(t, result.Stdout)
+}
+
+func TestTailCmd_ConfigError(t *testing.T) {
+	cmd := NewEventsCmd(testutil.FailCfgLoader(assert.AnError))
+	result := testutil.ExecCmd(t, cmd, "tail")
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "load config")
+}
+
+// syncBuffer is a bytes.Buffer safe for a writer and a polling reader.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestFollowJournal(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	j := writeJournal(t, dir, "a", "b")
+	defer j.Close()
+
+	var out syncBuffer
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- followJournal(ctx, dir, 3, []string{"b"}, 10*time.Millisecond, textPrinter(&out))
+	}()
+
+	_, err := j.Append("a", []byte(`{}`))
+	require.NoError(t, err)
+	_, err = j.Append("b", []byte(`{"new":true}`))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return strings.Contains(out.String(), `#4  `)
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.NotContains(t, out.String(), "#2  ", "records before the start are not printed")
+	assert.NotContains(t, out.String(), "#3  ", "records of other types are not printed")
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, 1, strings.Count(out.String(), "\n"), "each record is printed once")
+}
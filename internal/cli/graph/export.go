@@ -2,14 +2,13 @@ package graph
 
 import (
 	"context"
-	"encoding/csv"
-	"encoding/json"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/langoai/lango/internal/config"
+	graphstore "github.com/langoai/lango/internal/graph"
 )
 
 func newExportCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
@@ -18,10 +17,23 @@ func newExportCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export all triples from the knowledge graph",
-		Long:  "Export all triples from the knowledge graph in JSON or CSV format.",
+		Long: `Export all triples from the knowledge graph.
+
+Formats:
+  json      lango's own triple array (default)
+  csv       subject, predicate, object only
+  ntriples  RDF N-Triples
+  turtle    RDF Turtle
+  jsonld    JSON-LD
+  graphml   GraphML
+
+RDF formats map predicates to ` + graphstore.Namespace + `
+and node IDs to ` + graphstore.NodeNamespace + `. Triple metadata is kept
+as rdf:Statement reification in RDF formats and as edge attributes in GraphML.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if format != "json" && format != "csv" {
-				return fmt.Errorf("--format must be json or csv")
+			f, err := graphstore.ParseFormat(format)
+			if err != nil {
+				return fmt.Errorf("--format: %w", err)
 			}
 
 			cfg, err := cfgLoader()
@@ -40,31 +52,11 @@ func newExportCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
 				return fmt.Errorf("export triples: %w", err)
 			}
 
-			switch format {
-			case "json":
-				enc := json.NewEncoder(os.Stdout)
-				enc.SetIndent("", "  ")
-				return enc.Encode(triples)
-
-			case "csv":
-				w := csv.NewWriter(os.Stdout)
-				if err := w.Write([]string{"subject", "predicate", "object"}); err != nil {
-					return fmt.Errorf("write csv header: %w", err)
-				}
-				for _, t := range triples {
-					if err := w.Write([]string{t.Subject, t.Predicate, t.Object}); err != nil {
-						return fmt.Errorf("write csv row: %w", err)
-					}
-				}
-				w.Flush()
-				return w.Error()
-			}
-
-			return nil
+			return graphstore.WriteTriples(os.Stdout, f, triples)
 		},
 	}
 
-	cmd.Flags().StringVar(&format, "format", "json", "Output format: json or csv")
+	cmd.Flags().StringVar(&format, "format", "json", "Output format: json, csv, ntriples, turtle, jsonld or graphml")
 
 	return cmd
 }
@@ -1,6 +1,8 @@
 package graph
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -83,6 +85,26 @@ func TestQueryCmd_PredicateWithoutSubject(t *testing.T) {
 	assert.Contains(t, result.Err.Error(), "--predicate requires --subject")
 }
 
+func TestQueryCmd_InvalidQuery(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Graph.Enabled = true
+	cmd := NewGraphCmd(testutil.FakeCfgLoader(cfg))
+
+	result := testutil.ExecCmd(t, cmd, "query", "SELECT ?y WHERE { ?x caused_by ?z }")
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "parse query")
+}
+
+func TestQueryCmd_ExplainWithoutQuery(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Graph.Enabled = true
+	cmd := NewGraphCmd(testutil.FakeCfgLoader(cfg))
+
+	result := testutil.ExecCmd(t, cmd, "query", "--explain", "--subject", "Alice")
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "--explain requires a query")
+}
+
 func TestAddCmd_MissingRequiredFlags(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Graph.Enabled = true
@@ -100,7 +122,7 @@ func TestExportCmd_InvalidFormat(t *testing.T) {
 
 	result := testutil.ExecCmd(t, cmd, "export", "--format", "xml")
 	require.Error(t, result.Err)
-	assert.Contains(t, result.Err.Error(), "must be json or csv")
+	assert.Contains(t, result.Err.Error(), "must be one of")
 }
 
 func TestImportCmd_MissingFileArg(t *testing.T) {
@@ -140,3 +162,27 @@ func TestStatusCmd_GraphEnabledNoPath(t *testing.T) {
 	require.Error(t, result.Err)
 	assert.Contains(t, result.Err.Error(), "graph database path is not configured")
 }
+
+func TestExportImportCmd_Turtle(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.Graph.Enabled = true
+	cfg.Graph.DatabasePath = filepath.Join(dir, "graph.db")
+
+	ttl := filepath.Join(dir, "graph.ttl")
+	src := `@prefix lango: <https://github.com/langoai/lango/ns/graph#> .
+@prefix node: <https://github.com/langoai/lango/ns/node/> .
+node:Alice lango:related_to node:Bob , node:Carol .
+`
+	require.NoError(t, os.WriteFile(ttl, []byte(src), 0o600))
+
+	result := testutil.ExecCmdOK(t, NewGraphCmd(testutil.FakeCfgLoader(cfg)), "import", ttl)
+	assert.Contains(t, result.Stdout, "Imported 2 triples (0 updated, 0 duplicates skipped)")
+
+	result = testutil.ExecCmdOK(t, NewGraphCmd(testutil.FakeCfgLoader(cfg)), "import", ttl)
+	assert.Contains(t, result.Stdout, "Imported 0 triples (0 updated, 2 duplicates skipped)")
+
+	result = testutil.ExecCmdOK(t, NewGraphCmd(testutil.FakeCfgLoader(cfg)), "export", "--format", "nt")
+	assert.Equal(t, "<https://github.com/langoai/lango/ns/node/Alice> <https://github.com/langoai/lango/ns/graph#related_to> <https://github.com/langoai/lango/ns/node/Bob> .\n"+
+		"<https://github.com/langoai/lango/ns/node/Alice> <https://github.com/langoai/lango/ns/graph#related_to> <https://github.com/langoai/lango/ns/node/Carol> .\n", result.Stdout)
+}
@@ -13,35 +13,45 @@ import (
 )
 
 func newImportCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
-	var jsonOutput bool
+	var (
+		format     string
+		jsonOutput bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "import <file>",
-		Short: "Import triples from a JSON file",
-		Long: `Import triples into the knowledge graph from a JSON file.
+		Short: "Import triples from a file",
+		Long: `Import triples into the knowledge graph from a file in any export format.
 
-The file should contain a JSON array of triple objects:
+The format is taken from --format, or else from the file extension
+(.json, .csv, .nt, .ttl, .jsonld, .graphml). The JSON format is an array
+of triple objects:
 [
   {"Subject": "Alice", "Predicate": "knows", "Object": "Bob"},
   {"Subject": "Bob", "Predicate": "works_at", "Object": "Acme"}
-]`,
+]
+
+The file is streamed in batches. Triples already in the graph are skipped;
+if an imported duplicate carries new metadata, it is merged into the
+stored triple.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			filePath := args[0]
 
-			data, err := os.ReadFile(filePath)
+			f, err := importFormat(format, filePath)
 			if err != nil {
-				return fmt.Errorf("read file: %w", err)
+				return err
 			}
 
-			var triples []graphstore.Triple
-			if err := json.Unmarshal(data, &triples); err != nil {
-				return fmt.Errorf("parse JSON: %w", err)
+			file, err := os.Open(filePath)
+			if err != nil {
+				return fmt.Errorf("read file: %w", err)
 			}
+			defer file.Close()
 
-			if len(triples) == 0 {
-				fmt.Println("No triples to import.")
-				return nil
+			reader, err := graphstore.NewTripleReader(file, f)
+			if err != nil {
+				return err
 			}
 
 			cfg, err := cfgLoader()
@@ -55,24 +65,46 @@ The file should contain a JSON array of triple objects:
 			}
 			defer store.Close()
 
-			if err := store.AddTriples(context.Background(), triples); err != nil {
-				return fmt.Errorf("import triples: %w", err)
+			stats, err := graphstore.ImportTriples(context.Background(), store, reader, graphstore.DefaultImportBatchSize)
+			if err != nil {
+				return err
 			}
 
 			if jsonOutput {
 				enc := json.NewEncoder(os.Stdout)
 				enc.SetIndent("", "  ")
 				return enc.Encode(map[string]interface{}{
-					"imported": len(triples),
+					"imported": stats.Added,
+					"updated":  stats.Updated,
+					"skipped":  stats.Skipped,
 				})
 			}
 
-			fmt.Printf("Imported %d triples.\n", len(triples))
+			if stats == (graphstore.ImportStats{}) {
+				fmt.Println("No triples to import.")
+				return nil
+			}
+			fmt.Printf("Imported %d triples (%d updated, %d duplicates skipped).\n", stats.Added, stats.Updated, stats.Skipped)
 			return nil
 		},
 	}
 
+	cmd.Flags().StringVar(&format, "format", "", "Input format: json, csv, ntriples, turtle, jsonld or graphml (default: from file extension, else json)")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 
 	return cmd
 }
+
+func importFormat(flag, path string) (graphstore.Format, error) {
+	if flag != "" {
+		f, err := graphstore.ParseFormat(flag)
+		if err != nil {
+			return "", fmt.Errorf("--format: %w", err)
+		}
+		return f, nil
+	}
+	if f, ok := graphstore.FormatFromPath(path); ok {
+		return f, nil
+	}
+	return graphstore.FormatJSON, nil
+}
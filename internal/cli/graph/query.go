@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/langoai/lango/internal/config"
@@ -18,17 +19,36 @@ func newQueryCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
 		predicate  string
 		object     string
 		limit      int
+		explain    bool
 		jsonOutput bool
 	)
 
 	cmd := &cobra.Command{
-		Use:   "query",
+		Use:   "query [QUERY]",
 		Short: "Query triples from the knowledge graph",
-		Long: `Query triples by subject, object, or subject+predicate.
+		Long: `Query the knowledge graph with a pattern query, or look up triples by
+subject, object, or subject+predicate.
 
-At least one of --subject or --object is required.
+A pattern query is a SPARQL subset that joins triple patterns on shared
+?variables, with FILTER, OPTIONAL, ORDER BY, LIMIT and OFFSET:
+
+  lango graph query 'SELECT ?obs ?fix WHERE {
+    ?obs caused_by ?err . ?err resolved_by ?fix AS ?e .
+    FILTER(meta(?e, "confidence") >= 0.8)
+  } ORDER BY ?obs LIMIT 10'
+
+Use --explain to print the join order and indexes the planner chose.
+
+Without a query, at least one of --subject or --object is required.
 The --predicate flag can only be used together with --subject.`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				return runPatternQuery(cfgLoader, args[0], limit, explain, jsonOutput)
+			}
+			if explain {
+				return fmt.Errorf("--explain requires a query")
+			}
 			if subject == "" && object == "" {
 				return fmt.Errorf("at least one of --subject or --object is required")
 			}
@@ -90,7 +110,66 @@ The --predicate flag can only be used together with --subject.`,
 	cmd.Flags().StringVar(&predicate, "predicate", "", "Filter by predicate (requires --subject)")
 	cmd.Flags().StringVar(&object, "object", "", "Filter by object")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit number of results (0 = unlimited)")
+	cmd.Flags().BoolVar(&explain, "explain", false, "Print the query plan instead of running the query")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 
 	return cmd
 }
+
+func runPatternQuery(cfgLoader func() (*config.Config, error), src string, limit int, explain, jsonOutput bool) error {
+	q, err := graphstore.ParseQuery(src)
+	if err != nil {
+		return fmt.Errorf("parse query: %w", err)
+	}
+
+	cfg, err := cfgLoader()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	store, err := initGraphStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	if explain {
+		plan, err := q.Explain(ctx, store)
+		if err != nil {
+			return fmt.Errorf("explain query: %w", err)
+		}
+		fmt.Print(plan)
+		return nil
+	}
+
+	res, err := q.Execute(ctx, store)
+	if err != nil {
+		return fmt.Errorf("run query: %w", err)
+	}
+	if limit > 0 && len(res.Rows) > limit {
+		res.Rows = res.Rows[:limit]
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	}
+
+	if len(res.Rows) == 0 {
+		fmt.Println("No results found.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "?"+strings.Join(res.Vars, "\t?"))
+	for _, row := range res.Rows {
+		cells := make([]string, len(res.Vars))
+		for i, v := range res.Vars {
+			cells[i] = row[v]
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
@@ -22,6 +22,9 @@ func newAddCmd() *cobra.Command {
 		headers   []string
 		scope     string
 		safety    string
+		oauth     bool
+		include   []string
+		exclude   []string
 	)
 
 	cmd := &cobra.Command{
@@ -40,7 +43,11 @@ Examples:
   lango mcp add remote-api --type http \
     --url "https://api.example.com/mcp" \
     --header "Authorization=Bearer \${TOKEN}" \
-    --scope user`,
+    --scope user
+
+  # Add an OAuth-protected server exposing only two of its tools
+  lango mcp add linear --type http --url "https://mcp.linear.app/mcp" \
+    --oauth --include-tools "list_issues,create_issue"`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
@@ -66,6 +73,11 @@ Examples:
 			if len(headers) > 0 {
 				srv.Headers = parseKV(headers)
 			}
+			srv.IncludeTools = include
+			srv.ExcludeTools = exclude
+			if oauth {
+				srv.OAuth = &config.MCPOAuthConfig{}
+			}
 
 			// Validate
 			switch transport {
@@ -80,6 +92,9 @@ Examples:
 			default:
 				return fmt.Errorf("invalid transport type: %s (must be stdio, http, or sse)", transport)
 			}
+			if oauth && transport == "stdio" {
+				return fmt.Errorf("--oauth requires http or sse transport")
+			}
 
 			// Determine file path based on scope
 			path, err := scopePath(scope)
@@ -109,6 +124,9 @@ Examples:
 			if url != "" {
 				fmt.Printf("  URL:       %s\n", url)
 			}
+			if oauth {
+				fmt.Printf("\nRun 'lango mcp auth %s' to authorize.\n", name)
+			}
 			return nil
 		},
 	}
@@ -121,6 +139,9 @@ Examples:
 	cmd.Flags().StringSliceVar(&headers, "header", nil, "HTTP headers (KEY=VALUE)")
 	cmd.Flags().StringVar(&scope, "scope", "user", "config scope: user or project")
 	cmd.Flags().StringVar(&safety, "safety", "dangerous", "safety level: safe, moderate, dangerous")
+	cmd.Flags().BoolVar(&oauth, "oauth", false, "authorize with MCP OAuth (http/sse)")
+	cmd.Flags().StringSliceVar(&include, "include-tools", nil, "tool names or glob patterns to expose (default: all)")
+	cmd.Flags().StringSliceVar(&exclude, "exclude-tools", nil, "tool names or glob patterns to hide")
 
 	return cmd
 }
@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/bootstrap"
+	mcplib "github.com/langoai/lango/internal/mcp"
+	"github.com/langoai/lango/internal/security"
+)
+
+// authTimeout bounds how long the command waits for the browser redirect.
+const authTimeout = 5 * time.Minute
+
+func newAuthCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
+	var (
+		logout    bool
+		noBrowser bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "auth <name>",
+		Short: "Authorize an OAuth-protected MCP server",
+		Long: `Run the MCP OAuth authorization flow for a remote server configured with
+an "oauth" block. The authorization server is discovered from the MCP endpoint,
+a client is registered dynamically unless oauth.clientId is set, and the
+browser is opened to complete a PKCE authorization code flow. Tokens are stored
+in the encrypted secrets store and refreshed automatically.
+
+Examples:
+  lango mcp auth linear              # Authorize (opens browser)
+  lango mcp auth linear --no-browser # Print the URL instead
+  lango mcp auth linear --logout     # Remove stored tokens`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			boot, err := bootLoader()
+			if err != nil {
+				return fmt.Errorf("bootstrap: %w", err)
+			}
+			defer boot.DBClient.Close()
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			registry := security.NewKeyRegistry(boot.DBClient)
+			if _, err := registry.RegisterKey(ctx, "default", "local", security.KeyTypeEncryption); err != nil {
+				return fmt.Errorf("register default key: %w", err)
+			}
+			secrets := security.NewSecretsStore(boot.DBClient, registry, boot.Crypto)
+
+			if logout {
+				if err := secrets.Delete(ctx, mcplib.OAuthSecretName(name)); err != nil {
+					if errors.Is(err, security.ErrSecretNotFound) {
+						fmt.Printf("No stored tokens for %q.\n", name)
+						return nil
+					}
+					return fmt.Errorf("delete tokens: %w", err)
+				}
+				fmt.Printf("Removed stored tokens for %q.\n", name)
+				return nil
+			}
+
+			srv, ok := mcplib.MergedServers(&boot.Config.MCP)[name]
+			if !ok {
+				return fmt.Errorf("server %q not found", name)
+			}
+			if srv.OAuth == nil {
+				return fmt.Errorf("server %q has no oauth configuration (add \"oauth\": {} to enable it)", name)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, authTimeout)
+			defer cancel()
+
+			openURL := func(url string) error {
+				fmt.Printf("Open this URL to authorize %q:\n\n  %s\n\n", name, url)
+				if !noBrowser {
+					if err := openBrowser(url); err != nil {
+						fmt.Println("(could not open a browser automatically)")
+					}
+				}
+				fmt.Println("Waiting for authorization...")
+				return nil
+			}
+			if err := mcplib.Authorize(ctx, name, srv, secrets, openURL); err != nil {
+				return fmt.Errorf("authorize %q: %w", name, err)
+			}
+
+			fmt.Printf("Server %q authorized. Tokens stored as secret %q.\n", name, mcplib.OAuthSecretName(name))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&logout, "logout", false, "remove stored tokens instead of authorizing")
+	cmd.Flags().BoolVar(&noBrowser, "no-browser", false, "print the authorization URL without opening a browser")
+
+	return cmd
+}
+
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
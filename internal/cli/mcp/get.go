@@ -53,6 +53,19 @@ func newGetCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
 				if len(srv.Headers) > 0 {
 					fmt.Printf("  Headers:      %d configured\n", len(srv.Headers))
 				}
+				if srv.OAuth != nil {
+					fmt.Printf("  OAuth:        enabled (authorize with 'lango mcp auth %s')\n", name)
+				}
+			}
+
+			if len(srv.IncludeTools) > 0 {
+				fmt.Printf("  Include:      %v\n", srv.IncludeTools)
+			}
+			if len(srv.ExcludeTools) > 0 {
+				fmt.Printf("  Exclude:      %v\n", srv.ExcludeTools)
+			}
+			for tool, level := range srv.ToolSafety {
+				fmt.Printf("  Safety:       %s=%s\n", tool, level)
 			}
 
 			if srv.Timeout > 0 {
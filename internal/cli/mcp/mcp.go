@@ -20,12 +20,15 @@ func NewMCPCmd(
 
 MCP servers extend the agent with additional capabilities by connecting to
 external processes or HTTP endpoints that implement the Model Context Protocol.
+Use "lango mcp serve" to expose Lango itself to other MCP clients.
 
 Examples:
   lango mcp list                          # List configured servers
   lango mcp add github --type stdio ...   # Add a server
   lango mcp test github                   # Test server connectivity
-  lango mcp get github                    # Show server details`,
+  lango mcp get github                    # Show server details
+  lango mcp auth linear                   # Authorize an OAuth server
+  lango mcp serve                         # Serve Lango over stdio`,
 	}
 
 	cmd.AddCommand(newListCmd(cfgLoader))
@@ -35,6 +38,8 @@ Examples:
 	cmd.AddCommand(newTestCmd(cfgLoader))
 	cmd.AddCommand(newEnableCmd())
 	cmd.AddCommand(newDisableCmd())
+	cmd.AddCommand(newAuthCmd(bootLoader))
+	cmd.AddCommand(newServeCmd(bootLoader))
 
 	return cmd
 }
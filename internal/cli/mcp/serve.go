@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/app"
+	"github.com/langoai/lango/internal/approval"
+	"github.com/langoai/lango/internal/bootstrap"
+	"github.com/langoai/lango/internal/logging"
+	mcplib "github.com/langoai/lango/internal/mcp"
+)
+
+func newServeCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
+	var (
+		transport string
+		addr      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Expose Lango as an MCP server",
+		Long: `Run Lango as an MCP server so other MCP clients can use its tools and data.
+
+Only tools matched by mcp.serve.allowedTools are published. Every call runs
+through the normal tool middleware (approval, hooks, output truncation);
+approvals are requested from the client via MCP elicitation and denied when
+the client does not support it. Sessions and knowledge entries are published
+as resources when mcp.serve.resources is enabled.
+
+Examples:
+  lango mcp serve                              # stdio (for client configs)
+  lango mcp serve --transport http             # streamable HTTP
+  lango mcp serve --transport http --addr :9000  # needs mcp.serve.authToken
+
+Without mcp.serve.authToken the http transport only listens on loopback.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if transport != "stdio" && transport != "http" {
+				return fmt.Errorf("unsupported transport %q (want stdio or http)", transport)
+			}
+
+			boot, err := bootLoader()
+			if err != nil {
+				return fmt.Errorf("bootstrap: %w", err)
+			}
+			defer boot.DBClient.Close()
+
+			cfg := boot.Config
+			// stdout carries the MCP protocol in stdio mode, so logs go to a file.
+			logPath := filepath.Join(cfg.DataRoot, "mcp-serve.log")
+			if err := logging.Init(logging.LogConfig{
+				Level:      cfg.Logging.Level,
+				Format:     cfg.Logging.Format,
+				OutputPath: logPath,
+			}); err != nil {
+				return fmt.Errorf("init logging: %w", err)
+			}
+			defer func() { _ = logging.Sync() }()
+			if logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err == nil {
+				defer logFile.Close()
+				log.SetOutput(logFile)
+			}
+
+			application, err := app.New(boot, app.WithLocalChat())
+			if err != nil {
+				return fmt.Errorf("create application: %w", err)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			if err := application.Start(ctx); err != nil {
+				return fmt.Errorf("start application: %w", err)
+			}
+			defer func() {
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				_ = application.Stop(shutdownCtx)
+			}()
+
+			if composite, ok := application.ApprovalProvider.(*approval.CompositeProvider); ok {
+				composite.Register(&mcplib.ElicitationApprovalProvider{})
+			}
+
+			serveCfg := cfg.MCP.Serve
+			srvCfg := mcplib.ServerConfig{
+				Name:  "lango",
+				Tools: application.Tools,
+				Allow: serveCfg.AllowedTools,
+			}
+			if serveCfg.Resources {
+				if application.Store != nil {
+					srvCfg.Sessions = application.Store
+				}
+				if application.KnowledgeStore != nil {
+					srvCfg.Knowledge = application.KnowledgeStore
+				}
+			}
+			srv := mcplib.NewServer(srvCfg)
+
+			if transport == "stdio" {
+				if err := mcplib.ServeStdio(ctx, srv); err != nil && !errors.Is(err, context.Canceled) {
+					return fmt.Errorf("serve stdio: %w", err)
+				}
+				return nil
+			}
+
+			if addr == "" {
+				addr = serveCfg.HTTPAddr
+			}
+			if err := mcplib.CheckHTTPAddr(addr, serveCfg.AuthToken); err != nil {
+				return err
+			}
+			httpSrv := &http.Server{
+				Addr:              addr,
+				Handler:           mcplib.HTTPHandler(srv, serveCfg.AuthToken),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = httpSrv.Shutdown(shutdownCtx)
+			}()
+
+			fmt.Fprintf(os.Stderr, "MCP server listening on http://%s (logs: %s)\n", addr, logPath)
+			if err := httpSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("serve http: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&transport, "transport", "stdio", "Transport: stdio or http")
+	cmd.Flags().StringVar(&addr, "addr", "", "Listen address for the http transport (default: mcp.serve.httpAddr)")
+
+	return cmd
+}
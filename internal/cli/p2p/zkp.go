@@ -26,11 +26,12 @@ func newZKPCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "zkp",
 		Short: "Manage zero-knowledge proof settings",
-		Long:  "Inspect ZKP configuration, available circuits, and proving scheme.",
+		Long:  "Inspect ZKP configuration, available circuits, and proving scheme, and run the trusted-setup ceremony.",
 	}
 
 	cmd.AddCommand(newZKPStatusCmd(bootLoader))
 	cmd.AddCommand(newZKPCircuitsCmd())
+	cmd.AddCommand(newZKPCeremonyCmd())
 
 	return cmd
 }
@@ -0,0 +1,178 @@
+package p2p
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/p2p/zkp"
+	"github.com/langoai/lango/internal/p2p/zkp/ceremony"
+)
+
+func newZKPCeremonyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ceremony",
+		Short: "Run a multi-party trusted setup for the ZKP circuits",
+		Long: `Run a trusted-setup ceremony that replaces the unsafe SRS.
+
+Each participant runs "contribute" in turn on the same ceremony directory
+(passed between them, e.g. via a shared drive). Once everyone has
+contributed, the coordinator runs "finalize" with a public random beacon.
+Groth16 ceremonies have a second, per-circuit phase: contribute and finalize
+again after the first finalize. Anyone can audit the result with "verify".
+
+Point p2p.zkp.srsPath at <dir>/final/srs.bin and set p2p.zkp.srsMode to file.`,
+	}
+
+	cmd.AddCommand(newCeremonyInitCmd())
+	cmd.AddCommand(newCeremonyContributeCmd())
+	cmd.AddCommand(newCeremonyVerifyCmd())
+	cmd.AddCommand(newCeremonyFinalizeCmd())
+
+	return cmd
+}
+
+func newCeremonyInitCmd() *cobra.Command {
+	var (
+		scheme     string
+		circuitIDs []string
+		domainSize uint64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "init <dir>",
+		Short: "Create a new ceremony directory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := ceremony.Init(args[0], ceremony.InitOptions{
+				Scheme:     zkp.ProofScheme(scheme),
+				Circuits:   circuitIDs,
+				DomainSize: domainSize,
+			})
+			if err != nil {
+				return fmt.Errorf("init ceremony: %w", err)
+			}
+
+			fmt.Printf("Ceremony initialized in %s\n", args[0])
+			fmt.Printf("  Scheme:      %s\n", m.Scheme)
+			fmt.Printf("  Domain size: %d\n", m.DomainSize)
+			fmt.Printf("  Circuits:    %s\n", strings.Join(m.Circuits, ", "))
+			fmt.Printf("  Transcript:  %s\n", m.Transcript())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&scheme, "scheme", string(zkp.SchemePlonk), "Proving scheme (plonk, groth16)")
+	cmd.Flags().StringSliceVar(&circuitIDs, "circuits", nil,
+		"Circuit IDs to cover (default: "+strings.Join(ceremony.KnownCircuits(), ",")+")")
+	cmd.Flags().Uint64Var(&domainSize, "domain-size", 0, "Phase 1 domain size, a power of two (default: sized for the circuits)")
+	return cmd
+}
+
+func newCeremonyContributeCmd() *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "contribute <dir>",
+		Short: "Add your randomness to the current phase",
+		Long: `Add a contribution to the current phase of the ceremony.
+
+The randomness is drawn from the operating system and discarded as soon as
+the contribution is written. The setup is secure as long as at least one
+participant's randomness stays secret. Run "verify" first to audit the
+contributions you are building on.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := ceremony.Contribute(args[0], name)
+			if err != nil {
+				return fmt.Errorf("contribute: %w", err)
+			}
+
+			fmt.Printf("Contribution #%d to phase %d recorded for %s\n", c.Index, c.Phase, c.Participant)
+			fmt.Printf("  Transcript: %s\n", c.Transcript)
+			fmt.Println("Publish the transcript hash so others can check it was included.")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Participant name recorded in the transcript")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func newCeremonyVerifyCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "verify <dir>",
+		Short: "Verify every contribution and the sealed output",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := ceremony.Verify(args[0])
+			if err != nil {
+				return fmt.Errorf("verify ceremony: %w", err)
+			}
+
+			if jsonOutput {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(m)
+			}
+
+			fmt.Printf("Ceremony OK (scheme %s, phase %d, finalized %v)\n", m.Scheme, m.Phase, m.Finalized)
+			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "PHASE\t#\tPARTICIPANT\tTRANSCRIPT")
+			for _, c := range m.Contributions {
+				fmt.Fprintf(w, "%d\t%d\t%s\t%s\n", c.Phase, c.Index, c.Participant, c.Transcript)
+			}
+			for _, s := range m.Seals {
+				fmt.Fprintf(w, "%d\tseal\tbeacon %s\t%s\n", s.Phase, s.Beacon, s.Transcript)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	return cmd
+}
+
+func newCeremonyFinalizeCmd() *cobra.Command {
+	var beacon string
+
+	cmd := &cobra.Command{
+		Use:   "finalize <dir>",
+		Short: "Seal the current phase with a public random beacon",
+		Long: `Verify the current phase and seal it with a public random beacon.
+
+The beacon should be a value nobody could predict before the last
+contribution, such as a future block hash or a drand round. It is hashed
+before use, so any string works.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sum := sha256.Sum256([]byte(beacon))
+			m, err := ceremony.Finalize(args[0], sum[:])
+			if err != nil {
+				return fmt.Errorf("finalize: %w", err)
+			}
+
+			if !m.Finalized {
+				fmt.Printf("Phase 1 sealed. Phase %d is open: each participant contributes again, then run finalize.\n", m.Phase)
+				return nil
+			}
+			fmt.Println("Ceremony finalized.")
+			fmt.Printf("  SRS:        %s\n", ceremony.SRSPath(args[0]))
+			fmt.Printf("  Transcript: %s\n", m.Transcript())
+			fmt.Println("Set p2p.zkp.srsMode to file and p2p.zkp.srsPath to the SRS path.")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&beacon, "beacon", "", "Public random beacon value")
+	_ = cmd.MarkFlagRequired("beacon")
+	return cmd
+}
@@ -80,7 +80,10 @@ func newCheckpointListCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.C
 }
 
 func newCheckpointCreateCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
-	var runID string
+	var (
+		runID  string
+		gitDir string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "create <label>",
@@ -104,6 +107,9 @@ func newCheckpointCreateCmd(bootLoader func() (*bootstrap.Result, error)) *cobra
 			cpStore := provenancepkg.CheckpointStore(provenancepkg.NewEntCheckpointStore(boot.DBClient))
 			ledgerStore := runledger.NewEntStore(boot.DBClient)
 			svc := provenancepkg.NewCheckpointService(cpStore, ledgerStore, boot.Config.Provenance.Checkpoints)
+			if gitDir != "" {
+				svc.SetGitWorkDir(gitDir)
+			}
 
 			cp, err := svc.CreateManual(context.Background(), "", runID, args[0])
 			if err != nil {
@@ -116,6 +122,7 @@ func newCheckpointCreateCmd(bootLoader func() (*bootstrap.Result, error)) *cobra
 	}
 
 	cmd.Flags().StringVar(&runID, "run", "", "Run ID (required)")
+	cmd.Flags().StringVar(&gitDir, "git-dir", "", "Record the HEAD commit of this git working tree, for session rewind")
 	_ = cmd.MarkFlagRequired("run")
 
 	return cmd
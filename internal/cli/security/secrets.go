@@ -8,11 +8,13 @@ import (
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/langoai/lango/internal/bootstrap"
 	"github.com/langoai/lango/internal/cli/prompt"
+	sec "github.com/langoai/lango/internal/security"
 )
 
 func newSecretsCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
@@ -24,6 +26,11 @@ func newSecretsCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command
 	cmd.AddCommand(newSecretsListCmd(bootLoader))
 	cmd.AddCommand(newSecretsSetCmd(bootLoader))
 	cmd.AddCommand(newSecretsDeleteCmd(bootLoader))
+	cmd.AddCommand(newSecretsRotateCmd(bootLoader))
+	cmd.AddCommand(newSecretsVersionsCmd(bootLoader))
+	cmd.AddCommand(newSecretsRollbackCmd(bootLoader))
+	cmd.AddCommand(newSecretsPolicyCmd(bootLoader))
+	cmd.AddCommand(newSecretsExpireCmd(bootLoader))
 
 	return cmd
 }
@@ -64,14 +71,21 @@ func newSecretsListCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Comm
 			}
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			fmt.Fprintln(w, "NAME\tKEY\tCREATED\tUPDATED\tACCESS_COUNT")
+			fmt.Fprintln(w, "NAME\tVERSION\tKEY\tCREATED\tUPDATED\tEXPIRES\tACCESS_COUNT\tPOLICY")
 			for _, s := range secrets {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n",
+				expires := "-"
+				if s.ExpiresAt != nil {
+					expires = s.ExpiresAt.Format("2006-01-02 15:04")
+				}
+				fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%d\t%s\n",
 					s.Name,
+					s.Version,
 					s.KeyName,
 					s.CreatedAt.Format("2006-01-02 15:04"),
 					s.UpdatedAt.Format("2006-01-02 15:04"),
+					expires,
 					s.AccessCount,
+					formatPolicy(s.Policy),
 				)
 			}
 			return w.Flush()
@@ -83,7 +97,10 @@ func newSecretsListCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Comm
 }
 
 func newSecretsSetCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
-	var valueHex string
+	var (
+		valueHex  string
+		expiresIn time.Duration
+	)
 
 	cmd := &cobra.Command{
 		Use:   "set <name>",
@@ -103,29 +120,21 @@ func newSecretsSetCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Comma
 				return err
 			}
 
-			var raw []byte
-			if valueHex != "" {
-				// Non-interactive: decode hex value (with optional 0x prefix).
-				decoded, err := hex.DecodeString(strings.TrimPrefix(valueHex, "0x"))
-				if err != nil {
-					return fmt.Errorf("decode hex value: %w", err)
-				}
-				raw = decoded
-			} else {
-				if !prompt.IsInteractive() {
-					return fmt.Errorf("this command requires an interactive terminal (use --value-hex for non-interactive)")
-				}
-				value, err := prompt.Passphrase("Enter secret value: ")
-				if err != nil {
-					return fmt.Errorf("read secret value: %w", err)
-				}
-				raw = []byte(value)
+			raw, err := readSecretValue(valueHex)
+			if err != nil {
+				return err
 			}
 
 			ctx := context.Background()
 			if err := secretsStore.Store(ctx, name, raw); err != nil {
 				return fmt.Errorf("store secret: %w", err)
 			}
+			if expiresIn > 0 {
+				expiresAt := time.Now().Add(expiresIn)
+				if err := secretsStore.SetExpiry(ctx, name, &expiresAt); err != nil {
+					return fmt.Errorf("set secret expiry: %w", err)
+				}
+			}
 
 			fmt.Printf("Secret '%s' stored successfully.\n", name)
 			return nil
@@ -133,9 +142,31 @@ func newSecretsSetCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Comma
 	}
 
 	cmd.Flags().StringVar(&valueHex, "value-hex", "", "Hex-encoded value to store (non-interactive, optional 0x prefix)")
+	cmd.Flags().DurationVar(&expiresIn, "expires-in", 0, "Expire the secret after this duration (e.g. 720h)")
 	return cmd
 }
 
+// readSecretValue decodes a hex value for non-interactive use or prompts for
+// the value on an interactive terminal.
+func readSecretValue(valueHex string) ([]byte, error) {
+	if valueHex != "" {
+		// Non-interactive: decode hex value (with optional 0x prefix).
+		decoded, err := hex.DecodeString(strings.TrimPrefix(valueHex, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("decode hex value: %w", err)
+		}
+		return decoded, nil
+	}
+	if !prompt.IsInteractive() {
+		return nil, fmt.Errorf("this command requires an interactive terminal (use --value-hex for non-interactive)")
+	}
+	value, err := prompt.Passphrase("Enter secret value: ")
+	if err != nil {
+		return nil, fmt.Errorf("read secret value: %w", err)
+	}
+	return []byte(value), nil
+}
+
 func newSecretsDeleteCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
 	var force bool
 
@@ -183,3 +214,310 @@ func newSecretsDeleteCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Co
 	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
 	return cmd
 }
+
+func newSecretsRotateCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
+	var (
+		valueHex  string
+		expiresIn time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rotate <name>",
+		Short: "Store a new version of an existing secret",
+		Long: `Rotate replaces the value of an existing secret. The previous value is
+kept in the version history and can be restored with 'secrets rollback'.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			boot, err := bootLoader()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			defer boot.DBClient.Close()
+
+			secretsStore, err := secretsStoreFromBoot(boot)
+			if err != nil {
+				return err
+			}
+
+			raw, err := readSecretValue(valueHex)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			version, err := secretsStore.Rotate(ctx, name, raw)
+			if err != nil {
+				return fmt.Errorf("rotate secret: %w", err)
+			}
+			if cmd.Flags().Changed("expires-in") {
+				var expiresAt *time.Time
+				if expiresIn > 0 {
+					t := time.Now().Add(expiresIn)
+					expiresAt = &t
+				}
+				if err := secretsStore.SetExpiry(ctx, name, expiresAt); err != nil {
+					return fmt.Errorf("set secret expiry: %w", err)
+				}
+			}
+
+			fmt.Printf("Secret '%s' rotated to version %d.\n", name, version)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&valueHex, "value-hex", "", "Hex-encoded new value (non-interactive, optional 0x prefix)")
+	cmd.Flags().DurationVar(&expiresIn, "expires-in", 0, "Expire the new version after this duration (0 clears expiry)")
+	return cmd
+}
+
+func newSecretsVersionsCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "versions <name>",
+		Short: "Show the version history of a secret (values are never shown)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			boot, err := bootLoader()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			defer boot.DBClient.Close()
+
+			secretsStore, err := secretsStoreFromBoot(boot)
+			if err != nil {
+				return err
+			}
+
+			versions, err := secretsStore.Versions(context.Background(), args[0])
+			if err != nil {
+				return fmt.Errorf("list versions: %w", err)
+			}
+
+			if jsonOutput {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(versions)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tKEY\tCREATED\tCURRENT")
+			for _, v := range versions {
+				current := ""
+				if v.Current {
+					current = "*"
+				}
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\n",
+					v.Version, v.KeyName, v.CreatedAt.Format("2006-01-02 15:04"), current)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	return cmd
+}
+
+func newSecretsRollbackCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
+	var to int
+
+	cmd := &cobra.Command{
+		Use:   "rollback <name> --to <version>",
+		Short: "Restore an earlier version of a secret",
+		Long: `Rollback makes the value of an earlier version current again. The rollback
+is recorded as a new version, so history is never rewritten.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if to <= 0 {
+				return fmt.Errorf("--to must be a positive version number")
+			}
+
+			boot, err := bootLoader()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			defer boot.DBClient.Close()
+
+			secretsStore, err := secretsStoreFromBoot(boot)
+			if err != nil {
+				return err
+			}
+
+			version, err := secretsStore.Rollback(context.Background(), args[0], to)
+			if err != nil {
+				return fmt.Errorf("rollback secret: %w", err)
+			}
+
+			fmt.Printf("Secret '%s' rolled back to the value of version %d (now version %d).\n", args[0], to, version)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&to, "to", 0, "Version to restore")
+	return cmd
+}
+
+func newSecretsPolicyCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
+	var (
+		agents     []string
+		tools      []string
+		mcpServers []string
+		clear      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "policy <name>",
+		Short: "Show or set which agents, tools and MCP servers may resolve a secret",
+		Long: `Policy restricts resolution of {{secret:name}} references. Each list is an
+allowlist for one caller kind; an empty list leaves that kind unrestricted.
+A tool list denies every caller that is not a listed tool, including MCP
+servers; list secrets_get for agents to obtain {{secret:name}} references.
+Entries accept glob patterns. Without flags the current policy is shown.
+
+Examples:
+  lango security secrets policy github-token --agents operator --tools secrets_get,exec
+  lango security secrets policy db-password --mcp-servers postgres
+  lango security secrets policy github-token --clear`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			boot, err := bootLoader()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			defer boot.DBClient.Close()
+
+			secretsStore, err := secretsStoreFromBoot(boot)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			changed := clear || cmd.Flags().Changed("agents") ||
+				cmd.Flags().Changed("tools") || cmd.Flags().Changed("mcp-servers")
+			if !changed {
+				infos, err := secretsStore.List(ctx)
+				if err != nil {
+					return fmt.Errorf("list secrets: %w", err)
+				}
+				for _, info := range infos {
+					if info.Name == name {
+						fmt.Printf("%s: %s\n", name, formatPolicy(info.Policy))
+						return nil
+					}
+				}
+				return fmt.Errorf("%w: %s", sec.ErrSecretNotFound, name)
+			}
+
+			var policy sec.SecretPolicy
+			if !clear {
+				policy = sec.SecretPolicy{
+					AllowedAgents:     agents,
+					AllowedTools:      tools,
+					AllowedMCPServers: mcpServers,
+				}
+			}
+			if err := secretsStore.SetPolicy(ctx, name, policy); err != nil {
+				return fmt.Errorf("set policy: %w", err)
+			}
+
+			fmt.Printf("Secret '%s' policy: %s\n", name, formatPolicy(policy))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&agents, "agents", nil, "Agents allowed to resolve the secret")
+	cmd.Flags().StringSliceVar(&tools, "tools", nil, "Tools allowed to resolve the secret (e.g. secrets_get,exec)")
+	cmd.Flags().StringSliceVar(&mcpServers, "mcp-servers", nil, "MCP servers allowed to resolve the secret in env/headers")
+	cmd.Flags().BoolVar(&clear, "clear", false, "Remove all restrictions")
+	return cmd
+}
+
+func newSecretsExpireCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
+	var (
+		in    time.Duration
+		at    string
+		never bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "expire <name>",
+		Short: "Set or clear the expiry time of a secret",
+		Long: `Expired secrets can no longer be resolved by agents, tools or MCP servers.
+When alerting is enabled, an alert is raised ahead of expiry
+(security.secrets.expiryWarning) and again once the secret has expired.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			set := 0
+			for _, b := range []bool{in > 0, at != "", never} {
+				if b {
+					set++
+				}
+			}
+			if set != 1 {
+				return fmt.Errorf("specify exactly one of --in, --at or --never")
+			}
+
+			var expiresAt *time.Time
+			switch {
+			case in > 0:
+				t := time.Now().Add(in)
+				expiresAt = &t
+			case at != "":
+				t, err := time.Parse(time.RFC3339, at)
+				if err != nil {
+					return fmt.Errorf("parse --at (RFC3339): %w", err)
+				}
+				expiresAt = &t
+			}
+
+			boot, err := bootLoader()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			defer boot.DBClient.Close()
+
+			secretsStore, err := secretsStoreFromBoot(boot)
+			if err != nil {
+				return err
+			}
+
+			if err := secretsStore.SetExpiry(context.Background(), args[0], expiresAt); err != nil {
+				return fmt.Errorf("set expiry: %w", err)
+			}
+
+			if expiresAt == nil {
+				fmt.Printf("Secret '%s' no longer expires.\n", args[0])
+			} else {
+				fmt.Printf("Secret '%s' expires at %s.\n", args[0], expiresAt.Format(time.RFC3339))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&in, "in", 0, "Expire after this duration (e.g. 720h)")
+	cmd.Flags().StringVar(&at, "at", "", "Expire at this RFC3339 time")
+	cmd.Flags().BoolVar(&never, "never", false, "Clear the expiry")
+	return cmd
+}
+
+// formatPolicy renders a secret policy as a compact single line.
+func formatPolicy(p sec.SecretPolicy) string {
+	if p.IsZero() {
+		return "unrestricted"
+	}
+	var parts []string
+	if len(p.AllowedAgents) > 0 {
+		parts = append(parts, "agents="+strings.Join(p.AllowedAgents, ","))
+	}
+	if len(p.AllowedTools) > 0 {
+		parts = append(parts, "tools="+strings.Join(p.AllowedTools, ","))
+	}
+	if len(p.AllowedMCPServers) > 0 {
+		parts = append(parts, "mcp="+strings.Join(p.AllowedMCPServers, ","))
+	}
+	return strings.Join(parts, " ")
+}
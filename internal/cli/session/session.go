@@ -0,0 +1,202 @@
+// Package session provides CLI commands for managing conversation sessions.
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/bootstrap"
+	"github.com/langoai/lango/internal/cli/prompt"
+	"github.com/langoai/lango/internal/provenance"
+	"github.com/langoai/lango/internal/runledger"
+	sessionpkg "github.com/langoai/lango/internal/session"
+)
+
+const dateTimeFormat = "2006-01-02 15:04:05"
+
+// NewSessionCmd creates the session command with lazy bootstrap loading.
+func NewSessionCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "session",
+		Short: "Manage conversation sessions",
+	}
+
+	cmd.AddCommand(newRewindCmd(bootLoader))
+
+	return cmd
+}
+
+func newRewindCmd(bootLoader func() (*bootstrap.Result, error)) *cobra.Command {
+	var (
+		checkpointID string
+		dryRun       bool
+		force        bool
+		workspace    string
+		branch       string
+		workDir      string
+		jsonOutput   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rewind <session-key>",
+		Short: "Rewind a session to a provenance checkpoint",
+		Long: `Rewind a session to a provenance checkpoint.
+
+Messages recorded after the checkpoint's journal position are deleted. With
+--workspace, the git working tree is also restored to the checkpoint's git
+ref: "branch" checks it out on a new branch, "stash" restores the files in
+place on the current branch. Local changes are stashed first in both modes.
+The rewind is recorded as a new checkpoint with trigger "rewind".
+
+A preview is always printed first; use --dry-run to stop there.
+
+Examples:
+  lango session rewind telegram:123 --to 3f2a9c1e-... --dry-run
+  lango session rewind telegram:123 --to 3f2a9c1e-... --workspace branch
+  lango session rewind telegram:123 --to 3f2a9c1e-... --workspace stash --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mode := provenance.WorkspaceMode(workspace)
+			if !mode.Valid() {
+				return fmt.Errorf("--workspace must be %q or %q", provenance.WorkspaceBranch, provenance.WorkspaceStash)
+			}
+
+			boot, err := bootLoader()
+			if err != nil {
+				return err
+			}
+			defer boot.DBClient.Close()
+
+			if !boot.Config.Provenance.Enabled {
+				fmt.Println("Provenance is disabled. Enable with: lango config set provenance.enabled true")
+				return nil
+			}
+
+			svc := provenance.NewRewindService(
+				provenance.NewEntCheckpointStore(boot.DBClient),
+				runledger.NewEntStore(boot.DBClient),
+				sessionpkg.NewEntStoreWithClient(boot.DBClient),
+			)
+			req := provenance.RewindRequest{
+				SessionKey:   args[0],
+				CheckpointID: checkpointID,
+				DryRun:       true,
+				Workspace:    mode,
+				WorkDir:      workDir,
+				Branch:       branch,
+			}
+
+			ctx := context.Background()
+			preview, err := svc.Rewind(ctx, req)
+			if err != nil {
+				return fmt.Errorf("rewind session: %w", err)
+			}
+			if dryRun {
+				if jsonOutput {
+					return printJSON(preview)
+				}
+				printPreview(preview)
+				return nil
+			}
+
+			if !force {
+				if !prompt.IsInteractive() {
+					return fmt.Errorf("use --force for non-interactive mode")
+				}
+				printPreview(preview)
+				ok, err := prompt.Confirm("Rewind?")
+				if err != nil || !ok {
+					fmt.Println("Aborted.")
+					return nil
+				}
+			}
+
+			req.DryRun = false
+			result, err := svc.Rewind(ctx, req)
+			if err != nil {
+				return fmt.Errorf("rewind session: %w", err)
+			}
+			if jsonOutput {
+				return printJSON(result)
+			}
+
+			fmt.Printf("Rewound session %s to checkpoint %s: removed %d message(s).\n",
+				result.SessionKey, result.Checkpoint.ID, len(result.Removed))
+			if ws := result.Workspace; ws != nil {
+				if ws.Branch != "" {
+					fmt.Printf("Checked out %s on branch %s.\n", shortRef(ws.Commit), ws.Branch)
+				} else {
+					fmt.Printf("Restored files of %s on the current branch (staged).\n", shortRef(ws.Commit))
+				}
+				if ws.Stash != "" {
+					fmt.Printf("Local changes were stashed as %s (git stash list).\n", shortRef(ws.Stash))
+				}
+			}
+			fmt.Printf("Recorded rewind checkpoint %s.\n", result.RecordID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&checkpointID, "to", "", "Checkpoint ID to rewind to (required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without changing anything")
+	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
+	cmd.Flags().StringVar(&workspace, "workspace", "", "Also restore the working tree: branch or stash")
+	cmd.Flags().StringVar(&branch, "branch", "", "Branch for --workspace branch (default: rewind/<checkpoint>)")
+	cmd.Flags().StringVar(&workDir, "workdir", "", "Git working tree to restore (default: current directory)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// printPreview prints what a rewind removes and restores.
+func printPreview(r *provenance.RewindResult) {
+	cp := r.Checkpoint
+	fmt.Printf("Rewind session %s to checkpoint %s (%s, seq=%d)\n", r.SessionKey, cp.ID, cp.Label, cp.JournalSeq)
+	fmt.Printf("Cutoff:   %s\n", r.Cutoff.Local().Format(dateTimeFormat))
+	fmt.Printf("Messages: keep %d, remove %d\n", r.KeptMessages, len(r.Removed))
+	for _, m := range r.Removed {
+		fmt.Printf("  - %s  %-9s  %s\n", m.Timestamp.Local().Format(dateTimeFormat), m.Role,
+			strings.ReplaceAll(m.Preview, "\n", " "))
+	}
+
+	ws := r.Workspace
+	if ws == nil {
+		return
+	}
+	fmt.Printf("Workspace: %s -> %s", ws.Dir, shortRef(ws.Commit))
+	if ws.Branch != "" {
+		fmt.Printf(" on new branch %s", ws.Branch)
+	} else {
+		fmt.Print(" on the current branch")
+	}
+	fmt.Println()
+	if ws.Dirty {
+		fmt.Println("  Local changes will be stashed first.")
+	}
+	if ws.DiffStat == "" {
+		fmt.Println("  No file changes.")
+		return
+	}
+	for _, line := range strings.Split(ws.DiffStat, "\n") {
+		fmt.Printf("  %s\n", line)
+	}
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func shortRef(ref string) string {
+	if len(ref) > 12 {
+		return ref[:12]
+	}
+	return ref
+}
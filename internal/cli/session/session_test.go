@@ -0,0 +1,121 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/bootstrap"
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/ent"
+	"github.com/langoai/lango/internal/ent/enttest"
+	"github.com/langoai/lango/internal/provenance"
+	sessionpkg "github.com/langoai/lango/internal/session"
+	"github.com/langoai/lango/internal/testutil"
+)
+
+const testCheckpointID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+
+// seedDB creates a shared in-memory database holding a session with three
+// messages and a checkpoint after the first. The returned loader opens a
+// new client on the same database for each command, since commands close
+// the client they are given.
+func seedDB(t *testing.T) (*ent.Client, func() (*bootstrap.Result, error)) {
+	t.Helper()
+	dsn := "file:" + t.Name() + "?mode=memory&cache=shared&_fk=1"
+	client := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { client.Close() })
+
+	base := time.Now().Add(-time.Hour)
+	store := sessionpkg.NewEntStoreWithClient(client)
+	require.NoError(t, store.Create(&sessionpkg.Session{Key: "sess-1"}))
+	for i, content := range []string{"hello", "do the thing", "done"} {
+		require.NoError(t, store.AppendMessage("sess-1", sessionpkg.Message{
+			Role:      "user",
+			Content:   content,
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+	require.NoError(t, provenance.NewEntCheckpointStore(client).SaveCheckpoint(context.Background(), provenance.Checkpoint{
+		ID:         testCheckpointID,
+		SessionKey: "sess-1",
+		Label:      "start",
+		Trigger:    provenance.TriggerManual,
+		CreatedAt:  base.Add(30 * time.Second),
+	}))
+
+	cfg := config.DefaultConfig()
+	cfg.Provenance.Enabled = true
+	return client, func() (*bootstrap.Result, error) {
+		return &bootstrap.Result{Config: cfg, DBClient: enttest.Open(t, "sqlite3", dsn)}, nil
+	}
+}
+
+func TestRewindCmd_DryRun(t *testing.T) {
+	client, loader := seedDB(t)
+
+	result := testutil.ExecCmdOK(t, NewSessionCmd(loader), "rewind", "sess-1", "--to", testCheckpointID, "--dry-run")
+	assert.Contains(t, result.Stdout, "Messages: keep 1, remove 2")
+	assert.Contains(t, result.Stdout, "do the thing")
+
+	sess, err := sessionpkg.NewEntStoreWithClient(client).Get("sess-1")
+	require.NoError(t, err)
+	assert.Len(t, sess.History, 3)
+}
+
+func TestRewindCmd_Force(t *testing.T) {
+	client, loader := seedDB(t)
+
+	result := testutil.ExecCmdOK(t, NewSessionCmd(loader), "rewind", "sess-1", "--to", testCheckpointID, "--force", "--json")
+	var out provenance.RewindResult
+	require.NoError(t, json.Unmarshal([]byte(result.Stdout), &out))
+	assert.False(t, out.DryRun)
+	assert.Len(t, out.Removed, 2)
+	assert.NotEmpty(t, out.RecordID)
+
+	sess, err := sessionpkg.NewEntStoreWithClient(client).Get("sess-1")
+	require.NoError(t, err)
+	require.Len(t, sess.History, 1)
+	assert.Equal(t, "hello", sess.History[0].Content)
+}
+
+func TestRewindCmd_NonInteractiveNeedsForce(t *testing.T) {
+	_, loader := seedDB(t)
+
+	result := testutil.ExecCmd(t, NewSessionCmd(loader), "rewind", "sess-1", "--to", testCheckpointID)
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "--force")
+}
+
+func TestRewindCmd_Errors(t *testing.T) {
+	tests := []struct {
+		give    string
+		args    []string
+		wantErr string
+	}{
+		{give: "missing --to", args: []string{"rewind", "sess-1"}, wantErr: `"to" not set`},
+		{give: "bad workspace mode", args: []string{"rewind", "sess-1", "--to", testCheckpointID, "--workspace", "reset"}, wantErr: "--workspace"},
+		{give: "unknown checkpoint", args: []string{"rewind", "sess-1", "--to", "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "--dry-run"}, wantErr: "checkpoint not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			_, loader := seedDB(t)
+			result := testutil.ExecCmd(t, NewSessionCmd(loader), tt.args...)
+			require.Error(t, result.Err)
+			assert.Contains(t, result.Err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRewindCmd_ProvenanceDisabled(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Provenance.Enabled = false
+
+	result := testutil.ExecCmdOK(t, NewSessionCmd(testutil.FakeBootLoader(t, cfg)), "rewind", "sess-1", "--to", testCheckpointID)
+	assert.Contains(t, result.Stdout, "Provenance is disabled")
+}
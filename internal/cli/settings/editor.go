@@ -121,7 +121,7 @@ func (e *Editor) wireMenuCheckers() {
 func categoryIsEnabled(cfg *config.Config, id string) bool {
 	switch id {
 	case "channels":
-		return cfg.Channels.Telegram.Enabled || cfg.Channels.Discord.Enabled || cfg.Channels.Slack.Enabled
+		return cfg.Channels.Telegram.Enabled || cfg.Channels.Discord.Enabled || cfg.Channels.Slack.Enabled || cfg.Channels.Email.Enabled
 	case "knowledge":
 		return cfg.Knowledge.Enabled
 	case "skill":
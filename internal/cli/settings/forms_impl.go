@@ -252,6 +252,54 @@ func NewChannelsForm(cfg *config.Config) *tuicore.FormModel {
 		VisibleWhen: func() bool { return slackEnabled.Checked },
 	})
 
+	emailEnabled := &tuicore.Field{
+		Key: "email_enabled", Label: "Email", Type: tuicore.InputBool,
+		Checked:     cfg.Channels.Email.Enabled,
+		Description: "Enable email channel: read mail over IMAP and reply over SMTP",
+	}
+	form.AddField(emailEnabled)
+	form.AddField(&tuicore.Field{
+		Key: "email_imap", Label: "  IMAP Address", Type: tuicore.InputText,
+		Value:       cfg.Channels.Email.IMAPAddress,
+		Placeholder: "imap.example.com:993",
+		Description: "IMAP server host:port (TLS)",
+		VisibleWhen: func() bool { return emailEnabled.Checked },
+	})
+	form.AddField(&tuicore.Field{
+		Key: "email_smtp", Label: "  SMTP Address", Type: tuicore.InputText,
+		Value:       cfg.Channels.Email.SMTPAddress,
+		Placeholder: "smtp.example.com:587",
+		Description: "SMTP server host:port (465 = implicit TLS, otherwise STARTTLS)",
+		VisibleWhen: func() bool { return emailEnabled.Checked },
+	})
+	form.AddField(&tuicore.Field{
+		Key: "email_username", Label: "  Username", Type: tuicore.InputText,
+		Value:       cfg.Channels.Email.Username,
+		Placeholder: "agent@example.com",
+		Description: "Login for IMAP and SMTP; also the From address unless overridden",
+		VisibleWhen: func() bool { return emailEnabled.Checked },
+	})
+	form.AddField(&tuicore.Field{
+		Key: "email_password", Label: "  Password", Type: tuicore.InputPassword,
+		Value:       cfg.Channels.Email.Password,
+		Description: "Password or app password; use ${ENV_VAR} for security",
+		VisibleWhen: func() bool { return emailEnabled.Checked },
+	})
+	form.AddField(&tuicore.Field{
+		Key: "email_allowlist", Label: "  Allowed Senders", Type: tuicore.InputText,
+		Value:       strings.Join(cfg.Channels.Email.Allowlist, ","),
+		Placeholder: "alice@example.com,@example.com",
+		Description: "Comma-separated sender addresses or @domains; mail from others is ignored",
+		VisibleWhen: func() bool { return emailEnabled.Checked },
+	})
+	form.AddField(&tuicore.Field{
+		Key: "email_auth_serv_id", Label: "  Trusted Auth Server", Type: tuicore.InputText,
+		Value:       cfg.Channels.Email.TrustedAuthServID,
+		Placeholder: "mx.example.com",
+		Description: "Authserv-id whose Authentication-Results must show dmarc or aligned dkim pass",
+		VisibleWhen: func() bool { return emailEnabled.Checked },
+	})
+
 	return &form
 }
 
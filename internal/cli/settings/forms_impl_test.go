@@ -676,6 +676,7 @@ func TestNewSkillForm_AllFields(t *testing.T) {
 		"skill_enabled", "skill_dir",
 		"skill_allow_import", "skill_max_bulk",
 		"skill_import_concurrency", "skill_import_timeout",
+		"skill_trusted_keys", "skill_require_signature", "skill_index",
 	}
 
 	if len(form.Fields) != len(wantKeys) {
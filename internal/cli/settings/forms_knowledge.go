@@ -47,6 +47,15 @@ func NewSkillForm(cfg *config.Config) *tuicore.FormModel {
 	form.AddField(tuicore.TextInputWithPlaceholder("skill_import_timeout", "Import Timeout", cfg.Skill.ImportTimeout.String(), "2m (e.g. 30s, 1m, 5m)",
 		"Maximum time allowed for a single skill import operation"))
 
+	form.AddField(tuicore.TextInputWithPlaceholder("skill_trusted_keys", "Trusted Keys", strings.Join(cfg.Skill.TrustedKeys, ","), "RWQ... (comma-separated)",
+		"Minisign public keys trusted to sign skill packages installed with 'lango skill'"))
+
+	form.AddField(tuicore.BoolInput("skill_require_signature", "Require Signature", cfg.Skill.RequireSignature,
+		"Reject skill packages that are not signed by a trusted key"))
+
+	form.AddField(tuicore.TextInputWithPlaceholder("skill_index", "Skill Index", cfg.Skill.Index, "https://github.com/org/skill-index",
+		"Git source of the index.json used by 'lango skill search' and name-based installs"))
+
 	return &form
 }
 
@@ -80,6 +80,13 @@ func NewObservabilityForm(cfg *config.Config) *tuicore.FormModel {
 		},
 	})
 
+	form.AddField(&tuicore.Field{
+		Key: "obs_audit_checkpoint_interval", Label: "  Checkpoint Interval", Type: tuicore.InputText,
+		Value:       cfg.Observability.Audit.CheckpointInterval.String(),
+		Placeholder: "1h",
+		Description: "How often the audit hash chain is signed with the wallet key",
+	})
+
 	// Metrics Export
 	form.AddField(&tuicore.Field{
 		Key: "obs_metrics_enabled", Label: "Metrics Export", Type: tuicore.InputBool,
@@ -56,7 +56,7 @@ func NewSecurityForm(cfg *config.Config) *tuicore.FormModel {
 	form.AddField(&tuicore.Field{
 		Key: "interceptor_notify", Label: "  Notify Channel", Type: tuicore.InputSelect,
 		Value:       cfg.Security.Interceptor.NotifyChannel,
-		Options:     []string{"", string(types.ChannelTelegram), string(types.ChannelDiscord), string(types.ChannelSlack)},
+		Options:     []string{"", string(types.ChannelTelegram), string(types.ChannelDiscord), string(types.ChannelSlack), string(types.ChannelEmail)},
 		Description: "Channel to send approval notifications to; empty = no notification",
 		VisibleWhen: isInterceptorOn,
 	})
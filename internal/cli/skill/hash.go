@@ -0,0 +1,30 @@
+package skill
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	skillpkg "github.com/langoai/lango/internal/skill"
+)
+
+func newHashCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "hash <dir>",
+		Short: "Print the content hash of a skill directory (the message to sign)",
+		Long: `Print the content hash of a skill directory. Publishers sign this value
+with minisign and ship the signature as skill.minisig:
+
+  lango skill hash ./pdf | tr -d '\n' > /tmp/pdf.hash
+  minisign -S -m /tmp/pdf.hash -x ./pdf/skill.minisig`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hash, err := skillpkg.ContentHash(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(hash)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/config"
+	skillpkg "github.com/langoai/lango/internal/skill"
+)
+
+func newInstallCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
+	var (
+		only  string
+		force bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "install <source|name>",
+		Short: "Install skills from a git source or the skill index",
+		Long: `Install every skill found at a git source, or a single skill with --skill.
+
+A source is a GitHub tree URL (https://github.com/org/repo/tree/<ref>/<path>)
+or a git URL with optional path and ref: <git-url>[//<path>][#<ref>].
+A bare name is resolved through the index configured in skill.index.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, cfg, err := newManager(cfgLoader)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+
+			src, err := resolveSource(ctx, cfg, args[0])
+			if err != nil {
+				return err
+			}
+
+			fetched, err := pm.Fetch(ctx, src)
+			if err != nil {
+				return fmt.Errorf("fetch %s: %w", src, err)
+			}
+			defer fetched.Close()
+
+			installed := 0
+			for _, ps := range fetched.Skills {
+				if only != "" && ps.Name != only {
+					continue
+				}
+				if pm.Installed(ps.Name) && !force {
+					fmt.Printf("Skipped %q: already installed (use --force or \"lango skill update\").\n", ps.Name)
+					continue
+				}
+				if err := pm.Install(ps); err != nil {
+					return err
+				}
+				installed++
+				fmt.Printf("Installed %q at %s (%s)%s\n", ps.Name, shortCommit(ps.Commit), ps.Hash, signedSuffix(ps.SignedBy))
+			}
+			if only != "" && installed == 0 && !pm.Installed(only) {
+				return fmt.Errorf("skill %q not found in %s", only, src)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&only, "skill", "", "install only this skill from a multi-skill source")
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite skills that are already installed")
+	return cmd
+}
+
+// resolveSource parses a package source, resolving bare names through the
+// configured index.
+func resolveSource(ctx context.Context, cfg *config.Config, arg string) (skillpkg.PackageSource, error) {
+	if strings.ContainsAny(arg, "/:") {
+		return skillpkg.ParsePackageSource(arg)
+	}
+	if cfg.Skill.Index == "" {
+		return skillpkg.PackageSource{}, fmt.Errorf("%q is not a source URL and skill.index is not configured", arg)
+	}
+	idx, err := fetchIndex(ctx, cfg)
+	if err != nil {
+		return skillpkg.PackageSource{}, err
+	}
+	entry, ok := idx.Lookup(arg)
+	if !ok {
+		return skillpkg.PackageSource{}, fmt.Errorf("skill %q not found in index", arg)
+	}
+	return skillpkg.ParsePackageSource(entry.Source)
+}
+
+func signedSuffix(keyID string) string {
+	if keyID == "" {
+		return ""
+	}
+	return ", signed by " + keyID
+}
@@ -0,0 +1,46 @@
+package skill
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/config"
+	skillpkg "github.com/langoai/lango/internal/skill"
+)
+
+func newListCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List skills installed from packages",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, _, err := newManager(cfgLoader)
+			if err != nil {
+				return err
+			}
+			lf, err := skillpkg.LoadLockfile(pm.Dir())
+			if err != nil {
+				return err
+			}
+			if len(lf.Skills) == 0 {
+				fmt.Println("No installed skill packages.")
+				fmt.Println("\nInstall one with: lango skill install <source>")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tCOMMIT\tSIGNED\tINSTALLED\tSOURCE")
+			for _, name := range lf.Names() {
+				e := lf.Skills[name]
+				signed := "no"
+				if e.SignedBy != "" {
+					signed = e.SignedBy
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, shortCommit(e.Commit), signed, e.InstalledAt.Format("2006-01-02"), e.Source)
+			}
+			return w.Flush()
+		},
+	}
+}
@@ -0,0 +1,29 @@
+package skill
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/config"
+)
+
+func newRemoveCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm"},
+		Short:   "Remove an installed skill",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, _, err := newManager(cfgLoader)
+			if err != nil {
+				return err
+			}
+			if err := pm.Remove(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Skill %q removed.\n", args[0])
+			return nil
+		},
+	}
+}
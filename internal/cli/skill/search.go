@@ -0,0 +1,60 @@
+package skill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/config"
+	skillpkg "github.com/langoai/lango/internal/skill"
+)
+
+func fetchIndex(ctx context.Context, cfg *config.Config) (*skillpkg.Index, error) {
+	src, err := skillpkg.ParsePackageSource(cfg.Skill.Index)
+	if err != nil {
+		return nil, fmt.Errorf("skill.index: %w", err)
+	}
+	return skillpkg.FetchIndex(ctx, src)
+}
+
+func newSearchCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search the skill index",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := cfgLoader()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			if cfg.Skill.Index == "" {
+				return errors.New("skill.index is not configured")
+			}
+			idx, err := fetchIndex(context.Background(), cfg)
+			if err != nil {
+				return err
+			}
+
+			hits := idx.Search(strings.Join(args, " "))
+			if len(hits) == 0 {
+				fmt.Println("No matching skills.")
+				return nil
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tDESCRIPTION\tTAGS")
+			for _, h := range hits {
+				desc := h.Description
+				if len(desc) > 60 {
+					desc = desc[:57] + "..."
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", h.Name, desc, strings.Join(h.Tags, ","))
+			}
+			return w.Flush()
+		},
+	}
+}
@@ -0,0 +1,65 @@
+// Package skill provides CLI commands for skill package management.
+package skill
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/config"
+	skillpkg "github.com/langoai/lango/internal/skill"
+)
+
+// NewSkillCmd creates the skill command with lazy config loading.
+func NewSkillCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "skill",
+		Short: "Install and manage skill packages",
+		Long: `Install, update and verify skill packages from git repositories.
+
+Installed skills are pinned in <skillsDir>/skills-lock.json with their source,
+commit and content hash. When skill.trustedKeys is configured, minisign
+signatures (skill.minisig) are verified on install, update and verify;
+skill.requireSignature rejects unsigned packages.
+
+Examples:
+  lango skill install https://github.com/org/skills/tree/main/pdf
+  lango skill install pdf                   # Resolve through skill.index
+  lango skill search markdown               # Search skill.index
+  lango skill list                          # Show installed skills
+  lango skill update                        # Update all (shows a diff first)
+  lango skill verify                        # Check installed content
+  lango skill remove pdf`,
+	}
+
+	cmd.AddCommand(newInstallCmd(cfgLoader))
+	cmd.AddCommand(newUpdateCmd(cfgLoader))
+	cmd.AddCommand(newRemoveCmd(cfgLoader))
+	cmd.AddCommand(newListCmd(cfgLoader))
+	cmd.AddCommand(newVerifyCmd(cfgLoader))
+	cmd.AddCommand(newSearchCmd(cfgLoader))
+	cmd.AddCommand(newHashCmd())
+
+	return cmd
+}
+
+// newManager builds a package manager from the skill configuration.
+func newManager(cfgLoader func() (*config.Config, error)) (*skillpkg.PackageManager, *config.Config, error) {
+	cfg, err := cfgLoader()
+	if err != nil {
+		return nil, nil, fmt.Errorf("load config: %w", err)
+	}
+	keys, err := skillpkg.ParseTrustedKeys(cfg.Skill.TrustedKeys)
+	if err != nil {
+		return nil, nil, fmt.Errorf("skill.trustedKeys: %w", err)
+	}
+	dir := skillpkg.ResolveSkillsDir(cfg.Skill.SkillsDir)
+	return skillpkg.NewPackageManager(dir, keys, cfg.Skill.RequireSignature), cfg, nil
+}
+
+func shortCommit(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
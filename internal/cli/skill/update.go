@@ -0,0 +1,121 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/cli/prompt"
+	"github.com/langoai/lango/internal/config"
+	skillpkg "github.com/langoai/lango/internal/skill"
+)
+
+func newUpdateCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
+	var (
+		yes           bool
+		allowUnsigned bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update [name...]",
+		Short: "Update installed skills from their sources",
+		Long: `Re-fetch installed skills from the source recorded in the lockfile.
+A diff against the installed copy is shown and must be confirmed before the
+update is applied (use --yes to skip the prompt).
+
+A skill installed with a verified signature only updates to a version signed
+by a trusted key, unless --allow-unsigned is given.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, _, err := newManager(cfgLoader)
+			if err != nil {
+				return err
+			}
+			lf, err := skillpkg.LoadLockfile(pm.Dir())
+			if err != nil {
+				return err
+			}
+			names := args
+			if len(names) == 0 {
+				names = lf.Names()
+			}
+			if len(names) == 0 {
+				fmt.Println("No installed skill packages.")
+				return nil
+			}
+
+			ctx := context.Background()
+			for _, name := range names {
+				entry, ok := lf.Skills[name]
+				if !ok {
+					return fmt.Errorf("%w: %s", skillpkg.ErrNotInstalled, name)
+				}
+				if err := updateOne(ctx, pm, name, entry, yes, allowUnsigned); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "apply updates without confirmation")
+	cmd.Flags().BoolVar(&allowUnsigned, "allow-unsigned", false, "accept updates that drop the signature of a signed skill")
+	return cmd
+}
+
+func updateOne(ctx context.Context, pm *skillpkg.PackageManager, name string, entry skillpkg.LockEntry, yes, allowUnsigned bool) error {
+	src, err := skillpkg.ParsePackageSource(entry.Source)
+	if err != nil {
+		return fmt.Errorf("%s: lockfile source: %w", name, err)
+	}
+	fetched, err := pm.Fetch(ctx, src)
+	if err != nil {
+		return fmt.Errorf("%s: fetch %s: %w", name, src, err)
+	}
+	defer fetched.Close()
+
+	var ps *skillpkg.PackageSkill
+	for _, s := range fetched.Skills {
+		if s.Name == name {
+			ps = s
+		}
+	}
+	if ps == nil {
+		return fmt.Errorf("%s: no longer present in %s", name, src)
+	}
+	if ps.Hash == entry.Hash {
+		fmt.Printf("%s is up to date (%s).\n", name, shortCommit(ps.Commit))
+		return nil
+	}
+	if err := skillpkg.CheckSignatureDowngrade(entry, ps); err != nil {
+		if !allowUnsigned {
+			return fmt.Errorf("%w (re-run with --allow-unsigned to accept it)", err)
+		}
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	diff, err := pm.Diff(ps)
+	if err != nil {
+		return fmt.Errorf("%s: diff: %w", name, err)
+	}
+	fmt.Printf("Update %s: %s -> %s\n\n%s\n", name, shortCommit(entry.Commit), shortCommit(ps.Commit), diff)
+
+	if !yes {
+		if !prompt.IsInteractive() {
+			return fmt.Errorf("%s: confirmation required (re-run with --yes)", name)
+		}
+		ok, err := prompt.Confirm(fmt.Sprintf("Apply update to %s?", name))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Printf("Skipped %s.\n", name)
+			return nil
+		}
+	}
+	if err := pm.Install(ps); err != nil {
+		return err
+	}
+	fmt.Printf("Updated %s to %s%s\n", name, shortCommit(ps.Commit), signedSuffix(ps.SignedBy))
+	return nil
+}
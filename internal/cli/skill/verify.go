@@ -0,0 +1,55 @@
+package skill
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/langoai/lango/internal/config"
+)
+
+func newVerifyCmd(cfgLoader func() (*config.Config, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify [name...]",
+		Short: "Verify installed skills against the lockfile",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pm, _, err := newManager(cfgLoader)
+			if err != nil {
+				return err
+			}
+			results, err := pm.Verify(args...)
+			if err != nil {
+				return err
+			}
+			if len(results) == 0 {
+				fmt.Println("No installed skill packages.")
+				return nil
+			}
+
+			failed := 0
+			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tSTATUS\tDETAIL")
+			for _, r := range results {
+				switch {
+				case r.Err != nil:
+					failed++
+					fmt.Fprintf(w, "%s\tFAIL\t%v\n", r.Name, r.Err)
+				case !r.OK():
+					failed++
+					fmt.Fprintf(w, "%s\tMODIFIED\texpected %s, got %s\n", r.Name, r.Expected, r.Actual)
+				default:
+					fmt.Fprintf(w, "%s\tOK\t%s%s\n", r.Name, r.Actual, signedSuffix(r.SignedBy))
+				}
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d skill(s) failed verification", failed)
+			}
+			return nil
+		},
+	}
+}
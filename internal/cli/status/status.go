@@ -109,6 +109,9 @@ func collectStatus(cfg *config.Config, profile, addr string) StatusInfo {
 	if cfg.Channels.Slack.Enabled {
 		info.Channels = append(info.Channels, "slack")
 	}
+	if cfg.Channels.Email.Enabled {
+		info.Channels = append(info.Channels, "email")
+	}
 
 	// Collect features.
 	info.Features = collectFeatures(cfg)
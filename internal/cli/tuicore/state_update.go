@@ -88,6 +88,22 @@ func (s *ConfigState) UpdateConfigFromForm(form *FormModel) {
 		case "slack_app_token":
 			s.Current.Channels.Slack.AppToken = val
 
+		// Channels - Email
+		case "email_enabled":
+			s.Current.Channels.Email.Enabled = f.Checked
+		case "email_imap":
+			s.Current.Channels.Email.IMAPAddress = val
+		case "email_smtp":
+			s.Current.Channels.Email.SMTPAddress = val
+		case "email_username":
+			s.Current.Channels.Email.Username = val
+		case "email_password":
+			s.Current.Channels.Email.Password = val
+		case "email_allowlist":
+			s.Current.Channels.Email.Allowlist = splitCSV(val)
+		case "email_auth_serv_id":
+			s.Current.Channels.Email.TrustedAuthServID = val
+
 		// Tools
 		case "exec_timeout":
 			if d, err := time.ParseDuration(val); err == nil {
@@ -181,6 +197,12 @@ func (s *ConfigState) UpdateConfigFromForm(form *FormModel) {
 			if d, err := time.ParseDuration(val); err == nil {
 				s.Current.Skill.ImportTimeout = d
 			}
+		case "skill_trusted_keys":
+			s.Current.Skill.TrustedKeys = splitCSV(val)
+		case "skill_require_signature":
+			s.Current.Skill.RequireSignature = f.Checked
+		case "skill_index":
+			s.Current.Skill.Index = val
 
 			// Observational Memory
 		case "om_enabled":
@@ -910,6 +932,10 @@ func (s *ConfigState) UpdateConfigFromForm(form *FormModel) {
 			if i, err := strconv.Atoi(val); err == nil {
 				s.Current.Observability.Audit.RetentionDays = i
 			}
+		case "obs_audit_checkpoint_interval":
+			if d, err := time.ParseDuration(val); err == nil {
+				s.Current.Observability.Audit.CheckpointInterval = d
+			}
 		case "obs_metrics_enabled":
 			s.Current.Observability.Metrics.Enabled = f.Checked
 		case "obs_metrics_format":
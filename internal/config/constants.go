@@ -9,4 +9,5 @@ var (
 	ValidZKPSchemes        = map[string]bool{"plonk": true, "groth16": true}
 	ValidContainerRuntimes = map[string]bool{"auto": true, "docker": true, "gvisor": true, "native": true}
 	ValidMCPTransports     = map[string]bool{"": true, "stdio": true, "http": true, "sse": true}
+	ValidMCPSafetyLevels   = map[string]bool{"safe": true, "moderate": true, "dangerous": true}
 )
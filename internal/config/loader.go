@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/langoai/lango/internal/finance"
 	"github.com/langoai/lango/internal/provider"
 	sandboxos "github.com/langoai/lango/internal/sandbox/os"
 	"github.com/langoai/lango/internal/types"
@@ -82,6 +83,17 @@ func DefaultConfig() *Config {
 				TimeoutPerOperation: 5 * time.Second,
 				MaxRetries:          3,
 			},
+			Secrets: SecretsConfig{
+				ExpiryWarning:       7 * 24 * time.Hour,
+				ExpiryCheckInterval: time.Hour,
+				Vault: VaultConfig{
+					Mount:        "secret",
+					AuthMethod:   "token",
+					AppRoleMount: "approle",
+					CacheTTL:     5 * time.Minute,
+					Timeout:      10 * time.Second,
+				},
+			},
 		},
 		Knowledge: KnowledgeConfig{
 			Enabled:            false,
@@ -218,12 +230,24 @@ func DefaultConfig() *Config {
 			HealthCheckInterval:  30 * time.Second,
 			AutoReconnect:        true,
 			MaxReconnectAttempts: 5,
+			PinnedResourceTokens: 2000,
+			Serve: MCPServeConfig{
+				AllowedTools: []string{"search_knowledge", "graph_query", "graph_traverse", "list_skills", "skill_*"},
+				Resources:    true,
+				HTTPAddr:     "127.0.0.1:18791",
+			},
 		},
 		Ontology: OntologyConfig{
 			ACL: OntologyACLConfig{
 				P2PPermission: "read",
 			},
 		},
+		Observability: ObservabilityConfig{
+			EventJournal: EventJournalConfig{
+				Dir:           "~/.lango/events",
+				SegmentSizeMB: 16,
+			},
+		},
 		Alerting: AlertingConfig{
 			Enabled:         false,
 			PolicyBlockRate: 10,
@@ -242,8 +266,8 @@ func DefaultConfig() *Config {
 			HandshakeTimeout: 30 * time.Second,
 			SessionTokenTTL:  24 * time.Hour,
 			GossipInterval:   30 * time.Second,
-			ZKHandshake:      true,
-			ZKAttestation:    true,
+			ZKHandshake:      false,
+			ZKAttestation:    false,
 			ZKP: ZKPConfig{
 				ProofCacheDir:    "~/.lango/p2p/zkp-cache",
 				ProvingScheme:    "plonk",
@@ -419,6 +443,7 @@ func substituteEnvVars(cfg *Config) {
 	cfg.Channels.Slack.BotToken = ExpandEnvVars(cfg.Channels.Slack.BotToken)
 	cfg.Channels.Slack.AppToken = ExpandEnvVars(cfg.Channels.Slack.AppToken)
 	cfg.Channels.Slack.SigningSecret = ExpandEnvVars(cfg.Channels.Slack.SigningSecret)
+	cfg.Channels.Email.Password = ExpandEnvVars(cfg.Channels.Email.Password)
 
 	// Auth OIDC provider credentials
 	for id, aCfg := range cfg.Auth.Providers {
@@ -427,6 +452,11 @@ func substituteEnvVars(cfg *Config) {
 		cfg.Auth.Providers[id] = aCfg
 	}
 
+	// Remote cockpit tokens
+	for i := range cfg.Server.Cockpit.Tokens {
+		cfg.Server.Cockpit.Tokens[i].Token = ExpandEnvVars(cfg.Server.Cockpit.Tokens[i].Token)
+	}
+
 	// Payment
 	cfg.Payment.Network.RPCURL = ExpandEnvVars(cfg.Payment.Network.RPCURL)
 
@@ -438,8 +468,12 @@ func substituteEnvVars(cfg *Config) {
 		for k, v := range srv.Headers {
 			srv.Headers[k] = ExpandEnvVars(v)
 		}
+		if srv.OAuth != nil {
+			srv.OAuth.ClientSecret = ExpandEnvVars(srv.OAuth.ClientSecret)
+		}
 		cfg.MCP.Servers[name] = srv
 	}
+	cfg.MCP.Serve.AuthToken = ExpandEnvVars(cfg.MCP.Serve.AuthToken)
 
 	// Paths
 	cfg.Session.DatabasePath = ExpandEnvVars(cfg.Session.DatabasePath)
@@ -465,6 +499,14 @@ func Validate(cfg *Config) error {
 	if cfg.Server.Port < 1 || cfg.Server.Port > 65535 {
 		errs = append(errs, fmt.Sprintf("invalid port: %d (must be 1-65535)", cfg.Server.Port))
 	}
+	for i, t := range cfg.Server.Cockpit.Tokens {
+		if t.Token == "" {
+			errs = append(errs, fmt.Sprintf("server.cockpit.tokens[%d]: token is required", i))
+		}
+		if t.Role != "" && t.Role != "viewer" && t.Role != "operator" {
+			errs = append(errs, fmt.Sprintf("server.cockpit.tokens[%d]: invalid role %q (must be viewer or operator)", i, t.Role))
+		}
+	}
 
 	// Validate agent.provider references an existing key in providers map
 	if cfg.Agent.Provider != "" && len(cfg.Providers) > 0 {
@@ -540,6 +582,28 @@ func Validate(cfg *Config) error {
 		}
 	}
 
+	// Validate external secrets backend.
+	switch cfg.Security.Secrets.Backend {
+	case "", "local":
+	case "vault":
+		switch cfg.Security.Secrets.Vault.AuthMethod {
+		case "", "token":
+		case "approle":
+			if cfg.Security.Secrets.Vault.RoleID == "" {
+				errs = append(errs, "security.secrets.vault.roleId is required when authMethod is 'approle'")
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("invalid security.secrets.vault.authMethod: %q (must be token or approle)", cfg.Security.Secrets.Vault.AuthMethod))
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("invalid security.secrets.backend: %q (must be local or vault)", cfg.Security.Secrets.Backend))
+	}
+	for i, rp := range cfg.Security.Secrets.RemotePaths {
+		if strings.Trim(rp.Prefix, "/") == "" {
+			errs = append(errs, fmt.Sprintf("security.secrets.remotePaths[%d].prefix is required", i))
+		}
+	}
+
 	// Validate graph config
 	if cfg.Graph.Enabled && cfg.Graph.Backend != "bolt" {
 		errs = append(errs, fmt.Sprintf("graph.backend %q is not supported (must be \"bolt\")", cfg.Graph.Backend))
@@ -594,6 +658,19 @@ func Validate(cfg *Config) error {
 			errs = append(errs, fmt.Sprintf("invalid payment.walletProvider: %q (must be local, rpc, or composite)", cfg.Payment.WalletProvider))
 		}
 	}
+	if seller := cfg.Payment.X402.Seller; seller.Enabled {
+		if !cfg.Payment.Enabled {
+			errs = append(errs, "payment.x402.seller requires payment.enabled (wallet needed to receive payments)")
+		}
+		for i, rt := range seller.Routes {
+			if !strings.HasPrefix(rt.Path, "/") {
+				errs = append(errs, fmt.Sprintf("payment.x402.seller.routes[%d]: path %q must start with /", i, rt.Path))
+			}
+			if _, err := finance.ParseUSDC(rt.Price); err != nil {
+				errs = append(errs, fmt.Sprintf("payment.x402.seller.routes[%d]: invalid price %q: %v", i, rt.Price, err))
+			}
+		}
+	}
 
 	// Validate P2P config
 	if cfg.P2P.Enabled {
@@ -603,6 +680,10 @@ func Validate(cfg *Config) error {
 		if cfg.P2P.ZKP.ProvingScheme != "" && !ValidZKPSchemes[cfg.P2P.ZKP.ProvingScheme] {
 			errs = append(errs, fmt.Sprintf("invalid p2p.zkp.provingScheme: %q (must be plonk or groth16)", cfg.P2P.ZKP.ProvingScheme))
 		}
+		if (cfg.P2P.ZKHandshake || cfg.P2P.ZKAttestation) && !isLocalChain(cfg.Payment.Network.ChainID) &&
+			(cfg.P2P.ZKP.SRSMode != "file" || cfg.P2P.ZKP.SRSPath == "") {
+			errs = append(errs, fmt.Sprintf("p2p.zkHandshake/p2p.zkAttestation on chain %d need a ceremony SRS: run 'lango p2p zkp ceremony' and set p2p.zkp.srsMode to \"file\" with p2p.zkp.srsPath, or disable both (the unsafe SRS is only allowed on local chains 1337 and 31337)", cfg.Payment.Network.ChainID))
+		}
 	}
 
 	// Validate container sandbox config
@@ -628,6 +709,14 @@ func Validate(cfg *Config) error {
 					errs = append(errs, fmt.Sprintf("mcp.servers.%s.url is required for %s transport", name, srv.Transport))
 				}
 			}
+			if srv.OAuth != nil && srv.Transport != "http" && srv.Transport != "sse" {
+				errs = append(errs, fmt.Sprintf("mcp.servers.%s.oauth requires http or sse transport", name))
+			}
+			for tool, level := range srv.ToolSafety {
+				if !ValidMCPSafetyLevels[level] {
+					errs = append(errs, fmt.Sprintf("mcp.servers.%s.toolSafety.%s %q is not supported (must be safe, moderate, or dangerous)", name, tool, level))
+				}
+			}
 		}
 	}
 
@@ -643,6 +732,12 @@ func Validate(cfg *Config) error {
 // paths (NormalizePaths guarantees this at call sites that use this helper).
 // Returns false when filepath.Rel fails (different volumes on Windows, etc.)
 // so the caller does not trigger a spurious validation error.
+// isLocalChain mirrors wallet.IsDevNetwork, which config cannot import:
+// only local development chains may use the unsafe ZKP setup.
+func isLocalChain(chainID int64) bool {
+	return chainID == 1337 || chainID == 31337
+}
+
 func pathIsUnder(child, parent string) bool {
 	if child == "" || parent == "" {
 		return false
@@ -687,6 +782,7 @@ func NormalizePaths(cfg *Config) {
 	normalizePath(&cfg.P2P.KeyDir, cfg.DataRoot, home)
 	normalizePath(&cfg.P2P.ZKP.ProofCacheDir, cfg.DataRoot, home)
 	normalizePath(&cfg.P2P.Workspace.DataDir, cfg.DataRoot, home)
+	normalizePath(&cfg.Observability.EventJournal.Dir, cfg.DataRoot, home)
 
 	// Normalize sandbox paths so downstream code (supervisor wiring, bwrap arg
 	// compiler, Seatbelt profile generator) receives absolute paths instead of
@@ -749,6 +845,7 @@ func ValidateDataPaths(cfg *Config) error {
 		{"p2p.keyDir", cfg.P2P.KeyDir},
 		{"p2p.zkp.proofCacheDir", cfg.P2P.ZKP.ProofCacheDir},
 		{"p2p.workspace.dataDir", cfg.P2P.Workspace.DataDir},
+		{"observability.eventJournal.dir", cfg.Observability.EventJournal.Dir},
 	}
 
 	var errs []string
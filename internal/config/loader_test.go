@@ -279,6 +279,61 @@ func TestValidate(t *testing.T) {
 		assert.Contains(t, err.Error(), "scratch")
 	})
 
+	t.Run("p2p with default ZK settings accepted", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.Payment.Enabled = true
+		cfg.Payment.Network.RPCURL = "http://localhost:8545"
+		cfg.P2P.Enabled = true
+		assert.NoError(t, Validate(cfg))
+	})
+
+	t.Run("p2p ZK with unsafe SRS off local chains rejected", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.Payment.Enabled = true
+		cfg.Payment.Network.RPCURL = "http://localhost:8545"
+		cfg.P2P.Enabled = true
+		cfg.P2P.ZKHandshake = true
+		err := Validate(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "p2p.zkp.srsMode")
+
+		cfg.P2P.ZKP.SRSMode = "file"
+		assert.Error(t, Validate(cfg), "file mode needs srsPath")
+
+		cfg.P2P.ZKP.SRSPath = "/tmp/srs.bin"
+		assert.NoError(t, Validate(cfg))
+
+		cfg.P2P.ZKP.SRSMode = "unsafe"
+		cfg.Payment.Network.ChainID = 31337
+		assert.NoError(t, Validate(cfg))
+	})
+
+	t.Run("invalid secrets backend", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.Security.Secrets.Backend = "etcd"
+		assert.Error(t, Validate(cfg))
+	})
+
+	t.Run("vault approle requires roleId", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.Security.Secrets.Backend = "vault"
+		cfg.Security.Secrets.Vault.AuthMethod = "approle"
+		err := Validate(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "roleId")
+
+		cfg.Security.Secrets.Vault.RoleID = "role"
+		assert.NoError(t, Validate(cfg))
+	})
+
 	t.Run("sandbox workspacePath empty accepted", func(t *testing.T) {
 		t.Parallel()
 
@@ -177,6 +177,30 @@ type ServerConfig struct {
 
 	// Allowed origins for WebSocket CORS (empty = same-origin, ["*"] = allow all)
 	AllowedOrigins []string `mapstructure:"allowedOrigins" json:"allowedOrigins"`
+
+	// Remote cockpit access (lango cockpit --remote)
+	Cockpit RemoteCockpitConfig `mapstructure:"cockpit" json:"cockpit"`
+}
+
+// RemoteCockpitConfig controls remote cockpit attachment over the gateway.
+type RemoteCockpitConfig struct {
+	// Enable the /cockpit WebSocket endpoint
+	Enabled bool `mapstructure:"enabled" json:"enabled"`
+
+	// Bearer tokens accepted from remote operators
+	Tokens []CockpitTokenConfig `mapstructure:"tokens" json:"tokens"`
+}
+
+// CockpitTokenConfig maps a bearer token to an operator name and role.
+type CockpitTokenConfig struct {
+	// Operator name shown to other attached cockpits
+	Name string `mapstructure:"name" json:"name"`
+
+	// Bearer token (supports ${ENV_VAR})
+	Token string `mapstructure:"token" json:"token"`
+
+	// Role: "viewer" (read-only) or "operator" (chat, approvals, tasks)
+	Role string `mapstructure:"role" json:"role"`
 }
 
 // AgentConfig defines LLM agent settings
@@ -267,6 +291,7 @@ type ChannelsConfig struct {
 	Telegram TelegramConfig `mapstructure:"telegram" json:"telegram"`
 	Discord  DiscordConfig  `mapstructure:"discord" json:"discord"`
 	Slack    SlackConfig    `mapstructure:"slack" json:"slack"`
+	Email    EmailConfig    `mapstructure:"email" json:"email"`
 }
 
 // TelegramConfig defines Telegram bot settings
@@ -311,6 +336,47 @@ type SlackConfig struct {
 	SigningSecret string `mapstructure:"signingSecret" json:"signingSecret"`
 }
 
+// EmailConfig defines email channel settings (IMAP in, SMTP out)
+type EmailConfig struct {
+	// Enable email channel
+	Enabled bool `mapstructure:"enabled" json:"enabled"`
+
+	// IMAP server address (host:port, e.g. imap.example.com:993)
+	IMAPAddress string `mapstructure:"imapAddress" json:"imapAddress"`
+
+	// SMTP server address (host:port, e.g. smtp.example.com:587)
+	SMTPAddress string `mapstructure:"smtpAddress" json:"smtpAddress"`
+
+	// Username for IMAP and SMTP login
+	Username string `mapstructure:"username" json:"username"`
+
+	// Password for IMAP and SMTP login (supports ${ENV_VAR} substitution)
+	Password string `mapstructure:"password" json:"password"`
+
+	// Address mail is sent from (default: username)
+	Address string `mapstructure:"address" json:"address"`
+
+	// Mailbox to watch (default: INBOX)
+	Mailbox string `mapstructure:"mailbox" json:"mailbox"`
+
+	// Poll interval; 0 uses IMAP IDLE when the server supports it and
+	// polls every minute otherwise
+	PollInterval time.Duration `mapstructure:"pollInterval" json:"pollInterval"`
+
+	// Allowed sender addresses or domains ("@example.com"). Required:
+	// mail from any other sender is ignored.
+	Allowlist []string `mapstructure:"allowlist" json:"allowlist"`
+
+	// Authserv-id of the receiving mail server (e.g. mx.example.com).
+	// Required: mail is only accepted when that server's
+	// Authentication-Results header reports dmarc=pass, or dkim=pass for
+	// the From domain.
+	TrustedAuthServID string `mapstructure:"trustedAuthServId" json:"trustedAuthServId"`
+
+	// Insecure uses plain-text IMAP and SMTP without TLS (local servers only)
+	Insecure bool `mapstructure:"insecure" json:"insecure"`
+}
+
 // LoggingConfig defines logging settings
 type LoggingConfig struct {
 	// Log level (debug, info, warn, error)
@@ -382,6 +448,11 @@ type ExecToolConfig struct {
 	// AdditionalProtectedPaths specifies extra paths that the exec tool
 	// should block access to (in addition to the DataRoot).
 	AdditionalProtectedPaths []string `mapstructure:"additionalProtectedPaths" json:"additionalProtectedPaths,omitempty"`
+
+	// SecretEnv maps environment variable names to stored secret names.
+	// Each variable is injected into exec commands only when the secret's
+	// access policy allows the calling agent and the exec tool.
+	SecretEnv map[string]string `mapstructure:"secretEnv" json:"secretEnv,omitempty"`
 }
 
 // FilesystemToolConfig defines file access settings
@@ -481,16 +552,19 @@ type AlertingConfig struct {
 	// RecoveryRetries is the threshold for recovery retry events per session (default: 5)
 	RecoveryRetries int `mapstructure:"recoveryRetryThreshold" json:"recoveryRetryThreshold"`
 
-	// Delivery configures external alert delivery channels (webhook, etc.).
+	// Delivery configures external alert delivery channels (webhook, email).
 	Delivery []AlertDeliveryConfig `mapstructure:"delivery" json:"delivery,omitempty"`
 }
 
 // AlertDeliveryConfig configures a single alert delivery channel.
 type AlertDeliveryConfig struct {
-	// Type is the delivery channel type ("webhook").
+	// Type is the delivery channel type ("webhook", "email").
 	Type string `mapstructure:"type" json:"type"`
 	// WebhookURL is the target URL for webhook delivery.
 	WebhookURL string `mapstructure:"webhookURL" json:"webhookURL,omitempty"`
+	// To is the recipient address for email delivery (empty = first
+	// allowlisted address of the email channel).
+	To string `mapstructure:"to" json:"to,omitempty"`
 	// MinSeverity filters alerts below this level ("warning", "critical").
 	MinSeverity string `mapstructure:"minSeverity" json:"minSeverity,omitempty"`
 }
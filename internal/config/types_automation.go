@@ -111,6 +111,40 @@ type X402Config struct {
 
 	// MaxAutoPayAmount is the maximum amount to auto-pay for X402 challenges.
 	MaxAutoPayAmount string `mapstructure:"maxAutoPayAmount" json:"maxAutoPayAmount"`
+
+	// Seller charges X402 payments for this agent's own gateway and A2A routes.
+	Seller X402SellerConfig `mapstructure:"seller" json:"seller"`
+}
+
+// X402SellerConfig defines server-side X402 payment requirements.
+type X402SellerConfig struct {
+	// Enabled turns on the X402 seller middleware.
+	Enabled bool `mapstructure:"enabled" json:"enabled"`
+
+	// PayTo is the address that receives payments (default: the payment wallet address).
+	PayTo string `mapstructure:"payTo" json:"payTo,omitempty"`
+
+	// MaxTimeoutSeconds is how long a buyer's payment authorization must stay valid (default: 300).
+	MaxTimeoutSeconds int `mapstructure:"maxTimeoutSeconds" json:"maxTimeoutSeconds,omitempty"`
+
+	// Routes lists the paid endpoints.
+	Routes []X402RouteConfig `mapstructure:"routes" json:"routes"`
+}
+
+// X402RouteConfig defines a paid HTTP endpoint.
+type X402RouteConfig struct {
+	// Path is an exact request path, or a prefix when it ends in "*" (e.g. "/api/reports/*").
+	Path string `mapstructure:"path" json:"path"`
+
+	// Method restricts the route to one HTTP method (empty matches any method).
+	Method string `mapstructure:"method" json:"method,omitempty"`
+
+	// Price is the base price in USDC (e.g. "0.05"). Economy pricing rules
+	// matching the path adjust it.
+	Price string `mapstructure:"price" json:"price"`
+
+	// Description is shown to buyers in the payment requirements.
+	Description string `mapstructure:"description" json:"description,omitempty"`
 }
 
 // A2AConfig defines Agent-to-Agent protocol settings.
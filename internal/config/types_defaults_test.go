@@ -100,6 +100,10 @@ func TestDefaultConfig_MCP(t *testing.T) {
 	assert.Equal(t, 30*time.Second, cfg.MCP.HealthCheckInterval)
 	assert.True(t, cfg.MCP.AutoReconnect)
 	assert.Equal(t, 5, cfg.MCP.MaxReconnectAttempts)
+	assert.Contains(t, cfg.MCP.Serve.AllowedTools, "search_knowledge")
+	assert.True(t, cfg.MCP.Serve.Resources)
+	assert.Equal(t, "127.0.0.1:18791", cfg.MCP.Serve.HTTPAddr)
+	assert.Equal(t, 2000, cfg.MCP.PinnedResourceTokens)
 }
 
 func TestDefaultConfig_P2P(t *testing.T) {
@@ -115,8 +119,8 @@ func TestDefaultConfig_P2P(t *testing.T) {
 	assert.Equal(t, 50, cfg.P2P.MaxPeers)
 	assert.Equal(t, 30*time.Second, cfg.P2P.HandshakeTimeout)
 	assert.Equal(t, 24*time.Hour, cfg.P2P.SessionTokenTTL)
-	assert.True(t, cfg.P2P.ZKHandshake)
-	assert.True(t, cfg.P2P.ZKAttestation)
+	assert.False(t, cfg.P2P.ZKHandshake)
+	assert.False(t, cfg.P2P.ZKAttestation)
 	assert.Equal(t, "plonk", cfg.P2P.ZKP.ProvingScheme)
 }
 
@@ -244,6 +248,65 @@ func TestValidate_PortBoundaries(t *testing.T) {
 	}
 }
 
+func TestValidate_CockpitTokens(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give    CockpitTokenConfig
+		wantErr string
+	}{
+		{give: CockpitTokenConfig{Name: "alice", Token: "t1", Role: "operator"}},
+		{give: CockpitTokenConfig{Name: "bob", Token: "t2"}},
+		{give: CockpitTokenConfig{Name: "carol", Role: "viewer"}, wantErr: "token is required"},
+		{give: CockpitTokenConfig{Name: "dave", Token: "t3", Role: "admin"}, wantErr: "invalid role"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give.Name, func(t *testing.T) {
+			t.Parallel()
+			cfg := DefaultConfig()
+			cfg.Server.Cockpit.Tokens = []CockpitTokenConfig{tt.give}
+			err := Validate(cfg)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidate_X402SellerRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give    X402RouteConfig
+		wantErr string
+	}{
+		{give: X402RouteConfig{Path: "/api/reports/*", Price: "0.05"}},
+		{give: X402RouteConfig{Path: "api/reports", Price: "0.05"}, wantErr: "must start with /"},
+		{give: X402RouteConfig{Path: "/a2a", Price: "cheap"}, wantErr: "invalid price"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give.Path, func(t *testing.T) {
+			t.Parallel()
+			cfg := DefaultConfig()
+			cfg.Payment.Enabled = true
+			cfg.Payment.Network.RPCURL = "http://localhost:8545"
+			cfg.Payment.X402.Seller = X402SellerConfig{Enabled: true, Routes: []X402RouteConfig{tt.give}}
+			err := Validate(cfg)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 func TestValidate_SecuritySignerProviders(t *testing.T) {
 	t.Parallel()
 
@@ -340,6 +403,30 @@ func TestValidate_MCPServerTransports(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "not supported")
 	})
+
+	t.Run("oauth requires remote transport", func(t *testing.T) {
+		t.Parallel()
+		cfg := DefaultConfig()
+		cfg.MCP.Enabled = true
+		cfg.MCP.Servers = map[string]MCPServerConfig{
+			"test": {Transport: "stdio", Command: "srv", OAuth: &MCPOAuthConfig{}},
+		}
+		err := Validate(cfg)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "oauth requires http or sse transport")
+	})
+
+	t.Run("invalid tool safety override", func(t *testing.T) {
+		t.Parallel()
+		cfg := DefaultConfig()
+		cfg.MCP.Enabled = true
+		cfg.MCP.Servers = map[string]MCPServerConfig{
+			"test": {Transport: "http", URL: "https://x/mcp", ToolSafety: map[string]string{"list": "harmless"}},
+		}
+		err := Validate(cfg)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "toolSafety.list")
+	})
 }
 
 func TestApprovalPolicy_Valid(t *testing.T) {
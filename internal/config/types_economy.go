@@ -87,6 +87,21 @@ type EscrowConfig struct {
 
 	// OnChain configures the on-chain escrow hub/vault system.
 	OnChain EscrowOnChainConfig `mapstructure:"onChain" json:"onChain"`
+
+	// Arbitration configures third-party dispute arbitration over P2P.
+	Arbitration EscrowArbitrationConfig `mapstructure:"arbitration" json:"arbitration"`
+}
+
+// EscrowArbitrationConfig defines arbitrated dispute resolution settings.
+type EscrowArbitrationConfig struct {
+	// Enabled activates the arbitration protocol (requires P2P).
+	Enabled bool `mapstructure:"enabled" json:"enabled"`
+
+	// MinArbiterScore is the minimum local trust score for an arbiter (default: 0.7).
+	MinArbiterScore float64 `mapstructure:"minArbiterScore" json:"minArbiterScore"`
+
+	// MaxNominations is the number of arbiters proposed per nomination round (default: 3).
+	MaxNominations int `mapstructure:"maxNominations" json:"maxNominations"`
 }
 
 // EscrowOnChainConfig configures on-chain escrow contract integration.
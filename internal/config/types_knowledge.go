@@ -163,6 +163,17 @@ type SkillConfig struct {
 
 	// ImportTimeout is the overall timeout for skill import operations (default: 2m).
 	ImportTimeout time.Duration `mapstructure:"importTimeout" json:"importTimeout"`
+
+	// TrustedKeys are minisign or base64 ed25519 public keys trusted to sign
+	// skill packages installed with "lango skill install".
+	TrustedKeys []string `mapstructure:"trustedKeys" json:"trustedKeys,omitempty"`
+
+	// RequireSignature rejects skill packages not signed by a trusted key.
+	RequireSignature bool `mapstructure:"requireSignature" json:"requireSignature"`
+
+	// Index is the git source of the skill index searched by "lango skill search"
+	// (e.g. https://github.com/org/skills/tree/main or <git-url>//path#ref).
+	Index string `mapstructure:"index" json:"index,omitempty"`
 }
 
 // ProviderTypeToEmbeddingType maps a provider config type to the corresponding
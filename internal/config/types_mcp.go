@@ -24,6 +24,29 @@ type MCPConfig struct {
 
 	// MaxReconnectAttempts limits reconnection attempts (default: 5)
 	MaxReconnectAttempts int `mapstructure:"maxReconnectAttempts" json:"maxReconnectAttempts"`
+
+	// PinnedResourceTokens is the token budget shared by all pinned resources
+	// injected into the agent context (default: 2000).
+	PinnedResourceTokens int `mapstructure:"pinnedResourceTokens" json:"pinnedResourceTokens"`
+
+	// Serve configures `lango mcp serve`, which exposes Lango to MCP clients.
+	Serve MCPServeConfig `mapstructure:"serve" json:"serve"`
+}
+
+// MCPServeConfig defines what Lango publishes when acting as an MCP server.
+type MCPServeConfig struct {
+	// AllowedTools lists tool names or glob patterns (e.g. "skill_*") published
+	// as MCP tools. Tools not matched are never exposed.
+	AllowedTools []string `mapstructure:"allowedTools" json:"allowedTools"`
+
+	// Resources publishes sessions and knowledge entries as MCP resources (default: true).
+	Resources bool `mapstructure:"resources" json:"resources"`
+
+	// HTTPAddr is the listen address for the streamable HTTP transport (default: 127.0.0.1:18791).
+	HTTPAddr string `mapstructure:"httpAddr" json:"httpAddr"`
+
+	// AuthToken, when set, is required as a bearer token on HTTP requests.
+	AuthToken string `mapstructure:"authToken" json:"authToken"`
 }
 
 // MCPServerConfig defines a single MCP server connection.
@@ -54,6 +77,42 @@ type MCPServerConfig struct {
 
 	// SafetyLevel for tools from this server: "safe", "moderate", "dangerous" (default: "dangerous")
 	SafetyLevel string `mapstructure:"safetyLevel" json:"safetyLevel"`
+
+	// PinnedResources lists resource URIs from this server that are injected
+	// into the agent context every turn and kept fresh via subscriptions.
+	PinnedResources []string `mapstructure:"pinnedResources" json:"pinnedResources,omitempty"`
+
+	// IncludeTools lists tool names or glob patterns to expose from this
+	// server. When empty, all tools are exposed.
+	IncludeTools []string `mapstructure:"includeTools" json:"includeTools,omitempty"`
+
+	// ExcludeTools lists tool names or glob patterns to hide, applied after IncludeTools.
+	ExcludeTools []string `mapstructure:"excludeTools" json:"excludeTools,omitempty"`
+
+	// ToolSafety overrides SafetyLevel for individual tools, keyed by the
+	// server's tool name.
+	ToolSafety map[string]string `mapstructure:"toolSafety" json:"toolSafety,omitempty"`
+
+	// OAuth enables the MCP OAuth authorization flow for http/sse servers.
+	// Tokens are obtained with `lango mcp auth <name>` and kept in the secrets store.
+	OAuth *MCPOAuthConfig `mapstructure:"oauth" json:"oauth,omitempty"`
+}
+
+// MCPOAuthConfig configures OAuth authorization for a remote MCP server.
+type MCPOAuthConfig struct {
+	// ClientID of a pre-registered client. When empty, the client is
+	// registered dynamically with the authorization server.
+	ClientID string `mapstructure:"clientId" json:"clientId,omitempty"`
+
+	// ClientSecret of a pre-registered confidential client (supports ${VAR} expansion).
+	ClientSecret string `mapstructure:"clientSecret" json:"clientSecret,omitempty"`
+
+	// Scopes requested during authorization.
+	Scopes []string `mapstructure:"scopes" json:"scopes,omitempty"`
+
+	// CallbackPort is the local port for the authorization redirect (0 = any free port).
+	// Pre-registered clients usually need a fixed port matching their redirect URI.
+	CallbackPort int `mapstructure:"callbackPort" json:"callbackPort,omitempty"`
 }
 
 // IsEnabled returns whether the server is enabled (defaults to true when nil).
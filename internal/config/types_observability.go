@@ -24,6 +24,28 @@ type ObservabilityConfig struct {
 
 	// Tracing configures OpenTelemetry distributed tracing.
 	Tracing TracingConfig `mapstructure:"tracing" json:"tracing"`
+
+	// EventJournal configures the persistent event bus journal.
+	EventJournal EventJournalConfig `mapstructure:"eventJournal" json:"eventJournal"`
+}
+
+// EventJournalConfig defines the append-only journal of event bus events.
+// It is independent of Enabled so it can be turned on just for debugging.
+type EventJournalConfig struct {
+	// Enabled records every published event to the journal.
+	Enabled bool `mapstructure:"enabled" json:"enabled"`
+
+	// Dir is the journal directory (default: ~/.lango/events).
+	Dir string `mapstructure:"dir" json:"dir"`
+
+	// SegmentSizeMB is the size at which a new segment file starts (default: 16).
+	SegmentSizeMB int `mapstructure:"segmentSizeMB" json:"segmentSizeMB"`
+
+	// MaxSegments caps the number of segment files kept (0 = keep all).
+	MaxSegments int `mapstructure:"maxSegments" json:"maxSegments"`
+
+	// Sync fsyncs each event to disk before Publish returns.
+	Sync bool `mapstructure:"sync" json:"sync"`
 }
 
 // TracingConfig defines OpenTelemetry tracing settings.
@@ -33,6 +55,11 @@ type TracingConfig struct {
 
 	// Exporter selects the trace exporter: "stdout" (default) or "none".
 	Exporter string `mapstructure:"exporter" json:"exporter"`
+
+	// CaptureContent records prompts, completions and tool arguments on
+	// GenAI spans, passed through the PII redactor. Off by default; tool
+	// arguments are otherwise recorded only as a SHA-256 hash.
+	CaptureContent bool `mapstructure:"captureContent" json:"captureContent"`
 }
 
 // TokenTrackingConfig defines token usage tracking settings.
@@ -63,6 +90,16 @@ type AuditConfig struct {
 
 	// RetentionDays controls how long audit records are kept (default: 90).
 	RetentionDays int `mapstructure:"retentionDays" json:"retentionDays"`
+
+	// CheckpointInterval is how often the audit hash chain head is signed
+	// with the wallet identity key (default: 1h). Checkpoints are only
+	// written when a wallet is configured.
+	CheckpointInterval time.Duration `mapstructure:"checkpointInterval" json:"checkpointInterval"`
+
+	// CheckpointSigner is the DID trusted to sign checkpoints. `lango audit
+	// verify` rejects checkpoints signed by any other key. Defaults to the
+	// local wallet DID.
+	CheckpointSigner string `mapstructure:"checkpointSigner" json:"checkpointSigner,omitempty"`
 }
 
 // MetricsExportConfig defines metrics export settings.
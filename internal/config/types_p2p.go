@@ -43,6 +43,7 @@ type P2PConfig struct {
 	GossipInterval time.Duration `mapstructure:"gossipInterval" json:"gossipInterval"`
 
 	// ZKHandshake enables ZK-enhanced handshake instead of plain signature mode.
+	// Off by default; outside local chains it needs a ceremony SRS file.
 	ZKHandshake bool `mapstructure:"zkHandshake" json:"zkHandshake"`
 
 	// ZKAttestation enables ZK attestation proofs on responses to peers.
@@ -213,7 +214,8 @@ type ZKPConfig struct {
 	// ProvingScheme selects the ZKP proving scheme: "plonk" or "groth16".
 	ProvingScheme string `mapstructure:"provingScheme" json:"provingScheme"`
 
-	// SRSMode selects the SRS generation mode: "unsafe" (default) or "file".
+	// SRSMode selects the SRS generation mode: "unsafe" (default, local chains
+	// only) or "file".
 	SRSMode string `mapstructure:"srsMode" json:"srsMode"`
 
 	// SRSPath is the path to the SRS file (used when SRSMode == "file").
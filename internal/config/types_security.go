@@ -12,6 +12,76 @@ type SecurityConfig struct {
 	DBEncryption DBEncryptionConfig `mapstructure:"dbEncryption" json:"dbEncryption"`
 	// KMS configuration (Cloud KMS / HSM backends)
 	KMS KMSConfig `mapstructure:"kms" json:"kms"`
+	// Secrets configuration (expiry alerting)
+	Secrets SecretsConfig `mapstructure:"secrets" json:"secrets"`
+}
+
+// SecretsConfig defines stored-secret lifecycle settings.
+type SecretsConfig struct {
+	// ExpiryWarning is how far ahead of a secret's expiry an alert is raised (default: 168h).
+	ExpiryWarning time.Duration `mapstructure:"expiryWarning" json:"expiryWarning"`
+
+	// ExpiryCheckInterval is how often secret expiry is checked (default: 1h).
+	ExpiryCheckInterval time.Duration `mapstructure:"expiryCheckInterval" json:"expiryCheckInterval"`
+
+	// Backend selects an external secrets backend for {{secret:path#field}}
+	// references: "" or "local" (database only) or "vault".
+	Backend string `mapstructure:"backend" json:"backend,omitempty"`
+
+	// Vault holds HashiCorp Vault (KV v2) backend settings.
+	Vault VaultConfig `mapstructure:"vault" json:"vault"`
+
+	// RemotePaths allowlists the backend paths that agents, tools and MCP
+	// servers may reference, each with its own access policy. References to
+	// paths outside every entry are denied.
+	RemotePaths []RemoteSecretPathConfig `mapstructure:"remotePaths" json:"remotePaths,omitempty"`
+}
+
+// RemoteSecretPathConfig grants access to backend secrets under a path prefix.
+type RemoteSecretPathConfig struct {
+	// Prefix is the backend path, matched as a whole path segment
+	// ("apps" covers "apps/github" but not "apps-legacy").
+	Prefix string `mapstructure:"prefix" json:"prefix"`
+
+	// AllowedAgents, AllowedTools and AllowedMCPServers restrict callers
+	// exactly like a local secret's policy. Empty lists are unrestricted.
+	AllowedAgents     []string `mapstructure:"allowedAgents" json:"allowedAgents,omitempty"`
+	AllowedTools      []string `mapstructure:"allowedTools" json:"allowedTools,omitempty"`
+	AllowedMCPServers []string `mapstructure:"allowedMcpServers" json:"allowedMcpServers,omitempty"`
+}
+
+// VaultConfig defines HashiCorp Vault KV v2 secrets backend settings.
+type VaultConfig struct {
+	// Address is the Vault server URL (falls back to VAULT_ADDR).
+	Address string `mapstructure:"address" json:"address"`
+
+	// Namespace is the Vault Enterprise namespace (falls back to VAULT_NAMESPACE).
+	Namespace string `mapstructure:"namespace" json:"namespace,omitempty"`
+
+	// Mount is the KV v2 mount path (default: "secret").
+	Mount string `mapstructure:"mount" json:"mount"`
+
+	// AuthMethod is "token" or "approle" (default: "token").
+	AuthMethod string `mapstructure:"authMethod" json:"authMethod"`
+
+	// Token is the Vault token for token auth (prefer VAULT_TOKEN env var).
+	Token string `mapstructure:"token" json:"token,omitempty"`
+
+	// AppRoleMount is the AppRole auth mount path (default: "approle").
+	AppRoleMount string `mapstructure:"appRoleMount" json:"appRoleMount,omitempty"`
+
+	// RoleID is the AppRole role ID.
+	RoleID string `mapstructure:"roleId" json:"roleId,omitempty"`
+
+	// SecretID is the AppRole secret ID (prefer LANGO_VAULT_SECRET_ID env var).
+	SecretID string `mapstructure:"secretId" json:"secretId,omitempty"`
+
+	// CacheTTL is how long fetched secrets stay in the local encrypted cache
+	// (default: 5m, negative disables caching).
+	CacheTTL time.Duration `mapstructure:"cacheTtl" json:"cacheTtl"`
+
+	// Timeout is the maximum duration of a single Vault request (default: 10s).
+	Timeout time.Duration `mapstructure:"timeout" json:"timeout"`
 }
 
 // KMSConfig defines Cloud KMS and HSM backend settings.
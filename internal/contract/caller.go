@@ -234,6 +234,7 @@ func (c *Caller) Write(ctx context.Context, req ContractCallRequest) (*ContractC
 	return &ContractCallResult{
 		TxHash:  signedTx.Hash().Hex(),
 		GasUsed: receipt.GasUsed,
+		Logs:    receipt.Logs,
 	}, nil
 }
 
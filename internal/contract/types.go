@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // ContractCallRequest holds parameters for a contract call.
@@ -24,6 +25,8 @@ type ContractCallResult struct {
 	Data    []interface{} `json:"data"`
 	TxHash  string        `json:"txHash,omitempty"`
 	GasUsed uint64        `json:"gasUsed,omitempty"`
+	// Logs holds the receipt logs of a write, which carry its emitted events.
+	Logs []*types.Log `json:"-"`
 }
 
 // ParseABI parses a JSON ABI string into a go-ethereum ABI object.
@@ -6,8 +6,9 @@ import "time"
 type Job struct {
 	ID           string
 	Name         string
-	ScheduleType string // "at" | "every" | "cron"
+	ScheduleType string // "at" | "every" | "cron" | "trigger"
 	Schedule     string
+	Trigger      *Trigger // optional event trigger, fires alongside the schedule
 	Prompt       string
 	SessionMode  string // "isolated" | "main"
 	DeliverTo    []string
@@ -9,6 +9,8 @@ import (
 	"github.com/google/uuid"
 	robfigcron "github.com/robfig/cron/v3"
 	"go.uber.org/zap"
+
+	"github.com/langoai/lango/internal/eventbus"
 )
 
 // Scheduler manages cron job registration, lifecycle, and concurrent execution.
@@ -27,6 +29,12 @@ type Scheduler struct {
 	shutdownCh     chan struct{}
 	stopOnce       sync.Once
 	logger         *zap.SugaredLogger
+
+	bus       *eventbus.Bus
+	secrets   SecretStore
+	triggers  map[string]*activeTrigger // jobID -> registered trigger
+	eventSubs map[string]bool           // event names the dispatcher is subscribed to
+	triggerMu sync.RWMutex
 }
 
 // SchedulerConfig holds optional configuration for the Scheduler.
@@ -35,6 +43,13 @@ type SchedulerConfig struct {
 	MaxJobs        int
 	DefaultTimeout time.Duration
 	Logger         *zap.SugaredLogger
+	Bus            *eventbus.Bus // required for event triggers
+	Secrets        SecretStore   // required for webhook triggers with a SecretRef
+}
+
+// SecretStore reads webhook trigger secrets by name.
+type SecretStore interface {
+	Get(ctx context.Context, name string) ([]byte, error)
 }
 
 // New creates a new Scheduler.
@@ -63,6 +78,10 @@ func New(store Store, executor *Executor, cfg SchedulerConfig) *Scheduler {
 		timezone:       cfg.Timezone,
 		shutdownCh:     make(chan struct{}),
 		logger:         cfg.Logger,
+		bus:            cfg.Bus,
+		secrets:        cfg.Secrets,
+		triggers:       make(map[string]*activeTrigger),
+		eventSubs:      make(map[string]bool),
 	}
 }
 
@@ -131,6 +150,13 @@ func (s *Scheduler) Stop() {
 		s.entries = make(map[string]robfigcron.EntryID)
 		s.mu.Unlock()
 
+		s.triggerMu.Lock()
+		for id, at := range s.triggers {
+			at.stop()
+			delete(s.triggers, id)
+		}
+		s.triggerMu.Unlock()
+
 		s.logger.Info("cron scheduler stopped")
 	})
 }
@@ -237,10 +263,21 @@ func (s *Scheduler) AllHistory(ctx context.Context, limit int) ([]HistoryEntry,
 	return s.store.ListAllHistory(ctx, limit)
 }
 
-// registerJob adds a job to the internal cron scheduler based on its schedule type.
+// registerJob adds a job to the internal cron scheduler based on its schedule
+// type, and starts its event trigger if it has one.
 func (s *Scheduler) registerJob(job Job) error {
+	if job.Trigger != nil || job.ScheduleType == ScheduleTypeTrigger {
+		if err := s.startTrigger(job); err != nil {
+			return fmt.Errorf("start trigger for job %q: %w", job.Name, err)
+		}
+		if job.ScheduleType == ScheduleTypeTrigger {
+			return nil
+		}
+	}
+
 	spec, err := buildCronSpec(job)
 	if err != nil {
+		s.stopTrigger(job.ID)
 		return err
 	}
 
@@ -255,17 +292,18 @@ func (s *Scheduler) registerJob(job Job) error {
 		addFunc = func() {
 			once.Do(func() {
 				s.unregisterJob(j.ID)
-				s.executeWithSemaphore(j)
+				s.executeWithSemaphore(j, nil)
 			})
 		}
 	} else {
 		addFunc = func() {
-			s.executeWithSemaphore(j)
+			s.executeWithSemaphore(j, nil)
 		}
 	}
 
 	entryID, err := s.cron.AddFunc(spec, addFunc)
 	if err != nil {
+		s.stopTrigger(job.ID)
 		return fmt.Errorf("add cron entry for job %q: %w", job.Name, err)
 	}
 
@@ -276,8 +314,11 @@ func (s *Scheduler) registerJob(job Job) error {
 	return nil
 }
 
-// unregisterJob removes a job from the internal cron scheduler.
+// unregisterJob removes a job from the internal cron scheduler and stops its
+// trigger.
 func (s *Scheduler) unregisterJob(id string) {
+	s.stopTrigger(id)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -316,7 +357,8 @@ func (s *Scheduler) ResolveJobID(ctx context.Context, nameOrID string) (string,
 }
 
 // executeWithSemaphore runs a job while respecting the concurrency limit.
-func (s *Scheduler) executeWithSemaphore(job Job) {
+// ev is the trigger firing that started the run, or nil for scheduled runs.
+func (s *Scheduler) executeWithSemaphore(job Job, ev *TriggerEvent) {
 	// Context-aware semaphore acquisition: abort if shutting down.
 	select {
 	case s.semaphore <- struct{}{}:
@@ -343,10 +385,13 @@ func (s *Scheduler) executeWithSemaphore(job Job) {
 		s.inFlightMu.Unlock()
 	}()
 
+	if ev != nil {
+		job.Prompt = InterpolatePrompt(job.Prompt, *ev)
+	}
 	s.executor.Execute(ctx, job)
 
-	// For "at" (one-time) jobs, disable after execution.
-	if job.ScheduleType == "at" {
+	// For "at" (one-time) jobs, disable after the scheduled execution.
+	if job.ScheduleType == "at" && ev == nil {
 		s.disableOneTimeJob(ctx, job)
 	}
 }
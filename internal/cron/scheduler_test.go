@@ -502,7 +502,7 @@ func TestScheduler_ExecuteWithSemaphore_UsesDefaultTimeout(t *testing.T) {
 		ScheduleType: "every",
 		Schedule:     "1h",
 		Prompt:       "test",
-	})
+	}, nil)
 
 	assert.Equal(t, 1, runner.callCount())
 }
@@ -525,7 +525,7 @@ func TestScheduler_ExecuteWithSemaphore_UsesJobTimeout(t *testing.T) {
 		Schedule:     "1h",
 		Prompt:       "test",
 		Timeout:      10 * time.Second,
-	})
+	}, nil)
 
 	assert.Equal(t, 1, runner.callCount())
 }
@@ -558,7 +558,7 @@ func TestScheduler_ExecuteWithSemaphore_ShutdownAbortsAcquisition(t *testing.T)
 			ID:     "blocked",
 			Name:   "blocked-job",
 			Prompt: "test",
-		})
+		}, nil)
 		executed.Store(true)
 	}()
 
@@ -2,6 +2,7 @@ package cron
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -52,6 +53,14 @@ func (s *EntStore) Create(ctx context.Context, job Job) error {
 		builder.SetDeliverTo(job.DeliverTo)
 	}
 
+	if job.Trigger != nil {
+		spec, err := json.Marshal(job.Trigger)
+		if err != nil {
+			return fmt.Errorf("encode trigger for job %q: %w", job.Name, err)
+		}
+		builder.SetTrigger(string(spec))
+	}
+
 	if job.Timeout > 0 {
 		builder.SetTimeoutMs(job.Timeout.Milliseconds())
 	}
@@ -154,6 +163,16 @@ func (s *EntStore) Update(ctx context.Context, job Job) error {
 		builder.ClearDeliverTo()
 	}
 
+	if job.Trigger != nil {
+		spec, err := json.Marshal(job.Trigger)
+		if err != nil {
+			return fmt.Errorf("encode trigger for job %q: %w", job.Name, err)
+		}
+		builder.SetTrigger(string(spec))
+	} else {
+		builder.ClearTrigger()
+	}
+
 	if job.Timeout > 0 {
 		builder.SetTimeoutMs(job.Timeout.Milliseconds())
 	} else {
@@ -312,6 +331,14 @@ func entCronJobToDomain(e *ent.CronJob) Job {
 		j.DeliverTo = dt
 	}
 
+	// A malformed spec leaves Trigger nil; registration then reports it.
+	if e.Trigger != "" {
+		var t Trigger
+		if err := json.Unmarshal([]byte(e.Trigger), &t); err == nil {
+			j.Trigger = &t
+		}
+	}
+
 	if e.LastRunAt != nil {
 		t := *e.LastRunAt
 		j.LastRunAt = &t
@@ -29,7 +29,7 @@ func BuildTools(scheduler *Scheduler, defaultDeliverTo []string) []*agent.Tool {
 					"schedule":      map[string]interface{}{"type": "string", "description": "Schedule value: crontab expr for cron, Go duration for every (e.g. 1h30m), RFC3339 datetime for at"},
 					"prompt":        map[string]interface{}{"type": "string", "description": "The prompt to execute on each run"},
 					"session_mode":  map[string]interface{}{"type": "string", "description": "Session mode: isolated (new session each run) or main (shared session)", "enum": []string{"isolated", "main"}},
-					"deliver_to":    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "Channels to deliver results to (e.g. telegram:CHAT_ID, discord:CHANNEL_ID, slack:CHANNEL_ID)"},
+					"deliver_to":    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "Channels to deliver results to (e.g. telegram:CHAT_ID, discord:CHANNEL_ID, slack:CHANNEL_ID, email:ADDRESS)"},
 					"timeout":       map[string]interface{}{"type": "string", "description": "Per-job timeout as Go duration (e.g. 10m, 1h). Overrides the default job timeout."},
 				},
 				"required": []string{"name", "schedule_type", "schedule", "prompt"},
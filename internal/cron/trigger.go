@@ -0,0 +1,255 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TriggerKind identifies what fires an event-driven job.
+type TriggerKind string
+
+const (
+	TriggerKindFile    TriggerKind = "file"    // fsnotify watch on a path glob
+	TriggerKindWebhook TriggerKind = "webhook" // HMAC-signed POST to the gateway
+	TriggerKindEvent   TriggerKind = "event"   // event bus subscription
+)
+
+// ScheduleTypeTrigger marks a job that has no time schedule and only runs
+// when its trigger fires.
+const ScheduleTypeTrigger = "trigger"
+
+// Trigger defaults.
+const (
+	DefaultTriggerDebounce    = 500 * time.Millisecond
+	DefaultTriggerRateLimit   = 6 // fires per minute
+	DefaultTriggerDedupWindow = time.Minute
+)
+
+// Webhook request headers. The signature is "sha256=<hex HMAC-SHA256>" over
+// "<timestamp>.<delivery>.<body>", so the timestamp and delivery ID cannot be
+// changed without the secret.
+const (
+	WebhookSignatureHeader = "X-Lango-Signature"
+	WebhookTimestampHeader = "X-Lango-Timestamp" // unix seconds
+	WebhookDeliveryHeader  = "X-Lango-Delivery"  // unique per delivery, used for deduplication
+)
+
+// WebhookTimestampTolerance is how far a webhook timestamp may drift from
+// the server clock. Delivery IDs are remembered for twice as long, so a
+// captured request cannot be replayed while its timestamp is still valid.
+const WebhookTimestampTolerance = 5 * time.Minute
+
+// WebhookSecretPrefix prefixes the secrets store name of a webhook
+// trigger's HMAC key; the job ID completes it.
+const WebhookSecretPrefix = "cron.webhook."
+
+// Trigger fires a job in response to an external event rather than, or in
+// addition to, its time schedule.
+type Trigger struct {
+	Kind TriggerKind `json:"kind"`
+
+	// Path is the glob watched by file triggers, e.g. "/data/inbox/*.csv".
+	// Wildcards are allowed in the final path element only.
+	Path string `json:"path,omitempty"`
+	// Debounce is how long a file must be quiet before the trigger fires.
+	Debounce time.Duration `json:"debounce,omitempty"`
+
+	// SecretRef names the secrets store entry holding the HMAC-SHA256 key
+	// webhook requests are signed with.
+	SecretRef string `json:"secretRef,omitempty"`
+	// Secret is the plaintext HMAC key of jobs created before SecretRef.
+	// It is still honored but no longer written.
+	Secret string `json:"secret,omitempty"`
+
+	// Events are the event bus names an event trigger subscribes to.
+	Events []string `json:"events,omitempty"`
+	// Match filters events by field: every key (a dotted path into the
+	// event) must equal its value.
+	Match map[string]string `json:"match,omitempty"`
+
+	// RateLimit caps fires per minute (0 = DefaultTriggerRateLimit).
+	RateLimit int `json:"rateLimit,omitempty"`
+	// DedupWindow drops identical payloads seen within the window
+	// (0 = DefaultTriggerDedupWindow). Webhook delivery IDs are kept for at
+	// least twice WebhookTimestampTolerance.
+	DedupWindow time.Duration `json:"dedupWindow,omitempty"`
+}
+
+// Validate checks that the trigger is complete for its kind.
+func (t *Trigger) Validate() error {
+	switch t.Kind {
+	case TriggerKindFile:
+		if t.Path == "" {
+			return errors.New("file trigger requires a path")
+		}
+		if strings.ContainsAny(filepath.Dir(t.Path), "*?[") {
+			return fmt.Errorf("file trigger %q: wildcards are only allowed in the last path element", t.Path)
+		}
+		if _, err := filepath.Match(t.Path, ""); err != nil {
+			return fmt.Errorf("file trigger %q: %w", t.Path, err)
+		}
+	case TriggerKindWebhook:
+		if t.Secret == "" && t.SecretRef == "" {
+			return errors.New("webhook trigger requires a secret")
+		}
+	case TriggerKindEvent:
+		if len(t.Events) == 0 {
+			return errors.New("event trigger requires at least one event name")
+		}
+	default:
+		return fmt.Errorf("unknown trigger kind %q", t.Kind)
+	}
+	if t.RateLimit < 0 || t.Debounce < 0 || t.DedupWindow < 0 {
+		return errors.New("trigger limits must not be negative")
+	}
+	return nil
+}
+
+// Summary describes the trigger for listings; it is stored as the schedule
+// of trigger-only jobs.
+func (t *Trigger) Summary() string {
+	switch t.Kind {
+	case TriggerKindFile:
+		return "file:" + t.Path
+	case TriggerKindWebhook:
+		return "webhook"
+	case TriggerKindEvent:
+		s := "event:" + strings.Join(t.Events, ",")
+		if len(t.Match) > 0 {
+			keys := make([]string, 0, len(t.Match))
+			for k := range t.Match {
+				keys = append(keys, k+"="+t.Match[k])
+			}
+			sort.Strings(keys)
+			s += "[" + strings.Join(keys, ",") + "]"
+		}
+		return s
+	default:
+		return string(t.Kind)
+	}
+}
+
+func (t *Trigger) debounce() time.Duration {
+	if t.Debounce > 0 {
+		return t.Debounce
+	}
+	return DefaultTriggerDebounce
+}
+
+func (t *Trigger) rateLimit() int {
+	if t.RateLimit > 0 {
+		return t.RateLimit
+	}
+	return DefaultTriggerRateLimit
+}
+
+func (t *Trigger) dedupWindow() time.Duration {
+	window := DefaultTriggerDedupWindow
+	if t.DedupWindow > 0 {
+		window = t.DedupWindow
+	}
+	if t.Kind == TriggerKindWebhook {
+		window = max(window, 2*WebhookTimestampTolerance)
+	}
+	return window
+}
+
+// TriggerEvent is one firing of a trigger.
+type TriggerEvent struct {
+	Kind    TriggerKind
+	Payload map[string]interface{}
+	Time    time.Time
+}
+
+// payloadPlaceholder matches {{payload}} and {{payload.some.field}}.
+var payloadPlaceholder = regexp.MustCompile(`\{\{\s*payload((?:\.[A-Za-z0-9_-]+)*)\s*\}\}`)
+
+// InterpolatePrompt fills the trigger payload into a job prompt.
+// {{payload}} becomes the whole payload as JSON and {{payload.a.b}} a single
+// field; {{trigger}} becomes the trigger kind. A prompt without payload
+// placeholders gets the payload appended so the agent always sees it.
+func InterpolatePrompt(prompt string, ev TriggerEvent) string {
+	prompt = strings.ReplaceAll(prompt, "{{trigger}}", string(ev.Kind))
+	if !payloadPlaceholder.MatchString(prompt) {
+		return prompt + "\n\n[Trigger: " + string(ev.Kind) + "]\n" + formatPayloadValue(ev.Payload)
+	}
+	return payloadPlaceholder.ReplaceAllStringFunc(prompt, func(m string) string {
+		path := payloadPlaceholder.FindStringSubmatch(m)[1]
+		if path == "" {
+			return formatPayloadValue(ev.Payload)
+		}
+		v, ok := lookupPath(ev.Payload, strings.Split(path[1:], "."))
+		if !ok {
+			return ""
+		}
+		return formatPayloadValue(v)
+	})
+}
+
+// formatPayloadValue renders strings as-is and anything else as JSON.
+func formatPayloadValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// lookupPath walks a dotted path through nested maps. Keys match exactly
+// first, then case-insensitively, so "dealId" finds a DealID field.
+func lookupPath(v interface{}, path []string) (interface{}, bool) {
+	for _, key := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		next, ok := m[key]
+		if !ok {
+			for k, val := range m {
+				if strings.EqualFold(k, key) {
+					next, ok = val, true
+					break
+				}
+			}
+		}
+		if !ok {
+			return nil, false
+		}
+		v = next
+	}
+	return v, true
+}
+
+// matchFields reports whether payload satisfies every key=value filter.
+func matchFields(payload map[string]interface{}, match map[string]string) bool {
+	for key, want := range match {
+		got, ok := lookupPath(payload, strings.Split(key, "."))
+		if !ok || fmt.Sprint(got) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// toPayload converts an arbitrary value (typically an event struct) into a
+// JSON-shaped map.
+func toPayload(v interface{}) map[string]interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return map[string]interface{}{"value": fmt.Sprint(v)}
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return map[string]interface{}{"value": json.RawMessage(data)}
+	}
+	return m
+}
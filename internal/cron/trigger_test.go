@@ -0,0 +1,119 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrigger_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give    Trigger
+		wantErr string
+	}{
+		{give: Trigger{Kind: TriggerKindFile, Path: "/data/inbox/*.csv"}},
+		{give: Trigger{Kind: TriggerKindFile}, wantErr: "requires a path"},
+		{give: Trigger{Kind: TriggerKindFile, Path: "/data/*/in.csv"}, wantErr: "last path element"},
+		{give: Trigger{Kind: TriggerKindFile, Path: "/data/[.csv"}, wantErr: "syntax error"},
+		{give: Trigger{Kind: TriggerKindWebhook, SecretRef: "cron.webhook.j1"}},
+		{give: Trigger{Kind: TriggerKindWebhook, Secret: "s3cret"}},
+		{give: Trigger{Kind: TriggerKindWebhook}, wantErr: "requires a secret"},
+		{give: Trigger{Kind: TriggerKindEvent, Events: []string{"content.saved"}}},
+		{give: Trigger{Kind: TriggerKindEvent}, wantErr: "at least one event"},
+		{give: Trigger{Kind: TriggerKindEvent, Events: []string{"x"}, RateLimit: -1}, wantErr: "negative"},
+		{give: Trigger{Kind: "cron"}, wantErr: "unknown trigger kind"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.give.Kind)+"/"+tt.wantErr, func(t *testing.T) {
+			t.Parallel()
+			err := tt.give.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestTrigger_Summary(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "file:/tmp/*.txt", (&Trigger{Kind: TriggerKindFile, Path: "/tmp/*.txt"}).Summary())
+	assert.Equal(t, "webhook", (&Trigger{Kind: TriggerKindWebhook, Secret: "x"}).Summary())
+	assert.Equal(t, "event:a.b,c.d[x=1,y=2]", (&Trigger{
+		Kind:   TriggerKindEvent,
+		Events: []string{"a.b", "c.d"},
+		Match:  map[string]string{"y": "2", "x": "1"},
+	}).Summary())
+}
+
+func TestTrigger_Defaults(t *testing.T) {
+	t.Parallel()
+
+	var tr Trigger
+	assert.Equal(t, DefaultTriggerDebounce, tr.debounce())
+	assert.Equal(t, DefaultTriggerRateLimit, tr.rateLimit())
+	assert.Equal(t, DefaultTriggerDedupWindow, tr.dedupWindow())
+
+	tr = Trigger{Debounce: time.Second, RateLimit: 2, DedupWindow: time.Hour}
+	assert.Equal(t, time.Second, tr.debounce())
+	assert.Equal(t, 2, tr.rateLimit())
+	assert.Equal(t, time.Hour, tr.dedupWindow())
+
+	// Webhook delivery IDs outlive the timestamp tolerance.
+	tr = Trigger{Kind: TriggerKindWebhook}
+	assert.Equal(t, 2*WebhookTimestampTolerance, tr.dedupWindow())
+}
+
+func TestInterpolatePrompt(t *testing.T) {
+	t.Parallel()
+
+	ev := TriggerEvent{
+		Kind: TriggerKindWebhook,
+		Payload: map[string]interface{}{
+			"ref":  "main",
+			"repo": map[string]interface{}{"name": "lango"},
+		},
+	}
+
+	tests := []struct {
+		give string
+		want string
+	}{
+		{give: "Deploy {{payload.ref}} of {{ payload.repo.name }}", want: "Deploy main of lango"},
+		{give: "Missing: [{{payload.nope}}]", want: "Missing: []"},
+		{give: "Via {{trigger}}: {{payload.REF}}", want: "Via webhook: main"},
+		{give: "All: {{payload}}", want: "All: {\n  \"ref\": \"main\",\n  \"repo\": {\n    \"name\": \"lango\"\n  }\n}"},
+		{give: "Summarize", want: "Summarize\n\n[Trigger: webhook]\n{\n  \"ref\": \"main\",\n  \"repo\": {\n    \"name\": \"lango\"\n  }\n}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, InterpolatePrompt(tt.give, ev))
+		})
+	}
+}
+
+func TestMatchFields(t *testing.T) {
+	t.Parallel()
+
+	payload := map[string]interface{}{
+		"Status": "released",
+		"Amount": float64(42),
+		"Deal":   map[string]interface{}{"ID": "d-1"},
+	}
+
+	assert.True(t, matchFields(payload, nil))
+	assert.True(t, matchFields(payload, map[string]string{"status": "released"}))
+	assert.True(t, matchFields(payload, map[string]string{"amount": "42", "deal.id": "d-1"}))
+	assert.False(t, matchFields(payload, map[string]string{"status": "pending"}))
+	assert.False(t, matchFields(payload, map[string]string{"missing": "x"}))
+}
@@ -0,0 +1,438 @@
+package cron
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/time/rate"
+
+	"github.com/langoai/lango/internal/eventbus"
+)
+
+// WebhookRoute is the gateway route webhook triggers are served on.
+const WebhookRoute = "/cron/webhook/{name}"
+
+// maxWebhookBody caps the size of an inbound webhook body.
+const maxWebhookBody = 1 << 20
+
+// fireResult is the outcome of offering a payload to a trigger.
+type fireResult int
+
+const (
+	fireAccepted fireResult = iota
+	fireDuplicate
+	fireRateLimited
+)
+
+// activeTrigger is a registered trigger for one job, with its per-trigger
+// rate limiter and dedup memory.
+type activeTrigger struct {
+	job     Job
+	secret  string // resolved webhook HMAC key
+	limiter *rate.Limiter
+	stop    func()
+
+	mu   sync.Mutex
+	seen map[string]time.Time // payload key -> last accepted
+}
+
+func newActiveTrigger(job Job) *activeTrigger {
+	limit := job.Trigger.rateLimit()
+	return &activeTrigger{
+		job:     job,
+		limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(limit)), limit),
+		stop:    func() {},
+		seen:    make(map[string]time.Time),
+	}
+}
+
+// admit applies dedup, then rate limiting, to a payload identified by key.
+func (t *activeTrigger) admit(key string, now time.Time) fireResult {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	window := t.job.Trigger.dedupWindow()
+	for k, at := range t.seen {
+		if now.Sub(at) >= window {
+			delete(t.seen, k)
+		}
+	}
+	if _, ok := t.seen[key]; ok {
+		return fireDuplicate
+	}
+	if !t.limiter.AllowN(now, 1) {
+		return fireRateLimited
+	}
+	t.seen[key] = now
+	return fireAccepted
+}
+
+// payloadKey identifies a payload for deduplication.
+func payloadKey(payload map[string]interface{}) string {
+	data, _ := json.Marshal(payload) // map keys are marshaled sorted
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// startTrigger registers job's trigger and starts its event source.
+func (s *Scheduler) startTrigger(job Job) error {
+	if job.Trigger == nil {
+		return fmt.Errorf("job %q has no trigger spec", job.Name)
+	}
+	if err := job.Trigger.Validate(); err != nil {
+		return err
+	}
+
+	at := newActiveTrigger(job)
+	switch job.Trigger.Kind {
+	case TriggerKindFile:
+		stop, err := s.watchFiles(at)
+		if err != nil {
+			return err
+		}
+		at.stop = stop
+	case TriggerKindWebhook:
+		// Served by RegisterWebhookRoutes; only the secret is needed.
+		secret, err := s.webhookSecret(job.Trigger)
+		if err != nil {
+			return err
+		}
+		at.secret = secret
+	case TriggerKindEvent:
+		if s.bus == nil {
+			return errors.New("event trigger requires an event bus")
+		}
+		s.subscribeEvents(job.Trigger.Events)
+	}
+
+	s.triggerMu.Lock()
+	prev := s.triggers[job.ID]
+	s.triggers[job.ID] = at
+	s.triggerMu.Unlock()
+	if prev != nil {
+		prev.stop()
+	}
+	return nil
+}
+
+// webhookSecret resolves a webhook trigger's HMAC key from the secrets store,
+// falling back to the plaintext secret of jobs that predate SecretRef.
+func (s *Scheduler) webhookSecret(t *Trigger) (string, error) {
+	if t.SecretRef == "" {
+		return t.Secret, nil
+	}
+	if s.secrets == nil {
+		return "", fmt.Errorf("webhook secret %q: no secrets store configured", t.SecretRef)
+	}
+	secret, err := s.secrets.Get(context.Background(), t.SecretRef)
+	if err != nil {
+		return "", fmt.Errorf("webhook secret %q: %w", t.SecretRef, err)
+	}
+	return string(secret), nil
+}
+
+// stopTrigger unregisters a job's trigger and stops its event source.
+func (s *Scheduler) stopTrigger(id string) {
+	s.triggerMu.Lock()
+	at, ok := s.triggers[id]
+	delete(s.triggers, id)
+	s.triggerMu.Unlock()
+	if ok {
+		at.stop()
+	}
+}
+
+// fire offers a payload to a trigger and, if admitted, runs the job.
+func (s *Scheduler) fire(at *activeTrigger, key string, payload map[string]interface{}) fireResult {
+	res := at.admit(key, time.Now())
+	switch res {
+	case fireDuplicate:
+		s.logger.Debugw("cron trigger deduplicated", "job", at.job.Name, "kind", at.job.Trigger.Kind)
+		return res
+	case fireRateLimited:
+		s.logger.Warnw("cron trigger rate limited", "job", at.job.Name, "kind", at.job.Trigger.Kind)
+		return res
+	}
+
+	s.logger.Infow("cron trigger fired", "job", at.job.Name, "kind", at.job.Trigger.Kind)
+	ev := &TriggerEvent{Kind: at.job.Trigger.Kind, Payload: payload, Time: time.Now()}
+	go s.executeWithSemaphore(at.job, ev)
+	return res
+}
+
+// watchFiles starts an fsnotify watch on the directory of the trigger glob.
+// Each matching path fires once it has been quiet for the debounce period.
+func (s *Scheduler) watchFiles(at *activeTrigger) (func(), error) {
+	pattern := at.job.Trigger.Path
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create file watcher: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(pattern)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("watch %q: %w", filepath.Dir(pattern), err)
+	}
+
+	var (
+		mu      sync.Mutex
+		pending = make(map[string]*time.Timer)
+		done    = make(chan struct{})
+	)
+	debounce := at.job.Trigger.debounce()
+
+	go func() {
+		for {
+			select {
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if ev.Op == fsnotify.Chmod {
+					continue
+				}
+				if ok, _ := filepath.Match(pattern, ev.Name); !ok {
+					continue
+				}
+				op := fileOp(ev.Op)
+				mu.Lock()
+				if t, ok := pending[ev.Name]; ok {
+					t.Stop()
+				}
+				path := ev.Name
+				pending[path] = time.AfterFunc(debounce, func() {
+					mu.Lock()
+					delete(pending, path)
+					mu.Unlock()
+					select {
+					case <-done:
+						return
+					default:
+					}
+					payload := filePayload(path, op)
+					s.fire(at, payloadKey(payload), payload)
+				})
+				mu.Unlock()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				s.logger.Warnw("cron file trigger watch error", "job", at.job.Name, "error", err)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		watcher.Close()
+		mu.Lock()
+		for _, t := range pending {
+			t.Stop()
+		}
+		mu.Unlock()
+	}, nil
+}
+
+// fileOp names the most significant operation in an fsnotify event.
+func fileOp(op fsnotify.Op) string {
+	switch {
+	case op.Has(fsnotify.Create):
+		return "create"
+	case op.Has(fsnotify.Remove):
+		return "remove"
+	case op.Has(fsnotify.Rename):
+		return "rename"
+	default:
+		return "write"
+	}
+}
+
+// filePayload describes a changed file. Size and modification time make
+// successive writes to the same file distinct for deduplication.
+func filePayload(path, op string) map[string]interface{} {
+	payload := map[string]interface{}{
+		"path": path,
+		"name": filepath.Base(path),
+		"op":   op,
+	}
+	if info, err := os.Stat(path); err == nil {
+		payload["size"] = info.Size()
+		payload["modTime"] = info.ModTime().UTC().Format(time.RFC3339Nano)
+	} else if op != "remove" && op != "rename" {
+		payload["op"] = "remove"
+	}
+	return payload
+}
+
+// subscribeEvents subscribes the scheduler's dispatcher to event names it is
+// not yet listening to. The subscription outlives individual jobs; the
+// dispatcher routes each event to whichever triggers are registered.
+func (s *Scheduler) subscribeEvents(names []string) {
+	s.triggerMu.Lock()
+	defer s.triggerMu.Unlock()
+	for _, name := range names {
+		if s.eventSubs[name] {
+			continue
+		}
+		s.eventSubs[name] = true
+		eventName := name
+		s.bus.Subscribe(eventName, func(ev eventbus.Event) {
+			s.dispatchEvent(eventName, ev)
+		})
+	}
+}
+
+// dispatchEvent fires every event trigger listening to name whose field
+// filter matches the event.
+func (s *Scheduler) dispatchEvent(name string, ev eventbus.Event) {
+	s.triggerMu.RLock()
+	var targets []*activeTrigger
+	for _, at := range s.triggers {
+		if at.job.Trigger.Kind != TriggerKindEvent {
+			continue
+		}
+		for _, n := range at.job.Trigger.Events {
+			if n == name {
+				targets = append(targets, at)
+				break
+			}
+		}
+	}
+	s.triggerMu.RUnlock()
+	if len(targets) == 0 {
+		return
+	}
+
+	payload := toPayload(ev)
+	payload["event"] = name
+	key := payloadKey(payload)
+	for _, at := range targets {
+		if matchFields(payload, at.job.Trigger.Match) {
+			s.fire(at, key, payload)
+		}
+	}
+}
+
+// RegisterWebhookRoutes mounts the webhook trigger endpoint on the gateway
+// router. Requests authenticate with an HMAC signature rather than gateway
+// auth, so the route sits outside the protected group.
+func (s *Scheduler) RegisterWebhookRoutes(r chi.Router) {
+	r.Post(WebhookRoute, s.handleWebhook)
+	s.logger.Infow("cron webhook route registered", "route", WebhookRoute)
+}
+
+// handleWebhook verifies and fires a webhook trigger addressed by job name.
+func (s *Scheduler) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	at := s.webhookTrigger(name)
+	if at == nil {
+		http.Error(w, "unknown webhook", http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody+1))
+	if err != nil {
+		http.Error(w, "read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxWebhookBody {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	timestamp := r.Header.Get(WebhookTimestampHeader)
+	delivery := r.Header.Get(WebhookDeliveryHeader)
+	if !validDeliveryID.MatchString(delivery) {
+		http.Error(w, "missing or invalid "+WebhookDeliveryHeader, http.StatusBadRequest)
+		return
+	}
+	if !VerifyWebhookSignature(at.secret, timestamp, delivery, body, r.Header.Get(WebhookSignatureHeader)) {
+		s.logger.Warnw("cron webhook signature rejected", "job", name, "remote", r.RemoteAddr)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	if err := checkWebhookTimestamp(timestamp, time.Now()); err != nil {
+		s.logger.Warnw("cron webhook timestamp rejected", "job", name, "remote", r.RemoteAddr, "error", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil || payload == nil {
+		payload = map[string]interface{}{"body": string(body)}
+	}
+
+	// Deduplicate on the signed delivery ID: a replay within the timestamp
+	// tolerance is a duplicate, and outside it the timestamp check fails.
+	status, result := http.StatusAccepted, "accepted"
+	switch s.fire(at, delivery, payload) {
+	case fireDuplicate:
+		status, result = http.StatusOK, "duplicate"
+	case fireRateLimited:
+		status, result = http.StatusTooManyRequests, "rate_limited"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": result})
+}
+
+// webhookTrigger returns the registered webhook trigger for a job name.
+func (s *Scheduler) webhookTrigger(name string) *activeTrigger {
+	s.triggerMu.RLock()
+	defer s.triggerMu.RUnlock()
+	for _, at := range s.triggers {
+		if at.job.Name == name && at.job.Trigger.Kind == TriggerKindWebhook {
+			return at
+		}
+	}
+	return nil
+}
+
+// validDeliveryID restricts delivery IDs to characters that cannot collide
+// with the "." separators of the signed message.
+var validDeliveryID = regexp.MustCompile(`^[A-Za-z0-9_:-]{1,128}$`)
+
+// SignWebhook returns the signature header value for a delivery under secret.
+func SignWebhook(secret, timestamp, delivery string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + delivery + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature checks a signature header value in constant time.
+func VerifyWebhookSignature(secret, timestamp, delivery string, body []byte, signature string) bool {
+	if secret == "" || !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(SignWebhook(secret, timestamp, delivery, body)))
+}
+
+// checkWebhookTimestamp rejects a unix-seconds timestamp more than
+// WebhookTimestampTolerance away from now.
+func checkWebhookTimestamp(timestamp string, now time.Time) error {
+	secs, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s", WebhookTimestampHeader)
+	}
+	skew := now.Sub(time.Unix(secs, 0))
+	if skew > WebhookTimestampTolerance || skew < -WebhookTimestampTolerance {
+		return fmt.Errorf("%s outside the %s tolerance", WebhookTimestampHeader, WebhookTimestampTolerance)
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package cron
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/langoai/lango/internal/eventbus"
+)
+
+type testDealEvent struct {
+	DealID string
+	Status string
+}
+
+func (testDealEvent) EventName() string { return "deal.updated" }
+
+func (m *mockAgentRunner) prompts() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+// mapSecrets is an in-memory SecretStore.
+type mapSecrets map[string]string
+
+func (m mapSecrets) Get(_ context.Context, name string) ([]byte, error) {
+	v, ok := m[name]
+	if !ok {
+		return nil, errors.New("secret not found")
+	}
+	return []byte(v), nil
+}
+
+func newTriggerScheduler(t *testing.T, runner *mockAgentRunner, bus *eventbus.Bus) *Scheduler {
+	t.Helper()
+	logger := zap.NewNop().Sugar()
+	store := newMockStore()
+	s := New(store, NewExecutor(runner, nil, store, logger), SchedulerConfig{
+		Timezone:       "UTC",
+		DefaultTimeout: 5 * time.Second,
+		Logger:         logger,
+		Bus:            bus,
+		Secrets:        mapSecrets{"cron.webhook.deploy": "s3cret"},
+	})
+	require.NoError(t, s.Start(context.Background()))
+	t.Cleanup(s.Stop)
+	return s
+}
+
+func triggerJob(name string, tr *Trigger) Job {
+	return Job{
+		Name:         name,
+		ScheduleType: ScheduleTypeTrigger,
+		Schedule:     tr.Summary(),
+		Prompt:       "Handle {{payload}}",
+		SessionMode:  "isolated",
+		Trigger:      tr,
+		Enabled:      true,
+	}
+}
+
+func TestActiveTrigger_Admit(t *testing.T) {
+	t.Parallel()
+
+	at := newActiveTrigger(Job{Trigger: &Trigger{
+		Kind:        TriggerKindEvent,
+		Events:      []string{"x"},
+		RateLimit:   2,
+		DedupWindow: time.Minute,
+	}})
+	now := time.Now()
+
+	assert.Equal(t, fireAccepted, at.admit("a", now))
+	assert.Equal(t, fireDuplicate, at.admit("a", now.Add(time.Second)))
+	assert.Equal(t, fireAccepted, at.admit("b", now))
+	assert.Equal(t, fireRateLimited, at.admit("c", now))
+
+	// After the window the payload is new again and the bucket has refilled.
+	later := now.Add(2 * time.Minute)
+	assert.Equal(t, fireAccepted, at.admit("a", later))
+}
+
+func TestScheduler_TriggerJobRequiresSpec(t *testing.T) {
+	t.Parallel()
+
+	s := newTriggerScheduler(t, &mockAgentRunner{response: "ok"}, nil)
+
+	_, err := s.AddJob(context.Background(), Job{
+		Name:         "no-spec",
+		ScheduleType: ScheduleTypeTrigger,
+		Prompt:       "x",
+		Enabled:      true,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no trigger spec")
+
+	_, err = s.AddJob(context.Background(), triggerJob("no-bus", &Trigger{
+		Kind:   TriggerKindEvent,
+		Events: []string{"deal.updated"},
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires an event bus")
+}
+
+func TestScheduler_FileTrigger(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	runner := &mockAgentRunner{response: "ok"}
+	s := newTriggerScheduler(t, runner, nil)
+
+	job := triggerJob("inbox", &Trigger{
+		Kind:     TriggerKindFile,
+		Path:     filepath.Join(dir, "*.csv"),
+		Debounce: 50 * time.Millisecond,
+	})
+	job.Prompt = "Import {{payload.name}} ({{payload.op}})"
+	_, err := s.AddJob(context.Background(), job)
+	require.NoError(t, err)
+
+	// Non-matching files are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o600))
+
+	// A burst of writes to one matching file fires once after the debounce.
+	target := filepath.Join(dir, "orders.csv")
+	for i := 0; i < 5; i++ {
+		require.NoError(t, os.WriteFile(target, []byte(strings.Repeat("row\n", i+1)), 0o600))
+	}
+
+	require.Eventually(t, func() bool { return runner.callCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(t, runner.prompts()[0], "Import orders.csv (")
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, 1, runner.callCount())
+}
+
+func TestScheduler_FileTrigger_MissingDir(t *testing.T) {
+	t.Parallel()
+
+	s := newTriggerScheduler(t, &mockAgentRunner{response: "ok"}, nil)
+
+	_, err := s.AddJob(context.Background(), triggerJob("missing", &Trigger{
+		Kind: TriggerKindFile,
+		Path: filepath.Join(t.TempDir(), "nope", "*.csv"),
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "watch")
+}
+
+func TestScheduler_WebhookTrigger(t *testing.T) {
+	t.Parallel()
+
+	runner := &mockAgentRunner{response: "ok"}
+	s := newTriggerScheduler(t, runner, nil)
+
+	job := triggerJob("deploy", &Trigger{
+		Kind:      TriggerKindWebhook,
+		SecretRef: "cron.webhook.deploy",
+		RateLimit: 2,
+	})
+	job.Prompt = "Deploy {{payload.ref}}"
+	_, err := s.AddJob(context.Background(), job)
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	s.RegisterWebhookRoutes(r)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	type delivery struct {
+		body, id, timestamp, signature string
+	}
+	signed := func(body, id string, at time.Time) delivery {
+		ts := strconv.FormatInt(at.Unix(), 10)
+		return delivery{body: body, id: id, timestamp: ts, signature: SignWebhook("s3cret", ts, id, []byte(body))}
+	}
+	post := func(name string, d delivery) int {
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/cron/webhook/"+name, bytes.NewBufferString(d.body))
+		require.NoError(t, err)
+		req.Header.Set(WebhookSignatureHeader, d.signature)
+		req.Header.Set(WebhookTimestampHeader, d.timestamp)
+		req.Header.Set(WebhookDeliveryHeader, d.id)
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	body := `{"ref":"v1.2.0"}`
+	now := time.Now()
+	first := signed(body, "delivery-1", now)
+	assert.Equal(t, http.StatusNotFound, post("other", first))
+
+	unsigned := first
+	unsigned.signature = ""
+	assert.Equal(t, http.StatusUnauthorized, post("deploy", unsigned))
+	wrongKey := first
+	wrongKey.signature = SignWebhook("wrong", first.timestamp, first.id, []byte(body))
+	assert.Equal(t, http.StatusUnauthorized, post("deploy", wrongKey))
+	tampered := first
+	tampered.body = `{"ref":"evil"}`
+	assert.Equal(t, http.StatusUnauthorized, post("deploy", tampered))
+	noDelivery := first
+	noDelivery.id = ""
+	assert.Equal(t, http.StatusBadRequest, post("deploy", noDelivery))
+	assert.Equal(t, http.StatusUnauthorized, post("deploy", signed(body, "stale", now.Add(-time.Hour))))
+
+	assert.Equal(t, http.StatusAccepted, post("deploy", first))
+	require.Eventually(t, func() bool { return runner.callCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(t, runner.prompts()[0], "Task: Deploy v1.2.0")
+
+	// A replay is a duplicate, and the delivery ID and timestamp cannot be
+	// swapped without invalidating the signature.
+	assert.Equal(t, http.StatusOK, post("deploy", first))
+	replayed := first
+	replayed.id = "delivery-replayed"
+	assert.Equal(t, http.StatusUnauthorized, post("deploy", replayed))
+	replayed = first
+	replayed.timestamp = strconv.FormatInt(now.Add(time.Minute).Unix(), 10)
+	assert.Equal(t, http.StatusUnauthorized, post("deploy", replayed))
+
+	// The same body under a new delivery ID is a new delivery.
+	assert.Equal(t, http.StatusAccepted, post("deploy", signed(body, "delivery-2", now)))
+
+	// Two fires per minute are allowed.
+	assert.Equal(t, http.StatusTooManyRequests, post("deploy", signed(`{"ref":"v1.3.0"}`, "delivery-3", now)))
+	require.Eventually(t, func() bool { return runner.callCount() == 2 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestScheduler_WebhookTriggerMissingSecret(t *testing.T) {
+	t.Parallel()
+
+	s := newTriggerScheduler(t, &mockAgentRunner{response: "ok"}, nil)
+	_, err := s.AddJob(context.Background(), triggerJob("orphan", &Trigger{
+		Kind:      TriggerKindWebhook,
+		SecretRef: "cron.webhook.orphan",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cron.webhook.orphan")
+}
+
+func TestScheduler_EventTrigger(t *testing.T) {
+	t.Parallel()
+
+	bus := eventbus.New()
+	runner := &mockAgentRunner{response: "ok"}
+	s := newTriggerScheduler(t, runner, bus)
+
+	job := triggerJob("deals", &Trigger{
+		Kind:   TriggerKindEvent,
+		Events: []string{"deal.updated"},
+		Match:  map[string]string{"status": "released"},
+	})
+	job.Prompt = "Record {{payload.dealId}} from {{payload.event}}"
+	_, err := s.AddJob(context.Background(), job)
+	require.NoError(t, err)
+
+	bus.Publish(testDealEvent{DealID: "d-1", Status: "pending"})
+	bus.Publish(testDealEvent{DealID: "d-1", Status: "released"})
+	bus.Publish(testDealEvent{DealID: "d-1", Status: "released"}) // duplicate
+
+	require.Eventually(t, func() bool { return runner.callCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(t, runner.prompts()[0], "Record d-1 from deal.updated")
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, runner.callCount())
+
+	// Removing the job stops dispatch to it.
+	jobs, err := s.ListJobs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.NoError(t, s.RemoveJob(context.Background(), jobs[0].ID))
+	bus.Publish(testDealEvent{DealID: "d-2", Status: "released"})
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, runner.callCount())
+}
+
+func TestWebhookSignature(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"a":1}`)
+	sig := SignWebhook("k", "1700000000", "d-1", body)
+	assert.True(t, strings.HasPrefix(sig, "sha256="))
+	assert.True(t, VerifyWebhookSignature("k", "1700000000", "d-1", body, sig))
+	assert.False(t, VerifyWebhookSignature("k", "1700000000", "d-1", body, strings.TrimPrefix(sig, "sha256=")))
+	assert.False(t, VerifyWebhookSignature("other", "1700000000", "d-1", body, sig))
+	assert.False(t, VerifyWebhookSignature("k", "1700000001", "d-1", body, sig))
+	assert.False(t, VerifyWebhookSignature("k", "1700000000", "d-2", body, sig))
+	assert.False(t, VerifyWebhookSignature("", "1700000000", "d-1", body, SignWebhook("", "1700000000", "d-1", body)))
+}
+
+func TestCheckWebhookTimestamp(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		give    string
+		wantErr bool
+	}{
+		{give: "1700000000"},
+		{give: "1699999760"},
+		{give: "1700000240"},
+		{give: "1699999000", wantErr: true},
+		{give: "1700001000", wantErr: true},
+		{give: "", wantErr: true},
+		{give: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			err := checkWebhookTimestamp(tt.give, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
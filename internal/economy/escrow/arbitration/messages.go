@@ -0,0 +1,48 @@
+package arbitration
+
+import "context"
+
+// MessageType identifies an arbitration protocol message.
+type MessageType string
+
+const (
+	// MsgNominate proposes an arbiter to the counterparty (party → party).
+	MsgNominate MessageType = "nominate"
+	// MsgAssign hands an agreed case to the arbiter (party → arbiter).
+	MsgAssign MessageType = "assign"
+	// MsgAssigned confirms that the arbiter took the case (arbiter → the
+	// party that did not nominate it). The reply carries that party's
+	// evidence, which the arbiter only accepts directly from its submitter.
+	MsgAssigned MessageType = "assigned"
+	// MsgEvidence submits additional evidence (party → arbiter).
+	MsgEvidence MessageType = "evidence"
+	// MsgRuling delivers a signed ruling (arbiter → parties).
+	MsgRuling MessageType = "ruling"
+)
+
+// Message is an arbitration protocol message.
+type Message struct {
+	Type       MessageType `json:"type"`
+	EscrowID   string      `json:"escrowId"`
+	ArbiterDID string      `json:"arbiterDid,omitempty"`
+	Case       *Case       `json:"case,omitempty"`
+	Evidence   *Evidence   `json:"evidence,omitempty"`
+	Ruling     *Ruling     `json:"ruling,omitempty"`
+}
+
+// Reply answers an arbitration message.
+type Reply struct {
+	Accepted bool       `json:"accepted"`
+	Reason   string     `json:"reason,omitempty"`
+	Evidence []Evidence `json:"evidence,omitempty"` // replying party's own evidence
+	// Acceptance is the counterparty's signed agreement on MsgNominate.
+	Acceptance *Acceptance `json:"acceptance,omitempty"`
+}
+
+// Transport delivers a message to a peer and returns its reply.
+// Uses the callback pattern so the service stays independent of libp2p.
+type Transport func(ctx context.Context, peerDID string, msg Message) (*Reply, error)
+
+// BundleVerifier verifies that a provenance bundle is validly signed by the
+// submitting party.
+type BundleVerifier func(ctx context.Context, submitterDID string, bundle []byte) error
@@ -0,0 +1,153 @@
+package arbitration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Signer signs rulings and nomination acceptances with the local identity key. The handshake signers
+// (wallet secp256k1 or bundle Ed25519) satisfy it.
+type Signer interface {
+	SignMessage(ctx context.Context, message []byte) ([]byte, error)
+	Algorithm() string
+}
+
+// SignatureVerifyFunc verifies sig over payload for the given signer DID.
+type SignatureVerifyFunc func(signerDID string, payload, sig []byte) error
+
+// rulingPayload is the canonical signed form of a Ruling.
+type rulingPayload struct {
+	Domain        string `json:"domain"`
+	EscrowID      string `json:"escrowId"`
+	ArbiterDID    string `json:"arbiterDid"`
+	SellerPercent int    `json:"sellerPercent"`
+	Rationale     string `json:"rationale"`
+	IssuedAt      int64  `json:"issuedAt"`
+	Algorithm     string `json:"algorithm"`
+}
+
+// RulingPayload returns the canonical bytes covered by the ruling signature.
+func RulingPayload(r Ruling) ([]byte, error) {
+	return json.Marshal(rulingPayload{
+		Domain:        "lango-escrow-ruling",
+		EscrowID:      r.EscrowID,
+		ArbiterDID:    r.ArbiterDID,
+		SellerPercent: r.SellerPercent,
+		Rationale:     r.Rationale,
+		IssuedAt:      r.IssuedAt.UnixNano(),
+		Algorithm:     r.Algorithm,
+	})
+}
+
+// SignRuling signs r in place.
+func SignRuling(ctx context.Context, r *Ruling, signer Signer) error {
+	r.Algorithm = signer.Algorithm()
+	payload, err := RulingPayload(*r)
+	if err != nil {
+		return fmt.Errorf("encode ruling payload: %w", err)
+	}
+	sig, err := signer.SignMessage(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("sign ruling: %w", err)
+	}
+	r.Signature = sig
+	return nil
+}
+
+// VerifyRuling checks the ruling's split and its signature by the arbiter.
+func VerifyRuling(r Ruling, verifiers map[string]SignatureVerifyFunc) error {
+	if r.SellerPercent < 0 || r.SellerPercent > 100 {
+		return fmt.Errorf("%w: seller percent %d", ErrInvalidRuling, r.SellerPercent)
+	}
+	if r.EscrowID == "" || r.ArbiterDID == "" {
+		return fmt.Errorf("%w: missing escrow or arbiter", ErrInvalidRuling)
+	}
+	if len(r.Signature) == 0 {
+		return fmt.Errorf("%w: unsigned", ErrInvalidSignature)
+	}
+	verify, ok := verifiers[r.Algorithm]
+	if !ok {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, r.Algorithm)
+	}
+	payload, err := RulingPayload(r)
+	if err != nil {
+		return fmt.Errorf("encode ruling payload: %w", err)
+	}
+	if err := verify(r.ArbiterDID, payload, r.Signature); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return nil
+}
+
+// acceptancePayload is the canonical signed form of an Acceptance.
+type acceptancePayload struct {
+	Domain       string `json:"domain"`
+	EscrowID     string `json:"escrowId"`
+	ArbiterDID   string `json:"arbiterDid"`
+	NominatorDID string `json:"nominatorDid"`
+	AccepterDID  string `json:"accepterDid"`
+	AcceptedAt   int64  `json:"acceptedAt"`
+	Algorithm    string `json:"algorithm"`
+}
+
+// AcceptancePayload returns the canonical bytes covered by the acceptance
+// signature.
+func AcceptancePayload(a Acceptance) ([]byte, error) {
+	return json.Marshal(acceptancePayload{
+		Domain:       "lango-escrow-arbiter-acceptance",
+		EscrowID:     a.EscrowID,
+		ArbiterDID:   a.ArbiterDID,
+		NominatorDID: a.NominatorDID,
+		AccepterDID:  a.AccepterDID,
+		AcceptedAt:   a.AcceptedAt.UnixNano(),
+		Algorithm:    a.Algorithm,
+	})
+}
+
+// SignAcceptance signs a in place.
+func SignAcceptance(ctx context.Context, a *Acceptance, signer Signer) error {
+	a.Algorithm = signer.Algorithm()
+	payload, err := AcceptancePayload(*a)
+	if err != nil {
+		return fmt.Errorf("encode acceptance payload: %w", err)
+	}
+	sig, err := signer.SignMessage(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("sign acceptance: %w", err)
+	}
+	a.Signature = sig
+	return nil
+}
+
+// VerifyAcceptance checks that a is signed by its accepter and agrees to
+// arbiterDID on a case between the nominator and the accepter.
+func VerifyAcceptance(a *Acceptance, c *Case, arbiterDID string, verifiers map[string]SignatureVerifyFunc) error {
+	if a == nil {
+		return fmt.Errorf("%w: missing acceptance", ErrNotAccepted)
+	}
+	if a.EscrowID != c.EscrowID || a.ArbiterDID != arbiterDID {
+		return fmt.Errorf("%w: acceptance is for another case or arbiter", ErrNotAccepted)
+	}
+	if r := c.RoleOf(a.NominatorDID); r != RoleBuyer && r != RoleSeller {
+		return fmt.Errorf("%w: nominator is not a party", ErrNotAccepted)
+	}
+	if a.AccepterDID != c.Counterparty(a.NominatorDID) {
+		return fmt.Errorf("%w: accepter is not the counterparty", ErrNotAccepted)
+	}
+	if len(a.Signature) == 0 {
+		return fmt.Errorf("%w: unsigned acceptance", ErrNotAccepted)
+	}
+	verify, ok := verifiers[a.Algorithm]
+	if !ok {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrNotAccepted, a.Algorithm)
+	}
+	payload, err := AcceptancePayload(*a)
+	if err != nil {
+		return fmt.Errorf("encode acceptance payload: %w", err)
+	}
+	if err := verify(a.AccepterDID, payload, a.Signature); err != nil {
+		return fmt.Errorf("%w: %v", ErrNotAccepted, err)
+	}
+	return nil
+}
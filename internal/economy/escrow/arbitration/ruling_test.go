@@ -0,0 +1,87 @@
+package arbitration
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSigner struct {
+	priv ed25519.PrivateKey
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	return &testSigner{priv: priv}
+}
+
+func (s *testSigner) SignMessage(_ context.Context, message []byte) ([]byte, error) {
+	return ed25519.Sign(s.priv, message), nil
+}
+
+func (s *testSigner) Algorithm() string { return "ed25519" }
+
+// testVerifiers resolves DIDs to public keys through keys.
+func testVerifiers(keys map[string]*testSigner) map[string]SignatureVerifyFunc {
+	return map[string]SignatureVerifyFunc{
+		"ed25519": func(did string, payload, sig []byte) error {
+			s, ok := keys[did]
+			if !ok {
+				return errors.New("unknown signer")
+			}
+			if !ed25519.Verify(s.priv.Public().(ed25519.PublicKey), payload, sig) {
+				return errors.New("bad signature")
+			}
+			return nil
+		},
+	}
+}
+
+func TestSignVerifyRuling(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	verifiers := testVerifiers(map[string]*testSigner{"did:arb": signer})
+
+	r := &Ruling{
+		EscrowID:      "esc-1",
+		ArbiterDID:    "did:arb",
+		SellerPercent: 70,
+		Rationale:     "partial delivery",
+		IssuedAt:      time.Now(),
+	}
+	require.NoError(t, SignRuling(context.Background(), r, signer))
+	assert.Equal(t, "ed25519", r.Algorithm)
+	require.NoError(t, VerifyRuling(*r, verifiers))
+
+	tests := []struct {
+		give    func(r Ruling) Ruling
+		wantErr error
+	}{
+		{give: func(r Ruling) Ruling { r.SellerPercent = 100; return r }, wantErr: ErrInvalidSignature},
+		{give: func(r Ruling) Ruling { r.ArbiterDID = "did:other"; return r }, wantErr: ErrInvalidSignature},
+		{give: func(r Ruling) Ruling { r.Signature = nil; return r }, wantErr: ErrInvalidSignature},
+		{give: func(r Ruling) Ruling { r.Algorithm = "rsa"; return r }, wantErr: ErrInvalidSignature},
+		{give: func(r Ruling) Ruling { r.SellerPercent = 101; return r }, wantErr: ErrInvalidRuling},
+		{give: func(r Ruling) Ruling { r.EscrowID = ""; return r }, wantErr: ErrInvalidRuling},
+	}
+	for _, tt := range tests {
+		err := VerifyRuling(tt.give(*r), verifiers)
+		assert.ErrorIs(t, err, tt.wantErr)
+	}
+}
+
+func TestRulingFavoredRole(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, RoleSeller, (&Ruling{SellerPercent: 80}).FavoredRole())
+	assert.Equal(t, RoleBuyer, (&Ruling{SellerPercent: 20}).FavoredRole())
+	assert.Equal(t, Role(""), (&Ruling{SellerPercent: 50}).FavoredRole())
+}
@@ -0,0 +1,123 @@
+package arbitration
+
+import (
+	"context"
+	"sort"
+
+	"github.com/langoai/lango/internal/economy/escrow"
+)
+
+// ReputationFunc returns the local trust score of a peer.
+type ReputationFunc func(ctx context.Context, peerDID string) (float64, error)
+
+// ConflictFunc reports whether a candidate arbiter has a conflict of interest
+// with either party, e.g. prior deals with the buyer or seller.
+type ConflictFunc func(arbiterDID, buyerDID, sellerDID string) bool
+
+// EscrowConflicts returns a ConflictFunc that flags arbiters with whom either
+// party has a locally recorded escrow deal.
+func EscrowConflicts(engine *escrow.Engine) ConflictFunc {
+	return func(arbiterDID, buyerDID, sellerDID string) bool {
+		for _, e := range engine.ListByPeer(arbiterDID) {
+			for _, did := range []string{e.BuyerDID, e.SellerDID} {
+				if did == buyerDID || did == sellerDID {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// DefaultMinArbiterScore is the minimum local trust score for an arbiter.
+const DefaultMinArbiterScore = 0.7
+
+// SelectorConfig configures arbiter selection.
+type SelectorConfig struct {
+	Reputation ReputationFunc
+	Conflict   ConflictFunc
+	MinScore   float64
+}
+
+// Selector ranks arbiter candidates by local reputation. Each party runs its
+// own Selector, so an arbiter is only agreed when both parties' local views
+// trust it.
+type Selector struct {
+	reputation ReputationFunc
+	conflict   ConflictFunc
+	minScore   float64
+}
+
+// NewSelector creates an arbiter selector.
+func NewSelector(cfg SelectorConfig) *Selector {
+	if cfg.MinScore <= 0 {
+		cfg.MinScore = DefaultMinArbiterScore
+	}
+	return &Selector{
+		reputation: cfg.Reputation,
+		conflict:   cfg.Conflict,
+		minScore:   cfg.MinScore,
+	}
+}
+
+// Trusts reports whether arbiterDID is acceptable for a dispute between
+// buyerDID and sellerDID.
+func (s *Selector) Trusts(ctx context.Context, arbiterDID, buyerDID, sellerDID string) bool {
+	_, ok := s.score(ctx, arbiterDID, buyerDID, sellerDID)
+	return ok
+}
+
+func (s *Selector) score(ctx context.Context, arbiterDID, buyerDID, sellerDID string) (float64, bool) {
+	if arbiterDID == "" || arbiterDID == buyerDID || arbiterDID == sellerDID {
+		return 0, false
+	}
+	if s.conflict != nil && s.conflict(arbiterDID, buyerDID, sellerDID) {
+		return 0, false
+	}
+	if s.reputation == nil {
+		return 0, false
+	}
+	score, err := s.reputation(ctx, arbiterDID)
+	if err != nil || score < s.minScore {
+		return 0, false
+	}
+	return score, true
+}
+
+// Rank returns the acceptable candidates, best first. Candidates listed in
+// exclude are skipped.
+func (s *Selector) Rank(ctx context.Context, candidates []string, buyerDID, sellerDID string, exclude []string) []string {
+	skip := make(map[string]bool, len(exclude))
+	for _, did := range exclude {
+		skip[did] = true
+	}
+
+	type ranked struct {
+		did   string
+		score float64
+	}
+	var out []ranked
+	seen := make(map[string]bool, len(candidates))
+	for _, did := range candidates {
+		if skip[did] || seen[did] {
+			continue
+		}
+		seen[did] = true
+		if score, ok := s.score(ctx, did, buyerDID, sellerDID); ok {
+			out = append(out, ranked{did: did, score: score})
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].score != out[j].score {
+			return out[i].score > out[j].score
+		}
+		return out[i].did < out[j].did
+	})
+
+	dids := make([]string, len(out))
+	for i, r := range out {
+		dids[i] = r.did
+	}
+	return dids
+}
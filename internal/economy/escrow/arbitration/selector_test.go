@@ -0,0 +1,51 @@
+package arbitration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func scores(m map[string]float64) ReputationFunc {
+	return func(_ context.Context, did string) (float64, error) {
+		s, ok := m[did]
+		if !ok {
+			return 0, errors.New("unknown peer")
+		}
+		return s, nil
+	}
+}
+
+func TestSelectorRank(t *testing.T) {
+	t.Parallel()
+
+	sel := NewSelector(SelectorConfig{
+		Reputation: scores(map[string]float64{
+			"did:a": 0.9, "did:b": 0.95, "did:c": 0.5, "did:d": 0.9, "did:conflicted": 0.99, "did:buyer": 1,
+		}),
+		Conflict: func(arbiter, _, _ string) bool { return arbiter == "did:conflicted" },
+	})
+
+	got := sel.Rank(context.Background(),
+		[]string{"did:a", "did:b", "did:c", "did:d", "did:conflicted", "did:buyer", "did:unknown", "did:a"},
+		"did:buyer", "did:seller", []string{"did:d"})
+	assert.Equal(t, []string{"did:b", "did:a"}, got)
+}
+
+func TestSelectorTrusts(t *testing.T) {
+	t.Parallel()
+
+	sel := NewSelector(SelectorConfig{
+		Reputation: scores(map[string]float64{"did:a": 0.8, "did:b": 0.6}),
+		MinScore:   0.75,
+	})
+	ctx := context.Background()
+
+	assert.True(t, sel.Trusts(ctx, "did:a", "did:buyer", "did:seller"))
+	assert.False(t, sel.Trusts(ctx, "did:b", "did:buyer", "did:seller"))
+	assert.False(t, sel.Trusts(ctx, "did:a", "did:a", "did:seller"))
+	assert.False(t, sel.Trusts(ctx, "", "did:buyer", "did:seller"))
+	assert.False(t, NewSelector(SelectorConfig{}).Trusts(ctx, "did:a", "did:buyer", "did:seller"))
+}
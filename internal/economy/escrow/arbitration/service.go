@@ -0,0 +1,884 @@
+package arbitration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/langoai/lango/internal/economy/escrow"
+	"github.com/langoai/lango/internal/eventbus"
+)
+
+// DefaultMaxNominations bounds how many arbiters a party proposes per call.
+const DefaultMaxNominations = 3
+
+// Config configures an arbitration Service.
+type Config struct {
+	LocalDID string
+	Store    Store
+	// Escrow settles rulings on escrows held locally. Optional on nodes that
+	// only act as arbiters.
+	Escrow     *escrow.Engine
+	Selector   *Selector
+	Candidates func() []string // known peers to consider as arbiters
+	Transport  Transport
+	Signer     Signer
+	Verifiers  map[string]SignatureVerifyFunc
+	// VerifyBundle checks provenance bundles attached to evidence. When nil,
+	// bundles are carried unverified.
+	VerifyBundle BundleVerifier
+	// AcceptCase decides whether this node agrees to arbitrate a case.
+	// When nil, every assignment is accepted.
+	AcceptCase     func(c *Case) bool
+	MaxNominations int
+	Bus            *eventbus.Bus
+	Logger         *zap.SugaredLogger
+}
+
+// Service runs the arbitration workflow for the local node, which can act as
+// buyer, seller, or arbiter depending on the case.
+type Service struct {
+	mu  sync.Mutex
+	cfg Config
+}
+
+// NewService creates an arbitration service.
+func NewService(cfg Config) *Service {
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryStore()
+	}
+	if cfg.MaxNominations <= 0 {
+		cfg.MaxNominations = DefaultMaxNominations
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop().Sugar()
+	}
+	return &Service{cfg: cfg}
+}
+
+// Get returns the local view of a case.
+func (s *Service) Get(escrowID string) (*Case, error) {
+	return s.cfg.Store.Get(escrowID)
+}
+
+// List returns all locally known cases.
+func (s *Service) List() []*Case {
+	return s.cfg.Store.List()
+}
+
+// OpenCase opens an arbitration case for a disputed local escrow. Evidence
+// recorded on completed milestones is attached automatically. Opening an
+// already open case returns it unchanged.
+func (s *Service) OpenCase(ctx context.Context, escrowID string) (*Case, error) {
+	if s.cfg.Escrow == nil {
+		return nil, fmt.Errorf("open case %q: escrow engine not configured", escrowID)
+	}
+	entry, err := s.cfg.Escrow.Get(escrowID)
+	if err != nil {
+		return nil, fmt.Errorf("open case: %w", err)
+	}
+	if entry.Status != escrow.StatusDisputed {
+		return nil, fmt.Errorf("open case %q (status %q): %w", escrowID, entry.Status, ErrNotDisputed)
+	}
+	if s.cfg.LocalDID != entry.BuyerDID && s.cfg.LocalDID != entry.SellerDID {
+		return nil, fmt.Errorf("open case %q: %w", escrowID, ErrNotParty)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, err := s.cfg.Store.Get(escrowID); err == nil {
+		return c, nil
+	}
+
+	now := time.Now()
+	c := &Case{
+		EscrowID:    entry.ID,
+		BuyerDID:    entry.BuyerDID,
+		SellerDID:   entry.SellerDID,
+		TotalAmount: entry.TotalAmount.String(),
+		DisputeNote: entry.DisputeNote,
+		Status:      CaseOpen,
+		OpenedAt:    now,
+		UpdatedAt:   now,
+	}
+	for _, m := range entry.Milestones {
+		if m.Evidence == "" {
+			continue
+		}
+		c.Evidence = append(c.Evidence, Evidence{
+			ID:                "milestone:" + m.ID,
+			EscrowID:          entry.ID,
+			SubmitterDID:      entry.SellerDID,
+			Role:              RoleSeller,
+			MilestoneID:       m.ID,
+			MilestoneEvidence: m.Evidence,
+			Statement:         m.Description,
+			SubmittedAt:       now,
+		})
+	}
+	if err := s.cfg.Store.Create(c); err != nil {
+		return nil, err
+	}
+	return c.clone(), nil
+}
+
+// EvidenceRequest describes evidence submitted by the local party.
+type EvidenceRequest struct {
+	EscrowID         string
+	Statement        string
+	MilestoneID      string
+	ProvenanceBundle []byte
+}
+
+// SubmitEvidence records evidence from the local party and forwards it to the
+// arbiter once one is assigned.
+func (s *Service) SubmitEvidence(ctx context.Context, req EvidenceRequest) (*Evidence, error) {
+	if strings.TrimSpace(req.Statement) == "" && len(req.ProvenanceBundle) == 0 {
+		return nil, fmt.Errorf("%w: statement or provenance bundle required", ErrInvalidEvidence)
+	}
+
+	c, err := s.cfg.Store.Get(req.EscrowID)
+	if err != nil {
+		return nil, err
+	}
+	role := c.RoleOf(s.cfg.LocalDID)
+	if role != RoleBuyer && role != RoleSeller {
+		return nil, fmt.Errorf("submit evidence %q: %w", req.EscrowID, ErrNotParty)
+	}
+	if c.Ruling != nil {
+		return nil, fmt.Errorf("submit evidence %q: %w", req.EscrowID, ErrAlreadyRuled)
+	}
+
+	ev := Evidence{
+		ID:               uuid.New().String(),
+		EscrowID:         req.EscrowID,
+		SubmitterDID:     s.cfg.LocalDID,
+		Role:             role,
+		MilestoneID:      req.MilestoneID,
+		Statement:        req.Statement,
+		ProvenanceBundle: req.ProvenanceBundle,
+		SubmittedAt:      time.Now(),
+	}
+	if req.MilestoneID != "" {
+		text, err := s.milestoneEvidence(req.EscrowID, req.MilestoneID)
+		if err != nil {
+			return nil, err
+		}
+		ev.MilestoneEvidence = text
+	}
+	if err := s.verifyEvidence(ctx, ev); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	c, err = s.cfg.Store.Get(req.EscrowID)
+	if err == nil {
+		c.Evidence = mergeEvidence(c.Evidence, ev)
+		c.UpdatedAt = time.Now()
+		err = s.cfg.Store.Update(c)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ArbiterDID != "" {
+		reply, err := s.send(ctx, c.ArbiterDID, Message{Type: MsgEvidence, EscrowID: c.EscrowID, Evidence: &ev})
+		if err != nil {
+			return &ev, fmt.Errorf("forward evidence to arbiter: %w", err)
+		}
+		if reply.Accepted {
+			// The arbiter holds the case, so the nomination is settled.
+			if err := s.confirmArbiter(c.EscrowID, c.ArbiterDID); err != nil {
+				s.cfg.Logger.Warnw("confirm arbiter", "escrowID", c.EscrowID, "error", err)
+			}
+		}
+	}
+	return &ev, nil
+}
+
+// AgreeArbiterReplacement records that the local party agrees to replace the
+// assigned arbiter. The arbiter is replaced once both parties agreed and one
+// of them nominates a new one.
+func (s *Service) AgreeArbiterReplacement(escrowID string) (*Case, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.cfg.Store.Get(escrowID)
+	if err != nil {
+		return nil, err
+	}
+	role := c.RoleOf(s.cfg.LocalDID)
+	if role != RoleBuyer && role != RoleSeller {
+		return nil, fmt.Errorf("replace arbiter %q: %w", escrowID, ErrNotParty)
+	}
+	if c.ArbiterDID == "" {
+		return nil, fmt.Errorf("replace arbiter %q: %w", escrowID, ErrNoArbiter)
+	}
+	if c.Ruling != nil {
+		return nil, fmt.Errorf("replace arbiter %q: %w", escrowID, ErrAlreadyRuled)
+	}
+	c.ReplaceArbiter = true
+	c.UpdatedAt = time.Now()
+	if err := s.cfg.Store.Update(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// NominateArbiter proposes arbiters to the counterparty until one is
+// accepted by both the counterparty and the arbiter itself. When preferred is
+// set, only that arbiter is proposed; otherwise candidates are ranked by the
+// local Selector. An assigned arbiter is only replaced after both parties
+// called AgreeArbiterReplacement.
+func (s *Service) NominateArbiter(ctx context.Context, escrowID, preferred string) (*Case, error) {
+	c, err := s.cfg.Store.Get(escrowID)
+	if err != nil {
+		return nil, err
+	}
+	role := c.RoleOf(s.cfg.LocalDID)
+	if role != RoleBuyer && role != RoleSeller {
+		return nil, fmt.Errorf("nominate arbiter %q: %w", escrowID, ErrNotParty)
+	}
+	if c.ArbiterDID != "" && !c.ReplaceArbiter {
+		return nil, fmt.Errorf("nominate arbiter %q: %w", escrowID, ErrArbiterAssigned)
+	}
+	if s.cfg.Selector == nil {
+		return nil, fmt.Errorf("nominate arbiter %q: selector not configured", escrowID)
+	}
+
+	replaced := c.ArbiterDID
+	exclude := c.Rejected
+	if replaced != "" {
+		exclude = append(append([]string(nil), exclude...), replaced)
+	}
+
+	var candidates []string
+	if preferred != "" {
+		if preferred == replaced {
+			return nil, fmt.Errorf("nominate arbiter %q: %w: %s is the arbiter being replaced", escrowID, ErrNoCandidates, preferred)
+		}
+		if !s.cfg.Selector.Trusts(ctx, preferred, c.BuyerDID, c.SellerDID) {
+			return nil, fmt.Errorf("nominate arbiter %q: %w: %s is not trusted locally", escrowID, ErrNoCandidates, preferred)
+		}
+		candidates = []string{preferred}
+	} else {
+		var pool []string
+		if s.cfg.Candidates != nil {
+			pool = s.cfg.Candidates()
+		}
+		candidates = s.cfg.Selector.Rank(ctx, pool, c.BuyerDID, c.SellerDID, exclude)
+		if len(candidates) > s.cfg.MaxNominations {
+			candidates = candidates[:s.cfg.MaxNominations]
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("nominate arbiter %q: %w", escrowID, ErrNoCandidates)
+	}
+
+	counterparty := c.Counterparty(s.cfg.LocalDID)
+	for _, arbiter := range candidates {
+		reply, err := s.send(ctx, counterparty, Message{
+			Type: MsgNominate, EscrowID: escrowID, ArbiterDID: arbiter, Case: caseSummary(c),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("nominate arbiter: %w", err)
+		}
+		if !reply.Accepted && reply.Reason == ErrArbiterAssigned.Error() {
+			return nil, fmt.Errorf("nominate arbiter %q: counterparty has not agreed to a replacement: %w", escrowID, ErrArbiterAssigned)
+		}
+		if !reply.Accepted {
+			s.cfg.Logger.Infow("arbiter rejected by counterparty",
+				"escrowID", escrowID, "arbiter", arbiter, "reason", reply.Reason)
+			c = s.reject(escrowID, arbiter)
+			continue
+		}
+		acc := reply.Acceptance
+		if acc == nil || acc.ArbiterDID != arbiter || acc.NominatorDID != s.cfg.LocalDID || acc.AccepterDID != counterparty {
+			s.cfg.Logger.Infow("counterparty accepted without a signed acceptance",
+				"escrowID", escrowID, "arbiter", arbiter)
+			c = s.reject(escrowID, arbiter)
+			continue
+		}
+		for _, ev := range reply.Evidence {
+			if ev.SubmitterDID != counterparty || ev.EscrowID != escrowID {
+				continue
+			}
+			if err := s.verifyEvidence(ctx, ev); err != nil {
+				s.cfg.Logger.Warnw("drop counterparty evidence", "escrowID", escrowID, "error", err)
+				continue
+			}
+			c.Evidence = mergeEvidence(c.Evidence, ev)
+		}
+
+		assigned := c.clone()
+		assigned.ArbiterDID = arbiter
+		assigned.NominatedBy = s.cfg.LocalDID
+		assigned.Acceptance = acc
+		assigned.ReplaceArbiter = false
+		assigned.Status = CaseAssigned
+		reply, err = s.send(ctx, arbiter, Message{Type: MsgAssign, EscrowID: escrowID, ArbiterDID: arbiter, Case: assigned})
+		if err != nil || !reply.Accepted {
+			reason := "unreachable"
+			if err == nil {
+				reason = reply.Reason
+			}
+			s.cfg.Logger.Infow("arbiter declined case", "escrowID", escrowID, "arbiter", arbiter, "reason", reason)
+			c = s.reject(escrowID, arbiter)
+			continue
+		}
+
+		if replaced != "" {
+			assigned.Rejected = append(assigned.Rejected, replaced)
+		}
+		assigned.UpdatedAt = time.Now()
+		s.mu.Lock()
+		err = s.cfg.Store.Update(assigned)
+		s.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		s.publishAssigned(assigned)
+		return assigned, nil
+	}
+	return nil, fmt.Errorf("nominate arbiter %q: %w", escrowID, ErrNoCandidates)
+}
+
+// IssueRuling signs a ruling on a case assigned to the local node and
+// delivers it to both parties.
+func (s *Service) IssueRuling(ctx context.Context, escrowID string, sellerPercent int, rationale string) (*Ruling, error) {
+	if s.cfg.Signer == nil {
+		return nil, fmt.Errorf("issue ruling %q: signer not configured", escrowID)
+	}
+	if sellerPercent < 0 || sellerPercent > 100 {
+		return nil, fmt.Errorf("%w: seller percent %d", ErrInvalidRuling, sellerPercent)
+	}
+
+	s.mu.Lock()
+	c, err := s.cfg.Store.Get(escrowID)
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	if c.ArbiterDID != s.cfg.LocalDID || c.RoleOf(s.cfg.LocalDID) != RoleArbiter {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("issue ruling %q: %w", escrowID, ErrNotArbiter)
+	}
+	if c.Ruling != nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("issue ruling %q: %w", escrowID, ErrAlreadyRuled)
+	}
+	if err := VerifyAcceptance(c.Acceptance, c, s.cfg.LocalDID, s.cfg.Verifiers); err != nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("issue ruling %q: %w", escrowID, err)
+	}
+
+	r := &Ruling{
+		EscrowID:      escrowID,
+		ArbiterDID:    s.cfg.LocalDID,
+		SellerPercent: sellerPercent,
+		Rationale:     rationale,
+		IssuedAt:      time.Now().UTC(),
+	}
+	if err := SignRuling(ctx, r, s.cfg.Signer); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	c.Ruling = r
+	c.Status = CaseRuled
+	c.UpdatedAt = time.Now()
+	err = s.cfg.Store.Update(c)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	s.publishRuling(c)
+
+	var errs []error
+	for _, party := range []string{c.BuyerDID, c.SellerDID} {
+		reply, err := s.send(ctx, party, Message{Type: MsgRuling, EscrowID: escrowID, Ruling: r})
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("deliver ruling to %s: %w", party, err))
+		case !reply.Accepted:
+			errs = append(errs, fmt.Errorf("deliver ruling to %s: rejected: %s", party, reply.Reason))
+		}
+	}
+	return r, errors.Join(errs...)
+}
+
+// ApplyRuling settles a received ruling on the local escrow through the
+// escrow engine's settler. It is a no-op when the escrow is not held locally.
+func (s *Service) ApplyRuling(ctx context.Context, escrowID string) (*Case, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.cfg.Store.Get(escrowID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Ruling == nil {
+		return nil, fmt.Errorf("apply ruling %q: %w", escrowID, ErrInvalidRuling)
+	}
+	if c.Status == CaseSettled || s.cfg.Escrow == nil {
+		return c, nil
+	}
+	if _, err := s.cfg.Escrow.Get(escrowID); err != nil {
+		return c, nil
+	}
+
+	res, err := s.cfg.Escrow.ResolveDispute(ctx, escrowID, c.Ruling.SellerPercent)
+	if err != nil {
+		return nil, fmt.Errorf("apply ruling %q: %w", escrowID, err)
+	}
+	c.Status = CaseSettled
+	c.SettledTx = res.TxHash
+	c.UpdatedAt = time.Now()
+	if err := s.cfg.Store.Update(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// HandleMessage processes an arbitration message from peerDID, the
+// authenticated sender.
+func (s *Service) HandleMessage(ctx context.Context, peerDID string, msg Message) (*Reply, error) {
+	if msg.EscrowID == "" {
+		return nil, fmt.Errorf("%s message: missing escrow ID", msg.Type)
+	}
+	switch msg.Type {
+	case MsgNominate:
+		return s.handleNominate(ctx, peerDID, msg)
+	case MsgAssign:
+		return s.handleAssign(ctx, peerDID, msg)
+	case MsgAssigned:
+		return s.handleAssigned(peerDID, msg)
+	case MsgEvidence:
+		return s.handleEvidence(ctx, peerDID, msg)
+	case MsgRuling:
+		return s.handleRuling(ctx, peerDID, msg)
+	default:
+		return nil, fmt.Errorf("unknown arbitration message type %q", msg.Type)
+	}
+}
+
+func (s *Service) handleNominate(ctx context.Context, peerDID string, msg Message) (*Reply, error) {
+	in := msg.Case
+	if in == nil || in.EscrowID != msg.EscrowID {
+		return reject("missing case"), nil
+	}
+	if in.RoleOf(peerDID) == "" || in.RoleOf(peerDID) == RoleArbiter || in.Counterparty(peerDID) != s.cfg.LocalDID {
+		return reject(ErrNotParty.Error()), nil
+	}
+	if s.cfg.Escrow != nil {
+		if entry, err := s.cfg.Escrow.Get(msg.EscrowID); err == nil {
+			if entry.BuyerDID != in.BuyerDID || entry.SellerDID != in.SellerDID {
+				return reject("case parties do not match escrow"), nil
+			}
+			if entry.Status != escrow.StatusDisputed {
+				return reject(ErrNotDisputed.Error()), nil
+			}
+		}
+	}
+	if s.cfg.Selector == nil || !s.cfg.Selector.Trusts(ctx, msg.ArbiterDID, in.BuyerDID, in.SellerDID) {
+		return reject("arbiter not trusted"), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.cfg.Store.Get(msg.EscrowID)
+	if errors.Is(err, ErrCaseNotFound) {
+		c = &Case{
+			EscrowID:    in.EscrowID,
+			BuyerDID:    in.BuyerDID,
+			SellerDID:   in.SellerDID,
+			TotalAmount: in.TotalAmount,
+			DisputeNote: in.DisputeNote,
+			Status:      CaseOpen,
+			OpenedAt:    time.Now(),
+		}
+		if err := s.cfg.Store.Create(c); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	if c.Ruling != nil {
+		return reject(ErrAlreadyRuled.Error()), nil
+	}
+	if c.Status == CaseAssigned && c.ArbiterDID != "" {
+		// Otherwise either party could re-nominate until it got an arbiter
+		// it prefers, even after the other side submitted evidence.
+		if !c.ReplaceArbiter || c.ArbiterDID == msg.ArbiterDID {
+			return reject(ErrArbiterAssigned.Error()), nil
+		}
+		c.Rejected = append(c.Rejected, c.ArbiterDID)
+		c.ReplaceArbiter = false
+	}
+	if s.cfg.Signer == nil {
+		return reject("signer not configured"), nil
+	}
+	acc := &Acceptance{
+		EscrowID:     c.EscrowID,
+		ArbiterDID:   msg.ArbiterDID,
+		NominatorDID: peerDID,
+		AccepterDID:  s.cfg.LocalDID,
+		AcceptedAt:   time.Now().UTC(),
+	}
+	if err := SignAcceptance(ctx, acc, s.cfg.Signer); err != nil {
+		return nil, err
+	}
+
+	for _, ev := range in.Evidence {
+		if ev.SubmitterDID == peerDID && ev.EscrowID == c.EscrowID {
+			c.Evidence = mergeEvidence(c.Evidence, ev)
+		}
+	}
+	// The case stays open until the arbiter confirms it took the case, so a
+	// nominee that declines can still be replaced.
+	c.ArbiterDID = msg.ArbiterDID
+	c.NominatedBy = peerDID
+	c.Acceptance = acc
+	c.Status = CaseOpen
+	c.UpdatedAt = time.Now()
+	if err := s.cfg.Store.Update(c); err != nil {
+		return nil, err
+	}
+	return &Reply{Accepted: true, Evidence: s.ownEvidence(c), Acceptance: acc}, nil
+}
+
+func (s *Service) handleAssign(ctx context.Context, peerDID string, msg Message) (*Reply, error) {
+	in := msg.Case
+	if in == nil || in.EscrowID != msg.EscrowID {
+		return reject("missing case"), nil
+	}
+	if in.ArbiterDID != s.cfg.LocalDID || msg.ArbiterDID != s.cfg.LocalDID {
+		return reject(ErrNotArbiter.Error()), nil
+	}
+	if r := in.RoleOf(peerDID); r != RoleBuyer && r != RoleSeller {
+		return reject(ErrNotParty.Error()), nil
+	}
+	if in.Acceptance == nil || in.Acceptance.NominatorDID != peerDID {
+		return reject(ErrNotAccepted.Error()), nil
+	}
+	if err := VerifyAcceptance(in.Acceptance, in, s.cfg.LocalDID, s.cfg.Verifiers); err != nil {
+		return reject(err.Error()), nil
+	}
+	if s.cfg.AcceptCase != nil && !s.cfg.AcceptCase(in) {
+		return reject("arbiter declined case"), nil
+	}
+
+	s.mu.Lock()
+	if existing, err := s.cfg.Store.Get(msg.EscrowID); err == nil {
+		s.mu.Unlock()
+		if existing.Ruling != nil {
+			return reject(ErrAlreadyRuled.Error()), nil
+		}
+		return &Reply{Accepted: true}, nil
+	}
+
+	c := in.clone()
+	// Evidence is unsigned, so only the nominator's own entries are taken
+	// from its payload; the counterparty's evidence comes from the
+	// counterparty itself.
+	c.Evidence = nil
+	for _, ev := range in.Evidence {
+		if ev.SubmitterDID == peerDID && ev.EscrowID == c.EscrowID {
+			c.Evidence = mergeEvidence(c.Evidence, ev)
+		}
+	}
+	c.NominatedBy = peerDID
+	c.ReplaceArbiter = false
+	c.Status = CaseAssigned
+	c.Ruling = nil
+	c.SettledTx = ""
+	c.UpdatedAt = time.Now()
+	err := s.cfg.Store.Create(c)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	s.publishAssigned(c)
+
+	counterparty := c.Counterparty(peerDID)
+	reply, err := s.send(ctx, counterparty, Message{Type: MsgAssigned, EscrowID: c.EscrowID, ArbiterDID: s.cfg.LocalDID})
+	if err != nil {
+		s.cfg.Logger.Warnw("confirm assignment to counterparty", "escrowID", c.EscrowID, "peer", counterparty, "error", err)
+		return &Reply{Accepted: true}, nil
+	}
+	s.mergePartyEvidence(ctx, c.EscrowID, counterparty, reply.Evidence)
+	return &Reply{Accepted: true}, nil
+}
+
+// handleAssigned records the arbiter's confirmation that it took a case this
+// node accepted a nomination for.
+func (s *Service) handleAssigned(peerDID string, msg Message) (*Reply, error) {
+	if err := s.confirmArbiter(msg.EscrowID, peerDID); err != nil {
+		return reject(err.Error()), nil
+	}
+	c, err := s.cfg.Store.Get(msg.EscrowID)
+	if err != nil {
+		return nil, err
+	}
+	return &Reply{Accepted: true, Evidence: s.ownEvidence(c)}, nil
+}
+
+// mergePartyEvidence records evidence that party sent directly to the
+// arbiter, keeping only the entries it submitted itself.
+func (s *Service) mergePartyEvidence(ctx context.Context, escrowID, party string, list []Evidence) {
+	var accepted []Evidence
+	for _, ev := range list {
+		if ev.SubmitterDID != party || ev.EscrowID != escrowID {
+			continue
+		}
+		if err := s.verifyEvidence(ctx, ev); err != nil {
+			s.cfg.Logger.Warnw("drop party evidence", "escrowID", escrowID, "peer", party, "error", err)
+			continue
+		}
+		accepted = append(accepted, ev)
+	}
+	if len(accepted) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.cfg.Store.Get(escrowID)
+	if err != nil || c.Ruling != nil {
+		return
+	}
+	for _, ev := range accepted {
+		c.Evidence = mergeEvidence(c.Evidence, ev)
+	}
+	c.UpdatedAt = time.Now()
+	if err := s.cfg.Store.Update(c); err != nil {
+		s.cfg.Logger.Warnw("record party evidence", "escrowID", escrowID, "error", err)
+	}
+}
+
+func (s *Service) handleEvidence(ctx context.Context, peerDID string, msg Message) (*Reply, error) {
+	ev := msg.Evidence
+	if ev == nil || ev.EscrowID != msg.EscrowID || ev.SubmitterDID != peerDID {
+		return reject(ErrInvalidEvidence.Error()), nil
+	}
+	if err := s.verifyEvidence(ctx, *ev); err != nil {
+		return reject(err.Error()), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.cfg.Store.Get(msg.EscrowID)
+	if err != nil {
+		return reject(ErrCaseNotFound.Error()), nil
+	}
+	if c.ArbiterDID != s.cfg.LocalDID {
+		return reject(ErrNotArbiter.Error()), nil
+	}
+	if r := c.RoleOf(peerDID); r != RoleBuyer && r != RoleSeller {
+		return reject(ErrNotParty.Error()), nil
+	}
+	if c.Ruling != nil {
+		return reject(ErrAlreadyRuled.Error()), nil
+	}
+	c.Evidence = mergeEvidence(c.Evidence, *ev)
+	c.UpdatedAt = time.Now()
+	if err := s.cfg.Store.Update(c); err != nil {
+		return nil, err
+	}
+	return &Reply{Accepted: true}, nil
+}
+
+func (s *Service) handleRuling(ctx context.Context, peerDID string, msg Message) (*Reply, error) {
+	r := msg.Ruling
+	if r == nil || r.EscrowID != msg.EscrowID {
+		return reject(ErrInvalidRuling.Error()), nil
+	}
+
+	s.mu.Lock()
+	c, err := s.cfg.Store.Get(msg.EscrowID)
+	if err != nil {
+		s.mu.Unlock()
+		return reject(ErrCaseNotFound.Error()), nil
+	}
+	if c.ArbiterDID == "" || c.ArbiterDID != peerDID || r.ArbiterDID != peerDID {
+		s.mu.Unlock()
+		return reject(ErrNotArbiter.Error()), nil
+	}
+	if err := VerifyRuling(*r, s.cfg.Verifiers); err != nil {
+		s.mu.Unlock()
+		return reject(err.Error()), nil
+	}
+	if c.Ruling != nil {
+		s.mu.Unlock()
+		if c.Ruling.SellerPercent == r.SellerPercent && c.Ruling.IssuedAt.Equal(r.IssuedAt) {
+			return &Reply{Accepted: true}, nil
+		}
+		return reject(ErrAlreadyRuled.Error()), nil
+	}
+	c.Ruling = r
+	c.Status = CaseRuled
+	c.UpdatedAt = time.Now()
+	err = s.cfg.Store.Update(c)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	s.publishRuling(c)
+
+	if _, err := s.ApplyRuling(ctx, msg.EscrowID); err != nil {
+		s.cfg.Logger.Warnw("settle arbitration ruling", "escrowID", msg.EscrowID, "error", err)
+		return &Reply{Accepted: true, Reason: "ruling recorded; settlement pending: " + err.Error()}, nil
+	}
+	return &Reply{Accepted: true}, nil
+}
+
+func (s *Service) send(ctx context.Context, peerDID string, msg Message) (*Reply, error) {
+	if s.cfg.Transport == nil {
+		return nil, fmt.Errorf("send %s to %s: transport not configured", msg.Type, peerDID)
+	}
+	reply, err := s.cfg.Transport(ctx, peerDID, msg)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, fmt.Errorf("send %s to %s: empty reply", msg.Type, peerDID)
+	}
+	return reply, nil
+}
+
+// confirmArbiter assigns a case whose nominee was accepted locally once
+// arbiterDID has shown that it holds the case.
+func (s *Service) confirmArbiter(escrowID, arbiterDID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.cfg.Store.Get(escrowID)
+	if err != nil {
+		return err
+	}
+	if c.ArbiterDID == "" || c.ArbiterDID != arbiterDID {
+		return ErrNotArbiter
+	}
+	if c.Status != CaseOpen {
+		return nil
+	}
+	c.Status = CaseAssigned
+	c.UpdatedAt = time.Now()
+	return s.cfg.Store.Update(c)
+}
+
+// ownEvidence returns the entries of c submitted by the local node.
+func (s *Service) ownEvidence(c *Case) []Evidence {
+	var own []Evidence
+	for _, ev := range c.Evidence {
+		if ev.SubmitterDID == s.cfg.LocalDID {
+			own = append(own, ev)
+		}
+	}
+	return own
+}
+
+func (s *Service) reject(escrowID, arbiter string) *Case {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.cfg.Store.Get(escrowID)
+	if err != nil {
+		return &Case{EscrowID: escrowID}
+	}
+	c.Rejected = append(c.Rejected, arbiter)
+	c.UpdatedAt = time.Now()
+	if err := s.cfg.Store.Update(c); err != nil {
+		s.cfg.Logger.Warnw("record rejected arbiter", "escrowID", escrowID, "error", err)
+	}
+	return c
+}
+
+func (s *Service) milestoneEvidence(escrowID, milestoneID string) (string, error) {
+	if s.cfg.Escrow == nil {
+		return "", nil
+	}
+	entry, err := s.cfg.Escrow.Get(escrowID)
+	if err != nil {
+		return "", nil
+	}
+	for _, m := range entry.Milestones {
+		if m.ID == milestoneID {
+			return m.Evidence, nil
+		}
+	}
+	return "", fmt.Errorf("%w: milestone %q not found", ErrInvalidEvidence, milestoneID)
+}
+
+func (s *Service) verifyEvidence(ctx context.Context, ev Evidence) error {
+	if len(ev.ProvenanceBundle) == 0 || s.cfg.VerifyBundle == nil {
+		return nil
+	}
+	if err := s.cfg.VerifyBundle(ctx, ev.SubmitterDID, ev.ProvenanceBundle); err != nil {
+		return fmt.Errorf("%w: provenance bundle: %v", ErrInvalidEvidence, err)
+	}
+	return nil
+}
+
+func (s *Service) publishAssigned(c *Case) {
+	if s.cfg.Bus == nil {
+		return
+	}
+	s.cfg.Bus.Publish(eventbus.EscrowArbiterAssignedEvent{
+		EscrowID:    c.EscrowID,
+		ArbiterDID:  c.ArbiterDID,
+		NominatedBy: c.NominatedBy,
+		BuyerDID:    c.BuyerDID,
+		SellerDID:   c.SellerDID,
+	})
+}
+
+func (s *Service) publishRuling(c *Case) {
+	if s.cfg.Bus == nil || c.Ruling == nil {
+		return
+	}
+	s.cfg.Bus.Publish(eventbus.EscrowRulingEvent{
+		EscrowID:      c.EscrowID,
+		ArbiterDID:    c.ArbiterDID,
+		BuyerDID:      c.BuyerDID,
+		SellerDID:     c.SellerDID,
+		NominatedBy:   c.NominatedBy,
+		SellerPercent: c.Ruling.SellerPercent,
+	})
+}
+
+// caseSummary returns the case fields shared with the counterparty on
+// nomination: parties, amount, and the sender's own evidence.
+func caseSummary(c *Case) *Case {
+	out := c.clone()
+	out.Rejected = nil
+	out.ReplaceArbiter = false
+	out.Acceptance = nil
+	out.Ruling = nil
+	out.SettledTx = ""
+	return out
+}
+
+// mergeEvidence appends ev unless evidence with the same ID is present.
+func mergeEvidence(list []Evidence, ev Evidence) []Evidence {
+	for _, e := range list {
+		if e.ID == ev.ID {
+			return list
+		}
+	}
+	return append(list, ev)
+}
+
+func reject(reason string) *Reply {
+	return &Reply{Reason: reason}
+}
@@ -0,0 +1,458 @@
+package arbitration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/economy/escrow"
+	"github.com/langoai/lango/internal/eventbus"
+)
+
+const (
+	buyerDID   = "did:lango:buyer"
+	sellerDID  = "did:lango:seller"
+	arbiterDID = "did:lango:arbiter"
+)
+
+// network routes messages between in-process services through a JSON round
+// trip, mirroring the wire protocol.
+type network struct {
+	nodes map[string]*Service
+}
+
+func (n *network) transport(from string) Transport {
+	return func(ctx context.Context, peerDID string, msg Message) (*Reply, error) {
+		node, ok := n.nodes[peerDID]
+		if !ok {
+			return nil, fmt.Errorf("peer %s unreachable", peerDID)
+		}
+		raw, err := json.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		var wire Message
+		if err := json.Unmarshal(raw, &wire); err != nil {
+			return nil, err
+		}
+		return node.HandleMessage(ctx, from, wire)
+	}
+}
+
+type recordingSettler struct {
+	escrow.NoopSettler
+	released []*big.Int
+	refunded []*big.Int
+}
+
+func (s *recordingSettler) Release(_ context.Context, _ string, amount *big.Int) error {
+	s.released = append(s.released, new(big.Int).Set(amount))
+	return nil
+}
+
+func (s *recordingSettler) Refund(_ context.Context, _ string, amount *big.Int) error {
+	s.refunded = append(s.refunded, new(big.Int).Set(amount))
+	return nil
+}
+
+type testNode struct {
+	svc     *Service
+	engine  *escrow.Engine
+	settler *recordingSettler
+}
+
+type fixture struct {
+	net       *network
+	verifiers map[string]SignatureVerifyFunc
+	buyer     *testNode
+	seller    *testNode
+	arbiter   *testNode
+	escrowID  string
+	bus       *eventbus.Bus
+}
+
+// newFixture creates a disputed escrow held by buyer and seller and three
+// arbitration services wired through an in-memory network.
+func newFixture(t *testing.T, rep map[string]float64) *fixture {
+	t.Helper()
+
+	entry := &escrow.EscrowEntry{
+		ID:          "esc-1",
+		BuyerDID:    buyerDID,
+		SellerDID:   sellerDID,
+		TotalAmount: big.NewInt(1000),
+		Status:      escrow.StatusDisputed,
+		Milestones: []escrow.Milestone{
+			{ID: "m1", Description: "draft", Amount: big.NewInt(1000), Status: escrow.MilestoneCompleted, Evidence: "ipfs://draft"},
+		},
+		DisputeNote: "incomplete delivery",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	signers := map[string]*testSigner{
+		buyerDID:   newTestSigner(t),
+		sellerDID:  newTestSigner(t),
+		arbiterDID: newTestSigner(t),
+	}
+	verifiers := testVerifiers(signers)
+	net := &network{nodes: make(map[string]*Service)}
+	bus := eventbus.New()
+
+	newNode := func(did string, withEscrow bool) *testNode {
+		n := &testNode{settler: &recordingSettler{}}
+		if withEscrow {
+			store := escrow.NewMemoryStore()
+			cp := *entry
+			require.NoError(t, store.Create(&cp))
+			n.engine = escrow.NewEngine(store, n.settler, escrow.DefaultEngineConfig())
+		}
+		n.svc = NewService(Config{
+			LocalDID:   did,
+			Escrow:     n.engine,
+			Selector:   NewSelector(SelectorConfig{Reputation: scores(rep)}),
+			Candidates: func() []string { return []string{"did:lango:other", arbiterDID} },
+			Transport:  net.transport(did),
+			Signer:     signers[did],
+			Verifiers:  verifiers,
+			Bus:        bus,
+		})
+		net.nodes[did] = n.svc
+		return n
+	}
+
+	f := &fixture{
+		net:       net,
+		verifiers: verifiers,
+		buyer:     newNode(buyerDID, true),
+		seller:    newNode(sellerDID, true),
+		arbiter:   newNode(arbiterDID, false),
+		escrowID:  entry.ID,
+		bus:       bus,
+	}
+	return f
+}
+
+func TestServiceArbitrationFlow(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newFixture(t, map[string]float64{arbiterDID: 0.9, "did:lango:other": 0.95})
+
+	var rulings []eventbus.EscrowRulingEvent
+	eventbus.SubscribeTyped(f.bus, func(ev eventbus.EscrowRulingEvent) { rulings = append(rulings, ev) })
+
+	// Buyer opens the case; milestone evidence is attached automatically.
+	c, err := f.buyer.svc.OpenCase(ctx, f.escrowID)
+	require.NoError(t, err)
+	assert.Equal(t, CaseOpen, c.Status)
+	require.Len(t, c.Evidence, 1)
+	assert.Equal(t, "ipfs://draft", c.Evidence[0].MilestoneEvidence)
+
+	_, err = f.buyer.svc.SubmitEvidence(ctx, EvidenceRequest{EscrowID: f.escrowID, Statement: "draft misses half the spec", MilestoneID: "m1"})
+	require.NoError(t, err)
+
+	// Seller opens its own case and submits evidence before nomination.
+	_, err = f.seller.svc.OpenCase(ctx, f.escrowID)
+	require.NoError(t, err)
+	_, err = f.seller.svc.SubmitEvidence(ctx, EvidenceRequest{EscrowID: f.escrowID, Statement: "spec changed after funding"})
+	require.NoError(t, err)
+
+	// did:lango:other ranks first but is unreachable, so it is rejected and
+	// the arbiter is agreed next.
+	c, err = f.buyer.svc.NominateArbiter(ctx, f.escrowID, "")
+	require.NoError(t, err)
+	assert.Equal(t, arbiterDID, c.ArbiterDID)
+	assert.Equal(t, CaseAssigned, c.Status)
+	assert.Equal(t, []string{"did:lango:other"}, c.Rejected)
+
+	sellerCase, err := f.seller.svc.Get(f.escrowID)
+	require.NoError(t, err)
+	assert.Equal(t, arbiterDID, sellerCase.ArbiterDID)
+	assert.Equal(t, buyerDID, sellerCase.NominatedBy)
+	assert.Equal(t, CaseAssigned, sellerCase.Status, "the arbiter confirms it took the case")
+
+	// The arbiter sees evidence from both parties.
+	arbCase, err := f.arbiter.svc.Get(f.escrowID)
+	require.NoError(t, err)
+	submitters := map[string]int{}
+	for _, ev := range arbCase.Evidence {
+		submitters[ev.SubmitterDID]++
+	}
+	assert.Equal(t, 1, submitters[buyerDID])
+	assert.Equal(t, 2, submitters[sellerDID]) // milestone evidence and statement
+
+	// Late evidence is forwarded to the arbiter.
+	_, err = f.seller.svc.SubmitEvidence(ctx, EvidenceRequest{EscrowID: f.escrowID, Statement: "late note"})
+	require.NoError(t, err)
+	arbCase, _ = f.arbiter.svc.Get(f.escrowID)
+	assert.Len(t, arbCase.Evidence, 4)
+
+	// Only the arbiter can rule.
+	_, err = f.buyer.svc.IssueRuling(ctx, f.escrowID, 0, "mine")
+	assert.ErrorIs(t, err, ErrNotArbiter)
+
+	r, err := f.arbiter.svc.IssueRuling(ctx, f.escrowID, 60, "partial delivery")
+	require.NoError(t, err)
+	assert.Equal(t, 60, r.SellerPercent)
+
+	for _, n := range []*testNode{f.buyer, f.seller} {
+		got, err := n.svc.Get(f.escrowID)
+		require.NoError(t, err)
+		assert.Equal(t, CaseSettled, got.Status)
+		entry, err := n.engine.Get(f.escrowID)
+		require.NoError(t, err)
+		assert.Equal(t, escrow.StatusResolved, entry.Status)
+		require.Len(t, n.settler.released, 1)
+		assert.Equal(t, int64(600), n.settler.released[0].Int64())
+		require.Len(t, n.settler.refunded, 1)
+		assert.Equal(t, int64(400), n.settler.refunded[0].Int64())
+	}
+
+	// Issued once by the arbiter and received by both parties.
+	assert.Len(t, rulings, 3)
+
+	_, err = f.arbiter.svc.IssueRuling(ctx, f.escrowID, 10, "again")
+	assert.ErrorIs(t, err, ErrAlreadyRuled)
+}
+
+func TestServiceNominate_Untrusted(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newFixture(t, map[string]float64{arbiterDID: 0.9})
+
+	// The seller does not trust the arbiter in its own view.
+	f.seller.svc.cfg.Selector = NewSelector(SelectorConfig{Reputation: scores(map[string]float64{arbiterDID: 0.2})})
+
+	_, err := f.buyer.svc.OpenCase(ctx, f.escrowID)
+	require.NoError(t, err)
+	_, err = f.buyer.svc.NominateArbiter(ctx, f.escrowID, "")
+	assert.ErrorIs(t, err, ErrNoCandidates)
+
+	c, err := f.buyer.svc.Get(f.escrowID)
+	require.NoError(t, err)
+	assert.Equal(t, CaseOpen, c.Status)
+	assert.Equal(t, []string{arbiterDID}, c.Rejected)
+
+	_, err = f.arbiter.svc.Get(f.escrowID)
+	assert.ErrorIs(t, err, ErrCaseNotFound)
+}
+
+func TestServiceNominate_AssignedArbiterKept(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	const otherDID = "did:lango:other"
+	f := newFixture(t, map[string]float64{arbiterDID: 0.9, otherDID: 0.95})
+	f.net.nodes[otherDID] = NewService(Config{LocalDID: otherDID, Transport: f.net.transport(otherDID), Verifiers: f.verifiers})
+
+	_, err := f.buyer.svc.OpenCase(ctx, f.escrowID)
+	require.NoError(t, err)
+	_, err = f.buyer.svc.NominateArbiter(ctx, f.escrowID, arbiterDID)
+	require.NoError(t, err)
+	_, err = f.seller.svc.SubmitEvidence(ctx, EvidenceRequest{EscrowID: f.escrowID, Statement: "spec changed after funding"})
+	require.NoError(t, err)
+
+	// Neither party can swap the agreed arbiter on its own.
+	_, err = f.buyer.svc.NominateArbiter(ctx, f.escrowID, otherDID)
+	assert.ErrorIs(t, err, ErrArbiterAssigned)
+	buyerCase, err := f.buyer.svc.Get(f.escrowID)
+	require.NoError(t, err)
+	reply, err := f.seller.svc.HandleMessage(ctx, buyerDID, Message{
+		Type: MsgNominate, EscrowID: f.escrowID, ArbiterDID: otherDID, Case: caseSummary(buyerCase),
+	})
+	require.NoError(t, err)
+	assert.False(t, reply.Accepted)
+	assert.Equal(t, ErrArbiterAssigned.Error(), reply.Reason)
+
+	// Agreement from one party is not enough.
+	_, err = f.buyer.svc.AgreeArbiterReplacement(f.escrowID)
+	require.NoError(t, err)
+	_, err = f.buyer.svc.NominateArbiter(ctx, f.escrowID, otherDID)
+	assert.ErrorIs(t, err, ErrArbiterAssigned)
+	sellerCase, err := f.seller.svc.Get(f.escrowID)
+	require.NoError(t, err)
+	assert.Equal(t, arbiterDID, sellerCase.ArbiterDID)
+
+	// Once both agree, the arbiter is replaced.
+	_, err = f.seller.svc.AgreeArbiterReplacement(f.escrowID)
+	require.NoError(t, err)
+	c, err := f.buyer.svc.NominateArbiter(ctx, f.escrowID, "")
+	require.NoError(t, err)
+	assert.Equal(t, otherDID, c.ArbiterDID)
+	assert.False(t, c.ReplaceArbiter)
+	assert.Contains(t, c.Rejected, arbiterDID)
+
+	sellerCase, err = f.seller.svc.Get(f.escrowID)
+	require.NoError(t, err)
+	assert.Equal(t, otherDID, sellerCase.ArbiterDID)
+	assert.Equal(t, CaseAssigned, sellerCase.Status)
+	assert.False(t, sellerCase.ReplaceArbiter)
+
+	// The replaced arbiter can no longer rule.
+	_, err = f.arbiter.svc.IssueRuling(ctx, f.escrowID, 100, "late")
+	assert.Error(t, err)
+	entry, err := f.seller.engine.Get(f.escrowID)
+	require.NoError(t, err)
+	assert.Equal(t, escrow.StatusDisputed, entry.Status)
+}
+
+func TestServiceHandleAssign_NominatorCannotSpeakForCounterparty(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newFixture(t, map[string]float64{arbiterDID: 0.9})
+
+	c, err := f.buyer.svc.OpenCase(ctx, f.escrowID)
+	require.NoError(t, err)
+	assigned := c.clone()
+	assigned.ArbiterDID = arbiterDID
+	assigned.Status = CaseAssigned
+	assigned.Evidence = append(assigned.Evidence, Evidence{
+		ID: "forged", EscrowID: f.escrowID, SubmitterDID: sellerDID, Role: RoleSeller,
+		Statement: "the seller concedes", SubmittedAt: time.Now(),
+	})
+	msg := Message{Type: MsgAssign, EscrowID: f.escrowID, ArbiterDID: arbiterDID, Case: assigned}
+
+	// Without the seller's signed acceptance the arbiter refuses the case.
+	reply, err := f.arbiter.svc.HandleMessage(ctx, buyerDID, msg)
+	require.NoError(t, err)
+	assert.False(t, reply.Accepted)
+	_, err = f.arbiter.svc.Get(f.escrowID)
+	assert.ErrorIs(t, err, ErrCaseNotFound)
+
+	// An acceptance signed by the buyer itself is not the seller's.
+	forged := &Acceptance{EscrowID: f.escrowID, ArbiterDID: arbiterDID, NominatorDID: buyerDID, AccepterDID: sellerDID, AcceptedAt: time.Now()}
+	require.NoError(t, SignAcceptance(ctx, forged, f.buyer.svc.cfg.Signer))
+	assigned.Acceptance = forged
+	reply, err = f.arbiter.svc.HandleMessage(ctx, buyerDID, msg)
+	require.NoError(t, err)
+	assert.False(t, reply.Accepted)
+
+	// With a real acceptance, evidence attributed to the seller by the buyer
+	// is dropped.
+	acc := &Acceptance{EscrowID: f.escrowID, ArbiterDID: arbiterDID, NominatorDID: buyerDID, AccepterDID: sellerDID, AcceptedAt: time.Now()}
+	require.NoError(t, SignAcceptance(ctx, acc, f.seller.svc.cfg.Signer))
+	assigned.Acceptance = acc
+	reply, err = f.arbiter.svc.HandleMessage(ctx, buyerDID, msg)
+	require.NoError(t, err)
+	require.True(t, reply.Accepted)
+
+	arbCase, err := f.arbiter.svc.Get(f.escrowID)
+	require.NoError(t, err)
+	for _, ev := range arbCase.Evidence {
+		assert.NotEqual(t, "forged", ev.ID)
+		assert.NotEqual(t, "milestone:m1", ev.ID, "milestone evidence is the seller's to submit")
+	}
+}
+
+func TestServiceIssueRuling_RequiresAcceptance(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newFixture(t, map[string]float64{arbiterDID: 0.9})
+
+	require.NoError(t, f.arbiter.svc.cfg.Store.Create(&Case{
+		EscrowID:   f.escrowID,
+		BuyerDID:   buyerDID,
+		SellerDID:  sellerDID,
+		ArbiterDID: arbiterDID,
+		Status:     CaseAssigned,
+	}))
+	_, err := f.arbiter.svc.IssueRuling(ctx, f.escrowID, 100, "no agreement")
+	assert.ErrorIs(t, err, ErrNotAccepted)
+}
+
+func TestServiceHandleRuling_Rejects(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newFixture(t, map[string]float64{arbiterDID: 0.9})
+
+	_, err := f.buyer.svc.OpenCase(ctx, f.escrowID)
+	require.NoError(t, err)
+	_, err = f.buyer.svc.NominateArbiter(ctx, f.escrowID, arbiterDID)
+	require.NoError(t, err)
+
+	forged := &Ruling{EscrowID: f.escrowID, ArbiterDID: arbiterDID, SellerPercent: 100, IssuedAt: time.Now()}
+	require.NoError(t, SignRuling(ctx, forged, newTestSigner(t)))
+
+	tests := []struct {
+		give     string
+		giveFrom string
+		giveRule *Ruling
+	}{
+		{give: "forged signature", giveFrom: arbiterDID, giveRule: forged},
+		{give: "sender not arbiter", giveFrom: sellerDID, giveRule: forged},
+	}
+	for _, tt := range tests {
+		reply, err := f.buyer.svc.HandleMessage(ctx, tt.giveFrom, Message{Type: MsgRuling, EscrowID: f.escrowID, Ruling: tt.giveRule})
+		require.NoError(t, err, tt.give)
+		assert.False(t, reply.Accepted, tt.give)
+	}
+
+	entry, err := f.buyer.engine.Get(f.escrowID)
+	require.NoError(t, err)
+	assert.Equal(t, escrow.StatusDisputed, entry.Status)
+}
+
+func TestServiceBundleVerification(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	f := newFixture(t, map[string]float64{arbiterDID: 0.9})
+	f.buyer.svc.cfg.VerifyBundle = func(_ context.Context, did string, bundle []byte) error {
+		if string(bundle) != "valid:"+did {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	}
+
+	_, err := f.buyer.svc.OpenCase(ctx, f.escrowID)
+	require.NoError(t, err)
+
+	_, err = f.buyer.svc.SubmitEvidence(ctx, EvidenceRequest{EscrowID: f.escrowID, ProvenanceBundle: []byte("tampered")})
+	assert.ErrorIs(t, err, ErrInvalidEvidence)
+
+	ev, err := f.buyer.svc.SubmitEvidence(ctx, EvidenceRequest{EscrowID: f.escrowID, ProvenanceBundle: []byte("valid:" + buyerDID)})
+	require.NoError(t, err)
+	assert.Equal(t, RoleBuyer, ev.Role)
+
+	_, err = f.buyer.svc.SubmitEvidence(ctx, EvidenceRequest{EscrowID: f.escrowID, Statement: "x", MilestoneID: "missing"})
+	assert.ErrorIs(t, err, ErrInvalidEvidence)
+}
+
+func TestServiceOpenCase_NotDisputed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	engine := escrow.NewEngine(escrow.NewMemoryStore(), escrow.NoopSettler{}, escrow.DefaultEngineConfig())
+	entry, err := engine.Create(ctx, escrow.CreateRequest{
+		BuyerDID: buyerDID, SellerDID: sellerDID, Amount: big.NewInt(10), Reason: "test",
+		Milestones: []escrow.MilestoneRequest{{Description: "all", Amount: big.NewInt(10)}},
+	})
+	require.NoError(t, err)
+
+	svc := NewService(Config{LocalDID: buyerDID, Escrow: engine})
+	_, err = svc.OpenCase(ctx, entry.ID)
+	assert.ErrorIs(t, err, ErrNotDisputed)
+
+	svc = NewService(Config{LocalDID: "did:lango:stranger", Escrow: engine})
+	_, err = engine.Fund(ctx, entry.ID)
+	require.NoError(t, err)
+	_, err = engine.Activate(ctx, entry.ID)
+	require.NoError(t, err)
+	_, err = engine.Dispute(ctx, entry.ID, "x")
+	require.NoError(t, err)
+	_, err = svc.OpenCase(ctx, entry.ID)
+	assert.ErrorIs(t, err, ErrNotParty)
+}
@@ -0,0 +1,71 @@
+package arbitration
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Store persists arbitration cases keyed by escrow ID.
+type Store interface {
+	Create(c *Case) error
+	Get(escrowID string) (*Case, error)
+	Update(c *Case) error
+	List() []*Case
+}
+
+// memoryStore implements Store in-memory.
+type memoryStore struct {
+	mu    sync.RWMutex
+	cases map[string]*Case
+}
+
+// NewMemoryStore creates a new in-memory case store.
+func NewMemoryStore() Store {
+	return &memoryStore{cases: make(map[string]*Case)}
+}
+
+func (s *memoryStore) Create(c *Case) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.cases[c.EscrowID]; exists {
+		return fmt.Errorf("create %q: %w", c.EscrowID, ErrCaseExists)
+	}
+	s.cases[c.EscrowID] = c.clone()
+	return nil
+}
+
+func (s *memoryStore) Get(escrowID string) (*Case, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.cases[escrowID]
+	if !ok {
+		return nil, fmt.Errorf("get %q: %w", escrowID, ErrCaseNotFound)
+	}
+	return c.clone(), nil
+}
+
+func (s *memoryStore) Update(c *Case) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.cases[c.EscrowID]; !ok {
+		return fmt.Errorf("update %q: %w", c.EscrowID, ErrCaseNotFound)
+	}
+	s.cases[c.EscrowID] = c.clone()
+	return nil
+}
+
+func (s *memoryStore) List() []*Case {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]*Case, 0, len(s.cases))
+	for _, c := range s.cases {
+		out = append(out, c.clone())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].OpenedAt.Before(out[j].OpenedAt) })
+	return out
+}
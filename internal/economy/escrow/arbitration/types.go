@@ -0,0 +1,159 @@
+// Package arbitration implements third-party dispute resolution for escrow
+// deals: evidence exchange, mutually agreed arbiter selection, signed rulings,
+// and settlement of rulings through the escrow engine.
+package arbitration
+
+import (
+	"errors"
+	"time"
+)
+
+// CaseStatus represents the state of an arbitration case.
+type CaseStatus string
+
+const (
+	// CaseOpen collects evidence; no arbiter has been agreed yet. A
+	// counterparty that accepted a nomination keeps the case open, with the
+	// nominee set, until the arbiter confirms it took the case.
+	CaseOpen CaseStatus = "open"
+	// CaseAssigned has an arbiter accepted by both parties.
+	CaseAssigned CaseStatus = "assigned"
+	// CaseRuled has a signed ruling that has not been settled locally.
+	CaseRuled CaseStatus = "ruled"
+	// CaseSettled has its ruling settled on the escrow.
+	CaseSettled CaseStatus = "settled"
+)
+
+// Role identifies a participant in a case.
+type Role string
+
+const (
+	RoleBuyer   Role = "buyer"
+	RoleSeller  Role = "seller"
+	RoleArbiter Role = "arbiter"
+)
+
+// Evidence is a statement submitted by one party, optionally linked to an
+// escrow milestone and backed by a signed provenance bundle.
+type Evidence struct {
+	ID                string    `json:"id"`
+	EscrowID          string    `json:"escrowId"`
+	SubmitterDID      string    `json:"submitterDid"`
+	Role              Role      `json:"role"`
+	MilestoneID       string    `json:"milestoneId,omitempty"`
+	MilestoneEvidence string    `json:"milestoneEvidence,omitempty"` // copied from the escrow milestone
+	Statement         string    `json:"statement"`
+	ProvenanceBundle  []byte    `json:"provenanceBundle,omitempty"` // signed provenance bundle JSON
+	SubmittedAt       time.Time `json:"submittedAt"`
+}
+
+// Ruling is an arbiter's signed decision on how to split the escrowed funds.
+type Ruling struct {
+	EscrowID      string    `json:"escrowId"`
+	ArbiterDID    string    `json:"arbiterDid"`
+	SellerPercent int       `json:"sellerPercent"` // 0..100, remainder goes to the buyer
+	Rationale     string    `json:"rationale"`
+	IssuedAt      time.Time `json:"issuedAt"`
+	Algorithm     string    `json:"algorithm"`
+	Signature     []byte    `json:"signature"`
+}
+
+// FavoredRole returns the party the ruling favors, or "" for an even split.
+func (r *Ruling) FavoredRole() Role {
+	switch {
+	case r.SellerPercent > 50:
+		return RoleSeller
+	case r.SellerPercent < 50:
+		return RoleBuyer
+	}
+	return ""
+}
+
+// Acceptance is the counterparty's signed agreement to an arbiter nominated
+// by the other party. The arbiter only rules on cases that carry one.
+type Acceptance struct {
+	EscrowID     string    `json:"escrowId"`
+	ArbiterDID   string    `json:"arbiterDid"`
+	NominatorDID string    `json:"nominatorDid"`
+	AccepterDID  string    `json:"accepterDid"`
+	AcceptedAt   time.Time `json:"acceptedAt"`
+	Algorithm    string    `json:"algorithm"`
+	Signature    []byte    `json:"signature"`
+}
+
+// Case is the local view of an arbitration case. Parties and the arbiter
+// each keep their own copy.
+type Case struct {
+	EscrowID       string      `json:"escrowId"`
+	BuyerDID       string      `json:"buyerDid"`
+	SellerDID      string      `json:"sellerDid"`
+	TotalAmount    string      `json:"totalAmount"` // base units, decimal
+	DisputeNote    string      `json:"disputeNote,omitempty"`
+	ArbiterDID     string      `json:"arbiterDid,omitempty"`
+	NominatedBy    string      `json:"nominatedBy,omitempty"`
+	Rejected       []string    `json:"rejected,omitempty"`       // arbiters declined by a party or by themselves
+	ReplaceArbiter bool        `json:"replaceArbiter,omitempty"` // the local party agreed to replace the arbiter
+	Status         CaseStatus  `json:"status"`
+	Evidence       []Evidence  `json:"evidence,omitempty"`
+	Acceptance     *Acceptance `json:"acceptance,omitempty"` // counterparty agreement to ArbiterDID
+	Ruling         *Ruling     `json:"ruling,omitempty"`
+	SettledTx      string      `json:"settledTx,omitempty"`
+	OpenedAt       time.Time   `json:"openedAt"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+}
+
+// RoleOf returns the role of did in the case, or "" if it does not take part.
+func (c *Case) RoleOf(did string) Role {
+	if did == "" {
+		return ""
+	}
+	switch did {
+	case c.BuyerDID:
+		return RoleBuyer
+	case c.SellerDID:
+		return RoleSeller
+	case c.ArbiterDID:
+		return RoleArbiter
+	}
+	return ""
+}
+
+// Counterparty returns the other party's DID.
+func (c *Case) Counterparty(did string) string {
+	if did == c.BuyerDID {
+		return c.SellerDID
+	}
+	return c.BuyerDID
+}
+
+// clone returns a deep copy safe to hand out of the service lock.
+func (c *Case) clone() *Case {
+	out := *c
+	out.Rejected = append([]string(nil), c.Rejected...)
+	out.Evidence = append([]Evidence(nil), c.Evidence...)
+	if c.Acceptance != nil {
+		a := *c.Acceptance
+		out.Acceptance = &a
+	}
+	if c.Ruling != nil {
+		r := *c.Ruling
+		out.Ruling = &r
+	}
+	return &out
+}
+
+var (
+	ErrCaseNotFound     = errors.New("arbitration case not found")
+	ErrCaseExists       = errors.New("arbitration case already exists")
+	ErrNotDisputed      = errors.New("escrow is not disputed")
+	ErrNotParty         = errors.New("not a party to the escrow")
+	ErrNotArbiter       = errors.New("not the agreed arbiter")
+	ErrNoArbiter        = errors.New("no arbiter assigned")
+	ErrArbiterAssigned  = errors.New("arbiter already assigned")
+	ErrNoCandidates     = errors.New("no mutually trusted arbiter available")
+	ErrInvalidRuling    = errors.New("invalid ruling")
+	ErrInvalidSignature = errors.New("invalid ruling signature")
+	ErrAlreadyRuled     = errors.New("case already ruled")
+	ErrInvalidEvidence  = errors.New("invalid evidence")
+	ErrNotAccepted      = errors.New("arbiter not accepted by counterparty")
+)
@@ -18,6 +18,7 @@ var (
 	ErrNoMilestones      = errors.New("escrow has no milestones")
 	ErrTooManyMilestones = errors.New("too many milestones")
 	ErrInvalidAmount     = errors.New("milestone amounts do not match total")
+	ErrInvalidSplit      = errors.New("seller percent must be between 0 and 100")
 )
 
 // SettlementExecutor handles actual fund transfer operations.
@@ -27,6 +28,20 @@ type SettlementExecutor interface {
 	Refund(ctx context.Context, buyerDID string, amount *big.Int) error
 }
 
+// DisputeResolver is implemented by settlers that can split disputed funds
+// between seller and buyer in a single operation (e.g. on-chain resolve).
+type DisputeResolver interface {
+	ResolveDispute(ctx context.Context, escrowID string, sellerFavor bool, sellerAmount, buyerAmount *big.Int) (string, error)
+}
+
+// Resolution describes how a disputed escrow was settled.
+type Resolution struct {
+	Entry        *EscrowEntry
+	SellerAmount *big.Int
+	BuyerAmount  *big.Int
+	TxHash       string // set when settled through a DisputeResolver
+}
+
 // EngineConfig holds engine configuration.
 type EngineConfig struct {
 	DefaultTimeout time.Duration
@@ -305,6 +320,59 @@ func (e *Engine) Refund(ctx context.Context, escrowID string) (*EscrowEntry, err
 	return entry, nil
 }
 
+// ResolveDispute settles a disputed escrow by paying sellerPercent of the
+// total to the seller and the remainder to the buyer, then transitions
+// disputed -> resolved. Settlers implementing DisputeResolver settle the split
+// in one operation; others receive a Release and a Refund for the two parts.
+func (e *Engine) ResolveDispute(ctx context.Context, escrowID string, sellerPercent int) (*Resolution, error) {
+	if sellerPercent < 0 || sellerPercent > 100 {
+		return nil, fmt.Errorf("got %d: %w", sellerPercent, ErrInvalidSplit)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	entry, err := e.store.Get(escrowID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTransition(entry.Status, StatusResolved); err != nil {
+		return nil, err
+	}
+
+	sellerAmount := new(big.Int).Mul(entry.TotalAmount, big.NewInt(int64(sellerPercent)))
+	sellerAmount.Div(sellerAmount, big.NewInt(100))
+	buyerAmount := new(big.Int).Sub(entry.TotalAmount, sellerAmount)
+
+	res := &Resolution{SellerAmount: sellerAmount, BuyerAmount: buyerAmount}
+	if dr, ok := e.settler.(DisputeResolver); ok {
+		txHash, err := dr.ResolveDispute(ctx, escrowID, sellerPercent >= 50, sellerAmount, buyerAmount)
+		if err != nil {
+			return nil, fmt.Errorf("resolve dispute: %w", err)
+		}
+		res.TxHash = txHash
+	} else {
+		if sellerAmount.Sign() > 0 {
+			if err := e.settler.Release(ctx, entry.SellerDID, sellerAmount); err != nil {
+				return nil, fmt.Errorf("resolve release: %w", err)
+			}
+		}
+		if buyerAmount.Sign() > 0 {
+			if err := e.settler.Refund(ctx, entry.BuyerDID, buyerAmount); err != nil {
+				return nil, fmt.Errorf("resolve refund: %w", err)
+			}
+		}
+	}
+
+	entry.Status = StatusResolved
+	if err := e.store.Update(entry); err != nil {
+		return nil, err
+	}
+	res.Entry = entry
+	return res, nil
+}
+
 // Expire marks a timed-out escrow as expired and refunds if funded.
 func (e *Engine) Expire(ctx context.Context, escrowID string) (*EscrowEntry, error) {
 	e.mu.Lock()
@@ -372,6 +372,101 @@ func TestEngineRefund(t *testing.T) {
 	assert.Len(t, settler.refunded, 1)
 }
 
+// resolvingSettler records single-operation dispute resolutions.
+type resolvingSettler struct {
+	mockSettler
+	sellerFavor  bool
+	sellerAmount *big.Int
+	buyerAmount  *big.Int
+}
+
+func (r *resolvingSettler) ResolveDispute(_ context.Context, _ string, sellerFavor bool, sellerAmount, buyerAmount *big.Int) (string, error) {
+	r.sellerFavor = sellerFavor
+	r.sellerAmount = sellerAmount
+	r.buyerAmount = buyerAmount
+	return "0xresolve", nil
+}
+
+func TestEngineResolveDispute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give        string
+		percent     int
+		wantRelease []*big.Int
+		wantRefund  []*big.Int
+	}{
+		{give: "split", percent: 70, wantRelease: []*big.Int{big.NewInt(700)}, wantRefund: []*big.Int{big.NewInt(300)}},
+		{give: "all to seller", percent: 100, wantRelease: []*big.Int{big.NewInt(1000)}},
+		{give: "all to buyer", percent: 0, wantRefund: []*big.Int{big.NewInt(1000)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+
+			settler := &mockSettler{}
+			e := newTestEngine(settler)
+			ctx := context.Background()
+			funded := createFundedEscrow(t, e, settler)
+			active, _ := e.Activate(ctx, funded.ID)
+			disputed, _ := e.Dispute(ctx, active.ID, "issue")
+
+			res, err := e.ResolveDispute(ctx, disputed.ID, tt.percent)
+			require.NoError(t, err)
+			assert.Equal(t, StatusResolved, res.Entry.Status)
+			assert.Equal(t, tt.wantRelease, settler.released)
+			assert.Equal(t, tt.wantRefund, settler.refunded)
+			assert.Empty(t, res.TxHash)
+		})
+	}
+}
+
+func TestEngineResolveDispute_DisputeResolver(t *testing.T) {
+	t.Parallel()
+
+	settler := &resolvingSettler{}
+	e := NewEngine(NewMemoryStore(), settler, DefaultEngineConfig())
+	ctx := context.Background()
+	entry, err := e.Create(ctx, CreateRequest{
+		BuyerDID:   "did:buyer:1",
+		SellerDID:  "did:seller:1",
+		Amount:     big.NewInt(1000),
+		Milestones: []MilestoneRequest{{Description: "all", Amount: big.NewInt(1000)}},
+	})
+	require.NoError(t, err)
+	_, err = e.Fund(ctx, entry.ID)
+	require.NoError(t, err)
+	_, err = e.Activate(ctx, entry.ID)
+	require.NoError(t, err)
+	_, err = e.Dispute(ctx, entry.ID, "issue")
+	require.NoError(t, err)
+
+	res, err := e.ResolveDispute(ctx, entry.ID, 25)
+	require.NoError(t, err)
+	assert.Equal(t, "0xresolve", res.TxHash)
+	assert.False(t, settler.sellerFavor)
+	assert.Equal(t, big.NewInt(250), settler.sellerAmount)
+	assert.Equal(t, big.NewInt(750), settler.buyerAmount)
+	assert.Empty(t, settler.released, "no separate release")
+	assert.Empty(t, settler.refunded, "no separate refund")
+}
+
+func TestEngineResolveDispute_Invalid(t *testing.T) {
+	t.Parallel()
+
+	settler := &mockSettler{}
+	e := newTestEngine(settler)
+	ctx := context.Background()
+	funded := createFundedEscrow(t, e, settler)
+
+	_, err := e.ResolveDispute(ctx, funded.ID, 50)
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+
+	_, err = e.ResolveDispute(ctx, funded.ID, 101)
+	assert.ErrorIs(t, err, ErrInvalidSplit)
+}
+
 func TestEngineRefund_InvalidTransition(t *testing.T) {
 	t.Parallel()
 
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"reflect"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/langoai/lango/internal/contract"
 )
@@ -71,14 +73,34 @@ func (c *HubClient) CreateDeal(ctx context.Context, seller, token common.Address
 		return nil, "", fmt.Errorf("create deal: %w", err)
 	}
 
-	// Parse dealId from return value (uint256).
-	var dealID *big.Int
+	// Parse dealId from return value (uint256). A mined transaction has no
+	// return data, so fall back to the DealCreated event in its receipt.
 	if len(result.Data) > 0 {
 		if id, ok := result.Data[0].(*big.Int); ok {
-			dealID = id
+			return id, result.TxHash, nil
 		}
 	}
-	return dealID, result.TxHash, nil
+	return c.dealCreatedID(result.Logs), result.TxHash, nil
+}
+
+// dealCreatedID returns the dealId of the DealCreated event emitted by the
+// hub in logs, or nil when there is none.
+func (c *HubClient) dealCreatedID(logs []*types.Log) *big.Int {
+	parsed, err := contract.ParseABI(c.abiJSON)
+	if err != nil {
+		return nil
+	}
+	event, ok := parsed.Events["DealCreated"]
+	if !ok {
+		return nil
+	}
+	for _, log := range logs {
+		if log.Address != c.address || len(log.Topics) < 2 || log.Topics[0] != event.ID {
+			continue
+		}
+		return new(big.Int).SetBytes(log.Topics[1].Bytes())
+	}
+	return nil
 }
 
 // Deposit deposits ERC-20 tokens into the escrow for a deal.
@@ -140,8 +162,9 @@ func parseDealResult(data []interface{}) (*OnChainDeal, error) {
 		return nil, fmt.Errorf("empty deal result")
 	}
 
-	// Try direct struct assertion.
-	if d, ok := data[0].(struct {
+	// The ABI decoder returns an anonymous struct with json tags; convert
+	// it, since tags do not take part in struct conversion.
+	var d struct {
 		Buyer    common.Address
 		Seller   common.Address
 		Token    common.Address
@@ -149,7 +172,9 @@ func parseDealResult(data []interface{}) (*OnChainDeal, error) {
 		Deadline *big.Int
 		Status   uint8
 		WorkHash [32]byte
-	}); ok {
+	}
+	if v := reflect.ValueOf(data[0]); v.IsValid() && v.Type().ConvertibleTo(reflect.TypeOf(d)) {
+		reflect.ValueOf(&d).Elem().Set(v.Convert(reflect.TypeOf(d)))
 		return &OnChainDeal{
 			Buyer:    d.Buyer,
 			Seller:   d.Seller,
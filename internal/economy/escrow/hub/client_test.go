@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -37,6 +38,34 @@ func TestHubClient_CreateDeal_Success(t *testing.T) {
 	assert.Equal(t, "createDeal", mc.writeCalls[0].Method)
 }
 
+func TestHubClient_CreateDeal_FromReceiptLog(t *testing.T) {
+	t.Parallel()
+	parsed, err := ParseHubABI()
+	require.NoError(t, err)
+
+	hubAddr := common.HexToAddress("0x1")
+	mc := newMockCaller()
+	mc.writeResult = &contract.ContractCallResult{
+		TxHash: "0xabc",
+		Logs: []*types.Log{
+			{Address: common.HexToAddress("0x9"), Topics: []common.Hash{parsed.Events["DealCreated"].ID, common.BigToHash(big.NewInt(1))}},
+			{Address: hubAddr, Topics: []common.Hash{parsed.Events["DealCreated"].ID, common.BigToHash(big.NewInt(7))}},
+		},
+	}
+
+	client := NewHubClient(mc, hubAddr, 1)
+	dealID, _, err := client.CreateDeal(
+		context.Background(),
+		common.HexToAddress("0x2"),
+		common.HexToAddress("0x3"),
+		big.NewInt(1000),
+		big.NewInt(9999),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(7), dealID)
+}
+
 func TestHubClient_CreateDeal_Error(t *testing.T) {
 	t.Parallel()
 	mc := newMockCaller()
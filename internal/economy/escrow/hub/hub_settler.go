@@ -14,8 +14,11 @@ import (
 	"github.com/langoai/lango/internal/economy/escrow"
 )
 
-// Compile-time check.
-var _ escrow.SettlementExecutor = (*HubSettler)(nil)
+// Compile-time checks.
+var (
+	_ escrow.SettlementExecutor = (*HubSettler)(nil)
+	_ escrow.DisputeResolver    = (*HubSettler)(nil)
+)
 
 // HubSettler implements SettlementExecutor using the LangoEscrowHub contract.
 // Lock creates a deal + deposits on the hub. Release/Refund delegate to the hub.
@@ -182,6 +185,30 @@ func (s *HubSettler) Refund(ctx context.Context, buyerDID string, amount *big.In
 	return nil
 }
 
+// ResolveDispute splits a disputed deal on the hub contract.
+// If the hub client is nil (offline mode), this is a no-op.
+func (s *HubSettler) ResolveDispute(ctx context.Context, escrowID string, sellerFavor bool, sellerAmount, buyerAmount *big.Int) (string, error) {
+	if s.hub == nil {
+		s.logger.Warnw("hub client nil, skipping on-chain resolve", "escrowID", escrowID)
+		return "", nil
+	}
+
+	dealID, ok := s.GetDealID(escrowID)
+	if !ok {
+		return "", fmt.Errorf("resolve: no deal mapping for escrow %s", escrowID)
+	}
+
+	txHash, err := s.hub.ResolveDispute(ctx, dealID, sellerFavor, sellerAmount, buyerAmount)
+	if err != nil {
+		return "", fmt.Errorf("resolve deal %s: %w", dealID, err)
+	}
+
+	s.logger.Infow("dispute resolved on-chain",
+		"dealID", dealID, "txHash", txHash, "escrowID", escrowID,
+		"sellerAmount", sellerAmount, "buyerAmount", buyerAmount)
+	return txHash, nil
+}
+
 // HubClient exposes the underlying hub client for direct operations.
 func (s *HubSettler) HubClient() *HubClient {
 	return s.hub
@@ -13,8 +13,11 @@ import (
 	"github.com/langoai/lango/internal/economy/escrow"
 )
 
-// Compile-time check.
-var _ escrow.SettlementExecutor = (*VaultSettler)(nil)
+// Compile-time checks.
+var (
+	_ escrow.SettlementExecutor = (*VaultSettler)(nil)
+	_ escrow.DisputeResolver    = (*VaultSettler)(nil)
+)
 
 // VaultSettler implements SettlementExecutor using per-deal LangoVault contracts
 // created via the LangoVaultFactory.
@@ -106,6 +109,24 @@ func (s *VaultSettler) Refund(ctx context.Context, buyerDID string, amount *big.
 	return nil
 }
 
+// ResolveDispute splits a disputed vault between seller and buyer.
+func (s *VaultSettler) ResolveDispute(ctx context.Context, escrowID string, sellerFavor bool, sellerAmount, buyerAmount *big.Int) (string, error) {
+	vaultAddr, ok := s.GetVaultAddress(escrowID)
+	if !ok {
+		return "", fmt.Errorf("resolve: no vault mapping for escrow %s", escrowID)
+	}
+
+	txHash, err := s.VaultClientFor(vaultAddr).Resolve(ctx, sellerFavor, sellerAmount, buyerAmount)
+	if err != nil {
+		return "", fmt.Errorf("resolve vault %s: %w", vaultAddr.Hex(), err)
+	}
+
+	s.logger.Infow("vault dispute resolved",
+		"vault", vaultAddr.Hex(), "txHash", txHash, "escrowID", escrowID,
+		"sellerAmount", sellerAmount, "buyerAmount", buyerAmount)
+	return txHash, nil
+}
+
 // CreateVault creates a new vault via the factory and returns its address.
 func (s *VaultSettler) CreateVault(ctx context.Context, seller common.Address, amount, deadline *big.Int) (common.Address, string, error) {
 	info, txHash, err := s.factory.CreateVault(ctx, seller, s.tokenAddr, amount, deadline, s.arbitrator)
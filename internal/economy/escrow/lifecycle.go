@@ -8,8 +8,8 @@ var validTransitions = map[EscrowStatus][]EscrowStatus{
 	StatusFunded:    {StatusActive, StatusExpired},
 	StatusActive:    {StatusCompleted, StatusDisputed, StatusExpired},
 	StatusCompleted: {StatusReleased, StatusDisputed},
-	StatusDisputed:  {StatusRefunded, StatusReleased},
-	// Terminal states: StatusReleased, StatusExpired, StatusRefunded have no transitions.
+	StatusDisputed:  {StatusRefunded, StatusReleased, StatusResolved},
+	// Terminal states: StatusReleased, StatusExpired, StatusRefunded, StatusResolved have no transitions.
 }
 
 // canTransition returns true if from -> to is a valid transition.
@@ -28,6 +28,7 @@ func TestCanTransition(t *testing.T) {
 		{give: "completed->disputed", from: StatusCompleted, to: StatusDisputed, want: true},
 		{give: "disputed->refunded", from: StatusDisputed, to: StatusRefunded, want: true},
 		{give: "disputed->released", from: StatusDisputed, to: StatusReleased, want: true},
+		{give: "disputed->resolved", from: StatusDisputed, to: StatusResolved, want: true},
 		{give: "released->anything (terminal)", from: StatusReleased, to: StatusRefunded, want: false},
 		{give: "expired->anything (terminal)", from: StatusExpired, to: StatusPending, want: false},
 		{give: "refunded->anything (terminal)", from: StatusRefunded, to: StatusPending, want: false},
@@ -17,6 +17,7 @@ var (
 	_ Detector = (*RepeatedDisputeDetector)(nil)
 	_ Detector = (*UnusualTimingDetector)(nil)
 	_ Detector = (*BalanceDropDetector)(nil)
+	_ Detector = (*ArbiterCollusionDetector)(nil)
 )
 
 // windowCounter tracks timestamped events per key within a sliding window.
@@ -274,3 +275,71 @@ func (d *BalanceDropDetector) Analyze(event interface{}) *Alert {
 	d.previousBalance = new(big.Int).Set(ev.NewBalance)
 	return nil
 }
+
+// ArbiterCollusionDetector flags arbiters that repeatedly rule in favor of
+// the same party, or that the same party keeps nominating, within a window.
+type ArbiterCollusionDetector struct {
+	mu          sync.Mutex
+	favors      windowCounter // arbiter|favored party
+	nominations windowCounter // arbiter|nominating party
+}
+
+// NewArbiterCollusionDetector creates a detector for arbiter collusion patterns.
+func NewArbiterCollusionDetector(window time.Duration, max int) *ArbiterCollusionDetector {
+	return &ArbiterCollusionDetector{
+		favors:      newWindowCounter(window, max),
+		nominations: newWindowCounter(window, max),
+	}
+}
+
+func (d *ArbiterCollusionDetector) Name() string { return "arbiter_collusion" }
+
+func (d *ArbiterCollusionDetector) Analyze(event interface{}) *Alert {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch ev := event.(type) {
+	case eventbus.EscrowRulingEvent:
+		var favored string
+		switch {
+		case ev.SellerPercent > 50:
+			favored = ev.SellerDID
+		case ev.SellerPercent < 50:
+			favored = ev.BuyerDID
+		default:
+			return nil
+		}
+		count := d.favors.record(ev.ArbiterDID + "|" + favored)
+		if count > d.favors.max {
+			return &Alert{
+				ID:        uuid.New().String(),
+				Severity:  SeverityHigh,
+				Type:      "arbiter_collusion",
+				Message:   fmt.Sprintf("arbiter %s ruled for %s %d times in %s", ev.ArbiterDID, favored, count, d.favors.window),
+				DealID:    ev.EscrowID,
+				PeerDID:   ev.ArbiterDID,
+				Timestamp: time.Now(),
+				Metadata:  AlertMetadata{Count: count, Window: d.favors.window.String()},
+			}
+		}
+
+	case eventbus.EscrowArbiterAssignedEvent:
+		if ev.NominatedBy == "" {
+			return nil
+		}
+		count := d.nominations.record(ev.ArbiterDID + "|" + ev.NominatedBy)
+		if count > d.nominations.max {
+			return &Alert{
+				ID:        uuid.New().String(),
+				Severity:  SeverityMedium,
+				Type:      "arbiter_affinity",
+				Message:   fmt.Sprintf("peer %s nominated arbiter %s %d times in %s", ev.NominatedBy, ev.ArbiterDID, count, d.nominations.window),
+				DealID:    ev.EscrowID,
+				PeerDID:   ev.ArbiterDID,
+				Timestamp: time.Now(),
+				Metadata:  AlertMetadata{Count: count, Window: d.nominations.window.String()},
+			}
+		}
+	}
+	return nil
+}
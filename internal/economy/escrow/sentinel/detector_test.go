@@ -285,3 +285,73 @@ func TestBalanceDropDetector_IgnoresWrongEvent(t *testing.T) {
 	alert := d.Analyze(eventbus.EscrowCreatedEvent{EscrowID: "x"})
 	assert.Nil(t, alert)
 }
+
+func TestArbiterCollusionDetector(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give      string
+		events    []interface{}
+		wantType  string
+		wantAlert bool
+	}{
+		{
+			give: "rulings for different parties produce no alert",
+			events: []interface{}{
+				eventbus.EscrowRulingEvent{ArbiterDID: "did:arb", BuyerDID: "did:b", SellerDID: "did:s1", SellerPercent: 80},
+				eventbus.EscrowRulingEvent{ArbiterDID: "did:arb", BuyerDID: "did:b", SellerDID: "did:s2", SellerPercent: 80},
+				eventbus.EscrowRulingEvent{ArbiterDID: "did:arb", BuyerDID: "did:b", SellerDID: "did:s3", SellerPercent: 80},
+			},
+		},
+		{
+			give: "even splits are ignored",
+			events: []interface{}{
+				eventbus.EscrowRulingEvent{ArbiterDID: "did:arb", BuyerDID: "did:b", SellerDID: "did:s", SellerPercent: 50},
+				eventbus.EscrowRulingEvent{ArbiterDID: "did:arb", BuyerDID: "did:b", SellerDID: "did:s", SellerPercent: 50},
+				eventbus.EscrowRulingEvent{ArbiterDID: "did:arb", BuyerDID: "did:b", SellerDID: "did:s", SellerPercent: 50},
+			},
+		},
+		{
+			give: "repeated rulings for the same party alert",
+			events: []interface{}{
+				eventbus.EscrowRulingEvent{ArbiterDID: "did:arb", BuyerDID: "did:b1", SellerDID: "did:s", SellerPercent: 100},
+				eventbus.EscrowRulingEvent{ArbiterDID: "did:arb", BuyerDID: "did:b2", SellerDID: "did:s", SellerPercent: 70},
+				eventbus.EscrowRulingEvent{ArbiterDID: "did:arb", BuyerDID: "did:b3", SellerDID: "did:s", SellerPercent: 90},
+			},
+			wantType:  "arbiter_collusion",
+			wantAlert: true,
+		},
+		{
+			give: "repeated nominations by the same party alert",
+			events: []interface{}{
+				eventbus.EscrowArbiterAssignedEvent{ArbiterDID: "did:arb", NominatedBy: "did:b"},
+				eventbus.EscrowArbiterAssignedEvent{ArbiterDID: "did:arb", NominatedBy: "did:b"},
+				eventbus.EscrowArbiterAssignedEvent{ArbiterDID: "did:arb", NominatedBy: "did:b"},
+			},
+			wantType:  "arbiter_affinity",
+			wantAlert: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+
+			d := NewArbiterCollusionDetector(time.Hour, 2)
+			var gotAlert *Alert
+			for _, ev := range tt.events {
+				if alert := d.Analyze(ev); alert != nil {
+					gotAlert = alert
+				}
+			}
+
+			if tt.wantAlert {
+				require.NotNil(t, gotAlert)
+				assert.Equal(t, tt.wantType, gotAlert.Type)
+				assert.Equal(t, "did:arb", gotAlert.PeerDID)
+			} else {
+				assert.Nil(t, gotAlert)
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ func New(bus *eventbus.Bus, cfg SentinelConfig) *Engine {
 		NewRepeatedDisputeDetector(cfg.DisputeWindow, cfg.DisputeMax),
 		NewUnusualTimingDetector(cfg.WashTradeWindow),
 		NewBalanceDropDetector(),
+		NewArbiterCollusionDetector(cfg.CollusionWindow, cfg.CollusionMax),
 	}
 
 	return &Engine{
@@ -56,6 +57,12 @@ func (e *Engine) Start() error {
 	e.bus.Subscribe("escrow.milestone", func(ev eventbus.Event) {
 		e.runDetectors(ev)
 	})
+	e.bus.Subscribe("escrow.arbitration.assigned", func(ev eventbus.Event) {
+		e.runDetectors(ev)
+	})
+	e.bus.Subscribe("escrow.arbitration.ruling", func(ev eventbus.Event) {
+		e.runDetectors(ev)
+	})
 
 	e.running = true
 	return nil
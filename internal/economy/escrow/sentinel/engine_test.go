@@ -132,12 +132,13 @@ func TestEngine_Status(t *testing.T) {
 	assert.Equal(t, 0, status["activeAlerts"].(int))
 
 	detectors := status["detectors"].([]string)
-	assert.Len(t, detectors, 5)
+	assert.Len(t, detectors, 6)
 	assert.Contains(t, detectors, "rapid_creation")
 	assert.Contains(t, detectors, "large_withdrawal")
 	assert.Contains(t, detectors, "repeated_dispute")
 	assert.Contains(t, detectors, "unusual_timing")
 	assert.Contains(t, detectors, "balance_drop")
+	assert.Contains(t, detectors, "arbiter_collusion")
 }
 
 func TestEngine_UnusualTiming(t *testing.T) {
@@ -182,3 +183,24 @@ func TestEngine_Config(t *testing.T) {
 
 	assert.Equal(t, 10, eng.Config().RapidCreationMax)
 }
+
+func TestEngine_ArbiterCollusion(t *testing.T) {
+	t.Parallel()
+
+	bus := eventbus.New()
+	cfg := DefaultSentinelConfig()
+	cfg.CollusionMax = 1
+	eng := New(bus, cfg)
+	require.NoError(t, eng.Start())
+
+	for _, id := range []string{"e1", "e2"} {
+		bus.Publish(eventbus.EscrowRulingEvent{
+			EscrowID: id, ArbiterDID: "did:arb", BuyerDID: "did:b-" + id, SellerDID: "did:seller", SellerPercent: 90,
+		})
+	}
+
+	alerts := eng.Alerts()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "arbiter_collusion", alerts[0].Type)
+	assert.Equal(t, "did:arb", alerts[0].PeerDID)
+}
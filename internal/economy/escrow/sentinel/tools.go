@@ -131,6 +131,8 @@ func configTool(se *Engine) *agent.Tool {
 				"disputeWindow":         cfg.DisputeWindow.String(),
 				"disputeMax":            cfg.DisputeMax,
 				"washTradeWindow":       cfg.WashTradeWindow.String(),
+				"collusionWindow":       cfg.CollusionWindow.String(),
+				"collusionMax":          cfg.CollusionMax,
 			}, nil
 		},
 	}
@@ -44,6 +44,8 @@ type SentinelConfig struct {
 	DisputeWindow         time.Duration `json:"disputeWindow"`
 	DisputeMax            int           `json:"disputeMax"`
 	WashTradeWindow       time.Duration `json:"washTradeWindow"`
+	CollusionWindow       time.Duration `json:"collusionWindow"`
+	CollusionMax          int           `json:"collusionMax"`
 }
 
 // DefaultSentinelConfig returns sensible defaults.
@@ -55,6 +57,8 @@ func DefaultSentinelConfig() SentinelConfig {
 		DisputeWindow:         1 * time.Hour,
 		DisputeMax:            3,
 		WashTradeWindow:       1 * time.Minute,
+		CollusionWindow:       7 * 24 * time.Hour,
+		CollusionMax:          3,
 	}
 }
 
@@ -17,6 +17,7 @@ const (
 	StatusDisputed  EscrowStatus = "disputed"
 	StatusExpired   EscrowStatus = "expired"
 	StatusRefunded  EscrowStatus = "refunded"
+	StatusResolved  EscrowStatus = "resolved" // disputed funds split by a ruling
 )
 
 // TransactionType represents the type of an escrow transaction.
@@ -0,0 +1,125 @@
+package simchain
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultArtifactsDir is the Foundry output directory relative to the
+// repository root, for deploying from a local forge build.
+const DefaultArtifactsDir = "contracts/out"
+
+// embeddedArtifacts holds the creation bytecode deployed when no artifacts
+// directory is given. They are the abi, bytecode and deployedBytecode fields
+// of the Foundry artifacts (solc 0.8.30, optimizer 200 runs, EVM cancun);
+// after changing a deployed contract, refresh them with:
+//
+//	cd contracts && forge build && for c in MockUSDC LangoEscrowHub LangoVault LangoVaultFactory \
+//	    MockEntryPoint MockPaymaster MockSmartAccount; do
+//	  jq -c '{abi, bytecode: {object: .bytecode.object}, deployedBytecode: {object: .deployedBytecode.object}}' \
+//	    out/$c.sol/$c.json > ../internal/economy/simchain/artifacts/$c.sol/$c.json; done
+//
+//go:embed artifacts
+var embeddedArtifacts embed.FS
+
+// ErrArtifactsMissing is returned when the Foundry build output is not present.
+var ErrArtifactsMissing = errors.New("contract artifacts not found; run: cd contracts && forge build")
+
+// Contract names deployed on the simulated chain, in deployment order.
+const (
+	ContractMockUSDC     = "MockUSDC"
+	ContractEscrowHub    = "LangoEscrowHub"
+	ContractVault        = "LangoVault"
+	ContractVaultFactory = "LangoVaultFactory"
+	ContractEntryPoint   = "MockEntryPoint"
+	ContractPaymaster    = "MockPaymaster"
+)
+
+// ContractSmartAccount is the single-owner ERC-4337 account mock. It is not
+// deployed with the chain; tests deploy one per owner.
+const ContractSmartAccount = "MockSmartAccount"
+
+// deployOrder fixes the faucet nonce of each contract deployment. New
+// contracts go at the end so existing addresses do not move.
+var deployOrder = []string{
+	ContractMockUSDC,
+	ContractEscrowHub,
+	ContractVault,
+	ContractVaultFactory,
+	ContractEntryPoint,
+	ContractPaymaster,
+}
+
+// artifactSet maps contract name to creation bytecode.
+type artifactSet map[string][]byte
+
+// forgeArtifact is the subset of a Foundry artifact JSON we need.
+type forgeArtifact struct {
+	Bytecode struct {
+		Object string `json:"object"`
+	} `json:"bytecode"`
+}
+
+// ArtifactPath returns the Foundry artifact path for a contract,
+// e.g. contracts/out/LangoEscrowHub.sol/LangoEscrowHub.json.
+func ArtifactPath(dir, contractName string) string {
+	return filepath.Join(dir, contractName+".sol", contractName+".json")
+}
+
+// LoadBytecode reads the creation bytecode of a contract from Foundry output,
+// or from the embedded artifacts when dir is empty.
+func LoadBytecode(dir, contractName string) ([]byte, error) {
+	var (
+		path string
+		data []byte
+		err  error
+	)
+	if dir == "" {
+		path = "artifacts/" + contractName + ".sol/" + contractName + ".json"
+		data, err = fs.ReadFile(embeddedArtifacts, path)
+	} else {
+		path = ArtifactPath(dir, contractName)
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s: %w", path, ErrArtifactsMissing)
+		}
+		return nil, fmt.Errorf("read artifact %s: %w", path, err)
+	}
+
+	var artifact forgeArtifact
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("parse artifact %s: %w", path, err)
+	}
+	obj := strings.TrimPrefix(artifact.Bytecode.Object, "0x")
+	if obj == "" {
+		return nil, fmt.Errorf("artifact %s has no creation bytecode", path)
+	}
+	if strings.Contains(obj, "__$") {
+		return nil, fmt.Errorf("artifact %s requires library linking", path)
+	}
+	return common.FromHex(obj), nil
+}
+
+// loadArtifacts reads every contract in deployOrder from dir, or from the
+// embedded artifacts when dir is empty.
+func loadArtifacts(dir string) (artifactSet, error) {
+	set := make(artifactSet, len(deployOrder))
+	for _, name := range deployOrder {
+		bc, err := LoadBytecode(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		set[name] = bc
+	}
+	return set, nil
+}
@@ -0,0 +1,44 @@
+package simchain
+
+import (
+	"github.com/langoai/lango/internal/config"
+)
+
+// ApplyConfig points the payment network and on-chain escrow settings at the
+// simulated chain. Payment and on-chain escrow are enabled; an escrow mode
+// already set to "vault" is kept, anything else becomes "hub". V2 contracts
+// are not deployed, so the contract version is pinned to v1.
+func (d Deployment) ApplyConfig(cfg *config.Config) {
+	cfg.Payment.Enabled = true
+	cfg.Payment.Network.ChainID = d.ChainID
+	cfg.Payment.Network.RPCURL = d.RPCURL
+	cfg.Payment.Network.USDCContract = d.USDC.Hex()
+	if cfg.Payment.WalletProvider == "" {
+		cfg.Payment.WalletProvider = "local"
+	}
+
+	cfg.Economy.Enabled = true
+	cfg.Economy.Escrow.Enabled = true
+
+	oc := &cfg.Economy.Escrow.OnChain
+	oc.Enabled = true
+	if oc.Mode != "vault" {
+		oc.Mode = "hub"
+	}
+	oc.ContractVersion = "v1"
+	oc.HubAddress = d.EscrowHub.Hex()
+	oc.VaultFactoryAddress = d.VaultFactory.Hex()
+	oc.VaultImplementation = d.VaultImplementation.Hex()
+	oc.ArbitratorAddress = d.Arbitrator.Hex()
+	oc.TokenAddress = d.USDC.Hex()
+	oc.HubV2Address = ""
+	oc.BeaconAddress = ""
+	oc.BeaconFactoryAddress = ""
+	oc.DirectSettlerAddress = ""
+	oc.MilestoneSettlerAddress = ""
+	// Simulated blocks are final; poll quickly so the sentinel sees events promptly.
+	oc.ConfirmationDepth = 1
+	if oc.PollInterval <= 0 || oc.PollInterval > 2*DefaultBlockInterval {
+		oc.PollInterval = 2 * DefaultBlockInterval
+	}
+}
@@ -0,0 +1,240 @@
+package simchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// DefaultETHFunding is the ETH sent to a funded wallet for gas (100 ETH).
+var DefaultETHFunding = new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))
+
+// PaymasterDeposit is the EntryPoint deposit the faucet gives the mock
+// paymaster after deployment (10 ETH).
+var PaymasterDeposit = new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))
+
+// receiptPollInterval is how often deploy and Fund poll for receipts.
+const receiptPollInterval = 50 * time.Millisecond
+
+// mintABI is the MockUSDC mint(address,uint256) ABI.
+const mintABI = `[{"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"mint","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+// depositSelector is the MockPaymaster deposit() selector.
+var depositSelector = crypto.Keccak256([]byte("deposit()"))[:4]
+
+// Deployment holds the contract addresses on a simulated chain.
+type Deployment struct {
+	ChainID             int64
+	RPCURL              string
+	USDC                common.Address
+	EscrowHub           common.Address
+	VaultImplementation common.Address
+	VaultFactory        common.Address
+	Arbitrator          common.Address
+	EntryPoint          common.Address // ERC-4337 v0.7 EntryPoint mock
+	Paymaster           common.Address // sponsoring paymaster, funded with PaymasterDeposit
+}
+
+// ExpectedDeployment returns the addresses the faucet deploys to on a fresh
+// simulated chain. The RPC URL is left empty.
+func ExpectedDeployment() Deployment {
+	addrs := make(map[string]common.Address, len(deployOrder))
+	for i, name := range deployOrder {
+		addrs[name] = crypto.CreateAddress(FaucetAddress, uint64(i))
+	}
+	return Deployment{
+		ChainID:             ChainID,
+		USDC:                addrs[ContractMockUSDC],
+		EscrowHub:           addrs[ContractEscrowHub],
+		VaultImplementation: addrs[ContractVault],
+		VaultFactory:        addrs[ContractVaultFactory],
+		Arbitrator:          FaucetAddress,
+		EntryPoint:          addrs[ContractEntryPoint],
+		Paymaster:           addrs[ContractPaymaster],
+	}
+}
+
+// deploy deploys every contract from the faucet in deployOrder and checks the
+// resulting addresses match ExpectedDeployment.
+func deploy(ctx context.Context, client *ethclient.Client, artifacts artifactSet) (Deployment, error) {
+	want := ExpectedDeployment()
+
+	nonce, err := client.PendingNonceAt(ctx, FaucetAddress)
+	if err != nil {
+		return Deployment{}, fmt.Errorf("faucet nonce: %w", err)
+	}
+	if nonce != 0 {
+		return Deployment{}, fmt.Errorf("faucet nonce is %d, want 0 on a fresh chain", nonce)
+	}
+
+	addressArg := ethabi.Arguments{{Type: mustType("address")}}
+	ctorArgs := map[string]common.Address{
+		ContractEscrowHub:    want.Arbitrator,
+		ContractVaultFactory: want.VaultImplementation,
+		ContractPaymaster:    want.EntryPoint,
+	}
+
+	for _, name := range deployOrder {
+		data := append([]byte(nil), artifacts[name]...)
+		if arg, ok := ctorArgs[name]; ok {
+			packed, err := addressArg.Pack(arg)
+			if err != nil {
+				return Deployment{}, fmt.Errorf("pack %s constructor: %w", name, err)
+			}
+			data = append(data, packed...)
+		}
+
+		if _, err := sendFromFaucet(ctx, client, nil, nil, data); err != nil {
+			return Deployment{}, fmt.Errorf("deploy %s: %w", name, err)
+		}
+	}
+
+	// Confirm the fixed deployment order produced the expected addresses.
+	deployed := []common.Address{
+		want.USDC, want.EscrowHub, want.VaultImplementation, want.VaultFactory, want.EntryPoint, want.Paymaster,
+	}
+	for _, addr := range deployed {
+		code, err := client.CodeAt(ctx, addr, nil)
+		if err != nil {
+			return Deployment{}, fmt.Errorf("code at %s: %w", addr.Hex(), err)
+		}
+		if len(code) == 0 {
+			return Deployment{}, fmt.Errorf("no contract code at expected address %s", addr.Hex())
+		}
+	}
+
+	if _, err := sendFromFaucet(ctx, client, &want.Paymaster, PaymasterDeposit, depositSelector); err != nil {
+		return Deployment{}, fmt.Errorf("fund paymaster deposit: %w", err)
+	}
+	return want, nil
+}
+
+// Attach verifies that rpcURL serves a simulated chain with the Lango
+// contracts deployed and returns its Deployment.
+func Attach(ctx context.Context, rpcURL string) (Deployment, error) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return Deployment{}, fmt.Errorf("dial %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	id, err := client.ChainID(ctx)
+	if err != nil {
+		return Deployment{}, fmt.Errorf("chain id: %w", err)
+	}
+	if id.Int64() != ChainID {
+		return Deployment{}, fmt.Errorf("chain id %s: %w", id, ErrNotSimulated)
+	}
+
+	dep := ExpectedDeployment()
+	code, err := client.CodeAt(ctx, dep.EscrowHub, nil)
+	if err != nil {
+		return Deployment{}, fmt.Errorf("code at hub: %w", err)
+	}
+	if len(code) == 0 {
+		return Deployment{}, fmt.Errorf("no escrow hub at %s: %w", dep.EscrowHub.Hex(), ErrNotSimulated)
+	}
+	dep.RPCURL = rpcURL
+	return dep, nil
+}
+
+// Fund sends DefaultETHFunding and mints usdc (smallest units) of mock USDC
+// to addr from the faucet. A nil or zero usdc skips the mint.
+func Fund(ctx context.Context, client *ethclient.Client, dep Deployment, addr common.Address, usdc *big.Int) error {
+	if _, err := sendFromFaucet(ctx, client, &addr, DefaultETHFunding, nil); err != nil {
+		return fmt.Errorf("fund ETH: %w", err)
+	}
+	if usdc == nil || usdc.Sign() == 0 {
+		return nil
+	}
+
+	parsed, err := ethabi.JSON(strings.NewReader(mintABI))
+	if err != nil {
+		return fmt.Errorf("parse mint ABI: %w", err)
+	}
+	data, err := parsed.Pack("mint", addr, usdc)
+	if err != nil {
+		return fmt.Errorf("pack mint: %w", err)
+	}
+	if _, err := sendFromFaucet(ctx, client, &dep.USDC, nil, data); err != nil {
+		return fmt.Errorf("mint USDC: %w", err)
+	}
+	return nil
+}
+
+// sendFromFaucet signs and sends a faucet transaction (a contract creation
+// when to is nil) and waits for a successful receipt.
+func sendFromFaucet(ctx context.Context, client *ethclient.Client, to *common.Address, value *big.Int, data []byte) (*types.Receipt, error) {
+	nonce, err := client.PendingNonceAt(ctx, FaucetAddress)
+	if err != nil {
+		return nil, fmt.Errorf("nonce: %w", err)
+	}
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("head: %w", err)
+	}
+	tip := big.NewInt(1e9)
+	feeCap := new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tip)
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	gas := uint64(10_000_000)
+	if to != nil {
+		gas = 1_000_000
+	}
+
+	tx, err := types.SignNewTx(FaucetKey, types.LatestSignerForChainID(big.NewInt(ChainID)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(ChainID),
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+		Gas:       gas,
+		To:        to,
+		Value:     value,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("send: %w", err)
+	}
+	return waitReceipt(ctx, client, tx.Hash())
+}
+
+// waitReceipt polls until the transaction is mined and checks its status.
+func waitReceipt(ctx context.Context, client *ethclient.Client, hash common.Hash) (*types.Receipt, error) {
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		receipt, err := client.TransactionReceipt(ctx, hash)
+		if err == nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return nil, fmt.Errorf("tx %s reverted", hash.Hex())
+			}
+			return receipt, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for tx %s: %w", hash.Hex(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func mustType(t string) ethabi.Type {
+	typ, err := ethabi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
@@ -0,0 +1,283 @@
+// Package simchain runs an in-process simulated EVM chain with the Lango
+// escrow contracts, a mock USDC and an ERC-4337 EntryPoint and paymaster
+// mock deployed, so economy flows can be exercised without network access or
+// funded testnet wallets.
+//
+// Contracts are deployed from the embedded build artifacts, or from a local
+// Foundry build (contracts/out), by a well-known faucet key. Because the
+// faucet deploys first and in a fixed order, contract addresses are
+// deterministic: a second node pointed at the same chain over JSON-RPC can
+// Attach and derive the same Deployment.
+package simchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/catalyst"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ChainID is the chain ID of every simulated chain.
+const ChainID int64 = 1337
+
+// DefaultListenAddr is the default JSON-RPC listen address for a simulated chain.
+const DefaultListenAddr = "127.0.0.1:8545"
+
+// DefaultBlockInterval is the default interval between mined blocks.
+const DefaultBlockInterval = time.Second
+
+// faucetKeyHex is the first default Anvil/Hardhat dev account. It is public
+// knowledge and must never hold real funds.
+const faucetKeyHex = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+var (
+	// FaucetKey deploys the contracts, arbitrates disputes and funds wallets.
+	FaucetKey, _ = crypto.HexToECDSA(faucetKeyHex)
+
+	// FaucetAddress is the address of FaucetKey.
+	FaucetAddress = crypto.PubkeyToAddress(FaucetKey.PublicKey)
+
+	// faucetBalance is the genesis ETH balance of the faucet (1e9 ETH).
+	faucetBalance = new(big.Int).Mul(big.NewInt(1e9), big.NewInt(1e18))
+)
+
+// ErrNotSimulated is returned by Attach when the RPC endpoint is not a
+// simulated chain with the Lango contracts deployed.
+var ErrNotSimulated = errors.New("not a simulated lango chain")
+
+// Options configures a simulated chain.
+type Options struct {
+	// ArtifactsDir is a Foundry output directory to deploy from, e.g.
+	// DefaultArtifactsDir. Empty deploys the embedded artifacts.
+	ArtifactsDir string
+
+	// ListenAddr exposes JSON-RPC over HTTP when set (e.g. "127.0.0.1:8545").
+	// Leave empty for an in-process chain only.
+	ListenAddr string
+
+	// BlockInterval is the interval between mined blocks (default: 1s).
+	BlockInterval time.Duration
+
+	// Accounts are pre-funded with ETH in the genesis block.
+	Accounts []common.Address
+}
+
+// Chain is a running simulated chain.
+type Chain struct {
+	backend    *backend
+	client     *ethclient.Client
+	deployment Deployment
+	rpcURL     string
+
+	mu     sync.Mutex
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// Start boots a simulated chain, starts mining and deploys the contracts.
+func Start(ctx context.Context, opts Options) (*Chain, error) {
+	if opts.BlockInterval <= 0 {
+		opts.BlockInterval = DefaultBlockInterval
+	}
+
+	// Load artifacts before booting so a missing forge build fails fast.
+	artifacts, err := loadArtifacts(opts.ArtifactsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	alloc := types.GenesisAlloc{FaucetAddress: {Balance: faucetBalance}}
+	for _, addr := range opts.Accounts {
+		alloc[addr] = types.Account{Balance: new(big.Int).Set(DefaultETHFunding)}
+	}
+
+	backend, rpcURL, err := newBackend(alloc, opts.ListenAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	client := backend.client()
+
+	c := &Chain{
+		backend: backend,
+		client:  client,
+		rpcURL:  rpcURL,
+		stopCh:  make(chan struct{}),
+	}
+	c.wg.Add(1)
+	go c.mine(opts.BlockInterval)
+
+	dep, err := deploy(ctx, client, artifacts)
+	if err != nil {
+		_ = c.Close()
+		return nil, fmt.Errorf("deploy contracts: %w", err)
+	}
+	dep.RPCURL = rpcURL
+	c.deployment = dep
+
+	return c, nil
+}
+
+// backend is the in-process chain: a dev-mode node driven by a simulated
+// beacon. It mirrors ethclient/simulated.NewBackend, which does not expose
+// the *ethclient.Client that the contract caller and payment services need.
+type backend struct {
+	node   *node.Node
+	beacon *catalyst.SimulatedBeacon
+}
+
+// newBackend starts the chain, serving HTTP JSON-RPC when listenAddr is set.
+func newBackend(alloc types.GenesisAlloc, listenAddr string) (*backend, string, error) {
+	nodeConf := node.DefaultConfig
+	nodeConf.DataDir = ""
+	nodeConf.P2P = p2p.Config{NoDiscovery: true}
+
+	var rpcURL string
+	if listenAddr != "" {
+		host, p, err := net.SplitHostPort(listenAddr)
+		if err != nil {
+			return nil, "", fmt.Errorf("parse listen address %q: %w", listenAddr, err)
+		}
+		port, err := strconv.Atoi(p)
+		if err != nil || port <= 0 {
+			return nil, "", fmt.Errorf("listen address %q: port must be a positive number", listenAddr)
+		}
+		nodeConf.HTTPHost = host
+		nodeConf.HTTPPort = port
+		nodeConf.HTTPModules = []string{"eth", "net", "web3"}
+		nodeConf.HTTPVirtualHosts = []string{"*"}
+		rpcURL = "http://" + listenAddr
+	}
+
+	ethConf := ethconfig.Defaults
+	ethConf.Genesis = &core.Genesis{
+		Config:   params.AllDevChainProtocolChanges,
+		GasLimit: ethconfig.Defaults.Miner.GasCeil,
+		Alloc:    alloc,
+	}
+	ethConf.SyncMode = ethconfig.FullSync
+	ethConf.TxPool.NoLocals = true
+
+	stack, err := node.New(&nodeConf)
+	if err != nil {
+		return nil, "", fmt.Errorf("create node: %w", err)
+	}
+	svc, err := eth.New(stack, &ethConf)
+	if err != nil {
+		_ = stack.Close()
+		return nil, "", fmt.Errorf("create eth service: %w", err)
+	}
+	filterSystem := filters.NewFilterSystem(svc.APIBackend, filters.Config{})
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "eth",
+		Service:   filters.NewFilterAPI(filterSystem),
+	}})
+	if err := stack.Start(); err != nil {
+		_ = stack.Close()
+		return nil, "", fmt.Errorf("start node: %w", err)
+	}
+
+	beacon, err := catalyst.NewSimulatedBeacon(0, common.Address{}, svc)
+	if err != nil {
+		_ = stack.Close()
+		return nil, "", fmt.Errorf("create simulated beacon: %w", err)
+	}
+	if err := beacon.Fork(svc.BlockChain().GetCanonicalHash(0)); err != nil {
+		_ = beacon.Stop()
+		_ = stack.Close()
+		return nil, "", fmt.Errorf("reset to genesis: %w", err)
+	}
+	return &backend{node: stack, beacon: beacon}, rpcURL, nil
+}
+
+// client returns an RPC client attached to the node in-process.
+func (b *backend) client() *ethclient.Client {
+	return ethclient.NewClient(b.node.Attach())
+}
+
+// Close stops the beacon and the node.
+func (b *backend) Close() error {
+	return errors.Join(b.beacon.Stop(), b.node.Close())
+}
+
+// mine commits a block every interval until the chain is closed, so
+// transactions sent over RPC are included without manual commits.
+func (c *Chain) mine(interval time.Duration) {
+	defer c.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			c.backend.beacon.Commit()
+			c.mu.Unlock()
+		}
+	}
+}
+
+// Client returns an RPC client connected to the chain in-process.
+func (c *Chain) Client() *ethclient.Client { return c.client }
+
+// Deployment returns the deployed contract addresses.
+func (c *Chain) Deployment() Deployment { return c.deployment }
+
+// RPCURL returns the HTTP JSON-RPC URL, or "" when the chain is in-process only.
+func (c *Chain) RPCURL() string { return c.rpcURL }
+
+// Commit mines a block immediately.
+func (c *Chain) Commit() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.backend.beacon.Commit()
+}
+
+// AdjustTime moves the chain clock forward and mines a block, for exercising
+// escrow deadlines and refunds.
+func (c *Chain) AdjustTime(d time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// The backend seals the block itself and refuses while txs are pending.
+	return c.backend.beacon.AdjustTime(d)
+}
+
+// Fund sends ETH for gas and mints mock USDC to addr.
+func (c *Chain) Fund(ctx context.Context, addr common.Address, usdc *big.Int) error {
+	return Fund(ctx, c.client, c.deployment, addr, usdc)
+}
+
+// Close stops mining and shuts the chain down.
+func (c *Chain) Close() error {
+	c.mu.Lock()
+	select {
+	case <-c.stopCh:
+		c.mu.Unlock()
+		return nil
+	default:
+		close(c.stopCh)
+	}
+	c.mu.Unlock()
+	c.wg.Wait()
+	c.client.Close()
+	return c.backend.Close()
+}
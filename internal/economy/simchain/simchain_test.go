@@ -0,0 +1,502 @@
+package simchain
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/contract"
+	"github.com/langoai/lango/internal/economy/escrow/hub"
+	"github.com/langoai/lango/internal/eventbus"
+	"github.com/langoai/lango/internal/payment/eip3009"
+	"github.com/langoai/lango/internal/smartaccount"
+)
+
+// stubInitCode deploys a contract whose runtime code is a single STOP, so any
+// call (including mint) succeeds. It lets deployment be tested without a
+// forge build.
+const stubInitCode = "0x6001600c60003960016000f300"
+
+func writeStubArtifacts(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range deployOrder {
+		path := ArtifactPath(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		body := fmt.Sprintf(`{"bytecode":{"object":%q}}`, stubInitCode)
+		require.NoError(t, os.WriteFile(path, []byte(body), 0o644))
+	}
+	return dir
+}
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+	return addr
+}
+
+func TestLoadBytecode_Missing(t *testing.T) {
+	_, err := LoadBytecode(t.TempDir(), ContractEscrowHub)
+	assert.True(t, errors.Is(err, ErrArtifactsMissing))
+
+	_, err = Start(context.Background(), Options{ArtifactsDir: t.TempDir()})
+	assert.True(t, errors.Is(err, ErrArtifactsMissing))
+}
+
+func TestLoadBytecode_Embedded(t *testing.T) {
+	for _, name := range deployOrder {
+		bc, err := LoadBytecode("", name)
+		require.NoError(t, err, name)
+		assert.NotEmpty(t, bc, name)
+	}
+}
+
+func TestStart_DeploysAttachesAndFunds(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	chain, err := Start(ctx, Options{
+		ArtifactsDir:  writeStubArtifacts(t),
+		ListenAddr:    freeAddr(t),
+		BlockInterval: 20 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer chain.Close()
+
+	want := ExpectedDeployment()
+	want.RPCURL = chain.RPCURL()
+	assert.Equal(t, want, chain.Deployment())
+	assert.Equal(t, FaucetAddress, want.Arbitrator)
+
+	// A second node attaches over HTTP and derives the same addresses.
+	attached, err := Attach(ctx, chain.RPCURL())
+	require.NoError(t, err)
+	assert.Equal(t, chain.Deployment(), attached)
+
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	require.NoError(t, chain.Fund(ctx, addr, big.NewInt(1_000_000)))
+	bal, err := chain.Client().BalanceAt(ctx, addr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultETHFunding, bal)
+
+	// Deadlines can be crossed by moving the chain clock.
+	before, err := chain.Client().HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, chain.AdjustTime(48*time.Hour))
+	after, err := chain.Client().HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, after.Time, before.Time+uint64((48*time.Hour).Seconds()))
+}
+
+func TestStart_ListenAddrInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	_, err = Start(context.Background(), Options{
+		ArtifactsDir: writeStubArtifacts(t),
+		ListenAddr:   ln.Addr().String(),
+	})
+	assert.Error(t, err)
+}
+
+func TestAttach_NoContracts(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// A bare simulated backend has chain ID 1337 but no Lango contracts.
+	addr := freeAddr(t)
+	backend, rpcURL, err := newBackend(nil, addr)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	_, err = Attach(ctx, rpcURL)
+	assert.True(t, errors.Is(err, ErrNotSimulated))
+}
+
+func TestDeployment_ApplyConfig(t *testing.T) {
+	dep := ExpectedDeployment()
+	dep.RPCURL = "http://127.0.0.1:8545"
+
+	cfg := config.DefaultConfig()
+	cfg.Economy.Escrow.OnChain.HubV2Address = "0x1234"
+	dep.ApplyConfig(cfg)
+
+	assert.True(t, cfg.Payment.Enabled)
+	assert.Equal(t, ChainID, cfg.Payment.Network.ChainID)
+	assert.Equal(t, dep.RPCURL, cfg.Payment.Network.RPCURL)
+	assert.Equal(t, dep.USDC.Hex(), cfg.Payment.Network.USDCContract)
+
+	oc := cfg.Economy.Escrow.OnChain
+	assert.True(t, cfg.Economy.Escrow.Enabled)
+	assert.True(t, oc.Enabled)
+	assert.Equal(t, "hub", oc.Mode)
+	assert.False(t, oc.IsV2())
+	assert.Equal(t, dep.EscrowHub.Hex(), oc.HubAddress)
+	assert.Equal(t, dep.VaultFactory.Hex(), oc.VaultFactoryAddress)
+	assert.Equal(t, dep.USDC.Hex(), oc.TokenAddress)
+	assert.Equal(t, uint64(1), oc.ConfirmationDepth)
+
+	cfg.Economy.Escrow.OnChain.Mode = "vault"
+	dep.ApplyConfig(cfg)
+	assert.Equal(t, "vault", cfg.Economy.Escrow.OnChain.Mode)
+}
+
+// ---- Full scenario against the Foundry-built contracts ----
+
+type keyWallet struct {
+	key *ecdsa.PrivateKey
+}
+
+func newKeyWallet(t *testing.T) *keyWallet {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &keyWallet{key: key}
+}
+
+func (w *keyWallet) addr() common.Address { return crypto.PubkeyToAddress(w.key.PublicKey) }
+
+func (w *keyWallet) Address(context.Context) (string, error) { return w.addr().Hex(), nil }
+
+func (w *keyWallet) Balance(context.Context) (*big.Int, error) { return new(big.Int), nil }
+
+func (w *keyWallet) SignTransaction(_ context.Context, hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, w.key)
+}
+
+func (w *keyWallet) SignMessage(_ context.Context, message []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(message), w.key)
+}
+
+func (w *keyWallet) PublicKey(context.Context) ([]byte, error) {
+	return crypto.CompressPubkey(&w.key.PublicKey), nil
+}
+
+const erc20ABI = `[
+	{"inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+// startScenarioChain starts a chain with the embedded contract artifacts.
+func startScenarioChain(t *testing.T, ctx context.Context) *Chain {
+	t.Helper()
+	chain, err := Start(ctx, Options{BlockInterval: 50 * time.Millisecond})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = chain.Close() })
+	return chain
+}
+
+func usdcBalance(t *testing.T, ctx context.Context, caller *contract.Caller, dep Deployment, addr common.Address) *big.Int {
+	t.Helper()
+	res, err := caller.Read(ctx, contract.ContractCallRequest{
+		ChainID: ChainID, Address: dep.USDC, ABI: erc20ABI,
+		Method: "balanceOf", Args: []interface{}{addr},
+	})
+	require.NoError(t, err)
+	return res.Data[0].(*big.Int)
+}
+
+func TestScenario_HubEscrowRelease(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	chain := startScenarioChain(t, ctx)
+
+	dep := chain.Deployment()
+	client := chain.Client()
+	cache := contract.NewABICache()
+
+	buyer, seller := newKeyWallet(t), newKeyWallet(t)
+	require.NoError(t, chain.Fund(ctx, buyer.addr(), big.NewInt(10_000_000)))
+	require.NoError(t, chain.Fund(ctx, seller.addr(), nil))
+
+	buyerCaller := contract.NewCaller(client, buyer, ChainID, cache)
+	sellerCaller := contract.NewCaller(client, seller, ChainID, cache)
+
+	bus := eventbus.New()
+	var (
+		mu       sync.Mutex
+		released []eventbus.EscrowOnChainReleaseEvent
+	)
+	eventbus.SubscribeTyped(bus, func(e eventbus.EscrowOnChainReleaseEvent) {
+		mu.Lock()
+		released = append(released, e)
+		mu.Unlock()
+	})
+	monitor, err := hub.NewEventMonitor(client, bus, nil, dep.EscrowHub,
+		hub.WithPollInterval(100*time.Millisecond),
+		hub.WithConfirmationDepth(1),
+	)
+	require.NoError(t, err)
+	var wg sync.WaitGroup
+	wg.Add(1) // the monitor marks wg done once its poll loop is running
+	require.NoError(t, monitor.Start(ctx, &wg))
+	defer func() { _ = monitor.Stop(ctx) }()
+
+	amount := big.NewInt(2_500_000)
+	_, err = buyerCaller.Write(ctx, contract.ContractCallRequest{
+		ChainID: ChainID, Address: dep.USDC, ABI: erc20ABI,
+		Method: "approve", Args: []interface{}{dep.EscrowHub, amount},
+	})
+	require.NoError(t, err)
+
+	buyerHub := hub.NewHubClient(buyerCaller, dep.EscrowHub, ChainID)
+	deadline := big.NewInt(time.Now().Add(24 * time.Hour).Unix())
+	dealID, _, err := buyerHub.CreateDeal(ctx, seller.addr(), dep.USDC, amount, deadline)
+	require.NoError(t, err)
+	_, err = buyerHub.Deposit(ctx, dealID)
+	require.NoError(t, err)
+
+	var workHash [32]byte
+	copy(workHash[:], crypto.Keccak256([]byte("simulated work")))
+	_, err = hub.NewHubClient(sellerCaller, dep.EscrowHub, ChainID).SubmitWork(ctx, dealID, workHash)
+	require.NoError(t, err)
+	_, err = buyerHub.Release(ctx, dealID)
+	require.NoError(t, err)
+
+	deal, err := buyerHub.GetDeal(ctx, dealID)
+	require.NoError(t, err)
+	assert.Equal(t, hub.DealStatusReleased, deal.Status)
+
+	assert.Equal(t, amount, usdcBalance(t, ctx, sellerCaller, dep, seller.addr()))
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(released) == 1 && released[0].DealID == dealID.String()
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+// TestScenario_TransferWithAuthorization settles an EIP-3009 authorization,
+// as x402 sellers and P2P paid-tool settlement do, against the mock USDC.
+func TestScenario_TransferWithAuthorization(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	chain := startScenarioChain(t, ctx)
+	dep := chain.Deployment()
+	client := chain.Client()
+
+	buyer, seller := newKeyWallet(t), newKeyWallet(t)
+	require.NoError(t, chain.Fund(ctx, buyer.addr(), big.NewInt(5_000_000)))
+	require.NoError(t, chain.Fund(ctx, seller.addr(), nil))
+	sellerCaller := contract.NewCaller(client, seller, ChainID, contract.NewABICache())
+
+	amount := big.NewInt(1_250_000)
+	unsigned := eip3009.NewUnsigned(buyer.addr(), seller.addr(), amount, time.Now().Add(time.Hour))
+	unsigned.ValidAfter = big.NewInt(time.Now().Add(-time.Minute).Unix())
+	auth, err := eip3009.Sign(ctx, buyer, unsigned, ChainID, dep.USDC)
+	require.NoError(t, err)
+	require.NoError(t, eip3009.Verify(auth, buyer.addr(), ChainID, dep.USDC))
+
+	// The seller submits the buyer's authorization and pays the gas.
+	submit := func() error {
+		_, err := sendRaw(ctx, client, seller, dep.USDC, eip3009.EncodeCalldata(auth))
+		return err
+	}
+	require.NoError(t, submit())
+	assert.Equal(t, amount, usdcBalance(t, ctx, sellerCaller, dep, seller.addr()))
+	assert.Equal(t, new(big.Int).Sub(big.NewInt(5_000_000), amount), usdcBalance(t, ctx, sellerCaller, dep, buyer.addr()))
+
+	// Each authorization nonce settles once.
+	assert.Error(t, submit())
+}
+
+// packedUserOp mirrors the PackedUserOperation tuple of the EntryPoint ABI.
+type packedUserOp struct {
+	Sender             common.Address
+	Nonce              *big.Int
+	InitCode           []byte
+	CallData           []byte
+	AccountGasLimits   [32]byte
+	PreVerificationGas *big.Int
+	GasFees            [32]byte
+	PaymasterAndData   []byte
+	Signature          []byte
+}
+
+func packPair(hi, lo *big.Int) (out [32]byte) {
+	hi.FillBytes(out[:16])
+	lo.FillBytes(out[16:])
+	return out
+}
+
+// embeddedABI parses the ABI of an embedded contract artifact.
+func embeddedABI(t *testing.T, name string) ethabi.ABI {
+	t.Helper()
+	data, err := fs.ReadFile(embeddedArtifacts, "artifacts/"+name+".sol/"+name+".json")
+	require.NoError(t, err)
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	require.NoError(t, json.Unmarshal(data, &artifact))
+	parsed, err := ethabi.JSON(bytes.NewReader(artifact.ABI))
+	require.NoError(t, err)
+	return parsed
+}
+
+// callView calls a view method and returns its unpacked outputs.
+func callView(t *testing.T, ctx context.Context, client *ethclient.Client, parsed ethabi.ABI, to common.Address, method string, args ...interface{}) []interface{} {
+	t.Helper()
+	data, err := parsed.Pack(method, args...)
+	require.NoError(t, err)
+	out, err := client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+	require.NoError(t, err)
+	res, err := parsed.Unpack(method, out)
+	require.NoError(t, err)
+	return res
+}
+
+// TestScenario_PaymasterUserOperation sends one sponsored UserOperation from
+// a smart account with no ETH through the EntryPoint and paymaster mocks.
+func TestScenario_PaymasterUserOperation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	chain := startScenarioChain(t, ctx)
+	dep := chain.Deployment()
+	client := chain.Client()
+	entryPointABI := embeddedABI(t, ContractEntryPoint)
+	paymasterABI := embeddedABI(t, ContractPaymaster)
+	accountABI := embeddedABI(t, ContractSmartAccount)
+
+	owner, bundler, recipient := newKeyWallet(t), newKeyWallet(t), newKeyWallet(t)
+	require.NoError(t, chain.Fund(ctx, bundler.addr(), nil))
+
+	initCode, err := LoadBytecode("", ContractSmartAccount)
+	require.NoError(t, err)
+	ctor, err := accountABI.Pack("", dep.EntryPoint, owner.addr())
+	require.NoError(t, err)
+	receipt, err := sendFromFaucet(ctx, client, nil, nil, append(initCode, ctor...))
+	require.NoError(t, err)
+	account := receipt.ContractAddress
+	// The account holds ETH too; the paymaster must still be the one paying.
+	require.NoError(t, Fund(ctx, client, dep, account, big.NewInt(3_000_000)))
+
+	erc20, err := ethabi.JSON(strings.NewReader(erc20ABI))
+	require.NoError(t, err)
+	amount := big.NewInt(1_000_000)
+	transfer, err := erc20.Pack("transfer", recipient.addr(), amount)
+	require.NoError(t, err)
+	callData, err := accountABI.Pack("execute", dep.USDC, new(big.Int), transfer)
+	require.NoError(t, err)
+
+	pmGas := big.NewInt(100_000)
+	paymasterAndData := make([]byte, 52)
+	copy(paymasterAndData[:20], dep.Paymaster.Bytes())
+	pmGas.FillBytes(paymasterAndData[20:36])
+	pmGas.FillBytes(paymasterAndData[36:52])
+
+	head, err := client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	op := &smartaccount.UserOperation{
+		Sender:               account,
+		Nonce:                new(big.Int),
+		InitCode:             []byte{},
+		CallData:             callData,
+		CallGasLimit:         big.NewInt(200_000),
+		VerificationGasLimit: big.NewInt(200_000),
+		PreVerificationGas:   big.NewInt(50_000),
+		MaxFeePerGas:         new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), big.NewInt(1e9)),
+		MaxPriorityFeePerGas: big.NewInt(1e9),
+		PaymasterAndData:     paymasterAndData,
+	}
+	hash := smartaccount.ComputeUserOpHash(op, dep.EntryPoint, ChainID)
+	op.Signature, err = owner.SignTransaction(ctx, hash)
+	require.NoError(t, err)
+
+	packed := packedUserOp{
+		Sender:             op.Sender,
+		Nonce:              op.Nonce,
+		InitCode:           op.InitCode,
+		CallData:           op.CallData,
+		AccountGasLimits:   packPair(op.VerificationGasLimit, op.CallGasLimit),
+		PreVerificationGas: op.PreVerificationGas,
+		GasFees:            packPair(op.MaxPriorityFeePerGas, op.MaxFeePerGas),
+		PaymasterAndData:   op.PaymasterAndData,
+		Signature:          op.Signature,
+	}
+	onChainHash := callView(t, ctx, client, entryPointABI, dep.EntryPoint, "getUserOpHash", packed)[0].([32]byte)
+	assert.Equal(t, hash, onChainHash[:], "Go and EntryPoint userOpHash differ")
+
+	handleOps, err := entryPointABI.Pack("handleOps", []packedUserOp{packed}, bundler.addr())
+	require.NoError(t, err)
+	submit := func() error {
+		_, err := sendRaw(ctx, client, bundler, dep.EntryPoint, handleOps)
+		return err
+	}
+	require.NoError(t, submit())
+
+	caller := contract.NewCaller(client, bundler, ChainID, contract.NewABICache())
+	assert.Equal(t, amount, usdcBalance(t, ctx, caller, dep, recipient.addr()))
+	assert.Equal(t, big.NewInt(2_000_000), usdcBalance(t, ctx, caller, dep, account))
+
+	accountETH, err := client.BalanceAt(ctx, account, nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultETHFunding, accountETH, "the account paid for gas")
+	deposit := callView(t, ctx, client, entryPointABI, dep.EntryPoint, "balanceOf", dep.Paymaster)[0].(*big.Int)
+	assert.Equal(t, -1, deposit.Cmp(PaymasterDeposit), "the paymaster deposit did not pay for gas")
+	sponsored := callView(t, ctx, client, paymasterABI, dep.Paymaster, "sponsoredOps")[0].(*big.Int)
+	assert.Equal(t, int64(1), sponsored.Int64())
+
+	// The nonce advanced, so the same operation cannot be replayed.
+	nonce := callView(t, ctx, client, entryPointABI, dep.EntryPoint, "getNonce", account, new(big.Int))[0].(*big.Int)
+	assert.Equal(t, int64(1), nonce.Int64())
+	assert.Error(t, submit())
+}
+
+// sendRaw signs and sends a call from w and waits for a successful receipt.
+func sendRaw(ctx context.Context, client *ethclient.Client, w *keyWallet, to common.Address, data []byte) (*types.Receipt, error) {
+	nonce, err := client.PendingNonceAt(ctx, w.addr())
+	if err != nil {
+		return nil, err
+	}
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	tip := big.NewInt(1e9)
+	tx, err := types.SignNewTx(w.key, types.LatestSignerForChainID(big.NewInt(ChainID)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(ChainID),
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tip),
+		Gas:       1_000_000,
+		To:        &to,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	return waitReceipt(ctx, client, tx.Hash())
+}
@@ -0,0 +1,166 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/langoai/lango/internal/ent/auditcheckpoint"
+)
+
+// AuditCheckpoint is the model entity for the AuditCheckpoint schema.
+type AuditCheckpoint struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Audit chain position this checkpoint attests
+	Seq int64 `json:"seq,omitempty"`
+	// Chain hash of the audit record at seq
+	Hash string `json:"hash,omitempty"`
+	// DID of the signing identity
+	Signer string `json:"signer,omitempty"`
+	// Signature algorithm
+	Algorithm string `json:"algorithm,omitempty"`
+	// Signature holds the value of the "signature" field.
+	Signature []byte `json:"signature,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*AuditCheckpoint) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case auditcheckpoint.FieldSignature:
+			values[i] = new([]byte)
+		case auditcheckpoint.FieldSeq:
+			values[i] = new(sql.NullInt64)
+		case auditcheckpoint.FieldHash, auditcheckpoint.FieldSigner, auditcheckpoint.FieldAlgorithm:
+			values[i] = new(sql.NullString)
+		case auditcheckpoint.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case auditcheckpoint.FieldID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the AuditCheckpoint fields.
+func (_m *AuditCheckpoint) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case auditcheckpoint.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case auditcheckpoint.FieldSeq:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field seq", values[i])
+			} else if value.Valid {
+				_m.Seq = value.Int64
+			}
+		case auditcheckpoint.FieldHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field hash", values[i])
+			} else if value.Valid {
+				_m.Hash = value.String
+			}
+		case auditcheckpoint.FieldSigner:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field signer", values[i])
+			} else if value.Valid {
+				_m.Signer = value.String
+			}
+		case auditcheckpoint.FieldAlgorithm:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field algorithm", values[i])
+			} else if value.Valid {
+				_m.Algorithm = value.String
+			}
+		case auditcheckpoint.FieldSignature:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field signature", values[i])
+			} else if value != nil {
+				_m.Signature = *value
+			}
+		case auditcheckpoint.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the AuditCheckpoint.
+// This includes values selected through modifiers, order, etc.
+func (_m *AuditCheckpoint) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this AuditCheckpoint.
+// Note that you need to call AuditCheckpoint.Unwrap() before calling this method if this AuditCheckpoint
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *AuditCheckpoint) Update() *AuditCheckpointUpdateOne {
+	return NewAuditCheckpointClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the AuditCheckpoint entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *AuditCheckpoint) Unwrap() *AuditCheckpoint {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: AuditCheckpoint is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *AuditCheckpoint) String() string {
+	var builder strings.Builder
+	builder.WriteString("AuditCheckpoint(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("seq=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Seq))
+	builder.WriteString(", ")
+	builder.WriteString("hash=")
+	builder.WriteString(_m.Hash)
+	builder.WriteString(", ")
+	builder.WriteString("signer=")
+	builder.WriteString(_m.Signer)
+	builder.WriteString(", ")
+	builder.WriteString("algorithm=")
+	builder.WriteString(_m.Algorithm)
+	builder.WriteString(", ")
+	builder.WriteString("signature=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Signature))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// AuditCheckpoints is a parsable slice of AuditCheckpoint.
+type AuditCheckpoints []*AuditCheckpoint
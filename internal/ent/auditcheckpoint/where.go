@@ -0,0 +1,416 @@
+// Code generated by ent, DO NOT EDIT.
+
+package auditcheckpoint
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/langoai/lango/internal/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLTE(FieldID, id))
+}
+
+// Seq applies equality check predicate on the "seq" field. It's identical to SeqEQ.
+func Seq(v int64) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldSeq, v))
+}
+
+// Hash applies equality check predicate on the "hash" field. It's identical to HashEQ.
+func Hash(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldHash, v))
+}
+
+// Signer applies equality check predicate on the "signer" field. It's identical to SignerEQ.
+func Signer(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldSigner, v))
+}
+
+// Algorithm applies equality check predicate on the "algorithm" field. It's identical to AlgorithmEQ.
+func Algorithm(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldAlgorithm, v))
+}
+
+// Signature applies equality check predicate on the "signature" field. It's identical to SignatureEQ.
+func Signature(v []byte) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldSignature, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// SeqEQ applies the EQ predicate on the "seq" field.
+func SeqEQ(v int64) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldSeq, v))
+}
+
+// SeqNEQ applies the NEQ predicate on the "seq" field.
+func SeqNEQ(v int64) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNEQ(FieldSeq, v))
+}
+
+// SeqIn applies the In predicate on the "seq" field.
+func SeqIn(vs ...int64) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldIn(FieldSeq, vs...))
+}
+
+// SeqNotIn applies the NotIn predicate on the "seq" field.
+func SeqNotIn(vs ...int64) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNotIn(FieldSeq, vs...))
+}
+
+// SeqGT applies the GT predicate on the "seq" field.
+func SeqGT(v int64) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGT(FieldSeq, v))
+}
+
+// SeqGTE applies the GTE predicate on the "seq" field.
+func SeqGTE(v int64) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGTE(FieldSeq, v))
+}
+
+// SeqLT applies the LT predicate on the "seq" field.
+func SeqLT(v int64) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLT(FieldSeq, v))
+}
+
+// SeqLTE applies the LTE predicate on the "seq" field.
+func SeqLTE(v int64) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLTE(FieldSeq, v))
+}
+
+// HashEQ applies the EQ predicate on the "hash" field.
+func HashEQ(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldHash, v))
+}
+
+// HashNEQ applies the NEQ predicate on the "hash" field.
+func HashNEQ(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNEQ(FieldHash, v))
+}
+
+// HashIn applies the In predicate on the "hash" field.
+func HashIn(vs ...string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldIn(FieldHash, vs...))
+}
+
+// HashNotIn applies the NotIn predicate on the "hash" field.
+func HashNotIn(vs ...string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNotIn(FieldHash, vs...))
+}
+
+// HashGT applies the GT predicate on the "hash" field.
+func HashGT(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGT(FieldHash, v))
+}
+
+// HashGTE applies the GTE predicate on the "hash" field.
+func HashGTE(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGTE(FieldHash, v))
+}
+
+// HashLT applies the LT predicate on the "hash" field.
+func HashLT(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLT(FieldHash, v))
+}
+
+// HashLTE applies the LTE predicate on the "hash" field.
+func HashLTE(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLTE(FieldHash, v))
+}
+
+// HashContains applies the Contains predicate on the "hash" field.
+func HashContains(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldContains(FieldHash, v))
+}
+
+// HashHasPrefix applies the HasPrefix predicate on the "hash" field.
+func HashHasPrefix(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldHasPrefix(FieldHash, v))
+}
+
+// HashHasSuffix applies the HasSuffix predicate on the "hash" field.
+func HashHasSuffix(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldHasSuffix(FieldHash, v))
+}
+
+// HashEqualFold applies the EqualFold predicate on the "hash" field.
+func HashEqualFold(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEqualFold(FieldHash, v))
+}
+
+// HashContainsFold applies the ContainsFold predicate on the "hash" field.
+func HashContainsFold(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldContainsFold(FieldHash, v))
+}
+
+// SignerEQ applies the EQ predicate on the "signer" field.
+func SignerEQ(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldSigner, v))
+}
+
+// SignerNEQ applies the NEQ predicate on the "signer" field.
+func SignerNEQ(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNEQ(FieldSigner, v))
+}
+
+// SignerIn applies the In predicate on the "signer" field.
+func SignerIn(vs ...string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldIn(FieldSigner, vs...))
+}
+
+// SignerNotIn applies the NotIn predicate on the "signer" field.
+func SignerNotIn(vs ...string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNotIn(FieldSigner, vs...))
+}
+
+// SignerGT applies the GT predicate on the "signer" field.
+func SignerGT(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGT(FieldSigner, v))
+}
+
+// SignerGTE applies the GTE predicate on the "signer" field.
+func SignerGTE(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGTE(FieldSigner, v))
+}
+
+// SignerLT applies the LT predicate on the "signer" field.
+func SignerLT(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLT(FieldSigner, v))
+}
+
+// SignerLTE applies the LTE predicate on the "signer" field.
+func SignerLTE(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLTE(FieldSigner, v))
+}
+
+// SignerContains applies the Contains predicate on the "signer" field.
+func SignerContains(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldContains(FieldSigner, v))
+}
+
+// SignerHasPrefix applies the HasPrefix predicate on the "signer" field.
+func SignerHasPrefix(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldHasPrefix(FieldSigner, v))
+}
+
+// SignerHasSuffix applies the HasSuffix predicate on the "signer" field.
+func SignerHasSuffix(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldHasSuffix(FieldSigner, v))
+}
+
+// SignerEqualFold applies the EqualFold predicate on the "signer" field.
+func SignerEqualFold(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEqualFold(FieldSigner, v))
+}
+
+// SignerContainsFold applies the ContainsFold predicate on the "signer" field.
+func SignerContainsFold(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldContainsFold(FieldSigner, v))
+}
+
+// AlgorithmEQ applies the EQ predicate on the "algorithm" field.
+func AlgorithmEQ(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldAlgorithm, v))
+}
+
+// AlgorithmNEQ applies the NEQ predicate on the "algorithm" field.
+func AlgorithmNEQ(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNEQ(FieldAlgorithm, v))
+}
+
+// AlgorithmIn applies the In predicate on the "algorithm" field.
+func AlgorithmIn(vs ...string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldIn(FieldAlgorithm, vs...))
+}
+
+// AlgorithmNotIn applies the NotIn predicate on the "algorithm" field.
+func AlgorithmNotIn(vs ...string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNotIn(FieldAlgorithm, vs...))
+}
+
+// AlgorithmGT applies the GT predicate on the "algorithm" field.
+func AlgorithmGT(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGT(FieldAlgorithm, v))
+}
+
+// AlgorithmGTE applies the GTE predicate on the "algorithm" field.
+func AlgorithmGTE(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGTE(FieldAlgorithm, v))
+}
+
+// AlgorithmLT applies the LT predicate on the "algorithm" field.
+func AlgorithmLT(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLT(FieldAlgorithm, v))
+}
+
+// AlgorithmLTE applies the LTE predicate on the "algorithm" field.
+func AlgorithmLTE(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLTE(FieldAlgorithm, v))
+}
+
+// AlgorithmContains applies the Contains predicate on the "algorithm" field.
+func AlgorithmContains(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldContains(FieldAlgorithm, v))
+}
+
+// AlgorithmHasPrefix applies the HasPrefix predicate on the "algorithm" field.
+func AlgorithmHasPrefix(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldHasPrefix(FieldAlgorithm, v))
+}
+
+// AlgorithmHasSuffix applies the HasSuffix predicate on the "algorithm" field.
+func AlgorithmHasSuffix(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldHasSuffix(FieldAlgorithm, v))
+}
+
+// AlgorithmEqualFold applies the EqualFold predicate on the "algorithm" field.
+func AlgorithmEqualFold(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEqualFold(FieldAlgorithm, v))
+}
+
+// AlgorithmContainsFold applies the ContainsFold predicate on the "algorithm" field.
+func AlgorithmContainsFold(v string) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldContainsFold(FieldAlgorithm, v))
+}
+
+// SignatureEQ applies the EQ predicate on the "signature" field.
+func SignatureEQ(v []byte) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldSignature, v))
+}
+
+// SignatureNEQ applies the NEQ predicate on the "signature" field.
+func SignatureNEQ(v []byte) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNEQ(FieldSignature, v))
+}
+
+// SignatureIn applies the In predicate on the "signature" field.
+func SignatureIn(vs ...[]byte) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldIn(FieldSignature, vs...))
+}
+
+// SignatureNotIn applies the NotIn predicate on the "signature" field.
+func SignatureNotIn(vs ...[]byte) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNotIn(FieldSignature, vs...))
+}
+
+// SignatureGT applies the GT predicate on the "signature" field.
+func SignatureGT(v []byte) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGT(FieldSignature, v))
+}
+
+// SignatureGTE applies the GTE predicate on the "signature" field.
+func SignatureGTE(v []byte) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGTE(FieldSignature, v))
+}
+
+// SignatureLT applies the LT predicate on the "signature" field.
+func SignatureLT(v []byte) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLT(FieldSignature, v))
+}
+
+// SignatureLTE applies the LTE predicate on the "signature" field.
+func SignatureLTE(v []byte) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLTE(FieldSignature, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.AuditCheckpoint) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.AuditCheckpoint) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.AuditCheckpoint) predicate.AuditCheckpoint {
+	return predicate.AuditCheckpoint(sql.NotPredicates(p))
+}
@@ -0,0 +1,306 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/langoai/lango/internal/ent/auditcheckpoint"
+)
+
+// AuditCheckpointCreate is the builder for creating a AuditCheckpoint entity.
+type AuditCheckpointCreate struct {
+	config
+	mutation *AuditCheckpointMutation
+	hooks    []Hook
+}
+
+// SetSeq sets the "seq" field.
+func (_c *AuditCheckpointCreate) SetSeq(v int64) *AuditCheckpointCreate {
+	_c.mutation.SetSeq(v)
+	return _c
+}
+
+// SetHash sets the "hash" field.
+func (_c *AuditCheckpointCreate) SetHash(v string) *AuditCheckpointCreate {
+	_c.mutation.SetHash(v)
+	return _c
+}
+
+// SetSigner sets the "signer" field.
+func (_c *AuditCheckpointCreate) SetSigner(v string) *AuditCheckpointCreate {
+	_c.mutation.SetSigner(v)
+	return _c
+}
+
+// SetAlgorithm sets the "algorithm" field.
+func (_c *AuditCheckpointCreate) SetAlgorithm(v string) *AuditCheckpointCreate {
+	_c.mutation.SetAlgorithm(v)
+	return _c
+}
+
+// SetSignature sets the "signature" field.
+func (_c *AuditCheckpointCreate) SetSignature(v []byte) *AuditCheckpointCreate {
+	_c.mutation.SetSignature(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *AuditCheckpointCreate) SetCreatedAt(v time.Time) *AuditCheckpointCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *AuditCheckpointCreate) SetNillableCreatedAt(v *time.Time) *AuditCheckpointCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *AuditCheckpointCreate) SetID(v uuid.UUID) *AuditCheckpointCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *AuditCheckpointCreate) SetNillableID(v *uuid.UUID) *AuditCheckpointCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the AuditCheckpointMutation object of the builder.
+func (_c *AuditCheckpointCreate) Mutation() *AuditCheckpointMutation {
+	return _c.mutation
+}
+
+// Save creates the AuditCheckpoint in the database.
+func (_c *AuditCheckpointCreate) Save(ctx context.Context) (*AuditCheckpoint, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *AuditCheckpointCreate) SaveX(ctx context.Context) *AuditCheckpoint {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AuditCheckpointCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AuditCheckpointCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *AuditCheckpointCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := auditcheckpoint.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := auditcheckpoint.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *AuditCheckpointCreate) check() error {
+	if _, ok := _c.mutation.Seq(); !ok {
+		return &ValidationError{Name: "seq", err: errors.New(`ent: missing required field "AuditCheckpoint.seq"`)}
+	}
+	if _, ok := _c.mutation.Hash(); !ok {
+		return &ValidationError{Name: "hash", err: errors.New(`ent: missing required field "AuditCheckpoint.hash"`)}
+	}
+	if v, ok := _c.mutation.Hash(); ok {
+		if err := auditcheckpoint.HashValidator(v); err != nil {
+			return &ValidationError{Name: "hash", err: fmt.Errorf(`ent: validator failed for field "AuditCheckpoint.hash": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Signer(); !ok {
+		return &ValidationError{Name: "signer", err: errors.New(`ent: missing required field "AuditCheckpoint.signer"`)}
+	}
+	if v, ok := _c.mutation.Signer(); ok {
+		if err := auditcheckpoint.SignerValidator(v); err != nil {
+			return &ValidationError{Name: "signer", err: fmt.Errorf(`ent: validator failed for field "AuditCheckpoint.signer": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Algorithm(); !ok {
+		return &ValidationError{Name: "algorithm", err: errors.New(`ent: missing required field "AuditCheckpoint.algorithm"`)}
+	}
+	if v, ok := _c.mutation.Algorithm(); ok {
+		if err := auditcheckpoint.AlgorithmValidator(v); err != nil {
+			return &ValidationError{Name: "algorithm", err: fmt.Errorf(`ent: validator failed for field "AuditCheckpoint.algorithm": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Signature(); !ok {
+		return &ValidationError{Name: "signature", err: errors.New(`ent: missing required field "AuditCheckpoint.signature"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "AuditCheckpoint.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *AuditCheckpointCreate) sqlSave(ctx context.Context) (*AuditCheckpoint, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *AuditCheckpointCreate) createSpec() (*AuditCheckpoint, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AuditCheckpoint{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(auditcheckpoint.Table, sqlgraph.NewFieldSpec(auditcheckpoint.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Seq(); ok {
+		_spec.SetField(auditcheckpoint.FieldSeq, field.TypeInt64, value)
+		_node.Seq = value
+	}
+	if value, ok := _c.mutation.Hash(); ok {
+		_spec.SetField(auditcheckpoint.FieldHash, field.TypeString, value)
+		_node.Hash = value
+	}
+	if value, ok := _c.mutation.Signer(); ok {
+		_spec.SetField(auditcheckpoint.FieldSigner, field.TypeString, value)
+		_node.Signer = value
+	}
+	if value, ok := _c.mutation.Algorithm(); ok {
+		_spec.SetField(auditcheckpoint.FieldAlgorithm, field.TypeString, value)
+		_node.Algorithm = value
+	}
+	if value, ok := _c.mutation.Signature(); ok {
+		_spec.SetField(auditcheckpoint.FieldSignature, field.TypeBytes, value)
+		_node.Signature = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(auditcheckpoint.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// AuditCheckpointCreateBulk is the builder for creating many AuditCheckpoint entities in bulk.
+type AuditCheckpointCreateBulk struct {
+	config
+	err      error
+	builders []*AuditCheckpointCreate
+}
+
+// Save creates the AuditCheckpoint entities in the database.
+func (_c *AuditCheckpointCreateBulk) Save(ctx context.Context) ([]*AuditCheckpoint, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*AuditCheckpoint, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AuditCheckpointMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *AuditCheckpointCreateBulk) SaveX(ctx context.Context) []*AuditCheckpoint {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AuditCheckpointCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AuditCheckpointCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
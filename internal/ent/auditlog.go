@@ -30,7 +30,13 @@ type AuditLog struct {
 	// Details holds the value of the "details" field.
 	Details map[string]interface{} `json:"details,omitempty"`
 	// Timestamp holds the value of the "timestamp" field.
-	Timestamp    time.Time `json:"timestamp,omitempty"`
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	// Position in the audit hash chain; nil for rows written before chaining
+	Seq *int64 `json:"seq,omitempty"`
+	// Hash of the previous record in the chain (empty for the first)
+	PrevHash string `json:"prev_hash,omitempty"`
+	// SHA-256 of prev_hash and the record's canonical JSON
+	Hash         string `json:"hash,omitempty"`
 	selectValues sql.SelectValues
 }
 
@@ -41,7 +47,9 @@ func (*AuditLog) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case auditlog.FieldDetails:
 			values[i] = new([]byte)
-		case auditlog.FieldSessionKey, auditlog.FieldAction, auditlog.FieldActor, auditlog.FieldTarget:
+		case auditlog.FieldSeq:
+			values[i] = new(sql.NullInt64)
+		case auditlog.FieldSessionKey, auditlog.FieldAction, auditlog.FieldActor, auditlog.FieldTarget, auditlog.FieldPrevHash, auditlog.FieldHash:
 			values[i] = new(sql.NullString)
 		case auditlog.FieldTimestamp:
 			values[i] = new(sql.NullTime)
@@ -106,6 +114,25 @@ func (_m *AuditLog) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Timestamp = value.Time
 			}
+		case auditlog.FieldSeq:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field seq", values[i])
+			} else if value.Valid {
+				_m.Seq = new(int64)
+				*_m.Seq = value.Int64
+			}
+		case auditlog.FieldPrevHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field prev_hash", values[i])
+			} else if value.Valid {
+				_m.PrevHash = value.String
+			}
+		case auditlog.FieldHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field hash", values[i])
+			} else if value.Valid {
+				_m.Hash = value.String
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -159,6 +186,17 @@ func (_m *AuditLog) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("timestamp=")
 	builder.WriteString(_m.Timestamp.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := _m.Seq; v != nil {
+		builder.WriteString("seq=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("prev_hash=")
+	builder.WriteString(_m.PrevHash)
+	builder.WriteString(", ")
+	builder.WriteString("hash=")
+	builder.WriteString(_m.Hash)
 	builder.WriteByte(')')
 	return builder.String()
 }
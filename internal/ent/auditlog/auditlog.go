@@ -27,6 +27,12 @@ const (
 	FieldDetails = "details"
 	// FieldTimestamp holds the string denoting the timestamp field in the database.
 	FieldTimestamp = "timestamp"
+	// FieldSeq holds the string denoting the seq field in the database.
+	FieldSeq = "seq"
+	// FieldPrevHash holds the string denoting the prev_hash field in the database.
+	FieldPrevHash = "prev_hash"
+	// FieldHash holds the string denoting the hash field in the database.
+	FieldHash = "hash"
 	// Table holds the table name of the auditlog in the database.
 	Table = "audit_logs"
 )
@@ -40,6 +46,9 @@ var Columns = []string{
 	FieldTarget,
 	FieldDetails,
 	FieldTimestamp,
+	FieldSeq,
+	FieldPrevHash,
+	FieldHash,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -66,19 +75,21 @@ type Action string
 
 // Action values.
 const (
-	ActionToolCall         Action = "tool_call"
-	ActionKnowledgeSave    Action = "knowledge_save"
-	ActionLearningSave     Action = "learning_save"
-	ActionSkillCreate      Action = "skill_create"
-	ActionSkillExecute     Action = "skill_execute"
-	ActionSkillImport      Action = "skill_import"
-	ActionSkillImportBulk  Action = "skill_import_bulk"
-	ActionKnowledgeSearch  Action = "knowledge_search"
-	ActionApprovalRequest  Action = "approval_request"
-	ActionApprovalResponse Action = "approval_response"
-	ActionPolicyDecision   Action = "policy_decision"
-	ActionAlert            Action = "alert"
-	ActionSandboxDecision  Action = "sandbox_decision"
+	ActionToolCall                 Action = "tool_call"
+	ActionKnowledgeSave            Action = "knowledge_save"
+	ActionLearningSave             Action = "learning_save"
+	ActionSkillCreate              Action = "skill_create"
+	ActionSkillExecute             Action = "skill_execute"
+	ActionSkillImport              Action = "skill_import"
+	ActionSkillImportBulk          Action = "skill_import_bulk"
+	ActionKnowledgeSearch          Action = "knowledge_search"
+	ActionApprovalRequest          Action = "approval_request"
+	ActionApprovalResponse         Action = "approval_response"
+	ActionPolicyDecision           Action = "policy_decision"
+	ActionAlert                    Action = "alert"
+	ActionSandboxDecision          Action = "sandbox_decision"
+	ActionSecretAccess             Action = "secret_access"
+	ActionWorkspaceMessageRejected Action = "workspace_message_rejected"
 )
 
 func (a Action) String() string {
@@ -88,7 +99,7 @@ func (a Action) String() string {
 // ActionValidator is a validator for the "action" field enum values. It is called by the builders before save.
 func ActionValidator(a Action) error {
 	switch a {
-	case ActionToolCall, ActionKnowledgeSave, ActionLearningSave, ActionSkillCreate, ActionSkillExecute, ActionSkillImport, ActionSkillImportBulk, ActionKnowledgeSearch, ActionApprovalRequest, ActionApprovalResponse, ActionPolicyDecision, ActionAlert, ActionSandboxDecision:
+	case ActionToolCall, ActionKnowledgeSave, ActionLearningSave, ActionSkillCreate, ActionSkillExecute, ActionSkillImport, ActionSkillImportBulk, ActionKnowledgeSearch, ActionApprovalRequest, ActionApprovalResponse, ActionPolicyDecision, ActionAlert, ActionSandboxDecision, ActionSecretAccess, ActionWorkspaceMessageRejected:
 		return nil
 	default:
 		return fmt.Errorf("auditlog: invalid enum value for action field: %q", a)
@@ -127,3 +138,18 @@ func ByTarget(opts ...sql.OrderTermOption) OrderOption {
 func ByTimestamp(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldTimestamp, opts...).ToFunc()
 }
+
+// BySeq orders the results by the seq field.
+func BySeq(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSeq, opts...).ToFunc()
+}
+
+// ByPrevHash orders the results by the prev_hash field.
+func ByPrevHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPrevHash, opts...).ToFunc()
+}
+
+// ByHash orders the results by the hash field.
+func ByHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldHash, opts...).ToFunc()
+}
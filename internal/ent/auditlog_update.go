@@ -190,6 +190,15 @@ func (_u *AuditLogUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.DetailsCleared() {
 		_spec.ClearField(auditlog.FieldDetails, field.TypeJSON)
 	}
+	if _u.mutation.SeqCleared() {
+		_spec.ClearField(auditlog.FieldSeq, field.TypeInt64)
+	}
+	if _u.mutation.PrevHashCleared() {
+		_spec.ClearField(auditlog.FieldPrevHash, field.TypeString)
+	}
+	if _u.mutation.HashCleared() {
+		_spec.ClearField(auditlog.FieldHash, field.TypeString)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{auditlog.Label}
@@ -403,6 +412,15 @@ func (_u *AuditLogUpdateOne) sqlSave(ctx context.Context) (_node *AuditLog, err
 	if _u.mutation.DetailsCleared() {
 		_spec.ClearField(auditlog.FieldDetails, field.TypeJSON)
 	}
+	if _u.mutation.SeqCleared() {
+		_spec.ClearField(auditlog.FieldSeq, field.TypeInt64)
+	}
+	if _u.mutation.PrevHashCleared() {
+		_spec.ClearField(auditlog.FieldPrevHash, field.TypeString)
+	}
+	if _u.mutation.HashCleared() {
+		_spec.ClearField(auditlog.FieldHash, field.TypeString)
+	}
 	_node = &AuditLog{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
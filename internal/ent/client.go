@@ -18,6 +18,7 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/langoai/lango/internal/ent/actionlog"
 	"github.com/langoai/lango/internal/ent/agentmemory"
+	"github.com/langoai/lango/internal/ent/auditcheckpoint"
 	"github.com/langoai/lango/internal/ent/auditlog"
 	"github.com/langoai/lango/internal/ent/configprofile"
 	"github.com/langoai/lango/internal/ent/cronjob"
@@ -44,6 +45,7 @@ import (
 	"github.com/langoai/lango/internal/ent/runsnapshot"
 	"github.com/langoai/lango/internal/ent/runstep"
 	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
 	"github.com/langoai/lango/internal/ent/session"
 	"github.com/langoai/lango/internal/ent/sessionprovenance"
 	"github.com/langoai/lango/internal/ent/tokenusage"
@@ -62,6 +64,8 @@ type Client struct {
 	ActionLog *ActionLogClient
 	// AgentMemory is the client for interacting with the AgentMemory builders.
 	AgentMemory *AgentMemoryClient
+	// AuditCheckpoint is the client for interacting with the AuditCheckpoint builders.
+	AuditCheckpoint *AuditCheckpointClient
 	// AuditLog is the client for interacting with the AuditLog builders.
 	AuditLog *AuditLogClient
 	// ConfigProfile is the client for interacting with the ConfigProfile builders.
@@ -114,6 +118,8 @@ type Client struct {
 	RunStep *RunStepClient
 	// Secret is the client for interacting with the Secret builders.
 	Secret *SecretClient
+	// SecretVersion is the client for interacting with the SecretVersion builders.
+	SecretVersion *SecretVersionClient
 	// Session is the client for interacting with the Session builders.
 	Session *SessionClient
 	// SessionProvenance is the client for interacting with the SessionProvenance builders.
@@ -141,6 +147,7 @@ func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
 	c.ActionLog = NewActionLogClient(c.config)
 	c.AgentMemory = NewAgentMemoryClient(c.config)
+	c.AuditCheckpoint = NewAuditCheckpointClient(c.config)
 	c.AuditLog = NewAuditLogClient(c.config)
 	c.ConfigProfile = NewConfigProfileClient(c.config)
 	c.CronJob = NewCronJobClient(c.config)
@@ -167,6 +174,7 @@ func (c *Client) init() {
 	c.RunSnapshot = NewRunSnapshotClient(c.config)
 	c.RunStep = NewRunStepClient(c.config)
 	c.Secret = NewSecretClient(c.config)
+	c.SecretVersion = NewSecretVersionClient(c.config)
 	c.Session = NewSessionClient(c.config)
 	c.SessionProvenance = NewSessionProvenanceClient(c.config)
 	c.TokenUsage = NewTokenUsageClient(c.config)
@@ -268,6 +276,7 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 		config:                cfg,
 		ActionLog:             NewActionLogClient(cfg),
 		AgentMemory:           NewAgentMemoryClient(cfg),
+		AuditCheckpoint:       NewAuditCheckpointClient(cfg),
 		AuditLog:              NewAuditLogClient(cfg),
 		ConfigProfile:         NewConfigProfileClient(cfg),
 		CronJob:               NewCronJobClient(cfg),
@@ -294,6 +303,7 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 		RunSnapshot:           NewRunSnapshotClient(cfg),
 		RunStep:               NewRunStepClient(cfg),
 		Secret:                NewSecretClient(cfg),
+		SecretVersion:         NewSecretVersionClient(cfg),
 		Session:               NewSessionClient(cfg),
 		SessionProvenance:     NewSessionProvenanceClient(cfg),
 		TokenUsage:            NewTokenUsageClient(cfg),
@@ -322,6 +332,7 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 		config:                cfg,
 		ActionLog:             NewActionLogClient(cfg),
 		AgentMemory:           NewAgentMemoryClient(cfg),
+		AuditCheckpoint:       NewAuditCheckpointClient(cfg),
 		AuditLog:              NewAuditLogClient(cfg),
 		ConfigProfile:         NewConfigProfileClient(cfg),
 		CronJob:               NewCronJobClient(cfg),
@@ -348,6 +359,7 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 		RunSnapshot:           NewRunSnapshotClient(cfg),
 		RunStep:               NewRunStepClient(cfg),
 		Secret:                NewSecretClient(cfg),
+		SecretVersion:         NewSecretVersionClient(cfg),
 		Session:               NewSessionClient(cfg),
 		SessionProvenance:     NewSessionProvenanceClient(cfg),
 		TokenUsage:            NewTokenUsageClient(cfg),
@@ -384,14 +396,14 @@ func (c *Client) Close() error {
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
 	for _, n := range []interface{ Use(...Hook) }{
-		c.ActionLog, c.AgentMemory, c.AuditLog, c.ConfigProfile, c.CronJob,
-		c.CronJobHistory, c.EntityAlias, c.EntityProperty, c.EscrowDeal, c.ExternalRef,
-		c.Inquiry, c.Key, c.Knowledge, c.Learning, c.Message, c.Observation,
-		c.OntologyConflict, c.OntologyPredicate, c.OntologyType, c.PaymentTx,
-		c.PeerReputation, c.ProvenanceAttribution, c.ProvenanceCheckpoint,
-		c.Reflection, c.RunJournal, c.RunSnapshot, c.RunStep, c.Secret, c.Session,
-		c.SessionProvenance, c.TokenUsage, c.TurnTrace, c.TurnTraceEvent,
-		c.WorkflowRun, c.WorkflowStepRun,
+		c.ActionLog, c.AgentMemory, c.AuditCheckpoint, c.AuditLog, c.ConfigProfile,
+		c.CronJob, c.CronJobHistory, c.EntityAlias, c.EntityProperty, c.EscrowDeal,
+		c.ExternalRef, c.Inquiry, c.Key, c.Knowledge, c.Learning, c.Message,
+		c.Observation, c.OntologyConflict, c.OntologyPredicate, c.OntologyType,
+		c.PaymentTx, c.PeerReputation, c.ProvenanceAttribution, c.ProvenanceCheckpoint,
+		c.Reflection, c.RunJournal, c.RunSnapshot, c.RunStep, c.Secret,
+		c.SecretVersion, c.Session, c.SessionProvenance, c.TokenUsage, c.TurnTrace,
+		c.TurnTraceEvent, c.WorkflowRun, c.WorkflowStepRun,
 	} {
 		n.Use(hooks...)
 	}
@@ -401,14 +413,14 @@ func (c *Client) Use(hooks ...Hook) {
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
 	for _, n := range []interface{ Intercept(...Interceptor) }{
-		c.ActionLog, c.AgentMemory, c.AuditLog, c.ConfigProfile, c.CronJob,
-		c.CronJobHistory, c.EntityAlias, c.EntityProperty, c.EscrowDeal, c.ExternalRef,
-		c.Inquiry, c.Key, c.Knowledge, c.Learning, c.Message, c.Observation,
-		c.OntologyConflict, c.OntologyPredicate, c.OntologyType, c.PaymentTx,
-		c.PeerReputation, c.ProvenanceAttribution, c.ProvenanceCheckpoint,
-		c.Reflection, c.RunJournal, c.RunSnapshot, c.RunStep, c.Secret, c.Session,
-		c.SessionProvenance, c.TokenUsage, c.TurnTrace, c.TurnTraceEvent,
-		c.WorkflowRun, c.WorkflowStepRun,
+		c.ActionLog, c.AgentMemory, c.AuditCheckpoint, c.AuditLog, c.ConfigProfile,
+		c.CronJob, c.CronJobHistory, c.EntityAlias, c.EntityProperty, c.EscrowDeal,
+		c.ExternalRef, c.Inquiry, c.Key, c.Knowledge, c.Learning, c.Message,
+		c.Observation, c.OntologyConflict, c.OntologyPredicate, c.OntologyType,
+		c.PaymentTx, c.PeerReputation, c.ProvenanceAttribution, c.ProvenanceCheckpoint,
+		c.Reflection, c.RunJournal, c.RunSnapshot, c.RunStep, c.Secret,
+		c.SecretVersion, c.Session, c.SessionProvenance, c.TokenUsage, c.TurnTrace,
+		c.TurnTraceEvent, c.WorkflowRun, c.WorkflowStepRun,
 	} {
 		n.Intercept(interceptors...)
 	}
@@ -421,6 +433,8 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 		return c.ActionLog.mutate(ctx, m)
 	case *AgentMemoryMutation:
 		return c.AgentMemory.mutate(ctx, m)
+	case *AuditCheckpointMutation:
+		return c.AuditCheckpoint.mutate(ctx, m)
 	case *AuditLogMutation:
 		return c.AuditLog.mutate(ctx, m)
 	case *ConfigProfileMutation:
@@ -473,6 +487,8 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 		return c.RunStep.mutate(ctx, m)
 	case *SecretMutation:
 		return c.Secret.mutate(ctx, m)
+	case *SecretVersionMutation:
+		return c.SecretVersion.mutate(ctx, m)
 	case *SessionMutation:
 		return c.Session.mutate(ctx, m)
 	case *SessionProvenanceMutation:
@@ -758,6 +774,139 @@ func (c *AgentMemoryClient) mutate(ctx context.Context, m *AgentMemoryMutation)
 	}
 }
 
+// AuditCheckpointClient is a client for the AuditCheckpoint schema.
+type AuditCheckpointClient struct {
+	config
+}
+
+// NewAuditCheckpointClient returns a client for the AuditCheckpoint from the given config.
+func NewAuditCheckpointClient(c config) *AuditCheckpointClient {
+	return &AuditCheckpointClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `auditcheckpoint.Hooks(f(g(h())))`.
+func (c *AuditCheckpointClient) Use(hooks ...Hook) {
+	c.hooks.AuditCheckpoint = append(c.hooks.AuditCheckpoint, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `auditcheckpoint.Intercept(f(g(h())))`.
+func (c *AuditCheckpointClient) Intercept(interceptors ...Interceptor) {
+	c.inters.AuditCheckpoint = append(c.inters.AuditCheckpoint, interceptors...)
+}
+
+// Create returns a builder for creating a AuditCheckpoint entity.
+func (c *AuditCheckpointClient) Create() *AuditCheckpointCreate {
+	mutation := newAuditCheckpointMutation(c.config, OpCreate)
+	return &AuditCheckpointCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of AuditCheckpoint entities.
+func (c *AuditCheckpointClient) CreateBulk(builders ...*AuditCheckpointCreate) *AuditCheckpointCreateBulk {
+	return &AuditCheckpointCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *AuditCheckpointClient) MapCreateBulk(slice any, setFunc func(*AuditCheckpointCreate, int)) *AuditCheckpointCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &AuditCheckpointCreateBulk{err: fmt.Errorf("calling to AuditCheckpointClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*AuditCheckpointCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &AuditCheckpointCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for AuditCheckpoint.
+func (c *AuditCheckpointClient) Update() *AuditCheckpointUpdate {
+	mutation := newAuditCheckpointMutation(c.config, OpUpdate)
+	return &AuditCheckpointUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *AuditCheckpointClient) UpdateOne(_m *AuditCheckpoint) *AuditCheckpointUpdateOne {
+	mutation := newAuditCheckpointMutation(c.config, OpUpdateOne, withAuditCheckpoint(_m))
+	return &AuditCheckpointUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *AuditCheckpointClient) UpdateOneID(id uuid.UUID) *AuditCheckpointUpdateOne {
+	mutation := newAuditCheckpointMutation(c.config, OpUpdateOne, withAuditCheckpointID(id))
+	return &AuditCheckpointUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for AuditCheckpoint.
+func (c *AuditCheckpointClient) Delete() *AuditCheckpointDelete {
+	mutation := newAuditCheckpointMutation(c.config, OpDelete)
+	return &AuditCheckpointDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *AuditCheckpointClient) DeleteOne(_m *AuditCheckpoint) *AuditCheckpointDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *AuditCheckpointClient) DeleteOneID(id uuid.UUID) *AuditCheckpointDeleteOne {
+	builder := c.Delete().Where(auditcheckpoint.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &AuditCheckpointDeleteOne{builder}
+}
+
+// Query returns a query builder for AuditCheckpoint.
+func (c *AuditCheckpointClient) Query() *AuditCheckpointQuery {
+	return &AuditCheckpointQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeAuditCheckpoint},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a AuditCheckpoint entity by its id.
+func (c *AuditCheckpointClient) Get(ctx context.Context, id uuid.UUID) (*AuditCheckpoint, error) {
+	return c.Query().Where(auditcheckpoint.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *AuditCheckpointClient) GetX(ctx context.Context, id uuid.UUID) *AuditCheckpoint {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *AuditCheckpointClient) Hooks() []Hook {
+	return c.hooks.AuditCheckpoint
+}
+
+// Interceptors returns the client interceptors.
+func (c *AuditCheckpointClient) Interceptors() []Interceptor {
+	return c.inters.AuditCheckpoint
+}
+
+func (c *AuditCheckpointClient) mutate(ctx context.Context, m *AuditCheckpointMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&AuditCheckpointCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&AuditCheckpointUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&AuditCheckpointUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&AuditCheckpointDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown AuditCheckpoint mutation op: %q", m.Op())
+	}
+}
+
 // AuditLogClient is a client for the AuditLog schema.
 type AuditLogClient struct {
 	config
@@ -2079,6 +2228,22 @@ func (c *KeyClient) QuerySecrets(_m *Key) *SecretQuery {
 	return query
 }
 
+// QuerySecretVersions queries the secret_versions edge of a Key.
+func (c *KeyClient) QuerySecretVersions(_m *Key) *SecretVersionQuery {
+	query := (&SecretVersionClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(key.Table, key.FieldID, id),
+			sqlgraph.To(secretversion.Table, secretversion.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, key.SecretVersionsTable, key.SecretVersionsColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
 // Hooks returns the client hooks.
 func (c *KeyClient) Hooks() []Hook {
 	return c.hooks.Key
@@ -4239,6 +4404,22 @@ func (c *SecretClient) QueryKey(_m *Secret) *KeyQuery {
 	return query
 }
 
+// QueryVersions queries the versions edge of a Secret.
+func (c *SecretClient) QueryVersions(_m *Secret) *SecretVersionQuery {
+	query := (&SecretVersionClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(secret.Table, secret.FieldID, id),
+			sqlgraph.To(secretversion.Table, secretversion.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, secret.VersionsTable, secret.VersionsColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
 // Hooks returns the client hooks.
 func (c *SecretClient) Hooks() []Hook {
 	return c.hooks.Secret
@@ -4264,6 +4445,171 @@ func (c *SecretClient) mutate(ctx context.Context, m *SecretMutation) (Value, er
 	}
 }
 
+// SecretVersionClient is a client for the SecretVersion schema.
+type SecretVersionClient struct {
+	config
+}
+
+// NewSecretVersionClient returns a client for the SecretVersion from the given config.
+func NewSecretVersionClient(c config) *SecretVersionClient {
+	return &SecretVersionClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `secretversion.Hooks(f(g(h())))`.
+func (c *SecretVersionClient) Use(hooks ...Hook) {
+	c.hooks.SecretVersion = append(c.hooks.SecretVersion, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `secretversion.Intercept(f(g(h())))`.
+func (c *SecretVersionClient) Intercept(interceptors ...Interceptor) {
+	c.inters.SecretVersion = append(c.inters.SecretVersion, interceptors...)
+}
+
+// Create returns a builder for creating a SecretVersion entity.
+func (c *SecretVersionClient) Create() *SecretVersionCreate {
+	mutation := newSecretVersionMutation(c.config, OpCreate)
+	return &SecretVersionCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of SecretVersion entities.
+func (c *SecretVersionClient) CreateBulk(builders ...*SecretVersionCreate) *SecretVersionCreateBulk {
+	return &SecretVersionCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *SecretVersionClient) MapCreateBulk(slice any, setFunc func(*SecretVersionCreate, int)) *SecretVersionCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &SecretVersionCreateBulk{err: fmt.Errorf("calling to SecretVersionClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*SecretVersionCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &SecretVersionCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for SecretVersion.
+func (c *SecretVersionClient) Update() *SecretVersionUpdate {
+	mutation := newSecretVersionMutation(c.config, OpUpdate)
+	return &SecretVersionUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *SecretVersionClient) UpdateOne(_m *SecretVersion) *SecretVersionUpdateOne {
+	mutation := newSecretVersionMutation(c.config, OpUpdateOne, withSecretVersion(_m))
+	return &SecretVersionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *SecretVersionClient) UpdateOneID(id uuid.UUID) *SecretVersionUpdateOne {
+	mutation := newSecretVersionMutation(c.config, OpUpdateOne, withSecretVersionID(id))
+	return &SecretVersionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for SecretVersion.
+func (c *SecretVersionClient) Delete() *SecretVersionDelete {
+	mutation := newSecretVersionMutation(c.config, OpDelete)
+	return &SecretVersionDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *SecretVersionClient) DeleteOne(_m *SecretVersion) *SecretVersionDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *SecretVersionClient) DeleteOneID(id uuid.UUID) *SecretVersionDeleteOne {
+	builder := c.Delete().Where(secretversion.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &SecretVersionDeleteOne{builder}
+}
+
+// Query returns a query builder for SecretVersion.
+func (c *SecretVersionClient) Query() *SecretVersionQuery {
+	return &SecretVersionQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeSecretVersion},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a SecretVersion entity by its id.
+func (c *SecretVersionClient) Get(ctx context.Context, id uuid.UUID) (*SecretVersion, error) {
+	return c.Query().Where(secretversion.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *SecretVersionClient) GetX(ctx context.Context, id uuid.UUID) *SecretVersion {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QuerySecret queries the secret edge of a SecretVersion.
+func (c *SecretVersionClient) QuerySecret(_m *SecretVersion) *SecretQuery {
+	query := (&SecretClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(secretversion.Table, secretversion.FieldID, id),
+			sqlgraph.To(secret.Table, secret.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, secretversion.SecretTable, secretversion.SecretColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// QueryKey queries the key edge of a SecretVersion.
+func (c *SecretVersionClient) QueryKey(_m *SecretVersion) *KeyQuery {
+	query := (&KeyClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(secretversion.Table, secretversion.FieldID, id),
+			sqlgraph.To(key.Table, key.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, secretversion.KeyTable, secretversion.KeyColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *SecretVersionClient) Hooks() []Hook {
+	return c.hooks.SecretVersion
+}
+
+// Interceptors returns the client interceptors.
+func (c *SecretVersionClient) Interceptors() []Interceptor {
+	return c.inters.SecretVersion
+}
+
+func (c *SecretVersionClient) mutate(ctx context.Context, m *SecretVersionMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&SecretVersionCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&SecretVersionUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&SecretVersionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&SecretVersionDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown SecretVersion mutation op: %q", m.Op())
+	}
+}
+
 // SessionClient is a client for the Session schema.
 type SessionClient struct {
 	config
@@ -5214,21 +5560,22 @@ func (c *WorkflowStepRunClient) mutate(ctx context.Context, m *WorkflowStepRunMu
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		ActionLog, AgentMemory, AuditLog, ConfigProfile, CronJob, CronJobHistory,
-		EntityAlias, EntityProperty, EscrowDeal, ExternalRef, Inquiry, Key, Knowledge,
-		Learning, Message, Observation, OntologyConflict, OntologyPredicate,
-		OntologyType, PaymentTx, PeerReputation, ProvenanceAttribution,
-		ProvenanceCheckpoint, Reflection, RunJournal, RunSnapshot, RunStep, Secret,
-		Session, SessionProvenance, TokenUsage, TurnTrace, TurnTraceEvent, WorkflowRun,
-		WorkflowStepRun []ent.Hook
+		ActionLog, AgentMemory, AuditCheckpoint, AuditLog, ConfigProfile, CronJob,
+		CronJobHistory, EntityAlias, EntityProperty, EscrowDeal, ExternalRef, Inquiry,
+		Key, Knowledge, Learning, Message, Observation, OntologyConflict,
+		OntologyPredicate, OntologyType, PaymentTx, PeerReputation,
+		ProvenanceAttribution, ProvenanceCheckpoint, Reflection, RunJournal,
+		RunSnapshot, RunStep, Secret, SecretVersion, Session, SessionProvenance,
+		TokenUsage, TurnTrace, TurnTraceEvent, WorkflowRun, WorkflowStepRun []ent.Hook
 	}
 	inters struct {
-		ActionLog, AgentMemory, AuditLog, ConfigProfile, CronJob, CronJobHistory,
-		EntityAlias, EntityProperty, EscrowDeal, ExternalRef, Inquiry, Key, Knowledge,
-		Learning, Message, Observation, OntologyConflict, OntologyPredicate,
-		OntologyType, PaymentTx, PeerReputation, ProvenanceAttribution,
-		ProvenanceCheckpoint, Reflection, RunJournal, RunSnapshot, RunStep, Secret,
-		Session, SessionProvenance, TokenUsage, TurnTrace, TurnTraceEvent, WorkflowRun,
+		ActionLog, AgentMemory, AuditCheckpoint, AuditLog, ConfigProfile, CronJob,
+		CronJobHistory, EntityAlias, EntityProperty, EscrowDeal, ExternalRef, Inquiry,
+		Key, Knowledge, Learning, Message, Observation, OntologyConflict,
+		OntologyPredicate, OntologyType, PaymentTx, PeerReputation,
+		ProvenanceAttribution, ProvenanceCheckpoint, Reflection, RunJournal,
+		RunSnapshot, RunStep, Secret, SecretVersion, Session, SessionProvenance,
+		TokenUsage, TurnTrace, TurnTraceEvent, WorkflowRun,
 		WorkflowStepRun []ent.Interceptor
 	}
 )
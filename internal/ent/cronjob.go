@@ -21,10 +21,12 @@ type CronJob struct {
 	ID uuid.UUID `json:"id,omitempty"`
 	// Human-readable job name
 	Name string `json:"name,omitempty"`
-	// Schedule type: one-time, interval, or cron expression
+	// Schedule type: one-time, interval, cron expression, or trigger-only
 	ScheduleType cronjob.ScheduleType `json:"schedule_type,omitempty"`
-	// Schedule value: ISO8601 datetime, duration, or cron expression
+	// Schedule value: ISO8601 datetime, duration, cron expression, or trigger summary
 	Schedule string `json:"schedule,omitempty"`
+	// Event trigger spec as JSON (file watch, webhook, or event bus)
+	Trigger string `json:"trigger,omitempty"`
 	// Prompt to execute when the job fires
 	Prompt string `json:"prompt,omitempty"`
 	// Session mode: isolated or main
@@ -59,7 +61,7 @@ func (*CronJob) scanValues(columns []string) ([]any, error) {
 			values[i] = new(sql.NullBool)
 		case cronjob.FieldTimeoutMs:
 			values[i] = new(sql.NullInt64)
-		case cronjob.FieldName, cronjob.FieldScheduleType, cronjob.FieldSchedule, cronjob.FieldPrompt, cronjob.FieldSessionMode, cronjob.FieldTimezone:
+		case cronjob.FieldName, cronjob.FieldScheduleType, cronjob.FieldSchedule, cronjob.FieldTrigger, cronjob.FieldPrompt, cronjob.FieldSessionMode, cronjob.FieldTimezone:
 			values[i] = new(sql.NullString)
 		case cronjob.FieldLastRunAt, cronjob.FieldNextRunAt, cronjob.FieldCreatedAt, cronjob.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
@@ -104,6 +106,12 @@ func (_m *CronJob) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Schedule = value.String
 			}
+		case cronjob.FieldTrigger:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field trigger", values[i])
+			} else if value.Valid {
+				_m.Trigger = value.String
+			}
 		case cronjob.FieldPrompt:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field prompt", values[i])
@@ -214,6 +222,9 @@ func (_m *CronJob) String() string {
 	builder.WriteString("schedule=")
 	builder.WriteString(_m.Schedule)
 	builder.WriteString(", ")
+	builder.WriteString("trigger=")
+	builder.WriteString(_m.Trigger)
+	builder.WriteString(", ")
 	builder.WriteString("prompt=")
 	builder.WriteString(_m.Prompt)
 	builder.WriteString(", ")
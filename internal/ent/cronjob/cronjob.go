@@ -21,6 +21,8 @@ const (
 	FieldScheduleType = "schedule_type"
 	// FieldSchedule holds the string denoting the schedule field in the database.
 	FieldSchedule = "schedule"
+	// FieldTrigger holds the string denoting the trigger field in the database.
+	FieldTrigger = "trigger"
 	// FieldPrompt holds the string denoting the prompt field in the database.
 	FieldPrompt = "prompt"
 	// FieldSessionMode holds the string denoting the session_mode field in the database.
@@ -51,6 +53,7 @@ var Columns = []string{
 	FieldName,
 	FieldScheduleType,
 	FieldSchedule,
+	FieldTrigger,
 	FieldPrompt,
 	FieldSessionMode,
 	FieldDeliverTo,
@@ -101,9 +104,10 @@ type ScheduleType string
 
 // ScheduleType values.
 const (
-	ScheduleTypeAt    ScheduleType = "at"
-	ScheduleTypeEvery ScheduleType = "every"
-	ScheduleTypeCron  ScheduleType = "cron"
+	ScheduleTypeAt      ScheduleType = "at"
+	ScheduleTypeEvery   ScheduleType = "every"
+	ScheduleTypeCron    ScheduleType = "cron"
+	ScheduleTypeTrigger ScheduleType = "trigger"
 )
 
 func (st ScheduleType) String() string {
@@ -113,7 +117,7 @@ func (st ScheduleType) String() string {
 // ScheduleTypeValidator is a validator for the "schedule_type" field enum values. It is called by the builders before save.
 func ScheduleTypeValidator(st ScheduleType) error {
 	switch st {
-	case ScheduleTypeAt, ScheduleTypeEvery, ScheduleTypeCron:
+	case ScheduleTypeAt, ScheduleTypeEvery, ScheduleTypeCron, ScheduleTypeTrigger:
 		return nil
 	default:
 		return fmt.Errorf("cronjob: invalid enum value for schedule_type field: %q", st)
@@ -143,6 +147,11 @@ func BySchedule(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldSchedule, opts...).ToFunc()
 }
 
+// ByTrigger orders the results by the trigger field.
+func ByTrigger(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTrigger, opts...).ToFunc()
+}
+
 // ByPrompt orders the results by the prompt field.
 func ByPrompt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldPrompt, opts...).ToFunc()
@@ -39,6 +39,20 @@ func (_c *CronJobCreate) SetSchedule(v string) *CronJobCreate {
 	return _c
 }
 
+// SetTrigger sets the "trigger" field.
+func (_c *CronJobCreate) SetTrigger(v string) *CronJobCreate {
+	_c.mutation.SetTrigger(v)
+	return _c
+}
+
+// SetNillableTrigger sets the "trigger" field if the given value is not nil.
+func (_c *CronJobCreate) SetNillableTrigger(v *string) *CronJobCreate {
+	if v != nil {
+		_c.SetTrigger(*v)
+	}
+	return _c
+}
+
 // SetPrompt sets the "prompt" field.
 func (_c *CronJobCreate) SetPrompt(v string) *CronJobCreate {
 	_c.mutation.SetPrompt(v)
@@ -334,6 +348,10 @@ func (_c *CronJobCreate) createSpec() (*CronJob, *sqlgraph.CreateSpec) {
 		_spec.SetField(cronjob.FieldSchedule, field.TypeString, value)
 		_node.Schedule = value
 	}
+	if value, ok := _c.mutation.Trigger(); ok {
+		_spec.SetField(cronjob.FieldTrigger, field.TypeString, value)
+		_node.Trigger = value
+	}
 	if value, ok := _c.mutation.Prompt(); ok {
 		_spec.SetField(cronjob.FieldPrompt, field.TypeString, value)
 		_node.Prompt = value
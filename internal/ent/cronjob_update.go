@@ -71,6 +71,26 @@ func (_u *CronJobUpdate) SetNillableSchedule(v *string) *CronJobUpdate {
 	return _u
 }
 
+// SetTrigger sets the "trigger" field.
+func (_u *CronJobUpdate) SetTrigger(v string) *CronJobUpdate {
+	_u.mutation.SetTrigger(v)
+	return _u
+}
+
+// SetNillableTrigger sets the "trigger" field if the given value is not nil.
+func (_u *CronJobUpdate) SetNillableTrigger(v *string) *CronJobUpdate {
+	if v != nil {
+		_u.SetTrigger(*v)
+	}
+	return _u
+}
+
+// ClearTrigger clears the value of the "trigger" field.
+func (_u *CronJobUpdate) ClearTrigger() *CronJobUpdate {
+	_u.mutation.ClearTrigger()
+	return _u
+}
+
 // SetPrompt sets the "prompt" field.
 func (_u *CronJobUpdate) SetPrompt(v string) *CronJobUpdate {
 	_u.mutation.SetPrompt(v)
@@ -305,6 +325,12 @@ func (_u *CronJobUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.Schedule(); ok {
 		_spec.SetField(cronjob.FieldSchedule, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.Trigger(); ok {
+		_spec.SetField(cronjob.FieldTrigger, field.TypeString, value)
+	}
+	if _u.mutation.TriggerCleared() {
+		_spec.ClearField(cronjob.FieldTrigger, field.TypeString)
+	}
 	if value, ok := _u.mutation.Prompt(); ok {
 		_spec.SetField(cronjob.FieldPrompt, field.TypeString, value)
 	}
@@ -414,6 +440,26 @@ func (_u *CronJobUpdateOne) SetNillableSchedule(v *string) *CronJobUpdateOne {
 	return _u
 }
 
+// SetTrigger sets the "trigger" field.
+func (_u *CronJobUpdateOne) SetTrigger(v string) *CronJobUpdateOne {
+	_u.mutation.SetTrigger(v)
+	return _u
+}
+
+// SetNillableTrigger sets the "trigger" field if the given value is not nil.
+func (_u *CronJobUpdateOne) SetNillableTrigger(v *string) *CronJobUpdateOne {
+	if v != nil {
+		_u.SetTrigger(*v)
+	}
+	return _u
+}
+
+// ClearTrigger clears the value of the "trigger" field.
+func (_u *CronJobUpdateOne) ClearTrigger() *CronJobUpdateOne {
+	_u.mutation.ClearTrigger()
+	return _u
+}
+
 // SetPrompt sets the "prompt" field.
 func (_u *CronJobUpdateOne) SetPrompt(v string) *CronJobUpdateOne {
 	_u.mutation.SetPrompt(v)
@@ -678,6 +724,12 @@ func (_u *CronJobUpdateOne) sqlSave(ctx context.Context) (_node *CronJob, err er
 	if value, ok := _u.mutation.Schedule(); ok {
 		_spec.SetField(cronjob.FieldSchedule, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.Trigger(); ok {
+		_spec.SetField(cronjob.FieldTrigger, field.TypeString, value)
+	}
+	if _u.mutation.TriggerCleared() {
+		_spec.ClearField(cronjob.FieldTrigger, field.TypeString)
+	}
 	if value, ok := _u.mutation.Prompt(); ok {
 		_spec.SetField(cronjob.FieldPrompt, field.TypeString, value)
 	}
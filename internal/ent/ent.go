@@ -14,6 +14,7 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/langoai/lango/internal/ent/actionlog"
 	"github.com/langoai/lango/internal/ent/agentmemory"
+	"github.com/langoai/lango/internal/ent/auditcheckpoint"
 	"github.com/langoai/lango/internal/ent/auditlog"
 	"github.com/langoai/lango/internal/ent/configprofile"
 	"github.com/langoai/lango/internal/ent/cronjob"
@@ -40,6 +41,7 @@ import (
 	"github.com/langoai/lango/internal/ent/runsnapshot"
 	"github.com/langoai/lango/internal/ent/runstep"
 	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
 	"github.com/langoai/lango/internal/ent/session"
 	"github.com/langoai/lango/internal/ent/sessionprovenance"
 	"github.com/langoai/lango/internal/ent/tokenusage"
@@ -109,6 +111,7 @@ func checkColumn(t, c string) error {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
 			actionlog.Table:             actionlog.ValidColumn,
 			agentmemory.Table:           agentmemory.ValidColumn,
+			auditcheckpoint.Table:       auditcheckpoint.ValidColumn,
 			auditlog.Table:              auditlog.ValidColumn,
 			configprofile.Table:         configprofile.ValidColumn,
 			cronjob.Table:               cronjob.ValidColumn,
@@ -135,6 +138,7 @@ func checkColumn(t, c string) error {
 			runsnapshot.Table:           runsnapshot.ValidColumn,
 			runstep.Table:               runstep.ValidColumn,
 			secret.Table:                secret.ValidColumn,
+			secretversion.Table:         secretversion.ValidColumn,
 			session.Table:               session.ValidColumn,
 			sessionprovenance.Table:     sessionprovenance.ValidColumn,
 			tokenusage.Table:            tokenusage.ValidColumn,
@@ -38,9 +38,11 @@ type Key struct {
 type KeyEdges struct {
 	// Secrets encrypted with this key
 	Secrets []*Secret `json:"secrets,omitempty"`
+	// Secret versions encrypted with this key
+	SecretVersions []*SecretVersion `json:"secret_versions,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [1]bool
+	loadedTypes [2]bool
 }
 
 // SecretsOrErr returns the Secrets value or an error if the edge
@@ -52,6 +54,15 @@ func (e KeyEdges) SecretsOrErr() ([]*Secret, error) {
 	return nil, &NotLoadedError{edge: "secrets"}
 }
 
+// SecretVersionsOrErr returns the SecretVersions value or an error if the edge
+// was not loaded in eager-loading.
+func (e KeyEdges) SecretVersionsOrErr() ([]*SecretVersion, error) {
+	if e.loadedTypes[1] {
+		return e.SecretVersions, nil
+	}
+	return nil, &NotLoadedError{edge: "secret_versions"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Key) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -133,6 +144,11 @@ func (_m *Key) QuerySecrets() *SecretQuery {
 	return NewKeyClient(_m.config).QuerySecrets(_m)
 }
 
+// QuerySecretVersions queries the "secret_versions" edge of the Key entity.
+func (_m *Key) QuerySecretVersions() *SecretVersionQuery {
+	return NewKeyClient(_m.config).QuerySecretVersions(_m)
+}
+
 // Update returns a builder for updating this Key.
 // Note that you need to call Key.Unwrap() before calling this method if this Key
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -28,6 +28,8 @@ const (
 	FieldLastUsedAt = "last_used_at"
 	// EdgeSecrets holds the string denoting the secrets edge name in mutations.
 	EdgeSecrets = "secrets"
+	// EdgeSecretVersions holds the string denoting the secret_versions edge name in mutations.
+	EdgeSecretVersions = "secret_versions"
 	// Table holds the table name of the key in the database.
 	Table = "keys"
 	// SecretsTable is the table that holds the secrets relation/edge.
@@ -37,6 +39,13 @@ const (
 	SecretsInverseTable = "secrets"
 	// SecretsColumn is the table column denoting the secrets relation/edge.
 	SecretsColumn = "key_secrets"
+	// SecretVersionsTable is the table that holds the secret_versions relation/edge.
+	SecretVersionsTable = "secret_versions"
+	// SecretVersionsInverseTable is the table name for the SecretVersion entity.
+	// It exists in this package in order to avoid circular dependency with the "secretversion" package.
+	SecretVersionsInverseTable = "secret_versions"
+	// SecretVersionsColumn is the table column denoting the secret_versions relation/edge.
+	SecretVersionsColumn = "key_secret_versions"
 )
 
 // Columns holds all SQL columns for key fields.
@@ -142,6 +151,20 @@ func BySecrets(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newSecretsStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// BySecretVersionsCount orders the results by secret_versions count.
+func BySecretVersionsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newSecretVersionsStep(), opts...)
+	}
+}
+
+// BySecretVersions orders the results by secret_versions terms.
+func BySecretVersions(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newSecretVersionsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newSecretsStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -149,3 +172,10 @@ func newSecretsStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, SecretsTable, SecretsColumn),
 	)
 }
+func newSecretVersionsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(SecretVersionsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, SecretVersionsTable, SecretVersionsColumn),
+	)
+}
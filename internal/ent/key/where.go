@@ -339,6 +339,29 @@ func HasSecretsWith(preds ...predicate.Secret) predicate.Key {
 	})
 }
 
+// HasSecretVersions applies the HasEdge predicate on the "secret_versions" edge.
+func HasSecretVersions() predicate.Key {
+	return predicate.Key(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, SecretVersionsTable, SecretVersionsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasSecretVersionsWith applies the HasEdge predicate on the "secret_versions" edge with a given conditions (other predicates).
+func HasSecretVersionsWith(preds ...predicate.SecretVersion) predicate.Key {
+	return predicate.Key(func(s *sql.Selector) {
+		step := newSecretVersionsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Key) predicate.Key {
 	return predicate.Key(sql.AndPredicates(predicates...))
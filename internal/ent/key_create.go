@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/langoai/lango/internal/ent/key"
 	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
 )
 
 // KeyCreate is the builder for creating a Key entity.
@@ -105,6 +106,21 @@ func (_c *KeyCreate) AddSecrets(v ...*Secret) *KeyCreate {
 	return _c.AddSecretIDs(ids...)
 }
 
+// AddSecretVersionIDs adds the "secret_versions" edge to the SecretVersion entity by IDs.
+func (_c *KeyCreate) AddSecretVersionIDs(ids ...uuid.UUID) *KeyCreate {
+	_c.mutation.AddSecretVersionIDs(ids...)
+	return _c
+}
+
+// AddSecretVersions adds the "secret_versions" edges to the SecretVersion entity.
+func (_c *KeyCreate) AddSecretVersions(v ...*SecretVersion) *KeyCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddSecretVersionIDs(ids...)
+}
+
 // Mutation returns the KeyMutation object of the builder.
 func (_c *KeyCreate) Mutation() *KeyMutation {
 	return _c.mutation
@@ -254,6 +270,22 @@ func (_c *KeyCreate) createSpec() (*Key, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.SecretVersionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   key.SecretVersionsTable,
+			Columns: []string{key.SecretVersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
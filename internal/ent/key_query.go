@@ -16,16 +16,18 @@ import (
 	"github.com/langoai/lango/internal/ent/key"
 	"github.com/langoai/lango/internal/ent/predicate"
 	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
 )
 
 // KeyQuery is the builder for querying Key entities.
 type KeyQuery struct {
 	config
-	ctx         *QueryContext
-	order       []key.OrderOption
-	inters      []Interceptor
-	predicates  []predicate.Key
-	withSecrets *SecretQuery
+	ctx                *QueryContext
+	order              []key.OrderOption
+	inters             []Interceptor
+	predicates         []predicate.Key
+	withSecrets        *SecretQuery
+	withSecretVersions *SecretVersionQuery
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -84,6 +86,28 @@ func (_q *KeyQuery) QuerySecrets() *SecretQuery {
 	return query
 }
 
+// QuerySecretVersions chains the current query on the "secret_versions" edge.
+func (_q *KeyQuery) QuerySecretVersions() *SecretVersionQuery {
+	query := (&SecretVersionClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(key.Table, key.FieldID, selector),
+			sqlgraph.To(secretversion.Table, secretversion.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, key.SecretVersionsTable, key.SecretVersionsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Key entity from the query.
 // Returns a *NotFoundError when no Key was found.
 func (_q *KeyQuery) First(ctx context.Context) (*Key, error) {
@@ -271,12 +295,13 @@ func (_q *KeyQuery) Clone() *KeyQuery {
 		return nil
 	}
 	return &KeyQuery{
-		config:      _q.config,
-		ctx:         _q.ctx.Clone(),
-		order:       append([]key.OrderOption{}, _q.order...),
-		inters:      append([]Interceptor{}, _q.inters...),
-		predicates:  append([]predicate.Key{}, _q.predicates...),
-		withSecrets: _q.withSecrets.Clone(),
+		config:             _q.config,
+		ctx:                _q.ctx.Clone(),
+		order:              append([]key.OrderOption{}, _q.order...),
+		inters:             append([]Interceptor{}, _q.inters...),
+		predicates:         append([]predicate.Key{}, _q.predicates...),
+		withSecrets:        _q.withSecrets.Clone(),
+		withSecretVersions: _q.withSecretVersions.Clone(),
 		// clone intermediate query.
 		sql:  _q.sql.Clone(),
 		path: _q.path,
@@ -294,6 +319,17 @@ func (_q *KeyQuery) WithSecrets(opts ...func(*SecretQuery)) *KeyQuery {
 	return _q
 }
 
+// WithSecretVersions tells the query-builder to eager-load the nodes that are connected to
+// the "secret_versions" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *KeyQuery) WithSecretVersions(opts ...func(*SecretVersionQuery)) *KeyQuery {
+	query := (&SecretVersionClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withSecretVersions = query
+	return _q
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -372,8 +408,9 @@ func (_q *KeyQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Key, err
 	var (
 		nodes       = []*Key{}
 		_spec       = _q.querySpec()
-		loadedTypes = [1]bool{
+		loadedTypes = [2]bool{
 			_q.withSecrets != nil,
+			_q.withSecretVersions != nil,
 		}
 	)
 	_spec.ScanValues = func(columns []string) ([]any, error) {
@@ -401,6 +438,13 @@ func (_q *KeyQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Key, err
 			return nil, err
 		}
 	}
+	if query := _q.withSecretVersions; query != nil {
+		if err := _q.loadSecretVersions(ctx, query, nodes,
+			func(n *Key) { n.Edges.SecretVersions = []*SecretVersion{} },
+			func(n *Key, e *SecretVersion) { n.Edges.SecretVersions = append(n.Edges.SecretVersions, e) }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -435,6 +479,37 @@ func (_q *KeyQuery) loadSecrets(ctx context.Context, query *SecretQuery, nodes [
 	}
 	return nil
 }
+func (_q *KeyQuery) loadSecretVersions(ctx context.Context, query *SecretVersionQuery, nodes []*Key, init func(*Key), assign func(*Key, *SecretVersion)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[uuid.UUID]*Key)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.SecretVersion(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(key.SecretVersionsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.key_secret_versions
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "key_secret_versions" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "key_secret_versions" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
 
 func (_q *KeyQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := _q.querySpec()
@@ -15,6 +15,7 @@ import (
 	"github.com/langoai/lango/internal/ent/key"
 	"github.com/langoai/lango/internal/ent/predicate"
 	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
 )
 
 // KeyUpdate is the builder for updating Key entities.
@@ -107,6 +108,21 @@ func (_u *KeyUpdate) AddSecrets(v ...*Secret) *KeyUpdate {
 	return _u.AddSecretIDs(ids...)
 }
 
+// AddSecretVersionIDs adds the "secret_versions" edge to the SecretVersion entity by IDs.
+func (_u *KeyUpdate) AddSecretVersionIDs(ids ...uuid.UUID) *KeyUpdate {
+	_u.mutation.AddSecretVersionIDs(ids...)
+	return _u
+}
+
+// AddSecretVersions adds the "secret_versions" edges to the SecretVersion entity.
+func (_u *KeyUpdate) AddSecretVersions(v ...*SecretVersion) *KeyUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddSecretVersionIDs(ids...)
+}
+
 // Mutation returns the KeyMutation object of the builder.
 func (_u *KeyUpdate) Mutation() *KeyMutation {
 	return _u.mutation
@@ -133,6 +149,27 @@ func (_u *KeyUpdate) RemoveSecrets(v ...*Secret) *KeyUpdate {
 	return _u.RemoveSecretIDs(ids...)
 }
 
+// ClearSecretVersions clears all "secret_versions" edges to the SecretVersion entity.
+func (_u *KeyUpdate) ClearSecretVersions() *KeyUpdate {
+	_u.mutation.ClearSecretVersions()
+	return _u
+}
+
+// RemoveSecretVersionIDs removes the "secret_versions" edge to SecretVersion entities by IDs.
+func (_u *KeyUpdate) RemoveSecretVersionIDs(ids ...uuid.UUID) *KeyUpdate {
+	_u.mutation.RemoveSecretVersionIDs(ids...)
+	return _u
+}
+
+// RemoveSecretVersions removes "secret_versions" edges to SecretVersion entities.
+func (_u *KeyUpdate) RemoveSecretVersions(v ...*SecretVersion) *KeyUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveSecretVersionIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *KeyUpdate) Save(ctx context.Context) (int, error) {
 	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
@@ -252,6 +289,51 @@ func (_u *KeyUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.SecretVersionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   key.SecretVersionsTable,
+			Columns: []string{key.SecretVersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSecretVersionsIDs(); len(nodes) > 0 && !_u.mutation.SecretVersionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   key.SecretVersionsTable,
+			Columns: []string{key.SecretVersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SecretVersionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   key.SecretVersionsTable,
+			Columns: []string{key.SecretVersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{key.Label}
@@ -349,6 +431,21 @@ func (_u *KeyUpdateOne) AddSecrets(v ...*Secret) *KeyUpdateOne {
 	return _u.AddSecretIDs(ids...)
 }
 
+// AddSecretVersionIDs adds the "secret_versions" edge to the SecretVersion entity by IDs.
+func (_u *KeyUpdateOne) AddSecretVersionIDs(ids ...uuid.UUID) *KeyUpdateOne {
+	_u.mutation.AddSecretVersionIDs(ids...)
+	return _u
+}
+
+// AddSecretVersions adds the "secret_versions" edges to the SecretVersion entity.
+func (_u *KeyUpdateOne) AddSecretVersions(v ...*SecretVersion) *KeyUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddSecretVersionIDs(ids...)
+}
+
 // Mutation returns the KeyMutation object of the builder.
 func (_u *KeyUpdateOne) Mutation() *KeyMutation {
 	return _u.mutation
@@ -375,6 +472,27 @@ func (_u *KeyUpdateOne) RemoveSecrets(v ...*Secret) *KeyUpdateOne {
 	return _u.RemoveSecretIDs(ids...)
 }
 
+// ClearSecretVersions clears all "secret_versions" edges to the SecretVersion entity.
+func (_u *KeyUpdateOne) ClearSecretVersions() *KeyUpdateOne {
+	_u.mutation.ClearSecretVersions()
+	return _u
+}
+
+// RemoveSecretVersionIDs removes the "secret_versions" edge to SecretVersion entities by IDs.
+func (_u *KeyUpdateOne) RemoveSecretVersionIDs(ids ...uuid.UUID) *KeyUpdateOne {
+	_u.mutation.RemoveSecretVersionIDs(ids...)
+	return _u
+}
+
+// RemoveSecretVersions removes "secret_versions" edges to SecretVersion entities.
+func (_u *KeyUpdateOne) RemoveSecretVersions(v ...*SecretVersion) *KeyUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveSecretVersionIDs(ids...)
+}
+
 // Where appends a list predicates to the KeyUpdate builder.
 func (_u *KeyUpdateOne) Where(ps ...predicate.Key) *KeyUpdateOne {
 	_u.mutation.Where(ps...)
@@ -524,6 +642,51 @@ func (_u *KeyUpdateOne) sqlSave(ctx context.Context) (_node *Key, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.SecretVersionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   key.SecretVersionsTable,
+			Columns: []string{key.SecretVersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSecretVersionsIDs(); len(nodes) > 0 && !_u.mutation.SecretVersionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   key.SecretVersionsTable,
+			Columns: []string{key.SecretVersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SecretVersionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   key.SecretVersionsTable,
+			Columns: []string{key.SecretVersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Key{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
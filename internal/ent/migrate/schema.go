@@ -95,15 +95,46 @@ var (
 			},
 		},
 	}
+	// AuditCheckpointsColumns holds the columns for the "audit_checkpoints" table.
+	AuditCheckpointsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "seq", Type: field.TypeInt64},
+		{Name: "hash", Type: field.TypeString},
+		{Name: "signer", Type: field.TypeString},
+		{Name: "algorithm", Type: field.TypeString},
+		{Name: "signature", Type: field.TypeBytes},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// AuditCheckpointsTable holds the schema information for the "audit_checkpoints" table.
+	AuditCheckpointsTable = &schema.Table{
+		Name:       "audit_checkpoints",
+		Columns:    AuditCheckpointsColumns,
+		PrimaryKey: []*schema.Column{AuditCheckpointsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "auditcheckpoint_seq",
+				Unique:  false,
+				Columns: []*schema.Column{AuditCheckpointsColumns[1]},
+			},
+			{
+				Name:    "auditcheckpoint_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuditCheckpointsColumns[6]},
+			},
+		},
+	}
 	// AuditLogsColumns holds the columns for the "audit_logs" table.
 	AuditLogsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
 		{Name: "session_key", Type: field.TypeString, Nullable: true},
-		{Name: "action", Type: field.TypeEnum, Enums: []string{"tool_call", "knowledge_save", "learning_save", "skill_create", "skill_execute", "skill_import", "skill_import_bulk", "knowledge_search", "approval_request", "approval_response", "policy_decision", "alert", "sandbox_decision"}},
+		{Name: "action", Type: field.TypeEnum, Enums: []string{"tool_call", "knowledge_save", "learning_save", "skill_create", "skill_execute", "skill_import", "skill_import_bulk", "knowledge_search", "approval_request", "approval_response", "policy_decision", "alert", "sandbox_decision", "secret_access", "workspace_message_rejected"}},
 		{Name: "actor", Type: field.TypeString},
 		{Name: "target", Type: field.TypeString, Nullable: true},
 		{Name: "details", Type: field.TypeJSON, Nullable: true},
 		{Name: "timestamp", Type: field.TypeTime},
+		{Name: "seq", Type: field.TypeInt64, Nullable: true},
+		{Name: "prev_hash", Type: field.TypeString, Nullable: true},
+		{Name: "hash", Type: field.TypeString, Nullable: true},
 	}
 	// AuditLogsTable holds the schema information for the "audit_logs" table.
 	AuditLogsTable = &schema.Table{
@@ -126,6 +157,11 @@ var (
 				Unique:  false,
 				Columns: []*schema.Column{AuditLogsColumns[6]},
 			},
+			{
+				Name:    "auditlog_seq",
+				Unique:  true,
+				Columns: []*schema.Column{AuditLogsColumns[7]},
+			},
 		},
 	}
 	// ConfigProfilesColumns holds the columns for the "config_profiles" table.
@@ -160,8 +196,9 @@ var (
 	CronJobsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
 		{Name: "name", Type: field.TypeString, Unique: true},
-		{Name: "schedule_type", Type: field.TypeEnum, Enums: []string{"at", "every", "cron"}},
+		{Name: "schedule_type", Type: field.TypeEnum, Enums: []string{"at", "every", "cron", "trigger"}},
 		{Name: "schedule", Type: field.TypeString},
+		{Name: "trigger", Type: field.TypeString, Nullable: true, Size: 2147483647},
 		{Name: "prompt", Type: field.TypeString, Size: 2147483647},
 		{Name: "session_mode", Type: field.TypeString, Default: "isolated"},
 		{Name: "deliver_to", Type: field.TypeJSON, Nullable: true},
@@ -182,12 +219,12 @@ var (
 			{
 				Name:    "cronjob_enabled",
 				Unique:  false,
-				Columns: []*schema.Column{CronJobsColumns[8]},
+				Columns: []*schema.Column{CronJobsColumns[9]},
 			},
 			{
 				Name:    "cronjob_next_run_at",
 				Unique:  false,
-				Columns: []*schema.Column{CronJobsColumns[11]},
+				Columns: []*schema.Column{CronJobsColumns[12]},
 			},
 		},
 	}
@@ -771,7 +808,7 @@ var (
 		{Name: "session_key", Type: field.TypeString, Nullable: true},
 		{Name: "run_id", Type: field.TypeString, Nullable: true},
 		{Name: "label", Type: field.TypeString},
-		{Name: "trigger", Type: field.TypeEnum, Enums: []string{"manual", "step_complete", "policy_applied"}},
+		{Name: "trigger", Type: field.TypeEnum, Enums: []string{"manual", "step_complete", "policy_applied", "rewind"}},
 		{Name: "journal_seq", Type: field.TypeInt64, Default: 0},
 		{Name: "git_ref", Type: field.TypeString, Nullable: true},
 		{Name: "metadata", Type: field.TypeString, Nullable: true, Size: 2147483647},
@@ -957,6 +994,11 @@ var (
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 		{Name: "access_count", Type: field.TypeInt, Default: 0},
+		{Name: "version", Type: field.TypeInt, Default: 1},
+		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "allowed_agents", Type: field.TypeJSON, Nullable: true},
+		{Name: "allowed_tools", Type: field.TypeJSON, Nullable: true},
+		{Name: "allowed_mcp_servers", Type: field.TypeJSON, Nullable: true},
 		{Name: "key_secrets", Type: field.TypeUUID},
 	}
 	// SecretsTable holds the schema information for the "secrets" table.
@@ -967,7 +1009,7 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "secrets_keys_secrets",
-				Columns:    []*schema.Column{SecretsColumns[6]},
+				Columns:    []*schema.Column{SecretsColumns[11]},
 				RefColumns: []*schema.Column{KeysColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
@@ -983,6 +1025,47 @@ var (
 				Unique:  false,
 				Columns: []*schema.Column{SecretsColumns[3]},
 			},
+			{
+				Name:    "secret_expires_at",
+				Unique:  false,
+				Columns: []*schema.Column{SecretsColumns[7]},
+			},
+		},
+	}
+	// SecretVersionsColumns holds the columns for the "secret_versions" table.
+	SecretVersionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "version", Type: field.TypeInt},
+		{Name: "encrypted_value", Type: field.TypeBytes},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "key_secret_versions", Type: field.TypeUUID},
+		{Name: "secret_versions", Type: field.TypeUUID},
+	}
+	// SecretVersionsTable holds the schema information for the "secret_versions" table.
+	SecretVersionsTable = &schema.Table{
+		Name:       "secret_versions",
+		Columns:    SecretVersionsColumns,
+		PrimaryKey: []*schema.Column{SecretVersionsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "secret_versions_keys_secret_versions",
+				Columns:    []*schema.Column{SecretVersionsColumns[4]},
+				RefColumns: []*schema.Column{KeysColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+			{
+				Symbol:     "secret_versions_secrets_versions",
+				Columns:    []*schema.Column{SecretVersionsColumns[5]},
+				RefColumns: []*schema.Column{SecretsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "secretversion_version_secret_versions",
+				Unique:  true,
+				Columns: []*schema.Column{SecretVersionsColumns[1], SecretVersionsColumns[5]},
+			},
 		},
 	}
 	// SessionsColumns holds the columns for the "sessions" table.
@@ -1256,6 +1339,7 @@ var (
 	Tables = []*schema.Table{
 		ActionLogsTable,
 		AgentMemoriesTable,
+		AuditCheckpointsTable,
 		AuditLogsTable,
 		ConfigProfilesTable,
 		CronJobsTable,
@@ -1282,6 +1366,7 @@ var (
 		RunSnapshotsTable,
 		RunStepsTable,
 		SecretsTable,
+		SecretVersionsTable,
 		SessionsTable,
 		SessionProvenancesTable,
 		TokenUsagesTable,
@@ -1295,4 +1380,6 @@ var (
 func init() {
 	MessagesTable.ForeignKeys[0].RefTable = SessionsTable
 	SecretsTable.ForeignKeys[0].RefTable = KeysTable
+	SecretVersionsTable.ForeignKeys[0].RefTable = KeysTable
+	SecretVersionsTable.ForeignKeys[1].RefTable = SecretsTable
 }
@@ -12,6 +12,9 @@ type ActionLog func(*sql.Selector)
 // AgentMemory is the predicate function for agentmemory builders.
 type AgentMemory func(*sql.Selector)
 
+// AuditCheckpoint is the predicate function for auditcheckpoint builders.
+type AuditCheckpoint func(*sql.Selector)
+
 // AuditLog is the predicate function for auditlog builders.
 type AuditLog func(*sql.Selector)
 
@@ -90,6 +93,9 @@ type RunStep func(*sql.Selector)
 // Secret is the predicate function for secret builders.
 type Secret func(*sql.Selector)
 
+// SecretVersion is the predicate function for secretversion builders.
+type SecretVersion func(*sql.Selector)
+
 // Session is the predicate function for session builders.
 type Session func(*sql.Selector)
 
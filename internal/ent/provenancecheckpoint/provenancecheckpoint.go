@@ -77,6 +77,7 @@ const (
 	TriggerManual        Trigger = "manual"
 	TriggerStepComplete  Trigger = "step_complete"
 	TriggerPolicyApplied Trigger = "policy_applied"
+	TriggerRewind        Trigger = "rewind"
 )
 
 func (t Trigger) String() string {
@@ -86,7 +87,7 @@ func (t Trigger) String() string {
 // TriggerValidator is a validator for the "trigger" field enum values. It is called by the builders before save.
 func TriggerValidator(t Trigger) error {
 	switch t {
-	case TriggerManual, TriggerStepComplete, TriggerPolicyApplied:
+	case TriggerManual, TriggerStepComplete, TriggerPolicyApplied, TriggerRewind:
 		return nil
 	default:
 		return fmt.Errorf("provenancecheckpoint: invalid enum value for trigger field: %q", t)
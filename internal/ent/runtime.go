@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/langoai/lango/internal/ent/actionlog"
 	"github.com/langoai/lango/internal/ent/agentmemory"
+	"github.com/langoai/lango/internal/ent/auditcheckpoint"
 	"github.com/langoai/lango/internal/ent/auditlog"
 	"github.com/langoai/lango/internal/ent/configprofile"
 	"github.com/langoai/lango/internal/ent/cronjob"
@@ -35,6 +36,7 @@ import (
 	"github.com/langoai/lango/internal/ent/runstep"
 	"github.com/langoai/lango/internal/ent/schema"
 	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
 	"github.com/langoai/lango/internal/ent/session"
 	"github.com/langoai/lango/internal/ent/sessionprovenance"
 	"github.com/langoai/lango/internal/ent/tokenusage"
@@ -102,6 +104,28 @@ func init() {
 	agentmemoryDescID := agentmemoryFields[0].Descriptor()
 	// agentmemory.DefaultID holds the default value on creation for the id field.
 	agentmemory.DefaultID = agentmemoryDescID.Default.(func() uuid.UUID)
+	auditcheckpointFields := schema.AuditCheckpoint{}.Fields()
+	_ = auditcheckpointFields
+	// auditcheckpointDescHash is the schema descriptor for hash field.
+	auditcheckpointDescHash := auditcheckpointFields[2].Descriptor()
+	// auditcheckpoint.HashValidator is a validator for the "hash" field. It is called by the builders before save.
+	auditcheckpoint.HashValidator = auditcheckpointDescHash.Validators[0].(func(string) error)
+	// auditcheckpointDescSigner is the schema descriptor for signer field.
+	auditcheckpointDescSigner := auditcheckpointFields[3].Descriptor()
+	// auditcheckpoint.SignerValidator is a validator for the "signer" field. It is called by the builders before save.
+	auditcheckpoint.SignerValidator = auditcheckpointDescSigner.Validators[0].(func(string) error)
+	// auditcheckpointDescAlgorithm is the schema descriptor for algorithm field.
+	auditcheckpointDescAlgorithm := auditcheckpointFields[4].Descriptor()
+	// auditcheckpoint.AlgorithmValidator is a validator for the "algorithm" field. It is called by the builders before save.
+	auditcheckpoint.AlgorithmValidator = auditcheckpointDescAlgorithm.Validators[0].(func(string) error)
+	// auditcheckpointDescCreatedAt is the schema descriptor for created_at field.
+	auditcheckpointDescCreatedAt := auditcheckpointFields[6].Descriptor()
+	// auditcheckpoint.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditcheckpoint.DefaultCreatedAt = auditcheckpointDescCreatedAt.Default.(func() time.Time)
+	// auditcheckpointDescID is the schema descriptor for id field.
+	auditcheckpointDescID := auditcheckpointFields[0].Descriptor()
+	// auditcheckpoint.DefaultID holds the default value on creation for the id field.
+	auditcheckpoint.DefaultID = auditcheckpointDescID.Default.(func() uuid.UUID)
 	auditlogFields := schema.AuditLog{}.Fields()
 	_ = auditlogFields
 	// auditlogDescActor is the schema descriptor for actor field.
@@ -155,27 +179,27 @@ func init() {
 	// cronjob.ScheduleValidator is a validator for the "schedule" field. It is called by the builders before save.
 	cronjob.ScheduleValidator = cronjobDescSchedule.Validators[0].(func(string) error)
 	// cronjobDescPrompt is the schema descriptor for prompt field.
-	cronjobDescPrompt := cronjobFields[4].Descriptor()
+	cronjobDescPrompt := cronjobFields[5].Descriptor()
 	// cronjob.PromptValidator is a validator for the "prompt" field. It is called by the builders before save.
 	cronjob.PromptValidator = cronjobDescPrompt.Validators[0].(func(string) error)
 	// cronjobDescSessionMode is the schema descriptor for session_mode field.
-	cronjobDescSessionMode := cronjobFields[5].Descriptor()
+	cronjobDescSessionMode := cronjobFields[6].Descriptor()
 	// cronjob.DefaultSessionMode holds the default value on creation for the session_mode field.
 	cronjob.DefaultSessionMode = cronjobDescSessionMode.Default.(string)
 	// cronjobDescTimezone is the schema descriptor for timezone field.
-	cronjobDescTimezone := cronjobFields[7].Descriptor()
+	cronjobDescTimezone := cronjobFields[8].Descriptor()
 	// cronjob.DefaultTimezone holds the default value on creation for the timezone field.
 	cronjob.DefaultTimezone = cronjobDescTimezone.Default.(string)
 	// cronjobDescEnabled is the schema descriptor for enabled field.
-	cronjobDescEnabled := cronjobFields[8].Descriptor()
+	cronjobDescEnabled := cronjobFields[9].Descriptor()
 	// cronjob.DefaultEnabled holds the default value on creation for the enabled field.
 	cronjob.DefaultEnabled = cronjobDescEnabled.Default.(bool)
 	// cronjobDescCreatedAt is the schema descriptor for created_at field.
-	cronjobDescCreatedAt := cronjobFields[12].Descriptor()
+	cronjobDescCreatedAt := cronjobFields[13].Descriptor()
 	// cronjob.DefaultCreatedAt holds the default value on creation for the created_at field.
 	cronjob.DefaultCreatedAt = cronjobDescCreatedAt.Default.(func() time.Time)
 	// cronjobDescUpdatedAt is the schema descriptor for updated_at field.
-	cronjobDescUpdatedAt := cronjobFields[13].Descriptor()
+	cronjobDescUpdatedAt := cronjobFields[14].Descriptor()
 	// cronjob.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	cronjob.DefaultUpdatedAt = cronjobDescUpdatedAt.Default.(func() time.Time)
 	// cronjob.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -794,10 +818,28 @@ func init() {
 	secretDescAccessCount := secretFields[5].Descriptor()
 	// secret.DefaultAccessCount holds the default value on creation for the access_count field.
 	secret.DefaultAccessCount = secretDescAccessCount.Default.(int)
+	// secretDescVersion is the schema descriptor for version field.
+	secretDescVersion := secretFields[6].Descriptor()
+	// secret.DefaultVersion holds the default value on creation for the version field.
+	secret.DefaultVersion = secretDescVersion.Default.(int)
 	// secretDescID is the schema descriptor for id field.
 	secretDescID := secretFields[0].Descriptor()
 	// secret.DefaultID holds the default value on creation for the id field.
 	secret.DefaultID = secretDescID.Default.(func() uuid.UUID)
+	secretversionFields := schema.SecretVersion{}.Fields()
+	_ = secretversionFields
+	// secretversionDescVersion is the schema descriptor for version field.
+	secretversionDescVersion := secretversionFields[1].Descriptor()
+	// secretversion.VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	secretversion.VersionValidator = secretversionDescVersion.Validators[0].(func(int) error)
+	// secretversionDescCreatedAt is the schema descriptor for created_at field.
+	secretversionDescCreatedAt := secretversionFields[3].Descriptor()
+	// secretversion.DefaultCreatedAt holds the default value on creation for the created_at field.
+	secretversion.DefaultCreatedAt = secretversionDescCreatedAt.Default.(func() time.Time)
+	// secretversionDescID is the schema descriptor for id field.
+	secretversionDescID := secretversionFields[0].Descriptor()
+	// secretversion.DefaultID holds the default value on creation for the id field.
+	secretversion.DefaultID = secretversionDescID.Default.(func() uuid.UUID)
 	sessionFields := schema.Session{}.Fields()
 	_ = sessionFields
 	// sessionDescKey is the schema descriptor for key field.
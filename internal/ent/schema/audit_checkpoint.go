@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// AuditCheckpoint holds the schema definition for the AuditCheckpoint entity.
+// AuditCheckpoint stores a signed attestation of the audit hash chain head.
+type AuditCheckpoint struct {
+	ent.Schema
+}
+
+// Fields of the AuditCheckpoint.
+func (AuditCheckpoint) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.Int64("seq").
+			Immutable().
+			Comment("Audit chain position this checkpoint attests"),
+		field.String("hash").
+			NotEmpty().
+			Immutable().
+			Comment("Chain hash of the audit record at seq"),
+		field.String("signer").
+			NotEmpty().
+			Immutable().
+			Comment("DID of the signing identity"),
+		field.String("algorithm").
+			NotEmpty().
+			Immutable().
+			Comment("Signature algorithm"),
+		field.Bytes("signature").
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the AuditCheckpoint.
+func (AuditCheckpoint) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the AuditCheckpoint.
+func (AuditCheckpoint) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("seq"),
+		index.Fields("created_at"),
+	}
+}
@@ -38,6 +38,8 @@ func (AuditLog) Fields() []ent.Field {
 				"policy_decision",
 				"alert",
 				"sandbox_decision",
+				"secret_access",
+				"workspace_message_rejected",
 			),
 		field.String("actor").
 			NotEmpty(),
@@ -48,6 +50,19 @@ func (AuditLog) Fields() []ent.Field {
 		field.Time("timestamp").
 			Default(time.Now).
 			Immutable(),
+		field.Int64("seq").
+			Optional().
+			Nillable().
+			Immutable().
+			Comment("Position in the audit hash chain; nil for rows written before chaining"),
+		field.String("prev_hash").
+			Optional().
+			Immutable().
+			Comment("Hash of the previous record in the chain (empty for the first)"),
+		field.String("hash").
+			Optional().
+			Immutable().
+			Comment("SHA-256 of prev_hash and the record's canonical JSON"),
 	}
 }
 
@@ -62,5 +77,6 @@ func (AuditLog) Indexes() []ent.Index {
 		index.Fields("session_key"),
 		index.Fields("action"),
 		index.Fields("timestamp"),
+		index.Fields("seq").Unique(),
 	}
 }
@@ -25,11 +25,14 @@ func (CronJob) Fields() []ent.Field {
 			Unique().
 			Comment("Human-readable job name"),
 		field.Enum("schedule_type").
-			Values("at", "every", "cron").
-			Comment("Schedule type: one-time, interval, or cron expression"),
+			Values("at", "every", "cron", "trigger").
+			Comment("Schedule type: one-time, interval, cron expression, or trigger-only"),
 		field.String("schedule").
 			NotEmpty().
-			Comment("Schedule value: ISO8601 datetime, duration, or cron expression"),
+			Comment("Schedule value: ISO8601 datetime, duration, cron expression, or trigger summary"),
+		field.Text("trigger").
+			Optional().
+			Comment("Event trigger spec as JSON (file watch, webhook, or event bus)"),
 		field.Text("prompt").
 			NotEmpty().
 			Comment("Prompt to execute when the job fires"),
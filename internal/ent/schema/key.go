@@ -48,6 +48,8 @@ func (Key) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("secrets", Secret.Type).
 			Comment("Secrets encrypted with this key"),
+		edge.To("secret_versions", SecretVersion.Type).
+			Comment("Secret versions encrypted with this key"),
 	}
 }
 
@@ -30,7 +30,7 @@ func (ProvenanceCheckpoint) Fields() []ent.Field {
 			NotEmpty().
 			Comment("Human-readable checkpoint label"),
 		field.Enum("trigger").
-			Values("manual", "step_complete", "policy_applied").
+			Values("manual", "step_complete", "policy_applied", "rewind").
 			Comment("What caused this checkpoint"),
 		field.Int64("journal_seq").
 			Default(0).
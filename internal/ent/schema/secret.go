@@ -37,6 +37,22 @@ func (Secret) Fields() []ent.Field {
 		field.Int("access_count").
 			Default(0).
 			Comment("Number of times this secret has been accessed"),
+		field.Int("version").
+			Default(1).
+			Comment("Current version number, incremented on every rotation"),
+		field.Time("expires_at").
+			Optional().
+			Nillable().
+			Comment("Time after which the secret can no longer be resolved"),
+		field.JSON("allowed_agents", []string{}).
+			Optional().
+			Comment("Agents allowed to resolve this secret (empty = any)"),
+		field.JSON("allowed_tools", []string{}).
+			Optional().
+			Comment("Tools allowed to resolve this secret (empty = any)"),
+		field.JSON("allowed_mcp_servers", []string{}).
+			Optional().
+			Comment("MCP servers allowed to resolve this secret (empty = any)"),
 	}
 }
 
@@ -48,6 +64,8 @@ func (Secret) Edges() []ent.Edge {
 			Unique().
 			Required().
 			Comment("Encryption key used for this secret"),
+		edge.To("versions", SecretVersion.Type).
+			Comment("Historical versions of this secret"),
 	}
 }
 
@@ -56,5 +74,6 @@ func (Secret) Indexes() []ent.Index {
 	return []ent.Index{
 		index.Fields("name"),
 		index.Fields("created_at"),
+		index.Fields("expires_at"),
 	}
 }
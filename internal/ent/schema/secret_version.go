@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// SecretVersion holds the schema definition for the SecretVersion entity.
+// Every value ever stored under a secret name is kept as an immutable
+// version so that rotations can be audited and rolled back.
+type SecretVersion struct {
+	ent.Schema
+}
+
+// Fields of the SecretVersion.
+func (SecretVersion) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			Immutable(),
+		field.Int("version").
+			Positive().
+			Immutable().
+			Comment("Monotonic version number within the owning secret"),
+		field.Bytes("encrypted_value").
+			Immutable().
+			Comment("Encrypted secret data for this version"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the SecretVersion.
+func (SecretVersion) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("secret", Secret.Type).
+			Ref("versions").
+			Unique().
+			Required().
+			Comment("Secret this version belongs to"),
+		edge.From("key", Key.Type).
+			Ref("secret_versions").
+			Unique().
+			Required().
+			Comment("Encryption key used for this version"),
+	}
+}
+
+// Indexes of the SecretVersion.
+func (SecretVersion) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("version").
+			Edges("secret").
+			Unique(),
+	}
+}
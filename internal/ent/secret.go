@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -29,6 +30,16 @@ type Secret struct {
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
 	// Number of times this secret has been accessed
 	AccessCount int `json:"access_count,omitempty"`
+	// Current version number, incremented on every rotation
+	Version int `json:"version,omitempty"`
+	// Time after which the secret can no longer be resolved
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Agents allowed to resolve this secret (empty = any)
+	AllowedAgents []string `json:"allowed_agents,omitempty"`
+	// Tools allowed to resolve this secret (empty = any)
+	AllowedTools []string `json:"allowed_tools,omitempty"`
+	// MCP servers allowed to resolve this secret (empty = any)
+	AllowedMcpServers []string `json:"allowed_mcp_servers,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the SecretQuery when eager-loading is set.
 	Edges        SecretEdges `json:"edges"`
@@ -40,9 +51,11 @@ type Secret struct {
 type SecretEdges struct {
 	// Encryption key used for this secret
 	Key *Key `json:"key,omitempty"`
+	// Historical versions of this secret
+	Versions []*SecretVersion `json:"versions,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [1]bool
+	loadedTypes [2]bool
 }
 
 // KeyOrErr returns the Key value or an error if the edge
@@ -56,18 +69,27 @@ func (e SecretEdges) KeyOrErr() (*Key, error) {
 	return nil, &NotLoadedError{edge: "key"}
 }
 
+// VersionsOrErr returns the Versions value or an error if the edge
+// was not loaded in eager-loading.
+func (e SecretEdges) VersionsOrErr() ([]*SecretVersion, error) {
+	if e.loadedTypes[1] {
+		return e.Versions, nil
+	}
+	return nil, &NotLoadedError{edge: "versions"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Secret) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case secret.FieldEncryptedValue:
+		case secret.FieldEncryptedValue, secret.FieldAllowedAgents, secret.FieldAllowedTools, secret.FieldAllowedMcpServers:
 			values[i] = new([]byte)
-		case secret.FieldAccessCount:
+		case secret.FieldAccessCount, secret.FieldVersion:
 			values[i] = new(sql.NullInt64)
 		case secret.FieldName:
 			values[i] = new(sql.NullString)
-		case secret.FieldCreatedAt, secret.FieldUpdatedAt:
+		case secret.FieldCreatedAt, secret.FieldUpdatedAt, secret.FieldExpiresAt:
 			values[i] = new(sql.NullTime)
 		case secret.FieldID:
 			values[i] = new(uuid.UUID)
@@ -124,6 +146,43 @@ func (_m *Secret) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.AccessCount = int(value.Int64)
 			}
+		case secret.FieldVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				_m.Version = int(value.Int64)
+			}
+		case secret.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				_m.ExpiresAt = new(time.Time)
+				*_m.ExpiresAt = value.Time
+			}
+		case secret.FieldAllowedAgents:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field allowed_agents", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.AllowedAgents); err != nil {
+					return fmt.Errorf("unmarshal field allowed_agents: %w", err)
+				}
+			}
+		case secret.FieldAllowedTools:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field allowed_tools", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.AllowedTools); err != nil {
+					return fmt.Errorf("unmarshal field allowed_tools: %w", err)
+				}
+			}
+		case secret.FieldAllowedMcpServers:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field allowed_mcp_servers", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.AllowedMcpServers); err != nil {
+					return fmt.Errorf("unmarshal field allowed_mcp_servers: %w", err)
+				}
+			}
 		case secret.ForeignKeys[0]:
 			if value, ok := values[i].(*sql.NullScanner); !ok {
 				return fmt.Errorf("unexpected type %T for field key_secrets", values[i])
@@ -149,6 +208,11 @@ func (_m *Secret) QueryKey() *KeyQuery {
 	return NewSecretClient(_m.config).QueryKey(_m)
 }
 
+// QueryVersions queries the "versions" edge of the Secret entity.
+func (_m *Secret) QueryVersions() *SecretVersionQuery {
+	return NewSecretClient(_m.config).QueryVersions(_m)
+}
+
 // Update returns a builder for updating this Secret.
 // Note that you need to call Secret.Unwrap() before calling this method if this Secret
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -186,6 +250,23 @@ func (_m *Secret) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("access_count=")
 	builder.WriteString(fmt.Sprintf("%v", _m.AccessCount))
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Version))
+	builder.WriteString(", ")
+	if v := _m.ExpiresAt; v != nil {
+		builder.WriteString("expires_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("allowed_agents=")
+	builder.WriteString(fmt.Sprintf("%v", _m.AllowedAgents))
+	builder.WriteString(", ")
+	builder.WriteString("allowed_tools=")
+	builder.WriteString(fmt.Sprintf("%v", _m.AllowedTools))
+	builder.WriteString(", ")
+	builder.WriteString("allowed_mcp_servers=")
+	builder.WriteString(fmt.Sprintf("%v", _m.AllowedMcpServers))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -25,8 +25,20 @@ const (
 	FieldUpdatedAt = "updated_at"
 	// FieldAccessCount holds the string denoting the access_count field in the database.
 	FieldAccessCount = "access_count"
+	// FieldVersion holds the string denoting the version field in the database.
+	FieldVersion = "version"
+	// FieldExpiresAt holds the string denoting the expires_at field in the database.
+	FieldExpiresAt = "expires_at"
+	// FieldAllowedAgents holds the string denoting the allowed_agents field in the database.
+	FieldAllowedAgents = "allowed_agents"
+	// FieldAllowedTools holds the string denoting the allowed_tools field in the database.
+	FieldAllowedTools = "allowed_tools"
+	// FieldAllowedMcpServers holds the string denoting the allowed_mcp_servers field in the database.
+	FieldAllowedMcpServers = "allowed_mcp_servers"
 	// EdgeKey holds the string denoting the key edge name in mutations.
 	EdgeKey = "key"
+	// EdgeVersions holds the string denoting the versions edge name in mutations.
+	EdgeVersions = "versions"
 	// Table holds the table name of the secret in the database.
 	Table = "secrets"
 	// KeyTable is the table that holds the key relation/edge.
@@ -36,6 +48,13 @@ const (
 	KeyInverseTable = "keys"
 	// KeyColumn is the table column denoting the key relation/edge.
 	KeyColumn = "key_secrets"
+	// VersionsTable is the table that holds the versions relation/edge.
+	VersionsTable = "secret_versions"
+	// VersionsInverseTable is the table name for the SecretVersion entity.
+	// It exists in this package in order to avoid circular dependency with the "secretversion" package.
+	VersionsInverseTable = "secret_versions"
+	// VersionsColumn is the table column denoting the versions relation/edge.
+	VersionsColumn = "secret_versions"
 )
 
 // Columns holds all SQL columns for secret fields.
@@ -46,6 +65,11 @@ var Columns = []string{
 	FieldCreatedAt,
 	FieldUpdatedAt,
 	FieldAccessCount,
+	FieldVersion,
+	FieldExpiresAt,
+	FieldAllowedAgents,
+	FieldAllowedTools,
+	FieldAllowedMcpServers,
 }
 
 // ForeignKeys holds the SQL foreign-keys that are owned by the "secrets"
@@ -80,6 +104,8 @@ var (
 	UpdateDefaultUpdatedAt func() time.Time
 	// DefaultAccessCount holds the default value on creation for the "access_count" field.
 	DefaultAccessCount int
+	// DefaultVersion holds the default value on creation for the "version" field.
+	DefaultVersion int
 	// DefaultID holds the default value on creation for the "id" field.
 	DefaultID func() uuid.UUID
 )
@@ -112,12 +138,36 @@ func ByAccessCount(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldAccessCount, opts...).ToFunc()
 }
 
+// ByVersion orders the results by the version field.
+func ByVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVersion, opts...).ToFunc()
+}
+
+// ByExpiresAt orders the results by the expires_at field.
+func ByExpiresAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldExpiresAt, opts...).ToFunc()
+}
+
 // ByKeyField orders the results by key field.
 func ByKeyField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
 		sqlgraph.OrderByNeighborTerms(s, newKeyStep(), sql.OrderByField(field, opts...))
 	}
 }
+
+// ByVersionsCount orders the results by versions count.
+func ByVersionsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newVersionsStep(), opts...)
+	}
+}
+
+// ByVersions orders the results by versions terms.
+func ByVersions(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newVersionsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newKeyStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -125,3 +175,10 @@ func newKeyStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.M2O, true, KeyTable, KeyColumn),
 	)
 }
+func newVersionsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(VersionsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, VersionsTable, VersionsColumn),
+	)
+}
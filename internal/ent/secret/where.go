@@ -81,6 +81,16 @@ func AccessCount(v int) predicate.Secret {
 	return predicate.Secret(sql.FieldEQ(FieldAccessCount, v))
 }
 
+// Version applies equality check predicate on the "version" field. It's identical to VersionEQ.
+func Version(v int) predicate.Secret {
+	return predicate.Secret(sql.FieldEQ(FieldVersion, v))
+}
+
+// ExpiresAt applies equality check predicate on the "expires_at" field. It's identical to ExpiresAtEQ.
+func ExpiresAt(v time.Time) predicate.Secret {
+	return predicate.Secret(sql.FieldEQ(FieldExpiresAt, v))
+}
+
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.Secret {
 	return predicate.Secret(sql.FieldEQ(FieldName, v))
@@ -306,6 +316,126 @@ func AccessCountLTE(v int) predicate.Secret {
 	return predicate.Secret(sql.FieldLTE(FieldAccessCount, v))
 }
 
+// VersionEQ applies the EQ predicate on the "version" field.
+func VersionEQ(v int) predicate.Secret {
+	return predicate.Secret(sql.FieldEQ(FieldVersion, v))
+}
+
+// VersionNEQ applies the NEQ predicate on the "version" field.
+func VersionNEQ(v int) predicate.Secret {
+	return predicate.Secret(sql.FieldNEQ(FieldVersion, v))
+}
+
+// VersionIn applies the In predicate on the "version" field.
+func VersionIn(vs ...int) predicate.Secret {
+	return predicate.Secret(sql.FieldIn(FieldVersion, vs...))
+}
+
+// VersionNotIn applies the NotIn predicate on the "version" field.
+func VersionNotIn(vs ...int) predicate.Secret {
+	return predicate.Secret(sql.FieldNotIn(FieldVersion, vs...))
+}
+
+// VersionGT applies the GT predicate on the "version" field.
+func VersionGT(v int) predicate.Secret {
+	return predicate.Secret(sql.FieldGT(FieldVersion, v))
+}
+
+// VersionGTE applies the GTE predicate on the "version" field.
+func VersionGTE(v int) predicate.Secret {
+	return predicate.Secret(sql.FieldGTE(FieldVersion, v))
+}
+
+// VersionLT applies the LT predicate on the "version" field.
+func VersionLT(v int) predicate.Secret {
+	return predicate.Secret(sql.FieldLT(FieldVersion, v))
+}
+
+// VersionLTE applies the LTE predicate on the "version" field.
+func VersionLTE(v int) predicate.Secret {
+	return predicate.Secret(sql.FieldLTE(FieldVersion, v))
+}
+
+// ExpiresAtEQ applies the EQ predicate on the "expires_at" field.
+func ExpiresAtEQ(v time.Time) predicate.Secret {
+	return predicate.Secret(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtNEQ applies the NEQ predicate on the "expires_at" field.
+func ExpiresAtNEQ(v time.Time) predicate.Secret {
+	return predicate.Secret(sql.FieldNEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtIn applies the In predicate on the "expires_at" field.
+func ExpiresAtIn(vs ...time.Time) predicate.Secret {
+	return predicate.Secret(sql.FieldIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtNotIn applies the NotIn predicate on the "expires_at" field.
+func ExpiresAtNotIn(vs ...time.Time) predicate.Secret {
+	return predicate.Secret(sql.FieldNotIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtGT applies the GT predicate on the "expires_at" field.
+func ExpiresAtGT(v time.Time) predicate.Secret {
+	return predicate.Secret(sql.FieldGT(FieldExpiresAt, v))
+}
+
+// ExpiresAtGTE applies the GTE predicate on the "expires_at" field.
+func ExpiresAtGTE(v time.Time) predicate.Secret {
+	return predicate.Secret(sql.FieldGTE(FieldExpiresAt, v))
+}
+
+// ExpiresAtLT applies the LT predicate on the "expires_at" field.
+func ExpiresAtLT(v time.Time) predicate.Secret {
+	return predicate.Secret(sql.FieldLT(FieldExpiresAt, v))
+}
+
+// ExpiresAtLTE applies the LTE predicate on the "expires_at" field.
+func ExpiresAtLTE(v time.Time) predicate.Secret {
+	return predicate.Secret(sql.FieldLTE(FieldExpiresAt, v))
+}
+
+// ExpiresAtIsNil applies the IsNil predicate on the "expires_at" field.
+func ExpiresAtIsNil() predicate.Secret {
+	return predicate.Secret(sql.FieldIsNull(FieldExpiresAt))
+}
+
+// ExpiresAtNotNil applies the NotNil predicate on the "expires_at" field.
+func ExpiresAtNotNil() predicate.Secret {
+	return predicate.Secret(sql.FieldNotNull(FieldExpiresAt))
+}
+
+// AllowedAgentsIsNil applies the IsNil predicate on the "allowed_agents" field.
+func AllowedAgentsIsNil() predicate.Secret {
+	return predicate.Secret(sql.FieldIsNull(FieldAllowedAgents))
+}
+
+// AllowedAgentsNotNil applies the NotNil predicate on the "allowed_agents" field.
+func AllowedAgentsNotNil() predicate.Secret {
+	return predicate.Secret(sql.FieldNotNull(FieldAllowedAgents))
+}
+
+// AllowedToolsIsNil applies the IsNil predicate on the "allowed_tools" field.
+func AllowedToolsIsNil() predicate.Secret {
+	return predicate.Secret(sql.FieldIsNull(FieldAllowedTools))
+}
+
+// AllowedToolsNotNil applies the NotNil predicate on the "allowed_tools" field.
+func AllowedToolsNotNil() predicate.Secret {
+	return predicate.Secret(sql.FieldNotNull(FieldAllowedTools))
+}
+
+// AllowedMcpServersIsNil applies the IsNil predicate on the "allowed_mcp_servers" field.
+func AllowedMcpServersIsNil() predicate.Secret {
+	return predicate.Secret(sql.FieldIsNull(FieldAllowedMcpServers))
+}
+
+// AllowedMcpServersNotNil applies the NotNil predicate on the "allowed_mcp_servers" field.
+func AllowedMcpServersNotNil() predicate.Secret {
+	return predicate.Secret(sql.FieldNotNull(FieldAllowedMcpServers))
+}
+
 // HasKey applies the HasEdge predicate on the "key" edge.
 func HasKey() predicate.Secret {
 	return predicate.Secret(func(s *sql.Selector) {
@@ -329,6 +459,29 @@ func HasKeyWith(preds ...predicate.Key) predicate.Secret {
 	})
 }
 
+// HasVersions applies the HasEdge predicate on the "versions" edge.
+func HasVersions() predicate.Secret {
+	return predicate.Secret(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, VersionsTable, VersionsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasVersionsWith applies the HasEdge predicate on the "versions" edge with a given conditions (other predicates).
+func HasVersionsWith(preds ...predicate.SecretVersion) predicate.Secret {
+	return predicate.Secret(func(s *sql.Selector) {
+		step := newVersionsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Secret) predicate.Secret {
 	return predicate.Secret(sql.AndPredicates(predicates...))
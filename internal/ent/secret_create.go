@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/langoai/lango/internal/ent/key"
 	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
 )
 
 // SecretCreate is the builder for creating a Secret entity.
@@ -76,6 +77,52 @@ func (_c *SecretCreate) SetNillableAccessCount(v *int) *SecretCreate {
 	return _c
 }
 
+// SetVersion sets the "version" field.
+func (_c *SecretCreate) SetVersion(v int) *SecretCreate {
+	_c.mutation.SetVersion(v)
+	return _c
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_c *SecretCreate) SetNillableVersion(v *int) *SecretCreate {
+	if v != nil {
+		_c.SetVersion(*v)
+	}
+	return _c
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_c *SecretCreate) SetExpiresAt(v time.Time) *SecretCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_c *SecretCreate) SetNillableExpiresAt(v *time.Time) *SecretCreate {
+	if v != nil {
+		_c.SetExpiresAt(*v)
+	}
+	return _c
+}
+
+// SetAllowedAgents sets the "allowed_agents" field.
+func (_c *SecretCreate) SetAllowedAgents(v []string) *SecretCreate {
+	_c.mutation.SetAllowedAgents(v)
+	return _c
+}
+
+// SetAllowedTools sets the "allowed_tools" field.
+func (_c *SecretCreate) SetAllowedTools(v []string) *SecretCreate {
+	_c.mutation.SetAllowedTools(v)
+	return _c
+}
+
+// SetAllowedMcpServers sets the "allowed_mcp_servers" field.
+func (_c *SecretCreate) SetAllowedMcpServers(v []string) *SecretCreate {
+	_c.mutation.SetAllowedMcpServers(v)
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *SecretCreate) SetID(v uuid.UUID) *SecretCreate {
 	_c.mutation.SetID(v)
@@ -101,6 +148,21 @@ func (_c *SecretCreate) SetKey(v *Key) *SecretCreate {
 	return _c.SetKeyID(v.ID)
 }
 
+// AddVersionIDs adds the "versions" edge to the SecretVersion entity by IDs.
+func (_c *SecretCreate) AddVersionIDs(ids ...uuid.UUID) *SecretCreate {
+	_c.mutation.AddVersionIDs(ids...)
+	return _c
+}
+
+// AddVersions adds the "versions" edges to the SecretVersion entity.
+func (_c *SecretCreate) AddVersions(v ...*SecretVersion) *SecretCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddVersionIDs(ids...)
+}
+
 // Mutation returns the SecretMutation object of the builder.
 func (_c *SecretCreate) Mutation() *SecretMutation {
 	return _c.mutation
@@ -148,6 +210,10 @@ func (_c *SecretCreate) defaults() {
 		v := secret.DefaultAccessCount
 		_c.mutation.SetAccessCount(v)
 	}
+	if _, ok := _c.mutation.Version(); !ok {
+		v := secret.DefaultVersion
+		_c.mutation.SetVersion(v)
+	}
 	if _, ok := _c.mutation.ID(); !ok {
 		v := secret.DefaultID()
 		_c.mutation.SetID(v)
@@ -176,6 +242,9 @@ func (_c *SecretCreate) check() error {
 	if _, ok := _c.mutation.AccessCount(); !ok {
 		return &ValidationError{Name: "access_count", err: errors.New(`ent: missing required field "Secret.access_count"`)}
 	}
+	if _, ok := _c.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "Secret.version"`)}
+	}
 	if len(_c.mutation.KeyIDs()) == 0 {
 		return &ValidationError{Name: "key", err: errors.New(`ent: missing required edge "Secret.key"`)}
 	}
@@ -234,6 +303,26 @@ func (_c *SecretCreate) createSpec() (*Secret, *sqlgraph.CreateSpec) {
 		_spec.SetField(secret.FieldAccessCount, field.TypeInt, value)
 		_node.AccessCount = value
 	}
+	if value, ok := _c.mutation.Version(); ok {
+		_spec.SetField(secret.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(secret.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = &value
+	}
+	if value, ok := _c.mutation.AllowedAgents(); ok {
+		_spec.SetField(secret.FieldAllowedAgents, field.TypeJSON, value)
+		_node.AllowedAgents = value
+	}
+	if value, ok := _c.mutation.AllowedTools(); ok {
+		_spec.SetField(secret.FieldAllowedTools, field.TypeJSON, value)
+		_node.AllowedTools = value
+	}
+	if value, ok := _c.mutation.AllowedMcpServers(); ok {
+		_spec.SetField(secret.FieldAllowedMcpServers, field.TypeJSON, value)
+		_node.AllowedMcpServers = value
+	}
 	if nodes := _c.mutation.KeyIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -251,6 +340,22 @@ func (_c *SecretCreate) createSpec() (*Secret, *sqlgraph.CreateSpec) {
 		_node.key_secrets = &nodes[0]
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.VersionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   secret.VersionsTable,
+			Columns: []string{secret.VersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
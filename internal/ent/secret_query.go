@@ -4,6 +4,7 @@ package ent
 
 import (
 	"context"
+	"database/sql/driver"
 	"fmt"
 	"math"
 
@@ -15,17 +16,19 @@ import (
 	"github.com/langoai/lango/internal/ent/key"
 	"github.com/langoai/lango/internal/ent/predicate"
 	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
 )
 
 // SecretQuery is the builder for querying Secret entities.
 type SecretQuery struct {
 	config
-	ctx        *QueryContext
-	order      []secret.OrderOption
-	inters     []Interceptor
-	predicates []predicate.Secret
-	withKey    *KeyQuery
-	withFKs    bool
+	ctx          *QueryContext
+	order        []secret.OrderOption
+	inters       []Interceptor
+	predicates   []predicate.Secret
+	withKey      *KeyQuery
+	withVersions *SecretVersionQuery
+	withFKs      bool
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -84,6 +87,28 @@ func (_q *SecretQuery) QueryKey() *KeyQuery {
 	return query
 }
 
+// QueryVersions chains the current query on the "versions" edge.
+func (_q *SecretQuery) QueryVersions() *SecretVersionQuery {
+	query := (&SecretVersionClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(secret.Table, secret.FieldID, selector),
+			sqlgraph.To(secretversion.Table, secretversion.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, secret.VersionsTable, secret.VersionsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Secret entity from the query.
 // Returns a *NotFoundError when no Secret was found.
 func (_q *SecretQuery) First(ctx context.Context) (*Secret, error) {
@@ -271,12 +296,13 @@ func (_q *SecretQuery) Clone() *SecretQuery {
 		return nil
 	}
 	return &SecretQuery{
-		config:     _q.config,
-		ctx:        _q.ctx.Clone(),
-		order:      append([]secret.OrderOption{}, _q.order...),
-		inters:     append([]Interceptor{}, _q.inters...),
-		predicates: append([]predicate.Secret{}, _q.predicates...),
-		withKey:    _q.withKey.Clone(),
+		config:       _q.config,
+		ctx:          _q.ctx.Clone(),
+		order:        append([]secret.OrderOption{}, _q.order...),
+		inters:       append([]Interceptor{}, _q.inters...),
+		predicates:   append([]predicate.Secret{}, _q.predicates...),
+		withKey:      _q.withKey.Clone(),
+		withVersions: _q.withVersions.Clone(),
 		// clone intermediate query.
 		sql:  _q.sql.Clone(),
 		path: _q.path,
@@ -294,6 +320,17 @@ func (_q *SecretQuery) WithKey(opts ...func(*KeyQuery)) *SecretQuery {
 	return _q
 }
 
+// WithVersions tells the query-builder to eager-load the nodes that are connected to
+// the "versions" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *SecretQuery) WithVersions(opts ...func(*SecretVersionQuery)) *SecretQuery {
+	query := (&SecretVersionClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withVersions = query
+	return _q
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -373,8 +410,9 @@ func (_q *SecretQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Secre
 		nodes       = []*Secret{}
 		withFKs     = _q.withFKs
 		_spec       = _q.querySpec()
-		loadedTypes = [1]bool{
+		loadedTypes = [2]bool{
 			_q.withKey != nil,
+			_q.withVersions != nil,
 		}
 	)
 	if _q.withKey != nil {
@@ -407,6 +445,13 @@ func (_q *SecretQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Secre
 			return nil, err
 		}
 	}
+	if query := _q.withVersions; query != nil {
+		if err := _q.loadVersions(ctx, query, nodes,
+			func(n *Secret) { n.Edges.Versions = []*SecretVersion{} },
+			func(n *Secret, e *SecretVersion) { n.Edges.Versions = append(n.Edges.Versions, e) }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -442,6 +487,37 @@ func (_q *SecretQuery) loadKey(ctx context.Context, query *KeyQuery, nodes []*Se
 	}
 	return nil
 }
+func (_q *SecretQuery) loadVersions(ctx context.Context, query *SecretVersionQuery, nodes []*Secret, init func(*Secret), assign func(*Secret, *SecretVersion)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[uuid.UUID]*Secret)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.SecretVersion(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(secret.VersionsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.secret_versions
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "secret_versions" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "secret_versions" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
 
 func (_q *SecretQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := _q.querySpec()
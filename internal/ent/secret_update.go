@@ -10,11 +10,13 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"
 	"github.com/langoai/lango/internal/ent/key"
 	"github.com/langoai/lango/internal/ent/predicate"
 	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
 )
 
 // SecretUpdate is the builder for updating Secret entities.
@@ -77,6 +79,101 @@ func (_u *SecretUpdate) AddAccessCount(v int) *SecretUpdate {
 	return _u
 }
 
+// SetVersion sets the "version" field.
+func (_u *SecretUpdate) SetVersion(v int) *SecretUpdate {
+	_u.mutation.ResetVersion()
+	_u.mutation.SetVersion(v)
+	return _u
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_u *SecretUpdate) SetNillableVersion(v *int) *SecretUpdate {
+	if v != nil {
+		_u.SetVersion(*v)
+	}
+	return _u
+}
+
+// AddVersion adds value to the "version" field.
+func (_u *SecretUpdate) AddVersion(v int) *SecretUpdate {
+	_u.mutation.AddVersion(v)
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *SecretUpdate) SetExpiresAt(v time.Time) *SecretUpdate {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *SecretUpdate) SetNillableExpiresAt(v *time.Time) *SecretUpdate {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *SecretUpdate) ClearExpiresAt() *SecretUpdate {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
+// SetAllowedAgents sets the "allowed_agents" field.
+func (_u *SecretUpdate) SetAllowedAgents(v []string) *SecretUpdate {
+	_u.mutation.SetAllowedAgents(v)
+	return _u
+}
+
+// AppendAllowedAgents appends value to the "allowed_agents" field.
+func (_u *SecretUpdate) AppendAllowedAgents(v []string) *SecretUpdate {
+	_u.mutation.AppendAllowedAgents(v)
+	return _u
+}
+
+// ClearAllowedAgents clears the value of the "allowed_agents" field.
+func (_u *SecretUpdate) ClearAllowedAgents() *SecretUpdate {
+	_u.mutation.ClearAllowedAgents()
+	return _u
+}
+
+// SetAllowedTools sets the "allowed_tools" field.
+func (_u *SecretUpdate) SetAllowedTools(v []string) *SecretUpdate {
+	_u.mutation.SetAllowedTools(v)
+	return _u
+}
+
+// AppendAllowedTools appends value to the "allowed_tools" field.
+func (_u *SecretUpdate) AppendAllowedTools(v []string) *SecretUpdate {
+	_u.mutation.AppendAllowedTools(v)
+	return _u
+}
+
+// ClearAllowedTools clears the value of the "allowed_tools" field.
+func (_u *SecretUpdate) ClearAllowedTools() *SecretUpdate {
+	_u.mutation.ClearAllowedTools()
+	return _u
+}
+
+// SetAllowedMcpServers sets the "allowed_mcp_servers" field.
+func (_u *SecretUpdate) SetAllowedMcpServers(v []string) *SecretUpdate {
+	_u.mutation.SetAllowedMcpServers(v)
+	return _u
+}
+
+// AppendAllowedMcpServers appends value to the "allowed_mcp_servers" field.
+func (_u *SecretUpdate) AppendAllowedMcpServers(v []string) *SecretUpdate {
+	_u.mutation.AppendAllowedMcpServers(v)
+	return _u
+}
+
+// ClearAllowedMcpServers clears the value of the "allowed_mcp_servers" field.
+func (_u *SecretUpdate) ClearAllowedMcpServers() *SecretUpdate {
+	_u.mutation.ClearAllowedMcpServers()
+	return _u
+}
+
 // SetKeyID sets the "key" edge to the Key entity by ID.
 func (_u *SecretUpdate) SetKeyID(id uuid.UUID) *SecretUpdate {
 	_u.mutation.SetKeyID(id)
@@ -88,6 +185,21 @@ func (_u *SecretUpdate) SetKey(v *Key) *SecretUpdate {
 	return _u.SetKeyID(v.ID)
 }
 
+// AddVersionIDs adds the "versions" edge to the SecretVersion entity by IDs.
+func (_u *SecretUpdate) AddVersionIDs(ids ...uuid.UUID) *SecretUpdate {
+	_u.mutation.AddVersionIDs(ids...)
+	return _u
+}
+
+// AddVersions adds the "versions" edges to the SecretVersion entity.
+func (_u *SecretUpdate) AddVersions(v ...*SecretVersion) *SecretUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddVersionIDs(ids...)
+}
+
 // Mutation returns the SecretMutation object of the builder.
 func (_u *SecretUpdate) Mutation() *SecretMutation {
 	return _u.mutation
@@ -99,6 +211,27 @@ func (_u *SecretUpdate) ClearKey() *SecretUpdate {
 	return _u
 }
 
+// ClearVersions clears all "versions" edges to the SecretVersion entity.
+func (_u *SecretUpdate) ClearVersions() *SecretUpdate {
+	_u.mutation.ClearVersions()
+	return _u
+}
+
+// RemoveVersionIDs removes the "versions" edge to SecretVersion entities by IDs.
+func (_u *SecretUpdate) RemoveVersionIDs(ids ...uuid.UUID) *SecretUpdate {
+	_u.mutation.RemoveVersionIDs(ids...)
+	return _u
+}
+
+// RemoveVersions removes "versions" edges to SecretVersion entities.
+func (_u *SecretUpdate) RemoveVersions(v ...*SecretVersion) *SecretUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveVersionIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *SecretUpdate) Save(ctx context.Context) (int, error) {
 	_u.defaults()
@@ -175,6 +308,51 @@ func (_u *SecretUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.AddedAccessCount(); ok {
 		_spec.AddField(secret.FieldAccessCount, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.Version(); ok {
+		_spec.SetField(secret.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVersion(); ok {
+		_spec.AddField(secret.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(secret.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.ExpiresAtCleared() {
+		_spec.ClearField(secret.FieldExpiresAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.AllowedAgents(); ok {
+		_spec.SetField(secret.FieldAllowedAgents, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAllowedAgents(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, secret.FieldAllowedAgents, value)
+		})
+	}
+	if _u.mutation.AllowedAgentsCleared() {
+		_spec.ClearField(secret.FieldAllowedAgents, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.AllowedTools(); ok {
+		_spec.SetField(secret.FieldAllowedTools, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAllowedTools(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, secret.FieldAllowedTools, value)
+		})
+	}
+	if _u.mutation.AllowedToolsCleared() {
+		_spec.ClearField(secret.FieldAllowedTools, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.AllowedMcpServers(); ok {
+		_spec.SetField(secret.FieldAllowedMcpServers, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAllowedMcpServers(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, secret.FieldAllowedMcpServers, value)
+		})
+	}
+	if _u.mutation.AllowedMcpServersCleared() {
+		_spec.ClearField(secret.FieldAllowedMcpServers, field.TypeJSON)
+	}
 	if _u.mutation.KeyCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -204,6 +382,51 @@ func (_u *SecretUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.VersionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   secret.VersionsTable,
+			Columns: []string{secret.VersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedVersionsIDs(); len(nodes) > 0 && !_u.mutation.VersionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   secret.VersionsTable,
+			Columns: []string{secret.VersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.VersionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   secret.VersionsTable,
+			Columns: []string{secret.VersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{secret.Label}
@@ -271,6 +494,101 @@ func (_u *SecretUpdateOne) AddAccessCount(v int) *SecretUpdateOne {
 	return _u
 }
 
+// SetVersion sets the "version" field.
+func (_u *SecretUpdateOne) SetVersion(v int) *SecretUpdateOne {
+	_u.mutation.ResetVersion()
+	_u.mutation.SetVersion(v)
+	return _u
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_u *SecretUpdateOne) SetNillableVersion(v *int) *SecretUpdateOne {
+	if v != nil {
+		_u.SetVersion(*v)
+	}
+	return _u
+}
+
+// AddVersion adds value to the "version" field.
+func (_u *SecretUpdateOne) AddVersion(v int) *SecretUpdateOne {
+	_u.mutation.AddVersion(v)
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *SecretUpdateOne) SetExpiresAt(v time.Time) *SecretUpdateOne {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *SecretUpdateOne) SetNillableExpiresAt(v *time.Time) *SecretUpdateOne {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *SecretUpdateOne) ClearExpiresAt() *SecretUpdateOne {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
+// SetAllowedAgents sets the "allowed_agents" field.
+func (_u *SecretUpdateOne) SetAllowedAgents(v []string) *SecretUpdateOne {
+	_u.mutation.SetAllowedAgents(v)
+	return _u
+}
+
+// AppendAllowedAgents appends value to the "allowed_agents" field.
+func (_u *SecretUpdateOne) AppendAllowedAgents(v []string) *SecretUpdateOne {
+	_u.mutation.AppendAllowedAgents(v)
+	return _u
+}
+
+// ClearAllowedAgents clears the value of the "allowed_agents" field.
+func (_u *SecretUpdateOne) ClearAllowedAgents() *SecretUpdateOne {
+	_u.mutation.ClearAllowedAgents()
+	return _u
+}
+
+// SetAllowedTools sets the "allowed_tools" field.
+func (_u *SecretUpdateOne) SetAllowedTools(v []string) *SecretUpdateOne {
+	_u.mutation.SetAllowedTools(v)
+	return _u
+}
+
+// AppendAllowedTools appends value to the "allowed_tools" field.
+func (_u *SecretUpdateOne) AppendAllowedTools(v []string) *SecretUpdateOne {
+	_u.mutation.AppendAllowedTools(v)
+	return _u
+}
+
+// ClearAllowedTools clears the value of the "allowed_tools" field.
+func (_u *SecretUpdateOne) ClearAllowedTools() *SecretUpdateOne {
+	_u.mutation.ClearAllowedTools()
+	return _u
+}
+
+// SetAllowedMcpServers sets the "allowed_mcp_servers" field.
+func (_u *SecretUpdateOne) SetAllowedMcpServers(v []string) *SecretUpdateOne {
+	_u.mutation.SetAllowedMcpServers(v)
+	return _u
+}
+
+// AppendAllowedMcpServers appends value to the "allowed_mcp_servers" field.
+func (_u *SecretUpdateOne) AppendAllowedMcpServers(v []string) *SecretUpdateOne {
+	_u.mutation.AppendAllowedMcpServers(v)
+	return _u
+}
+
+// ClearAllowedMcpServers clears the value of the "allowed_mcp_servers" field.
+func (_u *SecretUpdateOne) ClearAllowedMcpServers() *SecretUpdateOne {
+	_u.mutation.ClearAllowedMcpServers()
+	return _u
+}
+
 // SetKeyID sets the "key" edge to the Key entity by ID.
 func (_u *SecretUpdateOne) SetKeyID(id uuid.UUID) *SecretUpdateOne {
 	_u.mutation.SetKeyID(id)
@@ -282,6 +600,21 @@ func (_u *SecretUpdateOne) SetKey(v *Key) *SecretUpdateOne {
 	return _u.SetKeyID(v.ID)
 }
 
+// AddVersionIDs adds the "versions" edge to the SecretVersion entity by IDs.
+func (_u *SecretUpdateOne) AddVersionIDs(ids ...uuid.UUID) *SecretUpdateOne {
+	_u.mutation.AddVersionIDs(ids...)
+	return _u
+}
+
+// AddVersions adds the "versions" edges to the SecretVersion entity.
+func (_u *SecretUpdateOne) AddVersions(v ...*SecretVersion) *SecretUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddVersionIDs(ids...)
+}
+
 // Mutation returns the SecretMutation object of the builder.
 func (_u *SecretUpdateOne) Mutation() *SecretMutation {
 	return _u.mutation
@@ -293,6 +626,27 @@ func (_u *SecretUpdateOne) ClearKey() *SecretUpdateOne {
 	return _u
 }
 
+// ClearVersions clears all "versions" edges to the SecretVersion entity.
+func (_u *SecretUpdateOne) ClearVersions() *SecretUpdateOne {
+	_u.mutation.ClearVersions()
+	return _u
+}
+
+// RemoveVersionIDs removes the "versions" edge to SecretVersion entities by IDs.
+func (_u *SecretUpdateOne) RemoveVersionIDs(ids ...uuid.UUID) *SecretUpdateOne {
+	_u.mutation.RemoveVersionIDs(ids...)
+	return _u
+}
+
+// RemoveVersions removes "versions" edges to SecretVersion entities.
+func (_u *SecretUpdateOne) RemoveVersions(v ...*SecretVersion) *SecretUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveVersionIDs(ids...)
+}
+
 // Where appends a list predicates to the SecretUpdate builder.
 func (_u *SecretUpdateOne) Where(ps ...predicate.Secret) *SecretUpdateOne {
 	_u.mutation.Where(ps...)
@@ -399,6 +753,51 @@ func (_u *SecretUpdateOne) sqlSave(ctx context.Context) (_node *Secret, err erro
 	if value, ok := _u.mutation.AddedAccessCount(); ok {
 		_spec.AddField(secret.FieldAccessCount, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.Version(); ok {
+		_spec.SetField(secret.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVersion(); ok {
+		_spec.AddField(secret.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(secret.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.ExpiresAtCleared() {
+		_spec.ClearField(secret.FieldExpiresAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.AllowedAgents(); ok {
+		_spec.SetField(secret.FieldAllowedAgents, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAllowedAgents(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, secret.FieldAllowedAgents, value)
+		})
+	}
+	if _u.mutation.AllowedAgentsCleared() {
+		_spec.ClearField(secret.FieldAllowedAgents, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.AllowedTools(); ok {
+		_spec.SetField(secret.FieldAllowedTools, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAllowedTools(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, secret.FieldAllowedTools, value)
+		})
+	}
+	if _u.mutation.AllowedToolsCleared() {
+		_spec.ClearField(secret.FieldAllowedTools, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.AllowedMcpServers(); ok {
+		_spec.SetField(secret.FieldAllowedMcpServers, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAllowedMcpServers(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, secret.FieldAllowedMcpServers, value)
+		})
+	}
+	if _u.mutation.AllowedMcpServersCleared() {
+		_spec.ClearField(secret.FieldAllowedMcpServers, field.TypeJSON)
+	}
 	if _u.mutation.KeyCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -428,6 +827,51 @@ func (_u *SecretUpdateOne) sqlSave(ctx context.Context) (_node *Secret, err erro
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.VersionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   secret.VersionsTable,
+			Columns: []string{secret.VersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedVersionsIDs(); len(nodes) > 0 && !_u.mutation.VersionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   secret.VersionsTable,
+			Columns: []string{secret.VersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.VersionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   secret.VersionsTable,
+			Columns: []string{secret.VersionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Secret{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
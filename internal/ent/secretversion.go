@@ -0,0 +1,199 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/langoai/lango/internal/ent/key"
+	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
+)
+
+// SecretVersion is the model entity for the SecretVersion schema.
+type SecretVersion struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// Monotonic version number within the owning secret
+	Version int `json:"version,omitempty"`
+	// Encrypted secret data for this version
+	EncryptedValue []byte `json:"encrypted_value,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the SecretVersionQuery when eager-loading is set.
+	Edges               SecretVersionEdges `json:"edges"`
+	key_secret_versions *uuid.UUID
+	secret_versions     *uuid.UUID
+	selectValues        sql.SelectValues
+}
+
+// SecretVersionEdges holds the relations/edges for other nodes in the graph.
+type SecretVersionEdges struct {
+	// Secret this version belongs to
+	Secret *Secret `json:"secret,omitempty"`
+	// Encryption key used for this version
+	Key *Key `json:"key,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// SecretOrErr returns the Secret value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e SecretVersionEdges) SecretOrErr() (*Secret, error) {
+	if e.Secret != nil {
+		return e.Secret, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: secret.Label}
+	}
+	return nil, &NotLoadedError{edge: "secret"}
+}
+
+// KeyOrErr returns the Key value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e SecretVersionEdges) KeyOrErr() (*Key, error) {
+	if e.Key != nil {
+		return e.Key, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: key.Label}
+	}
+	return nil, &NotLoadedError{edge: "key"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*SecretVersion) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case secretversion.FieldEncryptedValue:
+			values[i] = new([]byte)
+		case secretversion.FieldVersion:
+			values[i] = new(sql.NullInt64)
+		case secretversion.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case secretversion.FieldID:
+			values[i] = new(uuid.UUID)
+		case secretversion.ForeignKeys[0]: // key_secret_versions
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		case secretversion.ForeignKeys[1]: // secret_versions
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the SecretVersion fields.
+func (_m *SecretVersion) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case secretversion.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case secretversion.FieldVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				_m.Version = int(value.Int64)
+			}
+		case secretversion.FieldEncryptedValue:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field encrypted_value", values[i])
+			} else if value != nil {
+				_m.EncryptedValue = *value
+			}
+		case secretversion.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case secretversion.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field key_secret_versions", values[i])
+			} else if value.Valid {
+				_m.key_secret_versions = new(uuid.UUID)
+				*_m.key_secret_versions = *value.S.(*uuid.UUID)
+			}
+		case secretversion.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field secret_versions", values[i])
+			} else if value.Valid {
+				_m.secret_versions = new(uuid.UUID)
+				*_m.secret_versions = *value.S.(*uuid.UUID)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the SecretVersion.
+// This includes values selected through modifiers, order, etc.
+func (_m *SecretVersion) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QuerySecret queries the "secret" edge of the SecretVersion entity.
+func (_m *SecretVersion) QuerySecret() *SecretQuery {
+	return NewSecretVersionClient(_m.config).QuerySecret(_m)
+}
+
+// QueryKey queries the "key" edge of the SecretVersion entity.
+func (_m *SecretVersion) QueryKey() *KeyQuery {
+	return NewSecretVersionClient(_m.config).QueryKey(_m)
+}
+
+// Update returns a builder for updating this SecretVersion.
+// Note that you need to call SecretVersion.Unwrap() before calling this method if this SecretVersion
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *SecretVersion) Update() *SecretVersionUpdateOne {
+	return NewSecretVersionClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the SecretVersion entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *SecretVersion) Unwrap() *SecretVersion {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: SecretVersion is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *SecretVersion) String() string {
+	var builder strings.Builder
+	builder.WriteString("SecretVersion(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("version=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Version))
+	builder.WriteString(", ")
+	builder.WriteString("encrypted_value=")
+	builder.WriteString(fmt.Sprintf("%v", _m.EncryptedValue))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// SecretVersions is a parsable slice of SecretVersion.
+type SecretVersions []*SecretVersion
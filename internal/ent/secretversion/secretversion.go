@@ -0,0 +1,129 @@
+// Code generated by ent, DO NOT EDIT.
+
+package secretversion
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the secretversion type in the database.
+	Label = "secret_version"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldVersion holds the string denoting the version field in the database.
+	FieldVersion = "version"
+	// FieldEncryptedValue holds the string denoting the encrypted_value field in the database.
+	FieldEncryptedValue = "encrypted_value"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// EdgeSecret holds the string denoting the secret edge name in mutations.
+	EdgeSecret = "secret"
+	// EdgeKey holds the string denoting the key edge name in mutations.
+	EdgeKey = "key"
+	// Table holds the table name of the secretversion in the database.
+	Table = "secret_versions"
+	// SecretTable is the table that holds the secret relation/edge.
+	SecretTable = "secret_versions"
+	// SecretInverseTable is the table name for the Secret entity.
+	// It exists in this package in order to avoid circular dependency with the "secret" package.
+	SecretInverseTable = "secrets"
+	// SecretColumn is the table column denoting the secret relation/edge.
+	SecretColumn = "secret_versions"
+	// KeyTable is the table that holds the key relation/edge.
+	KeyTable = "secret_versions"
+	// KeyInverseTable is the table name for the Key entity.
+	// It exists in this package in order to avoid circular dependency with the "key" package.
+	KeyInverseTable = "keys"
+	// KeyColumn is the table column denoting the key relation/edge.
+	KeyColumn = "key_secret_versions"
+)
+
+// Columns holds all SQL columns for secretversion fields.
+var Columns = []string{
+	FieldID,
+	FieldVersion,
+	FieldEncryptedValue,
+	FieldCreatedAt,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the "secret_versions"
+// table and are not defined as standalone fields in the schema.
+var ForeignKeys = []string{
+	"key_secret_versions",
+	"secret_versions",
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	VersionValidator func(int) error
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// OrderOption defines the ordering options for the SecretVersion queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByVersion orders the results by the version field.
+func ByVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVersion, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// BySecretField orders the results by secret field.
+func BySecretField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newSecretStep(), sql.OrderByField(field, opts...))
+	}
+}
+
+// ByKeyField orders the results by key field.
+func ByKeyField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newKeyStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newSecretStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(SecretInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, SecretTable, SecretColumn),
+	)
+}
+func newKeyStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(KeyInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, KeyTable, KeyColumn),
+	)
+}
@@ -0,0 +1,253 @@
+// Code generated by ent, DO NOT EDIT.
+
+package secretversion
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+	"github.com/langoai/lango/internal/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldLTE(FieldID, id))
+}
+
+// Version applies equality check predicate on the "version" field. It's identical to VersionEQ.
+func Version(v int) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldEQ(FieldVersion, v))
+}
+
+// EncryptedValue applies equality check predicate on the "encrypted_value" field. It's identical to EncryptedValueEQ.
+func EncryptedValue(v []byte) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldEQ(FieldEncryptedValue, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// VersionEQ applies the EQ predicate on the "version" field.
+func VersionEQ(v int) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldEQ(FieldVersion, v))
+}
+
+// VersionNEQ applies the NEQ predicate on the "version" field.
+func VersionNEQ(v int) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldNEQ(FieldVersion, v))
+}
+
+// VersionIn applies the In predicate on the "version" field.
+func VersionIn(vs ...int) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldIn(FieldVersion, vs...))
+}
+
+// VersionNotIn applies the NotIn predicate on the "version" field.
+func VersionNotIn(vs ...int) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldNotIn(FieldVersion, vs...))
+}
+
+// VersionGT applies the GT predicate on the "version" field.
+func VersionGT(v int) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldGT(FieldVersion, v))
+}
+
+// VersionGTE applies the GTE predicate on the "version" field.
+func VersionGTE(v int) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldGTE(FieldVersion, v))
+}
+
+// VersionLT applies the LT predicate on the "version" field.
+func VersionLT(v int) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldLT(FieldVersion, v))
+}
+
+// VersionLTE applies the LTE predicate on the "version" field.
+func VersionLTE(v int) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldLTE(FieldVersion, v))
+}
+
+// EncryptedValueEQ applies the EQ predicate on the "encrypted_value" field.
+func EncryptedValueEQ(v []byte) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldEQ(FieldEncryptedValue, v))
+}
+
+// EncryptedValueNEQ applies the NEQ predicate on the "encrypted_value" field.
+func EncryptedValueNEQ(v []byte) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldNEQ(FieldEncryptedValue, v))
+}
+
+// EncryptedValueIn applies the In predicate on the "encrypted_value" field.
+func EncryptedValueIn(vs ...[]byte) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldIn(FieldEncryptedValue, vs...))
+}
+
+// EncryptedValueNotIn applies the NotIn predicate on the "encrypted_value" field.
+func EncryptedValueNotIn(vs ...[]byte) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldNotIn(FieldEncryptedValue, vs...))
+}
+
+// EncryptedValueGT applies the GT predicate on the "encrypted_value" field.
+func EncryptedValueGT(v []byte) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldGT(FieldEncryptedValue, v))
+}
+
+// EncryptedValueGTE applies the GTE predicate on the "encrypted_value" field.
+func EncryptedValueGTE(v []byte) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldGTE(FieldEncryptedValue, v))
+}
+
+// EncryptedValueLT applies the LT predicate on the "encrypted_value" field.
+func EncryptedValueLT(v []byte) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldLT(FieldEncryptedValue, v))
+}
+
+// EncryptedValueLTE applies the LTE predicate on the "encrypted_value" field.
+func EncryptedValueLTE(v []byte) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldLTE(FieldEncryptedValue, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// HasSecret applies the HasEdge predicate on the "secret" edge.
+func HasSecret() predicate.SecretVersion {
+	return predicate.SecretVersion(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, SecretTable, SecretColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasSecretWith applies the HasEdge predicate on the "secret" edge with a given conditions (other predicates).
+func HasSecretWith(preds ...predicate.Secret) predicate.SecretVersion {
+	return predicate.SecretVersion(func(s *sql.Selector) {
+		step := newSecretStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasKey applies the HasEdge predicate on the "key" edge.
+func HasKey() predicate.SecretVersion {
+	return predicate.SecretVersion(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, KeyTable, KeyColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasKeyWith applies the HasEdge predicate on the "key" edge with a given conditions (other predicates).
+func HasKeyWith(preds ...predicate.Key) predicate.SecretVersion {
+	return predicate.SecretVersion(func(s *sql.Selector) {
+		step := newKeyStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.SecretVersion) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.SecretVersion) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.SecretVersion) predicate.SecretVersion {
+	return predicate.SecretVersion(sql.NotPredicates(p))
+}
@@ -0,0 +1,321 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/langoai/lango/internal/ent/key"
+	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
+)
+
+// SecretVersionCreate is the builder for creating a SecretVersion entity.
+type SecretVersionCreate struct {
+	config
+	mutation *SecretVersionMutation
+	hooks    []Hook
+}
+
+// SetVersion sets the "version" field.
+func (_c *SecretVersionCreate) SetVersion(v int) *SecretVersionCreate {
+	_c.mutation.SetVersion(v)
+	return _c
+}
+
+// SetEncryptedValue sets the "encrypted_value" field.
+func (_c *SecretVersionCreate) SetEncryptedValue(v []byte) *SecretVersionCreate {
+	_c.mutation.SetEncryptedValue(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *SecretVersionCreate) SetCreatedAt(v time.Time) *SecretVersionCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *SecretVersionCreate) SetNillableCreatedAt(v *time.Time) *SecretVersionCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *SecretVersionCreate) SetID(v uuid.UUID) *SecretVersionCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *SecretVersionCreate) SetNillableID(v *uuid.UUID) *SecretVersionCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// SetSecretID sets the "secret" edge to the Secret entity by ID.
+func (_c *SecretVersionCreate) SetSecretID(id uuid.UUID) *SecretVersionCreate {
+	_c.mutation.SetSecretID(id)
+	return _c
+}
+
+// SetSecret sets the "secret" edge to the Secret entity.
+func (_c *SecretVersionCreate) SetSecret(v *Secret) *SecretVersionCreate {
+	return _c.SetSecretID(v.ID)
+}
+
+// SetKeyID sets the "key" edge to the Key entity by ID.
+func (_c *SecretVersionCreate) SetKeyID(id uuid.UUID) *SecretVersionCreate {
+	_c.mutation.SetKeyID(id)
+	return _c
+}
+
+// SetKey sets the "key" edge to the Key entity.
+func (_c *SecretVersionCreate) SetKey(v *Key) *SecretVersionCreate {
+	return _c.SetKeyID(v.ID)
+}
+
+// Mutation returns the SecretVersionMutation object of the builder.
+func (_c *SecretVersionCreate) Mutation() *SecretVersionMutation {
+	return _c.mutation
+}
+
+// Save creates the SecretVersion in the database.
+func (_c *SecretVersionCreate) Save(ctx context.Context) (*SecretVersion, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *SecretVersionCreate) SaveX(ctx context.Context) *SecretVersion {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SecretVersionCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SecretVersionCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *SecretVersionCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := secretversion.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := secretversion.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *SecretVersionCreate) check() error {
+	if _, ok := _c.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "SecretVersion.version"`)}
+	}
+	if v, ok := _c.mutation.Version(); ok {
+		if err := secretversion.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "SecretVersion.version": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.EncryptedValue(); !ok {
+		return &ValidationError{Name: "encrypted_value", err: errors.New(`ent: missing required field "SecretVersion.encrypted_value"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "SecretVersion.created_at"`)}
+	}
+	if len(_c.mutation.SecretIDs()) == 0 {
+		return &ValidationError{Name: "secret", err: errors.New(`ent: missing required edge "SecretVersion.secret"`)}
+	}
+	if len(_c.mutation.KeyIDs()) == 0 {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required edge "SecretVersion.key"`)}
+	}
+	return nil
+}
+
+func (_c *SecretVersionCreate) sqlSave(ctx context.Context) (*SecretVersion, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *SecretVersionCreate) createSpec() (*SecretVersion, *sqlgraph.CreateSpec) {
+	var (
+		_node = &SecretVersion{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(secretversion.Table, sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Version(); ok {
+		_spec.SetField(secretversion.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
+	if value, ok := _c.mutation.EncryptedValue(); ok {
+		_spec.SetField(secretversion.FieldEncryptedValue, field.TypeBytes, value)
+		_node.EncryptedValue = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(secretversion.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.SecretIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   secretversion.SecretTable,
+			Columns: []string{secretversion.SecretColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secret.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.secret_versions = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.KeyIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   secretversion.KeyTable,
+			Columns: []string{secretversion.KeyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(key.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.key_secret_versions = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// SecretVersionCreateBulk is the builder for creating many SecretVersion entities in bulk.
+type SecretVersionCreateBulk struct {
+	config
+	err      error
+	builders []*SecretVersionCreate
+}
+
+// Save creates the SecretVersion entities in the database.
+func (_c *SecretVersionCreateBulk) Save(ctx context.Context) ([]*SecretVersion, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*SecretVersion, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SecretVersionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *SecretVersionCreateBulk) SaveX(ctx context.Context) []*SecretVersion {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SecretVersionCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SecretVersionCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,390 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/langoai/lango/internal/ent/key"
+	"github.com/langoai/lango/internal/ent/predicate"
+	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
+)
+
+// SecretVersionUpdate is the builder for updating SecretVersion entities.
+type SecretVersionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *SecretVersionMutation
+}
+
+// Where appends a list predicates to the SecretVersionUpdate builder.
+func (_u *SecretVersionUpdate) Where(ps ...predicate.SecretVersion) *SecretVersionUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetSecretID sets the "secret" edge to the Secret entity by ID.
+func (_u *SecretVersionUpdate) SetSecretID(id uuid.UUID) *SecretVersionUpdate {
+	_u.mutation.SetSecretID(id)
+	return _u
+}
+
+// SetSecret sets the "secret" edge to the Secret entity.
+func (_u *SecretVersionUpdate) SetSecret(v *Secret) *SecretVersionUpdate {
+	return _u.SetSecretID(v.ID)
+}
+
+// SetKeyID sets the "key" edge to the Key entity by ID.
+func (_u *SecretVersionUpdate) SetKeyID(id uuid.UUID) *SecretVersionUpdate {
+	_u.mutation.SetKeyID(id)
+	return _u
+}
+
+// SetKey sets the "key" edge to the Key entity.
+func (_u *SecretVersionUpdate) SetKey(v *Key) *SecretVersionUpdate {
+	return _u.SetKeyID(v.ID)
+}
+
+// Mutation returns the SecretVersionMutation object of the builder.
+func (_u *SecretVersionUpdate) Mutation() *SecretVersionMutation {
+	return _u.mutation
+}
+
+// ClearSecret clears the "secret" edge to the Secret entity.
+func (_u *SecretVersionUpdate) ClearSecret() *SecretVersionUpdate {
+	_u.mutation.ClearSecret()
+	return _u
+}
+
+// ClearKey clears the "key" edge to the Key entity.
+func (_u *SecretVersionUpdate) ClearKey() *SecretVersionUpdate {
+	_u.mutation.ClearKey()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *SecretVersionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *SecretVersionUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *SecretVersionUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *SecretVersionUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *SecretVersionUpdate) check() error {
+	if _u.mutation.SecretCleared() && len(_u.mutation.SecretIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "SecretVersion.secret"`)
+	}
+	if _u.mutation.KeyCleared() && len(_u.mutation.KeyIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "SecretVersion.key"`)
+	}
+	return nil
+}
+
+func (_u *SecretVersionUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(secretversion.Table, secretversion.Columns, sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.SecretCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   secretversion.SecretTable,
+			Columns: []string{secretversion.SecretColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secret.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SecretIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   secretversion.SecretTable,
+			Columns: []string{secretversion.SecretColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secret.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.KeyCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   secretversion.KeyTable,
+			Columns: []string{secretversion.KeyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(key.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.KeyIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   secretversion.KeyTable,
+			Columns: []string{secretversion.KeyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(key.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{secretversion.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// SecretVersionUpdateOne is the builder for updating a single SecretVersion entity.
+type SecretVersionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *SecretVersionMutation
+}
+
+// SetSecretID sets the "secret" edge to the Secret entity by ID.
+func (_u *SecretVersionUpdateOne) SetSecretID(id uuid.UUID) *SecretVersionUpdateOne {
+	_u.mutation.SetSecretID(id)
+	return _u
+}
+
+// SetSecret sets the "secret" edge to the Secret entity.
+func (_u *SecretVersionUpdateOne) SetSecret(v *Secret) *SecretVersionUpdateOne {
+	return _u.SetSecretID(v.ID)
+}
+
+// SetKeyID sets the "key" edge to the Key entity by ID.
+func (_u *SecretVersionUpdateOne) SetKeyID(id uuid.UUID) *SecretVersionUpdateOne {
+	_u.mutation.SetKeyID(id)
+	return _u
+}
+
+// SetKey sets the "key" edge to the Key entity.
+func (_u *SecretVersionUpdateOne) SetKey(v *Key) *SecretVersionUpdateOne {
+	return _u.SetKeyID(v.ID)
+}
+
+// Mutation returns the SecretVersionMutation object of the builder.
+func (_u *SecretVersionUpdateOne) Mutation() *SecretVersionMutation {
+	return _u.mutation
+}
+
+// ClearSecret clears the "secret" edge to the Secret entity.
+func (_u *SecretVersionUpdateOne) ClearSecret() *SecretVersionUpdateOne {
+	_u.mutation.ClearSecret()
+	return _u
+}
+
+// ClearKey clears the "key" edge to the Key entity.
+func (_u *SecretVersionUpdateOne) ClearKey() *SecretVersionUpdateOne {
+	_u.mutation.ClearKey()
+	return _u
+}
+
+// Where appends a list predicates to the SecretVersionUpdate builder.
+func (_u *SecretVersionUpdateOne) Where(ps ...predicate.SecretVersion) *SecretVersionUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *SecretVersionUpdateOne) Select(field string, fields ...string) *SecretVersionUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated SecretVersion entity.
+func (_u *SecretVersionUpdateOne) Save(ctx context.Context) (*SecretVersion, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *SecretVersionUpdateOne) SaveX(ctx context.Context) *SecretVersion {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *SecretVersionUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *SecretVersionUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *SecretVersionUpdateOne) check() error {
+	if _u.mutation.SecretCleared() && len(_u.mutation.SecretIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "SecretVersion.secret"`)
+	}
+	if _u.mutation.KeyCleared() && len(_u.mutation.KeyIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "SecretVersion.key"`)
+	}
+	return nil
+}
+
+func (_u *SecretVersionUpdateOne) sqlSave(ctx context.Context) (_node *SecretVersion, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(secretversion.Table, secretversion.Columns, sqlgraph.NewFieldSpec(secretversion.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "SecretVersion.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, secretversion.FieldID)
+		for _, f := range fields {
+			if !secretversion.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != secretversion.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.SecretCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   secretversion.SecretTable,
+			Columns: []string{secretversion.SecretColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secret.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SecretIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   secretversion.SecretTable,
+			Columns: []string{secretversion.SecretColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(secret.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.KeyCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   secretversion.KeyTable,
+			Columns: []string{secretversion.KeyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(key.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.KeyIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   secretversion.KeyTable,
+			Columns: []string{secretversion.KeyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(key.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &SecretVersion{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{secretversion.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -16,6 +16,8 @@ type Tx struct {
 	ActionLog *ActionLogClient
 	// AgentMemory is the client for interacting with the AgentMemory builders.
 	AgentMemory *AgentMemoryClient
+	// AuditCheckpoint is the client for interacting with the AuditCheckpoint builders.
+	AuditCheckpoint *AuditCheckpointClient
 	// AuditLog is the client for interacting with the AuditLog builders.
 	AuditLog *AuditLogClient
 	// ConfigProfile is the client for interacting with the ConfigProfile builders.
@@ -68,6 +70,8 @@ type Tx struct {
 	RunStep *RunStepClient
 	// Secret is the client for interacting with the Secret builders.
 	Secret *SecretClient
+	// SecretVersion is the client for interacting with the SecretVersion builders.
+	SecretVersion *SecretVersionClient
 	// Session is the client for interacting with the Session builders.
 	Session *SessionClient
 	// SessionProvenance is the client for interacting with the SessionProvenance builders.
@@ -215,6 +219,7 @@ func (tx *Tx) Client() *Client {
 func (tx *Tx) init() {
 	tx.ActionLog = NewActionLogClient(tx.config)
 	tx.AgentMemory = NewAgentMemoryClient(tx.config)
+	tx.AuditCheckpoint = NewAuditCheckpointClient(tx.config)
 	tx.AuditLog = NewAuditLogClient(tx.config)
 	tx.ConfigProfile = NewConfigProfileClient(tx.config)
 	tx.CronJob = NewCronJobClient(tx.config)
@@ -241,6 +246,7 @@ func (tx *Tx) init() {
 	tx.RunSnapshot = NewRunSnapshotClient(tx.config)
 	tx.RunStep = NewRunStepClient(tx.config)
 	tx.Secret = NewSecretClient(tx.config)
+	tx.SecretVersion = NewSecretVersionClient(tx.config)
 	tx.Session = NewSessionClient(tx.config)
 	tx.SessionProvenance = NewSessionProvenanceClient(tx.config)
 	tx.TokenUsage = NewTokenUsageClient(tx.config)
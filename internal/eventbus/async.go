@@ -0,0 +1,91 @@
+package eventbus
+
+import "sync"
+
+// OverflowPolicy decides what an async subscriber does when its queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowDrop discards the new event so publishers never wait.
+	OverflowDrop OverflowPolicy = iota
+	// OverflowBlock makes Publish wait until the queue has room, applying
+	// backpressure to the publisher.
+	OverflowBlock
+)
+
+// DefaultAsyncQueueSize is the queue size used when AsyncOptions.QueueSize
+// is not set.
+const DefaultAsyncQueueSize = 64
+
+// AsyncOptions configures SubscribeAsync.
+type AsyncOptions struct {
+	// QueueSize bounds the number of events waiting for the handler
+	// (default: DefaultAsyncQueueSize).
+	QueueSize int
+
+	// Policy applies when the queue is full (default: OverflowDrop).
+	Policy OverflowPolicy
+
+	// OnDrop, if set, is called with each event discarded under OverflowDrop.
+	// It runs on the publisher's goroutine and must not block.
+	OnDrop func(Event)
+}
+
+// SubscribeAsync registers a handler that runs on its own goroutine. Events
+// are queued in publish order and handled one at a time, so a slow handler
+// does not hold up Publish or other subscribers. The returned func
+// unsubscribes the handler and stops its goroutine; events still queued
+// are discarded, and a Publish blocked on a full queue is released.
+func (b *Bus) SubscribeAsync(eventName string, handler HandlerFunc, opts AsyncOptions) func() {
+	size := opts.QueueSize
+	if size <= 0 {
+		size = DefaultAsyncQueueSize
+	}
+
+	var (
+		queue = make(chan Event, size)
+		done  = make(chan struct{})
+		once  sync.Once
+	)
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case ev := <-queue:
+				select {
+				case <-done:
+					return
+				default:
+				}
+				handler(ev)
+			}
+		}
+	}()
+
+	unsubscribe := b.Subscribe(eventName, func(ev Event) {
+		if opts.Policy == OverflowBlock {
+			select {
+			case queue <- ev:
+			case <-done:
+			}
+			return
+		}
+		select {
+		case queue <- ev:
+		case <-done:
+		default:
+			if opts.OnDrop != nil {
+				opts.OnDrop(ev)
+			}
+		}
+	})
+
+	return func() {
+		once.Do(func() {
+			unsubscribe()
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package eventbus
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeAsync_PreservesOrder(t *testing.T) {
+	t.Parallel()
+
+	bus := New()
+
+	var (
+		mu  sync.Mutex
+		got []int
+	)
+	unsubscribe := bus.SubscribeAsync("other.event", func(e Event) {
+		mu.Lock()
+		got = append(got, e.(otherEvent).Code)
+		mu.Unlock()
+	}, AsyncOptions{QueueSize: 100, Policy: OverflowBlock})
+	defer unsubscribe()
+
+	for i := 0; i < 100; i++ {
+		bus.Publish(otherEvent{Code: i})
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == 100
+	}, time.Second, 5*time.Millisecond)
+	for i, v := range got {
+		assert.Equal(t, i, v)
+	}
+}
+
+func TestSubscribeAsync_DoesNotBlockPublisher(t *testing.T) {
+	t.Parallel()
+
+	bus := New()
+
+	release := make(chan struct{})
+	unsubscribe := bus.SubscribeAsync("test.event", func(_ Event) { <-release }, AsyncOptions{})
+	defer unsubscribe()
+	defer close(release)
+
+	var syncCalls int
+	bus.Subscribe("test.event", func(_ Event) { syncCalls++ })
+
+	bus.Publish(testEvent{})
+	assert.Equal(t, 1, syncCalls, "a slow async handler must not hold up sync handlers")
+}
+
+func TestSubscribeAsync_DropPolicy(t *testing.T) {
+	t.Parallel()
+
+	bus := New()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var handled, dropped atomic.Int32
+	unsubscribe := bus.SubscribeAsync("test.event", func(_ Event) {
+		if handled.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+	}, AsyncOptions{
+		QueueSize: 2,
+		Policy:    OverflowDrop,
+		OnDrop:    func(Event) { dropped.Add(1) },
+	})
+	defer unsubscribe()
+
+	bus.Publish(testEvent{}) // picked up by the handler, which then blocks
+	<-started
+	for i := 0; i < 5; i++ {
+		bus.Publish(testEvent{}) // two fit in the queue, three are dropped
+	}
+	assert.Equal(t, int32(3), dropped.Load())
+
+	close(release)
+	require.Eventually(t, func() bool { return handled.Load() == 3 }, time.Second, 5*time.Millisecond)
+}
+
+func TestSubscribeAsync_BlockPolicyAppliesBackpressure(t *testing.T) {
+	t.Parallel()
+
+	bus := New()
+
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	var handled atomic.Int32
+	unsubscribe := bus.SubscribeAsync("test.event", func(_ Event) {
+		started <- struct{}{}
+		<-release
+		handled.Add(1)
+	}, AsyncOptions{QueueSize: 1, Policy: OverflowBlock})
+	defer unsubscribe()
+
+	bus.Publish(testEvent{}) // taken by the handler
+	<-started
+	bus.Publish(testEvent{}) // fills the queue
+
+	// The queue is full; the next publish waits for the handler.
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(testEvent{})
+		close(published)
+	}()
+	select {
+	case <-published:
+		t.Fatal("publish should block while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("publish did not resume after the handler drained the queue")
+	}
+	require.Eventually(t, func() bool { return handled.Load() == 3 }, time.Second, 5*time.Millisecond)
+}
+
+func TestSubscribeAsync_UnsubscribeReleasesBlockedPublisher(t *testing.T) {
+	t.Parallel()
+
+	bus := New()
+
+	started := make(chan struct{})
+	block := make(chan struct{})
+	defer close(block)
+	unsubscribe := bus.SubscribeAsync("test.event", func(_ Event) {
+		select {
+		case <-started:
+		default:
+			close(started)
+		}
+		<-block
+	}, AsyncOptions{QueueSize: 1, Policy: OverflowBlock})
+
+	bus.Publish(testEvent{})
+	<-started
+	bus.Publish(testEvent{}) // fills the queue
+
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(testEvent{})
+		close(published)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	unsubscribe()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("unsubscribe did not release the blocked publisher")
+	}
+}
@@ -3,10 +3,17 @@
 //
 // Events are dispatched synchronously in registration order. The bus is
 // safe for concurrent use; Subscribe takes a write lock, Publish takes a
-// read lock.
+// read lock. SubscribeAsync handlers run on their own goroutine behind a
+// bounded queue. An optional Journal records every published event so it
+// can be replayed after a restart.
 package eventbus
 
-import "sync"
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"sync"
+)
 
 // Event is implemented by all event types.
 type Event interface {
@@ -19,45 +26,135 @@ type HandlerFunc func(event Event)
 // Bus is a synchronous typed event bus.
 type Bus struct {
 	mu       sync.RWMutex
-	handlers map[string][]HandlerFunc
+	handlers map[string][]*subscription
+	nextID   uint64
+
+	journalMu sync.RWMutex
+	journal   *Journal
+	onJournal func(error) // called when an event cannot be journaled
+}
+
+// subscription is one registered handler. The ID lets unsubscribe find it
+// again without comparing funcs.
+type subscription struct {
+	id      uint64
+	handler HandlerFunc
 }
 
 // New creates a new event bus.
 func New() *Bus {
 	return &Bus{
-		handlers: make(map[string][]HandlerFunc),
+		handlers: make(map[string][]*subscription),
 	}
 }
 
-// Subscribe registers a handler for a specific event name.
-func (b *Bus) Subscribe(eventName string, handler HandlerFunc) {
+// Subscribe registers a handler for a specific event name. The returned
+// func unsubscribes the handler; it is safe to call more than once and from
+// within a handler. A Publish already in progress may still deliver to it.
+func (b *Bus) Subscribe(eventName string, handler HandlerFunc) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.handlers[eventName] = append(b.handlers[eventName], &subscription{id: id, handler: handler})
+
+	return func() { b.unsubscribe(eventName, id) }
+}
+
+func (b *Bus) unsubscribe(eventName string, id uint64) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	b.handlers[eventName] = append(b.handlers[eventName], handler)
+	subs := b.handlers[eventName]
+	for i, s := range subs {
+		if s.id != id {
+			continue
+		}
+		// Copy rather than splice in place: Publish may hold the old slice.
+		next := make([]*subscription, 0, len(subs)-1)
+		next = append(next, subs[:i]...)
+		next = append(next, subs[i+1:]...)
+		if len(next) == 0 {
+			delete(b.handlers, eventName)
+		} else {
+			b.handlers[eventName] = next
+		}
+		return
+	}
 }
 
-// Publish sends an event to all registered handlers synchronously.
-// If no handlers are registered for the event, it is silently ignored.
+// Publish journals the event, if a journal is set, then sends it to all
+// registered handlers synchronously. If no handlers are registered for the
+// event, it is silently ignored.
 func (b *Bus) Publish(event Event) {
+	b.record(event)
+
 	b.mu.RLock()
-	// Copy the handler slice under the read lock so that a handler calling
-	// Subscribe does not deadlock or observe a partially-mutated slice.
-	hs := make([]HandlerFunc, len(b.handlers[event.EventName()]))
-	copy(hs, b.handlers[event.EventName()])
+	// Take the handler slice under the read lock; Subscribe and unsubscribe
+	// replace it rather than mutate it, so a handler calling either does not
+	// deadlock or observe a partially-mutated slice.
+	hs := b.handlers[event.EventName()]
 	b.mu.RUnlock()
 
-	for _, h := range hs {
-		h(event)
+	for _, s := range hs {
+		s.handler(event)
+	}
+}
+
+// SetJournal makes the bus append every published event to j. onError, if
+// non-nil, is called when an event cannot be encoded or written; publishing
+// itself never fails. Pass a nil journal to stop journaling.
+func (b *Bus) SetJournal(j *Journal, onError func(error)) {
+	b.journalMu.Lock()
+	defer b.journalMu.Unlock()
+	b.journal = j
+	b.onJournal = onError
+}
+
+// Journal returns the journal set with SetJournal, or nil.
+func (b *Bus) Journal() *Journal {
+	b.journalMu.RLock()
+	defer b.journalMu.RUnlock()
+	return b.journal
+}
+
+func (b *Bus) record(event Event) {
+	b.journalMu.RLock()
+	j, onError := b.journal, b.onJournal
+	b.journalMu.RUnlock()
+	if j == nil {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err == nil {
+		_, err = j.Append(event.EventName(), payload)
+	}
+	if err != nil && onError != nil {
+		onError(fmt.Errorf("journal %s: %w", event.EventName(), err))
+	}
+}
+
+// Replay yields journaled events with sequence numbers >= fromSeq, in
+// order, optionally limited to the given event names. It yields
+// ErrNoJournal if no journal is set.
+func (b *Bus) Replay(fromSeq uint64, types ...string) iter.Seq2[Record, error] {
+	j := b.Journal()
+	if j == nil {
+		return func(yield func(Record, error) bool) {
+			yield(Record{}, ErrNoJournal)
+		}
 	}
+	return j.Replay(fromSeq, types...)
 }
 
 // SubscribeTyped is a generic helper that provides type-safe subscription.
 // It registers a handler that automatically type-asserts the event before
-// calling the typed handler function.
-func SubscribeTyped[T Event](bus *Bus, handler func(T)) {
+// calling the typed handler function. The returned func unsubscribes it.
+func SubscribeTyped[T Event](bus *Bus, handler func(T)) func() {
 	var zero T
-	bus.Subscribe(zero.EventName(), func(event Event) {
+	return bus.Subscribe(zero.EventName(), func(event Event) {
 		if typed, ok := event.(T); ok {
 			handler(typed)
 		}
@@ -250,3 +250,56 @@ func TestMemoryGraphEventRoundTrip(t *testing.T) {
 	assert.Equal(t, "sess-42", got.SessionKey)
 	assert.Equal(t, "observation", got.Type)
 }
+
+func TestUnsubscribe(t *testing.T) {
+	t.Parallel()
+
+	bus := New()
+
+	var first, second int
+	unsubscribe := bus.Subscribe("test.event", func(_ Event) { first++ })
+	bus.Subscribe("test.event", func(_ Event) { second++ })
+
+	bus.Publish(testEvent{})
+	unsubscribe()
+	unsubscribe() // idempotent
+	bus.Publish(testEvent{})
+
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 2, second)
+}
+
+func TestUnsubscribeFromWithinHandler(t *testing.T) {
+	t.Parallel()
+
+	bus := New()
+
+	var calls, after int
+	var unsubscribe func()
+	unsubscribe = bus.Subscribe("test.event", func(_ Event) {
+		calls++
+		unsubscribe()
+	})
+	bus.Subscribe("test.event", func(_ Event) { after++ })
+
+	bus.Publish(testEvent{})
+	bus.Publish(testEvent{})
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 2, after, "later handlers still run in the publish that unsubscribed")
+}
+
+func TestSubscribeTypedUnsubscribe(t *testing.T) {
+	t.Parallel()
+
+	bus := New()
+
+	var got []string
+	unsubscribe := SubscribeTyped(bus, func(e testEvent) { got = append(got, e.Value) })
+
+	bus.Publish(testEvent{Value: "a"})
+	unsubscribe()
+	bus.Publish(testEvent{Value: "b"})
+
+	assert.Equal(t, []string{"a"}, got)
+}
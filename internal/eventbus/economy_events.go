@@ -23,6 +23,8 @@ const (
 	EventEscrowOnChainResolved = "escrow.onchain.resolved"
 	EventEscrowReorgDetected   = "escrow.reorg.detected"
 	EventEscrowDangling        = "escrow.dangling"
+	EventEscrowArbiterAssigned = "escrow.arbitration.assigned"
+	EventEscrowRuling          = "escrow.arbitration.ruling"
 )
 
 // BudgetAlertEvent is published when a task budget crosses a configured threshold.
@@ -201,3 +203,30 @@ type EscrowDanglingEvent struct {
 
 // EventName implements Event.
 func (e EscrowDanglingEvent) EventName() string { return EventEscrowDangling }
+
+// EscrowArbiterAssignedEvent is published when both parties of a disputed
+// escrow agree on an arbiter.
+type EscrowArbiterAssignedEvent struct {
+	EscrowID    string
+	ArbiterDID  string
+	NominatedBy string
+	BuyerDID    string
+	SellerDID   string
+}
+
+// EventName implements Event.
+func (e EscrowArbiterAssignedEvent) EventName() string { return EventEscrowArbiterAssigned }
+
+// EscrowRulingEvent is published when a signed arbitration ruling is issued
+// or received.
+type EscrowRulingEvent struct {
+	EscrowID      string
+	ArbiterDID    string
+	BuyerDID      string
+	SellerDID     string
+	NominatedBy   string
+	SellerPercent int
+}
+
+// EventName implements Event.
+func (e EscrowRulingEvent) EventName() string { return EventEscrowRuling }
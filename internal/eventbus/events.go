@@ -23,6 +23,7 @@ const (
 	EventChannelMessageReceived  = "channel.message.received"
 	EventChannelMessageSent      = "channel.message.sent"
 	EventSandboxDecision         = "sandbox.decision"
+	EventSecretAccess            = "secret.access"
 )
 
 // ContentSavedEvent is published when knowledge or memory content is saved.
@@ -212,7 +213,7 @@ func (e PolicyDecisionEvent) EventName() string { return EventPolicyDecision }
 
 // AlertEvent is published when an operational alert condition is detected.
 type AlertEvent struct {
-	Type       string                 // "policy_block_rate", "recovery_retries", "circuit_breaker"; "config_drift" is planned
+	Type       string                 // "policy_block_rate", "recovery_retries", "circuit_breaker", "secret_expiry"; "config_drift" is planned
 	Severity   string                 // "warning", "critical"
 	Message    string
 	Details    map[string]interface{}
@@ -228,7 +229,7 @@ func (e AlertEvent) EventName() string { return EventAlertTriggered }
 // ChannelMessageReceivedEvent is published when an inbound message arrives
 // from a channel platform (Telegram, Discord, Slack, etc.).
 type ChannelMessageReceivedEvent struct {
-	Channel    string            // "telegram", "discord", "slack"
+	Channel    string            // "telegram", "discord", "slack", "email"
 	SessionKey string            // e.g., "telegram:123:456"
 	SenderName string            // username or display name
 	SenderID   string            // platform user ID
@@ -243,7 +244,7 @@ func (e ChannelMessageReceivedEvent) EventName() string { return EventChannelMes
 // ChannelMessageSentEvent is published when an outbound response is sent
 // to a channel platform.
 type ChannelMessageSentEvent struct {
-	Channel      string // "telegram", "discord", "slack"
+	Channel      string // "telegram", "discord", "slack", "email"
 	SessionKey   string
 	ResponseText string
 	Timestamp    time.Time
@@ -284,3 +285,23 @@ func PublishSandboxDecision(bus *Bus, evt SandboxDecisionEvent) {
 	}
 	bus.Publish(evt)
 }
+
+// --- Secret access events ---
+
+// SecretAccessEvent is published for every attempt to resolve a stored
+// secret through the access policy, whether it was granted or denied.
+// The secret value is never carried on the event.
+type SecretAccessEvent struct {
+	Name       string
+	Version    int
+	SessionKey string
+	Agent      string
+	Tool       string
+	MCPServer  string
+	Granted    bool
+	Reason     string // populated for denied attempts
+	Timestamp  time.Time
+}
+
+// EventName implements Event.
+func (e SecretAccessEvent) EventName() string { return EventSecretAccess }
@@ -0,0 +1,357 @@
+package eventbus
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNoJournal is returned by Bus.Replay when no journal is set.
+	ErrNoJournal = errors.New("event journal not configured")
+	// ErrJournalClosed is returned by Append after Close.
+	ErrJournalClosed = errors.New("event journal closed")
+)
+
+// DefaultSegmentSize is the segment size used when JournalOptions.SegmentSize
+// is not set.
+const DefaultSegmentSize = 16 << 20
+
+// segmentExt is the file extension of journal segments. Each segment is
+// named after the sequence number of its first record.
+const segmentExt = ".jsonl"
+
+// Record is one journaled event.
+type Record struct {
+	Seq     uint64          `json:"seq"`
+	Type    string          `json:"type"`
+	Time    time.Time       `json:"time"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// JournalOptions configures OpenJournal.
+type JournalOptions struct {
+	// SegmentSize is the size in bytes after which a new segment file is
+	// started (default: DefaultSegmentSize).
+	SegmentSize int64
+
+	// MaxSegments caps the number of segment files kept; the oldest are
+	// deleted on rotation. Zero keeps every segment.
+	MaxSegments int
+
+	// Sync fsyncs each append. Slower, but no acknowledged event is lost
+	// if the machine crashes.
+	Sync bool
+}
+
+// Journal is an append-only, segmented log of published events. Records
+// are JSON lines with a monotonically increasing sequence number that
+// continues across restarts. A journal directory must have a single writer;
+// any number of readers may use ReadJournal concurrently.
+type Journal struct {
+	dir  string
+	opts JournalOptions
+
+	mu      sync.Mutex
+	f       *os.File // current segment, nil until the first append
+	size    int64
+	nextSeq uint64
+	closed  bool
+}
+
+// OpenJournal opens or creates the journal in dir. A record left half
+// written by a crash is truncated away.
+func OpenJournal(dir string, opts JournalOptions) (*Journal, error) {
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = DefaultSegmentSize
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create journal dir: %w", err)
+	}
+
+	j := &Journal{dir: dir, opts: opts, nextSeq: 1}
+	segs, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(segs) == 0 {
+		return j, nil
+	}
+
+	last := segs[len(segs)-1]
+	lastSeq, validLen, err := scanSegment(last.path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(last.path, os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open journal segment: %w", err)
+	}
+	if err := f.Truncate(validLen); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("truncate journal segment: %w", err)
+	}
+	if _, err := f.Seek(validLen, io.SeekStart); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("seek journal segment: %w", err)
+	}
+
+	j.f, j.size = f, validLen
+	if lastSeq > 0 {
+		j.nextSeq = lastSeq + 1
+	} else {
+		j.nextSeq = last.first
+	}
+	return j, nil
+}
+
+// Dir returns the journal directory.
+func (j *Journal) Dir() string { return j.dir }
+
+// LastSeq returns the sequence number of the newest record, or 0 if the
+// journal is empty.
+func (j *Journal) LastSeq() uint64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.nextSeq - 1
+}
+
+// Append writes a record with the next sequence number and returns it.
+// payload must be valid JSON.
+func (j *Journal) Append(eventType string, payload []byte) (uint64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.closed {
+		return 0, ErrJournalClosed
+	}
+	line, err := json.Marshal(Record{
+		Seq:     j.nextSeq,
+		Type:    eventType,
+		Time:    time.Now().UTC(),
+		Payload: payload,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("encode record: %w", err)
+	}
+	line = append(line, '\n')
+
+	if j.f == nil || j.size >= j.opts.SegmentSize {
+		if err := j.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := j.f.Write(line)
+	j.size += int64(n)
+	if err != nil {
+		return 0, fmt.Errorf("write record: %w", err)
+	}
+	if j.opts.Sync {
+		if err := j.f.Sync(); err != nil {
+			return 0, fmt.Errorf("sync journal: %w", err)
+		}
+	}
+
+	seq := j.nextSeq
+	j.nextSeq++
+	return seq, nil
+}
+
+// rotate starts a new segment at nextSeq and prunes old ones. Caller holds mu.
+func (j *Journal) rotate() error {
+	if j.f != nil {
+		if err := j.f.Close(); err != nil {
+			return fmt.Errorf("close journal segment: %w", err)
+		}
+		j.f = nil
+	}
+
+	path := filepath.Join(j.dir, segmentName(j.nextSeq))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("create journal segment: %w", err)
+	}
+	j.f, j.size = f, 0
+
+	if j.opts.MaxSegments <= 0 {
+		return nil
+	}
+	segs, err := listSegments(j.dir)
+	if err != nil {
+		return err
+	}
+	for len(segs) > j.opts.MaxSegments {
+		if err := os.Remove(segs[0].path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("prune journal segment: %w", err)
+		}
+		segs = segs[1:]
+	}
+	return nil
+}
+
+// Replay yields records with sequence numbers >= fromSeq, in order,
+// optionally limited to the given event types.
+func (j *Journal) Replay(fromSeq uint64, types ...string) iter.Seq2[Record, error] {
+	return ReadJournal(j.dir, fromSeq, types...)
+}
+
+// Close closes the current segment. Further appends fail.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.closed {
+		return nil
+	}
+	j.closed = true
+	if j.f == nil {
+		return nil
+	}
+	return j.f.Close()
+}
+
+// ReadJournal yields the records in a journal directory with sequence
+// numbers >= fromSeq, in order, optionally limited to the given event types.
+// It does not need the journal to be open, so it can read a journal that
+// another process is writing; a record still being written is not yielded.
+func ReadJournal(dir string, fromSeq uint64, types ...string) iter.Seq2[Record, error] {
+	want := make(map[string]bool, len(types))
+	for _, t := range types {
+		want[t] = true
+	}
+
+	return func(yield func(Record, error) bool) {
+		segs, err := listSegments(dir)
+		if err != nil {
+			yield(Record{}, err)
+			return
+		}
+
+		// Skip segments that end before fromSeq.
+		start := 0
+		for i, s := range segs {
+			if s.first <= fromSeq {
+				start = i
+			}
+		}
+
+		for _, s := range segs[start:] {
+			ok, err := readSegment(s.path, func(rec Record) bool {
+				if rec.Seq < fromSeq || (len(want) > 0 && !want[rec.Type]) {
+					return true
+				}
+				return yield(rec, nil)
+			})
+			if err != nil {
+				yield(Record{}, err)
+				return
+			}
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// readSegment calls fn for each complete record in a segment until fn
+// returns false. It reports whether reading ran to the end.
+func readSegment(path string, fn func(Record) bool) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true, nil // pruned while reading
+		}
+		return false, fmt.Errorf("open journal segment: %w", err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			return true, nil // a trailing partial line is still being written
+		}
+		if err != nil {
+			return false, fmt.Errorf("read journal segment: %w", err)
+		}
+		var rec Record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return false, fmt.Errorf("decode record in %s: %w", filepath.Base(path), err)
+		}
+		if !fn(rec) {
+			return false, nil
+		}
+	}
+}
+
+// scanSegment returns the last sequence number in a segment and the length
+// of its valid prefix, which excludes a trailing partial or corrupt record.
+func scanSegment(path string) (uint64, int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("read journal segment: %w", err)
+	}
+
+	var lastSeq uint64
+	var valid int64
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		var rec Record
+		if err := json.Unmarshal(data[:i], &rec); err != nil {
+			break
+		}
+		lastSeq = rec.Seq
+		valid += int64(i + 1)
+		data = data[i+1:]
+	}
+	return lastSeq, valid, nil
+}
+
+type segment struct {
+	first uint64
+	path  string
+}
+
+func segmentName(first uint64) string {
+	return fmt.Sprintf("%020d%s", first, segmentExt)
+}
+
+// listSegments returns the segments in dir ordered by first sequence number.
+func listSegments(dir string) ([]segment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("list journal dir: %w", err)
+	}
+
+	var segs []segment
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		first, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		segs = append(segs, segment{first: first, path: filepath.Join(dir, name)})
+	}
+	sort.Slice(segs, func(i, k int) bool { return segs[i].first < segs[k].first })
+	return segs, nil
+}
@@ -0,0 +1,257 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collect(t *testing.T, j *Journal, from uint64, types ...string) []Record {
+	t.Helper()
+	var out []Record
+	for rec, err := range j.Replay(from, types...) {
+		require.NoError(t, err)
+		out = append(out, rec)
+	}
+	return out
+}
+
+func seqs(recs []Record) []uint64 {
+	out := make([]uint64, len(recs))
+	for i, r := range recs {
+		out[i] = r.Seq
+	}
+	return out
+}
+
+func TestJournal_AppendAndReplay(t *testing.T) {
+	t.Parallel()
+
+	j, err := OpenJournal(t.TempDir(), JournalOptions{})
+	require.NoError(t, err)
+	defer j.Close()
+
+	assert.Equal(t, uint64(0), j.LastSeq())
+	for i, typ := range []string{"a", "b", "a", "c"} {
+		seq, err := j.Append(typ, []byte(fmt.Sprintf(`{"i":%d}`, i)))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+1), seq)
+	}
+	assert.Equal(t, uint64(4), j.LastSeq())
+
+	all := collect(t, j, 0)
+	assert.Equal(t, []uint64{1, 2, 3, 4}, seqs(all))
+	assert.Equal(t, "b", all[1].Type)
+	assert.JSONEq(t, `{"i":1}`, string(all[1].Payload))
+	assert.False(t, all[0].Time.IsZero())
+
+	assert.Equal(t, []uint64{3, 4}, seqs(collect(t, j, 3)))
+	assert.Equal(t, []uint64{1, 3}, seqs(collect(t, j, 0, "a")))
+	assert.Equal(t, []uint64{3, 4}, seqs(collect(t, j, 2, "a", "c")))
+	assert.Empty(t, collect(t, j, 5))
+}
+
+func TestJournal_RejectsInvalidPayload(t *testing.T) {
+	t.Parallel()
+
+	j, err := OpenJournal(t.TempDir(), JournalOptions{})
+	require.NoError(t, err)
+	defer j.Close()
+
+	_, err = j.Append("a", []byte(`{not json`))
+	require.Error(t, err)
+	assert.Equal(t, uint64(0), j.LastSeq())
+}
+
+func TestJournal_ReplayAfterReopen(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	j, err := OpenJournal(dir, JournalOptions{})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err := j.Append("a", []byte(`{}`))
+		require.NoError(t, err)
+	}
+	require.NoError(t, j.Close())
+
+	_, err = j.Append("a", []byte(`{}`))
+	assert.ErrorIs(t, err, ErrJournalClosed)
+
+	j, err = OpenJournal(dir, JournalOptions{})
+	require.NoError(t, err)
+	defer j.Close()
+
+	assert.Equal(t, uint64(3), j.LastSeq())
+	seq, err := j.Append("b", []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), seq, "sequence continues across reopen")
+	assert.Equal(t, []uint64{1, 2, 3, 4}, seqs(collect(t, j, 0)))
+}
+
+func TestJournal_TruncatesPartialRecordOnReopen(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	j, err := OpenJournal(dir, JournalOptions{})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err := j.Append("a", []byte(`{}`))
+		require.NoError(t, err)
+	}
+	require.NoError(t, j.Close())
+
+	// Simulate a crash halfway through writing the third record.
+	path := filepath.Join(dir, segmentName(1))
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"seq":3,"type":"a","ti`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// Readers skip the partial record.
+	var n int
+	for _, err := range ReadJournal(dir, 0) {
+		require.NoError(t, err)
+		n++
+	}
+	assert.Equal(t, 2, n)
+
+	j, err = OpenJournal(dir, JournalOptions{})
+	require.NoError(t, err)
+	defer j.Close()
+
+	seq, err := j.Append("b", []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), seq)
+	recs := collect(t, j, 0)
+	assert.Equal(t, []uint64{1, 2, 3}, seqs(recs))
+	assert.Equal(t, "b", recs[2].Type)
+}
+
+func TestJournal_SegmentRotationAndPruning(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	j, err := OpenJournal(dir, JournalOptions{SegmentSize: 200, MaxSegments: 3})
+	require.NoError(t, err)
+	defer j.Close()
+
+	for i := 0; i < 30; i++ {
+		_, err := j.Append("a", []byte(`{"padding":"xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"}`))
+		require.NoError(t, err)
+	}
+
+	segs, err := listSegments(dir)
+	require.NoError(t, err)
+	assert.Len(t, segs, 3)
+
+	// Only the retained segments replay, still contiguous and in order.
+	recs := collect(t, j, 0)
+	require.NotEmpty(t, recs)
+	assert.Equal(t, segs[0].first, recs[0].Seq)
+	assert.Equal(t, uint64(30), recs[len(recs)-1].Seq)
+	for i := 1; i < len(recs); i++ {
+		assert.Equal(t, recs[i-1].Seq+1, recs[i].Seq)
+	}
+
+	// Replaying from the middle starts in the right segment.
+	assert.Equal(t, []uint64{29, 30}, seqs(collect(t, j, 29)))
+
+	// Reopening picks up the newest segment.
+	require.NoError(t, j.Close())
+	j, err = OpenJournal(dir, JournalOptions{SegmentSize: 200, MaxSegments: 3})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(30), j.LastSeq())
+}
+
+func TestJournal_ConcurrentAppendsAreOrdered(t *testing.T) {
+	t.Parallel()
+
+	j, err := OpenJournal(t.TempDir(), JournalOptions{SegmentSize: 1024})
+	require.NoError(t, err)
+	defer j.Close()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				_, err := j.Append("a", []byte(`{}`))
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	recs := collect(t, j, 0)
+	require.Len(t, recs, 400)
+	for i, r := range recs {
+		assert.Equal(t, uint64(i+1), r.Seq)
+	}
+}
+
+func TestBus_JournalsPublishedEvents(t *testing.T) {
+	t.Parallel()
+
+	bus := New()
+	j, err := OpenJournal(t.TempDir(), JournalOptions{})
+	require.NoError(t, err)
+	defer j.Close()
+
+	var journalErrs []error
+	bus.SetJournal(j, func(err error) { journalErrs = append(journalErrs, err) })
+	assert.Same(t, j, bus.Journal())
+
+	// Events are journaled even without subscribers.
+	bus.Publish(testEvent{Value: "one"})
+	bus.Publish(otherEvent{Code: 7})
+	bus.Publish(testEvent{Value: "two"})
+	bus.Publish(unencodableEvent{Fn: func() {}})
+
+	var got []Record
+	for rec, err := range bus.Replay(0) {
+		require.NoError(t, err)
+		got = append(got, rec)
+	}
+	require.Len(t, got, 3)
+	assert.Equal(t, "test.event", got[0].Type)
+	var ev testEvent
+	require.NoError(t, json.Unmarshal(got[0].Payload, &ev))
+	assert.Equal(t, "one", ev.Value)
+	assert.Equal(t, "other.event", got[1].Type)
+
+	var typed []uint64
+	for rec, err := range bus.Replay(2, "test.event") {
+		require.NoError(t, err)
+		typed = append(typed, rec.Seq)
+	}
+	assert.Equal(t, []uint64{3}, typed)
+
+	require.Len(t, journalErrs, 1)
+	assert.Contains(t, journalErrs[0].Error(), "unencodable.event")
+}
+
+func TestBus_ReplayWithoutJournal(t *testing.T) {
+	t.Parallel()
+
+	var errs []error
+	for _, err := range New().Replay(0) {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrNoJournal)
+}
+
+type unencodableEvent struct {
+	Fn func()
+}
+
+func (unencodableEvent) EventName() string { return "unencodable.event" }
@@ -11,6 +11,7 @@ const (
 	EventWorkspaceMessagePosted   = "workspace.message.posted"
 	EventWorkspaceArchived        = "workspace.archived"
 	EventWorkspaceGitDivergence   = "workspace.git.divergence"
+	EventWorkspaceMessageRejected = "workspace.message.rejected"
 )
 
 // WorkspaceCreatedEvent is published when a new workspace is created.
@@ -94,3 +95,19 @@ type GitDivergenceInfo struct {
 
 // EventName implements Event.
 func (e WorkspaceGitDivergenceEvent) EventName() string { return EventWorkspaceGitDivergence }
+
+// WorkspaceMessageRejectedEvent is published when a workspace message is
+// dropped because it could not be decrypted, its signature did not verify,
+// or its sender is not a workspace member.
+type WorkspaceMessageRejectedEvent struct {
+	WorkspaceID string
+	MessageID   string
+	SenderDID   string
+	PeerID      string
+	Stage       string // "gossip", "post", or "read"
+	Reason      string
+	RejectedAt  time.Time
+}
+
+// EventName implements Event.
+func (e WorkspaceMessageRejectedEvent) EventName() string { return EventWorkspaceMessageRejected }
@@ -0,0 +1,398 @@
+package gateway
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/langoai/lango/internal/approval"
+	"github.com/langoai/lango/internal/background"
+	"github.com/langoai/lango/internal/observability"
+)
+
+// Remote cockpit roles. Viewers receive the session's event stream;
+// operators may also chat, answer approvals and manage background tasks.
+const (
+	RoleViewer   = "viewer"
+	RoleOperator = "operator"
+)
+
+const (
+	// DefaultCockpitSession is the session a remote cockpit attaches to when
+	// none is requested.
+	DefaultCockpitSession = "cockpit"
+
+	cockpitMetricsInterval = 5 * time.Second
+)
+
+// cockpitMethodRoles lists the RPC methods a remote cockpit may call and
+// the minimum role each requires. Unlisted methods are rejected.
+var cockpitMethodRoles = map[string]string{
+	"cockpit.hello":        RoleViewer,
+	"cockpit.tasks.list":   RoleViewer,
+	"chat.message":         RoleOperator,
+	"approval.response":    RoleOperator,
+	"cockpit.tasks.cancel": RoleOperator,
+	"cockpit.tasks.retry":  RoleOperator,
+	"session.rewind":       RoleOperator,
+}
+
+// TaskManager is the background task surface exposed to remote cockpits.
+// *background.Manager satisfies it.
+type TaskManager interface {
+	List() []background.TaskSnapshot
+	Status(id string) (*background.TaskSnapshot, error)
+	Cancel(id string) error
+	Submit(ctx context.Context, prompt string, origin background.Origin) (string, error)
+}
+
+// MetricsSource provides the system metrics streamed to remote cockpits.
+// *observability.MetricsCollector satisfies it.
+type MetricsSource interface {
+	Snapshot() observability.SystemSnapshot
+}
+
+// CockpitOperator describes an attached remote cockpit.
+type CockpitOperator struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// SetTaskManager enables background task management for remote cockpits.
+func (s *Server) SetTaskManager(tm TaskManager) {
+	s.taskManager = tm
+}
+
+// SetMetricsSource enables periodic metrics events for remote cockpits.
+func (s *Server) SetMetricsSource(src MetricsSource) {
+	s.metrics = src
+}
+
+// registerCockpitHandlers registers the remote cockpit RPC methods.
+func (s *Server) registerCockpitHandlers() {
+	s.RegisterHandler("cockpit.hello", s.handleCockpitHello)
+	s.RegisterHandler("cockpit.tasks.list", s.handleCockpitTasksList)
+	s.RegisterHandler("cockpit.tasks.cancel", s.handleCockpitTasksCancel)
+	s.RegisterHandler("cockpit.tasks.retry", s.handleCockpitTasksRetry)
+}
+
+// authenticateCockpit matches the request's bearer token against the
+// configured cockpit tokens and returns the operator it belongs to.
+func (s *Server) authenticateCockpit(r *http.Request) (CockpitOperator, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return CockpitOperator{}, false
+	}
+
+	for i, t := range s.config.Cockpit.Tokens {
+		if t.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(t.Token)) != 1 {
+			continue
+		}
+		op := CockpitOperator{Name: t.Name, Role: t.Role}
+		if op.Name == "" {
+			op.Name = fmt.Sprintf("token-%d", i+1)
+		}
+		if op.Role != RoleOperator {
+			op.Role = RoleViewer
+		}
+		return op, true
+	}
+	return CockpitOperator{}, false
+}
+
+// handleCockpitWebSocket attaches a remote cockpit to a session. The
+// session is chosen with the "session" query parameter.
+func (s *Server) handleCockpitWebSocket(w http.ResponseWriter, r *http.Request) {
+	op, ok := s.authenticateCockpit(r)
+	if !ok {
+		http.Error(w, `{"error":"invalid cockpit token"}`, http.StatusUnauthorized)
+		return
+	}
+
+	sessionKey := r.URL.Query().Get("session")
+	if sessionKey == "" {
+		sessionKey = DefaultCockpitSession
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger().Errorw("websocket upgrade failed", "error", err)
+		return
+	}
+
+	client := &Client{
+		ID:         fmt.Sprintf("cockpit-%d", time.Now().UnixNano()),
+		Type:       "cockpit",
+		Conn:       conn,
+		Server:     s,
+		Send:       make(chan []byte, 256),
+		SessionKey: sessionKey,
+		Name:       op.Name,
+		Role:       op.Role,
+	}
+
+	logger().Infow("cockpit attached", "clientId", client.ID, "operator", op.Name, "role", op.Role, "session", sessionKey)
+	s.attachClient(client)
+	s.broadcastPresence(sessionKey)
+	s.metricsOnce.Do(func() { go s.metricsLoop() })
+}
+
+// mayCall reports whether the client's role permits calling method.
+// Only remote cockpits are restricted.
+func (c *Client) mayCall(method string) bool {
+	if c.Type != "cockpit" {
+		return true
+	}
+	need, ok := cockpitMethodRoles[method]
+	if !ok {
+		return false
+	}
+	return need == RoleViewer || c.Role == RoleOperator
+}
+
+// broadcastToCockpits sends an event to the cockpits attached to sessionKey.
+// When operatorsOnly is set, viewers are skipped.
+func (s *Server) broadcastToCockpits(sessionKey, event string, payload interface{}, operatorsOnly bool) {
+	msg, _ := json.Marshal(map[string]interface{}{
+		"type":    "event",
+		"event":   event,
+		"payload": payload,
+	})
+
+	s.clientsMu.RLock()
+	defer s.clientsMu.RUnlock()
+
+	for _, client := range s.clients {
+		if client.Type != "cockpit" {
+			continue
+		}
+		if sessionKey != "" && client.SessionKey != sessionKey {
+			continue
+		}
+		if operatorsOnly && client.Role != RoleOperator {
+			continue
+		}
+		select {
+		case client.Send <- msg:
+		default:
+			// Client buffer full, skip
+		}
+	}
+}
+
+// cockpitOperators lists the cockpits attached to sessionKey, sorted by name.
+func (s *Server) cockpitOperators(sessionKey string) []CockpitOperator {
+	s.clientsMu.RLock()
+	defer s.clientsMu.RUnlock()
+
+	ops := make([]CockpitOperator, 0)
+	for _, c := range s.clients {
+		if c.Type == "cockpit" && c.SessionKey == sessionKey {
+			ops = append(ops, CockpitOperator{Name: c.Name, Role: c.Role})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].Name < ops[j].Name })
+	return ops
+}
+
+func (s *Server) broadcastPresence(sessionKey string) {
+	s.broadcastToCockpits(sessionKey, "cockpit.presence", map[string]interface{}{
+		"sessionKey": sessionKey,
+		"operators":  s.cockpitOperators(sessionKey),
+	}, false)
+}
+
+// metricsLoop pushes a metrics snapshot to every attached cockpit until the
+// server shuts down.
+func (s *Server) metricsLoop() {
+	ticker := time.NewTicker(cockpitMetricsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.shutdownCtx.Done():
+			return
+		case <-ticker.C:
+			if s.metrics == nil {
+				continue
+			}
+			s.broadcastToCockpits("", "cockpit.metrics", s.metrics.Snapshot(), false)
+		}
+	}
+}
+
+// handleCockpitHello returns the caller's identity, the operators attached
+// to its session and, when available, a first metrics snapshot.
+func (s *Server) handleCockpitHello(client *Client, _ json.RawMessage) (interface{}, error) {
+	resp := map[string]interface{}{
+		"clientId":   client.ID,
+		"name":       client.Name,
+		"role":       client.Role,
+		"sessionKey": client.SessionKey,
+		"operators":  s.cockpitOperators(client.SessionKey),
+		"tasks":      s.taskManager != nil,
+	}
+	if s.metrics != nil {
+		resp["metrics"] = s.metrics.Snapshot()
+	}
+	return resp, nil
+}
+
+func (s *Server) handleCockpitTasksList(client *Client, _ json.RawMessage) (interface{}, error) {
+	if s.taskManager == nil {
+		return nil, ErrTasksUnavailable
+	}
+	tasks := s.taskManager.List()
+	if client == nil || client.Type != "cockpit" {
+		return tasks, nil
+	}
+	scoped := make([]background.TaskSnapshot, 0, len(tasks))
+	for _, t := range tasks {
+		if t.OriginSession == client.SessionKey {
+			scoped = append(scoped, t)
+		}
+	}
+	return scoped, nil
+}
+
+func (s *Server) handleCockpitTasksCancel(client *Client, params json.RawMessage) (interface{}, error) {
+	if s.taskManager == nil {
+		return nil, ErrTasksUnavailable
+	}
+	id, err := taskIDParam(params)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.sessionTask(client, id); err != nil {
+		return nil, fmt.Errorf("cancel task %s: %w", id, err)
+	}
+	if err := s.taskManager.Cancel(id); err != nil {
+		return nil, err
+	}
+	return map[string]string{"status": "ok"}, nil
+}
+
+func (s *Server) handleCockpitTasksRetry(client *Client, params json.RawMessage) (interface{}, error) {
+	if s.taskManager == nil {
+		return nil, ErrTasksUnavailable
+	}
+	id, err := taskIDParam(params)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := s.sessionTask(client, id)
+	if err != nil {
+		return nil, fmt.Errorf("retry task %s: %w", id, err)
+	}
+	newID, err := s.taskManager.Submit(s.shutdownCtx, snap.Prompt, background.Origin{
+		Channel: snap.OriginChannel,
+		Session: snap.OriginSession,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("retry task %s: %w", id, err)
+	}
+	return map[string]string{"id": newID}, nil
+}
+
+// sessionTask looks up a background task. Remote cockpits may only act on
+// tasks started from the session they are attached to.
+func (s *Server) sessionTask(client *Client, id string) (*background.TaskSnapshot, error) {
+	snap, err := s.taskManager.Status(id)
+	if err != nil {
+		return nil, err
+	}
+	if client != nil && client.Type == "cockpit" && snap.OriginSession != client.SessionKey {
+		return nil, ErrTaskSessionMismatch
+	}
+	return snap, nil
+}
+
+func taskIDParam(params json.RawMessage) (string, error) {
+	var req struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(params, &req); err != nil {
+		return "", fmt.Errorf("invalid params: %w", err)
+	}
+	if req.ID == "" {
+		return "", fmt.Errorf("id is required")
+	}
+	return req.ID, nil
+}
+
+// HasOperators reports whether an operator-role cockpit is attached to
+// sessionKey.
+func (s *Server) HasOperators(sessionKey string) bool {
+	s.clientsMu.RLock()
+	defer s.clientsMu.RUnlock()
+
+	for _, c := range s.clients {
+		if c.Type == "cockpit" && c.Role == RoleOperator && c.SessionKey == sessionKey {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestOperatorApproval broadcasts a tool approval request to the cockpits
+// attached to the request's session and waits for the first operator to
+// answer. Viewers receive the request for display only.
+func (s *Server) RequestOperatorApproval(ctx context.Context, req approval.ApprovalRequest) (approval.ApprovalResponse, error) {
+	if !s.HasOperators(req.SessionKey) {
+		return approval.ApprovalResponse{}, ErrNoOperator
+	}
+
+	id := req.ID
+	if id == "" {
+		id = fmt.Sprintf("req-%d", time.Now().UnixNano())
+	}
+	respChan := make(chan approval.ApprovalResponse, 1)
+
+	s.pendingApprovalsMu.Lock()
+	s.pendingApprovals[id] = &pendingApproval{sessionKey: req.SessionKey, ch: respChan}
+	s.pendingApprovalsMu.Unlock()
+
+	defer func() {
+		s.pendingApprovalsMu.Lock()
+		delete(s.pendingApprovals, id)
+		s.pendingApprovalsMu.Unlock()
+	}()
+
+	s.broadcastToCockpits(req.SessionKey, "approval.request", map[string]interface{}{
+		"id":          id,
+		"sessionKey":  req.SessionKey,
+		"toolName":    req.ToolName,
+		"summary":     req.Summary,
+		"params":      req.Params,
+		"safetyLevel": req.SafetyLevel,
+		"category":    req.Category,
+		"activity":    req.Activity,
+	}, false)
+
+	timeout := s.config.ApprovalTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	expire := func() {
+		s.broadcastToCockpits(req.SessionKey, "approval.resolved", map[string]interface{}{
+			"requestId": id,
+			"expired":   true,
+		}, false)
+	}
+
+	select {
+	case resp := <-respChan:
+		return resp, nil
+	case <-ctx.Done():
+		expire()
+		return approval.ApprovalResponse{}, ctx.Err()
+	case <-time.After(timeout):
+		expire()
+		return approval.ApprovalResponse{}, ErrApprovalTimeout
+	}
+}
@@ -0,0 +1,301 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/approval"
+	"github.com/langoai/lango/internal/background"
+	"github.com/langoai/lango/internal/config"
+)
+
+func newCockpitTestServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	server := New(Config{
+		WebSocketEnabled: true,
+		ApprovalTimeout:  5 * time.Second,
+		Cockpit: config.RemoteCockpitConfig{
+			Enabled: true,
+			Tokens: []config.CockpitTokenConfig{
+				{Name: "alice", Token: "op-token", Role: RoleOperator},
+				{Name: "bob", Token: "view-token", Role: RoleViewer},
+			},
+		},
+	}, nil, nil, nil, nil)
+	ts := httptest.NewServer(server.router)
+	t.Cleanup(ts.Close)
+	t.Cleanup(server.shutdownCancel)
+	return server, "ws" + strings.TrimPrefix(ts.URL, "http") + "/cockpit"
+}
+
+func dialCockpit(t *testing.T, url, token, session string) *websocket.Conn {
+	t.Helper()
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?session="+session, header)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readUntil reads messages until match returns true or the deadline passes.
+func readUntil(t *testing.T, conn *websocket.Conn, match func(map[string]json.RawMessage) bool) map[string]json.RawMessage {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(3*time.Second)))
+	for {
+		var msg map[string]json.RawMessage
+		require.NoError(t, conn.ReadJSON(&msg))
+		if match(msg) {
+			return msg
+		}
+	}
+}
+
+func readEvent(t *testing.T, conn *websocket.Conn, event string) json.RawMessage {
+	t.Helper()
+	want, _ := json.Marshal(event)
+	msg := readUntil(t, conn, func(m map[string]json.RawMessage) bool {
+		return string(m["event"]) == string(want)
+	})
+	return msg["payload"]
+}
+
+func callRPC(t *testing.T, conn *websocket.Conn, id, method string, params interface{}) RPCResponse {
+	t.Helper()
+	raw, err := json.Marshal(params)
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteJSON(RPCRequest{ID: id, Method: method, Params: raw}))
+
+	want, _ := json.Marshal(id)
+	msg := readUntil(t, conn, func(m map[string]json.RawMessage) bool {
+		return string(m["id"]) == string(want)
+	})
+	var resp RPCResponse
+	data, _ := json.Marshal(msg)
+	require.NoError(t, json.Unmarshal(data, &resp))
+	return resp
+}
+
+func TestCockpit_RejectsInvalidToken(t *testing.T) {
+	t.Parallel()
+	_, url := newCockpitTestServer(t)
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer wrong")
+	_, resp, err := websocket.DefaultDialer.Dial(url, header)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	_, resp, err = websocket.DefaultDialer.Dial(url, nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestCockpit_HelloAndPresence(t *testing.T) {
+	t.Parallel()
+	_, url := newCockpitTestServer(t)
+
+	op := dialCockpit(t, url, "op-token", "sess-1")
+	resp := callRPC(t, op, "1", "cockpit.hello", nil)
+	require.Nil(t, resp.Error)
+	hello := resp.Result.(map[string]interface{})
+	assert.Equal(t, "alice", hello["name"])
+	assert.Equal(t, RoleOperator, hello["role"])
+	assert.Equal(t, "sess-1", hello["sessionKey"])
+
+	viewer := dialCockpit(t, url, "view-token", "sess-1")
+	var presence struct {
+		Operators []CockpitOperator `json:"operators"`
+	}
+	require.NoError(t, json.Unmarshal(readEvent(t, op, "cockpit.presence"), &presence))
+	for len(presence.Operators) < 2 {
+		require.NoError(t, json.Unmarshal(readEvent(t, op, "cockpit.presence"), &presence))
+	}
+	assert.Equal(t, []CockpitOperator{
+		{Name: "alice", Role: RoleOperator},
+		{Name: "bob", Role: RoleViewer},
+	}, presence.Operators)
+
+	viewer.Close()
+	require.NoError(t, json.Unmarshal(readEvent(t, op, "cockpit.presence"), &presence))
+	assert.Equal(t, []CockpitOperator{{Name: "alice", Role: RoleOperator}}, presence.Operators)
+}
+
+func TestCockpit_ViewerIsReadOnly(t *testing.T) {
+	t.Parallel()
+	_, url := newCockpitTestServer(t)
+	viewer := dialCockpit(t, url, "view-token", "sess-1")
+
+	for i, method := range []string{"chat.message", "approval.response", "cockpit.tasks.cancel", "sign.response"} {
+		resp := callRPC(t, viewer, fmt.Sprint(i), method, map[string]string{"message": "hi"})
+		require.NotNil(t, resp.Error, method)
+		assert.Equal(t, -32003, resp.Error.Code, method)
+	}
+
+	resp := callRPC(t, viewer, "hello", "cockpit.hello", nil)
+	assert.Nil(t, resp.Error)
+}
+
+func TestCockpit_OperatorApproval(t *testing.T) {
+	t.Parallel()
+	server, url := newCockpitTestServer(t)
+
+	assert.False(t, server.HasOperators("sess-1"))
+	op := dialCockpit(t, url, "op-token", "sess-1")
+	viewer := dialCockpit(t, url, "view-token", "sess-1")
+	callRPC(t, op, "h1", "cockpit.hello", nil)
+	callRPC(t, viewer, "h2", "cockpit.hello", nil)
+	require.True(t, server.HasOperators("sess-1"))
+	assert.False(t, server.HasOperators("sess-2"))
+
+	type result struct {
+		resp approval.ApprovalResponse
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := server.RequestOperatorApproval(context.Background(), approval.ApprovalRequest{
+			ID: "req-1", SessionKey: "sess-1", ToolName: "exec", Summary: "rm -rf build",
+		})
+		done <- result{resp, err}
+	}()
+
+	var req struct {
+		ID       string `json:"id"`
+		ToolName string `json:"toolName"`
+	}
+	require.NoError(t, json.Unmarshal(readEvent(t, viewer, "approval.request"), &req))
+	assert.Equal(t, "exec", req.ToolName)
+	require.NoError(t, json.Unmarshal(readEvent(t, op, "approval.request"), &req))
+
+	resp := callRPC(t, op, "a1", "approval.response", map[string]interface{}{
+		"requestId": req.ID, "approved": true,
+	})
+	require.Nil(t, resp.Error)
+
+	got := <-done
+	require.NoError(t, got.err)
+	assert.True(t, got.resp.Approved)
+
+	var resolved struct {
+		RequestID string `json:"requestId"`
+		By        string `json:"by"`
+	}
+	require.NoError(t, json.Unmarshal(readEvent(t, viewer, "approval.resolved"), &resolved))
+	assert.Equal(t, "req-1", resolved.RequestID)
+	assert.Equal(t, "alice", resolved.By)
+}
+
+func TestCockpit_ApprovalScopedToSession(t *testing.T) {
+	t.Parallel()
+	server, url := newCockpitTestServer(t)
+
+	op := dialCockpit(t, url, "op-token", "sess-1")
+	other := dialCockpit(t, url, "op-token", "sess-2")
+	callRPC(t, op, "h1", "cockpit.hello", nil)
+	callRPC(t, other, "h2", "cockpit.hello", nil)
+
+	done := make(chan approval.ApprovalResponse, 1)
+	go func() {
+		resp, _ := server.RequestOperatorApproval(context.Background(), approval.ApprovalRequest{
+			ID: "req-1", SessionKey: "sess-1", ToolName: "exec",
+		})
+		done <- resp
+	}()
+	readEvent(t, op, "approval.request")
+
+	// An operator on another session cannot answer the request.
+	resp := callRPC(t, other, "a1", "approval.response", map[string]interface{}{
+		"requestId": "req-1", "approved": true,
+	})
+	require.NotNil(t, resp.Error)
+
+	resp = callRPC(t, op, "a2", "approval.response", map[string]interface{}{
+		"requestId": "req-1", "approved": false,
+	})
+	require.Nil(t, resp.Error)
+	assert.False(t, (<-done).Approved)
+
+	// Companion requests have no session and cannot be answered by a cockpit.
+	respChan := make(chan approval.ApprovalResponse, 1)
+	server.pendingApprovalsMu.Lock()
+	server.pendingApprovals["req-2"] = &pendingApproval{ch: respChan}
+	server.pendingApprovalsMu.Unlock()
+	resp = callRPC(t, op, "a3", "approval.response", map[string]interface{}{
+		"requestId": "req-2", "approved": true,
+	})
+	require.NotNil(t, resp.Error)
+	assert.Empty(t, respChan)
+}
+
+type fakeTaskManager struct {
+	tasks     []background.TaskSnapshot
+	cancelled []string
+	submitted []string
+}
+
+func (f *fakeTaskManager) List() []background.TaskSnapshot { return f.tasks }
+
+func (f *fakeTaskManager) Status(id string) (*background.TaskSnapshot, error) {
+	for i := range f.tasks {
+		if f.tasks[i].ID == id {
+			return &f.tasks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("task %q not found", id)
+}
+
+func (f *fakeTaskManager) Cancel(id string) error {
+	f.cancelled = append(f.cancelled, id)
+	return nil
+}
+
+func (f *fakeTaskManager) Submit(_ context.Context, prompt string, _ background.Origin) (string, error) {
+	f.submitted = append(f.submitted, prompt)
+	return "t2", nil
+}
+
+func TestCockpit_Tasks(t *testing.T) {
+	t.Parallel()
+	server, url := newCockpitTestServer(t)
+	tm := &fakeTaskManager{tasks: []background.TaskSnapshot{
+		{ID: "t1", Prompt: "summarize logs", StatusText: "failed", OriginSession: "sess-1"},
+		{ID: "t9", Prompt: "other session", StatusText: "running", OriginSession: "sess-2"},
+	}}
+	server.SetTaskManager(tm)
+
+	op := dialCockpit(t, url, "op-token", "sess-1")
+
+	resp := callRPC(t, op, "1", "cockpit.tasks.list", nil)
+	require.Nil(t, resp.Error)
+	tasks := resp.Result.([]interface{})
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "t1", tasks[0].(map[string]interface{})["id"])
+
+	resp = callRPC(t, op, "2", "cockpit.tasks.cancel", map[string]string{"id": "t1"})
+	require.Nil(t, resp.Error)
+	resp = callRPC(t, op, "3", "cockpit.tasks.retry", map[string]string{"id": "t1"})
+	require.Nil(t, resp.Error)
+	resp = callRPC(t, op, "4", "cockpit.tasks.retry", map[string]string{"id": "missing"})
+	require.NotNil(t, resp.Error)
+
+	// Tasks from other sessions are out of reach.
+	resp = callRPC(t, op, "5", "cockpit.tasks.cancel", map[string]string{"id": "t9"})
+	require.NotNil(t, resp.Error)
+	resp = callRPC(t, op, "6", "cockpit.tasks.retry", map[string]string{"id": "t9"})
+	require.NotNil(t, resp.Error)
+
+	assert.Equal(t, []string{"t1"}, tm.cancelled)
+	assert.Equal(t, []string{"summarize logs"}, tm.submitted)
+}
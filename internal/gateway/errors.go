@@ -6,9 +6,16 @@ import (
 )
 
 var (
-	ErrNoCompanion     = errors.New("no companion connected")
-	ErrApprovalTimeout = errors.New("approval timeout")
-	ErrAgentNotReady   = errors.New("agent not ready")
+	ErrNoCompanion       = errors.New("no companion connected")
+	ErrApprovalTimeout   = errors.New("approval timeout")
+	ErrAgentNotReady     = errors.New("agent not ready")
+	ErrNoOperator        = errors.New("no cockpit operator attached")
+	ErrTasksUnavailable  = errors.New("background tasks not enabled")
+	ErrRewindUnavailable = errors.New("session rewind not enabled")
+	ErrRewindWorkspace   = errors.New("workspace restore is not available over the gateway")
+
+	ErrApprovalSessionMismatch = errors.New("approval request belongs to another session")
+	ErrTaskSessionMismatch     = errors.New("task belongs to another session")
 )
 
 // Error implements the error interface for RPCError.
@@ -0,0 +1,73 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/langoai/lango/internal/provenance"
+)
+
+// SessionRewinder rewinds sessions to provenance checkpoints.
+// *provenance.RewindService satisfies it.
+type SessionRewinder interface {
+	Rewind(ctx context.Context, req provenance.RewindRequest) (*provenance.RewindResult, error)
+}
+
+// SetSessionRewinder enables the session.rewind RPC method.
+func (s *Server) SetSessionRewinder(r SessionRewinder) {
+	s.rewinder = r
+}
+
+// handleSessionRewind rewinds a session's messages to a checkpoint. Workspace
+// restores would run git in the server's working directory, so they are
+// refused here and left to "lango session rewind" on the server. After a real
+// rewind the session's clients receive a "session.rewound" event.
+func (s *Server) handleSessionRewind(client *Client, params json.RawMessage) (interface{}, error) {
+	if s.rewinder == nil {
+		return nil, ErrRewindUnavailable
+	}
+
+	var req struct {
+		SessionKey   string `json:"sessionKey"`
+		CheckpointID string `json:"checkpointId"`
+		DryRun       bool   `json:"dryRun"`
+		Workspace    string `json:"workspace"`
+		Branch       string `json:"branch"`
+	}
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid params: %w", err)
+	}
+	if req.CheckpointID == "" {
+		return nil, fmt.Errorf("checkpointId is required")
+	}
+	if req.Workspace != "" || req.Branch != "" {
+		return nil, fmt.Errorf("%w; run \"lango session rewind --workspace\" on the server", ErrRewindWorkspace)
+	}
+
+	// Authenticated clients may only rewind their own session.
+	sessionKey := req.SessionKey
+	if client.SessionKey != "" {
+		sessionKey = client.SessionKey
+	}
+	if sessionKey == "" {
+		return nil, fmt.Errorf("sessionKey is required")
+	}
+
+	ctx, cancel := s.newResumeContext()
+	defer cancel()
+
+	result, err := s.rewinder.Rewind(ctx, provenance.RewindRequest{
+		SessionKey:   sessionKey,
+		CheckpointID: req.CheckpointID,
+		DryRun:       req.DryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.DryRun {
+		s.BroadcastToSession(sessionKey, "session.rewound", result)
+	}
+	return result, nil
+}
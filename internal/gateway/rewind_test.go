@@ -0,0 +1,104 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/provenance"
+)
+
+type fakeRewinder struct {
+	got provenance.RewindRequest
+}
+
+func (f *fakeRewinder) Rewind(_ context.Context, req provenance.RewindRequest) (*provenance.RewindResult, error) {
+	f.got = req
+	return &provenance.RewindResult{SessionKey: req.SessionKey, DryRun: req.DryRun, KeptMessages: 2}, nil
+}
+
+func TestSessionRewind_Unavailable(t *testing.T) {
+	t.Parallel()
+	server := New(Config{}, nil, nil, nil, nil)
+
+	_, err := server.handleSessionRewind(&Client{Type: "ui"}, json.RawMessage(`{"checkpointId":"cp-1"}`))
+	assert.ErrorIs(t, err, ErrRewindUnavailable)
+}
+
+func TestSessionRewind_Params(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give        string
+		client      *Client
+		params      string
+		wantSession string
+		wantErr     string
+	}{
+		{
+			give:        "unauthenticated client names the session",
+			client:      &Client{Type: "ui"},
+			params:      `{"sessionKey":"sess-1","checkpointId":"cp-1","dryRun":true}`,
+			wantSession: "sess-1",
+		},
+		{
+			give:        "authenticated client is held to its own session",
+			client:      &Client{Type: "ui", SessionKey: "mine"},
+			params:      `{"sessionKey":"theirs","checkpointId":"cp-1","dryRun":true}`,
+			wantSession: "mine",
+		},
+		{
+			give:    "checkpoint is required",
+			client:  &Client{Type: "ui"},
+			params:  `{"sessionKey":"sess-1"}`,
+			wantErr: "checkpointId is required",
+		},
+		{
+			give:    "workspace restore is refused",
+			client:  &Client{Type: "cockpit", Role: RoleOperator, SessionKey: "sess-1"},
+			params:  `{"checkpointId":"cp-1","workspace":"stash"}`,
+			wantErr: ErrRewindWorkspace.Error(),
+		},
+		{
+			give:    "branch is refused",
+			client:  &Client{Type: "ui"},
+			params:  `{"sessionKey":"sess-1","checkpointId":"cp-1","branch":"main"}`,
+			wantErr: ErrRewindWorkspace.Error(),
+		},
+		{
+			give:    "session is required",
+			client:  &Client{Type: "ui"},
+			params:  `{"checkpointId":"cp-1"}`,
+			wantErr: "sessionKey is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			server := New(Config{}, nil, nil, nil, nil)
+			t.Cleanup(server.shutdownCancel)
+			rw := &fakeRewinder{}
+			server.SetSessionRewinder(rw)
+
+			res, err := server.handleSessionRewind(tt.client, json.RawMessage(tt.params))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSession, rw.got.SessionKey)
+			assert.Equal(t, "cp-1", rw.got.CheckpointID)
+			assert.True(t, rw.got.DryRun)
+			assert.Equal(t, tt.wantSession, res.(*provenance.RewindResult).SessionKey)
+		})
+	}
+}
+
+func TestSessionRewind_CockpitRoles(t *testing.T) {
+	t.Parallel()
+	assert.True(t, (&Client{Type: "cockpit", Role: RoleOperator}).mayCall("session.rewind"))
+	assert.False(t, (&Client{Type: "cockpit", Role: RoleViewer}).mayCall("session.rewind"))
+}
@@ -48,7 +48,7 @@ type Server struct {
 	clientsMu          sync.RWMutex
 	handlers           map[string]RPCHandler
 	handlersMu         sync.RWMutex
-	pendingApprovals   map[string]chan approval.ApprovalResponse
+	pendingApprovals   map[string]*pendingApproval
 	pendingApprovalsMu sync.Mutex
 	turnCallbacks      []TurnCallback
 	sanitizer          *gatekeeper.Sanitizer
@@ -56,6 +56,11 @@ type Server struct {
 	shutdownCtx        context.Context
 	shutdownCancel     context.CancelFunc
 	featureStatuses    []types.FeatureStatus
+	taskManager        TaskManager
+	metrics            MetricsSource
+	rewinder           SessionRewinder
+	metricsOnce        sync.Once
+	paywall            func(http.Handler) http.Handler
 }
 
 // Config holds gateway server configuration
@@ -70,16 +75,19 @@ type Config struct {
 	IdleTimeout      time.Duration // inactivity timeout (0 = disabled)
 	MaxTimeout       time.Duration // absolute hard ceiling
 	RunLedger        config.RunLedgerConfig
+	Cockpit          config.RemoteCockpitConfig
 }
 
 // Client represents a connected WebSocket client
 type Client struct {
 	ID         string
-	Type       string // "ui" or "companion"
+	Type       string // "ui", "companion" or "cockpit"
 	Conn       *websocket.Conn
 	Server     *Server
 	Send       chan []byte
 	SessionKey string
+	Name       string // operator name (cockpit only)
+	Role       string // RoleViewer or RoleOperator (cockpit only)
 	closed     bool
 	closeMu    sync.Mutex
 }
@@ -122,7 +130,7 @@ func New(cfg Config, agent *adk.Agent, provider *security.RPCProvider, store ses
 		router:           chi.NewRouter(),
 		clients:          make(map[string]*Client),
 		handlers:         make(map[string]RPCHandler),
-		pendingApprovals: make(map[string]chan approval.ApprovalResponse),
+		pendingApprovals: make(map[string]*pendingApproval),
 		shutdownCtx:      shutdownCtx,
 		shutdownCancel:   shutdownCancel,
 		upgrader: websocket.Upgrader{
@@ -140,6 +148,8 @@ func New(cfg Config, agent *adk.Agent, provider *security.RPCProvider, store ses
 	s.RegisterHandler("decrypt.response", s.handleDecryptResponse)
 	s.RegisterHandler("companion.hello", s.handleCompanionHello)
 	s.RegisterHandler("approval.response", s.handleApprovalResponse)
+	s.RegisterHandler("session.rewind", s.handleSessionRewind)
+	s.registerCockpitHandlers()
 
 	// Wire up provider sender
 	if s.provider != nil {
@@ -233,6 +243,18 @@ func (s *Server) handleChatMessage(client *Client, params json.RawMessage) (inte
 		return nil, ErrAgentNotReady
 	}
 
+	// Let other clients watching the session see who asked.
+	sender := client.Name
+	if sender == "" {
+		sender = client.ID
+	}
+	s.BroadcastToSession(sessionKey, "chat.user", map[string]string{
+		"sessionKey": sessionKey,
+		"clientId":   client.ID,
+		"sender":     sender,
+		"message":    req.Message,
+	})
+
 	// Notify UI that agent is thinking
 	s.BroadcastToSession(sessionKey, "agent.thinking", map[string]string{
 		"sessionKey": sessionKey,
@@ -303,6 +325,24 @@ func (s *Server) handleChatMessage(client *Client, params json.RawMessage) (inte
 				"max":        fmt.Sprintf("%d", max),
 			})
 		},
+		OnToolCall: func(callID, toolName string, params map[string]any) {
+			s.BroadcastToSession(sessionKey, "agent.tool_call", map[string]interface{}{
+				"sessionKey": sessionKey,
+				"callId":     callID,
+				"toolName":   toolName,
+				"params":     params,
+			})
+		},
+		OnToolResult: func(callID, toolName string, success bool, duration time.Duration, preview string) {
+			s.BroadcastToSession(sessionKey, "agent.tool_result", map[string]interface{}{
+				"sessionKey": sessionKey,
+				"callId":     callID,
+				"toolName":   toolName,
+				"success":    success,
+				"durationMs": duration.Milliseconds(),
+				"preview":    preview,
+			})
+		},
 	})
 
 	// Stop progress updates now that the agent has finished.
@@ -361,8 +401,9 @@ func (s *Server) newResumeContext() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(s.shutdownCtx, timeout)
 }
 
-// BroadcastToSession sends an event to all UI clients belonging to a specific session.
-// When the session key is empty (no auth), it broadcasts to all UI clients.
+// BroadcastToSession sends an event to all UI clients and remote cockpits
+// belonging to a specific session.
+// When the session key is empty (no auth), it broadcasts to all of them.
 func (s *Server) BroadcastToSession(sessionKey, event string, payload interface{}) {
 	msg, _ := json.Marshal(map[string]interface{}{
 		"type":    "event",
@@ -374,7 +415,7 @@ func (s *Server) BroadcastToSession(sessionKey, event string, payload interface{
 	defer s.clientsMu.RUnlock()
 
 	for _, client := range s.clients {
-		if client.Type != "ui" {
+		if client.Type != "ui" && client.Type != "cockpit" {
 			continue
 		}
 		// If authenticated, scope to the session; otherwise broadcast to all UI clients
@@ -465,7 +506,7 @@ func (s *Server) RequestApproval(ctx context.Context, message string) (approval.
 	respChan := make(chan approval.ApprovalResponse, 1)
 
 	s.pendingApprovalsMu.Lock()
-	s.pendingApprovals[id] = respChan
+	s.pendingApprovals[id] = &pendingApproval{ch: respChan}
 	s.pendingApprovalsMu.Unlock()
 
 	defer func() {
@@ -513,8 +554,17 @@ func (s *Server) handleCompanionHello(_ *Client, params json.RawMessage) (interf
 	return map[string]string{"status": "ok"}, nil
 }
 
-// handleApprovalResponse processes approval response from companion
-func (s *Server) handleApprovalResponse(_ *Client, params json.RawMessage) (interface{}, error) {
+// pendingApproval is an approval request awaiting a response. sessionKey is
+// empty for companion requests, which no cockpit may answer.
+type pendingApproval struct {
+	sessionKey string
+	ch         chan approval.ApprovalResponse
+}
+
+// handleApprovalResponse processes approval response from a companion or
+// remote cockpit operator. A cockpit may only answer requests raised in the
+// session it is attached to.
+func (s *Server) handleApprovalResponse(client *Client, params json.RawMessage) (interface{}, error) {
 	var req struct {
 		RequestID   string `json:"requestId"`
 		Approved    bool   `json:"approved"`
@@ -524,8 +574,14 @@ func (s *Server) handleApprovalResponse(_ *Client, params json.RawMessage) (inte
 		return nil, fmt.Errorf("invalid params: %w", err)
 	}
 
+	isCockpit := client != nil && client.Type == "cockpit"
+
 	s.pendingApprovalsMu.Lock()
-	ch, exists := s.pendingApprovals[req.RequestID]
+	pending, exists := s.pendingApprovals[req.RequestID]
+	if exists && isCockpit && pending.sessionKey != client.SessionKey {
+		s.pendingApprovalsMu.Unlock()
+		return nil, fmt.Errorf("approval %s: %w", req.RequestID, ErrApprovalSessionMismatch)
+	}
 	if exists {
 		delete(s.pendingApprovals, req.RequestID)
 	}
@@ -538,9 +594,19 @@ func (s *Server) handleApprovalResponse(_ *Client, params json.RawMessage) (inte
 		}
 		// Non-blocking send
 		select {
-		case ch <- resp:
+		case pending.ch <- resp:
 		default:
 		}
+
+		// Dismiss the request on the other cockpits watching the session.
+		if isCockpit {
+			s.broadcastToCockpits(client.SessionKey, "approval.resolved", map[string]interface{}{
+				"requestId":   req.RequestID,
+				"approved":    req.Approved,
+				"alwaysAllow": req.AlwaysAllow,
+				"by":          client.Name,
+			}, false)
+		}
 	}
 
 	return map[string]string{"status": "ok"}, nil
@@ -578,6 +644,11 @@ func (s *Server) setupRoutes() {
 	if s.config.WebSocketEnabled && s.provider != nil {
 		s.router.Get("/companion", s.handleCompanionWebSocket)
 	}
+
+	// Remote cockpit endpoint — bearer token auth from server.cockpit.tokens
+	if s.config.WebSocketEnabled && s.config.Cockpit.Enabled {
+		s.router.Get("/cockpit", s.handleCockpitWebSocket)
+	}
 }
 
 // Router returns the underlying chi.Router for mounting additional routes.
@@ -585,6 +656,22 @@ func (s *Server) Router() chi.Router {
 	return s.router
 }
 
+// Handler returns the HTTP handler served by Start: the router wrapped by
+// the paywall when one is set.
+func (s *Server) Handler() http.Handler {
+	if s.paywall != nil {
+		return s.paywall(s.router)
+	}
+	return s.router
+}
+
+// SetPaywall sets middleware that wraps every route, including routes
+// mounted later on Router() such as the A2A endpoints. It is used for
+// X402 payment enforcement and must be set before Start.
+func (s *Server) SetPaywall(mw func(http.Handler) http.Handler) {
+	s.paywall = mw
+}
+
 // SetAgent sets the agent on the server (used for deferred wiring).
 func (s *Server) SetAgent(agent *adk.Agent) {
 	s.agent = agent
@@ -628,7 +715,7 @@ func (s *Server) Start() error {
 	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
 	s.httpServer = &http.Server{
 		Addr:         addr,
-		Handler:      s.router,
+		Handler:      s.Handler(),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -726,13 +813,16 @@ func (s *Server) handleWebSocketConnection(w http.ResponseWriter, r *http.Reques
 		SessionKey: sessionKey,
 	}
 
+	logger().Infow("client connected", "clientId", clientID, "authenticated", sessionKey != "")
+	s.attachClient(client)
+}
+
+// attachClient registers a client and starts its read/write pumps.
+func (s *Server) attachClient(client *Client) {
 	s.clientsMu.Lock()
-	s.clients[clientID] = client
+	s.clients[client.ID] = client
 	s.clientsMu.Unlock()
 
-	logger().Infow("client connected", "clientId", clientID, "authenticated", sessionKey != "")
-
-	// Start read/write pumps
 	go client.writePump()
 	go client.readPump()
 }
@@ -804,6 +894,11 @@ func (c *Client) readPump() {
 			continue
 		}
 
+		if !c.mayCall(req.Method) {
+			c.sendError(req.ID, -32003, fmt.Sprintf("method %s not permitted for role %q", req.Method, c.Role))
+			continue
+		}
+
 		c.handleRPC(req, handler)
 	}
 }
@@ -894,11 +989,14 @@ func (s *Server) HasCompanions() bool {
 
 func (s *Server) removeClient(id string) {
 	s.clientsMu.Lock()
-	defer s.clientsMu.Unlock()
-
-	if client, exists := s.clients[id]; exists {
+	client, exists := s.clients[id]
+	if exists {
 		logger().Infow("client disconnected", "clientId", id)
 		delete(s.clients, id)
-		_ = client
+	}
+	s.clientsMu.Unlock()
+
+	if exists && client.Type == "cockpit" {
+		s.broadcastPresence(client.SessionKey)
 	}
 }
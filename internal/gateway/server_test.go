@@ -150,7 +150,7 @@ func TestApprovalResponse_AtomicDelete(t *testing.T) {
 
 	respChan := make(chan approval.ApprovalResponse, 1)
 	server.pendingApprovalsMu.Lock()
-	server.pendingApprovals["req-1"] = respChan
+	server.pendingApprovals["req-1"] = &pendingApproval{ch: respChan}
 	server.pendingApprovalsMu.Unlock()
 
 	params := json.RawMessage(`{"requestId":"req-1","approved":true}`)
@@ -183,7 +183,7 @@ func TestApprovalResponse_DuplicateResponse(t *testing.T) {
 
 	respChan := make(chan approval.ApprovalResponse, 1)
 	server.pendingApprovalsMu.Lock()
-	server.pendingApprovals["req-dup"] = respChan
+	server.pendingApprovals["req-dup"] = &pendingApproval{ch: respChan}
 	server.pendingApprovalsMu.Unlock()
 
 	params := json.RawMessage(`{"requestId":"req-dup","approved":true}`)
@@ -229,6 +229,48 @@ func (s *BoltStore) Traverse(_ context.Context, startNode string, maxDepth int,
 	return result, err
 }
 
+// MatchPattern returns the triples matching a pattern in which empty strings
+// are wildcards. It scans the index whose key order puts the bound
+// positions first: SPO when the subject is bound, POS for predicate and
+// object, OSP for object and subject.
+func (s *BoltStore) MatchPattern(_ context.Context, subject, predicate, object string) ([]Triple, error) {
+	var result []Triple
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b, prefix, decode := patternIndex(tx, subject, predicate, object)
+		triples, err := scanPrefix(b, prefix, decode)
+		if err != nil {
+			return err
+		}
+		for _, t := range triples {
+			if matchesPattern(t, subject, predicate, object) {
+				result = append(result, t)
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+// EstimatePattern returns the number of index keys under the pattern's
+// prefix, counting at most limit keys. It is used by the query planner to
+// order joins.
+func (s *BoltStore) EstimatePattern(_ context.Context, subject, predicate, object string, limit int) (int, error) {
+	var n int
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b, prefix, _ := patternIndex(tx, subject, predicate, object)
+		if len(prefix) == 0 {
+			n = min(b.Stats().KeyN, limit)
+			return nil
+		}
+		c := b.Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix) && n < limit; k, _ = c.Next() {
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
+
 // Count returns the total number of triples by counting keys in the SPO bucket.
 func (s *BoltStore) Count(_ context.Context) (int, error) {
 	var count int
@@ -405,6 +447,62 @@ func tripleFromOSPKey(key, val []byte) (Triple, error) {
 	return t, nil
 }
 
+func tripleFromPOSKey(key, val []byte) (Triple, error) {
+	p, o, s, err := splitKey(key)
+	if err != nil {
+		return Triple{}, err
+	}
+	meta, err := decodeMetadata(val)
+	if err != nil {
+		return Triple{}, err
+	}
+	t := Triple{Subject: s, Predicate: p, Object: o, Metadata: meta}
+	restoreTypeFields(&t)
+	return t, nil
+}
+
+// patternIndex picks the bucket, key prefix and decoder for a pattern with
+// empty strings as wildcards. See IndexFor for the choice of index.
+func patternIndex(tx *bolt.Tx, subject, predicate, object string) (*bolt.Bucket, []byte, keyDecoder) {
+	var parts []string
+	var bucket []byte
+	var decode keyDecoder
+	switch IndexFor(subject != "", predicate != "", object != "") {
+	case IndexSPO:
+		bucket, decode = bucketSPO, tripleFromSPOKey
+		parts = boundPrefix(subject, predicate, object)
+	case IndexPOS:
+		bucket, decode = bucketPOS, tripleFromPOSKey
+		parts = boundPrefix(predicate, object, subject)
+	case IndexOSP:
+		bucket, decode = bucketOSP, tripleFromOSPKey
+		parts = boundPrefix(object, subject, predicate)
+	default:
+		return tx.Bucket(bucketSPO), nil, tripleFromSPOKey
+	}
+	prefix := makeKey(parts...)
+	if len(parts) < 3 {
+		prefix = append(prefix, sep)
+	}
+	return tx.Bucket(bucket), prefix, decode
+}
+
+// boundPrefix returns the leading non-empty components of a key.
+func boundPrefix(parts ...string) []string {
+	for i, p := range parts {
+		if p == "" {
+			return parts[:i]
+		}
+	}
+	return parts
+}
+
+func matchesPattern(t Triple, subject, predicate, object string) bool {
+	return (subject == "" || t.Subject == subject) &&
+		(predicate == "" || t.Predicate == predicate) &&
+		(object == "" || t.Object == object)
+}
+
 // restoreTypeFields populates SubjectType/ObjectType from metadata keys.
 func restoreTypeFields(t *Triple) {
 	if t.Metadata == nil {
@@ -39,10 +39,7 @@ func NewExtractor(generator llm.TextGenerator, logger *zap.SugaredLogger, opts .
 	return e
 }
 
-const extractionSystemPrompt = `You are an entity and relationship extraction system. Given text, extract entities and relationships as triples.
-
-Output format (one triple per line):
-SUBJECT|PREDICATE|OBJECT
+const extractionSystemPrompt = `You are an entity and relationship extraction system. Given text, extract entities and relationships as subject-predicate-object triples.
 
 Valid predicates: related_to, caused_by, resolved_by, follows, similar_to, contains
 
@@ -51,14 +48,27 @@ Rules:
 - Use concise entity names (lowercase, underscored)
 - Skip trivial or obvious relationships
 - Maximum 10 triples per extraction
-- If no meaningful relationships found, output NONE
+- If no meaningful relationships found, return an empty triples list
 
 Example:
 Input: "JWT token expired causing authentication failure. Fixed by implementing token refresh."
 Output:
-jwt_token_expiry|caused_by|authentication_failure
-token_refresh|resolved_by|authentication_failure
-jwt_token_expiry|related_to|token_refresh`
+{"triples": [
+  {"subject": "jwt_token_expiry", "predicate": "caused_by", "object": "authentication_failure"},
+  {"subject": "token_refresh", "predicate": "resolved_by", "object": "authentication_failure"},
+  {"subject": "jwt_token_expiry", "predicate": "related_to", "object": "token_refresh"}
+]}`
+
+// extraction is the structured response requested from the LLM.
+type extraction struct {
+	Triples []extractedTriple `json:"triples" jsonschema:"relationships found in the text, empty if none"`
+}
+
+type extractedTriple struct {
+	Subject   string `json:"subject" jsonschema:"source entity name"`
+	Predicate string `json:"predicate" jsonschema:"one of the valid predicates"`
+	Object    string `json:"object" jsonschema:"target entity name"`
+}
 
 // Extract extracts triples from the given text content.
 // The sourceID is used as context for provenance tracking.
@@ -69,39 +79,23 @@ func (e *Extractor) Extract(ctx context.Context, content, sourceID string) ([]Tr
 
 	userPrompt := fmt.Sprintf("Extract entities and relationships from:\n\n%s", content)
 
-	response, err := e.generator.GenerateText(ctx, extractionSystemPrompt, userPrompt)
+	result, err := llm.GenerateStructured[extraction](ctx, e.generator, extractionSystemPrompt, userPrompt,
+		llm.WithSchemaName("triples", "Entity relationships extracted from the text"))
 	if err != nil {
 		return nil, fmt.Errorf("generate extraction: %w", err)
 	}
 
-	return e.parseResponse(response, sourceID), nil
+	return e.toTriples(result.Triples, sourceID), nil
 }
 
-// parseResponse parses the LLM response into triples.
-func (e *Extractor) parseResponse(response, sourceID string) []Triple {
-	response = strings.TrimSpace(response)
-	if response == "" || response == "NONE" {
-		return nil
-	}
-
-	lines := strings.Split(response, "\n")
-	triples := make([]Triple, 0, len(lines))
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || line == "NONE" {
-			continue
-		}
-
-		parts := strings.SplitN(line, "|", 3)
-		if len(parts) != 3 {
-			e.logger.Debugw("skip malformed triple", "line", line)
-			continue
-		}
-
-		subject := strings.TrimSpace(parts[0])
-		predicate := strings.TrimSpace(parts[1])
-		object := strings.TrimSpace(parts[2])
+// toTriples converts extracted triples, dropping incomplete ones and those
+// with unknown predicates.
+func (e *Extractor) toTriples(extracted []extractedTriple, sourceID string) []Triple {
+	triples := make([]Triple, 0, len(extracted))
+	for _, t := range extracted {
+		subject := strings.TrimSpace(t.Subject)
+		predicate := strings.TrimSpace(t.Predicate)
+		object := strings.TrimSpace(t.Object)
 
 		if subject == "" || predicate == "" || object == "" {
 			continue
@@ -1,10 +1,15 @@
 package graph
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+
+	"github.com/langoai/lango/internal/llm"
+	"github.com/langoai/lango/internal/llm/llmtest"
 )
 
 func TestExtractor_ValidatorRejectsUnknown(t *testing.T) {
@@ -36,17 +41,50 @@ func TestExtractor_NoValidatorUsesHardcodedFallback(t *testing.T) {
 	assert.False(t, e.isValidPredicate("made_up"))
 }
 
-func TestExtractor_ParseResponseRejectsInvalidPredicate(t *testing.T) {
+func TestExtractor_ToTriplesRejectsInvalidPredicate(t *testing.T) {
 	validator := func(name string) bool {
 		return name == CausedBy
 	}
 	logger := zap.NewNop().Sugar()
 	e := NewExtractor(nil, logger, WithPredicateValidator(validator))
 
-	response := "a|caused_by|b\nc|fake_rel|d\ne|caused_by|f"
-	triples := e.parseResponse(response, "test-source")
+	triples := e.toTriples([]extractedTriple{
+		{Subject: "a", Predicate: "caused_by", Object: "b"},
+		{Subject: "c", Predicate: "fake_rel", Object: "d"},
+		{Subject: "", Predicate: "caused_by", Object: "x"},
+		{Subject: "e", Predicate: "caused_by", Object: "f"},
+	}, "test-source")
 
 	assert.Len(t, triples, 2)
 	assert.Equal(t, "a", triples[0].Subject)
 	assert.Equal(t, "e", triples[1].Subject)
+	assert.Equal(t, "test-source", triples[1].Metadata["source"])
+}
+
+func TestExtractor_ExtractRepairsMalformedJSON(t *testing.T) {
+	gen := llmtest.NewScriptedGenerator(
+		"jwt_token_expiry|caused_by|authentication_failure",
+		`{"triples": [{"subject": "jwt_token_expiry", "predicate": "caused_by", "object": "authentication_failure"}]}`,
+	)
+	e := NewExtractor(gen, zap.NewNop().Sugar())
+
+	triples, err := e.Extract(context.Background(), "JWT expiry caused auth failures.", "doc-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, gen.Calls())
+	require.Len(t, triples, 1)
+	assert.Equal(t, Triple{
+		Subject:   "jwt_token_expiry",
+		Predicate: CausedBy,
+		Object:    "authentication_failure",
+		Metadata:  map[string]string{"source": "doc-1"},
+	}, triples[0])
+}
+
+func TestExtractor_ExtractGivesUpOnInvalidOutput(t *testing.T) {
+	gen := llmtest.NewScriptedGenerator("NONE", "NONE", `{"triples": null}`)
+	e := NewExtractor(gen, zap.NewNop().Sugar())
+
+	_, err := e.Extract(context.Background(), "some text", "doc-2")
+	require.ErrorIs(t, err, llm.ErrInvalidStructuredOutput)
+	assert.Equal(t, llm.DefaultStructuredAttempts, gen.Calls())
 }
@@ -0,0 +1,140 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// DefaultImportBatchSize is the number of triples written per transaction
+// by ImportTriples.
+const DefaultImportBatchSize = 1000
+
+// ImportStats reports what ImportTriples did with each triple read.
+type ImportStats struct {
+	Added   int `json:"added"`
+	Updated int `json:"updated"` // stored or earlier-read triples that gained metadata
+	Skipped int `json:"skipped"` // duplicates already in the store
+}
+
+// ImportTriples streams triples from r into store in batches. A triple whose
+// subject, predicate and object already exist is skipped, unless it carries
+// metadata the stored triple lacks, in which case the metadata is merged.
+func ImportTriples(ctx context.Context, store Store, r TripleReader, batchSize int) (ImportStats, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultImportBatchSize
+	}
+	m := matcherFor(store)
+
+	var (
+		stats ImportStats
+		batch []Triple
+		index = make(map[[3]string]int)
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := store.AddTriples(ctx, batch); err != nil {
+			return fmt.Errorf("import triples: %w", err)
+		}
+		batch = batch[:0]
+		clear(index)
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+		t, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, err
+		}
+		if t.Subject == "" || t.Predicate == "" || t.Object == "" {
+			return stats, fmt.Errorf("import triples: incomplete triple %q %q %q", t.Subject, t.Predicate, t.Object)
+		}
+		t.Metadata = typedMetadata(t)
+		key := [3]string{t.Subject, t.Predicate, t.Object}
+
+		if i, ok := index[key]; ok {
+			var changed bool
+			if batch[i].Metadata, changed = mergeMetadata(batch[i].Metadata, t.Metadata); changed {
+				stats.Updated++
+			} else {
+				stats.Skipped++
+			}
+			continue
+		}
+
+		existing, err := m.MatchPattern(ctx, t.Subject, t.Predicate, t.Object)
+		if err != nil {
+			return stats, fmt.Errorf("import triples: %w", err)
+		}
+		if len(existing) > 0 {
+			merged, changed := mergeMetadata(typedMetadata(existing[0]), t.Metadata)
+			if !changed {
+				stats.Skipped++
+				continue
+			}
+			t.Metadata = merged
+			stats.Updated++
+		} else {
+			stats.Added++
+		}
+
+		index[key] = len(batch)
+		batch = append(batch, Triple{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object, Metadata: t.Metadata})
+		if len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return stats, err
+			}
+		}
+	}
+	return stats, flush()
+}
+
+// typedMetadata returns a triple's metadata with SubjectType and ObjectType
+// folded in under the keys BoltStore persists them as.
+func typedMetadata(t Triple) map[string]string {
+	if t.SubjectType == "" && t.ObjectType == "" {
+		return t.Metadata
+	}
+	meta := make(map[string]string, len(t.Metadata)+2)
+	for k, v := range t.Metadata {
+		meta[k] = v
+	}
+	if t.SubjectType != "" {
+		meta["_subject_type"] = t.SubjectType
+	}
+	if t.ObjectType != "" {
+		meta["_object_type"] = t.ObjectType
+	}
+	return meta
+}
+
+// mergeMetadata returns base with extra's entries added, and whether that
+// changed anything. base is not modified.
+func mergeMetadata(base, extra map[string]string) (map[string]string, bool) {
+	changed := false
+	for k, v := range extra {
+		if cur, ok := base[k]; !ok || cur != v {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return base, false
+	}
+	out := make(map[string]string, len(base)+len(extra))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range extra {
+		out[k] = v
+	}
+	return out, true
+}
@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Index names the triple index a pattern scan uses.
+type Index string
+
+// Index constants name the BoltStore key orderings. IndexScan is a full
+// scan of the SPO index when no position is bound.
+const (
+	IndexSPO  Index = "spo"
+	IndexPOS  Index = "pos"
+	IndexOSP  Index = "osp"
+	IndexScan Index = "scan"
+)
+
+// IndexFor returns the index whose key order puts the bound positions of a
+// pattern first, so the scan is a prefix seek.
+func IndexFor(subject, predicate, object bool) Index {
+	switch {
+	case subject && (predicate || !object):
+		return IndexSPO
+	case subject && object:
+		return IndexOSP
+	case predicate:
+		return IndexPOS
+	case object:
+		return IndexOSP
+	default:
+		return IndexScan
+	}
+}
+
+// PatternMatcher is implemented by stores that answer triple patterns from
+// their indexes. Empty strings are wildcards. BoltStore implements it; other
+// stores are queried through the primitive Store calls.
+type PatternMatcher interface {
+	MatchPattern(ctx context.Context, subject, predicate, object string) ([]Triple, error)
+	EstimatePattern(ctx context.Context, subject, predicate, object string, limit int) (int, error)
+}
+
+var _ PatternMatcher = (*BoltStore)(nil)
+
+// QueryResult holds the solutions of a query. Unbound variables are absent
+// from their row.
+type QueryResult struct {
+	Vars []string            `json:"vars"`
+	Rows []map[string]string `json:"rows"`
+}
+
+// Query is a parsed graph query. See ParseQuery for the syntax.
+type Query struct {
+	selectVars []string // empty = all variables in order of appearance
+	distinct   bool
+	where      *group
+	orderBy    []orderKey
+	limit      int // 0 = unlimited
+	offset     int
+
+	vars  []string        // all node/predicate variables in order of appearance
+	edges map[string]bool // edge variables bound with AS
+}
+
+// group is a basic graph pattern with its filters and optional groups.
+type group struct {
+	patterns []*pattern
+	filters  []expr
+	optional []*group
+}
+
+// term is a pattern position: a variable or a constant value.
+type term struct {
+	varName string // without '?'; empty for constants
+	value   string
+}
+
+func (t term) isVar() bool { return t.varName != "" }
+
+func (t term) String() string {
+	if t.isVar() {
+		return "?" + t.varName
+	}
+	return quoteTerm(t.value)
+}
+
+// pattern is a triple pattern. edge, when set, binds the matched triple so
+// filters can read its metadata.
+type pattern struct {
+	subject, predicate, object term
+	edge                       string
+}
+
+func (p *pattern) terms() [3]term { return [3]term{p.subject, p.predicate, p.object} }
+
+func (p *pattern) String() string {
+	s := fmt.Sprintf("%s %s %s", p.subject, p.predicate, p.object)
+	if p.edge != "" {
+		s += " AS ?" + p.edge
+	}
+	return s
+}
+
+type orderKey struct {
+	varName string
+	desc    bool
+}
+
+// maxIntermediateRows bounds the solutions held between join steps so a
+// broad query fails instead of exhausting memory.
+const maxIntermediateRows = 100_000
+
+// RunQuery parses and executes a query against store.
+func RunQuery(ctx context.Context, store Store, src string) (*QueryResult, error) {
+	q, err := ParseQuery(src)
+	if err != nil {
+		return nil, err
+	}
+	return q.Execute(ctx, store)
+}
+
+// Execute plans and runs the query against store.
+func (q *Query) Execute(ctx context.Context, store Store) (*QueryResult, error) {
+	m := matcherFor(store)
+	p, err := planQuery(ctx, q, m)
+	if err != nil {
+		return nil, err
+	}
+	return newExecutor(m).run(ctx, q, p)
+}
+
+// Explain returns the execution plan for the query against store: the join
+// order, the index each pattern scans and its estimated size.
+func (q *Query) Explain(ctx context.Context, store Store) (string, error) {
+	p, err := planQuery(ctx, q, matcherFor(store))
+	if err != nil {
+		return "", err
+	}
+	return p.String(), nil
+}
+
+// matcherFor returns the store's own PatternMatcher, or an adapter over the
+// primitive Store calls.
+func matcherFor(store Store) PatternMatcher {
+	if m, ok := store.(PatternMatcher); ok {
+		return m
+	}
+	return storeMatcher{store}
+}
+
+// storeMatcher answers patterns through the Store interface for stores
+// without index access.
+type storeMatcher struct {
+	store Store
+}
+
+func (m storeMatcher) MatchPattern(ctx context.Context, subject, predicate, object string) ([]Triple, error) {
+	var (
+		triples []Triple
+		err     error
+	)
+	switch {
+	case subject != "" && predicate != "":
+		triples, err = m.store.QueryBySubjectPredicate(ctx, subject, predicate)
+	case subject != "":
+		triples, err = m.store.QueryBySubject(ctx, subject)
+	case object != "":
+		triples, err = m.store.QueryByObject(ctx, object)
+	default:
+		triples, err = m.store.AllTriples(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result []Triple
+	for _, t := range triples {
+		if matchesPattern(t, subject, predicate, object) {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+// EstimatePattern falls back to the total triple count reduced by a fixed
+// factor per bound position.
+func (m storeMatcher) EstimatePattern(ctx context.Context, subject, predicate, object string, limit int) (int, error) {
+	n, err := m.store.Count(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, bound := range []bool{subject != "", predicate != "", object != ""} {
+		if bound {
+			n /= 10
+		}
+	}
+	return min(max(n, 1), limit), nil
+}
+
+// quoteTerm renders a constant so that it parses back as the same value.
+func quoteTerm(v string) string {
+	if v != "" && !isKeyword(v) && strings.IndexFunc(v, func(r rune) bool { return !isIdentRune(r) }) < 0 && !strings.HasSuffix(v, ".") {
+		return v
+	}
+	return fmt.Sprintf("%q", v)
+}
@@ -0,0 +1,201 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// executor runs a plan as nested index joins. Scans are cached per query
+// so repeated join keys hit the store once.
+type executor struct {
+	m     PatternMatcher
+	cache map[[3]string][]Triple
+}
+
+func newExecutor(m PatternMatcher) *executor {
+	return &executor{m: m, cache: make(map[[3]string][]Triple)}
+}
+
+func (x *executor) run(ctx context.Context, q *Query, p *groupPlan) (*QueryResult, error) {
+	rows, err := x.runGroup(ctx, p, []row{{vals: map[string]string{}}})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(q.orderBy) > 0 {
+		sort.SliceStable(rows, func(i, j int) bool {
+			for _, k := range q.orderBy {
+				a, okA := rows[i].lookup(k.varName)
+				b, okB := rows[j].lookup(k.varName)
+				c := 0
+				switch {
+				case okA && okB:
+					c = compareValues(a, b)
+				case okB:
+					c = -1 // unbound sorts first
+				case okA:
+					c = 1
+				}
+				if k.desc {
+					c = -c
+				}
+				if c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+	}
+
+	vars := q.selectVars
+	if len(vars) == 0 {
+		vars = q.vars
+	}
+	result := &QueryResult{Vars: vars, Rows: []map[string]string{}}
+	seen := make(map[string]bool)
+	skipped := 0
+	for _, r := range rows {
+		out := make(map[string]string, len(vars))
+		for _, v := range vars {
+			if val, ok := r.lookup(v); ok {
+				out[v] = val
+			}
+		}
+		if q.distinct {
+			key := distinctKey(vars, out)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		if skipped < q.offset {
+			skipped++
+			continue
+		}
+		result.Rows = append(result.Rows, out)
+		if q.limit > 0 && len(result.Rows) == q.limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (x *executor) runGroup(ctx context.Context, gp *groupPlan, rows []row) ([]row, error) {
+	for _, st := range gp.steps {
+		var next []row
+		for _, r := range rows {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			var key [3]string
+			for j, t := range st.pattern.terms() {
+				switch {
+				case !t.isVar():
+					key[j] = t.value
+				default:
+					key[j] = r.vals[t.varName]
+				}
+			}
+			triples, err := x.match(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			for i := range triples {
+				nr, ok := bindPattern(r, st.pattern, &triples[i])
+				if !ok || !passes(st.filters, nr) {
+					continue
+				}
+				next = append(next, nr)
+				if len(next) > maxIntermediateRows {
+					return nil, fmt.Errorf("query matched more than %d intermediate results; add constants or filters to narrow it", maxIntermediateRows)
+				}
+			}
+		}
+		rows = next
+		if len(rows) == 0 {
+			return nil, nil
+		}
+	}
+
+	for _, opt := range gp.optional {
+		var next []row
+		for _, r := range rows {
+			sub, err := x.runGroup(ctx, opt, []row{r})
+			if err != nil {
+				return nil, err
+			}
+			if len(sub) == 0 {
+				next = append(next, r)
+			} else {
+				next = append(next, sub...)
+			}
+		}
+		rows = next
+	}
+
+	if len(gp.post) == 0 {
+		return rows, nil
+	}
+	out := rows[:0]
+	for _, r := range rows {
+		if passes(gp.post, r) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (x *executor) match(ctx context.Context, key [3]string) ([]Triple, error) {
+	if triples, ok := x.cache[key]; ok {
+		return triples, nil
+	}
+	triples, err := x.m.MatchPattern(ctx, key[0], key[1], key[2])
+	if err != nil {
+		return nil, fmt.Errorf("match pattern: %w", err)
+	}
+	x.cache[key] = triples
+	return triples, nil
+}
+
+// bindPattern extends r with the variables of pat bound to t. It fails when
+// a variable repeated within the pattern would take two values.
+func bindPattern(r row, pat *pattern, t *Triple) (row, bool) {
+	nr := r.extend()
+	for i, v := range [3]string{t.Subject, t.Predicate, t.Object} {
+		tm := pat.terms()[i]
+		if !tm.isVar() {
+			continue
+		}
+		if prev, ok := nr.vals[tm.varName]; ok && prev != v {
+			return row{}, false
+		}
+		nr.vals[tm.varName] = v
+	}
+	if pat.edge != "" {
+		nr = nr.withEdge(pat.edge, t)
+	}
+	return nr, true
+}
+
+func passes(filters []expr, r row) bool {
+	for _, f := range filters {
+		if !f.eval(r).truthy() {
+			return false
+		}
+	}
+	return true
+}
+
+func distinctKey(vars []string, out map[string]string) string {
+	var b strings.Builder
+	for _, v := range vars {
+		if val, ok := out[v]; ok {
+			b.WriteString("=")
+			b.WriteString(val)
+		}
+		b.WriteByte(0)
+	}
+	return b.String()
+}
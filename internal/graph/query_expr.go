@@ -0,0 +1,318 @@
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// row is one solution: values of node/predicate variables and the triples
+// bound to edge variables.
+type row struct {
+	vals  map[string]string
+	edges map[string]*Triple
+}
+
+func (r row) extend() row {
+	out := row{vals: make(map[string]string, len(r.vals)+3), edges: r.edges}
+	for k, v := range r.vals {
+		out.vals[k] = v
+	}
+	return out
+}
+
+func (r row) withEdge(name string, t *Triple) row {
+	edges := make(map[string]*Triple, len(r.edges)+1)
+	for k, v := range r.edges {
+		edges[k] = v
+	}
+	edges[name] = t
+	r.edges = edges
+	return r
+}
+
+// lookup returns the value of a variable. An edge variable renders as
+// "subject predicate object".
+func (r row) lookup(name string) (string, bool) {
+	if v, ok := r.vals[name]; ok {
+		return v, true
+	}
+	if t, ok := r.edges[name]; ok {
+		return t.Subject + " " + t.Predicate + " " + t.Object, true
+	}
+	return "", false
+}
+
+// value is the result of a FILTER expression. Evaluation errors, such as
+// comparing an unbound variable, yield an unbound value, which is false.
+type value struct {
+	bound  bool
+	isBool bool
+	s      string
+	b      bool
+}
+
+func strValue(s string) value { return value{bound: true, s: s} }
+func boolValue(b bool) value  { return value{bound: true, isBool: true, b: b} }
+
+func (v value) truthy() bool {
+	switch {
+	case !v.bound:
+		return false
+	case v.isBool:
+		return v.b
+	default:
+		return v.s != ""
+	}
+}
+
+func (v value) str() string {
+	if v.isBool {
+		return strconv.FormatBool(v.b)
+	}
+	return v.s
+}
+
+// compareValues orders two values numerically when both are numbers and
+// lexically otherwise.
+func compareValues(a, b string) int {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+type expr interface {
+	eval(r row) value
+	String() string
+}
+
+// walkExpr calls fn for e and every subexpression.
+func walkExpr(e expr, fn func(expr)) {
+	fn(e)
+	switch e := e.(type) {
+	case *notExpr:
+		walkExpr(e.x, fn)
+	case *logicExpr:
+		walkExpr(e.left, fn)
+		walkExpr(e.right, fn)
+	case *cmpExpr:
+		walkExpr(e.left, fn)
+		walkExpr(e.right, fn)
+	case *inExpr:
+		walkExpr(e.x, fn)
+		for _, x := range e.list {
+			walkExpr(x, fn)
+		}
+	case *callExpr:
+		for _, x := range e.args {
+			walkExpr(x, fn)
+		}
+	}
+}
+
+// exprVars returns the variables an expression reads, including the
+// arguments of bound() and meta().
+func exprVars(e expr) []string {
+	var vars []string
+	walkExpr(e, func(x expr) {
+		if v, ok := x.(*varExpr); ok {
+			vars = append(vars, v.name)
+		}
+	})
+	return vars
+}
+
+type varExpr struct{ name string }
+
+func (e *varExpr) eval(r row) value {
+	if v, ok := r.lookup(e.name); ok {
+		return strValue(v)
+	}
+	return value{}
+}
+
+func (e *varExpr) String() string { return "?" + e.name }
+
+type litExpr struct{ value string }
+
+func (e *litExpr) eval(row) value { return strValue(e.value) }
+
+func (e *litExpr) String() string {
+	if isNumber(e.value) {
+		return e.value
+	}
+	return strconv.Quote(e.value)
+}
+
+type boolExpr struct{ value bool }
+
+func (e *boolExpr) eval(row) value { return boolValue(e.value) }
+func (e *boolExpr) String() string { return strconv.FormatBool(e.value) }
+
+type notExpr struct{ x expr }
+
+func (e *notExpr) eval(r row) value {
+	v := e.x.eval(r)
+	if !v.bound {
+		return value{}
+	}
+	return boolValue(!v.truthy())
+}
+
+func (e *notExpr) String() string { return "!" + e.x.String() }
+
+type logicExpr struct {
+	op          string
+	left, right expr
+}
+
+func (e *logicExpr) eval(r row) value {
+	l := e.left.eval(r).truthy()
+	if e.op == "&&" {
+		return boolValue(l && e.right.eval(r).truthy())
+	}
+	return boolValue(l || e.right.eval(r).truthy())
+}
+
+func (e *logicExpr) String() string {
+	return fmt.Sprintf("(%s %s %s)", e.left, e.op, e.right)
+}
+
+type cmpExpr struct {
+	op          string
+	left, right expr
+}
+
+func (e *cmpExpr) eval(r row) value {
+	l, rv := e.left.eval(r), e.right.eval(r)
+	if !l.bound || !rv.bound {
+		return value{}
+	}
+	c := compareValues(l.str(), rv.str())
+	switch e.op {
+	case "=":
+		return boolValue(c == 0)
+	case "!=":
+		return boolValue(c != 0)
+	case "<":
+		return boolValue(c < 0)
+	case "<=":
+		return boolValue(c <= 0)
+	case ">":
+		return boolValue(c > 0)
+	default:
+		return boolValue(c >= 0)
+	}
+}
+
+func (e *cmpExpr) String() string {
+	return fmt.Sprintf("%s %s %s", e.left, e.op, e.right)
+}
+
+type inExpr struct {
+	x    expr
+	list []expr
+	not  bool
+}
+
+func (e *inExpr) eval(r row) value {
+	v := e.x.eval(r)
+	if !v.bound {
+		return value{}
+	}
+	for _, item := range e.list {
+		if iv := item.eval(r); iv.bound && compareValues(v.str(), iv.str()) == 0 {
+			return boolValue(!e.not)
+		}
+	}
+	return boolValue(e.not)
+}
+
+func (e *inExpr) String() string {
+	items := make([]string, len(e.list))
+	for i, x := range e.list {
+		items[i] = x.String()
+	}
+	op := "IN"
+	if e.not {
+		op = "NOT IN"
+	}
+	return fmt.Sprintf("%s %s (%s)", e.x, op, strings.Join(items, ", "))
+}
+
+// filterFuncs maps FILTER function names to their arity.
+var filterFuncs = map[string]int{
+	"bound":     1,
+	"contains":  2,
+	"strstarts": 2,
+	"strends":   2,
+	"regex":     2,
+	"lcase":     1,
+	"meta":      2,
+}
+
+type callExpr struct {
+	name string
+	args []expr
+	re   *regexp.Regexp // compiled pattern for regex
+}
+
+func (e *callExpr) eval(r row) value {
+	switch e.name {
+	case "bound":
+		_, ok := r.lookup(e.args[0].(*varExpr).name)
+		return boolValue(ok)
+	case "meta":
+		t, ok := r.edges[e.args[0].(*varExpr).name]
+		if !ok {
+			return value{}
+		}
+		v, ok := t.Metadata[e.args[1].(*litExpr).value]
+		if !ok {
+			return value{}
+		}
+		return strValue(v)
+	}
+
+	args := make([]string, len(e.args))
+	for i, a := range e.args {
+		v := a.eval(r)
+		if !v.bound {
+			return value{}
+		}
+		args[i] = v.str()
+	}
+	switch e.name {
+	case "contains":
+		return boolValue(strings.Contains(args[0], args[1]))
+	case "strstarts":
+		return boolValue(strings.HasPrefix(args[0], args[1]))
+	case "strends":
+		return boolValue(strings.HasSuffix(args[0], args[1]))
+	case "regex":
+		return boolValue(e.re.MatchString(args[0]))
+	case "lcase":
+		return strValue(strings.ToLower(args[0]))
+	default:
+		return value{}
+	}
+}
+
+func (e *callExpr) String() string {
+	args := make([]string, len(e.args))
+	for i, a := range e.args {
+		args[i] = a.String()
+	}
+	return fmt.Sprintf("%s(%s)", e.name, strings.Join(args, ", "))
+}
@@ -0,0 +1,691 @@
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseQuery parses a graph query. The language is a subset of SPARQL:
+//
+//	SELECT [DISTINCT] ?a ?b | *
+//	WHERE {
+//	    ?a caused_by ?b .
+//	    ?b resolved_by ?fix AS ?e .
+//	    OPTIONAL { ?fix related_to ?note . }
+//	    FILTER(meta(?e, "confidence") >= 0.8 && ?b != "error:unknown")
+//	}
+//	ORDER BY DESC(?a) ?b
+//	LIMIT 10 OFFSET 0
+//
+// Patterns are subject, predicate and object, each a ?variable or a
+// constant. Constants are bare words (letters, digits and _-:/.@#+%~) or
+// quoted strings; quote values that collide with keywords. "AS ?e" binds the
+// matched triple so FILTER can read its metadata with meta(?e, "key").
+//
+// The SELECT and WHERE keywords and the braces are optional, so a bare
+// pattern list such as "?x caused_by ?y . ?y resolved_by ?z" is a query
+// selecting every variable.
+//
+// FILTER supports = != < <= > >= (numeric when both sides are numbers),
+// && || !, IN (...), NOT IN (...), and the functions bound, contains,
+// strstarts, strends, regex, lcase and meta.
+func ParseQuery(src string) (*Query, error) {
+	toks, err := lexQuery(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{toks: toks}
+	q, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	if err := q.resolve(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// --- lexer ---
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokVar
+	tokIdent
+	tokString
+	tokNumber
+	tokPunct
+)
+
+type token struct {
+	kind tokKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of query"
+	case tokVar:
+		return "?" + t.text
+	default:
+		return strconv.Quote(t.text)
+	}
+}
+
+var queryKeywords = map[string]bool{
+	"SELECT": true, "DISTINCT": true, "WHERE": true, "OPTIONAL": true, "FILTER": true,
+	"ORDER": true, "BY": true, "ASC": true, "DESC": true, "LIMIT": true, "OFFSET": true,
+	"AS": true, "IN": true, "NOT": true,
+}
+
+func isKeyword(s string) bool { return queryKeywords[strings.ToUpper(s)] }
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-:/.@#+%~", r)
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+func lexQuery(src string) ([]token, error) {
+	var toks []token
+	i := 0
+	for i < len(src) {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '?':
+			start := i + 1
+			j := start
+			for j < len(src) {
+				r, size := utf8.DecodeRuneInString(src[j:])
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+					break
+				}
+				j += size
+			}
+			if j == start {
+				return nil, fmt.Errorf("position %d: expected variable name after '?'", i)
+			}
+			toks = append(toks, token{kind: tokVar, text: src[start:j], pos: i})
+			i = j
+		case r == '"' || r == '\'':
+			s, n, err := lexString(src[i:])
+			if err != nil {
+				return nil, fmt.Errorf("position %d: %w", i, err)
+			}
+			toks = append(toks, token{kind: tokString, text: s, pos: i})
+			i += n
+		case strings.HasPrefix(src[i:], "&&"), strings.HasPrefix(src[i:], "||"),
+			strings.HasPrefix(src[i:], "!="), strings.HasPrefix(src[i:], "<="), strings.HasPrefix(src[i:], ">="):
+			toks = append(toks, token{kind: tokPunct, text: src[i : i+2], pos: i})
+			i += 2
+		case strings.ContainsRune("{}(),=<>!*", r):
+			toks = append(toks, token{kind: tokPunct, text: string(r), pos: i})
+			i += size
+		case r == '.' && (i+1 == len(src) || !isIdentRune(rune(src[i+1]))):
+			toks = append(toks, token{kind: tokPunct, text: ".", pos: i})
+			i++
+		case isIdentRune(r):
+			j := i
+			for j < len(src) {
+				r, size := utf8.DecodeRuneInString(src[j:])
+				if !isIdentRune(r) {
+					break
+				}
+				j += size
+			}
+			// A trailing '.' terminates the pattern rather than the word.
+			end := j
+			for end > i && src[end-1] == '.' {
+				end--
+			}
+			text := src[i:end]
+			kind := tokIdent
+			if isNumber(text) {
+				kind = tokNumber
+			}
+			toks = append(toks, token{kind: kind, text: text, pos: i})
+			for k := end; k < j; k++ {
+				toks = append(toks, token{kind: tokPunct, text: ".", pos: k})
+			}
+			i = j
+		default:
+			return nil, fmt.Errorf("position %d: unexpected character %q", i, r)
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// lexString reads a quoted string starting at s[0], returning its value and
+// the number of bytes consumed.
+func lexString(s string) (string, int, error) {
+	quote := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == quote:
+			return b.String(), i + 1, nil
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+// --- parser ---
+
+type queryParser struct {
+	toks []token
+	pos  int
+}
+
+func (p *queryParser) peek() token { return p.toks[p.pos] }
+
+func (p *queryParser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *queryParser) isPunct(s string) bool {
+	t := p.peek()
+	return t.kind == tokPunct && t.text == s
+}
+
+func (p *queryParser) isKeyword(kw string) bool {
+	t := p.peek()
+	return t.kind == tokIdent && strings.EqualFold(t.text, kw)
+}
+
+func (p *queryParser) acceptPunct(s string) bool {
+	if p.isPunct(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) acceptKeyword(kw string) bool {
+	if p.isKeyword(kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) expectPunct(s string) error {
+	if !p.acceptPunct(s) {
+		return p.errorf("expected %q, got %s", s, p.peek())
+	}
+	return nil
+}
+
+func (p *queryParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("query syntax error at position %d: %s", p.peek().pos, fmt.Sprintf(format, args...))
+}
+
+func (p *queryParser) parseQuery() (*Query, error) {
+	q := &Query{}
+	if p.acceptKeyword("SELECT") {
+		q.distinct = p.acceptKeyword("DISTINCT")
+		if !p.acceptPunct("*") {
+			for p.peek().kind == tokVar {
+				q.selectVars = append(q.selectVars, p.next().text)
+			}
+			if len(q.selectVars) == 0 {
+				return nil, p.errorf("expected variables or '*' after SELECT, got %s", p.peek())
+			}
+		}
+	}
+	p.acceptKeyword("WHERE")
+
+	var err error
+	if p.acceptPunct("{") {
+		if q.where, err = p.parseGroup("}"); err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct("}"); err != nil {
+			return nil, err
+		}
+	} else if q.where, err = p.parseGroup(""); err != nil {
+		return nil, err
+	}
+
+	if err := p.parseModifiers(q); err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorf("unexpected %s", t)
+	}
+	return q, nil
+}
+
+// parseGroup parses patterns, FILTERs and OPTIONAL groups until the closing
+// punctuation (or, for a bare query, a solution modifier or the end).
+func (p *queryParser) parseGroup(closing string) (*group, error) {
+	g := &group{}
+	for {
+		switch {
+		case closing != "" && p.isPunct(closing):
+			return g, nil
+		case closing == "" && (p.peek().kind == tokEOF || p.isKeyword("ORDER") || p.isKeyword("LIMIT") || p.isKeyword("OFFSET")):
+			return g, nil
+		case p.peek().kind == tokEOF:
+			return nil, p.errorf("expected %q, got %s", closing, p.peek())
+		case p.acceptPunct("."):
+			continue
+		case p.acceptKeyword("OPTIONAL"):
+			if err := p.expectPunct("{"); err != nil {
+				return nil, err
+			}
+			opt, err := p.parseGroup("}")
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct("}"); err != nil {
+				return nil, err
+			}
+			if len(opt.patterns) == 0 {
+				return nil, p.errorf("OPTIONAL group has no triple patterns")
+			}
+			g.optional = append(g.optional, opt)
+		case p.acceptKeyword("FILTER"):
+			if err := p.expectPunct("("); err != nil {
+				return nil, err
+			}
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return nil, err
+			}
+			g.filters = append(g.filters, e)
+		default:
+			pat, err := p.parsePattern()
+			if err != nil {
+				return nil, err
+			}
+			g.patterns = append(g.patterns, pat)
+		}
+	}
+}
+
+func (p *queryParser) parsePattern() (*pattern, error) {
+	var terms [3]term
+	for i := range terms {
+		t, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		terms[i] = t
+	}
+	pat := &pattern{subject: terms[0], predicate: terms[1], object: terms[2]}
+	if p.acceptKeyword("AS") {
+		t := p.next()
+		if t.kind != tokVar {
+			return nil, p.errorf("expected variable after AS, got %s", t)
+		}
+		pat.edge = t.text
+	}
+	return pat, nil
+}
+
+func (p *queryParser) parseTerm() (term, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokVar:
+		p.next()
+		return term{varName: t.text}, nil
+	case tokString, tokNumber:
+		p.next()
+		return term{value: t.text}, nil
+	case tokIdent:
+		if isKeyword(t.text) {
+			return term{}, p.errorf("unexpected keyword %s in triple pattern (quote it to use it as a value)", t)
+		}
+		p.next()
+		return term{value: t.text}, nil
+	default:
+		return term{}, p.errorf("expected variable or value in triple pattern, got %s", t)
+	}
+}
+
+func (p *queryParser) parseModifiers(q *Query) error {
+	if p.acceptKeyword("ORDER") {
+		if !p.acceptKeyword("BY") {
+			return p.errorf("expected BY after ORDER, got %s", p.peek())
+		}
+		for {
+			var key orderKey
+			switch {
+			case p.peek().kind == tokVar:
+				key.varName = p.next().text
+			case p.isKeyword("ASC") || p.isKeyword("DESC"):
+				key.desc = strings.EqualFold(p.next().text, "DESC")
+				if err := p.expectPunct("("); err != nil {
+					return err
+				}
+				t := p.next()
+				if t.kind != tokVar {
+					return p.errorf("expected variable in ORDER BY, got %s", t)
+				}
+				key.varName = t.text
+				if err := p.expectPunct(")"); err != nil {
+					return err
+				}
+			}
+			if key.varName == "" {
+				break
+			}
+			q.orderBy = append(q.orderBy, key)
+		}
+		if len(q.orderBy) == 0 {
+			return p.errorf("expected variable after ORDER BY, got %s", p.peek())
+		}
+	}
+	for {
+		switch {
+		case p.acceptKeyword("LIMIT"):
+			n, err := p.parseCount("LIMIT")
+			if err != nil {
+				return err
+			}
+			q.limit = n
+		case p.acceptKeyword("OFFSET"):
+			n, err := p.parseCount("OFFSET")
+			if err != nil {
+				return err
+			}
+			q.offset = n
+		default:
+			return nil
+		}
+	}
+}
+
+func (p *queryParser) parseCount(kw string) (int, error) {
+	t := p.next()
+	n, err := strconv.Atoi(t.text)
+	if t.kind != tokNumber || err != nil || n < 0 {
+		return 0, p.errorf("expected non-negative integer after %s, got %s", kw, t)
+	}
+	return n, nil
+}
+
+// --- expressions ---
+
+func (p *queryParser) parseExpr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptPunct("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicExpr{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptPunct("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicExpr{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseUnary() (expr, error) {
+	if p.acceptPunct("!") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{x: x}, nil
+	}
+	return p.parseComparison()
+}
+
+var comparisonOps = map[string]bool{"=": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true}
+
+func (p *queryParser) parseComparison() (expr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind == tokPunct && comparisonOps[t.text] {
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &cmpExpr{op: t.text, left: left, right: right}, nil
+	}
+
+	not := false
+	if p.isKeyword("NOT") && p.toks[p.pos+1].kind == tokIdent && strings.EqualFold(p.toks[p.pos+1].text, "IN") {
+		p.pos++
+		not = true
+	}
+	if p.acceptKeyword("IN") {
+		if err := p.expectPunct("("); err != nil {
+			return nil, err
+		}
+		in := &inExpr{x: left, not: not}
+		for !p.acceptPunct(")") {
+			if len(in.list) > 0 {
+				if err := p.expectPunct(","); err != nil {
+					return nil, err
+				}
+			}
+			e, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			in.list = append(in.list, e)
+		}
+		return in, nil
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseOperand() (expr, error) {
+	t := p.peek()
+	switch {
+	case p.acceptPunct("("):
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case t.kind == tokVar:
+		p.next()
+		return &varExpr{name: t.text}, nil
+	case t.kind == tokString || t.kind == tokNumber:
+		p.next()
+		return &litExpr{value: t.text}, nil
+	case t.kind == tokIdent && p.toks[p.pos+1].kind == tokPunct && p.toks[p.pos+1].text == "(":
+		return p.parseCall()
+	case t.kind == tokIdent && (strings.EqualFold(t.text, "true") || strings.EqualFold(t.text, "false")):
+		p.next()
+		return &boolExpr{value: strings.EqualFold(t.text, "true")}, nil
+	default:
+		return nil, p.errorf("expected variable, value or function in FILTER, got %s", t)
+	}
+}
+
+func (p *queryParser) parseCall() (expr, error) {
+	nameTok := p.next()
+	name := strings.ToLower(nameTok.text)
+	arity, ok := filterFuncs[name]
+	if !ok {
+		return nil, fmt.Errorf("query syntax error at position %d: unknown function %s", nameTok.pos, nameTok.text)
+	}
+	p.next() // (
+	call := &callExpr{name: name}
+	for !p.acceptPunct(")") {
+		if len(call.args) > 0 {
+			if err := p.expectPunct(","); err != nil {
+				return nil, err
+			}
+		}
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, e)
+	}
+	if len(call.args) != arity {
+		return nil, fmt.Errorf("query syntax error at position %d: %s takes %d arguments, got %d", nameTok.pos, name, arity, len(call.args))
+	}
+
+	switch name {
+	case "regex":
+		lit, ok := call.args[1].(*litExpr)
+		if !ok {
+			return nil, fmt.Errorf("query syntax error at position %d: regex pattern must be a string", nameTok.pos)
+		}
+		re, err := regexp.Compile(lit.value)
+		if err != nil {
+			return nil, fmt.Errorf("query syntax error at position %d: regex: %w", nameTok.pos, err)
+		}
+		call.re = re
+	case "bound":
+		if _, ok := call.args[0].(*varExpr); !ok {
+			return nil, fmt.Errorf("query syntax error at position %d: bound takes a variable", nameTok.pos)
+		}
+	case "meta":
+		if _, ok := call.args[0].(*varExpr); !ok {
+			return nil, fmt.Errorf("query syntax error at position %d: meta takes an edge variable bound with AS", nameTok.pos)
+		}
+		if _, ok := call.args[1].(*litExpr); !ok {
+			return nil, fmt.Errorf("query syntax error at position %d: meta key must be a string", nameTok.pos)
+		}
+	}
+	return call, nil
+}
+
+// --- validation ---
+
+// resolve collects the query's variables and checks that every variable
+// used in SELECT, ORDER BY and FILTER is bound by some pattern.
+func (q *Query) resolve() error {
+	if len(q.where.patterns) == 0 {
+		return fmt.Errorf("query needs at least one triple pattern outside OPTIONAL")
+	}
+
+	q.edges = make(map[string]bool)
+	seen := make(map[string]bool)
+	var walk func(g *group) error
+	walk = func(g *group) error {
+		for _, pat := range g.patterns {
+			for _, t := range pat.terms() {
+				if t.isVar() && !seen[t.varName] {
+					seen[t.varName] = true
+					q.vars = append(q.vars, t.varName)
+				}
+			}
+			if pat.edge != "" {
+				if q.edges[pat.edge] {
+					return fmt.Errorf("edge variable ?%s is bound by more than one pattern", pat.edge)
+				}
+				q.edges[pat.edge] = true
+			}
+		}
+		for _, opt := range g.optional {
+			if err := walk(opt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(q.where); err != nil {
+		return err
+	}
+	for e := range q.edges {
+		if seen[e] {
+			return fmt.Errorf("?%s is used both as an edge variable and in a pattern", e)
+		}
+	}
+
+	known := func(v string) bool { return seen[v] || q.edges[v] }
+	for _, v := range q.selectVars {
+		if !known(v) {
+			return fmt.Errorf("SELECT variable ?%s does not appear in any pattern", v)
+		}
+	}
+	for _, k := range q.orderBy {
+		if !known(k.varName) {
+			return fmt.Errorf("ORDER BY variable ?%s does not appear in any pattern", k.varName)
+		}
+	}
+
+	var checkFilters func(g *group) error
+	checkFilters = func(g *group) error {
+		for _, f := range g.filters {
+			var err error
+			walkExpr(f, func(e expr) {
+				switch e := e.(type) {
+				case *varExpr:
+					if err == nil && !known(e.name) {
+						err = fmt.Errorf("FILTER variable ?%s does not appear in any pattern", e.name)
+					}
+				case *callExpr:
+					if e.name != "meta" || err != nil {
+						return
+					}
+					if v := e.args[0].(*varExpr); !q.edges[v.name] {
+						err = fmt.Errorf("meta(?%s, ...) needs an edge variable bound with AS", v.name)
+					}
+				}
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, opt := range g.optional {
+			if err := checkFilters(opt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return checkFilters(q.where)
+}
@@ -0,0 +1,195 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// estimateLimit caps the keys counted when estimating a pattern's size.
+const estimateLimit = 10_000
+
+// Selectivity of a position bound by an earlier join, relative to the
+// pattern's estimate from its constants alone. Subjects and objects are
+// node IDs and narrow a scan sharply; predicates are few and narrow it
+// little.
+const (
+	nodeJoinSelectivity      = 0.01
+	predicateJoinSelectivity = 0.2
+)
+
+// groupPlan is the execution plan of a group: join steps in order, the
+// plans of its optional groups, and the filters that must wait for them.
+type groupPlan struct {
+	steps    []*planStep
+	optional []*groupPlan
+	post     []expr
+}
+
+// planStep joins one pattern into the solutions.
+type planStep struct {
+	pattern  *pattern
+	index    Index
+	est      int      // matches for the pattern's constants alone
+	joinVars []string // variables bound before this step
+	filters  []expr   // filters whose variables are all bound after this step
+}
+
+// planQuery orders the joins of every group greedily: at each step it picks
+// the cheapest pattern connected to the variables bound so far, where the
+// cost is the index estimate for the pattern's constants scaled down for
+// each position an earlier step binds.
+func planQuery(ctx context.Context, q *Query, m PatternMatcher) (*groupPlan, error) {
+	return planGroup(ctx, q.where, map[string]bool{}, m)
+}
+
+func planGroup(ctx context.Context, g *group, outer map[string]bool, m PatternMatcher) (*groupPlan, error) {
+	bound := make(map[string]bool, len(outer))
+	for v := range outer {
+		bound[v] = true
+	}
+
+	ests := make([]int, len(g.patterns))
+	for i, pat := range g.patterns {
+		var c [3]string
+		for j, t := range pat.terms() {
+			if !t.isVar() {
+				c[j] = t.value
+			}
+		}
+		n, err := m.EstimatePattern(ctx, c[0], c[1], c[2], estimateLimit)
+		if err != nil {
+			return nil, fmt.Errorf("estimate pattern %s: %w", pat, err)
+		}
+		ests[i] = n
+	}
+
+	gp := &groupPlan{}
+	placed := make([]bool, len(g.patterns))
+	pending := append([]expr(nil), g.filters...)
+
+	for range g.patterns {
+		best := -1
+		var bestCost float64
+		bestConnected := false
+		for i, pat := range g.patterns {
+			if placed[i] {
+				continue
+			}
+			cost, connected := joinCost(pat, ests[i], bound)
+			// A pattern sharing no variable with the solutions so far is a
+			// cross product; take one only when nothing else is left.
+			connected = connected || len(bound) == 0
+			if best < 0 || connected && !bestConnected || connected == bestConnected && cost < bestCost {
+				best, bestCost, bestConnected = i, cost, connected
+			}
+		}
+		placed[best] = true
+		pat := g.patterns[best]
+
+		step := &planStep{pattern: pat, est: ests[best]}
+		var isBound [3]bool
+		for j, t := range pat.terms() {
+			isBound[j] = !t.isVar() || bound[t.varName]
+			if t.isVar() && bound[t.varName] && !containsString(step.joinVars, t.varName) {
+				step.joinVars = append(step.joinVars, t.varName)
+			}
+		}
+		step.index = IndexFor(isBound[0], isBound[1], isBound[2])
+
+		for _, t := range pat.terms() {
+			if t.isVar() {
+				bound[t.varName] = true
+			}
+		}
+		if pat.edge != "" {
+			bound[pat.edge] = true
+		}
+
+		// Push each filter down to the first step that binds its variables.
+		rest := pending[:0]
+		for _, f := range pending {
+			if allBound(exprVars(f), bound) {
+				step.filters = append(step.filters, f)
+			} else {
+				rest = append(rest, f)
+			}
+		}
+		pending = rest
+		gp.steps = append(gp.steps, step)
+	}
+
+	for _, opt := range g.optional {
+		op, err := planGroup(ctx, opt, bound, m)
+		if err != nil {
+			return nil, err
+		}
+		gp.optional = append(gp.optional, op)
+	}
+	gp.post = pending
+	return gp, nil
+}
+
+// joinCost estimates the matches per input solution of a pattern given the
+// variables already bound, and reports whether it shares one of them.
+func joinCost(pat *pattern, est int, bound map[string]bool) (float64, bool) {
+	cost := float64(est)
+	connected := false
+	for j, t := range pat.terms() {
+		if !t.isVar() || !bound[t.varName] {
+			continue
+		}
+		connected = true
+		if j == 1 {
+			cost *= predicateJoinSelectivity
+		} else {
+			cost *= nodeJoinSelectivity
+		}
+	}
+	return cost, connected
+}
+
+func allBound(vars []string, bound map[string]bool) bool {
+	for _, v := range vars {
+		if !bound[v] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the plan for EXPLAIN output.
+func (gp *groupPlan) String() string {
+	var b strings.Builder
+	gp.write(&b, "")
+	return b.String()
+}
+
+func (gp *groupPlan) write(b *strings.Builder, indent string) {
+	for i, st := range gp.steps {
+		fmt.Fprintf(b, "%s%d. %s  [%s", indent, i+1, st.pattern, st.index)
+		if len(st.joinVars) > 0 {
+			fmt.Fprintf(b, ", join ?%s", strings.Join(st.joinVars, " ?"))
+		}
+		fmt.Fprintf(b, ", est %d]\n", st.est)
+		for _, f := range st.filters {
+			fmt.Fprintf(b, "%s   FILTER %s\n", indent, f)
+		}
+	}
+	for _, opt := range gp.optional {
+		fmt.Fprintf(b, "%sOPTIONAL\n", indent)
+		opt.write(b, indent+"  ")
+	}
+	for _, f := range gp.post {
+		fmt.Fprintf(b, "%sFILTER %s\n", indent, f)
+	}
+}
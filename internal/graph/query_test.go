@@ -0,0 +1,315 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/langoai/lango/internal/agent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedQueryStore builds a small incident graph: observations caused by
+// errors, errors resolved by fixes, and sessions containing observations.
+func seedQueryStore(t *testing.T) *BoltStore {
+	t.Helper()
+	store := newTestStore(t)
+	err := store.AddTriples(context.Background(), []Triple{
+		{Subject: "obs:1", Predicate: CausedBy, Object: "error:timeout", Metadata: map[string]string{"confidence": "0.9"}},
+		{Subject: "obs:2", Predicate: CausedBy, Object: "error:timeout", Metadata: map[string]string{"confidence": "0.4"}},
+		{Subject: "obs:3", Predicate: CausedBy, Object: "error:oom", Metadata: map[string]string{"confidence": "0.8"}},
+		{Subject: "error:timeout", Predicate: ResolvedBy, Object: "fix:retry"},
+		{Subject: "error:oom", Predicate: ResolvedBy, Object: "fix:limits"},
+		{Subject: "fix:retry", Predicate: RelatedTo, Object: "note:backoff"},
+		{Subject: "session:a", Predicate: Contains, Object: "obs:1"},
+		{Subject: "session:a", Predicate: Contains, Object: "obs:2"},
+		{Subject: "session:b", Predicate: Contains, Object: "obs:3"},
+	})
+	require.NoError(t, err)
+	return store
+}
+
+func TestGolden_Query(t *testing.T) {
+	t.Parallel()
+	store := seedQueryStore(t)
+
+	tests := []struct {
+		give     string
+		query    string
+		wantVars []string
+		wantRows []map[string]string
+	}{
+		{
+			give:     "single pattern with constant predicate",
+			query:    `SELECT ?fix WHERE { error:oom resolved_by ?fix }`,
+			wantVars: []string{"fix"},
+			wantRows: []map[string]string{{"fix": "fix:limits"}},
+		},
+		{
+			give:     "two hop join",
+			query:    `SELECT ?obs ?fix WHERE { ?obs caused_by ?err . ?err resolved_by ?fix } ORDER BY ?obs`,
+			wantVars: []string{"obs", "fix"},
+			wantRows: []map[string]string{
+				{"obs": "obs:1", "fix": "fix:retry"},
+				{"obs": "obs:2", "fix": "fix:retry"},
+				{"obs": "obs:3", "fix": "fix:limits"},
+			},
+		},
+		{
+			give:     "bare pattern list selects every variable",
+			query:    `session:b contains ?obs . ?obs caused_by ?err`,
+			wantVars: []string{"obs", "err"},
+			wantRows: []map[string]string{{"obs": "obs:3", "err": "error:oom"}},
+		},
+		{
+			give:     "variable predicate",
+			query:    `SELECT ?p ?o WHERE { error:timeout ?p ?o }`,
+			wantVars: []string{"p", "o"},
+			wantRows: []map[string]string{{"p": "resolved_by", "o": "fix:retry"}},
+		},
+		{
+			give:     "filter on predicate",
+			query:    `SELECT ?s ?o WHERE { ?s ?p ?o . FILTER(?p IN ("resolved_by", "related_to")) } ORDER BY ?s`,
+			wantVars: []string{"s", "o"},
+			wantRows: []map[string]string{
+				{"s": "error:oom", "o": "fix:limits"},
+				{"s": "error:timeout", "o": "fix:retry"},
+				{"s": "fix:retry", "o": "note:backoff"},
+			},
+		},
+		{
+			give:     "filter on edge metadata",
+			query:    `SELECT ?obs WHERE { ?obs caused_by ?err AS ?e . FILTER(meta(?e, "confidence") >= 0.8) } ORDER BY ?obs`,
+			wantVars: []string{"obs"},
+			wantRows: []map[string]string{{"obs": "obs:1"}, {"obs": "obs:3"}},
+		},
+		{
+			give:     "filter with string functions",
+			query:    `SELECT ?s WHERE { ?s contains ?o . FILTER(strends(?o, "2") || regex(?s, "^session:b$")) } ORDER BY ?s`,
+			wantVars: []string{"s"},
+			wantRows: []map[string]string{{"s": "session:a"}, {"s": "session:b"}},
+		},
+		{
+			give:     "optional keeps unmatched rows",
+			query:    `SELECT ?fix ?note WHERE { ?err resolved_by ?fix . OPTIONAL { ?fix related_to ?note } } ORDER BY ?fix`,
+			wantVars: []string{"fix", "note"},
+			wantRows: []map[string]string{
+				{"fix": "fix:limits"},
+				{"fix": "fix:retry", "note": "note:backoff"},
+			},
+		},
+		{
+			give:     "filter on optional variable",
+			query:    `SELECT ?fix WHERE { ?err resolved_by ?fix . OPTIONAL { ?fix related_to ?note } FILTER(!bound(?note)) }`,
+			wantVars: []string{"fix"},
+			wantRows: []map[string]string{{"fix": "fix:limits"}},
+		},
+		{
+			give:     "distinct",
+			query:    `SELECT DISTINCT ?err WHERE { ?obs caused_by ?err } ORDER BY ?err`,
+			wantVars: []string{"err"},
+			wantRows: []map[string]string{{"err": "error:oom"}, {"err": "error:timeout"}},
+		},
+		{
+			give:     "edge variable with descending order and limit",
+			query:    `SELECT ?obs ?e WHERE { ?obs caused_by ?err AS ?e } ORDER BY DESC(?obs) LIMIT 2`,
+			wantVars: []string{"obs", "e"},
+			wantRows: []map[string]string{
+				{"obs": "obs:3", "e": "obs:3 caused_by error:oom"},
+				{"obs": "obs:2", "e": "obs:2 caused_by error:timeout"},
+			},
+		},
+		{
+			give:     "offset",
+			query:    `SELECT ?obs WHERE { ?obs caused_by ?err } ORDER BY ?obs LIMIT 1 OFFSET 1`,
+			wantVars: []string{"obs"},
+			wantRows: []map[string]string{{"obs": "obs:2"}},
+		},
+		{
+			give:     "repeated variable within a pattern",
+			query:    `SELECT ?x WHERE { ?x caused_by ?x }`,
+			wantVars: []string{"x"},
+			wantRows: []map[string]string{},
+		},
+		{
+			give:     "no matches",
+			query:    `SELECT ?fix WHERE { error:unknown resolved_by ?fix }`,
+			wantVars: []string{"fix"},
+			wantRows: []map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			got, err := RunQuery(context.Background(), store, tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVars, got.Vars)
+			assert.Equal(t, tt.wantRows, got.Rows)
+		})
+	}
+}
+
+func TestGolden_QueryPlan(t *testing.T) {
+	t.Parallel()
+	store := seedQueryStore(t)
+
+	tests := []struct {
+		give  string
+		query string
+		want  string
+	}{
+		{
+			give:  "constant subject starts the join",
+			query: `?obs caused_by ?err . ?err resolved_by ?fix . session:b contains ?obs`,
+			want: "1. session:b contains ?obs  [spo, est 1]\n" +
+				"2. ?obs caused_by ?err  [spo, join ?obs, est 3]\n" +
+				"3. ?err resolved_by ?fix  [spo, join ?err, est 2]\n",
+		},
+		{
+			give:  "more selective pattern scanned first",
+			query: `?s contains ?obs . ?obs caused_by error:oom AS ?e . FILTER(meta(?e, "confidence") > 0.5)`,
+			want: "1. ?obs caused_by error:oom AS ?e  [pos, est 1]\n" +
+				"   FILTER meta(?e, \"confidence\") > 0.5\n" +
+				"2. ?s contains ?obs  [pos, join ?obs, est 3]\n",
+		},
+		{
+			give:  "optional group and post filter",
+			query: `SELECT ?fix WHERE { ?err resolved_by ?fix . OPTIONAL { ?fix related_to ?note } FILTER(!bound(?note)) }`,
+			want: "1. ?err resolved_by ?fix  [pos, est 2]\n" +
+				"OPTIONAL\n" +
+				"  1. ?fix related_to ?note  [spo, join ?fix, est 1]\n" +
+				"FILTER !bound(?note)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			q, err := ParseQuery(tt.query)
+			require.NoError(t, err)
+			got, err := q.Explain(context.Background(), store)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give    string
+		wantErr string
+	}{
+		{give: ``, wantErr: "pattern"},
+		{give: `SELECT ?x WHERE { ?x caused_by }`, wantErr: "expected"},
+		{give: `SELECT ?y WHERE { ?x caused_by ?z }`, wantErr: "?y"},
+		{give: `?x caused_by ?z ORDER BY ?w`, wantErr: "?w"},
+		{give: `?x caused_by ?z . FILTER(?w = "a")`, wantErr: "?w"},
+		{give: `?x caused_by ?z . FILTER(meta(?x, "k") = "a")`, wantErr: "edge"},
+		{give: `?x caused_by ?z . FILTER(regex(?x, "["))`, wantErr: "regex"},
+		{give: `?x caused_by ?z . FILTER(nosuch(?x))`, wantErr: "nosuch"},
+		{give: `?x caused_by ?z LIMIT many`, wantErr: "LIMIT"},
+		{give: `?x caused_by ?z . OPTIONAL { }`, wantErr: "OPTIONAL"},
+		{give: `?x caused_by "unterminated`, wantErr: "unterminated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseQuery(tt.give)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestBoltStore_MatchPattern(t *testing.T) {
+	t.Parallel()
+	store := seedQueryStore(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		give                       string
+		subject, predicate, object string
+		wantIndex                  Index
+		want                       int
+	}{
+		{give: "all bound", subject: "obs:1", predicate: CausedBy, object: "error:timeout", wantIndex: IndexSPO, want: 1},
+		{give: "subject", subject: "session:a", wantIndex: IndexSPO, want: 2},
+		{give: "subject and object", subject: "error:oom", object: "fix:limits", wantIndex: IndexOSP, want: 1},
+		{give: "predicate and object", predicate: CausedBy, object: "error:timeout", wantIndex: IndexPOS, want: 2},
+		{give: "object", object: "obs:3", wantIndex: IndexOSP, want: 1},
+		{give: "none", wantIndex: IndexScan, want: 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.wantIndex, IndexFor(tt.subject != "", tt.predicate != "", tt.object != ""))
+
+			got, err := store.MatchPattern(ctx, tt.subject, tt.predicate, tt.object)
+			require.NoError(t, err)
+			assert.Len(t, got, tt.want)
+			for _, tr := range got {
+				assert.True(t, matchesPattern(tr, tt.subject, tt.predicate, tt.object))
+			}
+
+			est, err := store.EstimatePattern(ctx, tt.subject, tt.predicate, tt.object, 100)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, est)
+		})
+	}
+}
+
+func TestBoltStore_MatchPattern_Metadata(t *testing.T) {
+	t.Parallel()
+	store := seedQueryStore(t)
+
+	got, err := store.MatchPattern(context.Background(), "", CausedBy, "error:oom")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "0.8", got[0].Metadata["confidence"])
+}
+
+// plainStore hides BoltStore's PatternMatcher so queries take the
+// storeMatcher fallback.
+type plainStore struct{ Store }
+
+func TestRunQuery_StoreFallback(t *testing.T) {
+	t.Parallel()
+	store := plainStore{seedQueryStore(t)}
+
+	got, err := RunQuery(context.Background(), store,
+		`SELECT ?obs ?fix WHERE { ?obs caused_by ?err AS ?e . ?err resolved_by ?fix . FILTER(meta(?e, "confidence") < 0.5) }`)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"obs": "obs:2", "fix": "fix:retry"}}, got.Rows)
+}
+
+func TestBuildTools_GraphQueryPattern(t *testing.T) {
+	t.Parallel()
+	store := seedQueryStore(t)
+
+	var tool *agent.Tool
+	for _, tl := range BuildTools(store) {
+		if tl.Name == "graph_query" {
+			tool = tl
+		}
+	}
+	require.NotNil(t, tool)
+
+	got, err := tool.Handler(context.Background(), map[string]interface{}{
+		"query": "SELECT ?fix WHERE { error:oom resolved_by ?fix }",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"vars":      []string{"fix"},
+		"rows":      []map[string]string{{"fix": "fix:limits"}},
+		"count":     1,
+		"truncated": false,
+	}, got)
+
+	_, err = tool.Handler(context.Background(), map[string]interface{}{"query": "?x caused_by"})
+	require.Error(t, err)
+}
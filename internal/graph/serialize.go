@@ -0,0 +1,302 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// Format names a serialization for graph export and import.
+type Format string
+
+// Format constants. JSON is lango's own triple array; CSV carries only
+// subject, predicate and object.
+const (
+	FormatJSON     Format = "json"
+	FormatCSV      Format = "csv"
+	FormatNTriples Format = "ntriples"
+	FormatTurtle   Format = "turtle"
+	FormatJSONLD   Format = "jsonld"
+	FormatGraphML  Format = "graphml"
+)
+
+// Formats lists the supported formats.
+func Formats() []Format {
+	return []Format{FormatJSON, FormatCSV, FormatNTriples, FormatTurtle, FormatJSONLD, FormatGraphML}
+}
+
+// Namespace IRIs for RDF serializations. Predicates map to Namespace,
+// node IDs to NodeNamespace, and metadata keys on reified statements to
+// MetaNamespace; local names are path-escaped.
+const (
+	Namespace     = "https://github.com/langoai/lango/ns/graph#"
+	NodeNamespace = "https://github.com/langoai/lango/ns/node/"
+	MetaNamespace = "https://github.com/langoai/lango/ns/meta#"
+)
+
+const rdfNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
+const (
+	rdfType          = rdfNamespace + "type"
+	rdfStatementType = rdfNamespace + "Statement"
+	rdfSubject       = rdfNamespace + "subject"
+	rdfPredicate     = rdfNamespace + "predicate"
+	rdfObject        = rdfNamespace + "object"
+)
+
+// rdfPrefixes are the prefixes written in Turtle and JSON-LD output.
+var rdfPrefixes = []struct{ name, iri string }{
+	{"lango", Namespace},
+	{"node", NodeNamespace},
+	{"meta", MetaNamespace},
+	{"rdf", rdfNamespace},
+}
+
+// ParseFormat resolves a format name or common alias.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "json":
+		return FormatJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	case "ntriples", "n-triples", "nt":
+		return FormatNTriples, nil
+	case "turtle", "ttl":
+		return FormatTurtle, nil
+	case "jsonld", "json-ld":
+		return FormatJSONLD, nil
+	case "graphml":
+		return FormatGraphML, nil
+	}
+	names := make([]string, 0, len(Formats()))
+	for _, f := range Formats() {
+		names = append(names, string(f))
+	}
+	return "", fmt.Errorf("unknown format %q (must be one of %s)", s, strings.Join(names, ", "))
+}
+
+// FormatFromPath guesses the format from a file extension.
+func FormatFromPath(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, true
+	case ".csv":
+		return FormatCSV, true
+	case ".nt":
+		return FormatNTriples, true
+	case ".ttl":
+		return FormatTurtle, true
+	case ".jsonld":
+		return FormatJSONLD, true
+	case ".graphml":
+		return FormatGraphML, true
+	}
+	return "", false
+}
+
+// WriteTriples serializes triples to w.
+func WriteTriples(w io.Writer, f Format, triples []Triple) error {
+	bw := bufio.NewWriter(w)
+	var err error
+	switch f {
+	case FormatJSON:
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(triples)
+	case FormatCSV:
+		err = writeCSV(bw, triples)
+	case FormatNTriples, FormatTurtle:
+		err = writeRDF(bw, triples, f == FormatTurtle)
+	case FormatJSONLD:
+		err = writeJSONLD(bw, triples)
+	case FormatGraphML:
+		err = writeGraphML(bw, triples)
+	default:
+		err = fmt.Errorf("unknown format %q", f)
+	}
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// TripleReader streams triples from a serialized graph. Next returns io.EOF
+// after the last triple.
+type TripleReader interface {
+	Next() (Triple, error)
+}
+
+// NewTripleReader returns a streaming reader for r in format f.
+func NewTripleReader(r io.Reader, f Format) (TripleReader, error) {
+	br := bufio.NewReader(r)
+	switch f {
+	case FormatJSON:
+		return &jsonReader{dec: json.NewDecoder(br)}, nil
+	case FormatCSV:
+		cr := csv.NewReader(br)
+		cr.FieldsPerRecord = 3
+		return &csvReader{r: cr}, nil
+	case FormatNTriples, FormatTurtle:
+		return newRDFReader(newTurtleParser(br)), nil
+	case FormatJSONLD:
+		return newRDFReader(newJSONLDParser(br)), nil
+	case FormatGraphML:
+		return newGraphMLReader(br), nil
+	}
+	return nil, fmt.Errorf("unknown format %q", f)
+}
+
+// --- JSON ---
+
+type jsonReader struct {
+	dec     *json.Decoder
+	started bool
+}
+
+func (r *jsonReader) Next() (Triple, error) {
+	if !r.started {
+		tok, err := r.dec.Token()
+		if err == io.EOF {
+			return Triple{}, io.EOF
+		}
+		if err != nil {
+			return Triple{}, fmt.Errorf("parse JSON: %w", err)
+		}
+		if d, ok := tok.(json.Delim); !ok || d != '[' {
+			return Triple{}, fmt.Errorf("parse JSON: expected an array of triples")
+		}
+		r.started = true
+	}
+	if !r.dec.More() {
+		return Triple{}, io.EOF
+	}
+	var t Triple
+	if err := r.dec.Decode(&t); err != nil {
+		return Triple{}, fmt.Errorf("parse JSON: %w", err)
+	}
+	return t, nil
+}
+
+// --- CSV ---
+
+func writeCSV(w io.Writer, triples []Triple) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"subject", "predicate", "object"}); err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+	for _, t := range triples {
+		if err := cw.Write([]string{t.Subject, t.Predicate, t.Object}); err != nil {
+			return fmt.Errorf("write csv row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+type csvReader struct {
+	r      *csv.Reader
+	header bool
+}
+
+func (r *csvReader) Next() (Triple, error) {
+	for {
+		rec, err := r.r.Read()
+		if err == io.EOF {
+			return Triple{}, io.EOF
+		}
+		if err != nil {
+			return Triple{}, fmt.Errorf("parse CSV: %w", err)
+		}
+		if !r.header {
+			r.header = true
+			if strings.EqualFold(rec[0], "subject") && strings.EqualFold(rec[1], "predicate") && strings.EqualFold(rec[2], "object") {
+				continue
+			}
+		}
+		return Triple{Subject: rec[0], Predicate: rec[1], Object: rec[2]}, nil
+	}
+}
+
+// --- term mapping ---
+
+// isAbsoluteIRI reports whether s is already an IRI that can be written
+// as-is, such as a node imported from foreign RDF. Bare lango IDs like
+// "error:timeout" have no "//" after the scheme and are namespaced instead.
+func isAbsoluteIRI(s string) bool {
+	i := strings.Index(s, "://")
+	if i <= 0 {
+		return false
+	}
+	for j, r := range s[:i] {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || j > 0 && (r >= '0' && r <= '9' || r == '+' || r == '-' || r == '.')) {
+			return false
+		}
+	}
+	return !strings.ContainsAny(s, " <>\"{}|\\^`") && strings.IndexFunc(s, func(r rune) bool { return r < 0x20 }) < 0
+}
+
+func isBlankLabel(s string) bool {
+	if s == "" || s[0] == '-' || s[0] == '.' || s[len(s)-1] == '.' {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeTerm maps a node ID to an RDF term. IDs of the form "_:label" are
+// blank nodes.
+func nodeTerm(id string) rdfTerm {
+	switch {
+	case strings.HasPrefix(id, "_:") && isBlankLabel(id[2:]):
+		return rdfTerm{kind: termBlank, value: id[2:]}
+	case isAbsoluteIRI(id):
+		return iriTerm(id)
+	default:
+		return iriTerm(NodeNamespace + url.PathEscape(id))
+	}
+}
+
+func predicateTerm(p string) rdfTerm {
+	if isAbsoluteIRI(p) {
+		return iriTerm(p)
+	}
+	return iriTerm(Namespace + url.PathEscape(p))
+}
+
+func metaTerm(key string) rdfTerm {
+	if isAbsoluteIRI(key) {
+		return iriTerm(key)
+	}
+	return iriTerm(MetaNamespace + url.PathEscape(key))
+}
+
+// termID maps an RDF term back to a lango ID, stripping ns when the term
+// is an IRI in it. Foreign IRIs are kept whole and literals by value.
+func termID(t rdfTerm, ns string) string {
+	switch t.kind {
+	case termBlank:
+		return "_:" + t.value
+	case termLiteral:
+		return t.value
+	}
+	if local, ok := strings.CutPrefix(t.value, ns); ok {
+		if v, err := url.PathUnescape(local); err == nil && v != "" {
+			return v
+		}
+	}
+	return t.value
+}
+
+// errMalformed wraps parse errors with their position.
+func errMalformed(format string, line int, msg string, args ...interface{}) error {
+	return fmt.Errorf("parse %s: line %d: %s", format, line, fmt.Sprintf(msg, args...))
+}
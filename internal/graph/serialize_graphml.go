@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+// graphMLPredicateKey is the id of the edge attribute holding the predicate.
+// Metadata keys become further edge attributes named after the key.
+const graphMLPredicateKey = "predicate"
+
+// writeGraphML writes a directed GraphML graph: every subject and object
+// as a node, and every triple as an edge with its predicate and metadata as
+// data attributes.
+func writeGraphML(w *bufio.Writer, triples []Triple) error {
+	metaIDs := make(map[string]string)
+	var metaKeys []string
+	var nodes []string
+	seen := make(map[string]bool)
+	for _, t := range triples {
+		for k := range t.Metadata {
+			if _, ok := metaIDs[k]; !ok {
+				metaIDs[k] = ""
+				metaKeys = append(metaKeys, k)
+			}
+		}
+		for _, n := range []string{t.Subject, t.Object} {
+			if !seen[n] {
+				seen[n] = true
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	sort.Strings(metaKeys)
+
+	w.WriteString(xml.Header)
+	fmt.Fprintf(w, "<graphml xmlns=%q>\n", graphMLNamespace)
+	fmt.Fprintf(w, "  <key id=%q for=\"edge\" attr.name=%q attr.type=\"string\"/>\n", graphMLPredicateKey, graphMLPredicateKey)
+	for i, k := range metaKeys {
+		metaIDs[k] = fmt.Sprintf("m%d", i)
+		fmt.Fprintf(w, "  <key id=%q for=\"edge\" attr.name=\"%s\" attr.type=\"string\"/>\n", metaIDs[k], xmlEscape(k))
+	}
+	w.WriteString("  <graph id=\"lango\" edgedefault=\"directed\">\n")
+	for _, n := range nodes {
+		fmt.Fprintf(w, "    <node id=\"%s\"/>\n", xmlEscape(n))
+	}
+	for _, t := range triples {
+		fmt.Fprintf(w, "    <edge source=\"%s\" target=\"%s\">\n", xmlEscape(t.Subject), xmlEscape(t.Object))
+		fmt.Fprintf(w, "      <data key=%q>%s</data>\n", graphMLPredicateKey, xmlEscape(t.Predicate))
+		for _, k := range sortedKeys(t.Metadata) {
+			fmt.Fprintf(w, "      <data key=%q>%s</data>\n", metaIDs[k], xmlEscape(t.Metadata[k]))
+		}
+		w.WriteString("    </edge>\n")
+	}
+	w.WriteString("  </graph>\n</graphml>\n")
+	return nil
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// graphMLReader streams edges from a GraphML document. The predicate is the
+// edge attribute with key id "predicate", or else one named "predicate" or
+// "label"; edges without one are related_to. Other edge attributes become
+// metadata under their attr.name.
+type graphMLReader struct {
+	dec       *xml.Decoder
+	keys      map[string]string // key id -> attr.name for edge keys
+	predKey   string
+	predFound bool
+}
+
+func newGraphMLReader(r io.Reader) *graphMLReader {
+	return &graphMLReader{dec: xml.NewDecoder(r), keys: make(map[string]string)}
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+}
+
+type graphMLEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+	Data   []struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"data"`
+}
+
+func (r *graphMLReader) Next() (Triple, error) {
+	for {
+		tok, err := r.dec.Token()
+		if err == io.EOF {
+			return Triple{}, io.EOF
+		}
+		if err != nil {
+			return Triple{}, fmt.Errorf("parse GraphML: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "key":
+			var k graphMLKey
+			if err := r.dec.DecodeElement(&k, &start); err != nil {
+				return Triple{}, fmt.Errorf("parse GraphML: %w", err)
+			}
+			if k.For == "edge" || k.For == "all" || k.For == "" {
+				r.keys[k.ID] = k.Name
+			}
+		case "edge":
+			var e graphMLEdge
+			if err := r.dec.DecodeElement(&e, &start); err != nil {
+				return Triple{}, fmt.Errorf("parse GraphML: %w", err)
+			}
+			return r.edgeTriple(e)
+		}
+	}
+}
+
+func (r *graphMLReader) edgeTriple(e graphMLEdge) (Triple, error) {
+	if e.Source == "" || e.Target == "" {
+		return Triple{}, fmt.Errorf("parse GraphML: edge without source or target")
+	}
+	if !r.predFound {
+		r.predFound = true
+		if _, ok := r.keys[graphMLPredicateKey]; ok {
+			r.predKey = graphMLPredicateKey
+		} else {
+			for id, name := range r.keys {
+				if name == "predicate" || r.predKey == "" && name == "label" {
+					r.predKey = id
+				}
+			}
+		}
+	}
+
+	t := Triple{Subject: e.Source, Object: e.Target, Predicate: RelatedTo}
+	for _, d := range e.Data {
+		if d.Key == r.predKey {
+			if v := strings.TrimSpace(d.Value); v != "" {
+				t.Predicate = v
+			}
+			continue
+		}
+		name := r.keys[d.Key]
+		if name == "" {
+			name = d.Key
+		}
+		if t.Metadata == nil {
+			t.Metadata = make(map[string]string)
+		}
+		t.Metadata[name] = d.Value
+	}
+	restoreTypeFields(&t)
+	return t, nil
+}
@@ -0,0 +1,387 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// writeJSONLD writes a flattened JSON-LD document: one node object per
+// triple in @graph, each followed by an rdf:Statement node carrying the
+// triple's metadata when it has any.
+func writeJSONLD(w *bufio.Writer, triples []Triple) error {
+	ctx := make(map[string]string, len(rdfPrefixes))
+	for _, p := range rdfPrefixes {
+		ctx[p.name] = p.iri
+	}
+	header, err := json.Marshal(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "{\n  \"@context\": %s,\n  \"@graph\": [", header)
+
+	first := true
+	emit := func(node map[string]interface{}) error {
+		data, err := json.Marshal(node)
+		if err != nil {
+			return fmt.Errorf("encode JSON-LD: %w", err)
+		}
+		if !first {
+			w.WriteString(",")
+		}
+		first = false
+		w.WriteString("\n    ")
+		w.Write(data)
+		return nil
+	}
+
+	for _, t := range triples {
+		s, p, o := jsonldID(nodeTerm(t.Subject)), jsonldID(predicateTerm(t.Predicate)), jsonldID(nodeTerm(t.Object))
+		if err := emit(map[string]interface{}{
+			"@id": s,
+			p:     map[string]string{"@id": o},
+		}); err != nil {
+			return err
+		}
+		if len(t.Metadata) == 0 {
+			continue
+		}
+		stmt := map[string]interface{}{
+			"@type":         "rdf:Statement",
+			"rdf:subject":   map[string]string{"@id": s},
+			"rdf:predicate": map[string]string{"@id": p},
+			"rdf:object":    map[string]string{"@id": o},
+		}
+		for k, v := range t.Metadata {
+			stmt[jsonldID(metaTerm(k))] = v
+		}
+		if err := emit(stmt); err != nil {
+			return err
+		}
+	}
+	w.WriteString("\n  ]\n}\n")
+	return nil
+}
+
+// jsonldID renders a term as a compact IRI where a prefix applies.
+func jsonldID(t rdfTerm) string {
+	if t.kind == termBlank {
+		return "_:" + t.value
+	}
+	for _, p := range rdfPrefixes {
+		if local, ok := strings.CutPrefix(t.value, p.iri); ok && local != "" && !strings.HasPrefix(local, "//") {
+			return p.name + ":" + local
+		}
+	}
+	return t.value
+}
+
+// jsonldParser streams statements from a JSON-LD document: a top-level
+// node object, an array of them, or an object with @context and @graph.
+// Contexts are inline term and prefix definitions with an optional @vocab;
+// remote contexts are ignored. Elements of @graph are decoded one at a
+// time when @context precedes @graph.
+type jsonldParser struct {
+	dec     *json.Decoder
+	state   int // 0 = not started, 1 = in array, 2 = done
+	context map[string]string
+	vocab   string
+	queue   []rdfStatement
+	pending []json.RawMessage // @graph elements read before @context
+	anon    int
+}
+
+func newJSONLDParser(r *bufio.Reader) *jsonldParser {
+	return &jsonldParser{dec: json.NewDecoder(r), context: make(map[string]string)}
+}
+
+func (p *jsonldParser) next() (rdfStatement, error) {
+	for len(p.queue) == 0 {
+		if err := p.advance(); err != nil {
+			return rdfStatement{}, err
+		}
+	}
+	st := p.queue[0]
+	p.queue = p.queue[1:]
+	return st, nil
+}
+
+// advance queues the statements of the next node object.
+func (p *jsonldParser) advance() error {
+	switch p.state {
+	case 0:
+		return p.start()
+	case 1:
+		if len(p.pending) > 0 {
+			raw := p.pending[0]
+			p.pending = p.pending[1:]
+			return p.element(raw)
+		}
+		if !p.dec.More() {
+			p.state = 2
+			return io.EOF
+		}
+		var raw json.RawMessage
+		if err := p.dec.Decode(&raw); err != nil {
+			return fmt.Errorf("parse JSON-LD: %w", err)
+		}
+		return p.element(raw)
+	}
+	return io.EOF
+}
+
+func (p *jsonldParser) start() error {
+	tok, err := p.dec.Token()
+	if err == io.EOF {
+		p.state = 2
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("parse JSON-LD: %w", err)
+	}
+	switch tok {
+	case json.Delim('['):
+		p.state = 1
+		return nil
+	case json.Delim('{'):
+	default:
+		return fmt.Errorf("parse JSON-LD: expected an object or array")
+	}
+
+	// Walk the top-level object: @context first, then stream @graph. Any
+	// other keys make it a single node object.
+	top := make(map[string]json.RawMessage)
+	for p.dec.More() {
+		keyTok, err := p.dec.Token()
+		if err != nil {
+			return fmt.Errorf("parse JSON-LD: %w", err)
+		}
+		key, _ := keyTok.(string)
+		if key == "@graph" {
+			if _, ok := top["@context"]; ok || len(top) == 0 {
+				if ok {
+					if err := p.setContext(top["@context"]); err != nil {
+						return err
+					}
+				}
+				if t, err := p.dec.Token(); err != nil || t != json.Delim('[') {
+					return fmt.Errorf("parse JSON-LD: @graph must be an array")
+				}
+				p.state = 1
+				return nil
+			}
+		}
+		var raw json.RawMessage
+		if err := p.dec.Decode(&raw); err != nil {
+			return fmt.Errorf("parse JSON-LD: %w", err)
+		}
+		top[key] = raw
+	}
+	p.state = 2
+	if ctx, ok := top["@context"]; ok {
+		if err := p.setContext(ctx); err != nil {
+			return err
+		}
+		delete(top, "@context")
+	}
+	if g, ok := top["@graph"]; ok {
+		if err := json.Unmarshal(g, &p.pending); err != nil {
+			return fmt.Errorf("parse JSON-LD: @graph must be an array")
+		}
+		p.state = 1
+		return nil
+	}
+	if len(top) == 0 {
+		return io.EOF
+	}
+	return p.node(top)
+}
+
+func (p *jsonldParser) setContext(raw json.RawMessage) error {
+	var defs map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &defs); err != nil {
+		// A remote context URL or array cannot be resolved offline.
+		return nil
+	}
+	for term, def := range defs {
+		var iri string
+		if err := json.Unmarshal(def, &iri); err != nil {
+			var obj struct {
+				ID string `json:"@id"`
+			}
+			if json.Unmarshal(def, &obj) != nil || obj.ID == "" {
+				continue
+			}
+			iri = obj.ID
+		}
+		if term == "@vocab" {
+			p.vocab = iri
+		} else {
+			p.context[term] = iri
+		}
+	}
+	return nil
+}
+
+func (p *jsonldParser) element(raw json.RawMessage) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return fmt.Errorf("parse JSON-LD: @graph elements must be node objects")
+	}
+	return p.node(obj)
+}
+
+// subjectOf returns a node object's @id, or a fresh blank node.
+func (p *jsonldParser) subjectOf(obj map[string]json.RawMessage) (rdfTerm, error) {
+	raw, ok := obj["@id"]
+	if !ok {
+		p.anon++
+		return rdfTerm{kind: termBlank, value: fmt.Sprintf("b%d", p.anon)}, nil
+	}
+	var id string
+	if err := json.Unmarshal(raw, &id); err != nil {
+		return rdfTerm{}, fmt.Errorf("parse JSON-LD: @id must be a string")
+	}
+	return p.idTerm(id), nil
+}
+
+// node queues the statements of a node object. Nested node objects are
+// queued after their parent so each node's statements stay contiguous.
+func (p *jsonldParser) node(obj map[string]json.RawMessage) error {
+	subj, err := p.subjectOf(obj)
+	if err != nil {
+		return err
+	}
+	return p.describe(subj, obj)
+}
+
+func (p *jsonldParser) describe(subj rdfTerm, obj map[string]json.RawMessage) error {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	type child struct {
+		subj rdfTerm
+		obj  map[string]json.RawMessage
+	}
+	var nested []child
+	for _, k := range keys {
+		if k == "@type" {
+			var types []string
+			if err := unmarshalOneOrMany(obj[k], &types); err != nil {
+				return fmt.Errorf("parse JSON-LD: @type must be a string or array")
+			}
+			for _, t := range types {
+				p.queue = append(p.queue, rdfStatement{subject: subj, predicate: iriTerm(rdfType), object: p.idTerm(t)})
+			}
+			continue
+		}
+		if strings.HasPrefix(k, "@") {
+			continue
+		}
+		pred, ok := p.expand(k)
+		if !ok {
+			continue // unmapped terms are dropped, as in JSON-LD expansion
+		}
+		var values []json.RawMessage
+		if err := unmarshalOneOrMany(obj[k], &values); err != nil {
+			return fmt.Errorf("parse JSON-LD: %w", err)
+		}
+		for _, v := range values {
+			term, nestedObj, err := p.value(v)
+			if err != nil {
+				return err
+			}
+			if nestedObj != nil {
+				if term, err = p.subjectOf(nestedObj); err != nil {
+					return err
+				}
+				nested = append(nested, child{term, nestedObj})
+			}
+			p.queue = append(p.queue, rdfStatement{subject: subj, predicate: iriTerm(pred), object: term})
+		}
+	}
+
+	for _, c := range nested {
+		if err := p.describe(c.subj, c.obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// value converts a property value to a term, or returns it as a nested
+// node object to describe separately.
+func (p *jsonldParser) value(raw json.RawMessage) (rdfTerm, map[string]json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(raw, &obj) != nil {
+		return rdfTerm{kind: termLiteral, value: scalarString(raw)}, nil, nil
+	}
+	if v, ok := obj["@value"]; ok {
+		return rdfTerm{kind: termLiteral, value: scalarString(v)}, nil, nil
+	}
+	if id, ok := obj["@id"]; ok && len(obj) == 1 {
+		var s string
+		if err := json.Unmarshal(id, &s); err != nil {
+			return rdfTerm{}, nil, fmt.Errorf("parse JSON-LD: @id must be a string")
+		}
+		return p.idTerm(s), nil, nil
+	}
+	return rdfTerm{}, obj, nil
+}
+
+func scalarString(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
+
+func unmarshalOneOrMany[T any](raw json.RawMessage, out *[]T) error {
+	if len(raw) > 0 && raw[0] == '[' {
+		return json.Unmarshal(raw, out)
+	}
+	var one T
+	if err := json.Unmarshal(raw, &one); err != nil {
+		return err
+	}
+	*out = []T{one}
+	return nil
+}
+
+// idTerm expands an @id or @type value: a blank node, a compact IRI, a
+// context term or an absolute IRI.
+func (p *jsonldParser) idTerm(id string) rdfTerm {
+	if label, ok := strings.CutPrefix(id, "_:"); ok {
+		return rdfTerm{kind: termBlank, value: label}
+	}
+	if iri, ok := p.expand(id); ok {
+		return iriTerm(iri)
+	}
+	return iriTerm(id)
+}
+
+// expand resolves a term or compact IRI against the context.
+func (p *jsonldParser) expand(s string) (string, bool) {
+	if iri, ok := p.context[s]; ok {
+		return iri, true
+	}
+	if prefix, local, ok := strings.Cut(s, ":"); ok && !strings.HasPrefix(local, "//") {
+		if ns, ok := p.context[prefix]; ok {
+			return ns + local, true
+		}
+	}
+	if strings.Contains(s, ":") {
+		return s, true
+	}
+	if p.vocab != "" {
+		return p.vocab + s, true
+	}
+	return "", false
+}
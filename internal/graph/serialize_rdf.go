@@ -0,0 +1,258 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type termKind int
+
+const (
+	termIRI termKind = iota
+	termBlank
+	termLiteral
+)
+
+// rdfTerm is an RDF node: an IRI, a blank node label, or a literal's
+// lexical value (datatypes and language tags are dropped).
+type rdfTerm struct {
+	kind  termKind
+	value string
+}
+
+func iriTerm(iri string) rdfTerm { return rdfTerm{kind: termIRI, value: iri} }
+
+type rdfStatement struct {
+	subject, predicate, object rdfTerm
+}
+
+// statementSource yields RDF statements; next returns io.EOF at the end.
+type statementSource interface {
+	next() (rdfStatement, error)
+}
+
+// --- writer ---
+
+// writeRDF writes N-Triples, or Turtle with prefixed names. A triple with
+// metadata is followed by an rdf:Statement reifying it, carrying each
+// metadata entry as a property in MetaNamespace.
+func writeRDF(w *bufio.Writer, triples []Triple, turtle bool) error {
+	if turtle {
+		for _, p := range rdfPrefixes {
+			fmt.Fprintf(w, "@prefix %s: <%s> .\n", p.name, p.iri)
+		}
+		w.WriteString("\n")
+	}
+	term := func(t rdfTerm) string { return formatTerm(t, turtle) }
+
+	for i, t := range triples {
+		s, p, o := nodeTerm(t.Subject), predicateTerm(t.Predicate), nodeTerm(t.Object)
+		fmt.Fprintf(w, "%s %s %s .\n", term(s), term(p), term(o))
+		if len(t.Metadata) == 0 {
+			continue
+		}
+
+		stmt := rdfTerm{kind: termBlank, value: fmt.Sprintf("stmt%d", i+1)}
+		props := [][2]rdfTerm{
+			{iriTerm(rdfType), iriTerm(rdfStatementType)},
+			{iriTerm(rdfSubject), s},
+			{iriTerm(rdfPredicate), p},
+			{iriTerm(rdfObject), o},
+		}
+		for _, k := range sortedKeys(t.Metadata) {
+			props = append(props, [2]rdfTerm{metaTerm(k), {kind: termLiteral, value: t.Metadata[k]}})
+		}
+		if !turtle {
+			for _, pv := range props {
+				fmt.Fprintf(w, "%s %s %s .\n", term(stmt), term(pv[0]), term(pv[1]))
+			}
+			continue
+		}
+		fmt.Fprintf(w, "%s a rdf:Statement", term(stmt))
+		for _, pv := range props[1:] {
+			fmt.Fprintf(w, " ;\n    %s %s", term(pv[0]), term(pv[1]))
+		}
+		w.WriteString(" .\n")
+	}
+	return nil
+}
+
+func formatTerm(t rdfTerm, turtle bool) string {
+	switch t.kind {
+	case termBlank:
+		return "_:" + t.value
+	case termLiteral:
+		return quoteLiteral(t.value)
+	}
+	if turtle {
+		for _, p := range rdfPrefixes {
+			if local, ok := strings.CutPrefix(t.value, p.iri); ok && isSafeLocalName(local) {
+				return p.name + ":" + local
+			}
+		}
+	}
+	return "<" + t.value + ">"
+}
+
+// isSafeLocalName reports whether a path-escaped local name can be written
+// as a Turtle prefixed name without backslash escapes.
+func isSafeLocalName(s string) bool {
+	if s == "" || s[0] == '-' || s[0] == '.' || s[len(s)-1] == '.' {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("_-:.%", r)) {
+			return false
+		}
+	}
+	return true
+}
+
+var literalEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func quoteLiteral(s string) string {
+	return `"` + literalEscaper.Replace(s) + `"`
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// --- reader ---
+
+// rdfReader turns RDF statements into triples. Consecutive statements about
+// the same blank node that reify a statement (rdf:subject, rdf:predicate,
+// rdf:object) become that triple's metadata, merged into the asserted
+// triple when it is adjacent.
+type rdfReader struct {
+	src     statementSource
+	peeked  *rdfStatement
+	queue   []Triple
+	pending *Triple
+}
+
+func newRDFReader(src statementSource) *rdfReader {
+	return &rdfReader{src: src}
+}
+
+func (r *rdfReader) Next() (Triple, error) {
+	for {
+		t, err := r.readTriple()
+		if err == io.EOF {
+			if r.pending != nil {
+				out := *r.pending
+				r.pending = nil
+				return out, nil
+			}
+			return Triple{}, io.EOF
+		}
+		if err != nil {
+			return Triple{}, err
+		}
+		if p := r.pending; p != nil && p.Subject == t.Subject && p.Predicate == t.Predicate && p.Object == t.Object {
+			p.Metadata, _ = mergeMetadata(p.Metadata, t.Metadata)
+			restoreTypeFields(p)
+			continue
+		}
+		prev := r.pending
+		r.pending = &t
+		if prev != nil {
+			return *prev, nil
+		}
+	}
+}
+
+func (r *rdfReader) readTriple() (Triple, error) {
+	if len(r.queue) > 0 {
+		t := r.queue[0]
+		r.queue = r.queue[1:]
+		return t, nil
+	}
+	st, err := r.read()
+	if err != nil {
+		return Triple{}, err
+	}
+	if st.subject.kind != termBlank {
+		return statementTriple(st), nil
+	}
+
+	group := []rdfStatement{st}
+	for {
+		next, err := r.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Triple{}, err
+		}
+		if next.subject != st.subject {
+			r.peeked = &next
+			break
+		}
+		group = append(group, next)
+	}
+	if t, ok := reifiedTriple(group); ok {
+		return t, nil
+	}
+	for _, g := range group {
+		r.queue = append(r.queue, statementTriple(g))
+	}
+	t := r.queue[0]
+	r.queue = r.queue[1:]
+	return t, nil
+}
+
+func (r *rdfReader) read() (rdfStatement, error) {
+	if r.peeked != nil {
+		st := *r.peeked
+		r.peeked = nil
+		return st, nil
+	}
+	return r.src.next()
+}
+
+func statementTriple(st rdfStatement) Triple {
+	return Triple{
+		Subject:   termID(st.subject, NodeNamespace),
+		Predicate: termID(st.predicate, Namespace),
+		Object:    termID(st.object, NodeNamespace),
+	}
+}
+
+// reifiedTriple reads an rdf:Statement description. Properties other than
+// rdf:type and the three positions become metadata.
+func reifiedTriple(group []rdfStatement) (Triple, bool) {
+	var (
+		t    Triple
+		seen int
+	)
+	for _, st := range group {
+		switch st.predicate {
+		case iriTerm(rdfSubject):
+			t.Subject = termID(st.object, NodeNamespace)
+			seen |= 1
+		case iriTerm(rdfPredicate):
+			t.Predicate = termID(st.object, Namespace)
+			seen |= 2
+		case iriTerm(rdfObject):
+			t.Object = termID(st.object, NodeNamespace)
+			seen |= 4
+		case iriTerm(rdfType):
+		default:
+			if t.Metadata == nil {
+				t.Metadata = make(map[string]string)
+			}
+			t.Metadata[termID(st.predicate, MetaNamespace)] = termID(st.object, NodeNamespace)
+		}
+	}
+	restoreTypeFields(&t)
+	return t, seen == 7
+}
@@ -0,0 +1,272 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripTriples exercises IDs that need escaping in every format.
+var roundTripTriples = []Triple{
+	{Subject: "obs:1", Predicate: CausedBy, Object: "error:timeout", Metadata: map[string]string{"confidence": "0.9", "source": "obs_42"}},
+	{Subject: "error:timeout", Predicate: ResolvedBy, Object: "fix:retry with backoff", SubjectType: "error", ObjectType: "fix"},
+	{Subject: "session:a", Predicate: Contains, Object: "obs:1"},
+	{Subject: "note <draft>", Predicate: RelatedTo, Object: "ünïcode/path#frag.", Metadata: map[string]string{"quote": "say \"hi\"\nnext line", "amp": "a & b"}},
+	{Subject: "https://example.org/people/alice", Predicate: "https://schema.org/knows", Object: "_:b0"},
+	{Subject: "_:b0", Predicate: "custom_pred", Object: "trailing.dot."},
+	{Subject: "learning:7", Predicate: LearnedFrom, Object: "session:a", Metadata: map[string]string{"weird key/with:chars": "v"}},
+}
+
+func TestRoundTrip_ExportImport(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range []Format{FormatJSON, FormatNTriples, FormatTurtle, FormatJSONLD, FormatGraphML} {
+		t.Run(string(f), func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			src := newTestStore(t)
+			require.NoError(t, src.AddTriples(ctx, roundTripTriples))
+			want, err := src.AllTriples(ctx)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, WriteTriples(&buf, f, want))
+
+			dst := newTestStore(t)
+			r, err := NewTripleReader(bytes.NewReader(buf.Bytes()), f)
+			require.NoError(t, err)
+			stats, err := ImportTriples(ctx, dst, r, 2)
+			require.NoError(t, err, buf.String())
+			assert.Equal(t, ImportStats{Added: len(want)}, stats)
+
+			got, err := dst.AllTriples(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, want, got, buf.String())
+
+			// Importing the same file again adds nothing.
+			r, err = NewTripleReader(bytes.NewReader(buf.Bytes()), f)
+			require.NoError(t, err)
+			stats, err = ImportTriples(ctx, dst, r, 0)
+			require.NoError(t, err)
+			assert.Equal(t, ImportStats{Skipped: len(want)}, stats)
+		})
+	}
+}
+
+func TestImportTriples_MergesMetadata(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := newTestStore(t)
+	require.NoError(t, store.AddTriple(ctx, Triple{Subject: "a", Predicate: RelatedTo, Object: "b", Metadata: map[string]string{"x": "1"}}))
+
+	src := `[
+		{"Subject": "a", "Predicate": "related_to", "Object": "b", "Metadata": {"y": "2"}},
+		{"Subject": "a", "Predicate": "related_to", "Object": "b", "Metadata": {"x": "1"}},
+		{"Subject": "c", "Predicate": "related_to", "Object": "d"},
+		{"Subject": "c", "Predicate": "related_to", "Object": "d"},
+		{"Subject": "c", "Predicate": "related_to", "Object": "d", "Metadata": {"z": "3"}}
+	]`
+	r, err := NewTripleReader(strings.NewReader(src), FormatJSON)
+	require.NoError(t, err)
+	stats, err := ImportTriples(ctx, store, r, 0)
+	require.NoError(t, err)
+	assert.Equal(t, ImportStats{Added: 1, Updated: 2, Skipped: 2}, stats)
+
+	got, err := store.QueryBySubject(ctx, "a")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, map[string]string{"x": "1", "y": "2"}, got[0].Metadata)
+
+	// A repeat within the batch that adds metadata counts as an update.
+	got, err = store.QueryBySubject(ctx, "c")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, map[string]string{"z": "3"}, got[0].Metadata)
+}
+
+func readAll(t *testing.T, f Format, src string) []Triple {
+	t.Helper()
+	r, err := NewTripleReader(strings.NewReader(src), f)
+	require.NoError(t, err)
+	var out []Triple
+	for {
+		tr, err := r.Next()
+		if err == io.EOF {
+			return out
+		}
+		require.NoError(t, err)
+		out = append(out, tr)
+	}
+}
+
+func TestTripleReader_ForeignInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give   string
+		format Format
+		src    string
+		want   []Triple
+	}{
+		{
+			give:   "turtle with prefixes, lists and literals",
+			format: FormatTurtle,
+			src: `@prefix ex: <http://example.org/> .
+PREFIX lango: <https://github.com/langoai/lango/ns/graph#>
+# a comment
+ex:alice ex:knows ex:bob , ex:carol ;
+    ex:name "Alice"@en ;
+    ex:age 42 ;
+    a ex:Person .
+ex:bob lango:related_to [ ex:label 'anon' ] .`,
+			want: []Triple{
+				{Subject: "http://example.org/alice", Predicate: "http://example.org/knows", Object: "http://example.org/bob"},
+				{Subject: "http://example.org/alice", Predicate: "http://example.org/knows", Object: "http://example.org/carol"},
+				{Subject: "http://example.org/alice", Predicate: "http://example.org/name", Object: "Alice"},
+				{Subject: "http://example.org/alice", Predicate: "http://example.org/age", Object: "42"},
+				{Subject: "http://example.org/alice", Predicate: "http://www.w3.org/1999/02/22-rdf-syntax-ns#type", Object: "http://example.org/Person"},
+				{Subject: "_:anon1", Predicate: "http://example.org/label", Object: "anon"},
+				{Subject: "http://example.org/bob", Predicate: RelatedTo, Object: "_:anon1"},
+			},
+		},
+		{
+			give:   "n-triples with separate reification",
+			format: FormatNTriples,
+			src: `<https://github.com/langoai/lango/ns/node/x> <https://github.com/langoai/lango/ns/graph#caused_by> <https://github.com/langoai/lango/ns/node/y> .
+<https://github.com/langoai/lango/ns/node/z> <https://github.com/langoai/lango/ns/graph#follows> <https://github.com/langoai/lango/ns/node/x> .
+_:s <http://www.w3.org/1999/02/22-rdf-syntax-ns#subject> <https://github.com/langoai/lango/ns/node/x> .
+_:s <http://www.w3.org/1999/02/22-rdf-syntax-ns#predicate> <https://github.com/langoai/lango/ns/graph#caused_by> .
+_:s <http://www.w3.org/1999/02/22-rdf-syntax-ns#object> <https://github.com/langoai/lango/ns/node/y> .
+_:s <http://example.org/weight> "0.5"^^<http://www.w3.org/2001/XMLSchema#decimal> .
+`,
+			want: []Triple{
+				{Subject: "x", Predicate: CausedBy, Object: "y"},
+				{Subject: "z", Predicate: Follows, Object: "x"},
+				{Subject: "x", Predicate: CausedBy, Object: "y", Metadata: map[string]string{"http://example.org/weight": "0.5"}},
+			},
+		},
+		{
+			give:   "json-ld with vocab and nested node",
+			format: FormatJSONLD,
+			src: `{
+  "@context": {"@vocab": "http://example.org/", "lango": "https://github.com/langoai/lango/ns/graph#"},
+  "@id": "http://example.org/alice",
+  "knows": {"@id": "http://example.org/bob", "lango:caused_by": {"@id": "_:e"}},
+  "age": 42
+}`,
+			want: []Triple{
+				{Subject: "http://example.org/alice", Predicate: "http://example.org/age", Object: "42"},
+				{Subject: "http://example.org/alice", Predicate: "http://example.org/knows", Object: "http://example.org/bob"},
+				{Subject: "http://example.org/bob", Predicate: CausedBy, Object: "_:e"},
+			},
+		},
+		{
+			give:   "graphml with label attribute",
+			format: FormatGraphML,
+			src: `<?xml version="1.0"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="edge" attr.name="label" attr.type="string"/>
+  <key id="d1" for="edge" attr.name="weight" attr.type="double"/>
+  <graph edgedefault="directed">
+    <node id="a"/><node id="b"/>
+    <edge source="a" target="b"><data key="d0">follows</data><data key="d1">1.5</data></edge>
+    <edge source="b" target="a"/>
+  </graph>
+</graphml>`,
+			want: []Triple{
+				{Subject: "a", Predicate: Follows, Object: "b", Metadata: map[string]string{"weight": "1.5"}},
+				{Subject: "b", Predicate: RelatedTo, Object: "a"},
+			},
+		},
+		{
+			give:   "csv with header",
+			format: FormatCSV,
+			src:    "subject,predicate,object\na,follows,b\n",
+			want:   []Triple{{Subject: "a", Predicate: Follows, Object: "b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, readAll(t, tt.format, tt.src))
+		})
+	}
+}
+
+func TestTripleReader_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give    string
+		format  Format
+		src     string
+		wantErr string
+	}{
+		{give: "undefined prefix", format: FormatTurtle, src: `ex:a ex:b ex:c .`, wantErr: "undefined prefix"},
+		{give: "missing dot", format: FormatNTriples, src: `<http://a> <http://b> <http://c>`, wantErr: "line 1"},
+		{give: "unterminated string", format: FormatTurtle, src: "<http://a> <http://b> \"abc\n", wantErr: "unterminated string"},
+		{give: "collection", format: FormatTurtle, src: `<http://a> <http://b> ( <http://c> ) .`, wantErr: "collections"},
+		{give: "json not array", format: FormatJSON, src: `{"Subject": "a"}`, wantErr: "array"},
+		{give: "bad xml", format: FormatGraphML, src: `<graphml><edge source="a"`, wantErr: "parse GraphML"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			r, err := NewTripleReader(strings.NewReader(tt.src), tt.format)
+			require.NoError(t, err)
+			for {
+				_, err = r.Next()
+				if err != nil {
+					break
+				}
+			}
+			require.NotErrorIs(t, err, io.EOF)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestWriteTriples_Turtle(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteTriples(&buf, FormatTurtle, []Triple{
+		{Subject: "obs:1", Predicate: CausedBy, Object: "error:timeout", Metadata: map[string]string{"confidence": "0.9"}},
+	}))
+	want := `@prefix lango: <https://github.com/langoai/lango/ns/graph#> .
+@prefix node: <https://github.com/langoai/lango/ns/node/> .
+@prefix meta: <https://github.com/langoai/lango/ns/meta#> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+
+node:obs:1 lango:caused_by node:error:timeout .
+_:stmt1 a rdf:Statement ;
+    rdf:subject node:obs:1 ;
+    rdf:predicate lango:caused_by ;
+    rdf:object node:error:timeout ;
+    meta:confidence "0.9" .
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, give := range []string{"json", "CSV", "nt", "n-triples", "ttl", "json-ld", "graphml"} {
+		_, err := ParseFormat(give)
+		assert.NoError(t, err, give)
+	}
+	_, err := ParseFormat("xml")
+	assert.ErrorContains(t, err, "must be one of")
+
+	f, ok := FormatFromPath("/tmp/graph.TTL")
+	assert.True(t, ok)
+	assert.Equal(t, FormatTurtle, f)
+}
@@ -0,0 +1,618 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// turtleParser reads Turtle, and therefore N-Triples, one statement at a
+// time: prefixes and base, prefixed names, "a", predicate (;) and object
+// (,) lists, blank node labels and [ ] property lists, and literals with
+// their language tags or datatypes discarded. Collections are not supported.
+type turtleParser struct {
+	r        *bufio.Reader
+	line     int
+	tok      turtleToken
+	hasTok   bool
+	prefixes map[string]string
+	base     *url.URL
+	queue    []rdfStatement
+	anon     int
+}
+
+type turtleTokKind int
+
+const (
+	ttEOF turtleTokKind = iota
+	ttIRI
+	ttPName
+	ttBlank
+	ttLiteral
+	ttWord  // a, true, false, PREFIX, BASE
+	ttAt    // @prefix, @base, language tag
+	ttPunct // . ; , [ ] ( ) ^^
+)
+
+type turtleToken struct {
+	kind turtleTokKind
+	text string
+}
+
+func newTurtleParser(r *bufio.Reader) *turtleParser {
+	return &turtleParser{r: r, line: 1, prefixes: make(map[string]string)}
+}
+
+func (p *turtleParser) errorf(msg string, args ...interface{}) error {
+	return errMalformed("turtle", p.line, msg, args...)
+}
+
+func (p *turtleParser) next() (rdfStatement, error) {
+	for len(p.queue) == 0 {
+		tok, err := p.peek()
+		if err != nil {
+			return rdfStatement{}, err
+		}
+		if tok.kind == ttEOF {
+			return rdfStatement{}, io.EOF
+		}
+		if err := p.parseStatement(); err != nil {
+			return rdfStatement{}, err
+		}
+	}
+	st := p.queue[0]
+	p.queue = p.queue[1:]
+	return st, nil
+}
+
+func (p *turtleParser) parseStatement() error {
+	tok, _ := p.peek()
+	switch {
+	case tok.kind == ttAt && (tok.text == "prefix" || tok.text == "base"):
+		p.hasTok = false
+		if err := p.parseDirective(tok.text); err != nil {
+			return err
+		}
+		return p.expectPunct(".")
+	case tok.kind == ttWord && (strings.EqualFold(tok.text, "PREFIX") || strings.EqualFold(tok.text, "BASE")):
+		p.hasTok = false
+		return p.parseDirective(strings.ToLower(tok.text))
+	}
+
+	if tok.kind == ttPunct && tok.text == "[" {
+		subj, err := p.parseBlankPropertyList()
+		if err != nil {
+			return err
+		}
+		if next, err := p.peek(); err != nil {
+			return err
+		} else if next.kind != ttPunct || next.text != "." {
+			if err := p.parsePredicateObjectList(subj); err != nil {
+				return err
+			}
+		}
+		return p.expectPunct(".")
+	}
+
+	subj, err := p.parseSubject()
+	if err != nil {
+		return err
+	}
+	if err := p.parsePredicateObjectList(subj); err != nil {
+		return err
+	}
+	return p.expectPunct(".")
+}
+
+func (p *turtleParser) parseDirective(kind string) error {
+	if kind == "prefix" {
+		tok, err := p.read()
+		if err != nil {
+			return err
+		}
+		if tok.kind != ttPName || !strings.HasSuffix(tok.text, ":") || strings.Count(tok.text, ":") != 1 {
+			return p.errorf("expected prefix name, got %q", tok.text)
+		}
+		iri, err := p.readIRI()
+		if err != nil {
+			return err
+		}
+		p.prefixes[strings.TrimSuffix(tok.text, ":")] = iri
+		return nil
+	}
+	iri, err := p.readIRI()
+	if err != nil {
+		return err
+	}
+	u, err := url.Parse(iri)
+	if err != nil {
+		return p.errorf("invalid base IRI %q", iri)
+	}
+	p.base = u
+	return nil
+}
+
+func (p *turtleParser) readIRI() (string, error) {
+	tok, err := p.read()
+	if err != nil {
+		return "", err
+	}
+	if tok.kind != ttIRI {
+		return "", p.errorf("expected IRI, got %q", tok.text)
+	}
+	return p.resolve(tok.text), nil
+}
+
+func (p *turtleParser) resolve(iri string) string {
+	if p.base == nil {
+		return iri
+	}
+	u, err := url.Parse(iri)
+	if err != nil || u.IsAbs() {
+		return iri
+	}
+	return p.base.ResolveReference(u).String()
+}
+
+func (p *turtleParser) parseSubject() (rdfTerm, error) {
+	tok, err := p.read()
+	if err != nil {
+		return rdfTerm{}, err
+	}
+	switch tok.kind {
+	case ttIRI, ttPName, ttBlank:
+		return p.tokenTerm(tok)
+	}
+	return rdfTerm{}, p.errorf("expected subject, got %q", tok.text)
+}
+
+func (p *turtleParser) parsePredicateObjectList(subj rdfTerm) error {
+	for {
+		tok, err := p.read()
+		if err != nil {
+			return err
+		}
+		var pred rdfTerm
+		switch {
+		case tok.kind == ttWord && tok.text == "a":
+			pred = iriTerm(rdfType)
+		case tok.kind == ttIRI || tok.kind == ttPName:
+			if pred, err = p.tokenTerm(tok); err != nil {
+				return err
+			}
+		default:
+			return p.errorf("expected predicate, got %q", tok.text)
+		}
+
+		for {
+			obj, err := p.parseObject()
+			if err != nil {
+				return err
+			}
+			p.queue = append(p.queue, rdfStatement{subject: subj, predicate: pred, object: obj})
+			if !p.acceptPunct(",") {
+				break
+			}
+		}
+
+		if !p.acceptPunct(";") {
+			return nil
+		}
+		// A trailing ';' may end the list.
+		for p.acceptPunct(";") {
+		}
+		if next, err := p.peek(); err != nil {
+			return err
+		} else if next.kind == ttPunct && (next.text == "." || next.text == "]") {
+			return nil
+		}
+	}
+}
+
+func (p *turtleParser) parseObject() (rdfTerm, error) {
+	tok, err := p.peek()
+	if err != nil {
+		return rdfTerm{}, err
+	}
+	if tok.kind == ttPunct && tok.text == "[" {
+		return p.parseBlankPropertyList()
+	}
+	p.hasTok = false
+	switch tok.kind {
+	case ttIRI, ttPName, ttBlank:
+		return p.tokenTerm(tok)
+	case ttLiteral:
+		// Drop a language tag or datatype.
+		if next, err := p.peek(); err != nil {
+			return rdfTerm{}, err
+		} else if next.kind == ttAt {
+			p.hasTok = false
+		} else if next.kind == ttPunct && next.text == "^^" {
+			p.hasTok = false
+			dt, err := p.read()
+			if err != nil {
+				return rdfTerm{}, err
+			}
+			if dt.kind != ttIRI && dt.kind != ttPName {
+				return rdfTerm{}, p.errorf("expected datatype IRI, got %q", dt.text)
+			}
+		}
+		return rdfTerm{kind: termLiteral, value: tok.text}, nil
+	case ttWord:
+		if tok.text == "true" || tok.text == "false" {
+			return rdfTerm{kind: termLiteral, value: tok.text}, nil
+		}
+	case ttPunct:
+		if tok.text == "(" {
+			return rdfTerm{}, p.errorf("collections are not supported")
+		}
+	}
+	return rdfTerm{}, p.errorf("expected object, got %q", tok.text)
+}
+
+// parseBlankPropertyList parses "[ predicate object ; ... ]" and returns
+// the fresh blank node it describes.
+func (p *turtleParser) parseBlankPropertyList() (rdfTerm, error) {
+	if err := p.expectPunct("["); err != nil {
+		return rdfTerm{}, err
+	}
+	p.anon++
+	node := rdfTerm{kind: termBlank, value: fmt.Sprintf("anon%d", p.anon)}
+	if p.acceptPunct("]") {
+		return node, nil
+	}
+	if err := p.parsePredicateObjectList(node); err != nil {
+		return rdfTerm{}, err
+	}
+	return node, p.expectPunct("]")
+}
+
+func (p *turtleParser) tokenTerm(tok turtleToken) (rdfTerm, error) {
+	switch tok.kind {
+	case ttIRI:
+		return iriTerm(p.resolve(tok.text)), nil
+	case ttBlank:
+		return rdfTerm{kind: termBlank, value: tok.text}, nil
+	}
+	prefix, local, _ := strings.Cut(tok.text, ":")
+	ns, ok := p.prefixes[prefix]
+	if !ok {
+		return rdfTerm{}, p.errorf("undefined prefix %q", prefix)
+	}
+	return iriTerm(ns + local), nil
+}
+
+func (p *turtleParser) acceptPunct(s string) bool {
+	tok, err := p.peek()
+	if err != nil || tok.kind != ttPunct || tok.text != s {
+		return false
+	}
+	p.hasTok = false
+	return true
+}
+
+func (p *turtleParser) expectPunct(s string) error {
+	tok, err := p.read()
+	if err != nil {
+		return err
+	}
+	if tok.kind != ttPunct || tok.text != s {
+		if tok.kind == ttEOF {
+			return p.errorf("expected %q, got end of input", s)
+		}
+		return p.errorf("expected %q, got %q", s, tok.text)
+	}
+	return nil
+}
+
+// --- lexer ---
+
+func (p *turtleParser) peek() (turtleToken, error) {
+	if !p.hasTok {
+		tok, err := p.lex()
+		if err != nil {
+			return turtleToken{}, err
+		}
+		p.tok, p.hasTok = tok, true
+	}
+	return p.tok, nil
+}
+
+func (p *turtleParser) read() (turtleToken, error) {
+	tok, err := p.peek()
+	p.hasTok = false
+	return tok, err
+}
+
+func (p *turtleParser) readRune() (rune, error) {
+	r, _, err := p.r.ReadRune()
+	if err != nil {
+		if err == io.EOF {
+			return 0, io.EOF
+		}
+		return 0, fmt.Errorf("read turtle: %w", err)
+	}
+	if r == '\n' {
+		p.line++
+	}
+	return r, nil
+}
+
+func (p *turtleParser) unreadRune(r rune) {
+	_ = p.r.UnreadRune()
+	if r == '\n' {
+		p.line--
+	}
+}
+
+// peekByte returns the next byte without consuming it, or 0 at the end.
+func (p *turtleParser) peekByte() byte {
+	b, err := p.r.Peek(1)
+	if err != nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (p *turtleParser) lex() (turtleToken, error) {
+	var r rune
+	for {
+		var err error
+		r, err = p.readRune()
+		if err == io.EOF {
+			return turtleToken{kind: ttEOF}, nil
+		}
+		if err != nil {
+			return turtleToken{}, err
+		}
+		if r == '#' {
+			for r != '\n' {
+				if r, err = p.readRune(); err == io.EOF {
+					return turtleToken{kind: ttEOF}, nil
+				} else if err != nil {
+					return turtleToken{}, err
+				}
+			}
+			continue
+		}
+		if !unicode.IsSpace(r) {
+			break
+		}
+	}
+
+	switch {
+	case r == '<':
+		return p.lexIRI()
+	case r == '"' || r == '\'':
+		return p.lexLiteral(r)
+	case r == '@':
+		word := p.lexWhile(func(r rune) bool {
+			return r == '-' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+		})
+		return turtleToken{kind: ttAt, text: word}, nil
+	case r == '^':
+		if next, err := p.readRune(); err != nil || next != '^' {
+			return turtleToken{}, p.errorf("expected \"^^\"")
+		}
+		return turtleToken{kind: ttPunct, text: "^^"}, nil
+	case strings.ContainsRune(".;,[]()", r):
+		return turtleToken{kind: ttPunct, text: string(r)}, nil
+	case r == '_' && p.peekByte() == ':':
+		_, _ = p.readRune()
+		label := p.lexName()
+		if label == "" {
+			return turtleToken{}, p.errorf("empty blank node label")
+		}
+		return turtleToken{kind: ttBlank, text: label}, nil
+	case r == '+' || r == '-' || r >= '0' && r <= '9':
+		return p.lexNumber(r)
+	}
+
+	p.unreadRune(r)
+	prefix := p.lexWhile(isPNChar)
+	if p.peekByte() != ':' {
+		if prefix == "" {
+			r, _ := p.readRune()
+			return turtleToken{}, p.errorf("unexpected character %q", r)
+		}
+		return turtleToken{kind: ttWord, text: prefix}, nil
+	}
+	_, _ = p.readRune()
+	return turtleToken{kind: ttPName, text: prefix + ":" + p.lexName()}, nil
+}
+
+func isPNChar(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r) || r > 0x7f && r != 0xfeff
+}
+
+// lexName reads a local name or blank node label. A '.' belongs to the name
+// only when another name character follows it.
+func (p *turtleParser) lexName() string {
+	var b strings.Builder
+	for {
+		next, _ := p.r.Peek(2)
+		if len(next) == 0 || next[0] == '.' && (len(next) < 2 || !isNameByte(next[1])) {
+			return b.String()
+		}
+		if next[0] < 0x80 && next[0] != '.' && next[0] != '\\' && !isNameByte(next[0]) {
+			return b.String()
+		}
+		r, err := p.readRune()
+		if err != nil {
+			return b.String()
+		}
+		switch {
+		case r == '\\':
+			if esc, err := p.readRune(); err == nil {
+				b.WriteRune(esc)
+			}
+		case r == '.' || isNameByte(byte(r)) && r < 0x80 || isPNChar(r):
+			b.WriteRune(r)
+		default:
+			p.unreadRune(r)
+			return b.String()
+		}
+	}
+}
+
+// isNameByte reports whether an ASCII byte may appear in a local name; bytes
+// of multi-byte runes are accepted and checked once decoded.
+func isNameByte(c byte) bool {
+	return c >= 0x80 || c == '.' || c == ':' || c == '%' || isPNChar(rune(c))
+}
+
+func (p *turtleParser) lexWhile(ok func(rune) bool) string {
+	var b strings.Builder
+	for {
+		r, err := p.readRune()
+		if err != nil {
+			return b.String()
+		}
+		if !ok(r) {
+			p.unreadRune(r)
+			return b.String()
+		}
+		b.WriteRune(r)
+	}
+}
+
+func (p *turtleParser) lexNumber(first rune) (turtleToken, error) {
+	var b strings.Builder
+	b.WriteRune(first)
+	for {
+		next, _ := p.r.Peek(2)
+		if len(next) == 0 {
+			break
+		}
+		c := next[0]
+		switch {
+		case c >= '0' && c <= '9' || c == 'e' || c == 'E':
+		case c == '.' && len(next) == 2 && next[1] >= '0' && next[1] <= '9':
+		case (c == '+' || c == '-') && strings.HasSuffix(strings.ToLower(b.String()), "e"):
+		default:
+			return turtleToken{kind: ttLiteral, text: b.String()}, nil
+		}
+		_, _ = p.readRune()
+		b.WriteByte(c)
+	}
+	return turtleToken{kind: ttLiteral, text: b.String()}, nil
+}
+
+func (p *turtleParser) lexIRI() (turtleToken, error) {
+	var b strings.Builder
+	for {
+		r, err := p.readRune()
+		if err != nil {
+			return turtleToken{}, p.errorf("unterminated IRI")
+		}
+		switch r {
+		case '>':
+			return turtleToken{kind: ttIRI, text: b.String()}, nil
+		case '\\':
+			u, err := p.lexUnicodeEscape()
+			if err != nil {
+				return turtleToken{}, err
+			}
+			b.WriteRune(u)
+		case '\n':
+			return turtleToken{}, p.errorf("unterminated IRI")
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
+
+func (p *turtleParser) lexUnicodeEscape() (rune, error) {
+	r, err := p.readRune()
+	if err != nil || r != 'u' && r != 'U' {
+		return 0, p.errorf("invalid escape")
+	}
+	n := 4
+	if r == 'U' {
+		n = 8
+	}
+	var hex strings.Builder
+	for range n {
+		h, err := p.readRune()
+		if err != nil {
+			return 0, p.errorf("invalid escape")
+		}
+		hex.WriteRune(h)
+	}
+	v, err := strconv.ParseUint(hex.String(), 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid escape \\%c%s", r, hex.String())
+	}
+	return rune(v), nil
+}
+
+// lexLiteral reads a short or long ("""...""") string in either quote.
+func (p *turtleParser) lexLiteral(quote rune) (turtleToken, error) {
+	long := false
+	if p.peekByte() == byte(quote) {
+		_, _ = p.readRune()
+		if p.peekByte() != byte(quote) {
+			return turtleToken{kind: ttLiteral, text: ""}, nil
+		}
+		_, _ = p.readRune()
+		long = true
+	}
+
+	var b strings.Builder
+	run := 0
+	for {
+		r, err := p.readRune()
+		if err != nil {
+			return turtleToken{}, p.errorf("unterminated string")
+		}
+		switch {
+		case r == quote && !long:
+			return turtleToken{kind: ttLiteral, text: b.String()}, nil
+		case r == quote:
+			run++
+			if run == 3 {
+				s := b.String()
+				return turtleToken{kind: ttLiteral, text: s[:len(s)-2]}, nil
+			}
+			b.WriteRune(r)
+			continue
+		case r == '\n' && !long:
+			return turtleToken{}, p.errorf("unterminated string")
+		case r == '\\':
+			esc, err := p.readRune()
+			if err != nil {
+				return turtleToken{}, p.errorf("unterminated string")
+			}
+			switch esc {
+			case 't':
+				b.WriteByte('\t')
+			case 'b':
+				b.WriteByte('\b')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 'f':
+				b.WriteByte('\f')
+			case '"', '\'', '\\':
+				b.WriteRune(esc)
+			case 'u', 'U':
+				p.unreadRune(esc)
+				u, err := p.lexUnicodeEscape()
+				if err != nil {
+					return turtleToken{}, err
+				}
+				b.WriteRune(u)
+			default:
+				return turtleToken{}, p.errorf("invalid escape \\%c", esc)
+			}
+		default:
+			b.WriteRune(r)
+		}
+		run = 0
+	}
+}
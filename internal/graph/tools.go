@@ -8,6 +8,10 @@ import (
 	"github.com/langoai/lango/internal/toolparam"
 )
 
+// maxToolQueryRows caps the rows graph_query returns for a pattern query so a
+// broad query does not flood the model context.
+const maxToolQueryRows = 100
+
 // BuildTools creates tools for graph traversal and querying.
 func BuildTools(gs Store) []*agent.Tool {
 	return []*agent.Tool{
@@ -51,7 +55,7 @@ func BuildTools(gs Store) []*agent.Tool {
 		},
 		{
 			Name:        "graph_query",
-			Description: "Query the knowledge graph by subject or object node. Returns matching triples.",
+			Description: "Query the knowledge graph. Pass a pattern query in `query` (SPARQL subset: SELECT ?a ?b WHERE { ?a caused_by ?b . ?b resolved_by ?fix AS ?e . OPTIONAL { ... } FILTER(meta(?e, \"confidence\") > 0.5) } ORDER BY ?a LIMIT 10) to join multiple hops, or look up triples by subject or object node.",
 			SafetyLevel: agent.SafetyLevelSafe,
 			Capability: agent.ToolCapability{
 				Category:        "graph",
@@ -62,6 +66,7 @@ func BuildTools(gs Store) []*agent.Tool {
 			Parameters: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
+					"query":        map[string]interface{}{"type": "string", "description": "Pattern query; when set, the other parameters are ignored"},
 					"subject":      map[string]interface{}{"type": "string", "description": "Subject node to query by"},
 					"object":       map[string]interface{}{"type": "string", "description": "Object node to query by"},
 					"predicate":    map[string]interface{}{"type": "string", "description": "Optional predicate filter (used with subject)"},
@@ -70,12 +75,16 @@ func BuildTools(gs Store) []*agent.Tool {
 				},
 			},
 			Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				if src := toolparam.OptionalString(params, "query", ""); src != "" {
+					return runToolQuery(ctx, gs, src)
+				}
+
 				subject := toolparam.OptionalString(params, "subject", "")
 				object := toolparam.OptionalString(params, "object", "")
 				predicate := toolparam.OptionalString(params, "predicate", "")
 
 				if subject == "" && object == "" {
-					return nil, fmt.Errorf("either subject or object is required")
+					return nil, fmt.Errorf("either query, subject or object is required")
 				}
 
 				var triples []Triple
@@ -102,6 +111,23 @@ func BuildTools(gs Store) []*agent.Tool {
 	}
 }
 
+func runToolQuery(ctx context.Context, gs Store, src string) (interface{}, error) {
+	res, err := RunQuery(ctx, gs, src)
+	if err != nil {
+		return nil, fmt.Errorf("graph query: %w", err)
+	}
+	truncated := len(res.Rows) > maxToolQueryRows
+	if truncated {
+		res.Rows = res.Rows[:maxToolQueryRows]
+	}
+	return map[string]interface{}{
+		"vars":      res.Vars,
+		"rows":      res.Rows,
+		"count":     len(res.Rows),
+		"truncated": truncated,
+	}, nil
+}
+
 func filterBySubjectType(triples []Triple, subjectType string) []Triple {
 	var result []Triple
 	for _, t := range triples {
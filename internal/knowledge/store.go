@@ -191,14 +191,15 @@ func (s *Store) GetKnowledge(ctx context.Context, key string) (*KnowledgeEntry,
 	}
 
 	return &KnowledgeEntry{
-		Key:       k.Key,
-		Category:  k.Category,
-		Content:   k.Content,
-		Tags:      k.Tags,
-		Source:    k.Source,
-		Version:   k.Version,
-		CreatedAt: k.CreatedAt,
-		UpdatedAt: k.UpdatedAt,
+		Key:            k.Key,
+		Category:       k.Category,
+		Content:        k.Content,
+		Tags:           k.Tags,
+		Source:         k.Source,
+		Version:        k.Version,
+		CreatedAt:      k.CreatedAt,
+		UpdatedAt:      k.UpdatedAt,
+		RelevanceScore: k.RelevanceScore,
 	}, nil
 }
 
@@ -219,14 +220,15 @@ func (s *Store) GetKnowledgeHistory(ctx context.Context, key string) ([]Knowledg
 	result := make([]KnowledgeEntry, 0, len(entries))
 	for _, k := range entries {
 		result = append(result, KnowledgeEntry{
-			Key:       k.Key,
-			Category:  k.Category,
-			Content:   k.Content,
-			Tags:      k.Tags,
-			Source:    k.Source,
-			Version:   k.Version,
-			CreatedAt: k.CreatedAt,
-			UpdatedAt: k.UpdatedAt,
+			Key:            k.Key,
+			Category:       k.Category,
+			Content:        k.Content,
+			Tags:           k.Tags,
+			Source:         k.Source,
+			Version:        k.Version,
+			CreatedAt:      k.CreatedAt,
+			UpdatedAt:      k.UpdatedAt,
+			RelevanceScore: k.RelevanceScore,
 		})
 	}
 	return result, nil
@@ -281,14 +283,15 @@ func (s *Store) searchKnowledgeLIKE(ctx context.Context, query string, category
 	result := make([]KnowledgeEntry, 0, len(entries))
 	for _, k := range entries {
 		result = append(result, KnowledgeEntry{
-			Key:       k.Key,
-			Category:  k.Category,
-			Content:   k.Content,
-			Tags:      k.Tags,
-			Source:    k.Source,
-			Version:   k.Version,
-			CreatedAt: k.CreatedAt,
-			UpdatedAt: k.UpdatedAt,
+			Key:            k.Key,
+			Category:       k.Category,
+			Content:        k.Content,
+			Tags:           k.Tags,
+			Source:         k.Source,
+			Version:        k.Version,
+			CreatedAt:      k.CreatedAt,
+			UpdatedAt:      k.UpdatedAt,
+			RelevanceScore: k.RelevanceScore,
 		})
 	}
 	return result, nil
@@ -327,14 +330,15 @@ func (s *Store) resolveKnowledgeByKeys(ctx context.Context, ftsResults []search.
 			continue // Filtered out by category or missing.
 		}
 		result = append(result, KnowledgeEntry{
-			Key:       k.Key,
-			Category:  k.Category,
-			Content:   k.Content,
-			Tags:      k.Tags,
-			Source:    k.Source,
-			Version:   k.Version,
-			CreatedAt: k.CreatedAt,
-			UpdatedAt: k.UpdatedAt,
+			Key:            k.Key,
+			Category:       k.Category,
+			Content:        k.Content,
+			Tags:           k.Tags,
+			Source:         k.Source,
+			Version:        k.Version,
+			CreatedAt:      k.CreatedAt,
+			UpdatedAt:      k.UpdatedAt,
+			RelevanceScore: k.RelevanceScore,
 		})
 	}
 	return result, nil
@@ -415,14 +419,15 @@ func (s *Store) resolveKnowledgeScoredByKeys(ctx context.Context, ftsResults []s
 		}
 		result = append(result, ScoredKnowledgeEntry{
 			Entry: KnowledgeEntry{
-				Key:       k.Key,
-				Category:  k.Category,
-				Content:   k.Content,
-				Tags:      k.Tags,
-				Source:    k.Source,
-				Version:   k.Version,
-				CreatedAt: k.CreatedAt,
-				UpdatedAt: k.UpdatedAt,
+				Key:            k.Key,
+				Category:       k.Category,
+				Content:        k.Content,
+				Tags:           k.Tags,
+				Source:         k.Source,
+				Version:        k.Version,
+				CreatedAt:      k.CreatedAt,
+				UpdatedAt:      k.UpdatedAt,
+				RelevanceScore: k.RelevanceScore,
 			},
 			Score:        -r.Rank,
 			SearchSource: "fts5",
@@ -455,14 +460,15 @@ func (s *Store) searchKnowledgeScoredLIKE(ctx context.Context, query string, cat
 	for _, k := range entries {
 		result = append(result, ScoredKnowledgeEntry{
 			Entry: KnowledgeEntry{
-				Key:       k.Key,
-				Category:  k.Category,
-				Content:   k.Content,
-				Tags:      k.Tags,
-				Source:    k.Source,
-				Version:   k.Version,
-				CreatedAt: k.CreatedAt,
-				UpdatedAt: k.UpdatedAt,
+				Key:            k.Key,
+				Category:       k.Category,
+				Content:        k.Content,
+				Tags:           k.Tags,
+				Source:         k.Source,
+				Version:        k.Version,
+				CreatedAt:      k.CreatedAt,
+				UpdatedAt:      k.UpdatedAt,
+				RelevanceScore: k.RelevanceScore,
 			},
 			Score:        k.RelevanceScore,
 			SearchSource: "like",
@@ -499,14 +505,15 @@ func (s *Store) SearchRecentKnowledge(ctx context.Context, query string, limit i
 	result := make([]KnowledgeEntry, 0, len(entries))
 	for _, k := range entries {
 		result = append(result, KnowledgeEntry{
-			Key:       k.Key,
-			Category:  k.Category,
-			Content:   k.Content,
-			Tags:      k.Tags,
-			Source:    k.Source,
-			Version:   k.Version,
-			CreatedAt: k.CreatedAt,
-			UpdatedAt: k.UpdatedAt,
+			Key:            k.Key,
+			Category:       k.Category,
+			Content:        k.Content,
+			Tags:           k.Tags,
+			Source:         k.Source,
+			Version:        k.Version,
+			CreatedAt:      k.CreatedAt,
+			UpdatedAt:      k.UpdatedAt,
+			RelevanceScore: k.RelevanceScore,
 		})
 	}
 	return result, nil
@@ -604,6 +611,39 @@ func (s *Store) BoostRelevanceScore(ctx context.Context, key string, delta, maxS
 	return nil
 }
 
+// DemoteRelevanceScore decreases the relevance_score for the latest version of a knowledge entry.
+// Mirrors BoostRelevanceScore: floor first, then subtract, so results never drop below minScore.
+// Entries already below minScore are left alone rather than raised to it.
+func (s *Store) DemoteRelevanceScore(ctx context.Context, key string, delta, minScore float64) error {
+	// Step 1: Floor rows where score - delta would go below minScore → set to minScore.
+	_, err := s.client.Knowledge.Update().
+		Where(
+			entknowledge.Key(key),
+			entknowledge.IsLatest(true),
+			entknowledge.RelevanceScoreGTE(minScore),
+			entknowledge.RelevanceScoreLT(minScore+delta),
+		).
+		SetRelevanceScore(minScore).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("demote relevance score %q (floor): %w", key, err)
+	}
+
+	// Step 2: Demote rows where score - delta won't go below minScore.
+	_, err = s.client.Knowledge.Update().
+		Where(
+			entknowledge.Key(key),
+			entknowledge.IsLatest(true),
+			entknowledge.RelevanceScoreGTE(minScore+delta),
+		).
+		AddRelevanceScore(-delta).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("demote relevance score %q (subtract): %w", key, err)
+	}
+	return nil
+}
+
 // DecayAllRelevanceScores subtracts delta from all latest-version knowledge entries.
 // Two-step clamping: floor first, then subtract. Order matters — floor runs on original
 // scores before subtract modifies them, preventing overlap where subtract results fall into floor range.
@@ -908,6 +948,22 @@ func (s *Store) BoostLearningConfidence(ctx context.Context, id uuid.UUID, succe
 	return nil
 }
 
+// AdjustLearningConfidence adds delta (which may be negative) to a learning's
+// confidence without touching its success/occurrence counts. Used for manual
+// curation. Confidence is clamped to [0.1, 1.0].
+func (s *Store) AdjustLearningConfidence(ctx context.Context, id uuid.UUID, delta float64) error {
+	l, err := s.client.Learning.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get learning: %w", err)
+	}
+
+	newConfidence := min(max(l.Confidence+delta, 0.1), 1.0)
+	if err := l.Update().SetConfidence(newConfidence).Exec(ctx); err != nil {
+		return fmt.Errorf("adjust learning confidence: %w", err)
+	}
+	return nil
+}
+
 // SaveAuditLog creates a new audit log entry.
 func (s *Store) SaveAuditLog(ctx context.Context, entry AuditEntry) error {
 	builder := s.client.AuditLog.Create().
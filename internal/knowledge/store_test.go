@@ -1147,6 +1147,90 @@ func TestBoostRelevanceScore_Clamping(t *testing.T) {
 	}
 }
 
+func TestDemoteRelevanceScore_Clamping(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		give      string
+		initial   float64
+		delta     float64
+		minScore  float64
+		wantScore float64
+	}{
+		{give: "within range: 1.0 - 0.5 = 0.5", initial: 1.0, delta: 0.5, minScore: 0.25, wantScore: 0.5},
+		{give: "exact boundary: 0.75 - 0.5 = 0.25", initial: 0.75, delta: 0.5, minScore: 0.25, wantScore: 0.25},
+		{give: "undershoot floored: 0.5 - 0.5 -> 0.25", initial: 0.5, delta: 0.5, minScore: 0.25, wantScore: 0.25},
+		{give: "below floor untouched: 0.125 stays 0.125", initial: 0.125, delta: 0.5, minScore: 0.25, wantScore: 0.125},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			key := fmt.Sprintf("demote-%s", tt.give)
+			if err := store.SaveKnowledge(ctx, "s1", KnowledgeEntry{Key: key, Category: "fact", Content: "test"}); err != nil {
+				t.Fatalf("SaveKnowledge: %v", err)
+			}
+			_, err := store.client.Knowledge.Update().
+				Where(entknowledge.Key(key), entknowledge.IsLatest(true)).
+				SetRelevanceScore(tt.initial).
+				Save(ctx)
+			if err != nil {
+				t.Fatalf("set initial score: %v", err)
+			}
+
+			if err := store.DemoteRelevanceScore(ctx, key, tt.delta, tt.minScore); err != nil {
+				t.Fatalf("DemoteRelevanceScore: %v", err)
+			}
+
+			got, err := store.GetKnowledge(ctx, key)
+			if err != nil {
+				t.Fatalf("GetKnowledge: %v", err)
+			}
+			if got.RelevanceScore != tt.wantScore {
+				t.Errorf("want score %.2f, got %.2f", tt.wantScore, got.RelevanceScore)
+			}
+		})
+	}
+}
+
+func TestAdjustLearningConfidence(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	entry := LearningEntry{
+		Trigger:      "adjust-trigger",
+		ErrorPattern: "adjust-error",
+		Category:     entlearning.CategoryGeneral,
+	}
+	if err := store.SaveLearning(ctx, "session-1", entry); err != nil {
+		t.Fatalf("SaveLearning: %v", err)
+	}
+	entities, err := store.SearchLearningEntities(ctx, "adjust-error", 1)
+	if err != nil || len(entities) == 0 {
+		t.Fatalf("SearchLearningEntities: %v", err)
+	}
+	id := entities[0].ID
+
+	if err := store.AdjustLearningConfidence(ctx, id, 5); err != nil {
+		t.Fatalf("AdjustLearningConfidence: %v", err)
+	}
+	l, _ := store.client.Learning.Get(ctx, id)
+	if l.Confidence != 1.0 {
+		t.Errorf("want confidence capped at 1.0, got %v", l.Confidence)
+	}
+	if l.SuccessCount != entities[0].SuccessCount || l.OccurrenceCount != entities[0].OccurrenceCount {
+		t.Error("counts must not change on manual adjustment")
+	}
+
+	if err := store.AdjustLearningConfidence(ctx, id, -5); err != nil {
+		t.Fatalf("AdjustLearningConfidence: %v", err)
+	}
+	l, _ = store.client.Learning.Get(ctx, id)
+	if l.Confidence != 0.1 {
+		t.Errorf("want confidence floored at 0.1, got %v", l.Confidence)
+	}
+}
+
 func TestDecayAllRelevanceScores_Clamping(t *testing.T) {
 	store := newTestStore(t)
 	ctx := context.Background()
@@ -103,6 +103,8 @@ type KnowledgeEntry struct {
 	Version   int       // 0 = unset (callers constructing entries don't set this)
 	CreatedAt time.Time // zero = unset
 	UpdatedAt time.Time // zero = unset; populated from DB on read
+
+	RelevanceScore float64 // populated from DB on read; ignored on save
 }
 
 // LearningEntry is the domain type for learning CRUD operations.
@@ -164,7 +166,7 @@ type ToolDescriptor struct {
 // RuntimeContext holds the current session and system state.
 type RuntimeContext struct {
 	SessionKey        string
-	ChannelType       string // "telegram", "discord", "slack", "direct"
+	ChannelType       string // "telegram", "discord", "slack", "email", "direct"
 	ActiveToolCount   int
 	EncryptionEnabled bool
 	KnowledgeEnabled  bool
@@ -0,0 +1,90 @@
+// Package llmtest provides scripted text generators for tests of code built
+// on llm.TextGenerator and llm.SchemaGenerator.
+package llmtest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/langoai/lango/internal/provider"
+)
+
+// ScriptedGenerator replies with Responses in order, repeating the last, and
+// records every prompt it receives. It implements llm.TextGenerator.
+type ScriptedGenerator struct {
+	Responses []string
+	Err       error // returned by every call when set
+
+	mu      sync.Mutex
+	systems []string
+	users   []string
+}
+
+// NewScriptedGenerator creates a ScriptedGenerator with the given replies.
+func NewScriptedGenerator(responses ...string) *ScriptedGenerator {
+	return &ScriptedGenerator{Responses: responses}
+}
+
+func (g *ScriptedGenerator) GenerateText(_ context.Context, systemPrompt, userPrompt string) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.systems = append(g.systems, systemPrompt)
+	g.users = append(g.users, userPrompt)
+	if g.Err != nil {
+		return "", g.Err
+	}
+	if len(g.Responses) == 0 {
+		return "", nil
+	}
+	i := min(len(g.users), len(g.Responses)) - 1
+	return g.Responses[i], nil
+}
+
+// Calls returns the number of generate calls.
+func (g *ScriptedGenerator) Calls() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.users)
+}
+
+// SystemPrompts returns the system prompts received, in order.
+func (g *ScriptedGenerator) SystemPrompts() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.systems...)
+}
+
+// UserPrompts returns the user prompts received, in order.
+func (g *ScriptedGenerator) UserPrompts() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.users...)
+}
+
+// SchemaGenerator is a ScriptedGenerator with native schema support. It
+// implements llm.SchemaGenerator; native calls share the script with
+// GenerateText.
+type SchemaGenerator struct {
+	ScriptedGenerator
+	NativeErr error // returned by every GenerateWithSchema call when set
+
+	formatMu sync.Mutex
+	formats  []provider.ResponseFormat
+}
+
+func (g *SchemaGenerator) GenerateWithSchema(ctx context.Context, systemPrompt, userPrompt string, format provider.ResponseFormat) (string, error) {
+	g.formatMu.Lock()
+	g.formats = append(g.formats, format)
+	g.formatMu.Unlock()
+	if g.NativeErr != nil {
+		return "", g.NativeErr
+	}
+	return g.GenerateText(ctx, systemPrompt, userPrompt)
+}
+
+// Formats returns the response formats of native calls, in order.
+func (g *SchemaGenerator) Formats() []provider.ResponseFormat {
+	g.formatMu.Lock()
+	defer g.formatMu.Unlock()
+	return append([]provider.ResponseFormat(nil), g.formats...)
+}
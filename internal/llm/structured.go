@@ -0,0 +1,168 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/langoai/lango/internal/provider"
+)
+
+// DefaultStructuredAttempts is how many responses GenerateStructured requests
+// before giving up on output that does not match the schema.
+const DefaultStructuredAttempts = 3
+
+// ErrInvalidStructuredOutput is returned when no response matched the schema
+// within the allowed attempts.
+var ErrInvalidStructuredOutput = errors.New("structured output does not match schema")
+
+// SchemaGenerator is a TextGenerator that can constrain its reply to a JSON
+// schema using the provider's native structured output mode.
+type SchemaGenerator interface {
+	TextGenerator
+	GenerateWithSchema(ctx context.Context, systemPrompt, userPrompt string, format provider.ResponseFormat) (string, error)
+}
+
+type structuredConfig struct {
+	name        string
+	description string
+	attempts    int
+}
+
+// StructuredOption configures GenerateStructured.
+type StructuredOption func(*structuredConfig)
+
+// WithSchemaName names the response schema and describes its content.
+// Providers surface both to the model.
+func WithSchemaName(name, description string) StructuredOption {
+	return func(c *structuredConfig) {
+		c.name = name
+		c.description = description
+	}
+}
+
+// WithMaxAttempts sets how many responses are requested before giving up.
+// Values below 1 are ignored.
+func WithMaxAttempts(n int) StructuredOption {
+	return func(c *structuredConfig) {
+		if n > 0 {
+			c.attempts = n
+		}
+	}
+}
+
+// GenerateStructured asks gen for a JSON document matching the schema
+// inferred from T and decodes it. A SchemaGenerator is asked to enforce the
+// schema natively; any other generator gets the schema in its system prompt.
+// When a native call fails, for example because the endpoint rejects the
+// response format, the remaining calls use the prompt instead. Either way the
+// reply is validated, and an invalid reply is sent back to the model with the
+// validation error for repair until the attempts run out. Other generator
+// errors are returned immediately.
+func GenerateStructured[T any](ctx context.Context, gen TextGenerator, systemPrompt, userPrompt string, opts ...StructuredOption) (T, error) {
+	var zero T
+	cfg := structuredConfig{name: "response", attempts: DefaultStructuredAttempts}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	schema, err := jsonschema.For[T](nil)
+	if err != nil {
+		return zero, fmt.Errorf("infer schema: %w", err)
+	}
+	dropNullable(schema)
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		return zero, fmt.Errorf("resolve schema: %w", err)
+	}
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return zero, fmt.Errorf("marshal schema: %w", err)
+	}
+
+	promptSystem := fmt.Sprintf("%s\n\nRespond with only a JSON document, without prose or code fences, that validates against this JSON schema:\n%s",
+		systemPrompt, schemaJSON)
+	generate, system := gen.GenerateText, promptSystem
+	native := false
+	if sg, ok := gen.(SchemaGenerator); ok {
+		format := provider.ResponseFormat{Name: cfg.name, Description: cfg.description}
+		if err := json.Unmarshal(schemaJSON, &format.Schema); err != nil {
+			return zero, fmt.Errorf("decode schema: %w", err)
+		}
+		generate = func(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+			return sg.GenerateWithSchema(ctx, systemPrompt, userPrompt, format)
+		}
+		system, native = systemPrompt, true
+	}
+
+	prompt := userPrompt
+	var lastErr error
+	for range cfg.attempts {
+		raw, err := generate(ctx, system, prompt)
+		if err != nil && native && ctx.Err() == nil {
+			// Not every OpenAI-compatible endpoint accepts a JSON schema
+			// response format; retry with the schema in the prompt.
+			nativeErr := err
+			generate, system, native = gen.GenerateText, promptSystem, false
+			if raw, err = generate(ctx, system, prompt); err != nil {
+				err = fmt.Errorf("%w (native structured output: %v)", err, nativeErr)
+			}
+		}
+		if err != nil {
+			return zero, err
+		}
+
+		doc := extractJSON(raw)
+		var instance any
+		if err := json.Unmarshal([]byte(doc), &instance); err != nil {
+			lastErr = fmt.Errorf("parse JSON: %w", err)
+		} else if err := resolved.Validate(instance); err != nil {
+			lastErr = err
+		} else {
+			var out T
+			if err := json.Unmarshal([]byte(doc), &out); err != nil {
+				return zero, fmt.Errorf("decode %s: %w", cfg.name, err)
+			}
+			return out, nil
+		}
+
+		prompt = fmt.Sprintf("%s\n\nYour previous response was rejected:\n%s\n\nError: %v\n\nRespond again with only a JSON document that fixes the error.",
+			userPrompt, raw, lastErr)
+	}
+	return zero, fmt.Errorf("%w after %d attempts: %v", ErrInvalidStructuredOutput, cfg.attempts, lastErr)
+}
+
+// extractJSON trims whitespace and a surrounding markdown code fence, which
+// models add even when asked not to.
+func extractJSON(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```")
+	if nl := strings.IndexByte(s, '\n'); nl >= 0 {
+		s = s[nl+1:] // drop the language tag line
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "```"))
+}
+
+// dropNullable rewrites the ["null", T] types inferred for slices, maps and
+// pointers to plain T. Gemini accepts only a single type per schema, and a
+// model should send an empty list rather than null.
+func dropNullable(s *jsonschema.Schema) {
+	if s == nil {
+		return
+	}
+	if len(s.Types) == 2 && s.Types[0] == "null" {
+		s.Type, s.Types = s.Types[1], nil
+	}
+	for _, p := range s.Properties {
+		dropNullable(p)
+	}
+	dropNullable(s.Items)
+	dropNullable(s.AdditionalProperties)
+}
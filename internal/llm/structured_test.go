@@ -0,0 +1,132 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/llm/llmtest"
+)
+
+type answer struct {
+	Title string   `json:"title"`
+	Tags  []string `json:"tags"`
+}
+
+func TestGenerateStructured_RepairsFallback(t *testing.T) {
+	t.Parallel()
+
+	gen := llmtest.NewScriptedGenerator(
+		`{"title": "unterminated`,
+		`{"title": "Go"}`,
+		"```json\n{\"title\": \"Go\", \"tags\": [\"lang\"]}\n```",
+	)
+
+	got, err := GenerateStructured[answer](context.Background(), gen, "system", "describe Go")
+	require.NoError(t, err)
+	assert.Equal(t, answer{Title: "Go", Tags: []string{"lang"}}, got)
+
+	systems, users := gen.SystemPrompts(), gen.UserPrompts()
+	require.Len(t, users, 3)
+	assert.Contains(t, systems[0], "JSON schema")
+	assert.Contains(t, systems[0], `"tags"`)
+	assert.Equal(t, "describe Go", users[0])
+	assert.Contains(t, users[1], `{"title": "unterminated`)
+	assert.Contains(t, users[1], "parse JSON")
+	assert.Contains(t, users[2], "tags")
+}
+
+func TestGenerateStructured_Native(t *testing.T) {
+	t.Parallel()
+
+	gen := &llmtest.SchemaGenerator{ScriptedGenerator: llmtest.ScriptedGenerator{Responses: []string{
+		`not json`,
+		`{"title": "Go", "tags": []}`,
+	}}}
+
+	got, err := GenerateStructured[answer](context.Background(), gen, "system", "describe Go",
+		WithSchemaName("answer", "A titled answer"))
+	require.NoError(t, err)
+	assert.Equal(t, answer{Title: "Go", Tags: []string{}}, got)
+
+	assert.Equal(t, "system", gen.SystemPrompts()[0], "native generators get the prompt unchanged")
+	formats := gen.Formats()
+	require.Len(t, formats, 2)
+	format := formats[0]
+	assert.Equal(t, "answer", format.Name)
+	assert.Equal(t, "A titled answer", format.Description)
+	assert.Equal(t, "object", format.Schema["type"])
+	props := format.Schema["properties"].(map[string]interface{})
+	assert.Equal(t, "array", props["tags"].(map[string]interface{})["type"], "nullable types are dropped")
+}
+
+func TestGenerateStructured_NativeUnsupported(t *testing.T) {
+	t.Parallel()
+
+	gen := &llmtest.SchemaGenerator{
+		ScriptedGenerator: llmtest.ScriptedGenerator{Responses: []string{`{"title": "Go"}`, `{"title": "Go", "tags": []}`}},
+		NativeErr:         errors.New("400: response_format json_schema is not supported"),
+	}
+
+	got, err := GenerateStructured[answer](context.Background(), gen, "system", "describe Go")
+	require.NoError(t, err)
+	assert.Equal(t, answer{Title: "Go", Tags: []string{}}, got)
+
+	assert.Len(t, gen.Formats(), 1, "a rejected native call is not repeated")
+	systems := gen.SystemPrompts()
+	require.Len(t, systems, 2)
+	assert.Contains(t, systems[0], "JSON schema", "the fallback puts the schema in the prompt")
+	assert.Contains(t, systems[1], "JSON schema")
+}
+
+func TestGenerateStructured_GivesUp(t *testing.T) {
+	t.Parallel()
+
+	gen := llmtest.NewScriptedGenerator(`{"title": 7}`)
+
+	_, err := GenerateStructured[answer](context.Background(), gen, "system", "user", WithMaxAttempts(2))
+	require.ErrorIs(t, err, ErrInvalidStructuredOutput)
+	assert.ErrorContains(t, err, "after 2 attempts")
+	assert.Equal(t, 2, gen.Calls())
+}
+
+func TestGenerateStructured_GeneratorError(t *testing.T) {
+	t.Parallel()
+
+	wantErr := errors.New("provider down")
+	gen := &llmtest.ScriptedGenerator{Err: wantErr}
+
+	_, err := GenerateStructured[answer](context.Background(), gen, "system", "user")
+	require.ErrorIs(t, err, wantErr)
+	assert.Equal(t, 1, gen.Calls(), "generator errors are not retried")
+
+	native := &llmtest.SchemaGenerator{ScriptedGenerator: llmtest.ScriptedGenerator{Err: wantErr}, NativeErr: errors.New("bad format")}
+	_, err = GenerateStructured[answer](context.Background(), native, "system", "user")
+	require.ErrorIs(t, err, wantErr)
+	assert.ErrorContains(t, err, "bad format")
+	assert.Equal(t, 1, native.Calls(), "the prompt fallback is tried once")
+}
+
+func TestExtractJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give string
+		want string
+	}{
+		{give: `{"a":1}`, want: `{"a":1}`},
+		{give: "  {\"a\":1}\n", want: `{"a":1}`},
+		{give: "```json\n{\"a\":1}\n```", want: `{"a":1}`},
+		{give: "```\n{\"a\":1}\n```\n", want: `{"a":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, extractJSON(tt.give))
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/langoai/lango/internal/agent"
+	"github.com/langoai/lango/internal/config"
 	"github.com/langoai/lango/internal/logging"
 )
 
@@ -36,8 +37,12 @@ func AdaptTool(dt DiscoveredTool, conn *ServerConnection, maxOutputTokens int) *
 	// Convert MCP InputSchema to agent.Tool parameters
 	params := buildParams(tool.InputSchema)
 
-	// Determine safety level from server config
-	safety := parseSafetyLevel(conn.cfg.SafetyLevel)
+	// Determine safety level from server config, honoring per-tool overrides
+	level := conn.cfg.SafetyLevel
+	if override, ok := conn.cfg.ToolSafety[tool.Name]; ok {
+		level = override
+	}
+	safety := parseSafetyLevel(level)
 
 	t := &agent.Tool{
 		Name:        toolName,
@@ -206,6 +211,15 @@ func formatContent(content []sdkmcp.Content, maxTokens int) string {
 	return result
 }
 
+// toolExposed reports whether a server tool passes the server's
+// includeTools/excludeTools patterns.
+func toolExposed(cfg config.MCPServerConfig, name string) bool {
+	if len(cfg.IncludeTools) > 0 && !matchesAny(name, cfg.IncludeTools) {
+		return false
+	}
+	return !matchesAny(name, cfg.ExcludeTools)
+}
+
 // parseSafetyLevel converts a config string to an agent.SafetyLevel.
 func parseSafetyLevel(level string) agent.SafetyLevel {
 	switch strings.ToLower(level) {
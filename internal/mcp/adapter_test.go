@@ -288,3 +288,38 @@ func TestBuildParams_JSONRoundTrip(t *testing.T) {
 	assert.Len(t, params, 1)
 	assert.Contains(t, params, "name")
 }
+
+func TestAdaptTool_ToolSafetyOverride(t *testing.T) {
+	t.Parallel()
+
+	conn := NewServerConnection("gh", config.MCPServerConfig{
+		ToolSafety: map[string]string{"list_issues": "safe"},
+	}, config.MCPConfig{})
+
+	safe := AdaptTool(DiscoveredTool{ServerName: "gh", Tool: &sdkmcp.Tool{Name: "list_issues"}}, conn, 0)
+	other := AdaptTool(DiscoveredTool{ServerName: "gh", Tool: &sdkmcp.Tool{Name: "delete_repo"}}, conn, 0)
+
+	assert.Equal(t, agent.SafetyLevelSafe, safe.SafetyLevel)
+	assert.Equal(t, agent.SafetyLevelDangerous, other.SafetyLevel)
+}
+
+func TestToolExposed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give   config.MCPServerConfig
+		tool   string
+		wantOK bool
+	}{
+		{give: config.MCPServerConfig{}, tool: "anything", wantOK: true},
+		{give: config.MCPServerConfig{IncludeTools: []string{"list_*", "get_issue"}}, tool: "list_issues", wantOK: true},
+		{give: config.MCPServerConfig{IncludeTools: []string{"list_*", "get_issue"}}, tool: "get_issue", wantOK: true},
+		{give: config.MCPServerConfig{IncludeTools: []string{"list_*", "get_issue"}}, tool: "delete_issue", wantOK: false},
+		{give: config.MCPServerConfig{ExcludeTools: []string{"delete_*"}}, tool: "delete_issue", wantOK: false},
+		{give: config.MCPServerConfig{IncludeTools: []string{"*_issue"}, ExcludeTools: []string{"delete_*"}}, tool: "delete_issue", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.wantOK, toolExposed(tt.give, tt.tool), "tool=%s cfg=%+v", tt.tool, tt.give)
+	}
+}
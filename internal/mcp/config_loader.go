@@ -68,6 +68,9 @@ func loadMCPFile(path string) (map[string]config.MCPServerConfig, error) {
 		for k, v := range srv.Headers {
 			srv.Headers[k] = ExpandEnv(v)
 		}
+		if srv.OAuth != nil {
+			srv.OAuth.ClientSecret = ExpandEnv(srv.OAuth.ClientSecret)
+		}
 		fc.MCPServers[name] = srv
 	}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/langoai/lango/internal/eventbus"
 	"github.com/langoai/lango/internal/logging"
 	sandboxos "github.com/langoai/lango/internal/sandbox/os"
+	"github.com/langoai/lango/internal/security"
 )
 
 // ServerState represents the lifecycle state of an MCP server connection.
@@ -84,6 +85,11 @@ type ServerConnection struct {
 	workspacePath string        // User workspace root, used by MCPServerPolicy to walk up to .git
 	dataRoot      string        // Lango control-plane root, masked from sandboxed MCP child
 	bus           *eventbus.Bus // event bus for SandboxDecisionEvent (optional)
+	secrets       security.SecretResolver
+	tokens        TokenStore        // OAuth credential store (optional)
+	oauth         *oauthTokenSource // created on first OAuth transport, kept across reconnects
+
+	onResourceUpdated func(server, uri string) // resource subscription notifications (optional)
 
 	stopCh chan struct{}
 }
@@ -132,6 +138,48 @@ func (sc *ServerConnection) SetEventBus(bus *eventbus.Bus) {
 	sc.bus = bus
 }
 
+// SetSecretResolver attaches the resolver used to expand {{secret:name}}
+// references in this server's env and headers under the secret policy.
+func (sc *ServerConnection) SetSecretResolver(r security.SecretResolver) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.secrets = r
+}
+
+// SetTokenStore attaches the store holding this server's OAuth credentials.
+func (sc *ServerConnection) SetTokenStore(store TokenStore) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.tokens = store
+}
+
+// SetResourceUpdateHandler sets the callback invoked when the server reports
+// that a subscribed resource changed.
+func (sc *ServerConnection) SetResourceUpdateHandler(fn func(server, uri string)) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.onResourceUpdated = fn
+}
+
+// resolvedEnvAndHeaders expands secret references in the configured env and
+// headers. The server name is the policy accessor.
+func (sc *ServerConnection) resolvedEnvAndHeaders() (map[string]string, map[string]string, error) {
+	sc.mu.RLock()
+	resolver := sc.secrets
+	sc.mu.RUnlock()
+
+	acc := security.SecretAccessor{MCPServer: sc.name}
+	env, err := ResolveSecretRefsMap(context.Background(), sc.cfg.Env, resolver, acc)
+	if err != nil {
+		return nil, nil, err
+	}
+	headers, err := ResolveSecretRefsMap(context.Background(), sc.cfg.Headers, resolver, acc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return env, headers, nil
+}
+
 // publishSandboxDecision publishes a SandboxDecisionEvent for this MCP server's
 // transport-creation moment. SessionKey is intentionally empty: MCP server
 // lifecycle is process-level, not session-bound.
@@ -163,6 +211,36 @@ func (sc *ServerConnection) Session() *sdkmcp.ClientSession {
 	return sc.session
 }
 
+// Resources returns the discovered resources from this server.
+func (sc *ServerConnection) Resources() []DiscoveredResource {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	out := make([]DiscoveredResource, len(sc.resources))
+	copy(out, sc.resources)
+	return out
+}
+
+// Prompts returns the discovered prompts from this server.
+func (sc *ServerConnection) Prompts() []DiscoveredPrompt {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	out := make([]DiscoveredPrompt, len(sc.prompts))
+	copy(out, sc.prompts)
+	return out
+}
+
+// SupportsResourceSubscribe reports whether the server accepts
+// resources/subscribe requests.
+func (sc *ServerConnection) SupportsResourceSubscribe() bool {
+	session := sc.Session()
+	if session == nil {
+		return false
+	}
+	res := session.InitializeResult()
+	return res != nil && res.Capabilities != nil &&
+		res.Capabilities.Resources != nil && res.Capabilities.Resources.Subscribe
+}
+
 // Tools returns the discovered tools from this server.
 func (sc *ServerConnection) Tools() []DiscoveredTool {
 	sc.mu.RLock()
@@ -186,7 +264,7 @@ func (sc *ServerConnection) Connect(ctx context.Context) error {
 
 	client := sdkmcp.NewClient(
 		&sdkmcp.Implementation{Name: "lango", Version: "1.0.0"},
-		nil,
+		sc.clientOptions(),
 	)
 
 	timeout := sc.timeout()
@@ -339,14 +417,19 @@ func (sc *ServerConnection) timeout() time.Duration {
 }
 
 func (sc *ServerConnection) createTransport() (sdkmcp.Transport, error) {
+	env, headers, err := sc.resolvedEnvAndHeaders()
+	if err != nil {
+		return nil, err
+	}
+
 	switch sc.cfg.Transport {
 	case "", "stdio":
 		if sc.cfg.Command == "" {
 			return nil, fmt.Errorf("%w: stdio requires command", ErrInvalidTransport)
 		}
 		cmd := exec.Command(sc.cfg.Command, sc.cfg.Args...)
-		if len(sc.cfg.Env) > 0 {
-			cmd.Env = BuildEnvSlice(sc.cfg.Env)
+		if len(env) > 0 {
+			cmd.Env = BuildEnvSlice(env)
 		}
 		// Set cmd.Dir explicitly so the MCP child runs inside the user's
 		// workspace (and so the sandbox policy's git walk-up has a
@@ -386,41 +469,88 @@ func (sc *ServerConnection) createTransport() (sdkmcp.Transport, error) {
 		if sc.cfg.URL == "" {
 			return nil, fmt.Errorf("%w: http requires url", ErrInvalidTransport)
 		}
-		t := &sdkmcp.StreamableClientTransport{
-			Endpoint: sc.cfg.URL,
-		}
-		if len(sc.cfg.Headers) > 0 {
-			t.HTTPClient = &http.Client{
-				Transport: &headerRoundTripper{
-					base:    http.DefaultTransport,
-					headers: sc.cfg.Headers,
-				},
-			}
+		httpClient, err := sc.httpClient(headers)
+		if err != nil {
+			return nil, err
 		}
-		return t, nil
+		return &sdkmcp.StreamableClientTransport{
+			Endpoint:   sc.cfg.URL,
+			HTTPClient: httpClient,
+		}, nil
 
 	case "sse":
 		if sc.cfg.URL == "" {
 			return nil, fmt.Errorf("%w: sse requires url", ErrInvalidTransport)
 		}
-		t := &sdkmcp.SSEClientTransport{
-			Endpoint: sc.cfg.URL,
-		}
-		if len(sc.cfg.Headers) > 0 {
-			t.HTTPClient = &http.Client{
-				Transport: &headerRoundTripper{
-					base:    http.DefaultTransport,
-					headers: sc.cfg.Headers,
-				},
-			}
+		httpClient, err := sc.httpClient(headers)
+		if err != nil {
+			return nil, err
 		}
-		return t, nil
+		return &sdkmcp.SSEClientTransport{
+			Endpoint:   sc.cfg.URL,
+			HTTPClient: httpClient,
+		}, nil
 
 	default:
 		return nil, fmt.Errorf("%w: %q", ErrInvalidTransport, sc.cfg.Transport)
 	}
 }
 
+// httpClient builds the HTTP client for http/sse transports, layering
+// static headers and OAuth bearer tokens over the default transport. It
+// returns nil when neither is configured so the SDK default is used.
+func (sc *ServerConnection) httpClient(headers map[string]string) (*http.Client, error) {
+	if len(headers) == 0 && sc.cfg.OAuth == nil {
+		return nil, nil
+	}
+
+	var rt http.RoundTripper = http.DefaultTransport
+	if sc.cfg.OAuth != nil {
+		sc.mu.Lock()
+		if sc.tokens == nil {
+			sc.mu.Unlock()
+			return nil, fmt.Errorf("%w: no secrets store available for server %q", ErrOAuthRequired, sc.name)
+		}
+		if sc.oauth == nil {
+			sc.oauth = newOAuthTokenSource(sc.name, sc.tokens)
+		}
+		source := sc.oauth
+		sc.mu.Unlock()
+
+		// Fail fast with an actionable error instead of a rejected handshake.
+		if _, err := source.Token(); err != nil {
+			return nil, err
+		}
+		rt = &oauthRoundTripper{base: rt, source: source}
+	}
+	if len(headers) > 0 {
+		rt = &headerRoundTripper{base: rt, headers: headers}
+	}
+	return &http.Client{Transport: rt}, nil
+}
+
+// clientOptions wires server notifications: list changes trigger
+// rediscovery, resource updates are forwarded to the update handler.
+func (sc *ServerConnection) clientOptions() *sdkmcp.ClientOptions {
+	rediscover := func() {
+		// Notification handlers run on the session's read loop; discovery
+		// issues requests on the same session, so it must not block here.
+		go sc.discoverCapabilities(context.Background())
+	}
+	return &sdkmcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *sdkmcp.ResourceUpdatedNotificationRequest) {
+			sc.mu.RLock()
+			fn := sc.onResourceUpdated
+			sc.mu.RUnlock()
+			if fn != nil && req.Params != nil {
+				fn(sc.name, req.Params.URI)
+			}
+		},
+		ResourceListChangedHandler: func(context.Context, *sdkmcp.ResourceListChangedRequest) { rediscover() },
+		PromptListChangedHandler:   func(context.Context, *sdkmcp.PromptListChangedRequest) { rediscover() },
+	}
+}
+
 func (sc *ServerConnection) discoverCapabilities(ctx context.Context) {
 	session := sc.Session()
 	if session == nil {
@@ -437,6 +567,9 @@ func (sc *ServerConnection) discoverCapabilities(ctx context.Context) {
 			logging.App().Warnw("MCP tool discovery error", "server", sc.name, "error", err)
 			break
 		}
+		if !toolExposed(sc.cfg, tool.Name) {
+			continue
+		}
 		tools = append(tools, DiscoveredTool{
 			ServerName: sc.name,
 			Tool:       tool,
@@ -1,9 +1,13 @@
 package mcp
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"regexp"
 	"strings"
+
+	"github.com/langoai/lango/internal/security"
 )
 
 var envVarWithDefaultRegex = regexp.MustCompile(`\$\{([^}]+)\}`)
@@ -39,6 +43,35 @@ func ExpandEnvMap(m map[string]string) map[string]string {
 	return out
 }
 
+// ResolveSecretRefsMap expands {{secret:name}} tokens in all values of m
+// through resolver, which enforces each secret's access policy for the MCP
+// server identified by acc. Returns m unchanged when it holds no tokens.
+func ResolveSecretRefsMap(ctx context.Context, m map[string]string, resolver security.SecretResolver, acc security.SecretAccessor) (map[string]string, error) {
+	hasRefs := false
+	for _, v := range m {
+		if security.HasSecretRefs(v) {
+			hasRefs = true
+			break
+		}
+	}
+	if !hasRefs {
+		return m, nil
+	}
+	if resolver == nil {
+		return nil, fmt.Errorf("%w: secret references require a secrets store", ErrSecretResolution)
+	}
+
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		resolved, err := security.ExpandSecretRefs(ctx, v, resolver, acc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrSecretResolution, k, err)
+		}
+		out[k] = resolved
+	}
+	return out, nil
+}
+
 // BuildEnvSlice converts a map of env vars to a slice of "KEY=VALUE" strings
 // suitable for os/exec.Cmd.Env, inheriting the current process environment.
 func BuildEnvSlice(extra map[string]string) []string {
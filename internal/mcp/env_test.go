@@ -1,9 +1,13 @@
 package mcp
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/security"
 )
 
 func TestExpandEnv(t *testing.T) {
@@ -55,3 +59,37 @@ func TestBuildEnvSlice(t *testing.T) {
 func TestBuildEnvSlice_Empty(t *testing.T) {
 	assert.Nil(t, BuildEnvSlice(nil))
 }
+
+type stubSecretResolver struct {
+	values map[string]string
+}
+
+func (s stubSecretResolver) ResolveSecret(_ context.Context, name string, acc security.SecretAccessor) ([]byte, error) {
+	if acc.MCPServer != "github" {
+		return nil, security.ErrSecretAccessDenied
+	}
+	return []byte(s.values[name]), nil
+}
+
+func TestResolveSecretRefsMap(t *testing.T) {
+	resolver := stubSecretResolver{values: map[string]string{"gh": "ghp_test"}}
+	ctx := context.Background()
+
+	plain := map[string]string{"A": "plain"}
+	out, err := ResolveSecretRefsMap(ctx, plain, nil, security.SecretAccessor{})
+	require.NoError(t, err)
+	assert.Equal(t, plain, out)
+
+	in := map[string]string{"Authorization": "Bearer {{secret:gh}}", "X": "y"}
+	out, err = ResolveSecretRefsMap(ctx, in, resolver, security.SecretAccessor{MCPServer: "github"})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer ghp_test", out["Authorization"])
+	assert.Equal(t, "y", out["X"])
+
+	_, err = ResolveSecretRefsMap(ctx, in, resolver, security.SecretAccessor{MCPServer: "slack"})
+	assert.ErrorIs(t, err, ErrSecretResolution)
+	assert.ErrorIs(t, err, security.ErrSecretAccessDenied)
+
+	_, err = ResolveSecretRefsMap(ctx, in, nil, security.SecretAccessor{MCPServer: "github"})
+	assert.ErrorIs(t, err, ErrSecretResolution)
+}
@@ -1,5 +1,6 @@
 // Package mcp provides MCP (Model Context Protocol) client integration
-// for connecting to external MCP servers and adapting their tools.
+// for connecting to external MCP servers and adapting their tools, and a
+// server that exposes Lango's own tools and data to MCP clients.
 package mcp
 
 import "errors"
@@ -19,4 +20,16 @@ var (
 
 	// ErrInvalidTransport indicates an unsupported transport type.
 	ErrInvalidTransport = errors.New("mcp: invalid transport type")
+
+	// ErrSecretResolution indicates a {{secret:...}} reference in server env
+	// or headers could not be resolved (missing, expired, or denied by policy).
+	ErrSecretResolution = errors.New("mcp: secret resolution failed")
+
+	// ErrOAuthRequired indicates an OAuth server has no usable token and
+	// must be authorized with `lango mcp auth`.
+	ErrOAuthRequired = errors.New("mcp: oauth authorization required")
+
+	// ErrUnauthenticatedListen indicates the HTTP transport would serve a
+	// non-loopback address without an auth token.
+	ErrUnauthenticatedListen = errors.New("mcp: refusing to serve a non-loopback address without mcp.serve.authToken")
 )
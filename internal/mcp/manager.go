@@ -2,12 +2,14 @@ package mcp
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"github.com/langoai/lango/internal/config"
 	"github.com/langoai/lango/internal/eventbus"
 	"github.com/langoai/lango/internal/logging"
 	sandboxos "github.com/langoai/lango/internal/sandbox/os"
+	"github.com/langoai/lango/internal/security"
 )
 
 // ServerManager manages multiple MCP server connections.
@@ -20,6 +22,9 @@ type ServerManager struct {
 	workspacePath string        // User workspace root, forwarded to each connection for MCPServerPolicy walk-up
 	dataRoot      string        // Lango control-plane root, forwarded to each connection
 	bus           *eventbus.Bus // event bus, forwarded to each connection
+	secrets       security.SecretResolver
+	tokens        TokenStore               // OAuth credential store, forwarded to each connection
+	onUpdate      func(server, uri string) // resource update callback, forwarded to each connection
 }
 
 // NewServerManager creates a new manager for the given config.
@@ -70,6 +75,39 @@ func (m *ServerManager) SetEventBus(bus *eventbus.Bus) {
 	}
 }
 
+// SetSecretResolver attaches the resolver used to expand {{secret:name}}
+// references in server env and headers on all current and future connections.
+func (m *ServerManager) SetSecretResolver(r security.SecretResolver) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secrets = r
+	for _, s := range m.servers {
+		s.SetSecretResolver(r)
+	}
+}
+
+// SetResourceUpdateHandler sets the callback for subscribed resource
+// updates on all current and future connections.
+func (m *ServerManager) SetResourceUpdateHandler(fn func(server, uri string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onUpdate = fn
+	for _, s := range m.servers {
+		s.SetResourceUpdateHandler(fn)
+	}
+}
+
+// SetTokenStore attaches the OAuth credential store on all current and
+// future connections.
+func (m *ServerManager) SetTokenStore(store TokenStore) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens = store
+	for _, s := range m.servers {
+		s.SetTokenStore(store)
+	}
+}
+
 // ConnectAll connects to all configured and enabled servers.
 // Returns a map of server names to errors for any that failed.
 func (m *ServerManager) ConnectAll(ctx context.Context) map[string]error {
@@ -90,6 +128,15 @@ func (m *ServerManager) ConnectAll(ctx context.Context) map[string]error {
 		if m.bus != nil {
 			conn.SetEventBus(m.bus)
 		}
+		if m.secrets != nil {
+			conn.SetSecretResolver(m.secrets)
+		}
+		if m.tokens != nil {
+			conn.SetTokenStore(m.tokens)
+		}
+		if m.onUpdate != nil {
+			conn.SetResourceUpdateHandler(m.onUpdate)
+		}
 		conn.SetFailClosed(m.failClosed)
 		m.mu.Lock()
 		m.servers[name] = conn
@@ -149,12 +196,7 @@ func (m *ServerManager) AllResources() []DiscoveredResource {
 
 	var all []DiscoveredResource
 	for _, s := range m.servers {
-		sc := s
-		sc.mu.RLock()
-		res := make([]DiscoveredResource, len(sc.resources))
-		copy(res, sc.resources)
-		sc.mu.RUnlock()
-		all = append(all, res...)
+		all = append(all, s.Resources()...)
 	}
 	return all
 }
@@ -166,12 +208,7 @@ func (m *ServerManager) AllPrompts() []DiscoveredPrompt {
 
 	var all []DiscoveredPrompt
 	for _, s := range m.servers {
-		sc := s
-		sc.mu.RLock()
-		pr := make([]DiscoveredPrompt, len(sc.prompts))
-		copy(pr, sc.prompts)
-		sc.mu.RUnlock()
-		all = append(all, pr...)
+		all = append(all, s.Prompts()...)
 	}
 	return all
 }
@@ -202,3 +239,15 @@ func (m *ServerManager) ServerCount() int {
 	defer m.mu.RUnlock()
 	return len(m.servers)
 }
+
+// serverNames returns the managed server names in sorted order.
+func (m *ServerManager) serverNames() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.servers))
+	for name := range m.servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,459 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/logging"
+	"github.com/langoai/lango/internal/security"
+)
+
+// oauthSecretPrefix namespaces OAuth credentials in the secrets store.
+const oauthSecretPrefix = "mcp.oauth."
+
+// oauthSecretVersions is how many versions of a server's OAuth credentials
+// are kept. Every token refresh writes a new version.
+const oauthSecretVersions = 2
+
+// TokenStore persists OAuth credentials. Satisfied by *security.SecretsStore.
+type TokenStore interface {
+	Store(ctx context.Context, name string, value []byte) error
+	Get(ctx context.Context, name string) ([]byte, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// versionPruner is implemented by token stores that keep a version history,
+// such as *security.SecretsStore.
+type versionPruner interface {
+	PruneVersions(ctx context.Context, name string, keep int) (int, error)
+}
+
+// OAuthSecretName returns the secrets store key holding a server's OAuth credentials.
+func OAuthSecretName(server string) string {
+	return oauthSecretPrefix + server
+}
+
+// oauthCredentials is the persisted OAuth state of one server: the client
+// registration, the endpoints it was issued for, and the current token.
+type oauthCredentials struct {
+	ClientID     string        `json:"clientId"`
+	ClientSecret string        `json:"clientSecret,omitempty"`
+	AuthURL      string        `json:"authUrl"`
+	TokenURL     string        `json:"tokenUrl"`
+	Scopes       []string      `json:"scopes,omitempty"`
+	Token        *oauth2.Token `json:"token"`
+}
+
+func (c *oauthCredentials) config(redirectURL string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		Endpoint:     oauth2.Endpoint{AuthURL: c.AuthURL, TokenURL: c.TokenURL},
+		RedirectURL:  redirectURL,
+		Scopes:       c.Scopes,
+	}
+}
+
+func loadOAuthCredentials(ctx context.Context, store TokenStore, server string) (*oauthCredentials, error) {
+	data, err := store.Get(ctx, OAuthSecretName(server))
+	if err != nil {
+		if errors.Is(err, security.ErrSecretNotFound) {
+			return nil, fmt.Errorf("%w: run `lango mcp auth %s`", ErrOAuthRequired, server)
+		}
+		return nil, fmt.Errorf("load oauth credentials: %w", err)
+	}
+	var creds oauthCredentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("decode oauth credentials: %w", err)
+	}
+	if creds.Token == nil {
+		return nil, fmt.Errorf("%w: run `lango mcp auth %s`", ErrOAuthRequired, server)
+	}
+	return &creds, nil
+}
+
+// saveOAuthCredentials stores creds unless they are already stored, then
+// prunes old versions so refreshes do not grow the history without bound.
+func saveOAuthCredentials(ctx context.Context, store TokenStore, server string, creds *oauthCredentials) error {
+	data, err := json.Marshal(creds)
+	if err != nil {
+		return fmt.Errorf("encode oauth credentials: %w", err)
+	}
+	name := OAuthSecretName(server)
+	if current, err := store.Get(ctx, name); err == nil && bytes.Equal(current, data) {
+		return nil
+	}
+	if err := store.Store(ctx, name, data); err != nil {
+		return fmt.Errorf("store oauth credentials: %w", err)
+	}
+	if p, ok := store.(versionPruner); ok {
+		if _, err := p.PruneVersions(ctx, name, oauthSecretVersions); err != nil {
+			logging.App().Warnw("prune MCP OAuth credential versions", "server", server, "error", err)
+		}
+	}
+	return nil
+}
+
+// authServerMetadata is the subset of RFC 8414 authorization server
+// metadata used by the client.
+type authServerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	RegistrationEndpoint  string `json:"registration_endpoint"`
+}
+
+// discoverAuthServer locates the authorization server for an MCP endpoint.
+// It follows the protected resource metadata (RFC 9728) when published,
+// then fetches the authorization server metadata (RFC 8414, falling back to
+// OpenID discovery). Servers publishing neither get the default
+// /authorize, /token and /register endpoints on their origin.
+func discoverAuthServer(ctx context.Context, client *http.Client, serverURL string) (*authServerMetadata, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid server url %q", serverURL)
+	}
+	origin := u.Scheme + "://" + u.Host
+	path := strings.TrimSuffix(u.Path, "/")
+
+	issuer := origin
+	var resource struct {
+		AuthorizationServers []string `json:"authorization_servers"`
+	}
+	for _, candidate := range wellKnownURLs(origin, path, "oauth-protected-resource") {
+		if getJSON(ctx, client, candidate, &resource) == nil && len(resource.AuthorizationServers) > 0 {
+			issuer = strings.TrimSuffix(resource.AuthorizationServers[0], "/")
+			break
+		}
+	}
+
+	iu, err := url.Parse(issuer)
+	if err != nil || iu.Host == "" {
+		return nil, fmt.Errorf("invalid authorization server %q", issuer)
+	}
+	issuerOrigin := iu.Scheme + "://" + iu.Host
+	issuerPath := strings.TrimSuffix(iu.Path, "/")
+	candidates := append(
+		wellKnownURLs(issuerOrigin, issuerPath, "oauth-authorization-server"),
+		wellKnownURLs(issuerOrigin, issuerPath, "openid-configuration")...,
+	)
+	for _, candidate := range candidates {
+		var meta authServerMetadata
+		if getJSON(ctx, client, candidate, &meta) == nil && meta.AuthorizationEndpoint != "" && meta.TokenEndpoint != "" {
+			return &meta, nil
+		}
+	}
+
+	return &authServerMetadata{
+		Issuer:                issuer,
+		AuthorizationEndpoint: issuer + "/authorize",
+		TokenEndpoint:         issuer + "/token",
+		RegistrationEndpoint:  issuer + "/register",
+	}, nil
+}
+
+// wellKnownURLs returns the path-inserted and root well-known locations.
+func wellKnownURLs(origin, path, suffix string) []string {
+	root := origin + "/.well-known/" + suffix
+	if path == "" {
+		return []string{root}
+	}
+	return []string{root + path, root}
+}
+
+func getJSON(ctx context.Context, client *http.Client, rawURL string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", rawURL, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+// registerClient performs OAuth dynamic client registration (RFC 7591) as a
+// public client using PKCE.
+func registerClient(ctx context.Context, client *http.Client, endpoint, redirectURL string) (clientID, clientSecret string, err error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"client_name":                "Lango",
+		"redirect_uris":              []string{redirectURL},
+		"grant_types":                []string{"authorization_code", "refresh_token"},
+		"response_types":             []string{"code"},
+		"token_endpoint_auth_method": "none",
+	})
+	if err != nil {
+		return "", "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("register client: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", "", fmt.Errorf("register client: status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	var reg struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&reg); err != nil {
+		return "", "", fmt.Errorf("decode client registration: %w", err)
+	}
+	if reg.ClientID == "" {
+		return "", "", errors.New("register client: response has no client_id")
+	}
+	return reg.ClientID, reg.ClientSecret, nil
+}
+
+// Authorize runs the interactive OAuth authorization code flow with PKCE
+// for a remote MCP server and stores the resulting credentials. openURL is
+// called with the authorization URL the user must visit; the redirect is
+// received on a local loopback listener.
+func Authorize(ctx context.Context, name string, cfg config.MCPServerConfig, store TokenStore, openURL func(string) error) error {
+	if cfg.OAuth == nil {
+		return fmt.Errorf("server %q has no oauth configuration", name)
+	}
+	if cfg.URL == "" {
+		return fmt.Errorf("server %q has no url", name)
+	}
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", cfg.OAuth.CallbackPort))
+	if err != nil {
+		return fmt.Errorf("listen for oauth callback: %w", err)
+	}
+	defer ln.Close()
+	redirectURL := fmt.Sprintf("http://%s/callback", ln.Addr().String())
+
+	meta, err := discoverAuthServer(ctx, httpClient, cfg.URL)
+	if err != nil {
+		return fmt.Errorf("discover authorization server: %w", err)
+	}
+
+	creds := &oauthCredentials{
+		ClientID:     cfg.OAuth.ClientID,
+		ClientSecret: cfg.OAuth.ClientSecret,
+		AuthURL:      meta.AuthorizationEndpoint,
+		TokenURL:     meta.TokenEndpoint,
+		Scopes:       cfg.OAuth.Scopes,
+	}
+	if creds.ClientID == "" {
+		if meta.RegistrationEndpoint == "" {
+			return errors.New("authorization server does not support dynamic client registration; set oauth.clientId")
+		}
+		creds.ClientID, creds.ClientSecret, err = registerClient(ctx, httpClient, meta.RegistrationEndpoint, redirectURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	state, err := randomState()
+	if err != nil {
+		return err
+	}
+	verifier := oauth2.GenerateVerifier()
+	conf := creds.config(redirectURL)
+	resource := oauth2.SetAuthURLParam("resource", cfg.URL)
+	authURL := conf.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier), resource)
+
+	code, err := awaitAuthorizationCode(ctx, ln, state, func() error { return openURL(authURL) })
+	if err != nil {
+		return err
+	}
+
+	exchangeCtx := context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	token, err := conf.Exchange(exchangeCtx, code, oauth2.VerifierOption(verifier), resource)
+	if err != nil {
+		return fmt.Errorf("exchange authorization code: %w", err)
+	}
+	creds.Token = token
+	return saveOAuthCredentials(ctx, store, name, creds)
+}
+
+// awaitAuthorizationCode serves the redirect endpoint on ln, calls start,
+// and waits for the authorization server to redirect back with a code.
+func awaitAuthorizationCode(ctx context.Context, ln net.Listener, state string, start func() error) (string, error) {
+	type result struct {
+		code string
+		err  error
+	}
+	done := make(chan result, 1)
+	srv := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/callback" {
+				http.NotFound(w, r)
+				return
+			}
+			q := r.URL.Query()
+			var res result
+			switch {
+			case q.Get("error") != "":
+				res.err = fmt.Errorf("authorization denied: %s %s", q.Get("error"), q.Get("error_description"))
+			case q.Get("state") != state:
+				res.err = errors.New("authorization callback state mismatch")
+			case q.Get("code") == "":
+				res.err = errors.New("authorization callback has no code")
+			default:
+				res.code = q.Get("code")
+			}
+			if res.err != nil {
+				http.Error(w, res.err.Error(), http.StatusBadRequest)
+			} else {
+				fmt.Fprintln(w, "Lango authorization complete. You can close this window.")
+			}
+			select {
+			case done <- res:
+			default:
+			}
+		}),
+	}
+	go func() { _ = srv.Serve(ln) }()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := start(); err != nil {
+		return "", err
+	}
+	select {
+	case res := <-done:
+		return res.code, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate oauth state: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// oauthTokenSource serves a server's stored access token, refreshing it
+// through the token endpoint when it expires and persisting the result.
+type oauthTokenSource struct {
+	server string
+	store  TokenStore
+
+	mu    sync.Mutex
+	creds *oauthCredentials
+}
+
+func newOAuthTokenSource(server string, store TokenStore) *oauthTokenSource {
+	return &oauthTokenSource{server: server, store: store}
+}
+
+// Token returns a valid access token. Implements oauth2.TokenSource.
+func (s *oauthTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+	if s.creds == nil {
+		creds, err := loadOAuthCredentials(ctx, s.store, s.server)
+		if err != nil {
+			return nil, err
+		}
+		s.creds = creds
+	}
+	if s.creds.Token.Valid() {
+		return s.creds.Token, nil
+	}
+	if s.creds.Token.RefreshToken == "" {
+		return nil, fmt.Errorf("%w: token expired, run `lango mcp auth %s`", ErrOAuthRequired, s.server)
+	}
+
+	tok, err := s.creds.config("").TokenSource(ctx, s.creds.Token).Token()
+	if err != nil {
+		return nil, fmt.Errorf("%w: refresh token: %v", ErrOAuthRequired, err)
+	}
+	if tok.RefreshToken == "" {
+		tok.RefreshToken = s.creds.Token.RefreshToken
+	}
+	s.creds.Token = tok
+	if err := saveOAuthCredentials(ctx, s.store, s.server, s.creds); err != nil {
+		return nil, err
+	}
+	return tok, nil
+}
+
+// invalidate marks the cached token expired so the next Token call refreshes it.
+func (s *oauthTokenSource) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.creds != nil && s.creds.Token != nil {
+		s.creds.Token.Expiry = time.Unix(1, 0)
+	}
+}
+
+// oauthRoundTripper adds the bearer token to every request. A 401 response
+// forces a refresh and a single retry of replayable requests.
+type oauthRoundTripper struct {
+	base   http.RoundTripper
+	source *oauthTokenSource
+}
+
+func (rt *oauthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.send(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	resp.Body.Close()
+	rt.source.invalidate()
+	return rt.send(retry)
+}
+
+func (rt *oauthRoundTripper) send(req *http.Request) (*http.Response, error) {
+	tok, err := rt.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	tok.SetAuthHeader(clone)
+	return rt.base.RoundTrip(clone)
+}
@@ -0,0 +1,263 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/security"
+)
+
+type memTokenStore struct {
+	mu     sync.Mutex
+	data   map[string][]byte
+	writes int
+	pruned []string
+}
+
+func newMemTokenStore() *memTokenStore { return &memTokenStore{data: make(map[string][]byte)} }
+
+func (s *memTokenStore) Store(_ context.Context, name string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[name] = value
+	s.writes++
+	return nil
+}
+
+func (s *memTokenStore) Get(_ context.Context, name string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.data[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", security.ErrSecretNotFound, name)
+	}
+	return v, nil
+}
+
+func (s *memTokenStore) PruneVersions(_ context.Context, name string, _ int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruned = append(s.pruned, name)
+	return 0, nil
+}
+
+func (s *memTokenStore) Delete(_ context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, name)
+	return nil
+}
+
+// fakeAuthServer is a minimal MCP resource + OAuth authorization server.
+type fakeAuthServer struct {
+	*httptest.Server
+	refreshes  atomic.Int32
+	registered atomic.Int32
+	accessTTL  time.Duration
+}
+
+func newFakeAuthServer(t *testing.T) *fakeAuthServer {
+	t.Helper()
+	f := &fakeAuthServer{accessTTL: time.Hour}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/oauth-protected-resource/mcp", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"resource":              f.URL + "/mcp",
+			"authorization_servers": []string{f.URL + "/issuer"},
+		})
+	})
+	mux.HandleFunc("/.well-known/oauth-authorization-server/issuer", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 f.URL + "/issuer",
+			"authorization_endpoint": f.URL + "/issuer/authorize",
+			"token_endpoint":         f.URL + "/issuer/token",
+			"registration_endpoint":  f.URL + "/issuer/register",
+		})
+	})
+	mux.HandleFunc("/issuer/register", func(w http.ResponseWriter, r *http.Request) {
+		f.registered.Add(1)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]string{"client_id": "dyn-client"})
+	})
+	mux.HandleFunc("/issuer/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("code_challenge_method") != "S256" || q.Get("client_id") != "dyn-client" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		redirect := q.Get("redirect_uri") + "?code=the-code&state=" + url.QueryEscape(q.Get("state"))
+		http.Redirect(w, r, redirect, http.StatusFound)
+	})
+	mux.HandleFunc("/issuer/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		access := "access-1"
+		switch r.Form.Get("grant_type") {
+		case "authorization_code":
+			if r.Form.Get("code") != "the-code" || r.Form.Get("code_verifier") == "" {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+		case "refresh_token":
+			access = fmt.Sprintf("access-%d", f.refreshes.Add(1)+1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  access,
+			"token_type":    "Bearer",
+			"refresh_token": "refresh",
+			"expires_in":    int(f.accessTTL.Seconds()),
+		})
+	})
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func TestDiscoverAuthServer(t *testing.T) {
+	f := newFakeAuthServer(t)
+
+	meta, err := discoverAuthServer(context.Background(), f.Client(), f.URL+"/mcp")
+	require.NoError(t, err)
+	assert.Equal(t, f.URL+"/issuer/token", meta.TokenEndpoint)
+	assert.Equal(t, f.URL+"/issuer/register", meta.RegistrationEndpoint)
+
+	// Without any metadata the origin defaults are used.
+	bare := httptest.NewServer(http.NotFoundHandler())
+	defer bare.Close()
+	meta, err = discoverAuthServer(context.Background(), bare.Client(), bare.URL+"/mcp")
+	require.NoError(t, err)
+	assert.Equal(t, bare.URL+"/authorize", meta.AuthorizationEndpoint)
+	assert.Equal(t, bare.URL+"/token", meta.TokenEndpoint)
+}
+
+func TestAuthorize_DynamicRegistrationAndPKCE(t *testing.T) {
+	f := newFakeAuthServer(t)
+	store := newMemTokenStore()
+	cfg := config.MCPServerConfig{Transport: "http", URL: f.URL + "/mcp", OAuth: &config.MCPOAuthConfig{}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The "browser" follows the authorization redirect back to the callback.
+	openURL := func(u string) error {
+		go func() {
+			resp, err := http.Get(u)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+	require.NoError(t, Authorize(ctx, "linear", cfg, store, openURL))
+	assert.Equal(t, int32(1), f.registered.Load())
+
+	creds, err := loadOAuthCredentials(ctx, store, "linear")
+	require.NoError(t, err)
+	assert.Equal(t, "dyn-client", creds.ClientID)
+	assert.Equal(t, "access-1", creds.Token.AccessToken)
+	assert.Equal(t, f.URL+"/issuer/token", creds.TokenURL)
+}
+
+func TestOAuthTokenSource_RefreshesAndPersists(t *testing.T) {
+	f := newFakeAuthServer(t)
+	store := newMemTokenStore()
+	ctx := context.Background()
+
+	_, err := newOAuthTokenSource("srv", store).Token()
+	assert.ErrorIs(t, err, ErrOAuthRequired)
+
+	require.NoError(t, saveOAuthCredentials(ctx, store, "srv", &oauthCredentials{
+		ClientID: "c",
+		AuthURL:  f.URL + "/issuer/authorize",
+		TokenURL: f.URL + "/issuer/token",
+		Token:    &oauth2.Token{AccessToken: "stale", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Minute)},
+	}))
+
+	src := newOAuthTokenSource("srv", store)
+	tok, err := src.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access-2", tok.AccessToken)
+
+	persisted, err := loadOAuthCredentials(ctx, store, "srv")
+	require.NoError(t, err)
+	assert.Equal(t, "access-2", persisted.Token.AccessToken)
+	assert.Equal(t, "refresh", persisted.Token.RefreshToken)
+
+	// A valid token is served without contacting the token endpoint.
+	_, err = src.Token()
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), f.refreshes.Load())
+}
+
+func TestSaveOAuthCredentials_SkipsUnchanged(t *testing.T) {
+	store := newMemTokenStore()
+	ctx := context.Background()
+	creds := &oauthCredentials{ClientID: "c", Token: &oauth2.Token{AccessToken: "a", RefreshToken: "r"}}
+
+	require.NoError(t, saveOAuthCredentials(ctx, store, "srv", creds))
+	require.NoError(t, saveOAuthCredentials(ctx, store, "srv", creds))
+	assert.Equal(t, 1, store.writes, "unchanged credentials are not written again")
+
+	creds.Token = &oauth2.Token{AccessToken: "b", RefreshToken: "r"}
+	require.NoError(t, saveOAuthCredentials(ctx, store, "srv", creds))
+	assert.Equal(t, 2, store.writes)
+	assert.Equal(t, []string{OAuthSecretName("srv"), OAuthSecretName("srv")}, store.pruned)
+}
+
+func TestOAuthRoundTripper_RetriesAfterUnauthorized(t *testing.T) {
+	f := newFakeAuthServer(t)
+	store := newMemTokenStore()
+	require.NoError(t, saveOAuthCredentials(context.Background(), store, "srv", &oauthCredentials{
+		ClientID: "c",
+		TokenURL: f.URL + "/issuer/token",
+		Token:    &oauth2.Token{AccessToken: "revoked", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour)},
+	}))
+
+	var seen []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		seen = append(seen, auth)
+		if auth != "Bearer access-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	client := &http.Client{Transport: &oauthRoundTripper{base: http.DefaultTransport, source: newOAuthTokenSource("srv", store)}}
+	resp, err := client.Get(api.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"Bearer revoked", "Bearer access-2"}, seen)
+}
+
+func TestConnection_OAuthRequiresToken(t *testing.T) {
+	conn := NewServerConnection("linear", config.MCPServerConfig{
+		Transport: "http",
+		URL:       "http://127.0.0.1:1/mcp",
+		OAuth:     &config.MCPOAuthConfig{},
+	}, config.MCPConfig{})
+
+	_, err := conn.createTransport()
+	assert.ErrorIs(t, err, ErrOAuthRequired)
+
+	conn.SetTokenStore(newMemTokenStore())
+	_, err = conn.createTransport()
+	assert.ErrorIs(t, err, ErrOAuthRequired)
+	assert.Contains(t, err.Error(), "lango mcp auth linear")
+}
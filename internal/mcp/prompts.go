@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FindPrompt returns the named prompt discovered on the given server.
+func (m *ServerManager) FindPrompt(server, name string) (DiscoveredPrompt, bool) {
+	conn, ok := m.GetConnection(server)
+	if !ok {
+		return DiscoveredPrompt{}, false
+	}
+	for _, p := range conn.Prompts() {
+		if p.Prompt != nil && p.Prompt.Name == name {
+			return p, true
+		}
+	}
+	return DiscoveredPrompt{}, false
+}
+
+// GetPrompt renders a server prompt with the given arguments and returns its
+// messages flattened to text. Missing required arguments are rejected
+// before contacting the server.
+func (m *ServerManager) GetPrompt(ctx context.Context, server, name string, args map[string]string) (string, error) {
+	dp, ok := m.FindPrompt(server, name)
+	if !ok {
+		return "", fmt.Errorf("prompt %q not found on server %q", name, server)
+	}
+	for _, a := range dp.Prompt.Arguments {
+		if a.Required && args[a.Name] == "" {
+			return "", fmt.Errorf("prompt %s/%s: missing required argument %q", server, name, a.Name)
+		}
+	}
+
+	conn, _ := m.GetConnection(server)
+	session := conn.Session()
+	if session == nil {
+		return "", fmt.Errorf("%w: server %q", ErrNotConnected, server)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, conn.timeout())
+	defer cancel()
+	res, err := session.GetPrompt(callCtx, &sdkmcp.GetPromptParams{Name: name, Arguments: args})
+	if err != nil {
+		return "", fmt.Errorf("get prompt %s/%s: %w", server, name, err)
+	}
+	return FormatPromptMessages(res.Messages), nil
+}
+
+// FormatPromptMessages flattens prompt messages to text. A single user
+// message is returned verbatim so it can be submitted as the user's turn;
+// multi-message prompts are labelled by role.
+func FormatPromptMessages(msgs []*sdkmcp.PromptMessage) string {
+	if len(msgs) == 1 && msgs[0] != nil && msgs[0].Role == "user" {
+		return promptContentText(msgs[0].Content)
+	}
+	parts := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("[%s]\n%s", msg.Role, promptContentText(msg.Content)))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func promptContentText(c sdkmcp.Content) string {
+	switch v := c.(type) {
+	case *sdkmcp.TextContent:
+		return v.Text
+	case *sdkmcp.EmbeddedResource:
+		if v.Resource != nil {
+			return formatResourceContents([]*sdkmcp.ResourceContents{v.Resource})
+		}
+		return ""
+	default:
+		return formatContent([]sdkmcp.Content{c}, 0)
+	}
+}
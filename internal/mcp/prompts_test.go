@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/config"
+)
+
+func TestServerManager_GetPrompt(t *testing.T) {
+	srv := sdkmcp.NewServer(&sdkmcp.Implementation{Name: "review", Version: "1"}, nil)
+	srv.AddPrompt(&sdkmcp.Prompt{
+		Name:      "review",
+		Arguments: []*sdkmcp.PromptArgument{{Name: "file", Required: true}, {Name: "focus"}},
+	}, func(_ context.Context, req *sdkmcp.GetPromptRequest) (*sdkmcp.GetPromptResult, error) {
+		return &sdkmcp.GetPromptResult{Messages: []*sdkmcp.PromptMessage{{
+			Role:    "user",
+			Content: &sdkmcp.TextContent{Text: fmt.Sprintf("Review %s for %s", req.Params.Arguments["file"], req.Params.Arguments["focus"])},
+		}}}, nil
+	})
+
+	mgr := NewServerManager(config.MCPConfig{})
+	attachTestServer(t, mgr, "review", config.MCPServerConfig{}, srv)
+	ctx := context.Background()
+
+	_, ok := mgr.FindPrompt("review", "review")
+	assert.True(t, ok)
+	_, ok = mgr.FindPrompt("review", "missing")
+	assert.False(t, ok)
+
+	got, err := mgr.GetPrompt(ctx, "review", "review", map[string]string{"file": "main.go", "focus": "errors"})
+	require.NoError(t, err)
+	assert.Equal(t, "Review main.go for errors", got)
+
+	_, err = mgr.GetPrompt(ctx, "review", "review", map[string]string{"focus": "errors"})
+	assert.ErrorContains(t, err, `missing required argument "file"`)
+
+	_, err = mgr.GetPrompt(ctx, "other", "review", nil)
+	assert.Error(t, err)
+}
+
+func TestFormatPromptMessages(t *testing.T) {
+	tests := []struct {
+		give []*sdkmcp.PromptMessage
+		want string
+	}{
+		{
+			give: []*sdkmcp.PromptMessage{{Role: "user", Content: &sdkmcp.TextContent{Text: "hello"}}},
+			want: "hello",
+		},
+		{
+			give: []*sdkmcp.PromptMessage{
+				{Role: "assistant", Content: &sdkmcp.TextContent{Text: "context"}},
+				{Role: "user", Content: &sdkmcp.EmbeddedResource{Resource: &sdkmcp.ResourceContents{URI: "x://y", Text: "doc"}}},
+			},
+			want: "[assistant]\ncontext\n\n[user]\ndoc",
+		},
+		{give: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatPromptMessages(tt.give))
+	}
+}
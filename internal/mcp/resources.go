@@ -0,0 +1,213 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/langoai/lango/internal/logging"
+	"github.com/langoai/lango/internal/types"
+)
+
+// PinnedResource is a resource configured for automatic context injection,
+// with its content trimmed to the pinned token budget.
+type PinnedResource struct {
+	ServerName string
+	URI        string
+	Name       string
+	Content    string
+	Tokens     int
+	Truncated  bool
+}
+
+type cacheKey struct {
+	server string
+	uri    string
+}
+
+type cachedResource struct {
+	content string
+	session *sdkmcp.ClientSession // session the content (and subscription) belongs to
+}
+
+// ResourceCache caches MCP resource contents. Resources read with a
+// subscription stay cached until the server reports an update; others are
+// re-read each time. A reconnect (new session) invalidates cached entries
+// and re-establishes subscriptions on next access.
+type ResourceCache struct {
+	mgr *ServerManager
+
+	mu         sync.Mutex
+	entries    map[cacheKey]cachedResource
+	subscribed map[cacheKey]*sdkmcp.ClientSession
+	gens       map[cacheKey]uint64 // bumped by Invalidate; a read stores only if unchanged
+}
+
+// NewResourceCache creates a cache over the manager's connections and
+// registers itself for resource update notifications.
+func NewResourceCache(mgr *ServerManager) *ResourceCache {
+	c := &ResourceCache{
+		mgr:        mgr,
+		entries:    make(map[cacheKey]cachedResource),
+		subscribed: make(map[cacheKey]*sdkmcp.ClientSession),
+		gens:       make(map[cacheKey]uint64),
+	}
+	mgr.SetResourceUpdateHandler(c.Invalidate)
+	return c
+}
+
+// Read returns the text content of a resource. When subscribe is true and
+// the server supports subscriptions, the content is cached and refreshed
+// only after an update notification.
+func (c *ResourceCache) Read(ctx context.Context, server, uri string, subscribe bool) (string, error) {
+	conn, ok := c.mgr.GetConnection(server)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrServerNotFound, server)
+	}
+	session := conn.Session()
+	if session == nil {
+		return "", fmt.Errorf("%w: server %q", ErrNotConnected, server)
+	}
+
+	key := cacheKey{server: server, uri: uri}
+	c.mu.Lock()
+	entry, cached := c.entries[key]
+	gen := c.gens[key]
+	c.mu.Unlock()
+	if cached && entry.session == session {
+		return entry.content, nil
+	}
+
+	if subscribe {
+		c.subscribe(ctx, conn, session, key)
+	}
+
+	readCtx, cancel := context.WithTimeout(ctx, conn.timeout())
+	defer cancel()
+	res, err := session.ReadResource(readCtx, &sdkmcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		return "", fmt.Errorf("read resource %s/%s: %w", server, uri, err)
+	}
+	content := formatResourceContents(res.Contents)
+
+	// An update that arrived during the read may describe newer content than
+	// what was returned, so only cache it if none did.
+	c.mu.Lock()
+	if c.subscribed[key] == session && c.gens[key] == gen {
+		c.entries[key] = cachedResource{content: content, session: session}
+	}
+	c.mu.Unlock()
+	return content, nil
+}
+
+// subscribe registers for update notifications once per session.
+func (c *ResourceCache) subscribe(ctx context.Context, conn *ServerConnection, session *sdkmcp.ClientSession, key cacheKey) {
+	c.mu.Lock()
+	done := c.subscribed[key] == session
+	c.mu.Unlock()
+	if done || !conn.SupportsResourceSubscribe() {
+		return
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, conn.timeout())
+	defer cancel()
+	if err := session.Subscribe(subCtx, &sdkmcp.SubscribeParams{URI: key.uri}); err != nil {
+		logging.App().Debugw("MCP resource subscribe failed", "server", key.server, "uri", key.uri, "error", err)
+		return
+	}
+	c.mu.Lock()
+	c.subscribed[key] = session
+	c.mu.Unlock()
+}
+
+// Invalidate drops the cached content of a resource so the next read
+// fetches it again. Used as the resource update notification handler.
+func (c *ResourceCache) Invalidate(server, uri string) {
+	key := cacheKey{server: server, uri: uri}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+	c.gens[key]++
+}
+
+// Pinned reads every resource listed in the servers' pinnedResources config,
+// trimming contents so their total stays within budgetTokens (0 = unlimited).
+// Unavailable resources are skipped.
+func (c *ResourceCache) Pinned(ctx context.Context, budgetTokens int) []PinnedResource {
+	var out []PinnedResource
+	remaining := budgetTokens
+	for _, server := range c.mgr.serverNames() {
+		conn, ok := c.mgr.GetConnection(server)
+		if !ok {
+			continue
+		}
+		for _, uri := range conn.cfg.PinnedResources {
+			if budgetTokens > 0 && remaining <= 0 {
+				return out
+			}
+			content, err := c.Read(ctx, server, uri, true)
+			if err != nil {
+				logging.App().Debugw("MCP pinned resource unavailable", "server", server, "uri", uri, "error", err)
+				continue
+			}
+
+			pr := PinnedResource{ServerName: server, URI: uri, Name: resourceName(conn, uri)}
+			pr.Content, pr.Truncated = trimToTokens(content, remaining, budgetTokens > 0)
+			pr.Tokens = types.EstimateTokens(pr.Content)
+			remaining -= pr.Tokens
+			out = append(out, pr)
+		}
+	}
+	return out
+}
+
+// resourceName returns the discovered display name for uri, or uri itself.
+func resourceName(conn *ServerConnection, uri string) string {
+	for _, r := range conn.Resources() {
+		if r.Resource != nil && r.Resource.URI == uri && r.Resource.Name != "" {
+			return r.Resource.Name
+		}
+	}
+	return uri
+}
+
+// trimToTokens cuts text so its estimated token count fits budget,
+// keeping whole runes.
+func trimToTokens(text string, budget int, limited bool) (string, bool) {
+	if !limited || types.EstimateTokens(text) <= budget {
+		return text, false
+	}
+	runes := []rune(text)
+	lo, hi := 0, len(runes)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if types.EstimateTokens(string(runes[:mid])) <= budget {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return string(runes[:lo]) + "\n... [truncated]", true
+}
+
+// formatResourceContents flattens resource contents to text. Binary blobs
+// are summarized rather than inlined.
+func formatResourceContents(contents []*sdkmcp.ResourceContents) string {
+	parts := make([]string, 0, len(contents))
+	for _, rc := range contents {
+		if rc == nil {
+			continue
+		}
+		if rc.Text != "" {
+			parts = append(parts, rc.Text)
+			continue
+		}
+		if len(rc.Blob) > 0 {
+			parts = append(parts, fmt.Sprintf("[Binary: %s, %d bytes]", rc.MIMEType, len(rc.Blob)))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
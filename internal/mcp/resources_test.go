@@ -0,0 +1,203 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/types"
+)
+
+// attachTestServer connects a ServerConnection named name to srv over an
+// in-memory transport and registers it with mgr.
+func attachTestServer(t *testing.T, mgr *ServerManager, name string, cfg config.MCPServerConfig, srv *sdkmcp.Server) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	serverT, clientT := sdkmcp.NewInMemoryTransports()
+	ss, err := srv.Connect(ctx, serverT, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ss.Close() })
+
+	conn := NewServerConnection(name, cfg, mgr.cfg)
+	if mgr.onUpdate != nil {
+		conn.SetResourceUpdateHandler(mgr.onUpdate)
+	}
+	client := sdkmcp.NewClient(&sdkmcp.Implementation{Name: "lango", Version: "test"}, conn.clientOptions())
+	cs, err := client.Connect(ctx, clientT, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cs.Close() })
+
+	conn.mu.Lock()
+	conn.client = client
+	conn.session = cs
+	conn.state = StateConnected
+	conn.mu.Unlock()
+	conn.discoverCapabilities(ctx)
+
+	mgr.mu.Lock()
+	mgr.servers[name] = conn
+	mgr.mu.Unlock()
+}
+
+func textResourceHandler(text *atomic.Value, reads *atomic.Int32) sdkmcp.ResourceHandler {
+	return func(_ context.Context, req *sdkmcp.ReadResourceRequest) (*sdkmcp.ReadResourceResult, error) {
+		reads.Add(1)
+		return &sdkmcp.ReadResourceResult{
+			Contents: []*sdkmcp.ResourceContents{{URI: req.Params.URI, Text: text.Load().(string)}},
+		}, nil
+	}
+}
+
+func TestResourceCache_SubscribedReadIsCachedUntilUpdate(t *testing.T) {
+	var text atomic.Value
+	text.Store("v1")
+	var reads atomic.Int32
+	subscribed := make(chan string, 1)
+
+	srv := sdkmcp.NewServer(&sdkmcp.Implementation{Name: "docs", Version: "1"}, &sdkmcp.ServerOptions{
+		SubscribeHandler: func(_ context.Context, req *sdkmcp.SubscribeRequest) error {
+			subscribed <- req.Params.URI
+			return nil
+		},
+		UnsubscribeHandler: func(context.Context, *sdkmcp.UnsubscribeRequest) error { return nil },
+	})
+	srv.AddResource(&sdkmcp.Resource{URI: "docs://readme", Name: "README"}, textResourceHandler(&text, &reads))
+
+	mgr := NewServerManager(config.MCPConfig{})
+	cache := NewResourceCache(mgr)
+	attachTestServer(t, mgr, "docs", config.MCPServerConfig{}, srv)
+	ctx := context.Background()
+
+	got, err := cache.Read(ctx, "docs", "docs://readme", true)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", got)
+	assert.Equal(t, "docs://readme", <-subscribed)
+
+	got, err = cache.Read(ctx, "docs", "docs://readme", true)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", got)
+	assert.Equal(t, int32(1), reads.Load(), "second read should be served from cache")
+
+	text.Store("v2")
+	require.NoError(t, srv.ResourceUpdated(ctx, &sdkmcp.ResourceUpdatedNotificationParams{URI: "docs://readme"}))
+	require.Eventually(t, func() bool {
+		got, err := cache.Read(ctx, "docs", "docs://readme", true)
+		return err == nil && got == "v2"
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestResourceCache_UpdateDuringReadIsNotLost(t *testing.T) {
+	var (
+		cache *ResourceCache
+		reads atomic.Int32
+	)
+	srv := sdkmcp.NewServer(&sdkmcp.Implementation{Name: "docs", Version: "1"}, &sdkmcp.ServerOptions{
+		SubscribeHandler:   func(context.Context, *sdkmcp.SubscribeRequest) error { return nil },
+		UnsubscribeHandler: func(context.Context, *sdkmcp.UnsubscribeRequest) error { return nil },
+	})
+	srv.AddResource(&sdkmcp.Resource{URI: "docs://readme", Name: "README"},
+		func(_ context.Context, req *sdkmcp.ReadResourceRequest) (*sdkmcp.ReadResourceResult, error) {
+			text := "v2"
+			if reads.Add(1) == 1 {
+				// The resource changes after this read produced its content.
+				text = "v1"
+				cache.Invalidate("docs", req.Params.URI)
+			}
+			return &sdkmcp.ReadResourceResult{
+				Contents: []*sdkmcp.ResourceContents{{URI: req.Params.URI, Text: text}},
+			}, nil
+		})
+
+	mgr := NewServerManager(config.MCPConfig{})
+	cache = NewResourceCache(mgr)
+	attachTestServer(t, mgr, "docs", config.MCPServerConfig{}, srv)
+	ctx := context.Background()
+
+	got, err := cache.Read(ctx, "docs", "docs://readme", true)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", got)
+
+	got, err = cache.Read(ctx, "docs", "docs://readme", true)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", got, "content read before the update must not be cached")
+	assert.Equal(t, int32(2), reads.Load())
+}
+
+func TestResourceCache_UnsubscribedReadIsNotCached(t *testing.T) {
+	var text atomic.Value
+	text.Store("v1")
+	var reads atomic.Int32
+
+	srv := sdkmcp.NewServer(&sdkmcp.Implementation{Name: "docs", Version: "1"}, nil)
+	srv.AddResource(&sdkmcp.Resource{URI: "docs://readme", Name: "README"}, textResourceHandler(&text, &reads))
+
+	mgr := NewServerManager(config.MCPConfig{})
+	cache := NewResourceCache(mgr)
+	attachTestServer(t, mgr, "docs", config.MCPServerConfig{}, srv)
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := cache.Read(ctx, "docs", "docs://readme", true)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), reads.Load(), "server without subscribe support must be re-read")
+
+	_, err := cache.Read(ctx, "missing", "docs://readme", false)
+	assert.ErrorIs(t, err, ErrServerNotFound)
+}
+
+func TestResourceCache_Pinned(t *testing.T) {
+	var short, long atomic.Value
+	short.Store("short note")
+	long.Store(strings.Repeat("lorem ipsum ", 500))
+	var reads atomic.Int32
+
+	srv := sdkmcp.NewServer(&sdkmcp.Implementation{Name: "docs", Version: "1"}, nil)
+	srv.AddResource(&sdkmcp.Resource{URI: "docs://short", Name: "Short"}, textResourceHandler(&short, &reads))
+	srv.AddResource(&sdkmcp.Resource{URI: "docs://long"}, textResourceHandler(&long, &reads))
+
+	mgr := NewServerManager(config.MCPConfig{})
+	cache := NewResourceCache(mgr)
+	attachTestServer(t, mgr, "docs", config.MCPServerConfig{
+		PinnedResources: []string{"docs://short", "docs://missing", "docs://long"},
+	}, srv)
+
+	pinned := cache.Pinned(context.Background(), 100)
+	require.Len(t, pinned, 2, "missing resource is skipped")
+
+	assert.Equal(t, "Short", pinned[0].Name)
+	assert.Equal(t, "short note", pinned[0].Content)
+	assert.False(t, pinned[0].Truncated)
+
+	assert.Equal(t, "docs://long", pinned[1].Name)
+	assert.True(t, pinned[1].Truncated)
+	assert.LessOrEqual(t, pinned[0].Tokens+pinned[1].Tokens, 100+types.EstimateTokens("\n... [truncated]"))
+
+	all := cache.Pinned(context.Background(), 0)
+	require.Len(t, all, 2)
+	assert.False(t, all[1].Truncated)
+}
+
+func TestTrimToTokens(t *testing.T) {
+	text := strings.Repeat("한글 텍스트 ", 200)
+
+	got, truncated := trimToTokens(text, 10, false)
+	assert.Equal(t, text, got)
+	assert.False(t, truncated)
+
+	got, truncated = trimToTokens(text, 10, true)
+	assert.True(t, truncated)
+	assert.True(t, strings.HasSuffix(got, "[truncated]"))
+	body := strings.TrimSuffix(got, "\n... [truncated]")
+	assert.LessOrEqual(t, types.EstimateTokens(body), 10)
+	assert.True(t, strings.HasPrefix(text, body))
+}
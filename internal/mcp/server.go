@@ -0,0 +1,241 @@
+package mcp
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.uber.org/zap"
+
+	"github.com/langoai/lango/internal/adk"
+	"github.com/langoai/lango/internal/agent"
+	"github.com/langoai/lango/internal/logging"
+	"github.com/langoai/lango/internal/session"
+)
+
+// ServerSessionPrefix prefixes the Lango session key of every tool call
+// received from an MCP client, so approval providers can route them.
+const ServerSessionPrefix = "mcp:"
+
+// ServerConfig configures the MCP server that exposes Lango to MCP clients.
+type ServerConfig struct {
+	Name    string
+	Version string
+
+	// Tools are agent tools already wrapped with the toolchain middleware
+	// (approval, hooks, output management). Only tools matched by Allow are published.
+	Tools []*agent.Tool
+	// Allow lists tool names or path.Match glob patterns (e.g. "skill_*").
+	Allow []string
+
+	// Sessions and Knowledge back the MCP resources. Nil disables them.
+	Sessions  SessionSource
+	Knowledge KnowledgeSource
+
+	Logger *zap.SugaredLogger
+}
+
+// NewServer creates an MCP server publishing the allowlisted tools and,
+// when sources are configured, sessions and knowledge entries as resources.
+func NewServer(cfg ServerConfig) *sdkmcp.Server {
+	if cfg.Logger == nil {
+		cfg.Logger = logging.SubsystemSugar("mcp-server")
+	}
+	name := cfg.Name
+	if name == "" {
+		name = "lango"
+	}
+
+	srv := sdkmcp.NewServer(&sdkmcp.Implementation{Name: name, Version: cfg.Version}, nil)
+
+	published := AllowedTools(cfg.Tools, cfg.Allow)
+	for _, t := range published {
+		srv.AddTool(serverTool(t), toolHandler(t))
+	}
+	registerResources(srv, cfg.Sessions, cfg.Knowledge)
+
+	cfg.Logger.Infow("MCP server configured",
+		"tools", len(published),
+		"sessions", cfg.Sessions != nil,
+		"knowledge", cfg.Knowledge != nil)
+	return srv
+}
+
+// AllowedTools returns the tools whose names match at least one allow
+// pattern, sorted by name. An empty allowlist publishes nothing.
+func AllowedTools(tools []*agent.Tool, allow []string) []*agent.Tool {
+	var out []*agent.Tool
+	for _, t := range tools {
+		if t == nil || t.Handler == nil {
+			continue
+		}
+		if matchesAny(t.Name, allow) {
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func matchesAny(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if p == name {
+			return true
+		}
+		if ok, err := path.Match(p, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// serverTool describes an agent tool in MCP terms.
+func serverTool(t *agent.Tool) *sdkmcp.Tool {
+	readOnly := t.Capability.ReadOnly || t.SafetyLevel == agent.SafetyLevelSafe
+	destructive := t.SafetyLevel.IsDangerous()
+	return &sdkmcp.Tool{
+		Name:        t.Name,
+		Description: t.Description,
+		InputSchema: adk.InputSchema(t),
+		Annotations: &sdkmcp.ToolAnnotations{
+			ReadOnlyHint:    readOnly,
+			DestructiveHint: &destructive,
+		},
+	}
+}
+
+// toolHandler invokes a wrapped agent tool on behalf of an MCP client.
+// Handler errors are reported as tool errors rather than protocol errors so
+// that clients can show them to the model.
+func toolHandler(t *agent.Tool) sdkmcp.ToolHandler {
+	return func(ctx context.Context, req *sdkmcp.CallToolRequest) (*sdkmcp.CallToolResult, error) {
+		params := make(map[string]interface{})
+		if len(req.Params.Arguments) > 0 {
+			if err := json.Unmarshal(req.Params.Arguments, &params); err != nil {
+				return toolError(fmt.Errorf("invalid arguments: %w", err)), nil
+			}
+		}
+
+		ctx = session.WithSessionKey(ctx, serverSessionKey(req.Session))
+		ctx = withServerSession(ctx, req.Session)
+
+		result, err := t.Handler(ctx, params)
+		if err != nil {
+			return toolError(err), nil
+		}
+
+		text, ok := result.(string)
+		if !ok {
+			data, err := json.Marshal(result)
+			if err != nil {
+				return toolError(fmt.Errorf("marshal result: %w", err)), nil
+			}
+			text = string(data)
+		}
+		return &sdkmcp.CallToolResult{
+			Content: []sdkmcp.Content{&sdkmcp.TextContent{Text: text}},
+		}, nil
+	}
+}
+
+func toolError(err error) *sdkmcp.CallToolResult {
+	return &sdkmcp.CallToolResult{
+		Content: []sdkmcp.Content{&sdkmcp.TextContent{Text: err.Error()}},
+		IsError: true,
+	}
+}
+
+// serverSessionKey derives the Lango session key for an MCP client session.
+func serverSessionKey(ss *sdkmcp.ServerSession) string {
+	if ss == nil {
+		return ServerSessionPrefix + "anonymous"
+	}
+	client := "client"
+	if p := ss.InitializeParams(); p != nil && p.ClientInfo != nil && p.ClientInfo.Name != "" {
+		client = p.ClientInfo.Name
+	}
+	if id := ss.ID(); id != "" {
+		return ServerSessionPrefix + client + ":" + id
+	}
+	return ServerSessionPrefix + client
+}
+
+// ServeStdio runs the server over stdin/stdout until the client disconnects
+// or ctx is cancelled.
+func ServeStdio(ctx context.Context, srv *sdkmcp.Server) error {
+	return srv.Run(ctx, &sdkmcp.StdioTransport{})
+}
+
+// CheckHTTPAddr refuses to serve addr without a token unless it only listens
+// on loopback. An empty host (":9000") listens on every interface.
+func CheckHTTPAddr(addr, token string) error {
+	if token != "" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid listen address %q: %w", addr, err)
+	}
+	if !isLoopbackHost(host) {
+		return fmt.Errorf("%w: %s", ErrUnauthenticatedListen, addr)
+	}
+	return nil
+}
+
+// HTTPHandler serves the server over the streamable HTTP transport. When
+// token is non-empty, requests must carry it as a bearer token. Without a
+// token, which CheckHTTPAddr only allows on loopback, requests must name a
+// loopback Host and Origin so a web page cannot reach the server through
+// DNS rebinding.
+func HTTPHandler(srv *sdkmcp.Server, token string) http.Handler {
+	h := sdkmcp.NewStreamableHTTPHandler(func(*http.Request) *sdkmcp.Server { return srv }, nil)
+	if token == "" {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isLoopbackHost(hostname(r.Host)) {
+				http.Error(w, "forbidden host", http.StatusForbidden)
+				return
+			}
+			if origin := r.Header.Get("Origin"); origin != "" {
+				u, err := url.Parse(origin)
+				if err != nil || !isLoopbackHost(u.Hostname()) {
+					http.Error(w, "forbidden origin", http.StatusForbidden)
+					return
+				}
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, []byte("Bearer "+token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// hostname strips the port from a Host header value.
+func hostname(hostport string) string {
+	if host, _, err := net.SplitHostPort(hostport); err == nil {
+		return host
+	}
+	return strings.Trim(hostport, "[]")
+}
+
+// isLoopbackHost reports whether host is "localhost" or a loopback IP.
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/langoai/lango/internal/approval"
+)
+
+type serverSessionCtxKey struct{}
+
+func withServerSession(ctx context.Context, ss *sdkmcp.ServerSession) context.Context {
+	if ss == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, serverSessionCtxKey{}, ss)
+}
+
+func serverSessionFromContext(ctx context.Context) *sdkmcp.ServerSession {
+	ss, _ := ctx.Value(serverSessionCtxKey{}).(*sdkmcp.ServerSession)
+	return ss
+}
+
+// ElicitationApprovalProvider asks the MCP client's user to approve tool
+// calls via MCP elicitation. It handles "mcp:" sessions only and denies
+// when the client does not support elicitation (fail-closed), since a
+// stdio server has no terminal of its own to prompt on.
+type ElicitationApprovalProvider struct{}
+
+var _ approval.Provider = (*ElicitationApprovalProvider)(nil)
+
+// RequestApproval elicits an approve/deny decision from the client user.
+func (p *ElicitationApprovalProvider) RequestApproval(ctx context.Context, req approval.ApprovalRequest) (approval.ApprovalResponse, error) {
+	ss := serverSessionFromContext(ctx)
+	if ss == nil || !supportsElicitation(ss) {
+		return approval.ApprovalResponse{}, approval.WrapError(
+			approval.ErrUnavailable, p.Name(), req.ID,
+			fmt.Sprintf("MCP client cannot confirm tool %q (elicitation not supported)", req.ToolName),
+		)
+	}
+
+	msg := fmt.Sprintf("Lango wants to run tool %q", req.ToolName)
+	if req.Summary != "" {
+		msg += ": " + req.Summary
+	}
+	res, err := ss.Elicit(ctx, &sdkmcp.ElicitParams{
+		Message: msg,
+		RequestedSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"approve": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Allow this tool call",
+				},
+			},
+			"required": []string{"approve"},
+		},
+	})
+	if err != nil {
+		return approval.ApprovalResponse{}, fmt.Errorf("elicit approval for %q: %w", req.ToolName, err)
+	}
+
+	approved := res.Action == "accept"
+	if v, ok := res.Content["approve"].(bool); ok {
+		approved = approved && v
+	}
+	return approval.ApprovalResponse{Approved: approved, Provider: p.Name()}, nil
+}
+
+// CanHandle reports whether the session originates from an MCP client.
+func (p *ElicitationApprovalProvider) CanHandle(sessionKey string) bool {
+	return strings.HasPrefix(sessionKey, ServerSessionPrefix)
+}
+
+// Name returns the provider name.
+func (p *ElicitationApprovalProvider) Name() string {
+	return "mcp-elicitation"
+}
+
+func supportsElicitation(ss *sdkmcp.ServerSession) bool {
+	params := ss.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/langoai/lango/internal/knowledge"
+	"github.com/langoai/lango/internal/session"
+)
+
+const (
+	sessionsURI  = "lango://sessions"
+	knowledgeURI = "lango://knowledge"
+
+	// resourceListLimit caps the number of entries in an index resource.
+	resourceListLimit = 100
+)
+
+// SessionSource provides conversation sessions for MCP resources.
+// Satisfied by session.Store.
+type SessionSource interface {
+	ListSessions(ctx context.Context) ([]session.SessionSummary, error)
+	Get(key string) (*session.Session, error)
+}
+
+// KnowledgeSource provides knowledge entries for MCP resources.
+// Satisfied by *knowledge.Store.
+type KnowledgeSource interface {
+	SearchRecentKnowledge(ctx context.Context, query string, limit int) ([]knowledge.KnowledgeEntry, error)
+	GetKnowledge(ctx context.Context, key string) (*knowledge.KnowledgeEntry, error)
+}
+
+// registerResources publishes an index resource and a per-item template for
+// each configured source.
+func registerResources(srv *sdkmcp.Server, sessions SessionSource, know KnowledgeSource) {
+	if sessions != nil {
+		srv.AddResource(&sdkmcp.Resource{
+			URI:         sessionsURI,
+			Name:        "sessions",
+			Description: "Recent Lango conversation sessions",
+			MIMEType:    "application/json",
+		}, sessionIndexHandler(sessions))
+		srv.AddResourceTemplate(&sdkmcp.ResourceTemplate{
+			URITemplate: sessionsURI + "/{+key}",
+			Name:        "session",
+			Description: "A Lango conversation session with its message history",
+			MIMEType:    "application/json",
+		}, sessionHandler(sessions))
+	}
+	if know != nil {
+		srv.AddResource(&sdkmcp.Resource{
+			URI:         knowledgeURI,
+			Name:        "knowledge",
+			Description: "Recently updated Lango knowledge entries",
+			MIMEType:    "application/json",
+		}, knowledgeIndexHandler(know))
+		srv.AddResourceTemplate(&sdkmcp.ResourceTemplate{
+			URITemplate: knowledgeURI + "/{+key}",
+			Name:        "knowledge-entry",
+			Description: "A Lango knowledge entry",
+			MIMEType:    "application/json",
+		}, knowledgeHandler(know))
+	}
+}
+
+func sessionIndexHandler(src SessionSource) sdkmcp.ResourceHandler {
+	return func(ctx context.Context, req *sdkmcp.ReadResourceRequest) (*sdkmcp.ReadResourceResult, error) {
+		summaries, err := src.ListSessions(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("list sessions: %w", err)
+		}
+		if len(summaries) > resourceListLimit {
+			summaries = summaries[:resourceListLimit]
+		}
+		items := make([]map[string]interface{}, len(summaries))
+		for i, s := range summaries {
+			items[i] = map[string]interface{}{
+				"key":       s.Key,
+				"uri":       sessionsURI + "/" + s.Key,
+				"createdAt": s.CreatedAt,
+				"updatedAt": s.UpdatedAt,
+			}
+		}
+		return jsonResource(req.Params.URI, items)
+	}
+}
+
+func sessionHandler(src SessionSource) sdkmcp.ResourceHandler {
+	return func(_ context.Context, req *sdkmcp.ReadResourceRequest) (*sdkmcp.ReadResourceResult, error) {
+		key, ok := resourceKey(req.Params.URI, sessionsURI)
+		if !ok {
+			return nil, sdkmcp.ResourceNotFoundError(req.Params.URI)
+		}
+		s, err := src.Get(key)
+		if err != nil || s == nil {
+			return nil, sdkmcp.ResourceNotFoundError(req.Params.URI)
+		}
+		return jsonResource(req.Params.URI, s)
+	}
+}
+
+func knowledgeIndexHandler(src KnowledgeSource) sdkmcp.ResourceHandler {
+	return func(ctx context.Context, req *sdkmcp.ReadResourceRequest) (*sdkmcp.ReadResourceResult, error) {
+		entries, err := src.SearchRecentKnowledge(ctx, "", resourceListLimit)
+		if err != nil {
+			return nil, fmt.Errorf("list knowledge: %w", err)
+		}
+		items := make([]map[string]interface{}, len(entries))
+		for i, e := range entries {
+			items[i] = map[string]interface{}{
+				"key":       e.Key,
+				"uri":       knowledgeURI + "/" + e.Key,
+				"category":  string(e.Category),
+				"updatedAt": e.UpdatedAt,
+			}
+		}
+		return jsonResource(req.Params.URI, items)
+	}
+}
+
+func knowledgeHandler(src KnowledgeSource) sdkmcp.ResourceHandler {
+	return func(ctx context.Context, req *sdkmcp.ReadResourceRequest) (*sdkmcp.ReadResourceResult, error) {
+		key, ok := resourceKey(req.Params.URI, knowledgeURI)
+		if !ok {
+			return nil, sdkmcp.ResourceNotFoundError(req.Params.URI)
+		}
+		e, err := src.GetKnowledge(ctx, key)
+		if err != nil || e == nil {
+			return nil, sdkmcp.ResourceNotFoundError(req.Params.URI)
+		}
+		return jsonResource(req.Params.URI, map[string]interface{}{
+			"key":       e.Key,
+			"category":  string(e.Category),
+			"content":   e.Content,
+			"tags":      e.Tags,
+			"source":    e.Source,
+			"version":   e.Version,
+			"createdAt": e.CreatedAt,
+			"updatedAt": e.UpdatedAt,
+		})
+	}
+}
+
+// resourceKey extracts the item key from "<base>/<key>".
+func resourceKey(uri, base string) (string, bool) {
+	key, ok := strings.CutPrefix(uri, base+"/")
+	if !ok || key == "" {
+		return "", false
+	}
+	return key, true
+}
+
+func jsonResource(uri string, v interface{}) (*sdkmcp.ReadResourceResult, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal resource %q: %w", uri, err)
+	}
+	return &sdkmcp.ReadResourceResult{
+		Contents: []*sdkmcp.ResourceContents{{URI: uri, MIMEType: "application/json", Text: string(data)}},
+	}, nil
+}
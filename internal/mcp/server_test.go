@@ -0,0 +1,313 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sdkmcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/agent"
+	"github.com/langoai/lango/internal/approval"
+	"github.com/langoai/lango/internal/knowledge"
+	"github.com/langoai/lango/internal/session"
+)
+
+func echoTool(name string, gotKey *string) *agent.Tool {
+	return &agent.Tool{
+		Name:        name,
+		Description: "echo " + name,
+		SafetyLevel: agent.SafetyLevelSafe,
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"text": map[string]interface{}{"type": "string", "description": "Text to echo"},
+			},
+			"required": []string{"text"},
+		},
+		Handler: func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			if gotKey != nil {
+				*gotKey = session.SessionKeyFromContext(ctx)
+			}
+			text, _ := params["text"].(string)
+			if text == "fail" {
+				return nil, errors.New("boom")
+			}
+			return map[string]interface{}{"echo": text}, nil
+		},
+	}
+}
+
+func connectTestServer(t *testing.T, cfg ServerConfig, opts *sdkmcp.ClientOptions) *sdkmcp.ClientSession {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	srv := NewServer(cfg)
+	serverT, clientT := sdkmcp.NewInMemoryTransports()
+	ss, err := srv.Connect(ctx, serverT, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ss.Close() })
+
+	client := sdkmcp.NewClient(&sdkmcp.Implementation{Name: "test-client", Version: "1.0"}, opts)
+	cs, err := client.Connect(ctx, clientT, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cs.Close() })
+	return cs
+}
+
+func TestAllowedTools(t *testing.T) {
+	tools := []*agent.Tool{
+		echoTool("search_knowledge", nil),
+		echoTool("skill_deploy", nil),
+		echoTool("exec", nil),
+		{Name: "no_handler"},
+	}
+
+	tests := []struct {
+		give []string
+		want []string
+	}{
+		{give: nil, want: nil},
+		{give: []string{"search_knowledge"}, want: []string{"search_knowledge"}},
+		{give: []string{"skill_*", "exec"}, want: []string{"exec", "skill_deploy"}},
+		{give: []string{"*"}, want: []string{"exec", "search_knowledge", "skill_deploy"}},
+		{give: []string{"[invalid"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, tool := range AllowedTools(tools, tt.give) {
+			got = append(got, tool.Name)
+		}
+		assert.Equal(t, tt.want, got, "allow=%v", tt.give)
+	}
+}
+
+func TestServer_ListAndCallTools(t *testing.T) {
+	var gotKey string
+	cs := connectTestServer(t, ServerConfig{
+		Tools: []*agent.Tool{echoTool("search_knowledge", &gotKey), echoTool("exec", nil)},
+		Allow: []string{"search_knowledge"},
+	}, nil)
+	ctx := context.Background()
+
+	list, err := cs.ListTools(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, list.Tools, 1)
+	assert.Equal(t, "search_knowledge", list.Tools[0].Name)
+	assert.True(t, list.Tools[0].Annotations.ReadOnlyHint)
+
+	res, err := cs.CallTool(ctx, &sdkmcp.CallToolParams{
+		Name:      "search_knowledge",
+		Arguments: map[string]any{"text": "hi"},
+	})
+	require.NoError(t, err)
+	assert.False(t, res.IsError)
+	assert.JSONEq(t, `{"echo":"hi"}`, res.Content[0].(*sdkmcp.TextContent).Text)
+	assert.Contains(t, gotKey, ServerSessionPrefix+"test-client")
+
+	res, err = cs.CallTool(ctx, &sdkmcp.CallToolParams{
+		Name:      "search_knowledge",
+		Arguments: map[string]any{"text": "fail"},
+	})
+	require.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Equal(t, "boom", res.Content[0].(*sdkmcp.TextContent).Text)
+
+	_, err = cs.CallTool(ctx, &sdkmcp.CallToolParams{Name: "exec", Arguments: map[string]any{"text": "x"}})
+	assert.Error(t, err)
+}
+
+type fakeSessions struct{ sessions map[string]*session.Session }
+
+func (f *fakeSessions) ListSessions(context.Context) ([]session.SessionSummary, error) {
+	var out []session.SessionSummary
+	for k := range f.sessions {
+		out = append(out, session.SessionSummary{Key: k})
+	}
+	return out, nil
+}
+
+func (f *fakeSessions) Get(key string) (*session.Session, error) {
+	s, ok := f.sessions[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return s, nil
+}
+
+type fakeKnowledge struct {
+	entries map[string]knowledge.KnowledgeEntry
+}
+
+func (f *fakeKnowledge) SearchRecentKnowledge(context.Context, string, int) ([]knowledge.KnowledgeEntry, error) {
+	var out []knowledge.KnowledgeEntry
+	for _, e := range f.entries {
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+func (f *fakeKnowledge) GetKnowledge(_ context.Context, key string) (*knowledge.KnowledgeEntry, error) {
+	e, ok := f.entries[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &e, nil
+}
+
+func TestServer_Resources(t *testing.T) {
+	cs := connectTestServer(t, ServerConfig{
+		Sessions: &fakeSessions{sessions: map[string]*session.Session{
+			"telegram:1": {Key: "telegram:1", History: []session.Message{{Role: "user", Content: "hello"}}},
+		}},
+		Knowledge: &fakeKnowledge{entries: map[string]knowledge.KnowledgeEntry{
+			"go-style": {Key: "go-style", Content: "use gofmt"},
+		}},
+	}, nil)
+	ctx := context.Background()
+
+	list, err := cs.ListResources(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, list.Resources, 2)
+
+	idx, err := cs.ReadResource(ctx, &sdkmcp.ReadResourceParams{URI: sessionsURI})
+	require.NoError(t, err)
+	assert.Contains(t, idx.Contents[0].Text, "lango://sessions/telegram:1")
+
+	sess, err := cs.ReadResource(ctx, &sdkmcp.ReadResourceParams{URI: sessionsURI + "/telegram:1"})
+	require.NoError(t, err)
+	assert.Contains(t, sess.Contents[0].Text, "hello")
+
+	entry, err := cs.ReadResource(ctx, &sdkmcp.ReadResourceParams{URI: knowledgeURI + "/go-style"})
+	require.NoError(t, err)
+	assert.Contains(t, entry.Contents[0].Text, "use gofmt")
+
+	_, err = cs.ReadResource(ctx, &sdkmcp.ReadResourceParams{URI: knowledgeURI + "/missing"})
+	assert.Error(t, err)
+}
+
+func TestElicitationApprovalProvider(t *testing.T) {
+	p := &ElicitationApprovalProvider{}
+	assert.True(t, p.CanHandle("mcp:claude:abc"))
+	assert.False(t, p.CanHandle("telegram:1"))
+
+	gated := func() *agent.Tool {
+		tool := echoTool("exec", nil)
+		inner := tool.Handler
+		tool.Handler = func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+			resp, err := p.RequestApproval(ctx, approval.ApprovalRequest{
+				ToolName:   "exec",
+				SessionKey: session.SessionKeyFromContext(ctx),
+			})
+			if err != nil {
+				return nil, err
+			}
+			if !resp.Approved {
+				return nil, errors.New("denied")
+			}
+			return inner(ctx, params)
+		}
+		return tool
+	}
+
+	t.Run("approved via elicitation", func(t *testing.T) {
+		cs := connectTestServer(t, ServerConfig{Tools: []*agent.Tool{gated()}, Allow: []string{"exec"}},
+			&sdkmcp.ClientOptions{
+				ElicitationHandler: func(context.Context, *sdkmcp.ElicitRequest) (*sdkmcp.ElicitResult, error) {
+					return &sdkmcp.ElicitResult{Action: "accept", Content: map[string]any{"approve": true}}, nil
+				},
+			})
+		res, err := cs.CallTool(context.Background(), &sdkmcp.CallToolParams{Name: "exec", Arguments: map[string]any{"text": "x"}})
+		require.NoError(t, err)
+		assert.False(t, res.IsError)
+	})
+
+	t.Run("declined via elicitation", func(t *testing.T) {
+		cs := connectTestServer(t, ServerConfig{Tools: []*agent.Tool{gated()}, Allow: []string{"exec"}},
+			&sdkmcp.ClientOptions{
+				ElicitationHandler: func(context.Context, *sdkmcp.ElicitRequest) (*sdkmcp.ElicitResult, error) {
+					return &sdkmcp.ElicitResult{Action: "decline"}, nil
+				},
+			})
+		res, err := cs.CallTool(context.Background(), &sdkmcp.CallToolParams{Name: "exec", Arguments: map[string]any{"text": "x"}})
+		require.NoError(t, err)
+		assert.True(t, res.IsError)
+	})
+
+	t.Run("client without elicitation is denied", func(t *testing.T) {
+		cs := connectTestServer(t, ServerConfig{Tools: []*agent.Tool{gated()}, Allow: []string{"exec"}}, nil)
+		res, err := cs.CallTool(context.Background(), &sdkmcp.CallToolParams{Name: "exec", Arguments: map[string]any{"text": "x"}})
+		require.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Contains(t, res.Content[0].(*sdkmcp.TextContent).Text, "elicitation not supported")
+	})
+}
+
+func TestCheckHTTPAddr(t *testing.T) {
+	tests := []struct {
+		give      string
+		giveToken string
+		wantErr   bool
+	}{
+		{give: ":9000", wantErr: true},
+		{give: "0.0.0.0:9000", wantErr: true},
+		{give: "192.168.1.10:9000", wantErr: true},
+		{give: "127.0.0.1:9000"},
+		{give: "localhost:9000"},
+		{give: "[::1]:9000"},
+		{give: ":9000", giveToken: "secret"},
+		{give: "0.0.0.0:9000", giveToken: "secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give+"/"+tt.giveToken, func(t *testing.T) {
+			err := CheckHTTPAddr(tt.give, tt.giveToken)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnauthenticatedListen)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHTTPHandler_LoopbackOriginCheck(t *testing.T) {
+	srv := sdkmcp.NewServer(&sdkmcp.Implementation{Name: "test", Version: "v0"}, nil)
+	h := HTTPHandler(srv, "")
+
+	tests := []struct {
+		give       string
+		giveHost   string
+		giveOrigin string
+		wantDenied bool
+	}{
+		{give: "rebinding origin", giveHost: "127.0.0.1:18791", giveOrigin: "http://evil.example.com", wantDenied: true},
+		{give: "rebinding host", giveHost: "evil.example.com:18791", wantDenied: true},
+		{give: "loopback origin", giveHost: "localhost:18791", giveOrigin: "http://localhost:3000"},
+		{give: "no origin", giveHost: "127.0.0.1:18791"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://"+tt.giveHost+"/", nil)
+			req.Host = tt.giveHost
+			if tt.giveOrigin != "" {
+				req.Header.Set("Origin", tt.giveOrigin)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if tt.wantDenied {
+				assert.Equal(t, http.StatusForbidden, rec.Code)
+				return
+			}
+			assert.NotEqual(t, http.StatusForbidden, rec.Code)
+		})
+	}
+}
@@ -33,7 +33,7 @@ func newTestBuffer(t *testing.T, gen llm.TextGenerator, msgs []session.Message,
 }
 
 func TestBufferStartStop(t *testing.T) {
-	gen := &mockGenerator{response: "test observation"}
+	gen := &mockGenerator{response: `{"observation": "test observation"}`}
 	buf := newTestBuffer(t, gen, nil, 100, 200)
 
 	var wg sync.WaitGroup
@@ -56,7 +56,7 @@ func TestBufferTriggerProcessesObservation(t *testing.T) {
 		}
 	}
 
-	gen := &mockGenerator{response: "Compressed observation of the conversation."}
+	gen := &mockGenerator{response: `{"observation": "Compressed observation of the conversation."}`}
 	buf := newTestBuffer(t, gen, messages, 10, 100000)
 
 	var wg sync.WaitGroup
@@ -88,7 +88,7 @@ func TestBufferConcurrentTriggers(t *testing.T) {
 		}
 	}
 
-	gen := &mockGenerator{response: "Concurrent observation."}
+	gen := &mockGenerator{response: `{"observation": "Concurrent observation."}`}
 	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
 	t.Cleanup(func() { client.Close() })
 	logger := zap.NewNop().Sugar()
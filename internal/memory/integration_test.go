@@ -28,7 +28,7 @@ func TestIntegration_ObservationGeneration(t *testing.T) {
 
 	logger := zap.NewNop().Sugar()
 	store := NewStore(client, logger)
-	gen := &integrationMockGenerator{response: "User wants to build a REST API using Go with Chi router"}
+	gen := &integrationMockGenerator{response: `{"observation": "User wants to build a REST API using Go with Chi router"}`}
 	observer := NewObserver(gen, store, logger)
 
 	ctx := context.Background()
@@ -61,7 +61,7 @@ func TestIntegration_ObservationToReflection(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	store := NewStore(client, logger)
 
-	obsGen := &integrationMockGenerator{response: "Observation note"}
+	obsGen := &integrationMockGenerator{response: `{"observation": "Observation note"}`}
 	observer := NewObserver(obsGen, store, logger)
 
 	ctx := context.Background()
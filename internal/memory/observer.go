@@ -27,7 +27,12 @@ Do NOT include:
 - Redundant greetings or pleasantries
 - Technical details that can be re-derived
 
-Write a concise paragraph (2-5 sentences) capturing the essential information.`
+Write the observation as a concise paragraph (2-5 sentences) capturing the essential information.`
+
+// observationNote is the structured response requested from the LLM.
+type observationNote struct {
+	Observation string `json:"observation" jsonschema:"concise 2-5 sentence note capturing the essential information"`
+}
 
 // Observer generates compressed observation notes from conversation history.
 type Observer struct {
@@ -61,10 +66,12 @@ func (o *Observer) Observe(ctx context.Context, sessionKey string, messages []se
 
 	userPrompt := formatMessages(newMessages)
 
-	response, err := o.generator.GenerateText(ctx, observerPrompt, userPrompt)
+	note, err := llm.GenerateStructured[observationNote](ctx, o.generator, observerPrompt, userPrompt,
+		llm.WithSchemaName("observation", "Compressed observation note for the conversation"))
 	if err != nil {
 		return nil, fmt.Errorf("generate observation: %w", err)
 	}
+	response := strings.TrimSpace(note.Observation)
 
 	obs := Observation{
 		ID:               uuid.New(),
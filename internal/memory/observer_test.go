@@ -11,6 +11,7 @@ import (
 
 	"github.com/langoai/lango/internal/ent/enttest"
 	"github.com/langoai/lango/internal/llm"
+	"github.com/langoai/lango/internal/llm/llmtest"
 	"github.com/langoai/lango/internal/session"
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -36,7 +37,7 @@ func newTestObserver(t *testing.T, gen llm.TextGenerator) (*Observer, *Store) {
 
 func TestObserve(t *testing.T) {
 	t.Run("generates observation and saves it", func(t *testing.T) {
-		gen := &mockGenerator{response: "User discussed building a REST API with Go."}
+		gen := &mockGenerator{response: `{"observation": "User discussed building a REST API with Go."}`}
 		observer, store := newTestObserver(t, gen)
 		ctx := context.Background()
 
@@ -64,7 +65,7 @@ func TestObserve(t *testing.T) {
 	})
 
 	t.Run("partial observation from lastObservedIndex", func(t *testing.T) {
-		gen := &mockGenerator{response: "User decided on PostgreSQL."}
+		gen := &mockGenerator{response: `{"observation": "User decided on PostgreSQL."}`}
 		observer, _ := newTestObserver(t, gen)
 		ctx := context.Background()
 
@@ -108,6 +109,46 @@ func TestObserve(t *testing.T) {
 	})
 }
 
+func TestObserve_RepairsMalformedJSON(t *testing.T) {
+	gen := llmtest.NewScriptedGenerator(
+		"User wants a REST API.",
+		`{"observation": 42}`,
+		`{"observation": "User wants a REST API in Go."}`,
+	)
+	observer, _ := newTestObserver(t, gen)
+
+	messages := []session.Message{
+		{Role: "user", Content: "I want to build a REST API in Go", Timestamp: time.Now()},
+	}
+
+	obs, err := observer.Observe(context.Background(), "session-obs-5", messages, -1)
+	require.NoError(t, err)
+	require.NotNil(t, obs)
+	assert.Equal(t, "User wants a REST API in Go.", obs.Content)
+
+	prompts := gen.UserPrompts()
+	require.Len(t, prompts, 3)
+	assert.Contains(t, prompts[1], "User wants a REST API.")
+	assert.Contains(t, prompts[2], `{"observation": 42}`)
+}
+
+func TestObserve_InvalidOutputNotSaved(t *testing.T) {
+	gen := &mockGenerator{response: "not json"}
+	observer, store := newTestObserver(t, gen)
+	ctx := context.Background()
+
+	messages := []session.Message{
+		{Role: "user", Content: "Hello", Timestamp: time.Now()},
+	}
+
+	_, err := observer.Observe(ctx, "session-obs-6", messages, -1)
+	require.ErrorIs(t, err, llm.ErrInvalidStructuredOutput)
+
+	saved, err := store.ListObservations(ctx, "session-obs-6")
+	require.NoError(t, err)
+	assert.Empty(t, saved)
+}
+
 func TestFormatMessages(t *testing.T) {
 	tests := []struct {
 		give string
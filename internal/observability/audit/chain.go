@@ -0,0 +1,222 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+
+	"github.com/langoai/lango/internal/ent"
+	"github.com/langoai/lango/internal/ent/auditlog"
+	"github.com/langoai/lango/internal/ent/hook"
+)
+
+// ErrAppendOnly is returned when an audit log row is updated or deleted
+// through a client with the chain installed.
+var ErrAppendOnly = errors.New("audit log is append-only")
+
+// Record is the hashed content of an audit log row. Its canonical JSON,
+// prefixed with the previous record's hash, is what the chain hash covers.
+type Record struct {
+	ID         uuid.UUID
+	Seq        int64
+	SessionKey string
+	Action     string
+	Actor      string
+	Target     string
+	Details    map[string]interface{}
+	Timestamp  time.Time
+}
+
+// canonicalRecord fixes the field order and encoding of a Record.
+type canonicalRecord struct {
+	ID         string      `json:"id"`
+	Seq        int64       `json:"seq"`
+	SessionKey string      `json:"session_key"`
+	Action     string      `json:"action"`
+	Actor      string      `json:"actor"`
+	Target     string      `json:"target"`
+	Details    interface{} `json:"details"`
+	Timestamp  string      `json:"timestamp"`
+}
+
+// CanonicalJSON returns the deterministic JSON encoding of r. Details are
+// normalized through a JSON round trip so that a record hashes the same
+// before it is written and after it is read back from the database.
+func (r Record) CanonicalJSON() ([]byte, error) {
+	var details interface{}
+	if len(r.Details) > 0 {
+		raw, err := json.Marshal(r.Details)
+		if err != nil {
+			return nil, fmt.Errorf("marshal audit details: %w", err)
+		}
+		if err := json.Unmarshal(raw, &details); err != nil {
+			return nil, fmt.Errorf("normalize audit details: %w", err)
+		}
+	}
+	return json.Marshal(canonicalRecord{
+		ID:         r.ID.String(),
+		Seq:        r.Seq,
+		SessionKey: r.SessionKey,
+		Action:     r.Action,
+		Actor:      r.Actor,
+		Target:     r.Target,
+		Details:    details,
+		Timestamp:  r.Timestamp.UTC().Format(time.RFC3339Nano),
+	})
+}
+
+// Hash returns hex(SHA-256(prevHash || canonical JSON of r)).
+func (r Record) Hash(prevHash string) (string, error) {
+	data, err := r.CanonicalJSON()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// recordOf extracts the hashed content of a stored row.
+func recordOf(row *ent.AuditLog) Record {
+	var seq int64
+	if row.Seq != nil {
+		seq = *row.Seq
+	}
+	return Record{
+		ID:         row.ID,
+		Seq:        seq,
+		SessionKey: row.SessionKey,
+		Action:     string(row.Action),
+		Actor:      row.Actor,
+		Target:     row.Target,
+		Details:    row.Details,
+		Timestamp:  row.Timestamp,
+	}
+}
+
+// chainInsertAttempts bounds how often an insert is re-linked after losing
+// its seq to another writer on the same database.
+const chainInsertAttempts = 5
+
+// Chain links every audit log row created through a client to its
+// predecessor. Install it once per client with InstallChain; the head is
+// loaded lazily and cached. Inserts are serialized so the cached head only
+// advances once a row is stored; when another writer (for example a second
+// process on the same database) took the seq first, the head is reloaded
+// and the row re-linked.
+type Chain struct {
+	client *ent.Client
+
+	mu     sync.Mutex
+	loaded bool
+	seq    int64
+	hash   string
+}
+
+// InstallChain registers the chain hook on client's AuditLog entity. Every
+// create is assigned the next sequence number and hash; updates and
+// deletes are rejected with ErrAppendOnly.
+func InstallChain(client *ent.Client) *Chain {
+	c := &Chain{client: client}
+	client.AuditLog.Use(c.hook)
+	return c
+}
+
+func (c *Chain) hook(next ent.Mutator) ent.Mutator {
+	return hook.AuditLogFunc(func(ctx context.Context, m *ent.AuditLogMutation) (ent.Value, error) {
+		if !m.Op().Is(ent.OpCreate) {
+			return nil, ErrAppendOnly
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for attempt := 1; ; attempt++ {
+			seq, hash, err := c.link(ctx, m)
+			if err != nil {
+				return nil, fmt.Errorf("chain audit log: %w", err)
+			}
+			v, err := next.Mutate(ctx, m)
+			if err == nil {
+				c.seq, c.hash = seq, hash
+				return v, nil
+			}
+			// The stored head may have moved; re-read it before the next insert.
+			c.loaded = false
+			if !ent.IsConstraintError(err) || attempt == chainInsertAttempts {
+				return nil, err
+			}
+		}
+	})
+}
+
+// link assigns m the sequence number, previous hash and hash following the
+// cached head, and returns the new seq and hash. The caller holds c.mu.
+func (c *Chain) link(ctx context.Context, m *ent.AuditLogMutation) (int64, string, error) {
+	id, ok := m.ID()
+	if !ok {
+		id = uuid.New()
+		m.SetID(id)
+	}
+	ts, ok := m.Timestamp()
+	if !ok {
+		ts = time.Now()
+		m.SetTimestamp(ts)
+	}
+	action, _ := m.Action()
+	sessionKey, _ := m.SessionKey()
+	actor, _ := m.Actor()
+	target, _ := m.Target()
+	details, _ := m.Details()
+
+	if !c.loaded {
+		seq, hash, err := Head(ctx, c.client)
+		if err != nil {
+			return 0, "", err
+		}
+		c.seq, c.hash, c.loaded = seq, hash, true
+	}
+
+	rec := Record{
+		ID:         id,
+		Seq:        c.seq + 1,
+		SessionKey: sessionKey,
+		Action:     string(action),
+		Actor:      actor,
+		Target:     target,
+		Details:    details,
+		Timestamp:  ts,
+	}
+	hash, err := rec.Hash(c.hash)
+	if err != nil {
+		return 0, "", err
+	}
+	m.SetSeq(rec.Seq)
+	m.SetPrevHash(c.hash)
+	m.SetHash(hash)
+	return rec.Seq, hash, nil
+}
+
+// Head returns the sequence number and hash of the newest chained audit
+// record, or zero and "" when no record has been chained yet.
+func Head(ctx context.Context, client *ent.Client) (int64, string, error) {
+	row, err := client.AuditLog.Query().
+		Where(auditlog.SeqNotNil()).
+		Order(auditlog.BySeq(sql.OrderDesc())).
+		First(ctx)
+	if ent.IsNotFound(err) {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("query audit chain head: %w", err)
+	}
+	return *row.Seq, row.Hash, nil
+}
@@ -0,0 +1,413 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/ent"
+	"github.com/langoai/lango/internal/ent/auditlog"
+	"github.com/langoai/lango/internal/eventbus"
+	"github.com/langoai/lango/internal/toolchain"
+)
+
+const testSignerDID = "did:test:node"
+
+type testSigner struct{ key ed25519.PrivateKey }
+
+func (s testSigner) Sign(_ context.Context, payload []byte) ([]byte, error) {
+	return ed25519.Sign(s.key, payload), nil
+}
+
+func (s testSigner) Algorithm() string { return "ed25519" }
+
+func newTestSigner(t *testing.T) (testSigner, map[string]SignatureVerifyFunc) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	verifiers := map[string]SignatureVerifyFunc{
+		"ed25519": func(did string, payload, sig []byte) error {
+			if did != testSignerDID || !ed25519.Verify(pub, payload, sig) {
+				return errors.New("signature mismatch")
+			}
+			return nil
+		},
+	}
+	return testSigner{key: priv}, verifiers
+}
+
+// openTestDB returns an ent client and a raw handle on the same SQLite file,
+// so tests can tamper with rows behind ent's back.
+func openTestDB(t *testing.T) (*ent.Client, *sql.DB) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "audit.db")+"?_fk=1")
+	require.NoError(t, err)
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, db)))
+	t.Cleanup(func() { client.Close() })
+	require.NoError(t, client.Schema.Create(context.Background(), schema.WithForeignKeys(false)))
+	return client, db
+}
+
+// seedChain writes five chained records through the Recorder and the plain
+// ent API, and returns their IDs by seq.
+func seedChain(t *testing.T, client *ent.Client) map[int64]string {
+	t.Helper()
+	ctx := context.Background()
+	InstallChain(client)
+
+	bus := eventbus.New()
+	NewRecorder(client).Subscribe(bus)
+	bus.Publish(toolchain.ToolExecutedEvent{SessionKey: "s1", AgentName: "agent", ToolName: "exec", Duration: time.Second, Success: true})
+	bus.Publish(eventbus.PolicyDecisionEvent{SessionKey: "s1", Command: "rm -rf /", Verdict: "block", Reason: "destructive"})
+	for i, target := range []string{"note-a", "note-b", "note-c"} {
+		_, err := client.AuditLog.Create().
+			SetAction(auditlog.ActionKnowledgeSave).
+			SetActor("agent").
+			SetTarget(target).
+			SetDetails(map[string]interface{}{"size": i * 10, "tags": []string{"x", "y"}, "nested": map[string]interface{}{"b": 1.5, "a": true}}).
+			Save(ctx)
+		require.NoError(t, err)
+	}
+
+	rows, err := client.AuditLog.Query().Where(auditlog.SeqNotNil()).All(ctx)
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+	ids := make(map[int64]string, len(rows))
+	for _, row := range rows {
+		require.NotNil(t, row.Seq)
+		ids[*row.Seq] = row.ID.String()
+	}
+	return ids
+}
+
+func TestVerify_IntactChain(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client, _ := openTestDB(t)
+	seedChain(t, client)
+
+	signer, verifiers := newTestSigner(t)
+	cp, err := NewCheckpointer(client, testSignerDID, signer, 0).Checkpoint(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, cp)
+	assert.Equal(t, int64(5), cp.Seq)
+
+	// Nothing new to sign.
+	again, err := NewCheckpointer(client, testSignerDID, signer, 0).Checkpoint(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, again)
+
+	report, err := Verify(ctx, client, testSignerDID, verifiers)
+	require.NoError(t, err)
+	assert.True(t, report.OK(), report.Problems)
+	assert.Equal(t, 5, report.Records)
+	assert.Equal(t, 1, report.Checkpoints)
+	assert.Equal(t, int64(5), report.HeadSeq)
+	assert.Equal(t, cp.Hash, report.HeadHash)
+}
+
+func TestVerify_PinpointsTampering(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give   string
+		tamper string // SQL run against the raw database; ? is replaced by the seq 3 row ID
+		want   []Problem
+	}{
+		{
+			give:   "modified target",
+			tamper: `UPDATE audit_logs SET target = 'note-z' WHERE id = ?`,
+			want:   []Problem{{Kind: ProblemModified, Seq: 3}},
+		},
+		{
+			give:   "modified details",
+			tamper: `UPDATE audit_logs SET details = '{"size":999}' WHERE id = ?`,
+			want:   []Problem{{Kind: ProblemModified, Seq: 3}},
+		},
+		{
+			give:   "modified timestamp",
+			tamper: `UPDATE audit_logs SET timestamp = '2001-01-01 00:00:00+00:00' WHERE id = ?`,
+			want:   []Problem{{Kind: ProblemModified, Seq: 3}},
+		},
+		{
+			give:   "recomputed hash",
+			tamper: `UPDATE audit_logs SET target = 'note-z', hash = 'forged' WHERE id = ?`,
+			want:   []Problem{{Kind: ProblemModified, Seq: 3}, {Kind: ProblemBrokenLink, Seq: 4}},
+		},
+		{
+			give:   "deleted row",
+			tamper: `DELETE FROM audit_logs WHERE id = ?`,
+			want:   []Problem{{Kind: ProblemGap, Seq: 4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			client, db := openTestDB(t)
+			ids := seedChain(t, client)
+
+			res, err := db.Exec(tt.tamper, ids[3])
+			require.NoError(t, err)
+			n, err := res.RowsAffected()
+			require.NoError(t, err)
+			require.Equal(t, int64(1), n)
+
+			report, err := Verify(ctx, client, testSignerDID, nil)
+			require.NoError(t, err)
+			require.Len(t, report.Problems, len(tt.want), report.Problems)
+			for i, want := range tt.want {
+				got := report.Problems[i]
+				assert.Equal(t, want.Kind, got.Kind, got.Message)
+				assert.Equal(t, want.Seq, got.Seq, got.Message)
+				assert.Equal(t, ids[want.Seq], got.ID)
+			}
+		})
+	}
+}
+
+func TestVerify_Checkpoints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give   string
+		tamper string
+		want   ProblemKind
+		seq    int64
+	}{
+		{
+			give:   "truncated tail",
+			tamper: `DELETE FROM audit_logs WHERE seq >= 4`,
+			want:   ProblemCheckpoint,
+			seq:    5,
+		},
+		{
+			give:   "forged signature",
+			tamper: `UPDATE audit_checkpoints SET signature = X'00'`,
+			want:   ProblemCheckpoint,
+			seq:    5,
+		},
+		{
+			give:   "rewritten checkpoint hash",
+			tamper: `UPDATE audit_checkpoints SET hash = 'forged'`,
+			want:   ProblemCheckpoint,
+			seq:    5,
+		},
+		{
+			give:   "re-signed by another key",
+			tamper: `UPDATE audit_checkpoints SET signer = 'did:test:intruder'`,
+			want:   ProblemCheckpoint,
+			seq:    5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			client, db := openTestDB(t)
+			seedChain(t, client)
+			signer, verifiers := newTestSigner(t)
+			_, err := NewCheckpointer(client, testSignerDID, signer, 0).Checkpoint(ctx)
+			require.NoError(t, err)
+
+			_, err = db.Exec(tt.tamper)
+			require.NoError(t, err)
+
+			report, err := Verify(ctx, client, testSignerDID, verifiers)
+			require.NoError(t, err)
+			require.NotEmpty(t, report.Problems)
+			got := report.Problems[len(report.Problems)-1]
+			assert.Equal(t, tt.want, got.Kind, got.Message)
+			assert.Equal(t, tt.seq, got.Seq)
+		})
+	}
+}
+
+func TestVerify_UntrustedSigner(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client, _ := openTestDB(t)
+	seedChain(t, client)
+	signer, verifiers := newTestSigner(t)
+	_, err := NewCheckpointer(client, testSignerDID, signer, 0).Checkpoint(ctx)
+	require.NoError(t, err)
+
+	for _, pinned := range []string{"", "did:test:other"} {
+		report, err := Verify(ctx, client, pinned, verifiers)
+		require.NoError(t, err)
+		require.Len(t, report.Problems, 1, pinned)
+		assert.Equal(t, ProblemCheckpoint, report.Problems[0].Kind)
+		assert.Contains(t, report.Problems[0].Message, testSignerDID)
+	}
+}
+
+func TestVerify_UnchainedRows(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client, db := openTestDB(t)
+
+	// A row written before the chain was installed is legacy.
+	_, err := client.AuditLog.Create().SetAction(auditlog.ActionToolCall).SetActor("old").
+		SetTimestamp(time.Now().Add(-time.Hour)).Save(ctx)
+	require.NoError(t, err)
+	seedChain(t, client)
+
+	// A row slipped in later without the hook is not.
+	_, err = db.Exec(`INSERT INTO audit_logs (id, action, actor, timestamp) VALUES ('00000000-0000-0000-0000-000000000001', 'tool_call', 'intruder', ?)`, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	report, err := Verify(ctx, client, testSignerDID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Legacy)
+	require.Len(t, report.Problems, 1)
+	assert.Equal(t, ProblemUnchained, report.Problems[0].Kind)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", report.Problems[0].ID)
+}
+
+func TestChain_AppendOnly(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client, _ := openTestDB(t)
+	seedChain(t, client)
+
+	_, err := client.AuditLog.Update().SetTarget("x").Save(ctx)
+	assert.ErrorIs(t, err, ErrAppendOnly)
+	_, err = client.AuditLog.Delete().Exec(ctx)
+	assert.ErrorIs(t, err, ErrAppendOnly)
+}
+
+func TestChain_ResumesFromStoredHead(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client, db := openTestDB(t)
+	seedChain(t, client)
+
+	// A second client on the same database continues the chain.
+	other := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, db)))
+	InstallChain(other)
+	row, err := other.AuditLog.Create().SetAction(auditlog.ActionAlert).SetActor("system").Save(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, row.Seq)
+	assert.Equal(t, int64(6), *row.Seq)
+
+	report, err := Verify(ctx, client, testSignerDID, nil)
+	require.NoError(t, err)
+	assert.True(t, report.OK(), report.Problems)
+}
+
+func TestChain_ConcurrentWriters(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client, db := openTestDB(t)
+	InstallChain(client)
+
+	// A second client on the same database stands in for another process
+	// whose cached head goes stale.
+	other := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, db)))
+	InstallChain(other)
+
+	const perWriter = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, 4*perWriter)
+	for _, c := range []*ent.Client{client, client, other, other} {
+		wg.Add(1)
+		go func(c *ent.Client) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				_, err := c.AuditLog.Create().SetAction(auditlog.ActionAlert).SetActor("system").Save(ctx)
+				errs <- err
+			}
+		}(c)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	report, err := Verify(ctx, client, testSignerDID, nil)
+	require.NoError(t, err)
+	assert.True(t, report.OK(), report.Problems)
+	assert.Equal(t, 4*perWriter, report.Records)
+	assert.Equal(t, int64(4*perWriter), report.HeadSeq)
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client, _ := openTestDB(t)
+	seedChain(t, client)
+	signer, _ := newTestSigner(t)
+	_, err := NewCheckpointer(client, testSignerDID, signer, 0).Checkpoint(ctx)
+	require.NoError(t, err)
+
+	t.Run("jsonl", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		require.NoError(t, Export(ctx, client, &buf, ExportJSONL, time.Time{}))
+
+		var recs []ExportRecord
+		sc := bufio.NewScanner(&buf)
+		for sc.Scan() {
+			var rec ExportRecord
+			require.NoError(t, json.Unmarshal(sc.Bytes(), &rec))
+			recs = append(recs, rec)
+		}
+		require.Len(t, recs, 5)
+		for i, rec := range recs {
+			require.NotNil(t, rec.Seq)
+			assert.Equal(t, int64(i+1), *rec.Seq)
+			if i > 0 {
+				assert.Equal(t, recs[i-1].Hash, rec.PrevHash)
+			}
+		}
+		assert.Nil(t, recs[3].Checkpoint)
+		require.NotNil(t, recs[4].Checkpoint)
+		assert.Equal(t, testSignerDID, recs[4].Checkpoint.Signer)
+	})
+
+	t.Run("csv since", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		require.NoError(t, Export(ctx, client, &buf, ExportCSV, time.Now().Add(time.Hour)))
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{exportCSVHeader}, rows)
+
+		buf.Reset()
+		require.NoError(t, Export(ctx, client, &buf, ExportCSV, time.Now().Add(-time.Hour)))
+		rows, err = csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 6)
+		assert.Equal(t, "5", rows[5][0])
+		assert.Equal(t, "ed25519", rows[5][11])
+	})
+}
+
+func TestParseExportFormat(t *testing.T) {
+	t.Parallel()
+
+	f, err := ParseExportFormat("JSONL")
+	require.NoError(t, err)
+	assert.Equal(t, ExportJSONL, f)
+	_, err = ParseExportFormat("xml")
+	assert.ErrorContains(t, err, "must be jsonl or csv")
+}
@@ -0,0 +1,135 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"go.uber.org/zap"
+
+	"github.com/langoai/lango/internal/ent"
+	"github.com/langoai/lango/internal/ent/auditcheckpoint"
+	"github.com/langoai/lango/internal/logging"
+)
+
+func logger() *zap.SugaredLogger { return logging.SubsystemSugar("audit") }
+
+// DefaultCheckpointInterval is how often the chain head is signed when no
+// interval is configured.
+const DefaultCheckpointInterval = time.Hour
+
+// Signer signs checkpoint payloads with the node's identity key.
+type Signer interface {
+	Sign(ctx context.Context, payload []byte) ([]byte, error)
+	Algorithm() string
+}
+
+// SignatureVerifyFunc verifies a checkpoint signature against a signer DID.
+type SignatureVerifyFunc func(signerDID string, payload, signature []byte) error
+
+// CheckpointPayload returns the bytes signed for a checkpoint at seq.
+func CheckpointPayload(seq int64, hash string) []byte {
+	return []byte(fmt.Sprintf("lango-audit-checkpoint:%d:%s", seq, hash))
+}
+
+// Checkpointer periodically signs the audit chain head so that rewriting
+// the chain, not just single rows, can be detected.
+type Checkpointer struct {
+	client    *ent.Client
+	signerDID string
+	signer    Signer
+	interval  time.Duration
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewCheckpointer creates a checkpointer signing as signerDID.
+func NewCheckpointer(client *ent.Client, signerDID string, signer Signer, interval time.Duration) *Checkpointer {
+	if interval <= 0 {
+		interval = DefaultCheckpointInterval
+	}
+	return &Checkpointer{
+		client:    client,
+		signerDID: signerDID,
+		signer:    signer,
+		interval:  interval,
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+}
+
+func (c *Checkpointer) Name() string { return "audit-checkpointer" }
+
+func (c *Checkpointer) Start(_ context.Context, _ *sync.WaitGroup) error {
+	go c.run()
+	return nil
+}
+
+// Stop halts the ticker and signs a final checkpoint.
+func (c *Checkpointer) Stop(ctx context.Context) error {
+	c.stopOnce.Do(func() { close(c.stopCh) })
+	<-c.doneCh
+	if _, err := c.Checkpoint(ctx); err != nil {
+		logger().Warnw("final audit checkpoint", "error", err)
+	}
+	return nil
+}
+
+func (c *Checkpointer) run() {
+	defer close(c.doneCh)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if _, err := c.Checkpoint(ctx); err != nil {
+				logger().Warnw("audit checkpoint", "error", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// Checkpoint signs the current chain head. It returns nil without error
+// when the head is already covered by the latest checkpoint.
+func (c *Checkpointer) Checkpoint(ctx context.Context) (*ent.AuditCheckpoint, error) {
+	seq, hash, err := Head(ctx, c.client)
+	if err != nil {
+		return nil, err
+	}
+	if seq == 0 {
+		return nil, nil
+	}
+	last, err := c.client.AuditCheckpoint.Query().
+		Order(auditcheckpoint.BySeq(sql.OrderDesc())).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, fmt.Errorf("query latest audit checkpoint: %w", err)
+	}
+	if last != nil && last.Seq >= seq {
+		return nil, nil
+	}
+
+	sig, err := c.signer.Sign(ctx, CheckpointPayload(seq, hash))
+	if err != nil {
+		return nil, fmt.Errorf("sign audit checkpoint: %w", err)
+	}
+	cp, err := c.client.AuditCheckpoint.Create().
+		SetSeq(seq).
+		SetHash(hash).
+		SetSigner(c.signerDID).
+		SetAlgorithm(c.signer.Algorithm()).
+		SetSignature(sig).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("save audit checkpoint: %w", err)
+	}
+	return cp, nil
+}
@@ -0,0 +1,161 @@
+package audit
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/langoai/lango/internal/ent"
+	"github.com/langoai/lango/internal/ent/auditcheckpoint"
+	"github.com/langoai/lango/internal/ent/auditlog"
+)
+
+// ExportFormat names an audit export serialization.
+type ExportFormat string
+
+// ExportFormat values.
+const (
+	ExportJSONL ExportFormat = "jsonl"
+	ExportCSV   ExportFormat = "csv"
+)
+
+// ParseExportFormat resolves an export format name.
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch f := ExportFormat(strings.ToLower(s)); f {
+	case ExportJSONL, ExportCSV:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown format %q (must be jsonl or csv)", s)
+}
+
+// ExportCheckpoint is the signed checkpoint attached to the record it covers.
+type ExportCheckpoint struct {
+	Signer    string    `json:"signer"`
+	Algorithm string    `json:"algorithm"`
+	Signature string    `json:"signature"` // base64
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ExportRecord is one exported audit row with its chain proof.
+type ExportRecord struct {
+	Seq        *int64                 `json:"seq"`
+	ID         string                 `json:"id"`
+	Timestamp  time.Time              `json:"timestamp"`
+	SessionKey string                 `json:"sessionKey,omitempty"`
+	Action     string                 `json:"action"`
+	Actor      string                 `json:"actor"`
+	Target     string                 `json:"target,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	PrevHash   string                 `json:"prevHash"`
+	Hash       string                 `json:"hash"`
+	Checkpoint *ExportCheckpoint      `json:"checkpoint,omitempty"`
+}
+
+var exportCSVHeader = []string{
+	"seq", "id", "timestamp", "session_key", "action", "actor", "target", "details",
+	"prev_hash", "hash", "checkpoint_signer", "checkpoint_algorithm", "checkpoint_signature",
+}
+
+// Export writes audit rows with timestamp at or after since (all rows when
+// since is zero) in chain order, each with its prev_hash and hash. Rows
+// covered by a signed checkpoint carry it, so an export can be checked
+// against the signer's key without database access.
+func Export(ctx context.Context, client *ent.Client, w io.Writer, format ExportFormat, since time.Time) error {
+	var write func(ExportRecord) error
+	flush := func() error { return nil }
+	switch format {
+	case ExportJSONL:
+		enc := json.NewEncoder(w)
+		write = func(r ExportRecord) error { return enc.Encode(r) }
+	case ExportCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(exportCSVHeader); err != nil {
+			return fmt.Errorf("write csv header: %w", err)
+		}
+		write = func(r ExportRecord) error { return cw.Write(csvRow(r)) }
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	checkpoints, err := client.AuditCheckpoint.Query().
+		Order(auditcheckpoint.BySeq(sql.OrderAsc())).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("query audit checkpoints: %w", err)
+	}
+	bySeq := make(map[int64]*ent.AuditCheckpoint, len(checkpoints))
+	for _, cp := range checkpoints {
+		bySeq[cp.Seq] = cp
+	}
+
+	query := client.AuditLog.Query()
+	if !since.IsZero() {
+		query = query.Where(auditlog.TimestampGTE(since))
+	}
+	// SQLite sorts NULL first, so pre-chain rows lead in timestamp order.
+	rows, err := query.
+		Order(auditlog.BySeq(sql.OrderAsc()), auditlog.ByTimestamp()).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("query audit logs: %w", err)
+	}
+	for _, row := range rows {
+		rec := ExportRecord{
+			Seq:        row.Seq,
+			ID:         row.ID.String(),
+			Timestamp:  row.Timestamp.UTC(),
+			SessionKey: row.SessionKey,
+			Action:     string(row.Action),
+			Actor:      row.Actor,
+			Target:     row.Target,
+			Details:    row.Details,
+			PrevHash:   row.PrevHash,
+			Hash:       row.Hash,
+		}
+		if row.Seq != nil {
+			if cp, ok := bySeq[*row.Seq]; ok {
+				rec.Checkpoint = &ExportCheckpoint{
+					Signer:    cp.Signer,
+					Algorithm: cp.Algorithm,
+					Signature: base64.StdEncoding.EncodeToString(cp.Signature),
+					CreatedAt: cp.CreatedAt.UTC(),
+				}
+			}
+		}
+		if err := write(rec); err != nil {
+			return fmt.Errorf("write audit record: %w", err)
+		}
+	}
+	return flush()
+}
+
+func csvRow(r ExportRecord) []string {
+	var seq, details string
+	if r.Seq != nil {
+		seq = strconv.FormatInt(*r.Seq, 10)
+	}
+	if len(r.Details) > 0 {
+		data, _ := json.Marshal(r.Details)
+		details = string(data)
+	}
+	row := []string{
+		seq, r.ID, r.Timestamp.Format(time.RFC3339Nano), r.SessionKey, r.Action, r.Actor, r.Target, details,
+		r.PrevHash, r.Hash, "", "", "",
+	}
+	if cp := r.Checkpoint; cp != nil {
+		row[10], row[11], row[12] = cp.Signer, cp.Algorithm, cp.Signature
+	}
+	return row
+}
@@ -27,6 +27,8 @@ func (r *Recorder) Subscribe(bus *eventbus.Bus) {
 	eventbus.SubscribeTyped[eventbus.PolicyDecisionEvent](bus, r.handlePolicyDecision)
 	eventbus.SubscribeTyped[eventbus.AlertEvent](bus, r.handleAlert)
 	eventbus.SubscribeTyped[eventbus.SandboxDecisionEvent](bus, r.handleSandboxDecision)
+	eventbus.SubscribeTyped[eventbus.SecretAccessEvent](bus, r.handleSecretAccess)
+	eventbus.SubscribeTyped[eventbus.WorkspaceMessageRejectedEvent](bus, r.handleWorkspaceMessageRejected)
 }
 
 func (r *Recorder) handleToolExecuted(evt toolchain.ToolExecutedEvent) {
@@ -121,6 +123,66 @@ func (r *Recorder) handleSandboxDecision(evt eventbus.SandboxDecisionEvent) {
 	_, _ = create.Save(context.Background())
 }
 
+func (r *Recorder) handleSecretAccess(evt eventbus.SecretAccessEvent) {
+	details := map[string]interface{}{
+		"granted": evt.Granted,
+		"version": evt.Version,
+	}
+	if evt.Tool != "" {
+		details["tool"] = evt.Tool
+	}
+	if evt.MCPServer != "" {
+		details["mcpServer"] = evt.MCPServer
+	}
+	if evt.Reason != "" {
+		details["reason"] = evt.Reason
+	}
+
+	actor := evt.Agent
+	switch {
+	case actor != "":
+	case evt.MCPServer != "":
+		actor = "mcp:" + evt.MCPServer
+	default:
+		actor = "system"
+	}
+
+	create := r.client.AuditLog.Create().
+		SetAction(auditlog.ActionSecretAccess).
+		SetActor(actor).
+		SetTarget(evt.Name).
+		SetDetails(details)
+	if evt.SessionKey != "" {
+		create = create.SetSessionKey(evt.SessionKey)
+	}
+	_, _ = create.Save(context.Background())
+}
+
+func (r *Recorder) handleWorkspaceMessageRejected(evt eventbus.WorkspaceMessageRejectedEvent) {
+	details := map[string]interface{}{
+		"stage":  evt.Stage,
+		"reason": evt.Reason,
+	}
+	if evt.MessageID != "" {
+		details["messageId"] = evt.MessageID
+	}
+	if evt.PeerID != "" {
+		details["peerId"] = evt.PeerID
+	}
+
+	actor := evt.SenderDID
+	if actor == "" {
+		actor = "unknown"
+	}
+
+	_, _ = r.client.AuditLog.Create().
+		SetAction(auditlog.ActionWorkspaceMessageRejected).
+		SetActor(actor).
+		SetTarget(evt.WorkspaceID).
+		SetDetails(details).
+		Save(context.Background())
+}
+
 func (r *Recorder) handleAlert(evt eventbus.AlertEvent) {
 	details := map[string]interface{}{
 		"severity":  evt.Severity,
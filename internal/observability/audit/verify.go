@@ -0,0 +1,186 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/langoai/lango/internal/ent"
+	"github.com/langoai/lango/internal/ent/auditcheckpoint"
+	"github.com/langoai/lango/internal/ent/auditlog"
+)
+
+// verifyPageSize is the number of audit rows read per query during Verify.
+const verifyPageSize = 1000
+
+// ProblemKind classifies a verification failure.
+type ProblemKind string
+
+// ProblemKind values.
+const (
+	// ProblemModified: a row's content no longer matches its hash.
+	ProblemModified ProblemKind = "modified"
+	// ProblemBrokenLink: a row's prev_hash does not match its predecessor.
+	ProblemBrokenLink ProblemKind = "broken_link"
+	// ProblemGap: one or more sequence numbers are missing.
+	ProblemGap ProblemKind = "gap"
+	// ProblemUnchained: a row written after chaining began has no sequence.
+	ProblemUnchained ProblemKind = "unchained"
+	// ProblemCheckpoint: a checkpoint does not match the chain or its
+	// signature does not verify.
+	ProblemCheckpoint ProblemKind = "checkpoint"
+)
+
+// Problem is a single verification failure. Seq and ID identify the
+// offending audit row where there is one.
+type Problem struct {
+	Kind    ProblemKind `json:"kind"`
+	Seq     int64       `json:"seq,omitempty"`
+	ID      string      `json:"id,omitempty"`
+	Message string      `json:"message"`
+}
+
+// Report summarizes a verification run.
+type Report struct {
+	Records     int       `json:"records"`     // chained rows checked
+	Legacy      int       `json:"legacy"`      // rows written before chaining began
+	Checkpoints int       `json:"checkpoints"` // checkpoints checked
+	HeadSeq     int64     `json:"headSeq"`
+	HeadHash    string    `json:"headHash,omitempty"`
+	Signer      string    `json:"signer,omitempty"` // trusted checkpoint signer
+	Problems    []Problem `json:"problems"`
+}
+
+// OK reports whether verification found no problems.
+func (r *Report) OK() bool { return len(r.Problems) == 0 }
+
+func (r *Report) add(kind ProblemKind, seq int64, id, format string, args ...interface{}) {
+	r.Problems = append(r.Problems, Problem{Kind: kind, Seq: seq, ID: id, Message: fmt.Sprintf(format, args...)})
+}
+
+// Verify walks the audit chain and every checkpoint. It recomputes each
+// row's hash, checks each prev_hash link and the sequence for gaps, and
+// verifies checkpoint signatures with the verifier registered for their
+// algorithm. Rows without a sequence are accepted only if they predate the
+// first chained row.
+//
+// Checkpoints must be signed by signerDID. The signer stored with a
+// checkpoint is not trusted on its own: anyone able to rewrite the chain
+// could re-sign it with a fresh key. With an empty signerDID every
+// checkpoint is reported as unverifiable.
+func Verify(ctx context.Context, client *ent.Client, signerDID string, verifiers map[string]SignatureVerifyFunc) (*Report, error) {
+	report := &Report{Signer: signerDID, Problems: []Problem{}}
+
+	checkpoints, err := client.AuditCheckpoint.Query().
+		Order(auditcheckpoint.BySeq(sql.OrderAsc())).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query audit checkpoints: %w", err)
+	}
+	// hashes collects the chain hash at each checkpointed seq.
+	hashes := make(map[int64]string, len(checkpoints))
+	wanted := make(map[int64]bool, len(checkpoints))
+	for _, cp := range checkpoints {
+		wanted[cp.Seq] = true
+	}
+
+	var (
+		prevSeq  int64
+		prevHash string
+		first    *ent.AuditLog
+	)
+	for {
+		rows, err := client.AuditLog.Query().
+			Where(auditlog.SeqGT(prevSeq)).
+			Order(auditlog.BySeq(sql.OrderAsc())).
+			Limit(verifyPageSize).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("query audit chain: %w", err)
+		}
+		for _, row := range rows {
+			seq, id := *row.Seq, row.ID.String()
+			if first == nil {
+				first = row
+			}
+
+			want, err := recordOf(row).Hash(row.PrevHash)
+			if err != nil {
+				return nil, fmt.Errorf("hash audit record %d: %w", seq, err)
+			}
+			if want != row.Hash {
+				report.add(ProblemModified, seq, id, "record content does not match its hash")
+			}
+			switch {
+			case seq != prevSeq+1 && prevSeq == 0:
+				report.add(ProblemGap, seq, id, "chain starts at seq %d; seq 1..%d missing", seq, seq-1)
+			case seq != prevSeq+1:
+				report.add(ProblemGap, seq, id, "seq %d..%d missing before this record", prevSeq+1, seq-1)
+			case row.PrevHash != prevHash:
+				report.add(ProblemBrokenLink, seq, id, "prev_hash does not match the hash of seq %d", prevSeq)
+			}
+
+			prevSeq, prevHash = seq, row.Hash
+			if wanted[seq] {
+				hashes[seq] = row.Hash
+			}
+			report.Records++
+		}
+		if len(rows) < verifyPageSize {
+			break
+		}
+	}
+	report.HeadSeq, report.HeadHash = prevSeq, prevHash
+
+	legacy := client.AuditLog.Query().Where(auditlog.SeqIsNil())
+	if first != nil {
+		unchained, err := legacy.Clone().
+			Where(auditlog.TimestampGTE(first.Timestamp)).
+			Order(auditlog.ByTimestamp()).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("query unchained audit rows: %w", err)
+		}
+		for _, row := range unchained {
+			report.add(ProblemUnchained, 0, row.ID.String(), "record written at %s without a chain position", row.Timestamp.UTC().Format(time.RFC3339))
+		}
+		legacy = legacy.Where(auditlog.TimestampLT(first.Timestamp))
+	}
+	if report.Legacy, err = legacy.Count(ctx); err != nil {
+		return nil, fmt.Errorf("count legacy audit rows: %w", err)
+	}
+
+	for _, cp := range checkpoints {
+		report.Checkpoints++
+		hash, ok := hashes[cp.Seq]
+		switch {
+		case !ok && cp.Seq > report.HeadSeq:
+			report.add(ProblemCheckpoint, cp.Seq, "", "checkpoint covers seq %d but the chain ends at seq %d", cp.Seq, report.HeadSeq)
+			continue
+		case !ok:
+			report.add(ProblemCheckpoint, cp.Seq, "", "checkpointed record is missing")
+			continue
+		case hash != cp.Hash:
+			report.add(ProblemCheckpoint, cp.Seq, "", "chain hash differs from the signed checkpoint")
+		}
+		if signerDID == "" {
+			report.add(ProblemCheckpoint, cp.Seq, "", "no trusted signer configured to verify checkpoint signed by %s", cp.Signer)
+			continue
+		}
+		if cp.Signer != signerDID {
+			report.add(ProblemCheckpoint, cp.Seq, "", "checkpoint signed by %s, expected %s", cp.Signer, signerDID)
+			continue
+		}
+		verify, ok := verifiers[cp.Algorithm]
+		if !ok {
+			report.add(ProblemCheckpoint, cp.Seq, "", "unsupported signature algorithm %q", cp.Algorithm)
+			continue
+		}
+		if err := verify(cp.Signer, CheckpointPayload(cp.Seq, cp.Hash), cp.Signature); err != nil {
+			report.add(ProblemCheckpoint, cp.Seq, "", "invalid signature by %s: %v", cp.Signer, err)
+		}
+	}
+	return report, nil
+}
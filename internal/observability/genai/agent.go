@@ -0,0 +1,104 @@
+package genai
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type scopeCtxKey struct{}
+
+// AgentScope tracks the agent span active during a multi-agent run.
+//
+// ADK runs every agent of a turn under the context given to the runner, so
+// spans started by sub-agents cannot be parented by context alone. The run
+// loop instead reports each delegation to the scope, and model and tool
+// spans pick their parent with ParentContext. Each delegation starts an
+// "invoke_agent" span under the root span, linked to the span of the agent
+// that delegated.
+type AgentScope struct {
+	tracer trace.Tracer
+
+	mu      sync.Mutex
+	root    trace.Span
+	current trace.Span
+	name    string
+}
+
+// StartAgent starts the root "invoke_agent" span of a run and returns a
+// context carrying the scope.
+func StartAgent(ctx context.Context, tracer trace.Tracer, agentName string) (context.Context, *AgentScope) {
+	ctx, span := tracer.Start(ctx, OpInvokeAgent+" "+agentName,
+		trace.WithAttributes(
+			AttrOperationName.String(OpInvokeAgent),
+			AttrAgentName.String(agentName),
+		),
+	)
+	s := &AgentScope{tracer: tracer, root: span, current: span, name: agentName}
+	return context.WithValue(ctx, scopeCtxKey{}, s), s
+}
+
+// Delegate records a transfer from one agent to another. The previous
+// delegated span, if any, is ended.
+func (s *AgentScope) Delegate(from, to string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rootCtx := trace.ContextWithSpan(context.Background(), s.root)
+	_, span := s.tracer.Start(rootCtx, OpInvokeAgent+" "+to,
+		trace.WithLinks(trace.Link{
+			SpanContext: s.current.SpanContext(),
+			Attributes:  []attribute.KeyValue{AttrAgentName.String(s.name)},
+		}),
+		trace.WithAttributes(
+			AttrOperationName.String(OpInvokeAgent),
+			AttrAgentName.String(to),
+			AttrDelegationFrom.String(from),
+		),
+	)
+	if s.current != s.root {
+		s.current.End()
+	}
+	s.current = span
+	s.name = to
+}
+
+// RecordError marks the active agent span and the root span as failed.
+func (s *AgentScope) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, span := range []trace.Span{s.current, s.root} {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if s.current == s.root {
+			break
+		}
+	}
+}
+
+// End ends the active delegated span and the root span.
+func (s *AgentScope) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current != s.root {
+		s.current.End()
+	}
+	s.root.End()
+}
+
+// ParentContext returns ctx with the active agent span of its scope as the
+// current span. Without a scope, ctx is returned unchanged.
+func ParentContext(ctx context.Context) context.Context {
+	s, ok := ctx.Value(scopeCtxKey{}).(*AgentScope)
+	if !ok {
+		return ctx
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return trace.ContextWithSpan(ctx, s.current)
+}
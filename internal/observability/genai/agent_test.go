@@ -0,0 +1,145 @@
+package genai_test
+
+import (
+	"context"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/langoai/lango/internal/agent"
+	"github.com/langoai/lango/internal/observability/genai"
+	"github.com/langoai/lango/internal/provider"
+	"github.com/langoai/lango/internal/toolchain"
+)
+
+type echoProvider struct{}
+
+func (echoProvider) ID() string { return "echo" }
+
+func (echoProvider) Generate(_ context.Context, _ provider.GenerateParams) (iter.Seq2[provider.StreamEvent, error], error) {
+	return func(yield func(provider.StreamEvent, error) bool) {
+		yield(provider.StreamEvent{Type: provider.StreamEventDone}, nil)
+	}, nil
+}
+
+func (echoProvider) ListModels(_ context.Context) ([]provider.ModelInfo, error) { return nil, nil }
+
+func chat(t *testing.T, ctx context.Context, p provider.Provider, model string) {
+	t.Helper()
+	seq, err := p.Generate(ctx, provider.GenerateParams{Model: model})
+	require.NoError(t, err)
+	for range seq {
+	}
+}
+
+// TestAgentScope_SpanTree runs an orchestrator turn that delegates to a
+// sub-agent and back, and checks the resulting span tree:
+//
+//	invoke_agent orchestrator
+//	├── chat orchestrator-model
+//	├── invoke_agent researcher   (link → orchestrator)
+//	│   ├── chat researcher-model
+//	│   └── execute_tool web_search
+//	└── invoke_agent orchestrator (link → researcher)
+//	    └── chat orchestrator-model
+func TestAgentScope_SpanTree(t *testing.T) {
+	t.Parallel()
+
+	rec := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer("test")
+
+	model := provider.WithTracing(echoProvider{}, "openai", tracer, genai.ContentCapture{})
+	search := toolchain.Chain(&agent.Tool{
+		Name: "web_search",
+		Handler: func(_ context.Context, _ map[string]interface{}) (interface{}, error) {
+			return "results", nil
+		},
+	}, toolchain.WithTracing(tracer, genai.ContentCapture{}))
+
+	// All calls use the run context, as ADK does for sub-agents.
+	ctx, scope := genai.StartAgent(context.Background(), tracer, "orchestrator")
+	chat(t, ctx, model, "orchestrator-model")
+	scope.Delegate("orchestrator", "researcher")
+	chat(t, ctx, model, "researcher-model")
+	_, err := search.Handler(ctx, map[string]interface{}{"q": "otel"})
+	require.NoError(t, err)
+	scope.Delegate("researcher", "orchestrator")
+	chat(t, ctx, model, "orchestrator-model")
+	scope.End()
+
+	spans := rec.Ended()
+	require.Len(t, spans, 7)
+
+	byName := func(name string) []sdktrace.ReadOnlySpan {
+		var out []sdktrace.ReadOnlySpan
+		for _, s := range spans {
+			if s.Name() == name {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+
+	var root sdktrace.ReadOnlySpan
+	for _, s := range byName("invoke_agent orchestrator") {
+		if !s.Parent().IsValid() {
+			root = s
+		}
+	}
+	require.NotNil(t, root, "root invoke_agent span")
+
+	researcher := byName("invoke_agent researcher")
+	require.Len(t, researcher, 1)
+	assert.Equal(t, root.SpanContext().SpanID(), researcher[0].Parent().SpanID())
+	require.Len(t, researcher[0].Links(), 1)
+	assert.Equal(t, root.SpanContext().SpanID(), researcher[0].Links()[0].SpanContext.SpanID())
+
+	var back sdktrace.ReadOnlySpan
+	for _, s := range byName("invoke_agent orchestrator") {
+		if s.Parent().IsValid() {
+			back = s
+		}
+	}
+	require.NotNil(t, back, "return delegation span")
+	assert.Equal(t, root.SpanContext().SpanID(), back.Parent().SpanID())
+	require.Len(t, back.Links(), 1)
+	assert.Equal(t, researcher[0].SpanContext().SpanID(), back.Links()[0].SpanContext.SpanID())
+
+	subModel := byName("chat researcher-model")
+	require.Len(t, subModel, 1)
+	assert.Equal(t, researcher[0].SpanContext().SpanID(), subModel[0].Parent().SpanID())
+
+	tool := byName("execute_tool web_search")
+	require.Len(t, tool, 1)
+	assert.Equal(t, researcher[0].SpanContext().SpanID(), tool[0].Parent().SpanID())
+
+	orchModel := byName("chat orchestrator-model")
+	require.Len(t, orchModel, 2)
+	parents := []string{orchModel[0].Parent().SpanID().String(), orchModel[1].Parent().SpanID().String()}
+	assert.ElementsMatch(t, []string{root.SpanContext().SpanID().String(), back.SpanContext().SpanID().String()}, parents)
+
+	for _, s := range spans {
+		assert.Equal(t, root.SpanContext().TraceID(), s.SpanContext().TraceID(), s.Name())
+	}
+}
+
+func TestParentContext_NoScope(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	assert.Equal(t, ctx, genai.ParentContext(ctx))
+}
+
+func TestHashArguments(t *testing.T) {
+	t.Parallel()
+
+	a := genai.HashArguments(map[string]interface{}{"a": 1, "b": "x"})
+	b := genai.HashArguments(map[string]interface{}{"b": "x", "a": 1})
+	assert.Equal(t, a, b)
+	assert.Len(t, a, 64)
+	assert.NotEqual(t, a, genai.HashArguments(map[string]interface{}{"a": 2, "b": "x"}))
+}
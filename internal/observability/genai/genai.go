@@ -0,0 +1,78 @@
+// Package genai holds the OpenTelemetry GenAI semantic-convention attributes
+// and helpers shared by the model, tool and agent spans. It depends only on
+// OpenTelemetry so that low-level packages (provider, toolchain, adk) can
+// emit spans without importing the observability subsystem.
+package genai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GenAI semantic-convention attribute keys.
+const (
+	AttrSystem             = attribute.Key("gen_ai.system")
+	AttrOperationName      = attribute.Key("gen_ai.operation.name")
+	AttrRequestModel       = attribute.Key("gen_ai.request.model")
+	AttrRequestTemperature = attribute.Key("gen_ai.request.temperature")
+	AttrRequestMaxTokens   = attribute.Key("gen_ai.request.max_tokens")
+	AttrUsageInputTokens   = attribute.Key("gen_ai.usage.input_tokens")
+	AttrUsageOutputTokens  = attribute.Key("gen_ai.usage.output_tokens")
+	AttrToolName           = attribute.Key("gen_ai.tool.name")
+	AttrToolCallID         = attribute.Key("gen_ai.tool.call.id")
+	AttrAgentName          = attribute.Key("gen_ai.agent.name")
+
+	// AttrPrompt and AttrCompletion carry captured content on the
+	// EventPrompt and EventCompletion span events.
+	AttrPrompt     = attribute.Key("gen_ai.prompt")
+	AttrCompletion = attribute.Key("gen_ai.completion")
+
+	// Lango-specific attributes.
+	AttrToolArgumentsHash = attribute.Key("lango.tool.arguments.sha256")
+	AttrToolArguments     = attribute.Key("lango.tool.arguments")
+	AttrDelegationFrom    = attribute.Key("lango.delegation.from")
+)
+
+// gen_ai.operation.name values.
+const (
+	OpChat        = "chat"
+	OpExecuteTool = "execute_tool"
+	OpInvokeAgent = "invoke_agent"
+)
+
+// Span event names for captured content.
+const (
+	EventPrompt     = "gen_ai.content.prompt"
+	EventCompletion = "gen_ai.content.completion"
+)
+
+// ContentCapture controls whether prompt, completion and tool argument
+// content is recorded on spans. Content is off by default; when enabled it
+// is passed through Redact (normally the PII redactor) first.
+type ContentCapture struct {
+	Enabled bool
+	Redact  func(string) string
+}
+
+// Text returns s as it may be recorded on a span. Callers must check
+// Enabled first.
+func (c ContentCapture) Text(s string) string {
+	if c.Redact == nil {
+		return s
+	}
+	return c.Redact(s)
+}
+
+// HashArguments returns the hex SHA-256 of the JSON encoding of params.
+// Map keys are encoded in sorted order, so equal arguments hash equally.
+func HashArguments(params map[string]interface{}) string {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,18 @@
+package arbitrationproto
+
+import "github.com/langoai/lango/internal/economy/escrow/arbitration"
+
+// ProtocolID is the libp2p protocol identifier for escrow arbitration.
+const ProtocolID = "/lango/arbitration/1.0.0"
+
+// Request is the envelope for arbitration protocol messages.
+type Request struct {
+	Token   string              `json:"token,omitempty"`
+	Message arbitration.Message `json:"message"`
+}
+
+// Response carries the recipient's reply or a protocol error.
+type Response struct {
+	Reply *arbitration.Reply `json:"reply,omitempty"`
+	Error string             `json:"error,omitempty"`
+}
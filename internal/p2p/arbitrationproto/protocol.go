@@ -0,0 +1,128 @@
+package arbitrationproto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"go.uber.org/zap"
+
+	"github.com/langoai/lango/internal/economy/escrow/arbitration"
+)
+
+// maxRequestSize bounds a request; evidence may carry provenance bundles.
+const maxRequestSize = 8 * 1024 * 1024
+
+// SessionValidator validates a session token and returns the peer DID.
+type SessionValidator func(token string) (string, bool)
+
+// MessageHandler processes an arbitration message from an authenticated peer.
+type MessageHandler func(ctx context.Context, peerDID string, msg arbitration.Message) (*arbitration.Reply, error)
+
+// Handler handles arbitration protocol streams.
+type Handler struct {
+	validator SessionValidator
+	handle    MessageHandler
+	logger    *zap.Logger
+}
+
+// HandlerConfig configures an arbitration protocol handler.
+type HandlerConfig struct {
+	Validator SessionValidator
+	Handle    MessageHandler
+	Logger    *zap.Logger
+}
+
+// NewHandler creates a new arbitration protocol handler.
+func NewHandler(cfg HandlerConfig) *Handler {
+	return &Handler{
+		validator: cfg.Validator,
+		handle:    cfg.Handle,
+		logger:    cfg.Logger,
+	}
+}
+
+// StreamHandler returns the libp2p stream handler function.
+func (h *Handler) StreamHandler() network.StreamHandler {
+	return func(s network.Stream) {
+		defer s.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		var req Request
+		if err := json.NewDecoder(io.LimitReader(s, maxRequestSize)).Decode(&req); err != nil {
+			h.writeError(s, "decode request: "+err.Error())
+			return
+		}
+
+		if h.validator == nil {
+			h.writeError(s, "session validator not configured")
+			return
+		}
+		peerDID, ok := h.validator(req.Token)
+		if !ok {
+			h.writeError(s, "invalid or expired session token")
+			return
+		}
+		if h.handle == nil {
+			h.writeError(s, "arbitration handler not configured")
+			return
+		}
+
+		reply, err := h.handle(ctx, peerDID, req.Message)
+		if err != nil {
+			h.writeError(s, err.Error())
+			return
+		}
+
+		if h.logger != nil {
+			h.logger.Info("arbitration message handled",
+				zap.String("peerDID", peerDID),
+				zap.String("type", string(req.Message.Type)),
+				zap.String("escrowID", req.Message.EscrowID),
+				zap.Bool("accepted", reply.Accepted))
+		}
+		_ = json.NewEncoder(s).Encode(&Response{Reply: reply})
+	}
+}
+
+func (h *Handler) writeError(s network.Stream, message string) {
+	_ = json.NewEncoder(s).Encode(&Response{Error: message})
+}
+
+// Send delivers an arbitration message to a remote peer and returns its reply.
+func Send(ctx context.Context, host host.Host, peerID peer.ID, token string, msg arbitration.Message) (*arbitration.Reply, error) {
+	stream, err := host.NewStream(ctx, peerID, protocol.ID(ProtocolID))
+	if err != nil {
+		return nil, fmt.Errorf("open arbitration stream: %w", err)
+	}
+	defer stream.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = stream.SetDeadline(deadline)
+	}
+
+	if err := json.NewEncoder(stream).Encode(&Request{Token: token, Message: msg}); err != nil {
+		return nil, fmt.Errorf("send arbitration request: %w", err)
+	}
+
+	var resp Response
+	if err := json.NewDecoder(stream).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("decode arbitration response: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	if resp.Reply == nil {
+		return nil, errors.New("empty arbitration response")
+	}
+	return resp.Reply, nil
+}
@@ -0,0 +1,54 @@
+package arbitrationproto
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/economy/escrow/arbitration"
+)
+
+func TestSendRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server, err := libp2p.New()
+	require.NoError(t, err)
+	defer server.Close()
+
+	client, err := libp2p.New()
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.NoError(t, client.Connect(ctx, peer.AddrInfo{
+		ID:    server.ID(),
+		Addrs: server.Addrs(),
+	}))
+
+	handler := NewHandler(HandlerConfig{
+		Validator: func(token string) (string, bool) {
+			return "did:lango:buyer", token == "token"
+		},
+		Handle: func(_ context.Context, peerDID string, msg arbitration.Message) (*arbitration.Reply, error) {
+			assert.Equal(t, "did:lango:buyer", peerDID)
+			assert.Equal(t, arbitration.MsgNominate, msg.Type)
+			return &arbitration.Reply{Accepted: msg.ArbiterDID == "did:lango:arb"}, nil
+		},
+	})
+	server.SetStreamHandler(ProtocolID, handler.StreamHandler())
+
+	reply, err := Send(ctx, client, server.ID(), "token", arbitration.Message{
+		Type: arbitration.MsgNominate, EscrowID: "esc-1", ArbiterDID: "did:lango:arb",
+	})
+	require.NoError(t, err)
+	assert.True(t, reply.Accepted)
+
+	_, err = Send(ctx, client, server.ID(), "bad", arbitration.Message{Type: arbitration.MsgNominate, EscrowID: "esc-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid or expired session token")
+}
@@ -83,6 +83,33 @@ type BundleResolver interface {
 	ResolveBundle(did string) (*IdentityBundle, error)
 }
 
+// ResolveDID parses a DID and populates its signing PublicKey and PeerID.
+// V1 DIDs carry the key inline; v2 DIDs are resolved through resolver, which
+// must already hold the peer's bundle (learned during handshake or gossip).
+func ResolveDID(didStr string, resolver BundleResolver) (*DID, error) {
+	did, err := ParseDID(didStr)
+	if err != nil {
+		return nil, err
+	}
+	if did.Version != 2 {
+		return did, nil
+	}
+	if resolver == nil {
+		return nil, fmt.Errorf("resolve DID v2 %q: no bundle resolver", didStr)
+	}
+	bundle, err := resolver.ResolveBundle(didStr)
+	if err != nil {
+		return nil, fmt.Errorf("resolve DID v2 %q: %w", didStr, err)
+	}
+	peerID, err := peerIDFromPublicKey(bundle.SigningKey.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("derive peer ID: %w", err)
+	}
+	did.PublicKey = bundle.SigningKey.PublicKey
+	did.PeerID = peerID
+	return did, nil
+}
+
 // MemoryBundleCache is a simple in-memory BundleResolver populated during
 // handshakes and gossip card exchanges.
 type MemoryBundleCache struct {
@@ -224,6 +224,39 @@ func TestDIDAlias_CanonicalDID(t *testing.T) {
 	assert.Equal(t, "did:lango:other", alias.CanonicalDID("did:lango:other"))
 }
 
+func TestResolveDID(t *testing.T) {
+	t.Parallel()
+
+	pub := generateTestPubkey(t)
+	v1, err := DIDFromPublicKey(pub)
+	require.NoError(t, err)
+
+	got, err := ResolveDID(v1.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, v1.PeerID, got.PeerID)
+
+	bundle := &IdentityBundle{
+		Version:    1,
+		SigningKey: PublicKeyEntry{Algorithm: "ed25519", PublicKey: make([]byte, 32)},
+	}
+	didV2, err := ComputeDIDv2(bundle)
+	require.NoError(t, err)
+
+	_, err = ResolveDID(didV2, nil)
+	assert.Error(t, err)
+
+	cache := NewMemoryBundleCache()
+	_, err = ResolveDID(didV2, cache)
+	assert.Error(t, err)
+
+	cache.Store(didV2, bundle)
+	got, err = ResolveDID(didV2, cache)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Version)
+	assert.Equal(t, bundle.SigningKey.PublicKey, got.PublicKey)
+	assert.NotEmpty(t, got.PeerID)
+}
+
 func TestParseDIDPublicKey_InvalidPrefix(t *testing.T) {
 	t.Parallel()
 	_, err := ParseDIDPublicKey("did:other:abc123")
@@ -64,6 +64,20 @@ func (l *DeferredLedger) Settle(id, txHash string) bool {
 	return true
 }
 
+// Remove drops an unsettled entry, e.g. when the paid invocation it covers
+// did not complete. Returns false if the entry does not exist or is settled.
+func (l *DeferredLedger) Remove(id string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[id]
+	if !ok || entry.Settled {
+		return false
+	}
+	delete(l.entries, id)
+	return true
+}
+
 // Pending returns all unsettled entries.
 func (l *DeferredLedger) Pending() []*DeferredEntry {
 	l.mu.Lock()
@@ -44,6 +44,20 @@ func TestDeferredLedger_Settle_NotFound(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestDeferredLedger_Remove(t *testing.T) {
+	t.Parallel()
+
+	l := NewDeferredLedger()
+	id := l.Add("did:peer:c", "tool-z", "0.25")
+	settled := l.Add("did:peer:c", "tool-z", "0.25")
+	require.True(t, l.Settle(settled, "0xdef"))
+
+	assert.True(t, l.Remove(id))
+	assert.Empty(t, l.Pending())
+	assert.False(t, l.Remove(id), "already removed")
+	assert.False(t, l.Remove(settled), "settled entries are kept")
+}
+
 func TestDeferredLedger_PendingByPeer(t *testing.T) {
 	t.Parallel()
 
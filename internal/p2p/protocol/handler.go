@@ -84,6 +84,7 @@ type Handler struct {
 	ontologyHandler OntologyHandler
 	localDID        string
 	logger          *zap.SugaredLogger
+	jobs            *JobManager
 
 	// Safety-level gate for P2P tool invocations.
 	safetyChecker  SafetyLevelChecker
@@ -99,6 +100,7 @@ type HandlerConfig struct {
 	CardFn   CardProvider
 	LocalDID string
 	Logger   *zap.SugaredLogger
+	Jobs     JobManagerConfig
 }
 
 // NewHandler creates a new A2A-over-P2P protocol handler.
@@ -110,6 +112,7 @@ func NewHandler(cfg HandlerConfig) *Handler {
 		cardFn:   cfg.CardFn,
 		localDID: cfg.LocalDID,
 		logger:   cfg.Logger,
+		jobs:     NewJobManager(cfg.Jobs),
 	}
 }
 
@@ -184,8 +187,15 @@ func (h *Handler) StreamHandler() network.StreamHandler {
 		}
 
 		resp := h.handleRequest(ctx, s, &req)
-		if err := json.NewEncoder(s).Encode(resp); err != nil {
+		enc := json.NewEncoder(s)
+		if err := enc.Encode(resp); err != nil {
 			h.logger.Warnw("encode response", "error", err)
+			return
+		}
+
+		// Job requests that asked for a stream keep it open for frames.
+		if jobID, afterSeq, ok := streamTarget(&req, resp); ok {
+			h.streamJob(enc, h.resolvePeerDID(s, req.SessionToken), jobID, afterSeq)
 		}
 	}
 }
@@ -222,6 +232,14 @@ func (h *Handler) handleRequest(ctx context.Context, s network.Stream, req *Requ
 		return h.handleSchemaQuery(ctx, req, peerDID)
 	case RequestSchemaPropose:
 		return h.handleSchemaPropose(ctx, req, peerDID)
+	case RequestToolInvokeAsync:
+		return h.handleToolInvokeAsync(ctx, req, peerDID)
+	case RequestJobStatus, RequestJobStream:
+		return h.handleJobStatus(req, peerDID)
+	case RequestJobCancel:
+		return h.handleJobCancel(req, peerDID)
+	case RequestJobResult:
+		return h.handleJobResult(req, peerDID)
 	default:
 		return &Response{
 			RequestID: req.RequestID,
@@ -379,21 +397,7 @@ func (h *Handler) handleToolInvoke(ctx context.Context, req *Request, peerDID st
 		Result:    result,
 		Timestamp: time.Now(),
 	}
-	if h.firewall != nil {
-		resultBytes, _ := json.Marshal(result)
-		hash := sha256.Sum256(resultBytes)
-		didHash := sha256.Sum256([]byte(h.localDID))
-		ar, _ := h.firewall.AttestResponse(hash[:], didHash[:])
-		if ar != nil {
-			resp.Attestation = &AttestationData{
-				Proof:        ar.Proof,
-				PublicInputs: ar.PublicInputs,
-				CircuitID:    ar.CircuitID,
-				Scheme:       ar.Scheme,
-			}
-			resp.AttestationProof = ar.Proof // backward compat
-		}
-	}
+	h.attest(resp)
 
 	return resp
 }
@@ -612,25 +616,32 @@ func (h *Handler) handleToolInvokePaid(ctx context.Context, req *Request, peerDI
 		Result:    result,
 		Timestamp: time.Now(),
 	}
-	if h.firewall != nil {
-		resultBytes, _ := json.Marshal(result)
-		hash := sha256.Sum256(resultBytes)
-		didHash := sha256.Sum256([]byte(h.localDID))
-		ar, _ := h.firewall.AttestResponse(hash[:], didHash[:])
-		if ar != nil {
-			paidResp.Attestation = &AttestationData{
-				Proof:        ar.Proof,
-				PublicInputs: ar.PublicInputs,
-				CircuitID:    ar.CircuitID,
-				Scheme:       ar.Scheme,
-			}
-			paidResp.AttestationProof = ar.Proof // backward compat
-		}
-	}
+	h.attest(paidResp)
 
 	return paidResp
 }
 
+// attest attaches a ZK attestation of the response result when the firewall
+// has an attestation prover configured.
+func (h *Handler) attest(resp *Response) {
+	if h.firewall == nil {
+		return
+	}
+	resultBytes, _ := json.Marshal(resp.Result)
+	hash := sha256.Sum256(resultBytes)
+	didHash := sha256.Sum256([]byte(h.localDID))
+	ar, _ := h.firewall.AttestResponse(hash[:], didHash[:])
+	if ar != nil {
+		resp.Attestation = &AttestationData{
+			Proof:        ar.Proof,
+			PublicInputs: ar.PublicInputs,
+			CircuitID:    ar.CircuitID,
+			Scheme:       ar.Scheme,
+		}
+		resp.AttestationProof = ar.Proof // backward compat
+	}
+}
+
 // resolvePeerDID validates the session token and returns the peer DID.
 func (h *Handler) resolvePeerDID(s network.Stream, token string) string {
 	if h.sessions == nil {
@@ -0,0 +1,375 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/langoai/lango/internal/ctxkeys"
+	"github.com/langoai/lango/internal/eventbus"
+)
+
+// PayGateReleaser is optionally implemented by a PayGateChecker to drop a
+// deferred post-pay obligation when the paid job does not complete.
+type PayGateReleaser interface {
+	Release(settlementID string)
+}
+
+// handleToolInvokeAsync validates a tool invocation exactly like the
+// synchronous path, then runs it as a background job and returns its ID.
+// Payment is settled only when the job completes; the firewall sanitizes and
+// attests the final result.
+func (h *Handler) handleToolInvokeAsync(ctx context.Context, req *Request, peerDID string) *Response {
+	ctx = ctxkeys.WithP2PRequest(ctx)
+
+	toolName, _ := req.Payload["toolName"].(string)
+	if toolName == "" {
+		return &Response{
+			RequestID: req.RequestID,
+			Status:    ResponseStatusError,
+			Error:     ErrMissingToolName.Error(),
+			Timestamp: time.Now(),
+		}
+	}
+
+	// 1. Firewall ACL check.
+	if h.firewall != nil {
+		if err := h.firewall.FilterQuery(ctx, peerDID, toolName); err != nil {
+			return &Response{
+				RequestID: req.RequestID,
+				Status:    ResponseStatusDenied,
+				Error:     err.Error(),
+				Timestamp: time.Now(),
+			}
+		}
+	}
+
+	// 2. Safety-level gate.
+	if !h.checkSafetyGate(toolName) {
+		return &Response{
+			RequestID: req.RequestID,
+			Status:    ResponseStatusDenied,
+			Error:     ErrToolSafetyBlocked.Error(),
+			Timestamp: time.Now(),
+		}
+	}
+
+	// 3. Payment gate check for paid jobs. Authorizations are verified up
+	// front but only settled after the job completes.
+	paid, _ := req.Payload["paid"].(bool)
+	var verifiedAuth interface{}
+	var settlementID string
+	if paid && h.payGate != nil {
+		pgResult, err := h.payGate.Check(peerDID, toolName, req.Payload)
+		if err != nil {
+			return &Response{
+				RequestID: req.RequestID,
+				Status:    ResponseStatusError,
+				Error:     fmt.Sprintf("payment check %s: %v", toolName, err),
+				Timestamp: time.Now(),
+			}
+		}
+
+		switch pgResult.Status {
+		case payGateStatusPaymentRequired:
+			return &Response{
+				RequestID: req.RequestID,
+				Status:    ResponseStatusPaymentRequired,
+				Result:    pgResult.PriceQuote,
+				Timestamp: time.Now(),
+			}
+		case payGateStatusInvalid:
+			return &Response{
+				RequestID: req.RequestID,
+				Status:    ResponseStatusError,
+				Error:     ErrInvalidPaymentAuth.Error(),
+				Timestamp: time.Now(),
+			}
+		case payGateStatusPostPayApproved:
+			settlementID = pgResult.SettlementID
+		case payGateStatusVerified:
+			verifiedAuth = pgResult.Auth
+		case payGateStatusFree:
+		}
+	}
+
+	// 4. Owner approval check (default-deny when no approval handler is configured).
+	params, _ := req.Payload["params"].(map[string]interface{})
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	if h.approvalFn == nil {
+		h.releasePayment(settlementID)
+		return &Response{
+			RequestID: req.RequestID,
+			Status:    ResponseStatusDenied,
+			Error:     ErrNoApprovalHandler.Error(),
+			Timestamp: time.Now(),
+		}
+	}
+	approved, err := h.approvalFn(ctx, peerDID, toolName, params)
+	if err != nil {
+		h.releasePayment(settlementID)
+		return &Response{
+			RequestID: req.RequestID,
+			Status:    ResponseStatusError,
+			Error:     fmt.Sprintf("approval check: %v", err),
+			Timestamp: time.Now(),
+		}
+	}
+	if !approved {
+		h.releasePayment(settlementID)
+		return &Response{
+			RequestID: req.RequestID,
+			Status:    ResponseStatusDenied,
+			Error:     ErrDeniedByOwner.Error(),
+			Timestamp: time.Now(),
+		}
+	}
+
+	// 5. Sandbox executor only — refuse in-process fallback for remote peers.
+	if h.sandboxExec == nil {
+		h.releasePayment(settlementID)
+		return &Response{
+			RequestID: req.RequestID,
+			Status:    ResponseStatusDenied,
+			Error:     ErrNoSandboxExecutor.Error(),
+			Timestamp: time.Now(),
+		}
+	}
+
+	requestID := req.RequestID
+	jobID, err := h.jobs.Start(peerDID, toolName, func(jobCtx context.Context) *Response {
+		jobCtx = withSandboxEvents(ctxkeys.WithP2PRequest(jobCtx))
+
+		result, err := h.sandboxExec(jobCtx, toolName, params)
+		if jobCtx.Err() != nil {
+			h.releasePayment(settlementID)
+			return &Response{
+				RequestID: requestID,
+				Status:    ResponseStatusError,
+				Error:     "job cancelled",
+				Timestamp: time.Now(),
+			}
+		}
+		if err != nil {
+			h.releasePayment(settlementID)
+			if h.securityEvents != nil {
+				h.securityEvents.RecordToolFailure(peerDID)
+			}
+			return &Response{
+				RequestID: requestID,
+				Status:    ResponseStatusError,
+				Error:     err.Error(),
+				Timestamp: time.Now(),
+			}
+		}
+
+		if h.securityEvents != nil {
+			h.securityEvents.RecordToolSuccess(peerDID)
+		}
+
+		if h.eventBus != nil && (verifiedAuth != nil || settlementID != "") {
+			h.eventBus.Publish(eventbus.ToolExecutionPaidEvent{
+				PeerDID:      peerDID,
+				ToolName:     toolName,
+				Auth:         verifiedAuth,
+				SettlementID: settlementID,
+			})
+		}
+
+		if h.firewall != nil {
+			result = h.firewall.SanitizeResponse(result)
+		}
+
+		resp := &Response{
+			RequestID: requestID,
+			Status:    ResponseStatusOK,
+			Result:    result,
+			Timestamp: time.Now(),
+		}
+		h.attest(resp)
+		return resp
+	})
+	if err != nil {
+		h.releasePayment(settlementID)
+		return jobErrorResponse(req, err)
+	}
+
+	return &Response{
+		RequestID: req.RequestID,
+		Status:    ResponseStatusOK,
+		Result: map[string]interface{}{
+			"jobId":  jobID,
+			"status": string(JobStatusRunning),
+		},
+		Timestamp: time.Now(),
+	}
+}
+
+// releasePayment drops a deferred post-pay obligation that will not be
+// settled because the invocation did not complete.
+func (h *Handler) releasePayment(settlementID string) {
+	if settlementID == "" {
+		return
+	}
+	if r, ok := h.payGate.(PayGateReleaser); ok {
+		r.Release(settlementID)
+	}
+}
+
+// handleJobStatus returns a job snapshot. It also serves job_stream, whose
+// frames follow the snapshot on the same stream.
+func (h *Handler) handleJobStatus(req *Request, peerDID string) *Response {
+	jobID, _ := req.Payload["jobId"].(string)
+	if jobID == "" {
+		return jobErrorResponse(req, ErrMissingJobID)
+	}
+
+	info, err := h.jobs.Status(jobID, peerDID)
+	if err != nil {
+		return jobErrorResponse(req, err)
+	}
+
+	return &Response{
+		RequestID: req.RequestID,
+		Status:    ResponseStatusOK,
+		Result:    jobInfoMap(info),
+		Timestamp: time.Now(),
+	}
+}
+
+// handleJobCancel cancels a running job.
+func (h *Handler) handleJobCancel(req *Request, peerDID string) *Response {
+	jobID, _ := req.Payload["jobId"].(string)
+	if jobID == "" {
+		return jobErrorResponse(req, ErrMissingJobID)
+	}
+
+	if err := h.jobs.Cancel(jobID, peerDID); err != nil {
+		return jobErrorResponse(req, err)
+	}
+
+	return &Response{
+		RequestID: req.RequestID,
+		Status:    ResponseStatusOK,
+		Result:    map[string]interface{}{"jobId": jobID, "cancelled": true},
+		Timestamp: time.Now(),
+	}
+}
+
+// handleJobResult returns the final response of a finished job, or a
+// pending response carrying the job snapshot while it still runs.
+func (h *Handler) handleJobResult(req *Request, peerDID string) *Response {
+	jobID, _ := req.Payload["jobId"].(string)
+	if jobID == "" {
+		return jobErrorResponse(req, ErrMissingJobID)
+	}
+
+	final, info, err := h.jobs.Result(jobID, peerDID)
+	if err != nil {
+		return jobErrorResponse(req, err)
+	}
+	if final == nil {
+		return &Response{
+			RequestID: req.RequestID,
+			Status:    ResponseStatusPending,
+			Result:    jobInfoMap(info),
+			Timestamp: time.Now(),
+		}
+	}
+
+	resp := *final
+	resp.RequestID = req.RequestID
+	return &resp
+}
+
+// streamTarget reports whether a handled request continues as a job frame
+// stream, and from which sequence number.
+func streamTarget(req *Request, resp *Response) (jobID string, afterSeq int, ok bool) {
+	if resp.Status != ResponseStatusOK {
+		return "", 0, false
+	}
+	switch req.Type {
+	case RequestToolInvokeAsync:
+		if stream, _ := req.Payload["stream"].(bool); !stream {
+			return "", 0, false
+		}
+		jobID, _ = resp.Result["jobId"].(string)
+	case RequestJobStream:
+		jobID, _ = req.Payload["jobId"].(string)
+		if f, isNum := req.Payload["afterSeq"].(float64); isNum {
+			afterSeq = int(f)
+		}
+	default:
+		return "", 0, false
+	}
+	return jobID, afterSeq, jobID != ""
+}
+
+// streamJob writes job frames after afterSeq until the final frame or until
+// the peer goes away. The job keeps running if the stream breaks.
+func (h *Handler) streamJob(enc *json.Encoder, peerDID, jobID string, afterSeq int) {
+	replay, ch, unsubscribe, err := h.jobs.Subscribe(jobID, peerDID, afterSeq)
+	if err != nil {
+		return
+	}
+	defer unsubscribe()
+
+	last := afterSeq
+	for _, f := range replay {
+		if err := enc.Encode(f); err != nil {
+			return
+		}
+		last = f.Seq
+	}
+	if ch == nil {
+		return
+	}
+
+	for f := range ch {
+		if f.Seq <= last {
+			continue
+		}
+		if err := enc.Encode(f); err != nil {
+			h.logger.Debugw("job stream closed", "jobId", jobID, "error", err)
+			return
+		}
+		last = f.Seq
+		if f.Type == JobFrameFinal {
+			return
+		}
+	}
+
+	// The live channel may have dropped frames for a slow reader; flush
+	// whatever remains, including the final frame, from the replay buffer.
+	rest, _, _, err := h.jobs.Subscribe(jobID, peerDID, last)
+	if err != nil {
+		return
+	}
+	for _, f := range rest {
+		if err := enc.Encode(f); err != nil {
+			return
+		}
+	}
+}
+
+// jobErrorResponse builds an error response for a failed job request.
+func jobErrorResponse(req *Request, err error) *Response {
+	return &Response{
+		RequestID: req.RequestID,
+		Status:    ResponseStatusError,
+		Error:     err.Error(),
+		Timestamp: time.Now(),
+	}
+}
+
+// jobInfoMap converts a job snapshot to a response result map.
+func jobInfoMap(info JobInfo) map[string]interface{} {
+	result := map[string]interface{}{}
+	raw, _ := json.Marshal(info)
+	_ = json.Unmarshal(raw, &result)
+	return result
+}
@@ -0,0 +1,388 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/langoai/lango/internal/eventbus"
+	"github.com/langoai/lango/internal/sandbox"
+)
+
+// postPayGate approves every call as post-pay and records released entries.
+type postPayGate struct {
+	mu       sync.Mutex
+	released []string
+}
+
+func (g *postPayGate) Check(_, _ string, _ map[string]interface{}) (PayGateResult, error) {
+	return PayGateResult{Status: payGateStatusPostPayApproved, SettlementID: "settle-1"}, nil
+}
+
+func (g *postPayGate) Release(settlementID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.released = append(g.released, settlementID)
+}
+
+func (g *postPayGate) Released() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.released...)
+}
+
+func approveAll(_ context.Context, _, _ string, _ map[string]interface{}) (bool, error) {
+	return true, nil
+}
+
+func startAsyncJob(t *testing.T, h *Handler, token string, payload map[string]interface{}) string {
+	t.Helper()
+	resp := h.handleRequest(context.Background(), nil, &Request{
+		Type:         RequestToolInvokeAsync,
+		SessionToken: token,
+		RequestID:    "req-async",
+		Payload:      payload,
+	})
+	require.Equal(t, ResponseStatusOK, resp.Status, resp.Error)
+	jobID, _ := resp.Result["jobId"].(string)
+	require.NotEmpty(t, jobID)
+	return jobID
+}
+
+func jobRequest(h *Handler, token string, reqType RequestType, jobID string) *Response {
+	return h.handleRequest(context.Background(), nil, &Request{
+		Type:         reqType,
+		SessionToken: token,
+		RequestID:    "req-job",
+		Payload:      map[string]interface{}{"jobId": jobID},
+	})
+}
+
+func TestHandleToolInvokeAsync_ResultAfterCompletion(t *testing.T) {
+	t.Parallel()
+
+	h, sessions := testHandler()
+	h.SetApprovalFunc(approveAll)
+	release := make(chan struct{})
+	h.SetSandboxExecutor(func(ctx context.Context, toolName string, _ map[string]interface{}) (map[string]interface{}, error) {
+		ReportProgress(ctx, 0.1, "started")
+		<-release
+		return map[string]interface{}{"tool": toolName}, nil
+	})
+
+	token := createSession(sessions, "did:key:peer-1")
+	jobID := startAsyncJob(t, h, token, map[string]interface{}{"toolName": "slow"})
+
+	resp := jobRequest(h, token, RequestJobResult, jobID)
+	assert.Equal(t, ResponseStatusPending, resp.Status)
+
+	status := jobRequest(h, token, RequestJobStatus, jobID)
+	require.Equal(t, ResponseStatusOK, status.Status)
+	assert.Equal(t, string(JobStatusRunning), status.Result["status"])
+
+	close(release)
+	require.Eventually(t, func() bool {
+		return jobRequest(h, token, RequestJobResult, jobID).Status == ResponseStatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	resp = jobRequest(h, token, RequestJobResult, jobID)
+	assert.Equal(t, "slow", resp.Result["tool"])
+	assert.Equal(t, "req-job", resp.RequestID)
+}
+
+func TestHandleToolInvokeAsync_ChecksRunBeforeJob(t *testing.T) {
+	t.Parallel()
+
+	h, sessions := testHandler()
+	// No approval handler: the request is denied without creating a job.
+	h.SetSandboxExecutor(testSandboxExecutor())
+
+	token := createSession(sessions, "did:key:peer-1")
+	resp := h.handleRequest(context.Background(), nil, &Request{
+		Type:         RequestToolInvokeAsync,
+		SessionToken: token,
+		RequestID:    "req-denied",
+		Payload:      map[string]interface{}{"toolName": "echo"},
+	})
+	assert.Equal(t, ResponseStatusDenied, resp.Status)
+	assert.Equal(t, ErrNoApprovalHandler.Error(), resp.Error)
+	assert.Nil(t, resp.Result)
+}
+
+func TestHandleJobRequests_OtherPeerCannotAccess(t *testing.T) {
+	t.Parallel()
+
+	h, sessions := testHandler()
+	h.SetApprovalFunc(approveAll)
+	release := make(chan struct{})
+	defer close(release)
+	h.SetSandboxExecutor(func(_ context.Context, _ string, _ map[string]interface{}) (map[string]interface{}, error) {
+		<-release
+		return nil, nil
+	})
+
+	owner := createSession(sessions, "did:key:peer-1")
+	other := createSession(sessions, "did:key:peer-2")
+	jobID := startAsyncJob(t, h, owner, map[string]interface{}{"toolName": "slow"})
+
+	for _, reqType := range []RequestType{RequestJobStatus, RequestJobCancel, RequestJobResult} {
+		resp := jobRequest(h, other, reqType, jobID)
+		assert.Equal(t, ResponseStatusError, resp.Status, reqType)
+		assert.Contains(t, resp.Error, ErrJobNotFound.Error(), reqType)
+	}
+}
+
+func TestHandleToolInvokeAsync_ForwardsSandboxEvents(t *testing.T) {
+	t.Parallel()
+
+	h, sessions := testHandler()
+	h.SetApprovalFunc(approveAll)
+	// Stands in for the subprocess executor, which emits each event line its
+	// worker writes.
+	h.SetSandboxExecutor(func(ctx context.Context, _ string, _ map[string]interface{}) (map[string]interface{}, error) {
+		sandbox.Emit(ctx, sandbox.ExecutionEvent{Progress: 0.5, Message: "halfway"})
+		sandbox.Emit(ctx, sandbox.ExecutionEvent{Output: "line 1\n"})
+		return map[string]interface{}{"done": true}, nil
+	})
+
+	token := createSession(sessions, "did:key:peer-1")
+	jobID := startAsyncJob(t, h, token, map[string]interface{}{"toolName": "slow"})
+	require.Eventually(t, func() bool {
+		return jobRequest(h, token, RequestJobResult, jobID).Status == ResponseStatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	frames, _, _, err := h.jobs.Subscribe(jobID, "did:key:peer-1", 0)
+	require.NoError(t, err)
+	require.Len(t, frames, 3)
+	assert.Equal(t, JobFrameProgress, frames[0].Type)
+	assert.Equal(t, 0.5, frames[0].Progress)
+	assert.Equal(t, "halfway", frames[0].Message)
+	assert.Equal(t, JobFrameOutput, frames[1].Type)
+	assert.Equal(t, "line 1\n", frames[1].Output)
+	assert.Equal(t, JobFrameFinal, frames[2].Type)
+}
+
+func TestHandleToolInvokeAsync_PeerJobLimit(t *testing.T) {
+	t.Parallel()
+
+	h, sessions := testHandler()
+	h.SetApprovalFunc(approveAll)
+	release := make(chan struct{})
+	defer close(release)
+	h.SetSandboxExecutor(func(_ context.Context, _ string, _ map[string]interface{}) (map[string]interface{}, error) {
+		<-release
+		return nil, nil
+	})
+
+	token := createSession(sessions, "did:key:peer-1")
+	for i := 0; i < 4; i++ {
+		startAsyncJob(t, h, token, map[string]interface{}{"toolName": "slow"})
+	}
+
+	resp := h.handleRequest(context.Background(), nil, &Request{
+		Type:         RequestToolInvokeAsync,
+		SessionToken: token,
+		RequestID:    "req-excess",
+		Payload:      map[string]interface{}{"toolName": "slow", "stream": true},
+	})
+	assert.Equal(t, ResponseStatusError, resp.Status)
+	assert.Contains(t, resp.Error, ErrTooManyJobs.Error())
+	assert.Nil(t, resp.Result)
+
+	// Another peer still has its own allowance.
+	other := createSession(sessions, "did:key:peer-2")
+	startAsyncJob(t, h, other, map[string]interface{}{"toolName": "slow"})
+}
+
+func TestHandleToolInvokeAsync_PaidChargesOnlyOnCompletion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give        string
+		exec        ToolExecutor
+		cancel      bool
+		wantPaid    bool
+		wantRelease bool
+	}{
+		{
+			give: "completed",
+			exec: func(_ context.Context, _ string, _ map[string]interface{}) (map[string]interface{}, error) {
+				return map[string]interface{}{"ok": true}, nil
+			},
+			wantPaid: true,
+		},
+		{
+			give: "failed",
+			exec: func(_ context.Context, _ string, _ map[string]interface{}) (map[string]interface{}, error) {
+				return nil, errors.New("boom")
+			},
+			wantRelease: true,
+		},
+		{
+			give: "cancelled",
+			exec: func(ctx context.Context, _ string, _ map[string]interface{}) (map[string]interface{}, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+			cancel:      true,
+			wantRelease: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+
+			h, sessions := testHandler()
+			h.SetApprovalFunc(approveAll)
+			h.SetSandboxExecutor(tt.exec)
+			gate := &postPayGate{}
+			h.SetPayGate(gate)
+
+			bus := eventbus.New()
+			var mu sync.Mutex
+			var paid []eventbus.ToolExecutionPaidEvent
+			eventbus.SubscribeTyped(bus, func(e eventbus.ToolExecutionPaidEvent) {
+				mu.Lock()
+				defer mu.Unlock()
+				paid = append(paid, e)
+			})
+			h.SetEventBus(bus)
+
+			token := createSession(sessions, "did:key:peer-1")
+			jobID := startAsyncJob(t, h, token, map[string]interface{}{"toolName": "priced", "paid": true})
+			if tt.cancel {
+				require.Equal(t, ResponseStatusOK, jobRequest(h, token, RequestJobCancel, jobID).Status)
+			}
+
+			require.Eventually(t, func() bool {
+				return jobRequest(h, token, RequestJobResult, jobID).Status != ResponseStatusPending
+			}, 5*time.Second, 10*time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if tt.wantPaid {
+				require.Len(t, paid, 1)
+				assert.Equal(t, "settle-1", paid[0].SettlementID)
+			} else {
+				assert.Empty(t, paid)
+			}
+			if tt.wantRelease {
+				assert.Equal(t, []string{"settle-1"}, gate.Released())
+			} else {
+				assert.Empty(t, gate.Released())
+			}
+		})
+	}
+}
+
+func TestRemoteAgent_JobStreamOverLibp2p(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	server, err := libp2p.New()
+	require.NoError(t, err)
+	defer server.Close()
+	client, err := libp2p.New()
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.Connect(ctx, peer.AddrInfo{ID: server.ID(), Addrs: server.Addrs()}))
+
+	h, sessions := testHandler()
+	h.SetApprovalFunc(approveAll)
+	h.SetSandboxExecutor(func(ctx context.Context, toolName string, params map[string]interface{}) (map[string]interface{}, error) {
+		if toolName == "wait" {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		ReportProgress(ctx, 0.5, "working")
+		ReportOutput(ctx, "chunk")
+		return map[string]interface{}{"echo": params["v"]}, nil
+	})
+	server.SetStreamHandler(ProtocolID, h.StreamHandler())
+
+	logger, _ := zap.NewDevelopment()
+	agent := NewRemoteAgent(RemoteAgentConfig{
+		Name:         "server",
+		DID:          "did:key:peer-1",
+		PeerID:       server.ID(),
+		SessionToken: createSession(sessions, "did:key:peer-1"),
+		Host:         client,
+		Logger:       logger.Sugar(),
+	})
+
+	t.Run("InvokeTool streams frames to completion", func(t *testing.T) {
+		var frames []JobFrame
+		resp, err := agent.InvokeToolStream(ctx, "echo", map[string]interface{}{"v": "x"}, func(f JobFrame) {
+			frames = append(frames, f)
+		})
+		require.NoError(t, err)
+		require.Equal(t, ResponseStatusOK, resp.Status)
+		assert.Equal(t, "x", resp.Result["echo"])
+
+		require.Len(t, frames, 2)
+		assert.Equal(t, JobFrameProgress, frames[0].Type)
+		assert.Equal(t, "working", frames[0].Message)
+		assert.Equal(t, JobFrameOutput, frames[1].Type)
+		assert.Equal(t, "chunk", frames[1].Output)
+
+		result, err := agent.InvokeTool(ctx, "echo", map[string]interface{}{"v": "y"})
+		require.NoError(t, err)
+		assert.Equal(t, "y", result["echo"])
+	})
+
+	t.Run("result retrieval after reconnect", func(t *testing.T) {
+		jobID, err := agent.InvokeToolAsync(ctx, "echo", map[string]interface{}{"v": "z"})
+		require.NoError(t, err)
+
+		resp, err := agent.StreamJob(ctx, jobID, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "z", resp.Result["echo"])
+
+		resp, err = agent.JobResult(ctx, jobID)
+		require.NoError(t, err)
+		assert.Equal(t, ResponseStatusOK, resp.Status)
+
+		info, err := agent.JobStatus(ctx, jobID)
+		require.NoError(t, err)
+		assert.Equal(t, JobStatusCompleted, info.Status)
+	})
+
+	t.Run("caller cancellation cancels the remote job", func(t *testing.T) {
+		callCtx, callCancel := context.WithCancel(ctx)
+		done := make(chan error, 1)
+		go func() {
+			_, err := agent.InvokeTool(callCtx, "wait", nil)
+			done <- err
+		}()
+
+		var jobID string
+		require.Eventually(t, func() bool {
+			h.jobs.mu.Lock()
+			defer h.jobs.mu.Unlock()
+			for id, j := range h.jobs.jobs {
+				if j.toolName == "wait" {
+					jobID = id
+					return true
+				}
+			}
+			return false
+		}, 5*time.Second, 10*time.Millisecond)
+
+		callCancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+
+		require.Eventually(t, func() bool {
+			info, err := h.jobs.Status(jobID, "did:key:peer-1")
+			return err == nil && info.Status == JobStatusCancelled
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
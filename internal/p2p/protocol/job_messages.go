@@ -0,0 +1,141 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/langoai/lango/internal/sandbox"
+)
+
+// Job-oriented request types for long-running tool invocations.
+const (
+	// RequestToolInvokeAsync starts a tool invocation as a background job and
+	// returns its job ID. With payload "stream": true the handler keeps the
+	// stream open and writes JobFrames until the job finishes.
+	RequestToolInvokeAsync RequestType = "tool_invoke_async"
+
+	// RequestJobStatus returns a snapshot of a job.
+	RequestJobStatus RequestType = "job_status"
+
+	// RequestJobCancel cancels a running job.
+	RequestJobCancel RequestType = "job_cancel"
+
+	// RequestJobResult returns the final response of a finished job, or a
+	// pending response while it is still running.
+	RequestJobResult RequestType = "job_result"
+
+	// RequestJobStream re-attaches to a job's frame stream, replaying frames
+	// after payload "afterSeq".
+	RequestJobStream RequestType = "job_stream"
+)
+
+// Sentinel errors for job requests.
+var (
+	ErrMissingJobID = errors.New("missing jobId in payload")
+	ErrJobNotFound  = errors.New("job not found")
+	ErrJobFinished  = errors.New("job already finished")
+	ErrTooManyJobs  = errors.New("too many concurrent jobs for peer")
+)
+
+// JobStatus is the lifecycle state of a remote job.
+type JobStatus string
+
+const (
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+// Terminal reports whether the status is final.
+func (s JobStatus) Terminal() bool {
+	return s == JobStatusCompleted || s == JobStatusFailed || s == JobStatusCancelled
+}
+
+// JobFrameType identifies a streamed job frame.
+type JobFrameType string
+
+const (
+	// JobFrameProgress reports progress or a liveness heartbeat.
+	JobFrameProgress JobFrameType = "progress"
+	// JobFrameOutput carries a chunk of partial output.
+	JobFrameOutput JobFrameType = "output"
+	// JobFrameFinal carries the final Response; no frames follow it.
+	JobFrameFinal JobFrameType = "final"
+)
+
+// JobFrame is one message in a job's frame stream. Seq increases by one per
+// frame so a reconnecting client can resume with afterSeq.
+type JobFrame struct {
+	JobID     string       `json:"jobId"`
+	Seq       int          `json:"seq"`
+	Type      JobFrameType `json:"type"`
+	Status    JobStatus    `json:"status"`
+	Progress  float64      `json:"progress,omitempty"` // 0..1, 0 when unknown
+	Message   string       `json:"message,omitempty"`
+	Output    string       `json:"output,omitempty"`
+	Response  *Response    `json:"response,omitempty"` // JobFrameFinal only
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// JobInfo is a point-in-time view of a job returned by job_status.
+type JobInfo struct {
+	JobID     string    `json:"jobId"`
+	ToolName  string    `json:"toolName"`
+	Status    JobStatus `json:"status"`
+	Progress  float64   `json:"progress,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	LastSeq   int       `json:"lastSeq"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// JobReporter lets a running tool report progress and partial output.
+type JobReporter interface {
+	Progress(fraction float64, message string)
+	Output(chunk string)
+}
+
+type jobReporterKey struct{}
+
+// WithJobReporter attaches a job reporter to ctx.
+func WithJobReporter(ctx context.Context, r JobReporter) context.Context {
+	return context.WithValue(ctx, jobReporterKey{}, r)
+}
+
+// ReportProgress reports job progress when ctx belongs to a remote job.
+// Inside a sandbox worker the report is streamed to the parent, which
+// forwards it to the job. It is a no-op otherwise, so tools may call it
+// unconditionally.
+func ReportProgress(ctx context.Context, fraction float64, message string) {
+	if r, ok := ctx.Value(jobReporterKey{}).(JobReporter); ok {
+		r.Progress(fraction, message)
+		return
+	}
+	sandbox.Emit(ctx, sandbox.ExecutionEvent{Progress: fraction, Message: message})
+}
+
+// ReportOutput streams a chunk of partial output when ctx belongs to a
+// remote job, directly or through a sandbox worker. It is a no-op
+// otherwise.
+func ReportOutput(ctx context.Context, chunk string) {
+	if r, ok := ctx.Value(jobReporterKey{}).(JobReporter); ok {
+		r.Output(chunk)
+		return
+	}
+	sandbox.Emit(ctx, sandbox.ExecutionEvent{Output: chunk})
+}
+
+// withSandboxEvents forwards the events a sandboxed tool streams back to
+// the job reporter in ctx.
+func withSandboxEvents(ctx context.Context) context.Context {
+	return sandbox.WithEventFunc(ctx, func(ev sandbox.ExecutionEvent) {
+		if ev.Output != "" {
+			ReportOutput(ctx, ev.Output)
+		}
+		if ev.Progress > 0 || ev.Message != "" {
+			ReportProgress(ctx, ev.Progress, ev.Message)
+		}
+	})
+}
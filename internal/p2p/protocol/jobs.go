@@ -0,0 +1,339 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobRunFunc executes a job and returns its final response.
+type JobRunFunc func(ctx context.Context) *Response
+
+// job is a single background tool invocation.
+type job struct {
+	mu        sync.Mutex
+	id        string
+	peerDID   string
+	toolName  string
+	status    JobStatus
+	progress  float64
+	message   string
+	frames    []JobFrame // bounded replay buffer
+	seq       int
+	final     *Response
+	createdAt time.Time
+	updatedAt time.Time
+	cancel    context.CancelFunc
+	subs      map[chan JobFrame]struct{}
+	done      chan struct{}
+	maxFrames int
+}
+
+func (j *job) info() JobInfo {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return JobInfo{
+		JobID:     j.id,
+		ToolName:  j.toolName,
+		Status:    j.status,
+		Progress:  j.progress,
+		Message:   j.message,
+		LastSeq:   j.seq,
+		CreatedAt: j.createdAt,
+		UpdatedAt: j.updatedAt,
+	}
+}
+
+// emit appends a frame and fans it out to subscribers. Frames after the
+// final frame are dropped. Slow subscribers miss frames rather than block
+// the job; they can resume from the replay buffer.
+func (j *job) emit(f JobFrame) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status.Terminal() {
+		return
+	}
+	j.seq++
+	f.JobID = j.id
+	f.Seq = j.seq
+	f.Timestamp = time.Now()
+	j.updatedAt = f.Timestamp
+
+	switch f.Type {
+	case JobFrameProgress:
+		if f.Progress > 0 {
+			j.progress = f.Progress
+		}
+		if f.Message != "" {
+			j.message = f.Message
+		}
+	case JobFrameFinal:
+		j.status = f.Status
+		j.final = f.Response
+	}
+	f.Status = j.status
+	if f.Type != JobFrameFinal {
+		f.Status = JobStatusRunning
+	}
+
+	j.frames = append(j.frames, f)
+	if len(j.frames) > j.maxFrames {
+		j.frames = j.frames[len(j.frames)-j.maxFrames:]
+	}
+	for ch := range j.subs {
+		select {
+		case ch <- f:
+		default:
+		}
+	}
+	if f.Type == JobFrameFinal {
+		for ch := range j.subs {
+			close(ch)
+		}
+		j.subs = nil
+		close(j.done)
+	}
+}
+
+// Progress implements JobReporter.
+func (j *job) Progress(fraction float64, message string) {
+	j.emit(JobFrame{Type: JobFrameProgress, Progress: fraction, Message: message})
+}
+
+// Output implements JobReporter.
+func (j *job) Output(chunk string) {
+	j.emit(JobFrame{Type: JobFrameOutput, Output: chunk})
+}
+
+// JobManagerConfig configures a JobManager.
+type JobManagerConfig struct {
+	// Retention is how long finished jobs stay retrievable. Default 1h.
+	Retention time.Duration
+	// Heartbeat is the interval of liveness frames while a job runs. Default 10s.
+	Heartbeat time.Duration
+	// MaxFrames bounds the per-job replay buffer. Default 256.
+	MaxFrames int
+	// MaxPerPeer caps the running jobs of one peer DID. Default 4.
+	MaxPerPeer int
+}
+
+// JobManager runs remote tool invocations as background jobs that outlive
+// the stream that started them.
+type JobManager struct {
+	mu         sync.Mutex
+	jobs       map[string]*job
+	running    map[string]int // running jobs per peer DID
+	retention  time.Duration
+	heartbeat  time.Duration
+	maxFrames  int
+	maxPerPeer int
+	now        func() time.Time
+}
+
+// NewJobManager creates a job manager.
+func NewJobManager(cfg JobManagerConfig) *JobManager {
+	if cfg.Retention <= 0 {
+		cfg.Retention = time.Hour
+	}
+	if cfg.Heartbeat <= 0 {
+		cfg.Heartbeat = 10 * time.Second
+	}
+	if cfg.MaxFrames <= 0 {
+		cfg.MaxFrames = 256
+	}
+	if cfg.MaxPerPeer <= 0 {
+		cfg.MaxPerPeer = 4
+	}
+	return &JobManager{
+		jobs:       make(map[string]*job),
+		running:    make(map[string]int),
+		retention:  cfg.Retention,
+		heartbeat:  cfg.Heartbeat,
+		maxFrames:  cfg.MaxFrames,
+		maxPerPeer: cfg.MaxPerPeer,
+		now:        time.Now,
+	}
+}
+
+// Start launches run in the background on behalf of peerDID and returns the
+// job ID. run receives a context carrying the job's JobReporter; it is
+// cancelled by Cancel but not by the requesting stream closing. Start fails
+// with ErrTooManyJobs when peerDID already has MaxPerPeer jobs running.
+func (m *JobManager) Start(peerDID, toolName string, run JobRunFunc) (string, error) {
+	m.prune()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	now := m.now()
+	j := &job{
+		id:        uuid.New().String(),
+		peerDID:   peerDID,
+		toolName:  toolName,
+		status:    JobStatusRunning,
+		createdAt: now,
+		updatedAt: now,
+		cancel:    cancel,
+		subs:      make(map[chan JobFrame]struct{}),
+		done:      make(chan struct{}),
+		maxFrames: m.maxFrames,
+	}
+
+	m.mu.Lock()
+	if m.running[peerDID] >= m.maxPerPeer {
+		m.mu.Unlock()
+		cancel()
+		return "", fmt.Errorf("%s: %w (limit %d)", peerDID, ErrTooManyJobs, m.maxPerPeer)
+	}
+	m.running[peerDID]++
+	m.jobs[j.id] = j
+	m.mu.Unlock()
+
+	go m.heartbeatLoop(ctx, j)
+	go func() {
+		defer cancel()
+		resp := run(WithJobReporter(ctx, j))
+		if resp == nil {
+			resp = &Response{Status: ResponseStatusError, Error: "job produced no response", Timestamp: time.Now()}
+		}
+		status := JobStatusCompleted
+		switch {
+		case ctx.Err() != nil:
+			status = JobStatusCancelled
+		case resp.Status != ResponseStatusOK:
+			status = JobStatusFailed
+		}
+		j.emit(JobFrame{Type: JobFrameFinal, Status: status, Response: resp})
+
+		m.mu.Lock()
+		if m.running[peerDID]--; m.running[peerDID] <= 0 {
+			delete(m.running, peerDID)
+		}
+		m.mu.Unlock()
+	}()
+
+	return j.id, nil
+}
+
+func (m *JobManager) heartbeatLoop(ctx context.Context, j *job) {
+	ticker := time.NewTicker(m.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-j.done:
+			return
+		case <-ticker.C:
+			j.emit(JobFrame{Type: JobFrameProgress})
+		}
+	}
+}
+
+// lookup returns the job if it exists and belongs to peerDID.
+func (m *JobManager) lookup(jobID, peerDID string) (*job, error) {
+	m.mu.Lock()
+	j, ok := m.jobs[jobID]
+	m.mu.Unlock()
+	if !ok || j.peerDID != peerDID {
+		return nil, fmt.Errorf("%s: %w", jobID, ErrJobNotFound)
+	}
+	return j, nil
+}
+
+// Status returns a job snapshot.
+func (m *JobManager) Status(jobID, peerDID string) (JobInfo, error) {
+	j, err := m.lookup(jobID, peerDID)
+	if err != nil {
+		return JobInfo{}, err
+	}
+	return j.info(), nil
+}
+
+// Result returns the final response of a finished job, or nil while the job
+// is still running.
+func (m *JobManager) Result(jobID, peerDID string) (*Response, JobInfo, error) {
+	j, err := m.lookup(jobID, peerDID)
+	if err != nil {
+		return nil, JobInfo{}, err
+	}
+	j.mu.Lock()
+	final := j.final
+	j.mu.Unlock()
+	return final, j.info(), nil
+}
+
+// Cancel cancels a running job. The job finishes with JobStatusCancelled
+// once its tool returns.
+func (m *JobManager) Cancel(jobID, peerDID string) error {
+	j, err := m.lookup(jobID, peerDID)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	terminal := j.status.Terminal()
+	j.mu.Unlock()
+	if terminal {
+		return fmt.Errorf("%s: %w", jobID, ErrJobFinished)
+	}
+	j.cancel()
+	return nil
+}
+
+// Subscribe returns buffered frames after afterSeq and a channel of live
+// frames. The channel is closed after the final frame; it is nil when the
+// job has already finished. Call the returned func to unsubscribe.
+func (m *JobManager) Subscribe(jobID, peerDID string, afterSeq int) ([]JobFrame, <-chan JobFrame, func(), error) {
+	j, err := m.lookup(jobID, peerDID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var replay []JobFrame
+	for _, f := range j.frames {
+		if f.Seq > afterSeq {
+			replay = append(replay, f)
+		}
+	}
+	if j.status.Terminal() {
+		// The final frame may have been evicted from the replay buffer.
+		if len(replay) == 0 || replay[len(replay)-1].Type != JobFrameFinal {
+			replay = append(replay, JobFrame{
+				JobID: j.id, Seq: j.seq, Type: JobFrameFinal, Status: j.status,
+				Response: j.final, Timestamp: j.updatedAt,
+			})
+		}
+		return replay, nil, func() {}, nil
+	}
+
+	ch := make(chan JobFrame, 64)
+	j.subs[ch] = struct{}{}
+	unsubscribe := func() {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		if _, ok := j.subs[ch]; ok {
+			delete(j.subs, ch)
+			close(ch)
+		}
+	}
+	return replay, ch, unsubscribe, nil
+}
+
+// prune drops finished jobs older than the retention window.
+func (m *JobManager) prune() {
+	cutoff := m.now().Add(-m.retention)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, j := range m.jobs {
+		j.mu.Lock()
+		expired := j.status.Terminal() && j.updatedAt.Before(cutoff)
+		j.mu.Unlock()
+		if expired {
+			delete(m.jobs, id)
+		}
+	}
+}
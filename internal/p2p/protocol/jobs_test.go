@@ -0,0 +1,185 @@
+package protocol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitFinal(t *testing.T, ch <-chan JobFrame) JobFrame {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case f, ok := <-ch:
+			require.True(t, ok, "channel closed before final frame")
+			if f.Type == JobFrameFinal {
+				return f
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for final frame")
+		}
+	}
+}
+
+func TestJobManager_FramesAndResult(t *testing.T) {
+	t.Parallel()
+
+	m := NewJobManager(JobManagerConfig{Heartbeat: time.Hour})
+	release := make(chan struct{})
+
+	id, err := m.Start("did:key:owner", "slow", func(ctx context.Context) *Response {
+		ReportProgress(ctx, 0.5, "halfway")
+		ReportOutput(ctx, "partial")
+		<-release
+		return &Response{Status: ResponseStatusOK, Result: map[string]interface{}{"done": true}}
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		info, err := m.Status(id, "did:key:owner")
+		return err == nil && info.LastSeq == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	final, info, err := m.Result(id, "did:key:owner")
+	require.NoError(t, err)
+	assert.Nil(t, final, "no result while running")
+	assert.Equal(t, JobStatusRunning, info.Status)
+	assert.Equal(t, 0.5, info.Progress)
+	assert.Equal(t, "halfway", info.Message)
+
+	// Replay after seq 1 returns only the output frame.
+	replay, ch, unsubscribe, err := m.Subscribe(id, "did:key:owner", 1)
+	require.NoError(t, err)
+	defer unsubscribe()
+	require.Len(t, replay, 1)
+	assert.Equal(t, JobFrameOutput, replay[0].Type)
+	assert.Equal(t, "partial", replay[0].Output)
+
+	close(release)
+	f := waitFinal(t, ch)
+	assert.Equal(t, JobStatusCompleted, f.Status)
+	assert.Equal(t, 3, f.Seq)
+
+	final, info, err = m.Result(id, "did:key:owner")
+	require.NoError(t, err)
+	require.NotNil(t, final)
+	assert.Equal(t, true, final.Result["done"])
+	assert.Equal(t, JobStatusCompleted, info.Status)
+
+	// Subscribing to a finished job replays the final frame without a channel.
+	replay, ch, _, err = m.Subscribe(id, "did:key:owner", 2)
+	require.NoError(t, err)
+	assert.Nil(t, ch)
+	require.Len(t, replay, 1)
+	assert.Equal(t, JobFrameFinal, replay[0].Type)
+}
+
+func TestJobManager_Cancel(t *testing.T) {
+	t.Parallel()
+
+	m := NewJobManager(JobManagerConfig{})
+	id, err := m.Start("did:key:owner", "wait", func(ctx context.Context) *Response {
+		<-ctx.Done()
+		return &Response{Status: ResponseStatusError, Error: "job cancelled"}
+	})
+	require.NoError(t, err)
+
+	_, ch, unsubscribe, err := m.Subscribe(id, "did:key:owner", 0)
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	require.NoError(t, m.Cancel(id, "did:key:owner"))
+	f := waitFinal(t, ch)
+	assert.Equal(t, JobStatusCancelled, f.Status)
+
+	assert.ErrorIs(t, m.Cancel(id, "did:key:owner"), ErrJobFinished)
+}
+
+func TestJobManager_OwnerIsolation(t *testing.T) {
+	t.Parallel()
+
+	m := NewJobManager(JobManagerConfig{})
+	id, err := m.Start("did:key:owner", "noop", func(context.Context) *Response {
+		return &Response{Status: ResponseStatusOK}
+	})
+	require.NoError(t, err)
+
+	_, err = m.Status(id, "did:key:other")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	assert.ErrorIs(t, m.Cancel(id, "did:key:other"), ErrJobNotFound)
+	_, _, _, err = m.Subscribe(id, "did:key:other", 0)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestJobManager_Heartbeat(t *testing.T) {
+	t.Parallel()
+
+	m := NewJobManager(JobManagerConfig{Heartbeat: 10 * time.Millisecond})
+	release := make(chan struct{})
+	id, err := m.Start("did:key:owner", "slow", func(context.Context) *Response {
+		<-release
+		return &Response{Status: ResponseStatusOK}
+	})
+	require.NoError(t, err)
+	defer close(release)
+
+	require.Eventually(t, func() bool {
+		info, err := m.Status(id, "did:key:owner")
+		return err == nil && info.LastSeq >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestJobManager_PruneFinished(t *testing.T) {
+	t.Parallel()
+
+	m := NewJobManager(JobManagerConfig{Retention: time.Minute})
+	id, err := m.Start("did:key:owner", "noop", func(context.Context) *Response {
+		return &Response{Status: ResponseStatusOK}
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		info, err := m.Status(id, "did:key:owner")
+		return err == nil && info.Status.Terminal()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	m.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	m.prune()
+
+	_, err = m.Status(id, "did:key:owner")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestJobManager_PeerLimit(t *testing.T) {
+	t.Parallel()
+
+	m := NewJobManager(JobManagerConfig{MaxPerPeer: 2})
+	release := make(chan struct{})
+	wait := func(context.Context) *Response {
+		<-release
+		return &Response{Status: ResponseStatusOK}
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := m.Start("did:key:owner", "slow", wait)
+		require.NoError(t, err)
+	}
+	_, err := m.Start("did:key:owner", "slow", wait)
+	assert.ErrorIs(t, err, ErrTooManyJobs)
+
+	// The limit is per peer.
+	_, err = m.Start("did:key:other", "slow", wait)
+	require.NoError(t, err)
+
+	// Finished jobs free their slot.
+	close(release)
+	require.Eventually(t, func() bool {
+		_, err := m.Start("did:key:owner", "noop", func(context.Context) *Response {
+			return &Response{Status: ResponseStatusOK}
+		})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
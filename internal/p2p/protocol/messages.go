@@ -59,12 +59,15 @@ const (
 
 	// ResponseStatusPaymentRequired indicates payment is needed.
 	ResponseStatusPaymentRequired ResponseStatus = "payment_required"
+
+	// ResponseStatusPending indicates a job has not finished yet.
+	ResponseStatusPending ResponseStatus = "pending"
 )
 
 // Valid reports whether s is a known response status.
 func (s ResponseStatus) Valid() bool {
 	switch s {
-	case ResponseStatusOK, ResponseStatusError, ResponseStatusDenied, ResponseStatusPaymentRequired:
+	case ResponseStatusOK, ResponseStatusError, ResponseStatusDenied, ResponseStatusPaymentRequired, ResponseStatusPending:
 		return true
 	}
 	return false
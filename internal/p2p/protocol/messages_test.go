@@ -20,6 +20,7 @@ func TestResponseStatus_Valid(t *testing.T) {
 		{give: ResponseStatusError, want: true},
 		{give: ResponseStatusDenied, want: true},
 		{give: ResponseStatusPaymentRequired, want: true},
+		{give: ResponseStatusPending, want: true},
 		{give: ResponseStatus(""), want: false},
 		{give: ResponseStatus("unknown"), want: false},
 		{give: ResponseStatus("OK"), want: false},
@@ -48,6 +49,11 @@ func TestRequestType_Constants(t *testing.T) {
 		{give: RequestContextShare, want: "context_share"},
 		{give: RequestNegotiatePropose, want: "negotiate_propose"},
 		{give: RequestNegotiateRespond, want: "negotiate_respond"},
+		{give: RequestToolInvokeAsync, want: "tool_invoke_async"},
+		{give: RequestJobStatus, want: "job_status"},
+		{give: RequestJobCancel, want: "job_cancel"},
+		{give: RequestJobResult, want: "job_result"},
+		{give: RequestJobStream, want: "job_stream"},
 	}
 
 	for _, tt := range tests {
@@ -3,6 +3,7 @@ package protocol
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/libp2p/go-libp2p/core/host"
@@ -71,33 +72,42 @@ func (a *P2PRemoteAgent) PeerID() peer.ID { return a.peerID }
 // Capabilities returns the remote agent's advertised capabilities.
 func (a *P2PRemoteAgent) Capabilities() []string { return a.capabilities }
 
-// InvokeTool sends a tool invocation to the remote agent.
+// InvokeTool sends a tool invocation to the remote agent. The call runs as
+// a streamed remote job so long-running tools neither hit stream timeouts nor
+// lose their result on a dropped connection; peers without job support are
+// invoked with a plain tool_invoke request.
 func (a *P2PRemoteAgent) InvokeTool(ctx context.Context, toolName string, params map[string]interface{}) (map[string]interface{}, error) {
-	s, err := a.host.NewStream(ctx, a.peerID, ProtocolID)
-	if err != nil {
-		return nil, fmt.Errorf("open stream to %s: %w", a.peerID, err)
+	resp, err := a.InvokeToolStream(ctx, toolName, params, func(f JobFrame) {
+		a.logger.Debugw("remote job progress", "tool", toolName, "remote", a.name,
+			"jobId", f.JobID, "type", f.Type, "progress", f.Progress, "message", f.Message)
+	})
+	if errors.Is(err, errJobsUnsupported) {
+		resp, err = a.invokeToolSync(ctx, toolName, params)
 	}
-	defer s.Close()
-
-	payload := map[string]interface{}{
-		"toolName": toolName,
-		"params":   params,
-	}
-
-	resp, err := SendRequest(ctx, s, RequestToolInvoke, a.token, payload)
 	if err != nil {
 		return nil, fmt.Errorf("tool invoke %s on %s: %w", toolName, a.name, err)
 	}
 
 	if resp.Status != ResponseStatusOK {
-		errMsg := resp.Error
-		if errMsg == "" {
-			errMsg = errMsgUnknown
-		}
-		return nil, fmt.Errorf("remote tool %s error: %s", toolName, errMsg)
+		return nil, fmt.Errorf("remote tool %s error: %s", toolName, responseError(resp))
+	}
+
+	a.verifyAttestation(ctx, toolName, resp)
+	return resp.Result, nil
+}
+
+// invokeToolSync sends a single tool_invoke request and waits for the result.
+func (a *P2PRemoteAgent) invokeToolSync(ctx context.Context, toolName string, params map[string]interface{}) (*Response, error) {
+	payload := map[string]interface{}{
+		"toolName": toolName,
+		"params":   params,
 	}
+	return a.sendJobRequest(ctx, RequestToolInvoke, payload)
+}
 
-	// Verify ZK attestation if present.
+// verifyAttestation checks the ZK attestation of a tool response, if any.
+// Verification failures are logged; the result is still returned.
+func (a *P2PRemoteAgent) verifyAttestation(ctx context.Context, toolName string, resp *Response) {
 	if resp.Attestation != nil && a.attestVerify != nil {
 		valid, err := a.attestVerify(ctx, resp.Attestation)
 		if err != nil {
@@ -110,8 +120,6 @@ func (a *P2PRemoteAgent) InvokeTool(ctx context.Context, toolName string, params
 	} else if len(resp.AttestationProof) > 0 {
 		a.logger.Debugw("response has legacy attestation proof (unverified)", "tool", toolName, "remote", a.name)
 	}
-
-	return resp.Result, nil
 }
 
 // QueryCapabilities fetches the remote agent's capabilities.
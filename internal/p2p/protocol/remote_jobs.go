@@ -0,0 +1,294 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/libp2p/go-libp2p/core/network"
+)
+
+const (
+	// maxJobReattach bounds how often a broken job stream is re-attached
+	// before InvokeTool gives up.
+	maxJobReattach = 3
+
+	// jobCancelTimeout bounds the best-effort job_cancel sent after the
+	// caller's context is cancelled.
+	jobCancelTimeout = 10 * time.Second
+)
+
+// errJobsUnsupported reports that the remote peer predates the job protocol.
+var errJobsUnsupported = errors.New("remote peer does not support jobs")
+
+// JobFrameFunc receives streamed job frames.
+type JobFrameFunc func(frame JobFrame)
+
+// InvokeToolStream runs a tool on the remote agent as a streamed job and
+// returns its final response. onFrame, if set, receives progress and partial
+// output frames. A broken stream is re-attached without restarting the job;
+// cancelling ctx cancels the remote job.
+func (a *P2PRemoteAgent) InvokeToolStream(ctx context.Context, toolName string, params map[string]interface{}, onFrame JobFrameFunc) (*Response, error) {
+	payload := map[string]interface{}{
+		"toolName": toolName,
+		"params":   params,
+		"stream":   true,
+	}
+	return a.runJob(ctx, toolName, payload, onFrame)
+}
+
+// InvokeToolPaidStream is the paid counterpart of InvokeToolStream. The
+// payment authorization is only settled if the job completes.
+func (a *P2PRemoteAgent) InvokeToolPaidStream(
+	ctx context.Context,
+	toolName string,
+	params map[string]interface{},
+	paymentAuth map[string]interface{},
+	onFrame JobFrameFunc,
+) (*Response, error) {
+	payload := map[string]interface{}{
+		"toolName":    toolName,
+		"params":      params,
+		"paid":        true,
+		"paymentAuth": paymentAuth,
+		"stream":      true,
+	}
+	return a.runJob(ctx, toolName, payload, onFrame)
+}
+
+// InvokeToolAsync starts a tool invocation on the remote agent as a
+// background job and returns the job ID without waiting for the result.
+func (a *P2PRemoteAgent) InvokeToolAsync(ctx context.Context, toolName string, params map[string]interface{}) (string, error) {
+	payload := map[string]interface{}{
+		"toolName": toolName,
+		"params":   params,
+	}
+	resp, err := a.sendJobRequest(ctx, RequestToolInvokeAsync, payload)
+	if err != nil {
+		return "", fmt.Errorf("async invoke %s on %s: %w", toolName, a.name, err)
+	}
+	if resp.Status != ResponseStatusOK {
+		return "", fmt.Errorf("async invoke %s error: %s", toolName, responseError(resp))
+	}
+	jobID, _ := resp.Result["jobId"].(string)
+	if jobID == "" {
+		return "", fmt.Errorf("async invoke %s: %w", toolName, ErrMissingJobID)
+	}
+	return jobID, nil
+}
+
+// JobStatus fetches a snapshot of a remote job.
+func (a *P2PRemoteAgent) JobStatus(ctx context.Context, jobID string) (*JobInfo, error) {
+	resp, err := a.sendJobRequest(ctx, RequestJobStatus, map[string]interface{}{"jobId": jobID})
+	if err != nil {
+		return nil, fmt.Errorf("job status %s on %s: %w", jobID, a.name, err)
+	}
+	if resp.Status != ResponseStatusOK {
+		return nil, fmt.Errorf("job status %s error: %s", jobID, responseError(resp))
+	}
+	return decodeJobInfo(resp.Result)
+}
+
+// CancelJob cancels a remote job.
+func (a *P2PRemoteAgent) CancelJob(ctx context.Context, jobID string) error {
+	resp, err := a.sendJobRequest(ctx, RequestJobCancel, map[string]interface{}{"jobId": jobID})
+	if err != nil {
+		return fmt.Errorf("job cancel %s on %s: %w", jobID, a.name, err)
+	}
+	if resp.Status != ResponseStatusOK {
+		return fmt.Errorf("job cancel %s error: %s", jobID, responseError(resp))
+	}
+	return nil
+}
+
+// JobResult fetches the final response of a remote job. While the job is
+// still running the response status is ResponseStatusPending.
+func (a *P2PRemoteAgent) JobResult(ctx context.Context, jobID string) (*Response, error) {
+	resp, err := a.sendJobRequest(ctx, RequestJobResult, map[string]interface{}{"jobId": jobID})
+	if err != nil {
+		return nil, fmt.Errorf("job result %s on %s: %w", jobID, a.name, err)
+	}
+	return resp, nil
+}
+
+// StreamJob re-attaches to a remote job, delivering frames after afterSeq
+// to onFrame, and returns the job's final response.
+func (a *P2PRemoteAgent) StreamJob(ctx context.Context, jobID string, afterSeq int, onFrame JobFrameFunc) (*Response, error) {
+	last := afterSeq
+	resp, err := a.attachJob(ctx, jobID, &last, onFrame)
+	if err != nil {
+		return nil, fmt.Errorf("job stream %s on %s: %w", jobID, a.name, err)
+	}
+	return resp, nil
+}
+
+// runJob starts a streamed job and follows it to completion, re-attaching
+// when the stream breaks. It returns errJobsUnsupported when the peer does
+// not know the job protocol.
+func (a *P2PRemoteAgent) runJob(ctx context.Context, toolName string, payload map[string]interface{}, onFrame JobFrameFunc) (*Response, error) {
+	s, dec, resp, err := a.openJobStream(ctx, RequestToolInvokeAsync, payload)
+	if err != nil {
+		return nil, fmt.Errorf("async invoke %s on %s: %w", toolName, a.name, err)
+	}
+	if resp.Status != ResponseStatusOK {
+		s.Close()
+		if isUnknownRequestType(resp, RequestToolInvokeAsync) {
+			return nil, errJobsUnsupported
+		}
+		return resp, nil
+	}
+	jobID, _ := resp.Result["jobId"].(string)
+	if jobID == "" {
+		s.Close()
+		return nil, fmt.Errorf("async invoke %s: %w", toolName, ErrMissingJobID)
+	}
+
+	last := 0
+	final, err := a.readJobFrames(ctx, s, dec, &last, onFrame)
+	for attempt := 1; err != nil && ctx.Err() == nil && attempt <= maxJobReattach; attempt++ {
+		a.logger.Debugw("job stream broken, re-attaching",
+			"jobId", jobID, "remote", a.name, "afterSeq", last, "attempt", attempt, "error", err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Duration(attempt) * 500 * time.Millisecond):
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		final, err = a.attachJob(ctx, jobID, &last, onFrame)
+	}
+
+	if ctx.Err() != nil {
+		// Best effort: the caller is gone, so stop the remote work.
+		cancelCtx, cancel := context.WithTimeout(context.Background(), jobCancelTimeout)
+		defer cancel()
+		if cerr := a.CancelJob(cancelCtx, jobID); cerr != nil {
+			a.logger.Debugw("cancel remote job", "jobId", jobID, "remote", a.name, "error", cerr)
+		}
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("job %s on %s: %w", jobID, a.name, err)
+	}
+	return final, nil
+}
+
+// attachJob opens a job_stream and reads frames after *last.
+func (a *P2PRemoteAgent) attachJob(ctx context.Context, jobID string, last *int, onFrame JobFrameFunc) (*Response, error) {
+	payload := map[string]interface{}{"jobId": jobID, "afterSeq": *last}
+	s, dec, resp, err := a.openJobStream(ctx, RequestJobStream, payload)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != ResponseStatusOK {
+		s.Close()
+		return nil, errors.New(responseError(resp))
+	}
+	return a.readJobFrames(ctx, s, dec, last, onFrame)
+}
+
+// readJobFrames consumes frames until the final frame and closes the stream.
+func (a *P2PRemoteAgent) readJobFrames(ctx context.Context, s network.Stream, dec *json.Decoder, last *int, onFrame JobFrameFunc) (*Response, error) {
+	defer s.Close()
+
+	// Unblock the decoder when the caller gives up.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = s.Reset()
+		case <-done:
+		}
+	}()
+
+	for {
+		var f JobFrame
+		if err := dec.Decode(&f); err != nil {
+			return nil, fmt.Errorf("receive job frame: %w", err)
+		}
+		if f.Seq <= *last {
+			continue
+		}
+		*last = f.Seq
+		if f.Type == JobFrameFinal {
+			if f.Response == nil {
+				return nil, fmt.Errorf("job %s finished without a response", f.JobID)
+			}
+			return f.Response, nil
+		}
+		if onFrame != nil {
+			onFrame(f)
+		}
+	}
+}
+
+// openJobStream sends a request and decodes its response, returning the
+// decoder so that job frames following the response are not lost.
+func (a *P2PRemoteAgent) openJobStream(ctx context.Context, reqType RequestType, payload map[string]interface{}) (network.Stream, *json.Decoder, *Response, error) {
+	s, err := a.host.NewStream(ctx, a.peerID, ProtocolID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("open stream to %s: %w", a.peerID, err)
+	}
+
+	req := Request{
+		Type:         reqType,
+		SessionToken: a.token,
+		RequestID:    uuid.New().String(),
+		Payload:      payload,
+	}
+	if err := json.NewEncoder(s).Encode(req); err != nil {
+		s.Close()
+		return nil, nil, nil, fmt.Errorf("send request: %w", err)
+	}
+
+	dec := json.NewDecoder(s)
+	var resp Response
+	if err := dec.Decode(&resp); err != nil {
+		s.Close()
+		return nil, nil, nil, fmt.Errorf("receive response: %w", err)
+	}
+	return s, dec, &resp, nil
+}
+
+// sendJobRequest sends a single job request and returns its response.
+func (a *P2PRemoteAgent) sendJobRequest(ctx context.Context, reqType RequestType, payload map[string]interface{}) (*Response, error) {
+	s, err := a.host.NewStream(ctx, a.peerID, ProtocolID)
+	if err != nil {
+		return nil, fmt.Errorf("open stream to %s: %w", a.peerID, err)
+	}
+	defer s.Close()
+
+	return SendRequest(ctx, s, reqType, a.token, payload)
+}
+
+// isUnknownRequestType reports whether resp rejects reqType as unknown,
+// which is how peers without job support answer job requests.
+func isUnknownRequestType(resp *Response, reqType RequestType) bool {
+	return resp.Status == ResponseStatusError &&
+		strings.HasPrefix(resp.Error, "unknown request type") &&
+		strings.Contains(resp.Error, string(reqType))
+}
+
+func responseError(resp *Response) string {
+	if resp.Error != "" {
+		return resp.Error
+	}
+	return errMsgUnknown
+}
+
+func decodeJobInfo(m map[string]interface{}) (*JobInfo, error) {
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("marshal job info: %w", err)
+	}
+	var info JobInfo
+	if err := json.Unmarshal(raw, &info); err != nil {
+		return nil, fmt.Errorf("unmarshal job info: %w", err)
+	}
+	return &info, nil
+}
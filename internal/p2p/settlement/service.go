@@ -1,6 +1,7 @@
 // Package settlement handles asynchronous on-chain settlement of P2P tool
 // invocation payments. It subscribes to ToolExecutionPaidEvent from the event
 // bus and submits transferWithAuthorization transactions to the USDC contract.
+// Settle is also called synchronously by the x402 seller middleware.
 package settlement
 
 import (
@@ -30,10 +31,30 @@ type ReputationRecorder interface {
 	RecordFailure(ctx context.Context, peerDID string) error
 }
 
+// ChainClient is the subset of the Ethereum RPC API used for settlement.
+// *ethclient.Client and the go-ethereum simulated backend satisfy it.
+type ChainClient interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+var _ ChainClient = (*ethclient.Client)(nil)
+
+// Record describes the payment_txes row written for a settlement.
+type Record struct {
+	Method     paymenttx.PaymentMethod
+	Purpose    string
+	SessionKey string
+	X402URL    string
+}
+
 // Config holds construction parameters for a settlement Service.
 type Config struct {
 	Wallet         wallet.WalletProvider
-	RPCClient      *ethclient.Client
+	RPCClient      ChainClient
 	DBClient       *ent.Client
 	ChainID        int64
 	USDCAddr       common.Address
@@ -45,7 +66,7 @@ type Config struct {
 // Service processes on-chain settlement for paid tool invocations.
 type Service struct {
 	wallet     wallet.WalletProvider
-	rpc        *ethclient.Client
+	rpc        ChainClient
 	db         *ent.Client
 	chainID    *big.Int
 	usdcAddr   common.Address
@@ -119,7 +140,11 @@ func (s *Service) handleEvent(evt eventbus.ToolExecutionPaidEvent) {
 		return
 	}
 
-	if err := s.settle(ctx, auth, evt.PeerDID, evt.ToolName); err != nil {
+	_, err := s.Settle(ctx, auth, Record{
+		Method:  paymenttx.PaymentMethodP2pSettlement,
+		Purpose: fmt.Sprintf("p2p settlement: %s from %s", evt.ToolName, evt.PeerDID),
+	})
+	if err != nil {
 		s.logger.Errorw("settlement failed",
 			"peerDID", evt.PeerDID, "tool", evt.ToolName, "error", err)
 		if s.reputation != nil {
@@ -133,47 +158,57 @@ func (s *Service) handleEvent(evt eventbus.ToolExecutionPaidEvent) {
 	}
 }
 
-// settle executes the full settlement lifecycle:
+// Settle synchronously executes the full settlement lifecycle for auth and
+// returns the confirmed transaction hash:
 // 1. Create DB record (pending)
 // 2. Build transaction (calldata + EIP-1559)
 // 3. Sign transaction via wallet
 // 4. Submit with retry
 // 5. Wait for on-chain confirmation
-func (s *Service) settle(ctx context.Context, auth *eip3009.Authorization, peerDID, toolName string) error {
+func (s *Service) Settle(ctx context.Context, auth *eip3009.Authorization, rec Record) (string, error) {
 	if s.db == nil {
-		return fmt.Errorf("db client not configured")
+		return "", fmt.Errorf("db client not configured")
+	}
+	if rec.Method == "" {
+		rec.Method = paymenttx.PaymentMethodP2pSettlement
 	}
 
 	// 1. Create DB record.
-	txRecord, err := s.db.PaymentTx.Create().
+	create := s.db.PaymentTx.Create().
 		SetID(uuid.New()).
 		SetFromAddress(auth.From.Hex()).
 		SetToAddress(auth.To.Hex()).
 		SetAmount(auth.Value.String()).
 		SetChainID(s.chainID.Int64()).
 		SetStatus(paymenttx.StatusPending).
-		SetPaymentMethod(paymenttx.PaymentMethodP2pSettlement).
-		SetPurpose(fmt.Sprintf("p2p settlement: %s from %s", toolName, peerDID)).
-		Save(ctx)
+		SetPaymentMethod(rec.Method).
+		SetPurpose(rec.Purpose)
+	if rec.SessionKey != "" {
+		create = create.SetSessionKey(rec.SessionKey)
+	}
+	if rec.X402URL != "" {
+		create = create.SetX402URL(rec.X402URL)
+	}
+	txRecord, err := create.Save(ctx)
 	if err != nil {
-		return fmt.Errorf("create payment record: %w", err)
+		return "", fmt.Errorf("create payment record: %w", err)
 	}
 
 	s.logger.Infow("settlement record created",
-		"id", txRecord.ID, "tool", toolName, "peerDID", peerDID)
+		"id", txRecord.ID, "method", rec.Method, "purpose", rec.Purpose)
 
 	// 2. Build settlement transaction.
 	signedTx, err := s.buildAndSignTx(ctx, auth)
 	if err != nil {
 		s.updateStatus(ctx, txRecord.ID, paymenttx.StatusFailed, "", err.Error())
-		return fmt.Errorf("build/sign tx: %w", err)
+		return "", fmt.Errorf("build/sign tx: %w", err)
 	}
 
 	// 3. Submit with retry.
 	txHash, err := s.submitWithRetry(ctx, signedTx)
 	if err != nil {
 		s.updateStatus(ctx, txRecord.ID, paymenttx.StatusFailed, "", err.Error())
-		return fmt.Errorf("submit tx: %w", err)
+		return "", fmt.Errorf("submit tx: %w", err)
 	}
 
 	// Update DB with submitted status.
@@ -183,12 +218,12 @@ func (s *Service) settle(ctx context.Context, auth *eip3009.Authorization, peerD
 	// 4. Wait for confirmation.
 	if err := s.waitForConfirmation(ctx, common.HexToHash(txHash)); err != nil {
 		s.updateStatus(ctx, txRecord.ID, paymenttx.StatusFailed, txHash, err.Error())
-		return fmt.Errorf("wait confirmation: %w", err)
+		return txHash, fmt.Errorf("wait confirmation: %w", err)
 	}
 
 	s.updateStatus(ctx, txRecord.ID, paymenttx.StatusConfirmed, txHash, "")
 	s.logger.Infow("settlement confirmed", "txHash", txHash, "id", txRecord.ID)
-	return nil
+	return txHash, nil
 }
 
 // buildAndSignTx constructs the transferWithAuthorization calldata, builds an
@@ -4,4 +4,10 @@ import "errors"
 
 var (
 	ErrWorkspaceNotFound = errors.New("workspace not found")
+	ErrUnsignedMessage   = errors.New("workspace message is not signed")
+	ErrInvalidSignature  = errors.New("invalid workspace message signature")
+	ErrNotMember         = errors.New("sender is not a workspace member")
+	ErrNotInvited        = errors.New("peer is not invited to the workspace")
+	ErrNoGroupKey        = errors.New("no workspace group key")
+	ErrUndecryptable     = errors.New("workspace message cannot be decrypted")
 )
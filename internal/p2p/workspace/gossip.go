@@ -22,6 +22,9 @@ type WorkspaceGossip struct {
 	localID peer.ID
 	logger  *zap.SugaredLogger
 	handler MessageHandler
+	keyring *GroupKeyring
+
+	onRejected RejectionHandler
 
 	mu     sync.RWMutex
 	topics map[string]*topicState // workspaceID → topic state
@@ -39,6 +42,12 @@ type GossipConfig struct {
 	LocalID peer.ID
 	Handler MessageHandler
 	Logger  *zap.SugaredLogger
+
+	// Keyring, when set, end-to-end encrypts every published message under
+	// the workspace group key and drops received messages that do not open.
+	Keyring *GroupKeyring
+	// OnRejected is notified of received messages that cannot be decrypted.
+	OnRejected RejectionHandler
 }
 
 // NewWorkspaceGossip creates a new workspace gossip manager.
@@ -48,7 +57,10 @@ func NewWorkspaceGossip(cfg GossipConfig) *WorkspaceGossip {
 		localID: cfg.LocalID,
 		handler: cfg.Handler,
 		logger:  cfg.Logger,
+		keyring: cfg.Keyring,
 		topics:  make(map[string]*topicState),
+
+		onRejected: cfg.OnRejected,
 	}
 }
 
@@ -105,7 +117,9 @@ func (g *WorkspaceGossip) Unsubscribe(workspaceID string) {
 	g.logger.Infow("unsubscribed from workspace topic", "workspace", workspaceID)
 }
 
-// Publish sends a message to the workspace's GossipSub topic.
+// Publish sends a message to the workspace's GossipSub topic. With a
+// keyring configured the message is sealed under the current group key and
+// ErrNoGroupKey is returned if the workspace has none yet.
 func (g *WorkspaceGossip) Publish(ctx context.Context, workspaceID string, msg Message) error {
 	g.mu.RLock()
 	state, ok := g.topics[workspaceID]
@@ -114,9 +128,16 @@ func (g *WorkspaceGossip) Publish(ctx context.Context, workspaceID string, msg M
 		return fmt.Errorf("not subscribed to workspace %s", workspaceID)
 	}
 
-	data, err := json.Marshal(msg)
+	var data []byte
+	var err error
+	if g.keyring != nil {
+		msg.WorkspaceID = workspaceID
+		data, err = g.keyring.Seal(msg)
+	} else {
+		data, err = json.Marshal(msg)
+	}
 	if err != nil {
-		return fmt.Errorf("marshal message: %w", err)
+		return fmt.Errorf("encode message: %w", err)
 	}
 
 	if err := state.topic.Publish(ctx, data); err != nil {
@@ -169,9 +190,17 @@ func (g *WorkspaceGossip) readLoop(ctx context.Context, workspaceID string, sub
 			continue
 		}
 
-		var msg Message
-		if err := json.Unmarshal(raw.Data, &msg); err != nil {
-			g.logger.Debugw("unmarshal workspace message", "workspace", workspaceID, "error", err)
+		msg, err := g.decode(workspaceID, raw.Data)
+		if err != nil {
+			g.logger.Debugw("decode workspace message", "workspace", workspaceID, "peer", raw.ReceivedFrom, "error", err)
+			if g.keyring != nil && g.onRejected != nil {
+				g.onRejected(Rejection{
+					WorkspaceID: workspaceID,
+					PeerID:      raw.ReceivedFrom.String(),
+					Stage:       StageGossip,
+					Err:         err,
+				})
+			}
 			continue
 		}
 
@@ -180,3 +209,14 @@ func (g *WorkspaceGossip) readLoop(ctx context.Context, workspaceID string, sub
 		}
 	}
 }
+
+func (g *WorkspaceGossip) decode(workspaceID string, data []byte) (Message, error) {
+	if g.keyring != nil {
+		return g.keyring.Open(workspaceID, data)
+	}
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return Message{}, err
+	}
+	return msg, nil
+}
@@ -0,0 +1,414 @@
+package workspace
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"go.uber.org/zap"
+)
+
+// KeyProtocolID is the libp2p protocol used to distribute workspace group keys.
+const KeyProtocolID = "/lango/workspace-key/1.0.0"
+
+const maxKeyMessageSize = 16 * 1024
+
+// KeyRequestType identifies a key exchange request.
+type KeyRequestType string
+
+const (
+	// KeyRequestDistribute pushes a sealed group key to a member.
+	KeyRequestDistribute KeyRequestType = "distribute"
+	// KeyRequestFetch asks a member for the current group key.
+	KeyRequestFetch KeyRequestType = "fetch"
+)
+
+// KeyDistribution carries a group key sealed under the pairwise handshake
+// session key shared by sender and recipient.
+type KeyDistribution struct {
+	WorkspaceID  string `json:"workspaceId"`
+	SenderDID    string `json:"senderDid"`
+	RecipientDID string `json:"recipientDid"`
+	Epoch        uint64 `json:"epoch"`
+	Nonce        []byte `json:"nonce"`
+	SealedKey    []byte `json:"sealedKey"`
+}
+
+// KeyRequest is the envelope written by the stream initiator.
+type KeyRequest struct {
+	Type         KeyRequestType   `json:"type"`
+	WorkspaceID  string           `json:"workspaceId"`
+	SenderDID    string           `json:"senderDid"`
+	Distribution *KeyDistribution `json:"distribution,omitempty"`
+}
+
+// KeyResponse answers a KeyRequest. Fetch responses carry the sealed key.
+type KeyResponse struct {
+	OK           bool             `json:"ok"`
+	Message      string           `json:"message,omitempty"`
+	Distribution *KeyDistribution `json:"distribution,omitempty"`
+}
+
+// SessionKeyFunc returns the handshake session encryption key shared with
+// a peer DID, or nil when no key-agreed session exists.
+type SessionKeyFunc func(peerDID string) []byte
+
+// PeerResolver maps a member DID to its libp2p peer ID.
+type PeerResolver func(did string) (peer.ID, error)
+
+// MembersFunc returns the current members of a workspace.
+type MembersFunc func(workspaceID string) ([]*Member, error)
+
+// AdmitFunc decides whether a peer may fetch the group key of a workspace.
+// It may add the peer as a member as a side effect.
+type AdmitFunc func(workspaceID, did string) error
+
+// KeyExchangeConfig configures a KeyExchange.
+type KeyExchangeConfig struct {
+	Host        host.Host
+	LocalDID    string
+	Keyring     *GroupKeyring
+	SessionKey  SessionKeyFunc
+	ResolvePeer PeerResolver
+	Members     MembersFunc
+	Admit       AdmitFunc // nil: only current members may fetch
+	Timeout     time.Duration
+	Logger      *zap.SugaredLogger
+}
+
+// KeyExchange distributes workspace group keys to members over pairwise
+// handshake sessions and rotates them when membership shrinks.
+type KeyExchange struct {
+	host        host.Host
+	localDID    string
+	keyring     *GroupKeyring
+	sessionKey  SessionKeyFunc
+	resolvePeer PeerResolver
+	members     MembersFunc
+	admit       AdmitFunc
+	timeout     time.Duration
+	logger      *zap.SugaredLogger
+}
+
+// NewKeyExchange creates a key exchange.
+func NewKeyExchange(cfg KeyExchangeConfig) *KeyExchange {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 15 * time.Second
+	}
+	return &KeyExchange{
+		host:        cfg.Host,
+		localDID:    cfg.LocalDID,
+		keyring:     cfg.Keyring,
+		sessionKey:  cfg.SessionKey,
+		resolvePeer: cfg.ResolvePeer,
+		members:     cfg.Members,
+		admit:       cfg.Admit,
+		timeout:     cfg.Timeout,
+		logger:      cfg.Logger,
+	}
+}
+
+// KeyLeader returns the member responsible for generating and rotating the
+// workspace group key: the creator while present, otherwise the member who
+// joined first. Ties are broken by DID so every member picks the same one.
+func KeyLeader(members []*Member) string {
+	var leader *Member
+	for _, m := range members {
+		if m.Role == RoleCreator {
+			return m.DID
+		}
+		if leader == nil || m.JoinedAt.Before(leader.JoinedAt) ||
+			(m.JoinedAt.Equal(leader.JoinedAt) && m.DID < leader.DID) {
+			leader = m
+		}
+	}
+	if leader == nil {
+		return ""
+	}
+	return leader.DID
+}
+
+// IsLeader reports whether the local agent is the key leader of a workspace.
+func (kx *KeyExchange) IsLeader(workspaceID string) bool {
+	members, err := kx.members(workspaceID)
+	if err != nil {
+		return false
+	}
+	return KeyLeader(members) == kx.localDID
+}
+
+// Ensure makes sure a current group key exists for the workspace. The key
+// leader generates and distributes one; other members fetch it from the
+// leader.
+func (kx *KeyExchange) Ensure(ctx context.Context, workspaceID string) error {
+	if _, ok := kx.keyring.Current(workspaceID); ok {
+		return nil
+	}
+	members, err := kx.members(workspaceID)
+	if err != nil {
+		return err
+	}
+	leader := KeyLeader(members)
+	if leader == kx.localDID {
+		return kx.Rotate(ctx, workspaceID)
+	}
+	return kx.Fetch(ctx, workspaceID, leader)
+}
+
+// Rotate generates a new group key and distributes it to every other
+// member. Distribution failures are logged; members that missed the key can
+// fetch it later.
+func (kx *KeyExchange) Rotate(ctx context.Context, workspaceID string) error {
+	gk, err := kx.keyring.Rotate(workspaceID)
+	if err != nil {
+		return err
+	}
+	kx.logger.Infow("workspace group key rotated", "workspace", workspaceID, "epoch", gk.Epoch)
+	kx.Distribute(ctx, workspaceID)
+	return nil
+}
+
+// Distribute sends the current group key to every other member.
+func (kx *KeyExchange) Distribute(ctx context.Context, workspaceID string) {
+	members, err := kx.members(workspaceID)
+	if err != nil {
+		kx.logger.Warnw("list workspace members for key distribution", "workspace", workspaceID, "error", err)
+		return
+	}
+	for _, m := range members {
+		if m.DID == kx.localDID {
+			continue
+		}
+		if err := kx.Send(ctx, workspaceID, m.DID); err != nil {
+			kx.logger.Warnw("distribute workspace key", "workspace", workspaceID, "member", m.DID, "error", err)
+		}
+	}
+}
+
+// Send seals the current group key for one member and pushes it.
+func (kx *KeyExchange) Send(ctx context.Context, workspaceID, memberDID string) error {
+	dist, err := kx.seal(workspaceID, memberDID)
+	if err != nil {
+		return err
+	}
+	_, err = kx.roundTrip(ctx, memberDID, KeyRequest{
+		Type:         KeyRequestDistribute,
+		WorkspaceID:  workspaceID,
+		SenderDID:    kx.localDID,
+		Distribution: dist,
+	})
+	return err
+}
+
+// Fetch asks a member for the current group key and installs it.
+func (kx *KeyExchange) Fetch(ctx context.Context, workspaceID, memberDID string) error {
+	resp, err := kx.roundTrip(ctx, memberDID, KeyRequest{
+		Type:        KeyRequestFetch,
+		WorkspaceID: workspaceID,
+		SenderDID:   kx.localDID,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Distribution == nil {
+		return fmt.Errorf("fetch workspace key from %s: empty response", memberDID)
+	}
+	return kx.install(workspaceID, memberDID, resp.Distribution)
+}
+
+// StreamHandler returns the libp2p stream handler for KeyProtocolID.
+func (kx *KeyExchange) StreamHandler() network.StreamHandler {
+	return func(s network.Stream) {
+		defer s.Close()
+		_ = s.SetDeadline(time.Now().Add(kx.timeout))
+
+		var req KeyRequest
+		if err := json.NewDecoder(io.LimitReader(s, maxKeyMessageSize)).Decode(&req); err != nil {
+			writeKeyResponse(s, KeyResponse{Message: "decode request: " + err.Error()})
+			return
+		}
+
+		resp, err := kx.handlePeer(s.Conn().RemotePeer(), req)
+		if err != nil {
+			kx.logger.Warnw("workspace key request rejected",
+				"workspace", req.WorkspaceID, "sender", req.SenderDID,
+				"peer", s.Conn().RemotePeer().String(), "error", err)
+			writeKeyResponse(s, KeyResponse{Message: err.Error()})
+			return
+		}
+		writeKeyResponse(s, resp)
+	}
+}
+
+// handlePeer binds the claimed sender DID to the stream's remote peer before
+// handling the request, so one peer cannot act under another member's DID.
+func (kx *KeyExchange) handlePeer(remote peer.ID, req KeyRequest) (KeyResponse, error) {
+	pid, err := kx.resolvePeer(req.SenderDID)
+	if err != nil {
+		return KeyResponse{}, fmt.Errorf("resolve peer for %s: %w", req.SenderDID, err)
+	}
+	if pid != remote {
+		return KeyResponse{}, fmt.Errorf("sender %s does not match remote peer %s", req.SenderDID, remote)
+	}
+	return kx.handle(req)
+}
+
+func (kx *KeyExchange) handle(req KeyRequest) (KeyResponse, error) {
+	switch req.Type {
+	case KeyRequestDistribute:
+		if err := kx.authorize(req.WorkspaceID, req.SenderDID); err != nil {
+			return KeyResponse{}, err
+		}
+		if req.Distribution == nil {
+			return KeyResponse{}, errors.New("missing key distribution")
+		}
+		if err := kx.install(req.WorkspaceID, req.SenderDID, req.Distribution); err != nil {
+			return KeyResponse{}, err
+		}
+		return KeyResponse{OK: true}, nil
+	case KeyRequestFetch:
+		admit := kx.admit
+		if admit == nil {
+			admit = kx.authorize
+		}
+		if err := admit(req.WorkspaceID, req.SenderDID); err != nil {
+			return KeyResponse{}, err
+		}
+		dist, err := kx.seal(req.WorkspaceID, req.SenderDID)
+		if err != nil {
+			return KeyResponse{}, err
+		}
+		return KeyResponse{OK: true, Distribution: dist}, nil
+	default:
+		return KeyResponse{}, fmt.Errorf("unknown request type %q", req.Type)
+	}
+}
+
+// authorize checks that did is a current member of the workspace.
+func (kx *KeyExchange) authorize(workspaceID, did string) error {
+	members, err := kx.members(workspaceID)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if m.DID == did {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %w", did, ErrNotMember)
+}
+
+func (kx *KeyExchange) seal(workspaceID, recipientDID string) (*KeyDistribution, error) {
+	gk, ok := kx.keyring.Current(workspaceID)
+	if !ok {
+		return nil, fmt.Errorf("workspace %s: %w", workspaceID, ErrNoGroupKey)
+	}
+	return SealGroupKey(kx.sessionKey(recipientDID), workspaceID, kx.localDID, recipientDID, gk)
+}
+
+func (kx *KeyExchange) install(workspaceID, senderDID string, dist *KeyDistribution) error {
+	if dist.WorkspaceID != workspaceID || dist.SenderDID != senderDID || dist.RecipientDID != kx.localDID {
+		return errors.New("key distribution does not match request")
+	}
+	key, err := OpenGroupKey(kx.sessionKey(senderDID), dist)
+	if err != nil {
+		return err
+	}
+	if err := kx.keyring.Install(workspaceID, dist.Epoch, key); err != nil {
+		return err
+	}
+	kx.logger.Infow("workspace group key installed", "workspace", workspaceID, "epoch", dist.Epoch, "from", senderDID)
+	return nil
+}
+
+func (kx *KeyExchange) roundTrip(ctx context.Context, memberDID string, req KeyRequest) (*KeyResponse, error) {
+	pid, err := kx.resolvePeer(memberDID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve peer for %s: %w", memberDID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, kx.timeout)
+	defer cancel()
+
+	stream, err := kx.host.NewStream(ctx, pid, protocol.ID(KeyProtocolID))
+	if err != nil {
+		return nil, fmt.Errorf("open workspace key stream: %w", err)
+	}
+	defer stream.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = stream.SetDeadline(deadline)
+	}
+
+	if err := json.NewEncoder(stream).Encode(&req); err != nil {
+		return nil, fmt.Errorf("send workspace key request: %w", err)
+	}
+	var resp KeyResponse
+	if err := json.NewDecoder(io.LimitReader(stream, maxKeyMessageSize)).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("decode workspace key response: %w", err)
+	}
+	if !resp.OK {
+		return nil, fmt.Errorf("workspace key request refused by %s: %s", memberDID, resp.Message)
+	}
+	return &resp, nil
+}
+
+func writeKeyResponse(s network.Stream, resp KeyResponse) {
+	_ = json.NewEncoder(s).Encode(&resp)
+}
+
+// SealGroupKey encrypts a group key for one recipient under the handshake
+// session key they share with the sender. Sessions established without the
+// post-quantum KEM have no shared key and cannot receive group keys.
+func SealGroupKey(sessionKey []byte, workspaceID, senderDID, recipientDID string, gk GroupKey) (*KeyDistribution, error) {
+	if len(sessionKey) == 0 {
+		return nil, fmt.Errorf("no encrypted handshake session with %s", recipientDID)
+	}
+	dist := &KeyDistribution{
+		WorkspaceID:  workspaceID,
+		SenderDID:    senderDID,
+		RecipientDID: recipientDID,
+		Epoch:        gk.Epoch,
+	}
+	nonce, sealed, err := sealGCM(keyWrapKey(sessionKey), gk.Key, keyDistributionAAD(dist))
+	if err != nil {
+		return nil, err
+	}
+	dist.Nonce = nonce
+	dist.SealedKey = sealed
+	return dist, nil
+}
+
+// OpenGroupKey decrypts a KeyDistribution with the shared session key.
+func OpenGroupKey(sessionKey []byte, dist *KeyDistribution) ([]byte, error) {
+	if len(sessionKey) == 0 {
+		return nil, fmt.Errorf("no encrypted handshake session with %s", dist.SenderDID)
+	}
+	key, err := openGCM(keyWrapKey(sessionKey), dist.Nonce, dist.SealedKey, keyDistributionAAD(dist))
+	if err != nil {
+		return nil, fmt.Errorf("open workspace key: %w", err)
+	}
+	return key, nil
+}
+
+// keyWrapKey derives a dedicated key-wrapping key so the session key is
+// never used directly for more than one purpose.
+func keyWrapKey(sessionKey []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte("lango-workspace-key-wrap"))
+	h.Write(sessionKey)
+	return h.Sum(nil)
+}
+
+func keyDistributionAAD(d *KeyDistribution) []byte {
+	return []byte("lango-workspace-key|" + d.WorkspaceID + "|" + strconv.FormatUint(d.Epoch, 10) +
+		"|" + d.SenderDID + "|" + d.RecipientDID)
+}
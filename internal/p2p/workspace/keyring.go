@@ -0,0 +1,251 @@
+package workspace
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	groupKeySize = 32
+
+	// DefaultKeyGrace is how long the previous group key still decrypts
+	// messages after a rotation, covering messages already in flight.
+	DefaultKeyGrace = time.Minute
+)
+
+// GroupKey is a symmetric workspace key identified by a monotonically
+// increasing epoch.
+type GroupKey struct {
+	Epoch     uint64
+	Key       []byte
+	CreatedAt time.Time
+}
+
+type keyState struct {
+	current   GroupKey
+	previous  *GroupKey
+	rotatedAt time.Time
+}
+
+// GroupKeyring holds the current group key of every workspace the local
+// agent belongs to. Keys are kept in memory only; after a restart they are
+// re-established through the key exchange protocol.
+type GroupKeyring struct {
+	mu    sync.RWMutex
+	keys  map[string]*keyState // workspaceID → key state
+	grace time.Duration
+	now   func() time.Time
+}
+
+// NewGroupKeyring creates an empty keyring. A non-positive grace uses
+// DefaultKeyGrace.
+func NewGroupKeyring(grace time.Duration) *GroupKeyring {
+	if grace <= 0 {
+		grace = DefaultKeyGrace
+	}
+	return &GroupKeyring{
+		keys:  make(map[string]*keyState),
+		grace: grace,
+		now:   time.Now,
+	}
+}
+
+// Rotate generates a fresh key for the workspace at the next epoch.
+func (r *GroupKeyring) Rotate(workspaceID string) (GroupKey, error) {
+	key := make([]byte, groupKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return GroupKey{}, fmt.Errorf("generate group key: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var epoch uint64 = 1
+	if st, ok := r.keys[workspaceID]; ok {
+		epoch = st.current.Epoch + 1
+	}
+	gk := GroupKey{Epoch: epoch, Key: key, CreatedAt: r.now()}
+	r.installLocked(workspaceID, gk)
+	return gk, nil
+}
+
+// Install stores a key received from another member. Only newer epochs are
+// accepted; re-installing the current epoch with the same key is a no-op.
+func (r *GroupKeyring) Install(workspaceID string, epoch uint64, key []byte) error {
+	if len(key) != groupKeySize {
+		return fmt.Errorf("invalid group key size %d", len(key))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if st, ok := r.keys[workspaceID]; ok {
+		if epoch == st.current.Epoch && string(key) == string(st.current.Key) {
+			return nil
+		}
+		if epoch <= st.current.Epoch {
+			return fmt.Errorf("stale group key epoch %d (current %d)", epoch, st.current.Epoch)
+		}
+	}
+
+	k := make([]byte, len(key))
+	copy(k, key)
+	r.installLocked(workspaceID, GroupKey{Epoch: epoch, Key: k, CreatedAt: r.now()})
+	return nil
+}
+
+func (r *GroupKeyring) installLocked(workspaceID string, gk GroupKey) {
+	st, ok := r.keys[workspaceID]
+	if !ok {
+		r.keys[workspaceID] = &keyState{current: gk}
+		return
+	}
+	prev := st.current
+	st.previous = &prev
+	st.current = gk
+	st.rotatedAt = r.now()
+}
+
+// Current returns the current key of a workspace.
+func (r *GroupKeyring) Current(workspaceID string) (GroupKey, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	st, ok := r.keys[workspaceID]
+	if !ok {
+		return GroupKey{}, false
+	}
+	return st.current, true
+}
+
+// Key returns the key for an epoch: the current one, or the previous one
+// while it is still inside the rotation grace window.
+func (r *GroupKeyring) Key(workspaceID string, epoch uint64) ([]byte, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	st, ok := r.keys[workspaceID]
+	if !ok {
+		return nil, false
+	}
+	if st.current.Epoch == epoch {
+		return st.current.Key, true
+	}
+	if st.previous != nil && st.previous.Epoch == epoch && r.now().Sub(st.rotatedAt) <= r.grace {
+		return st.previous.Key, true
+	}
+	return nil, false
+}
+
+// Forget drops all keys for a workspace.
+func (r *GroupKeyring) Forget(workspaceID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.keys, workspaceID)
+}
+
+// Envelope is the encrypted wire form of a signed workspace message.
+type Envelope struct {
+	WorkspaceID string `json:"workspaceId"`
+	Epoch       uint64 `json:"epoch"`
+	Nonce       []byte `json:"nonce"`
+	Ciphertext  []byte `json:"ciphertext"`
+}
+
+// Seal encrypts a signed message under the workspace's current group key.
+func (r *GroupKeyring) Seal(msg Message) ([]byte, error) {
+	gk, ok := r.Current(msg.WorkspaceID)
+	if !ok {
+		return nil, fmt.Errorf("workspace %s: %w", msg.WorkspaceID, ErrNoGroupKey)
+	}
+
+	plaintext, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal message: %w", err)
+	}
+	nonce, ciphertext, err := sealGCM(gk.Key, plaintext, envelopeAAD(msg.WorkspaceID, gk.Epoch))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(Envelope{
+		WorkspaceID: msg.WorkspaceID,
+		Epoch:       gk.Epoch,
+		Nonce:       nonce,
+		Ciphertext:  ciphertext,
+	})
+}
+
+// Open decrypts an envelope received on the given workspace topic. The
+// envelope and the inner message must both name that workspace.
+func (r *GroupKeyring) Open(workspaceID string, data []byte) (Message, error) {
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Message{}, fmt.Errorf("%w: decode envelope: %v", ErrUndecryptable, err)
+	}
+	if env.WorkspaceID != workspaceID {
+		return Message{}, fmt.Errorf("%w: envelope for workspace %q on topic %q", ErrUndecryptable, env.WorkspaceID, workspaceID)
+	}
+	key, ok := r.Key(workspaceID, env.Epoch)
+	if !ok {
+		return Message{}, fmt.Errorf("%w: epoch %d: %v", ErrUndecryptable, env.Epoch, ErrNoGroupKey)
+	}
+
+	plaintext, err := openGCM(key, env.Nonce, env.Ciphertext, envelopeAAD(workspaceID, env.Epoch))
+	if err != nil {
+		return Message{}, fmt.Errorf("%w: %v", ErrUndecryptable, err)
+	}
+
+	var msg Message
+	if err := json.Unmarshal(plaintext, &msg); err != nil {
+		return Message{}, fmt.Errorf("%w: decode message: %v", ErrUndecryptable, err)
+	}
+	if msg.WorkspaceID != workspaceID {
+		return Message{}, fmt.Errorf("%w: message for workspace %q sealed under %q", ErrUndecryptable, msg.WorkspaceID, workspaceID)
+	}
+	return msg, nil
+}
+
+func envelopeAAD(workspaceID string, epoch uint64) []byte {
+	return []byte("lango-workspace-msg|" + workspaceID + "|" + strconv.FormatUint(epoch, 10))
+}
+
+func sealGCM(key, plaintext, aad []byte) (nonce, ciphertext []byte, err error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return nonce, gcm.Seal(nil, nonce, plaintext, aad), nil
+}
+
+func openGCM(key, nonce, ciphertext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce size %d", len(nonce))
+	}
+	return gcm.Open(nil, nonce, ciphertext, aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create GCM: %w", err)
+	}
+	return gcm, nil
+}
@@ -0,0 +1,205 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGroupKeyring_RotateAndGrace(t *testing.T) {
+	r := NewGroupKeyring(time.Minute)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	_, ok := r.Current("ws")
+	assert.False(t, ok)
+
+	k1, err := r.Rotate("ws")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), k1.Epoch)
+
+	k2, err := r.Rotate("ws")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), k2.Epoch)
+
+	_, ok = r.Key("ws", 1)
+	assert.True(t, ok, "previous key usable during grace")
+
+	now = now.Add(2 * time.Minute)
+	_, ok = r.Key("ws", 1)
+	assert.False(t, ok, "previous key expired after grace")
+	_, ok = r.Key("ws", 2)
+	assert.True(t, ok)
+
+	r.Forget("ws")
+	_, ok = r.Current("ws")
+	assert.False(t, ok)
+}
+
+func TestGroupKeyring_Install(t *testing.T) {
+	r := NewGroupKeyring(0)
+	key := make([]byte, groupKeySize)
+
+	require.NoError(t, r.Install("ws", 3, key))
+	require.NoError(t, r.Install("ws", 3, key), "re-install is a no-op")
+	assert.Error(t, r.Install("ws", 2, key), "stale epoch")
+	assert.Error(t, r.Install("ws", 4, []byte("short")))
+
+	gk, ok := r.Current("ws")
+	require.True(t, ok)
+	assert.Equal(t, uint64(3), gk.Epoch)
+}
+
+func TestGroupKeyring_SealOpen(t *testing.T) {
+	sender := NewGroupKeyring(0)
+	gk, err := sender.Rotate("ws")
+	require.NoError(t, err)
+
+	receiver := NewGroupKeyring(0)
+	require.NoError(t, receiver.Install("ws", gk.Epoch, gk.Key))
+
+	msg := Message{ID: "m1", WorkspaceID: "ws", Type: MessageTypeKnowledgeShare, Content: "secret", Signature: []byte{1}}
+	data, err := sender.Seal(msg)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	got, err := receiver.Open("ws", data)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", got.Content)
+	assert.Equal(t, []byte{1}, got.Signature)
+
+	// Wrong topic.
+	_, err = receiver.Open("other", data)
+	assert.ErrorIs(t, err, ErrUndecryptable)
+
+	// Outsider without the key.
+	_, err = NewGroupKeyring(0).Open("ws", data)
+	assert.ErrorIs(t, err, ErrUndecryptable)
+
+	// Plaintext JSON is not accepted.
+	_, err = receiver.Open("ws", []byte(`{"id":"m2","workspaceId":"ws"}`))
+	assert.ErrorIs(t, err, ErrUndecryptable)
+
+	// No key to seal with.
+	_, err = receiver.Seal(Message{WorkspaceID: "none"})
+	assert.ErrorIs(t, err, ErrNoGroupKey)
+}
+
+func TestSealGroupKey(t *testing.T) {
+	session := []byte("0123456789abcdef0123456789abcdef")
+	gk := GroupKey{Epoch: 2, Key: make([]byte, groupKeySize)}
+
+	dist, err := SealGroupKey(session, "ws", "did:lango:a", "did:lango:b", gk)
+	require.NoError(t, err)
+
+	key, err := OpenGroupKey(session, dist)
+	require.NoError(t, err)
+	assert.Equal(t, gk.Key, key)
+
+	redirected := *dist
+	redirected.RecipientDID = "did:lango:c"
+	_, err = OpenGroupKey(session, &redirected)
+	assert.Error(t, err, "AAD binds the recipient")
+
+	_, err = OpenGroupKey([]byte("other-session-key-other-session!"), dist)
+	assert.Error(t, err)
+
+	_, err = SealGroupKey(nil, "ws", "did:lango:a", "did:lango:b", gk)
+	assert.Error(t, err, "sessions without a KEM key cannot carry group keys")
+}
+
+func TestKeyLeader(t *testing.T) {
+	now := time.Now()
+	members := []*Member{
+		{DID: "did:lango:c", Role: RoleMember, JoinedAt: now.Add(time.Minute)},
+		{DID: "did:lango:b", Role: RoleMember, JoinedAt: now},
+		{DID: "did:lango:a", Role: RoleCreator, JoinedAt: now.Add(time.Hour)},
+	}
+	assert.Equal(t, "did:lango:a", KeyLeader(members))
+	assert.Equal(t, "did:lango:b", KeyLeader(members[:2]))
+	assert.Empty(t, KeyLeader(nil))
+}
+
+func TestKeyExchange_DistributeAndFetch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	hostA, err := libp2p.New()
+	require.NoError(t, err)
+	defer hostA.Close()
+	hostB, err := libp2p.New()
+	require.NoError(t, err)
+	defer hostB.Close()
+	require.NoError(t, hostB.Connect(ctx, peer.AddrInfo{ID: hostA.ID(), Addrs: hostA.Addrs()}))
+
+	const didA, didB, didC = "did:lango:a", "did:lango:b", "did:lango:c"
+	peers := map[string]peer.ID{didA: hostA.ID(), didB: hostB.ID()}
+	resolve := func(did string) (peer.ID, error) {
+		if pid, ok := peers[did]; ok {
+			return pid, nil
+		}
+		return "", fmt.Errorf("unknown DID %s", did)
+	}
+	session := []byte("shared-kem-session-key-32-bytes!")
+	sessionKey := func(string) []byte { return session }
+
+	members := []*Member{
+		{DID: didA, Role: RoleCreator, JoinedAt: time.Now()},
+		{DID: didB, Role: RoleMember, JoinedAt: time.Now()},
+	}
+	membersFn := func(string) ([]*Member, error) { return members, nil }
+
+	ringA, ringB := NewGroupKeyring(0), NewGroupKeyring(0)
+	kxA := NewKeyExchange(KeyExchangeConfig{
+		Host: hostA, LocalDID: didA, Keyring: ringA, SessionKey: sessionKey,
+		ResolvePeer: resolve, Members: membersFn, Logger: zap.NewNop().Sugar(),
+	})
+	kxB := NewKeyExchange(KeyExchangeConfig{
+		Host: hostB, LocalDID: didB, Keyring: ringB, SessionKey: sessionKey,
+		ResolvePeer: resolve, Members: membersFn, Logger: zap.NewNop().Sugar(),
+	})
+	hostA.SetStreamHandler(KeyProtocolID, kxA.StreamHandler())
+	hostB.SetStreamHandler(KeyProtocolID, kxB.StreamHandler())
+
+	// Leader A generates and pushes the key to B.
+	assert.True(t, kxA.IsLeader("ws"))
+	require.NoError(t, kxA.Ensure(ctx, "ws"))
+	gkA, _ := ringA.Current("ws")
+	gkB, ok := ringB.Current("ws")
+	require.True(t, ok)
+	assert.Equal(t, gkA.Key, gkB.Key)
+
+	// B loses its key and fetches it back from the leader.
+	ringB.Forget("ws")
+	require.NoError(t, kxB.Ensure(ctx, "ws"))
+	gkB, ok = ringB.Current("ws")
+	require.True(t, ok)
+	assert.Equal(t, gkA.Epoch, gkB.Epoch)
+
+	// Once B has left, A refuses to hand out the rotated key.
+	members = members[:1]
+	require.NoError(t, kxA.Rotate(ctx, "ws"))
+	err = kxB.Fetch(ctx, "ws", didA)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrNotMember.Error())
+
+	// A peer cannot claim another member's DID.
+	peers[didC] = hostA.ID()
+	members = append(members, &Member{DID: didC, Role: RoleMember})
+	kxImpostor := NewKeyExchange(KeyExchangeConfig{
+		Host: hostB, LocalDID: didC, Keyring: NewGroupKeyring(0), SessionKey: sessionKey,
+		ResolvePeer: resolve, Members: membersFn, Logger: zap.NewNop().Sugar(),
+	})
+	err = kxImpostor.Fetch(ctx, "ws", didA)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrNoGroupKey))
+	assert.Contains(t, err.Error(), "does not match remote peer")
+}
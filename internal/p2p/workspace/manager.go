@@ -24,6 +24,15 @@ type ManagerConfig struct {
 	LocalDID      string
 	MaxWorkspaces int
 	Logger        *zap.SugaredLogger
+
+	// Signer signs locally composed messages. When nil, messages are stored
+	// unsigned.
+	Signer Signer
+	// Verifiers maps signature algorithms to verifiers. When set, Post and
+	// Read accept only messages signed by a workspace member.
+	Verifiers map[string]SignatureVerifyFunc
+	// OnRejected is notified of every message dropped by verification.
+	OnRejected RejectionHandler
 }
 
 // Manager manages workspace lifecycle with BoltDB persistence.
@@ -32,6 +41,9 @@ type Manager struct {
 	localDID      string
 	maxWorkspaces int
 	logger        *zap.SugaredLogger
+	signer        Signer
+	verifiers     map[string]SignatureVerifyFunc
+	onRejected    RejectionHandler
 
 	mu         sync.RWMutex
 	workspaces map[string]*Workspace
@@ -64,6 +76,9 @@ func NewManager(cfg ManagerConfig) (*Manager, error) {
 		localDID:      cfg.LocalDID,
 		maxWorkspaces: cfg.MaxWorkspaces,
 		logger:        cfg.Logger,
+		signer:        cfg.Signer,
+		verifiers:     cfg.Verifiers,
+		onRejected:    cfg.OnRejected,
 		workspaces:    make(map[string]*Workspace),
 	}
 
@@ -249,7 +264,30 @@ func (m *Manager) Archive(ctx context.Context, workspaceID string) error {
 	return nil
 }
 
-// Post adds a message to a workspace.
+// Compose fills in the identity fields of a locally authored message
+// (ID, workspace, sender, timestamp) and signs it when a signer is
+// configured. The result is ready for Post and gossip publication.
+func (m *Manager) Compose(ctx context.Context, workspaceID string, msg Message) (Message, error) {
+	if msg.ID == "" {
+		msg.ID = uuid.New().String()
+	}
+	msg.WorkspaceID = workspaceID
+	msg.SenderDID = m.localDID
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = time.Now()
+	}
+	msg.Timestamp = normalizeTimestamp(msg.Timestamp)
+
+	if m.signer != nil {
+		if err := SignMessage(ctx, &msg, m.signer); err != nil {
+			return Message{}, err
+		}
+	}
+	return msg, nil
+}
+
+// Post adds a message to a workspace. Unsigned local messages are composed
+// and signed first; signed messages are stored as-is after verification.
 func (m *Manager) Post(ctx context.Context, workspaceID string, msg Message) error {
 	m.mu.RLock()
 	ws, ok := m.workspaces[workspaceID]
@@ -261,14 +299,32 @@ func (m *Manager) Post(ctx context.Context, workspaceID string, msg Message) err
 		return fmt.Errorf("workspace %s is archived", workspaceID)
 	}
 
+	if len(msg.Signature) == 0 && m.signer != nil && (msg.SenderDID == "" || msg.SenderDID == m.localDID) {
+		composed, err := m.Compose(ctx, workspaceID, msg)
+		if err != nil {
+			return err
+		}
+		msg = composed
+	}
+
 	if msg.ID == "" {
 		msg.ID = uuid.New().String()
 	}
+	if msg.WorkspaceID != "" && msg.WorkspaceID != workspaceID && len(msg.Signature) > 0 {
+		err := fmt.Errorf("%w: message signed for workspace %q", ErrInvalidSignature, msg.WorkspaceID)
+		m.reject(workspaceID, msg, StagePost, err)
+		return err
+	}
 	msg.WorkspaceID = workspaceID
 	if msg.Timestamp.IsZero() {
 		msg.Timestamp = time.Now()
 	}
 
+	if err := m.verify(workspaceID, msg); err != nil {
+		m.reject(workspaceID, msg, StagePost, err)
+		return err
+	}
+
 	data, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("marshal message: %w", err)
@@ -281,6 +337,40 @@ func (m *Manager) Post(ctx context.Context, workspaceID string, msg Message) err
 	})
 }
 
+// verify checks the signature and workspace membership of a message. It is
+// a no-op when no verifiers are configured. A MEMBER_JOINED announcement is
+// the only message accepted from a sender that is not yet a member, and only
+// when an existing member has invited that sender.
+func (m *Manager) verify(workspaceID string, msg Message) error {
+	if m.verifiers == nil {
+		return nil
+	}
+	if err := VerifyMessage(msg, m.verifiers); err != nil {
+		return err
+	}
+	if msg.Type == MessageTypeMemberJoined && m.IsInvited(workspaceID, msg.SenderDID) {
+		return nil
+	}
+	if !m.IsMember(workspaceID, msg.SenderDID) {
+		return fmt.Errorf("%s: %w", msg.SenderDID, ErrNotMember)
+	}
+	return nil
+}
+
+func (m *Manager) reject(workspaceID string, msg Message, stage string, err error) {
+	m.logger.Warnw("workspace message rejected",
+		"workspace", workspaceID, "message", msg.ID, "sender", msg.SenderDID, "stage", stage, "error", err)
+	if m.onRejected != nil {
+		m.onRejected(Rejection{
+			WorkspaceID: workspaceID,
+			MessageID:   msg.ID,
+			SenderDID:   msg.SenderDID,
+			Stage:       stage,
+			Err:         err,
+		})
+	}
+}
+
 // Read returns messages from a workspace.
 func (m *Manager) Read(ctx context.Context, workspaceID string, opts ReadOptions) ([]Message, error) {
 	m.mu.RLock()
@@ -295,6 +385,7 @@ func (m *Manager) Read(ctx context.Context, workspaceID string, opts ReadOptions
 	}
 
 	var messages []Message
+	var rejected []rejectedMessage
 	prefix := []byte(workspaceID + "/")
 
 	err := m.db.View(func(tx *bolt.Tx) error {
@@ -310,6 +401,12 @@ func (m *Manager) Read(ctx context.Context, workspaceID string, opts ReadOptions
 			if err := json.Unmarshal(v, &msg); err != nil {
 				continue
 			}
+			if m.verifiers != nil {
+				if err := VerifyMessage(msg, m.verifiers); err != nil {
+					rejected = append(rejected, rejectedMessage{msg: msg, err: err})
+					continue
+				}
+			}
 
 			// Apply filters.
 			if !opts.Before.IsZero() && !msg.Timestamp.Before(opts.Before) {
@@ -345,9 +442,19 @@ func (m *Manager) Read(ctx context.Context, workspaceID string, opts ReadOptions
 		return nil, fmt.Errorf("read messages: %w", err)
 	}
 
+	// Report outside the read transaction so handlers may touch the store.
+	for _, r := range rejected {
+		m.reject(workspaceID, r.msg, StageRead, r.err)
+	}
+
 	return messages, nil
 }
 
+type rejectedMessage struct {
+	msg Message
+	err error
+}
+
 // AddMember adds a remote member to a workspace.
 func (m *Manager) AddMember(ctx context.Context, workspaceID string, member *Member) error {
 	m.mu.Lock()
@@ -365,11 +472,139 @@ func (m *Manager) AddMember(ctx context.Context, workspaceID string, member *Mem
 	}
 
 	ws.Members = append(ws.Members, member)
+	ws.Invites = dropInvite(ws.Invites, member.DID)
+	ws.UpdatedAt = time.Now()
+
+	return m.persist(ws)
+}
+
+// Invite records that invitedBy, an existing member, admits did to the
+// workspace. The invite lets did announce MEMBER_JOINED and fetch the group
+// key; it is consumed when did is added as a member.
+func (m *Manager) Invite(ctx context.Context, workspaceID, did, invitedBy string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ws, ok := m.workspaces[workspaceID]
+	if !ok {
+		return fmt.Errorf("workspace %s: %w", workspaceID, ErrWorkspaceNotFound)
+	}
+	if ws.Status == StatusArchived {
+		return fmt.Errorf("workspace %s is archived", workspaceID)
+	}
+	if !hasMember(ws, invitedBy) {
+		return fmt.Errorf("inviter %s: %w", invitedBy, ErrNotMember)
+	}
+	if hasMember(ws, did) {
+		return nil
+	}
+	for _, inv := range ws.Invites {
+		if inv.DID == did {
+			return nil // already invited
+		}
+	}
+
+	ws.Invites = append(ws.Invites, &Invite{DID: did, InvitedBy: invitedBy, CreatedAt: time.Now()})
 	ws.UpdatedAt = time.Now()
 
 	return m.persist(ws)
 }
 
+// IsInvited reports whether did holds an unconsumed invite to the workspace.
+func (m *Manager) IsInvited(workspaceID, did string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ws, ok := m.workspaces[workspaceID]
+	if !ok {
+		return false
+	}
+	for _, inv := range ws.Invites {
+		if inv.DID == did {
+			return true
+		}
+	}
+	return false
+}
+
+func hasMember(ws *Workspace, did string) bool {
+	for _, mem := range ws.Members {
+		if mem.DID == did {
+			return true
+		}
+	}
+	return false
+}
+
+func dropInvite(invites []*Invite, did string) []*Invite {
+	kept := invites[:0]
+	for _, inv := range invites {
+		if inv.DID != did {
+			kept = append(kept, inv)
+		}
+	}
+	return kept
+}
+
+// RemoveMember removes a remote member from a workspace.
+func (m *Manager) RemoveMember(ctx context.Context, workspaceID, did string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ws, ok := m.workspaces[workspaceID]
+	if !ok {
+		return fmt.Errorf("workspace %s: %w", workspaceID, ErrWorkspaceNotFound)
+	}
+
+	members := make([]*Member, 0, len(ws.Members))
+	for _, mem := range ws.Members {
+		if mem.DID != did {
+			members = append(members, mem)
+		}
+	}
+	if len(members) == len(ws.Members) {
+		return nil // not a member
+	}
+	ws.Members = members
+	ws.UpdatedAt = time.Now()
+
+	return m.persist(ws)
+}
+
+// IsMember reports whether did is a member of the workspace.
+func (m *Manager) IsMember(workspaceID, did string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ws, ok := m.workspaces[workspaceID]
+	if !ok {
+		return false
+	}
+	for _, mem := range ws.Members {
+		if mem.DID == did {
+			return true
+		}
+	}
+	return false
+}
+
+// Members returns a copy of the workspace member list.
+func (m *Manager) Members(workspaceID string) ([]*Member, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ws, ok := m.workspaces[workspaceID]
+	if !ok {
+		return nil, fmt.Errorf("workspace %s: %w", workspaceID, ErrWorkspaceNotFound)
+	}
+	members := make([]*Member, len(ws.Members))
+	for i, mem := range ws.Members {
+		cp := *mem
+		members[i] = &cp
+	}
+	return members, nil
+}
+
 func (m *Manager) persist(ws *Workspace) error {
 	data, err := json.Marshal(ws)
 	if err != nil {
@@ -366,6 +366,36 @@ func TestManager_AddMember(t *testing.T) {
 	assert.Equal(t, "Remote Agent 1", got.Members[1].Name)
 }
 
+func TestManager_Invite(t *testing.T) {
+	m := newTestManager(t)
+	ctx := context.Background()
+
+	ws, err := m.Create(ctx, CreateRequest{Name: "ws", Goal: "goal"})
+	require.NoError(t, err)
+
+	// Only members may invite.
+	err = m.Invite(ctx, ws.ID, "did:lango:remote-1", "did:lango:stranger")
+	assert.ErrorIs(t, err, ErrNotMember)
+	assert.False(t, m.IsInvited(ws.ID, "did:lango:remote-1"))
+
+	require.NoError(t, m.Invite(ctx, ws.ID, "did:lango:remote-1", "did:lango:test-local"))
+	require.NoError(t, m.Invite(ctx, ws.ID, "did:lango:remote-1", "did:lango:test-local"))
+	assert.True(t, m.IsInvited(ws.ID, "did:lango:remote-1"))
+
+	got, err := m.Get(ctx, ws.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Invites, 1, "no duplicate invite")
+	assert.Equal(t, "did:lango:test-local", got.Invites[0].InvitedBy)
+
+	// Joining consumes the invite.
+	require.NoError(t, m.AddMember(ctx, ws.ID, &Member{DID: "did:lango:remote-1", Role: RoleMember, JoinedAt: time.Now()}))
+	assert.False(t, m.IsInvited(ws.ID, "did:lango:remote-1"))
+
+	got, err = m.Get(ctx, ws.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.Invites)
+}
+
 func TestManager_AddMember_AlreadyExists(t *testing.T) {
 	m := newTestManager(t)
 	ctx := context.Background()
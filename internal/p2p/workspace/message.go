@@ -14,6 +14,7 @@ const (
 	MessageTypeKnowledgeShare MessageType = "KNOWLEDGE_SHARE"
 	MessageTypeMemberJoined   MessageType = "MEMBER_JOINED"
 	MessageTypeMemberLeft     MessageType = "MEMBER_LEFT"
+	MessageTypeMemberInvited  MessageType = "MEMBER_INVITED"
 
 	// Conflict and branch collaboration message types.
 	MessageTypeConflictReport MessageType = "CONFLICT_REPORT"
@@ -22,6 +23,10 @@ const (
 	MessageTypeSyncRequest    MessageType = "SYNC_REQUEST"
 )
 
+// MetaInvitee is the Metadata key holding the invited DID of a
+// MEMBER_INVITED message.
+const MetaInvitee = "invitee"
+
 // Message represents a message posted to a workspace.
 type Message struct {
 	ID          string            `json:"id"`
@@ -32,4 +37,9 @@ type Message struct {
 	Metadata    map[string]string `json:"metadata,omitempty"`
 	ParentID    string            `json:"parentId,omitempty"`
 	Timestamp   time.Time         `json:"timestamp"`
+
+	// SignatureAlgorithm and Signature authenticate the message to SenderDID.
+	// The signature covers SigningPayload, i.e. every field above.
+	SignatureAlgorithm string `json:"signatureAlgorithm,omitempty"`
+	Signature          []byte `json:"signature,omitempty"`
 }
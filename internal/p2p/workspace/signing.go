@@ -0,0 +1,110 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Signer signs workspace messages with the local identity key. The
+// handshake signers (wallet secp256k1 or bundle Ed25519) satisfy it.
+type Signer interface {
+	SignMessage(ctx context.Context, message []byte) ([]byte, error)
+	Algorithm() string
+}
+
+// SignatureVerifyFunc verifies sig over payload for the given signer DID.
+type SignatureVerifyFunc func(signerDID string, payload, sig []byte) error
+
+// Rejection stages identify where a message failed verification.
+const (
+	StageGossip = "gossip"
+	StagePost   = "post"
+	StageRead   = "read"
+)
+
+// Rejection describes a workspace message that was dropped because it could
+// not be decrypted, verified, or attributed to a workspace member.
+type Rejection struct {
+	WorkspaceID string
+	MessageID   string
+	SenderDID   string
+	PeerID      string
+	Stage       string
+	Err         error
+}
+
+// RejectionHandler is called for every dropped workspace message.
+type RejectionHandler func(Rejection)
+
+// signingPayload is the canonical signed form of a Message. Field order is
+// fixed by the struct and the timestamp is reduced to Unix nanoseconds so the
+// payload survives JSON round-trips unchanged.
+type signingPayload struct {
+	ID          string            `json:"id"`
+	Type        MessageType       `json:"type"`
+	WorkspaceID string            `json:"workspaceId"`
+	SenderDID   string            `json:"senderDid"`
+	Content     string            `json:"content"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	ParentID    string            `json:"parentId,omitempty"`
+	Timestamp   int64             `json:"timestamp"`
+	Algorithm   string            `json:"algorithm"`
+}
+
+// SigningPayload returns the canonical bytes covered by the message signature.
+func SigningPayload(msg Message) ([]byte, error) {
+	return json.Marshal(signingPayload{
+		ID:          msg.ID,
+		Type:        msg.Type,
+		WorkspaceID: msg.WorkspaceID,
+		SenderDID:   msg.SenderDID,
+		Content:     msg.Content,
+		Metadata:    msg.Metadata,
+		ParentID:    msg.ParentID,
+		Timestamp:   msg.Timestamp.UnixNano(),
+		Algorithm:   msg.SignatureAlgorithm,
+	})
+}
+
+// SignMessage signs msg in place with signer.
+func SignMessage(ctx context.Context, msg *Message, signer Signer) error {
+	msg.SignatureAlgorithm = signer.Algorithm()
+	payload, err := SigningPayload(*msg)
+	if err != nil {
+		return fmt.Errorf("encode signing payload: %w", err)
+	}
+	sig, err := signer.SignMessage(ctx, payload)
+	if err != nil {
+		return fmt.Errorf("sign workspace message: %w", err)
+	}
+	msg.Signature = sig
+	return nil
+}
+
+// VerifyMessage checks the message signature against SenderDID using the
+// verifier registered for its algorithm.
+func VerifyMessage(msg Message, verifiers map[string]SignatureVerifyFunc) error {
+	if len(msg.Signature) == 0 || msg.SenderDID == "" {
+		return ErrUnsignedMessage
+	}
+	verify, ok := verifiers[msg.SignatureAlgorithm]
+	if !ok {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, msg.SignatureAlgorithm)
+	}
+	payload, err := SigningPayload(msg)
+	if err != nil {
+		return fmt.Errorf("encode signing payload: %w", err)
+	}
+	if err := verify(msg.SenderDID, payload, msg.Signature); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return nil
+}
+
+// normalizeTimestamp drops the monotonic clock reading and sub-nanosecond
+// location detail so the signed timestamp matches what peers decode.
+func normalizeTimestamp(t time.Time) time.Time {
+	return time.Unix(0, t.UnixNano()).UTC()
+}
@@ -0,0 +1,229 @@
+package workspace
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+// testIdentity is an Ed25519 identity whose DID maps to its public key
+// through testVerifiers.
+type testIdentity struct {
+	did  string
+	priv ed25519.PrivateKey
+}
+
+func newTestIdentity(t *testing.T, did string) *testIdentity {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return &testIdentity{did: did, priv: priv}
+}
+
+func (id *testIdentity) SignMessage(_ context.Context, message []byte) ([]byte, error) {
+	return ed25519.Sign(id.priv, message), nil
+}
+
+func (id *testIdentity) Algorithm() string { return "ed25519" }
+
+func testVerifiers(ids ...*testIdentity) map[string]SignatureVerifyFunc {
+	keys := make(map[string]ed25519.PublicKey, len(ids))
+	for _, id := range ids {
+		keys[id.did] = id.priv.Public().(ed25519.PublicKey)
+	}
+	return map[string]SignatureVerifyFunc{
+		"ed25519": func(did string, payload, sig []byte) error {
+			pub, ok := keys[did]
+			if !ok {
+				return errors.New("unknown DID")
+			}
+			if !ed25519.Verify(pub, payload, sig) {
+				return errors.New("bad signature")
+			}
+			return nil
+		},
+	}
+}
+
+func newSigningManager(t *testing.T, local *testIdentity, verifiers map[string]SignatureVerifyFunc, rejected *[]Rejection) *Manager {
+	t.Helper()
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "test.db"), 0o600, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := NewManager(ManagerConfig{
+		DB:        db,
+		LocalDID:  local.did,
+		Logger:    zap.NewNop().Sugar(),
+		Signer:    local,
+		Verifiers: verifiers,
+		OnRejected: func(r Rejection) {
+			*rejected = append(*rejected, r)
+		},
+	})
+	require.NoError(t, err)
+	return m
+}
+
+func TestSignAndVerifyMessage(t *testing.T) {
+	alice := newTestIdentity(t, "did:lango:alice")
+	verifiers := testVerifiers(alice)
+
+	msg := Message{
+		ID:          "m1",
+		Type:        MessageTypeCommitSignal,
+		WorkspaceID: "ws",
+		SenderDID:   alice.did,
+		Content:     "pushed",
+		Metadata:    map[string]string{"headCommit": "abc"},
+		Timestamp:   normalizeTimestamp(time.Now()),
+	}
+	require.NoError(t, SignMessage(context.Background(), &msg, alice))
+	assert.Equal(t, "ed25519", msg.SignatureAlgorithm)
+	require.NoError(t, VerifyMessage(msg, verifiers))
+
+	tampered := msg
+	tampered.Metadata = map[string]string{"headCommit": "evil"}
+	assert.ErrorIs(t, VerifyMessage(tampered, verifiers), ErrInvalidSignature)
+
+	spoofed := msg
+	spoofed.SenderDID = "did:lango:mallory"
+	assert.ErrorIs(t, VerifyMessage(spoofed, verifiers), ErrInvalidSignature)
+
+	unsigned := msg
+	unsigned.Signature = nil
+	assert.ErrorIs(t, VerifyMessage(unsigned, verifiers), ErrUnsignedMessage)
+
+	unknownAlg := msg
+	unknownAlg.SignatureAlgorithm = "rsa"
+	assert.ErrorIs(t, VerifyMessage(unknownAlg, verifiers), ErrInvalidSignature)
+}
+
+func TestManager_Post_SignsLocalMessages(t *testing.T) {
+	alice := newTestIdentity(t, "did:lango:alice")
+	var rejected []Rejection
+	m := newSigningManager(t, alice, testVerifiers(alice), &rejected)
+	ctx := context.Background()
+
+	ws, err := m.Create(ctx, CreateRequest{Name: "ws"})
+	require.NoError(t, err)
+
+	require.NoError(t, m.Post(ctx, ws.ID, Message{Type: MessageTypeKnowledgeShare, Content: "hello"}))
+
+	msgs, err := m.Read(ctx, ws.ID, ReadOptions{})
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, alice.did, msgs[0].SenderDID)
+	assert.NotEmpty(t, msgs[0].Signature)
+	assert.Empty(t, rejected)
+}
+
+func TestManager_Post_RejectsUnverifiable(t *testing.T) {
+	alice := newTestIdentity(t, "did:lango:alice")
+	bob := newTestIdentity(t, "did:lango:bob")
+	mallory := newTestIdentity(t, "did:lango:mallory")
+	var rejected []Rejection
+	m := newSigningManager(t, alice, testVerifiers(alice, bob, mallory), &rejected)
+	ctx := context.Background()
+
+	ws, err := m.Create(ctx, CreateRequest{Name: "ws"})
+	require.NoError(t, err)
+	require.NoError(t, m.AddMember(ctx, ws.ID, &Member{DID: bob.did, Role: RoleMember, JoinedAt: time.Now()}))
+
+	compose := func(id *testIdentity, typ MessageType) Message {
+		msg := Message{
+			ID:          id.did + "-" + string(typ),
+			Type:        typ,
+			WorkspaceID: ws.ID,
+			SenderDID:   id.did,
+			Content:     "x",
+			Timestamp:   normalizeTimestamp(time.Now()),
+		}
+		require.NoError(t, SignMessage(ctx, &msg, id))
+		return msg
+	}
+
+	// A member's signed message is accepted unchanged.
+	require.NoError(t, m.Post(ctx, ws.ID, compose(bob, MessageTypeCommitSignal)))
+
+	// Spoofed sender: Mallory signs but claims to be Bob.
+	spoofed := compose(mallory, MessageTypeCommitSignal)
+	spoofed.SenderDID = bob.did
+	assert.ErrorIs(t, m.Post(ctx, ws.ID, spoofed), ErrInvalidSignature)
+
+	// Valid signature from a non-member.
+	assert.ErrorIs(t, m.Post(ctx, ws.ID, compose(mallory, MessageTypeKnowledgeShare)), ErrNotMember)
+
+	// Uninvited peers may not announce themselves; invited ones may.
+	assert.ErrorIs(t, m.Post(ctx, ws.ID, compose(mallory, MessageTypeMemberJoined)), ErrNotMember)
+	require.NoError(t, m.Invite(ctx, ws.ID, mallory.did, alice.did))
+	require.NoError(t, m.Post(ctx, ws.ID, compose(mallory, MessageTypeMemberJoined)))
+
+	// Unsigned remote message.
+	assert.ErrorIs(t, m.Post(ctx, ws.ID, Message{SenderDID: bob.did, Content: "x"}), ErrUnsignedMessage)
+
+	require.Len(t, rejected, 4)
+	for _, r := range rejected {
+		assert.Equal(t, StagePost, r.Stage)
+		assert.Equal(t, ws.ID, r.WorkspaceID)
+	}
+	assert.Equal(t, bob.did, rejected[0].SenderDID)
+}
+
+func TestManager_Read_DropsUnverifiable(t *testing.T) {
+	alice := newTestIdentity(t, "did:lango:alice")
+	var rejected []Rejection
+	m := newSigningManager(t, alice, testVerifiers(alice), &rejected)
+	ctx := context.Background()
+
+	ws, err := m.Create(ctx, CreateRequest{Name: "ws"})
+	require.NoError(t, err)
+	require.NoError(t, m.Post(ctx, ws.ID, Message{Content: "signed"}))
+
+	// Plant an unsigned message directly in the store, bypassing Post.
+	plain := newTestManagerFromDB(t, m.db, alice.did)
+	require.NoError(t, plain.Post(ctx, ws.ID, Message{ID: "forged", SenderDID: "did:lango:mallory", Content: "forged"}))
+
+	msgs, err := m.Read(ctx, ws.ID, ReadOptions{})
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "signed", msgs[0].Content)
+
+	require.Len(t, rejected, 1)
+	assert.Equal(t, StageRead, rejected[0].Stage)
+	assert.Equal(t, "forged", rejected[0].MessageID)
+	assert.ErrorIs(t, rejected[0].Err, ErrUnsignedMessage)
+}
+
+func TestManager_RemoveMember(t *testing.T) {
+	m := newTestManager(t)
+	ctx := context.Background()
+
+	ws, err := m.Create(ctx, CreateRequest{Name: "ws"})
+	require.NoError(t, err)
+	require.NoError(t, m.AddMember(ctx, ws.ID, &Member{DID: "did:lango:bob", Role: RoleMember}))
+	assert.True(t, m.IsMember(ws.ID, "did:lango:bob"))
+
+	require.NoError(t, m.RemoveMember(ctx, ws.ID, "did:lango:bob"))
+	assert.False(t, m.IsMember(ws.ID, "did:lango:bob"))
+
+	members, err := m.Members(ws.ID)
+	require.NoError(t, err)
+	assert.Len(t, members, 1)
+}
+
+func newTestManagerFromDB(t *testing.T, db *bolt.DB, localDID string) *Manager {
+	t.Helper()
+	m, err := NewManager(ManagerConfig{DB: db, LocalDID: localDID, Logger: zap.NewNop().Sugar()})
+	require.NoError(t, err)
+	return m
+}
@@ -20,6 +20,7 @@ type Workspace struct {
 	Goal      string            `json:"goal"`
 	Status    Status            `json:"status"`
 	Members   []*Member         `json:"members"`
+	Invites   []*Invite         `json:"invites,omitempty"`
 	CreatedAt time.Time         `json:"createdAt"`
 	UpdatedAt time.Time         `json:"updatedAt"`
 	Metadata  map[string]string `json:"metadata,omitempty"`
@@ -41,6 +42,14 @@ type Member struct {
 	JoinedAt time.Time `json:"joinedAt"`
 }
 
+// Invite admits a DID that is not yet a member. It is created by an existing
+// member and consumed when the invitee is added as a member.
+type Invite struct {
+	DID       string    `json:"did"`
+	InvitedBy string    `json:"invitedBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 // CreateRequest holds parameters for creating a new workspace.
 type CreateRequest struct {
 	Name     string            `json:"name"`
@@ -0,0 +1,236 @@
+// Package ceremony runs a multi-party trusted setup for the ZKP circuits, so
+// no single party knows the setup secret that would let it forge proofs.
+//
+// Phase 1 is a universal powers-of-tau ceremony; its sealed output is the KZG
+// SRS used by PlonK. Phase 2 runs per circuit on top of phase 1 and produces
+// Groth16 proving and verifying keys. Every contribution is a file in the
+// ceremony directory, recorded in ceremony.json together with a running
+// transcript hash that commits to every earlier contribution.
+//
+// A ceremony is driven with Init, then Contribute once per participant, then
+// Finalize with a public random beacon (twice for Groth16: once to close
+// phase 1 and open phase 2, once to produce the keys). Verify re-checks the
+// whole chain at any point.
+package ceremony
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/langoai/lango/internal/p2p/zkp"
+)
+
+// File layout of a ceremony directory.
+const (
+	ManifestFile = "ceremony.json"
+	FinalDir     = "final"
+	SRSFile      = "srs.bin"
+
+	phase1Dir = "phase1"
+	phase2Dir = "phase2"
+
+	// phase1Label keys the phase 1 file in a contribution; phase 2 files are
+	// keyed by circuit ID.
+	phase1Label = "phase1"
+
+	manifestVersion = 1
+)
+
+// Sentinel errors for ceremony operations.
+var (
+	ErrExists          = errors.New("ceremony already initialized")
+	ErrFinalized       = errors.New("ceremony already finalized")
+	ErrNoContributions = errors.New("no contributions in the current phase")
+	ErrTranscript      = errors.New("transcript mismatch")
+)
+
+// Manifest is the ceremony state stored in ceremony.json.
+type Manifest struct {
+	Version       int               `json:"version"`
+	Scheme        zkp.ProofScheme   `json:"scheme"`
+	DomainSize    uint64            `json:"domainSize"`
+	Circuits      []string          `json:"circuits"`
+	Phase         int               `json:"phase"`
+	Contributions []Contribution    `json:"contributions"`
+	Seals         []Seal            `json:"seals,omitempty"`
+	Outputs       map[string]string `json:"outputs,omitempty"` // relative path -> sha256
+	Finalized     bool              `json:"finalized"`
+	CreatedAt     time.Time         `json:"createdAt"`
+}
+
+// Contribution records one participant's update to the current phase.
+type Contribution struct {
+	Phase       int               `json:"phase"`
+	Index       int               `json:"index"`
+	Participant string            `json:"participant"`
+	Files       map[string]string `json:"files"`  // label -> relative path
+	Hashes      map[string]string `json:"hashes"` // label -> sha256 of the file
+	Transcript  string            `json:"transcript"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
+
+// Seal records the random beacon that closed a phase.
+type Seal struct {
+	Phase      int       `json:"phase"`
+	Beacon     string    `json:"beacon"` // hex
+	Transcript string    `json:"transcript"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// InitOptions configures a new ceremony.
+type InitOptions struct {
+	// Scheme is the proving scheme the ceremony serves (default: plonk).
+	// Groth16 adds a per-circuit phase 2.
+	Scheme zkp.ProofScheme
+
+	// Circuits are the circuit IDs the SRS must support (default: all known).
+	Circuits []string
+
+	// DomainSize overrides the phase 1 domain size. It must be a power of two
+	// at least as large as the largest circuit needs.
+	DomainSize uint64
+}
+
+// Transcript returns the transcript hash after the latest contribution or
+// seal. Entries are appended in ceremony order, so the last one is the head.
+func (m *Manifest) Transcript() string {
+	head := m.genesisTranscript()
+	for _, c := range m.contributions(1) {
+		head = c.Transcript
+	}
+	if s, ok := m.seal(1); ok {
+		head = s.Transcript
+	}
+	for _, c := range m.contributions(2) {
+		head = c.Transcript
+	}
+	if s, ok := m.seal(2); ok {
+		head = s.Transcript
+	}
+	return head
+}
+
+// contributions returns the contributions of one phase in order.
+func (m *Manifest) contributions(phase int) []Contribution {
+	var out []Contribution
+	for _, c := range m.Contributions {
+		if c.Phase == phase {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// seal returns the seal of a phase, if any.
+func (m *Manifest) seal(phase int) (Seal, bool) {
+	for _, s := range m.Seals {
+		if s.Phase == phase {
+			return s, true
+		}
+	}
+	return Seal{}, false
+}
+
+// genesisTranscript commits to the ceremony parameters.
+func (m *Manifest) genesisTranscript() string {
+	h := sha256.New()
+	h.Write([]byte("lango-zkp-ceremony/v1"))
+	h.Write([]byte(m.Scheme))
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], m.DomainSize)
+	h.Write(size[:])
+	for _, id := range m.Circuits {
+		h.Write([]byte{0})
+		h.Write([]byte(id))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// contributionTranscript chains a contribution onto prev.
+func contributionTranscript(prev string, c Contribution) string {
+	h := sha256.New()
+	h.Write([]byte(prev))
+	fmt.Fprintf(h, "|contribution|%d|%d|%s", c.Phase, c.Index, c.Participant)
+	labels := make([]string, 0, len(c.Hashes))
+	for label := range c.Hashes {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		fmt.Fprintf(h, "|%s=%s", label, c.Hashes[label])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sealTranscript chains a seal onto prev.
+func sealTranscript(prev string, s Seal) string {
+	h := sha256.New()
+	h.Write([]byte(prev))
+	fmt.Fprintf(h, "|seal|%d|%s", s.Phase, s.Beacon)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// LoadManifest reads the manifest of the ceremony in dir.
+func LoadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	if m.Version != manifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d", m.Version)
+	}
+	return &m, nil
+}
+
+// save writes the manifest atomically.
+func (m *Manifest) save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+	tmp := filepath.Join(dir, ManifestFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, ManifestFile)); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return nil
+}
+
+// SRSPath returns the finalized SRS path of the ceremony in dir, suitable for
+// p2p.zkp.srsPath.
+func SRSPath(dir string) string {
+	return filepath.Join(dir, FinalDir, SRSFile)
+}
+
+// hashFile returns the hex sha256 of a file.
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// contributionPath returns the relative path of a contribution file.
+func contributionPath(phase int, label string, index int) string {
+	name := fmt.Sprintf("%04d.bin", index)
+	if phase == 1 {
+		return filepath.Join(phase1Dir, name)
+	}
+	return filepath.Join(phase2Dir, label, name)
+}
@@ -0,0 +1,260 @@
+package ceremony
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	nativemimc "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/langoai/lango/internal/p2p/zkp"
+	"github.com/langoai/lango/internal/p2p/zkp/circuits"
+)
+
+const (
+	attestationID = "response_attestation"
+	// smallID has the smallest domain, for tests that don't prove.
+	smallID = "wallet_ownership"
+)
+
+func mimcHash(elems ...*big.Int) *big.Int {
+	h := nativemimc.NewMiMC()
+	for _, e := range elems {
+		var elem fr.Element
+		elem.SetBigInt(e)
+		b := elem.Bytes()
+		h.Write(b[:])
+	}
+	var out big.Int
+	out.SetBytes(h.Sum(nil))
+	return &out
+}
+
+func attestationAssignment() *circuits.ResponseAttestationCircuit {
+	agentKeyProof := big.NewInt(777)
+	sourceDataHash := big.NewInt(555)
+	timestamp := big.NewInt(1700000000)
+	return &circuits.ResponseAttestationCircuit{
+		ResponseHash:   mimcHash(sourceDataHash, agentKeyProof, timestamp),
+		AgentDIDHash:   mimcHash(agentKeyProof),
+		Timestamp:      timestamp,
+		MinTimestamp:   big.NewInt(1699999700),
+		MaxTimestamp:   big.NewInt(1700000030),
+		SourceDataHash: sourceDataHash,
+		AgentKeyProof:  agentKeyProof,
+	}
+}
+
+// runCeremony runs a three-party ceremony for one circuit through every
+// phase of scheme.
+func runCeremony(t *testing.T, scheme zkp.ProofScheme, circuitID string) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	_, err := Init(dir, InitOptions{Scheme: scheme, Circuits: []string{circuitID}})
+	require.NoError(t, err)
+
+	phases := 1
+	if scheme == zkp.SchemeGroth16 {
+		phases = 2
+	}
+	for phase := 1; phase <= phases; phase++ {
+		for _, who := range []string{"alice", "bob", "carol"} {
+			c, err := Contribute(dir, who)
+			require.NoError(t, err)
+			assert.Equal(t, phase, c.Phase)
+		}
+		_, err := Finalize(dir, []byte(fmt.Sprintf("beacon-%d", phase)))
+		require.NoError(t, err)
+	}
+
+	m, err := Verify(dir)
+	require.NoError(t, err)
+	assert.True(t, m.Finalized)
+	assert.Len(t, m.Contributions, 3*phases)
+	return dir
+}
+
+// productionProver returns a prover for a non-dev network that loads the
+// ceremony output in dir, so unsafe fallbacks are ruled out.
+func productionProver(t *testing.T, scheme zkp.ProofScheme, dir string) *zkp.ProverService {
+	t.Helper()
+	ps, err := zkp.NewProverService(zkp.Config{
+		CacheDir: t.TempDir(),
+		Scheme:   scheme,
+		SRSMode:  zkp.SRSModeFile,
+		SRSPath:  SRSPath(dir),
+		Logger:   zap.NewNop().Sugar(),
+	})
+	require.NoError(t, err)
+	return ps
+}
+
+func TestCeremony_PlonkAttestation(t *testing.T) {
+	t.Parallel()
+
+	dir := runCeremony(t, zkp.SchemePlonk, attestationID)
+	ps := productionProver(t, zkp.SchemePlonk, dir)
+	require.NoError(t, ps.Compile(attestationID, &circuits.ResponseAttestationCircuit{}))
+
+	assignment := attestationAssignment()
+	proof, err := ps.Prove(context.Background(), attestationID, assignment)
+	require.NoError(t, err)
+
+	public := &circuits.ResponseAttestationCircuit{
+		ResponseHash: assignment.ResponseHash,
+		AgentDIDHash: assignment.AgentDIDHash,
+		Timestamp:    assignment.Timestamp,
+		MinTimestamp: assignment.MinTimestamp,
+		MaxTimestamp: assignment.MaxTimestamp,
+	}
+	ok, err := ps.Verify(context.Background(), proof, public)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+// Groth16 runs on the smallest circuit: its phase 2 setup is far more
+// expensive than PlonK's and scales with the circuit domain.
+func TestCeremony_Groth16(t *testing.T) {
+	t.Parallel()
+
+	dir := runCeremony(t, zkp.SchemeGroth16, smallID)
+	ps := productionProver(t, zkp.SchemeGroth16, dir)
+	require.NoError(t, ps.Compile(smallID, &circuits.WalletOwnershipCircuit{}))
+
+	response, challenge := big.NewInt(42), big.NewInt(123)
+	assignment := &circuits.WalletOwnershipCircuit{
+		PublicKeyHash: mimcHash(response, challenge),
+		Challenge:     challenge,
+		Response:      response,
+	}
+	proof, err := ps.Prove(context.Background(), smallID, assignment)
+	require.NoError(t, err)
+
+	ok, err := ps.Verify(context.Background(), proof, &circuits.WalletOwnershipCircuit{
+		PublicKeyHash: assignment.PublicKeyHash,
+		Challenge:     assignment.Challenge,
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestCeremony_Lifecycle(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	_, err := Init(dir, InitOptions{Circuits: []string{smallID}})
+	require.NoError(t, err)
+
+	_, err = Init(dir, InitOptions{Circuits: []string{smallID}})
+	assert.ErrorIs(t, err, ErrExists)
+
+	_, err = Finalize(dir, []byte("beacon"))
+	assert.ErrorIs(t, err, ErrNoContributions)
+
+	_, err = Contribute(dir, "")
+	assert.Error(t, err)
+
+	_, err = Contribute(dir, "alice")
+	require.NoError(t, err)
+	_, err = Finalize(dir, nil)
+	assert.Error(t, err, "beacon required")
+
+	_, err = Finalize(dir, []byte("beacon"))
+	require.NoError(t, err)
+
+	_, err = Contribute(dir, "bob")
+	assert.ErrorIs(t, err, ErrFinalized)
+	_, err = Finalize(dir, []byte("beacon"))
+	assert.ErrorIs(t, err, ErrFinalized)
+}
+
+func TestInit_Options(t *testing.T) {
+	t.Parallel()
+
+	_, err := Init(t.TempDir(), InitOptions{Circuits: []string{"nope"}})
+	assert.Error(t, err)
+
+	_, err = Init(t.TempDir(), InitOptions{Scheme: "stark"})
+	assert.ErrorIs(t, err, zkp.ErrUnsupportedScheme)
+
+	_, err = Init(t.TempDir(), InitOptions{Circuits: []string{smallID}, DomainSize: 1000})
+	assert.Error(t, err, "not a power of two")
+
+	_, err = Init(t.TempDir(), InitOptions{Circuits: []string{smallID}, DomainSize: 512})
+	assert.Error(t, err, "too small")
+
+	m, err := Init(t.TempDir(), InitOptions{Circuits: []string{smallID}, DomainSize: 1 << 11})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1<<11), m.DomainSize)
+	assert.Equal(t, zkp.SchemePlonk, m.Scheme)
+	assert.Equal(t, 1, m.Phase)
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	t.Parallel()
+
+	t.Run("contribution file", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		_, err := Init(dir, InitOptions{Circuits: []string{smallID}})
+		require.NoError(t, err)
+		c, err := Contribute(dir, "alice")
+		require.NoError(t, err)
+
+		path := filepath.Join(dir, c.Files[phase1Label])
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		data[len(data)/2] ^= 0xff
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+
+		_, err = Verify(dir)
+		assert.ErrorContains(t, err, "hash mismatch")
+		_, err = Contribute(dir, "bob")
+		assert.ErrorContains(t, err, "hash mismatch")
+	})
+
+	t.Run("transcript", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		_, err := Init(dir, InitOptions{Circuits: []string{smallID}})
+		require.NoError(t, err)
+		_, err = Contribute(dir, "alice")
+		require.NoError(t, err)
+
+		m, err := LoadManifest(dir)
+		require.NoError(t, err)
+		m.Contributions[0].Participant = "mallory"
+		require.NoError(t, m.save(dir))
+
+		_, err = Verify(dir)
+		assert.ErrorIs(t, err, ErrTranscript)
+	})
+
+	t.Run("final srs", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		_, err := Init(dir, InitOptions{Circuits: []string{smallID}})
+		require.NoError(t, err)
+		_, err = Contribute(dir, "alice")
+		require.NoError(t, err)
+		_, err = Finalize(dir, []byte("beacon"))
+		require.NoError(t, err)
+
+		m, err := LoadManifest(dir)
+		require.NoError(t, err)
+		m.Seals[0].Beacon = "00"
+		m.Seals[0].Transcript = sealTranscript(m.Contributions[0].Transcript, m.Seals[0])
+		require.NoError(t, m.save(dir))
+
+		_, err = Verify(dir)
+		assert.ErrorContains(t, err, "does not match the ceremony")
+	})
+}
@@ -0,0 +1,83 @@
+package ceremony
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+
+	"github.com/langoai/lango/internal/p2p/zkp"
+	"github.com/langoai/lango/internal/p2p/zkp/circuits"
+)
+
+// knownCircuits maps the circuit IDs the node compiles at startup to their
+// definitions. The IDs must match the ones passed to ProverService.Compile.
+var knownCircuits = map[string]func() frontend.Circuit{
+	"wallet_ownership":     func() frontend.Circuit { return &circuits.WalletOwnershipCircuit{} },
+	"response_attestation": func() frontend.Circuit { return &circuits.ResponseAttestationCircuit{} },
+	"balance_range":        func() frontend.Circuit { return &circuits.BalanceRangeCircuit{} },
+	"agent_capability":     func() frontend.Circuit { return &circuits.AgentCapabilityCircuit{} },
+}
+
+// KnownCircuits returns the IDs of the circuits a ceremony can cover, sorted.
+func KnownCircuits() []string {
+	ids := make([]string, 0, len(knownCircuits))
+	for id := range knownCircuits {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// compile compiles a known circuit for scheme.
+func compile(scheme zkp.ProofScheme, circuitID string) (constraint.ConstraintSystem, error) {
+	newCircuit, ok := knownCircuits[circuitID]
+	if !ok {
+		return nil, fmt.Errorf("unknown circuit %q", circuitID)
+	}
+	var (
+		ccs constraint.ConstraintSystem
+		err error
+	)
+	switch scheme {
+	case zkp.SchemePlonk:
+		ccs, err = frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, newCircuit())
+	case zkp.SchemeGroth16:
+		ccs, err = frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, newCircuit())
+	default:
+		return nil, fmt.Errorf("%w: %s", zkp.ErrUnsupportedScheme, scheme)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("compile circuit %q: %w", circuitID, err)
+	}
+	return ccs, nil
+}
+
+// compileR1CS compiles a known circuit for the Groth16 phase 2.
+func compileR1CS(circuitID string) (*cs.R1CS, error) {
+	ccs, err := compile(zkp.SchemeGroth16, circuitID)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := ccs.(*cs.R1CS)
+	if !ok {
+		return nil, fmt.Errorf("circuit %q: not a BN254 R1CS", circuitID)
+	}
+	return r, nil
+}
+
+// domainSize returns the FFT domain a circuit uses under scheme: the
+// Lagrange domain for PlonK, the constraint domain for Groth16. These match
+// the domains gnark's setup derives, so ceremony output fits exactly.
+func domainSize(scheme zkp.ProofScheme, ccs constraint.ConstraintSystem) uint64 {
+	n := uint64(ccs.GetNbConstraints())
+	if scheme == zkp.SchemePlonk {
+		n += uint64(ccs.GetNbPublicVariables())
+	}
+	return ecc.NextPowerOfTwo(n)
+}
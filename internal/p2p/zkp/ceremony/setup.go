@@ -0,0 +1,541 @@
+package ceremony
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	kzgbn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+
+	"github.com/langoai/lango/internal/p2p/zkp"
+)
+
+// Init creates a ceremony in dir for the given circuits. The phase 1 domain
+// is sized for the largest circuit unless opts.DomainSize overrides it.
+func Init(dir string, opts InitOptions) (*Manifest, error) {
+	if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err == nil {
+		return nil, fmt.Errorf("%s: %w", dir, ErrExists)
+	}
+
+	scheme := opts.Scheme
+	if scheme == "" {
+		scheme = zkp.SchemePlonk
+	}
+	if !scheme.Valid() {
+		return nil, fmt.Errorf("%w: %s", zkp.ErrUnsupportedScheme, scheme)
+	}
+	ids := opts.Circuits
+	if len(ids) == 0 {
+		ids = KnownCircuits()
+	}
+
+	// PlonK needs 3 points beyond the Lagrange domain; 2N-1 >= N+3 from N=4.
+	need := uint64(4)
+	for _, id := range ids {
+		ccs, err := compile(scheme, id)
+		if err != nil {
+			return nil, err
+		}
+		need = max(need, domainSize(scheme, ccs))
+	}
+	size := need
+	if opts.DomainSize != 0 {
+		if ecc.NextPowerOfTwo(opts.DomainSize) != opts.DomainSize {
+			return nil, fmt.Errorf("domain size %d is not a power of two", opts.DomainSize)
+		}
+		if opts.DomainSize < need {
+			return nil, fmt.Errorf("domain size %d is too small, circuits need %d", opts.DomainSize, need)
+		}
+		size = opts.DomainSize
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, phase1Dir), 0o755); err != nil {
+		return nil, fmt.Errorf("create ceremony dir: %w", err)
+	}
+	m := &Manifest{
+		Version:    manifestVersion,
+		Scheme:     scheme,
+		DomainSize: size,
+		Circuits:   ids,
+		Phase:      1,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if err := m.save(dir); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Contribute adds participant's randomness to the current phase. The
+// previous contribution is checked against its recorded hash, but not
+// re-verified cryptographically; run Verify first to audit the whole chain.
+func Contribute(dir, participant string) (*Contribution, error) {
+	if participant == "" {
+		return nil, errors.New("participant name is required")
+	}
+	m, err := LoadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if m.Finalized {
+		return nil, ErrFinalized
+	}
+
+	prior := m.contributions(m.Phase)
+	c := Contribution{
+		Phase:       m.Phase,
+		Index:       len(prior) + 1,
+		Participant: participant,
+		Files:       make(map[string]string),
+		Hashes:      make(map[string]string),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	switch m.Phase {
+	case 1:
+		p := mpcsetup.NewPhase1(m.DomainSize)
+		if len(prior) > 0 {
+			p = new(mpcsetup.Phase1)
+			if err := readChecked(dir, prior[len(prior)-1], phase1Label, p); err != nil {
+				return nil, err
+			}
+		}
+		p.Contribute()
+		if err := c.write(dir, phase1Label, p); err != nil {
+			return nil, err
+		}
+	case 2:
+		for _, id := range m.Circuits {
+			p := new(mpcsetup.Phase2)
+			if len(prior) > 0 {
+				err = readChecked(dir, prior[len(prior)-1], id, p)
+			} else {
+				err = m.readOutput(dir, phase2InitPath(id), p)
+			}
+			if err != nil {
+				return nil, err
+			}
+			p.Contribute()
+			if err := c.write(dir, id, p); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid ceremony phase %d", m.Phase)
+	}
+
+	c.Transcript = contributionTranscript(m.Transcript(), c)
+	m.Contributions = append(m.Contributions, c)
+	if err := m.save(dir); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Finalize seals the current phase with a public random beacon, after
+// verifying its contributions. Sealing phase 1 writes the KZG SRS; for
+// Groth16 it also opens phase 2, whose seal writes the circuit keys.
+func Finalize(dir string, beacon []byte) (*Manifest, error) {
+	if len(beacon) == 0 {
+		return nil, errors.New("random beacon is required")
+	}
+	m, err := LoadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if m.Finalized {
+		return nil, ErrFinalized
+	}
+	if len(m.contributions(m.Phase)) == 0 {
+		return nil, ErrNoContributions
+	}
+	if err := m.checkTranscript(dir); err != nil {
+		return nil, err
+	}
+	if m.Outputs == nil {
+		m.Outputs = make(map[string]string)
+	}
+
+	seal := Seal{Phase: m.Phase, Beacon: hex.EncodeToString(beacon), CreatedAt: time.Now().UTC()}
+	seal.Transcript = sealTranscript(m.Transcript(), seal)
+
+	switch m.Phase {
+	case 1:
+		commons, err := m.verifyPhase1(dir, beacon)
+		if err != nil {
+			return nil, err
+		}
+		if err := m.writeOutput(dir, filepath.Join(FinalDir, SRSFile), kzgSRSWriter(commons)); err != nil {
+			return nil, err
+		}
+		if m.Scheme == zkp.SchemeGroth16 {
+			for _, id := range m.Circuits {
+				r1cs, err := compileR1CS(id)
+				if err != nil {
+					return nil, err
+				}
+				var p mpcsetup.Phase2
+				p.Initialize(r1cs, truncate(commons, domainSize(m.Scheme, r1cs)))
+				if err := m.writeOutput(dir, phase2InitPath(id), &p); err != nil {
+					return nil, err
+				}
+			}
+			m.Phase = 2
+		} else {
+			m.Finalized = true
+		}
+	case 2:
+		commons, err := m.sealedCommons(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range m.Circuits {
+			pk, vk, err := m.verifyPhase2(dir, id, commons, beacon)
+			if err != nil {
+				return nil, err
+			}
+			pkPath, vkPath := Groth16KeyPaths(id)
+			if err := m.writeOutput(dir, pkPath, pk); err != nil {
+				return nil, err
+			}
+			if err := m.writeOutput(dir, vkPath, vk); err != nil {
+				return nil, err
+			}
+		}
+		m.Finalized = true
+	}
+
+	m.Seals = append(m.Seals, seal)
+	if err := m.save(dir); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Verify re-checks the ceremony in dir: file hashes, the transcript chain,
+// every contribution's proof against its predecessor, and that the sealed
+// outputs are exactly what the contributions and beacons produce.
+func Verify(dir string) (*Manifest, error) {
+	m, err := LoadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.checkTranscript(dir); err != nil {
+		return nil, err
+	}
+
+	seal1, sealed1 := m.seal(1)
+	var beacon1 []byte
+	if sealed1 {
+		if beacon1, err = hex.DecodeString(seal1.Beacon); err != nil {
+			return nil, fmt.Errorf("phase 1 beacon: %w", err)
+		}
+	}
+	commons, err := m.verifyPhase1(dir, beacon1)
+	if err != nil {
+		return nil, err
+	}
+	if !sealed1 {
+		return m, nil
+	}
+	if err := m.checkOutput(dir, filepath.Join(FinalDir, SRSFile), kzgSRSWriter(commons)); err != nil {
+		return nil, err
+	}
+	if m.Scheme != zkp.SchemeGroth16 {
+		return m, nil
+	}
+
+	seal2, sealed2 := m.seal(2)
+	var beacon2 []byte
+	if sealed2 {
+		if beacon2, err = hex.DecodeString(seal2.Beacon); err != nil {
+			return nil, fmt.Errorf("phase 2 beacon: %w", err)
+		}
+	}
+	for _, id := range m.Circuits {
+		pk, vk, err := m.verifyPhase2(dir, id, commons, beacon2)
+		if err != nil {
+			return nil, err
+		}
+		if !sealed2 {
+			continue
+		}
+		pkPath, vkPath := Groth16KeyPaths(id)
+		if err := m.checkOutput(dir, pkPath, pk); err != nil {
+			return nil, err
+		}
+		if err := m.checkOutput(dir, vkPath, vk); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Groth16KeyPaths returns the Groth16 key paths for circuitID relative to the
+// ceremony directory, laid out where zkp.Groth16KeyPaths expects them next
+// to the SRS.
+func Groth16KeyPaths(circuitID string) (pkPath, vkPath string) {
+	return zkp.Groth16KeyPaths(filepath.Join(FinalDir, SRSFile), circuitID)
+}
+
+// checkTranscript recomputes the transcript chain and checks every recorded
+// contribution file against its hash.
+func (m *Manifest) checkTranscript(dir string) error {
+	head := m.genesisTranscript()
+	for phase := 1; phase <= 2; phase++ {
+		for i, c := range m.contributions(phase) {
+			if c.Index != i+1 {
+				return fmt.Errorf("phase %d contribution %d: out of order index %d", phase, i+1, c.Index)
+			}
+			for label, rel := range c.Files {
+				got, err := hashFile(filepath.Join(dir, rel))
+				if err != nil {
+					return fmt.Errorf("contribution %d (%s): %w", c.Index, c.Participant, err)
+				}
+				if got != c.Hashes[label] {
+					return fmt.Errorf("contribution %d (%s): %s hash mismatch", c.Index, c.Participant, rel)
+				}
+			}
+			head = contributionTranscript(head, c)
+			if head != c.Transcript {
+				return fmt.Errorf("phase %d contribution %d (%s): %w", phase, c.Index, c.Participant, ErrTranscript)
+			}
+		}
+		if s, ok := m.seal(phase); ok {
+			head = sealTranscript(head, s)
+			if head != s.Transcript {
+				return fmt.Errorf("phase %d seal: %w", phase, ErrTranscript)
+			}
+		}
+	}
+	return nil
+}
+
+// verifyPhase1 verifies the phase 1 chain and, when beacon is set, returns
+// the sealed SRS commons.
+func (m *Manifest) verifyPhase1(dir string, beacon []byte) (*mpcsetup.SrsCommons, error) {
+	prev := mpcsetup.NewPhase1(m.DomainSize)
+	for _, c := range m.contributions(1) {
+		next := new(mpcsetup.Phase1)
+		if err := readFile(filepath.Join(dir, c.Files[phase1Label]), next); err != nil {
+			return nil, err
+		}
+		if err := prev.Verify(next); err != nil {
+			return nil, fmt.Errorf("phase 1 contribution %d (%s): %w", c.Index, c.Participant, err)
+		}
+		prev = next
+	}
+	if beacon == nil {
+		return nil, nil
+	}
+	commons := prev.Seal(beacon)
+	return &commons, nil
+}
+
+// sealedCommons re-derives the sealed phase 1 output for phase 2.
+func (m *Manifest) sealedCommons(dir string) (*mpcsetup.SrsCommons, error) {
+	s, ok := m.seal(1)
+	if !ok {
+		return nil, errors.New("phase 1 is not sealed")
+	}
+	beacon, err := hex.DecodeString(s.Beacon)
+	if err != nil {
+		return nil, fmt.Errorf("phase 1 beacon: %w", err)
+	}
+	return m.verifyPhase1(dir, beacon)
+}
+
+// verifyPhase2 verifies one circuit's phase 2 chain and, when beacon is set,
+// returns its sealed keys as writers.
+func (m *Manifest) verifyPhase2(
+	dir, circuitID string, commons *mpcsetup.SrsCommons, beacon []byte,
+) (io.WriterTo, io.WriterTo, error) {
+	r1cs, err := compileR1CS(circuitID)
+	if err != nil {
+		return nil, nil, err
+	}
+	commons = truncate(commons, domainSize(m.Scheme, r1cs))
+
+	var chain []*mpcsetup.Phase2
+	for _, c := range m.contributions(2) {
+		p := new(mpcsetup.Phase2)
+		if err := readFile(filepath.Join(dir, c.Files[circuitID]), p); err != nil {
+			return nil, nil, err
+		}
+		chain = append(chain, p)
+	}
+
+	if beacon == nil {
+		prev := new(mpcsetup.Phase2)
+		prev.Initialize(r1cs, commons)
+		for i, next := range chain {
+			if err := prev.Verify(next); err != nil {
+				return nil, nil, fmt.Errorf("phase 2 %s contribution %d: %w", circuitID, i+1, err)
+			}
+			prev = next
+		}
+		return nil, nil, nil
+	}
+
+	pk, vk, err := mpcsetup.VerifyPhase2(r1cs, commons, beacon, chain...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("phase 2 %s: %w", circuitID, err)
+	}
+	return pk, vk, nil
+}
+
+// truncate restricts the commons to an n-point domain. Powers of tau for a
+// smaller domain are a prefix of those for a larger one.
+func truncate(c *mpcsetup.SrsCommons, n uint64) *mpcsetup.SrsCommons {
+	if uint64(len(c.G1.AlphaTau)) == n {
+		return c
+	}
+	var t mpcsetup.SrsCommons
+	t.G1.Tau = c.G1.Tau[:2*n-1]
+	t.G1.AlphaTau = c.G1.AlphaTau[:n]
+	t.G1.BetaTau = c.G1.BetaTau[:n]
+	t.G2.Tau = c.G2.Tau[:n]
+	t.G2.Beta = c.G2.Beta
+	return &t
+}
+
+// kzgSRSWriter encodes the sealed powers of tau as the SRS file the prover
+// loads: the canonical KZG SRS followed by its Lagrange form over the full
+// domain.
+func kzgSRSWriter(c *mpcsetup.SrsCommons) io.WriterTo {
+	return writerFunc(func(w io.Writer) (int64, error) {
+		var canonical kzgbn254.SRS
+		canonical.Pk.G1 = c.G1.Tau
+		canonical.Vk.G1 = c.G1.Tau[0]
+		canonical.Vk.G2 = [2]bn254.G2Affine{c.G2.Tau[0], c.G2.Tau[1]}
+		canonical.Vk.Lines[0] = bn254.PrecomputeLines(canonical.Vk.G2[0])
+		canonical.Vk.Lines[1] = bn254.PrecomputeLines(canonical.Vk.G2[1])
+
+		points, err := kzgbn254.ToLagrangeG1(c.G1.Tau[:len(c.G2.Tau)])
+		if err != nil {
+			return 0, fmt.Errorf("derive lagrange SRS: %w", err)
+		}
+		lagrange := kzgbn254.SRS{Pk: kzgbn254.ProvingKey{G1: points}, Vk: canonical.Vk}
+
+		n, err := canonical.WriteTo(w)
+		if err != nil {
+			return n, err
+		}
+		dn, err := lagrange.WriteTo(w)
+		return n + dn, err
+	})
+}
+
+type writerFunc func(io.Writer) (int64, error)
+
+func (f writerFunc) WriteTo(w io.Writer) (int64, error) { return f(w) }
+
+// write stores one contribution file and records its hash.
+func (c *Contribution) write(dir, label string, v io.WriterTo) error {
+	rel := contributionPath(c.Phase, label, c.Index)
+	sum, err := writeFile(filepath.Join(dir, rel), v)
+	if err != nil {
+		return err
+	}
+	c.Files[label] = rel
+	c.Hashes[label] = sum
+	return nil
+}
+
+// writeOutput stores a sealed output and records its hash.
+func (m *Manifest) writeOutput(dir, rel string, v io.WriterTo) error {
+	sum, err := writeFile(filepath.Join(dir, rel), v)
+	if err != nil {
+		return err
+	}
+	m.Outputs[rel] = sum
+	return nil
+}
+
+// checkOutput compares a sealed output on disk and in the manifest against
+// the bytes v encodes to.
+func (m *Manifest) checkOutput(dir, rel string, v io.WriterTo) error {
+	h := sha256.New()
+	if _, err := v.WriteTo(h); err != nil {
+		return fmt.Errorf("encode %s: %w", rel, err)
+	}
+	want := hex.EncodeToString(h.Sum(nil))
+	if m.Outputs[rel] != want {
+		return fmt.Errorf("%s: recorded hash does not match the ceremony", rel)
+	}
+	got, err := hashFile(filepath.Join(dir, rel))
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("%s: file does not match the ceremony", rel)
+	}
+	return nil
+}
+
+// readOutput reads a sealed output after checking its recorded hash.
+func (m *Manifest) readOutput(dir, rel string, r io.ReaderFrom) error {
+	got, err := hashFile(filepath.Join(dir, rel))
+	if err != nil {
+		return err
+	}
+	if got != m.Outputs[rel] {
+		return fmt.Errorf("%s: hash mismatch", rel)
+	}
+	return readFile(filepath.Join(dir, rel), r)
+}
+
+// readChecked reads a contribution file after checking its recorded hash.
+func readChecked(dir string, c Contribution, label string, r io.ReaderFrom) error {
+	rel := c.Files[label]
+	got, err := hashFile(filepath.Join(dir, rel))
+	if err != nil {
+		return err
+	}
+	if got != c.Hashes[label] {
+		return fmt.Errorf("contribution %d (%s): %s hash mismatch", c.Index, c.Participant, rel)
+	}
+	return readFile(filepath.Join(dir, rel), r)
+}
+
+func phase2InitPath(circuitID string) string {
+	return contributionPath(2, circuitID, 0)
+}
+
+func readFile(path string, r io.ReaderFrom) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := r.ReadFrom(bufio.NewReaderSize(f, 1<<20)); err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeFile encodes v to path and returns the hex sha256 of the bytes.
+func writeFile(path string, v io.WriterTo) (string, error) {
+	var buf bytes.Buffer
+	if _, err := v.WriteTo(&buf); err != nil {
+		return "", fmt.Errorf("encode %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -14,6 +14,7 @@ import (
 	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	kzgbn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
 	"github.com/consensys/gnark-crypto/kzg"
 	"github.com/consensys/gnark/backend/groth16"
 	groth16bn254 "github.com/consensys/gnark/backend/groth16/bn254"
@@ -63,6 +64,10 @@ func (m SRSMode) Valid() bool {
 // ErrUnsupportedScheme is returned when an unrecognized proving scheme is used.
 var ErrUnsupportedScheme = errors.New("unsupported proving scheme")
 
+// ErrUnsafeSRS is returned when unsafe setup material would be used outside a
+// development network. Anyone who knows the setup secret can forge proofs.
+var ErrUnsafeSRS = errors.New("unsafe SRS is only allowed on development networks")
+
 // Proof holds the serialized proof data and metadata.
 type Proof struct {
 	Data         []byte      `json:"data"`
@@ -85,6 +90,10 @@ type Config struct {
 	Logger   *zap.SugaredLogger
 	SRSMode  SRSMode // SRSModeUnsafe (default) or SRSModeFile
 	SRSPath  string  // path to SRS file (used when SRSMode == SRSModeFile)
+
+	// DevNetwork permits unsafe setup material: SRSModeUnsafe, and falling
+	// back to it when the SRS file or Groth16 keys are missing.
+	DevNetwork bool
 }
 
 // ProverService manages circuit compilation, proof generation, and verification.
@@ -93,6 +102,7 @@ type ProverService struct {
 	scheme   ProofScheme
 	srsMode  SRSMode
 	srsPath  string
+	dev      bool
 	logger   *zap.SugaredLogger
 	mu       sync.RWMutex
 	compiled map[string]*CompiledCircuit
@@ -122,12 +132,22 @@ func NewProverService(cfg Config) (*ProverService, error) {
 	if srsMode == "" {
 		srsMode = SRSModeUnsafe
 	}
+	if !srsMode.Valid() {
+		return nil, fmt.Errorf("unknown SRS mode %q", srsMode)
+	}
+	if srsMode == SRSModeUnsafe && !cfg.DevNetwork {
+		return nil, fmt.Errorf("%w: run a trusted-setup ceremony and set srsMode to %q", ErrUnsafeSRS, SRSModeFile)
+	}
+	if srsMode == SRSModeFile && cfg.SRSPath == "" {
+		return nil, fmt.Errorf("SRS mode %q requires an SRS path", SRSModeFile)
+	}
 
 	svc := &ProverService{
 		cacheDir: cacheDir,
 		scheme:   scheme,
 		srsMode:  srsMode,
 		srsPath:  cfg.SRSPath,
+		dev:      cfg.DevNetwork,
 		logger:   cfg.Logger,
 		compiled: make(map[string]*CompiledCircuit),
 	}
@@ -189,9 +209,9 @@ func (s *ProverService) Compile(circuitID string, circuit frontend.Circuit) erro
 		compiled.VerifyingKey = vk
 
 	case SchemeGroth16:
-		pk, vk, err := groth16.Setup(ccs)
+		pk, vk, err := s.loadGroth16Keys(ccs, circuitID)
 		if err != nil {
-			return fmt.Errorf("groth16 setup for %q: %w", circuitID, err)
+			return fmt.Errorf("load groth16 keys for %q: %w", circuitID, err)
 		}
 		compiled.ProvingKey = pk
 		compiled.VerifyingKey = vk
@@ -328,8 +348,9 @@ func (s *ProverService) Verify(ctx context.Context, proof *Proof, circuit fronte
 }
 
 // ExportGroth16Verifier compiles the circuit with Groth16 (regardless of the
-// service's default scheme), generates a trusted setup, and writes a Solidity
-// verifier contract to w. Uses unsafe SRS for R&D.
+// service's default scheme) and writes a Solidity verifier contract to w.
+// The verifying key comes from the ceremony output in SRS file mode and from
+// an unsafe local setup otherwise.
 //
 // The exported verifier is BN254-specific and implements the standard Groth16
 // verification interface: verifyProof(uint256[2] a, uint256[2][2] b, uint256[2] c, uint256[] input).
@@ -341,9 +362,9 @@ func (s *ProverService) ExportGroth16Verifier(circuitID string, circuit frontend
 		return fmt.Errorf("compile circuit %q for groth16: %w", circuitID, err)
 	}
 
-	_, vk, err := groth16.Setup(ccs)
+	_, vk, err := s.loadGroth16Keys(ccs, circuitID)
 	if err != nil {
-		return fmt.Errorf("groth16 setup for %q: %w", circuitID, err)
+		return fmt.Errorf("load groth16 keys for %q: %w", circuitID, err)
 	}
 
 	// Type-assert to *groth16bn254.VerifyingKey which has ExportSolidity.
@@ -372,28 +393,32 @@ func (s *ProverService) IsCompiled(circuitID string) bool {
 }
 
 // loadSRS returns canonical and lagrange SRS for a compiled constraint system.
-// When SRSMode is "file", it attempts to load from the configured SRS file,
-// falling back to unsafe generation if the file does not exist.
+// When SRSMode is "file", it loads the configured SRS file and derives the
+// Lagrange form for the circuit's domain if the file holds a different size.
+// A missing file falls back to unsafe generation on development networks only.
 func (s *ProverService) loadSRS(
 	ccs constraint.ConstraintSystem, circuitID string,
 ) (kzg.SRS, kzg.SRS, error) {
-	if s.srsMode == SRSModeFile && s.srsPath != "" {
+	if s.srsMode == SRSModeFile {
 		canonical, lagrange, err := loadSRSFromFile(s.srsPath)
-		if err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				s.logger.Warnw("SRS file not found, falling back to unsafe SRS",
-					"path", s.srsPath,
-					"circuitID", circuitID,
-				)
-			} else {
-				return nil, nil, fmt.Errorf("load SRS from file: %w", err)
+		switch {
+		case err == nil:
+			canonical, lagrange, err = fitSRS(ccs, canonical, lagrange)
+			if err != nil {
+				return nil, nil, fmt.Errorf("SRS %q: %w", s.srsPath, err)
 			}
-		} else {
 			s.logger.Infow("loaded SRS from file",
 				"path", s.srsPath,
 				"circuitID", circuitID,
 			)
 			return canonical, lagrange, nil
+		case errors.Is(err, os.ErrNotExist) && s.dev:
+			s.logger.Warnw("SRS file not found, falling back to unsafe SRS",
+				"path", s.srsPath,
+				"circuitID", circuitID,
+			)
+		default:
+			return nil, nil, fmt.Errorf("load SRS from file: %w", err)
 		}
 	}
 
@@ -405,6 +430,93 @@ func (s *ProverService) loadSRS(
 	return canonical, lagrange, nil
 }
 
+// fitSRS checks that the canonical SRS is large enough for ccs and returns a
+// Lagrange SRS of exactly the circuit's domain size, as plonk.Setup requires.
+func fitSRS(ccs constraint.ConstraintSystem, canonical, lagrange kzg.SRS) (kzg.SRS, kzg.SRS, error) {
+	sizeLagrange := ecc.NextPowerOfTwo(uint64(ccs.GetNbConstraints() + ccs.GetNbPublicVariables()))
+
+	c, ok := canonical.(*kzgbn254.SRS)
+	if !ok {
+		return nil, nil, errors.New("SRS is not a BN254 KZG SRS")
+	}
+	if uint64(len(c.Pk.G1)) < sizeLagrange+3 {
+		return nil, nil, fmt.Errorf("SRS has %d points, circuit needs %d; rerun the ceremony with a larger domain",
+			len(c.Pk.G1), sizeLagrange+3)
+	}
+	if l, ok := lagrange.(*kzgbn254.SRS); ok && uint64(len(l.Pk.G1)) == sizeLagrange {
+		return canonical, lagrange, nil
+	}
+
+	points, err := kzgbn254.ToLagrangeG1(c.Pk.G1[:sizeLagrange])
+	if err != nil {
+		return nil, nil, fmt.Errorf("derive lagrange SRS: %w", err)
+	}
+	return canonical, &kzgbn254.SRS{Pk: kzgbn254.ProvingKey{G1: points}, Vk: c.Vk}, nil
+}
+
+// Groth16KeyPaths returns where a ceremony stores the Groth16 proving and
+// verifying keys for circuitID, relative to its finalized SRS file.
+func Groth16KeyPaths(srsPath, circuitID string) (pkPath, vkPath string) {
+	dir := filepath.Join(filepath.Dir(srsPath), "groth16")
+	return filepath.Join(dir, circuitID+".pk"), filepath.Join(dir, circuitID+".vk")
+}
+
+// loadGroth16Keys returns the Groth16 keys for ccs. In SRS file mode they are
+// read from the ceremony output; otherwise, or when the keys are missing on a
+// development network, they come from an unsafe local setup.
+func (s *ProverService) loadGroth16Keys(
+	ccs constraint.ConstraintSystem, circuitID string,
+) (groth16.ProvingKey, groth16.VerifyingKey, error) {
+	if s.srsMode == SRSModeFile {
+		pkPath, vkPath := Groth16KeyPaths(s.srsPath, circuitID)
+		pk, vk, err := readGroth16Keys(pkPath, vkPath)
+		switch {
+		case err == nil:
+			s.logger.Infow("loaded groth16 keys from ceremony",
+				"path", filepath.Dir(pkPath),
+				"circuitID", circuitID,
+			)
+			return pk, vk, nil
+		case errors.Is(err, os.ErrNotExist) && s.dev:
+			s.logger.Warnw("groth16 keys not found, falling back to unsafe setup",
+				"path", filepath.Dir(pkPath),
+				"circuitID", circuitID,
+			)
+		default:
+			return nil, nil, err
+		}
+	}
+
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("groth16 setup: %w", err)
+	}
+	return pk, vk, nil
+}
+
+// readGroth16Keys reads a serialized Groth16 key pair.
+func readGroth16Keys(pkPath, vkPath string) (groth16.ProvingKey, groth16.VerifyingKey, error) {
+	pk := groth16.NewProvingKey(ecc.BN254)
+	if err := readFrom(pkPath, pk); err != nil {
+		return nil, nil, fmt.Errorf("read proving key: %w", err)
+	}
+	vk := groth16.NewVerifyingKey(ecc.BN254)
+	if err := readFrom(vkPath, vk); err != nil {
+		return nil, nil, fmt.Errorf("read verifying key: %w", err)
+	}
+	return pk, vk, nil
+}
+
+func readFrom(path string, r io.ReaderFrom) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = r.ReadFrom(bufio.NewReaderSize(f, 1<<20))
+	return err
+}
+
 // loadSRSFromFile reads canonical and lagrange KZG SRS from a file.
 // The file must contain both SRS written sequentially (canonical first, then lagrange).
 func loadSRSFromFile(path string) (kzg.SRS, kzg.SRS, error) {
@@ -3,6 +3,7 @@ package zkp
 import (
 	"context"
 	"math/big"
+	"path/filepath"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
@@ -50,9 +51,10 @@ func TestProverService_CompileAndProve_PlonK(t *testing.T) {
 	t.Parallel()
 
 	cfg := Config{
-		CacheDir: t.TempDir(),
-		Scheme:   SchemePlonk,
-		Logger:   newTestLogger(),
+		CacheDir:   t.TempDir(),
+		Scheme:     SchemePlonk,
+		Logger:     newTestLogger(),
+		DevNetwork: true,
 	}
 	ps, err := NewProverService(cfg)
 	require.NoError(t, err)
@@ -75,9 +77,10 @@ func TestProverService_CompileAndProve_Groth16(t *testing.T) {
 	t.Parallel()
 
 	cfg := Config{
-		CacheDir: t.TempDir(),
-		Scheme:   SchemeGroth16,
-		Logger:   newTestLogger(),
+		CacheDir:   t.TempDir(),
+		Scheme:     SchemeGroth16,
+		Logger:     newTestLogger(),
+		DevNetwork: true,
 	}
 	ps, err := NewProverService(cfg)
 	require.NoError(t, err)
@@ -99,9 +102,10 @@ func TestProverService_Verify_Valid(t *testing.T) {
 	t.Parallel()
 
 	cfg := Config{
-		CacheDir: t.TempDir(),
-		Scheme:   SchemePlonk,
-		Logger:   newTestLogger(),
+		CacheDir:   t.TempDir(),
+		Scheme:     SchemePlonk,
+		Logger:     newTestLogger(),
+		DevNetwork: true,
 	}
 	ps, err := NewProverService(cfg)
 	require.NoError(t, err)
@@ -129,9 +133,10 @@ func TestProverService_Verify_Invalid(t *testing.T) {
 	t.Parallel()
 
 	cfg := Config{
-		CacheDir: t.TempDir(),
-		Scheme:   SchemePlonk,
-		Logger:   newTestLogger(),
+		CacheDir:   t.TempDir(),
+		Scheme:     SchemePlonk,
+		Logger:     newTestLogger(),
+		DevNetwork: true,
 	}
 	ps, err := NewProverService(cfg)
 	require.NoError(t, err)
@@ -173,9 +178,10 @@ func TestProverService_DoubleCompile_Idempotent(t *testing.T) {
 	t.Parallel()
 
 	cfg := Config{
-		CacheDir: t.TempDir(),
-		Scheme:   SchemePlonk,
-		Logger:   newTestLogger(),
+		CacheDir:   t.TempDir(),
+		Scheme:     SchemePlonk,
+		Logger:     newTestLogger(),
+		DevNetwork: true,
 	}
 	ps, err := NewProverService(cfg)
 	require.NoError(t, err)
@@ -196,9 +202,10 @@ func TestProverService_ProveUncompiled_Error(t *testing.T) {
 	t.Parallel()
 
 	cfg := Config{
-		CacheDir: t.TempDir(),
-		Scheme:   SchemePlonk,
-		Logger:   newTestLogger(),
+		CacheDir:   t.TempDir(),
+		Scheme:     SchemePlonk,
+		Logger:     newTestLogger(),
+		DevNetwork: true,
 	}
 	ps, err := NewProverService(cfg)
 	require.NoError(t, err)
@@ -209,3 +216,61 @@ func TestProverService_ProveUncompiled_Error(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "not compiled")
 }
+
+func TestNewProverService_SRSModeGuard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give    Config
+		wantErr error
+	}{
+		{give: Config{SRSMode: SRSModeUnsafe}, wantErr: ErrUnsafeSRS},
+		{give: Config{}, wantErr: ErrUnsafeSRS},
+		{give: Config{SRSMode: SRSModeUnsafe, DevNetwork: true}},
+		{give: Config{SRSMode: SRSModeFile, SRSPath: "srs.bin"}},
+	}
+
+	for _, tt := range tests {
+		tt.give.CacheDir = t.TempDir()
+		tt.give.Logger = newTestLogger()
+		_, err := NewProverService(tt.give)
+		if tt.wantErr != nil {
+			assert.ErrorIs(t, err, tt.wantErr)
+			continue
+		}
+		assert.NoError(t, err)
+	}
+
+	_, err := NewProverService(Config{CacheDir: t.TempDir(), SRSMode: SRSModeFile, Logger: newTestLogger()})
+	assert.Error(t, err, "file mode without a path")
+}
+
+func TestProverService_MissingSRSFile(t *testing.T) {
+	t.Parallel()
+
+	circuit, _ := validOwnershipAssignment()
+	missing := filepath.Join(t.TempDir(), "final", "srs.bin")
+
+	for _, scheme := range []ProofScheme{SchemePlonk, SchemeGroth16} {
+		ps, err := NewProverService(Config{
+			CacheDir: t.TempDir(),
+			Scheme:   scheme,
+			Logger:   newTestLogger(),
+			SRSMode:  SRSModeFile,
+			SRSPath:  missing,
+		})
+		require.NoError(t, err)
+		assert.Error(t, ps.Compile("wallet_ownership", circuit), "production refuses %s without setup", scheme)
+
+		dev, err := NewProverService(Config{
+			CacheDir:   t.TempDir(),
+			Scheme:     scheme,
+			Logger:     newTestLogger(),
+			SRSMode:    SRSModeFile,
+			SRSPath:    missing,
+			DevNetwork: true,
+		})
+		require.NoError(t, err)
+		assert.NoError(t, dev.Compile("wallet_ownership", circuit), "dev falls back for %s", scheme)
+	}
+}
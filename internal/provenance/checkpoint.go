@@ -16,6 +16,7 @@ type CheckpointService struct {
 	store       CheckpointStore
 	ledger      runledger.RunLedgerStore
 	cfg         config.CheckpointConfig
+	gitDir      string
 }
 
 // NewCheckpointService creates a new checkpoint service.
@@ -27,6 +28,12 @@ func NewCheckpointService(store CheckpointStore, ledger runledger.RunLedgerStore
 	}
 }
 
+// SetGitWorkDir makes new checkpoints record the HEAD commit of the git
+// working tree at dir as their GitRef, so a rewind can restore the files.
+func (s *CheckpointService) SetGitWorkDir(dir string) {
+	s.gitDir = dir
+}
+
 // CreateManual creates a manually-triggered checkpoint.
 func (s *CheckpointService) CreateManual(ctx context.Context, sessionKey, runID, label string) (*Checkpoint, error) {
 	if label == "" {
@@ -111,6 +118,11 @@ func (s *CheckpointService) create(ctx context.Context, sessionKey, runID, label
 		Metadata:   metadata,
 		CreatedAt:  time.Now(),
 	}
+	if s.gitDir != "" {
+		if head, err := git(ctx, s.gitDir, "rev-parse", "HEAD"); err == nil {
+			cp.GitRef = head
+		}
+	}
 
 	if err := s.store.SaveCheckpoint(ctx, cp); err != nil {
 		return nil, fmt.Errorf("save checkpoint: %w", err)
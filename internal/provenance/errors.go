@@ -11,4 +11,6 @@ var (
 	ErrInvalidRunID       = errors.New("run ID is required")
 	ErrInvalidSessionKey  = errors.New("session key is required")
 	ErrInvalidRedaction   = errors.New("invalid redaction level")
+	ErrSessionMismatch    = errors.New("checkpoint belongs to a different session")
+	ErrNoGitRef           = errors.New("checkpoint has no git ref")
 )
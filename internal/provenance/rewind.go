@@ -0,0 +1,220 @@
+package provenance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/langoai/lango/internal/runledger"
+	"github.com/langoai/lango/internal/session"
+)
+
+// rewindPreviewLen is the number of characters of each removed message
+// shown in a rewind preview.
+const rewindPreviewLen = 80
+
+// SessionHistory is the session store surface used by rewind.
+// *session.EntStore satisfies it.
+type SessionHistory interface {
+	Get(key string) (*session.Session, error)
+	TruncateMessages(key string, after time.Time) (int, error)
+}
+
+// RewindRequest describes a session rewind.
+type RewindRequest struct {
+	SessionKey   string
+	CheckpointID string
+
+	// DryRun computes the result without changing anything.
+	DryRun bool
+
+	// Workspace selects whether and how the working tree is restored to the
+	// checkpoint's GitRef. The zero value leaves it untouched.
+	Workspace WorkspaceMode
+
+	// WorkDir is the git working tree to restore (default: current directory).
+	WorkDir string
+
+	// Branch names the branch created by WorkspaceBranch
+	// (default: "rewind/<checkpoint id prefix>").
+	Branch string
+}
+
+// RewindResult describes what a rewind removed and restored. For a dry run
+// it describes what would happen.
+type RewindResult struct {
+	SessionKey   string           `json:"session_key"`
+	Checkpoint   Checkpoint       `json:"checkpoint"`
+	Cutoff       time.Time        `json:"cutoff"`
+	DryRun       bool             `json:"dry_run"`
+	KeptMessages int              `json:"kept_messages"`
+	Removed      []RewoundMessage `json:"removed_messages,omitempty"`
+	Workspace    *WorkspaceRewind `json:"workspace,omitempty"`
+	RecordID     string           `json:"record_id,omitempty"`
+}
+
+// RewoundMessage summarizes a message removed by a rewind.
+type RewoundMessage struct {
+	Role      string    `json:"role"`
+	Timestamp time.Time `json:"timestamp"`
+	Preview   string    `json:"preview"`
+}
+
+// RewindService restores a session to a provenance checkpoint: it drops the
+// messages recorded after the checkpoint's journal position, optionally
+// restores the working tree to the checkpoint's GitRef, and records the
+// rewind as a checkpoint of its own.
+type RewindService struct {
+	checkpoints CheckpointStore
+	ledger      runledger.RunLedgerStore
+	history     SessionHistory
+}
+
+// NewRewindService creates a new rewind service. ledger may be nil, in which
+// case the checkpoint's creation time marks its position.
+func NewRewindService(checkpoints CheckpointStore, ledger runledger.RunLedgerStore, history SessionHistory) *RewindService {
+	return &RewindService{
+		checkpoints: checkpoints,
+		ledger:      ledger,
+		history:     history,
+	}
+}
+
+// Rewind rewinds a session to a checkpoint. The working tree is restored
+// before the session is truncated, so a failed restore leaves the session
+// untouched.
+func (s *RewindService) Rewind(ctx context.Context, req RewindRequest) (*RewindResult, error) {
+	if req.SessionKey == "" {
+		return nil, ErrInvalidSessionKey
+	}
+	if !req.Workspace.Valid() {
+		return nil, fmt.Errorf("invalid workspace mode %q", req.Workspace)
+	}
+
+	cp, err := s.checkpoints.GetCheckpoint(ctx, req.CheckpointID)
+	if err != nil {
+		return nil, err
+	}
+	if cp.SessionKey != "" && cp.SessionKey != req.SessionKey {
+		return nil, fmt.Errorf("checkpoint %s: %w", cp.ID, ErrSessionMismatch)
+	}
+	if req.Workspace != WorkspaceNone && cp.GitRef == "" {
+		return nil, fmt.Errorf("checkpoint %s: %w", cp.ID, ErrNoGitRef)
+	}
+
+	sess, err := s.history.Get(req.SessionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := s.cutoff(ctx, cp)
+	result := &RewindResult{
+		SessionKey: req.SessionKey,
+		Checkpoint: *cp,
+		Cutoff:     cutoff,
+		DryRun:     req.DryRun,
+	}
+	for _, msg := range sess.History {
+		if !msg.Timestamp.After(cutoff) {
+			result.KeptMessages++
+			continue
+		}
+		result.Removed = append(result.Removed, RewoundMessage{
+			Role:      string(msg.Role),
+			Timestamp: msg.Timestamp,
+			Preview:   preview(msg.Content),
+		})
+	}
+
+	if req.Workspace != WorkspaceNone {
+		branch := req.Branch
+		if branch == "" {
+			branch = "rewind/" + shortID(cp.ID)
+		}
+		result.Workspace, err = planWorkspace(ctx, req.Workspace, req.WorkDir, cp.GitRef, branch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.DryRun {
+		return result, nil
+	}
+
+	if result.Workspace != nil {
+		if err := applyWorkspace(ctx, result.Workspace, cp.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.history.TruncateMessages(req.SessionKey, cutoff); err != nil {
+		return nil, fmt.Errorf("truncate session: %w", err)
+	}
+
+	record := Checkpoint{
+		ID:         uuid.New().String(),
+		SessionKey: req.SessionKey,
+		RunID:      cp.RunID,
+		Label:      "rewind_to_" + cp.Label,
+		Trigger:    TriggerRewind,
+		JournalSeq: cp.JournalSeq,
+		GitRef:     cp.GitRef,
+		Metadata: map[string]string{
+			"rewound_to":       cp.ID,
+			"removed_messages": strconv.Itoa(len(result.Removed)),
+		},
+		CreatedAt: time.Now(),
+	}
+	if ws := result.Workspace; ws != nil {
+		record.Metadata["workspace_mode"] = string(ws.Mode)
+		if ws.Branch != "" {
+			record.Metadata["branch"] = ws.Branch
+		}
+		if ws.Stash != "" {
+			record.Metadata["stash"] = ws.Stash
+		}
+	}
+	if err := s.checkpoints.SaveCheckpoint(ctx, record); err != nil {
+		return nil, fmt.Errorf("record rewind: %w", err)
+	}
+	result.RecordID = record.ID
+
+	return result, nil
+}
+
+// cutoff returns the time of the journal event a checkpoint points at,
+// falling back to the checkpoint's creation time when the event is not
+// available.
+func (s *RewindService) cutoff(ctx context.Context, cp *Checkpoint) time.Time {
+	if s.ledger == nil || cp.RunID == "" || cp.JournalSeq == 0 {
+		return cp.CreatedAt
+	}
+	events, err := s.ledger.GetJournalEvents(ctx, cp.RunID)
+	if err != nil {
+		return cp.CreatedAt
+	}
+	for _, ev := range events {
+		if ev.Seq == cp.JournalSeq {
+			return ev.Timestamp
+		}
+	}
+	return cp.CreatedAt
+}
+
+func preview(content string) string {
+	r := []rune(content)
+	if len(r) <= rewindPreviewLen {
+		return content
+	}
+	return string(r[:rewindPreviewLen]) + "..."
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
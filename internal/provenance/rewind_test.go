@@ -0,0 +1,356 @@
+package provenance
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/ent/enttest"
+	"github.com/langoai/lango/internal/runledger"
+	"github.com/langoai/lango/internal/session"
+)
+
+type rewindFixture struct {
+	checkpoints *EntCheckpointStore
+	sessions    *session.EntStore
+	ledger      *runledger.MemoryStore
+	svc         *RewindService
+	base        time.Time
+}
+
+// newRewindFixture creates a session with five messages one minute apart
+// and a run whose journal event seq=2 falls between the second and third.
+func newRewindFixture(t *testing.T) *rewindFixture {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	f := &rewindFixture{
+		checkpoints: NewEntCheckpointStore(client),
+		sessions:    session.NewEntStoreWithClient(client),
+		ledger:      runledger.NewMemoryStore(),
+		base:        time.Now().Add(-time.Hour).Truncate(time.Second),
+	}
+	f.svc = NewRewindService(f.checkpoints, f.ledger, f.sessions)
+
+	require.NoError(t, f.sessions.Create(&session.Session{Key: "sess-1"}))
+	for i, content := range []string{"first", "second", "third", "fourth", "fifth"} {
+		require.NoError(t, f.sessions.AppendMessage("sess-1", session.Message{
+			Role:      "user",
+			Content:   content,
+			Timestamp: f.base.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+
+	ctx := context.Background()
+	for i, offset := range []time.Duration{30 * time.Second, 90 * time.Second, 150 * time.Second} {
+		require.NoError(t, f.ledger.AppendJournalEvent(ctx, runledger.JournalEvent{
+			RunID:     "run-1",
+			Type:      runledger.EventNoteWritten,
+			Timestamp: f.base.Add(offset),
+			Payload:   testPayload(map[string]int{"i": i}),
+		}))
+	}
+	return f
+}
+
+func (f *rewindFixture) saveCheckpoint(t *testing.T, cp Checkpoint) {
+	t.Helper()
+	if cp.ID == "" {
+		cp.ID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	}
+	if cp.Label == "" {
+		cp.Label = "before-refactor"
+	}
+	cp.Trigger = TriggerManual
+	cp.CreatedAt = time.Now()
+	require.NoError(t, f.checkpoints.SaveCheckpoint(context.Background(), cp))
+}
+
+func historyContents(t *testing.T, store *session.EntStore, key string) []string {
+	t.Helper()
+	sess, err := store.Get(key)
+	require.NoError(t, err)
+	out := make([]string, len(sess.History))
+	for i, m := range sess.History {
+		out[i] = m.Content
+	}
+	return out
+}
+
+func TestRewindService_TruncatesToJournalPosition(t *testing.T) {
+	f := newRewindFixture(t)
+	ctx := context.Background()
+	f.saveCheckpoint(t, Checkpoint{SessionKey: "sess-1", RunID: "run-1", JournalSeq: 2})
+
+	result, err := f.svc.Rewind(ctx, RewindRequest{
+		SessionKey:   "sess-1",
+		CheckpointID: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, f.base.Add(90*time.Second), result.Cutoff)
+	assert.Equal(t, 2, result.KeptMessages)
+	require.Len(t, result.Removed, 3)
+	assert.Equal(t, "third", result.Removed[0].Preview)
+	assert.Nil(t, result.Workspace)
+	assert.Equal(t, []string{"first", "second"}, historyContents(t, f.sessions, "sess-1"))
+
+	// The rewind is recorded as a checkpoint of its own.
+	require.NotEmpty(t, result.RecordID)
+	rec, err := f.checkpoints.GetCheckpoint(ctx, result.RecordID)
+	require.NoError(t, err)
+	assert.Equal(t, TriggerRewind, rec.Trigger)
+	assert.Equal(t, "rewind_to_before-refactor", rec.Label)
+	assert.Equal(t, "sess-1", rec.SessionKey)
+	assert.Equal(t, int64(2), rec.JournalSeq)
+	assert.Equal(t, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", rec.Metadata["rewound_to"])
+	assert.Equal(t, "3", rec.Metadata["removed_messages"])
+}
+
+func TestRewindService_DryRunChangesNothing(t *testing.T) {
+	f := newRewindFixture(t)
+	ctx := context.Background()
+	f.saveCheckpoint(t, Checkpoint{SessionKey: "sess-1", RunID: "run-1", JournalSeq: 1})
+
+	result, err := f.svc.Rewind(ctx, RewindRequest{
+		SessionKey:   "sess-1",
+		CheckpointID: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+		DryRun:       true,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 1, result.KeptMessages)
+	assert.Len(t, result.Removed, 4)
+	assert.Empty(t, result.RecordID)
+
+	assert.Len(t, historyContents(t, f.sessions, "sess-1"), 5)
+	count, err := f.checkpoints.CountBySession(ctx, "sess-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestRewindService_FallsBackToCreationTime(t *testing.T) {
+	f := newRewindFixture(t)
+	f.svc = NewRewindService(f.checkpoints, nil, f.sessions)
+
+	cp := Checkpoint{
+		ID:         "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+		SessionKey: "sess-1",
+		Label:      "no-run",
+		Trigger:    TriggerManual,
+		CreatedAt:  f.base.Add(3*time.Minute + 30*time.Second),
+	}
+	require.NoError(t, f.checkpoints.SaveCheckpoint(context.Background(), cp))
+
+	result, err := f.svc.Rewind(context.Background(), RewindRequest{SessionKey: "sess-1", CheckpointID: cp.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.KeptMessages)
+	assert.Equal(t, []string{"first", "second", "third", "fourth"}, historyContents(t, f.sessions, "sess-1"))
+}
+
+func TestRewindService_Errors(t *testing.T) {
+	f := newRewindFixture(t)
+	ctx := context.Background()
+	f.saveCheckpoint(t, Checkpoint{SessionKey: "other", RunID: "run-1", JournalSeq: 1})
+
+	tests := []struct {
+		give    string
+		req     RewindRequest
+		wantErr error
+	}{
+		{
+			give:    "missing session key",
+			req:     RewindRequest{CheckpointID: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"},
+			wantErr: ErrInvalidSessionKey,
+		},
+		{
+			give:    "unknown checkpoint",
+			req:     RewindRequest{SessionKey: "sess-1", CheckpointID: "c0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"},
+			wantErr: ErrCheckpointNotFound,
+		},
+		{
+			give:    "checkpoint of another session",
+			req:     RewindRequest{SessionKey: "sess-1", CheckpointID: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"},
+			wantErr: ErrSessionMismatch,
+		},
+		{
+			give:    "workspace restore without git ref",
+			req:     RewindRequest{SessionKey: "other", CheckpointID: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", Workspace: WorkspaceBranch},
+			wantErr: ErrNoGitRef,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			_, err := f.svc.Rewind(ctx, tt.req)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+// newGitRepo creates a repository with two commits and returns its path
+// and the first commit.
+func newGitRepo(t *testing.T) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		out, err := git(context.Background(), dir, args...)
+		require.NoError(t, err)
+		return out
+	}
+	run("init", "-q", "-b", "main")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "test")
+
+	writeFile(t, dir, "a.txt", "one\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "first")
+	first := run("rev-parse", "HEAD")
+
+	writeFile(t, dir, "a.txt", "two\n")
+	writeFile(t, dir, "b.txt", "added later\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "second")
+	return dir, first
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestRewindService_WorkspaceBranch(t *testing.T) {
+	dir, first := newGitRepo(t)
+	f := newRewindFixture(t)
+	ctx := context.Background()
+	f.saveCheckpoint(t, Checkpoint{SessionKey: "sess-1", RunID: "run-1", JournalSeq: 2, GitRef: first})
+
+	// Uncommitted work must survive the rewind.
+	writeFile(t, dir, "a.txt", "local edit\n")
+
+	req := RewindRequest{
+		SessionKey:   "sess-1",
+		CheckpointID: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+		Workspace:    WorkspaceBranch,
+		WorkDir:      dir,
+		DryRun:       true,
+	}
+	preview, err := f.svc.Rewind(ctx, req)
+	require.NoError(t, err)
+	require.NotNil(t, preview.Workspace)
+	assert.Equal(t, "rewind/a0eebc99", preview.Workspace.Branch)
+	assert.True(t, preview.Workspace.Dirty)
+	assert.Contains(t, preview.Workspace.DiffStat, "a.txt")
+	assert.Contains(t, preview.Workspace.DiffStat, "b.txt")
+	assert.Equal(t, "local edit\n", readFile(t, dir, "a.txt"), "dry run leaves files alone")
+
+	req.DryRun = false
+	result, err := f.svc.Rewind(ctx, req)
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.Workspace.Stash)
+
+	assert.Equal(t, "one\n", readFile(t, dir, "a.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "b.txt"))
+	branch, err := git(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, "rewind/a0eebc99", branch)
+
+	stashed, err := git(ctx, dir, "show", result.Workspace.Stash+":a.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "local edit", stashed)
+
+	rec, err := f.checkpoints.GetCheckpoint(ctx, result.RecordID)
+	require.NoError(t, err)
+	assert.Equal(t, "rewind/a0eebc99", rec.Metadata["branch"])
+	assert.Equal(t, result.Workspace.Stash, rec.Metadata["stash"])
+
+	// The branch now exists, so the same rewind is refused before touching the session.
+	_, err = f.svc.Rewind(ctx, req)
+	assert.ErrorContains(t, err, "already exists")
+}
+
+func TestRewindService_WorkspaceInvalidBranch(t *testing.T) {
+	dir, first := newGitRepo(t)
+	f := newRewindFixture(t)
+	ctx := context.Background()
+	f.saveCheckpoint(t, Checkpoint{SessionKey: "sess-1", RunID: "run-1", JournalSeq: 2, GitRef: first})
+
+	for _, branch := range []string{"-f", "a..b", "@{-1}", "with space"} {
+		_, err := f.svc.Rewind(ctx, RewindRequest{
+			SessionKey:   "sess-1",
+			CheckpointID: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+			Workspace:    WorkspaceBranch,
+			Branch:       branch,
+			WorkDir:      dir,
+			DryRun:       true,
+		})
+		assert.ErrorContains(t, err, "invalid branch name", branch)
+	}
+}
+
+func TestApplyWorkspace_FailureNamesStash(t *testing.T) {
+	dir, first := newGitRepo(t)
+	ctx := context.Background()
+	writeFile(t, dir, "a.txt", "local edit\n")
+
+	// The branch exists, so the checkout fails after the stash.
+	ws := &WorkspaceRewind{Mode: WorkspaceBranch, Dir: dir, Commit: first, Branch: "main", Dirty: true}
+	err := applyWorkspace(ctx, ws, "cp-1")
+	require.Error(t, err)
+	require.NotEmpty(t, ws.Stash)
+	assert.ErrorContains(t, err, "git stash apply "+ws.Stash)
+}
+
+func TestRewindService_WorkspaceStash(t *testing.T) {
+	dir, first := newGitRepo(t)
+	f := newRewindFixture(t)
+	ctx := context.Background()
+	f.saveCheckpoint(t, Checkpoint{SessionKey: "sess-1", RunID: "run-1", JournalSeq: 2, GitRef: first})
+
+	result, err := f.svc.Rewind(ctx, RewindRequest{
+		SessionKey:   "sess-1",
+		CheckpointID: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+		Workspace:    WorkspaceStash,
+		WorkDir:      dir,
+	})
+	require.NoError(t, err)
+	assert.False(t, result.Workspace.Dirty)
+	assert.Empty(t, result.Workspace.Stash)
+
+	assert.Equal(t, "one\n", readFile(t, dir, "a.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "b.txt"))
+	branch, err := git(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, "main", branch, "stash mode stays on the current branch")
+}
+
+func TestCheckpointService_RecordsGitRef(t *testing.T) {
+	dir, _ := newGitRepo(t)
+	head, err := git(context.Background(), dir, "rev-parse", "HEAD")
+	require.NoError(t, err)
+
+	svc := NewCheckpointService(NewMemoryStore(), nil, config.CheckpointConfig{})
+	svc.SetGitWorkDir(dir)
+	cp, err := svc.CreateManualWithMetadata(context.Background(), "sess-1", "", "with-git", nil)
+	require.NoError(t, err)
+	assert.Equal(t, head, cp.GitRef)
+}
@@ -0,0 +1,137 @@
+package provenance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// WorkspaceMode selects how a rewind restores the working tree.
+type WorkspaceMode string
+
+const (
+	// WorkspaceNone leaves the working tree untouched.
+	WorkspaceNone WorkspaceMode = ""
+	// WorkspaceBranch checks out the checkpoint's GitRef on a new branch.
+	WorkspaceBranch WorkspaceMode = "branch"
+	// WorkspaceStash restores the files of the checkpoint's GitRef in place,
+	// staged on the current branch.
+	WorkspaceStash WorkspaceMode = "stash"
+)
+
+// Valid reports whether m is a recognised workspace mode.
+func (m WorkspaceMode) Valid() bool {
+	switch m {
+	case WorkspaceNone, WorkspaceBranch, WorkspaceStash:
+		return true
+	}
+	return false
+}
+
+// WorkspaceRewind describes the working tree restore of a rewind. In both
+// modes, local changes are stashed first so nothing is lost; Stash is the
+// commit of that stash entry.
+type WorkspaceRewind struct {
+	Mode     WorkspaceMode `json:"mode"`
+	Dir      string        `json:"dir"`
+	GitRef   string        `json:"git_ref"`
+	Commit   string        `json:"commit"`
+	Branch   string        `json:"branch,omitempty"`
+	Dirty    bool          `json:"dirty"`
+	Stash    string        `json:"stash,omitempty"`
+	DiffStat string        `json:"diff_stat,omitempty"`
+}
+
+// planWorkspace checks that a restore can run and previews the change as a
+// diffstat of the working tree against the target commit.
+func planWorkspace(ctx context.Context, mode WorkspaceMode, dir, ref, branch string) (*WorkspaceRewind, error) {
+	if dir == "" {
+		dir = "."
+	}
+	root, err := git(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("workspace %s is not a git working tree: %w", dir, err)
+	}
+	commit, err := git(ctx, root, "rev-parse", "--verify", ref+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("resolve git ref %s: %w", ref, err)
+	}
+	status, err := git(ctx, root, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	diff, err := git(ctx, root, "diff", "--stat", commit)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &WorkspaceRewind{
+		Mode:     mode,
+		Dir:      root,
+		GitRef:   ref,
+		Commit:   commit,
+		Dirty:    status != "",
+		DiffStat: diff,
+	}
+	if mode == WorkspaceBranch {
+		// check-ref-format also expands @{-N}; require the name unchanged.
+		name, err := git(ctx, root, "check-ref-format", "--branch", branch)
+		if err != nil || name != branch {
+			return nil, fmt.Errorf("invalid branch name %q", branch)
+		}
+		if _, err := git(ctx, root, "rev-parse", "--verify", "refs/heads/"+branch); err == nil {
+			return nil, fmt.Errorf("branch %q already exists", branch)
+		}
+		ws.Branch = branch
+	}
+	return ws, nil
+}
+
+// applyWorkspace stashes local changes and restores the planned commit.
+// Errors after the stash name it, so the changes can be recovered.
+func applyWorkspace(ctx context.Context, ws *WorkspaceRewind, checkpointID string) error {
+	if ws.Dirty {
+		msg := "lango rewind to checkpoint " + checkpointID
+		if _, err := git(ctx, ws.Dir, "stash", "push", "--include-untracked", "-m", msg); err != nil {
+			return fmt.Errorf("stash local changes: %w", err)
+		}
+		stash, err := git(ctx, ws.Dir, "rev-parse", "stash@{0}")
+		if err != nil {
+			return fmt.Errorf("resolve stash (local changes are saved in stash@{0}): %w", err)
+		}
+		ws.Stash = stash
+	}
+
+	var err error
+	switch ws.Mode {
+	case WorkspaceBranch:
+		if _, err = git(ctx, ws.Dir, "checkout", "-b", ws.Branch, ws.Commit); err != nil {
+			err = fmt.Errorf("check out rewind branch: %w", err)
+		}
+	case WorkspaceStash:
+		if _, err = git(ctx, ws.Dir, "restore", "--source="+ws.Commit, "--staged", "--worktree", "--", ":/"); err != nil {
+			err = fmt.Errorf("restore working tree: %w", err)
+		}
+	}
+	if err != nil && ws.Stash != "" {
+		return fmt.Errorf("%w; local changes are saved in stash %s (git stash apply %s)", err, ws.Stash, ws.Stash)
+	}
+	return err
+}
+
+// git runs a git command in dir and returns its trimmed stdout.
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %s: %w", args[0], strings.TrimSpace(stderr.String()), err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
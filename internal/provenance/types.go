@@ -11,6 +11,7 @@ const (
 	TriggerManual       CheckpointTrigger = "manual"
 	TriggerStepComplete CheckpointTrigger = "step_complete"
 	TriggerPolicy       CheckpointTrigger = "policy_applied"
+	TriggerRewind       CheckpointTrigger = "rewind"
 )
 
 // Checkpoint is a thin metadata record marking a point in a RunLedger journal.
@@ -47,6 +47,9 @@ func (p *AnthropicProvider) Generate(ctx context.Context, params provider.Genera
 
 	return func(yield func(provider.StreamEvent, error) bool) {
 		var accMsg anthropic.Message
+		// structured is set while streaming the forced response-format tool
+		// call, whose input JSON is surfaced as plain text.
+		var structured bool
 		for stream.Next() {
 			evt := stream.Current()
 			_ = accMsg.Accumulate(evt)
@@ -62,6 +65,15 @@ func (p *AnthropicProvider) Generate(ctx context.Context, params provider.Genera
 						return
 					}
 				case "input_json_delta":
+					if structured {
+						if !yield(provider.StreamEvent{
+							Type: provider.StreamEventPlainText,
+							Text: evt.Delta.PartialJSON,
+						}, nil) {
+							return
+						}
+						continue
+					}
 					if !yield(provider.StreamEvent{
 						Type: provider.StreamEventToolCall,
 						ToolCall: &provider.ToolCall{
@@ -72,7 +84,10 @@ func (p *AnthropicProvider) Generate(ctx context.Context, params provider.Genera
 					}
 				}
 			case "content_block_start":
-				if evt.ContentBlock.Type == "tool_use" {
+				structured = params.ResponseFormat != nil &&
+					evt.ContentBlock.Type == "tool_use" &&
+					evt.ContentBlock.Name == params.ResponseFormat.SchemaName()
+				if evt.ContentBlock.Type == "tool_use" && !structured {
 					if !yield(provider.StreamEvent{
 						Type: provider.StreamEventToolCall,
 						ToolCall: &provider.ToolCall{
@@ -166,9 +181,25 @@ func (p *AnthropicProvider) convertParams(params provider.GenerateParams) (anthr
 		req.Temperature = param.NewOpt(params.Temperature)
 	}
 
-	if len(params.Tools) > 0 {
+	// Anthropic has no JSON response mode; a response format becomes a tool
+	// the model is forced to call, with the schema as its input.
+	toolDefs := params.Tools
+	if rf := params.ResponseFormat; rf != nil {
+		desc := rf.Description
+		if desc == "" {
+			desc = "Respond with the result as structured input to this tool."
+		}
+		toolDefs = append(toolDefs[:len(toolDefs):len(toolDefs)], provider.Tool{
+			Name:        rf.SchemaName(),
+			Description: desc,
+			Parameters:  rf.Schema,
+		})
+		req.ToolChoice = anthropic.ToolChoiceParamOfTool(rf.SchemaName())
+	}
+
+	if len(toolDefs) > 0 {
 		var tools []anthropic.ToolUnionParam
-		for _, t := range params.Tools {
+		for _, t := range toolDefs {
 			var required []string
 			if reqRaw, ok := t.Parameters["required"]; ok {
 				if reqSlice, ok := reqRaw.([]interface{}); ok {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"os"
 	"testing"
+
+	"github.com/langoai/lango/internal/provider"
 )
 
 func TestNewProvider(t *testing.T) {
@@ -34,3 +36,38 @@ func TestAnthropicProvider_ListModels(t *testing.T) {
 		}
 	}
 }
+
+func TestConvertParams_ResponseFormat(t *testing.T) {
+	p := NewProvider("anthropic", "test-key")
+	req, err := p.convertParams(provider.GenerateParams{
+		Model:    "claude-sonnet-4-5",
+		Messages: []provider.Message{{Role: "user", Content: "hello"}},
+		Tools:    []provider.Tool{{Name: "exec", Parameters: map[string]interface{}{"type": "object"}}},
+		ResponseFormat: &provider.ResponseFormat{
+			Name: "answer",
+			Schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"text": map[string]interface{}{"type": "string"},
+				},
+				"required": []interface{}{"text"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("convertParams: %v", err)
+	}
+	if len(req.Tools) != 2 {
+		t.Fatalf("expected caller tool plus response tool, got %d tools", len(req.Tools))
+	}
+	tool := req.Tools[1].OfTool
+	if tool == nil || tool.Name != "answer" {
+		t.Fatalf("expected forced tool named %q, got %+v", "answer", req.Tools[1])
+	}
+	if got := tool.InputSchema.Required; len(got) != 1 || got[0] != "text" {
+		t.Errorf("expected required [text], got %v", got)
+	}
+	if got := req.ToolChoice.GetName(); got == nil || *got != "answer" {
+		t.Errorf("expected tool_choice forcing %q, got %v", "answer", got)
+	}
+}
@@ -170,6 +170,10 @@ func (p *GeminiProvider) Generate(ctx context.Context, params provider.GenerateP
 		Tools:           tools,
 	}
 
+	if err := applyResponseFormat(conf, params.ResponseFormat); err != nil {
+		return nil, err
+	}
+
 	if len(systemParts) > 0 {
 		conf.SystemInstruction = &genai.Content{
 			Parts: systemParts,
@@ -279,6 +283,21 @@ func convertSchema(schemaMap map[string]interface{}) (*genai.Schema, error) {
 	return &s, nil
 }
 
+// applyResponseFormat switches conf to JSON output constrained by the
+// format's schema. A nil format leaves conf unchanged.
+func applyResponseFormat(conf *genai.GenerateContentConfig, rf *provider.ResponseFormat) error {
+	if rf == nil {
+		return nil
+	}
+	schema, err := convertSchema(rf.Schema)
+	if err != nil {
+		return fmt.Errorf("convert response schema: %w", err)
+	}
+	conf.ResponseMIMEType = "application/json"
+	conf.ResponseSchema = schema
+	return nil
+}
+
 // resolveFunctionCallID returns the FunctionCall.ID if non-empty, falling back to Name.
 func resolveFunctionCallID(fc *genai.FunctionCall) string {
 	if fc.ID != "" {
@@ -448,3 +448,26 @@ func TestResolveFunctionCallID(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyResponseFormat(t *testing.T) {
+	conf := &genai.GenerateContentConfig{}
+	require.NoError(t, applyResponseFormat(conf, nil))
+	assert.Empty(t, conf.ResponseMIMEType)
+	assert.Nil(t, conf.ResponseSchema)
+
+	err := applyResponseFormat(conf, &provider.ResponseFormat{
+		Name: "answer",
+		Schema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"text": map[string]interface{}{"type": "string"},
+			},
+			"required": []interface{}{"text"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", conf.ResponseMIMEType)
+	require.NotNil(t, conf.ResponseSchema)
+	assert.Equal(t, []string{"text"}, conf.ResponseSchema.Required)
+	assert.Contains(t, conf.ResponseSchema.Properties, "text")
+}
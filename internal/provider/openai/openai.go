@@ -2,6 +2,7 @@ package openai
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -225,9 +226,65 @@ func (p *OpenAIProvider) convertParams(params provider.GenerateParams) (openai.C
 		}
 	}
 
+	if rf := params.ResponseFormat; rf != nil {
+		req.ResponseFormat = &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+				Name:        rf.SchemaName(),
+				Description: rf.Description,
+				Schema:      jsonSchema(rf.Schema),
+				Strict:      canUseStrictSchema(rf.Schema),
+			},
+		}
+	}
+
 	return req, nil
 }
 
+// jsonSchema adapts a schema map to the json.Marshaler the SDK expects.
+type jsonSchema map[string]interface{}
+
+func (s jsonSchema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}(s))
+}
+
+// canUseStrictSchema applies canUseStrictMode to an object schema and every
+// object schema nested in its properties and array items. Strict mode on a
+// response format covers the whole document, not just the top level.
+func canUseStrictSchema(schema map[string]interface{}) bool {
+	if !canUseStrictMode(schema) {
+		return false
+	}
+	props, _ := schema["properties"].(map[string]interface{})
+	for _, raw := range props {
+		prop, ok := raw.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for prop != nil {
+			if isObjectSchema(prop) && !canUseStrictSchema(prop) {
+				return false
+			}
+			prop, _ = prop["items"].(map[string]interface{})
+		}
+	}
+	return true
+}
+
+func isObjectSchema(schema map[string]interface{}) bool {
+	switch t := schema["type"].(type) {
+	case string:
+		return t == "object"
+	case []interface{}:
+		for _, v := range t {
+			if v == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // canUseStrictMode returns true when a tool's parameter schema satisfies OpenAI's
 // strict mode requirements: additionalProperties must be false, and every
 // declared property must be listed in "required".
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/sashabaranov/go-openai"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -479,3 +480,62 @@ func TestConvertParams_RepairIntegration(t *testing.T) {
 	assert.Equal(t, "call_1", req.Messages[1].ToolCallID)
 	assert.Equal(t, "user", req.Messages[2].Role)
 }
+
+func TestConvertParams_ResponseFormat(t *testing.T) {
+	t.Parallel()
+
+	item := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{"type": "string"},
+		},
+		"required":             []interface{}{"name"},
+		"additionalProperties": false,
+	}
+	tests := []struct {
+		give       string
+		itemSchema map[string]interface{}
+		wantStrict bool
+	}{
+		{give: "strict nested object", itemSchema: item, wantStrict: true},
+		{
+			give: "nested object with optional field",
+			itemSchema: map[string]interface{}{
+				"type":                 "object",
+				"properties":           map[string]interface{}{"name": map[string]interface{}{"type": "string"}},
+				"additionalProperties": false,
+			},
+			wantStrict: false,
+		},
+	}
+
+	p := NewProvider("openai", "test-key", "")
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			schema := map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"items": map[string]interface{}{"type": "array", "items": tt.itemSchema},
+				},
+				"required":             []interface{}{"items"},
+				"additionalProperties": false,
+			}
+			req, err := p.convertParams(provider.GenerateParams{
+				Model:          "gpt-4o",
+				Messages:       []provider.Message{{Role: "user", Content: "hello"}},
+				ResponseFormat: &provider.ResponseFormat{Name: "listing", Schema: schema},
+			})
+			require.NoError(t, err)
+			require.NotNil(t, req.ResponseFormat)
+			assert.Equal(t, openai.ChatCompletionResponseFormatTypeJSONSchema, req.ResponseFormat.Type)
+			require.NotNil(t, req.ResponseFormat.JSONSchema)
+			assert.Equal(t, "listing", req.ResponseFormat.JSONSchema.Name)
+			assert.Equal(t, tt.wantStrict, req.ResponseFormat.JSONSchema.Strict)
+
+			data, err := req.ResponseFormat.JSONSchema.Schema.MarshalJSON()
+			require.NoError(t, err)
+			assert.Contains(t, string(data), `"required":["items"]`)
+		})
+	}
+}
@@ -73,13 +73,31 @@ type Tool struct {
 	Parameters  map[string]interface{} // JSON schema
 }
 
+// ResponseFormat constrains a generation to a single JSON document matching
+// Schema. Providers map it to their native structured output mode, and the
+// document is streamed back as text_delta events.
+type ResponseFormat struct {
+	Name        string                 // schema identifier, e.g. "triples"
+	Description string                 // what the document holds
+	Schema      map[string]interface{} // JSON schema of the document
+}
+
+// SchemaName returns Name, or "response" when Name is empty.
+func (f *ResponseFormat) SchemaName() string {
+	if f.Name == "" {
+		return "response"
+	}
+	return f.Name
+}
+
 // GenerateParams contains parameters for generation.
 type GenerateParams struct {
-	Model       string
-	Messages    []Message
-	Tools       []Tool
-	Temperature float64
-	MaxTokens   int
+	Model          string
+	Messages       []Message
+	Tools          []Tool
+	Temperature    float64
+	MaxTokens      int
+	ResponseFormat *ResponseFormat // nil for free-form text
 }
 
 // ModelInfo describes an available model.
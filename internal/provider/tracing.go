@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"iter"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/langoai/lango/internal/observability/genai"
+)
+
+// WithTracing wraps p so that each Generate call is recorded as a "chat"
+// span following the OpenTelemetry GenAI conventions. system is the
+// gen_ai.system value, normally the provider type. The span stays open
+// until the returned stream is drained and carries the token usage
+// reported by the provider.
+func WithTracing(p Provider, system string, tracer trace.Tracer, capture genai.ContentCapture) Provider {
+	return &tracingProvider{Provider: p, system: system, tracer: tracer, capture: capture}
+}
+
+type tracingProvider struct {
+	Provider
+	system  string
+	tracer  trace.Tracer
+	capture genai.ContentCapture
+}
+
+func (t *tracingProvider) Generate(ctx context.Context, params GenerateParams) (iter.Seq2[StreamEvent, error], error) {
+	ctx, span := t.tracer.Start(genai.ParentContext(ctx), genai.OpChat+" "+params.Model,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			genai.AttrSystem.String(t.system),
+			genai.AttrOperationName.String(genai.OpChat),
+			genai.AttrRequestModel.String(params.Model),
+			genai.AttrRequestTemperature.Float64(params.Temperature),
+			genai.AttrRequestMaxTokens.Int(params.MaxTokens),
+		),
+	)
+	if t.capture.Enabled {
+		span.AddEvent(genai.EventPrompt, trace.WithAttributes(
+			genai.AttrPrompt.String(t.capture.Text(formatPrompt(params.Messages))),
+		))
+	}
+
+	seq, err := t.Provider.Generate(ctx, params)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		return nil, err
+	}
+
+	return func(yield func(StreamEvent, error) bool) {
+		defer span.End()
+
+		var (
+			completion strings.Builder
+			usage      *Usage
+			failed     error
+		)
+		for evt, err := range seq {
+			switch {
+			case err != nil:
+				failed = err
+			case evt.Type == StreamEventError && evt.Error != nil:
+				failed = evt.Error
+			case evt.Type == StreamEventPlainText:
+				completion.WriteString(evt.Text)
+			}
+			if evt.Usage != nil {
+				usage = evt.Usage
+			}
+			if !yield(evt, err) {
+				break
+			}
+		}
+
+		if usage != nil {
+			span.SetAttributes(
+				genai.AttrUsageInputTokens.Int64(usage.InputTokens),
+				genai.AttrUsageOutputTokens.Int64(usage.OutputTokens),
+			)
+		}
+		if t.capture.Enabled {
+			span.AddEvent(genai.EventCompletion, trace.WithAttributes(
+				genai.AttrCompletion.String(t.capture.Text(completion.String())),
+			))
+		}
+		if failed != nil {
+			span.RecordError(failed)
+			span.SetStatus(codes.Error, failed.Error())
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+	}, nil
+}
+
+// formatPrompt renders messages as "role: content" lines.
+func formatPrompt(msgs []Message) string {
+	var b strings.Builder
+	for i, m := range msgs {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(m.Role)
+		b.WriteString(": ")
+		b.WriteString(m.Content)
+	}
+	return b.String()
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/langoai/lango/internal/observability/genai"
+)
+
+type streamProvider struct {
+	events []StreamEvent
+	err    error
+}
+
+func (p *streamProvider) ID() string { return "stub" }
+
+func (p *streamProvider) Generate(_ context.Context, _ GenerateParams) (iter.Seq2[StreamEvent, error], error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return func(yield func(StreamEvent, error) bool) {
+		for _, evt := range p.events {
+			if !yield(evt, nil) {
+				return
+			}
+		}
+	}, nil
+}
+
+func (p *streamProvider) ListModels(_ context.Context) ([]ModelInfo, error) { return nil, nil }
+
+func newRecorder() (*tracetest.SpanRecorder, trace.Tracer) {
+	rec := tracetest.NewSpanRecorder()
+	return rec, sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer("test")
+}
+
+func attrMap(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func drain(t *testing.T, p Provider, params GenerateParams) string {
+	t.Helper()
+	seq, err := p.Generate(context.Background(), params)
+	require.NoError(t, err)
+	var out strings.Builder
+	for evt, err := range seq {
+		require.NoError(t, err)
+		out.WriteString(evt.Text)
+	}
+	return out.String()
+}
+
+func TestWithTracing_ChatSpan(t *testing.T) {
+	t.Parallel()
+
+	rec, tracer := newRecorder()
+	inner := &streamProvider{events: []StreamEvent{
+		{Type: StreamEventPlainText, Text: "Hel"},
+		{Type: StreamEventPlainText, Text: "lo"},
+		{Type: StreamEventDone, Usage: &Usage{InputTokens: 12, OutputTokens: 3, TotalTokens: 15}},
+	}}
+	p := WithTracing(inner, "openai", tracer, genai.ContentCapture{})
+
+	text := drain(t, p, GenerateParams{Model: "gpt-4o", MaxTokens: 256, Messages: []Message{{Role: "user", Content: "hi"}}})
+	assert.Equal(t, "Hello", text)
+
+	spans := rec.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "chat gpt-4o", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, codes.Ok, span.Status().Code)
+	assert.Empty(t, span.Events(), "content must not be captured by default")
+
+	attrs := attrMap(span)
+	assert.Equal(t, "openai", attrs[genai.AttrSystem].AsString())
+	assert.Equal(t, "chat", attrs[genai.AttrOperationName].AsString())
+	assert.Equal(t, "gpt-4o", attrs[genai.AttrRequestModel].AsString())
+	assert.Equal(t, int64(256), attrs[genai.AttrRequestMaxTokens].AsInt64())
+	assert.Equal(t, int64(12), attrs[genai.AttrUsageInputTokens].AsInt64())
+	assert.Equal(t, int64(3), attrs[genai.AttrUsageOutputTokens].AsInt64())
+}
+
+func TestWithTracing_CaptureContent(t *testing.T) {
+	t.Parallel()
+
+	rec, tracer := newRecorder()
+	inner := &streamProvider{events: []StreamEvent{
+		{Type: StreamEventPlainText, Text: "Mail bob@example.com"},
+		{Type: StreamEventDone},
+	}}
+	capture := genai.ContentCapture{
+		Enabled: true,
+		Redact: func(s string) string {
+			s = strings.ReplaceAll(s, "alice@example.com", "[EMAIL]")
+			return strings.ReplaceAll(s, "bob@example.com", "[EMAIL]")
+		},
+	}
+	p := WithTracing(inner, "anthropic", tracer, capture)
+
+	drain(t, p, GenerateParams{Model: "claude", Messages: []Message{
+		{Role: "system", Content: "be brief"},
+		{Role: "user", Content: "I am alice@example.com"},
+	}})
+
+	spans := rec.Ended()
+	require.Len(t, spans, 1)
+	events := spans[0].Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, genai.EventPrompt, events[0].Name)
+	assert.Equal(t, "system: be brief\nuser: I am [EMAIL]", events[0].Attributes[0].Value.AsString())
+	assert.Equal(t, genai.EventCompletion, events[1].Name)
+	assert.Equal(t, "Mail [EMAIL]", events[1].Attributes[0].Value.AsString())
+}
+
+func TestWithTracing_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give     string
+		provider *streamProvider
+		wantErr  bool
+	}{
+		{
+			give:     "generate fails",
+			provider: &streamProvider{err: errors.New("rate limited")},
+			wantErr:  true,
+		},
+		{
+			give: "stream error event",
+			provider: &streamProvider{events: []StreamEvent{
+				{Type: StreamEventError, Error: errors.New("rate limited")},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+
+			rec, tracer := newRecorder()
+			p := WithTracing(tt.provider, "gemini", tracer, genai.ContentCapture{})
+
+			seq, err := p.Generate(context.Background(), GenerateParams{Model: "m"})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				for range seq {
+				}
+			}
+
+			spans := rec.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, codes.Error, spans[0].Status().Code)
+			assert.Equal(t, "rate limited", spans[0].Status().Description)
+		})
+	}
+}
@@ -34,8 +34,43 @@ type ExecutionRequest struct {
 	Params   map[string]interface{} `json:"params"`
 }
 
+// streamingVersion is the first request version whose worker writes an
+// ExecutionEvent line to stdout for each event the tool emits, ahead of
+// the final ExecutionResult.
+const streamingVersion = 2
+
 // ExecutionResult is the JSON message received from the sandbox worker via stdout.
 type ExecutionResult struct {
 	Output map[string]interface{} `json:"output,omitempty"`
 	Error  string                 `json:"error,omitempty"`
+	// Event is set on the interim lines of a streaming worker only.
+	Event *ExecutionEvent `json:"event,omitempty"`
+}
+
+// ExecutionEvent is a progress report or partial-output chunk emitted by a
+// running tool.
+type ExecutionEvent struct {
+	Progress float64 `json:"progress,omitempty"` // 0..1, 0 when unknown
+	Message  string  `json:"message,omitempty"`
+	Output   string  `json:"output,omitempty"`
+}
+
+// EventFunc receives the events of a running tool.
+type EventFunc func(ExecutionEvent)
+
+type eventFuncKey struct{}
+
+// WithEventFunc attaches fn to ctx. The subprocess executor calls it for
+// every event its worker streams back; inside the worker it forwards the
+// tool's events to the parent.
+func WithEventFunc(ctx context.Context, fn EventFunc) context.Context {
+	return context.WithValue(ctx, eventFuncKey{}, fn)
+}
+
+// Emit delivers ev to the EventFunc attached to ctx. It is a no-op when
+// there is none, so tools may call it unconditionally.
+func Emit(ctx context.Context, ev ExecutionEvent) {
+	if fn, ok := ctx.Value(eventFuncKey{}).(EventFunc); ok {
+		fn(ev)
+	}
 }
@@ -3,6 +3,8 @@ package sandbox
 import (
 	"context"
 	"encoding/json"
+	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -10,6 +12,21 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// TestMain lets the subprocess tests run this test binary as their worker.
+func TestMain(m *testing.M) {
+	if IsWorkerMode() {
+		RunWorker(ToolRegistry{
+			"report": func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
+				Emit(ctx, ExecutionEvent{Progress: 0.5, Message: "halfway"})
+				Emit(ctx, ExecutionEvent{Output: "partial\n"})
+				return map[string]interface{}{"done": true}, nil
+			},
+		})
+		return
+	}
+	os.Exit(m.Run())
+}
+
 func TestInProcessExecutor_Execute(t *testing.T) {
 	tests := []struct {
 		give       string
@@ -117,6 +134,31 @@ func TestSubprocessExecutor_Timeout(t *testing.T) {
 	assert.Contains(t, err.Error(), "timed out")
 }
 
+func TestSubprocessExecutor_StreamsEvents(t *testing.T) {
+	exec := NewSubprocessExecutor(Config{TimeoutPerTool: 30 * time.Second})
+
+	var mu sync.Mutex
+	var events []ExecutionEvent
+	ctx := WithEventFunc(context.Background(), func(ev ExecutionEvent) {
+		mu.Lock()
+		events = append(events, ev)
+		mu.Unlock()
+	})
+
+	result, err := exec.Execute(ctx, "report", nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"done": true}, result)
+	assert.Equal(t, []ExecutionEvent{
+		{Progress: 0.5, Message: "halfway"},
+		{Output: "partial\n"},
+	}, events)
+
+	// Without an EventFunc the events are dropped and the result is intact.
+	result, err = exec.Execute(context.Background(), "report", nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"done": true}, result)
+}
+
 func TestIsWorkerMode(t *testing.T) {
 	// IsWorkerMode checks os.Args, which we cannot safely mutate in parallel tests.
 	// Just verify the function exists and returns false in normal test mode.
@@ -1,10 +1,12 @@
 package sandbox
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 )
@@ -22,7 +24,8 @@ func NewSubprocessExecutor(cfg Config) *SubprocessExecutor {
 }
 
 // Execute launches a child process running in sandbox worker mode and
-// communicates via JSON over stdin/stdout.
+// communicates via JSON over stdin/stdout. Events the tool emits are
+// passed to the EventFunc in ctx as they arrive.
 func (e *SubprocessExecutor) Execute(ctx context.Context, toolName string, params map[string]interface{}) (map[string]interface{}, error) {
 	// Apply per-tool timeout if configured.
 	if e.cfg.TimeoutPerTool > 0 {
@@ -44,6 +47,7 @@ func (e *SubprocessExecutor) Execute(ctx context.Context, toolName string, param
 
 	// Prepare JSON request for stdin.
 	req := ExecutionRequest{
+		Version:  streamingVersion,
 		ToolName: toolName,
 		Params:   params,
 	}
@@ -53,24 +57,38 @@ func (e *SubprocessExecutor) Execute(ctx context.Context, toolName string, param
 	}
 	cmd.Stdin = bytes.NewReader(reqBytes)
 
-	// Capture stdout and stderr.
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
+	// Read stdout line by line so events reach the caller while the tool
+	// runs; capture stderr for error reports.
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("open worker stdout: %w", err)
+	}
+	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
 	// Run the subprocess.
-	if err := cmd.Run(); err != nil {
+	err = cmd.Start()
+	var stdout []byte
+	var readErr error
+	if err == nil {
+		stdout, readErr = readWorkerOutput(ctx, stdoutPipe)
+		err = cmd.Wait()
+	}
+	if err != nil {
 		// Check for timeout.
 		if ctx.Err() == context.DeadlineExceeded {
 			return nil, fmt.Errorf("tool %q timed out after %v", toolName, e.cfg.TimeoutPerTool)
 		}
 		return nil, fmt.Errorf("subprocess execution of tool %q: %w (stderr: %s)", toolName, err, stderr.String())
 	}
+	if readErr != nil {
+		return nil, fmt.Errorf("read worker output: %w", readErr)
+	}
 
-	// Parse result from stdout.
+	// Parse result from the last non-event line.
 	var result ExecutionResult
-	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
-		return nil, fmt.Errorf("unmarshal execution result: %w (raw: %s)", err, stdout.String())
+	if err := json.Unmarshal(stdout, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal execution result: %w (raw: %s)", err, stdout)
 	}
 
 	if result.Error != "" {
@@ -80,6 +98,30 @@ func (e *SubprocessExecutor) Execute(ctx context.Context, toolName string, param
 	return result.Output, nil
 }
 
+// readWorkerOutput reads a worker's stdout to EOF, emitting event lines to
+// ctx and returning the remaining output, which holds the result.
+func readWorkerOutput(ctx context.Context, r io.Reader) ([]byte, error) {
+	var rest bytes.Buffer
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			var msg ExecutionResult
+			if json.Unmarshal(line, &msg) == nil && msg.Event != nil {
+				Emit(ctx, *msg.Event)
+			} else {
+				rest.Write(line)
+			}
+		}
+		if err == io.EOF {
+			return rest.Bytes(), nil
+		}
+		if err != nil {
+			return rest.Bytes(), err
+		}
+	}
+}
+
 // cleanEnv returns a minimal environment with only PATH and HOME.
 func cleanEnv() []string {
 	var env []string
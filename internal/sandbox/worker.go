@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sync"
 )
 
 // workerFlag is the CLI flag that triggers sandbox worker mode.
@@ -28,8 +29,9 @@ type ToolRegistry map[string]ToolHandler
 
 // RunWorker is the entry point for the sandbox worker subprocess.
 // It reads an ExecutionRequest from stdin, executes the named tool
-// from the registry, and writes an ExecutionResult to stdout.
-// The worker exits with code 0 on success, 1 on failure.
+// from the registry, and writes an ExecutionResult to stdout. For a
+// streaming request, events the tool emits are written first, one line
+// each. The worker exits with code 0 on success, 1 on failure.
 func RunWorker(registry ToolRegistry) {
 	var req ExecutionRequest
 	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
@@ -43,10 +45,24 @@ func RunWorker(registry ToolRegistry) {
 		os.Exit(1)
 	}
 
+	// Events and the result share stdout; a tool may emit from its own
+	// goroutines, so lines are written one at a time.
+	var mu sync.Mutex
+	write := func(r ExecutionResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		writeResult(r)
+	}
+
 	ctx := context.Background()
+	if req.Version >= streamingVersion {
+		ctx = WithEventFunc(ctx, func(ev ExecutionEvent) {
+			write(ExecutionResult{Event: &ev})
+		})
+	}
 	result, err := handler(ctx, req.Params)
 	if err != nil {
-		writeResult(ExecutionResult{Error: err.Error()})
+		write(ExecutionResult{Error: err.Error()})
 		os.Exit(0) // exit 0 — error is communicated via JSON
 	}
 
@@ -59,7 +75,7 @@ func RunWorker(registry ToolRegistry) {
 		output = map[string]interface{}{"result": v}
 	}
 
-	writeResult(ExecutionResult{Output: output})
+	write(ExecutionResult{Output: output})
 }
 
 // writeResult encodes an ExecutionResult to stdout.
@@ -10,6 +10,13 @@ var (
 	ErrNoEncryptionKeys = errors.New("no encryption keys available")
 	ErrDecryptionFailed = errors.New("decryption failed")
 
+	// Secret access errors
+	ErrSecretNotFound     = errors.New("secret not found")
+	ErrSecretAccessDenied = errors.New("secret access denied")
+	ErrSecretExpired      = errors.New("secret expired")
+	ErrSecretVersion      = errors.New("secret version not found")
+	ErrSecretsBackend     = errors.New("secrets backend error")
+
 	// Envelope errors
 	ErrInvalidSlot     = errors.New("invalid KEK slot")
 	ErrLastSlot        = errors.New("cannot remove last KEK slot")
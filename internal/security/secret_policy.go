@@ -0,0 +1,89 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"path"
+)
+
+// SecretPolicy restricts which callers may resolve a secret. Each list is an
+// allowlist for one caller dimension; an empty list leaves that dimension
+// unrestricted. Entries may use path.Match glob syntax (e.g. "research-*").
+type SecretPolicy struct {
+	AllowedAgents     []string `json:"allowedAgents,omitempty"`
+	AllowedTools      []string `json:"allowedTools,omitempty"`
+	AllowedMCPServers []string `json:"allowedMcpServers,omitempty"`
+}
+
+// IsZero reports whether the policy places no restrictions at all.
+func (p SecretPolicy) IsZero() bool {
+	return len(p.AllowedAgents) == 0 && len(p.AllowedTools) == 0 && len(p.AllowedMCPServers) == 0
+}
+
+// SecretAccessor identifies the caller resolving a secret.
+// Empty fields mean the caller is not acting in that capacity; for example
+// an MCP server launch has no Agent or Tool. Agent tools set Tool to their
+// own name.
+type SecretAccessor struct {
+	SessionKey string
+	Agent      string
+	Tool       string
+	MCPServer  string
+}
+
+// Check returns ErrSecretAccessDenied when the accessor falls outside the
+// policy. Restricted dimensions require a matching, non-empty caller value,
+// so a policy with AllowedTools denies callers that are not tools, MCP
+// servers included. An MCP server acts on no agent's behalf, so it is checked
+// against AllowedMCPServers in place of AllowedAgents; a policy that
+// restricts agents but names no MCP servers denies every MCP server.
+func (p SecretPolicy) Check(acc SecretAccessor) error {
+	if acc.MCPServer != "" {
+		if (len(p.AllowedMCPServers) > 0 || len(p.AllowedAgents) > 0) && !matchAny(p.AllowedMCPServers, acc.MCPServer) {
+			return fmt.Errorf("%w: MCP server %q not allowed", ErrSecretAccessDenied, acc.MCPServer)
+		}
+	} else if len(p.AllowedAgents) > 0 && !matchAny(p.AllowedAgents, acc.Agent) {
+		return fmt.Errorf("%w: agent %q not allowed", ErrSecretAccessDenied, displayName(acc.Agent))
+	}
+	if len(p.AllowedTools) > 0 && !matchAny(p.AllowedTools, acc.Tool) {
+		return fmt.Errorf("%w: tool %q not allowed", ErrSecretAccessDenied, displayName(acc.Tool))
+	}
+	return nil
+}
+
+func matchAny(patterns []string, name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, p := range patterns {
+		if p == "*" || p == name {
+			return true
+		}
+		if ok, err := path.Match(p, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func displayName(name string) string {
+	if name == "" {
+		return "(none)"
+	}
+	return name
+}
+
+// SecretResolver resolves a secret value on behalf of an accessor,
+// enforcing the secret's access policy and expiry.
+// *SecretsStore satisfies this interface.
+type SecretResolver interface {
+	ResolveSecret(ctx context.Context, name string, acc SecretAccessor) ([]byte, error)
+}
+
+// SecretAuthorizer checks whether an accessor may resolve a secret without
+// returning its value. It is used by RefStore, which already holds the
+// plaintext captured when the reference token was issued.
+// *SecretsStore satisfies this interface.
+type SecretAuthorizer interface {
+	AuthorizeSecret(ctx context.Context, name string, acc SecretAccessor) error
+}
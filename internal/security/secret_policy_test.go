@@ -0,0 +1,315 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/eventbus"
+)
+
+func TestSecretPolicy_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give     string
+		policy   SecretPolicy
+		acc      SecretAccessor
+		wantDeny bool
+	}{
+		{
+			give: "zero policy allows anyone",
+			acc:  SecretAccessor{Agent: "anyone", Tool: "exec"},
+		},
+		{
+			give:   "agent allowed",
+			policy: SecretPolicy{AllowedAgents: []string{"operator"}},
+			acc:    SecretAccessor{Agent: "operator"},
+		},
+		{
+			give:     "agent denied",
+			policy:   SecretPolicy{AllowedAgents: []string{"operator"}},
+			acc:      SecretAccessor{Agent: "librarian"},
+			wantDeny: true,
+		},
+		{
+			give:     "missing agent denied when agents restricted",
+			policy:   SecretPolicy{AllowedAgents: []string{"operator"}},
+			acc:      SecretAccessor{},
+			wantDeny: true,
+		},
+		{
+			give:   "agent glob",
+			policy: SecretPolicy{AllowedAgents: []string{"research-*"}},
+			acc:    SecretAccessor{Agent: "research-web"},
+		},
+		{
+			give:     "tool denied",
+			policy:   SecretPolicy{AllowedTools: []string{"exec"}},
+			acc:      SecretAccessor{Agent: "operator", Tool: "browser"},
+			wantDeny: true,
+		},
+		{
+			give:   "secrets_get allowed by name",
+			policy: SecretPolicy{AllowedTools: []string{"secrets_get", "exec"}},
+			acc:    SecretAccessor{Agent: "operator", Tool: "secrets_get"},
+		},
+		{
+			give:     "secrets_get denied by exec-only policy",
+			policy:   SecretPolicy{AllowedTools: []string{"exec"}},
+			acc:      SecretAccessor{Agent: "operator", Tool: "secrets_get"},
+			wantDeny: true,
+		},
+		{
+			give:     "missing tool denied when tools restricted",
+			policy:   SecretPolicy{AllowedTools: []string{"exec"}},
+			acc:      SecretAccessor{Agent: "operator"},
+			wantDeny: true,
+		},
+		{
+			give:   "mcp server allowed despite agent restriction",
+			policy: SecretPolicy{AllowedAgents: []string{"operator"}, AllowedMCPServers: []string{"github"}},
+			acc:    SecretAccessor{MCPServer: "github"},
+		},
+		{
+			give:     "mcp server denied by agent-only policy",
+			policy:   SecretPolicy{AllowedAgents: []string{"operator"}},
+			acc:      SecretAccessor{MCPServer: "github"},
+			wantDeny: true,
+		},
+		{
+			give: "mcp server allowed by zero policy",
+			acc:  SecretAccessor{MCPServer: "github"},
+		},
+		{
+			give:     "mcp server denied by tool-only policy",
+			policy:   SecretPolicy{AllowedTools: []string{"exec"}},
+			acc:      SecretAccessor{MCPServer: "github"},
+			wantDeny: true,
+		},
+		{
+			give:     "mcp server denied",
+			policy:   SecretPolicy{AllowedMCPServers: []string{"github"}},
+			acc:      SecretAccessor{MCPServer: "slack"},
+			wantDeny: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			err := tt.policy.Check(tt.acc)
+			if tt.wantDeny {
+				assert.ErrorIs(t, err, ErrSecretAccessDenied)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSecretsStore_RotateAndVersions(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestSecretsStore(t)
+	ctx := context.Background()
+
+	_, err := store.Rotate(ctx, "missing", []byte("x"))
+	require.ErrorIs(t, err, ErrSecretNotFound)
+
+	require.NoError(t, store.Store(ctx, "api-key", []byte("v1")))
+	v, err := store.Rotate(ctx, "api-key", []byte("v2"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	val, err := store.Get(ctx, "api-key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v2"), val)
+
+	versions, err := store.Versions(ctx, "api-key")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, 2, versions[0].Version)
+	assert.True(t, versions[0].Current)
+	assert.Equal(t, 1, versions[1].Version)
+	assert.False(t, versions[1].Current)
+}
+
+func TestSecretsStore_PruneVersions(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestSecretsStore(t)
+	ctx := context.Background()
+
+	_, err := store.PruneVersions(ctx, "missing", 2)
+	require.ErrorIs(t, err, ErrSecretNotFound)
+
+	for _, v := range []string{"v1", "v2", "v3", "v4"} {
+		require.NoError(t, store.Store(ctx, "token", []byte(v)))
+	}
+	n, err := store.PruneVersions(ctx, "token", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	versions, err := store.Versions(ctx, "token")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, 4, versions[0].Version)
+	assert.Equal(t, 3, versions[1].Version)
+
+	val, err := store.Get(ctx, "token")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v4"), val)
+}
+
+func TestSecretsStore_StaleWriteRejected(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestSecretsStore(t)
+	ctx := context.Background()
+	require.NoError(t, store.Store(ctx, "api-key", []byte("v1")))
+
+	stale, err := store.client.Secret.Query().WithKey().Only(ctx)
+	require.NoError(t, err)
+	_, err = store.Rotate(ctx, "api-key", []byte("v2"))
+	require.NoError(t, err)
+
+	// A writer that read version 1 before the rotation must not overwrite it.
+	err = setCurrentVersion(ctx, store.client, stale, stale.Edges.Key, 2, []byte("ENC:stale"))
+	require.ErrorContains(t, err, "modified concurrently")
+
+	val, err := store.Get(ctx, "api-key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v2"), val)
+}
+
+func TestSecretsStore_Rollback(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestSecretsStore(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.Store(ctx, "api-key", []byte("v1")))
+	_, err := store.Rotate(ctx, "api-key", []byte("v2"))
+	require.NoError(t, err)
+
+	v, err := store.Rollback(ctx, "api-key", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, v, "rollback appends a new version")
+
+	val, err := store.Get(ctx, "api-key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v1"), val)
+
+	_, err = store.Rollback(ctx, "api-key", 9)
+	assert.ErrorIs(t, err, ErrSecretVersion)
+}
+
+func TestSecretsStore_ResolveSecret(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestSecretsStore(t)
+	ctx := context.Background()
+	bus := eventbus.New()
+	store.SetEventBus(bus)
+
+	var events []eventbus.SecretAccessEvent
+	eventbus.SubscribeTyped(bus, func(e eventbus.SecretAccessEvent) {
+		events = append(events, e)
+	})
+
+	require.NoError(t, store.Store(ctx, "token", []byte("secret")))
+	require.NoError(t, store.SetPolicy(ctx, "token", SecretPolicy{AllowedAgents: []string{"operator"}}))
+
+	val, err := store.ResolveSecret(ctx, "token", SecretAccessor{Agent: "operator"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), val)
+
+	_, err = store.ResolveSecret(ctx, "token", SecretAccessor{Agent: "librarian"})
+	require.ErrorIs(t, err, ErrSecretAccessDenied)
+
+	past := time.Now().Add(-time.Minute)
+	require.NoError(t, store.SetExpiry(ctx, "token", &past))
+	_, err = store.ResolveSecret(ctx, "token", SecretAccessor{Agent: "operator"})
+	require.ErrorIs(t, err, ErrSecretExpired)
+
+	// Get is the trusted path and ignores policy and expiry.
+	_, err = store.Get(ctx, "token")
+	require.NoError(t, err)
+
+	require.Len(t, events, 3)
+	assert.True(t, events[0].Granted)
+	assert.False(t, events[1].Granted)
+	assert.Equal(t, "librarian", events[1].Agent)
+	assert.False(t, events[2].Granted)
+	assert.NotEmpty(t, events[2].Reason)
+}
+
+func TestExpandSecretRefs_ToolPolicyDeniesMCPServers(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestSecretsStore(t)
+	ctx := context.Background()
+	require.NoError(t, store.Store(ctx, "gh", []byte("ghp_test")))
+	require.NoError(t, store.SetPolicy(ctx, "gh", SecretPolicy{AllowedTools: []string{"exec"}, AllowedMCPServers: []string{"github"}}))
+
+	// MCP server env and headers are not resolved by a tool.
+	_, err := ExpandSecretRefs(ctx, "Bearer {{secret:gh}}", store, SecretAccessor{MCPServer: "github"})
+	assert.ErrorIs(t, err, ErrSecretAccessDenied)
+
+	require.NoError(t, store.SetPolicy(ctx, "gh", SecretPolicy{AllowedMCPServers: []string{"github"}}))
+	out, err := ExpandSecretRefs(ctx, "Bearer {{secret:gh}}", store, SecretAccessor{MCPServer: "github"})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer ghp_test", out)
+}
+
+func TestSecretsStore_ListExpiring(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestSecretsStore(t)
+	ctx := context.Background()
+
+	soon := time.Now().Add(time.Hour)
+	later := time.Now().Add(30 * 24 * time.Hour)
+	require.NoError(t, store.Store(ctx, "soon", []byte("a")))
+	require.NoError(t, store.Store(ctx, "later", []byte("b")))
+	require.NoError(t, store.Store(ctx, "never", []byte("c")))
+	require.NoError(t, store.SetExpiry(ctx, "soon", &soon))
+	require.NoError(t, store.SetExpiry(ctx, "later", &later))
+
+	infos, err := store.ListExpiring(ctx, time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, "soon", infos[0].Name)
+}
+
+type stubAuthorizer struct {
+	deny map[string]bool
+}
+
+func (a stubAuthorizer) AuthorizeSecret(_ context.Context, name string, _ SecretAccessor) error {
+	if a.deny[name] {
+		return ErrSecretAccessDenied
+	}
+	return nil
+}
+
+func TestRefStore_ResolveAllFor(t *testing.T) {
+	t.Parallel()
+
+	rs := NewRefStore()
+	allowed := rs.Store("allowed", []byte("A"))
+	denied := rs.Store("denied", []byte("D"))
+	rs.SetAuthorizer(stubAuthorizer{deny: map[string]bool{"denied": true}})
+	ctx := context.Background()
+
+	out, err := rs.ResolveAllFor(ctx, "echo "+allowed, SecretAccessor{Tool: "exec"})
+	require.NoError(t, err)
+	assert.Equal(t, "echo A", out)
+
+	_, err = rs.ResolveAllFor(ctx, "echo "+allowed+" "+denied, SecretAccessor{Tool: "exec"})
+	assert.True(t, errors.Is(err, ErrSecretAccessDenied))
+}
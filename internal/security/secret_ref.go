@@ -1,6 +1,8 @@
 package security
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"sync"
@@ -17,8 +19,9 @@ var refLogger = logging.SubsystemSugar("secret-ref")
 // plaintext values. It prevents AI agents from seeing actual secret values
 // by substituting them with safe reference tokens.
 type RefStore struct {
-	mu      sync.RWMutex
-	secrets map[string][]byte // token -> plaintext
+	mu         sync.RWMutex
+	secrets    map[string][]byte // token -> plaintext
+	authorizer SecretAuthorizer  // optional policy check for {{secret:...}} tokens
 }
 
 // NewRefStore creates a new RefStore.
@@ -91,6 +94,56 @@ func (r *RefStore) ResolveAll(input string) string {
 	})
 }
 
+// SetAuthorizer attaches a policy check that ResolveAllFor consults before
+// substituting each {{secret:name}} token. Passing nil disables the check.
+func (r *RefStore) SetAuthorizer(a SecretAuthorizer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.authorizer = a
+}
+
+// ResolveAllFor is like ResolveAll but authorizes every {{secret:name}}
+// token for acc before substitution. Resolution fails closed: if any token is
+// denied, an error is returned and no substitution is performed.
+// {{decrypt:...}} tokens are not policy-controlled and resolve as usual.
+func (r *RefStore) ResolveAllFor(ctx context.Context, input string, acc SecretAccessor) (string, error) {
+	r.mu.RLock()
+	authorizer := r.authorizer
+	r.mu.RUnlock()
+
+	if authorizer != nil {
+		checked := make(map[string]bool)
+		for _, m := range tokenPattern.FindAllStringSubmatch(input, -1) {
+			if m[1] != "secret" || checked[m[2]] {
+				continue
+			}
+			checked[m[2]] = true
+			if _, ok := r.Resolve(m[0]); !ok {
+				// Remote references ("path#field") that were never issued by
+				// secrets_get are fetched on demand when the authorizer can
+				// resolve values; other unknown tokens are left untouched.
+				resolver, canResolve := authorizer.(SecretResolver)
+				if _, _, remote := ParseRemoteSecretRef(m[2]); !remote || !canResolve {
+					continue
+				}
+				val, err := resolver.ResolveSecret(ctx, m[2], acc)
+				if errors.Is(err, ErrSecretNotFound) {
+					continue
+				}
+				if err != nil {
+					return "", err
+				}
+				r.Store(m[2], val)
+				continue
+			}
+			if err := authorizer.AuthorizeSecret(ctx, m[2], acc); err != nil {
+				return "", err
+			}
+		}
+	}
+	return r.ResolveAll(input), nil
+}
+
 // Values returns all stored plaintext values. This is useful for output
 // scanning to detect accidental secret leakage.
 func (r *RefStore) Values() [][]byte {
@@ -131,3 +184,37 @@ func (r *RefStore) Clear() {
 	r.secrets = make(map[string][]byte)
 	refLogger.Debugw("cleared all secret references")
 }
+
+// ExpandSecretRefs replaces every {{secret:name}} token in input with the
+// value returned by resolver for acc. Unlike RefStore, nothing is cached: each
+// token is resolved (and policy-checked) on every call. {{decrypt:...}}
+// tokens are left untouched. The first resolution error aborts expansion.
+func ExpandSecretRefs(ctx context.Context, input string, resolver SecretResolver, acc SecretAccessor) (string, error) {
+	var firstErr error
+	out := tokenPattern.ReplaceAllStringFunc(input, func(token string) string {
+		m := tokenPattern.FindStringSubmatch(token)
+		if firstErr != nil || m[1] != "secret" {
+			return token
+		}
+		val, err := resolver.ResolveSecret(ctx, m[2], acc)
+		if err != nil {
+			firstErr = err
+			return token
+		}
+		return string(val)
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return out, nil
+}
+
+// HasSecretRefs reports whether input contains any {{secret:name}} token.
+func HasSecretRefs(input string) bool {
+	for _, m := range tokenPattern.FindAllStringSubmatch(input, -1) {
+		if m[1] == "secret" {
+			return true
+		}
+	}
+	return false
+}
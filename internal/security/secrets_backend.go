@@ -0,0 +1,98 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/langoai/lango/internal/config"
+)
+
+// SecretsBackend resolves secrets held outside the local database.
+// References of the form {{secret:path#field}} are routed to the configured
+// backend; plain names always resolve from the local SecretsStore.
+type SecretsBackend interface {
+	// Resolve returns the value of field in the secret stored at path.
+	Resolve(ctx context.Context, path, field string) ([]byte, error)
+}
+
+// SecretsBackendName identifies a supported external secrets backend.
+type SecretsBackendName string
+
+const (
+	SecretsBackendLocal SecretsBackendName = "local"
+	SecretsBackendVault SecretsBackendName = "vault"
+)
+
+// NewSecretsBackend creates the external secrets backend selected by cfg.
+// It returns nil, nil for the local (database-only) backend. Build tags
+// control which backends are compiled in; uncompiled backends return a
+// descriptive error.
+func NewSecretsBackend(cfg config.SecretsConfig) (SecretsBackend, error) {
+	switch SecretsBackendName(cfg.Backend) {
+	case "", SecretsBackendLocal:
+		return nil, nil
+	case SecretsBackendVault:
+		return newVaultBackend(cfg.Vault)
+	default:
+		return nil, fmt.Errorf("unknown secrets backend: %q (supported: local, vault)", cfg.Backend)
+	}
+}
+
+// RemoteSecretPath allowlists backend secrets under Prefix for
+// agent-facing resolution and sets their access policy.
+type RemoteSecretPath struct {
+	Prefix string
+	Policy SecretPolicy
+}
+
+// RemoteSecretPathsFromConfig converts the configured remote path allowlist.
+func RemoteSecretPathsFromConfig(cfg []config.RemoteSecretPathConfig) []RemoteSecretPath {
+	paths := make([]RemoteSecretPath, 0, len(cfg))
+	for _, c := range cfg {
+		paths = append(paths, RemoteSecretPath{
+			Prefix: strings.Trim(c.Prefix, "/"),
+			Policy: SecretPolicy{
+				AllowedAgents:     c.AllowedAgents,
+				AllowedTools:      c.AllowedTools,
+				AllowedMCPServers: c.AllowedMCPServers,
+			},
+		})
+	}
+	return paths
+}
+
+// matchRemotePath returns the entry with the longest prefix covering path.
+func matchRemotePath(paths []RemoteSecretPath, path string) (RemoteSecretPath, bool) {
+	var (
+		best  RemoteSecretPath
+		found bool
+	)
+	for _, p := range paths {
+		if p.Prefix == "" || (path != p.Prefix && !strings.HasPrefix(path, p.Prefix+"/")) {
+			continue
+		}
+		if !found || len(p.Prefix) > len(best.Prefix) {
+			best, found = p, true
+		}
+	}
+	return best, found
+}
+
+// ParseRemoteSecretRef splits a remote secret name of the form "path#field".
+// ok is false when name has no field selector, either part is empty, or the
+// path has an empty, "." or ".." segment: the backend could resolve such a
+// path outside the remotePaths prefix it was matched against.
+func ParseRemoteSecretRef(name string) (path, field string, ok bool) {
+	path, field, ok = strings.Cut(name, "#")
+	if !ok || path == "" || field == "" {
+		return "", "", false
+	}
+	path = strings.Trim(path, "/")
+	for _, seg := range strings.Split(path, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return "", "", false
+		}
+	}
+	return path, field, true
+}
@@ -0,0 +1,157 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/config"
+)
+
+func TestParseRemoteSecretRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give      string
+		wantPath  string
+		wantField string
+		wantOK    bool
+	}{
+		{give: "apps/github#token", wantPath: "apps/github", wantField: "token", wantOK: true},
+		{give: "/apps/github/#token", wantPath: "apps/github", wantField: "token", wantOK: true},
+		{give: "api-key"},
+		{give: "#token"},
+		{give: "apps/github#"},
+		{give: "apps/../admin#token"},
+		{give: "apps/./github#token"},
+		{give: "apps//github#token"},
+		{give: "/#token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			path, field, ok := ParseRemoteSecretRef(tt.give)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantPath, path)
+			assert.Equal(t, tt.wantField, field)
+		})
+	}
+}
+
+func TestNewSecretsBackend(t *testing.T) {
+	t.Parallel()
+
+	b, err := NewSecretsBackend(config.SecretsConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, b)
+
+	b, err = NewSecretsBackend(config.SecretsConfig{Backend: "local"})
+	require.NoError(t, err)
+	assert.Nil(t, b)
+
+	_, err = NewSecretsBackend(config.SecretsConfig{Backend: "etcd"})
+	assert.Error(t, err)
+}
+
+type mapBackend map[string]string
+
+func (m mapBackend) Resolve(_ context.Context, path, field string) ([]byte, error) {
+	v, ok := m[path+"#"+field]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s#%s", ErrSecretNotFound, path, field)
+	}
+	return []byte(v), nil
+}
+
+func TestSecretsStore_RemoteRefs(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestSecretsStore(t)
+	ctx := context.Background()
+	require.NoError(t, store.Store(ctx, "local#name", []byte("local")))
+
+	// Without a backend, "path#field" names are ordinary local names.
+	val, err := store.ResolveSecret(ctx, "local#name", SecretAccessor{})
+	require.NoError(t, err)
+	assert.Equal(t, "local", string(val))
+
+	store.SetBackend(mapBackend{"apps/db#password": "remote", "infra/root#key": "root"})
+	store.SetRemotePaths([]RemoteSecretPath{{Prefix: "apps"}})
+
+	val, err = store.ResolveSecret(ctx, "apps/db#password", SecretAccessor{Agent: "operator"})
+	require.NoError(t, err)
+	assert.Equal(t, "remote", string(val))
+
+	val, err = store.Get(ctx, "apps/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "remote", string(val))
+
+	_, err = store.ResolveSecret(ctx, "apps/db#user", SecretAccessor{})
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+
+	// Unknown remote tokens in exec commands are fetched on demand.
+	refs := NewRefStore()
+	refs.SetAuthorizer(store)
+	out, err := refs.ResolveAllFor(ctx, "psql -W {{secret:apps/db#password}} {{secret:apps/db#user}}", SecretAccessor{Tool: "exec"})
+	require.NoError(t, err)
+	assert.Equal(t, "psql -W remote {{secret:apps/db#user}}", out)
+}
+
+func TestSecretsStore_RemotePathPolicy(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestSecretsStore(t)
+	ctx := context.Background()
+	store.SetBackend(mapBackend{
+		"apps/db#password":     "db",
+		"apps/ci/deploy#token": "deploy",
+		"apps-legacy/x#key":    "legacy",
+		"infra/root#key":       "root",
+	})
+	store.SetRemotePaths(RemoteSecretPathsFromConfig([]config.RemoteSecretPathConfig{
+		{Prefix: "apps/"},
+		{Prefix: "apps/ci", AllowedAgents: []string{"operator"}},
+	}))
+
+	tests := []struct {
+		give     string
+		acc      SecretAccessor
+		wantDeny bool
+	}{
+		{give: "apps/db#password", acc: SecretAccessor{Agent: "librarian"}},
+		{give: "infra/root#key", acc: SecretAccessor{Agent: "operator"}, wantDeny: true},
+		{give: "apps-legacy/x#key", acc: SecretAccessor{Agent: "operator"}, wantDeny: true},
+		{give: "apps/ci/deploy#token", acc: SecretAccessor{Agent: "operator"}},
+		{give: "apps/ci/deploy#token", acc: SecretAccessor{Agent: "librarian"}, wantDeny: true},
+	}
+	// Traversal out of an allowed prefix never reaches the backend.
+	for _, name := range []string{"apps/../infra/root#key", "apps/db/../../infra/root#key"} {
+		val, err := store.ResolveSecret(ctx, name, SecretAccessor{Agent: "operator"})
+		assert.Error(t, err, name)
+		assert.Empty(t, val, name)
+		val, err = store.Get(ctx, name)
+		assert.Error(t, err, name)
+		assert.Empty(t, val, name)
+	}
+
+
+	for _, tt := range tests {
+		t.Run(tt.give+"/"+tt.acc.Agent, func(t *testing.T) {
+			_, err := store.ResolveSecret(ctx, tt.give, tt.acc)
+			if tt.wantDeny {
+				assert.ErrorIs(t, err, ErrSecretAccessDenied)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	// Prompt-injected references to paths outside the allowlist fail closed.
+	refs := NewRefStore()
+	refs.SetAuthorizer(store)
+	_, err := refs.ResolveAllFor(ctx, "echo {{secret:infra/root#key}}", SecretAccessor{Tool: "exec"})
+	assert.ErrorIs(t, err, ErrSecretAccessDenied)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/langoai/lango/internal/ent"
 	"github.com/langoai/lango/internal/ent/secret"
+	"github.com/langoai/lango/internal/ent/secretversion"
+	"github.com/langoai/lango/internal/eventbus"
 	"github.com/langoai/lango/internal/logging"
 )
 
@@ -22,6 +24,22 @@ type SecretInfo struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	AccessCount int
+	Version     int
+	ExpiresAt   *time.Time
+	Policy      SecretPolicy
+}
+
+// Expired reports whether the secret's expiry time has passed at now.
+func (i *SecretInfo) Expired(now time.Time) bool {
+	return i.ExpiresAt != nil && !now.Before(*i.ExpiresAt)
+}
+
+// SecretVersionInfo describes one stored version of a secret.
+type SecretVersionInfo struct {
+	Version   int
+	KeyName   string
+	CreatedAt time.Time
+	Current   bool
 }
 
 // SecretsStore manages encrypted secrets.
@@ -29,6 +47,10 @@ type SecretsStore struct {
 	client   *ent.Client
 	registry *KeyRegistry
 	crypto   CryptoProvider
+	bus      *eventbus.Bus
+	backend  SecretsBackend
+	remote   []RemoteSecretPath
+	now      func() time.Time
 }
 
 // NewSecretsStore creates a new SecretsStore.
@@ -37,59 +59,370 @@ func NewSecretsStore(client *ent.Client, registry *KeyRegistry, crypto CryptoPro
 		client:   client,
 		registry: registry,
 		crypto:   crypto,
+		now:      time.Now,
+	}
+}
+
+// SetEventBus attaches an event bus for SecretAccessEvent publishing.
+// Passing nil disables publishing.
+func (s *SecretsStore) SetEventBus(bus *eventbus.Bus) {
+	s.bus = bus
+}
+
+// SetBackend attaches an external secrets backend. Names of the form
+// "path#field" then resolve through the backend instead of the database.
+// Passing nil restores database-only resolution.
+func (s *SecretsStore) SetBackend(b SecretsBackend) {
+	s.backend = b
+}
+
+// SetRemotePaths sets the backend path allowlist applied to agent-facing
+// resolution of "path#field" names. Paths outside every entry are denied.
+func (s *SecretsStore) SetRemotePaths(paths []RemoteSecretPath) {
+	s.remote = paths
+}
+
+// remoteRef reports whether name addresses the external backend.
+func (s *SecretsStore) remoteRef(name string) (path, field string, ok bool) {
+	if s.backend == nil {
+		return "", "", false
 	}
+	return ParseRemoteSecretRef(name)
 }
 
-// Store encrypts and stores a secret value.
+// Store encrypts and stores a secret value. Storing under an existing name
+// rotates the secret: the previous value is kept as a historical version.
 func (s *SecretsStore) Store(ctx context.Context, name string, value []byte) error {
+	_, err := s.store(ctx, name, value)
+	return err
+}
+
+// Rotate stores a new value for an existing secret and returns the new
+// version number. Unlike Store, it fails when the secret does not exist.
+func (s *SecretsStore) Rotate(ctx context.Context, name string, value []byte) (int, error) {
+	exists, err := s.client.Secret.Query().Where(secret.NameEQ(name)).Exist(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("query secret: %w", err)
+	}
+	if !exists {
+		return 0, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return s.store(ctx, name, value)
+}
+
+func (s *SecretsStore) store(ctx context.Context, name string, value []byte) (int, error) {
 	// Get default key for encryption
 	keyInfo, err := s.registry.GetDefaultKey(ctx)
 	if err != nil {
-		return fmt.Errorf("no encryption key available: %w", err)
+		return 0, fmt.Errorf("no encryption key available: %w", err)
 	}
 
 	// Encrypt the value
 	encrypted, err := s.crypto.Encrypt(ctx, keyInfo.RemoteKeyID, value)
 	if err != nil {
-		return fmt.Errorf("encrypt secret: %w", err)
+		return 0, fmt.Errorf("encrypt secret: %w", err)
+	}
+
+	// Read the current version and append the next one atomically so
+	// concurrent writers cannot interleave.
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	version, err := s.storeTx(ctx, tx.Client(), name, keyInfo.ID, encrypted)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit secret: %w", err)
+	}
+	if version > 1 {
+		secretsLogger.Infow("secret rotated", "name", name, "version", version)
 	}
+	return version, nil
+}
 
+func (s *SecretsStore) storeTx(ctx context.Context, client *ent.Client, name string, keyID uuid.UUID, encrypted []byte) (int, error) {
 	// Get the key entity
-	keyEntity, err := s.client.Key.Get(ctx, keyInfo.ID)
+	keyEntity, err := client.Key.Get(ctx, keyID)
 	if err != nil {
-		return fmt.Errorf("get key entity: %w", err)
+		return 0, fmt.Errorf("get key entity: %w", err)
 	}
 
 	// Check if secret exists
-	existing, err := s.client.Secret.Query().Where(secret.NameEQ(name)).Only(ctx)
+	existing, err := client.Secret.Query().Where(secret.NameEQ(name)).WithKey().Only(ctx)
 	if err == nil {
-		// Update existing secret
-		_, err = existing.Update().
-			SetEncryptedValue(encrypted).
+		// Preserve the outgoing value before overwriting it.
+		if err := ensureVersionRow(ctx, client, existing); err != nil {
+			return 0, err
+		}
+		next := existing.Version + 1
+		if _, err := client.SecretVersion.Create().
+			SetSecret(existing).
 			SetKey(keyEntity).
-			SetAccessCount(0). // Reset access count on update
-			Save(ctx)
-		if err != nil {
-			return fmt.Errorf("update secret: %w", err)
+			SetVersion(next).
+			SetEncryptedValue(encrypted).
+			Save(ctx); err != nil {
+			return 0, fmt.Errorf("create secret version: %w", err)
 		}
-		return nil
+
+		// Update existing secret
+		if err := setCurrentVersion(ctx, client, existing, keyEntity, next, encrypted); err != nil {
+			return 0, err
+		}
+		return next, nil
+	}
+	if !ent.IsNotFound(err) {
+		return 0, fmt.Errorf("query secret: %w", err)
 	}
 
 	// Create new secret
-	_, err = s.client.Secret.Create().
+	created, err := client.Secret.Create().
 		SetName(name).
 		SetEncryptedValue(encrypted).
 		SetKey(keyEntity).
 		Save(ctx)
 	if err != nil {
-		return fmt.Errorf("create secret: %w", err)
+		return 0, fmt.Errorf("create secret: %w", err)
+	}
+	if _, err := client.SecretVersion.Create().
+		SetSecret(created).
+		SetKey(keyEntity).
+		SetVersion(created.Version).
+		SetEncryptedValue(encrypted).
+		Save(ctx); err != nil {
+		return 0, fmt.Errorf("create secret version: %w", err)
+	}
+
+	return created.Version, nil
+}
+
+// setCurrentVersion points sec at a new current value. The update only
+// applies if sec is still at the version it was read at, so a concurrent
+// writer that got there first fails this one instead of being overwritten.
+func setCurrentVersion(ctx context.Context, client *ent.Client, sec *ent.Secret, key *ent.Key, version int, encrypted []byte) error {
+	n, err := client.Secret.Update().
+		Where(secret.ID(sec.ID), secret.VersionEQ(sec.Version)).
+		SetEncryptedValue(encrypted).
+		SetKey(key).
+		SetVersion(version).
+		SetAccessCount(0). // Reset access count on update
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("update secret: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("update secret %q: modified concurrently", sec.Name)
 	}
+	return nil
+}
 
+// ensureVersionRow backfills the history row for the current value of a
+// secret created before versioning existed.
+func ensureVersionRow(ctx context.Context, client *ent.Client, sec *ent.Secret) error {
+	exists, err := client.Secret.QueryVersions(sec).Where(secretversion.VersionEQ(sec.Version)).Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("query secret versions: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if sec.Edges.Key == nil {
+		return fmt.Errorf("secret has no associated key")
+	}
+	_, err = client.SecretVersion.Create().
+		SetSecret(sec).
+		SetKey(sec.Edges.Key).
+		SetVersion(sec.Version).
+		SetEncryptedValue(sec.EncryptedValue).
+		SetCreatedAt(sec.UpdatedAt).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("backfill secret version: %w", err)
+	}
+	return nil
+}
+
+// Rollback makes the value of an earlier version current again. The rollback
+// is recorded as a new version so the history stays append-only.
+// Returns the new current version number.
+func (s *SecretsStore) Rollback(ctx context.Context, name string, version int) (int, error) {
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	next, err := rollbackTx(ctx, tx.Client(), name, version)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit secret rollback: %w", err)
+	}
+
+	secretsLogger.Infow("secret rolled back", "name", name, "from", version, "version", next)
+	return next, nil
+}
+
+func rollbackTx(ctx context.Context, client *ent.Client, name string, version int) (int, error) {
+	sec, err := client.Secret.Query().
+		Where(secret.NameEQ(name)).
+		WithKey().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+		}
+		return 0, fmt.Errorf("get secret: %w", err)
+	}
+	if version == sec.Version {
+		return 0, fmt.Errorf("version %d is already current", version)
+	}
+
+	target, err := client.Secret.QueryVersions(sec).
+		Where(secretversion.VersionEQ(version)).
+		WithKey().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, fmt.Errorf("%w: %s@%d", ErrSecretVersion, name, version)
+		}
+		return 0, fmt.Errorf("get secret version: %w", err)
+	}
+	if target.Edges.Key == nil {
+		return 0, fmt.Errorf("secret version has no associated key")
+	}
+
+	if err := ensureVersionRow(ctx, client, sec); err != nil {
+		return 0, err
+	}
+	next := sec.Version + 1
+	if _, err := client.SecretVersion.Create().
+		SetSecret(sec).
+		SetKey(target.Edges.Key).
+		SetVersion(next).
+		SetEncryptedValue(target.EncryptedValue).
+		Save(ctx); err != nil {
+		return 0, fmt.Errorf("create secret version: %w", err)
+	}
+	if err := setCurrentVersion(ctx, client, sec, target.Edges.Key, next, target.EncryptedValue); err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
+// Versions returns the version history of a secret, newest first.
+func (s *SecretsStore) Versions(ctx context.Context, name string) ([]*SecretVersionInfo, error) {
+	sec, err := s.client.Secret.Query().Where(secret.NameEQ(name)).WithKey().Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+		}
+		return nil, fmt.Errorf("get secret: %w", err)
+	}
+
+	rows, err := sec.QueryVersions().
+		WithKey().
+		Order(ent.Desc(secretversion.FieldVersion)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list secret versions: %w", err)
+	}
+
+	result := make([]*SecretVersionInfo, 0, len(rows)+1)
+	hasCurrent := false
+	for _, row := range rows {
+		info := &SecretVersionInfo{
+			Version:   row.Version,
+			CreatedAt: row.CreatedAt,
+			Current:   row.Version == sec.Version,
+		}
+		if row.Edges.Key != nil {
+			info.KeyName = row.Edges.Key.Name
+		}
+		hasCurrent = hasCurrent || info.Current
+		result = append(result, info)
+	}
+	if !hasCurrent {
+		// Secret predates versioning: synthesize its current entry.
+		info := &SecretVersionInfo{Version: sec.Version, CreatedAt: sec.UpdatedAt, Current: true}
+		if sec.Edges.Key != nil {
+			info.KeyName = sec.Edges.Key.Name
+		}
+		result = append([]*SecretVersionInfo{info}, result...)
+	}
+	return result, nil
+}
+
+// PruneVersions deletes all but the newest keep versions of a secret and
+// returns how many were deleted. The current version is always kept.
+func (s *SecretsStore) PruneVersions(ctx context.Context, name string, keep int) (int, error) {
+	if keep < 1 {
+		keep = 1
+	}
+	sec, err := s.client.Secret.Query().Where(secret.NameEQ(name)).Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+		}
+		return 0, fmt.Errorf("get secret: %w", err)
+	}
+	n, err := s.client.SecretVersion.Delete().
+		Where(
+			secretversion.HasSecretWith(secret.ID(sec.ID)),
+			secretversion.VersionLTE(sec.Version-keep),
+		).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("prune secret versions: %w", err)
+	}
+	return n, nil
+}
+
+// SetPolicy replaces the access policy of a secret.
+func (s *SecretsStore) SetPolicy(ctx context.Context, name string, policy SecretPolicy) error {
+	n, err := s.client.Secret.Update().
+		Where(secret.NameEQ(name)).
+		SetAllowedAgents(policy.AllowedAgents).
+		SetAllowedTools(policy.AllowedTools).
+		SetAllowedMcpServers(policy.AllowedMCPServers).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("update secret policy: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return nil
+}
+
+// SetExpiry sets the expiry time of a secret. A nil time clears the expiry.
+func (s *SecretsStore) SetExpiry(ctx context.Context, name string, expiresAt *time.Time) error {
+	upd := s.client.Secret.Update().Where(secret.NameEQ(name))
+	if expiresAt == nil {
+		upd = upd.ClearExpiresAt()
+	} else {
+		upd = upd.SetExpiresAt(*expiresAt)
+	}
+	n, err := upd.Save(ctx)
+	if err != nil {
+		return fmt.Errorf("update secret expiry: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
 	return nil
 }
 
 // Get retrieves and decrypts a secret value.
+// Get is the trusted, in-process path (wallets, P2P keys) and does not apply
+// the secret's access policy; agent-facing callers use ResolveSecret.
 func (s *SecretsStore) Get(ctx context.Context, name string) ([]byte, error) {
+	if path, field, ok := s.remoteRef(name); ok {
+		return s.backend.Resolve(ctx, path, field)
+	}
+
 	// Query secret with key edge
 	sec, err := s.client.Secret.Query().
 		Where(secret.NameEQ(name)).
@@ -97,11 +430,14 @@ func (s *SecretsStore) Get(ctx context.Context, name string) ([]byte, error) {
 		Only(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
-			return nil, fmt.Errorf("secret not found: %s", name)
+			return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
 		}
 		return nil, fmt.Errorf("get secret: %w", err)
 	}
+	return s.decrypt(ctx, sec)
+}
 
+func (s *SecretsStore) decrypt(ctx context.Context, sec *ent.Secret) ([]byte, error) {
 	// Get key info for decryption
 	keyEntity := sec.Edges.Key
 	if keyEntity == nil {
@@ -127,13 +463,134 @@ func (s *SecretsStore) Get(ctx context.Context, name string) ([]byte, error) {
 	return decrypted, nil
 }
 
+// AuthorizeSecret checks the secret's expiry and access policy for acc and
+// records the decision as a SecretAccessEvent.
+func (s *SecretsStore) AuthorizeSecret(ctx context.Context, name string, acc SecretAccessor) error {
+	if path, field, ok := s.remoteRef(name); ok {
+		_, err := s.resolveRemote(ctx, name, path, field, acc)
+		return err
+	}
+	_, err := s.authorize(ctx, name, acc)
+	return err
+}
+
+// ResolveSecret authorizes acc against the secret's policy and expiry, then
+// returns the decrypted value. Every call is recorded as a SecretAccessEvent.
+func (s *SecretsStore) ResolveSecret(ctx context.Context, name string, acc SecretAccessor) ([]byte, error) {
+	if path, field, ok := s.remoteRef(name); ok {
+		return s.resolveRemote(ctx, name, path, field, acc)
+	}
+	sec, err := s.authorize(ctx, name, acc)
+	if err != nil {
+		return nil, err
+	}
+	return s.decrypt(ctx, sec)
+}
+
+func (s *SecretsStore) authorize(ctx context.Context, name string, acc SecretAccessor) (*ent.Secret, error) {
+	sec, err := s.client.Secret.Query().
+		Where(secret.NameEQ(name)).
+		WithKey().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			err = fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+			s.publishAccess(name, 0, acc, err)
+			return nil, err
+		}
+		return nil, fmt.Errorf("get secret: %w", err)
+	}
+
+	if sec.ExpiresAt != nil && !s.now().Before(*sec.ExpiresAt) {
+		err := fmt.Errorf("%w: %s (expired %s)", ErrSecretExpired, name, sec.ExpiresAt.Format(time.RFC3339))
+		s.publishAccess(name, sec.Version, acc, err)
+		return nil, err
+	}
+
+	if err := policyOf(sec).Check(acc); err != nil {
+		err = fmt.Errorf("secret %q: %w", name, err)
+		s.publishAccess(name, sec.Version, acc, err)
+		return nil, err
+	}
+
+	s.publishAccess(name, sec.Version, acc, nil)
+	return sec, nil
+}
+
+// resolveRemote fetches a "path#field" secret from the external backend.
+// The path must fall under a SetRemotePaths entry, whose policy is checked
+// like a local secret's; the backend's own policies (for example Vault
+// policies on the configured token) apply on top. Every access is recorded
+// as a SecretAccessEvent.
+func (s *SecretsStore) resolveRemote(ctx context.Context, name, path, field string, acc SecretAccessor) ([]byte, error) {
+	rule, ok := matchRemotePath(s.remote, path)
+	if !ok {
+		err := fmt.Errorf("secret %q: %w: remote path %q is not in security.secrets.remotePaths", name, ErrSecretAccessDenied, path)
+		s.publishAccess(name, 0, acc, err)
+		return nil, err
+	}
+	if err := rule.Policy.Check(acc); err != nil {
+		err = fmt.Errorf("secret %q: %w", name, err)
+		s.publishAccess(name, 0, acc, err)
+		return nil, err
+	}
+
+	val, err := s.backend.Resolve(ctx, path, field)
+	s.publishAccess(name, 0, acc, err)
+	if err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+func (s *SecretsStore) publishAccess(name string, version int, acc SecretAccessor, err error) {
+	if err != nil {
+		secretsLogger.Warnw("secret access denied",
+			"name", name, "agent", acc.Agent, "tool", acc.Tool, "mcpServer", acc.MCPServer, "error", err)
+	}
+	if s.bus == nil {
+		return
+	}
+	evt := eventbus.SecretAccessEvent{
+		Name:       name,
+		Version:    version,
+		SessionKey: acc.SessionKey,
+		Agent:      acc.Agent,
+		Tool:       acc.Tool,
+		MCPServer:  acc.MCPServer,
+		Granted:    err == nil,
+		Timestamp:  s.now(),
+	}
+	if err != nil {
+		evt.Reason = err.Error()
+	}
+	s.bus.Publish(evt)
+}
+
 // List returns metadata for all secrets.
 func (s *SecretsStore) List(ctx context.Context) ([]*SecretInfo, error) {
 	secrets, err := s.client.Secret.Query().WithKey().All(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("list secrets: %w", err)
 	}
+	return toSecretInfos(secrets), nil
+}
+
+// ListExpiring returns metadata for secrets whose expiry time is at or
+// before the given time, including those that have already expired.
+func (s *SecretsStore) ListExpiring(ctx context.Context, before time.Time) ([]*SecretInfo, error) {
+	secrets, err := s.client.Secret.Query().
+		Where(secret.ExpiresAtNotNil(), secret.ExpiresAtLTE(before)).
+		WithKey().
+		Order(ent.Asc(secret.FieldExpiresAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list expiring secrets: %w", err)
+	}
+	return toSecretInfos(secrets), nil
+}
 
+func toSecretInfos(secrets []*ent.Secret) []*SecretInfo {
 	result := make([]*SecretInfo, len(secrets))
 	for i, sec := range secrets {
 		info := &SecretInfo{
@@ -142,6 +599,9 @@ func (s *SecretsStore) List(ctx context.Context) ([]*SecretInfo, error) {
 			CreatedAt:   sec.CreatedAt,
 			UpdatedAt:   sec.UpdatedAt,
 			AccessCount: sec.AccessCount,
+			Version:     sec.Version,
+			ExpiresAt:   sec.ExpiresAt,
+			Policy:      policyOf(sec),
 		}
 		if sec.Edges.Key != nil {
 			info.KeyID = sec.Edges.Key.ID
@@ -149,18 +609,46 @@ func (s *SecretsStore) List(ctx context.Context) ([]*SecretInfo, error) {
 		}
 		result[i] = info
 	}
+	return result
+}
 
-	return result, nil
+func policyOf(sec *ent.Secret) SecretPolicy {
+	return SecretPolicy{
+		AllowedAgents:     sec.AllowedAgents,
+		AllowedTools:      sec.AllowedTools,
+		AllowedMCPServers: sec.AllowedMcpServers,
+	}
 }
 
-// Delete removes a secret by name.
+// Delete removes a secret and its version history by name. Both are removed
+// in one transaction so a failure cannot orphan either.
 func (s *SecretsStore) Delete(ctx context.Context, name string) error {
-	deleted, err := s.client.Secret.Delete().Where(secret.NameEQ(name)).Exec(ctx)
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	if err := deleteTx(ctx, tx.Client(), name); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit secret delete: %w", err)
+	}
+	return nil
+}
+
+func deleteTx(ctx context.Context, client *ent.Client, name string) error {
+	if _, err := client.SecretVersion.Delete().
+		Where(secretversion.HasSecretWith(secret.NameEQ(name))).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("delete secret versions: %w", err)
+	}
+	deleted, err := client.Secret.Delete().Where(secret.NameEQ(name)).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("delete secret: %w", err)
 	}
 	if deleted == 0 {
-		return fmt.Errorf("secret not found: %s", name)
+		return fmt.Errorf("%w: %s", ErrSecretNotFound, name)
 	}
 	return nil
 }
@@ -0,0 +1,530 @@
+//go:build secrets_vault
+
+package security
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/logging"
+)
+
+var vaultLogger = logging.SubsystemSugar("vault")
+
+const (
+	vaultAuthToken   = "token"
+	vaultAuthAppRole = "approle"
+
+	// vaultMinRenewInterval bounds how often the renewal loop may run.
+	vaultMinRenewInterval = 10 * time.Second
+	// vaultIdleRecheck is how often the renewal loop wakes up when the
+	// current token has no lease (root or periodic tokens without TTL).
+	vaultIdleRecheck = 5 * time.Minute
+)
+
+// VaultBackend implements SecretsBackend using the HashiCorp Vault KV v2 HTTP
+// API. It supports token and AppRole auth, Vault Enterprise namespaces, token
+// lease renewal, and a short-lived local cache encrypted under an ephemeral
+// per-process key.
+type VaultBackend struct {
+	address      string
+	namespace    string
+	mount        string
+	authMethod   string
+	staticToken  string
+	appRoleMount string
+	roleID       string
+	secretID     string
+	timeout      time.Duration
+	httpClient   *http.Client
+	cache        *vaultCache
+	now          func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	ttl       time.Duration
+	expiresAt time.Time // zero when the token has no lease
+	renewable bool
+
+	cancel context.CancelFunc
+}
+
+var _ SecretsBackend = (*VaultBackend)(nil)
+
+func newVaultBackend(cfg config.VaultConfig) (SecretsBackend, error) {
+	return NewVaultBackend(cfg)
+}
+
+// NewVaultBackend creates a Vault KV v2 backend. Address, namespace and
+// token fall back to the standard VAULT_ADDR, VAULT_NAMESPACE and VAULT_TOKEN
+// environment variables; the AppRole secret ID is read from
+// LANGO_VAULT_SECRET_ID before falling back to config.
+func NewVaultBackend(cfg config.VaultConfig) (*VaultBackend, error) {
+	v := &VaultBackend{
+		address:      strings.TrimRight(envOr(cfg.Address, "VAULT_ADDR"), "/"),
+		namespace:    envOr(cfg.Namespace, "VAULT_NAMESPACE"),
+		mount:        strings.Trim(cfg.Mount, "/"),
+		authMethod:   cfg.AuthMethod,
+		appRoleMount: strings.Trim(cfg.AppRoleMount, "/"),
+		roleID:       cfg.RoleID,
+		timeout:      cfg.Timeout,
+		now:          time.Now,
+	}
+	if v.address == "" {
+		return nil, fmt.Errorf("new Vault backend: %w: address is required (security.secrets.vault.address or VAULT_ADDR)", ErrSecretsBackend)
+	}
+	if v.mount == "" {
+		v.mount = "secret"
+	}
+	if v.appRoleMount == "" {
+		v.appRoleMount = "approle"
+	}
+	if v.timeout <= 0 {
+		v.timeout = 10 * time.Second
+	}
+
+	switch v.authMethod {
+	case "", vaultAuthToken:
+		v.authMethod = vaultAuthToken
+		v.staticToken = envOr(cfg.Token, "VAULT_TOKEN")
+		if v.staticToken == "" {
+			return nil, fmt.Errorf("new Vault backend: %w: token is required (security.secrets.vault.token or VAULT_TOKEN)", ErrSecretsBackend)
+		}
+	case vaultAuthAppRole:
+		if v.roleID == "" {
+			return nil, fmt.Errorf("new Vault backend: %w: roleId is required for AppRole auth", ErrSecretsBackend)
+		}
+		// Secret ID: environment variable takes priority over config.
+		v.secretID = os.Getenv("LANGO_VAULT_SECRET_ID")
+		if v.secretID == "" {
+			v.secretID = cfg.SecretID
+		}
+	default:
+		return nil, fmt.Errorf("new Vault backend: %w: unknown auth method %q", ErrSecretsBackend, cfg.AuthMethod)
+	}
+
+	ttl := cfg.CacheTTL
+	if ttl == 0 {
+		ttl = 5 * time.Minute
+	}
+	if ttl > 0 {
+		c, err := newVaultCache(ttl)
+		if err != nil {
+			return nil, fmt.Errorf("new Vault backend: %w", err)
+		}
+		v.cache = c
+	}
+
+	v.httpClient = &http.Client{Timeout: v.timeout}
+	return v, nil
+}
+
+// envOr returns value, or the named environment variable when value is empty.
+func envOr(value, env string) string {
+	if value != "" {
+		return value
+	}
+	return os.Getenv(env)
+}
+
+// Resolve returns field from the KV v2 secret at path (relative to the
+// configured mount). Non-string field values are returned as JSON.
+func (v *VaultBackend) Resolve(ctx context.Context, path, field string) ([]byte, error) {
+	data, err := v.read(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := data[field]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s#%s (no such field)", ErrSecretNotFound, path, field)
+	}
+	if s, ok := raw.(string); ok {
+		return []byte(s), nil
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("encode field %s#%s: %w", path, field, err)
+	}
+	return b, nil
+}
+
+func (v *VaultBackend) read(ctx context.Context, path string) (map[string]any, error) {
+	if v.cache != nil {
+		if data, ok := v.cache.get(path, v.now()); ok {
+			return data, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	data, err := v.readRemote(ctx, path)
+	if errors.Is(err, ErrSecretAccessDenied) && v.authMethod == vaultAuthAppRole {
+		// The AppRole token may have been revoked or expired server-side;
+		// log in again and retry once.
+		v.mu.Lock()
+		loginErr := v.authenticateLocked(ctx)
+		v.mu.Unlock()
+		if loginErr != nil {
+			return nil, loginErr
+		}
+		data, err = v.readRemote(ctx, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if v.cache != nil {
+		v.cache.put(path, data, v.now())
+	}
+	return data, nil
+}
+
+func (v *VaultBackend) readRemote(ctx context.Context, path string) (map[string]any, error) {
+	token, err := v.currentToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp vaultKVResponse
+	if err := v.do(ctx, http.MethodGet, v.mount+"/data/"+escapeVaultPath(path), nil, token, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Data.Data == nil {
+		return nil, fmt.Errorf("%w: %s (deleted or destroyed)", ErrSecretNotFound, path)
+	}
+	return resp.Data.Data, nil
+}
+
+// currentToken returns a usable token, authenticating first if needed.
+func (v *VaultBackend) currentToken(ctx context.Context) (string, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	expired := !v.expiresAt.IsZero() && !v.now().Before(v.expiresAt)
+	if v.token == "" || expired {
+		if expired && v.authMethod == vaultAuthToken {
+			return "", fmt.Errorf("%w: Vault token lease expired", ErrSecretsBackend)
+		}
+		if err := v.authenticateLocked(ctx); err != nil {
+			return "", err
+		}
+	}
+	return v.token, nil
+}
+
+// authenticateLocked obtains a token and its lease. For token auth the
+// configured token is validated with lookup-self. v.mu must be held.
+func (v *VaultBackend) authenticateLocked(ctx context.Context) error {
+	switch v.authMethod {
+	case vaultAuthAppRole:
+		var resp vaultAuthResponse
+		body := map[string]string{"role_id": v.roleID, "secret_id": v.secretID}
+		if err := v.do(ctx, http.MethodPost, "auth/"+v.appRoleMount+"/login", body, "", &resp); err != nil {
+			return fmt.Errorf("vault AppRole login: %w", err)
+		}
+		if resp.Auth == nil || resp.Auth.ClientToken == "" {
+			return fmt.Errorf("vault AppRole login: %w: no client token in response", ErrSecretsBackend)
+		}
+		v.token = resp.Auth.ClientToken
+		v.setLeaseLocked(resp.Auth.LeaseDuration, resp.Auth.Renewable)
+		vaultLogger.Infow("vault AppRole login succeeded", "ttl", v.ttl)
+	default:
+		var resp vaultLookupResponse
+		if err := v.do(ctx, http.MethodGet, "auth/token/lookup-self", nil, v.staticToken, &resp); err != nil {
+			return fmt.Errorf("vault token lookup: %w", err)
+		}
+		v.token = v.staticToken
+		v.setLeaseLocked(resp.Data.TTL, resp.Data.Renewable)
+	}
+	return nil
+}
+
+func (v *VaultBackend) setLeaseLocked(seconds int, renewable bool) {
+	v.ttl = time.Duration(seconds) * time.Second
+	v.renewable = renewable
+	if seconds <= 0 {
+		v.expiresAt = time.Time{}
+		return
+	}
+	v.expiresAt = v.now().Add(v.ttl)
+}
+
+// Renew extends the current token lease. AppRole backends fall back to a
+// fresh login when the token is not renewable or renewal fails.
+func (v *VaultBackend) Renew(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.token == "" {
+		return v.authenticateLocked(ctx)
+	}
+
+	var renewErr error
+	if v.renewable {
+		var resp vaultAuthResponse
+		renewErr = v.do(ctx, http.MethodPost, "auth/token/renew-self", map[string]any{}, v.token, &resp)
+		if renewErr == nil && resp.Auth != nil {
+			v.setLeaseLocked(resp.Auth.LeaseDuration, resp.Auth.Renewable)
+			return nil
+		}
+	}
+	if v.authMethod == vaultAuthAppRole {
+		return v.authenticateLocked(ctx)
+	}
+	if renewErr != nil {
+		return fmt.Errorf("vault token renewal: %w", renewErr)
+	}
+	return nil
+}
+
+// renewDelay returns how long to wait before the next renewal attempt:
+// two thirds of the lease, immediately when no token is held yet.
+func (v *VaultBackend) renewDelay() time.Duration {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	switch {
+	case v.token == "":
+		return 0
+	case v.expiresAt.IsZero():
+		return vaultIdleRecheck
+	}
+	d := v.expiresAt.Sub(v.now()) * 2 / 3
+	if d < vaultMinRenewInterval {
+		d = vaultMinRenewInterval
+	}
+	return d
+}
+
+// Name implements lifecycle.Component.
+func (v *VaultBackend) Name() string { return "vault-secrets-backend" }
+
+// Start runs the token renewal loop until Stop.
+func (v *VaultBackend) Start(ctx context.Context, wg *sync.WaitGroup) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	v.mu.Lock()
+	v.cancel = cancel
+	v.mu.Unlock()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			timer := time.NewTimer(v.renewDelay())
+			select {
+			case <-runCtx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			if v.leaseless() {
+				continue
+			}
+			if err := v.Renew(runCtx); err != nil && runCtx.Err() == nil {
+				vaultLogger.Warnw("vault token renewal failed", "error", err)
+				select {
+				case <-runCtx.Done():
+					return
+				case <-time.After(vaultMinRenewInterval):
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// leaseless reports whether the held token has no lease to renew.
+func (v *VaultBackend) leaseless() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.token != "" && v.expiresAt.IsZero()
+}
+
+// Stop halts the renewal loop.
+func (v *VaultBackend) Stop(_ context.Context) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+	return nil
+}
+
+// do issues a Vault API request and decodes the JSON response into out.
+func (v *VaultBackend) do(ctx context.Context, method, path string, body any, token string, out any) error {
+	var rdr io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		rdr = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, v.address+"/v1/"+path, rdr)
+	if err != nil {
+		return fmt.Errorf("%w: build request: %v", ErrSecretsBackend, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s %s: %v", ErrSecretsBackend, method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&apiErr)
+		detail := strings.Join(apiErr.Errors, "; ")
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			return fmt.Errorf("%w: vault %s", ErrSecretNotFound, path)
+		case http.StatusForbidden:
+			return fmt.Errorf("%w: vault %s: %s", ErrSecretAccessDenied, path, detail)
+		default:
+			return fmt.Errorf("%w: %s %s: status %d: %s", ErrSecretsBackend, method, path, resp.StatusCode, detail)
+		}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: decode %s response: %v", ErrSecretsBackend, path, err)
+	}
+	return nil
+}
+
+func escapeVaultPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+type vaultKVResponse struct {
+	Data struct {
+		Data     map[string]any `json:"data"`
+		Metadata struct {
+			Version int `json:"version"`
+		} `json:"metadata"`
+	} `json:"data"`
+}
+
+type vaultAuthResponse struct {
+	Auth *struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+		Renewable     bool   `json:"renewable"`
+	} `json:"auth"`
+}
+
+type vaultLookupResponse struct {
+	Data struct {
+		TTL       int  `json:"ttl"`
+		Renewable bool `json:"renewable"`
+	} `json:"data"`
+}
+
+// vaultCache holds fetched KV data sealed with AES-256-GCM under a random
+// key that never leaves the process, so cached plaintext does not linger in
+// memory between reads.
+type vaultCache struct {
+	aead cipher.AEAD
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]vaultCacheEntry
+}
+
+type vaultCacheEntry struct {
+	sealed    []byte
+	expiresAt time.Time
+}
+
+func newVaultCache(ttl time.Duration) (*vaultCache, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generate cache key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cache cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create cache cipher: %w", err)
+	}
+	return &vaultCache{aead: aead, ttl: ttl, entries: make(map[string]vaultCacheEntry)}, nil
+}
+
+func (c *vaultCache) get(path string, now time.Time) (map[string]any, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[path]
+	if ok && !now.Before(entry.expiresAt) {
+		delete(c.entries, path)
+		ok = false
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	n := c.aead.NonceSize()
+	plain, err := c.aead.Open(nil, entry.sealed[:n], entry.sealed[n:], []byte(path))
+	if err != nil {
+		return nil, false
+	}
+	var data map[string]any
+	if err := json.Unmarshal(plain, &data); err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+func (c *vaultCache) put(path string, data map[string]any, now time.Time) {
+	plain, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return
+	}
+	sealed := c.aead.Seal(nonce, nonce, plain, []byte(path))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[path] = vaultCacheEntry{sealed: sealed, expiresAt: now.Add(c.ttl)}
+}
@@ -0,0 +1,13 @@
+//go:build !secrets_vault
+
+package security
+
+import (
+	"fmt"
+
+	"github.com/langoai/lango/internal/config"
+)
+
+func newVaultBackend(_ config.VaultConfig) (SecretsBackend, error) {
+	return nil, fmt.Errorf("Vault secrets backend not compiled: rebuild with -tags secrets_vault")
+}
@@ -0,0 +1,234 @@
+//go:build secrets_vault
+
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/langoai/lango/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault is an in-process stand-in for the subset of the Vault HTTP API
+// used by VaultBackend: KV v2 reads, AppRole login, token lookup and renewal.
+type fakeVault struct {
+	t         *testing.T
+	namespace string
+	secrets   map[string]map[string]any // "mount/path" -> data
+	tokens    map[string]bool
+	reads     atomic.Int32
+	logins    atomic.Int32
+	renewals  atomic.Int32
+}
+
+func newFakeVault(t *testing.T) (*fakeVault, *httptest.Server) {
+	fv := &fakeVault{
+		t:       t,
+		secrets: map[string]map[string]any{},
+		tokens:  map[string]bool{"root-token": true},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(fv.handle))
+	t.Cleanup(srv.Close)
+	return fv, srv
+}
+
+func (f *fakeVault) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	require.NoError(f.t, json.NewEncoder(w).Encode(v))
+}
+
+func (f *fakeVault) handle(w http.ResponseWriter, r *http.Request) {
+	if f.namespace != "" && r.Header.Get("X-Vault-Namespace") != f.namespace {
+		f.writeJSON(w, http.StatusForbidden, map[string]any{"errors": []string{"namespace not authorized"}})
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+
+	switch {
+	case path == "auth/approle/login":
+		var body map[string]string
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&body))
+		if body["role_id"] != "role" || body["secret_id"] != "sid" {
+			f.writeJSON(w, http.StatusBadRequest, map[string]any{"errors": []string{"invalid role or secret ID"}})
+			return
+		}
+		n := f.logins.Add(1)
+		token := "approle-token-" + string(rune('0'+n))
+		f.tokens[token] = true
+		f.writeJSON(w, http.StatusOK, map[string]any{
+			"auth": map[string]any{"client_token": token, "lease_duration": 3600, "renewable": true},
+		})
+		return
+	}
+
+	if !f.tokens[r.Header.Get("X-Vault-Token")] {
+		f.writeJSON(w, http.StatusForbidden, map[string]any{"errors": []string{"permission denied"}})
+		return
+	}
+
+	switch {
+	case path == "auth/token/lookup-self":
+		f.writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"ttl": 600, "renewable": true}})
+	case path == "auth/token/renew-self":
+		f.renewals.Add(1)
+		f.writeJSON(w, http.StatusOK, map[string]any{
+			"auth": map[string]any{"client_token": r.Header.Get("X-Vault-Token"), "lease_duration": 1200, "renewable": true},
+		})
+	default:
+		mount, rest, _ := strings.Cut(path, "/data/")
+		data, ok := f.secrets[mount+"/"+rest]
+		if !ok {
+			f.writeJSON(w, http.StatusNotFound, map[string]any{"errors": []string{}})
+			return
+		}
+		f.reads.Add(1)
+		f.writeJSON(w, http.StatusOK, map[string]any{
+			"data": map[string]any{"data": data, "metadata": map[string]any{"version": 1}},
+		})
+	}
+}
+
+func TestVaultBackend_TokenAuth(t *testing.T) {
+	fv, srv := newFakeVault(t)
+	fv.namespace = "team-a"
+	fv.secrets["secret/apps/github"] = map[string]any{"token": "ghp_test", "scopes": []string{"repo"}}
+
+	b, err := NewVaultBackend(config.VaultConfig{
+		Address:   srv.URL,
+		Namespace: "team-a",
+		Token:     "root-token",
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	val, err := b.Resolve(ctx, "apps/github", "token")
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_test", string(val))
+
+	val, err = b.Resolve(ctx, "apps/github", "scopes")
+	require.NoError(t, err)
+	assert.JSONEq(t, `["repo"]`, string(val))
+	assert.Equal(t, int32(1), fv.reads.Load(), "second field served from cache")
+
+	_, err = b.Resolve(ctx, "apps/github", "missing")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+
+	_, err = b.Resolve(ctx, "apps/none", "token")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+}
+
+func TestVaultBackend_CacheTTL(t *testing.T) {
+	fv, srv := newFakeVault(t)
+	fv.secrets["secret/db"] = map[string]any{"password": "one"}
+
+	b, err := NewVaultBackend(config.VaultConfig{Address: srv.URL, Token: "root-token", CacheTTL: time.Minute})
+	require.NoError(t, err)
+	now := time.Now()
+	b.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err = b.Resolve(ctx, "db", "password")
+	require.NoError(t, err)
+
+	fv.secrets["secret/db"] = map[string]any{"password": "two"}
+	val, err := b.Resolve(ctx, "db", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "one", string(val))
+
+	now = now.Add(2 * time.Minute)
+	val, err = b.Resolve(ctx, "db", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "two", string(val))
+	assert.Equal(t, int32(2), fv.reads.Load())
+}
+
+func TestVaultBackend_AppRole(t *testing.T) {
+	fv, srv := newFakeVault(t)
+	fv.secrets["kv/svc"] = map[string]any{"key": "v"}
+
+	b, err := NewVaultBackend(config.VaultConfig{
+		Address:    srv.URL,
+		Mount:      "kv",
+		AuthMethod: "approle",
+		RoleID:     "role",
+		SecretID:   "sid",
+		CacheTTL:   -1,
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	val, err := b.Resolve(ctx, "svc", "key")
+	require.NoError(t, err)
+	assert.Equal(t, "v", string(val))
+	assert.Equal(t, int32(1), fv.logins.Load())
+
+	// Revoked token: the backend logs in again and retries once.
+	fv.tokens = map[string]bool{}
+	val, err = b.Resolve(ctx, "svc", "key")
+	require.NoError(t, err)
+	assert.Equal(t, "v", string(val))
+	assert.Equal(t, int32(2), fv.logins.Load())
+}
+
+func TestVaultBackend_Renew(t *testing.T) {
+	fv, srv := newFakeVault(t)
+
+	b, err := NewVaultBackend(config.VaultConfig{Address: srv.URL, Token: "root-token"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, b.Renew(ctx)) // first call authenticates via lookup-self
+	assert.Equal(t, 600*time.Second, b.ttl)
+
+	require.NoError(t, b.Renew(ctx))
+	assert.Equal(t, int32(1), fv.renewals.Load())
+	assert.Equal(t, 1200*time.Second, b.ttl)
+}
+
+func TestVaultBackend_Config(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "")
+	t.Setenv("VAULT_TOKEN", "")
+
+	_, err := NewVaultBackend(config.VaultConfig{Token: "t"})
+	assert.ErrorIs(t, err, ErrSecretsBackend)
+
+	_, err = NewVaultBackend(config.VaultConfig{Address: "http://127.0.0.1:1"})
+	assert.ErrorIs(t, err, ErrSecretsBackend)
+
+	_, err = NewVaultBackend(config.VaultConfig{Address: "http://127.0.0.1:1", AuthMethod: "approle"})
+	assert.ErrorIs(t, err, ErrSecretsBackend)
+}
+
+func TestSecretsStore_VaultBackend(t *testing.T) {
+	fv, srv := newFakeVault(t)
+	fv.secrets["secret/apps/github"] = map[string]any{"token": "ghp_test"}
+
+	backend, err := NewSecretsBackend(config.SecretsConfig{
+		Backend: "vault",
+		Vault:   config.VaultConfig{Address: srv.URL, Token: "root-token"},
+	})
+	require.NoError(t, err)
+
+	store, _ := newTestSecretsStore(t)
+	store.SetBackend(backend)
+	ctx := context.Background()
+
+	val, err := store.ResolveSecret(ctx, "apps/github#token", SecretAccessor{Agent: "operator"})
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_test", string(val))
+
+	refs := NewRefStore()
+	refs.SetAuthorizer(store)
+	out, err := refs.ResolveAllFor(ctx, "gh auth {{secret:apps/github#token}}", SecretAccessor{Tool: "exec"})
+	require.NoError(t, err)
+	assert.Equal(t, "gh auth ghp_test", out)
+}
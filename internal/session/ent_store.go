@@ -478,6 +478,42 @@ func (s *EntStore) CompactMessages(key string, upToIndex int, summary string) er
 	return nil
 }
 
+// TruncateMessages deletes every message in the session with a timestamp
+// after the given time and returns how many were removed. It is used to
+// rewind a session to an earlier point.
+func (s *EntStore) TruncateMessages(key string, after time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+
+	entSession, err := s.client.Session.
+		Query().
+		Where(entsession.Key(key)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return 0, fmt.Errorf("truncate session %q: %w", key, ErrSessionNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("truncate session %q: %w", key, err)
+	}
+
+	n, err := s.client.Message.Delete().
+		Where(
+			message.HasSessionWith(entsession.ID(entSession.ID)),
+			message.TimestampGT(after),
+		).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("delete messages: %w", err)
+	}
+
+	if _, err := entSession.Update().SetUpdatedAt(time.Now()).Save(ctx); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
 // ListSessions returns lightweight summaries of all sessions,
 // ordered by most recent update first.
 func (s *EntStore) ListSessions(ctx context.Context) ([]SessionSummary, error) {
@@ -194,6 +194,45 @@ func TestEntStore_MaxHistoryTurns(t *testing.T) {
 	}
 }
 
+func TestEntStore_TruncateMessages(t *testing.T) {
+	store := newTestEntStore(t)
+
+	if err := store.Create(&Session{Key: "sess-trunc"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		msg := Message{
+			Role:      "user",
+			Content:   string(rune('a' + i)),
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+		}
+		if err := store.AppendMessage("sess-trunc", msg); err != nil {
+			t.Fatalf("AppendMessage %d: %v", i, err)
+		}
+	}
+
+	n, err := store.TruncateMessages("sess-trunc", base.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("TruncateMessages: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("removed: want 2, got %d", n)
+	}
+
+	got, err := store.Get("sess-trunc")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(got.History) != 3 || got.History[2].Content != "c" {
+		t.Errorf("History after truncate: got %+v", got.History)
+	}
+
+	if _, err := store.TruncateMessages("ghost", base); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("want ErrSessionNotFound, got %v", err)
+	}
+}
+
 func TestEntStore_TTL(t *testing.T) {
 	store := newTestEntStore(t, WithTTL(50*time.Millisecond))
 
@@ -2,18 +2,27 @@ package skill
 
 // SkillStep represents one step in a composite skill.
 type SkillStep struct {
+	ID     string                 `json:"id,omitempty"` // referenced as {{.steps.<id>.output}} (default: step<N>)
 	Tool   string                 `json:"tool"`
 	Params map[string]interface{} `json:"params"`
+	If     string                 `json:"if,omitempty"` // template condition; the step is skipped when false
 }
 
 // BuildCompositeSkill creates a SkillEntry for a multi-step tool chain.
 func BuildCompositeSkill(name, description string, steps []SkillStep, params map[string]interface{}) SkillEntry {
 	stepDefs := make([]interface{}, 0, len(steps))
 	for _, s := range steps {
-		stepDefs = append(stepDefs, map[string]interface{}{
+		def := map[string]interface{}{
 			"tool":   s.Tool,
 			"params": s.Params,
-		})
+		}
+		if s.ID != "" {
+			def["id"] = s.ID
+		}
+		if s.If != "" {
+			def["if"] = s.If
+		}
+		stepDefs = append(stepDefs, def)
 	}
 
 	entry := SkillEntry{
@@ -0,0 +1,341 @@
+package skill
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/langoai/lango/internal/agent"
+)
+
+// ToolLookup resolves a tool by name for composite skill steps. The app
+// supplies the fully wrapped tool set so steps pass through approval, hooks
+// and policy like any other tool call.
+type ToolLookup func(name string) (*agent.Tool, bool)
+
+// Step statuses reported in a CompositeResult.
+const (
+	StepStatusOK      = "ok"
+	StepStatusSkipped = "skipped"
+	StepStatusFailed  = "failed"
+)
+
+// maxCompositeDepth bounds composite skills invoking other composite skills.
+const maxCompositeDepth = 4
+
+// maxErrorOutputLen caps the partial outputs embedded in a StepError message.
+const maxErrorOutputLen = 2000
+
+// compositeStep is a parsed composite skill step.
+type compositeStep struct {
+	ID     string
+	Tool   string
+	Params map[string]interface{}
+	If     string
+}
+
+// StepResult is the outcome of one composite skill step.
+type StepResult struct {
+	ID       string                 `json:"id"`
+	Tool     string                 `json:"tool"`
+	Status   string                 `json:"status"`
+	Params   map[string]interface{} `json:"params,omitempty"`
+	Output   interface{}            `json:"output,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+	Duration string                 `json:"duration,omitempty"`
+}
+
+// CompositeResult is the structured result of a composite skill run.
+// Output is the output of the last step that ran.
+type CompositeResult struct {
+	Skill  string       `json:"skill"`
+	Type   string       `json:"type"`
+	Status string       `json:"status"`
+	Steps  []StepResult `json:"steps"`
+	Output interface{}  `json:"output,omitempty"`
+}
+
+// StepError reports a failed composite skill step together with the
+// results of the steps that ran before it.
+type StepError struct {
+	Skill  string
+	Index  int // 1-based
+	StepID string
+	Tool   string
+	Err    error
+	Result *CompositeResult
+}
+
+func (e *StepError) Error() string {
+	msg := fmt.Sprintf("composite skill %q: step %d %q (%s) failed: %v", e.Skill, e.Index, e.StepID, e.Tool, e.Err)
+	partial := make(map[string]interface{})
+	for _, s := range e.Result.Steps {
+		if s.Status == StepStatusOK {
+			partial[s.ID] = s.Output
+		}
+	}
+	if len(partial) == 0 {
+		return msg
+	}
+	data, err := json.Marshal(partial)
+	if err != nil {
+		return msg
+	}
+	out := string(data)
+	if len(out) > maxErrorOutputLen {
+		out = out[:maxErrorOutputLen] + "...(truncated)"
+	}
+	return msg + "; completed step outputs: " + out
+}
+
+func (e *StepError) Unwrap() error { return e.Err }
+
+type compositeDepthKey struct{}
+
+// parseCompositeSteps validates and normalizes a composite definition.
+// Steps without an id are named step1, step2, ...
+func parseCompositeSteps(skill SkillEntry) ([]compositeStep, error) {
+	stepsRaw, ok := skill.Definition["steps"]
+	if !ok {
+		return nil, fmt.Errorf("composite skill %q missing 'steps' in definition", skill.Name)
+	}
+	raw, ok := stepsRaw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("composite skill %q: 'steps' must be an array", skill.Name)
+	}
+
+	steps := make([]compositeStep, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for i, stepRaw := range raw {
+		m, ok := stepRaw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("composite skill %q: step %d is not an object", skill.Name, i+1)
+		}
+		step := compositeStep{}
+		step.Tool, _ = m["tool"].(string)
+		if step.Tool == "" {
+			return nil, fmt.Errorf("composite skill %q: step %d has no 'tool'", skill.Name, i+1)
+		}
+		step.ID, _ = m["id"].(string)
+		if step.ID == "" {
+			step.ID = fmt.Sprintf("step%d", i+1)
+		}
+		if _, dup := seen[step.ID]; dup {
+			return nil, fmt.Errorf("composite skill %q: duplicate step id %q", skill.Name, step.ID)
+		}
+		seen[step.ID] = struct{}{}
+		step.Params, _ = m["params"].(map[string]interface{})
+		step.If, _ = m["if"].(string)
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// executeComposite runs the skill's tool steps in order. Step params and
+// conditions are Go templates evaluated against {{.inputs}} (the skill
+// parameters) and {{.steps.<id>.output}} (earlier step outputs).
+func (e *Executor) executeComposite(ctx context.Context, skill SkillEntry, params map[string]interface{}) (interface{}, error) {
+	steps, err := parseCompositeSteps(skill)
+	if err != nil {
+		return nil, err
+	}
+	if e.lookup == nil {
+		return nil, fmt.Errorf("composite skill %q: tool execution is not available", skill.Name)
+	}
+	depth, _ := ctx.Value(compositeDepthKey{}).(int)
+	if depth >= maxCompositeDepth {
+		return nil, fmt.Errorf("composite skill %q: nesting exceeds %d levels", skill.Name, maxCompositeDepth)
+	}
+	ctx = context.WithValue(ctx, compositeDepthKey{}, depth+1)
+
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	stepData := make(map[string]interface{}, len(steps))
+	data := map[string]interface{}{"inputs": params, "steps": stepData}
+	result := &CompositeResult{Skill: skill.Name, Type: string(SkillTypeComposite), Status: "completed"}
+
+	fail := func(i int, step compositeStep, sr StepResult, err error) error {
+		sr.Status = StepStatusFailed
+		sr.Error = err.Error()
+		result.Steps = append(result.Steps, sr)
+		result.Status = "failed"
+		return &StepError{Skill: skill.Name, Index: i + 1, StepID: step.ID, Tool: step.Tool, Err: err, Result: result}
+	}
+
+	for i, step := range steps {
+		sr := StepResult{ID: step.ID, Tool: step.Tool}
+
+		if step.If != "" {
+			run, err := evalCondition(step.If, data)
+			if err != nil {
+				return nil, fail(i, step, sr, fmt.Errorf("evaluate condition: %w", err))
+			}
+			if !run {
+				sr.Status = StepStatusSkipped
+				result.Steps = append(result.Steps, sr)
+				stepData[step.ID] = map[string]interface{}{"skipped": true, "output": nil}
+				continue
+			}
+		}
+
+		rendered, err := renderValue(step.Params, data)
+		if err != nil {
+			return nil, fail(i, step, sr, fmt.Errorf("render params: %w", err))
+		}
+		sr.Params, _ = rendered.(map[string]interface{})
+		if sr.Params == nil {
+			sr.Params = map[string]interface{}{}
+		}
+
+		tool, ok := e.lookup(step.Tool)
+		if !ok || tool.Handler == nil {
+			return nil, fail(i, step, sr, fmt.Errorf("tool %q not found", step.Tool))
+		}
+
+		start := time.Now()
+		out, err := tool.Handler(ctx, sr.Params)
+		sr.Duration = time.Since(start).Truncate(time.Millisecond).String()
+		if err != nil {
+			return nil, fail(i, step, sr, err)
+		}
+
+		sr.Status = StepStatusOK
+		sr.Output = normalizeOutput(out)
+		result.Steps = append(result.Steps, sr)
+		result.Output = sr.Output
+		stepData[step.ID] = map[string]interface{}{"skipped": false, "output": sr.Output}
+	}
+
+	return result, nil
+}
+
+// normalizeOutput converts a tool result to generic JSON values so templates
+// address fields by their JSON names.
+func normalizeOutput(v interface{}) interface{} {
+	switch v.(type) {
+	case nil, string, bool, float64:
+		return v
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return string(data)
+	}
+	return out
+}
+
+// _singleActionRe matches a string consisting of exactly one template
+// action, e.g. "{{ .steps.fetch.output.items }}".
+var _singleActionRe = regexp.MustCompile(`^\{\{\s*([^{}]+?)\s*\}\}$`)
+
+// _controlKeywords start actions that cannot be captured as a value.
+var _controlKeywords = []string{"if ", "range ", "with ", "end", "else", "define ", "block ", "template "}
+
+var _templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// renderValue renders templates in every string of v. A string that is a
+// single action keeps the type of the value it evaluates to, so arrays and
+// objects from earlier steps pass through intact.
+func renderValue(v interface{}, data map[string]interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case string:
+		return renderString(val, data)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			r, err := renderValue(item, data)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			out[k] = r
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			r, err := renderValue(item, data)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			out[i] = r
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+func renderString(s string, data map[string]interface{}) (interface{}, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+	if m := _singleActionRe.FindStringSubmatch(strings.TrimSpace(s)); m != nil && !isControlAction(m[1]) {
+		var captured interface{}
+		funcs := template.FuncMap{"__capture": func(v interface{}) string { captured = v; return "" }}
+		tmpl, err := template.New("param").Funcs(_templateFuncs).Funcs(funcs).Option("missingkey=error").
+			Parse("{{ " + m[1] + " | __capture }}")
+		if err == nil {
+			if err := tmpl.Execute(&bytes.Buffer{}, data); err != nil {
+				return nil, err
+			}
+			return captured, nil
+		}
+	}
+	return executeTemplateString(s, data)
+}
+
+func isControlAction(action string) bool {
+	for _, kw := range _controlKeywords {
+		if strings.HasPrefix(action, kw) || action == strings.TrimSpace(kw) {
+			return true
+		}
+	}
+	return false
+}
+
+func executeTemplateString(s string, data map[string]interface{}) (string, error) {
+	tmpl, err := template.New("param").Funcs(_templateFuncs).Option("missingkey=error").Parse(s)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// evalCondition renders a step's "if" template. The step runs unless the
+// result is empty, "false", "0", "no" or "<no value>".
+func evalCondition(cond string, data map[string]interface{}) (bool, error) {
+	if !strings.Contains(cond, "{{") {
+		cond = "{{ " + cond + " }}"
+	}
+	tmpl, err := template.New("if").Funcs(_templateFuncs).Parse(cond)
+	if err != nil {
+		return false, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(buf.String())) {
+	case "", "false", "0", "no", "<no value>", "<nil>":
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,210 @@
+package skill
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/langoai/lango/internal/agent"
+)
+
+type recordedCall struct {
+	tool   string
+	params map[string]interface{}
+}
+
+func fakeLookup(calls *[]recordedCall, handlers map[string]agent.ToolHandler) ToolLookup {
+	return func(name string) (*agent.Tool, bool) {
+		h, ok := handlers[name]
+		if !ok {
+			return nil, false
+		}
+		return &agent.Tool{Name: name, Handler: func(ctx context.Context, p map[string]interface{}) (interface{}, error) {
+			*calls = append(*calls, recordedCall{tool: name, params: p})
+			return h(ctx, p)
+		}}, true
+	}
+}
+
+type issue struct {
+	Title  string   `json:"title"`
+	Labels []string `json:"labels"`
+}
+
+func TestExecuteComposite_DataPassing(t *testing.T) {
+	t.Parallel()
+
+	var calls []recordedCall
+	executor := newTestExecutor(t)
+	executor.SetToolLookup(fakeLookup(&calls, map[string]agent.ToolHandler{
+		"fetch_issue": func(_ context.Context, p map[string]interface{}) (interface{}, error) {
+			return issue{Title: "crash on " + p["id"].(string), Labels: []string{"bug", "p1"}}, nil
+		},
+		"notify": func(_ context.Context, p map[string]interface{}) (interface{}, error) {
+			return map[string]interface{}{"sent": true}, nil
+		},
+		"escalate": func(_ context.Context, p map[string]interface{}) (interface{}, error) {
+			return "escalated", nil
+		},
+	}))
+
+	sk := SkillEntry{
+		Name: "triage",
+		Type: SkillTypeComposite,
+		Definition: map[string]interface{}{
+			"steps": []interface{}{
+				map[string]interface{}{"id": "fetch", "tool": "fetch_issue", "params": map[string]interface{}{"id": "{{.inputs.issue}}"}},
+				map[string]interface{}{"tool": "notify", "params": map[string]interface{}{
+					"message": "New issue: {{.steps.fetch.output.title}}",
+					"labels":  "{{ .steps.fetch.output.labels }}",
+				}},
+				map[string]interface{}{"id": "esc", "tool": "escalate", "if": `{{eq .inputs.priority "high"}}`},
+			},
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), sk, map[string]interface{}{"issue": "42", "priority": "low"})
+	require.NoError(t, err)
+
+	res, ok := result.(*CompositeResult)
+	require.True(t, ok, "result is %T", result)
+	assert.Equal(t, "completed", res.Status)
+	require.Len(t, res.Steps, 3)
+	assert.Equal(t, StepStatusOK, res.Steps[0].Status)
+	assert.Equal(t, "step2", res.Steps[1].ID)
+	assert.Equal(t, StepStatusSkipped, res.Steps[2].Status)
+	assert.Equal(t, map[string]interface{}{"sent": true}, res.Output)
+
+	require.Len(t, calls, 2)
+	assert.Equal(t, "42", calls[0].params["id"])
+	assert.Equal(t, "New issue: crash on 42", calls[1].params["message"])
+	assert.Equal(t, []interface{}{"bug", "p1"}, calls[1].params["labels"], "single-action params keep their type")
+}
+
+func TestExecuteComposite_StepFailure(t *testing.T) {
+	t.Parallel()
+
+	var calls []recordedCall
+	executor := newTestExecutor(t)
+	executor.SetToolLookup(fakeLookup(&calls, map[string]agent.ToolHandler{
+		"build": func(context.Context, map[string]interface{}) (interface{}, error) {
+			return map[string]interface{}{"artifact": "app.tar"}, nil
+		},
+		"deploy": func(context.Context, map[string]interface{}) (interface{}, error) {
+			return nil, errors.New("cluster unreachable")
+		},
+	}))
+
+	sk := SkillEntry{
+		Name: "release",
+		Type: SkillTypeComposite,
+		Definition: map[string]interface{}{
+			"steps": []interface{}{
+				map[string]interface{}{"id": "build", "tool": "build"},
+				map[string]interface{}{"id": "deploy", "tool": "deploy", "params": map[string]interface{}{"artifact": "{{.steps.build.output.artifact}}"}},
+				map[string]interface{}{"id": "never", "tool": "build"},
+			},
+		},
+	}
+
+	_, err := executor.Execute(context.Background(), sk, nil)
+	require.Error(t, err)
+
+	var stepErr *StepError
+	require.True(t, errors.As(err, &stepErr))
+	assert.Equal(t, 2, stepErr.Index)
+	assert.Equal(t, "deploy", stepErr.StepID)
+	assert.Equal(t, "failed", stepErr.Result.Status)
+	require.Len(t, stepErr.Result.Steps, 2)
+	assert.Equal(t, "app.tar", stepErr.Result.Steps[1].Params["artifact"])
+	assert.Contains(t, err.Error(), `step 2 "deploy"`)
+	assert.Contains(t, err.Error(), "cluster unreachable")
+	assert.Contains(t, err.Error(), "app.tar", "partial outputs are reported")
+	assert.Len(t, calls, 2, "steps after the failure do not run")
+}
+
+func TestExecuteComposite_Errors(t *testing.T) {
+	t.Parallel()
+
+	var calls []recordedCall
+	executor := newTestExecutor(t)
+	executor.SetToolLookup(fakeLookup(&calls, map[string]agent.ToolHandler{}))
+
+	tests := []struct {
+		give    []interface{}
+		wantErr string
+	}{
+		{give: []interface{}{map[string]interface{}{"tool": "missing"}}, wantErr: `tool "missing" not found`},
+		{give: []interface{}{map[string]interface{}{"params": map[string]interface{}{}}}, wantErr: "has no 'tool'"},
+		{
+			give: []interface{}{
+				map[string]interface{}{"id": "a", "tool": "x"},
+				map[string]interface{}{"id": "a", "tool": "y"},
+			},
+			wantErr: "duplicate step id",
+		},
+		{
+			give:    []interface{}{map[string]interface{}{"tool": "x", "params": map[string]interface{}{"v": "{{.inputs.nope}}"}}},
+			wantErr: "render params",
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := executor.Execute(context.Background(), SkillEntry{
+			Name:       "bad",
+			Type:       SkillTypeComposite,
+			Definition: map[string]interface{}{"steps": tt.give},
+		}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), tt.wantErr)
+	}
+}
+
+func TestExecuteComposite_NestingLimit(t *testing.T) {
+	t.Parallel()
+
+	executor := newTestExecutor(t)
+	sk := SkillEntry{
+		Name: "loop",
+		Type: SkillTypeComposite,
+		Definition: map[string]interface{}{
+			"steps": []interface{}{map[string]interface{}{"tool": "skill_loop"}},
+		},
+	}
+	executor.SetToolLookup(func(name string) (*agent.Tool, bool) {
+		return &agent.Tool{Name: name, Handler: func(ctx context.Context, p map[string]interface{}) (interface{}, error) {
+			return executor.Execute(ctx, sk, p)
+		}}, true
+	})
+
+	_, err := executor.Execute(context.Background(), sk, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nesting exceeds")
+}
+
+func TestEvalCondition(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]interface{}{
+		"inputs": map[string]interface{}{"dry": false, "env": "prod"},
+		"steps":  map[string]interface{}{},
+	}
+	tests := []struct {
+		give string
+		want bool
+	}{
+		{give: ".inputs.dry", want: false},
+		{give: "not .inputs.dry", want: true},
+		{give: `{{eq .inputs.env "prod"}}`, want: true},
+		{give: ".inputs.missing", want: false},
+		{give: "{{if .inputs.env}}yes{{end}}", want: true},
+	}
+	for _, tt := range tests {
+		got, err := evalCondition(tt.give, data)
+		require.NoError(t, err, tt.give)
+		assert.Equal(t, tt.want, got, tt.give)
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"os"
-	"os/exec"
 	"regexp"
 	"strings"
 	"text/template"
@@ -29,11 +27,13 @@ var _dangerousPatterns = []*regexp.Regexp{
 // Executor safely executes skills.
 type Executor struct {
 	logger        *zap.SugaredLogger
-	isolator      sandboxos.OSIsolator // OS-level sandbox (nil = disabled)
-	workspacePath string               // Workspace root for sandbox write policy
-	dataRoot      string               // Lango control-plane root, masked from sandboxed children
-	failClosed    bool                 // reject execution when sandbox unavailable
-	bus           *eventbus.Bus        // event bus for SandboxDecisionEvent (optional)
+	isolator      sandboxos.OSIsolator                              // OS-level sandbox (nil = disabled)
+	workspacePath string                                            // Workspace root for sandbox write policy
+	dataRoot      string                                            // Lango control-plane root, masked from sandboxed children
+	failClosed    bool                                              // reject execution when sandbox unavailable
+	bus           *eventbus.Bus                                     // event bus for SandboxDecisionEvent (optional)
+	lookup        ToolLookup                                        // resolves composite step tools (nil = composites unavailable)
+	sandboxCheck  func(ctx context.Context, skill SkillEntry) error // approves script sandbox requests (nil = deny)
 }
 
 // NewExecutor creates a new skill executor.
@@ -41,6 +41,11 @@ func NewExecutor(logger *zap.SugaredLogger) *Executor {
 	return &Executor{logger: logger}
 }
 
+// SetToolLookup sets the resolver used to run composite skill steps.
+func (e *Executor) SetToolLookup(lookup ToolLookup) {
+	e.lookup = lookup
+}
+
 // SetEventBus attaches an event bus for SandboxDecisionEvent publishing.
 // Passing nil disables publishing.
 func (e *Executor) SetEventBus(bus *eventbus.Bus) {
@@ -85,9 +90,9 @@ func (e *Executor) SetFailClosed(fc bool) {
 func (e *Executor) Execute(ctx context.Context, skill SkillEntry, params map[string]interface{}) (interface{}, error) {
 	switch skill.Type {
 	case "composite":
-		return e.executeComposite(ctx, skill)
+		return e.executeComposite(ctx, skill, params)
 	case "script":
-		return e.executeScript(ctx, skill)
+		return e.executeScript(ctx, skill, params)
 	case "template":
 		return e.executeTemplate(skill, params)
 	case "instruction":
@@ -114,117 +119,6 @@ func (e *Executor) ValidateScript(script string) error {
 	return nil
 }
 
-func (e *Executor) executeComposite(_ context.Context, skill SkillEntry) (interface{}, error) {
-	stepsRaw, ok := skill.Definition["steps"]
-	if !ok {
-		return nil, fmt.Errorf("composite skill %q missing 'steps' in definition", skill.Name)
-	}
-
-	steps, ok := stepsRaw.([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("composite skill %q: 'steps' must be an array", skill.Name)
-	}
-
-	plan := make([]map[string]interface{}, 0, len(steps))
-	for i, stepRaw := range steps {
-		step, ok := stepRaw.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("composite skill %q: step %d is not an object", skill.Name, i)
-		}
-
-		toolName, _ := step["tool"].(string)
-		stepParams, _ := step["params"].(map[string]interface{})
-
-		plan = append(plan, map[string]interface{}{
-			"step":   i + 1,
-			"tool":   toolName,
-			"params": stepParams,
-		})
-	}
-
-	return map[string]interface{}{
-		"skill": skill.Name,
-		"type":  "composite",
-		"plan":  plan,
-	}, nil
-}
-
-func (e *Executor) executeScript(ctx context.Context, skill SkillEntry) (interface{}, error) {
-	scriptRaw, ok := skill.Definition["script"]
-	if !ok {
-		return nil, fmt.Errorf("script skill %q missing 'script' in definition", skill.Name)
-	}
-
-	script, ok := scriptRaw.(string)
-	if !ok {
-		return nil, fmt.Errorf("script skill %q: 'script' must be a string", skill.Name)
-	}
-
-	if err := e.ValidateScript(script); err != nil {
-		return nil, fmt.Errorf("script skill %q: %w", skill.Name, err)
-	}
-
-	// Decide the nil-isolator branch BEFORE creating any temp files. Moving
-	// this check up consolidates the previous two nil-isolator branches (an
-	// early return that skipped publish + a later else-if that was
-	// unreachable) into a single publish path so the audit trail always
-	// records the decision before the function returns.
-	if e.isolator == nil {
-		if e.failClosed {
-			e.publishSandboxDecision(ctx, skill.Name, "rejected", "no isolator configured")
-			return nil, fmt.Errorf("%w: no OS isolator configured for skill script", sandboxos.ErrSandboxRequired)
-		}
-		e.publishSandboxDecision(ctx, skill.Name, "skipped", "no isolator configured")
-	}
-
-	f, err := os.CreateTemp("", fmt.Sprintf("lango-skill-%s-*.sh", skill.Name))
-	if err != nil {
-		return nil, fmt.Errorf("create temp script: %w", err)
-	}
-	defer os.Remove(f.Name())
-
-	if _, err := f.Write([]byte(script)); err != nil {
-		f.Close()
-		return nil, fmt.Errorf("write script: %w", err)
-	}
-	if err := f.Close(); err != nil {
-		return nil, fmt.Errorf("close script: %w", err)
-	}
-
-	cmd := exec.CommandContext(ctx, "sh", f.Name())
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	// The nil-isolator branch was already decided and published above.
-	// Here we only need to apply the sandbox when an isolator is present.
-	if e.isolator != nil {
-		policy := sandboxos.DefaultToolPolicy(e.workspacePath, e.dataRoot)
-		if applyErr := e.isolator.Apply(ctx, cmd, policy); applyErr != nil {
-			if e.failClosed {
-				e.publishSandboxDecision(ctx, skill.Name, "rejected", applyErr.Error())
-				return nil, fmt.Errorf("%w: %w", sandboxos.ErrSandboxRequired, applyErr)
-			}
-			e.logger.Warnw("apply OS sandbox to skill script", "skill", skill.Name, "error", applyErr)
-			e.publishSandboxDecision(ctx, skill.Name, "skipped", applyErr.Error())
-		} else {
-			e.publishSandboxDecision(ctx, skill.Name, "applied", "")
-		}
-	}
-
-	runErr := cmd.Run()
-
-	if e.isolator != nil {
-		sandboxos.CleanupProfileFile(cmd)
-	}
-
-	if runErr != nil {
-		return nil, fmt.Errorf("execute script skill %q: %w (stderr: %s)", skill.Name, runErr, stderr.String())
-	}
-
-	return stdout.String(), nil
-}
-
 func (e *Executor) executeFork(skill SkillEntry, params map[string]interface{}) (interface{}, error) {
 	instruction, _ := skill.Definition["instruction"].(string)
 	if instruction == "" {
@@ -65,7 +65,7 @@ func TestExecute_Composite(t *testing.T) {
 	executor := newTestExecutor(t)
 	ctx := context.Background()
 
-	t.Run("normal plan returned", func(t *testing.T) {
+	t.Run("requires tool lookup", func(t *testing.T) {
 		t.Parallel()
 
 		sk := SkillEntry{
@@ -74,22 +74,13 @@ func TestExecute_Composite(t *testing.T) {
 			Definition: map[string]interface{}{
 				"steps": []interface{}{
 					map[string]interface{}{"tool": "read", "params": map[string]interface{}{"path": "/tmp"}},
-					map[string]interface{}{"tool": "write", "params": map[string]interface{}{"path": "/out"}},
 				},
 			},
 		}
 
-		result, err := executor.Execute(ctx, sk, nil)
-		require.NoError(t, err)
-
-		resultMap, ok := result.(map[string]interface{})
-		require.True(t, ok, "result is %T, want map[string]interface{}", result)
-		assert.Equal(t, "test-composite", resultMap["skill"])
-		assert.Equal(t, "composite", resultMap["type"])
-
-		plan, ok := resultMap["plan"].([]map[string]interface{})
-		require.True(t, ok, "result[\"plan\"] is %T, want []map[string]interface{}", resultMap["plan"])
-		assert.Len(t, plan, 2)
+		_, err := executor.Execute(ctx, sk, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "tool execution is not available")
 	})
 
 	t.Run("missing steps key", func(t *testing.T) {
@@ -371,10 +362,12 @@ type mockIsolator struct {
 	available  bool
 	applyCalls int
 	applyErr   error
+	policy     sandboxos.Policy
 }
 
-func (m *mockIsolator) Apply(_ context.Context, _ *exec.Cmd, _ sandboxos.Policy) error {
+func (m *mockIsolator) Apply(_ context.Context, _ *exec.Cmd, policy sandboxos.Policy) error {
 	m.applyCalls++
+	m.policy = policy
 	return m.applyErr
 }
 
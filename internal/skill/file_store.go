@@ -2,6 +2,7 @@ package skill
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/fs"
 	"os"
@@ -11,7 +12,15 @@ import (
 	"go.uber.org/zap"
 )
 
-var _ SkillStore = (*FileSkillStore)(nil)
+var (
+	_ SkillStore        = (*FileSkillStore)(nil)
+	_ SandboxGrantStore = (*FileSkillStore)(nil)
+)
+
+// sandboxGrantsFile holds approved sandbox request fingerprints. It lives at
+// the skills root, outside every skill directory, so skills cannot ship
+// their own approvals.
+const sandboxGrantsFile = ".sandbox-grants.json"
 
 // FileSkillStore implements SkillStore using .lango/skills/<name>/SKILL.md files.
 type FileSkillStore struct {
@@ -19,6 +28,20 @@ type FileSkillStore struct {
 	logger *zap.SugaredLogger
 }
 
+// ResolveSkillsDir expands the configured skills directory, applying the
+// default (~/.lango/skills) and home-directory expansion.
+func ResolveSkillsDir(dir string) string {
+	if dir == "" {
+		dir = "~/.lango/skills"
+	}
+	if strings.HasPrefix(dir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, dir[2:])
+		}
+	}
+	return dir
+}
+
 // NewFileSkillStore creates a new file-based skill store rooted at dir.
 func NewFileSkillStore(dir string, logger *zap.SugaredLogger) *FileSkillStore {
 	return &FileSkillStore{dir: dir, logger: logger}
@@ -68,6 +91,7 @@ func (s *FileSkillStore) Get(_ context.Context, name string) (*SkillEntry, error
 	if err != nil {
 		return nil, fmt.Errorf("parse skill %q: %w", name, err)
 	}
+	entry.Dir = filepath.Dir(path)
 
 	return entry, nil
 }
@@ -100,6 +124,7 @@ func (s *FileSkillStore) ListActive(_ context.Context) ([]SkillEntry, error) {
 			s.logger.Warnw("skip invalid skill", "dir", e.Name(), "error", err)
 			continue
 		}
+		entry.Dir = filepath.Dir(path)
 
 		if entry.Status == "active" {
 			result = append(result, *entry)
@@ -144,6 +169,48 @@ func (s *FileSkillStore) SaveResource(_ context.Context, skillName, relPath stri
 	return os.WriteFile(path, data, 0o644)
 }
 
+// SandboxGrant returns the approved sandbox fingerprint for a skill, or ""
+// when none was granted.
+func (s *FileSkillStore) SandboxGrant(_ context.Context, name string) (string, error) {
+	grants, err := s.readSandboxGrants()
+	if err != nil {
+		return "", err
+	}
+	return grants[name], nil
+}
+
+// SaveSandboxGrant records an approved sandbox fingerprint for a skill.
+func (s *FileSkillStore) SaveSandboxGrant(_ context.Context, name, fingerprint string) error {
+	grants, err := s.readSandboxGrants()
+	if err != nil {
+		return err
+	}
+	grants[name] = fingerprint
+	data, err := json.MarshalIndent(grants, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal sandbox grants: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("ensure skills dir: %w", err)
+	}
+	return os.WriteFile(filepath.Join(s.dir, sandboxGrantsFile), data, 0o600)
+}
+
+func (s *FileSkillStore) readSandboxGrants() (map[string]string, error) {
+	grants := make(map[string]string)
+	data, err := os.ReadFile(filepath.Join(s.dir, sandboxGrantsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return grants, nil
+		}
+		return nil, fmt.Errorf("read sandbox grants: %w", err)
+	}
+	if err := json.Unmarshal(data, &grants); err != nil {
+		return nil, fmt.Errorf("parse sandbox grants: %w", err)
+	}
+	return grants, nil
+}
+
 // DiscoverProjectSkills scans projectRoot/.lango/skills/ for SKILL.md files.
 // Returns discovered skills without modifying the store.
 func (s *FileSkillStore) DiscoverProjectSkills(_ context.Context, projectRoot string) ([]SkillEntry, error) {
@@ -175,6 +242,7 @@ func (s *FileSkillStore) DiscoverProjectSkills(_ context.Context, projectRoot st
 			s.logger.Warnw("skip invalid project skill", "dir", e.Name(), "error", err)
 			continue
 		}
+		entry.Dir = filepath.Dir(path)
 
 		result = append(result, *entry)
 	}
@@ -0,0 +1,98 @@
+package skill
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// IndexFileName is the skill index file looked up in an index repository.
+const IndexFileName = "index.json"
+
+// IndexEntry describes one skill package listed in an index.
+type IndexEntry struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Source      string   `json:"source"` // package source (ParsePackageSource form)
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// Index is a searchable list of skill packages hosted in a git repository.
+type Index struct {
+	Skills []IndexEntry `json:"skills"`
+}
+
+// FetchIndex fetches the index file from the repository at src. The index
+// file is index.json at src.Path, or src.Path itself when it names a file.
+func FetchIndex(ctx context.Context, src PackageSource) (*Index, error) {
+	path := src.Path
+	if !strings.HasSuffix(path, ".json") {
+		path = filepath.Join(path, IndexFileName)
+	}
+
+	co, err := FetchSource(ctx, PackageSource{Repo: src.Repo, Ref: src.Ref})
+	if err != nil {
+		return nil, fmt.Errorf("fetch skill index: %w", err)
+	}
+	defer co.Close()
+
+	data, err := os.ReadFile(filepath.Join(co.Dir, path))
+	if err != nil {
+		return nil, fmt.Errorf("read skill index: %w", err)
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("parse skill index: %w", err)
+	}
+	return &idx, nil
+}
+
+// Lookup returns the entry with the given name.
+func (idx *Index) Lookup(name string) (IndexEntry, bool) {
+	for _, e := range idx.Skills {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return IndexEntry{}, false
+}
+
+// Search returns entries whose name, description or tags contain every
+// whitespace-separated term of query (case-insensitive). Name matches rank
+// first.
+func (idx *Index) Search(query string) []IndexEntry {
+	terms := strings.Fields(strings.ToLower(query))
+	type scored struct {
+		entry IndexEntry
+		score int
+	}
+	var hits []scored
+	for _, e := range idx.Skills {
+		name := strings.ToLower(e.Name)
+		text := name + " " + strings.ToLower(e.Description) + " " + strings.ToLower(strings.Join(e.Tags, " "))
+		score := 0
+		matched := true
+		for _, t := range terms {
+			if !strings.Contains(text, t) {
+				matched = false
+				break
+			}
+			if strings.Contains(name, t) {
+				score++
+			}
+		}
+		if matched {
+			hits = append(hits, scored{entry: e, score: score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	out := make([]IndexEntry, len(hits))
+	for i, h := range hits {
+		out[i] = h.entry
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package skill
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// LockfileName is the lockfile kept at the skills directory root.
+const LockfileName = "skills-lock.json"
+
+// lockfileVersion is the current lockfile format version.
+const lockfileVersion = 1
+
+// LockEntry pins an installed skill to the exact content it was installed from.
+type LockEntry struct {
+	Source      string    `json:"source"`             // package source (ParsePackageSource form)
+	Commit      string    `json:"commit"`             // resolved commit SHA
+	Hash        string    `json:"hash"`               // ContentHash of the installed directory
+	SignedBy    string    `json:"signedBy,omitempty"` // trusted key id that verified the signature
+	InstalledAt time.Time `json:"installedAt"`
+}
+
+// Lockfile records every skill installed by the package manager.
+type Lockfile struct {
+	Version int                  `json:"version"`
+	Skills  map[string]LockEntry `json:"skills"`
+}
+
+// LoadLockfile reads the lockfile in skillsDir. A missing file yields an
+// empty lockfile.
+func LoadLockfile(skillsDir string) (*Lockfile, error) {
+	lf := &Lockfile{Version: lockfileVersion, Skills: make(map[string]LockEntry)}
+	data, err := os.ReadFile(filepath.Join(skillsDir, LockfileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return lf, nil
+		}
+		return nil, fmt.Errorf("read lockfile: %w", err)
+	}
+	if err := json.Unmarshal(data, lf); err != nil {
+		return nil, fmt.Errorf("parse lockfile: %w", err)
+	}
+	if lf.Version > lockfileVersion {
+		return nil, fmt.Errorf("lockfile version %d is newer than supported version %d", lf.Version, lockfileVersion)
+	}
+	if lf.Skills == nil {
+		lf.Skills = make(map[string]LockEntry)
+	}
+	return lf, nil
+}
+
+// Save writes the lockfile to skillsDir atomically.
+func (lf *Lockfile) Save(skillsDir string) error {
+	lf.Version = lockfileVersion
+	data, err := json.MarshalIndent(lf, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal lockfile: %w", err)
+	}
+	if err := os.MkdirAll(skillsDir, 0o700); err != nil {
+		return fmt.Errorf("ensure skills dir: %w", err)
+	}
+	tmp := filepath.Join(skillsDir, "."+LockfileName+".tmp")
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write lockfile: %w", err)
+	}
+	return os.Rename(tmp, filepath.Join(skillsDir, LockfileName))
+}
+
+// Names returns the locked skill names in sorted order.
+func (lf *Lockfile) Names() []string {
+	names := make([]string, 0, len(lf.Skills))
+	for name := range lf.Skills {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,291 @@
+package skill
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PackageSource identifies a skill package directory inside a git repository.
+type PackageSource struct {
+	Repo string // clone URL
+	Ref  string // branch, tag or commit (empty = remote HEAD)
+	Path string // directory within the repo (empty = repo root)
+}
+
+// String renders the source in the form accepted by ParsePackageSource.
+func (s PackageSource) String() string {
+	out := s.Repo
+	if s.Path != "" {
+		out += "//" + s.Path
+	}
+	if s.Ref != "" {
+		out += "#" + s.Ref
+	}
+	return out
+}
+
+// ParsePackageSource parses a skill package source. Accepted forms:
+//   - https://github.com/owner/repo/tree/<ref>/<path>
+//   - <git-url>[//<path>][#<ref>]   e.g. https://git.example.com/skills.git//pdf#v1.2.0
+func ParsePackageSource(raw string) (PackageSource, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return PackageSource{}, fmt.Errorf("empty skill source")
+	}
+
+	if IsGitHubURL(raw) && strings.Contains(raw, "/tree/") {
+		ref, err := ParseGitHubURL(raw)
+		if err != nil {
+			return PackageSource{}, err
+		}
+		return PackageSource{
+			Repo: fmt.Sprintf("https://github.com/%s/%s.git", ref.Owner, ref.Repo),
+			Ref:  ref.Branch,
+			Path: ref.Path,
+		}, nil
+	}
+
+	var src PackageSource
+	if i := strings.LastIndex(raw, "#"); i >= 0 {
+		src.Ref = raw[i+1:]
+		raw = raw[:i]
+	}
+	// "//" after the scheme separates the repo from the path.
+	rest := raw
+	scheme := ""
+	if i := strings.Index(raw, "://"); i >= 0 {
+		scheme, rest = raw[:i+3], raw[i+3:]
+	}
+	if i := strings.Index(rest, "//"); i >= 0 {
+		src.Path = strings.Trim(rest[i+2:], "/")
+		rest = rest[:i]
+	}
+	src.Repo = scheme + rest
+	if IsGitHubURL(src.Repo) && !strings.HasSuffix(src.Repo, ".git") {
+		src.Repo = strings.TrimSuffix(src.Repo, "/") + ".git"
+	}
+	return src, nil
+}
+
+// Checkout is a fetched copy of a package source.
+type Checkout struct {
+	Dir    string // repo root
+	Commit string // resolved commit SHA
+}
+
+// Close removes the checkout.
+func (c *Checkout) Close() error { return os.RemoveAll(c.Dir) }
+
+// FetchSource fetches src at its ref with a shallow git fetch and resolves
+// the commit. The caller must Close the checkout.
+func FetchSource(ctx context.Context, src PackageSource) (*Checkout, error) {
+	if !hasGit() {
+		return nil, fmt.Errorf("git is required to install skill packages")
+	}
+	dir, err := os.MkdirTemp("", "lango-skill-pkg-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+
+	ref := src.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	steps := [][]string{
+		{"init", "--quiet"},
+		{"remote", "add", "origin", src.Repo},
+		{"fetch", "--quiet", "--depth=1", "origin", ref},
+		{"checkout", "--quiet", "FETCH_HEAD"},
+	}
+	for _, args := range steps {
+		if out, err := runGit(ctx, dir, args...); err != nil {
+			os.RemoveAll(dir)
+			return nil, fmt.Errorf("git %s: %s: %w", args[0], strings.TrimSpace(out), err)
+		}
+	}
+	commit, err := runGit(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("resolve commit: %w", err)
+	}
+	return &Checkout{Dir: dir, Commit: strings.TrimSpace(commit)}, nil
+}
+
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// FindPackageSkills returns the skill directories under dir: dir itself when
+// it contains a SKILL.md, otherwise its immediate subdirectories that do.
+func FindPackageSkills(dir string) ([]string, error) {
+	if _, err := os.Stat(filepath.Join(dir, "SKILL.md")); err == nil {
+		return []string{dir}, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read package dir: %w", err)
+	}
+	var dirs []string
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, e.Name(), "SKILL.md")); err == nil {
+			dirs = append(dirs, filepath.Join(dir, e.Name()))
+		}
+	}
+	return dirs, nil
+}
+
+// SignatureFile is the minisign signature over a skill's content hash.
+const SignatureFile = "skill.minisig"
+
+// maxSignatureSize bounds the signature file; minisign signatures are a few
+// hundred bytes.
+const maxSignatureSize = 4 << 10
+
+// packageFiles lists the files that make up a skill directory, relative to
+// dir and sorted. Dotfiles and the signature file are excluded.
+func packageFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == SignatureFile {
+			return nil
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// canonicalSkillMD renders SKILL.md without its status so enabling or
+// disabling a skill (which rewrites the file) does not change its hash.
+func canonicalSkillMD(data []byte) ([]byte, error) {
+	entry, err := ParseSkillMD(data)
+	if err != nil {
+		return nil, err
+	}
+	entry.Status = ""
+	return RenderSkillMD(entry)
+}
+
+// ContentHash returns the "sha256:<hex>" digest of a skill directory. Each
+// file contributes its slash-separated relative path and contents; SKILL.md
+// is hashed in canonical form.
+func ContentHash(dir string) (string, error) {
+	files, err := packageFiles(dir)
+	if err != nil {
+		return "", fmt.Errorf("list skill files: %w", err)
+	}
+	h := sha256.New()
+	for _, rel := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return "", fmt.Errorf("read %s: %w", rel, err)
+		}
+		if rel == "SKILL.md" {
+			if data, err = canonicalSkillMD(data); err != nil {
+				return "", fmt.Errorf("parse SKILL.md: %w", err)
+			}
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", rel, len(data))
+		h.Write(data)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// copyDir copies the files of a skill directory (as listed by packageFiles,
+// plus the signature) from src to dst.
+func copyDir(src, dst string) error {
+	files, err := packageFiles(src)
+	if err != nil {
+		return err
+	}
+	for _, rel := range files {
+		target := filepath.Join(dst, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+			return err
+		}
+		if err := copyFile(filepath.Join(src, filepath.FromSlash(rel)), target); err != nil {
+			return err
+		}
+	}
+	sig, err := readSignatureFile(src)
+	if err != nil || sig == nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dst, SignatureFile), sig, 0o600)
+}
+
+// readSignatureFile returns the package signature in dir, or nil when there
+// is none. Like packageFiles it does not follow symlinks, so a package cannot
+// smuggle an arbitrary local file into the skill directory as its signature.
+func readSignatureFile(dir string) ([]byte, error) {
+	path := filepath.Join(dir, SignatureFile)
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%w: %s is not a regular file", ErrSignatureInvalid, SignatureFile)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	opened, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !os.SameFile(info, opened) {
+		return nil, fmt.Errorf("%w: %s changed while reading", ErrSignatureInvalid, SignatureFile)
+	}
+	data, err := io.ReadAll(io.LimitReader(f, maxSignatureSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxSignatureSize {
+		return nil, fmt.Errorf("%w: %s too large", ErrSignatureInvalid, SignatureFile)
+	}
+	if _, err := parseMinisign(data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSignatureInvalid, err)
+	}
+	return data, nil
+}
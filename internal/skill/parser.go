@@ -19,7 +19,11 @@ func buildSkillBody(entry *SkillEntry) (string, error) {
 	switch entry.Type {
 	case "script":
 		script, _ := entry.Definition["script"].(string)
-		buf.WriteString("```sh\n")
+		fence := _runtimeFences[scriptRuntime(*entry)]
+		if fence == "" {
+			fence = "sh"
+		}
+		buf.WriteString("```" + fence + "\n")
 		buf.WriteString(script)
 		if !strings.HasSuffix(script, "\n") {
 			buf.WriteString("\n")
@@ -73,6 +77,16 @@ func buildSkillBody(entry *SkillEntry) (string, error) {
 		buf.WriteString("\n```\n")
 	}
 
+	if len(entry.OutputSchema) > 0 {
+		outJSON, err := json.MarshalIndent(entry.OutputSchema, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("marshal output schema: %w", err)
+		}
+		buf.WriteString("\n## Output\n\n```json\n")
+		buf.Write(outJSON)
+		buf.WriteString("\n```\n")
+	}
+
 	return buf.String(), nil
 }
 
@@ -93,6 +107,7 @@ type frontmatter struct {
 	Effort           string            `yaml:"effort,omitempty"`
 	Agent            string            `yaml:"agent,omitempty"`
 	Hooks            map[string]string `yaml:"hooks,omitempty"`
+	Sandbox          *SandboxRequest   `yaml:"sandbox,omitempty"`
 }
 
 var _codeBlockRe = regexp.MustCompile("(?s)```(\\w+)?\\s*\n(.*?)```")
@@ -147,6 +162,9 @@ func ParseSkillMD(content []byte) (*SkillEntry, error) {
 		Agent:            meta.Agent,
 		Hooks:            meta.Hooks,
 	}
+	if !meta.Sandbox.Empty() {
+		entry.Sandbox = meta.Sandbox
+	}
 
 	definition, params, err := parseBody(meta.Type, body)
 	if err != nil {
@@ -154,6 +172,7 @@ func ParseSkillMD(content []byte) (*SkillEntry, error) {
 	}
 	entry.Definition = definition
 	entry.Parameters = params
+	entry.OutputSchema = sectionJSON(body, "## Output")
 
 	return entry, nil
 }
@@ -192,6 +211,9 @@ func RenderSkillMD(entry *SkillEntry) ([]byte, error) {
 		Agent:            entry.Agent,
 		Hooks:            entry.Hooks,
 	}
+	if !entry.Sandbox.Empty() {
+		meta.Sandbox = entry.Sandbox
+	}
 
 	body, err := buildSkillBody(entry)
 	if err != nil {
@@ -216,12 +238,18 @@ func parseBody(skillType, body string) (definition map[string]interface{}, param
 
 	switch skillType {
 	case "script":
+		// The first code block in a supported language is the script; the
+		// fence language selects the runtime.
 		for _, block := range blocks {
-			lang := strings.ToLower(block[1])
-			if lang == "sh" || lang == "bash" || lang == "" {
-				definition["script"] = strings.TrimSpace(block[2])
-				break
+			rt, ok := _fenceRuntimes[strings.ToLower(block[1])]
+			if !ok {
+				continue
 			}
+			definition["script"] = strings.TrimSpace(block[2])
+			if rt != RuntimeSh {
+				definition["runtime"] = rt
+			}
+			break
 		}
 
 	case "template":
@@ -268,22 +296,24 @@ func parseBody(skillType, body string) (definition map[string]interface{}, param
 		definition["instruction"] = instruction
 	}
 
-	// Extract parameters section (last json block after ## Parameters header)
-	paramIdx := strings.Index(body, "## Parameters")
-	if paramIdx >= 0 {
-		paramBody := body[paramIdx:]
-		paramBlocks := _codeBlockRe.FindAllStringSubmatch(paramBody, -1)
-		for _, block := range paramBlocks {
-			lang := strings.ToLower(block[1])
-			if lang == "json" {
-				var p map[string]interface{}
-				if err := json.Unmarshal([]byte(block[2]), &p); err == nil {
-					params = p
-					break
-				}
-			}
+	return definition, sectionJSON(body, "## Parameters"), nil
+}
+
+// sectionJSON returns the first JSON object code block after the given
+// section header, or nil when the section is absent.
+func sectionJSON(body, header string) map[string]interface{} {
+	idx := strings.Index(body, header)
+	if idx < 0 {
+		return nil
+	}
+	for _, block := range _codeBlockRe.FindAllStringSubmatch(body[idx:], -1) {
+		if strings.ToLower(block[1]) != "json" {
+			continue
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(block[2]), &m); err == nil {
+			return m
 		}
 	}
-
-	return definition, params, nil
+	return nil
 }
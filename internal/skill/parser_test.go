@@ -453,3 +453,33 @@ func TestRenderSkillMD_V2Fields_PartialFill(t *testing.T) {
 	assert.Empty(t, parsed.Paths)
 	assert.Empty(t, parsed.Hooks)
 }
+
+func TestParseSkillMD_ScriptRuntimeAndIO(t *testing.T) {
+	t.Parallel()
+
+	content := "---\nname: report\ndescription: Build a report\ntype: script\n" +
+		"sandbox:\n  write_paths: [~/reports]\n  network: [api.example.com]\n---\n\n" +
+		"```python\nimport json, sys\nprint(json.dumps(json.load(sys.stdin)))\n```\n\n" +
+		"## Parameters\n\n```json\n{\"type\": \"object\", \"properties\": {\"q\": {\"type\": \"string\"}}}\n```\n\n" +
+		"## Output\n\n```json\n{\"type\": \"object\"}\n```\n"
+
+	entry, err := ParseSkillMD([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, RuntimePython, entry.Definition["runtime"])
+	assert.Contains(t, entry.Definition["script"], "json.load")
+	assert.Equal(t, map[string]interface{}{"type": "object"}, entry.OutputSchema)
+	assert.Contains(t, entry.Parameters, "properties")
+	require.NotNil(t, entry.Sandbox)
+	assert.Equal(t, []string{"~/reports"}, entry.Sandbox.WritePaths)
+	assert.Equal(t, []string{"api.example.com"}, entry.Sandbox.Network)
+
+	rendered, err := RenderSkillMD(entry)
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "```python\n")
+
+	parsed, err := ParseSkillMD(rendered)
+	require.NoError(t, err)
+	assert.Equal(t, entry.Definition, parsed.Definition)
+	assert.Equal(t, entry.OutputSchema, parsed.OutputSchema)
+	assert.Equal(t, entry.Sandbox, parsed.Sandbox)
+}
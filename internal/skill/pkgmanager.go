@@ -0,0 +1,343 @@
+package skill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ErrNotInstalled is returned for skills that are not in the lockfile.
+var ErrNotInstalled = errors.New("skill not installed by package manager")
+
+// PackageManager installs skill packages from git sources into a skills
+// directory and pins them in the lockfile.
+type PackageManager struct {
+	dir              string
+	keys             []TrustedKey
+	requireSignature bool
+}
+
+// NewPackageManager creates a package manager for skillsDir. When
+// requireSignature is set, only packages signed by one of keys install.
+func NewPackageManager(skillsDir string, keys []TrustedKey, requireSignature bool) *PackageManager {
+	return &PackageManager{dir: skillsDir, keys: keys, requireSignature: requireSignature}
+}
+
+// Dir returns the skills directory.
+func (pm *PackageManager) Dir() string { return pm.dir }
+
+// PackageSkill is a skill found in a fetched package.
+type PackageSkill struct {
+	Name     string
+	Dir      string
+	Source   PackageSource // source pinned to this skill's directory
+	Commit   string
+	Hash     string
+	SignedBy string
+}
+
+// FetchedPackage is a fetched source with its verified skills. Close
+// removes the temporary checkout.
+type FetchedPackage struct {
+	checkout *Checkout
+	Skills   []*PackageSkill
+}
+
+// Close removes the temporary checkout.
+func (f *FetchedPackage) Close() error { return f.checkout.Close() }
+
+// Fetch checks out src and verifies every skill it contains.
+func (pm *PackageManager) Fetch(ctx context.Context, src PackageSource) (*FetchedPackage, error) {
+	co, err := FetchSource(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	fetched := &FetchedPackage{checkout: co}
+
+	root := filepath.Join(co.Dir, filepath.FromSlash(src.Path))
+	dirs, err := FindPackageSkills(root)
+	if err != nil {
+		co.Close()
+		return nil, err
+	}
+	if len(dirs) == 0 {
+		co.Close()
+		return nil, fmt.Errorf("no SKILL.md found in %s", src)
+	}
+
+	for _, dir := range dirs {
+		ps, err := pm.inspect(dir)
+		if err != nil {
+			co.Close()
+			return nil, err
+		}
+		ps.Commit = co.Commit
+		ps.Source = src
+		if dir != root {
+			ps.Source.Path = path.Join(src.Path, filepath.Base(dir))
+		}
+		fetched.Skills = append(fetched.Skills, ps)
+	}
+	return fetched, nil
+}
+
+// inspect parses, hashes and verifies one skill directory.
+func (pm *PackageManager) inspect(dir string) (*PackageSkill, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		return nil, fmt.Errorf("read SKILL.md: %w", err)
+	}
+	entry, err := ParseSkillMD(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(dir), err)
+	}
+	if err := checkSkillName(entry.Name); err != nil {
+		return nil, err
+	}
+	hash, err := ContentHash(dir)
+	if err != nil {
+		return nil, fmt.Errorf("hash skill %q: %w", entry.Name, err)
+	}
+	signedBy, err := pm.verifySignature(dir, hash)
+	if err != nil {
+		return nil, fmt.Errorf("skill %q: %w", entry.Name, err)
+	}
+	return &PackageSkill{Name: entry.Name, Dir: dir, Hash: hash, SignedBy: signedBy}, nil
+}
+
+// checkSkillName rejects names that would not stay a single directory
+// under the skills directory.
+func checkSkillName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid skill name %q", name)
+	}
+	return nil
+}
+
+// verifySignature checks the skill's signature over its content hash.
+// Unsigned skills pass unless signatures are required; a signature that
+// fails against the trusted keys is always an error.
+func (pm *PackageManager) verifySignature(dir, hash string) (string, error) {
+	sig, err := readSignatureFile(dir)
+	if err != nil {
+		return "", fmt.Errorf("read signature: %w", err)
+	}
+	if sig == nil {
+		if pm.requireSignature {
+			return "", fmt.Errorf("%w: package is not signed", ErrSignatureInvalid)
+		}
+		return "", nil
+	}
+	if len(pm.keys) == 0 {
+		if pm.requireSignature {
+			return "", fmt.Errorf("%w: no trusted keys configured", ErrSignatureInvalid)
+		}
+		return "", nil
+	}
+	key, err := VerifySignature(sig, []byte(hash), pm.keys)
+	if err != nil {
+		return "", err
+	}
+	return key.KeyID(), nil
+}
+
+// CheckSignatureDowngrade rejects an update to a skill that was installed
+// with a verified signature when the update carries no signature from a
+// trusted key. Signatures are otherwise optional, so without this check an
+// unsigned update would silently replace a signed skill.
+func CheckSignatureDowngrade(entry LockEntry, ps *PackageSkill) error {
+	if entry.SignedBy == "" || ps.SignedBy != "" {
+		return nil
+	}
+	return fmt.Errorf("%w: %s was signed by %s, but the update is not signed by a trusted key",
+		ErrSignatureDowngrade, ps.Name, entry.SignedBy)
+}
+
+// Installed reports whether a skill directory exists for name.
+func (pm *PackageManager) Installed(name string) bool {
+	_, err := os.Stat(filepath.Join(pm.dir, name, "SKILL.md"))
+	return err == nil
+}
+
+// Install copies a fetched skill into the skills directory, replacing any
+// existing copy, and pins it in the lockfile.
+func (pm *PackageManager) Install(ps *PackageSkill) error {
+	if err := os.MkdirAll(pm.dir, 0o700); err != nil {
+		return fmt.Errorf("ensure skills dir: %w", err)
+	}
+	staging, err := os.MkdirTemp(pm.dir, ".install-"+ps.Name+"-")
+	if err != nil {
+		return fmt.Errorf("create staging dir: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	if err := copyDir(ps.Dir, staging); err != nil {
+		return fmt.Errorf("copy skill %q: %w", ps.Name, err)
+	}
+
+	target := filepath.Join(pm.dir, ps.Name)
+	backup := ""
+	if _, err := os.Stat(target); err == nil {
+		backup = staging + ".old"
+		if err := os.Rename(target, backup); err != nil {
+			return fmt.Errorf("replace skill %q: %w", ps.Name, err)
+		}
+	}
+	if err := os.Rename(staging, target); err != nil {
+		if backup != "" {
+			_ = os.Rename(backup, target)
+		}
+		return fmt.Errorf("install skill %q: %w", ps.Name, err)
+	}
+	if backup != "" {
+		os.RemoveAll(backup)
+	}
+
+	lf, err := LoadLockfile(pm.dir)
+	if err != nil {
+		return err
+	}
+	lf.Skills[ps.Name] = LockEntry{
+		Source:      ps.Source.String(),
+		Commit:      ps.Commit,
+		Hash:        ps.Hash,
+		SignedBy:    ps.SignedBy,
+		InstalledAt: time.Now().UTC().Truncate(time.Second),
+	}
+	return lf.Save(pm.dir)
+}
+
+// Remove deletes an installed skill and its lockfile entry.
+func (pm *PackageManager) Remove(name string) error {
+	if err := checkSkillName(name); err != nil {
+		return err
+	}
+	lf, err := LoadLockfile(pm.dir)
+	if err != nil {
+		return err
+	}
+	_, locked := lf.Skills[name]
+	dir := filepath.Join(pm.dir, name)
+	if _, err := os.Stat(dir); os.IsNotExist(err) && !locked {
+		return fmt.Errorf("skill not found: %s", name)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("delete skill %q: %w", name, err)
+	}
+	if locked {
+		delete(lf.Skills, name)
+		return lf.Save(pm.dir)
+	}
+	return nil
+}
+
+// VerifyResult reports the integrity of one installed skill.
+type VerifyResult struct {
+	Name     string
+	Expected string // locked hash
+	Actual   string // current hash ("" when the directory is missing)
+	SignedBy string
+	Err      error // signature or read failure
+}
+
+// OK reports whether the skill matches its lockfile entry and signature.
+func (r VerifyResult) OK() bool { return r.Err == nil && r.Expected == r.Actual }
+
+// Verify recomputes the content hash of locked skills and re-checks their
+// signatures. A skill locked as signed must still be signed by the same key.
+// With no names, every locked skill is verified.
+func (pm *PackageManager) Verify(names ...string) ([]VerifyResult, error) {
+	lf, err := LoadLockfile(pm.dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		names = lf.Names()
+	}
+	results := make([]VerifyResult, 0, len(names))
+	for _, name := range names {
+		entry, ok := lf.Skills[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNotInstalled, name)
+		}
+		res := VerifyResult{Name: name, Expected: entry.Hash}
+		dir := filepath.Join(pm.dir, name)
+		if _, err := os.Stat(dir); err != nil {
+			res.Err = fmt.Errorf("skill directory missing")
+			results = append(results, res)
+			continue
+		}
+		if res.Actual, err = ContentHash(dir); err != nil {
+			res.Err = err
+		} else if res.SignedBy, err = pm.verifySignature(dir, res.Actual); err != nil {
+			res.Err = err
+		} else if entry.SignedBy != "" && res.SignedBy != entry.SignedBy {
+			// Otherwise deleting the signature of a signed skill would pass.
+			res.Err = fmt.Errorf("%w: locked as signed by %s, but no longer signed by that key",
+				ErrSignatureDowngrade, entry.SignedBy)
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// Diff returns a unified diff from the installed copy of a skill to ps.
+// Files are compared as text; an empty string means no changes.
+func (pm *PackageManager) Diff(ps *PackageSkill) (string, error) {
+	oldDir := filepath.Join(pm.dir, ps.Name)
+	oldFiles, err := packageFiles(oldDir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	newFiles, err := packageFiles(ps.Dir)
+	if err != nil {
+		return "", err
+	}
+
+	seen := make(map[string]struct{})
+	var all []string
+	for _, f := range append(oldFiles, newFiles...) {
+		if _, ok := seen[f]; !ok {
+			seen[f] = struct{}{}
+			all = append(all, f)
+		}
+	}
+	sort.Strings(all)
+
+	var b strings.Builder
+	for _, rel := range all {
+		a := readText(filepath.Join(oldDir, filepath.FromSlash(rel)))
+		c := readText(filepath.Join(ps.Dir, filepath.FromSlash(rel)))
+		if a == c {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(a),
+			B:        difflib.SplitLines(c),
+			FromFile: "installed/" + rel,
+			ToFile:   "update/" + rel,
+			Context:  3,
+		})
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(diff)
+	}
+	return b.String(), nil
+}
+
+func readText(p string) string {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,354 @@
+package skill
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/blake2b"
+)
+
+const pdfSkillMD = "---\nname: pdf\ndescription: Work with PDFs\ntype: instruction\n---\n\nUse pdftotext.\n"
+
+// newSkillRepo creates a git repository with a skills/pdf package and
+// returns its path.
+func newSkillRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := t.TempDir()
+	writeRepoFile(t, repo, "skills/pdf/SKILL.md", pdfSkillMD)
+	writeRepoFile(t, repo, "skills/pdf/scripts/extract.sh", "pdftotext \"$1\" -\n")
+	for _, args := range [][]string{{"init", "--quiet"}, {"add", "."}} {
+		gitRun(t, repo, args...)
+	}
+	commitAll(t, repo, "initial")
+	return repo
+}
+
+func writeRepoFile(t *testing.T, repo, rel, content string) {
+	t.Helper()
+	p := filepath.Join(repo, filepath.FromSlash(rel))
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o700))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+}
+
+func gitRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+		"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func commitAll(t *testing.T, repo, msg string) {
+	t.Helper()
+	gitRun(t, repo, "add", ".")
+	gitRun(t, repo, "commit", "--quiet", "-m", msg)
+}
+
+// minisignSign produces a minisign (prehashed) signature and public key.
+func minisignSign(t *testing.T, priv ed25519.PrivateKey, message []byte) (sig, pub string) {
+	t.Helper()
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	sum := blake2b.Sum512(message)
+	s := ed25519.Sign(priv, sum[:])
+	comment := "timestamp:1700000000"
+	global := ed25519.Sign(priv, append(append([]byte{}, s...), comment...))
+
+	sigLine := base64.StdEncoding.EncodeToString(append(append([]byte("ED"), keyID...), s...))
+	sig = "untrusted comment: signature\n" + sigLine + "\ntrusted comment: " + comment + "\n" +
+		base64.StdEncoding.EncodeToString(global) + "\n"
+	pubKey := priv.Public().(ed25519.PublicKey)
+	pub = base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), pubKey...))
+	return sig, pub
+}
+
+func TestParsePackageSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give string
+		want PackageSource
+	}{
+		{
+			give: "https://github.com/org/skills/tree/v1/packs/pdf",
+			want: PackageSource{Repo: "https://github.com/org/skills.git", Ref: "v1", Path: "packs/pdf"},
+		},
+		{
+			give: "https://github.com/org/skills",
+			want: PackageSource{Repo: "https://github.com/org/skills.git"},
+		},
+		{
+			give: "https://git.example.com/skills.git//pdf#abc123",
+			want: PackageSource{Repo: "https://git.example.com/skills.git", Ref: "abc123", Path: "pdf"},
+		},
+		{
+			give: "/srv/skills//pdf",
+			want: PackageSource{Repo: "/srv/skills", Path: "pdf"},
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParsePackageSource(tt.give)
+		require.NoError(t, err, tt.give)
+		assert.Equal(t, tt.want, got, tt.give)
+
+		again, err := ParsePackageSource(got.String())
+		require.NoError(t, err)
+		assert.Equal(t, got, again, "String round-trips")
+	}
+}
+
+func TestContentHash_IgnoresStatus(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeRepoFile(t, dir, "SKILL.md", pdfSkillMD)
+	before, err := ContentHash(dir)
+	require.NoError(t, err)
+
+	// Activating rewrites SKILL.md with a status; the hash is unchanged.
+	store := NewFileSkillStore(filepath.Dir(dir), zap.NewNop().Sugar())
+	require.NoError(t, store.Activate(context.Background(), filepath.Base(dir)))
+	after, err := ContentHash(dir)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	writeRepoFile(t, dir, "assets/extra.txt", "x")
+	changed, err := ContentHash(dir)
+	require.NoError(t, err)
+	assert.NotEqual(t, before, changed)
+}
+
+func TestPackageManager_InstallVerifyUpdateRemove(t *testing.T) {
+	t.Parallel()
+
+	repo := newSkillRepo(t)
+	skillsDir := t.TempDir()
+	pm := NewPackageManager(skillsDir, nil, false)
+	ctx := context.Background()
+
+	fetched, err := pm.Fetch(ctx, PackageSource{Repo: repo, Path: "skills"})
+	require.NoError(t, err)
+	require.Len(t, fetched.Skills, 1)
+	ps := fetched.Skills[0]
+	assert.Equal(t, "pdf", ps.Name)
+	assert.Equal(t, "skills/pdf", ps.Source.Path)
+	assert.Len(t, ps.Commit, 40)
+	require.NoError(t, pm.Install(ps))
+	fetched.Close()
+
+	assert.FileExists(t, filepath.Join(skillsDir, "pdf", "scripts", "extract.sh"))
+	lf, err := LoadLockfile(skillsDir)
+	require.NoError(t, err)
+	require.Contains(t, lf.Skills, "pdf")
+	assert.Equal(t, ps.Hash, lf.Skills["pdf"].Hash)
+	assert.Equal(t, repo+"//skills/pdf", lf.Skills["pdf"].Source)
+
+	results, err := pm.Verify()
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].OK())
+
+	// Local tampering is detected.
+	writeRepoFile(t, skillsDir, "pdf/scripts/extract.sh", "curl evil\n")
+	results, err = pm.Verify("pdf")
+	require.NoError(t, err)
+	assert.False(t, results[0].OK())
+
+	// An upstream change produces a diff against the installed copy.
+	writeRepoFile(t, repo, "skills/pdf/SKILL.md", pdfSkillMD+"Prefer qpdf for repairs.\n")
+	commitAll(t, repo, "update")
+	src, err := ParsePackageSource(lf.Skills["pdf"].Source)
+	require.NoError(t, err)
+	fetched, err = pm.Fetch(ctx, src)
+	require.NoError(t, err)
+	defer fetched.Close()
+	update := fetched.Skills[0]
+	assert.NotEqual(t, ps.Commit, update.Commit)
+
+	diff, err := pm.Diff(update)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+Prefer qpdf for repairs.")
+	assert.Contains(t, diff, "-curl evil")
+
+	require.NoError(t, pm.Install(update))
+	results, err = pm.Verify("pdf")
+	require.NoError(t, err)
+	assert.True(t, results[0].OK())
+
+	require.NoError(t, pm.Remove("pdf"))
+	assert.NoDirExists(t, filepath.Join(skillsDir, "pdf"))
+	lf, err = LoadLockfile(skillsDir)
+	require.NoError(t, err)
+	assert.Empty(t, lf.Skills)
+}
+
+func TestPackageManager_RemoveRejectsTraversal(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	skillsDir := filepath.Join(root, "skills")
+	writeRepoFile(t, skillsDir, "pdf/SKILL.md", pdfSkillMD)
+	writeRepoFile(t, root, "outside/keep.txt", "keep")
+	pm := NewPackageManager(skillsDir, nil, false)
+
+	for _, name := range []string{"../outside", "pdf/../../outside", `..\outside`, ".", "..", ""} {
+		err := pm.Remove(name)
+		require.Error(t, err, name)
+		assert.Contains(t, err.Error(), "invalid skill name", name)
+	}
+	assert.FileExists(t, filepath.Join(root, "outside", "keep.txt"))
+	assert.FileExists(t, filepath.Join(skillsDir, "pdf", "SKILL.md"))
+}
+
+func TestPackageManager_Signatures(t *testing.T) {
+	t.Parallel()
+
+	repo := newSkillRepo(t)
+	hash, err := ContentHash(filepath.Join(repo, "skills", "pdf"))
+	require.NoError(t, err)
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sig, pub := minisignSign(t, priv, []byte(hash))
+	writeRepoFile(t, repo, "skills/pdf/"+SignatureFile, sig)
+	commitAll(t, repo, "sign")
+
+	key, err := ParseTrustedKey("untrusted comment: minisign public key\n" + pub)
+	require.NoError(t, err)
+	assert.Equal(t, "0807060504030201", key.KeyID())
+
+	ctx := context.Background()
+	src := PackageSource{Repo: repo, Path: "skills/pdf"}
+
+	fetched, err := NewPackageManager(t.TempDir(), []TrustedKey{key}, true).Fetch(ctx, src)
+	require.NoError(t, err)
+	assert.Equal(t, key.KeyID(), fetched.Skills[0].SignedBy)
+	fetched.Close()
+
+	// A signed install only verifies while its signature is in place.
+	skillsDir := t.TempDir()
+	pm := NewPackageManager(skillsDir, []TrustedKey{key}, false)
+	fetched, err = pm.Fetch(ctx, src)
+	require.NoError(t, err)
+	require.NoError(t, pm.Install(fetched.Skills[0]))
+	fetched.Close()
+	results, err := pm.Verify("pdf")
+	require.NoError(t, err)
+	assert.True(t, results[0].OK())
+	assert.Equal(t, key.KeyID(), results[0].SignedBy)
+
+	require.NoError(t, os.Remove(filepath.Join(skillsDir, "pdf", SignatureFile)))
+	results, err = pm.Verify("pdf")
+	require.NoError(t, err)
+	assert.False(t, results[0].OK())
+	assert.ErrorIs(t, results[0].Err, ErrSignatureDowngrade)
+
+	_, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rawOther := base64.StdEncoding.EncodeToString(otherPriv.Public().(ed25519.PublicKey))
+	other, err := ParseTrustedKey(rawOther)
+	require.NoError(t, err)
+
+	_, err = NewPackageManager(t.TempDir(), []TrustedKey{other}, false).Fetch(ctx, src)
+	assert.ErrorIs(t, err, ErrSignatureInvalid, "a bad signature always fails")
+
+	// Content changes after signing invalidate the signature.
+	writeRepoFile(t, repo, "skills/pdf/scripts/extract.sh", "changed\n")
+	commitAll(t, repo, "tamper")
+	_, err = NewPackageManager(t.TempDir(), []TrustedKey{key}, false).Fetch(ctx, src)
+	assert.ErrorIs(t, err, ErrSignatureInvalid)
+}
+
+func TestCopyDir_RejectsSymlinkedSignature(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRepoFile(t, root, "secret/key", "private")
+	writeRepoFile(t, root, "pkg/SKILL.md", pdfSkillMD)
+	require.NoError(t, os.Symlink(filepath.Join(root, "secret", "key"), filepath.Join(root, "pkg", SignatureFile)))
+
+	dst := filepath.Join(root, "installed")
+	err := copyDir(filepath.Join(root, "pkg"), dst)
+	assert.ErrorIs(t, err, ErrSignatureInvalid)
+	assert.NoFileExists(t, filepath.Join(dst, SignatureFile))
+
+	// A regular file that is not a minisign signature is rejected too.
+	require.NoError(t, os.Remove(filepath.Join(root, "pkg", SignatureFile)))
+	writeRepoFile(t, root, "pkg/"+SignatureFile, "not a signature\n")
+	err = copyDir(filepath.Join(root, "pkg"), dst)
+	assert.ErrorIs(t, err, ErrSignatureInvalid)
+	assert.NoFileExists(t, filepath.Join(dst, SignatureFile))
+}
+
+func TestCheckSignatureDowngrade(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		give    string
+		locked  string
+		update  string
+		wantErr bool
+	}{
+		{give: "unsigned to unsigned"},
+		{give: "unsigned to signed", update: "0807060504030201"},
+		{give: "signed to signed", locked: "0807060504030201", update: "0807060504030201"},
+		{give: "signed to other trusted key", locked: "0807060504030201", update: "1111111111111111"},
+		{give: "signed to unsigned", locked: "0807060504030201", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			t.Parallel()
+			err := CheckSignatureDowngrade(LockEntry{SignedBy: tt.locked}, &PackageSkill{Name: "pdf", SignedBy: tt.update})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrSignatureDowngrade)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPackageManager_RequireSignature(t *testing.T) {
+	t.Parallel()
+
+	repo := newSkillRepo(t)
+	_, err := NewPackageManager(t.TempDir(), nil, true).Fetch(context.Background(), PackageSource{Repo: repo, Path: "skills"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrSignatureInvalid)
+}
+
+func TestIndex_Search(t *testing.T) {
+	t.Parallel()
+
+	idx := &Index{Skills: []IndexEntry{
+		{Name: "pdf-tools", Description: "Extract text from documents", Tags: []string{"docs"}},
+		{Name: "markdown", Description: "Markdown and PDF export", Tags: []string{"docs", "writing"}},
+		{Name: "k8s", Description: "Kubernetes helpers"},
+	}}
+
+	hits := idx.Search("pdf")
+	require.Len(t, hits, 2)
+	assert.Equal(t, "pdf-tools", hits[0].Name, "name matches rank first")
+
+	assert.Len(t, idx.Search("docs writing"), 1)
+	assert.Empty(t, idx.Search("terraform"))
+
+	e, ok := idx.Lookup("k8s")
+	require.True(t, ok)
+	assert.Equal(t, "Kubernetes helpers", e.Description)
+}
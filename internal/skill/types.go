@@ -68,4 +68,7 @@ type SkillEntry struct {
 	Effort           string            // "low", "medium", "high" — reasoning effort
 	Agent            string            // target agent name (empty = operator)
 	Hooks            map[string]string // lifecycle hooks: "pre", "post"
+	OutputSchema     map[string]interface{} // JSON schema script output must satisfy (script skills)
+	Sandbox          *SandboxRequest        // extra sandbox permissions requested by a script skill
+	Dir              string                 // skill directory on disk (set by file-based stores)
 }
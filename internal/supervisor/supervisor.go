@@ -7,14 +7,18 @@ import (
 
 	"os"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/langoai/lango/internal/config"
 	"github.com/langoai/lango/internal/eventbus"
 	"github.com/langoai/lango/internal/logging"
+	"github.com/langoai/lango/internal/observability/genai"
 	"github.com/langoai/lango/internal/provider"
 	"github.com/langoai/lango/internal/provider/anthropic"
 	"github.com/langoai/lango/internal/provider/gemini"
 	"github.com/langoai/lango/internal/provider/openai"
 	sandboxos "github.com/langoai/lango/internal/sandbox/os"
+	"github.com/langoai/lango/internal/security"
 	"github.com/langoai/lango/internal/tools/exec"
 	"github.com/langoai/lango/internal/types"
 )
@@ -26,6 +30,9 @@ type Supervisor struct {
 	Config   *config.Config
 	registry *provider.Registry
 	execTool *exec.Tool
+
+	tracer  trace.Tracer
+	capture genai.ContentCapture
 }
 
 // New creates a new Supervisor.
@@ -45,6 +52,7 @@ func New(cfg *config.Config) (*Supervisor, error) {
 			"SHELL", "TMPDIR", "SSH_AUTH_SOCK",
 		},
 		ExcludedCommands: append([]string(nil), cfg.Sandbox.ExcludedCommands...),
+		SecretEnv:        cfg.Tools.Exec.SecretEnv,
 	}
 
 	// Inject OS-level sandbox if enabled.
@@ -104,6 +112,22 @@ func (s *Supervisor) SetEventBus(bus *eventbus.Bus) {
 	}
 }
 
+// SetSecrets attaches the secret reference store and policy-checked secret
+// resolver to the supervised exec tool so {{secret:...}} tokens and
+// tools.exec.secretEnv variables resolve under each secret's access policy.
+func (s *Supervisor) SetSecrets(refs *security.RefStore, secrets security.SecretResolver) {
+	if s.execTool != nil {
+		s.execTool.SetSecrets(refs, secrets)
+	}
+}
+
+// SetTracing records every proxied generation request as an OpenTelemetry
+// GenAI span. capture controls prompt and completion content recording.
+func (s *Supervisor) SetTracing(tracer trace.Tracer, capture genai.ContentCapture) {
+	s.tracer = tracer
+	s.capture = capture
+}
+
 // initializeProviders sets up the AI providers with secrets from config.
 func (s *Supervisor) initializeProviders() error {
 	if len(s.Config.Providers) > 0 {
@@ -184,6 +208,9 @@ func (s *Supervisor) Generate(ctx context.Context, providerID, model string, par
 	}
 
 	logger.Infow("proxying generation request", "provider", providerID, "model", params.Model)
+	if s.tracer != nil {
+		p = provider.WithTracing(p, string(s.Config.Providers[providerID].Type), s.tracer, s.capture)
+	}
 	return p.Generate(ctx, params)
 }
 
@@ -216,8 +243,14 @@ func (s *Supervisor) ExecuteTool(ctx context.Context, cmd string) (string, error
 
 // StartBackground starts a command in the background.
 func (s *Supervisor) StartBackground(cmd string) (string, error) {
+	return s.StartBackgroundContext(context.Background(), cmd)
+}
+
+// StartBackgroundContext starts a command in the background on behalf of
+// the caller identified by ctx.
+func (s *Supervisor) StartBackgroundContext(ctx context.Context, cmd string) (string, error) {
 	logger.Infow("supervisor starting background process", "command", cmd)
-	return s.execTool.StartBackground(cmd)
+	return s.execTool.StartBackgroundContext(ctx, cmd)
 }
 
 // GetBackgroundStatus returns the status of a background process.
@@ -2,27 +2,40 @@ package toolchain
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/langoai/lango/internal/agent"
+	"github.com/langoai/lango/internal/observability/genai"
 )
 
 // WithTracing returns a middleware that wraps each tool invocation in an
-// OpenTelemetry span. The span records the tool name, parameter count,
-// and any error. It should be placed as the outermost middleware so that
-// blocked calls (by policy/approval) are also traced.
-func WithTracing(tracer trace.Tracer) Middleware {
+// "execute_tool" span following the OpenTelemetry GenAI conventions. The
+// span records the tool name, parameter count, a SHA-256 of the arguments
+// and any error; the arguments themselves are recorded, redacted, only when
+// content capture is enabled. It should be placed as the outermost
+// middleware so that blocked calls (by policy/approval) are also traced.
+func WithTracing(tracer trace.Tracer, capture genai.ContentCapture) Middleware {
 	return func(tool *agent.Tool, next agent.ToolHandler) agent.ToolHandler {
 		return func(ctx context.Context, params map[string]interface{}) (interface{}, error) {
-			ctx, span := tracer.Start(ctx, fmt.Sprintf("tool/%s", tool.Name),
-				trace.WithAttributes(
-					attribute.String("tool.name", tool.Name),
-					attribute.Int("tool.params_count", len(params)),
-				),
+			attrs := []attribute.KeyValue{
+				genai.AttrOperationName.String(genai.OpExecuteTool),
+				genai.AttrToolName.String(tool.Name),
+				genai.AttrToolArgumentsHash.String(genai.HashArguments(params)),
+				attribute.String("tool.name", tool.Name),
+				attribute.Int("tool.params_count", len(params)),
+			}
+			if capture.Enabled {
+				if data, err := json.Marshal(params); err == nil {
+					attrs = append(attrs, genai.AttrToolArguments.String(capture.Text(string(data))))
+				}
+			}
+
+			ctx, span := tracer.Start(genai.ParentContext(ctx), genai.OpExecuteTool+" "+tool.Name,
+				trace.WithAttributes(attrs...),
 			)
 			defer span.End()
 
@@ -0,0 +1,87 @@
+package toolchain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/langoai/lango/internal/observability/genai"
+)
+
+func spanAttr(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestWithTracing_ExecuteToolSpan(t *testing.T) {
+	t.Parallel()
+
+	rec := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer("test")
+
+	tool := makeTool("fs_read", func(_ context.Context, _ map[string]interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	wrapped := Chain(tool, WithTracing(tracer, genai.ContentCapture{}))
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	params := map[string]interface{}{"path": "/home/alice/secret.txt"}
+	_, err := wrapped.Handler(ctx, params)
+	require.NoError(t, err)
+	parent.End()
+
+	spans := rec.Ended()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "execute_tool fs_read", span.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, codes.Ok, span.Status().Code)
+
+	op, _ := spanAttr(span, genai.AttrOperationName)
+	assert.Equal(t, "execute_tool", op.AsString())
+	name, _ := spanAttr(span, genai.AttrToolName)
+	assert.Equal(t, "fs_read", name.AsString())
+	hash, _ := spanAttr(span, genai.AttrToolArgumentsHash)
+	assert.Equal(t, genai.HashArguments(params), hash.AsString())
+	_, ok := spanAttr(span, genai.AttrToolArguments)
+	assert.False(t, ok, "arguments must not be recorded without content capture")
+}
+
+func TestWithTracing_CaptureRedactsArguments(t *testing.T) {
+	t.Parallel()
+
+	rec := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer("test")
+
+	capture := genai.ContentCapture{
+		Enabled: true,
+		Redact:  func(s string) string { return strings.ReplaceAll(s, "alice@example.com", "[REDACTED]") },
+	}
+	tool := makeTool("send_mail", func(_ context.Context, _ map[string]interface{}) (interface{}, error) {
+		return nil, errors.New("smtp down")
+	})
+	wrapped := Chain(tool, WithTracing(tracer, capture))
+
+	_, err := wrapped.Handler(context.Background(), map[string]interface{}{"to": "alice@example.com"})
+	require.Error(t, err)
+
+	spans := rec.Ended()
+	require.Len(t, spans, 1)
+	args, ok := spanAttr(spans[0], genai.AttrToolArguments)
+	require.True(t, ok)
+	assert.Equal(t, `{"to":"[REDACTED]"}`, args.AsString())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "smtp down", spans[0].Status().Description)
+}
@@ -8,12 +8,14 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/creack/pty"
+	"github.com/langoai/lango/internal/ctxkeys"
 	"github.com/langoai/lango/internal/eventbus"
 	"github.com/langoai/lango/internal/logging"
 	sandboxos "github.com/langoai/lango/internal/sandbox/os"
@@ -28,14 +30,16 @@ type Config struct {
 	DefaultTimeout   time.Duration
 	AllowBackground  bool
 	WorkDir          string
-	EnvFilter        []string             // environment variables to exclude
-	EnvWhitelist     []string             // if set, ONLY these vars are allowed
-	Refs             *security.RefStore   // secret reference token resolver
-	OSIsolator       sandboxos.OSIsolator // OS-level sandbox (nil = disabled)
-	SandboxPolicy    sandboxos.Policy     // policy for sandboxed execution
-	FailClosed       bool                 // if true, reject execution when sandbox unavailable
-	ExcludedCommands []string             // command basenames that bypass the sandbox
-	Bus              *eventbus.Bus        // event bus for SandboxDecisionEvent (optional)
+	EnvFilter        []string                // environment variables to exclude
+	EnvWhitelist     []string                // if set, ONLY these vars are allowed
+	Refs             *security.RefStore      // secret reference token resolver
+	Secrets          security.SecretResolver // policy-checked resolver for SecretEnv (optional)
+	SecretEnv        map[string]string       // env var name -> secret name, injected through the secret policy
+	OSIsolator       sandboxos.OSIsolator    // OS-level sandbox (nil = disabled)
+	SandboxPolicy    sandboxos.Policy        // policy for sandboxed execution
+	FailClosed       bool                    // if true, reject execution when sandbox unavailable
+	ExcludedCommands []string                // command basenames that bypass the sandbox
+	Bus              *eventbus.Bus           // event bus for SandboxDecisionEvent (optional)
 }
 
 // Tool provides shell command execution
@@ -196,14 +200,62 @@ func excludedMatch(userCommand string, patterns []string) (matched, pattern stri
 	return "", ""
 }
 
+// SetSecrets attaches the secret reference store and the policy-checked
+// resolver used for SecretEnv. Wiring may call this after Tool construction
+// once the secrets store is available.
+func (t *Tool) SetSecrets(refs *security.RefStore, secrets security.SecretResolver) {
+	t.config.Refs = refs
+	t.config.Secrets = secrets
+}
+
+// secretAccessor identifies this exec invocation to the secret policy.
+func secretAccessor(ctx context.Context) security.SecretAccessor {
+	return security.SecretAccessor{
+		SessionKey: session.SessionKeyFromContext(ctx),
+		Agent:      ctxkeys.AgentNameFromContext(ctx),
+		Tool:       "exec",
+	}
+}
+
 // resolveRefs resolves any secret reference tokens in the command string.
 // Tokens like {{secret:name}} and {{decrypt:id}} are replaced with actual values
-// just before execution. The resolved command is never logged or returned to the agent.
-func (t *Tool) resolveRefs(command string) string {
+// just before execution. {{secret:...}} tokens are checked against each
+// secret's access policy; a denied token aborts the command.
+// The resolved command is never logged or returned to the agent.
+func (t *Tool) resolveRefs(ctx context.Context, command string) (string, error) {
 	if t.config.Refs == nil {
-		return command
+		return command, nil
 	}
-	return t.config.Refs.ResolveAll(command)
+	return t.config.Refs.ResolveAllFor(ctx, command, secretAccessor(ctx))
+}
+
+// buildEnv returns the filtered process environment plus any SecretEnv
+// variables. Secret-backed variables go through the same policy check as
+// {{secret:...}} tokens; a denied or expired secret aborts the command.
+func (t *Tool) buildEnv(ctx context.Context) ([]string, error) {
+	env := t.filterEnv(os.Environ())
+	if len(t.config.SecretEnv) == 0 {
+		return env, nil
+	}
+	if t.config.Secrets == nil {
+		return nil, fmt.Errorf("secret env configured but no secrets store available")
+	}
+
+	names := make([]string, 0, len(t.config.SecretEnv))
+	for k := range t.config.SecretEnv {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	acc := secretAccessor(ctx)
+	for _, envName := range names {
+		val, err := t.config.Secrets.ResolveSecret(ctx, t.config.SecretEnv[envName], acc)
+		if err != nil {
+			return nil, fmt.Errorf("secret env %s: %w", envName, err)
+		}
+		env = append(env, envName+"="+string(val))
+	}
+	return env, nil
 }
 
 // Run executes a command synchronously
@@ -217,10 +269,17 @@ func (t *Tool) Run(ctx context.Context, command string, timeout time.Duration) (
 
 	// Resolve secret reference tokens just before execution.
 	// The resolved command is never logged or sent back to the agent.
-	resolved := t.resolveRefs(command)
+	resolved, err := t.resolveRefs(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+	env, err := t.buildEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
 	cmd := exec.CommandContext(ctx, "sh", "-c", resolved)
 	cmd.Dir = t.config.WorkDir
-	cmd.Env = t.filterEnv(os.Environ())
+	cmd.Env = env
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -232,7 +291,7 @@ func (t *Tool) Run(ctx context.Context, command string, timeout time.Duration) (
 
 	logger.Infow("executing command", "command", command, "timeout", timeout)
 
-	err := cmd.Run()
+	err = cmd.Run()
 	sandboxos.CleanupProfileFile(cmd)
 
 	result := &Result{
@@ -266,10 +325,17 @@ func (t *Tool) RunWithPTY(ctx context.Context, command string, timeout time.Dura
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	resolved := t.resolveRefs(command)
+	resolved, err := t.resolveRefs(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+	env, err := t.buildEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
 	cmd := exec.CommandContext(ctx, "sh", "-c", resolved)
 	cmd.Dir = t.config.WorkDir
-	cmd.Env = t.filterEnv(os.Environ())
+	cmd.Env = env
 
 	if err := t.applySandbox(ctx, cmd, command); err != nil {
 		return nil, err
@@ -323,22 +389,36 @@ func (t *Tool) RunWithPTY(ctx context.Context, command string, timeout time.Dura
 
 // StartBackground starts a command in the background
 func (t *Tool) StartBackground(command string) (string, error) {
+	return t.StartBackgroundContext(context.Background(), command)
+}
+
+// StartBackgroundContext starts a command in the background. ctx identifies
+// the caller for secret policy checks and sandbox audit; it does not bound
+// the lifetime of the process.
+func (t *Tool) StartBackgroundContext(ctx context.Context, command string) (string, error) {
 	if !t.config.AllowBackground {
 		return "", fmt.Errorf("background processes not allowed")
 	}
 
 	id := fmt.Sprintf("bg-%d", time.Now().UnixNano())
 
-	resolved := t.resolveRefs(command)
+	resolved, err := t.resolveRefs(ctx, command)
+	if err != nil {
+		return "", err
+	}
+	env, err := t.buildEnv(ctx)
+	if err != nil {
+		return "", err
+	}
 	cmd := exec.Command("sh", "-c", resolved)
 	cmd.Dir = t.config.WorkDir
-	cmd.Env = t.filterEnv(os.Environ())
+	cmd.Env = env
 
 	output := &syncBuffer{}
 	cmd.Stdout = output
 	cmd.Stderr = output
 
-	if err := t.applySandbox(context.Background(), cmd, command); err != nil {
+	if err := t.applySandbox(ctx, cmd, command); err != nil {
 		return "", err
 	}
 
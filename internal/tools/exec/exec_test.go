@@ -8,6 +8,7 @@ import (
 	"time"
 
 	sandboxos "github.com/langoai/lango/internal/sandbox/os"
+	"github.com/langoai/lango/internal/security"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -398,3 +399,42 @@ func TestApplySandbox_ExcludedBypass(t *testing.T) {
 	assert.Equal(t, int32(0), iso.applied.Load(),
 		"isolator must NOT be applied for an excluded command")
 }
+
+// stubSecrets resolves secrets from a map and denies anything listed in deny.
+type stubSecrets struct {
+	values map[string]string
+	deny   map[string]bool
+}
+
+func (s stubSecrets) ResolveSecret(_ context.Context, name string, _ security.SecretAccessor) ([]byte, error) {
+	if s.deny[name] {
+		return nil, security.ErrSecretAccessDenied
+	}
+	return []byte(s.values[name]), nil
+}
+
+func TestRun_SecretEnv(t *testing.T) {
+	t.Parallel()
+
+	secrets := stubSecrets{
+		values: map[string]string{"gh": "ghp_test"},
+		deny:   map[string]bool{"prod-db": true},
+	}
+
+	tool := New(Config{
+		DefaultTimeout: 5 * time.Second,
+		Secrets:        secrets,
+		SecretEnv:      map[string]string{"GH_TOKEN": "gh"},
+	})
+	result, err := tool.Run(context.Background(), "printf %s \"$GH_TOKEN\"", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_test", result.Stdout)
+
+	denied := New(Config{
+		DefaultTimeout: 5 * time.Second,
+		Secrets:        secrets,
+		SecretEnv:      map[string]string{"DB_PASSWORD": "prod-db"},
+	})
+	_, err = denied.Run(context.Background(), "true", 0)
+	assert.ErrorIs(t, err, security.ErrSecretAccessDenied)
+}
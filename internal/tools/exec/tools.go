@@ -27,6 +27,12 @@ type Executor interface {
 	StopBackground(id string) error
 }
 
+// contextBackgroundStarter is implemented by executors that can attribute a
+// background command to the calling agent (for secret policy checks).
+type contextBackgroundStarter interface {
+	StartBackgroundContext(ctx context.Context, cmd string) (string, error)
+}
+
 // BuildTools creates exec agent tools backed by the given Executor.
 // guardFns are command guard functions invoked before execution; if any
 // returns a non-empty string the command is blocked.
@@ -88,6 +94,9 @@ func BuildTools(ex Executor, guardFns ...GuardFunc) []*agent.Tool {
 				if br := checkGuards(cmd); br != nil {
 					return br, nil
 				}
+				if bs, ok := ex.(contextBackgroundStarter); ok {
+					return bs.StartBackgroundContext(ctx, cmd)
+				}
 				return ex.StartBackground(cmd)
 			},
 		},
@@ -6,8 +6,10 @@ import (
 	"fmt"
 
 	"github.com/langoai/lango/internal/agent"
+	"github.com/langoai/lango/internal/ctxkeys"
 	"github.com/langoai/lango/internal/logging"
 	"github.com/langoai/lango/internal/security"
+	"github.com/langoai/lango/internal/session"
 )
 
 var logger = logging.SubsystemSugar("tool.secrets")
@@ -49,6 +51,8 @@ type SecretEntry struct {
 	CreatedAt   string `json:"createdAt"`
 	UpdatedAt   string `json:"updatedAt"`
 	AccessCount int    `json:"accessCount"`
+	Version     int    `json:"version"`
+	ExpiresAt   string `json:"expiresAt,omitempty"`
 }
 
 // ListResult is the result of the list operation.
@@ -85,7 +89,11 @@ func (t *Tool) Store(ctx context.Context, params map[string]interface{}) (interf
 
 // Get retrieves a secret and returns an opaque reference token.
 // The plaintext value is stored in the RefStore and resolved at execution time.
-// The agent never sees the actual secret value.
+// The agent never sees the actual secret value. Retrieval is checked against
+// the secret's agent allowlist, its tool allowlist (with this tool as
+// "secrets_get") and expiry, so a tool-restricted secret must list
+// secrets_get to be fetched here. The consuming tool is checked again when it
+// resolves the token.
 func (t *Tool) Get(ctx context.Context, params map[string]interface{}) (interface{}, error) {
 	var p GetParams
 	if err := mapToStruct(params, &p); err != nil {
@@ -98,7 +106,11 @@ func (t *Tool) Get(ctx context.Context, params map[string]interface{}) (interfac
 
 	logger.Infow("retrieving secret reference", "name", p.Name)
 
-	value, err := t.store.Get(ctx, p.Name)
+	value, err := t.store.ResolveSecret(ctx, p.Name, security.SecretAccessor{
+		SessionKey: session.SessionKeyFromContext(ctx),
+		Agent:      ctxkeys.AgentNameFromContext(ctx),
+		Tool:       "secrets_get",
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -133,6 +145,10 @@ func (t *Tool) List(ctx context.Context, params map[string]interface{}) (interfa
 			CreatedAt:   s.CreatedAt.Format("2006-01-02T15:04:05Z"),
 			UpdatedAt:   s.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 			AccessCount: s.AccessCount,
+			Version:     s.Version,
+		}
+		if s.ExpiresAt != nil {
+			entries[i].ExpiresAt = s.ExpiresAt.Format("2006-01-02T15:04:05Z")
 		}
 	}
 
@@ -125,6 +125,25 @@ func TestSecretsTool_Get(t *testing.T) {
 	})
 }
 
+func TestSecretsTool_Get_ToolPolicy(t *testing.T) {
+	t.Parallel()
+
+	tool, _ := newTestSecretsTool(t)
+	ctx := context.Background()
+
+	_, err := tool.Store(ctx, map[string]interface{}{"name": "deploy-key", "value": "k"})
+	require.NoError(t, err)
+
+	// A secret limited to exec cannot be issued by secrets_get.
+	require.NoError(t, tool.store.SetPolicy(ctx, "deploy-key", security.SecretPolicy{AllowedTools: []string{"exec"}}))
+	_, err = tool.Get(ctx, map[string]interface{}{"name": "deploy-key"})
+	assert.ErrorIs(t, err, security.ErrSecretAccessDenied)
+
+	require.NoError(t, tool.store.SetPolicy(ctx, "deploy-key", security.SecretPolicy{AllowedTools: []string{"secrets_get", "exec"}}))
+	_, err = tool.Get(ctx, map[string]interface{}{"name": "deploy-key"})
+	require.NoError(t, err)
+}
+
 func TestSecretsTool_List(t *testing.T) {
 	t.Parallel()
 
@@ -7,12 +7,13 @@ const (
 	ChannelTelegram ChannelType = "telegram"
 	ChannelDiscord  ChannelType = "discord"
 	ChannelSlack    ChannelType = "slack"
+	ChannelEmail    ChannelType = "email"
 )
 
 // Valid reports whether c is a known channel type.
 func (c ChannelType) Valid() bool {
 	switch c {
-	case ChannelTelegram, ChannelDiscord, ChannelSlack:
+	case ChannelTelegram, ChannelDiscord, ChannelSlack, ChannelEmail:
 		return true
 	}
 	return false
@@ -20,5 +21,5 @@ func (c ChannelType) Valid() bool {
 
 // Values returns all known channel types.
 func (c ChannelType) Values() []ChannelType {
-	return []ChannelType{ChannelTelegram, ChannelDiscord, ChannelSlack}
+	return []ChannelType{ChannelTelegram, ChannelDiscord, ChannelSlack, ChannelEmail}
 }
@@ -37,8 +37,21 @@ const (
 	ChainBase            ChainID = 8453
 	ChainBaseSepolia     ChainID = 84532
 	ChainSepolia         ChainID = 11155111
+	ChainLocalDev        ChainID = 1337  // geth dev mode and simulated backends
+	ChainAnvil           ChainID = 31337 // Anvil and Hardhat
 )
 
+// IsDevNetwork reports whether chainID is a local development chain, where
+// test-only cryptographic setups are acceptable. Public testnets are not:
+// their peers are real, so forgeable setups must not be used there either.
+func IsDevNetwork(chainID int64) bool {
+	switch ChainID(chainID) {
+	case ChainLocalDev, ChainAnvil:
+		return true
+	}
+	return false
+}
+
 // CurrencyUSDC is the ticker symbol for the USDC stablecoin used across the
 // payment system.
 // Deprecated: Use finance.CurrencyUSDC instead.
@@ -42,6 +42,25 @@ func TestChainIDConstants(t *testing.T) {
 	assert.Equal(t, ChainID(11155111), ChainSepolia)
 }
 
+func TestIsDevNetwork(t *testing.T) {
+	tests := []struct {
+		give int64
+		want bool
+	}{
+		{give: int64(ChainEthereumMainnet), want: false},
+		{give: int64(ChainBase), want: false},
+		{give: int64(ChainBaseSepolia), want: false},
+		{give: int64(ChainSepolia), want: false},
+		{give: int64(ChainLocalDev), want: true},
+		{give: int64(ChainAnvil), want: true},
+		{give: 137, want: false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, IsDevNetwork(tt.give), "chain %d", tt.give)
+	}
+}
+
 func TestCurrencyUSDC(t *testing.T) {
 	assert.Equal(t, "USDC", CurrencyUSDC)
 }
@@ -0,0 +1,412 @@
+package x402
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	x402sdk "github.com/coinbase/x402/go"
+	"github.com/coinbase/x402/go/mechanisms/evm"
+	"github.com/coinbase/x402/go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/langoai/lango/internal/economy/pricing"
+	"github.com/langoai/lango/internal/ent/paymenttx"
+	"github.com/langoai/lango/internal/p2p/settlement"
+	"github.com/langoai/lango/internal/payment/eip3009"
+)
+
+// X402 V2 HTTP headers. Header values are base64-encoded JSON.
+const (
+	HeaderPaymentRequired  = "PAYMENT-REQUIRED"
+	HeaderPaymentSignature = "PAYMENT-SIGNATURE"
+	HeaderPaymentResponse  = "PAYMENT-RESPONSE"
+)
+
+const (
+	// SchemeExact is the only payment scheme the seller accepts.
+	SchemeExact = "exact"
+
+	protocolVersion     = 2
+	defaultMaxTimeout   = 5 * time.Minute
+	defaultWriteTimeout = 15 * time.Second
+	defaultMinValidity  = 30 * time.Second
+
+	// eip3009TokenName and eip3009TokenVersion are advertised in the
+	// requirements so buyers sign the same EIP-712 domain that
+	// eip3009.Verify checks.
+	eip3009TokenName    = "USD Coin"
+	eip3009TokenVersion = "2"
+)
+
+// Quoter prices a paid route. *pricing.Engine satisfies it.
+type Quoter interface {
+	Quote(ctx context.Context, toolName, peerDID string) (*pricing.Quote, error)
+}
+
+// Settler submits a verified authorization on-chain and records the
+// receipt. *settlement.Service satisfies it.
+type Settler interface {
+	Settle(ctx context.Context, auth *eip3009.Authorization, rec settlement.Record) (string, error)
+}
+
+// Route is a paid HTTP endpoint. Its Path doubles as the pricing key, so
+// economy pricing rules with a matching toolPattern apply to it.
+type Route struct {
+	// Method restricts the route to one HTTP method (empty matches any).
+	Method string
+	// Path is an exact request path, or a prefix when it ends in "*".
+	Path        string
+	Description string
+}
+
+func (r Route) matches(req *http.Request) bool {
+	if r.Method != "" && !strings.EqualFold(r.Method, req.Method) {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(r.Path, "*"); ok {
+		return strings.HasPrefix(req.URL.Path, prefix)
+	}
+	return req.URL.Path == r.Path
+}
+
+// SellerConfig holds construction parameters for a Seller.
+type SellerConfig struct {
+	ChainID int64
+	// Asset is the USDC contract buyers authorize transfers on.
+	Asset common.Address
+	// PayTo receives the payments; it must be the settlement wallet's
+	// counterparty in each authorization.
+	PayTo common.Address
+	// MaxTimeout is how long a payment authorization must remain valid
+	// (default: 5m).
+	MaxTimeout time.Duration
+	// WriteTimeout is the time the handler gets to write its response once
+	// the payment has settled (default: 15s, the gateway's write timeout).
+	WriteTimeout time.Duration
+	// MinValidity is how long an authorization must still be valid when it
+	// arrives, so the transfer is mined before validBefore (default: 30s).
+	MinValidity time.Duration
+	Routes       []Route
+	Quoter       Quoter
+	Settler      Settler
+	Logger       *zap.SugaredLogger
+}
+
+// Seller is server-side X402 V2 middleware. Requests to paid routes without
+// a payment receive 402 with payment requirements derived from a pricing
+// quote; requests carrying a PAYMENT-SIGNATURE header have their EIP-3009
+// authorization verified and settled on-chain before the handler runs.
+type Seller struct {
+	cfg     SellerConfig
+	network string
+
+	mu sync.Mutex
+	// nonces holds accepted authorizations, keyed to their validBefore, so
+	// a payment cannot be replayed while still valid.
+	nonces map[authKey]time.Time
+}
+
+// authKey identifies an EIP-3009 authorization. Nonces are scoped to the
+// authorizer, so two payers may legitimately use the same nonce.
+type authKey struct {
+	from  common.Address
+	nonce [32]byte
+}
+
+// NewSeller creates an X402 seller.
+func NewSeller(cfg SellerConfig) *Seller {
+	if cfg.MaxTimeout <= 0 {
+		cfg.MaxTimeout = defaultMaxTimeout
+	}
+	if cfg.WriteTimeout <= 0 {
+		cfg.WriteTimeout = defaultWriteTimeout
+	}
+	if cfg.MinValidity <= 0 {
+		cfg.MinValidity = defaultMinValidity
+	}
+	return &Seller{
+		cfg:     cfg,
+		network: CAIP2Network(cfg.ChainID),
+		nonces:  make(map[authKey]time.Time),
+	}
+}
+
+// Middleware wraps next with X402 payment enforcement for the configured
+// routes. Requests to other routes pass through unchanged.
+func (s *Seller) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, ok := s.match(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		quote, err := s.cfg.Quoter.Quote(r.Context(), route.Path, "")
+		if err != nil {
+			s.cfg.Logger.Warnw("x402 quote failed", "path", route.Path, "error", err)
+			http.Error(w, "pricing unavailable", http.StatusInternalServerError)
+			return
+		}
+		if quote.IsFree {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		required := s.requirements(quote.FinalPrice)
+		resource := &types.ResourceInfo{URL: resourceURL(r), Description: route.Description}
+
+		header := r.Header.Get(HeaderPaymentSignature)
+		if header == "" {
+			s.paymentRequired(w, required, resource, "")
+			return
+		}
+
+		auth, err := s.verify(header, required)
+		if err != nil {
+			s.paymentRequired(w, required, resource, err.Error())
+			return
+		}
+
+		// Settle before serving so the handler only runs for paid requests.
+		// Waiting for confirmation can outlast the server's write timeout,
+		// which would charge the buyer and drop the response, so the write
+		// deadline is lifted while settling and re-armed for the handler.
+		// The settlement context outlives a client disconnect so a submitted
+		// transaction is still tracked to confirmation.
+		rc := http.NewResponseController(w)
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+			s.cfg.Logger.Debugw("x402 write deadline not adjustable", "error", err)
+		}
+		txHash, err := s.cfg.Settler.Settle(context.WithoutCancel(r.Context()), auth, settlement.Record{
+			Method:  paymenttx.PaymentMethodX402V2,
+			Purpose: fmt.Sprintf("x402 sale: %s %s", r.Method, r.URL.Path),
+			X402URL: resource.URL,
+		})
+		if err != nil {
+			if txHash == "" {
+				s.releaseNonce(auth)
+			}
+			s.cfg.Logger.Warnw("x402 settlement failed",
+				"path", r.URL.Path, "payer", auth.From.Hex(), "error", err)
+			s.paymentRequired(w, required, resource, "settlement failed: "+err.Error())
+			return
+		}
+
+		s.cfg.Logger.Infow("x402 payment settled",
+			"path", r.URL.Path, "payer", auth.From.Hex(), "amount", auth.Value.String(), "txHash", txHash)
+
+		receipt, _ := json.Marshal(x402sdk.SettleResponse{
+			Success:     true,
+			Payer:       auth.From.Hex(),
+			Transaction: txHash,
+			Network:     x402sdk.Network(s.network),
+		})
+		w.Header().Set(HeaderPaymentResponse, base64.StdEncoding.EncodeToString(receipt))
+		_ = rc.SetWriteDeadline(time.Now().Add(s.cfg.WriteTimeout))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// match returns the first configured route matching r.
+func (s *Seller) match(r *http.Request) (Route, bool) {
+	for _, route := range s.cfg.Routes {
+		if route.matches(r) {
+			return route, true
+		}
+	}
+	return Route{}, false
+}
+
+// requirements builds the exact-scheme payment requirements for amount
+// (in smallest USDC units).
+func (s *Seller) requirements(amount *big.Int) types.PaymentRequirements {
+	return types.PaymentRequirements{
+		Scheme:            SchemeExact,
+		Network:           s.network,
+		Asset:             s.cfg.Asset.Hex(),
+		Amount:            amount.String(),
+		PayTo:             s.cfg.PayTo.Hex(),
+		MaxTimeoutSeconds: int(s.cfg.MaxTimeout / time.Second),
+		Extra: map[string]interface{}{
+			"name":    eip3009TokenName,
+			"version": eip3009TokenVersion,
+		},
+	}
+}
+
+// paymentRequired writes a 402 response carrying the requirements in both
+// the PAYMENT-REQUIRED header and the JSON body.
+func (s *Seller) paymentRequired(w http.ResponseWriter, required types.PaymentRequirements, resource *types.ResourceInfo, reason string) {
+	body, err := json.Marshal(types.PaymentRequired{
+		X402Version: protocolVersion,
+		Error:       reason,
+		Resource:    resource,
+		Accepts:     []types.PaymentRequirements{required},
+	})
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(HeaderPaymentRequired, base64.StdEncoding.EncodeToString(body))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusPaymentRequired)
+	_, _ = w.Write(body)
+}
+
+// verify decodes a PAYMENT-SIGNATURE header and checks its EIP-3009
+// authorization against the requirements. On success the authorization's
+// nonce is reserved against replay.
+func (s *Seller) verify(header string, required types.PaymentRequirements) (*eip3009.Authorization, error) {
+	raw, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return nil, fmt.Errorf("decode payment header: %w", err)
+	}
+	var payload types.PaymentPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, fmt.Errorf("parse payment payload: %w", err)
+	}
+
+	if payload.X402Version != protocolVersion {
+		return nil, fmt.Errorf("unsupported x402 version %d", payload.X402Version)
+	}
+	accepted := payload.Accepted
+	if accepted.Scheme != SchemeExact || accepted.Network != s.network {
+		return nil, fmt.Errorf("unsupported scheme %q on network %q", accepted.Scheme, accepted.Network)
+	}
+	if !strings.EqualFold(accepted.Asset, required.Asset) {
+		return nil, fmt.Errorf("asset mismatch: got %s, want %s", accepted.Asset, required.Asset)
+	}
+
+	auth, err := parseExactPayload(payload.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if auth.To != s.cfg.PayTo {
+		return nil, fmt.Errorf("recipient mismatch: got %s, want %s", auth.To.Hex(), s.cfg.PayTo.Hex())
+	}
+	want, _ := new(big.Int).SetString(required.Amount, 10)
+	if auth.Value.Cmp(want) < 0 {
+		return nil, fmt.Errorf("insufficient payment: got %s, need %s", auth.Value, want)
+	}
+	now := time.Now().Unix()
+	if auth.ValidAfter.Int64() > now {
+		return nil, fmt.Errorf("payment authorization not yet valid")
+	}
+	if auth.ValidBefore.Int64() <= now {
+		return nil, fmt.Errorf("payment authorization expired")
+	}
+	if auth.ValidBefore.Int64() < now+int64(s.cfg.MinValidity/time.Second) {
+		// The transfer would revert on-chain after the resource was served.
+		return nil, fmt.Errorf("payment authorization expires too soon to settle (need at least %s)", s.cfg.MinValidity)
+	}
+	if err := eip3009.Verify(auth, auth.From, s.cfg.ChainID, s.cfg.Asset); err != nil {
+		return nil, fmt.Errorf("verify payment signature: %w", err)
+	}
+
+	if !s.reserveNonce(auth, time.Unix(auth.ValidBefore.Int64(), 0)) {
+		return nil, fmt.Errorf("payment authorization already used")
+	}
+	return auth, nil
+}
+
+// reserveNonce records the authorization's nonce as used by its payer until
+// expiry. It returns false when the nonce is already reserved for that payer.
+func (s *Seller) reserveNonce(auth *eip3009.Authorization, expiry time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for n, exp := range s.nonces {
+		if now.After(exp) {
+			delete(s.nonces, n)
+		}
+	}
+	key := authKey{from: auth.From, nonce: auth.Nonce}
+	if _, used := s.nonces[key]; used {
+		return false
+	}
+	s.nonces[key] = expiry
+	return true
+}
+
+// releaseNonce frees a nonce whose settlement never reached the chain so the
+// buyer may retry with the same authorization.
+func (s *Seller) releaseNonce(auth *eip3009.Authorization) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.nonces, authKey{from: auth.From, nonce: auth.Nonce})
+}
+
+// parseExactPayload converts an exact-scheme EVM payload into a signed
+// EIP-3009 authorization. Only 65-byte EOA signatures are supported.
+func parseExactPayload(m map[string]interface{}) (*eip3009.Authorization, error) {
+	p, err := evm.PayloadFromMap(m)
+	if err != nil {
+		return nil, fmt.Errorf("parse exact payload: %w", err)
+	}
+	a := p.Authorization
+
+	if !common.IsHexAddress(a.From) || !common.IsHexAddress(a.To) {
+		return nil, fmt.Errorf("invalid authorization address")
+	}
+	auth := &eip3009.Authorization{
+		From: common.HexToAddress(a.From),
+		To:   common.HexToAddress(a.To),
+	}
+
+	ints := []struct {
+		name string
+		val  string
+		dst  **big.Int
+	}{
+		{"value", a.Value, &auth.Value},
+		{"validAfter", a.ValidAfter, &auth.ValidAfter},
+		{"validBefore", a.ValidBefore, &auth.ValidBefore},
+	}
+	for _, f := range ints {
+		n, ok := new(big.Int).SetString(f.val, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid authorization %s %q", f.name, f.val)
+		}
+		*f.dst = n
+	}
+
+	nonce := common.FromHex(a.Nonce)
+	if len(nonce) != 32 {
+		return nil, fmt.Errorf("invalid authorization nonce length %d", len(nonce))
+	}
+	copy(auth.Nonce[:], nonce)
+
+	sig := common.FromHex(p.Signature)
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("unsupported signature length %d (want 65-byte EOA signature)", len(sig))
+	}
+	copy(auth.R[:], sig[:32])
+	copy(auth.S[:], sig[32:64])
+	auth.V = sig[64]
+	if auth.V < 27 {
+		auth.V += 27
+	}
+	return auth, nil
+}
+
+// resourceURL reconstructs the absolute URL of r for the payment resource.
+func resourceURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
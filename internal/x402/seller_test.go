@@ -0,0 +1,349 @@
+package x402
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	x402sdk "github.com/coinbase/x402/go"
+	x402http "github.com/coinbase/x402/go/http"
+	evmclient "github.com/coinbase/x402/go/mechanisms/evm/exact/client"
+	evmsigners "github.com/coinbase/x402/go/signers/evm"
+	"github.com/coinbase/x402/go/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/langoai/lango/internal/config"
+	"github.com/langoai/lango/internal/economy/pricing"
+	"github.com/langoai/lango/internal/ent/enttest"
+	"github.com/langoai/lango/internal/ent/paymenttx"
+	"github.com/langoai/lango/internal/p2p/settlement"
+	"github.com/langoai/lango/internal/payment/eip3009"
+)
+
+// simChainID is the chain ID of go-ethereum's simulated backend.
+const simChainID = 1337
+
+var testUSDC = common.HexToAddress("0x00000000000000000000000000000000000005dc")
+
+// keyWallet implements wallet.WalletProvider with a raw ECDSA key.
+type keyWallet struct{ key *ecdsa.PrivateKey }
+
+func (w keyWallet) Address(context.Context) (string, error) {
+	return crypto.PubkeyToAddress(w.key.PublicKey).Hex(), nil
+}
+
+func (w keyWallet) Balance(context.Context) (*big.Int, error) { return new(big.Int), nil }
+
+func (w keyWallet) SignTransaction(_ context.Context, hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, w.key)
+}
+
+func (w keyWallet) SignMessage(_ context.Context, msg []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(msg), w.key)
+}
+
+func (w keyWallet) PublicKey(context.Context) ([]byte, error) {
+	return crypto.CompressPubkey(&w.key.PublicKey), nil
+}
+
+func newTestQuoter(t *testing.T, prices map[string]string) *pricing.Engine {
+	t.Helper()
+	engine, err := pricing.New(config.DynamicPricingConfig{})
+	require.NoError(t, err)
+	for path, price := range prices {
+		require.NoError(t, engine.SetBasePriceFromString(path, price))
+	}
+	return engine
+}
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte("report"))
+})
+
+func TestSeller_SettlesOnSimulatedChain(t *testing.T) {
+	sellerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	buyerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sellerAddr := crypto.PubkeyToAddress(sellerKey.PublicKey)
+
+	// The token is a stub contract that accepts any call, so settlement
+	// exercises tx building, signing, submission and receipts without a
+	// full USDC implementation.
+	backend := simulated.NewBackend(ethtypes.GenesisAlloc{
+		sellerAddr: {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))},
+		testUSDC:   {Code: []byte{0x00}, Balance: new(big.Int)},
+	})
+	t.Cleanup(func() { backend.Close() })
+	stopMining := make(chan struct{})
+	t.Cleanup(func() { close(stopMining) })
+	go func() {
+		ticker := time.NewTicker(50 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopMining:
+				return
+			case <-ticker.C:
+				backend.Commit()
+			}
+		}
+	}()
+
+	db := enttest.Open(t, "sqlite3", "file:x402seller?mode=memory&_fk=1")
+	t.Cleanup(func() { db.Close() })
+
+	seller := NewSeller(SellerConfig{
+		ChainID: simChainID,
+		Asset:   testUSDC,
+		PayTo:   sellerAddr,
+		Routes:  []Route{{Method: http.MethodGet, Path: "/api/reports/*", Description: "Daily report"}},
+		Quoter:  newTestQuoter(t, map[string]string{"/api/reports/*": "0.05"}),
+		Settler: settlement.New(settlement.Config{
+			Wallet:         keyWallet{sellerKey},
+			RPCClient:      backend.Client(),
+			DBClient:       db,
+			ChainID:        simChainID,
+			USDCAddr:       testUSDC,
+			ReceiptTimeout: 10 * time.Second,
+			Logger:         zap.NewNop().Sugar(),
+		}),
+		Logger: zap.NewNop().Sugar(),
+	})
+	ts := httptest.NewServer(seller.Middleware(okHandler))
+	t.Cleanup(ts.Close)
+
+	// Unpaid routes pass through.
+	resp, err := http.Get(ts.URL + "/health")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Paid routes answer 402 with requirements from the pricing quote.
+	resp, err = http.Get(ts.URL + "/api/reports/today")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusPaymentRequired, resp.StatusCode)
+	raw, err := base64.StdEncoding.DecodeString(resp.Header.Get(HeaderPaymentRequired))
+	require.NoError(t, err)
+	var required types.PaymentRequired
+	require.NoError(t, json.Unmarshal(raw, &required))
+	require.Len(t, required.Accepts, 1)
+	assert.Equal(t, "50000", required.Accepts[0].Amount)
+	assert.Equal(t, "eip155:1337", required.Accepts[0].Network)
+	assert.Equal(t, sellerAddr.Hex(), required.Accepts[0].PayTo)
+
+	// An X402 SDK buyer pays and receives the resource with a receipt.
+	signer, err := evmsigners.NewClientSignerFromPrivateKey(hex.EncodeToString(crypto.FromECDSA(buyerKey)))
+	require.NoError(t, err)
+	buyer := x402sdk.Newx402Client()
+	buyer.Register(x402sdk.Network(CAIP2Network(simChainID)), evmclient.NewExactEvmScheme(signer))
+	payingClient := x402http.WrapHTTPClientWithPayment(&http.Client{}, x402http.Newx402HTTPClient(buyer))
+
+	resp, err = payingClient.Get(ts.URL + "/api/reports/today")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	raw, err = base64.StdEncoding.DecodeString(resp.Header.Get(HeaderPaymentResponse))
+	require.NoError(t, err)
+	var receipt x402sdk.SettleResponse
+	require.NoError(t, json.Unmarshal(raw, &receipt))
+	assert.True(t, receipt.Success)
+	assert.Equal(t, crypto.PubkeyToAddress(buyerKey.PublicKey).Hex(), receipt.Payer)
+
+	onChain, err := backend.Client().TransactionReceipt(context.Background(), common.HexToHash(receipt.Transaction))
+	require.NoError(t, err)
+	assert.Equal(t, ethtypes.ReceiptStatusSuccessful, onChain.Status)
+
+	rec, err := db.PaymentTx.Query().Only(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, paymenttx.StatusConfirmed, rec.Status)
+	assert.Equal(t, paymenttx.PaymentMethodX402V2, rec.PaymentMethod)
+	assert.Equal(t, "50000", rec.Amount)
+	assert.Equal(t, receipt.Transaction, rec.TxHash)
+	assert.Equal(t, ts.URL+"/api/reports/today", rec.X402URL)
+}
+
+// recordingSettler accepts every authorization without touching a chain,
+// after waiting delay to stand in for on-chain confirmation.
+type recordingSettler struct {
+	settled []*eip3009.Authorization
+	delay   time.Duration
+}
+
+func (s *recordingSettler) Settle(_ context.Context, auth *eip3009.Authorization, _ settlement.Record) (string, error) {
+	time.Sleep(s.delay)
+	s.settled = append(s.settled, auth)
+	return "0xabc", nil
+}
+
+func TestSeller_Verify(t *testing.T) {
+	buyerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	buyer := keyWallet{buyerKey}
+	buyerAddr := crypto.PubkeyToAddress(buyerKey.PublicKey)
+	payTo := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	quoter := newTestQuoter(t, map[string]string{"/a2a/*": "0.10"})
+	quoter.AddRule(pricing.PricingRule{
+		Name:      "a2a-surge",
+		Enabled:   true,
+		Condition: pricing.RuleCondition{ToolPattern: "/a2a/*"},
+		Modifier:  pricing.PriceModifier{Type: pricing.ModifierSurge, Factor: 2},
+	})
+	settler := &recordingSettler{}
+	seller := NewSeller(SellerConfig{
+		ChainID: simChainID,
+		Asset:   testUSDC,
+		PayTo:   payTo,
+		Routes:  []Route{{Path: "/a2a/*"}},
+		Quoter:  quoter,
+		Settler: settler,
+		Logger:  zap.NewNop().Sugar(),
+	})
+	handler := seller.Middleware(okHandler)
+
+	// sign builds a PAYMENT-SIGNATURE header for an authorization, letting
+	// each case tamper with it.
+	sign := func(to common.Address, value int64, deadline time.Time, mutate func(*eip3009.Authorization)) string {
+		unsigned := eip3009.NewUnsigned(buyerAddr, to, big.NewInt(value), deadline)
+		unsigned.ValidAfter.Sub(unsigned.ValidAfter, big.NewInt(30))
+		auth, err := eip3009.Sign(context.Background(), buyer, unsigned, simChainID, testUSDC)
+		require.NoError(t, err)
+		if mutate != nil {
+			mutate(auth)
+		}
+		sig := append(append(auth.R[:], auth.S[:]...), auth.V)
+		payload := types.PaymentPayload{
+			X402Version: 2,
+			Accepted:    types.PaymentRequirements{Scheme: SchemeExact, Network: CAIP2Network(simChainID), Asset: testUSDC.Hex()},
+			Payload: map[string]interface{}{
+				"signature": "0x" + hex.EncodeToString(sig),
+				"authorization": map[string]interface{}{
+					"from":        auth.From.Hex(),
+					"to":          auth.To.Hex(),
+					"value":       auth.Value.String(),
+					"validAfter":  auth.ValidAfter.String(),
+					"validBefore": auth.ValidBefore.String(),
+					"nonce":       "0x" + hex.EncodeToString(auth.Nonce[:]),
+				},
+			},
+		}
+		data, err := json.Marshal(payload)
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(data)
+	}
+
+	do := func(header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/a2a/tasks", nil)
+		if header != "" {
+			req.Header.Set(HeaderPaymentSignature, header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	errorOf := func(rec *httptest.ResponseRecorder) string {
+		var body types.PaymentRequired
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body.Error
+	}
+
+	// Pricing rules apply: 0.10 USDC base with a 2x surge.
+	rec := do("")
+	require.Equal(t, http.StatusPaymentRequired, rec.Code)
+	var required types.PaymentRequired
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &required))
+	assert.Equal(t, "200000", required.Accepts[0].Amount)
+
+	future := time.Now().Add(time.Minute)
+	tests := []struct {
+		give    string
+		header  string
+		wantErr string
+	}{
+		{give: "garbage", header: "not base64!", wantErr: "decode payment header"},
+		{give: "underpaid", header: sign(payTo, 100000, future, nil), wantErr: "insufficient payment"},
+		{give: "wrong recipient", header: sign(buyerAddr, 200000, future, nil), wantErr: "recipient mismatch"},
+		{give: "expired", header: sign(payTo, 200000, time.Now().Add(-time.Second), nil), wantErr: "expired"},
+		{give: "expires during settlement", header: sign(payTo, 200000, time.Now().Add(5*time.Second), nil), wantErr: "expires too soon"},
+		{
+			give:    "tampered",
+			header:  sign(payTo, 200000, future, func(a *eip3009.Authorization) { a.Value = big.NewInt(300000) }),
+			wantErr: "verify payment signature",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			rec := do(tt.header)
+			require.Equal(t, http.StatusPaymentRequired, rec.Code)
+			assert.Contains(t, errorOf(rec), tt.wantErr)
+		})
+	}
+	assert.Empty(t, settler.settled)
+
+	// A valid payment is settled once; replaying it is rejected.
+	valid := sign(payTo, 200000, future, nil)
+	rec = do(valid)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "report", rec.Body.String())
+	require.Len(t, settler.settled, 1)
+
+	rec = do(valid)
+	require.Equal(t, http.StatusPaymentRequired, rec.Code)
+	assert.Contains(t, errorOf(rec), "already used")
+	assert.Len(t, settler.settled, 1)
+
+	// Settlement slower than the server's write timeout still delivers the
+	// paid response.
+	settler.delay = 300 * time.Millisecond
+	ts := httptest.NewUnstartedServer(handler)
+	ts.Config.WriteTimeout = 100 * time.Millisecond
+	ts.Start()
+	defer ts.Close()
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/a2a/tasks", nil)
+	require.NoError(t, err)
+	req.Header.Set(HeaderPaymentSignature, sign(payTo, 200000, future, nil))
+	resp, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "report", string(body))
+	assert.Len(t, settler.settled, 2)
+}
+
+func TestSeller_NoncesScopedToPayer(t *testing.T) {
+	t.Parallel()
+
+	seller := NewSeller(SellerConfig{ChainID: simChainID, Logger: zap.NewNop().Sugar()})
+	expiry := time.Now().Add(time.Minute)
+	nonce := [32]byte{1, 2, 3}
+	alice := &eip3009.Authorization{From: common.HexToAddress("0xa1"), Nonce: nonce}
+	bob := &eip3009.Authorization{From: common.HexToAddress("0xb0b"), Nonce: nonce}
+
+	assert.True(t, seller.reserveNonce(alice, expiry))
+	assert.False(t, seller.reserveNonce(alice, expiry), "replay by the same payer")
+	assert.True(t, seller.reserveNonce(bob, expiry), "another payer's nonce is independent")
+
+	seller.releaseNonce(alice)
+	assert.True(t, seller.reserveNonce(alice, expiry))
+	assert.False(t, seller.reserveNonce(bob, expiry))
+}